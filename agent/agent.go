@@ -1,6 +1,7 @@
 package agent
 
 import (
+	"context"
 	"os"
 	"os/signal"
 	"syscall"
@@ -13,14 +14,44 @@ import (
 
 var logger = logging.New("agent")
 
-// Wait waits a signal or shutdowns the db.
-func Wait(db *db.DB) error {
+// ShutdownTimeout is the deadline for flushing the pending flows on shutdown.
+var ShutdownTimeout = 10 * time.Second
+
+// SignalContext returns a context, which is canceled when the process
+// receives SIGTERM or SIGINT. A second signal kills the process
+// immediately.
+func SignalContext() (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(context.Background())
 	sigch := make(chan os.Signal, 1)
 	signal.Notify(sigch, syscall.SIGTERM, syscall.SIGINT)
-	sig := <-sigch
-	logger.Infof("Received %s gracefully shutdown...", sig)
+	go func() {
+		select {
+		case sig := <-sigch:
+			logger.Infof("Received %s gracefully shutdown...", sig)
+			cancel()
+		case <-ctx.Done():
+		}
+		signal.Stop(sigch)
+	}()
+	return ctx, cancel
+}
+
+// Shutdown waits drain to write the pending flows, and then closes the db.
+// It gives up after ShutdownTimeout so that a stuck write does not block the
+// exit; the transaction in flight is rolled back by postgres when the
+// process exits.
+func Shutdown(db db.Store, drain func()) error {
+	done := make(chan struct{})
+	go func() {
+		drain()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(ShutdownTimeout):
+		return xerrors.Errorf("gave up flushing the pending flows after %s", ShutdownTimeout)
+	}
 
-	time.Sleep(3 * time.Second)
 	logger.Infof("--> Closing db connection...")
 	if err := db.Shutdown(); err != nil {
 		return xerrors.Errorf("db close error: %w", err)
@@ -0,0 +1,35 @@
+package agent
+
+import (
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestSignalContext(t *testing.T) {
+	ctx, cancel := SignalContext()
+	defer cancel()
+
+	if err := syscall.Kill(syscall.Getpid(), syscall.SIGTERM); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-ctx.Done():
+	case <-time.After(3 * time.Second):
+		t.Error("the context should be canceled by SIGTERM")
+	}
+}
+
+func TestShutdown_timeout(t *testing.T) {
+	timeout := ShutdownTimeout
+	ShutdownTimeout = 10 * time.Millisecond
+	defer func() { ShutdownTimeout = timeout }()
+
+	stuck := make(chan struct{})
+	defer close(stuck)
+
+	// the db is not closed while drain is in flight.
+	if err := Shutdown(nil, func() { <-stuck }); err == nil {
+		t.Error("Shutdown() should return an error when drain does not finish")
+	}
+}
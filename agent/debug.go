@@ -0,0 +1,53 @@
+package agent
+
+import (
+	"expvar"
+	"net"
+	"net/http"
+	"net/http/pprof"
+
+	"golang.org/x/xerrors"
+)
+
+// Counters of the agent exposed at /debug/vars.
+var (
+	ScansTotal       = expvar.NewInt("shawk.scans_total")
+	ScanErrorsTotal  = expvar.NewInt("shawk.scan_errors_total")
+	FlowsTotal       = expvar.NewInt("shawk.flows_total")
+	FlushErrorsTotal = expvar.NewInt("shawk.flush_errors_total")
+)
+
+func debugHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	mux.Handle("/debug/vars", expvar.Handler())
+	return mux
+}
+
+// ServeDebug serves net/http/pprof and expvar on addr in background.
+// addr must be a loopback address because the profiles expose the internals
+// of the process.
+func ServeDebug(addr string) error {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return xerrors.Errorf("invalid debug address %q: %w", addr, err)
+	}
+	if ip := net.ParseIP(host); host != "localhost" && (ip == nil || !ip.IsLoopback()) {
+		return xerrors.Errorf("debug address %q must be a loopback address", addr)
+	}
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return xerrors.Errorf("could not listen %s: %w", addr, err)
+	}
+	logger.Infof("Serving debug endpoints on http://%s/debug/pprof/", ln.Addr())
+	go func() {
+		if err := http.Serve(ln, debugHandler()); err != nil {
+			logger.Errorf("debug server error: %v", err)
+		}
+	}()
+	return nil
+}
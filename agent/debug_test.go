@@ -0,0 +1,45 @@
+package agent
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestServeDebug_notLoopback(t *testing.T) {
+	for _, addr := range []string{"0.0.0.0:6060", ":6060", "192.0.2.1:6060", "localhost"} {
+		if err := ServeDebug(addr); err == nil {
+			t.Errorf("ServeDebug(%q) should return an error", addr)
+		}
+	}
+}
+
+func TestDebugHandler(t *testing.T) {
+	ts := httptest.NewServer(debugHandler())
+	defer ts.Close()
+
+	ScansTotal.Add(1)
+
+	resp, err := http.Get(ts.URL + "/debug/vars")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	defer resp.Body.Close()
+	var vars map[string]interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&vars); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if _, ok := vars["shawk.scans_total"]; !ok {
+		t.Error("/debug/vars should include shawk.scans_total")
+	}
+
+	resp, err = http.Get(ts.URL + "/debug/pprof/")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("/debug/pprof/ should return 200, but %d", resp.StatusCode)
+	}
+}
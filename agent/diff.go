@@ -0,0 +1,201 @@
+package agent
+
+import (
+	"sync"
+	"time"
+
+	"github.com/yuuki/shawk/probe"
+)
+
+// writtenFlow is the state of a flow when it was written last. The ages of
+// the connections are not remembered, since they grow at every scan: the
+// flows rewritten once per refresh interval keep them fresh instead.
+type writtenFlow struct {
+	connections int64
+	pname       string
+	owners      []probe.Process
+	labels      [2]map[string]string // local and peer
+	queue       *probe.Queue
+	cong        string
+	subflows    int64
+	written     time.Time
+}
+
+func newWrittenFlow(f *probe.HostFlow, now time.Time) *writtenFlow {
+	w := &writtenFlow{
+		connections: f.Connections,
+		labels:      [2]map[string]string{f.Local.Labels, f.Peer.Labels},
+		cong:        f.Cong,
+		subflows:    f.Subflows,
+		written:     now,
+	}
+	if f.Process != nil {
+		w.pname = f.Process.Name
+		w.owners = copyOwners(f.Process.Owners)
+	}
+	if f.Queue != nil {
+		q := *f.Queue
+		w.queue = &q
+	}
+	return w
+}
+
+func (w *writtenFlow) changed(f *probe.HostFlow) bool {
+	var (
+		pname  string
+		owners []*probe.Process
+	)
+	if f.Process != nil {
+		pname = f.Process.Name
+		owners = f.Process.Owners
+	}
+	return w.connections != f.Connections || w.pname != pname ||
+		!ownersEqual(w.owners, owners) ||
+		!labelsEqual(w.labels[0], f.Local.Labels) ||
+		!labelsEqual(w.labels[1], f.Peer.Labels) ||
+		!queueEqual(w.queue, f.Queue) ||
+		w.cong != f.Cong || w.subflows != f.Subflows
+}
+
+func copyOwners(owners []*probe.Process) []probe.Process {
+	if len(owners) == 0 {
+		return nil
+	}
+	c := make([]probe.Process, len(owners))
+	for i, o := range owners {
+		c[i] = probe.Process{Name: o.Name, Pgid: o.Pgid}
+	}
+	return c
+}
+
+func ownersEqual(a []probe.Process, b []*probe.Process) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].Name != b[i].Name || a[i].Pgid != b[i].Pgid {
+			return false
+		}
+	}
+	return true
+}
+
+func queueEqual(a, b *probe.Queue) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func labelsEqual(a, b map[string]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for k, v := range a {
+		if bv, ok := b[k]; !ok || bv != v {
+			return false
+		}
+	}
+	return true
+}
+
+// FlowDiffer remembers the flows written into the CMDB, so that the agent
+// writes only new or changed flows. Unchanged flows are rewritten once per
+// refresh interval to keep their timestamps fresh in the CMDB. The flows to
+// the ignored ports are never written.
+type FlowDiffer struct {
+	mu              sync.Mutex
+	refreshInterval time.Duration
+	ignorePorts     map[uint16]bool
+	written         map[probe.FlowKey]*writtenFlow
+	now             func() time.Time
+}
+
+// NewFlowDiffer creates a FlowDiffer. A zero refreshInterval disables the
+// diffing and all flows are written every time.
+func NewFlowDiffer(refreshInterval time.Duration) *FlowDiffer {
+	return &FlowDiffer{
+		refreshInterval: refreshInterval,
+		written:         map[probe.FlowKey]*writtenFlow{},
+		now:             time.Now,
+	}
+}
+
+// SetRefreshInterval changes the refresh interval. Changing it to zero
+// forgets the written flows, so that they are written again if the diffing
+// is enabled later.
+func (d *FlowDiffer) SetRefreshInterval(refreshInterval time.Duration) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.refreshInterval = refreshInterval
+	if refreshInterval == 0 {
+		d.written = map[probe.FlowKey]*writtenFlow{}
+	}
+}
+
+// SetIgnorePorts changes the ports of the servers whose flows are not
+// written.
+func (d *FlowDiffer) SetIgnorePorts(ports []uint16) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.ignorePorts = make(map[uint16]bool, len(ports))
+	for _, p := range ports {
+		d.ignorePorts[p] = true
+	}
+}
+
+// ignored returns whether the flow is to an ignored port.
+func (d *FlowDiffer) ignored(f *probe.HostFlow) bool {
+	port := f.Peer.Port
+	if f.Direction == probe.FlowPassive {
+		port = f.Local.Port
+	}
+	return d.ignorePorts[port]
+}
+
+// Filter returns the flows which are new, changed or expired since the
+// last write, except the ones to the ignored ports.
+func (d *FlowDiffer) Filter(flows []*probe.HostFlow) []*probe.HostFlow {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.refreshInterval == 0 && len(d.ignorePorts) == 0 {
+		return flows
+	}
+
+	now := d.now()
+	filtered := make([]*probe.HostFlow, 0, len(flows))
+	for _, f := range flows {
+		if d.ignored(f) {
+			continue
+		}
+		if d.refreshInterval == 0 {
+			filtered = append(filtered, f)
+			continue
+		}
+		w, ok := d.written[f.Key()]
+		if !ok || w.changed(f) || now.Sub(w.written) >= d.refreshInterval {
+			filtered = append(filtered, f)
+		}
+	}
+	return filtered
+}
+
+// Record records the flows as written, and forgets the flows not written
+// for the refresh interval, which have disappeared from the host.
+func (d *FlowDiffer) Record(flows []*probe.HostFlow) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.refreshInterval == 0 {
+		return
+	}
+
+	now := d.now()
+	for _, f := range flows {
+		d.written[f.Key()] = newWrittenFlow(f, now)
+	}
+	for key, w := range d.written {
+		if now.Sub(w.written) >= 2*d.refreshInterval {
+			delete(d.written, key)
+		}
+	}
+}
@@ -0,0 +1,186 @@
+package agent
+
+import (
+	"testing"
+	"time"
+
+	"github.com/yuuki/shawk/probe"
+)
+
+func TestFlowDiffer(t *testing.T) {
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	d := NewFlowDiffer(5 * time.Minute)
+	d.now = func() time.Time { return now }
+
+	newFlows := func(connections int64) []*probe.HostFlow {
+		return []*probe.HostFlow{
+			{
+				Direction:   probe.FlowActive,
+				Local:       &probe.AddrPort{Addr: "10.0.0.1", Aggregated: true},
+				Peer:        &probe.AddrPort{Addr: "10.0.0.2", Port: 5432},
+				Connections: connections,
+				Process:     &probe.Process{Name: "app", Pgid: 100},
+			},
+			{
+				Direction:   probe.FlowPassive,
+				Local:       &probe.AddrPort{Addr: "10.0.0.1", Port: 80},
+				Peer:        &probe.AddrPort{Addr: "10.0.0.3", Aggregated: true},
+				Connections: 1,
+				Process:     &probe.Process{Name: "nginx", Pgid: 200},
+			},
+		}
+	}
+
+	flows := newFlows(1)
+	if got := d.Filter(flows); len(got) != 2 {
+		t.Fatalf("new flows should be written, but %d flows", len(got))
+	}
+	d.Record(flows)
+
+	now = now.Add(time.Minute)
+	if got := d.Filter(newFlows(1)); len(got) != 0 {
+		t.Errorf("unchanged flows should not be written, but %d flows", len(got))
+	}
+
+	flows = newFlows(2)
+	flows[1].Peer.SetLabel("k8s.pod", "web-0")
+	if got := d.Filter(flows); len(got) != 2 {
+		t.Errorf("changed flows should be written, but %d flows", len(got))
+	}
+
+	now = now.Add(5 * time.Minute)
+	if got := d.Filter(newFlows(1)); len(got) != 2 {
+		t.Errorf("expired flows should be written, but %d flows", len(got))
+	}
+
+	// flows not written for a while are forgotten.
+	now = now.Add(5 * time.Minute)
+	d.Record(newFlows(1)[:1])
+	if n := len(d.written); n != 1 {
+		t.Errorf("the disappeared flow should be forgotten, but %d flows remain", n)
+	}
+}
+
+func TestFlowDiffer_disabled(t *testing.T) {
+	d := NewFlowDiffer(0)
+	flows := []*probe.HostFlow{
+		{
+			Direction: probe.FlowActive,
+			Local:     &probe.AddrPort{Addr: "10.0.0.1", Aggregated: true},
+			Peer:      &probe.AddrPort{Addr: "10.0.0.2", Port: 5432},
+		},
+	}
+	d.Record(flows)
+	if got := d.Filter(flows); len(got) != 1 {
+		t.Errorf("all flows should be written if disabled, but %d flows", len(got))
+	}
+}
+
+func TestFlowDiffer_SetRefreshInterval(t *testing.T) {
+	d := NewFlowDiffer(5 * time.Minute)
+	flows := []*probe.HostFlow{
+		{
+			Direction: probe.FlowActive,
+			Local:     &probe.AddrPort{Addr: "10.0.0.1", Aggregated: true},
+			Peer:      &probe.AddrPort{Addr: "10.0.0.2", Port: 5432},
+		},
+	}
+	d.Record(flows)
+	if got := d.Filter(flows); len(got) != 0 {
+		t.Fatalf("unchanged flows should not be written, but %d flows", len(got))
+	}
+
+	d.SetRefreshInterval(0)
+	if got := d.Filter(flows); len(got) != 1 {
+		t.Errorf("all flows should be written after disabled, but %d flows", len(got))
+	}
+
+	d.SetRefreshInterval(5 * time.Minute)
+	if got := d.Filter(flows); len(got) != 1 {
+		t.Errorf("the forgotten flows should be written after enabled again, but %d flows", len(got))
+	}
+}
+
+func TestFlowDiffer_SetIgnorePorts(t *testing.T) {
+	flows := []*probe.HostFlow{
+		{
+			Direction: probe.FlowActive,
+			Local:     &probe.AddrPort{Addr: "10.0.0.1", Aggregated: true},
+			Peer:      &probe.AddrPort{Addr: "10.0.0.2", Port: 9100},
+		},
+		{
+			Direction: probe.FlowPassive,
+			Local:     &probe.AddrPort{Addr: "10.0.0.1", Port: 9100},
+			Peer:      &probe.AddrPort{Addr: "10.0.0.3", Aggregated: true},
+		},
+		{
+			Direction: probe.FlowActive,
+			Local:     &probe.AddrPort{Addr: "10.0.0.1", Aggregated: true},
+			Peer:      &probe.AddrPort{Addr: "10.0.0.2", Port: 5432},
+		},
+	}
+	for _, interval := range []time.Duration{0, 5 * time.Minute} {
+		d := NewFlowDiffer(interval)
+		d.SetIgnorePorts([]uint16{9100})
+		if got := d.Filter(flows); len(got) != 1 || got[0].Peer.Port != 5432 {
+			t.Errorf("the flows to the ignored port should not be written (refresh interval %s), but %v", interval, got)
+		}
+		d.SetIgnorePorts(nil)
+		if got := d.Filter(flows); len(got) != 3 {
+			t.Errorf("all flows should be written after the ports are not ignored (refresh interval %s), but %d flows", interval, len(got))
+		}
+	}
+}
+
+func TestFlowDiffer_changedFields(t *testing.T) {
+	newFlow := func() *probe.HostFlow {
+		return &probe.HostFlow{
+			Direction:   probe.FlowActive,
+			Local:       &probe.AddrPort{Addr: "10.0.0.1", Aggregated: true},
+			Peer:        &probe.AddrPort{Addr: "10.0.0.2", Port: 5432},
+			Connections: 1,
+			Process: &probe.Process{Name: "app", Pgid: 100,
+				Owners: []*probe.Process{{Name: "systemd", Pgid: 1}}},
+			Queue:    &probe.Queue{RecvQ: 10, SendQ: 20},
+			Cong:     "cubic",
+			Age:      probe.NewAge(time.Minute),
+			Subflows: 2,
+		}
+	}
+
+	tests := []struct {
+		desc    string
+		change  func(f *probe.HostFlow)
+		changed bool
+	}{
+		{"queue", func(f *probe.HostFlow) { f.Queue.SendQ = 30 }, true},
+		{"no queue", func(f *probe.HostFlow) { f.Queue = nil }, true},
+		{"cong", func(f *probe.HostFlow) { f.Cong = "bbr" }, true},
+		{"subflows", func(f *probe.HostFlow) { f.Subflows = 3 }, true},
+		{"owners", func(f *probe.HostFlow) {
+			f.Process.Owners = append(f.Process.Owners, &probe.Process{Name: "sshd", Pgid: 50})
+		}, true},
+		{"owner name", func(f *probe.HostFlow) { f.Process.Owners[0].Name = "init" }, true},
+		{"no owners", func(f *probe.HostFlow) { f.Process.Owners = nil }, true},
+		{"age", func(f *probe.HostFlow) { f.Age = probe.NewAge(2 * time.Minute) }, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			d := NewFlowDiffer(5 * time.Minute)
+			recorded := newFlow()
+			d.Record([]*probe.HostFlow{recorded})
+			// the differ must not see the changes of the recorded flow.
+			tt.change(recorded)
+
+			f := newFlow()
+			if got := d.Filter([]*probe.HostFlow{f}); len(got) != 0 {
+				t.Fatalf("the unchanged flow should not be written, but %d flows", len(got))
+			}
+			tt.change(f)
+			got := d.Filter([]*probe.HostFlow{f})
+			if changed := len(got) == 1; changed != tt.changed {
+				t.Errorf("the flow of the changed %s should be written: %v, but %v", tt.desc, tt.changed, changed)
+			}
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package agent
+
+import (
+	"sync"
+	"time"
+
+	"github.com/yuuki/shawk/fluentd"
+	"github.com/yuuki/shawk/probe"
+)
+
+// fluentdWriter ships the flows written into the CMDB, or is nil.
+var fluentdWriter = struct {
+	sync.Mutex
+	w *fluentd.Writer
+}{}
+
+// SetFluentd makes the flushes ship the flows to Fluentd by w as well.
+func SetFluentd(w *fluentd.Writer) {
+	fluentdWriter.Lock()
+	defer fluentdWriter.Unlock()
+	fluentdWriter.w = w
+}
+
+// ShipFlows ships the flows of a flush to Fluentd by SetFluentd, if any. The
+// error is logged, since the flows are written into the CMDB regardless.
+func ShipFlows(flows []*probe.HostFlow) {
+	fluentdWriter.Lock()
+	w := fluentdWriter.w
+	fluentdWriter.Unlock()
+	if w == nil || len(flows) == 0 {
+		return
+	}
+	if err := w.WriteFlows(flows, time.Now()); err != nil {
+		logger.Warningf("%v", err)
+	}
+}
@@ -0,0 +1,167 @@
+package agent
+
+import (
+	"encoding/json"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"golang.org/x/xerrors"
+
+	"github.com/yuuki/shawk/probe"
+)
+
+// HealthStaleAfter is how long the agent stays live without a successful scan.
+var HealthStaleAfter = time.Minute
+
+// health is the state of the agent reported by /healthz and /readyz.
+var health = struct {
+	sync.Mutex
+	started      time.Time
+	lastScan     time.Time
+	lastScanErr  error
+	lastFlush    time.Time
+	lastFlushErr error
+	backlog      func() int
+	// flows are the flows of the last scan served by /metrics.
+	flows []*probe.HostFlow
+}{started: time.Now()}
+
+// RecordScan records the result of a scan of the flows.
+func RecordScan(err error) {
+	if err != nil {
+		countScanError()
+		sendScanError()
+	}
+	health.Lock()
+	defer health.Unlock()
+	health.lastScanErr = err
+	if err == nil {
+		health.lastScan = time.Now()
+	}
+}
+
+// RecordFlows records the flows of the last scan, which /metrics serves,
+// and sends them to statsd by SetStatsd. elapsed is the time of the scan, or
+// zero if the flows are aggregated from the events.
+func RecordFlows(flows []*probe.HostFlow, elapsed time.Duration) {
+	health.Lock()
+	health.flows = flows
+	health.Unlock()
+	sendScan(flows, elapsed)
+}
+
+// RecordFlush records the result of writing the flows into the CMDB.
+func RecordFlush(err error) {
+	health.Lock()
+	defer health.Unlock()
+	health.lastFlushErr = err
+	if err == nil {
+		health.lastFlush = time.Now()
+	}
+}
+
+// SetBacklog sets the function returning the number of the flows, or the
+// batches of them, waiting to be written into the CMDB.
+func SetBacklog(backlog func() int) {
+	health.Lock()
+	defer health.Unlock()
+	health.backlog = backlog
+}
+
+// healthStatus is the body of /healthz and /readyz.
+type healthStatus struct {
+	Status        string     `json:"status"`
+	LastScan      *time.Time `json:"last_scan,omitempty"`
+	LastScanError string     `json:"last_scan_error,omitempty"`
+	LastFlush     *time.Time `json:"last_flush,omitempty"`
+	DB            string     `json:"db"`
+	Backlog       int        `json:"backlog"`
+}
+
+// checkHealth returns whether the agent is live and ready.
+func checkHealth(now time.Time) (status *healthStatus, live, ready bool) {
+	health.Lock()
+	defer health.Unlock()
+
+	status = &healthStatus{DB: "ok"}
+	if !health.lastScan.IsZero() {
+		t := health.lastScan
+		status.LastScan = &t
+	}
+	if health.lastScanErr != nil {
+		status.LastScanError = health.lastScanErr.Error()
+	}
+	if !health.lastFlush.IsZero() {
+		t := health.lastFlush
+		status.LastFlush = &t
+	}
+	if health.lastFlushErr != nil {
+		status.DB = health.lastFlushErr.Error()
+	}
+	if health.backlog != nil {
+		status.Backlog = health.backlog()
+	}
+
+	// The agent is live while it scans, or until the first scan within
+	// HealthStaleAfter since it started.
+	since := health.lastScan
+	if since.IsZero() {
+		since = health.started
+	}
+	live = now.Sub(since) <= HealthStaleAfter
+	ready = live && !health.lastScan.IsZero() && health.lastFlushErr == nil
+	return status, live, ready
+}
+
+func healthHandler() http.Handler {
+	write := func(w http.ResponseWriter, status *healthStatus, ok bool) {
+		code := http.StatusOK
+		status.Status = "ok"
+		if !ok {
+			code = http.StatusServiceUnavailable
+			status.Status = "unavailable"
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(code)
+		json.NewEncoder(w).Encode(status)
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		status, live, _ := checkHealth(time.Now())
+		write(w, status, live)
+	})
+	mux.HandleFunc("/readyz", func(w http.ResponseWriter, r *http.Request) {
+		status, _, ready := checkHealth(time.Now())
+		write(w, status, ready)
+	})
+	mux.HandleFunc("/metrics", func(w http.ResponseWriter, r *http.Request) {
+		health.Lock()
+		flows := health.flows
+		health.Unlock()
+		w.Header().Set("Content-Type", probe.OpenMetricsContentType)
+		if err := probe.WriteOpenMetrics(w, flows); err != nil {
+			logger.Errorf("could not write the metrics of the flows: %v", err)
+		}
+	})
+	return mux
+}
+
+// ServeHealth serves /healthz, /readyz and /metrics on addr in background.
+// /healthz fails when no scan has succeeded within HealthStaleAfter, and
+// /readyz fails as well when the last write into the CMDB has failed.
+// /metrics serves the flows of the last scan in the OpenMetrics text format.
+func ServeHealth(addr string) error {
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return xerrors.Errorf("could not listen %s: %w", addr, err)
+	}
+	logger.Infof("Serving health endpoints on http://%s/healthz", ln.Addr())
+	go func() {
+		if err := http.Serve(ln, healthHandler()); err != nil {
+			logger.Errorf("health server error: %v", err)
+		}
+	}()
+	return nil
+}
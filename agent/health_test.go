@@ -0,0 +1,124 @@
+package agent
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yuuki/shawk/probe"
+)
+
+func resetHealth(started time.Time) {
+	health.Lock()
+	defer health.Unlock()
+	health.started = started
+	health.lastScan, health.lastScanErr = time.Time{}, nil
+	health.lastFlush, health.lastFlushErr = time.Time{}, nil
+	health.backlog = nil
+	health.flows = nil
+}
+
+func TestCheckHealth(t *testing.T) {
+	now := time.Now()
+	defer resetHealth(now)
+
+	// starting
+	resetHealth(now)
+	if _, live, ready := checkHealth(now); !live || ready {
+		t.Errorf("a starting agent should be live but not ready: live=%v ready=%v", live, ready)
+	}
+
+	// scanned and flushed
+	RecordScan(nil)
+	RecordFlush(nil)
+	if _, live, ready := checkHealth(time.Now()); !live || !ready {
+		t.Errorf("the agent should be live and ready: live=%v ready=%v", live, ready)
+	}
+
+	// the CMDB is down
+	RecordFlush(errors.New("connection refused"))
+	status, live, ready := checkHealth(time.Now())
+	if !live || ready {
+		t.Errorf("the agent should be live but not ready: live=%v ready=%v", live, ready)
+	}
+	if status.DB != "connection refused" {
+		t.Errorf("db should report the error, but %q", status.DB)
+	}
+
+	// scans are stuck
+	if _, live, _ := checkHealth(time.Now().Add(HealthStaleAfter + time.Second)); live {
+		t.Error("the agent should not be live without a scan for HealthStaleAfter")
+	}
+}
+
+func TestHealthHandler(t *testing.T) {
+	defer resetHealth(time.Now())
+	resetHealth(time.Now())
+	SetBacklog(func() int { return 3 })
+
+	ts := httptest.NewServer(healthHandler())
+	defer ts.Close()
+
+	tests := []struct {
+		path string
+		code int
+	}{
+		{"/healthz", http.StatusOK},
+		{"/readyz", http.StatusServiceUnavailable},
+	}
+	for _, tt := range tests {
+		resp, err := http.Get(ts.URL + tt.path)
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		var status healthStatus
+		err = json.NewDecoder(resp.Body).Decode(&status)
+		resp.Body.Close()
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		if resp.StatusCode != tt.code {
+			t.Errorf("%s should return %d, but %d", tt.path, tt.code, resp.StatusCode)
+		}
+		if status.Backlog != 3 {
+			t.Errorf("%s should report the backlog 3, but %d", tt.path, status.Backlog)
+		}
+	}
+}
+
+func TestHealthHandler_metrics(t *testing.T) {
+	defer resetHealth(time.Now())
+	resetHealth(time.Now())
+	RecordFlows([]*probe.HostFlow{{
+		Direction:   probe.FlowActive,
+		Local:       &probe.AddrPort{Addr: "10.0.0.1", Aggregated: true},
+		Peer:        &probe.AddrPort{Addr: "10.0.0.3", Port: 5432},
+		Connections: 10,
+		Process:     &probe.Process{Name: "app", Pgid: 200},
+	}}, time.Second)
+
+	ts := httptest.NewServer(healthHandler())
+	defer ts.Close()
+
+	resp, err := http.Get(ts.URL + "/metrics")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if got := resp.Header.Get("Content-Type"); got != probe.OpenMetricsContentType {
+		t.Errorf("Content-Type should be %q, but %q", probe.OpenMetricsContentType, got)
+	}
+	want := `shawk_flow_connections{direction="active",local="10.0.0.1:many",peer="10.0.0.3:5432",process="app"} 10`
+	if !strings.Contains(string(body), want) {
+		t.Errorf("/metrics should contain %s, but\n%s", want, body)
+	}
+}
@@ -1,10 +1,13 @@
 package polling
 
 import (
+	"context"
+	"sync"
 	"time"
 
 	"github.com/yuuki/shawk/agent"
 	"github.com/yuuki/shawk/db"
+	"github.com/yuuki/shawk/enricher"
 	"github.com/yuuki/shawk/logging"
 	"github.com/yuuki/shawk/probe"
 	"github.com/yuuki/shawk/probe/netlink"
@@ -15,8 +18,9 @@ type flowBuffer chan []*probe.HostFlow
 
 var logger = logging.New("agent/polling")
 
-// Run starts agent.
-func Run(interval time.Duration, flushInterval time.Duration, db *db.DB) error {
+// Run starts agent. It stops scanning when ctx is canceled, and then
+// flushes the flows scanned since the last flush and closes the db.
+func Run(ctx context.Context, interval time.Duration, flushInterval time.Duration, db db.Store, enrichers enricher.Chain, differ *agent.FlowDiffer) error {
 	if interval > flushInterval {
 		return xerrors.Errorf(
 			"polling interval (%s) must not exceed flush interval (%s)",
@@ -24,47 +28,96 @@ func Run(interval time.Duration, flushInterval time.Duration, db *db.DB) error {
 	}
 
 	buffer := make(flowBuffer, flushInterval/interval+1)
-	defer close(buffer)
-
-	go watch(interval, buffer, db)
-	go flusher(flushInterval, buffer, db)
-
-	return agent.Wait(db)
+	agent.SetBacklog(func() int { return len(buffer) })
+
+	watched, flushed := make(chan struct{}), make(chan struct{})
+	go func() {
+		watch(ctx, interval, buffer, db, enrichers)
+		close(watched)
+	}()
+	go func() {
+		flusher(ctx, flushInterval, buffer, db, differ)
+		close(flushed)
+	}()
+
+	<-ctx.Done()
+	return agent.Shutdown(db, func() {
+		<-watched
+		<-flushed
+		if err := flush(db, buffer, differ); err != nil {
+			logger.Errorf("%+v", err)
+		}
+	})
 }
 
-// RunOnce runs agent once.
-func RunOnce(db *db.DB) error {
+// RunOnce scans the flows once, writes them into the CMDB, and returns them.
+// The flows filtered out by differ, which are to the ignored ports, are
+// neither written nor returned.
+func RunOnce(db db.Store, enrichers enricher.Chain, differ *agent.FlowDiffer) ([]*probe.HostFlow, error) {
 	errChan := make(chan error, 1)
 	buffer := make(flowBuffer, 1)
-	scanFlows(db, buffer, errChan)
-	return <-errChan
+	scanFlows(context.Background(), db, buffer, enrichers, errChan)
+	select {
+	case err := <-errChan:
+		return nil, err
+	default:
+	}
+	flows := differ.Filter(<-buffer)
+	err := db.InsertOrUpdateHostFlows(flows)
+	agent.WriteScan(len(flows), err)
+	if err != nil {
+		return nil, err
+	}
+	return flows, nil
 }
 
-// watch watches host flows for localhost.
-func watch(interval time.Duration, buffer flowBuffer, db *db.DB) {
+// drain logs the errors until the goroutines in flight are done.
+func drain(wg *sync.WaitGroup, errChan chan error) {
+	go func() {
+		wg.Wait()
+		close(errChan)
+	}()
+	for err := range errChan {
+		logger.Errorf("%+v", err)
+	}
+}
+
+// watch watches host flows for localhost until ctx is canceled.
+func watch(ctx context.Context, interval time.Duration, buffer flowBuffer, db db.Store, enrichers enricher.Chain) {
 	ticker := time.NewTicker(interval)
 	defer ticker.Stop()
 	errChan := make(chan error, 1)
+	var wg sync.WaitGroup
 	for {
 		select {
+		case <-ctx.Done():
+			drain(&wg, errChan)
+			return
 		case err := <-errChan:
 			if err != nil {
 				logger.Errorf("%+v", err)
 			}
 		case <-ticker.C:
-			go scanFlows(db, buffer, errChan)
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				scanFlows(ctx, db, buffer, enrichers, errChan)
+			}()
 		}
 	}
 }
 
 // scanFlows scans host flows and store it to the buffer store.
-func scanFlows(db *db.DB, buffer flowBuffer, errChan chan error) {
+func scanFlows(ctx context.Context, db db.Store, buffer flowBuffer, enrichers enricher.Chain, errChan chan error) {
 	start := time.Now()
 
 	mapFlows, err := netlink.GetHostFlows(
-		&netlink.GetHostFlowsOption{Processes: true},
+		&netlink.GetHostFlowsOption{Processes: true, Ages: true},
 	)
+	agent.ScansTotal.Add(1)
+	agent.RecordScan(err)
 	if err != nil {
+		agent.ScanErrorsTotal.Add(1)
 		errChan <- err
 	}
 	// convert map into slice to solve the order problem in testing
@@ -72,42 +125,75 @@ func scanFlows(db *db.DB, buffer flowBuffer, errChan chan error) {
 	for _, f := range mapFlows {
 		flows = append(flows, f)
 	}
+	enrichers.Apply(flows)
 
 	elapsed := time.Since(start)
+	agent.RecordFlows(flows, elapsed)
 	for _, f := range flows {
 		logger.Debugf("completed to collect flows: %s", f)
 	}
 	logger.Debugf("elapsed time for collect flows [%s]", elapsed)
 
-	buffer <- flows
+	select {
+	case buffer <- flows:
+	default:
+		// The flusher does not drain the buffer while shutting down.
+		select {
+		case buffer <- flows:
+		case <-ctx.Done():
+			logger.Warningf("dropped %d flows scanned while shutting down: the buffer is full", len(flows))
+		}
+	}
 }
 
-// flusher flushes data into the CMDB periodically.
-func flusher(interval time.Duration, buffer flowBuffer, db *db.DB) {
+// flusher flushes data into the CMDB periodically until ctx is canceled.
+func flusher(ctx context.Context, interval time.Duration, buffer flowBuffer, db db.Store, differ *agent.FlowDiffer) {
 	ticker := time.NewTicker(interval)
 	defer ticker.Stop()
 	errChan := make(chan error, 1)
+	var wg sync.WaitGroup
 	for {
 		select {
+		case <-ctx.Done():
+			drain(&wg, errChan)
+			return
 		case err := <-errChan:
 			if err != nil {
 				logger.Errorf("%+v\n", err)
 			}
 		case <-ticker.C:
-			go flush(db, buffer, errChan)
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if err := flush(db, buffer, differ); err != nil {
+					errChan <- err
+				}
+			}()
 		}
 	}
 }
 
-func flush(db *db.DB, buffer flowBuffer, errChan chan error) {
+func flush(db db.Store, buffer flowBuffer, differ *agent.FlowDiffer) (err error) {
 	size := len(buffer)
+	var written int
+	defer func() { agent.WriteScan(written, err) }()
 	for i := 0; i < size; i++ {
-		flows := <-buffer
-		if err := db.InsertOrUpdateHostFlows(flows); err != nil {
-			errChan <- err
-			break
+		flows := differ.Filter(<-buffer)
+		if len(flows) == 0 {
+			continue
+		}
+		agent.ShipFlows(flows)
+		err := db.InsertOrUpdateHostFlows(flows)
+		agent.RecordFlush(err)
+		if err != nil {
+			agent.FlushErrorsTotal.Add(1)
+			return err
 		}
+		differ.Record(flows)
+		agent.FlowsTotal.Add(int64(len(flows)))
+		written += len(flows)
 	}
 
 	logger.Debugf("completed to insert flows to the CMDB (buffer size: %d) \n", size)
+	return nil
 }
@@ -0,0 +1,93 @@
+package agent
+
+import (
+	"net"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/yuuki/shawk/db"
+	"github.com/yuuki/shawk/version"
+)
+
+// Backends of the probe recorded with the scans.
+const (
+	BackendNetlink    = "netlink"
+	BackendStateTable = "statetable"
+	BackendEBPF       = "ebpf"
+)
+
+// scans is the state of the scans since the last record written into the
+// CMDB.
+var scans = struct {
+	sync.Mutex
+	w       db.ScanWriter
+	host    string
+	addrs   []net.IP
+	backend string
+	since   time.Time
+	errors  int
+}{}
+
+// SetScanWriter makes the flushes write the records of the scans into w, as
+// of the host of the addresses and the backend reading the flows.
+func SetScanWriter(w db.ScanWriter, host string, addrs []net.IP, backend string) {
+	scans.Lock()
+	defer scans.Unlock()
+	scans.w, scans.host, scans.addrs, scans.backend = w, host, addrs, backend
+	scans.since = time.Now()
+	scans.errors = 0
+}
+
+// countScanError counts a scan failed since the last record.
+func countScanError() {
+	scans.Lock()
+	defer scans.Unlock()
+	scans.errors++
+}
+
+// WriteScan writes the record of the flows written since the last record,
+// with err of the write and the health of the agent. It does nothing without
+// SetScanWriter. The error of writing the record is logged, since it doesn't
+// lose the flows.
+func WriteScan(flows int, err error) {
+	health.Lock()
+	lastSuccess, backlog := health.lastScan, 0
+	if health.backlog != nil {
+		backlog = health.backlog()
+	}
+	health.Unlock()
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+
+	scans.Lock()
+	if scans.w == nil {
+		scans.Unlock()
+		return
+	}
+	now := time.Now()
+	scan := &db.Scan{
+		Host:         scans.host,
+		AgentVersion: version.GetVersion(),
+		Backend:      scans.backend,
+		Started:      scans.since,
+		Finished:     now,
+		Flows:        flows,
+		Errors:       scans.errors,
+		Addrs:        scans.addrs,
+		LastSuccess:  lastSuccess,
+		Backlog:      backlog,
+		Memory:       mem.Sys,
+	}
+	if err != nil {
+		scan.Errors++
+		scan.Error = err.Error()
+	}
+	w := scans.w
+	scans.since, scans.errors = now, 0
+	scans.Unlock()
+
+	if err := w.InsertScan(scan); err != nil {
+		logger.Warningf("could not write the record of the scans: %v", err)
+	}
+}
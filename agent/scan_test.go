@@ -0,0 +1,54 @@
+package agent
+
+import (
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/yuuki/shawk/db"
+)
+
+type memScanWriter struct {
+	scans []*db.Scan
+}
+
+func (m *memScanWriter) InsertScan(scan *db.Scan) error {
+	m.scans = append(m.scans, scan)
+	return nil
+}
+
+func TestWriteScan(t *testing.T) {
+	w := &memScanWriter{}
+	SetScanWriter(w, "web-1", []net.IP{net.ParseIP("10.0.0.5")}, BackendNetlink)
+	defer SetScanWriter(nil, "", nil, "")
+	SetBacklog(func() int { return 4 })
+	defer SetBacklog(nil)
+
+	RecordScan(errors.New("netlink dump failed"))
+	RecordScan(nil)
+	WriteScan(3, nil)
+	WriteScan(0, errors.New("connection refused"))
+
+	if len(w.scans) != 2 {
+		t.Fatalf("WriteScan() should write 2 scans, but %d", len(w.scans))
+	}
+	first, second := w.scans[0], w.scans[1]
+	if first.Host != "web-1" || first.Backend != BackendNetlink || first.Flows != 3 || first.Errors != 1 || first.Error != "" {
+		t.Errorf("the first scan should be of 3 flows and a scan failed, but %+v", first)
+	}
+	if second.Errors != 1 || second.Error != "connection refused" {
+		t.Errorf("the second scan should be of the write failed, but %+v", second)
+	}
+	if !second.Started.Equal(first.Finished) {
+		t.Errorf("the second scan should start at %s when the first finished, but %s", first.Finished, second.Started)
+	}
+	if first.AgentVersion == "" {
+		t.Error("the scan should have the version of the agent")
+	}
+	if len(first.Addrs) != 1 || !first.Addrs[0].Equal(net.ParseIP("10.0.0.5")) {
+		t.Errorf("the scan should have the addresses of the host, but %v", first.Addrs)
+	}
+	if first.LastSuccess.IsZero() || first.Backlog != 4 || first.Memory == 0 {
+		t.Errorf("the scan should have the health of the agent, but %+v", first)
+	}
+}
@@ -0,0 +1,52 @@
+package agent
+
+import (
+	"sync"
+	"time"
+
+	"github.com/yuuki/shawk/probe"
+	"github.com/yuuki/shawk/statsd"
+)
+
+// statsdClient sends the metrics of the scans, or is nil.
+var statsdClient = struct {
+	sync.Mutex
+	c *statsd.Client
+}{}
+
+// SetStatsd makes the scans send their flows and their summaries to statsd
+// by c.
+func SetStatsd(c *statsd.Client) {
+	statsdClient.Lock()
+	defer statsdClient.Unlock()
+	statsdClient.c = c
+}
+
+func getStatsd() *statsd.Client {
+	statsdClient.Lock()
+	defer statsdClient.Unlock()
+	return statsdClient.c
+}
+
+// sendScan sends the flows of a scan taking elapsed to statsd, if any. The
+// error is logged, since the metrics are not the records of the flows.
+func sendScan(flows []*probe.HostFlow, elapsed time.Duration) {
+	c := getStatsd()
+	if c == nil {
+		return
+	}
+	if err := c.SendScan(flows, elapsed); err != nil {
+		logger.Warningf("%v", err)
+	}
+}
+
+// sendScanError counts a failed scan in statsd, if any.
+func sendScanError() {
+	c := getStatsd()
+	if c == nil {
+		return
+	}
+	if err := c.SendScanError(); err != nil {
+		logger.Warningf("%v", err)
+	}
+}
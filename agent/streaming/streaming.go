@@ -1,15 +1,16 @@
 package streaming
 
 import (
+	"context"
 	"time"
 
-	"golang.org/x/xerrors"
-
 	"github.com/yuuki/shawk/agent"
 	"github.com/yuuki/shawk/db"
+	"github.com/yuuki/shawk/enricher"
 	"github.com/yuuki/shawk/logging"
 	"github.com/yuuki/shawk/probe"
 	"github.com/yuuki/shawk/probe/ebpf"
+	"github.com/yuuki/shawk/probe/netlink/netutil"
 )
 
 type flowAggBuffer chan *probe.HostFlow
@@ -18,72 +19,101 @@ const flowBufferSize uint16 = 0xffff
 
 var logger = logging.New("agent/streaming")
 
-// Run starts agent process on streaming mode.
-func Run(interval time.Duration, db *db.DB) error {
+// Run starts agent process on streaming mode. It stops tracing when ctx is
+// canceled, and then flushes the aggregated flows and closes the db.
+func Run(ctx context.Context, interval time.Duration, db db.Store, enrichers enricher.Chain, differ *agent.FlowDiffer) error {
 	ok, err := ebpf.IsSupportedLinux()
 	if err != nil {
 		return err
 	}
 	if !ok {
-		return xerrors.Errorf("this linux kernel is out of supoort")
+		return &netutil.KernelUnsupportedError{Feature: "eBPF tracer (kernel >= 4.1)"}
 	}
 
 	aggBuffer := make(flowAggBuffer, flowBufferSize)
-	defer close(aggBuffer)
+	agent.SetBacklog(func() int { return len(aggBuffer) })
 
-	go aggregator(db, interval, aggBuffer)
+	// The aggregator outlives the tracer to flush the last events.
+	aggCtx, stopAggregator := context.WithCancel(context.Background())
+	defer stopAggregator()
+	aggregated := make(chan struct{})
+	go func() {
+		aggregator(aggCtx, db, interval, aggBuffer, enrichers, differ)
+		close(aggregated)
+	}()
 
 	cb := func(v *probe.HostFlow) {
 		logger.Debugf("%s\n", v)
 		aggBuffer <- v
 	}
-	if err := ebpf.StartTracer(cb); err != nil {
+	// The tracer is alive while its event loop runs, even if no connection
+	// is traced.
+	heartbeat := func() { agent.RecordScan(nil) }
+	if err := ebpf.StartTracerWithHeartbeat(ctx, cb, heartbeat, interval); err != nil {
 		return err
 	}
 
-	return agent.Wait(db)
+	return agent.Shutdown(db, func() {
+		stopAggregator()
+		<-aggregated
+	})
 }
 
-func aggregator(db *db.DB, interval time.Duration, buffer chan *probe.HostFlow) {
+// aggregator flushes the aggregated flows periodically. It flushes the
+// remaining flows once more when ctx is canceled.
+func aggregator(ctx context.Context, db db.Store, interval time.Duration, buffer chan *probe.HostFlow, enrichers enricher.Chain, differ *agent.FlowDiffer) {
 	ticker := time.NewTicker(interval)
 	defer ticker.Stop()
-	errChan := make(chan error, 1)
 
 	for {
 		select {
-		case err := <-errChan:
-			if err != nil {
+		case <-ctx.Done():
+			if err := flush(db, buffer, enrichers, differ); err != nil {
 				logger.Errorf("%+v\n", err)
 			}
+			return
 		case <-ticker.C:
-			flows := aggregate(buffer)
-			if err := db.InsertOrUpdateHostFlows(flows); err != nil {
-				errChan <- err
+			if err := flush(db, buffer, enrichers, differ); err != nil {
+				logger.Errorf("%+v\n", err)
 			}
-			logger.Debugf("completed to insert flows to the CMDB (the number of flows: %d) \n", len(flows))
 		}
 	}
 }
 
+func flush(db db.Store, buffer chan *probe.HostFlow, enrichers enricher.Chain, differ *agent.FlowDiffer) error {
+	flows := aggregate(buffer)
+	enrichers.Apply(flows)
+	agent.RecordFlows(flows, 0)
+	flows = differ.Filter(flows)
+	if len(flows) == 0 {
+		// Skip the write as polling does, when no flow was traced or
+		// changed since the last flush.
+		agent.WriteScan(0, nil)
+		return nil
+	}
+	agent.ShipFlows(flows)
+	err := db.InsertOrUpdateHostFlows(flows)
+	agent.RecordFlush(err)
+	agent.WriteScan(len(flows), err)
+	if err != nil {
+		agent.FlushErrorsTotal.Add(1)
+		return err
+	}
+	differ.Record(flows)
+	agent.FlowsTotal.Add(int64(len(flows)))
+	logger.Debugf("completed to insert flows to the CMDB (the number of flows: %d) \n", len(flows))
+	return nil
+}
+
 func aggregate(buffer chan *probe.HostFlow) []*probe.HostFlow {
 	size := len(buffer)
 	if size == 0 {
 		return []*probe.HostFlow{}
 	}
 
-	aggMap := make(map[string]*probe.HostFlow)
+	aggMap := make(probe.HostFlows, size)
 	for i := 0; i < size; i++ {
-		flow := <-buffer
-		key := flow.UniqKey()
-
-		if _, ok := aggMap[key]; !ok {
-			aggMap[key] = flow
-		} else {
-			if aggMap[key].Process == nil {
-				aggMap[key].Process = flow.Process
-			}
-		}
-		aggMap[key].Connections++
+		aggMap.Insert(<-buffer)
 	}
 
 	flows := make([]*probe.HostFlow, 0, len(aggMap))
@@ -0,0 +1,59 @@
+package streaming
+
+import (
+	"testing"
+	"time"
+
+	"github.com/yuuki/shawk/agent"
+	"github.com/yuuki/shawk/db"
+	"github.com/yuuki/shawk/probe"
+)
+
+// countingStore counts the writes of the flows.
+type countingStore struct {
+	db.Store
+	writes int
+}
+
+func (s *countingStore) InsertOrUpdateHostFlows(flows []*probe.HostFlow) error {
+	s.writes++
+	return nil
+}
+
+func TestFlush_empty(t *testing.T) {
+	store := &countingStore{}
+	buffer := make(chan *probe.HostFlow, 2)
+	differ := agent.NewFlowDiffer(time.Minute)
+
+	// no flow is traced.
+	if err := flush(store, buffer, nil, differ); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if store.writes != 0 {
+		t.Errorf("flush() should skip the write without flows, but %d writes", store.writes)
+	}
+
+	newFlow := func() *probe.HostFlow {
+		return &probe.HostFlow{
+			Direction: probe.FlowActive,
+			Local:     &probe.AddrPort{Addr: "10.0.0.1", Aggregated: true},
+			Peer:      &probe.AddrPort{Addr: "10.0.0.2", Port: 5432},
+		}
+	}
+	buffer <- newFlow()
+	if err := flush(store, buffer, nil, differ); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if store.writes != 1 {
+		t.Errorf("flush() should write the traced flows once, but %d writes", store.writes)
+	}
+
+	// the flow is unchanged within the refresh interval of the differ.
+	buffer <- newFlow()
+	if err := flush(store, buffer, nil, differ); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if store.writes != 1 {
+		t.Errorf("flush() should skip the write of the unchanged flows, but %d writes", store.writes)
+	}
+}
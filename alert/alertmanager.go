@@ -0,0 +1,186 @@
+// Package alert posts the changes of the flows as the alerts of Alertmanager,
+// so that the routing, the grouping and the silences of Alertmanager notify
+// them.
+package alert
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/xerrors"
+
+	"github.com/yuuki/shawk/db"
+	"github.com/yuuki/shawk/logging"
+)
+
+// alertsPath is the path of the API of Alertmanager receiving the alerts.
+const alertsPath = "/api/v2/alerts"
+
+const requestTimeout = 10 * time.Second
+
+// DefaultResolveAfter is how long an alert lasts until it is resolved.
+const DefaultResolveAfter = time.Hour
+
+var logger = logging.New("alert")
+
+// The names of the alerts of the types of the changes.
+var alertNames = map[string]string{
+	db.ChangeAdded:     "ShawkDependencyAdded",
+	db.ChangeRemoved:   "ShawkDependencyRemoved",
+	db.ChangeThreshold: "ShawkConnectionsSpike",
+}
+
+var summaries = map[string]string{
+	db.ChangeAdded:     "a new dependency from %s to %s",
+	db.ChangeRemoved:   "the dependency from %s to %s disappeared",
+	db.ChangeThreshold: "the connections from %s to %s reached %d",
+}
+
+// Alert is an alert of the API of Alertmanager.
+type Alert struct {
+	Labels       map[string]string `json:"labels"`
+	Annotations  map[string]string `json:"annotations"`
+	StartsAt     time.Time         `json:"startsAt"`
+	EndsAt       time.Time         `json:"endsAt"`
+	GeneratorURL string            `json:"generatorURL,omitempty"`
+}
+
+// Option represents an option for the Sender.
+type Option struct {
+	// URLs are the base URLs of Alertmanager such as
+	// http://127.0.0.1:9093. The alerts are posted to all of them, which
+	// deduplicate the alerts in a cluster.
+	URLs []string
+	// ResolveAfter is how long an alert lasts. The changes are the events
+	// without the end, so Alertmanager resolves them after it.
+	ResolveAfter time.Duration
+}
+
+// Sender posts the changes as the alerts to Alertmanager.
+type Sender struct {
+	opt        *Option
+	httpClient *http.Client
+}
+
+// NewSender creates a Sender.
+func NewSender(opt *Option) *Sender {
+	if opt.ResolveAfter <= 0 {
+		opt.ResolveAfter = DefaultResolveAfter
+	}
+	return &Sender{
+		opt:        opt,
+		httpClient: &http.Client{Timeout: requestTimeout},
+	}
+}
+
+// NewAlert returns the alert of the change at now.
+func NewAlert(c *db.Change, now time.Time, resolveAfter time.Duration) *Alert {
+	labels := map[string]string{
+		"alertname": alertNames[c.Type],
+		"change":    c.Type,
+	}
+	if c.Tenant != "" {
+		labels["tenant"] = c.Tenant
+	}
+	// The labels identify the flow, so that the alerts of a flow are
+	// deduplicated but not the ones of the other flows.
+	if c.Client != nil {
+		labels["client_addr"] = c.Client.Addr
+		labels["client_process"] = c.Client.Process
+	}
+	if c.Server != nil {
+		labels["server_addr"] = c.Server.Addr
+		if c.Server.Port != 0 {
+			labels["server_port"] = strconv.Itoa(int(c.Server.Port))
+		}
+		labels["server_process"] = c.Server.Process
+	}
+	for k, v := range labels {
+		if v == "" {
+			delete(labels, k)
+		}
+	}
+
+	summary := summaries[c.Type]
+	args := []interface{}{formatNode(c.Client), formatNode(c.Server)}
+	if c.Type == db.ChangeThreshold {
+		args = append(args, c.Connections)
+	}
+	return &Alert{
+		Labels: labels,
+		Annotations: map[string]string{
+			"summary":     fmt.Sprintf(summary, args...),
+			"connections": strconv.Itoa(c.Connections),
+			"flow_id":     strconv.FormatInt(c.ID, 10),
+		},
+		StartsAt: now,
+		EndsAt:   now.Add(resolveAfter),
+	}
+}
+
+// formatNode formats the node such as 10.0.0.1:80 ('nginx').
+func formatNode(n *db.ChangeNode) string {
+	if n == nil {
+		return "(deleted)"
+	}
+	s := n.Addr
+	if n.Port != 0 {
+		s += ":" + strconv.Itoa(int(n.Port))
+	}
+	if n.Process != "" {
+		s += " ('" + n.Process + "')"
+	}
+	return s
+}
+
+// Send posts the alert of the change to all the Alertmanagers. It returns
+// an error only if all of them fail, since an Alertmanager in a cluster
+// notifies the alert to the others.
+func (s *Sender) Send(c *db.Change, now time.Time) error {
+	a := NewAlert(c, now, s.opt.ResolveAfter)
+	body, err := json.Marshal([]*Alert{a})
+	if err != nil {
+		return xerrors.Errorf("could not encode the alert: %w", err)
+	}
+	var lastErr error
+	sent := 0
+	for _, u := range s.opt.URLs {
+		if err := s.post(u, body); err != nil {
+			logger.Warningf("%v", err)
+			lastErr = err
+			continue
+		}
+		sent++
+	}
+	if sent == 0 && lastErr != nil {
+		return lastErr
+	}
+	return nil
+}
+
+func (s *Sender) post(base string, body []byte) error {
+	u := strings.TrimSuffix(base, "/") + alertsPath
+	req, err := http.NewRequest(http.MethodPost, u, bytes.NewReader(body))
+	if err != nil {
+		return xerrors.Errorf("could not create request %s: %w", u, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return xerrors.Errorf("POST %s: %w", u, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		b, _ := ioutil.ReadAll(resp.Body)
+		return xerrors.Errorf("POST %s: unexpected status %s: %s", u, resp.Status, b)
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+package alert
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/yuuki/shawk/db"
+)
+
+func TestNewAlert(t *testing.T) {
+	now := time.Date(2020, 12, 20, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		desc   string
+		change *db.Change
+		want   *Alert
+	}{
+		{
+			desc: "added",
+			change: &db.Change{
+				Type: db.ChangeAdded, ID: 1, Connections: 3, Tenant: "team-a",
+				Client: &db.ChangeNode{Addr: "10.0.0.1", Process: "app"},
+				Server: &db.ChangeNode{Addr: "10.0.0.2", Port: 80, Process: "nginx"},
+			},
+			want: &Alert{
+				Labels: map[string]string{
+					"alertname": "ShawkDependencyAdded", "change": "added", "tenant": "team-a",
+					"client_addr": "10.0.0.1", "client_process": "app",
+					"server_addr": "10.0.0.2", "server_port": "80", "server_process": "nginx",
+				},
+				Annotations: map[string]string{
+					"summary":     "a new dependency from 10.0.0.1 ('app') to 10.0.0.2:80 ('nginx')",
+					"connections": "3", "flow_id": "1",
+				},
+				StartsAt: now, EndsAt: now.Add(time.Hour),
+			},
+		},
+		{
+			desc:   "removed with the nodes deleted",
+			change: &db.Change{Type: db.ChangeRemoved, ID: 2, Connections: 1},
+			want: &Alert{
+				Labels: map[string]string{"alertname": "ShawkDependencyRemoved", "change": "removed"},
+				Annotations: map[string]string{
+					"summary":     "the dependency from (deleted) to (deleted) disappeared",
+					"connections": "1", "flow_id": "2",
+				},
+				StartsAt: now, EndsAt: now.Add(time.Hour),
+			},
+		},
+		{
+			desc: "threshold",
+			change: &db.Change{
+				Type: db.ChangeThreshold, ID: 3, Connections: 100,
+				Client: &db.ChangeNode{Addr: "10.0.0.1", Process: "app"},
+				Server: &db.ChangeNode{Addr: "10.0.0.3", Port: 5432},
+			},
+			want: &Alert{
+				Labels: map[string]string{
+					"alertname": "ShawkConnectionsSpike", "change": "threshold",
+					"client_addr": "10.0.0.1", "client_process": "app",
+					"server_addr": "10.0.0.3", "server_port": "5432",
+				},
+				Annotations: map[string]string{
+					"summary":     "the connections from 10.0.0.1 ('app') to 10.0.0.3:5432 reached 100",
+					"connections": "100", "flow_id": "3",
+				},
+				StartsAt: now, EndsAt: now.Add(time.Hour),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got := NewAlert(tt.change, now, time.Hour)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("NewAlert() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSender_Send(t *testing.T) {
+	var received [][]*Alert
+	ok := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != alertsPath {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		var alerts []*Alert
+		if err := json.NewDecoder(r.Body).Decode(&alerts); err != nil {
+			t.Errorf("could not decode the alerts: %v", err)
+		}
+		received = append(received, alerts)
+	}))
+	defer ok.Close()
+	down := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	}))
+	defer down.Close()
+
+	c := &db.Change{Type: db.ChangeAdded, ID: 1, Connections: 1}
+	now := time.Now()
+
+	s := NewSender(&Option{URLs: []string{down.URL, ok.URL + "/"}})
+	if err := s.Send(c, now); err != nil {
+		t.Fatalf("Send() should succeed if an Alertmanager receives it: %+v", err)
+	}
+	if len(received) != 1 || len(received[0]) != 1 {
+		t.Fatalf("Alertmanager should receive an alert, but %v", received)
+	}
+	if got := received[0][0].EndsAt.Sub(received[0][0].StartsAt); got != DefaultResolveAfter {
+		t.Errorf("the alert should last %s, but %s", DefaultResolveAfter, got)
+	}
+
+	s = NewSender(&Option{URLs: []string{down.URL}})
+	if err := s.Send(c, now); err == nil {
+		t.Error("Send() should fail if no Alertmanager receives it")
+	}
+}
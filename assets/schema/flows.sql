@@ -3,11 +3,13 @@ CREATE TABLE IF NOT EXISTS processes (
     ipv4    inet NOT NULL,
     pgid    integer NOT NULL CHECK (pgid >= 0) DEFAULT 0, -- pgid=0 means failure to capture process information
     pname   varchar(50) NOT NULL DEFAULT '', -- TODO: +cmdline
+    labels  jsonb NOT NULL DEFAULT '{}', -- workload identities and other metadata attached by enrichers
     created timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
     updated timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
 
     UNIQUE (ipv4, pgid, pname)
 );
+ALTER TABLE processes ADD COLUMN IF NOT EXISTS labels jsonb NOT NULL DEFAULT '{}';
 
 -- connect side
 CREATE TABLE IF NOT EXISTS active_nodes (
@@ -25,7 +27,6 @@ CREATE TABLE IF NOT EXISTS passive_nodes (
 
     UNIQUE (process_id, port)
 );
-CREATE INDEX IF NOT EXISTS passive_nodes_port_key ON passive_nodes USING btree (port);
 
 CREATE TABLE IF NOT EXISTS flows (
     flow_id                 bigserial NOT NULL PRIMARY KEY,
@@ -40,4 +41,244 @@ CREATE TABLE IF NOT EXISTS flows (
 CREATE INDEX IF NOT EXISTS flows_destination_node_id_updated_key ON flows USING btree (destination_node_id, updated);
 CREATE INDEX IF NOT EXISTS flows_source_node_id_destination_node_id_updated_key ON flows USING btree (source_node_id, destination_node_id, updated);
 CREATE INDEX IF NOT EXISTS flows_destination_node_id_source_node_id_key ON flows USING btree (destination_node_id, source_node_id);
-CREATE INDEX IF NOT EXISTS flows_source_node_id_destination_node_id_key ON flows USING btree (source_node_id, destination_node_id);
+
+-- notify the flows deleted on shawk_flows, as the writes of shawk probe notify the new flows
+CREATE OR REPLACE FUNCTION notify_flow_removed() RETURNS trigger AS $$
+BEGIN
+    PERFORM pg_notify('shawk_flows', json_build_object(
+        'type', 'removed',
+        'id', OLD.flow_id,
+        'connections', OLD.connections,
+        'tenant', OLD.tenant,
+        'client', (
+            SELECT json_build_object('addr', host(processes.ipv4), 'process', processes.pname)
+            FROM active_nodes
+            INNER JOIN processes ON processes.process_id = active_nodes.process_id
+            WHERE active_nodes.node_id = OLD.source_node_id
+        ),
+        'server', (
+            SELECT json_build_object('addr', host(processes.ipv4), 'port', passive_nodes.port, 'process', processes.pname)
+            FROM passive_nodes
+            INNER JOIN processes ON processes.process_id = passive_nodes.process_id
+            WHERE passive_nodes.node_id = OLD.destination_node_id
+        )
+    )::text);
+    RETURN OLD;
+END;
+$$ LANGUAGE plpgsql;
+DROP TRIGGER IF EXISTS flows_notify_removed ON flows;
+CREATE TRIGGER flows_notify_removed AFTER DELETE ON flows
+    FOR EACH ROW EXECUTE PROCEDURE notify_flow_removed();
+
+-- the indexes of the query shapes, which 'shawk db explain' shows the plans of
+-- the processes by the name, such as the services
+CREATE INDEX IF NOT EXISTS processes_pname_key ON processes USING btree (pname);
+-- the servers by the port and the address, which supersedes passive_nodes_port_key
+CREATE INDEX IF NOT EXISTS passive_nodes_port_process_id_key ON passive_nodes USING btree (port, process_id);
+DROP INDEX IF EXISTS passive_nodes_port_key;
+-- the flows updated in a time range, such as 'shawk export --since'
+CREATE INDEX IF NOT EXISTS flows_updated_key ON flows USING btree (updated);
+-- the same as the unique constraint of (source_node_id, destination_node_id)
+DROP INDEX IF EXISTS flows_source_node_id_destination_node_id_key;
+
+-- the tenants sharing the CMDB, which own the processes and the flows written
+-- by their agents. The same address in two tenants is two processes.
+ALTER TABLE processes ADD COLUMN IF NOT EXISTS tenant varchar(63) NOT NULL DEFAULT '';
+-- the key before the VRFs, which is superseded by
+-- processes_ipv4_tenant_vrf_pgid_pname_key below. It is built only while the
+-- VRFs are absent, since the processes of two VRFs may share it.
+DO $$
+BEGIN
+    IF NOT EXISTS (
+        SELECT 1 FROM information_schema.columns WHERE table_name = 'processes' AND column_name = 'vrf'
+    ) THEN
+        CREATE UNIQUE INDEX IF NOT EXISTS processes_ipv4_tenant_pgid_pname_key ON processes USING btree (ipv4, tenant, pgid, pname);
+    END IF;
+END
+$$;
+ALTER TABLE processes DROP CONSTRAINT IF EXISTS processes_ipv4_pgid_pname_key;
+ALTER TABLE flows ADD COLUMN IF NOT EXISTS tenant varchar(63) NOT NULL DEFAULT '';
+CREATE INDEX IF NOT EXISTS flows_tenant_updated_key ON flows USING btree (tenant, updated);
+-- drop the views aggregated before the tenants, which are recreated by
+-- 'shawk create-scheme --views'. The queries read the flows meanwhile.
+DO $$
+BEGIN
+    IF to_regclass('top_talkers') IS NOT NULL AND NOT EXISTS (
+        SELECT 1 FROM pg_attribute WHERE attrelid = to_regclass('top_talkers') AND attname = 'tenant'
+    ) THEN
+        DROP MATERIALIZED VIEW top_talkers;
+        DROP MATERIALIZED VIEW IF EXISTS service_edges;
+        DELETE FROM view_refreshes;
+    END IF;
+END;
+$$;
+
+-- the connections translated by the NAT gateways, which the agents on them
+-- write from conntrack. The flows through them are written as the flows
+-- between the clients and the translated servers.
+CREATE TABLE IF NOT EXISTS nat_translations (
+    tenant          varchar(63) NOT NULL DEFAULT '',
+    client_ipv4     inet NOT NULL,
+    original_ipv4   inet NOT NULL,
+    original_port   integer NOT NULL CHECK (original_port > 0),
+    translated_ipv4 inet NOT NULL,
+    translated_port integer NOT NULL CHECK (translated_port > 0),
+    source_ipv4     inet NOT NULL,
+    connections     integer NOT NULL CHECK (connections > 0),
+    updated         timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY (tenant, client_ipv4, original_ipv4, original_port, translated_ipv4, translated_port, source_ipv4)
+);
+CREATE INDEX IF NOT EXISTS nat_translations_tenant_updated_key ON nat_translations USING btree (tenant, updated);
+
+-- the depth of the socket queues written by the agents of the servers, which
+-- are the accept queue and the backlog of the listeners, and the maxima of
+-- the bytes queued in the sockets of the flows
+ALTER TABLE passive_nodes ADD COLUMN IF NOT EXISTS accept_queue integer NOT NULL DEFAULT 0;
+ALTER TABLE passive_nodes ADD COLUMN IF NOT EXISTS backlog integer NOT NULL DEFAULT 0;
+ALTER TABLE flows ADD COLUMN IF NOT EXISTS recv_queue integer NOT NULL DEFAULT 0;
+ALTER TABLE flows ADD COLUMN IF NOT EXISTS send_queue integer NOT NULL DEFAULT 0;
+
+-- the congestion control algorithms of the flows, such as cubic or bbr,
+-- written by the agents of the clients and the servers respectively
+ALTER TABLE flows ADD COLUMN IF NOT EXISTS client_cong varchar(255) NOT NULL DEFAULT '';
+ALTER TABLE flows ADD COLUMN IF NOT EXISTS server_cong varchar(255) NOT NULL DEFAULT '';
+
+-- the other process groups sharing the sockets of the processes, such as
+-- systemd activating them, as the array of {"name": pname, "pgid": pgid}
+ALTER TABLE processes ADD COLUMN IF NOT EXISTS owners jsonb NOT NULL DEFAULT '[]';
+
+-- the ages of the connections of the flows in seconds, which are the
+-- minimum, the average and the maximum written by the agent of either side,
+-- or NULL if the agents don't track them
+ALTER TABLE flows ADD COLUMN IF NOT EXISTS age_min integer;
+ALTER TABLE flows ADD COLUMN IF NOT EXISTS age_avg integer;
+ALTER TABLE flows ADD COLUMN IF NOT EXISTS age_max integer;
+
+-- the VRFs of the processes, whose addresses are of the address spaces of the
+-- VRFs on the hosts of the agents. The same address in two VRFs is two
+-- processes.
+ALTER TABLE processes ADD COLUMN IF NOT EXISTS vrf varchar(15) NOT NULL DEFAULT '';
+CREATE UNIQUE INDEX IF NOT EXISTS processes_ipv4_tenant_vrf_pgid_pname_key ON processes USING btree (ipv4, tenant, vrf, pgid, pname);
+DROP INDEX IF EXISTS processes_ipv4_tenant_pgid_pname_key;
+-- drop the views aggregated before the VRFs, which are recreated by
+-- 'shawk create-scheme --views'. The queries read the flows meanwhile.
+DO $$
+BEGIN
+    IF to_regclass('top_talkers') IS NOT NULL AND NOT EXISTS (
+        SELECT 1 FROM pg_attribute WHERE attrelid = to_regclass('top_talkers') AND attname = 'vrf'
+    ) THEN
+        DROP MATERIALIZED VIEW top_talkers;
+        DELETE FROM view_refreshes WHERE name = 'top_talkers';
+    END IF;
+END;
+$$;
+
+-- the subflows of the MPTCP connections of the flows, which are the paths of
+-- the connections counted as one connection each, written by the agent of
+-- either side. It is zero for the plain TCP connections.
+ALTER TABLE flows ADD COLUMN IF NOT EXISTS subflows integer NOT NULL DEFAULT 0;
+
+-- the records of the flows written by the agents on each flush, which tell
+-- how fresh the flows of the hosts are and how healthy their collections are.
+-- The period of a record starts when the last one of the host finished.
+CREATE TABLE IF NOT EXISTS scans (
+    scan_id         bigserial NOT NULL PRIMARY KEY,
+    tenant          varchar(63) NOT NULL DEFAULT '',
+    host            varchar(255) NOT NULL,
+    agent_version   varchar(63) NOT NULL DEFAULT '',
+    backend         varchar(15) NOT NULL DEFAULT '', -- netlink, statetable or ebpf
+    started         timestamp NOT NULL,
+    finished        timestamp NOT NULL,
+    flows           integer NOT NULL DEFAULT 0,
+    errors          integer NOT NULL DEFAULT 0, -- the scans and the writes failed in the period
+    error           text NOT NULL DEFAULT '' -- the error of the write
+);
+CREATE INDEX IF NOT EXISTS scans_tenant_host_finished_key ON scans USING btree (tenant, host, finished);
+
+-- the addresses merged into the others by shawk merge, such as the previous
+-- addresses of the hosts re-provisioned, so that the lookups of them follow
+-- the hosts
+CREATE TABLE IF NOT EXISTS node_aliases (
+    tenant  varchar(63) NOT NULL DEFAULT '',
+    alias   inet NOT NULL,
+    ipv4    inet NOT NULL,
+    created timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY (tenant, alias)
+);
+
+-- the notes of the reviews of the edges, such as "expected: backup traffic"
+-- or the tickets of them, keyed by the client, the server and the port so
+-- that they outlive the flows expired and written again
+CREATE TABLE IF NOT EXISTS flow_annotations (
+    tenant  varchar(63) NOT NULL DEFAULT '',
+    client  inet NOT NULL,
+    server  inet NOT NULL,
+    port    integer NOT NULL CHECK (port > 0),
+    note    text NOT NULL,
+    updated timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY (tenant, client, server, port)
+);
+-- the VRFs of the servers of the edges annotated, whose addresses are of the
+-- address spaces of the VRFs. The same edge in two VRFs is two edges.
+ALTER TABLE flow_annotations ADD COLUMN IF NOT EXISTS vrf varchar(15) NOT NULL DEFAULT '';
+CREATE UNIQUE INDEX IF NOT EXISTS flow_annotations_tenant_vrf_client_server_port_key ON flow_annotations USING btree (tenant, vrf, client, server, port);
+ALTER TABLE flow_annotations DROP CONSTRAINT IF EXISTS flow_annotations_pkey;
+
+-- the progress of shawk sync from the other CMDBs, which is the latest update
+-- of the flows synced from each source, so that the next sync starts from it
+CREATE TABLE IF NOT EXISTS syncs (
+    source  varchar(255) NOT NULL PRIMARY KEY,
+    synced  timestamp NOT NULL,
+    updated timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- the backlog and the memory of the agents in the records of the scans
+ALTER TABLE scans ADD COLUMN IF NOT EXISTS backlog integer NOT NULL DEFAULT 0;
+ALTER TABLE scans ADD COLUMN IF NOT EXISTS memory bigint NOT NULL DEFAULT 0;
+
+-- the health of each agent by its last record of the scans, which shawk look
+-- reads to flag the flows of the unhealthy agents by their addresses
+CREATE TABLE IF NOT EXISTS agents (
+    tenant          varchar(63) NOT NULL DEFAULT '',
+    host            varchar(255) NOT NULL,
+    addrs           inet[] NOT NULL DEFAULT '{}',
+    agent_version   varchar(63) NOT NULL DEFAULT '',
+    backend         varchar(15) NOT NULL DEFAULT '',
+    last_record     timestamp NOT NULL,
+    last_success    timestamp, -- the last scan succeeded, or NULL if none
+    errors          integer NOT NULL DEFAULT 0, -- the scans and the writes failed in the last period
+    error           text NOT NULL DEFAULT '',
+    backlog         integer NOT NULL DEFAULT 0, -- the flows waiting to be written
+    memory          bigint NOT NULL DEFAULT 0, -- the bytes of the memory from the OS
+    PRIMARY KEY (tenant, host)
+);
+
+-- the settings of the agents managed by shawk agent-config, which the agents
+-- of the host, or all the agents if host is empty, apply over their environment
+CREATE TABLE IF NOT EXISTS agent_settings (
+    tenant  varchar(63) NOT NULL DEFAULT '',
+    host    varchar(255) NOT NULL DEFAULT '',
+    name    varchar(127) NOT NULL,
+    value   text NOT NULL,
+    updated timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (tenant, host, name)
+);
+-- the agents only read the settings, which the default privileges of
+-- shawk_writer of 'shawk create-scheme --with-roles' would let them write
+DO $$
+BEGIN
+    IF EXISTS (SELECT FROM pg_roles WHERE rolname = 'shawk_writer') THEN
+        REVOKE INSERT, UPDATE, DELETE ON agent_settings FROM shawk_writer;
+    END IF;
+END
+$$;
+
+-- the version of this schema, which is checked by the agents on start
+CREATE TABLE IF NOT EXISTS schema_info (
+    version integer NOT NULL PRIMARY KEY,
+    applied timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+INSERT INTO schema_info (version) VALUES (18) ON CONFLICT (version) DO NOTHING;
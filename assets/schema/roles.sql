@@ -0,0 +1,30 @@
+-- The optional roles of the CMDB, created by 'shawk create-scheme --with-roles'.
+-- They can't log in, but are granted to the users of the operators:
+-- shawk_reader reads the tables and the views for the queries, and
+-- shawk_writer writes the flows as the agents and 'shawk sync' do, but only
+-- reads the settings of the agents, which apply to the whole fleet.
+DO $$
+DECLARE
+	schema_name text := quote_ident(current_schema());
+BEGIN
+	IF NOT EXISTS (SELECT FROM pg_roles WHERE rolname = 'shawk_reader') THEN
+		CREATE ROLE shawk_reader NOLOGIN;
+	END IF;
+	IF NOT EXISTS (SELECT FROM pg_roles WHERE rolname = 'shawk_writer') THEN
+		CREATE ROLE shawk_writer NOLOGIN;
+	END IF;
+
+	EXECUTE 'GRANT USAGE ON SCHEMA ' || schema_name || ' TO shawk_reader, shawk_writer';
+	EXECUTE 'GRANT SELECT ON ALL TABLES IN SCHEMA ' || schema_name || ' TO shawk_reader';
+	EXECUTE 'GRANT SELECT, INSERT, UPDATE, DELETE ON ALL TABLES IN SCHEMA ' || schema_name || ' TO shawk_writer';
+	EXECUTE 'GRANT USAGE, SELECT ON ALL SEQUENCES IN SCHEMA ' || schema_name || ' TO shawk_writer';
+
+	-- The tables and the views created by the later migrations.
+	EXECUTE 'ALTER DEFAULT PRIVILEGES IN SCHEMA ' || schema_name || ' GRANT SELECT ON TABLES TO shawk_reader';
+	EXECUTE 'ALTER DEFAULT PRIVILEGES IN SCHEMA ' || schema_name || ' GRANT SELECT, INSERT, UPDATE, DELETE ON TABLES TO shawk_writer';
+	EXECUTE 'ALTER DEFAULT PRIVILEGES IN SCHEMA ' || schema_name || ' GRANT USAGE, SELECT ON SEQUENCES TO shawk_writer';
+
+	-- The credentials of an agent must not rewrite the sinks of the others.
+	REVOKE INSERT, UPDATE, DELETE ON agent_settings FROM shawk_writer;
+END
+$$;
@@ -0,0 +1,45 @@
+-- The optional rollups of the flows for the aggregate queries, created by
+-- 'shawk create-scheme --views' and refreshed by 'shawk serve --refresh-views'.
+
+-- the connections of each client process of each tenant, whose addresses of
+-- the VRFs are apart from the same ones of the others
+CREATE MATERIALIZED VIEW IF NOT EXISTS top_talkers AS
+    SELECT
+        processes.tenant,
+        processes.ipv4,
+        processes.vrf,
+        processes.pname,
+        SUM(flows.connections)::bigint AS connections,
+        COUNT(*)::bigint AS flows
+    FROM flows
+    INNER JOIN active_nodes ON active_nodes.node_id = flows.source_node_id
+    INNER JOIN processes ON processes.process_id = active_nodes.process_id
+    GROUP BY processes.tenant, processes.ipv4, processes.vrf, processes.pname;
+CREATE UNIQUE INDEX IF NOT EXISTS top_talkers_tenant_ipv4_vrf_pname_key ON top_talkers USING btree (tenant, ipv4, vrf, pname);
+CREATE INDEX IF NOT EXISTS top_talkers_connections_key ON top_talkers USING btree (connections DESC);
+
+-- the edges between the services, which are the processes of the same name,
+-- of each tenant
+CREATE MATERIALIZED VIEW IF NOT EXISTS service_edges AS
+    SELECT
+        flows.tenant,
+        active_processes.pname AS client,
+        passive_processes.pname AS server,
+        passive_nodes.port,
+        SUM(flows.connections)::bigint AS connections,
+        COUNT(DISTINCT active_processes.ipv4)::bigint AS clients,
+        COUNT(DISTINCT passive_processes.ipv4)::bigint AS servers
+    FROM flows
+    INNER JOIN active_nodes ON active_nodes.node_id = flows.source_node_id
+    INNER JOIN processes AS active_processes ON active_processes.process_id = active_nodes.process_id
+    INNER JOIN passive_nodes ON passive_nodes.node_id = flows.destination_node_id
+    INNER JOIN processes AS passive_processes ON passive_processes.process_id = passive_nodes.process_id
+    GROUP BY flows.tenant, active_processes.pname, passive_processes.pname, passive_nodes.port;
+CREATE UNIQUE INDEX IF NOT EXISTS service_edges_tenant_client_server_port_key ON service_edges USING btree (tenant, client, server, port);
+
+-- the last refresh of each view, which tells the queries whether it is fresh
+CREATE TABLE IF NOT EXISTS view_refreshes (
+    name      varchar(63) NOT NULL PRIMARY KEY,
+    refreshed timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+INSERT INTO view_refreshes (name) VALUES ('top_talkers'), ('service_edges') ON CONFLICT (name) DO NOTHING;
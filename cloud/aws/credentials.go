@@ -0,0 +1,170 @@
+package aws
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/xerrors"
+)
+
+// containerCredentialsEndpoint serves the credentials of the ECS task role.
+const containerCredentialsEndpoint = "http://169.254.170.2"
+
+// Credentials are AWS access keys.
+type Credentials struct {
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+	Expiration      time.Time // zero if the credentials never expire
+}
+
+// expired reports whether the credentials should be renewed.
+func (c *Credentials) expired() bool {
+	// renew five minutes before the expiration to leave time for signed requests in flight.
+	return !c.Expiration.IsZero() && time.Now().Add(5*time.Minute).After(c.Expiration)
+}
+
+// CredentialsProvider retrieves credentials.
+type CredentialsProvider interface {
+	Retrieve() (*Credentials, error)
+}
+
+func getenv(keys ...string) string {
+	for _, k := range keys {
+		if v := os.Getenv(k); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// EnvProvider retrieves credentials from AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY.
+type EnvProvider struct{}
+
+// Retrieve retrieves the credentials.
+func (EnvProvider) Retrieve() (*Credentials, error) {
+	id := getenv("AWS_ACCESS_KEY_ID", "AWS_ACCESS_KEY")
+	secret := getenv("AWS_SECRET_ACCESS_KEY", "AWS_SECRET_KEY")
+	if id == "" || secret == "" {
+		return nil, xerrors.New("AWS_ACCESS_KEY_ID or AWS_SECRET_ACCESS_KEY is not set")
+	}
+	return &Credentials{
+		AccessKeyID:     id,
+		SecretAccessKey: secret,
+		SessionToken:    os.Getenv("AWS_SESSION_TOKEN"),
+	}, nil
+}
+
+// remoteCredentials is the JSON document served by the metadata services.
+type remoteCredentials struct {
+	AccessKeyID     string    `json:"AccessKeyId"`
+	SecretAccessKey string    `json:"SecretAccessKey"`
+	Token           string    `json:"Token"`
+	Expiration      time.Time `json:"Expiration"`
+}
+
+func (r *remoteCredentials) credentials() *Credentials {
+	return &Credentials{
+		AccessKeyID:     r.AccessKeyID,
+		SecretAccessKey: r.SecretAccessKey,
+		SessionToken:    r.Token,
+		Expiration:      r.Expiration,
+	}
+}
+
+// ContainerProvider retrieves the credentials of the ECS task role.
+type ContainerProvider struct{}
+
+// Retrieve retrieves the credentials.
+func (ContainerProvider) Retrieve() (*Credentials, error) {
+	uri := os.Getenv("AWS_CONTAINER_CREDENTIALS_RELATIVE_URI")
+	if uri == "" {
+		return nil, xerrors.New("AWS_CONTAINER_CREDENTIALS_RELATIVE_URI is not set")
+	}
+	client := &http.Client{Timeout: imdsTimeout}
+	resp, err := client.Get(containerCredentialsEndpoint + uri)
+	if err != nil {
+		return nil, xerrors.Errorf("could not get container credentials: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, xerrors.Errorf("could not get container credentials: unexpected status %s", resp.Status)
+	}
+	var rc remoteCredentials
+	if err := json.NewDecoder(resp.Body).Decode(&rc); err != nil {
+		return nil, xerrors.Errorf("could not decode container credentials: %w", err)
+	}
+	return rc.credentials(), nil
+}
+
+// InstanceProvider retrieves the credentials of the EC2 instance profile.
+type InstanceProvider struct {
+	IMDS *IMDS
+}
+
+// Retrieve retrieves the credentials.
+func (p *InstanceProvider) Retrieve() (*Credentials, error) {
+	roles, err := p.IMDS.GetMetadata("meta-data/iam/security-credentials/")
+	if err != nil {
+		return nil, xerrors.Errorf("could not get the instance profile: %w", err)
+	}
+	role := strings.TrimSpace(strings.SplitN(roles, "\n", 2)[0])
+	if role == "" {
+		return nil, xerrors.New("no instance profile is attached")
+	}
+	doc, err := p.IMDS.GetMetadata("meta-data/iam/security-credentials/" + role)
+	if err != nil {
+		return nil, xerrors.Errorf("could not get the instance profile credentials: %w", err)
+	}
+	var rc remoteCredentials
+	if err := json.Unmarshal([]byte(doc), &rc); err != nil {
+		return nil, xerrors.Errorf("could not decode the instance profile credentials: %w", err)
+	}
+	return rc.credentials(), nil
+}
+
+// ChainProvider tries the providers in order and caches the first credentials
+// retrieved until they expire.
+type ChainProvider struct {
+	Providers []CredentialsProvider
+
+	mu     sync.Mutex
+	cached *Credentials
+}
+
+// NewDefaultCredentials returns the provider chain of the environment
+// variables, the ECS task role and the EC2 instance profile.
+func NewDefaultCredentials(imds *IMDS) *ChainProvider {
+	return &ChainProvider{
+		Providers: []CredentialsProvider{
+			EnvProvider{},
+			ContainerProvider{},
+			&InstanceProvider{IMDS: imds},
+		},
+	}
+}
+
+// Retrieve retrieves the credentials.
+func (c *ChainProvider) Retrieve() (*Credentials, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.cached != nil && !c.cached.expired() {
+		return c.cached, nil
+	}
+	errs := make([]string, 0, len(c.Providers))
+	for _, p := range c.Providers {
+		creds, err := p.Retrieve()
+		if err != nil {
+			errs = append(errs, err.Error())
+			continue
+		}
+		c.cached = creds
+		return creds, nil
+	}
+	return nil, xerrors.Errorf("no valid AWS credentials: %s", strings.Join(errs, "; "))
+}
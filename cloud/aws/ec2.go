@@ -0,0 +1,217 @@
+package aws
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+
+	"golang.org/x/xerrors"
+)
+
+const (
+	ec2APIVersion = "2016-11-15"
+	ec2Timeout    = 30 * time.Second
+	ec2PageSize   = "1000"
+)
+
+// EC2 is a client of the EC2 Query API.
+type EC2 struct {
+	endpoint   string
+	signer     *Signer
+	httpClient *http.Client
+}
+
+// NewEC2 creates a client of the EC2 API in the region.
+func NewEC2(creds CredentialsProvider, region string) *EC2 {
+	return &EC2{
+		endpoint:   "https://ec2." + region + ".amazonaws.com/",
+		signer:     NewSigner(creds, region, "ec2"),
+		httpClient: &http.Client{Timeout: ec2Timeout},
+	}
+}
+
+type ec2Error struct {
+	Code    string `xml:"Errors>Error>Code"`
+	Message string `xml:"Errors>Error>Message"`
+}
+
+// call invokes the action and decodes the XML response into v.
+func (c *EC2) call(action string, params url.Values, v interface{}) error {
+	form := url.Values{}
+	for k, vs := range params {
+		form[k] = vs
+	}
+	form.Set("Action", action)
+	form.Set("Version", ec2APIVersion)
+	body := []byte(form.Encode())
+
+	req, err := http.NewRequest(http.MethodPost, c.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return xerrors.Errorf("could not create %s request: %w", action, err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded; charset=utf-8")
+	if err := c.signer.Sign(req, body); err != nil {
+		return xerrors.Errorf("could not sign %s request: %w", action, err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return xerrors.Errorf("%s: %w", action, err)
+	}
+	defer resp.Body.Close()
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return xerrors.Errorf("%s: %w", action, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		var e ec2Error
+		if xml.Unmarshal(data, &e) == nil && e.Code != "" {
+			return xerrors.Errorf("%s: %s: %s", action, e.Code, e.Message)
+		}
+		return xerrors.Errorf("%s: unexpected status %s", action, resp.Status)
+	}
+	if err := xml.Unmarshal(data, v); err != nil {
+		return xerrors.Errorf("%s: could not decode response: %w", action, err)
+	}
+	return nil
+}
+
+// Tag is a resource tag.
+type Tag struct {
+	Key   string `xml:"key"`
+	Value string `xml:"value"`
+}
+
+// SecurityGroup is a security group attached to a network interface.
+type SecurityGroup struct {
+	GroupID   string `xml:"groupId"`
+	GroupName string `xml:"groupName"`
+}
+
+// NetworkInterface is an elastic network interface.
+type NetworkInterface struct {
+	NetworkInterfaceID string          `xml:"networkInterfaceId"`
+	VpcID              string          `xml:"vpcId"`
+	Description        string          `xml:"description"`
+	InterfaceType      string          `xml:"interfaceType"`
+	PrivateIPAddresses []string        `xml:"privateIpAddressesSet>item>privateIpAddress"`
+	Groups             []SecurityGroup `xml:"groupSet>item"`
+	InstanceID         string          `xml:"attachment>instanceId"`
+	Tags               []Tag           `xml:"tagSet>item"`
+}
+
+// DescribeNetworkInterfaces lists all network interfaces in the region.
+func (c *EC2) DescribeNetworkInterfaces() ([]NetworkInterface, error) {
+	var enis []NetworkInterface
+	token := ""
+	for {
+		params := url.Values{"MaxResults": {ec2PageSize}}
+		if token != "" {
+			params.Set("NextToken", token)
+		}
+		var resp struct {
+			NetworkInterfaces []NetworkInterface `xml:"networkInterfaceSet>item"`
+			NextToken         string             `xml:"nextToken"`
+		}
+		if err := c.call("DescribeNetworkInterfaces", params, &resp); err != nil {
+			return nil, err
+		}
+		enis = append(enis, resp.NetworkInterfaces...)
+		if resp.NextToken == "" {
+			return enis, nil
+		}
+		token = resp.NextToken
+	}
+}
+
+// Instance is an EC2 instance.
+type Instance struct {
+	InstanceID       string `xml:"instanceId"`
+	AvailabilityZone string `xml:"placement>availabilityZone"`
+	Tags             []Tag  `xml:"tagSet>item"`
+}
+
+// Name returns the value of the Name tag.
+func (i *Instance) Name() string {
+	for _, t := range i.Tags {
+		if t.Key == "Name" {
+			return t.Value
+		}
+	}
+	return ""
+}
+
+// DescribeInstances lists all instances in the region.
+func (c *EC2) DescribeInstances() ([]Instance, error) {
+	var instances []Instance
+	token := ""
+	for {
+		params := url.Values{"MaxResults": {ec2PageSize}}
+		if token != "" {
+			params.Set("NextToken", token)
+		}
+		var resp struct {
+			Reservations []struct {
+				Instances []Instance `xml:"instancesSet>item"`
+			} `xml:"reservationSet>item"`
+			NextToken string `xml:"nextToken"`
+		}
+		if err := c.call("DescribeInstances", params, &resp); err != nil {
+			return nil, err
+		}
+		for _, r := range resp.Reservations {
+			instances = append(instances, r.Instances...)
+		}
+		if resp.NextToken == "" {
+			return instances, nil
+		}
+		token = resp.NextToken
+	}
+}
+
+// IPPermission is an inbound rule of a security group, which permits the
+// protocol on the ports from the CIDR blocks and the security groups.
+type IPPermission struct {
+	// IPProtocol is "tcp", "udp", "icmp", a protocol number, or "-1" for
+	// all the protocols and the ports.
+	IPProtocol string   `xml:"ipProtocol"`
+	FromPort   int      `xml:"fromPort"`
+	ToPort     int      `xml:"toPort"`
+	CIDRs      []string `xml:"ipRanges>item>cidrIp"`
+	GroupIDs   []string `xml:"groups>item>groupId"`
+}
+
+// SecurityGroupRules is a security group with its inbound rules.
+type SecurityGroupRules struct {
+	GroupID     string         `xml:"groupId"`
+	GroupName   string         `xml:"groupName"`
+	VpcID       string         `xml:"vpcId"`
+	Permissions []IPPermission `xml:"ipPermissions>item"`
+}
+
+// DescribeSecurityGroups lists all security groups in the region.
+func (c *EC2) DescribeSecurityGroups() ([]SecurityGroupRules, error) {
+	var groups []SecurityGroupRules
+	token := ""
+	for {
+		params := url.Values{"MaxResults": {ec2PageSize}}
+		if token != "" {
+			params.Set("NextToken", token)
+		}
+		var resp struct {
+			SecurityGroups []SecurityGroupRules `xml:"securityGroupInfo>item"`
+			NextToken      string               `xml:"nextToken"`
+		}
+		if err := c.call("DescribeSecurityGroups", params, &resp); err != nil {
+			return nil, err
+		}
+		groups = append(groups, resp.SecurityGroups...)
+		if resp.NextToken == "" {
+			return groups, nil
+		}
+		token = resp.NextToken
+	}
+}
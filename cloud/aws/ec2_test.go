@@ -0,0 +1,179 @@
+package aws
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDescribeNetworkInterfaces(t *testing.T) {
+	pages := map[string]string{
+		"": `<DescribeNetworkInterfacesResponse xmlns="http://ec2.amazonaws.com/doc/2016-11-15/">
+	<requestId>1</requestId>
+	<networkInterfaceSet>
+		<item>
+			<networkInterfaceId>eni-1</networkInterfaceId>
+			<description>Primary network interface</description>
+			<interfaceType>interface</interfaceType>
+			<privateIpAddressesSet>
+				<item><privateIpAddress>10.0.0.1</privateIpAddress><primary>true</primary></item>
+				<item><privateIpAddress>10.0.0.2</privateIpAddress><primary>false</primary></item>
+			</privateIpAddressesSet>
+			<groupSet>
+				<item><groupId>sg-1</groupId><groupName>web</groupName></item>
+			</groupSet>
+			<attachment><instanceId>i-1</instanceId></attachment>
+		</item>
+	</networkInterfaceSet>
+	<nextToken>page2</nextToken>
+</DescribeNetworkInterfacesResponse>`,
+		"page2": `<DescribeNetworkInterfacesResponse xmlns="http://ec2.amazonaws.com/doc/2016-11-15/">
+	<requestId>2</requestId>
+	<networkInterfaceSet>
+		<item>
+			<networkInterfaceId>eni-2</networkInterfaceId>
+			<description>RDSNetworkInterface</description>
+			<interfaceType>interface</interfaceType>
+			<privateIpAddressesSet>
+				<item><privateIpAddress>10.0.1.5</privateIpAddress><primary>true</primary></item>
+			</privateIpAddressesSet>
+			<groupSet>
+				<item><groupId>sg-2</groupId><groupName>db</groupName></item>
+				<item><groupId>sg-3</groupId><groupName>default</groupName></item>
+			</groupSet>
+		</item>
+	</networkInterfaceSet>
+</DescribeNetworkInterfacesResponse>`,
+	}
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.Header.Get("Authorization"), signingAlgorithm) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		body, _ := ioutil.ReadAll(r.Body)
+		form, _ := url.ParseQuery(string(body))
+		if form.Get("Action") != "DescribeNetworkInterfaces" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.Write([]byte(pages[form.Get("NextToken")]))
+	}))
+	defer ts.Close()
+
+	c := NewEC2(staticProvider{AccessKeyID: "id", SecretAccessKey: "secret"}, "us-east-1")
+	c.endpoint = ts.URL + "/"
+
+	got, err := c.DescribeNetworkInterfaces()
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	want := []NetworkInterface{
+		{
+			NetworkInterfaceID: "eni-1",
+			Description:        "Primary network interface",
+			InterfaceType:      "interface",
+			PrivateIPAddresses: []string{"10.0.0.1", "10.0.0.2"},
+			Groups:             []SecurityGroup{{GroupID: "sg-1", GroupName: "web"}},
+			InstanceID:         "i-1",
+		},
+		{
+			NetworkInterfaceID: "eni-2",
+			Description:        "RDSNetworkInterface",
+			InterfaceType:      "interface",
+			PrivateIPAddresses: []string{"10.0.1.5"},
+			Groups: []SecurityGroup{
+				{GroupID: "sg-2", GroupName: "db"},
+				{GroupID: "sg-3", GroupName: "default"},
+			},
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("DescribeNetworkInterfaces() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestDescribeSecurityGroups(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		form, _ := url.ParseQuery(string(body))
+		if form.Get("Action") != "DescribeSecurityGroups" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.Write([]byte(`<DescribeSecurityGroupsResponse xmlns="http://ec2.amazonaws.com/doc/2016-11-15/">
+	<requestId>1</requestId>
+	<securityGroupInfo>
+		<item>
+			<ownerId>123456789012</ownerId>
+			<groupId>sg-1</groupId>
+			<groupName>web</groupName>
+			<vpcId>vpc-1</vpcId>
+			<ipPermissions>
+				<item>
+					<ipProtocol>tcp</ipProtocol>
+					<fromPort>80</fromPort>
+					<toPort>80</toPort>
+					<groups/>
+					<ipRanges>
+						<item><cidrIp>0.0.0.0/0</cidrIp></item>
+					</ipRanges>
+				</item>
+				<item>
+					<ipProtocol>-1</ipProtocol>
+					<groups>
+						<item><userId>123456789012</userId><groupId>sg-2</groupId></item>
+					</groups>
+					<ipRanges/>
+				</item>
+			</ipPermissions>
+		</item>
+	</securityGroupInfo>
+</DescribeSecurityGroupsResponse>`))
+	}))
+	defer ts.Close()
+
+	c := NewEC2(staticProvider{AccessKeyID: "id", SecretAccessKey: "secret"}, "us-east-1")
+	c.endpoint = ts.URL + "/"
+
+	got, err := c.DescribeSecurityGroups()
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	want := []SecurityGroupRules{
+		{
+			GroupID:   "sg-1",
+			GroupName: "web",
+			VpcID:     "vpc-1",
+			Permissions: []IPPermission{
+				{IPProtocol: "tcp", FromPort: 80, ToPort: 80, CIDRs: []string{"0.0.0.0/0"}},
+				{IPProtocol: "-1", GroupIDs: []string{"sg-2"}},
+			},
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("DescribeSecurityGroups() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestEC2_error(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(`<Response><Errors><Error><Code>UnauthorizedOperation</Code>` +
+			`<Message>You are not authorized to perform this operation.</Message></Error></Errors></Response>`))
+	}))
+	defer ts.Close()
+
+	c := NewEC2(staticProvider{AccessKeyID: "id", SecretAccessKey: "secret"}, "us-east-1")
+	c.endpoint = ts.URL + "/"
+
+	_, err := c.DescribeInstances()
+	if err == nil || !strings.Contains(err.Error(), "UnauthorizedOperation") {
+		t.Errorf("DescribeInstances() should return UnauthorizedOperation, but %v", err)
+	}
+}
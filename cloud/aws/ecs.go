@@ -0,0 +1,284 @@
+package aws
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"golang.org/x/xerrors"
+)
+
+const (
+	ecsTimeout = 30 * time.Second
+	// ecsTargetPrefix is the prefix of X-Amz-Target of the actions of the
+	// ECS API.
+	ecsTargetPrefix = "AmazonEC2ContainerServiceV20141113."
+	// ecsPageSize is the maximum number of the tasks of ListTasks and
+	// DescribeTasks.
+	ecsPageSize = 100
+	// taskMetadataTimeout is short since the task metadata endpoint is
+	// local to the task.
+	taskMetadataTimeout = 2 * time.Second
+)
+
+// ErrNotECS is returned if the process is not running in an ECS task.
+var ErrNotECS = xerrors.New("not running in an ECS task: ECS_CONTAINER_METADATA_URI_V4 is not set")
+
+// TaskMetadata is the task served by the task metadata endpoint version 4
+// of ECS.
+type TaskMetadata struct {
+	// Cluster is the ARN of the cluster of the task.
+	Cluster          string `json:"Cluster"`
+	TaskARN          string `json:"TaskARN"`
+	Family           string `json:"Family"`
+	Revision         string `json:"Revision"`
+	ServiceName      string `json:"ServiceName"`
+	LaunchType       string `json:"LaunchType"`
+	AvailabilityZone string `json:"AvailabilityZone"`
+	Containers       []struct {
+		Networks []struct {
+			NetworkMode   string   `json:"NetworkMode"`
+			IPv4Addresses []string `json:"IPv4Addresses"`
+		} `json:"Networks"`
+	} `json:"Containers"`
+}
+
+// ClusterName returns the name of the cluster of its ARN.
+func (t *TaskMetadata) ClusterName() string {
+	if i := strings.LastIndex(t.Cluster, "/"); i >= 0 {
+		return t.Cluster[i+1:]
+	}
+	return t.Cluster
+}
+
+// Region returns the region of the task of its ARN, such as
+// 'arn:aws:ecs:us-east-1:123456789012:task/...'.
+func (t *TaskMetadata) Region() string {
+	parts := strings.SplitN(t.TaskARN, ":", 5)
+	if len(parts) < 5 {
+		return ""
+	}
+	return parts[3]
+}
+
+// Addrs returns the addresses of the containers of the task in the awsvpc
+// network mode, which are the addresses of the ENI of the task.
+func (t *TaskMetadata) Addrs() []string {
+	seen := map[string]bool{}
+	var addrs []string
+	for _, c := range t.Containers {
+		for _, n := range c.Networks {
+			for _, a := range n.IPv4Addresses {
+				if !seen[a] {
+					seen[a] = true
+					addrs = append(addrs, a)
+				}
+			}
+		}
+	}
+	return addrs
+}
+
+// GetTaskMetadata gets the task of the process from the task metadata
+// endpoint. It returns ErrNotECS outside of ECS.
+func GetTaskMetadata() (*TaskMetadata, error) {
+	uri := os.Getenv("ECS_CONTAINER_METADATA_URI_V4")
+	if uri == "" {
+		return nil, ErrNotECS
+	}
+	return getTaskMetadata(uri)
+}
+
+func getTaskMetadata(uri string) (*TaskMetadata, error) {
+	client := &http.Client{Timeout: taskMetadataTimeout}
+	resp, err := client.Get(uri + "/task")
+	if err != nil {
+		return nil, xerrors.Errorf("could not get the task metadata: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, xerrors.Errorf("could not get the task metadata: unexpected status %s", resp.Status)
+	}
+	var t TaskMetadata
+	if err := json.NewDecoder(resp.Body).Decode(&t); err != nil {
+		return nil, xerrors.Errorf("could not decode the task metadata: %w", err)
+	}
+	return &t, nil
+}
+
+// ECS is a client of the ECS API.
+type ECS struct {
+	endpoint   string
+	signer     *Signer
+	httpClient *http.Client
+}
+
+// NewECS creates a client of the ECS API in the region.
+func NewECS(creds CredentialsProvider, region string) *ECS {
+	return &ECS{
+		endpoint:   "https://ecs." + region + ".amazonaws.com/",
+		signer:     NewSigner(creds, region, "ecs"),
+		httpClient: &http.Client{Timeout: ecsTimeout},
+	}
+}
+
+type ecsError struct {
+	Type    string `json:"__type"`
+	Message string `json:"message"`
+}
+
+// call invokes the action with the JSON of in and decodes the JSON response
+// into out.
+func (c *ECS) call(action string, in, out interface{}) error {
+	body, err := json.Marshal(in)
+	if err != nil {
+		return xerrors.Errorf("could not encode %s request: %w", action, err)
+	}
+	req, err := http.NewRequest(http.MethodPost, c.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return xerrors.Errorf("could not create %s request: %w", action, err)
+	}
+	req.Header.Set("Content-Type", "application/x-amz-json-1.1")
+	req.Header.Set("X-Amz-Target", ecsTargetPrefix+action)
+	if err := c.signer.Sign(req, body); err != nil {
+		return xerrors.Errorf("could not sign %s request: %w", action, err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return xerrors.Errorf("%s: %w", action, err)
+	}
+	defer resp.Body.Close()
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return xerrors.Errorf("%s: %w", action, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		var e ecsError
+		if json.Unmarshal(data, &e) == nil && e.Type != "" {
+			// The type is prefixed with the namespace such as
+			// 'com.amazonaws.ecs#ClusterNotFoundException'.
+			typ := e.Type[strings.LastIndex(e.Type, "#")+1:]
+			return xerrors.Errorf("%s: %s: %s", action, typ, e.Message)
+		}
+		return xerrors.Errorf("%s: unexpected status %s", action, resp.Status)
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return xerrors.Errorf("%s: could not decode response: %w", action, err)
+	}
+	return nil
+}
+
+// ListTasks lists the ARNs of the running tasks of the cluster.
+func (c *ECS) ListTasks(cluster string) ([]string, error) {
+	var arns []string
+	token := ""
+	for {
+		in := map[string]interface{}{"cluster": cluster, "maxResults": ecsPageSize}
+		if token != "" {
+			in["nextToken"] = token
+		}
+		var resp struct {
+			TaskARNs  []string `json:"taskArns"`
+			NextToken string   `json:"nextToken"`
+		}
+		if err := c.call("ListTasks", in, &resp); err != nil {
+			return nil, err
+		}
+		arns = append(arns, resp.TaskARNs...)
+		if resp.NextToken == "" {
+			return arns, nil
+		}
+		token = resp.NextToken
+	}
+}
+
+// Task is a task of ECS.
+type Task struct {
+	TaskARN           string `json:"taskArn"`
+	TaskDefinitionARN string `json:"taskDefinitionArn"`
+	// Group is 'service:NAME' for the tasks of a service, or 'family:NAME'
+	// for the standalone tasks.
+	Group       string `json:"group"`
+	Attachments []struct {
+		Type    string `json:"type"`
+		Details []struct {
+			Name  string `json:"name"`
+			Value string `json:"value"`
+		} `json:"details"`
+	} `json:"attachments"`
+	Containers []struct {
+		NetworkInterfaces []struct {
+			PrivateIPv4Address string `json:"privateIpv4Address"`
+		} `json:"networkInterfaces"`
+	} `json:"containers"`
+}
+
+// Service returns the name of the service of the task, or empty for a
+// standalone task.
+func (t *Task) Service() string {
+	if strings.HasPrefix(t.Group, "service:") {
+		return strings.TrimPrefix(t.Group, "service:")
+	}
+	return ""
+}
+
+// Family returns the family of the task definition of its ARN, such as
+// 'arn:aws:ecs:us-east-1:123456789012:task-definition/web:3'.
+func (t *Task) Family() string {
+	family := t.TaskDefinitionARN[strings.LastIndex(t.TaskDefinitionARN, "/")+1:]
+	if i := strings.LastIndex(family, ":"); i >= 0 {
+		family = family[:i]
+	}
+	return family
+}
+
+// Addrs returns the private addresses of the ENI of the task in the awsvpc
+// network mode.
+func (t *Task) Addrs() []string {
+	seen := map[string]bool{}
+	var addrs []string
+	add := func(a string) {
+		if a != "" && !seen[a] {
+			seen[a] = true
+			addrs = append(addrs, a)
+		}
+	}
+	for _, a := range t.Attachments {
+		for _, d := range a.Details {
+			if d.Name == "privateIPv4Address" {
+				add(d.Value)
+			}
+		}
+	}
+	for _, c := range t.Containers {
+		for _, n := range c.NetworkInterfaces {
+			add(n.PrivateIPv4Address)
+		}
+	}
+	return addrs
+}
+
+// DescribeTasks describes the tasks of the ARNs of the cluster.
+func (c *ECS) DescribeTasks(cluster string, arns []string) ([]Task, error) {
+	var tasks []Task
+	for len(arns) > 0 {
+		n := len(arns)
+		if n > ecsPageSize {
+			n = ecsPageSize
+		}
+		var resp struct {
+			Tasks []Task `json:"tasks"`
+		}
+		if err := c.call("DescribeTasks", map[string]interface{}{"cluster": cluster, "tasks": arns[:n]}, &resp); err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, resp.Tasks...)
+		arns = arns[n:]
+	}
+	return tasks, nil
+}
@@ -0,0 +1,135 @@
+package aws
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestGetTaskMetadata(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v4/abc/task" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{
+	"Cluster": "arn:aws:ecs:us-west-2:123456789012:cluster/prod",
+	"TaskARN": "arn:aws:ecs:us-west-2:123456789012:task/prod/0123",
+	"Family": "web",
+	"Revision": "3",
+	"ServiceName": "web",
+	"LaunchType": "FARGATE",
+	"Containers": [
+		{"Networks": [{"NetworkMode": "awsvpc", "IPv4Addresses": ["10.0.2.15"]}]},
+		{"Networks": [{"NetworkMode": "awsvpc", "IPv4Addresses": ["10.0.2.15"]}]}
+	]
+}`))
+	}))
+	defer ts.Close()
+
+	task, err := getTaskMetadata(ts.URL + "/v4/abc")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if got, want := task.ClusterName(), "prod"; got != want {
+		t.Errorf("ClusterName() = %q, want %q", got, want)
+	}
+	if got, want := task.Region(), "us-west-2"; got != want {
+		t.Errorf("Region() = %q, want %q", got, want)
+	}
+	if diff := cmp.Diff([]string{"10.0.2.15"}, task.Addrs()); diff != "" {
+		t.Errorf("Addrs() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestECSTasks(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.Header.Get("Authorization"), signingAlgorithm) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		var in struct {
+			Cluster   string   `json:"cluster"`
+			NextToken string   `json:"nextToken"`
+			Tasks     []string `json:"tasks"`
+		}
+		json.NewDecoder(r.Body).Decode(&in)
+		if in.Cluster != "prod" {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"__type":"com.amazonaws.ecs#ClusterNotFoundException","message":"Cluster not found."}`))
+			return
+		}
+		switch r.Header.Get("X-Amz-Target") {
+		case ecsTargetPrefix + "ListTasks":
+			if in.NextToken == "" {
+				w.Write([]byte(`{"taskArns":["arn:task/1"],"nextToken":"page2"}`))
+				return
+			}
+			w.Write([]byte(`{"taskArns":["arn:task/2"]}`))
+		case ecsTargetPrefix + "DescribeTasks":
+			if diff := cmp.Diff([]string{"arn:task/1", "arn:task/2"}, in.Tasks); diff != "" {
+				t.Errorf("DescribeTasks tasks mismatch (-want +got):\n%s", diff)
+			}
+			w.Write([]byte(`{"tasks":[
+	{
+		"taskArn": "arn:task/1",
+		"taskDefinitionArn": "arn:aws:ecs:us-west-2:123456789012:task-definition/web:3",
+		"group": "service:web",
+		"attachments": [{"type": "ElasticNetworkInterface", "details": [
+			{"name": "networkInterfaceId", "value": "eni-1"},
+			{"name": "privateIPv4Address", "value": "10.0.2.15"}
+		]}],
+		"containers": [{"networkInterfaces": [{"privateIpv4Address": "10.0.2.15"}]}]
+	},
+	{
+		"taskArn": "arn:task/2",
+		"taskDefinitionArn": "arn:aws:ecs:us-west-2:123456789012:task-definition/migrate:1",
+		"group": "family:migrate"
+	}
+]}`))
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer ts.Close()
+
+	c := NewECS(staticProvider{AccessKeyID: "id", SecretAccessKey: "secret"}, "us-west-2")
+	c.endpoint = ts.URL + "/"
+
+	arns, err := c.ListTasks("prod")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if diff := cmp.Diff([]string{"arn:task/1", "arn:task/2"}, arns); diff != "" {
+		t.Errorf("ListTasks() mismatch (-want +got):\n%s", diff)
+	}
+
+	tasks, err := c.DescribeTasks("prod", arns)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	type summary struct {
+		Service, Family string
+		Addrs           []string
+	}
+	got := make([]summary, 0, len(tasks))
+	for i := range tasks {
+		got = append(got, summary{tasks[i].Service(), tasks[i].Family(), tasks[i].Addrs()})
+	}
+	want := []summary{
+		{Service: "web", Family: "web", Addrs: []string{"10.0.2.15"}},
+		{Service: "", Family: "migrate"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("DescribeTasks() mismatch (-want +got):\n%s", diff)
+	}
+
+	_, err = c.ListTasks("dev")
+	if err == nil || !strings.Contains(err.Error(), "ClusterNotFoundException: Cluster not found.") {
+		t.Errorf("ListTasks(dev) error = %v, want ClusterNotFoundException", err)
+	}
+}
@@ -0,0 +1,137 @@
+package aws
+
+import (
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/xerrors"
+)
+
+const (
+	imdsEndpoint = "http://169.254.169.254"
+	imdsTokenTTL = 6 * time.Hour
+	// The metadata service is link-local, so that a short timeout avoids
+	// stalling outside of EC2.
+	imdsTimeout = 2 * time.Second
+)
+
+// ErrMetadataNotFound is returned if the metadata service does not serve the path.
+var ErrMetadataNotFound = xerrors.New("metadata not found")
+
+// IMDS is a client of the EC2 instance metadata service (IMDSv2).
+type IMDS struct {
+	endpoint   string
+	httpClient *http.Client
+
+	mu          sync.Mutex
+	token       string
+	tokenExpiry time.Time
+}
+
+// NewIMDS creates a client of the instance metadata service.
+func NewIMDS() *IMDS {
+	return &IMDS{
+		endpoint:   imdsEndpoint,
+		httpClient: &http.Client{Timeout: imdsTimeout},
+	}
+}
+
+// sessionToken returns the IMDSv2 session token, fetching a new one if expired.
+func (m *IMDS) sessionToken() (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.token != "" && time.Now().Before(m.tokenExpiry) {
+		return m.token, nil
+	}
+
+	req, err := http.NewRequest(http.MethodPut, m.endpoint+"/latest/api/token", nil)
+	if err != nil {
+		return "", xerrors.Errorf("could not create token request: %w", err)
+	}
+	req.Header.Set("X-aws-ec2-metadata-token-ttl-seconds",
+		strconv.Itoa(int(imdsTokenTTL/time.Second)))
+	resp, err := m.httpClient.Do(req)
+	if err != nil {
+		return "", xerrors.Errorf("could not get IMDSv2 token: %w", err)
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", xerrors.Errorf("could not read IMDSv2 token: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", xerrors.Errorf("could not get IMDSv2 token: unexpected status %s", resp.Status)
+	}
+
+	m.token = string(body)
+	// renew the token a minute before it expires.
+	m.tokenExpiry = time.Now().Add(imdsTokenTTL - time.Minute)
+	return m.token, nil
+}
+
+// GetMetadata returns the value of the metadata path such as 'meta-data/instance-id'.
+func (m *IMDS) GetMetadata(path string) (string, error) {
+	token, err := m.sessionToken()
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequest(http.MethodGet, m.endpoint+"/latest/"+strings.TrimPrefix(path, "/"), nil)
+	if err != nil {
+		return "", xerrors.Errorf("could not create metadata request: %w", err)
+	}
+	req.Header.Set("X-aws-ec2-metadata-token", token)
+	resp, err := m.httpClient.Do(req)
+	if err != nil {
+		return "", xerrors.Errorf("GET %s: %w", path, err)
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", xerrors.Errorf("GET %s: %w", path, err)
+	}
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return string(body), nil
+	case http.StatusNotFound:
+		return "", xerrors.Errorf("GET %s: %w", path, ErrMetadataNotFound)
+	default:
+		return "", xerrors.Errorf("GET %s: unexpected status %s", path, resp.Status)
+	}
+}
+
+// Region returns the region from AWS_REGION, AWS_DEFAULT_REGION or the metadata service.
+func Region(imds *IMDS) (string, error) {
+	if r := getenv("AWS_REGION", "AWS_DEFAULT_REGION"); r != "" {
+		return r, nil
+	}
+	r, err := imds.GetMetadata("meta-data/placement/region")
+	if err != nil {
+		return "", xerrors.Errorf("could not detect the AWS region, set AWS_REGION: %w", err)
+	}
+	return r, nil
+}
+
+// InstanceTags returns the tags of the instance. The tags are only served if
+// the instance enables 'instance metadata tags', otherwise it returns an error
+// wrapping ErrMetadataNotFound.
+func (m *IMDS) InstanceTags() (map[string]string, error) {
+	keys, err := m.GetMetadata("meta-data/tags/instance")
+	if err != nil {
+		return nil, err
+	}
+	tags := map[string]string{}
+	for _, key := range strings.Fields(keys) {
+		v, err := m.GetMetadata("meta-data/tags/instance/" + key)
+		if err != nil {
+			return nil, err
+		}
+		tags[key] = v
+	}
+	return tags, nil
+}
@@ -0,0 +1,57 @@
+package aws
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"golang.org/x/xerrors"
+)
+
+func newTestIMDS(t *testing.T, metadata map[string]string) *IMDS {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPut && r.URL.Path == "/latest/api/token" {
+			w.Write([]byte("token"))
+			return
+		}
+		if r.Header.Get("X-aws-ec2-metadata-token") != "token" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		v, ok := metadata[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(v))
+	}))
+	t.Cleanup(ts.Close)
+	return &IMDS{endpoint: ts.URL, httpClient: ts.Client()}
+}
+
+func TestInstanceTags(t *testing.T) {
+	imds := newTestIMDS(t, map[string]string{
+		"/latest/meta-data/tags/instance":      "Name\nrole",
+		"/latest/meta-data/tags/instance/Name": "web-1",
+		"/latest/meta-data/tags/instance/role": "frontend",
+	})
+
+	tags, err := imds.InstanceTags()
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	want := map[string]string{"Name": "web-1", "role": "frontend"}
+	if diff := cmp.Diff(want, tags); diff != "" {
+		t.Errorf("InstanceTags() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestInstanceTags_disabled(t *testing.T) {
+	imds := newTestIMDS(t, map[string]string{})
+
+	_, err := imds.InstanceTags()
+	if !xerrors.Is(err, ErrMetadataNotFound) {
+		t.Errorf("InstanceTags() should return ErrMetadataNotFound, but %v", err)
+	}
+}
@@ -0,0 +1,78 @@
+package aws
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/xerrors"
+)
+
+const (
+	// rdsAuthTokenTTL is how long an IAM authentication token of RDS is
+	// valid to connect.
+	rdsAuthTokenTTL = 15 * time.Minute
+	// rdsAuthTokenRenewal is how long before the expiry a token is renewed.
+	rdsAuthTokenRenewal = 5 * time.Minute
+)
+
+// RDSAuthToken generates the IAM authentication token to connect to the DB
+// instance of RDS at endpoint ('host:port') as user, which is used as the
+// password for 15 minutes.
+// see https://docs.aws.amazon.com/AmazonRDS/latest/UserGuide/UsingWithRDS.IAMDBAuth.Connecting.html.
+func RDSAuthToken(signer *Signer, endpoint, user string) (string, error) {
+	if !strings.Contains(endpoint, ":") {
+		return "", xerrors.Errorf("the endpoint of RDS must be 'host:port', but %q", endpoint)
+	}
+	q := url.Values{"Action": {"connect"}, "DBUser": {user}}
+	req, err := http.NewRequest(http.MethodGet, "https://"+endpoint+"/?"+q.Encode(), nil)
+	if err != nil {
+		return "", xerrors.Errorf("could not create the RDS auth request: %w", err)
+	}
+	signed, err := signer.Presign(req, rdsAuthTokenTTL)
+	if err != nil {
+		return "", xerrors.Errorf("could not sign the RDS auth token: %w", err)
+	}
+	return strings.TrimPrefix(signed, "https://"), nil
+}
+
+// RDSTokenSource generates the IAM authentication tokens of RDS, caching a
+// token until shortly before it expires.
+type RDSTokenSource struct {
+	signer   *Signer
+	endpoint string
+	user     string
+
+	mu     sync.Mutex
+	token  string
+	expiry time.Time
+}
+
+// NewRDSTokenSource creates a RDSTokenSource for the DB instance at endpoint
+// in the region as user.
+func NewRDSTokenSource(creds CredentialsProvider, region, endpoint, user string) *RDSTokenSource {
+	return &RDSTokenSource{
+		signer:   NewSigner(creds, region, "rds-db"),
+		endpoint: endpoint,
+		user:     user,
+	}
+}
+
+// Token returns the cached token, or a new one if it expires soon.
+func (s *RDSTokenSource) Token() (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.signer.now()
+	if s.token != "" && now.Add(rdsAuthTokenRenewal).Before(s.expiry) {
+		return s.token, nil
+	}
+	token, err := RDSAuthToken(s.signer, s.endpoint, s.user)
+	if err != nil {
+		return "", err
+	}
+	s.token, s.expiry = token, now.Add(rdsAuthTokenTTL)
+	return token, nil
+}
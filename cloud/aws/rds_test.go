@@ -0,0 +1,58 @@
+package aws
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRDSTokenSource(t *testing.T) {
+	now := time.Date(2020, 12, 20, 12, 0, 0, 0, time.UTC)
+	s := NewRDSTokenSource(staticProvider{
+		AccessKeyID:     "AKIDEXAMPLE",
+		SecretAccessKey: "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY",
+		SessionToken:    "session",
+	}, "ap-northeast-1", "shawk.xxxx.ap-northeast-1.rds.amazonaws.com:5432", "shawk")
+	s.signer.now = func() time.Time { return now }
+
+	token, err := s.Token()
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	prefix := "shawk.xxxx.ap-northeast-1.rds.amazonaws.com:5432/?"
+	if !strings.HasPrefix(token, prefix) {
+		t.Fatalf("the token should start with %q, but %q", prefix, token)
+	}
+	q, err := url.ParseQuery(strings.TrimPrefix(token, prefix))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for k, want := range map[string]string{
+		"Action":               "connect",
+		"DBUser":               "shawk",
+		"X-Amz-Credential":     "AKIDEXAMPLE/20201220/ap-northeast-1/rds-db/aws4_request",
+		"X-Amz-Expires":        "900",
+		"X-Amz-Security-Token": "session",
+	} {
+		if got := q.Get(k); got != want {
+			t.Errorf("%s should be %q, but %q", k, want, got)
+		}
+	}
+	if q.Get("X-Amz-Signature") == "" {
+		t.Error("the token should be signed")
+	}
+
+	now = now.Add(9 * time.Minute)
+	if cached, _ := s.Token(); cached != token {
+		t.Error("the token should be cached until shortly before it expires")
+	}
+	now = now.Add(2 * time.Minute)
+	if renewed, _ := s.Token(); renewed == token {
+		t.Error("the token should be renewed before it expires")
+	}
+
+	if _, err := RDSAuthToken(s.signer, "shawk.xxxx.rds.amazonaws.com", "shawk"); err == nil {
+		t.Error("RDSAuthToken() should return an error for the endpoint without the port")
+	}
+}
@@ -0,0 +1,196 @@
+package aws
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	signingAlgorithm = "AWS4-HMAC-SHA256"
+	amzDateFormat    = "20060102T150405Z"
+	shortDateFormat  = "20060102"
+)
+
+// Signer signs HTTP requests with AWS Signature Version 4.
+// see https://docs.aws.amazon.com/general/latest/gr/sigv4_signing.html.
+type Signer struct {
+	Credentials CredentialsProvider
+	Region      string
+	Service     string
+
+	now func() time.Time
+}
+
+// NewSigner creates a Signer for the service in the region.
+func NewSigner(creds CredentialsProvider, region, service string) *Signer {
+	return &Signer{Credentials: creds, Region: region, Service: service, now: time.Now}
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
+
+func hashSHA256(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// escape encodes s as RFC 3986 requires.
+func escape(s string) string {
+	return strings.Replace(url.QueryEscape(s), "+", "%20", -1)
+}
+
+func canonicalQuery(q url.Values) string {
+	keys := make([]string, 0, len(q))
+	for k := range q {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	pairs := make([]string, 0, len(q))
+	for _, k := range keys {
+		vs := append([]string{}, q[k]...)
+		sort.Strings(vs)
+		for _, v := range vs {
+			pairs = append(pairs, escape(k)+"="+escape(v))
+		}
+	}
+	return strings.Join(pairs, "&")
+}
+
+// canonicalHeaders returns the canonical headers and the signed header names.
+func canonicalHeaders(req *http.Request) (string, string) {
+	headers := map[string]string{"host": req.URL.Host}
+	if req.Host != "" {
+		headers["host"] = req.Host
+	}
+	for k, vs := range req.Header {
+		name := strings.ToLower(k)
+		if name == "authorization" || name == "user-agent" {
+			continue
+		}
+		values := make([]string, 0, len(vs))
+		for _, v := range vs {
+			values = append(values, strings.Join(strings.Fields(v), " "))
+		}
+		headers[name] = strings.Join(values, ",")
+	}
+	names := make([]string, 0, len(headers))
+	for k := range headers {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	for _, k := range names {
+		b.WriteString(k + ":" + headers[k] + "\n")
+	}
+	return b.String(), strings.Join(names, ";")
+}
+
+func (s *Signer) signingKey(secret string, t time.Time) []byte {
+	kDate := hmacSHA256([]byte("AWS4"+secret), t.Format(shortDateFormat))
+	kRegion := hmacSHA256(kDate, s.Region)
+	kService := hmacSHA256(kRegion, s.Service)
+	return hmacSHA256(kService, "aws4_request")
+}
+
+func (s *Signer) scope(t time.Time) string {
+	return strings.Join([]string{t.Format(shortDateFormat), s.Region, s.Service, "aws4_request"}, "/")
+}
+
+// signature computes the signature of the canonical request.
+func (s *Signer) signature(creds *Credentials, t time.Time, canonicalRequest string) string {
+	stringToSign := strings.Join([]string{
+		signingAlgorithm,
+		t.Format(amzDateFormat),
+		s.scope(t),
+		hashSHA256([]byte(canonicalRequest)),
+	}, "\n")
+	return hex.EncodeToString(hmacSHA256(s.signingKey(creds.SecretAccessKey, t), stringToSign))
+}
+
+func canonicalPath(u *url.URL) string {
+	if p := u.EscapedPath(); p != "" {
+		return p
+	}
+	return "/"
+}
+
+// Sign adds the Authorization header to the request whose payload is body.
+func (s *Signer) Sign(req *http.Request, body []byte) error {
+	creds, err := s.Credentials.Retrieve()
+	if err != nil {
+		return err
+	}
+	t := s.now().UTC()
+
+	req.Header.Set("X-Amz-Date", t.Format(amzDateFormat))
+	if creds.SessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", creds.SessionToken)
+	}
+
+	headers, signedHeaders := canonicalHeaders(req)
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		canonicalPath(req.URL),
+		canonicalQuery(req.URL.Query()),
+		headers,
+		signedHeaders,
+		hashSHA256(body),
+	}, "\n")
+
+	req.Header.Set("Authorization", signingAlgorithm+
+		" Credential="+creds.AccessKeyID+"/"+s.scope(t)+
+		", SignedHeaders="+signedHeaders+
+		", Signature="+s.signature(creds, t, canonicalRequest))
+	return nil
+}
+
+// Presign returns the URL of the request signed in the query string, which
+// is valid for expires. The payload is empty.
+func (s *Signer) Presign(req *http.Request, expires time.Duration) (string, error) {
+	return s.presign(req, expires, hashSHA256(nil))
+}
+
+func (s *Signer) presign(req *http.Request, expires time.Duration, payloadHash string) (string, error) {
+	creds, err := s.Credentials.Retrieve()
+	if err != nil {
+		return "", err
+	}
+	t := s.now().UTC()
+
+	host := req.URL.Host
+	if req.Host != "" {
+		host = req.Host
+	}
+	u := *req.URL
+	q := u.Query()
+	q.Set("X-Amz-Algorithm", signingAlgorithm)
+	q.Set("X-Amz-Credential", creds.AccessKeyID+"/"+s.scope(t))
+	q.Set("X-Amz-Date", t.Format(amzDateFormat))
+	q.Set("X-Amz-Expires", strconv.Itoa(int(expires/time.Second)))
+	q.Set("X-Amz-SignedHeaders", "host")
+	if creds.SessionToken != "" {
+		q.Set("X-Amz-Security-Token", creds.SessionToken)
+	}
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		canonicalPath(&u),
+		canonicalQuery(q),
+		"host:" + host + "\n",
+		"host",
+		payloadHash,
+	}, "\n")
+	u.RawQuery = canonicalQuery(q) + "&X-Amz-Signature=" + s.signature(creds, t, canonicalRequest)
+	return u.String(), nil
+}
@@ -0,0 +1,205 @@
+// Package cloud detects the identity of the host from the metadata service
+// of the cloud provider.
+package cloud
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"golang.org/x/xerrors"
+
+	"github.com/yuuki/shawk/cloud/aws"
+	"github.com/yuuki/shawk/logging"
+)
+
+// Supported providers. ProviderAuto tries each of them in turn.
+const (
+	ProviderAuto  = "auto"
+	ProviderAWS   = "aws"
+	ProviderGCP   = "gcp"
+	ProviderAzure = "azure"
+)
+
+// Label keys attached to the local endpoints.
+const (
+	LabelProvider   = "cloud.provider"
+	LabelInstanceID = "cloud.instance-id"
+	LabelName       = "cloud.name"
+	LabelZone       = "cloud.zone"
+	LabelTagPrefix  = "cloud.tag."
+)
+
+const metadataTimeout = 2 * time.Second
+
+var (
+	gcpEndpoint   = "http://metadata.google.internal"
+	azureEndpoint = "http://169.254.169.254"
+)
+
+// ErrNotDetected is returned if no metadata service answers.
+var ErrNotDetected = xerrors.New("no cloud metadata service detected")
+
+var logger = logging.New("cloud")
+
+// Metadata is the identity of the host in the cloud.
+type Metadata struct {
+	Provider   string
+	InstanceID string
+	Name       string
+	Zone       string
+	Tags       map[string]string
+}
+
+// Labels returns the metadata as labels of flow endpoints.
+func (m *Metadata) Labels() map[string]string {
+	labels := map[string]string{
+		LabelProvider:   m.Provider,
+		LabelInstanceID: m.InstanceID,
+	}
+	if m.Name != "" {
+		labels[LabelName] = m.Name
+	}
+	if m.Zone != "" {
+		labels[LabelZone] = m.Zone
+	}
+	for k, v := range m.Tags {
+		labels[LabelTagPrefix+k] = v
+	}
+	return labels
+}
+
+var detectors = map[string]func() (*Metadata, error){
+	ProviderAWS:   detectAWS,
+	ProviderGCP:   detectGCP,
+	ProviderAzure: detectAzure,
+}
+
+// Detect queries the metadata service of the provider. If the provider is
+// ProviderAuto, the providers are tried in turn and ErrNotDetected is
+// returned if none of them answers.
+func Detect(provider string) (*Metadata, error) {
+	if provider != ProviderAuto {
+		detect, ok := detectors[provider]
+		if !ok {
+			return nil, xerrors.Errorf("unknown cloud provider %q", provider)
+		}
+		return detect()
+	}
+	for _, p := range []string{ProviderAWS, ProviderGCP, ProviderAzure} {
+		md, err := detectors[p]()
+		if err == nil {
+			return md, nil
+		}
+		logger.Debugf("%s metadata is not available: %v", p, err)
+	}
+	return nil, ErrNotDetected
+}
+
+func detectAWS() (*Metadata, error) {
+	imds := aws.NewIMDS()
+	id, err := imds.GetMetadata("meta-data/instance-id")
+	if err != nil {
+		return nil, xerrors.Errorf("could not get instance id: %w", err)
+	}
+	zone, err := imds.GetMetadata("meta-data/placement/availability-zone")
+	if err != nil {
+		return nil, xerrors.Errorf("could not get availability zone: %w", err)
+	}
+	md := &Metadata{Provider: ProviderAWS, InstanceID: id, Zone: zone}
+
+	tags, err := imds.InstanceTags()
+	switch {
+	case err == nil:
+		md.Name = tags["Name"]
+		md.Tags = tags
+	case xerrors.Is(err, aws.ErrMetadataNotFound):
+		logger.Infof("instance tags are not exposed to the metadata service")
+	default:
+		return nil, xerrors.Errorf("could not get instance tags: %w", err)
+	}
+	return md, nil
+}
+
+// getJSON requests the metadata service and decodes the JSON response into v.
+func getJSON(url string, header http.Header, v interface{}) error {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return xerrors.Errorf("could not create request %s: %w", url, err)
+	}
+	req.Header = header
+	resp, err := (&http.Client{Timeout: metadataTimeout}).Do(req)
+	if err != nil {
+		return xerrors.Errorf("GET %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := ioutil.ReadAll(resp.Body)
+		return xerrors.Errorf("GET %s: unexpected status %s: %s", url, resp.Status, body)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return xerrors.Errorf("GET %s: could not decode response: %w", url, err)
+	}
+	return nil
+}
+
+func detectGCP() (*Metadata, error) {
+	var instance struct {
+		ID   json.Number `json:"id"`
+		Name string      `json:"name"`
+		Zone string      `json:"zone"` // projects/<project-number>/zones/<zone>
+		Tags []string    `json:"tags"` // network tags
+	}
+	err := getJSON(gcpEndpoint+"/computeMetadata/v1/instance/?recursive=true",
+		http.Header{"Metadata-Flavor": {"Google"}}, &instance)
+	if err != nil {
+		return nil, err
+	}
+	tags := make(map[string]string, len(instance.Tags))
+	for _, t := range instance.Tags {
+		tags[t] = ""
+	}
+	return &Metadata{
+		Provider:   ProviderGCP,
+		InstanceID: instance.ID.String(),
+		Name:       instance.Name,
+		Zone:       instance.Zone[strings.LastIndex(instance.Zone, "/")+1:],
+		Tags:       tags,
+	}, nil
+}
+
+func detectAzure() (*Metadata, error) {
+	var compute struct {
+		VMID     string `json:"vmId"`
+		Name     string `json:"name"`
+		Location string `json:"location"`
+		Zone     string `json:"zone"` // empty unless deployed into an availability zone
+		TagsList []struct {
+			Name  string `json:"name"`
+			Value string `json:"value"`
+		} `json:"tagsList"`
+	}
+	err := getJSON(azureEndpoint+"/metadata/instance/compute?api-version=2021-02-01&format=json",
+		http.Header{"Metadata": {"true"}}, &compute)
+	if err != nil {
+		return nil, err
+	}
+	zone := compute.Location
+	if compute.Zone != "" {
+		zone += "-" + compute.Zone
+	}
+	tags := make(map[string]string, len(compute.TagsList))
+	for _, t := range compute.TagsList {
+		tags[t.Name] = t.Value
+	}
+	return &Metadata{
+		Provider:   ProviderAzure,
+		InstanceID: compute.VMID,
+		Name:       compute.Name,
+		Zone:       zone,
+		Tags:       tags,
+	}, nil
+}
@@ -0,0 +1,80 @@
+package cloud
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDetect_gcp(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Metadata-Flavor") != "Google" {
+			http.Error(w, "missing Metadata-Flavor", http.StatusForbidden)
+			return
+		}
+		w.Write([]byte(`{
+			"id": 4520031799277581759,
+			"name": "web-1",
+			"zone": "projects/123456789/zones/us-central1-a",
+			"tags": ["http-server"]
+		}`))
+	}))
+	defer ts.Close()
+	gcpEndpoint = ts.URL
+
+	md, err := Detect(ProviderGCP)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	want := map[string]string{
+		LabelProvider:                  "gcp",
+		LabelInstanceID:                "4520031799277581759",
+		LabelName:                      "web-1",
+		LabelZone:                      "us-central1-a",
+		LabelTagPrefix + "http-server": "",
+	}
+	if diff := cmp.Diff(want, md.Labels()); diff != "" {
+		t.Errorf("Labels() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestDetect_azure(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Metadata") != "true" {
+			http.Error(w, "missing Metadata header", http.StatusBadRequest)
+			return
+		}
+		w.Write([]byte(`{
+			"vmId": "02aab8a4-74ef-476e-8182-f6d2ba4166a6",
+			"name": "web-1",
+			"location": "eastus",
+			"zone": "2",
+			"tagsList": [{"name": "env", "value": "prod"}]
+		}`))
+	}))
+	defer ts.Close()
+	azureEndpoint = ts.URL
+
+	md, err := Detect(ProviderAzure)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	want := map[string]string{
+		LabelProvider:          "azure",
+		LabelInstanceID:        "02aab8a4-74ef-476e-8182-f6d2ba4166a6",
+		LabelName:              "web-1",
+		LabelZone:              "eastus-2",
+		LabelTagPrefix + "env": "prod",
+	}
+	if diff := cmp.Diff(want, md.Labels()); diff != "" {
+		t.Errorf("Labels() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestDetect_unknownProvider(t *testing.T) {
+	if _, err := Detect("openstack"); err == nil {
+		t.Error("Detect() should return an error for an unknown provider")
+	}
+}
@@ -0,0 +1,141 @@
+package command
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"golang.org/x/xerrors"
+
+	"github.com/yuuki/shawk/config"
+	"github.com/yuuki/shawk/db"
+)
+
+// AgentConfigParam represents an agent-config command parameter.
+type AgentConfigParam struct {
+	// Host is the host of the agent of the settings, or empty for all the
+	// agents.
+	Host string
+	// Set is the setting to set as NAME=VALUE, and Unset is the name of the
+	// setting to remove.
+	Set   string
+	Unset string
+	// List lists the settings instead, and Format is FormatText or
+	// FormatJSON of them.
+	List   bool
+	Format string
+	Color  string
+}
+
+// setting returns the setting of Set.
+func (p *AgentConfigParam) setting() (*db.AgentSetting, error) {
+	i := strings.Index(p.Set, "=")
+	if i < 0 {
+		return nil, xerrors.Errorf("--set must be NAME=VALUE such as 'SHAWK_PROBE_IGNORE_PORTS=9100', but %q", p.Set)
+	}
+	name, value := p.Set[:i], p.Set[i+1:]
+	if err := config.CheckRemoteName(name); err != nil {
+		return nil, xerrors.Errorf("--set: %w", err)
+	}
+	return &db.AgentSetting{Host: p.Host, Name: name, Value: value}, nil
+}
+
+// AgentConfig runs agent-config subcommand, which sets, removes or lists the
+// settings of the agents managed in the CMDB. The agents apply them over
+// their environment on start and when they are changed.
+func AgentConfig(param *AgentConfigParam) error {
+	if err := param.Validate(); err != nil {
+		return err
+	}
+	store, err := openCMDB()
+	if err != nil {
+		return xerrors.Errorf("CMDB initialize error: %w", err)
+	}
+	defer store.Shutdown()
+	st, ok := store.(db.AgentSettingStore)
+	if !ok {
+		return xerrors.New("agent-config is not available for the CMDB")
+	}
+
+	if param.List {
+		found, err := st.FindAgentSettings(param.Host)
+		if err != nil {
+			return err
+		}
+		if param.Format == FormatJSON {
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			return enc.Encode(found)
+		}
+		writeAgentSettings(os.Stdout, found, useColor(param.Color, os.Stdout))
+		return nil
+	}
+
+	scope := "all the agents"
+	if param.Host != "" {
+		scope = "the agent of " + param.Host
+	}
+	if param.Unset != "" {
+		if err := st.UnsetAgentSetting(param.Host, param.Unset); err != nil {
+			return err
+		}
+		fmt.Printf("unset %s for %s\n", param.Unset, scope)
+		return nil
+	}
+	s, _ := param.setting() // validated
+	if err := st.SetAgentSetting(s); err != nil {
+		return err
+	}
+	fmt.Printf("set %s=%s for %s\n", s.Name, s.Value, scope)
+	return nil
+}
+
+// writeAgentSettings writes the settings in the order of the hosts, where
+// the empty host is all the agents.
+func writeAgentSettings(w io.Writer, settings []*db.AgentSetting, color bool) {
+	rows := []tableRow{{cells: []cell{
+		{"HOST", styleDim}, {"NAME", styleDim}, {"VALUE", styleDim}, {"UPDATED", styleDim},
+	}}}
+	for _, s := range settings {
+		host := s.Host
+		if host == "" {
+			host = "*"
+		}
+		rows = append(rows, tableRow{cells: []cell{
+			{host, styleNone},
+			{s.Name, styleNone},
+			{s.Value, styleNone},
+			{s.Updated.UTC().Format(time.RFC3339), styleDim},
+		}})
+	}
+	writeTable(w, rows, color)
+}
+
+// loadAgentSettings applies the settings of the agent of the host in the
+// CMDB over the environment, and reloads them by watchConfig. The settings
+// which the agent has already used before reading the CMDB are applied as
+// watchConfig does.
+func loadAgentSettings(store db.Store, host string) {
+	st, ok := store.(db.AgentSettingStore)
+	if !ok {
+		return
+	}
+	changes, err := config.LoadRemote(func() (map[string]string, error) {
+		settings, err := st.FindAgentSettings(host)
+		if err != nil {
+			return nil, err
+		}
+		return db.EffectiveAgentSettings(settings, host), nil
+	})
+	if err != nil {
+		logger.Errorf("Could not apply the settings in the CMDB, keeping the local ones: %v", err)
+		return
+	}
+	for _, c := range changes {
+		applySetting(c, config.Config, store, nil)
+		logger.Infof("Applied %s from the CMDB", c)
+	}
+}
@@ -0,0 +1,26 @@
+package command
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/yuuki/shawk/db"
+)
+
+func TestWriteAgentSettings(t *testing.T) {
+	settings := []*db.AgentSetting{
+		{Name: "SHAWK_PROBE_IGNORE_PORTS", Value: "9100", Updated: time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)},
+		{Host: "web-1", Name: "SHAWK_PROBE_INTERVAL", Value: "5s", Updated: time.Date(2026, 10, 2, 9, 0, 0, 0, time.UTC)},
+	}
+	var b bytes.Buffer
+	writeAgentSettings(&b, settings, false)
+	want := "HOST   NAME                      VALUE  UPDATED\n" +
+		"*      SHAWK_PROBE_IGNORE_PORTS  9100   2026-10-01T09:00:00Z\n" +
+		"web-1  SHAWK_PROBE_INTERVAL      5s     2026-10-02T09:00:00Z\n"
+	if diff := cmp.Diff(want, b.String()); diff != "" {
+		t.Errorf("writeAgentSettings() mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,114 @@
+package command
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"golang.org/x/xerrors"
+
+	"github.com/yuuki/shawk/db"
+	"github.com/yuuki/shawk/version"
+)
+
+// AgentsParam represents an agents command parameter.
+type AgentsParam struct {
+	// Since excludes the agents without a record since the relative
+	// duration, or includes all the agents if empty.
+	Since string
+	// LatestURL is the URL of the latest version published, or empty to
+	// take the newest version of the agents as the latest.
+	LatestURL string
+	Format    string
+	Color     string
+}
+
+// Agents runs agents subcommand, which reports the versions of the agents
+// recorded in the CMDB, to track the rollout of their upgrades.
+func Agents(param *AgentsParam) error {
+	if err := param.Validate(); err != nil {
+		return err
+	}
+	var since time.Time
+	if param.Since != "" {
+		var err error
+		if since, err = durationFromString(param.Since); err != nil {
+			return err
+		}
+	}
+	var latest string
+	if param.LatestURL != "" {
+		var err error
+		if latest, err = version.FetchLatest(param.LatestURL); err != nil {
+			return xerrors.Errorf("could not fetch the latest version: %w", err)
+		}
+	}
+	store, err := openCMDB()
+	if err != nil {
+		return xerrors.Errorf("CMDB initialize error: %w", err)
+	}
+	defer store.Shutdown()
+	r, ok := store.(db.AgentHealthReader)
+	if !ok {
+		return xerrors.New("agents is not available for the CMDB")
+	}
+	agents, err := r.FindAgentHealth(nil)
+	if err != nil {
+		return err
+	}
+	rollout := version.NewRollout(agentVersions(agents, since), latest)
+
+	if param.Format == FormatJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(rollout)
+	}
+	writeRollout(os.Stdout, rollout, useColor(param.Color, os.Stdout))
+	return nil
+}
+
+// agentVersions returns the versions of the agents having a record since
+// since by their hosts.
+func agentVersions(agents []*db.AgentHealth, since time.Time) map[string]string {
+	versions := make(map[string]string, len(agents))
+	for _, h := range agents {
+		if h.LastRecord.Before(since) {
+			continue
+		}
+		versions[h.Host] = h.AgentVersion
+	}
+	return versions
+}
+
+// writeRollout writes the versions of the agents from the newest, and the
+// number of the agents older than the latest version.
+func writeRollout(w io.Writer, r *version.Rollout, color bool) {
+	rows := []tableRow{{cells: []cell{
+		{"VERSION", styleDim}, {"AGENTS", styleDim}, {"HOSTS", styleDim},
+	}}}
+	for _, v := range r.Versions {
+		name, style := v.Version, styleNone
+		if name == "" {
+			name = "(unknown)"
+		}
+		if v.Outdated {
+			name += " (outdated)"
+			style = styleChanged
+		}
+		rows = append(rows, tableRow{cells: []cell{
+			{name, style},
+			{fmt.Sprint(len(v.Hosts)), styleNone},
+			{strings.Join(v.Hosts, ","), styleDim},
+		}})
+	}
+	writeTable(w, rows, color)
+
+	latest := r.Latest
+	if r.Published {
+		latest += " (published)"
+	}
+	fmt.Fprintf(w, "%d of %d agents are older than %s\n", r.Outdated, r.Agents, latest)
+}
@@ -0,0 +1,46 @@
+package command
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/yuuki/shawk/db"
+	"github.com/yuuki/shawk/version"
+)
+
+func TestAgentVersions(t *testing.T) {
+	now := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	agents := []*db.AgentHealth{
+		{Host: "web-1", AgentVersion: "0.8.0", LastRecord: now},
+		{Host: "web-2", AgentVersion: "0.7.1", LastRecord: now.Add(-2 * time.Hour)},
+	}
+	got := agentVersions(agents, now.Add(-time.Hour))
+	want := map[string]string{"web-1": "0.8.0"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("agentVersions() mismatch (-want +got):\n%s", diff)
+	}
+	got = agentVersions(agents, time.Time{})
+	want = map[string]string{"web-1": "0.8.0", "web-2": "0.7.1"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("agentVersions() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestWriteRollout(t *testing.T) {
+	r := version.NewRollout(map[string]string{
+		"web-1": "0.8.0", "web-2": "0.7.1", "db-1": "0.7.1", "batch-1": "",
+	}, "0.8.0")
+	var b bytes.Buffer
+	writeRollout(&b, r, false)
+	want := "VERSION               AGENTS  HOSTS\n" +
+		"0.8.0                 1       web-1\n" +
+		"0.7.1 (outdated)      2       db-1,web-2\n" +
+		"(unknown) (outdated)  1       batch-1\n" +
+		"3 of 4 agents are older than 0.8.0 (published)\n"
+	if diff := cmp.Diff(want, b.String()); diff != "" {
+		t.Errorf("writeRollout() mismatch (-want +got):\n%s", diff)
+	}
+}
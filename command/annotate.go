@@ -0,0 +1,104 @@
+package command
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"time"
+
+	"golang.org/x/xerrors"
+
+	"github.com/yuuki/shawk/db"
+)
+
+// AnnotateParam represents an annotate command parameter.
+type AnnotateParam struct {
+	// Client, Server, Port and VRF are the edge to annotate.
+	Client string
+	Server string
+	Port   int
+	VRF    string
+	Note   string
+	// Remove removes the annotation of the edge instead of Note.
+	Remove bool
+	// List lists the annotations instead of annotating an edge.
+	List   bool
+	Format string
+	Color  string
+}
+
+// annotation returns the annotation of the edge of the parameter.
+func (p *AnnotateParam) annotation() *db.Annotation {
+	a := &db.Annotation{
+		Client: net.ParseIP(p.Client),
+		Server: net.ParseIP(p.Server),
+		Port:   uint16(p.Port),
+		VRF:    p.VRF,
+	}
+	if !p.Remove {
+		a.Note = p.Note
+	}
+	return a
+}
+
+// Annotate runs annotate subcommand, which annotates an edge with a note of
+// its review, such as "expected: backup traffic", removes the annotation, or
+// lists the annotations.
+func Annotate(param *AnnotateParam) error {
+	if err := param.Validate(); err != nil {
+		return err
+	}
+	store, err := openCMDB()
+	if err != nil {
+		return xerrors.Errorf("CMDB initialize error: %w", err)
+	}
+	defer store.Shutdown()
+	an, ok := store.(db.Annotator)
+	if !ok {
+		return xerrors.New("annotate is not available for the CMDB")
+	}
+
+	if param.List {
+		annotations, err := an.FindAnnotations()
+		if err != nil {
+			return err
+		}
+		if param.Format == FormatJSON {
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			return enc.Encode(annotations)
+		}
+		writeAnnotations(os.Stdout, annotations, useColor(param.Color, os.Stdout))
+		return nil
+	}
+
+	a := param.annotation()
+	if err := an.Annotate(a); err != nil {
+		return err
+	}
+	edge := fmt.Sprintf("%s --> %s", a.Client, a.ServerAddr())
+	if a.Note == "" {
+		fmt.Printf("removed the annotation of %s\n", edge)
+	} else {
+		fmt.Printf("annotated %s\n", edge)
+	}
+	return nil
+}
+
+// writeAnnotations writes the annotations in the order of the edges.
+func writeAnnotations(w io.Writer, annotations []*db.Annotation, color bool) {
+	rows := []tableRow{{cells: []cell{
+		{"CLIENT", styleDim}, {"SERVER", styleDim}, {"NOTE", styleDim}, {"UPDATED", styleDim},
+	}}}
+	for _, a := range annotations {
+		rows = append(rows, tableRow{cells: []cell{
+			{a.Client.String(), styleNone},
+			{a.ServerAddr(), styleNone},
+			{a.Note, styleNone},
+			{a.Updated.UTC().Format(time.RFC3339), styleDim},
+		}})
+	}
+	writeTable(w, rows, color)
+}
@@ -0,0 +1,38 @@
+package command
+
+import (
+	"bytes"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/yuuki/shawk/db"
+)
+
+func TestWriteAnnotations(t *testing.T) {
+	annotations := []*db.Annotation{
+		{
+			Client: net.ParseIP("10.0.1.5"), Server: net.ParseIP("10.0.2.1"), Port: 873,
+			Note: "expected: backup traffic", Updated: time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			Client: net.ParseIP("10.0.1.6"), Server: net.ParseIP("10.0.2.2"), Port: 5432,
+			Note: "INFRA-123", Updated: time.Date(2026, 10, 2, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			Client: net.ParseIP("10.0.1.6"), Server: net.ParseIP("10.0.2.2"), Port: 5432, VRF: "blue",
+			Note: "INFRA-124", Updated: time.Date(2026, 10, 3, 9, 0, 0, 0, time.UTC),
+		},
+	}
+	var b bytes.Buffer
+	writeAnnotations(&b, annotations, false)
+	want := "CLIENT    SERVER              NOTE                      UPDATED\n" +
+		"10.0.1.5  10.0.2.1:873        expected: backup traffic  2026-10-01T09:00:00Z\n" +
+		"10.0.1.6  10.0.2.2:5432       INFRA-123                 2026-10-02T09:00:00Z\n" +
+		"10.0.1.6  10.0.2.2%blue:5432  INFRA-124                 2026-10-03T09:00:00Z\n"
+	if diff := cmp.Diff(want, b.String()); diff != "" {
+		t.Errorf("writeAnnotations() mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -1,7 +1,118 @@
 package command
 
-import "github.com/yuuki/shawk/logging"
+import (
+	"net"
+	"strconv"
+
+	"github.com/jackc/pgx/v4"
+	"golang.org/x/xerrors"
+
+	"github.com/yuuki/shawk/cloud/aws"
+	"github.com/yuuki/shawk/config"
+	"github.com/yuuki/shawk/db"
+	"github.com/yuuki/shawk/logging"
+)
 
 var (
 	logger = logging.New("command")
 )
+
+// retryPolicy returns the retry policy of the CMDB from the settings.
+func retryPolicy(s *config.Settings) db.RetryPolicy {
+	c := s.CMDB
+	return db.RetryPolicy{
+		MaxRetries:     c.RetryMax,
+		InitialBackoff: c.RetryInitialBackoff,
+		MaxBackoff:     c.RetryMaxBackoff,
+	}
+}
+
+// setupCMDBAuth makes the connections to the CMDB authenticate by the IAM
+// authentication tokens of RDS if SHAWK_CMDB_AUTH is rds-iam. The tokens
+// are renewed before they expire, so that the reconnections succeed.
+func setupCMDBAuth(s *config.Settings) error {
+	if s.CMDB.Auth != config.AuthRDSIAM {
+		db.SetPasswordFunc(nil)
+		return nil
+	}
+	conf, err := pgx.ParseConfig(s.CMDB.URL)
+	if err != nil {
+		return xerrors.Errorf("could not parse SHAWK_CMDB_URL: %v", err)
+	}
+	if conf.TLSConfig == nil {
+		logger.Warningf("RDS requires SSL for the IAM authentication: set sslmode=require or stricter in SHAWK_CMDB_URL")
+	}
+
+	imds := aws.NewIMDS()
+	region := s.CMDB.RDSRegion
+	if region == "" {
+		if region, err = aws.Region(imds); err != nil {
+			return xerrors.Errorf("could not detect the region of RDS, set SHAWK_CMDB_RDS_REGION: %w", err)
+		}
+	}
+	endpoint := net.JoinHostPort(conf.Host, strconv.Itoa(int(conf.Port)))
+	tokens := aws.NewRDSTokenSource(aws.NewDefaultCredentials(imds), region, endpoint, conf.User)
+	db.SetPasswordFunc(tokens.Token)
+	logger.Infof("Connecting to the CMDB on RDS %s in %s as %s by IAM", endpoint, region, conf.User)
+	return nil
+}
+
+// openCMDB connects to the CMDB of SHAWK_CMDB_URL, sharded with the
+// databases of SHAWK_CMDB_SHARD_URLS if set. The retry policy, the
+// staleness of the views, the thresholds of the notifications and the slow
+// queries, and the tenant of the config are applied to the CMDB on
+// Postgres.
+func openCMDB() (db.Store, error) {
+	if err := setupCMDBAuth(config.Config); err != nil {
+		return nil, err
+	}
+	urls := append([]string{config.Config.CMDB.URL}, config.Config.CMDB.ShardURLs...)
+	stores := make([]db.Store, 0, len(urls))
+	for i, url := range urls {
+		store, err := db.Open(url)
+		if err != nil {
+			for _, s := range stores {
+				s.Shutdown()
+			}
+			if len(urls) > 1 {
+				return nil, xerrors.Errorf("shard %d: %w", i, err)
+			}
+			return nil, err
+		}
+		stores = append(stores, store)
+	}
+	store := stores[0]
+	if len(stores) > 1 {
+		store = db.NewShards(stores)
+		logger.Infof("Sharding the CMDB into %d databases", len(stores))
+	}
+	for _, pg := range postgresDBs(store) {
+		configureDB(pg)
+	}
+	return store, nil
+}
+
+// configureDB applies the settings of the config to the CMDB on Postgres.
+func configureDB(pg *db.DB) {
+	pg.SetRetryPolicy(retryPolicy(config.Config))
+	pg.SetViewMaxStale(config.Config.CMDB.ViewMaxStale)
+	pg.SetNotifyThreshold(config.Config.CMDB.NotifyThreshold)
+	pg.SetSlowQueryThreshold(config.Config.CMDB.SlowQueryThreshold)
+	pg.SetTenant(config.Config.CMDB.Tenant)
+	pg.SetAllTenants(config.Config.CMDB.AllTenants)
+}
+
+// postgresDBs returns the CMDB on Postgres, or its shards on Postgres.
+func postgresDBs(store db.Store) []*db.DB {
+	stores := []db.Store{store}
+	if shards, ok := store.(*db.Shards); ok {
+		stores = shards.Stores()
+	}
+	var dbs []*db.DB
+	for _, s := range stores {
+		if pg, ok := s.(*db.DB); ok {
+			dbs = append(dbs, pg)
+		}
+	}
+	return dbs
+}
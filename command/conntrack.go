@@ -0,0 +1,52 @@
+package command
+
+import (
+	"context"
+	"time"
+
+	"golang.org/x/xerrors"
+
+	"github.com/yuuki/shawk/db"
+	"github.com/yuuki/shawk/probe"
+)
+
+// watchConntrack writes the translations of conntrack into the CMDB every
+// interval until ctx is done.
+func watchConntrack(ctx context.Context, store db.Store, interval time.Duration) error {
+	w, ok := store.(db.NATWriter)
+	if !ok {
+		return xerrors.New("SHAWK_PROBE_CONNTRACK is not available for the CMDB")
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			if err := writeConntrackNATs(w); err != nil {
+				logger.Errorf("%+v", err)
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	return nil
+}
+
+// writeConntrackNATs writes the translations of conntrack on the host.
+func writeConntrackNATs(w db.NATWriter) error {
+	nats, err := probe.ConntrackNATs()
+	if xerrors.Is(err, probe.ErrNoConntrack) {
+		logger.Warningf("Skip writing the NATs: %v", err)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if err := w.InsertOrUpdateNATs(nats); err != nil {
+		return xerrors.Errorf("write NATs error: %w", err)
+	}
+	logger.Debugf("Wrote %d NATs", len(nats))
+	return nil
+}
@@ -1,30 +1,66 @@
 package command
 
 import (
-	"github.com/yuuki/shawk/config"
-	"github.com/yuuki/shawk/db"
 	"golang.org/x/xerrors"
+
+	"github.com/yuuki/shawk/db"
 )
 
 // CreateSchemeParam is
-type CreateSchemeParam struct{}
+type CreateSchemeParam struct {
+	// Views also creates the materialized views of the aggregate queries.
+	Views bool
+	// WithRoles also creates the roles of the readers and the writers.
+	WithRoles bool
+}
 
 // CreateScheme runs create-scheme subcommand.
 func CreateScheme(param *CreateSchemeParam) error {
-	logger.Infof("Connecting postgres ...")
+	if err := validateCMDB(); err != nil {
+		return err
+	}
 
-	db, err := db.New(config.Config.CMDB.URL)
+	logger.Infof("Connecting the CMDB ...")
+
+	store, err := openCMDB()
 	if err != nil {
-		return xerrors.Errorf("postgres initialize error: %w", err)
+		return xerrors.Errorf("CMDB initialize error: %w", err)
 	}
 
-	logger.Infof("Connected postgres ...")
+	logger.Infof("Connected the CMDB ...")
 
-	logger.Infof("Creating postgres schema ...")
+	logger.Infof("Creating the CMDB schema ...")
 
-	if err := db.CreateSchema(); err != nil {
+	if err := store.CreateSchema(); err != nil {
 		return err
 	}
 
+	if param.Views {
+		dbs := postgresDBs(store)
+		if len(dbs) == 0 {
+			return xerrors.New("--views is only available for the CMDB on Postgres")
+		}
+		logger.Infof("Creating the materialized views ...")
+		for _, pg := range dbs {
+			if err := pg.CreateViews(); err != nil {
+				return err
+			}
+		}
+	}
+
+	// The roles are granted the views as well, so they are created last.
+	if param.WithRoles {
+		dbs := postgresDBs(store)
+		if len(dbs) == 0 {
+			return xerrors.New("--with-roles is only available for the CMDB on Postgres")
+		}
+		logger.Infof("Creating the roles %s and %s ...", db.ReaderRole, db.WriterRole)
+		for _, pg := range dbs {
+			if err := pg.CreateRoles(); err != nil {
+				return err
+			}
+		}
+	}
+
 	return nil
 }
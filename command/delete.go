@@ -0,0 +1,112 @@
+package command
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+
+	"golang.org/x/xerrors"
+
+	"github.com/yuuki/shawk/db"
+)
+
+// DeleteParam represents a delete command parameter.
+type DeleteParam struct {
+	// Hosts and CIDRs are the comma-separated addresses and prefixes of the
+	// processes to delete.
+	Hosts  string
+	CIDRs  string
+	DryRun bool
+	Color  string
+}
+
+// prefixes returns the hosts and the CIDRs as the prefixes.
+func (p *DeleteParam) prefixes() ([]*net.IPNet, error) {
+	var prefixes []*net.IPNet
+	for _, s := range splitList(p.Hosts) {
+		ip := net.ParseIP(s)
+		if ip == nil {
+			return nil, xerrors.Errorf("--host must be the comma-separated IP addresses, but %q", s)
+		}
+		bits := 8 * net.IPv6len
+		if v4 := ip.To4(); v4 != nil {
+			ip, bits = v4, 8*net.IPv4len
+		}
+		prefixes = append(prefixes, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+	}
+	for _, s := range splitList(p.CIDRs) {
+		_, prefix, err := net.ParseCIDR(s)
+		if err != nil {
+			return nil, xerrors.Errorf("--cidr must be the comma-separated CIDRs such as '10.0.1.0/24', but %q", s)
+		}
+		prefixes = append(prefixes, prefix)
+	}
+	return prefixes, nil
+}
+
+func splitList(s string) []string {
+	var list []string
+	for _, e := range strings.Split(s, ",") {
+		if e = strings.TrimSpace(e); e != "" {
+			list = append(list, e)
+		}
+	}
+	return list
+}
+
+// Delete runs delete subcommand, which deletes the processes of the
+// decommissioned hosts or the reclaimed address ranges with their nodes and
+// flows, and lists them.
+func Delete(param *DeleteParam) error {
+	if err := param.Validate(); err != nil {
+		return err
+	}
+	prefixes, err := param.prefixes()
+	if err != nil {
+		return err
+	}
+	store, err := openCMDB()
+	if err != nil {
+		return xerrors.Errorf("CMDB initialize error: %w", err)
+	}
+	defer store.Shutdown()
+	d, ok := store.(db.Deleter)
+	if !ok {
+		return xerrors.New("delete is not available for the CMDB")
+	}
+
+	deleted, err := d.DeleteProcesses(prefixes, param.DryRun)
+	if err != nil {
+		return err
+	}
+	writeDeleted(os.Stdout, deleted, param.DryRun, useColor(param.Color, os.Stdout))
+	return nil
+}
+
+// writeDeleted writes the processes deleted, or to delete if dryRun.
+func writeDeleted(w io.Writer, deleted []*db.DeletedProcess, dryRun bool, color bool) {
+	style := styleRemoved
+	if dryRun {
+		style = styleNone
+	}
+	if len(deleted) > 0 {
+		rows := []tableRow{{cells: []cell{
+			{"ADDR", styleDim}, {"PROCESS", styleDim}, {"PGID", styleDim}, {"FLOWS", styleDim},
+		}}}
+		for _, p := range deleted {
+			rows = append(rows, tableRow{cells: []cell{
+				{p.Addr, style}, {p.Pname, styleNone},
+				{strconv.Itoa(p.Pgid), styleNone}, {strconv.FormatInt(p.Flows, 10), styleNone},
+			}})
+		}
+		writeTable(w, rows, color)
+	}
+	verb := "deleted"
+	if dryRun {
+		verb = "would delete"
+	}
+	fmt.Fprintf(w, "%s %d processes with their nodes and flows\n", verb, len(deleted))
+}
@@ -0,0 +1,49 @@
+package command
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/yuuki/shawk/db"
+)
+
+func TestWriteDeleted(t *testing.T) {
+	deleted := []*db.DeletedProcess{
+		{Addr: "10.0.1.5", Pname: "nginx", Pgid: 1200, Flows: 12},
+		{Addr: "10.0.1.6%blue", Pname: "python", Pgid: 3400, Flows: 3},
+	}
+	var b bytes.Buffer
+	writeDeleted(&b, deleted, true, false)
+	want := `ADDR           PROCESS  PGID  FLOWS
+10.0.1.5       nginx    1200  12
+10.0.1.6%blue  python   3400  3
+would delete 2 processes with their nodes and flows
+`
+	if diff := cmp.Diff(want, b.String()); diff != "" {
+		t.Errorf("writeDeleted() mismatch (-want +got):\n%s", diff)
+	}
+
+	b.Reset()
+	writeDeleted(&b, nil, false, false)
+	if got, want := b.String(), "deleted 0 processes with their nodes and flows\n"; got != want {
+		t.Errorf("writeDeleted() = %q, want %q", got, want)
+	}
+}
+
+func TestDeleteParam_prefixes(t *testing.T) {
+	p := &DeleteParam{Hosts: "10.0.1.5,2001:db8::1", CIDRs: "10.0.2.0/24"}
+	prefixes, err := p.prefixes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, prefix := range prefixes {
+		got = append(got, prefix.String())
+	}
+	want := []string{"10.0.1.5/32", "2001:db8::1/128", "10.0.2.0/24"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("prefixes() mismatch (-want +got):\n%s", diff)
+	}
+}
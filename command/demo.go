@@ -0,0 +1,90 @@
+package command
+
+import (
+	"math/rand"
+	"sort"
+	"time"
+
+	"golang.org/x/xerrors"
+
+	"github.com/yuuki/shawk/agent"
+	"github.com/yuuki/shawk/db"
+	"github.com/yuuki/shawk/demo"
+)
+
+// DemoParam represents a demo command parameter.
+type DemoParam struct {
+	Hosts    int
+	Services int
+	Seed     int64
+	// Interval is the interval of writing the flows again with the new
+	// connections until interrupted. Zero writes them once.
+	Interval time.Duration
+}
+
+func (p *DemoParam) option() *demo.Option {
+	return &demo.Option{Hosts: p.Hosts, Services: p.Services, Seed: p.Seed}
+}
+
+// Demo runs demo subcommand, which writes the flows of a synthetic topology
+// into the CMDB as the agents on its hosts would.
+func Demo(param *DemoParam) error {
+	if err := param.Validate(); err != nil {
+		return err
+	}
+	topo, err := demo.New(param.option())
+	if err != nil {
+		return err
+	}
+	logger.Infof("Generated %s", topo)
+
+	dbCon, err := openCMDB()
+	if err != nil {
+		return xerrors.Errorf("CMDB initialize error: %w", err)
+	}
+	defer dbCon.Shutdown()
+
+	rnd := rand.New(rand.NewSource(param.Seed))
+	if err := writeDemoFlows(dbCon, topo, rnd); err != nil {
+		return err
+	}
+	if param.Interval == 0 {
+		return nil
+	}
+
+	ctx, cancel := agent.SignalContext()
+	defer cancel()
+	ticker := time.NewTicker(param.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := writeDemoFlows(dbCon, topo, rnd); err != nil {
+				logger.Errorf("%+v", err)
+			}
+		}
+	}
+}
+
+// writeDemoFlows writes the flows of the topology host by host, as the
+// agents on the hosts write them.
+func writeDemoFlows(store db.Store, topo *demo.Topology, rnd *rand.Rand) error {
+	start := time.Now()
+	flows := topo.Flows(rnd)
+	addrs := make([]string, 0, len(flows))
+	for addr := range flows {
+		addrs = append(addrs, addr)
+	}
+	sort.Strings(addrs)
+	n := 0
+	for _, addr := range addrs {
+		if err := store.InsertOrUpdateHostFlows(flows[addr]); err != nil {
+			return xerrors.Errorf("could not write the flows of %s: %w", addr, err)
+		}
+		n += len(flows[addr])
+	}
+	logger.Infof("Wrote %d flows of %d hosts in %s", n, len(addrs), time.Since(start))
+	return nil
+}
@@ -0,0 +1,129 @@
+package command
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"golang.org/x/xerrors"
+
+	"github.com/yuuki/shawk/agent"
+	"github.com/yuuki/shawk/db"
+	"github.com/yuuki/shawk/drift"
+	"github.com/yuuki/shawk/graph"
+)
+
+// DriftParam represents a drift command parameter.
+type DriftParam struct {
+	File   string
+	Since  string
+	Until  string
+	Format string
+}
+
+// Drift runs drift subcommand, which compares the flows in the CMDB with
+// the dependencies declared in the file, and returns an error if they
+// drift after writing the drifts.
+func Drift(param *DriftParam) error {
+	if err := param.Validate(); err != nil {
+		return err
+	}
+	declared, err := readDeclared(param.File)
+	if err != nil {
+		return err
+	}
+
+	cond := &db.ListFlowsCond{}
+	if param.Since != "" {
+		if cond.Since, err = durationFromString(param.Since); err != nil {
+			return err
+		}
+	}
+	if param.Until != "" {
+		if cond.Until, err = durationFromString(param.Until); err != nil {
+			return err
+		}
+	}
+
+	dbCon, err := openCMDB()
+	if err != nil {
+		return xerrors.Errorf("CMDB initialize error: %w", err)
+	}
+	defer dbCon.Shutdown()
+
+	ctx, cancel := agent.SignalContext()
+	defer cancel()
+	var flows []*db.Flow
+	err = db.EachFlow(ctx, dbCon, cond, func(f *db.Flow) error {
+		flows = append(flows, f)
+		return nil
+	})
+	if err != nil {
+		return xerrors.Errorf("could not list the flows: %w", err)
+	}
+
+	findings := drift.Check(declared, graph.New(flows))
+	for _, f := range findings {
+		if err := writeFinding(os.Stdout, f, param.Format); err != nil {
+			return err
+		}
+	}
+	if len(findings) > 0 {
+		return xerrors.Errorf("%d drifts from the dependencies of %s", len(findings), param.File)
+	}
+	logger.Infof("The %d flows conform to the %d dependencies of %s", len(flows), len(declared.Edges), param.File)
+	return nil
+}
+
+// readDeclared reads the declared dependencies from the file, or from stdin
+// if the file is '-'.
+func readDeclared(file string) (*drift.Declared, error) {
+	var r io.Reader = os.Stdin
+	if file != "-" {
+		f, err := os.Open(file)
+		if err != nil {
+			return nil, xerrors.Errorf("could not open the declared dependencies: %w", err)
+		}
+		defer f.Close()
+		r = f
+	}
+	d, err := drift.Parse(r)
+	if err != nil {
+		return nil, xerrors.Errorf("invalid declared dependencies %s: %w", file, err)
+	}
+	return d, nil
+}
+
+// finding is a drift in JSON.
+type finding struct {
+	Kind        drift.Kind `json:"kind"`
+	Client      string     `json:"client"`
+	Server      string     `json:"server"`
+	Line        int        `json:"line,omitempty"`
+	Connections int        `json:"connections,omitempty"`
+}
+
+// writeFinding writes the drift in the format as a line.
+func writeFinding(w io.Writer, f *drift.Finding, format string) error {
+	if format == FormatJSON {
+		return json.NewEncoder(w).Encode(&finding{
+			Kind:        f.Kind,
+			Client:      f.Client,
+			Server:      f.Server,
+			Line:        f.Line,
+			Connections: f.Connections,
+		})
+	}
+	var err error
+	switch f.Kind {
+	case drift.Undeclared:
+		_, err = fmt.Fprintf(w, "undeclared: %s --> %s %d conns\n", f.Client, f.Server, f.Connections)
+	case drift.Reversed:
+		_, err = fmt.Fprintf(w, "reversed: %s --> %s declared at line %d, but %s --> %s %d conns\n",
+			f.Client, f.Server, f.Line, f.Server, f.Client, f.Connections)
+	case drift.Unobserved:
+		_, err = fmt.Fprintf(w, "unobserved: %s --> %s declared at line %d\n", f.Client, f.Server, f.Line)
+	}
+	return err
+}
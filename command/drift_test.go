@@ -0,0 +1,44 @@
+package command
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/yuuki/shawk/drift"
+)
+
+func TestWriteFinding(t *testing.T) {
+	findings := []*drift.Finding{
+		{Kind: drift.Undeclared, Client: "web", Server: "postgres", Connections: 2},
+		{Kind: drift.Reversed, Client: "worker", Server: "api", Line: 7, Connections: 3},
+		{Kind: drift.Unobserved, Client: "api", Server: "queue", Line: 6},
+	}
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{FormatText, `undeclared: web --> postgres 2 conns
+reversed: worker --> api declared at line 7, but api --> worker 3 conns
+unobserved: api --> queue declared at line 6
+`},
+		{FormatJSON, `{"kind":"undeclared","client":"web","server":"postgres","connections":2}
+{"kind":"reversed","client":"worker","server":"api","line":7,"connections":3}
+{"kind":"unobserved","client":"api","server":"queue","line":6}
+`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			var b bytes.Buffer
+			for _, f := range findings {
+				if err := writeFinding(&b, f, tt.format); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if diff := cmp.Diff(tt.want, b.String()); diff != "" {
+				t.Errorf("writeFinding() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package command
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strings"
+
+	"golang.org/x/xerrors"
+
+	"github.com/yuuki/shawk/agent"
+	"github.com/yuuki/shawk/db"
+)
+
+// DBExplainParam represents a db explain command parameter.
+type DBExplainParam struct {
+	Addr    string
+	Port    int
+	Since   string
+	Analyze bool
+}
+
+// DBExplain runs db explain subcommand, which prints the plans of the main
+// queries of the CMDB.
+func DBExplain(param *DBExplainParam) error {
+	if err := param.Validate(); err != nil {
+		return err
+	}
+	cond := &db.ExplainCond{Analyze: param.Analyze}
+	if param.Addr != "" {
+		cond.Addr = net.ParseIP(param.Addr)
+		cond.Port = uint16(param.Port)
+	}
+	if param.Since != "" {
+		var err error
+		if cond.Since, err = durationFromString(param.Since); err != nil {
+			return err
+		}
+	}
+
+	store, err := openCMDB()
+	if err != nil {
+		return xerrors.Errorf("CMDB initialize error: %w", err)
+	}
+	defer store.Shutdown()
+	pg, ok := store.(*db.DB)
+	if !ok {
+		return xerrors.New("db explain is only available for the CMDB on Postgres")
+	}
+
+	ctx, cancel := agent.SignalContext()
+	defer cancel()
+	plans, err := pg.Explain(ctx, cond)
+	if err != nil {
+		return err
+	}
+	writePlans(os.Stdout, plans)
+	return nil
+}
+
+// writePlans writes the plans under the names of the queries, followed by
+// the tables scanned sequentially.
+func writePlans(w io.Writer, plans []*db.Plan) {
+	for i, p := range plans {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		fmt.Fprintf(w, "-- %s\n", p.Name)
+		for _, line := range p.Lines {
+			fmt.Fprintln(w, line)
+		}
+		if tables := p.SeqScans(); len(tables) > 0 {
+			fmt.Fprintf(w, "-- %s scans %s sequentially\n", p.Name, strings.Join(tables, ", "))
+		}
+	}
+}
@@ -0,0 +1,37 @@
+package command
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/yuuki/shawk/db"
+)
+
+func TestWritePlans(t *testing.T) {
+	plans := []*db.Plan{
+		{Name: "list flows", Lines: []string{
+			"Limit  (cost=0.29..61.32 rows=1000 width=120)",
+			"  ->  Index Scan using flows_pkey on flows  (cost=0.29..6.40 rows=100 width=40)",
+		}},
+		{Name: "find passive nodes", Lines: []string{
+			"Hash Semi Join  (cost=1.05..2.12 rows=1 width=8)",
+			"  ->  Seq Scan on passive_nodes  (cost=0.00..1.04 rows=1 width=12)",
+		}},
+	}
+	var b bytes.Buffer
+	writePlans(&b, plans)
+	want := `-- list flows
+Limit  (cost=0.29..61.32 rows=1000 width=120)
+  ->  Index Scan using flows_pkey on flows  (cost=0.29..6.40 rows=100 width=40)
+
+-- find passive nodes
+Hash Semi Join  (cost=1.05..2.12 rows=1 width=8)
+  ->  Seq Scan on passive_nodes  (cost=0.00..1.04 rows=1 width=12)
+-- find passive nodes scans passive_nodes sequentially
+`
+	if diff := cmp.Diff(want, b.String()); diff != "" {
+		t.Errorf("writePlans() mismatch (-want +got):\n%s", diff)
+	}
+}
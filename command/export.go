@@ -0,0 +1,132 @@
+package command
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"time"
+
+	"golang.org/x/xerrors"
+
+	"github.com/yuuki/shawk/agent"
+	"github.com/yuuki/shawk/db"
+	"github.com/yuuki/shawk/serve/api"
+	"github.com/yuuki/shawk/siem"
+)
+
+// The compressions of the exported flows.
+const (
+	CompressNone = "none"
+	CompressGzip = "gzip"
+)
+
+// ExportParam represents an export command parameter.
+type ExportParam struct {
+	Since string
+	Until string
+	// PageSize is the number of the flows queried at a time.
+	PageSize int
+	// Syslog is the URL of the syslog collector to send the flows to
+	// instead of stdout. Empty writes them to stdout.
+	Syslog       string
+	SyslogFormat string
+	// Cong is the congestion control algorithm such as "bbr" to export
+	// only the flows running it on either side. Empty exports all.
+	Cong string
+	// Compress is the compression of the flows written to stdout.
+	Compress string
+}
+
+// Export runs export subcommand, which writes all the flows in the CMDB as
+// JSON lines.
+func Export(param *ExportParam) error {
+	if err := param.Validate(); err != nil {
+		return err
+	}
+
+	dbCon, err := openCMDB()
+	if err != nil {
+		return xerrors.Errorf("CMDB initialize error: %w", err)
+	}
+	defer dbCon.Shutdown()
+
+	cond := &db.ListFlowsCond{Limit: param.PageSize}
+	if param.Since != "" {
+		if cond.Since, err = durationFromString(param.Since); err != nil {
+			return err
+		}
+	}
+	if param.Until != "" {
+		if cond.Until, err = durationFromString(param.Until); err != nil {
+			return err
+		}
+	}
+
+	ctx, cancel := agent.SignalContext()
+	defer cancel()
+	if param.Syslog != "" {
+		w, err := siem.NewWriter(param.Syslog, param.SyslogFormat)
+		if err != nil {
+			return err
+		}
+		defer w.Close()
+		return sendFlows(ctx, w, dbCon, cond, param.Cong, time.Now())
+	}
+	if param.Compress == CompressGzip {
+		gw := gzip.NewWriter(os.Stdout)
+		if err := exportFlows(ctx, gw, dbCon, cond, param.Cong); err != nil {
+			gw.Close()
+			return err
+		}
+		if err := gw.Close(); err != nil {
+			return xerrors.Errorf("could not export the flows: %w", err)
+		}
+		return nil
+	}
+	return exportFlows(ctx, os.Stdout, dbCon, cond, param.Cong)
+}
+
+// exportFlows writes the flows of cond running the congestion control
+// algorithm cong, or all of them if it is empty, into w page by page, a JSON
+// object of the flow per line in the format of GET /api/v1/flows.
+func exportFlows(ctx context.Context, w io.Writer, store db.Store, cond *db.ListFlowsCond, cong string) error {
+	bw := bufio.NewWriter(w)
+	enc := json.NewEncoder(bw)
+	err := db.EachFlow(ctx, store, cond, func(f *db.Flow) error {
+		if cong != "" && !f.UsesCong(cong) {
+			return nil
+		}
+		return enc.Encode(api.FlowOf(f))
+	})
+	if ferr := bw.Flush(); err == nil {
+		err = ferr
+	}
+	if err != nil {
+		return xerrors.Errorf("could not export the flows: %w", err)
+	}
+	return nil
+}
+
+// eventWriter is the writer of the events to the syslog collector.
+type eventWriter interface {
+	Write(e *siem.Event) error
+}
+
+// sendFlows sends the flows of cond running the congestion control
+// algorithm cong, or all of them if it is empty, to w as the events at now
+// page by page.
+func sendFlows(ctx context.Context, w eventWriter, store db.Store, cond *db.ListFlowsCond, cong string, now time.Time) error {
+	err := db.EachFlow(ctx, store, cond, func(f *db.Flow) error {
+		if cong != "" && !f.UsesCong(cong) {
+			return nil
+		}
+		return w.Write(siem.FlowEvent(f, now))
+	})
+	if err != nil {
+		return xerrors.Errorf("could not send the flows: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,134 @@
+package command
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/yuuki/shawk/db"
+	"github.com/yuuki/shawk/serve/api"
+	"github.com/yuuki/shawk/siem"
+)
+
+// pagedStore lists its flows by their IDs, counting the pages.
+type pagedStore struct {
+	db.Store
+	flows []*db.Flow
+	pages int
+}
+
+func (s *pagedStore) ListFlows(cond *db.ListFlowsCond) ([]*db.Flow, error) {
+	s.pages++
+	var page []*db.Flow
+	for _, f := range s.flows {
+		if f.ID > cond.After && len(page) < cond.Limit {
+			page = append(page, f)
+		}
+	}
+	return page, nil
+}
+
+func TestExportFlows(t *testing.T) {
+	s := &pagedStore{}
+	for i, addr := range []string{"10.0.0.2", "10.0.0.3", "10.0.0.4"} {
+		s.flows = append(s.flows, &db.Flow{
+			ID:          int64(i + 1),
+			ActiveNode:  &db.Node{IPAddr: net.ParseIP("10.0.0.1"), Aggregated: true, Pname: "app"},
+			PassiveNode: &db.Node{IPAddr: net.ParseIP(addr), Port: 80, Pname: "nginx", Labels: map[string]string{"role": "web"}},
+			Connections: 1,
+		})
+	}
+
+	var b bytes.Buffer
+	if err := exportFlows(context.Background(), &b, s, &db.ListFlowsCond{Limit: 2}, ""); err != nil {
+		t.Fatalf("exportFlows() should not return an error: %v", err)
+	}
+	want := []string{
+		`{"client":{"addr":"10.0.0.1","port":null,"process":"app","pgid":0,"labels":{}},"server":{"addr":"10.0.0.2","port":80,"process":"nginx","pgid":0,"labels":{"role":"web"}},"connections":1,"id":1}`,
+		`{"client":{"addr":"10.0.0.1","port":null,"process":"app","pgid":0,"labels":{}},"server":{"addr":"10.0.0.3","port":80,"process":"nginx","pgid":0,"labels":{"role":"web"}},"connections":1,"id":2}`,
+		`{"client":{"addr":"10.0.0.1","port":null,"process":"app","pgid":0,"labels":{}},"server":{"addr":"10.0.0.4","port":80,"process":"nginx","pgid":0,"labels":{"role":"web"}},"connections":1,"id":3}`,
+	}
+	if diff := cmp.Diff(want, strings.Split(strings.TrimSpace(b.String()), "\n")); diff != "" {
+		t.Errorf("exportFlows() mismatch (-want +got):\n%s", diff)
+	}
+	if s.pages != 2 {
+		t.Errorf("exportFlows() should list 2 pages, but %d", s.pages)
+	}
+}
+
+func TestExportFlows_cong(t *testing.T) {
+	s := &pagedStore{}
+	for i, cong := range [][2]string{{"bbr", "cubic"}, {"cubic", "cubic"}, {"", "bbr,cubic"}, {"", ""}} {
+		s.flows = append(s.flows, &db.Flow{
+			ID:          int64(i + 1),
+			ActiveNode:  &db.Node{IPAddr: net.ParseIP("10.0.0.1"), Aggregated: true, Pname: "app"},
+			PassiveNode: &db.Node{IPAddr: net.ParseIP("10.0.0.2"), Port: 443, Pname: "envoy"},
+			Connections: 1,
+			ClientCong:  cong[0],
+			ServerCong:  cong[1],
+		})
+	}
+
+	var b bytes.Buffer
+	if err := exportFlows(context.Background(), &b, s, &db.ListFlowsCond{Limit: 2}, "bbr"); err != nil {
+		t.Fatalf("exportFlows() should not return an error: %v", err)
+	}
+	var ids []int
+	dec := json.NewDecoder(&b)
+	for dec.More() {
+		var f api.Flow
+		if err := dec.Decode(&f); err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, f.ID)
+	}
+	if diff := cmp.Diff([]int{1, 3}, ids); diff != "" {
+		t.Errorf("exportFlows() of bbr mismatch (-want +got):\n%s", diff)
+	}
+}
+
+// eventRecorder records the events written into it.
+type eventRecorder struct {
+	events []*siem.Event
+}
+
+func (r *eventRecorder) Write(e *siem.Event) error {
+	r.events = append(r.events, e)
+	return nil
+}
+
+func TestSendFlows(t *testing.T) {
+	s := &pagedStore{}
+	for i, addr := range []string{"10.0.0.2", "10.0.0.3", "10.0.0.4"} {
+		s.flows = append(s.flows, &db.Flow{
+			ID:          int64(i + 1),
+			ActiveNode:  &db.Node{IPAddr: net.ParseIP("10.0.0.1"), Aggregated: true, Pname: "app"},
+			PassiveNode: &db.Node{IPAddr: net.ParseIP(addr), Port: 80, Pname: "nginx"},
+			Connections: 1,
+		})
+	}
+
+	now := time.Date(2020, 12, 20, 12, 0, 0, 0, time.UTC)
+	var r eventRecorder
+	if err := sendFlows(context.Background(), &r, s, &db.ListFlowsCond{Limit: 2}, "", now); err != nil {
+		t.Fatalf("sendFlows() should not return an error: %v", err)
+	}
+	if len(r.events) != len(s.flows) {
+		t.Fatalf("sendFlows() should send %d flows, but %d", len(s.flows), len(r.events))
+	}
+	want := &siem.Event{
+		Type: siem.EventFlow, Time: now, FlowID: 3,
+		ClientAddr: "10.0.0.1", ClientProcess: "app",
+		ServerAddr: "10.0.0.4", ServerPort: 80, ServerProcess: "nginx",
+		Connections: 1,
+	}
+	if diff := cmp.Diff(want, r.events[2]); diff != "" {
+		t.Errorf("sendFlows() mismatch (-want +got):\n%s", diff)
+	}
+}
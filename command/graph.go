@@ -0,0 +1,73 @@
+package command
+
+import (
+	"context"
+	"net"
+	"os"
+
+	"golang.org/x/xerrors"
+
+	"github.com/yuuki/shawk/graph"
+	"github.com/yuuki/shawk/pkg/client"
+)
+
+// GraphParam represents a graph command parameter.
+type GraphParam struct {
+	IPv4    string
+	Depth   int
+	Since   string
+	Until   string
+	Format  string
+	GroupBy string
+}
+
+// Graph runs graph subcommand, which writes the dependency graph around the
+// node in the format of a diagram tool.
+func Graph(param *GraphParam) error {
+	if err := param.Validate(); err != nil {
+		return err
+	}
+
+	dbCon, err := openCMDB()
+	if err != nil {
+		return xerrors.Errorf("CMDB initialize error: %w", err)
+	}
+	defer dbCon.Shutdown()
+
+	q := &client.Query{Addr: net.ParseIP(param.IPv4), Depth: param.Depth}
+	if param.Since != "" {
+		if q.Since, err = durationFromString(param.Since); err != nil {
+			return err
+		}
+	}
+	if param.Until != "" {
+		if q.Until, err = durationFromString(param.Until); err != nil {
+			return err
+		}
+	}
+	deps, err := client.NewWithStore(dbCon).Query(context.Background(), q)
+	if err != nil {
+		return err
+	}
+	if isTemplateFormat(param.Format) {
+		t, err := parseFormatTemplate(param.Format)
+		if err != nil {
+			return err
+		}
+		for _, d := range deps {
+			if err := writeTemplate(os.Stdout, t, d); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	flows := make([]*client.Flow, 0, len(deps))
+	for _, d := range deps {
+		flows = append(flows, d.Flow)
+	}
+	g := graph.New(flows)
+	if err := g.GroupBy(param.GroupBy); err != nil {
+		return err
+	}
+	return graph.Render(os.Stdout, g, param.Format)
+}
@@ -0,0 +1,68 @@
+package command
+
+import (
+	"net"
+	"os"
+	"os/user"
+	"strconv"
+	"syscall"
+
+	"golang.org/x/xerrors"
+
+	"github.com/yuuki/shawk/agent"
+	"github.com/yuuki/shawk/probe/netlink/netutil"
+)
+
+// HelperParam represents a helper command parameter.
+type HelperParam struct {
+	// Socket is the path of the unix socket the helper listens on.
+	Socket string
+	// User is the user of the agent permitted to connect to the socket
+	// besides root, or empty for root only.
+	User string
+}
+
+// Helper runs helper subcommand, which serves the sockets dumped by netlink
+// and their processes from /proc to the unprivileged agent on the unix
+// socket until SIGTERM or SIGINT.
+func Helper(param *HelperParam) error {
+	if err := param.Validate(); err != nil {
+		return err
+	}
+	uid, gid := -1, -1
+	if param.User != "" {
+		u, err := user.Lookup(param.User)
+		if err != nil {
+			return xerrors.Errorf("could not look up --user: %w", err)
+		}
+		// The ids of the users of Linux are numeric.
+		uid, _ = strconv.Atoi(u.Uid)
+		gid, _ = strconv.Atoi(u.Gid)
+	}
+
+	// Remove the socket left by the helper killed before.
+	if fi, err := os.Lstat(param.Socket); err == nil && fi.Mode()&os.ModeSocket != 0 {
+		if err := os.Remove(param.Socket); err != nil {
+			return xerrors.Errorf("could not remove the stale socket: %w", err)
+		}
+	}
+	// Create the socket of 0600 under the umask, since the other users
+	// could connect to it before it is changed by chmod.
+	mask := syscall.Umask(0177)
+	l, err := net.Listen("unix", param.Socket)
+	syscall.Umask(mask)
+	if err != nil {
+		return xerrors.Errorf("could not listen on %s: %w", param.Socket, err)
+	}
+	defer l.Close()
+	if uid >= 0 {
+		if err := os.Chown(param.Socket, uid, gid); err != nil {
+			return xerrors.Errorf("could not change the owner of the socket: %w", err)
+		}
+	}
+
+	ctx, cancel := agent.SignalContext()
+	defer cancel()
+	logger.Infof("Serving the sockets and the processes on %s", param.Socket)
+	return netutil.ServeHelper(ctx, l)
+}
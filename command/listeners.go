@@ -0,0 +1,95 @@
+package command
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"golang.org/x/xerrors"
+
+	"github.com/yuuki/shawk/probe"
+	"github.com/yuuki/shawk/probe/netlink"
+)
+
+// ListenersParam represents a listeners command parameter.
+type ListenersParam struct {
+	// Allow is the file of the allow list of the listeners, or empty to
+	// print all the listeners as the baseline.
+	Allow  string
+	Format string
+}
+
+// Listeners runs listeners subcommand, which prints the listening ports of
+// the host with their processes. With the allow list, it prints only the
+// unexpected ones, and returns an error if any.
+func Listeners(param *ListenersParam) error {
+	if err := param.Validate(); err != nil {
+		return err
+	}
+	var allow *probe.ListenerAllowList
+	if param.Allow != "" {
+		var err error
+		if allow, err = readListenerAllowList(param.Allow); err != nil {
+			return err
+		}
+	}
+	listeners, err := netlink.GetListeners()
+	if err != nil {
+		return xerrors.Errorf("could not get the listeners: %w", err)
+	}
+	unexpected := filterListeners(listeners, allow)
+	for _, l := range unexpected {
+		if err := writeListener(os.Stdout, l, param.Format); err != nil {
+			return err
+		}
+	}
+	if allow != nil && len(unexpected) > 0 {
+		return xerrors.Errorf("%d of %d listeners are not allowed by %s", len(unexpected), len(listeners), param.Allow)
+	}
+	return nil
+}
+
+// filterListeners returns the listeners not allowed by allow, or all of
+// them if allow is nil.
+func filterListeners(listeners []*probe.Listener, allow *probe.ListenerAllowList) []*probe.Listener {
+	if allow == nil {
+		return listeners
+	}
+	var unexpected []*probe.Listener
+	for _, l := range listeners {
+		if !allow.Allows(l) {
+			unexpected = append(unexpected, l)
+		}
+	}
+	return unexpected
+}
+
+// readListenerAllowList reads the allow list from the file, or from stdin if
+// the file is '-'.
+func readListenerAllowList(file string) (*probe.ListenerAllowList, error) {
+	var r io.Reader = os.Stdin
+	if file != "-" {
+		f, err := os.Open(file)
+		if err != nil {
+			return nil, xerrors.Errorf("could not open the allow list: %w", err)
+		}
+		defer f.Close()
+		r = f
+	}
+	l, err := probe.ParseListenerAllowList(r)
+	if err != nil {
+		return nil, xerrors.Errorf("invalid allow list %s: %w", file, err)
+	}
+	return l, nil
+}
+
+// writeListener writes the listener in the format as a line, which is a
+// line of the allow list in the text format.
+func writeListener(w io.Writer, l *probe.Listener, format string) error {
+	if format == FormatJSON {
+		return json.NewEncoder(w).Encode(l)
+	}
+	_, err := fmt.Fprintln(w, l)
+	return err
+}
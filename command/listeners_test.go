@@ -0,0 +1,44 @@
+package command
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/yuuki/shawk/probe"
+)
+
+func TestFilterListeners(t *testing.T) {
+	listeners := []*probe.Listener{
+		{Port: 22, Addrs: []string{"0.0.0.0", "::"}, Processes: []*probe.Process{{Name: "sshd", Pgid: 1}}},
+		{Port: 80, Addrs: []string{"0.0.0.0"}, Processes: []*probe.Process{{Name: "nginx", Pgid: 100}}},
+		{Port: 4444, Addrs: []string{"0.0.0.0"}, Processes: []*probe.Process{{Name: "nc", Pgid: 200}}},
+	}
+	allow, err := probe.ParseListenerAllowList(strings.NewReader("22 sshd\n80 nginx\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		allow  *probe.ListenerAllowList
+		format string
+		want   string
+	}{
+		{nil, FormatText, "22 sshd # 0.0.0.0,::\n80 nginx # 0.0.0.0\n4444 nc # 0.0.0.0\n"},
+		{allow, FormatText, "4444 nc # 0.0.0.0\n"},
+		{allow, FormatJSON, `{"port":4444,"addrs":["0.0.0.0"],"processes":[{"name":"nc","pgid":200}]}` + "\n"},
+	}
+	for _, tt := range tests {
+		var b bytes.Buffer
+		for _, l := range filterListeners(listeners, tt.allow) {
+			if err := writeListener(&b, l, tt.format); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if diff := cmp.Diff(tt.want, b.String()); diff != "" {
+			t.Errorf("filterListeners() mismatch (-want +got):\n%s", diff)
+		}
+	}
+}
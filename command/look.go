@@ -1,18 +1,29 @@
 package command
 
 import (
+	"context"
 	"fmt"
+	"io"
 	"net"
+	"os"
+	"sort"
 	"time"
 
+	"github.com/yuuki/shawk/agent"
 	"github.com/yuuki/shawk/config"
 	"github.com/yuuki/shawk/db"
+	"github.com/yuuki/shawk/pkg/client"
+	"github.com/yuuki/shawk/probe"
 	"golang.org/x/xerrors"
 )
 
 const (
 	// MaxGraphDepth is maximum depth of dependency graph.
 	MaxGraphDepth = 4
+
+	// DirectionAll is the --direction of look to show both the passive
+	// flows and the active flows of the node.
+	DirectionAll = "all"
 )
 
 // LookParam represents a look command parameter.
@@ -21,33 +32,132 @@ type LookParam struct {
 	Depth int
 	Since string
 	Until string
+
+	// Direction is DirectionAll, "passive" or "active" to show only the
+	// flows from the clients or to the servers of the node. Empty is
+	// DirectionAll. Process, Port and MinConnections filter the flows in
+	// the queries to the CMDB as db.FindFlowsCond.
+	Direction      string
+	Process        string
+	Port           int
+	MinConnections int
+
+	// Sort is one of probe.SortOrders, or empty to keep the order of the
+	// CMDB. Limit is the maximum number of the flows of each node if positive.
+	Sort  string
+	Limit int
+
+	// Color is one of ColorAuto, ColorAlways or ColorNever. Empty is ColorAuto.
+	Color string
+	// Format is FormatText or a template of client.Dependency of each flow.
+	Format string
+
+	// Watch re-runs the query every Interval until interrupted.
+	Watch    bool
+	Interval time.Duration
 }
 
 // Look runs look subcommand.
 func Look(param *LookParam) error {
-	var (
-		since, until time.Time
-		err          error
-	)
-	if param.Since != "" {
-		since, err = durationFromString(param.Since)
+	if err := param.Validate(); err != nil {
+		return err
+	}
+
+	dbCon, err := openCMDB()
+	if err != nil {
+		return xerrors.Errorf("CMDB initialize error: %w", err)
+	}
+	addr := net.ParseIP(param.IPv4)
+	if m, ok := dbCon.(db.Merger); ok {
+		alias, err := m.FindNodeAlias(addr)
 		if err != nil {
 			return err
 		}
+		if alias != nil {
+			logger.Infof("%s was merged into %s", addr, alias)
+			addr = alias
+		}
 	}
-	if param.Until != "" {
-		until, err = durationFromString(param.Until)
+
+	if isTemplateFormat(param.Format) {
+		t, err := parseFormatTemplate(param.Format)
+		if err != nil {
+			return err
+		}
+		cond, err := lookCond(param, addr)
+		if err != nil {
+			return err
+		}
+		pflows, aflows, err := findLookFlows(dbCon, cond, param.Direction)
 		if err != nil {
 			return err
 		}
+		for _, d := range lookDependencies(pflows, aflows, param.Sort, param.Limit) {
+			if err := writeTemplate(os.Stdout, t, d); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	query := func() ([]lookRow, error) {
+		cond, err := lookCond(param, addr)
+		if err != nil {
+			return nil, err
+		}
+		return queryLook(dbCon, cond, param.Direction, param.Sort, param.Limit)
 	}
 
-	if param.IPv4 != "" {
-		return doIPv4(param.IPv4, param.Depth, since, until)
+	if param.Watch {
+		ctx, cancel := agent.SignalContext()
+		defer cancel()
+		return watchLook(ctx, os.Stdout, param, useColor(param.Color, os.Stdout), query)
 	}
+
+	rows, err := query()
+	if err != nil {
+		return err
+	}
+	table := make([]tableRow, 0, len(rows))
+	for _, r := range rows {
+		table = append(table, r.tableRow)
+	}
+	writeTable(os.Stdout, table, useColor(param.Color, os.Stdout))
 	return nil
 }
 
+// lookRange returns the time range of the flows by --since and --until,
+// which are relative to now.
+func lookRange(param *LookParam) (since, until time.Time, err error) {
+	if param.Since != "" {
+		since, err = durationFromString(param.Since)
+		if err != nil {
+			return
+		}
+	}
+	if param.Until != "" {
+		until, err = durationFromString(param.Until)
+	}
+	return
+}
+
+// lookCond returns the condition of the flows of the node of addr by the
+// options of param.
+func lookCond(param *LookParam, addr net.IP) (*db.FindFlowsCond, error) {
+	since, until, err := lookRange(param)
+	if err != nil {
+		return nil, err
+	}
+	return &db.FindFlowsCond{
+		Addrs:          []net.IP{addr},
+		Since:          since,
+		Until:          until,
+		Process:        param.Process,
+		Port:           uint16(param.Port),
+		MinConnections: param.MinConnections,
+	}, nil
+}
+
 func durationFromString(s string) (time.Time, error) {
 	d, err := time.ParseDuration(s)
 	if err != nil {
@@ -56,60 +166,337 @@ func durationFromString(s string) (time.Time, error) {
 	return time.Now().Add(-d), nil
 }
 
-func doIPv4(ipv4 string, depth int, since, until time.Time) error {
-	dbCon, err := db.New(config.Config.CMDB.URL)
+// lookRow is a line of the output of look.
+type lookRow struct {
+	tableRow
+	// group is the node the row belongs to.
+	group string
+	// key identifies the row across the refreshes of --watch.
+	key string
+}
+
+func nodeKey(n *db.Node) string {
+	return fmt.Sprintf("%s:%d:%t:%d:%s", n.Addr(), n.Port, n.Aggregated, n.Pgid, n.Pname)
+}
+
+func sortedGroups(flows db.Flows) []string {
+	groups := make([]string, 0, len(flows))
+	for g := range flows {
+		groups = append(groups, g)
+	}
+	sort.Strings(groups)
+	return groups
+}
+
+// findLookFlows finds the flows of cond from the clients and to the servers
+// of the node. The flows of the other direction than direction are not
+// queried unless it is DirectionAll or empty.
+func findLookFlows(dbCon db.Store, cond *db.FindFlowsCond, direction string) (pflows, aflows db.Flows, err error) {
+	pflows, aflows = db.Flows{}, db.Flows{}
+	if direction != probe.FlowActive.String() {
+		c := *cond
+		pflows, err = dbCon.FindPassiveFlows(&c)
+		if err != nil {
+			return nil, nil, xerrors.Errorf("find passive flows error: %w", err)
+		}
+	}
+	if direction != probe.FlowPassive.String() {
+		c := *cond
+		aflows, err = dbCon.FindActiveFlows(&c)
+		if err != nil {
+			return nil, nil, xerrors.Errorf("find active flows error: %w", err)
+		}
+	}
+	return pflows, aflows, nil
+}
+
+// queryLook queries the flows of cond of the direction into the rows to
+// print.
+func queryLook(dbCon db.Store, cond *db.FindFlowsCond, direction, sortBy string, limit int) ([]lookRow, error) {
+	pflows, aflows, err := findLookFlows(dbCon, cond, direction)
 	if err != nil {
-		return xerrors.Errorf("postgres initialize error: %w", err)
+		return nil, err
 	}
-	addr := net.ParseIP(ipv4)
+	unhealthy, err := unhealthyAgents(dbCon, time.Now(), pflows, aflows)
+	if err != nil {
+		return nil, err
+	}
+	return buildLookRows(pflows, aflows, sortBy, limit, unhealthy), nil
+}
 
-	pflows, err := dbCon.FindPassiveFlows(&db.FindFlowsCond{
-		Addrs: []net.IP{addr},
-		Since: since,
-		Until: until,
-	})
+// agentStaleAfter returns how long an agent stays healthy without a record
+// of the scans or a successful scan, which is a few flushes of the agents.
+func agentStaleAfter() time.Duration {
+	return 3 * config.Config.ProbeFlushInterval
+}
+
+// unhealthyAgents returns the problems of the unhealthy agents of the nodes
+// of the flows at now by their addresses, or none if the store doesn't
+// record the health of the agents. The addresses without agents are not
+// unhealthy.
+func unhealthyAgents(dbCon db.Store, now time.Time, flows ...db.Flows) (map[string]string, error) {
+	r, ok := dbCon.(db.AgentHealthReader)
+	if !ok {
+		return nil, nil
+	}
+	seen := map[string]bool{}
+	var addrs []net.IP
+	for _, fs := range flows {
+		for _, group := range fs {
+			for _, f := range group {
+				for _, n := range []*db.Node{f.ActiveNode, f.PassiveNode} {
+					if key := n.IPAddr.String(); !seen[key] {
+						seen[key] = true
+						addrs = append(addrs, n.IPAddr)
+					}
+				}
+			}
+		}
+	}
+	if len(addrs) == 0 {
+		return nil, nil
+	}
+	agents, err := r.FindAgentHealth(addrs)
 	if err != nil {
-		return xerrors.Errorf("find active flows error: %w", err)
+		return nil, err
 	}
+	unhealthy := map[string]string{}
+	for _, h := range agents {
+		problem := h.Problem(now, agentStaleAfter())
+		if problem == "" {
+			continue
+		}
+		for _, addr := range h.Addrs {
+			unhealthy[addr.String()] = fmt.Sprintf("agent %s: %s", h.Host, problem)
+		}
+	}
+	return unhealthy, nil
+}
 
-	// print thet flows of passive nodes
-	for _, flows := range pflows {
-		pn := flows[0].PassiveNode
-		fmt.Printf("%s:%d ('%s', pgid=%d)\n", pn.IPAddr, pn.Port, pn.Pname, pn.Pgid)
+// buildLookRows builds the trees of the passive nodes and the active nodes.
+// The flows of each node are sorted by sortBy if not empty, and at most limit
+// flows are shown if limit is positive. The nodes of the unhealthy agents by
+// their addresses are flagged with the problems.
+// No implementation of printing tree with depth > 1
+func buildLookRows(pflows, aflows db.Flows, sortBy string, limit int, unhealthy map[string]string) []lookRow {
+	var rows []lookRow
+	for _, tree := range []struct {
+		flows  db.Flows
+		prefix string
+		arrow  string
+		root   func(f *db.Flow) (string, *db.Node)
+		peer   func(f *db.Flow) *db.Node
+	}{
+		{
+			flows:  pflows,
+			prefix: "passive\t",
+			arrow:  arrowPassive,
+			root:   func(f *db.Flow) (string, *db.Node) { return addrPort(f.PassiveNode), f.PassiveNode },
+			peer:   func(f *db.Flow) *db.Node { return f.ActiveNode },
+		},
+		{
+			flows:  aflows,
+			prefix: "active\t",
+			arrow:  arrowActive,
+			root:   func(f *db.Flow) (string, *db.Node) { return f.ActiveNode.Addr(), f.ActiveNode },
+			peer:   func(f *db.Flow) *db.Node { return f.PassiveNode },
+		},
+	} {
+		for _, group := range sortedGroups(tree.flows) {
+			flows := append([]*db.Flow(nil), tree.flows[group]...)
+			g := tree.prefix + group
+			addr, root := tree.root(flows[0])
+			rows = append(rows, lookRow{
+				tableRow: tableRow{cells: append(nodeCells(addr, root), agentCells(root, unhealthy)...), free: true},
+				group:    g,
+				key:      g,
+			})
+			if sortBy != "" {
+				sortLookFlows(flows, sortBy, tree.peer)
+			}
+			for i, flow := range flows {
+				if limit > 0 && i >= limit {
+					rows = append(rows, lookRow{
+						tableRow: tableRow{cells: moreCells(len(flows) - limit)},
+						group:    g,
+						key:      g + "\tmore",
+					})
+					break
+				}
+				cells := append(peerCells(tree.arrow, tree.peer(flow), flow.Connections), annotationCells(flow)...)
+				rows = append(rows, lookRow{
+					tableRow: tableRow{cells: append(cells, agentCells(tree.peer(flow), unhealthy)...)},
+					group:    g,
+					key:      g + "\t" + nodeKey(flow.ActiveNode) + "\t" + nodeKey(flow.PassiveNode),
+				})
+			}
+		}
+	}
+	return rows
+}
 
-		printPassiveFlows(flows)
+// lookDependencies returns the flows of the nodes as the dependencies of the
+// depth 1 for the templates, in the order of the rows of buildLookRows
+// without the rows of the nodes and of the flows beyond limit.
+func lookDependencies(pflows, aflows db.Flows, sortBy string, limit int) []*client.Dependency {
+	var deps []*client.Dependency
+	for _, tree := range []struct {
+		flows     db.Flows
+		direction probe.FlowDirection
+		peer      func(f *db.Flow) *db.Node
+	}{
+		{pflows, probe.FlowPassive, func(f *db.Flow) *db.Node { return f.ActiveNode }},
+		{aflows, probe.FlowActive, func(f *db.Flow) *db.Node { return f.PassiveNode }},
+	} {
+		for _, group := range sortedGroups(tree.flows) {
+			flows := append([]*db.Flow(nil), tree.flows[group]...)
+			if sortBy != "" {
+				sortLookFlows(flows, sortBy, tree.peer)
+			}
+			if limit > 0 && len(flows) > limit {
+				flows = flows[:limit]
+			}
+			for _, f := range flows {
+				deps = append(deps, &client.Dependency{Flow: f, Direction: tree.direction, Depth: 1})
+			}
+		}
 	}
+	return deps
+}
 
-	aflows, err := dbCon.FindActiveFlows(&db.FindFlowsCond{
-		Addrs: []net.IP{addr},
-		Since: since,
-		Until: until,
-	})
-	if err != nil {
-		return xerrors.Errorf("find active flows error: %w", err)
+// sortLookFlows sorts the flows of a node by the order.
+func sortLookFlows(flows []*db.Flow, by string, peer func(f *db.Flow) *db.Node) {
+	sortKey := func(f *db.Flow) probe.SortKey {
+		p := peer(f)
+		return probe.SortKey{
+			Connections: int64(f.Connections),
+			PeerAddr:    p.IPAddr,
+			PeerPort:    p.Port,
+			Port:        f.PassiveNode.Port,
+			Process:     p.Pname,
+		}
 	}
+	sort.SliceStable(flows, func(i, j int) bool {
+		if c := sortKey(flows[i]).Compare(sortKey(flows[j]), by); c != 0 {
+			return c < 0
+		}
+		return nodeKey(peer(flows[i])) < nodeKey(peer(flows[j]))
+	})
+}
+
+// rowChange is the change of a row since the previous refresh.
+type rowChange int
 
-	// print the flows of active nodes
-	for _, flows := range aflows {
-		anode := flows[0].ActiveNode
-		fmt.Printf("%s ('%s', pgid=%d)\n", anode.IPAddr, anode.Pname, anode.Pgid)
+const (
+	rowKept rowChange = iota
+	rowAppeared
+	rowDisappeared
+	rowChanged
+)
+
+type changedRow struct {
+	lookRow
+	change rowChange
+}
+
+// diffRows marks the rows of cur by the changes since prev. The rows
+// disappeared are placed at the end of their node, or at the end if the
+// whole node has disappeared.
+func diffRows(prev, cur []lookRow) []changedRow {
+	prevRows := make(map[string]lookRow, len(prev))
+	for _, r := range prev {
+		prevRows[r.key] = r
+	}
+	curKeys := make(map[string]struct{}, len(cur))
+	curGroups := make(map[string]struct{}, len(cur))
+	for _, r := range cur {
+		curKeys[r.key] = struct{}{}
+		curGroups[r.group] = struct{}{}
+	}
+	disappeared := func(group string) []changedRow {
+		var rows []changedRow
+		for _, r := range prev {
+			if _, ok := curKeys[r.key]; !ok && r.group == group {
+				rows = append(rows, changedRow{r, rowDisappeared})
+			}
+		}
+		return rows
+	}
 
-		printActiveFlows(flows)
+	rows := make([]changedRow, 0, len(cur))
+	for i, r := range cur {
+		change := rowKept
+		if p, ok := prevRows[r.key]; !ok {
+			change = rowAppeared
+		} else if plain(p.cells) != plain(r.cells) {
+			change = rowChanged
+		}
+		rows = append(rows, changedRow{r, change})
+		if i == len(cur)-1 || cur[i+1].group != r.group {
+			rows = append(rows, disappeared(r.group)...)
+		}
+	}
+	seen := make(map[string]struct{})
+	for _, r := range prev {
+		if _, ok := curGroups[r.group]; ok {
+			continue
+		}
+		if _, ok := seen[r.group]; !ok {
+			seen[r.group] = struct{}{}
+			rows = append(rows, disappeared(r.group)...)
+		}
 	}
+	return rows
+}
 
-	return nil
+var rowMarks = map[rowChange]cell{
+	rowKept:        {" ", styleNone},
+	rowAppeared:    {"+", styleAdded},
+	rowDisappeared: {"-", styleRemoved},
+	rowChanged:     {"~", styleChanged},
 }
 
-func printPassiveFlows(flows []*db.Flow) {
-	// No implementation of printing tree with depth > 1
-	for _, flow := range flows {
-		fmt.Printf("└<-- %s\n", flow.ActiveNode)
+// printChangedRows prints the rows with the marks of the changes.
+func printChangedRows(w io.Writer, rows []changedRow, color bool) {
+	table := make([]tableRow, 0, len(rows))
+	for _, r := range rows {
+		cells := append([]cell{rowMarks[r.change]}, r.cells...)
+		table = append(table, tableRow{cells: cells, free: r.free})
 	}
+	writeTable(w, table, color)
 }
 
-func printActiveFlows(flows []*db.Flow) {
-	// No implementation of printing tree with depth > 1
-	for _, flow := range flows {
-		fmt.Printf("└--> %s\n", flow.PassiveNode)
+// watchLook runs query every param.Interval, and highlights the rows
+// appeared, disappeared or changed since the previous refresh.
+func watchLook(ctx context.Context, w io.Writer, param *LookParam, color bool, query func() ([]lookRow, error)) error {
+	ticker := time.NewTicker(param.Interval)
+	defer ticker.Stop()
+
+	var prev []lookRow
+	first := true
+	for {
+		rows, err := query()
+		// Clear the screen.
+		fmt.Fprint(w, "\x1b[H\x1b[2J")
+		fmt.Fprintf(w, "Every %s: shawk look --ipv4 %s\t%s\n", param.Interval, param.IPv4, time.Now().Format("2006-01-02 15:04:05"))
+		fmt.Fprintf(w, "+ appeared  - disappeared  ~ changed\n\n")
+		switch {
+		case err != nil:
+			// Keep watching through a failover of the CMDB.
+			fmt.Fprintf(w, "error: %v\n", err)
+		default:
+			if first {
+				// Nothing is highlighted on the first refresh.
+				prev, first = rows, false
+			}
+			printChangedRows(w, diffRows(prev, rows), color)
+			prev = rows
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
 	}
 }
@@ -0,0 +1,288 @@
+package command
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"golang.org/x/xerrors"
+
+	"github.com/yuuki/shawk/db"
+	"github.com/yuuki/shawk/probe"
+)
+
+// testRow returns a row of the root node if peer is empty, or the flow to peer.
+func testRow(group, peer string, connections int) lookRow {
+	if peer == "" {
+		return lookRow{tableRow: tableRow{cells: []cell{{group, styleBold}}, free: true}, group: group, key: group}
+	}
+	return lookRow{
+		tableRow: tableRow{cells: []cell{{arrowPassive, stylePassive}, {peer, styleDim}, {fmt.Sprintf("%d conns", connections), styleNone}}},
+		group:    group,
+		key:      group + "\t" + peer,
+	}
+}
+
+func TestDiffRows(t *testing.T) {
+	prev := []lookRow{
+		testRow("a", "", 0),
+		testRow("a", "10.0.0.11:many", 1),
+		testRow("a", "10.0.0.12:many", 1),
+		testRow("b", "", 0),
+		testRow("b", "10.0.0.13:24224", 1),
+	}
+	cur := []lookRow{
+		testRow("a", "", 0),
+		testRow("a", "10.0.0.11:many", 3),
+		testRow("a", "10.0.0.14:many", 1),
+	}
+
+	type row struct {
+		Key    string
+		Change rowChange
+	}
+	var got []row
+	for _, r := range diffRows(prev, cur) {
+		got = append(got, row{r.key, r.change})
+	}
+	want := []row{
+		{"a", rowKept},
+		{"a\t10.0.0.11:many", rowChanged},
+		{"a\t10.0.0.14:many", rowAppeared},
+		{"a\t10.0.0.12:many", rowDisappeared},
+		{"b", rowDisappeared},
+		{"b\t10.0.0.13:24224", rowDisappeared},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("diffRows() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestWatchLook(t *testing.T) {
+	refreshes := [][]lookRow{
+		{testRow("a", "", 0), testRow("a", "10.0.0.11:many", 1)},
+		nil, // the query fails
+		{testRow("a", "", 0)},
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var n int
+	query := func() ([]lookRow, error) {
+		defer func() { n++ }()
+		if n == len(refreshes)-1 {
+			cancel()
+		}
+		if refreshes[n] == nil {
+			return nil, xerrors.New("connection refused")
+		}
+		return refreshes[n], nil
+	}
+
+	var b bytes.Buffer
+	param := &LookParam{IPv4: "10.0.0.10", Watch: true, Interval: time.Millisecond}
+	if err := watchLook(ctx, &b, param, false, query); err != nil {
+		t.Fatal(err)
+	}
+	screens := strings.Split(b.String(), "\x1b[H\x1b[2J")[1:]
+	if len(screens) != 3 {
+		t.Fatalf("watchLook() should refresh 3 times, but %d", len(screens))
+	}
+	if strings.Contains(screens[0], "\n+  ") || strings.Contains(screens[0], "\n-  ") {
+		t.Errorf("the first refresh should not mark rows: %q", screens[0])
+	}
+	if !strings.Contains(screens[1], "error: connection refused") {
+		t.Errorf("the error should be shown: %q", screens[1])
+	}
+	if want := "\n-  └<--  10.0.0.11:many  1 conns\n"; !strings.Contains(screens[2], want) {
+		t.Errorf("the disappeared row should be marked: %q", screens[2])
+	}
+}
+
+func TestLookDependencies(t *testing.T) {
+	root := &db.Node{IPAddr: net.ParseIP("10.0.0.10"), Port: 80, Pname: "nginx", Pgid: 1}
+	client := func(addr, pname string) *db.Node {
+		return &db.Node{IPAddr: net.ParseIP(addr), Aggregated: true, Pname: pname}
+	}
+	pflows := db.Flows{
+		"10.0.0.10-nginx": {
+			{ActiveNode: client("10.0.0.20", "curl"), PassiveNode: root, Connections: 1},
+			{ActiveNode: client("10.0.0.3", "wrk"), PassiveNode: root, Connections: 7},
+		},
+	}
+	aflows := db.Flows{
+		"10.0.0.10-nginx": {
+			{ActiveNode: client("10.0.0.10", "nginx"), PassiveNode: &db.Node{IPAddr: net.ParseIP("10.0.0.30"), Port: 5432, Pname: "postgres"}, Connections: 2},
+		},
+	}
+
+	var got []string
+	for _, d := range lookDependencies(pflows, aflows, probe.SortConnections, 1) {
+		got = append(got, fmt.Sprintf("%s %d %s %d", d.Direction, d.Depth, d.ActiveNode.Pname, d.Connections))
+	}
+	want := []string{"passive 1 wrk 7", "active 1 nginx 2"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("lookDependencies() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+// healthStore records the health of the agents.
+type healthStore struct {
+	db.Store
+	agents []*db.AgentHealth
+}
+
+func (s *healthStore) FindAgentHealth(addrs []net.IP) ([]*db.AgentHealth, error) {
+	var found []*db.AgentHealth
+	for _, h := range s.agents {
+		for _, a := range h.Addrs {
+			for _, addr := range addrs {
+				if a.Equal(addr) {
+					found = append(found, h)
+				}
+			}
+		}
+	}
+	return found, nil
+}
+
+func TestUnhealthyAgents(t *testing.T) {
+	now := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	s := &healthStore{agents: []*db.AgentHealth{
+		{Host: "web-1", Addrs: []net.IP{net.ParseIP("10.0.0.10")}, LastRecord: now, LastSuccess: now},
+		{Host: "batch-1", Addrs: []net.IP{net.ParseIP("10.0.0.3")}, LastRecord: now.Add(-time.Hour), LastSuccess: now.Add(-time.Hour)},
+		{Host: "db-1", Addrs: []net.IP{net.ParseIP("10.0.0.99")}, LastRecord: now.Add(-time.Hour)},
+	}}
+	root := &db.Node{IPAddr: net.ParseIP("10.0.0.10"), Port: 80, Pname: "nginx", Pgid: 1}
+	pflows := db.Flows{
+		"10.0.0.10-nginx": {
+			{ActiveNode: &db.Node{IPAddr: net.ParseIP("10.0.0.3"), Aggregated: true, Pname: "wrk"}, PassiveNode: root, Connections: 7},
+			{ActiveNode: &db.Node{IPAddr: net.ParseIP("10.0.0.20"), Aggregated: true, Pname: "curl"}, PassiveNode: root, Connections: 1},
+		},
+	}
+
+	unhealthy, err := unhealthyAgents(s, now, pflows)
+	if err != nil {
+		t.Fatalf("unhealthyAgents() should not return an error: %v", err)
+	}
+	if diff := cmp.Diff(map[string]string{"10.0.0.3": "agent batch-1: no record for 1h0m0s"}, unhealthy); diff != "" {
+		t.Errorf("unhealthyAgents() mismatch (-want +got):\n%s", diff)
+	}
+
+	var got []string
+	for _, r := range buildLookRows(pflows, nil, "", 0, unhealthy) {
+		got = append(got, strings.Join(strings.Fields(plain(r.cells)), " "))
+	}
+	want := []string{
+		"10.0.0.10:80 nginx pgid=1",
+		"└<-- 10.0.0.3:many wrk pgid=0 7 conns ! agent batch-1: no record for 1h0m0s",
+		"└<-- 10.0.0.20:many curl pgid=0 1 conns",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("buildLookRows() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestBuildLookRows(t *testing.T) {
+	root := &db.Node{IPAddr: net.ParseIP("10.0.0.10"), Port: 80, Pname: "nginx", Pgid: 1}
+	client := func(addr, pname string) *db.Node {
+		return &db.Node{IPAddr: net.ParseIP(addr), Aggregated: true, Pname: pname}
+	}
+	pflows := db.Flows{
+		"10.0.0.10-nginx": {
+			{ActiveNode: client("10.0.0.20", "curl"), PassiveNode: root, Connections: 1},
+			{ActiveNode: client("10.0.0.3", "wrk"), PassiveNode: root, Connections: 7, Annotation: "expected: load test"},
+			{ActiveNode: client("10.0.0.11", "ab"), PassiveNode: root, Connections: 3},
+		},
+	}
+
+	tests := []struct {
+		sortBy string
+		limit  int
+		want   []string
+	}{
+		{"", 0, []string{
+			"10.0.0.10:80 nginx pgid=1",
+			"└<-- 10.0.0.20:many curl pgid=0 1 conns",
+			"└<-- 10.0.0.3:many wrk pgid=0 7 conns # expected: load test",
+			"└<-- 10.0.0.11:many ab pgid=0 3 conns",
+		}},
+		{probe.SortConnections, 2, []string{
+			"10.0.0.10:80 nginx pgid=1",
+			"└<-- 10.0.0.3:many wrk pgid=0 7 conns # expected: load test",
+			"└<-- 10.0.0.11:many ab pgid=0 3 conns",
+			"... 1 more",
+		}},
+		{probe.SortPeer, 0, []string{
+			"10.0.0.10:80 nginx pgid=1",
+			"└<-- 10.0.0.3:many wrk pgid=0 7 conns # expected: load test",
+			"└<-- 10.0.0.11:many ab pgid=0 3 conns",
+			"└<-- 10.0.0.20:many curl pgid=0 1 conns",
+		}},
+		{probe.SortProcess, 1, []string{
+			"10.0.0.10:80 nginx pgid=1",
+			"└<-- 10.0.0.11:many ab pgid=0 3 conns",
+			"... 2 more",
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s/%d", tt.sortBy, tt.limit), func(t *testing.T) {
+			var got []string
+			for _, r := range buildLookRows(pflows, nil, tt.sortBy, tt.limit, nil) {
+				// The alignment is tested by TestWriteTable.
+				got = append(got, strings.Join(strings.Fields(plain(r.cells)), " "))
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("buildLookRows() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+// condStore records the conditions of the queries of the flows.
+type condStore struct {
+	db.Store
+	queried []string
+}
+
+func (s *condStore) FindPassiveFlows(cond *db.FindFlowsCond) (db.Flows, error) {
+	s.queried = append(s.queried, fmt.Sprintf("passive %s %d %d", cond.Process, cond.Port, cond.MinConnections))
+	return db.Flows{}, nil
+}
+
+func (s *condStore) FindActiveFlows(cond *db.FindFlowsCond) (db.Flows, error) {
+	s.queried = append(s.queried, fmt.Sprintf("active %s %d %d", cond.Process, cond.Port, cond.MinConnections))
+	return db.Flows{}, nil
+}
+
+func TestFindLookFlowsFilters(t *testing.T) {
+	tests := []struct {
+		direction string
+		want      []string
+	}{
+		{"", []string{"passive nginx 80 10", "active nginx 80 10"}},
+		{DirectionAll, []string{"passive nginx 80 10", "active nginx 80 10"}},
+		{"passive", []string{"passive nginx 80 10"}},
+		{"active", []string{"active nginx 80 10"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.direction, func(t *testing.T) {
+			param := &LookParam{Direction: tt.direction, Process: "nginx", Port: 80, MinConnections: 10}
+			cond, err := lookCond(param, net.ParseIP("10.0.0.10"))
+			if err != nil {
+				t.Fatalf("lookCond() should not return an error: %v", err)
+			}
+			s := &condStore{}
+			if _, _, err := findLookFlows(s, cond, param.Direction); err != nil {
+				t.Fatalf("findLookFlows() should not return an error: %v", err)
+			}
+			if diff := cmp.Diff(tt.want, s.queried); diff != "" {
+				t.Errorf("findLookFlows() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
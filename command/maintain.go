@@ -0,0 +1,161 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"time"
+
+	"golang.org/x/xerrors"
+
+	"github.com/yuuki/shawk/agent"
+	"github.com/yuuki/shawk/db"
+)
+
+// bloatedRatio is the ratio of the dead rows reported as bloated, which is
+// the default of autovacuum_vacuum_scale_factor.
+const bloatedRatio = 0.2
+
+// DBMaintainParam represents a db maintain command parameter.
+type DBMaintainParam struct {
+	// Reindex also rebuilds the indexes.
+	Reindex bool
+	// ReportOnly only reports the bloat and the usage of the indexes.
+	ReportOnly bool
+	Color      string
+}
+
+// DBMaintain runs db maintain subcommand, which vacuums and analyzes the
+// tables of the CMDB, and reports the bloat of the tables and the usage of
+// the indexes.
+func DBMaintain(param *DBMaintainParam) error {
+	if err := param.Validate(); err != nil {
+		return err
+	}
+	store, err := openCMDB()
+	if err != nil {
+		return xerrors.Errorf("CMDB initialize error: %w", err)
+	}
+	defer store.Shutdown()
+	pg, ok := store.(*db.DB)
+	if !ok {
+		return xerrors.New("db maintain is only available for the CMDB on Postgres")
+	}
+
+	ctx, cancel := agent.SignalContext()
+	defer cancel()
+
+	if !param.ReportOnly {
+		opt := &db.MaintainOption{Vacuum: true, Analyze: true, Reindex: param.Reindex}
+		err := pg.Maintain(ctx, opt, func(op, table string, elapsed time.Duration) {
+			logger.Infof("%s %s in %s", op, table, elapsed.Round(time.Millisecond))
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	tables, err := pg.FindTableStats(ctx)
+	if err != nil {
+		return err
+	}
+	indexes, err := pg.FindIndexStats(ctx)
+	if err != nil {
+		return err
+	}
+	writeMaintainReport(os.Stdout, tables, indexes, useColor(param.Color, os.Stdout))
+	return nil
+}
+
+// writeMaintainReport writes the statistics of the tables and the indexes,
+// highlighting the bloated tables and the unused indexes.
+func writeMaintainReport(w io.Writer, tables []*db.TableStats, indexes []*db.IndexStats, color bool) {
+	rows := []tableRow{{cells: []cell{
+		{"TABLE", styleDim}, {"LIVE", styleDim}, {"DEAD", styleDim}, {"DEAD%", styleDim},
+		{"SIZE", styleDim}, {"VACUUMED", styleDim}, {"ANALYZED", styleDim},
+	}}}
+	for _, t := range tables {
+		style := styleNone
+		if t.DeadRatio() >= bloatedRatio {
+			style = styleChanged
+		}
+		rows = append(rows, tableRow{cells: []cell{
+			{t.Name, styleNone},
+			{strconv.FormatInt(t.LiveRows, 10), styleNone},
+			{strconv.FormatInt(t.DeadRows, 10), style},
+			{fmt.Sprintf("%.1f%%", t.DeadRatio()*100), style},
+			{humanBytes(t.TotalSize), styleNone},
+			{formatLastRun(t.LastVacuum), styleNone},
+			{formatLastRun(t.LastAnalyze), styleNone},
+		}})
+	}
+	writeTable(w, rows, color)
+	fmt.Fprintln(w)
+
+	rows = []tableRow{{cells: []cell{
+		{"INDEX", styleDim}, {"TABLE", styleDim}, {"SCANS", styleDim}, {"SIZE", styleDim},
+	}}}
+	for _, i := range indexes {
+		scans := cell{strconv.FormatInt(i.Scans, 10), styleNone}
+		if i.Scans == 0 {
+			scans = cell{"0 (unused)", styleChanged}
+		}
+		rows = append(rows, tableRow{cells: []cell{
+			{i.Name, styleNone}, {i.Table, styleNone}, scans, {humanBytes(i.Size), styleNone},
+		}})
+	}
+	writeTable(w, rows, color)
+}
+
+func formatLastRun(t time.Time) string {
+	if t.IsZero() {
+		return "never"
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+// humanBytes returns the size in the binary prefixes such as '1.5 MiB'.
+func humanBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return strconv.FormatInt(n, 10) + " B"
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
+
+// maintainer is the CMDB running the maintenance.
+type maintainer interface {
+	Maintain(ctx context.Context, opt *db.MaintainOption, done func(op, table string, elapsed time.Duration)) error
+}
+
+// maintainCMDB vacuums and analyzes the tables every interval until ctx is
+// done. A failed run is logged and retried at the next interval. The first
+// run waits for the interval, so that restarting the server doesn't vacuum
+// the CMDB each time.
+func maintainCMDB(ctx context.Context, m maintainer, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		start := time.Now()
+		if err := m.Maintain(ctx, &db.MaintainOption{Vacuum: true, Analyze: true}, nil); err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			logger.Warningf("could not maintain the CMDB: %+v", err)
+			continue
+		}
+		logger.Infof("vacuumed and analyzed the CMDB in %s", time.Since(start))
+	}
+}
@@ -0,0 +1,105 @@
+package command
+
+import (
+	"bytes"
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"golang.org/x/xerrors"
+
+	"github.com/yuuki/shawk/db"
+)
+
+func TestWriteMaintainReport(t *testing.T) {
+	vacuumed := time.Date(2020, 12, 20, 12, 0, 0, 0, time.UTC)
+	tables := []*db.TableStats{
+		{Name: "flows", LiveRows: 48000, DeadRows: 16000, TotalSize: 22 << 20, LastVacuum: vacuumed, LastAnalyze: vacuumed},
+		{Name: "processes", LiveRows: 1500, DeadRows: 15, TotalSize: 512 << 10},
+	}
+	indexes := []*db.IndexStats{
+		{Table: "flows", Name: "flows_destination_node_id_source_node_id_key", Size: 1 << 20},
+		{Table: "flows", Name: "flows_pkey", Scans: 1234, Size: 1100 << 10},
+	}
+	var b bytes.Buffer
+	writeMaintainReport(&b, tables, indexes, false)
+	want := `TABLE      LIVE   DEAD   DEAD%  SIZE       VACUUMED              ANALYZED
+flows      48000  16000  25.0%  22.0 MiB   2020-12-20T12:00:00Z  2020-12-20T12:00:00Z
+processes  1500   15     1.0%   512.0 KiB  never                 never
+
+INDEX                                         TABLE  SCANS       SIZE
+flows_destination_node_id_source_node_id_key  flows  0 (unused)  1.0 MiB
+flows_pkey                                    flows  1234        1.1 MiB
+`
+	if diff := cmp.Diff(want, b.String()); diff != "" {
+		t.Errorf("writeMaintainReport() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestHumanBytes(t *testing.T) {
+	tests := []struct {
+		in   int64
+		want string
+	}{
+		{0, "0 B"},
+		{1023, "1023 B"},
+		{1024, "1.0 KiB"},
+		{1536 << 10, "1.5 MiB"},
+		{3 << 40, "3.0 TiB"},
+	}
+	for _, tt := range tests {
+		if got := humanBytes(tt.in); got != tt.want {
+			t.Errorf("humanBytes(%d) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+// countMaintainer counts the runs, failing the first one.
+type countMaintainer struct {
+	n   int32
+	ran chan *db.MaintainOption
+}
+
+func (m *countMaintainer) Maintain(ctx context.Context, opt *db.MaintainOption, done func(op, table string, elapsed time.Duration)) error {
+	n := atomic.AddInt32(&m.n, 1)
+	m.ran <- opt
+	if n == 1 {
+		return xerrors.New("could not obtain lock on relation flows")
+	}
+	return nil
+}
+
+func TestMaintainCMDB(t *testing.T) {
+	m := &countMaintainer{ran: make(chan *db.MaintainOption)}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		maintainCMDB(ctx, m, time.Millisecond)
+		close(done)
+	}()
+
+	// The failed run is retried at the next interval.
+	for i := 0; i < 2; i++ {
+		select {
+		case opt := <-m.ran:
+			if diff := cmp.Diff(&db.MaintainOption{Vacuum: true, Analyze: true}, opt); diff != "" {
+				t.Errorf("the option mismatch (-want +got):\n%s", diff)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("the CMDB should be maintained %d times", i+1)
+		}
+	}
+	cancel()
+	go func() {
+		for range m.ran {
+		}
+	}()
+	select {
+	case <-done:
+		close(m.ran)
+	case <-time.After(time.Second):
+		t.Fatal("maintainCMDB() should return when ctx is done")
+	}
+}
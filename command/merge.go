@@ -0,0 +1,57 @@
+package command
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"os"
+
+	"golang.org/x/xerrors"
+
+	"github.com/yuuki/shawk/db"
+)
+
+// MergeParam represents a merge command parameter.
+type MergeParam struct {
+	// From is the previous address of the host, which is merged into To.
+	From   string
+	To     string
+	DryRun bool
+}
+
+// Merge runs merge subcommand, which merges the nodes and the flows of the
+// previous address of a host into its current address, so that the history
+// of its dependencies continues.
+func Merge(param *MergeParam) error {
+	if err := param.Validate(); err != nil {
+		return err
+	}
+	store, err := openCMDB()
+	if err != nil {
+		return xerrors.Errorf("CMDB initialize error: %w", err)
+	}
+	defer store.Shutdown()
+	m, ok := store.(db.Merger)
+	if !ok {
+		return xerrors.New("merge is not available for the CMDB")
+	}
+
+	from, to := net.ParseIP(param.From), net.ParseIP(param.To)
+	result, err := m.MergeNodes(from, to, param.DryRun)
+	if err != nil {
+		return err
+	}
+	writeMergeResult(os.Stdout, from, to, result, param.DryRun)
+	return nil
+}
+
+// writeMergeResult writes the processes and the flows merged, or to merge
+// if dryRun.
+func writeMergeResult(w io.Writer, from, to net.IP, r *db.MergeResult, dryRun bool) {
+	verb := "merged"
+	if dryRun {
+		verb = "would merge"
+	}
+	fmt.Fprintf(w, "%s %s into %s: %d processes and %d flows moved, %d processes and %d flows merged into the same ones\n",
+		verb, from, to, r.MovedProcesses, r.MovedFlows, r.MergedProcesses, r.MergedFlows)
+}
@@ -0,0 +1,19 @@
+package command
+
+import (
+	"bytes"
+	"net"
+	"testing"
+
+	"github.com/yuuki/shawk/db"
+)
+
+func TestWriteMergeResult(t *testing.T) {
+	r := &db.MergeResult{MovedProcesses: 2, MergedProcesses: 1, MovedFlows: 10, MergedFlows: 3}
+	var b bytes.Buffer
+	writeMergeResult(&b, net.ParseIP("10.0.1.5"), net.ParseIP("10.0.1.9"), r, true)
+	want := "would merge 10.0.1.5 into 10.0.1.9: 2 processes and 10 flows moved, 1 processes and 3 flows merged into the same ones\n"
+	if got := b.String(); got != want {
+		t.Errorf("writeMergeResult() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,127 @@
+package command
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"golang.org/x/xerrors"
+
+	"github.com/yuuki/shawk/agent"
+	"github.com/yuuki/shawk/db"
+	"github.com/yuuki/shawk/policy"
+	"github.com/yuuki/shawk/serve/api"
+)
+
+// PolicyCheckParam represents a policy check command parameter.
+type PolicyCheckParam struct {
+	File   string
+	Since  string
+	Until  string
+	Format string
+}
+
+// PolicyCheck runs policy check subcommand, which checks the flows in the
+// CMDB against the policy of the file, and returns an error if any flow
+// violates it after writing the violations.
+func PolicyCheck(param *PolicyCheckParam) error {
+	if err := param.Validate(); err != nil {
+		return err
+	}
+	pol, err := readPolicy(param.File)
+	if err != nil {
+		return err
+	}
+
+	cond := &db.ListFlowsCond{}
+	if param.Since != "" {
+		if cond.Since, err = durationFromString(param.Since); err != nil {
+			return err
+		}
+	}
+	if param.Until != "" {
+		if cond.Until, err = durationFromString(param.Until); err != nil {
+			return err
+		}
+	}
+
+	dbCon, err := openCMDB()
+	if err != nil {
+		return xerrors.Errorf("CMDB initialize error: %w", err)
+	}
+	defer dbCon.Shutdown()
+
+	ctx, cancel := agent.SignalContext()
+	defer cancel()
+	var flows []*db.Flow
+	err = db.EachFlow(ctx, dbCon, cond, func(f *db.Flow) error {
+		flows = append(flows, f)
+		return nil
+	})
+	if err != nil {
+		return xerrors.Errorf("could not list the flows: %w", err)
+	}
+
+	vs := pol.Check(flows)
+	for _, v := range vs {
+		if err := writeViolation(os.Stdout, v, param.Format); err != nil {
+			return err
+		}
+	}
+	if len(vs) > 0 {
+		return xerrors.Errorf("%d of %d flows violate the policy of %s", len(vs), len(flows), param.File)
+	}
+	logger.Infof("All the %d flows conform to the policy of %s", len(flows), param.File)
+	return nil
+}
+
+// readPolicy reads the policy from the file, or from stdin if the file is
+// '-'.
+func readPolicy(file string) (*policy.Policy, error) {
+	var r io.Reader = os.Stdin
+	if file != "-" {
+		f, err := os.Open(file)
+		if err != nil {
+			return nil, xerrors.Errorf("could not open the policy: %w", err)
+		}
+		defer f.Close()
+		r = f
+	}
+	pol, err := policy.Parse(r)
+	if err != nil {
+		return nil, xerrors.Errorf("invalid policy %s: %w", file, err)
+	}
+	return pol, nil
+}
+
+// violation is a violation in JSON.
+type violation struct {
+	Flow *api.Flow `json:"flow"`
+	// Rule is the rule denying the flow, or 'default deny'.
+	Rule string `json:"rule"`
+	// Line is the line number of the rule, or zero for the default.
+	Line int `json:"line,omitempty"`
+}
+
+func violationOf(v *policy.Violation) *violation {
+	if v.Rule == nil {
+		return &violation{Flow: api.FlowOf(v.Flow), Rule: "default deny"}
+	}
+	return &violation{Flow: api.FlowOf(v.Flow), Rule: v.Rule.Text, Line: v.Rule.Line}
+}
+
+// writeViolation writes the violation in the format as a line.
+func writeViolation(w io.Writer, v *policy.Violation, format string) error {
+	vj := violationOf(v)
+	if format == FormatJSON {
+		return json.NewEncoder(w).Encode(vj)
+	}
+	rule := vj.Rule
+	if vj.Line > 0 {
+		rule = fmt.Sprintf("line %d: %s", vj.Line, vj.Rule)
+	}
+	_, err := fmt.Fprintf(w, "%s --> %s %d denied by %s\n",
+		v.Flow.ActiveNode, v.Flow.PassiveNode, v.Flow.Connections, rule)
+	return err
+}
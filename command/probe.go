@@ -1,10 +1,37 @@
 package command
 
 import (
+	"fmt"
+	"net"
+	"os"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/yuuki/shawk/agent"
 	"github.com/yuuki/shawk/agent/polling"
 	"github.com/yuuki/shawk/agent/streaming"
+	"github.com/yuuki/shawk/cloud"
+	"github.com/yuuki/shawk/cloud/aws"
 	"github.com/yuuki/shawk/config"
 	"github.com/yuuki/shawk/db"
+	"github.com/yuuki/shawk/enricher"
+	"github.com/yuuki/shawk/enricher/consul"
+	"github.com/yuuki/shawk/enricher/dns"
+	"github.com/yuuki/shawk/enricher/ec2"
+	"github.com/yuuki/shawk/enricher/ecs"
+	"github.com/yuuki/shawk/enricher/environ"
+	"github.com/yuuki/shawk/enricher/host"
+	"github.com/yuuki/shawk/enricher/iface"
+	"github.com/yuuki/shawk/enricher/kubernetes"
+	"github.com/yuuki/shawk/fluentd"
+	"github.com/yuuki/shawk/logging"
+	"github.com/yuuki/shawk/probe"
+	"github.com/yuuki/shawk/probe/ebpf/statetable"
+	"github.com/yuuki/shawk/probe/netlink"
+	"github.com/yuuki/shawk/probe/netlink/netutil"
+	"github.com/yuuki/shawk/statsd"
 	"golang.org/x/xerrors"
 )
 
@@ -17,31 +44,198 @@ const (
 
 // ProbeParam represents a probe command parameter.
 type ProbeParam struct {
-	Once bool
+	Once       bool
+	Kubernetes bool
+
+	// NodeName and NodeIP override the identity of the host.
+	NodeName string
+	NodeIP   string
+
+	// Sort and Limit arrange the flows printed by --once, and Format is
+	// FormatText, FormatOpenMetrics or a template of them.
+	Sort   string
+	Limit  int
+	Format string
+
+	// Record writes the snapshots of the scans into the directory, and
+	// Replay prints the flows of the snapshots in the directory.
+	Record string
+	Replay string
+
+	LogFile       string
+	LogMaxSize    int // megabytes
+	LogMaxAge     time.Duration
+	LogMaxBackups int
 }
 
 // Probe runs probe subcommand.
 func Probe(param *ProbeParam) error {
-	logger.Infof("--> Connecting postgres ...")
+	if err := param.Validate(); err != nil {
+		return err
+	}
+
+	if param.Replay != "" {
+		return replayProbe(param)
+	}
+
+	if param.LogFile != "" {
+		f, err := logging.OpenFile(param.LogFile)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		f.MaxSize = int64(param.LogMaxSize) << 20
+		f.MaxAge = param.LogMaxAge
+		f.MaxBackups = param.LogMaxBackups
+		// logrotate sends SIGUSR1 after moving the file.
+		f.ReopenOn(syscall.SIGUSR1)
+		logging.SetOutput(f)
+	}
+
+	if param.NodeName != "" || param.NodeIP != "" {
+		hostAddrs, err := netutil.LocalIPAddrs()
+		if err != nil {
+			return err
+		}
+		if param.NodeIP != "" && !containsString(hostAddrs, param.NodeIP) {
+			logger.Warningf("--node-ip %s is not an address of this host", param.NodeIP)
+		}
+		probe.SetNodeIdentity(probe.NewNodeIdentity(param.NodeName, param.NodeIP, hostAddrs))
+		logger.Infof("Recording flows as node (name=%q, ip=%q)", param.NodeName, param.NodeIP)
+	}
 
-	dbCon, err := db.New(config.Config.CMDB.URL)
+	if addr := config.Config.DebugAddr; addr != "" && !param.Once {
+		if err := agent.ServeDebug(addr); err != nil {
+			return err
+		}
+	}
+	if addr := config.Config.HealthAddr; addr != "" && !param.Once {
+		agent.HealthStaleAfter = config.Config.HealthStaleAfter
+		if err := agent.ServeHealth(addr); err != nil {
+			return err
+		}
+	}
+
+	logger.Infof("--> Connecting the CMDB ...")
+
+	dbCon, err := openCMDB()
+	if err != nil {
+		return xerrors.Errorf("CMDB connecting error: %w", err)
+	}
+
+	logger.Infof("Connected the CMDB")
+
+	if err := dbCon.CheckSchemaVersion(); err != nil {
+		return err
+	}
+
+	host, err := agentHost(param)
 	if err != nil {
-		return xerrors.Errorf("postgres connecting error: %w", err)
+		return err
 	}
+	// The settings in the CMDB apply to the rest, such as the enrichers.
+	loadAgentSettings(dbCon, host)
 
-	logger.Infof("Connected postgres")
+	enrichers, err := buildEnrichers(param)
+	if err != nil {
+		return err
+	}
+
+	// Only the CMDB on Postgres writes by multiple connections, which are
+	// of each shard.
+	if c := config.Config.CMDB; c.WriteConcurrency > 1 {
+		for _, pg := range postgresDBs(dbCon) {
+			if err := pg.EnableParallelWrites(c.WriteBatchSize, c.WriteConcurrency); err != nil {
+				return xerrors.Errorf("postgres connecting error: %w", err)
+			}
+			logger.Infof("Writing flows by %d connections (batch size: %d)", c.WriteConcurrency, c.WriteBatchSize)
+		}
+	}
+
+	ctx, cancel := agent.SignalContext()
+	defer cancel()
+	agent.ShutdownTimeout = config.Config.ShutdownTimeout
+
+	differ := agent.NewFlowDiffer(config.Config.ProbeRefreshInterval)
+	differ.SetIgnorePorts(config.Config.ProbeIgnorePorts)
+	if !param.Once {
+		watchConfig(ctx, dbCon, differ)
+		if config.Config.ProbeConntrack {
+			if err := watchConntrack(ctx, dbCon, config.Config.ProbeFlushInterval); err != nil {
+				return err
+			}
+		}
+	}
+
+	if c := config.Config.Statsd; c.Addr != "" && !param.Once {
+		client, err := statsd.NewClient(c.Addr, c.Prefix, c.DogStatsD, c.Tags)
+		if err != nil {
+			return err
+		}
+		defer client.Close()
+		agent.SetStatsd(client)
+		logger.Infof("Sending the metrics of the flows to statsd %s", c.Addr)
+	}
+
+	if c := config.Config.Fluentd; c.Addr != "" && !param.Once {
+		w, err := fluentd.NewWriter(c.Addr, c.Tag, host)
+		if err != nil {
+			return err
+		}
+		defer w.Close()
+		agent.SetFluentd(w)
+		logger.Infof("Shipping the flows to fluentd %s with the tag %s", c.Addr, c.Tag)
+	}
+
+	if w, ok := dbCon.(db.ScanWriter); ok {
+		addrs, err := scanAddrs(param)
+		if err != nil {
+			return err
+		}
+		agent.SetScanWriter(w, host, addrs, probeBackend(param))
+	}
 
 	switch config.Config.ProbeMode {
 	case PollingMode:
+		if path := config.Config.ProbeHelperSocket; path != "" {
+			helper := netutil.NewHelperClient(path)
+			netutil.SetInetDiag(helper)
+			netutil.SetUserEntsBuilder(helper)
+			logger.Infof("Reading the sockets and the processes from the helper on %s", path)
+		}
+		if param.Record != "" {
+			stop, err := netlink.Record(param.Record)
+			if err != nil {
+				return err
+			}
+			defer stop()
+			logger.Infof("Recording the scans into %s", param.Record)
+		}
+		if config.Config.ProbeStateTable && !param.Once {
+			table, err := statetable.Open(config.Config.ProbeStateTableSize)
+			if err != nil {
+				return xerrors.Errorf("could not open the state table: %w", err)
+			}
+			defer table.Close()
+			netutil.SetInetDiag(table)
+			logger.Infof("Reading the sockets from the state table of eBPF")
+		}
 		if param.Once {
-			if err := polling.RunOnce(dbCon); err != nil {
+			flows, err := polling.RunOnce(dbCon, enrichers, differ)
+			if err != nil {
+				return err
+			}
+			if err := printHostFlows(os.Stdout, flows, param); err != nil {
 				return err
 			}
 		} else {
 			err := polling.Run(
+				ctx,
 				config.Config.ProbeInterval,
 				config.Config.ProbeFlushInterval,
 				dbCon,
+				enrichers,
+				differ,
 			)
 			if err != nil {
 				return err
@@ -49,8 +243,11 @@ func Probe(param *ProbeParam) error {
 		}
 	case StreamingMode:
 		err := streaming.Run(
+			ctx,
 			config.Config.ProbeInterval,
 			dbCon,
+			enrichers,
+			differ,
 		)
 		if err != nil {
 			return err
@@ -61,3 +258,221 @@ func Probe(param *ProbeParam) error {
 
 	return nil
 }
+
+// agentHost returns the name of the host of the agent, which is --node-name
+// or the hostname.
+func agentHost(param *ProbeParam) (string, error) {
+	if param.NodeName != "" {
+		return param.NodeName, nil
+	}
+	host, err := os.Hostname()
+	if err != nil {
+		return "", xerrors.Errorf("could not get the hostname: %w", err)
+	}
+	return host, nil
+}
+
+// probeBackend returns how the probe reads the flows, which is recorded
+// with the scans.
+func probeBackend(param *ProbeParam) string {
+	switch {
+	case config.Config.ProbeMode == StreamingMode:
+		return agent.BackendEBPF
+	case config.Config.ProbeStateTable && !param.Once:
+		return agent.BackendStateTable
+	default:
+		return agent.BackendNetlink
+	}
+}
+
+// scanAddrs returns the addresses of this host recorded with the scans, which
+// is --node-ip if given, since the flows are written as of it.
+func scanAddrs(param *ProbeParam) ([]net.IP, error) {
+	if param.NodeIP != "" {
+		return []net.IP{net.ParseIP(param.NodeIP)}, nil
+	}
+	hostAddrs, err := netutil.LocalIPAddrs()
+	if err != nil {
+		return nil, err
+	}
+	addrs := make([]net.IP, 0, len(hostAddrs))
+	for _, a := range hostAddrs {
+		addrs = append(addrs, net.ParseIP(a))
+	}
+	return addrs, nil
+}
+
+// buildEnrichers creates the enrichers enabled by the parameter or the config.
+func buildEnrichers(param *ProbeParam) (enricher.Chain, error) {
+	var enrichers enricher.Chain
+
+	if provider := config.Config.CloudProvider; provider != "" {
+		md, err := cloud.Detect(provider)
+		switch {
+		case err == nil:
+			logger.Infof("Labeling the host as %s instance %s in %s", md.Provider, md.InstanceID, md.Zone)
+			enrichers = append(enrichers, host.NewEnricher(md.Labels()))
+		case provider == cloud.ProviderAuto && xerrors.Is(err, cloud.ErrNotDetected):
+			logger.Warningf("Skip labeling the host: %v", err)
+		default:
+			return nil, xerrors.Errorf("could not get cloud metadata: %w", err)
+		}
+	}
+
+	if param.Kubernetes || config.Config.Kubernetes.Enabled {
+		if !kubernetes.InCluster() {
+			return nil, xerrors.New("--kubernetes requires running in a Kubernetes pod")
+		}
+		if err := kubernetes.SetupHostContext(); err != nil {
+			return nil, err
+		}
+		nodeName, err := kubernetes.NodeName()
+		if err != nil {
+			return nil, err
+		}
+		client, err := kubernetes.NewInClusterClient()
+		if err != nil {
+			return nil, xerrors.Errorf("kubernetes client error: %w", err)
+		}
+		logger.Infof("Running in Kubernetes DaemonSet mode on node %s", nodeName)
+		enrichers = append(enrichers, kubernetes.NewEnricher(
+			client, nodeName, config.Config.Kubernetes.RefreshInterval))
+	}
+
+	if key := config.Config.ProbeServiceEnv; key != "" {
+		logger.Infof("Labeling flows with the services named by $%s of the processes", key)
+		enrichers = append(enrichers, environ.NewEnricher(key))
+	}
+
+	if config.Config.ProbeInterfaces {
+		logger.Infof("Labeling flows with the interfaces of the local addresses")
+		enrichers = append(enrichers, iface.NewEnricher())
+	}
+
+	if config.Config.ProbeDNSCapture {
+		logger.Infof("Labeling flows with the names of the DNS responses captured on the host")
+		e := dns.NewEnricher(config.Config.ProbeDNSRetention)
+		// The capture runs as long as the agent.
+		if _, err := dns.Capture(e); err != nil {
+			return nil, err
+		}
+		enrichers = append(enrichers, e)
+	}
+
+	if c := config.Config.Consul; c.Enabled {
+		logger.Infof("Labeling flows with the Consul catalog on %s", c.Address)
+		enrichers = append(enrichers, consul.NewEnricher(&consul.Option{
+			Address:         c.Address,
+			Token:           c.Token,
+			Datacenter:      c.Datacenter,
+			RefreshInterval: c.RefreshInterval,
+		}))
+	}
+
+	if c := config.Config.EC2; c.Enabled {
+		imds := aws.NewIMDS()
+		region := c.Region
+		if region == "" {
+			r, err := aws.Region(imds)
+			if err != nil {
+				return nil, xerrors.Errorf("could not detect AWS region: %w", err)
+			}
+			region = r
+		}
+		logger.Infof("Labeling flows with EC2 instances in %s", region)
+		api := aws.NewEC2(aws.NewDefaultCredentials(imds), region)
+		enrichers = append(enrichers, ec2.NewEnricher(api, c.RefreshInterval))
+	}
+
+	if c := config.Config.ECS; c.Enabled {
+		task, err := aws.GetTaskMetadata()
+		if err != nil {
+			return nil, xerrors.Errorf("could not get the ECS task: %w", err)
+		}
+		if len(task.Addrs()) == 0 {
+			logger.Warningf("The ECS task %s has no addresses of the awsvpc network mode, so the local endpoints are not labeled", task.TaskARN)
+		}
+		opt := &ecs.Option{Task: task, RefreshInterval: c.RefreshInterval}
+		if c.Peers {
+			region := c.Region
+			if region == "" {
+				region = task.Region()
+			}
+			opt.Clusters = c.Clusters
+			if len(opt.Clusters) == 0 {
+				opt.Clusters = []string{task.ClusterName()}
+			}
+			opt.API = aws.NewECS(aws.NewDefaultCredentials(aws.NewIMDS()), region)
+			logger.Infof("Labeling flows with ECS tasks of %s in %s", strings.Join(opt.Clusters, ","), region)
+		} else {
+			logger.Infof("Labeling flows with ECS task %s", task.TaskARN)
+		}
+		enrichers = append(enrichers, ecs.NewEnricher(opt))
+	}
+
+	return enrichers, nil
+}
+
+// printHostFlows prints the flows sorted by --sort if not empty, and at most
+// --limit flows if it is positive, in --format. The metrics of
+// FormatOpenMetrics are of all the flows.
+func printHostFlows(w *os.File, flows []*probe.HostFlow, param *ProbeParam) error {
+	if param.Format == FormatOpenMetrics {
+		return probe.WriteOpenMetrics(w, flows)
+	}
+	if param.Sort != "" {
+		probe.SortFlows(flows, param.Sort)
+	}
+	if isTemplateFormat(param.Format) {
+		t, err := parseFormatTemplate(param.Format)
+		if err != nil {
+			return err
+		}
+		for i, f := range flows {
+			if param.Limit > 0 && i >= param.Limit {
+				break
+			}
+			if err := writeTemplate(w, t, f); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	rows := make([]tableRow, 0, len(flows))
+	for i, f := range flows {
+		if param.Limit > 0 && i >= param.Limit {
+			rows = append(rows, tableRow{cells: moreCells(len(flows) - param.Limit)})
+			break
+		}
+		rows = append(rows, tableRow{cells: hostFlowCells(f)})
+	}
+	writeTable(w, rows, useColor(ColorAuto, w))
+	return nil
+}
+
+// replayProbe prints the flows of the recorded snapshots by the scans. The
+// addresses are not resolved into the names, so that the output of a
+// snapshot is the same wherever it is replayed.
+func replayProbe(param *ProbeParam) error {
+	opt := &netlink.GetHostFlowsOption{Numeric: true, Processes: true, Filter: probe.FilterAll}
+	return netlink.Replay(param.Replay, opt, func(snapshot string, mapFlows probe.HostFlows) error {
+		flows := make([]*probe.HostFlow, 0, len(mapFlows))
+		for _, f := range mapFlows {
+			flows = append(flows, f)
+		}
+		// Sort the flows by their string as well, so that the flows of
+		// the same key stay in the same order.
+		sort.Slice(flows, func(i, j int) bool { return flows[i].String() < flows[j].String() })
+		fmt.Fprintf(os.Stdout, "# %s\n", snapshot)
+		return printHostFlows(os.Stdout, flows, param)
+	})
+}
+
+func containsString(ss []string, s string) bool {
+	for _, v := range ss {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,67 @@
+package command
+
+import (
+	"context"
+	"strings"
+
+	"github.com/yuuki/shawk/agent"
+	"github.com/yuuki/shawk/config"
+	"github.com/yuuki/shawk/db"
+	"github.com/yuuki/shawk/logging"
+)
+
+// watchConfig reloads the env file of SHAWK_ENV_FILE and the settings in the
+// CMDB in the background until ctx is canceled, and applies the changed
+// settings to the running agent.
+func watchConfig(ctx context.Context, store db.Store, differ *agent.FlowDiffer) {
+	go func() {
+		ok := config.Watch(ctx, config.Config.ReloadInterval, func(prev, cur *config.Settings) {
+			applyConfig(config.Diff(prev, cur), cur, store, differ)
+		})
+		if !ok {
+			logger.Debugf("Reloading the config is disabled without SHAWK_ENV_FILE or the settings in the CMDB")
+		}
+	}()
+}
+
+// applyConfig applies the changes of the settings, which can be changed
+// without restarting the agent, and warns about the others.
+func applyConfig(changes []*config.Change, cur *config.Settings, store db.Store, differ *agent.FlowDiffer) {
+	for _, c := range changes {
+		if !applySetting(c, cur, store, differ) {
+			logger.Warningf("Reloaded %s, which requires restarting the agent", c)
+			continue
+		}
+		logger.Infof("Reloaded %s", c)
+	}
+}
+
+// applySetting applies the change of the setting to the running agent, and
+// returns false if it can't be changed without restarting the agent.
+func applySetting(c *config.Change, cur *config.Settings, store db.Store, differ *agent.FlowDiffer) bool {
+	dbs := postgresDBs(store)
+	switch {
+	case c.Field == "LogLevel":
+		lv, _ := logging.ParseLevel(cur.LogLevel) // validated by config.Parse
+		logging.SetLogLevel(lv)
+	case c.Field == "ProbeRefreshInterval" && differ != nil:
+		differ.SetRefreshInterval(cur.ProbeRefreshInterval)
+	case c.Field == "ProbeIgnorePorts" && differ != nil:
+		differ.SetIgnorePorts(cur.ProbeIgnorePorts)
+	case strings.HasPrefix(c.Field, "CMDB.Retry") && len(dbs) > 0:
+		for _, pg := range dbs {
+			pg.SetRetryPolicy(retryPolicy(cur))
+		}
+	case c.Field == "CMDB.NotifyThreshold" && len(dbs) > 0:
+		for _, pg := range dbs {
+			pg.SetNotifyThreshold(cur.CMDB.NotifyThreshold)
+		}
+	case c.Field == "CMDB.SlowQueryThreshold" && len(dbs) > 0:
+		for _, pg := range dbs {
+			pg.SetSlowQueryThreshold(cur.CMDB.SlowQueryThreshold)
+		}
+	default:
+		return false
+	}
+	return true
+}
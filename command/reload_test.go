@@ -0,0 +1,45 @@
+package command
+
+import (
+	"testing"
+	"time"
+
+	"github.com/yuuki/shawk/agent"
+	"github.com/yuuki/shawk/config"
+	"github.com/yuuki/shawk/logging"
+	"github.com/yuuki/shawk/probe"
+)
+
+func TestApplyConfig(t *testing.T) {
+	defer logging.SetLogLevel(logging.INFO)
+
+	prev, cur := &config.Settings{}, &config.Settings{}
+	prev.LogLevel, cur.LogLevel = "info", "error"
+	prev.ProbeRefreshInterval, cur.ProbeRefreshInterval = 5*time.Minute, 0
+	prev.ProbeMode, cur.ProbeMode = "polling", "streaming"
+	cur.ProbeIgnorePorts = []uint16{9100}
+
+	differ := agent.NewFlowDiffer(prev.ProbeRefreshInterval)
+	flows := []*probe.HostFlow{
+		{
+			Direction: probe.FlowActive,
+			Local:     &probe.AddrPort{Addr: "10.0.0.1", Aggregated: true},
+			Peer:      &probe.AddrPort{Addr: "10.0.0.2", Port: 5432},
+		},
+		{
+			Direction: probe.FlowActive,
+			Local:     &probe.AddrPort{Addr: "10.0.0.1", Aggregated: true},
+			Peer:      &probe.AddrPort{Addr: "10.0.0.3", Port: 9100},
+		},
+	}
+	differ.Record(flows)
+
+	applyConfig(config.Diff(prev, cur), cur, nil, differ)
+
+	if got := differ.Filter(flows); len(got) != 1 || got[0].Peer.Port != 5432 {
+		t.Errorf("the refresh interval should be reloaded to 0, which writes all flows except to the ignored port, but %v", got)
+	}
+	if logging.Enabled(logging.WARNING) || !logging.Enabled(logging.ERROR) {
+		t.Error("the log level should be reloaded to error")
+	}
+}
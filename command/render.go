@@ -0,0 +1,255 @@
+package command
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/xerrors"
+
+	"github.com/yuuki/shawk/db"
+	"github.com/yuuki/shawk/probe"
+	"github.com/yuuki/shawk/probe/netlink/netutil"
+)
+
+const (
+	// ColorAuto colorizes the output only if it is a terminal.
+	ColorAuto = "auto"
+	// ColorAlways colorizes the output.
+	ColorAlways = "always"
+	// ColorNever does not colorize the output.
+	ColorNever = "never"
+)
+
+func validateColor(mode string) error {
+	switch mode {
+	case "", ColorAuto, ColorAlways, ColorNever:
+		return nil
+	}
+	return xerrors.Errorf("--color must be one of '%s', '%s' or '%s', but %q", ColorAuto, ColorAlways, ColorNever, mode)
+}
+
+// useColor returns whether to colorize the output into f by the mode.
+// ColorAuto follows NO_COLOR (https://no-color.org/).
+func useColor(mode string, f *os.File) bool {
+	switch mode {
+	case ColorAlways:
+		return true
+	case ColorNever:
+		return false
+	}
+	if os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// The styles of the cells by the SGR escape sequences.
+const (
+	styleNone      = ""
+	styleBold      = "\x1b[1m"
+	styleDim       = "\x1b[2m"
+	styleActive    = "\x1b[36m"   // cyan
+	stylePassive   = "\x1b[35m"   // magenta
+	stylePublic    = "\x1b[1;33m" // bold yellow
+	styleAdded     = "\x1b[32m"   // green
+	styleRemoved   = "\x1b[31m"   // red
+	styleChanged   = "\x1b[33m"   // yellow
+	styleSaturated = "\x1b[1;31m" // bold red
+	styleReset     = "\x1b[0m"
+)
+
+// cell is a column of a row of the output.
+type cell struct {
+	text  string
+	style string
+}
+
+// plain returns the text of the row without the styles.
+func plain(cells []cell) string {
+	texts := make([]string, 0, len(cells))
+	for _, c := range cells {
+		texts = append(texts, c.text)
+	}
+	return strings.TrimRight(strings.Join(texts, " "), " ")
+}
+
+// tableRow is a row of the output.
+type tableRow struct {
+	cells []cell
+	// free rows, such as the roots of the trees, are not aligned.
+	free bool
+}
+
+// writeTable writes the rows aligning their columns, and colorizes the
+// cells if color.
+func writeTable(w io.Writer, rows []tableRow, color bool) {
+	var widths []int
+	for _, row := range rows {
+		if row.free {
+			continue
+		}
+		for i, c := range row.cells {
+			if i >= len(widths) {
+				widths = append(widths, 0)
+			}
+			if n := utf8.RuneCountInString(c.text); n > widths[i] {
+				widths[i] = n
+			}
+		}
+	}
+	var b strings.Builder
+	for _, row := range rows {
+		b.Reset()
+		// Drop the empty cells at the end, so that the rows have no
+		// trailing spaces.
+		cells := row.cells
+		for len(cells) > 0 && cells[len(cells)-1].text == "" {
+			cells = cells[:len(cells)-1]
+		}
+		for i, c := range cells {
+			if row.free && c.text == "" {
+				continue
+			}
+			if i > 0 {
+				b.WriteString(" ")
+				if !row.free {
+					b.WriteString(" ")
+				}
+			}
+			if color && c.style != styleNone && c.text != "" {
+				b.WriteString(c.style + c.text + styleReset)
+			} else {
+				b.WriteString(c.text)
+			}
+			if !row.free && i < len(cells)-1 {
+				b.WriteString(strings.Repeat(" ", widths[i]-utf8.RuneCountInString(c.text)))
+			}
+		}
+		fmt.Fprintln(w, b.String())
+	}
+}
+
+func addrPort(n *db.Node) string {
+	if n.Aggregated {
+		return fmt.Sprintf("%s:many", n.Addr())
+	}
+	return net.JoinHostPort(n.Addr(), fmt.Sprint(n.Port))
+}
+
+// The arrows of the flows from the root of the tree.
+const (
+	arrowActive  = "└-->"
+	arrowPassive = "└<--"
+)
+
+// nodeCells returns the cells of the node of the root of the tree, followed
+// by the backlog of the listener of a server written by its agent, which is
+// highlighted if it is saturated.
+func nodeCells(addr string, n *db.Node) []cell {
+	cells := []cell{
+		{addr, styleBold},
+		{n.Pname, styleNone},
+		{fmt.Sprintf("pgid=%d", n.Pgid), styleDim},
+		{labelsOf(n), styleDim},
+	}
+	if n.Backlog > 0 {
+		style := styleDim
+		if n.Saturated() {
+			style = styleSaturated
+		}
+		cells = append(cells, cell{fmt.Sprintf("backlog=%d/%d", n.AcceptQueue, n.Backlog), style})
+	}
+	if len(n.Owners) > 0 {
+		cells = append(cells, cell{"owners=" + probe.FormatProcesses(n.Owners), styleDim})
+	}
+	return cells
+}
+
+// peerCells returns the cells of the peer of a flow. The arrow is colored by
+// the direction, and the private peers are dimmed to highlight the public ones.
+func peerCells(arrow string, n *db.Node, connections int) []cell {
+	arrowStyle := styleActive
+	if arrow == arrowPassive {
+		arrowStyle = stylePassive
+	}
+	peerStyle := stylePublic
+	if netutil.IsPrivateIP(n.IPAddr) {
+		peerStyle = styleDim
+	}
+	return []cell{
+		{arrow, arrowStyle},
+		{addrPort(n), peerStyle},
+		{n.Pname, styleNone},
+		{fmt.Sprintf("pgid=%d", n.Pgid), styleDim},
+		{labelsOf(n), styleDim},
+		{fmt.Sprintf("%d conns", connections), styleNone},
+	}
+}
+
+// annotationCells returns the cell of the annotation of the edge of a flow,
+// or none if it is not annotated.
+func annotationCells(f *db.Flow) []cell {
+	if f.Annotation == "" {
+		return nil
+	}
+	return []cell{{"# " + f.Annotation, styleDim}}
+}
+
+// agentCells returns the cell flagging the node whose agent is unhealthy by
+// the problem of unhealthy by the address, or none if it is healthy.
+func agentCells(n *db.Node, unhealthy map[string]string) []cell {
+	problem, ok := unhealthy[n.IPAddr.String()]
+	if !ok {
+		return nil
+	}
+	return []cell{{"! " + problem, styleSaturated}}
+}
+
+// moreCells returns the cells of the row noting the n flows not shown by --limit.
+func moreCells(n int) []cell {
+	return []cell{{"", styleNone}, {fmt.Sprintf("... %d more", n), styleDim}}
+}
+
+// hostFlowCells returns the cells of a flow probed on this host.
+func hostFlowCells(f *probe.HostFlow) []cell {
+	arrow, arrowStyle := "-->", styleActive
+	if f.Direction == probe.FlowPassive {
+		arrow, arrowStyle = "<--", stylePassive
+	}
+	peerStyle := stylePublic
+	if ip := net.ParseIP(f.Peer.Addr); ip == nil || netutil.IsPrivateIP(ip) {
+		peerStyle = styleDim
+	}
+	var pname, pgid string
+	if f.Process != nil {
+		pname, pgid = f.Process.Name, fmt.Sprintf("pgid=%d", f.Process.Pgid)
+		if len(f.Process.Owners) > 0 {
+			pgid += " owners=" + probe.FormatProcesses(f.Process.Owners)
+		}
+	}
+	var labels string
+	if len(f.Peer.Labels) > 0 {
+		labels = db.FormatLabels(f.Peer.Labels)
+	}
+	return []cell{
+		{f.Local.String(), styleNone},
+		{arrow, arrowStyle},
+		{f.Peer.String(), peerStyle},
+		{pname, styleNone},
+		{pgid, styleDim},
+		{labels, styleDim},
+		{fmt.Sprintf("%d conns", f.Connections), styleNone},
+	}
+}
+
+func labelsOf(n *db.Node) string {
+	if len(n.Labels) == 0 {
+		return ""
+	}
+	return db.FormatLabels(n.Labels)
+}
@@ -0,0 +1,82 @@
+package command
+
+import (
+	"bytes"
+	"net"
+	"os"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/yuuki/shawk/db"
+	"github.com/yuuki/shawk/probe"
+)
+
+func TestWriteTable(t *testing.T) {
+	root := &db.Node{IPAddr: net.ParseIP("10.0.0.10"), Port: 80, Pname: "nginx", Pgid: 4656}
+	rows := []tableRow{
+		{cells: nodeCells(addrPort(root), root), free: true},
+		{cells: peerCells(arrowPassive, &db.Node{IPAddr: net.ParseIP("10.0.0.11"), Aggregated: true, Pname: "wrk", Pgid: 5982}, 12)},
+		{cells: peerCells(arrowPassive, &db.Node{
+			IPAddr: net.ParseIP("203.0.113.5"), Aggregated: true, Pgid: 0,
+			Labels: map[string]string{"k8s.pod": "web-0"},
+		}, 3)},
+	}
+
+	var b bytes.Buffer
+	writeTable(&b, rows, false)
+	want := "10.0.0.10:80 nginx pgid=4656\n" +
+		"└<--  10.0.0.11:many    wrk  pgid=5982                   12 conns\n" +
+		"└<--  203.0.113.5:many       pgid=0     [k8s.pod=web-0]  3 conns\n"
+	if diff := cmp.Diff(want, b.String()); diff != "" {
+		t.Errorf("writeTable() mismatch (-want +got):\n%s", diff)
+	}
+
+	b.Reset()
+	writeTable(&b, rows[1:2], true)
+	want = "\x1b[35m└<--\x1b[0m  \x1b[2m10.0.0.11:many\x1b[0m  wrk  \x1b[2mpgid=5982\x1b[0m    12 conns\n"
+	if diff := cmp.Diff(want, b.String()); diff != "" {
+		t.Errorf("writeTable() with color mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestNodeCellsBacklog(t *testing.T) {
+	root := &db.Node{IPAddr: net.ParseIP("10.0.0.10"), Port: 80, Pname: "nginx", Pgid: 4656, AcceptQueue: 120, Backlog: 128}
+	var b bytes.Buffer
+	writeTable(&b, []tableRow{{cells: nodeCells(addrPort(root), root), free: true}}, true)
+	want := "\x1b[1m10.0.0.10:80\x1b[0m nginx \x1b[2mpgid=4656\x1b[0m \x1b[1;31mbacklog=120/128\x1b[0m\n"
+	if diff := cmp.Diff(want, b.String()); diff != "" {
+		t.Errorf("writeTable() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestNodeCellsOwners(t *testing.T) {
+	root := &db.Node{
+		IPAddr: net.ParseIP("10.0.0.10"), Port: 80, Pname: "nginx", Pgid: 4656,
+		Owners: []*probe.Process{{Name: "systemd", Pgid: 1}},
+	}
+	var b bytes.Buffer
+	writeTable(&b, []tableRow{{cells: nodeCells(addrPort(root), root), free: true}}, false)
+	want := "10.0.0.10:80 nginx pgid=4656 owners=systemd/1\n"
+	if diff := cmp.Diff(want, b.String()); diff != "" {
+		t.Errorf("writeTable() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestUseColor(t *testing.T) {
+	f, err := os.Create(t.TempDir() + "/out")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	if useColor(ColorAuto, f) {
+		t.Error("useColor(auto) should be false for a file")
+	}
+	if !useColor(ColorAlways, f) {
+		t.Error("useColor(always) should be true")
+	}
+	if useColor(ColorNever, os.Stdout) {
+		t.Error("useColor(never) should be false")
+	}
+}
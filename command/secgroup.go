@@ -0,0 +1,84 @@
+package command
+
+import (
+	"os"
+
+	"golang.org/x/xerrors"
+
+	"github.com/yuuki/shawk/agent"
+	"github.com/yuuki/shawk/cloud/aws"
+	"github.com/yuuki/shawk/config"
+	"github.com/yuuki/shawk/db"
+	"github.com/yuuki/shawk/secgroup"
+)
+
+// SecGroupParam represents a secgroup command parameter.
+type SecGroupParam struct {
+	Since string
+	Until string
+	// Region is the region of EC2, which defaults to SHAWK_EC2_REGION or
+	// the region of the instance.
+	Region string
+	Format string
+}
+
+// SecGroup runs secgroup subcommand, which writes the security group rules
+// permitting exactly the flows in the CMDB between the network interfaces of
+// EC2, and the current rules that no flow uses.
+func SecGroup(param *SecGroupParam) error {
+	if err := param.Validate(); err != nil {
+		return err
+	}
+
+	cond := &db.ListFlowsCond{}
+	var err error
+	if param.Since != "" {
+		if cond.Since, err = durationFromString(param.Since); err != nil {
+			return err
+		}
+	}
+	if param.Until != "" {
+		if cond.Until, err = durationFromString(param.Until); err != nil {
+			return err
+		}
+	}
+
+	imds := aws.NewIMDS()
+	region := param.Region
+	if region == "" {
+		region = config.Config.EC2.Region
+	}
+	if region == "" {
+		if region, err = aws.Region(imds); err != nil {
+			return xerrors.Errorf("could not detect AWS region, set --region: %w", err)
+		}
+	}
+	api := aws.NewEC2(aws.NewDefaultCredentials(imds), region)
+	enis, err := api.DescribeNetworkInterfaces()
+	if err != nil {
+		return xerrors.Errorf("could not describe the network interfaces: %w", err)
+	}
+	groups, err := api.DescribeSecurityGroups()
+	if err != nil {
+		return xerrors.Errorf("could not describe the security groups: %w", err)
+	}
+
+	dbCon, err := openCMDB()
+	if err != nil {
+		return xerrors.Errorf("CMDB initialize error: %w", err)
+	}
+	defer dbCon.Shutdown()
+
+	ctx, cancel := agent.SignalContext()
+	defer cancel()
+	var flows []*db.Flow
+	err = db.EachFlow(ctx, dbCon, cond, func(f *db.Flow) error {
+		flows = append(flows, f)
+		return nil
+	})
+	if err != nil {
+		return xerrors.Errorf("could not list the flows: %w", err)
+	}
+
+	return secgroup.Render(os.Stdout, secgroup.New(flows, enis, groups), param.Format)
+}
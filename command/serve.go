@@ -0,0 +1,99 @@
+package command
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"golang.org/x/xerrors"
+
+	"github.com/yuuki/shawk/agent"
+	"github.com/yuuki/shawk/db"
+	"github.com/yuuki/shawk/serve"
+)
+
+// ServeParam represents a serve command parameter.
+type ServeParam struct {
+	Listen string
+	// RefreshViews is the interval to refresh the materialized views.
+	// Zero doesn't refresh them.
+	RefreshViews time.Duration
+	// Maintain is the interval to vacuum and analyze the tables. Zero
+	// leaves them to autovacuum.
+	Maintain time.Duration
+	// MetricsInterval is the interval of the metrics of the graph on
+	// /metrics. Zero collects them on each scrape.
+	MetricsInterval time.Duration
+	// StaleAfter is how long a process is without its flows updated until
+	// it is stale in the metrics of the graph.
+	StaleAfter time.Duration
+	// AllowOrigins is the comma-separated origins of the pages opening the
+	// WebSocket of /changes besides the same host.
+	AllowOrigins string
+}
+
+// Serve runs serve subcommand, which serves the GraphQL API of the CMDB.
+func Serve(param *ServeParam) error {
+	if err := param.Validate(); err != nil {
+		return err
+	}
+
+	dbCon, err := openCMDB()
+	if err != nil {
+		return xerrors.Errorf("CMDB initialize error: %w", err)
+	}
+	defer dbCon.Shutdown()
+
+	ctx, cancel := agent.SignalContext()
+	defer cancel()
+
+	if param.RefreshViews > 0 {
+		pg, ok := dbCon.(*db.DB)
+		if !ok {
+			return xerrors.New("--refresh-views is only available for the CMDB on Postgres")
+		}
+		go refreshViews(ctx, pg, param.RefreshViews)
+	}
+	if param.Maintain > 0 {
+		pg, ok := dbCon.(*db.DB)
+		if !ok {
+			return xerrors.New("--maintain is only available for the CMDB on Postgres")
+		}
+		go maintainCMDB(ctx, pg, param.Maintain)
+	}
+	serve.MetricsInterval = param.MetricsInterval
+	serve.StaleAfter = param.StaleAfter
+	if param.AllowOrigins != "" {
+		serve.AllowedOrigins = strings.Split(param.AllowOrigins, ",")
+	}
+	return serve.Run(ctx, param.Listen, dbCon)
+}
+
+// viewRefresher is the CMDB refreshing the materialized views.
+type viewRefresher interface {
+	RefreshViews(ctx context.Context) error
+}
+
+// refreshViews refreshes the views every interval until ctx is done. A
+// failed refresh is logged and retried at the next interval, leaving the
+// views to get stale, so that the queries fall back to the flows.
+func refreshViews(ctx context.Context, r viewRefresher, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		start := time.Now()
+		if err := r.RefreshViews(ctx); err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			logger.Warningf("could not refresh the materialized views: %+v", err)
+		} else {
+			logger.Infof("refreshed the materialized views in %s", time.Since(start))
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package command
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"golang.org/x/xerrors"
+)
+
+// countRefresher counts the refreshes, failing the first one.
+type countRefresher struct {
+	n       int32
+	refresh chan struct{}
+}
+
+func (r *countRefresher) RefreshViews(ctx context.Context) error {
+	n := atomic.AddInt32(&r.n, 1)
+	r.refresh <- struct{}{}
+	if n == 1 {
+		return xerrors.New("the views are locked")
+	}
+	return nil
+}
+
+func TestRefreshViews(t *testing.T) {
+	r := &countRefresher{refresh: make(chan struct{})}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		refreshViews(ctx, r, time.Millisecond)
+		close(done)
+	}()
+
+	// The failed refresh is retried at the next interval.
+	for i := 0; i < 3; i++ {
+		select {
+		case <-r.refresh:
+		case <-time.After(time.Second):
+			t.Fatalf("the views should be refreshed %d times", i+1)
+		}
+	}
+	cancel()
+	go func() {
+		for range r.refresh {
+		}
+	}()
+	select {
+	case <-done:
+		close(r.refresh)
+	case <-time.After(time.Second):
+		t.Fatal("refreshViews() should return when ctx is done")
+	}
+}
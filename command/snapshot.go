@@ -0,0 +1,120 @@
+package command
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"golang.org/x/xerrors"
+
+	"github.com/yuuki/shawk/agent"
+	"github.com/yuuki/shawk/db"
+)
+
+// SnapshotParam represents a snapshot create or restore command parameter.
+type SnapshotParam struct {
+	// File is the file of the snapshot, or '-' for stdout or stdin.
+	File string
+	// Force restores the snapshot into the CMDB which has flows.
+	Force bool
+}
+
+// openSnapshotCMDB opens the CMDB on Postgres, which the snapshots are
+// taken of by COPY.
+func openSnapshotCMDB() (*db.DB, error) {
+	store, err := openCMDB()
+	if err != nil {
+		return nil, xerrors.Errorf("CMDB initialize error: %w", err)
+	}
+	pg, ok := store.(*db.DB)
+	if !ok {
+		store.Shutdown()
+		return nil, xerrors.New("snapshot is only available for the CMDB on Postgres")
+	}
+	return pg, nil
+}
+
+// SnapshotCreate runs snapshot create subcommand, which writes the
+// processes, the nodes and the flows in the CMDB into the file. The file is
+// replaced only after the snapshot is completed.
+func SnapshotCreate(param *SnapshotParam) error {
+	if err := param.Validate(); err != nil {
+		return err
+	}
+	pg, err := openSnapshotCMDB()
+	if err != nil {
+		return err
+	}
+	defer pg.Shutdown()
+
+	ctx, cancel := agent.SignalContext()
+	defer cancel()
+
+	if param.File == "-" {
+		_, err := pg.CreateSnapshot(ctx, os.Stdout)
+		return err
+	}
+	f, err := ioutil.TempFile(filepath.Dir(param.File), "."+filepath.Base(param.File))
+	if err != nil {
+		return xerrors.Errorf("could not create the snapshot: %w", err)
+	}
+	defer os.Remove(f.Name())
+	m, err := pg.CreateSnapshot(ctx, f)
+	if cerr := f.Close(); err == nil && cerr != nil {
+		err = xerrors.Errorf("could not write the snapshot: %w", cerr)
+	}
+	if err != nil {
+		return err
+	}
+	if err := os.Rename(f.Name(), param.File); err != nil {
+		return xerrors.Errorf("could not create the snapshot: %w", err)
+	}
+	logger.Infof("Created the snapshot %s of %d processes and %d flows", param.File, m.Rows["processes"], m.Rows["flows"])
+	return nil
+}
+
+// SnapshotRestore runs snapshot restore subcommand, which replaces the
+// processes, the nodes, the flows, the aliases of the nodes and the
+// annotations of the edges in the CMDB with the snapshot of the file.
+func SnapshotRestore(param *SnapshotParam) error {
+	if err := param.Validate(); err != nil {
+		return err
+	}
+	var r io.Reader = os.Stdin
+	if param.File != "-" {
+		f, err := os.Open(param.File)
+		if err != nil {
+			return xerrors.Errorf("could not open the snapshot: %w", err)
+		}
+		defer f.Close()
+		r = f
+	}
+
+	pg, err := openSnapshotCMDB()
+	if err != nil {
+		return err
+	}
+	defer pg.Shutdown()
+
+	ctx, cancel := agent.SignalContext()
+	defer cancel()
+
+	if !param.Force {
+		n, err := pg.CountFlows(ctx)
+		if err != nil {
+			return err
+		}
+		if n > 0 {
+			return xerrors.Errorf("the CMDB has %d flows, which the snapshot replaces: add --force to restore it", n)
+		}
+	}
+	m, err := pg.RestoreSnapshot(ctx, r)
+	if err != nil {
+		return xerrors.Errorf("could not restore the snapshot %s: %w", param.File, err)
+	}
+	logger.Infof("Restored the snapshot of %d processes and %d flows created at %s",
+		m.Rows["processes"], m.Rows["flows"], m.Created.Format(time.RFC3339))
+	return nil
+}
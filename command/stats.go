@@ -0,0 +1,131 @@
+package command
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"time"
+
+	"golang.org/x/xerrors"
+
+	"github.com/yuuki/shawk/agent"
+	"github.com/yuuki/shawk/db"
+)
+
+// StatsParam represents a stats command parameter.
+type StatsParam struct {
+	// Top is the number of the hosts of the most edges.
+	Top    int
+	Format string
+	Color  string
+}
+
+// tableSize is the rows and the storage of a table.
+type tableSize struct {
+	Name string `json:"name"`
+	Rows int64  `json:"rows"`
+	Size int64  `json:"size"` // bytes including the indexes and TOAST
+}
+
+// statsReport is the output of stats.
+type statsReport struct {
+	*db.GraphStats
+	Tables []*tableSize `json:"tables"`
+}
+
+// Stats runs stats subcommand, which reports the overview of the graph and
+// the storage of the tables of the CMDB.
+func Stats(param *StatsParam) error {
+	if err := param.Validate(); err != nil {
+		return err
+	}
+	store, err := openCMDB()
+	if err != nil {
+		return xerrors.Errorf("CMDB initialize error: %w", err)
+	}
+	defer store.Shutdown()
+	pg, ok := store.(*db.DB)
+	if !ok {
+		return xerrors.New("stats is only available for the CMDB on Postgres")
+	}
+
+	ctx, cancel := agent.SignalContext()
+	defer cancel()
+
+	graph, err := pg.FindGraphStats(ctx, param.Top)
+	if err != nil {
+		return err
+	}
+	tables, err := pg.FindTableStats(ctx)
+	if err != nil {
+		return err
+	}
+	report := &statsReport{GraphStats: graph, Tables: make([]*tableSize, 0, len(tables))}
+	for _, t := range tables {
+		report.Tables = append(report.Tables, &tableSize{Name: t.Name, Rows: t.LiveRows, Size: t.TotalSize})
+	}
+
+	if param.Format == FormatJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(report)
+	}
+	writeStatsReport(os.Stdout, report, useColor(param.Color, os.Stdout))
+	return nil
+}
+
+// writeStatsReport writes the counts and the growth of the graph, the age of
+// the flows, the top hosts and the storage of the tables.
+func writeStatsReport(w io.Writer, r *statsReport, color bool) {
+	growth := func(n int64) string {
+		return "+" + strconv.FormatInt(n, 10)
+	}
+	rows := []tableRow{
+		{cells: []cell{{"", styleNone}, {"TOTAL", styleDim}, {"LAST 24H", styleDim}, {"LAST 7D", styleDim}}},
+		{cells: []cell{
+			{"processes", styleNone}, {strconv.FormatInt(r.Processes, 10), styleNone},
+			{growth(r.ProcessesGrowth.Day), styleNone}, {growth(r.ProcessesGrowth.Week), styleNone},
+		}},
+		{cells: []cell{{"clients", styleNone}, {strconv.FormatInt(r.Clients, 10), styleNone}}},
+		{cells: []cell{{"servers", styleNone}, {strconv.FormatInt(r.Servers, 10), styleNone}}},
+		{cells: []cell{
+			{"flows", styleNone}, {strconv.FormatInt(r.Flows, 10), styleNone},
+			{growth(r.FlowsGrowth.Day), styleNone}, {growth(r.FlowsGrowth.Week), styleNone},
+		}},
+	}
+	writeTable(w, rows, color)
+	fmt.Fprintln(w)
+
+	rows = []tableRow{
+		{cells: []cell{{"oldest flow", styleDim}, {formatStatsTime(r.Oldest), styleNone}}},
+		{cells: []cell{{"newest flow", styleDim}, {formatStatsTime(r.Newest), styleNone}}},
+	}
+	writeTable(w, rows, color)
+	fmt.Fprintln(w)
+
+	rows = []tableRow{{cells: []cell{{"HOST", styleDim}, {"EDGES", styleDim}}}}
+	for _, h := range r.TopHosts {
+		rows = append(rows, tableRow{cells: []cell{
+			{h.Addr, styleNone}, {strconv.FormatInt(h.Edges, 10), styleNone},
+		}})
+	}
+	writeTable(w, rows, color)
+	fmt.Fprintln(w)
+
+	rows = []tableRow{{cells: []cell{{"TABLE", styleDim}, {"ROWS", styleDim}, {"SIZE", styleDim}}}}
+	for _, t := range r.Tables {
+		rows = append(rows, tableRow{cells: []cell{
+			{t.Name, styleNone}, {strconv.FormatInt(t.Rows, 10), styleNone}, {humanBytes(t.Size), styleNone},
+		}})
+	}
+	writeTable(w, rows, color)
+}
+
+func formatStatsTime(t *time.Time) string {
+	if t == nil {
+		return "-"
+	}
+	return t.UTC().Format(time.RFC3339)
+}
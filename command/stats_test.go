@@ -0,0 +1,77 @@
+package command
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/yuuki/shawk/db"
+)
+
+func TestWriteStatsReport(t *testing.T) {
+	oldest := time.Date(2020, 12, 1, 0, 0, 0, 0, time.UTC)
+	newest := time.Date(2020, 12, 20, 12, 0, 0, 0, time.UTC)
+	r := &statsReport{
+		GraphStats: &db.GraphStats{
+			Processes: 1500, Clients: 900, Servers: 700, Flows: 48000,
+			ProcessesGrowth: db.Growth{Day: 12, Week: 80},
+			FlowsGrowth:     db.Growth{Day: 120, Week: 900},
+			Oldest:          &oldest,
+			Newest:          &newest,
+			TopHosts: []*db.HostEdges{
+				{Addr: "10.0.0.1", Edges: 120},
+				{Addr: "10.0.0.2%blue", Edges: 80},
+			},
+		},
+		Tables: []*tableSize{
+			{Name: "flows", Rows: 48000, Size: 22 << 20},
+			{Name: "processes", Rows: 1500, Size: 512 << 10},
+		},
+	}
+	var b bytes.Buffer
+	writeStatsReport(&b, r, false)
+	want := `           TOTAL  LAST 24H  LAST 7D
+processes  1500   +12       +80
+clients    900
+servers    700
+flows      48000  +120      +900
+
+oldest flow  2020-12-01T00:00:00Z
+newest flow  2020-12-20T12:00:00Z
+
+HOST           EDGES
+10.0.0.1       120
+10.0.0.2%blue  80
+
+TABLE      ROWS   SIZE
+flows      48000  22.0 MiB
+processes  1500   512.0 KiB
+`
+	if diff := cmp.Diff(want, b.String()); diff != "" {
+		t.Errorf("writeStatsReport() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestWriteStatsReport_empty(t *testing.T) {
+	r := &statsReport{GraphStats: &db.GraphStats{}}
+	var b bytes.Buffer
+	writeStatsReport(&b, r, false)
+	want := `           TOTAL  LAST 24H  LAST 7D
+processes  0      +0        +0
+clients    0
+servers    0
+flows      0      +0        +0
+
+oldest flow  -
+newest flow  -
+
+HOST  EDGES
+
+TABLE  ROWS  SIZE
+`
+	if diff := cmp.Diff(want, b.String()); diff != "" {
+		t.Errorf("writeStatsReport() mismatch (-want +got):\n%s", diff)
+	}
+}
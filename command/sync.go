@@ -0,0 +1,155 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"time"
+
+	"golang.org/x/xerrors"
+
+	"github.com/yuuki/shawk/agent"
+	"github.com/yuuki/shawk/config"
+	"github.com/yuuki/shawk/db"
+)
+
+// syncOverlap is the overlap of a sync with the previous one, so that the
+// flows written by the transactions committed after the previous sync read
+// them are not missed.
+const syncOverlap = time.Minute
+
+// SyncParam represents a sync command parameter.
+type SyncParam struct {
+	// From and To are the URLs of the source and the destination CMDBs.
+	From string
+	To   string
+	// Full syncs all the flows of the source instead of the ones updated
+	// since the previous sync.
+	Full     bool
+	PageSize int
+}
+
+// syncSource returns the source of the URL in the marks of the destination,
+// which is the host and the database without the credentials, qualified by
+// the tenant unless syncing all the tenants.
+func syncSource(dbURL string) string {
+	source := dbURL
+	if u, err := url.Parse(dbURL); err == nil {
+		source = u.Host + u.Path
+	}
+	if !config.Config.CMDB.AllTenants {
+		source += "?tenant=" + config.Config.CMDB.Tenant
+	}
+	return source
+}
+
+// openSyncDB connects to the CMDB on Postgres of the URL with the settings
+// of the config.
+func openSyncDB(dbURL string) (*db.DB, error) {
+	store, err := db.Open(dbURL)
+	if err != nil {
+		return nil, err
+	}
+	pg, ok := store.(*db.DB)
+	if !ok {
+		store.Shutdown()
+		return nil, xerrors.New("sync is only available between the CMDBs on Postgres")
+	}
+	configureDB(pg)
+	return pg, nil
+}
+
+// Sync runs sync subcommand, which replicates the flows updated since the
+// previous sync with their nodes from a CMDB into another, such as from the
+// CMDBs of the regions into a global one.
+func Sync(param *SyncParam) error {
+	if err := param.Validate(); err != nil {
+		return err
+	}
+	src, err := openSyncDB(param.From)
+	if err != nil {
+		return xerrors.Errorf("could not open the source CMDB: %w", err)
+	}
+	defer src.Shutdown()
+	dst, err := openSyncDB(param.To)
+	if err != nil {
+		return xerrors.Errorf("could not open the destination CMDB: %w", err)
+	}
+	defer dst.Shutdown()
+	if err := dst.CheckSchemaVersion(); err != nil {
+		return xerrors.Errorf("destination: %w", err)
+	}
+
+	source := syncSource(param.From)
+	var since time.Time
+	if !param.Full {
+		mark, err := dst.FindSyncMark(source)
+		if err != nil {
+			return err
+		}
+		if !mark.IsZero() {
+			since = mark.Add(-syncOverlap)
+		}
+	}
+
+	ctx, cancel := agent.SignalContext()
+	defer cancel()
+
+	result, mark, err := syncFlows(ctx, src, dst, since, param.PageSize)
+	if err != nil {
+		return err
+	}
+	if !mark.IsZero() {
+		if err := dst.SetSyncMark(source, mark); err != nil {
+			return err
+		}
+	}
+	writeSyncResult(os.Stdout, source, result)
+	return nil
+}
+
+// syncFlows writes the flows of src updated since into dst by the pages of
+// pageSize flows, and returns the latest update of the flows synced.
+func syncFlows(ctx context.Context, src db.Store, dst db.Syncer, since time.Time, pageSize int) (*db.SyncResult, time.Time, error) {
+	var (
+		result = &db.SyncResult{}
+		mark   time.Time
+		page   = make([]*db.Flow, 0, pageSize)
+	)
+	flush := func() error {
+		if len(page) == 0 {
+			return nil
+		}
+		r, err := dst.SyncFlows(page)
+		if err != nil {
+			return err
+		}
+		result.Add(r)
+		page = page[:0]
+		return nil
+	}
+	err := db.EachFlow(ctx, src, &db.ListFlowsCond{Since: since, Limit: pageSize}, func(f *db.Flow) error {
+		page = append(page, f)
+		if f.Updated.After(mark) {
+			mark = f.Updated
+		}
+		if len(page) < pageSize {
+			return nil
+		}
+		return flush()
+	})
+	if err == nil {
+		err = flush()
+	}
+	if err != nil {
+		return nil, time.Time{}, xerrors.Errorf("sync flows error: %w", err)
+	}
+	return result, mark, nil
+}
+
+func writeSyncResult(w io.Writer, source string, r *db.SyncResult) {
+	fmt.Fprintf(w, "synced %d flows from %s: %d inserted, %d updated, %d skipped as updated later in the destination\n",
+		r.Inserted+r.Updated+r.Skipped, source, r.Inserted, r.Updated, r.Skipped)
+}
@@ -0,0 +1,64 @@
+package command
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+	"text/template"
+
+	"golang.org/x/xerrors"
+)
+
+// formatTemplatePrefix is the prefix of --format of a text/template, such as
+// 'template={{.Peer.Addr}}', which is executed for each flow.
+const formatTemplatePrefix = "template="
+
+// templateFuncs are the functions of the templates of --format in addition
+// to the builtins of text/template.
+var templateFuncs = template.FuncMap{
+	"json": func(v interface{}) (string, error) {
+		b, err := json.Marshal(v)
+		return string(b), err
+	},
+	"join": strings.Join,
+}
+
+func isTemplateFormat(format string) bool {
+	return strings.HasPrefix(format, formatTemplatePrefix)
+}
+
+// validateFlowFormat validates --format of the flows printed as the tables,
+// which is FormatText or a template. Empty is FormatText.
+func validateFlowFormat(format string) error {
+	switch {
+	case format == "", format == FormatText:
+		return nil
+	case isTemplateFormat(format):
+		_, err := parseFormatTemplate(format)
+		return err
+	}
+	return xerrors.Errorf("--format must be '%s' or 'template=TEMPLATE', but %q", FormatText, format)
+}
+
+// parseFormatTemplate parses the template of --format 'template=...'.
+func parseFormatTemplate(format string) (*template.Template, error) {
+	text := strings.TrimPrefix(format, formatTemplatePrefix)
+	if text == "" {
+		return nil, xerrors.New("--format template= requires a template such as 'template={{.Connections}}'")
+	}
+	t, err := template.New("format").Funcs(templateFuncs).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, xerrors.Errorf("--format has an invalid template: %w", err)
+	}
+	return t, nil
+}
+
+// writeTemplate writes the output of t for v followed by a newline, as a
+// line of the output of each flow.
+func writeTemplate(w io.Writer, t *template.Template, v interface{}) error {
+	if err := t.Execute(w, v); err != nil {
+		return xerrors.Errorf("could not execute the template of --format: %w", err)
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
@@ -0,0 +1,62 @@
+package command
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/yuuki/shawk/probe"
+)
+
+func TestValidateFlowFormat(t *testing.T) {
+	tests := []struct {
+		format  string
+		wantErr string
+	}{
+		{"", ""},
+		{FormatText, ""},
+		{"template={{.Peer.Addr}}", ""},
+		{"template=", "requires a template"},
+		{"template={{.Peer.Addr", "invalid template"},
+		{FormatJSON, "--format must be 'text' or 'template=TEMPLATE'"},
+	}
+	for _, tt := range tests {
+		err := validateFlowFormat(tt.format)
+		switch {
+		case tt.wantErr == "" && err != nil:
+			t.Errorf("validateFlowFormat(%q) should not return an error: %v", tt.format, err)
+		case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+			t.Errorf("validateFlowFormat(%q) should return an error containing %q, but %v", tt.format, tt.wantErr, err)
+		}
+	}
+}
+
+func TestWriteTemplate(t *testing.T) {
+	f := &probe.HostFlow{
+		Direction:   probe.FlowActive,
+		Local:       &probe.AddrPort{Addr: "10.0.0.1", Aggregated: true},
+		Peer:        &probe.AddrPort{Addr: "10.0.0.2", Port: 5432},
+		Connections: 3,
+		Process:     &probe.Process{Name: "app", Pgid: 100},
+	}
+	tmpl, err := parseFormatTemplate(`template={{.Direction}} {{.Peer.Addr}}:{{.Peer.Port}} {{.Process.Name}} {{.Connections}} {{json .Peer}}`)
+	if err != nil {
+		t.Fatalf("parseFormatTemplate() should not return an error: %v", err)
+	}
+	var b bytes.Buffer
+	if err := writeTemplate(&b, tmpl, f); err != nil {
+		t.Fatalf("writeTemplate() should not return an error: %v", err)
+	}
+	want := `active 10.0.0.2:5432 app 3 {"name":"","addr":"10.0.0.2","port":5432,"aggregated":false}` + "\n"
+	if got := b.String(); got != want {
+		t.Errorf("writeTemplate() = %q, want %q", got, want)
+	}
+
+	tmpl, err = parseFormatTemplate(`template={{.Flows}}`)
+	if err != nil {
+		t.Fatalf("parseFormatTemplate() should not return an error: %v", err)
+	}
+	if err := writeTemplate(&b, tmpl, f); err == nil {
+		t.Error("writeTemplate() should return an error of the missing field")
+	}
+}
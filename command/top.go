@@ -0,0 +1,43 @@
+package command
+
+import (
+	"os"
+	"time"
+
+	"github.com/yuuki/shawk/agent"
+	"github.com/yuuki/shawk/probe"
+	"github.com/yuuki/shawk/probe/netlink"
+	"github.com/yuuki/shawk/top"
+)
+
+// TopParam represents a top command parameter.
+type TopParam struct {
+	Interval time.Duration
+	Numeric  bool
+	Filter   string
+}
+
+// Top runs top subcommand, which shows the flows of this host on the terminal.
+func Top(param *TopParam) error {
+	if err := param.Validate(); err != nil {
+		return err
+	}
+	if !isPrivileged() {
+		logger.Warningf("shawk is not running as root: the processes of the other users are not resolved")
+	}
+
+	ctx, cancel := agent.SignalContext()
+	defer cancel()
+
+	opt := &netlink.GetHostFlowsOption{
+		Numeric:   param.Numeric,
+		Processes: true,
+		Filter:    param.Filter,
+	}
+	return top.Run(ctx, os.Stdin, os.Stdout, &top.Option{
+		Interval: param.Interval,
+		Probe: func() (probe.HostFlows, error) {
+			return netlink.GetHostFlows(opt)
+		},
+	})
+}
@@ -0,0 +1,151 @@
+package command
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/xerrors"
+
+	"github.com/yuuki/shawk/agent"
+	"github.com/yuuki/shawk/alert"
+	"github.com/yuuki/shawk/db"
+	"github.com/yuuki/shawk/serve"
+	"github.com/yuuki/shawk/siem"
+)
+
+// The formats of watch.
+const (
+	// FormatText prints a change per line for humans.
+	FormatText = "text"
+	// FormatJSON prints a change per line as JSON.
+	FormatJSON = "json"
+	// FormatOpenMetrics prints the flows of probe --once as the gauges of
+	// the OpenMetrics text format.
+	FormatOpenMetrics = "openmetrics"
+)
+
+// relistenInterval is the wait before listening again after the connection
+// to the CMDB is lost.
+var relistenInterval = 5 * time.Second
+
+// WatchParam represents a watch command parameter.
+type WatchParam struct {
+	// Types is the comma-separated types of the changes to print. Empty
+	// prints all.
+	Types  string
+	Format string
+	// Alertmanager is the comma-separated URLs of Alertmanager to post the
+	// changes to as the alerts. Empty doesn't post them.
+	Alertmanager string
+	// AlertResolve is how long an alert lasts until Alertmanager resolves
+	// it.
+	AlertResolve time.Duration
+	// Syslog is the URL of the syslog collector to send the changes to.
+	// Empty doesn't send them.
+	Syslog       string
+	SyslogFormat string
+}
+
+// Watch runs watch subcommand, which prints the changes of the flows
+// written into the CMDB until interrupted.
+func Watch(param *WatchParam) error {
+	if err := param.Validate(); err != nil {
+		return err
+	}
+
+	dbCon, err := openCMDB()
+	if err != nil {
+		return xerrors.Errorf("CMDB initialize error: %w", err)
+	}
+	defer dbCon.Shutdown()
+	l, ok := dbCon.(serve.Listener)
+	if !ok {
+		return xerrors.New("the CMDB doesn't notify the changes")
+	}
+
+	types := map[string]bool{}
+	for _, t := range splitTypes(param.Types) {
+		types[t] = true
+	}
+	var sender *alert.Sender
+	if param.Alertmanager != "" {
+		sender = alert.NewSender(&alert.Option{
+			URLs:         strings.Split(param.Alertmanager, ","),
+			ResolveAfter: param.AlertResolve,
+		})
+	}
+	var collector *siem.Writer
+	if param.Syslog != "" {
+		if collector, err = siem.NewWriter(param.Syslog, param.SyslogFormat); err != nil {
+			return err
+		}
+		defer collector.Close()
+	}
+	write := func(c *db.Change) error {
+		if len(types) > 0 && !types[c.Type] {
+			return nil
+		}
+		now := time.Now()
+		if sender != nil {
+			// A lost alert doesn't stop watching the changes.
+			if err := sender.Send(c, now); err != nil {
+				logger.Errorf("could not post the alert of the change of the flow %d: %v", c.ID, err)
+			}
+		}
+		if collector != nil {
+			if err := collector.Write(siem.ChangeEvent(c, now)); err != nil {
+				logger.Errorf("could not send the change of the flow %d to the syslog collector: %v", c.ID, err)
+			}
+		}
+		return writeChange(os.Stdout, c, param.Format, now)
+	}
+
+	ctx, cancel := agent.SignalContext()
+	defer cancel()
+	for {
+		err := l.Listen(ctx, write)
+		if ctx.Err() != nil {
+			return nil
+		}
+		logger.Warningf("stopped listening to the changes, retrying in %s: %v", relistenInterval, err)
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(relistenInterval):
+		}
+	}
+}
+
+func splitTypes(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, ",")
+}
+
+// formatChangeNode formats the node like db.Node.String.
+func formatChangeNode(n *db.ChangeNode) string {
+	if n == nil {
+		return "(deleted)"
+	}
+	port := "many"
+	if n.Port != 0 {
+		port = strconv.Itoa(int(n.Port))
+	}
+	return fmt.Sprintf("%s:%s ('%s')", n.Addr, port, n.Process)
+}
+
+// writeChange writes the change in the format as a line.
+func writeChange(w io.Writer, c *db.Change, format string, now time.Time) error {
+	if format == FormatJSON {
+		return json.NewEncoder(w).Encode(c)
+	}
+	_, err := fmt.Fprintf(w, "%s %-9s %s --> %s %d\n", now.Format("15:04:05"), c.Type,
+		formatChangeNode(c.Client), formatChangeNode(c.Server), c.Connections)
+	return err
+}
@@ -0,0 +1,48 @@
+package command
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/yuuki/shawk/db"
+)
+
+func TestWriteChange(t *testing.T) {
+	now := time.Date(2020, 12, 20, 12, 0, 0, 0, time.UTC)
+	added := &db.Change{
+		Type: db.ChangeAdded, ID: 1, Connections: 3,
+		Client: &db.ChangeNode{Addr: "10.0.0.1", Process: "app"},
+		Server: &db.ChangeNode{Addr: "10.0.0.2", Port: 80, Process: "nginx"},
+	}
+	tests := []struct {
+		desc   string
+		change *db.Change
+		format string
+		want   string
+	}{
+		{
+			desc: "text", change: added, format: FormatText,
+			want: "12:00:00 added     10.0.0.1:many ('app') --> 10.0.0.2:80 ('nginx') 3\n",
+		},
+		{
+			desc: "deleted nodes", change: &db.Change{Type: db.ChangeRemoved, ID: 2, Connections: 1}, format: FormatText,
+			want: "12:00:00 removed   (deleted) --> (deleted) 1\n",
+		},
+		{
+			desc: "json", change: added, format: FormatJSON,
+			want: `{"type":"added","id":1,"connections":3,"client":{"addr":"10.0.0.1","process":"app"},"server":{"addr":"10.0.0.2","port":80,"process":"nginx"}}` + "\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			var b bytes.Buffer
+			if err := writeChange(&b, tt.change, tt.format, now); err != nil {
+				t.Fatal(err)
+			}
+			if got := b.String(); got != tt.want {
+				t.Errorf("writeChange() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
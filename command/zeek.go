@@ -0,0 +1,136 @@
+package command
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"golang.org/x/xerrors"
+
+	"github.com/yuuki/shawk/agent"
+	"github.com/yuuki/shawk/db"
+	"github.com/yuuki/shawk/zeek"
+)
+
+// tailPollInterval is the interval of polling conn.log for the lines
+// appended to it.
+var tailPollInterval = time.Second
+
+// ZeekParam represents a zeek command parameter.
+type ZeekParam struct {
+	// File is conn.log, or its archive ending with .gz.
+	File string
+	// Follow keeps reading the lines appended to the file, and writes the
+	// flows every Interval.
+	Follow   bool
+	Interval time.Duration
+}
+
+// Zeek runs zeek subcommand, which writes the flows of the TCP connections
+// of conn.log of Zeek into the CMDB.
+func Zeek(param *ZeekParam) error {
+	if err := param.Validate(); err != nil {
+		return err
+	}
+
+	dbCon, err := openCMDB()
+	if err != nil {
+		return xerrors.Errorf("CMDB initialize error: %w", err)
+	}
+	defer dbCon.Shutdown()
+
+	if !param.Follow {
+		return loadZeekConns(dbCon, param.File)
+	}
+	ctx, cancel := agent.SignalContext()
+	defer cancel()
+	return tailZeekConns(ctx, dbCon, param.File, param.Interval)
+}
+
+// loadZeekConns writes the flows of the connections of the file at once.
+func loadZeekConns(store db.Store, path string) error {
+	p := zeek.NewParser()
+	agg := zeek.NewAggregator()
+	var read, added int
+	err := zeek.ReadFile(path, func(line string) error {
+		c, err := p.Parse(line)
+		if err != nil {
+			return xerrors.Errorf("could not parse %s: %w", path, err)
+		}
+		if c == nil {
+			return nil
+		}
+		read++
+		if agg.Add(c) {
+			added++
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	flows := agg.Flush()
+	logger.Infof("aggregated %d established or midstream connections of %d TCP connections into %d flows", added, read, len(flows))
+	if len(flows) == 0 {
+		return nil
+	}
+	if err := store.InsertOrUpdateHostFlows(flows); err != nil {
+		return xerrors.Errorf("could not write the flows: %w", err)
+	}
+	return nil
+}
+
+// tailZeekConns writes the flows of the connections appended to the file
+// every interval until ctx is done. The invalid lines are skipped, since
+// Zeek may be writing the lines being read.
+func tailZeekConns(ctx context.Context, store db.Store, path string, interval time.Duration) error {
+	p := zeek.NewParser()
+	agg := zeek.NewAggregator()
+	var mu sync.Mutex
+
+	flush := func() {
+		mu.Lock()
+		flows := agg.Flush()
+		mu.Unlock()
+		if len(flows) == 0 {
+			return
+		}
+		if err := store.InsertOrUpdateHostFlows(flows); err != nil {
+			logger.Errorf("could not write the flows: %+v", err)
+			return
+		}
+		logger.Infof("wrote %d flows of %s", len(flows), path)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				flush()
+			}
+		}
+	}()
+
+	err := zeek.Tail(ctx, path, tailPollInterval, func(line string) error {
+		c, err := p.Parse(line)
+		if err != nil {
+			logger.Warningf("skipped a line of %s: %v", path, err)
+			return nil
+		}
+		if c != nil {
+			mu.Lock()
+			agg.Add(c)
+			mu.Unlock()
+		}
+		return nil
+	})
+	close(done)
+	// The connections read since the last interval.
+	flush()
+	return err
+}
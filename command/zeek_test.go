@@ -0,0 +1,80 @@
+package command
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/yuuki/shawk/db"
+	"github.com/yuuki/shawk/probe"
+)
+
+// writtenStore records the flows written into it.
+type writtenStore struct {
+	db.Store
+	mu      sync.Mutex
+	written [][]*probe.HostFlow
+}
+
+func (s *writtenStore) InsertOrUpdateHostFlows(flows []*probe.HostFlow) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.written = append(s.written, flows)
+	return nil
+}
+
+func TestLoadZeekConns(t *testing.T) {
+	s := &writtenStore{}
+	if err := loadZeekConns(s, "../zeek/testdata/conn.log"); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if len(s.written) != 1 {
+		t.Fatalf("loadZeekConns() should write the flows at once, but %d times", len(s.written))
+	}
+	var got []string
+	for _, f := range s.written[0] {
+		got = append(got, f.String())
+	}
+	want := []string{
+		"10.0.0.1:many\t-->\t10.0.0.2:80\t2",
+		"10.0.0.1:many\t-->\t10.0.0.3:5432\t1",
+	}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("loadZeekConns() should write %q, but %q", want, got)
+	}
+}
+
+func TestTailZeekConns(t *testing.T) {
+	dir, err := ioutil.TempDir("", "zeek")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "conn.log")
+	data, err := ioutil.ReadFile("../zeek/testdata/conn.log")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(path, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	orig := tailPollInterval
+	defer func() { tailPollInterval = orig }()
+	tailPollInterval = 10 * time.Millisecond
+
+	s := &writtenStore{}
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	if err := tailZeekConns(ctx, s, path, time.Hour); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	// The flows read since the last interval are written when ctx is done.
+	if len(s.written) != 1 || len(s.written[0]) != 2 {
+		t.Errorf("tailZeekConns() should write 2 flows at the end, but %v", s.written)
+	}
+}
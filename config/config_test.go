@@ -2,6 +2,7 @@ package config
 
 import (
 	"os"
+	"strings"
 	"testing"
 	"time"
 )
@@ -45,3 +46,196 @@ func TestLoad(t *testing.T) {
 		t.Errorf("Config.ProbeFlushInterval should be not '%v', but true", v)
 	}
 }
+
+func TestParse_auth(t *testing.T) {
+	defer os.Unsetenv("SHAWK_CMDB_AUTH")
+
+	os.Setenv("SHAWK_CMDB_AUTH", "rds-iam")
+	s, err := Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.CMDB.Auth != AuthRDSIAM {
+		t.Errorf("CMDB.Auth should be %q, but %q", AuthRDSIAM, s.CMDB.Auth)
+	}
+
+	os.Setenv("SHAWK_CMDB_AUTH", "kerberos")
+	if _, err := Parse(); err == nil {
+		t.Error("Parse() should return an error for an unknown auth")
+	}
+}
+
+func TestParse_serviceEnv(t *testing.T) {
+	defer os.Unsetenv("SHAWK_PROBE_SERVICE_ENV")
+
+	os.Setenv("SHAWK_PROBE_SERVICE_ENV", "SHAWK_SERVICE")
+	s, err := Parse()
+	if err != nil {
+		t.Fatalf("Parse() should accept the name: %v", err)
+	}
+	if s.ProbeServiceEnv != "SHAWK_SERVICE" {
+		t.Errorf("ProbeServiceEnv should be SHAWK_SERVICE, but %q", s.ProbeServiceEnv)
+	}
+	for _, name := range []string{"1SERVICE", "SERVICE=web", "service name"} {
+		os.Setenv("SHAWK_PROBE_SERVICE_ENV", name)
+		if _, err := Parse(); err == nil {
+			t.Errorf("Parse() should return an error for the name %q", name)
+		}
+	}
+}
+
+func TestParse_stateTable(t *testing.T) {
+	defer os.Unsetenv("SHAWK_PROBE_STATE_TABLE")
+	defer os.Unsetenv("SHAWK_PROBE_STATE_TABLE_SIZE")
+	defer os.Unsetenv("SHAWK_PROBE_MODE")
+
+	os.Setenv("SHAWK_PROBE_MODE", "polling")
+	os.Setenv("SHAWK_PROBE_STATE_TABLE", "1")
+	s, err := Parse()
+	if err != nil {
+		t.Fatalf("Parse() should accept the state table: %v", err)
+	}
+	if !s.ProbeStateTable || s.ProbeStateTableSize != 65536 {
+		t.Errorf("the state table should be enabled with 65536 sockets, but %v and %d", s.ProbeStateTable, s.ProbeStateTableSize)
+	}
+
+	os.Setenv("SHAWK_PROBE_STATE_TABLE_SIZE", "0")
+	if _, err := Parse(); err == nil {
+		t.Error("Parse() should return an error for the size 0")
+	}
+	os.Setenv("SHAWK_PROBE_STATE_TABLE_SIZE", "1024")
+	os.Setenv("SHAWK_PROBE_MODE", "streaming")
+	if _, err := Parse(); err == nil {
+		t.Error("Parse() should return an error for the streaming mode")
+	}
+}
+
+func TestParse_helperSocket(t *testing.T) {
+	defer os.Unsetenv("SHAWK_PROBE_HELPER_SOCKET")
+	defer os.Unsetenv("SHAWK_PROBE_STATE_TABLE")
+	defer os.Unsetenv("SHAWK_PROBE_SERVICE_ENV")
+	defer os.Unsetenv("SHAWK_PROBE_CONNTRACK")
+	defer os.Unsetenv("SHAWK_PROBE_MODE")
+
+	os.Setenv("SHAWK_PROBE_MODE", "polling")
+	os.Setenv("SHAWK_PROBE_HELPER_SOCKET", "/run/shawk/helper.sock")
+	s, err := Parse()
+	if err != nil {
+		t.Fatalf("Parse() should accept the helper: %v", err)
+	}
+	if s.ProbeHelperSocket != "/run/shawk/helper.sock" {
+		t.Errorf("ProbeHelperSocket should be /run/shawk/helper.sock, but %q", s.ProbeHelperSocket)
+	}
+
+	os.Setenv("SHAWK_PROBE_STATE_TABLE", "1")
+	if _, err := Parse(); err == nil {
+		t.Error("Parse() should return an error for the state table")
+	}
+	os.Setenv("SHAWK_PROBE_STATE_TABLE", "0")
+	os.Setenv("SHAWK_PROBE_SERVICE_ENV", "SHAWK_SERVICE")
+	if _, err := Parse(); err == nil || !strings.Contains(err.Error(), "SHAWK_PROBE_SERVICE_ENV") {
+		t.Errorf("Parse() should return an error for the environments of the processes, but %v", err)
+	}
+	os.Unsetenv("SHAWK_PROBE_SERVICE_ENV")
+	os.Setenv("SHAWK_PROBE_CONNTRACK", "1")
+	if _, err := Parse(); err == nil || !strings.Contains(err.Error(), "SHAWK_PROBE_CONNTRACK") {
+		t.Errorf("Parse() should return an error for conntrack, but %v", err)
+	}
+	os.Unsetenv("SHAWK_PROBE_CONNTRACK")
+	os.Setenv("SHAWK_PROBE_MODE", "streaming")
+	if _, err := Parse(); err == nil {
+		t.Error("Parse() should return an error for the streaming mode")
+	}
+}
+
+func TestParse_dnsRetention(t *testing.T) {
+	defer os.Unsetenv("SHAWK_PROBE_DNS_RETENTION")
+
+	s, err := Parse()
+	if err != nil {
+		t.Fatalf("Parse() should accept the default retention: %v", err)
+	}
+	if s.ProbeDNSRetention != time.Hour {
+		t.Errorf("ProbeDNSRetention should be 1h, but %s", s.ProbeDNSRetention)
+	}
+	os.Setenv("SHAWK_PROBE_DNS_RETENTION", "-1m")
+	if _, err := Parse(); err == nil {
+		t.Error("Parse() should return an error for the negative retention")
+	}
+}
+
+func TestParse_tenant(t *testing.T) {
+	defer os.Unsetenv("SHAWK_CMDB_TENANT")
+
+	for _, tenant := range []string{"team-a", "prod.eu_1", "a"} {
+		os.Setenv("SHAWK_CMDB_TENANT", tenant)
+		s, err := Parse()
+		if err != nil {
+			t.Fatalf("Parse() should accept %q: %v", tenant, err)
+		}
+		if s.CMDB.Tenant != tenant {
+			t.Errorf("CMDB.Tenant should be %q, but %q", tenant, s.CMDB.Tenant)
+		}
+	}
+	for _, tenant := range []string{"Team-A", "-team", "team a", strings.Repeat("a", 64)} {
+		os.Setenv("SHAWK_CMDB_TENANT", tenant)
+		if _, err := Parse(); err == nil {
+			t.Errorf("Parse() should return an error for the tenant %q", tenant)
+		}
+	}
+}
+
+func TestParse_ecs(t *testing.T) {
+	defer os.Unsetenv("SHAWK_ECS_ENABLED")
+	defer os.Unsetenv("SHAWK_ECS_PEERS")
+	defer os.Unsetenv("SHAWK_ECS_CLUSTERS")
+
+	os.Setenv("SHAWK_ECS_ENABLED", "true")
+	os.Setenv("SHAWK_ECS_PEERS", "true")
+	os.Setenv("SHAWK_ECS_CLUSTERS", "prod,batch")
+	s, err := Parse()
+	if err != nil {
+		t.Fatalf("Parse() should accept the peers of ECS: %v", err)
+	}
+	if len(s.ECS.Clusters) != 2 || s.ECS.RefreshInterval != 5*time.Minute {
+		t.Errorf("ECS should have 2 clusters and the refresh interval 5m, but %+v", s.ECS)
+	}
+
+	for _, env := range []struct{ name, value string }{
+		{"SHAWK_ECS_ENABLED", "false"},
+		{"SHAWK_ECS_PEERS", "false"},
+	} {
+		os.Setenv(env.name, env.value)
+		if _, err := Parse(); err == nil {
+			t.Errorf("Parse() should return an error for %s=%s", env.name, env.value)
+		}
+	}
+}
+
+func TestParse_statsd(t *testing.T) {
+	defer os.Unsetenv("SHAWK_STATSD_ADDR")
+	defer os.Unsetenv("SHAWK_STATSD_DOGSTATSD")
+	defer os.Unsetenv("SHAWK_STATSD_TAGS")
+
+	os.Setenv("SHAWK_STATSD_ADDR", "127.0.0.1:8125")
+	os.Setenv("SHAWK_STATSD_DOGSTATSD", "true")
+	os.Setenv("SHAWK_STATSD_TAGS", "env:prod,team:infra")
+	s, err := Parse()
+	if err != nil {
+		t.Fatalf("Parse() should accept DogStatsD: %v", err)
+	}
+	if !s.Statsd.DogStatsD || len(s.Statsd.Tags) != 2 || s.Statsd.Prefix != "shawk." {
+		t.Errorf("Statsd should be DogStatsD with 2 tags and the prefix 'shawk.', but %+v", s.Statsd)
+	}
+
+	for _, env := range []struct{ name, value string }{
+		{"SHAWK_STATSD_TAGS", "prod"},
+		{"SHAWK_STATSD_DOGSTATSD", "false"},
+		{"SHAWK_STATSD_ADDR", "127.0.0.1"},
+	} {
+		os.Setenv(env.name, env.value)
+		if _, err := Parse(); err == nil {
+			t.Errorf("Parse() should return an error for %s=%s", env.name, env.value)
+		}
+	}
+}
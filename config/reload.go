@@ -0,0 +1,189 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"reflect"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/joho/godotenv"
+	"golang.org/x/xerrors"
+
+	"github.com/yuuki/shawk/logging"
+)
+
+var logger = logging.New("config")
+
+// envFile is the env file of SHAWK_ENV_FILE. Its variables don't override
+// the ones set in the environment of the process, as godotenv.Load.
+type envFile struct {
+	path string
+
+	mu sync.Mutex
+	// keys are the variables set from the file.
+	keys    map[string]bool
+	modTime time.Time
+	size    int64
+}
+
+var envFileLoaded *envFile
+
+// LoadEnvFile sets the variables of the env file at path into the
+// environment, and remembers the file to reload it by Watch.
+func LoadEnvFile(path string) error {
+	f := &envFile{path: path, keys: map[string]bool{}}
+	if _, err := f.reload(); err != nil {
+		return err
+	}
+	envFileLoaded = f
+	return nil
+}
+
+// reload sets the variables of the file into the environment again if the
+// file has been modified since the last load, unsetting the ones removed
+// from the file. It returns whether the file has been modified.
+func (f *envFile) reload() (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	info, err := os.Stat(f.path)
+	if err != nil {
+		return false, xerrors.Errorf("could not stat env file: %w", err)
+	}
+	if info.ModTime().Equal(f.modTime) && info.Size() == f.size {
+		return false, nil
+	}
+	env, err := godotenv.Read(f.path)
+	if err != nil {
+		return false, xerrors.Errorf("could not read env file %s: %w", f.path, err)
+	}
+	for k := range f.keys {
+		if _, ok := env[k]; !ok {
+			os.Unsetenv(k)
+			delete(f.keys, k)
+		}
+	}
+	for k, v := range env {
+		if _, ok := os.LookupEnv(k); ok && !f.keys[k] {
+			continue // set outside the file
+		}
+		os.Setenv(k, v)
+		f.keys[k] = true
+	}
+	f.modTime, f.size = info.ModTime(), info.Size()
+	return true, nil
+}
+
+// Watch reloads the env file loaded by LoadEnvFile when it is modified or
+// the process receives SIGHUP, and the settings in the CMDB loaded by
+// LoadRemote when they are changed, and calls fn with the settings before
+// and after the reload if they have changed. They are checked every
+// interval, and only on SIGHUP if the interval is zero. It returns false
+// without watching if neither of them has been loaded, or when ctx is
+// canceled.
+func Watch(ctx context.Context, interval time.Duration, fn func(prev, cur *Settings)) bool {
+	f, r := envFileLoaded, remoteEnvLoaded
+	if f == nil && r == nil {
+		return false
+	}
+	loaded := *Config
+	prev := &loaded
+
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+	var tick <-chan time.Time
+	if interval > 0 {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return true
+		case <-hup:
+			if f != nil {
+				f.mu.Lock()
+				f.modTime = time.Time{} // reload even if unmodified
+				f.mu.Unlock()
+			}
+		case <-tick:
+		}
+		var modified bool
+		if f != nil {
+			m, err := f.reload()
+			if err != nil {
+				logger.Errorf("Could not reload %s: %v", f.path, err)
+			}
+			if m && r != nil {
+				r.reapply() // the CMDB overrides the file
+			}
+			modified = m
+		}
+		if r != nil {
+			m, err := r.reload()
+			if err != nil {
+				logger.Errorf("Could not reload the settings: %v", err)
+			}
+			modified = modified || m
+		}
+		if !modified {
+			continue
+		}
+		cur, err := Parse()
+		if err != nil {
+			logger.Errorf("Could not reload the settings, keeping the current ones: %v", err)
+			continue
+		}
+		if len(Diff(prev, cur)) > 0 {
+			fn(prev, cur)
+		}
+		prev = cur
+	}
+}
+
+// Change is a setting changed by reloading.
+type Change struct {
+	// Field is the path of the field such as "CMDB.RetryMax".
+	Field string
+	Old   string
+	New   string
+}
+
+func (c *Change) String() string {
+	return fmt.Sprintf("%s: %s -> %s", c.Field, c.Old, c.New)
+}
+
+// Diff returns the changes of the settings from a to b in the order of the
+// fields. The values of the secret fields are masked.
+func Diff(a, b *Settings) []*Change {
+	return diffStruct("", reflect.ValueOf(a).Elem(), reflect.ValueOf(b).Elem())
+}
+
+func diffStruct(prefix string, a, b reflect.Value) []*Change {
+	var changes []*Change
+	for i := 0; i < a.NumField(); i++ {
+		field := a.Type().Field(i)
+		name := prefix + field.Name
+		av, bv := a.Field(i), b.Field(i)
+		if field.Type.Kind() == reflect.Struct && field.Type != reflect.TypeOf(time.Time{}) {
+			changes = append(changes, diffStruct(name+".", av, bv)...)
+			continue
+		}
+		if reflect.DeepEqual(av.Interface(), bv.Interface()) {
+			continue
+		}
+		c := &Change{Field: name, Old: fmt.Sprint(av.Interface()), New: fmt.Sprint(bv.Interface())}
+		if field.Tag.Get("secret") == "true" {
+			c.Old, c.New = "(secret)", "(secret)"
+		}
+		changes = append(changes, c)
+	}
+	return changes
+}
@@ -0,0 +1,130 @@
+package config
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDiff(t *testing.T) {
+	a, b := &Settings{}, &Settings{}
+	a.CMDB.URL, b.CMDB.URL = "postgres://shawk:old@db/shawk", "postgres://shawk:new@db/shawk"
+	a.CMDB.RetryMax, b.CMDB.RetryMax = 3, 5
+	a.LogLevel, b.LogLevel = "info", "debug"
+	a.ProbeMode, b.ProbeMode = "polling", "polling"
+
+	var got []string
+	for _, c := range Diff(a, b) {
+		got = append(got, c.String())
+	}
+	want := []string{
+		"CMDB.URL: (secret) -> (secret)",
+		"CMDB.RetryMax: 3 -> 5",
+		"LogLevel: info -> debug",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Diff() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func writeEnvFile(t *testing.T, path, content string, modTime time.Time) {
+	t.Helper()
+	if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(path, modTime, modTime); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestEnvFile_reload(t *testing.T) {
+	dir, err := ioutil.TempDir("", "shawk-config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "shawk.env")
+
+	os.Setenv("SHAWK_TEST_OUTER", "outer")
+	defer os.Unsetenv("SHAWK_TEST_OUTER")
+	defer os.Unsetenv("SHAWK_TEST_A")
+	defer os.Unsetenv("SHAWK_TEST_B")
+
+	now := time.Now()
+	writeEnvFile(t, path, "SHAWK_TEST_A=1\nSHAWK_TEST_B=2\nSHAWK_TEST_OUTER=file\n", now)
+	f := &envFile{path: path, keys: map[string]bool{}}
+	if _, err := f.reload(); err != nil {
+		t.Fatal(err)
+	}
+	if modified, _ := f.reload(); modified {
+		t.Error("the unmodified file should not be reloaded")
+	}
+
+	writeEnvFile(t, path, "SHAWK_TEST_A=10\nSHAWK_TEST_OUTER=file\n", now.Add(time.Second))
+	if modified, err := f.reload(); err != nil || !modified {
+		t.Fatalf("the modified file should be reloaded: %v", err)
+	}
+
+	got := map[string]string{}
+	for _, k := range []string{"SHAWK_TEST_A", "SHAWK_TEST_B", "SHAWK_TEST_OUTER"} {
+		v, ok := os.LookupEnv(k)
+		if !ok {
+			v = "(unset)"
+		}
+		got[k] = v
+	}
+	want := map[string]string{
+		"SHAWK_TEST_A":     "10",
+		"SHAWK_TEST_B":     "(unset)",
+		"SHAWK_TEST_OUTER": "outer",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("environment mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestWatch(t *testing.T) {
+	if Watch(context.Background(), time.Millisecond, nil) {
+		t.Error("Watch() should return false without the env file")
+	}
+
+	dir, err := ioutil.TempDir("", "shawk-config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "shawk.env")
+	defer os.Unsetenv("SHAWK_LOG_LEVEL")
+	defer func() { envFileLoaded = nil }()
+
+	now := time.Now()
+	writeEnvFile(t, path, "SHAWK_LOG_LEVEL=info\n", now)
+	if err := LoadEnvFile(path); err != nil {
+		t.Fatal(err)
+	}
+	if err := Load(); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	changed := make(chan []*Change, 1)
+	go Watch(ctx, time.Millisecond, func(prev, cur *Settings) {
+		changed <- Diff(prev, cur)
+	})
+
+	writeEnvFile(t, path, "SHAWK_LOG_LEVEL=debug\n", now.Add(time.Second))
+	select {
+	case changes := <-changed:
+		if diff := cmp.Diff([]*Change{{Field: "LogLevel", Old: "info", New: "debug"}}, changes); diff != "" {
+			t.Errorf("changes mismatch (-want +got):\n%s", diff)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("the modified env file should be reloaded")
+	}
+}
@@ -0,0 +1,152 @@
+package config
+
+import (
+	"bytes"
+	"os"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/kelseyhightower/envconfig"
+	"golang.org/x/xerrors"
+)
+
+// remoteNames are the settings the agents read from the CMDB, which are the
+// filters, the intervals and the sinks of the flows shared by the fleet. The
+// others, such as of connecting to the CMDB or to the helper, are only set in
+// the environment of the agents, so that the settings in the CMDB can't turn
+// the agents into anything but a different policy of the same collection.
+var remoteNames = map[string]bool{
+	"SHAWK_PROBE_IGNORE_PORTS":          true,
+	"SHAWK_PROBE_INTERVAL":              true,
+	"SHAWK_PROBE_FLUSH_INTERVAL":        true,
+	"SHAWK_PROBE_REFRESH_INTERVAL":      true,
+	"SHAWK_KUBERNETES_REFRESH_INTERVAL": true,
+	"SHAWK_CONSUL_REFRESH_INTERVAL":     true,
+	"SHAWK_EC2_REFRESH_INTERVAL":        true,
+	"SHAWK_ECS_REFRESH_INTERVAL":        true,
+	"SHAWK_STATSD_ADDR":                 true,
+	"SHAWK_STATSD_PREFIX":               true,
+	"SHAWK_STATSD_DOGSTATSD":            true,
+	"SHAWK_STATSD_TAGS":                 true,
+	"SHAWK_FLUENTD_ADDR":                true,
+	"SHAWK_FLUENTD_TAG":                 true,
+}
+
+// settingNames returns the names of the environment variables of Settings.
+func settingNames() map[string]bool {
+	var b bytes.Buffer
+	if err := envconfig.Usagef("shawk", &Settings{}, &b, "{{range .}}{{usage_key .}}\n{{end}}"); err != nil {
+		panic(err) // the template is constant
+	}
+	names := make(map[string]bool)
+	for _, name := range strings.Fields(b.String()) {
+		names[name] = true
+	}
+	return names
+}
+
+// CheckRemoteName returns an error if the setting of the environment
+// variable can't be managed in the CMDB.
+func CheckRemoteName(name string) error {
+	if !settingNames()[name] {
+		return xerrors.Errorf("%s is not a setting of the agents", name)
+	}
+	if !remoteNames[name] {
+		return xerrors.Errorf("%s is set only in the environment of the agents, since the CMDB manages only the filters, the intervals and the sinks", name)
+	}
+	return nil
+}
+
+// remoteEnv is the settings managed in the CMDB, which override the
+// environment of the process and the env file.
+type remoteEnv struct {
+	fetch func() (map[string]string, error)
+
+	mu sync.Mutex
+	// values are the variables set from the CMDB, and local are the values
+	// of them in the environment before, or nil if they were unset.
+	values map[string]string
+	local  map[string]*string
+}
+
+var remoteEnvLoaded *remoteEnv
+
+// reload sets the variables fetched into the environment if they have
+// changed since the last fetch, restoring the ones removed from the CMDB.
+// It returns whether they have changed.
+func (r *remoteEnv) reload() (bool, error) {
+	fetched, err := r.fetch()
+	if err != nil {
+		return false, xerrors.Errorf("could not fetch the settings from the CMDB: %w", err)
+	}
+	env := make(map[string]string, len(fetched))
+	for name, value := range fetched {
+		if err := CheckRemoteName(name); err != nil {
+			logger.Warningf("Ignored the setting in the CMDB: %v", err)
+			continue
+		}
+		env[name] = value
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if reflect.DeepEqual(env, r.values) {
+		return false, nil
+	}
+	r.apply(env)
+	return true, nil
+}
+
+// reapply sets the variables again over the ones reloaded from the env file.
+func (r *remoteEnv) reapply() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.apply(r.values)
+}
+
+func (r *remoteEnv) apply(env map[string]string) {
+	for k, v := range r.local {
+		if _, ok := env[k]; ok {
+			continue
+		}
+		if v == nil {
+			os.Unsetenv(k)
+		} else {
+			os.Setenv(k, *v)
+		}
+		delete(r.local, k)
+	}
+	for k, v := range env {
+		cur, ok := os.LookupEnv(k)
+		if prev, applied := r.values[k]; !applied || !ok || cur != prev {
+			// set outside since the last apply
+			if ok {
+				r.local[k] = &cur
+			} else {
+				r.local[k] = nil
+			}
+		}
+		os.Setenv(k, v)
+	}
+	r.values = env
+}
+
+// LoadRemote sets the settings fetched from the CMDB by fetch, which are the
+// environment variables such as SHAWK_PROBE_INTERVAL, into the environment
+// and loads Config from it, and remembers fetch to reload them by Watch. It
+// returns the changes of Config by them, or an error keeping Config if they
+// can't be fetched or are invalid.
+func LoadRemote(fetch func() (map[string]string, error)) ([]*Change, error) {
+	r := &remoteEnv{fetch: fetch, local: map[string]*string{}}
+	remoteEnvLoaded = r
+	if _, err := r.reload(); err != nil {
+		return nil, err
+	}
+	s, err := Parse()
+	if err != nil {
+		return nil, xerrors.Errorf("invalid settings in the CMDB: %w", err)
+	}
+	changes := Diff(Config, s)
+	*Config = *s
+	return changes, nil
+}
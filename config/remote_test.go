@@ -0,0 +1,111 @@
+package config
+
+import (
+	"os"
+	"testing"
+
+	"golang.org/x/xerrors"
+)
+
+func TestCheckRemoteName(t *testing.T) {
+	tests := []struct {
+		name    string
+		wantErr bool
+	}{
+		{"SHAWK_PROBE_IGNORE_PORTS", false},
+		{"SHAWK_PROBE_INTERVAL", false},
+		{"SHAWK_EC2_REFRESH_INTERVAL", false},
+		{"SHAWK_FLUENTD_ADDR", false},
+		{"SHAWK_PROBE_DNS_CAPTURE", true},
+		{"SHAWK_PROBE_HELPER_SOCKET", true},
+		{"SHAWK_CMDB_RETRY_MAX", true},
+		{"SHAWK_LOG_LEVEL", true},
+		{"SHAWK_CMDB_URL", true},
+		{"SHAWK_CMDB_TENANT", true},
+		{"SHAWK_HEALTH_ADDR", true},
+		{"SHAWK_NO_SUCH_SETTING", true},
+		{"PATH", true},
+	}
+	for _, tt := range tests {
+		err := CheckRemoteName(tt.name)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("CheckRemoteName(%q) = %v, want error: %v", tt.name, err, tt.wantErr)
+		}
+	}
+	names := settingNames()
+	for name := range remoteNames {
+		if !names[name] {
+			t.Errorf("%s of remoteNames is not a setting of the agents", name)
+		}
+	}
+}
+
+func TestRemoteEnv_reload(t *testing.T) {
+	os.Setenv("SHAWK_PROBE_INTERVAL", "5s")
+	defer os.Unsetenv("SHAWK_PROBE_INTERVAL")
+	defer os.Unsetenv("SHAWK_PROBE_IGNORE_PORTS")
+
+	var fetched map[string]string
+	var fetchErr error
+	r := &remoteEnv{
+		fetch: func() (map[string]string, error) { return fetched, fetchErr },
+		local: map[string]*string{},
+	}
+	reload := func(wantModified bool) {
+		t.Helper()
+		modified, err := r.reload()
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		if modified != wantModified {
+			t.Errorf("reload() = %v, want %v", modified, wantModified)
+		}
+	}
+	env := func(name string) string {
+		v, ok := os.LookupEnv(name)
+		if !ok {
+			return "(unset)"
+		}
+		return v
+	}
+
+	fetched = map[string]string{"SHAWK_PROBE_INTERVAL": "10s", "SHAWK_PROBE_IGNORE_PORTS": "9100", "SHAWK_CMDB_URL": "postgres://other"}
+	reload(true)
+	if got := env("SHAWK_PROBE_INTERVAL"); got != "10s" {
+		t.Errorf("the CMDB should override the environment, but %s", got)
+	}
+	if got := env("SHAWK_PROBE_IGNORE_PORTS"); got != "9100" {
+		t.Errorf("SHAWK_PROBE_IGNORE_PORTS should be set, but %s", got)
+	}
+	if got := env("SHAWK_CMDB_URL"); got == "postgres://other" {
+		t.Errorf("the local setting should not be set from the CMDB, but %s", got)
+	}
+
+	fetched = map[string]string{"SHAWK_PROBE_INTERVAL": "10s", "SHAWK_PROBE_IGNORE_PORTS": "9100"}
+	reload(false)
+
+	fetchErr = xerrors.New("connection refused")
+	if _, err := r.reload(); err == nil {
+		t.Error("reload() should return the error of the fetch")
+	}
+	if got := env("SHAWK_PROBE_INTERVAL"); got != "10s" {
+		t.Errorf("the settings should be kept on the error, but %s", got)
+	}
+	fetchErr = nil
+
+	// The env file reloaded sets the variable again.
+	os.Setenv("SHAWK_PROBE_INTERVAL", "3s")
+	r.reapply()
+	if got := env("SHAWK_PROBE_INTERVAL"); got != "10s" {
+		t.Errorf("the CMDB should override the env file, but %s", got)
+	}
+
+	fetched = map[string]string{}
+	reload(true)
+	if got := env("SHAWK_PROBE_INTERVAL"); got != "3s" {
+		t.Errorf("the local value should be restored, but %s", got)
+	}
+	if got := env("SHAWK_PROBE_IGNORE_PORTS"); got != "(unset)" {
+		t.Errorf("the variable unset before should be unset, but %s", got)
+	}
+}
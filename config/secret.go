@@ -0,0 +1,157 @@
+package config
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"golang.org/x/xerrors"
+)
+
+const (
+	// secretFilePrefix is the prefix of the secret value read from the file
+	// of the path following it, such as 'file:/run/secrets/cmdb-password'.
+	secretFilePrefix = "file:"
+	// secretVaultPrefix is the prefix of the secret value read from the KV
+	// secrets engine of Vault, such as 'vault:secret/data/shawk#password'.
+	secretVaultPrefix = "vault:"
+)
+
+// vaultTimeout is the timeout of reading a secret from Vault.
+var vaultTimeout = 10 * time.Second
+
+// secrets returns the secret fields of the settings with their environment
+// variables.
+func (s *Settings) secrets() map[string]*string {
+	return map[string]*string{
+		"SHAWK_CMDB_URL":      &s.CMDB.URL,
+		"SHAWK_CMDB_PASSWORD": &s.CMDB.Password,
+		"SHAWK_CONSUL_TOKEN":  &s.Consul.Token,
+	}
+}
+
+// resolveSecrets resolves the secret fields of the settings, so that the
+// credentials need not be in the environment or the env file:
+//
+//   - <VAR>_FILE names the file of the value, such as SHAWK_CMDB_PASSWORD_FILE
+//   - 'file:PATH' is read from the file of PATH
+//   - 'vault:PATH#KEY' is read from KEY of the secret at PATH in Vault of
+//     VAULT_ADDR with VAULT_TOKEN
+//
+// The trailing newlines of the files are trimmed. The password of the CMDB
+// replaces the one in the URL of the CMDB.
+func resolveSecrets(s *Settings) error {
+	for env, v := range s.secrets() {
+		if path := os.Getenv(env + "_FILE"); path != "" {
+			if *v != "" {
+				return xerrors.Errorf("%s and %s_FILE are exclusive", env, env)
+			}
+			*v = secretFilePrefix + path
+		}
+		secret, err := resolveSecret(*v)
+		if err != nil {
+			return xerrors.Errorf("could not resolve %s: %w", env, err)
+		}
+		*v = secret
+	}
+	if s.CMDB.Password != "" {
+		u, err := url.Parse(s.CMDB.URL)
+		if err != nil {
+			return xerrors.Errorf("could not set SHAWK_CMDB_PASSWORD into SHAWK_CMDB_URL: %w", err)
+		}
+		u.User = url.UserPassword(u.User.Username(), s.CMDB.Password)
+		s.CMDB.URL = u.String()
+	}
+	return nil
+}
+
+// resolveSecret returns the secret of the value, or the value as it is
+// unless it is a reference to a file or Vault.
+func resolveSecret(v string) (string, error) {
+	switch {
+	case strings.HasPrefix(v, secretFilePrefix):
+		b, err := ioutil.ReadFile(strings.TrimPrefix(v, secretFilePrefix))
+		if err != nil {
+			return "", xerrors.Errorf("could not read secret file: %w", err)
+		}
+		return strings.TrimRight(string(b), "\r\n"), nil
+	case strings.HasPrefix(v, secretVaultPrefix):
+		ref := strings.TrimPrefix(v, secretVaultPrefix)
+		i := strings.LastIndex(ref, "#")
+		if i <= 0 || i == len(ref)-1 {
+			return "", xerrors.Errorf("the secret of Vault must be 'vault:PATH#KEY', but %q", v)
+		}
+		return readVault(ref[:i], ref[i+1:])
+	}
+	return v, nil
+}
+
+// readVault reads the key of the secret at path from Vault by the HTTP API.
+// It reads both the versions 1 and 2 of the KV secrets engine, whose path
+// includes 'data/' such as 'secret/data/shawk'.
+func readVault(path, key string) (string, error) {
+	addr := os.Getenv("VAULT_ADDR")
+	if addr == "" {
+		return "", xerrors.New("VAULT_ADDR is empty")
+	}
+	token, err := vaultToken()
+	if err != nil {
+		return "", err
+	}
+	req, err := http.NewRequest(http.MethodGet, strings.TrimRight(addr, "/")+"/v1/"+strings.TrimLeft(path, "/"), nil)
+	if err != nil {
+		return "", xerrors.Errorf("could not create Vault request: %w", err)
+	}
+	req.Header.Set("X-Vault-Token", token)
+	if ns := os.Getenv("VAULT_NAMESPACE"); ns != "" {
+		req.Header.Set("X-Vault-Namespace", ns)
+	}
+	client := &http.Client{Timeout: vaultTimeout}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", xerrors.Errorf("could not read %s from Vault: %w", path, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", xerrors.Errorf("could not read %s from Vault: %s", path, resp.Status)
+	}
+
+	var body struct {
+		Data map[string]interface{} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return "", xerrors.Errorf("could not decode the secret %s of Vault: %w", path, err)
+	}
+	data := body.Data
+	// The version 2 nests the data with its metadata.
+	if inner, ok := data["data"].(map[string]interface{}); ok {
+		if _, ok := data["metadata"]; ok {
+			data = inner
+		}
+	}
+	secret, ok := data[key].(string)
+	if !ok {
+		return "", xerrors.Errorf("the secret %s of Vault has no string %q", path, key)
+	}
+	return secret, nil
+}
+
+// vaultToken returns the token of VAULT_TOKEN, or the one of ~/.vault-token
+// written by 'vault login'.
+func vaultToken() (string, error) {
+	if token := os.Getenv("VAULT_TOKEN"); token != "" {
+		return token, nil
+	}
+	home, err := os.UserHomeDir()
+	if err == nil {
+		if b, err := ioutil.ReadFile(filepath.Join(home, ".vault-token")); err == nil {
+			return strings.TrimSpace(string(b)), nil
+		}
+	}
+	return "", xerrors.New("VAULT_TOKEN is empty and ~/.vault-token is not found")
+}
@@ -0,0 +1,142 @@
+package db
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"time"
+	"unicode/utf8"
+
+	"github.com/jackc/pgx/v4"
+	"golang.org/x/xerrors"
+)
+
+// MaxAnnotationLength is the maximum number of the characters of the note
+// of an annotation.
+const MaxAnnotationLength = 1024
+
+// MaxVRFLength is the maximum length of the names of the VRFs, which are the
+// names of the network interfaces.
+const MaxVRFLength = 15
+
+const (
+	// upsertAnnotationSQL annotates the edge from the client of $2 to the
+	// server of $3 on the port of $4 in the VRF of $6 with the note of $5 in
+	// the tenant of $1.
+	upsertAnnotationSQL = `
+		INSERT INTO flow_annotations (tenant, client, server, port, note, vrf) VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (tenant, vrf, client, server, port) DO UPDATE SET note = EXCLUDED.note, updated = CURRENT_TIMESTAMP
+	`
+
+	// deleteAnnotationSQL removes the annotation of the edge of $2, $3, $4
+	// and $5 in the tenant of $1.
+	deleteAnnotationSQL = `
+		DELETE FROM flow_annotations WHERE tenant = $1 AND client = $2 AND server = $3 AND port = $4 AND vrf = $5
+	`
+
+	// findAnnotationsSQL finds the annotations of the tenant of $1, or of
+	// all the tenants if $1 is NULL.
+	findAnnotationsSQL = `
+		SELECT client, server, port, vrf, note, updated FROM flow_annotations
+		WHERE ($1::varchar IS NULL OR tenant = $1)
+		ORDER BY client, server, port, vrf
+	`
+)
+
+// Annotation is the note of the review of an edge, such as "expected:
+// backup traffic" or the ticket of it. The edge is the flows from the
+// client to the port of the server, whatever the processes of them. VRF is
+// the VRF of the server, which is empty for the default VRF.
+type Annotation struct {
+	Client  net.IP    `json:"client"`
+	Server  net.IP    `json:"server"`
+	Port    uint16    `json:"port"`
+	VRF     string    `json:"vrf,omitempty"`
+	Note    string    `json:"note"`
+	Updated time.Time `json:"updated"`
+}
+
+// Validate returns an error if the edge or the note of a is invalid.
+func (a *Annotation) Validate() error {
+	if a.Client.To4() == nil {
+		return xerrors.New("the client must be an IPv4 address")
+	}
+	if a.Server.To4() == nil {
+		return xerrors.New("the server must be an IPv4 address")
+	}
+	if a.Port == 0 {
+		return xerrors.New("the port must be between 1 and 65535")
+	}
+	if len(a.VRF) > MaxVRFLength {
+		return xerrors.Errorf("the VRF must be at most %d bytes, but %q", MaxVRFLength, a.VRF)
+	}
+	if n := utf8.RuneCountInString(a.Note); n > MaxAnnotationLength {
+		return xerrors.Errorf("the note must be at most %d characters, but %d", MaxAnnotationLength, n)
+	}
+	return nil
+}
+
+// ServerAddr returns the address and the port of the server of the edge,
+// whose address is suffixed with the VRF as Node.Addr.
+func (a *Annotation) ServerAddr() string {
+	return net.JoinHostPort(vrfAddr(a.Server, a.VRF), strconv.Itoa(int(a.Port)))
+}
+
+// Annotator is the CMDB annotating the edges.
+type Annotator interface {
+	// Annotate annotates the edge of a with a.Note, or removes the
+	// annotation of the edge if a.Note is empty.
+	Annotate(a *Annotation) error
+	FindAnnotations() ([]*Annotation, error)
+}
+
+var (
+	_ Annotator = (*DB)(nil)
+	_ Annotator = (*Shards)(nil)
+)
+
+// Annotate annotates the edge of a in the tenant, or removes the
+// annotation of it if a.Note is empty.
+func (db *DB) Annotate(a *Annotation) error {
+	return db.retry("annotate", &db.Conn, func(conn *pgx.Conn) error {
+		ctx, cancel := context.WithTimeout(context.Background(), InsertOrUpdateTimeoutSec*time.Second)
+		defer cancel()
+
+		if a.Note == "" {
+			if _, err := conn.Exec(ctx, deleteAnnotationSQL, db.tenant, a.Client.String(), a.Server.String(), a.Port, a.VRF); err != nil {
+				return xerrors.Errorf("delete annotation error: %w", err)
+			}
+			return nil
+		}
+		if _, err := conn.Exec(ctx, upsertAnnotationSQL, db.tenant, a.Client.String(), a.Server.String(), a.Port, a.Note, a.VRF); err != nil {
+			return xerrors.Errorf("upsert annotation error: %w", err)
+		}
+		return nil
+	})
+}
+
+// FindAnnotations returns the annotations of the tenant, or of all the
+// tenants in the admin mode, in the order of the edges.
+func (db *DB) FindAnnotations() ([]*Annotation, error) {
+	var annotations []*Annotation
+	err := db.retry("find annotations", &db.Conn, func(conn *pgx.Conn) error {
+		annotations = []*Annotation{}
+		rows, err := conn.Query(context.Background(), findAnnotationsSQL, db.tenantScope())
+		if err != nil {
+			return xerrors.Errorf("find annotations query error: %w", err)
+		}
+		defer rows.Close()
+		for rows.Next() {
+			var a Annotation
+			if err := rows.Scan(&a.Client, &a.Server, &a.Port, &a.VRF, &a.Note, &a.Updated); err != nil {
+				return xerrors.Errorf("scan annotations error: %w", err)
+			}
+			annotations = append(annotations, &a)
+		}
+		if err := rows.Err(); err != nil {
+			return xerrors.Errorf("find annotations query error: %w", err)
+		}
+		return nil
+	})
+	return annotations, err
+}
@@ -0,0 +1,85 @@
+package db
+
+import (
+	"net"
+	"testing"
+
+	"github.com/yuuki/shawk/probe"
+)
+
+func TestAnnotate(t *testing.T) {
+	db, teardown := setupTestCase(t)
+	defer teardown(t)
+
+	flows := []*probe.HostFlow{
+		{
+			Direction: probe.FlowActive, Process: &probe.Process{Name: "backup", Pgid: 100}, Connections: 1,
+			Local: &probe.AddrPort{Addr: "10.0.1.5", Aggregated: true},
+			Peer:  &probe.AddrPort{Addr: "10.0.2.1", Port: 873},
+		},
+		{
+			Direction: probe.FlowActive, Process: &probe.Process{Name: "app", Pgid: 200}, Connections: 2,
+			Local: &probe.AddrPort{Addr: "10.0.1.5", Aggregated: true},
+			Peer:  &probe.AddrPort{Addr: "10.0.2.2", Port: 5432},
+		},
+	}
+	if err := db.InsertOrUpdateHostFlows(flows); err != nil {
+		t.Fatalf("%+v", err)
+	}
+
+	a := &Annotation{
+		Client: net.ParseIP("10.0.1.5"), Server: net.ParseIP("10.0.2.1"), Port: 873,
+		Note: "expected: backup traffic",
+	}
+	if err := db.Annotate(a); err != nil {
+		t.Fatalf("%+v", err)
+	}
+
+	notes := func() map[uint16]string {
+		found, err := db.FindActiveFlows(&FindFlowsCond{Addrs: []net.IP{net.ParseIP("10.0.1.5")}})
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		notes := map[uint16]string{}
+		for _, fs := range found {
+			for _, f := range fs {
+				notes[f.PassiveNode.Port] = f.Annotation
+			}
+		}
+		return notes
+	}
+	got := notes()
+	if got[873] != a.Note || got[5432] != "" {
+		t.Errorf("only the flow to 10.0.2.1:873 should be annotated, but %v", got)
+	}
+
+	annotations, err := db.FindAnnotations()
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if len(annotations) != 1 || annotations[0].Note != a.Note {
+		t.Errorf("FindAnnotations() = %v, want the annotation of 10.0.2.1:873", annotations)
+	}
+
+	// the same edge in a VRF is another edge.
+	if err := db.Annotate(&Annotation{Client: a.Client, Server: a.Server, Port: a.Port, VRF: "vrf-blue", Note: "INFRA-123"}); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if got := notes(); got[873] != a.Note {
+		t.Errorf("the annotation of the edge in the VRF should not be of the default VRF, but %v", got)
+	}
+	annotations, err = db.FindAnnotations()
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if len(annotations) != 2 || annotations[1].VRF != "vrf-blue" {
+		t.Errorf("FindAnnotations() = %v, want the annotations of 10.0.2.1:873 of both VRFs", annotations)
+	}
+
+	if err := db.Annotate(&Annotation{Client: a.Client, Server: a.Server, Port: a.Port}); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if got := notes(); got[873] != "" {
+		t.Errorf("the annotation should be removed, but %v", got)
+	}
+}
@@ -0,0 +1,47 @@
+package db
+
+import (
+	"context"
+	"sync"
+
+	"github.com/jackc/pgx/v4"
+	"golang.org/x/xerrors"
+)
+
+// PasswordFunc returns the password of a new connection to postgres, such
+// as a short-lived token of IAM database authentication.
+type PasswordFunc func() (string, error)
+
+var (
+	passwordFuncMu sync.RWMutex
+	passwordFunc   PasswordFunc
+)
+
+// SetPasswordFunc sets fn to get the password on every connection to
+// postgres instead of the password of the URL, including the reconnections
+// and the extra connections of the parallel writes. nil uses the password
+// of the URL.
+func SetPasswordFunc(fn PasswordFunc) {
+	passwordFuncMu.Lock()
+	defer passwordFuncMu.Unlock()
+	passwordFunc = fn
+}
+
+// connect connects to postgres by conf with the password of PasswordFunc
+// if set.
+func connect(ctx context.Context, conf *pgx.ConnConfig) (*pgx.Conn, error) {
+	passwordFuncMu.RLock()
+	fn := passwordFunc
+	passwordFuncMu.RUnlock()
+	if fn == nil {
+		return pgx.ConnectConfig(ctx, conf)
+	}
+
+	password, err := fn()
+	if err != nil {
+		return nil, xerrors.Errorf("could not get the password of postgres: %w", err)
+	}
+	c := *conf
+	c.Password = password
+	return pgx.ConnectConfig(ctx, &c)
+}
@@ -0,0 +1,37 @@
+package db
+
+import (
+	"strconv"
+
+	"github.com/jackc/pgx/v4"
+	"golang.org/x/xerrors"
+)
+
+// preferSimpleProtocolParam is the parameter of the URL to disable the
+// prepared statements.
+const preferSimpleProtocolParam = "prefer_simple_protocol"
+
+// parseConfig parses the URL or the connection string of postgres. In
+// addition to the parameters of pgx, 'prefer_simple_protocol=true' makes the
+// queries by the simple protocol without the prepared statements, which
+// the poolers such as PgBouncer in the transaction pooling mode break. A
+// unix domain socket is given by its directory as the host, such as
+// 'postgres:///shawk?host=/var/run/postgresql'.
+func parseConfig(dbURL string) (*pgx.ConnConfig, error) {
+	conf, err := pgx.ParseConfig(dbURL)
+	if err != nil {
+		return nil, err
+	}
+	if s, ok := conf.RuntimeParams[preferSimpleProtocolParam]; ok {
+		delete(conf.RuntimeParams, preferSimpleProtocolParam)
+		simple, err := strconv.ParseBool(s)
+		if err != nil {
+			return nil, xerrors.Errorf("cannot parse %s: %w", preferSimpleProtocolParam, err)
+		}
+		conf.PreferSimpleProtocol = simple
+		if simple {
+			conf.BuildStatementCache = nil
+		}
+	}
+	return conf, nil
+}
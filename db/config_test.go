@@ -0,0 +1,95 @@
+package db
+
+import (
+	"net"
+	"testing"
+
+	"github.com/yuuki/shawk/probe"
+)
+
+func TestParseConfig(t *testing.T) {
+	tests := []struct {
+		url        string
+		wantHost   string
+		wantSimple bool
+		wantErr    bool
+	}{
+		{"postgres://shawk@127.0.0.1:5432/shawk", "127.0.0.1", false, false},
+		{"postgres:///shawk?host=/var/run/postgresql", "/var/run/postgresql", false, false},
+		{"host=/var/run/postgresql dbname=shawk", "/var/run/postgresql", false, false},
+		{"postgres://shawk@pgbouncer:6432/shawk?prefer_simple_protocol=true", "pgbouncer", true, false},
+		{"postgres://shawk@pgbouncer:6432/shawk?prefer_simple_protocol=yes", "", false, true},
+	}
+	for _, tt := range tests {
+		conf, err := parseConfig(tt.url)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("parseConfig(%q) should return an error", tt.url)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parseConfig(%q) should not return an error: %v", tt.url, err)
+			continue
+		}
+		if conf.Host != tt.wantHost {
+			t.Errorf("the host of %q should be %q, but %q", tt.url, tt.wantHost, conf.Host)
+		}
+		if conf.PreferSimpleProtocol != tt.wantSimple {
+			t.Errorf("PreferSimpleProtocol of %q should be %v", tt.url, tt.wantSimple)
+		}
+		if tt.wantSimple && conf.BuildStatementCache != nil {
+			t.Errorf("the statements of %q should not be cached", tt.url)
+		}
+		if _, ok := conf.RuntimeParams[preferSimpleProtocolParam]; ok {
+			t.Errorf("%s of %q should not be sent to postgres", preferSimpleProtocolParam, tt.url)
+		}
+	}
+}
+
+func TestAddrsArg(t *testing.T) {
+	got := addrsArg([]net.IP{net.ParseIP("10.0.0.1"), net.ParseIP("10.0.0.2").To4()})
+	if want := "{10.0.0.1,10.0.0.2}"; got != want {
+		t.Errorf("addrsArg() should be %q, but %q", want, got)
+	}
+}
+
+func TestInsertOrUpdateHostFlows_simpleProtocol(t *testing.T) {
+	_, teardown := setupTestCase(t) // creates the schema
+	defer teardown(t)
+
+	db, err := New(testdb.GetURL().String() + "&prefer_simple_protocol=true")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Shutdown()
+
+	flows := []*probe.HostFlow{
+		{
+			Direction:   probe.FlowActive,
+			Local:       &probe.AddrPort{Addr: "10.0.10.1", Aggregated: true},
+			Peer:        &probe.AddrPort{Addr: "10.0.10.2", Port: 5432, Labels: map[string]string{"role": "db"}},
+			Process:     &probe.Process{Pgid: 1001, Name: "app"},
+			Connections: 3,
+		},
+	}
+	// twice to update the labels of the existing peer
+	for i := 0; i < 2; i++ {
+		if err := db.InsertOrUpdateHostFlows(flows); err != nil {
+			t.Fatalf("%+v", err)
+		}
+	}
+
+	got, err := db.FindPassiveFlows(&FindFlowsCond{Addrs: []net.IP{net.ParseIP("10.0.10.2")}})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if n := len(got); n != 1 {
+		t.Fatalf("the flows should be found by the simple protocol, but %d", n)
+	}
+	for _, fs := range got {
+		if fs[0].PassiveNode.Labels["role"] != "db" {
+			t.Errorf("the labels should be written by the simple protocol, but %v", fs[0].PassiveNode.Labels)
+		}
+	}
+}
@@ -2,8 +2,13 @@ package db
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/jackc/pgx/v4"
@@ -22,39 +27,81 @@ var (
 	}
 )
 
-// DB represents a Database handler.
+// DB represents a Database handler. Its methods are safe for concurrent use,
+// such as by the handlers of shawk serve, since they serialize the use of
+// Conn, which is not. Conn is not to be used directly while they run.
 type DB struct {
 	*pgx.Conn
+	// connMu serializes the queries on Conn, including reading their rows,
+	// and its replacement by the reconnection of retry.
+	connMu sync.Mutex
+
+	conf *pgx.ConnConfig
+	// writers are the extra connections for parallel writes.
+	writers   []*pgx.Conn
+	batchSize int
+	// queries observes the statements and logs the slow ones.
+	queries *queryLogger
+
+	// settingsMu guards retryPolicy and notifyThreshold, which the agent
+	// changes by reloading the config while writing.
+	settingsMu  sync.RWMutex
+	retryPolicy RetryPolicy
+	// viewMaxStale is how old the materialized views may be to answer the
+	// aggregate queries.
+	viewMaxStale time.Duration
+	// notifyThreshold is the connections to notify ChangeThreshold.
+	notifyThreshold int
+	// tenant is the tenant of the writes and the queries, and allTenants
+	// makes the queries read all the tenants.
+	tenant     string
+	allTenants bool
 }
 
 // New creates the DB object.
 func New(dbURL string) (*DB, error) {
-	conf, err := pgx.ParseConfig(dbURL)
+	conf, err := parseConfig(dbURL)
 	if err != nil {
 		return nil, xerrors.Errorf("Could not parse postgres config (%s): %v", dbURL, err)
 	}
+	var next pgx.Logger
 	if config.Config.Debug {
-		conf.Logger = log15adapter.NewLogger(log15.New("module", "pgx"))
+		next = log15adapter.NewLogger(log15.New("module", "pgx"))
 	}
+	// The logger observes the statements of all the connections of conf,
+	// including the writers and the reconnections.
+	queries := newQueryLogger(next)
+	conf.Logger = queries
+	conf.LogLevel = pgx.LogLevelInfo
 
 	ctx := context.Background()
-	db, err := pgx.ConnectConfig(ctx, conf)
+	db, err := connect(ctx, conf)
 	if err != nil {
 		return nil, xerrors.Errorf("Could not connect to postgres: %v", err)
 	}
 	if err = db.Ping(ctx); err != nil {
 		return nil, xerrors.Errorf("postgres ping error: %v", err)
 	}
-	return &DB{db}, nil
+	return &DB{Conn: db, conf: conf, queries: queries, retryPolicy: defaultRetryPolicy, viewMaxStale: defaultViewMaxStale}, nil
 }
 
 // Shutdown finishes the DB connection.
 func (db *DB) Shutdown() error {
-	return db.Close(context.Background())
+	ctx := context.Background()
+	for _, w := range db.writers {
+		if err := w.Close(ctx); err != nil {
+			return xerrors.Errorf("close writer connection error: %v", err)
+		}
+	}
+	db.connMu.Lock()
+	defer db.connMu.Unlock()
+	return db.Close(ctx)
 }
 
 // CreateSchema creates the table schemas defined by the paths including Schemas.
 func (db *DB) CreateSchema() error {
+	db.connMu.Lock()
+	defer db.connMu.Unlock()
 	ctx := context.Background()
 	for _, schema := range schemas {
 		sql, err := statik.FindString(schema)
@@ -78,22 +125,133 @@ const (
 		INNER JOIN (SELECT node_id FROM passive_nodes WHERE port = $1)
 			AS pn ON pn.node_id = flows.destination_node_id
 		INNER JOIN (SELECT node_id FROM active_nodes WHERE process_id IN (
-			SELECT process_id FROM processes WHERE ipv4 = $2
+			SELECT process_id FROM processes WHERE ipv4 = $2 AND tenant = $3 AND vrf = $4
 		)) AS an ON an.node_id = flows.source_node_id
 	`
 
 	findPassiveNodesSQL = `
 		SELECT node_id FROM passive_nodes
 		WHERE process_id IN (
-			SELECT process_id FROM processes WHERE ipv4 = $1
+			SELECT process_id FROM processes WHERE ipv4 = $1 AND tenant = $3 AND vrf = $4
 		) AND port = $2
 	`
 
+	// findPassiveFlowsSQL finds the flows to the servers of the addresses
+	// of $1 updated between $2 and $3 of the tenant of $4, or of all the
+	// tenants if $4 is NULL. The flows are filtered by the process name of
+	// either side of $5 unless NULL, the port of $6 unless zero and the
+	// connections of at least $7.
+	findPassiveFlowsSQL = `
+		SELECT
+			DISTINCT ON (pipv4, pn.vrf, pn.pname)
+			pn.ipv4 AS pipv4,
+			pn.vrf AS pvrf,
+			pn.pname AS ppname,
+			pn.port AS pport,
+			pn.pgid AS ppgid,
+			pn.labels AS plabels,
+			pn.owners AS powners,
+			pn.accept_queue AS paccept,
+			pn.backlog AS pbacklog,
+			active_processes.ipv4 AS aipv4,
+			active_processes.vrf AS avrf,
+			active_processes.pname AS apname,
+			active_processes.pgid AS apgid,
+			active_processes.labels AS alabels,
+			active_processes.owners AS aowners,
+			connections,
+			flows.recv_queue,
+			flows.send_queue,
+			flows.client_cong,
+			flows.server_cong,
+			flows.age_min,
+			flows.age_avg,
+			flows.age_max,
+			flows.subflows,
+			COALESCE(flow_annotations.note, '') AS annotation,
+			flows.updated AS updated,
+			flows.tenant AS tenant
+		FROM flows
+		INNER JOIN active_nodes ON active_nodes.node_id = flows.source_node_id
+		INNER JOIN processes AS active_processes ON active_nodes.process_id = active_processes.process_id
+		INNER JOIN (
+			SELECT passive_nodes.node_id, passive_nodes.port, passive_nodes.accept_queue, passive_nodes.backlog, passive_processes.* FROM passive_nodes
+			INNER JOIN processes AS passive_processes ON passive_processes.process_id = passive_nodes.process_id
+			WHERE passive_processes.ipv4 = ANY($1)
+		) AS pn ON pn.node_id = flows.destination_node_id
+		LEFT JOIN flow_annotations ON flow_annotations.tenant = flows.tenant
+			AND flow_annotations.client = active_processes.ipv4
+			AND flow_annotations.server = pn.ipv4
+			AND flow_annotations.port = pn.port
+			AND flow_annotations.vrf = pn.vrf
+		WHERE flows.updated BETWEEN $2 AND $3 AND ($4::varchar IS NULL OR flows.tenant = $4)
+			AND ($5::varchar IS NULL OR active_processes.pname = $5 OR pn.pname = $5)
+			AND ($6::int = 0 OR pn.port = $6)
+			AND flows.connections >= $7
+		ORDER BY pn.ipv4, pn.vrf, pn.pname, flows.updated DESC
+	`
+
+	// findActiveFlowsSQL finds the flows from the clients of the addresses
+	// of $1 updated between $2 and $3 of the tenant of $4, or of all the
+	// tenants if $4 is NULL, filtered as findPassiveFlowsSQL by $5 to $7.
+	findActiveFlowsSQL = `
+		SELECT
+			DISTINCT ON (aipv4, an.vrf, an.pname)
+			an.ipv4 AS aipv4,
+			an.vrf AS avrf,
+			an.pname AS apname,
+			passive_nodes.port AS pport,
+			an.pgid AS apgid,
+			an.labels AS alabels,
+			an.owners AS aowners,
+			passive_processes.ipv4 AS pipv4,
+			passive_processes.vrf AS pvrf,
+			passive_processes.pname AS ppname,
+			passive_processes.pgid AS ppgid,
+			passive_processes.labels AS plabels,
+			passive_processes.owners AS powners,
+			passive_nodes.accept_queue AS paccept,
+			passive_nodes.backlog AS pbacklog,
+			connections,
+			flows.recv_queue,
+			flows.send_queue,
+			flows.client_cong,
+			flows.server_cong,
+			flows.age_min,
+			flows.age_avg,
+			flows.age_max,
+			flows.subflows,
+			COALESCE(flow_annotations.note, '') AS annotation,
+			flows.updated AS updated,
+			flows.tenant AS tenant
+		FROM flows
+		INNER JOIN passive_nodes ON passive_nodes.node_id = flows.destination_node_id
+		INNER JOIN processes AS passive_processes ON passive_nodes.process_id = passive_processes.process_id
+		INNER JOIN (
+			SELECT node_id, active_processes.* FROM active_nodes
+			INNER JOIN processes AS active_processes ON active_processes.process_id = active_nodes.process_id
+			WHERE active_processes.ipv4 = ANY($1)
+		) AS an ON an.node_id = flows.source_node_id
+		LEFT JOIN flow_annotations ON flow_annotations.tenant = flows.tenant
+			AND flow_annotations.client = an.ipv4
+			AND flow_annotations.server = passive_processes.ipv4
+			AND flow_annotations.port = passive_nodes.port
+			AND flow_annotations.vrf = passive_processes.vrf
+		WHERE flows.updated BETWEEN $2 AND $3 AND ($4::varchar IS NULL OR flows.tenant = $4)
+			AND ($5::varchar IS NULL OR an.pname = $5 OR passive_processes.pname = $5)
+			AND ($6::int = 0 OR passive_nodes.port = $6)
+			AND flows.connections >= $7
+		ORDER BY an.ipv4, an.vrf, an.pname, flows.updated DESC
+	`
+
+	// The owners of NULL, which the probe sees only for the local
+	// processes, are kept as they are.
 	insertProcessesSQL = `
-		INSERT INTO processes (ipv4, pgid, pname, updated)
-		VALUES ($1, $2, $3, CURRENT_TIMESTAMP)
-		ON CONFLICT (ipv4, pgid, pname)
-		DO UPDATE SET updated=CURRENT_TIMESTAMP
+		INSERT INTO processes (ipv4, pgid, pname, labels, tenant, owners, vrf, updated)
+		VALUES ($1, $2, $3, $4, $5, COALESCE($6::jsonb, '[]'), $7, CURRENT_TIMESTAMP)
+		ON CONFLICT (ipv4, tenant, vrf, pgid, pname)
+		DO UPDATE SET updated=CURRENT_TIMESTAMP, labels=processes.labels || EXCLUDED.labels,
+			owners=COALESCE($6::jsonb, processes.owners)
 		RETURNING process_id
 	`
 
@@ -121,17 +279,71 @@ const (
 		SELECT node_id FROM passive_nodes WHERE process_id = $1 AND port = $2
 	`
 
+	updateActiveNodeLabelsSQL = `
+		UPDATE processes SET labels=labels || $2
+		WHERE process_id = (SELECT process_id FROM active_nodes WHERE node_id = $1)
+	`
+
+	// the accept queue of the listener is written only by its agent
+	updateListenerQueueSQL = `
+		UPDATE passive_nodes SET accept_queue=$2, backlog=$3 WHERE node_id = $1
+	`
+
+	updatePassiveNodeLabelsSQL = `
+		UPDATE processes SET labels=labels || $2
+		WHERE process_id = (SELECT process_id FROM passive_nodes WHERE node_id = $1)
+	`
+
+	// returns the connections before the write, which is NULL for a new flow.
+	// The queues, the congestion control algorithms, the ages and the
+	// subflows of NULL, which are written by the agent of either side, are
+	// kept as they are.
 	insertFlowsSQL = `
+		WITH prev AS (
+			SELECT connections FROM flows
+			WHERE source_node_id = $1 AND destination_node_id = $2
+		)
 		INSERT INTO flows
-		(source_node_id, destination_node_id, connections)
-		VALUES ($1, $2, $3)
+		(source_node_id, destination_node_id, connections, tenant, recv_queue, send_queue, client_cong, server_cong,
+			age_min, age_avg, age_max, subflows)
+		VALUES ($1, $2, $3, $4, COALESCE($5::integer, 0), COALESCE($6::integer, 0),
+			COALESCE($7::varchar, ''), COALESCE($8::varchar, ''), $9::integer, $10::integer, $11::integer,
+			COALESCE($12::integer, 0))
 		ON CONFLICT (source_node_id, destination_node_id)
-		DO UPDATE SET connections=$3, updated=CURRENT_TIMESTAMP
+		DO UPDATE SET connections=$3, updated=CURRENT_TIMESTAMP,
+			recv_queue=COALESCE($5::integer, flows.recv_queue),
+			send_queue=COALESCE($6::integer, flows.send_queue),
+			client_cong=COALESCE($7::varchar, flows.client_cong),
+			server_cong=COALESCE($8::varchar, flows.server_cong),
+			age_min=COALESCE($9::integer, flows.age_min),
+			age_avg=COALESCE($10::integer, flows.age_avg),
+			age_max=COALESCE($11::integer, flows.age_max),
+			subflows=COALESCE($12::integer, flows.subflows)
+		RETURNING flow_id, (SELECT connections FROM prev)
 	`
 )
 
 // InsertOrUpdateHostFlows insert host flows or update it if the same flow exists.
+// If parallel writes are enabled, the flows are written by batches concurrently.
+// A transaction failed by a transient error is retried by the retry policy.
+// The flows through the NAT gateways are translated by translateFlows.
 func (db *DB) InsertOrUpdateHostFlows(flows []*probe.HostFlow) error {
+	nats, err := db.findNATs()
+	if err != nil {
+		return err
+	}
+	flows = translateFlows(flows, nats)
+	if len(db.writers) == 0 {
+		return db.retry("write flows", &db.Conn, func(conn *pgx.Conn) error {
+			return insertOrUpdateHostFlows(conn, flows, db.tenant, db.threshold())
+		})
+	}
+	return db.insertOrUpdateHostFlowsParallel(flows)
+}
+
+// insertOrUpdateHostFlows writes the flows of the tenant in a transaction on
+// the conn, notifying the new flows and the flows reaching the threshold.
+func insertOrUpdateHostFlows(conn *pgx.Conn, flows []*probe.HostFlow, tenant string, threshold int) error {
 	if len(flows) < 1 {
 		return nil
 	}
@@ -139,9 +351,9 @@ func (db *DB) InsertOrUpdateHostFlows(flows []*probe.HostFlow) error {
 	ctx, cancel := context.WithTimeout(context.Background(), InsertOrUpdateTimeoutSec*time.Second)
 	defer cancel()
 
-	tx, err := db.Begin(ctx)
+	tx, err := conn.Begin(ctx)
 	if err != nil {
-		return xerrors.Errorf("begin transaction error: %v", err)
+		return xerrors.Errorf("begin transaction error: %w", err)
 	}
 	defer tx.Rollback(ctx)
 
@@ -158,6 +370,8 @@ func (db *DB) InsertOrUpdateHostFlows(flows []*probe.HostFlow) error {
 			localProcessID, peerProcessID int64
 			pgid                          int
 			pname                         string
+			flowID                        int64
+			prev                          *int
 		)
 		if flow.Process != nil {
 			pgid = flow.Process.Pgid
@@ -186,120 +400,343 @@ func (db *DB) InsertOrUpdateHostFlows(flows []*probe.HostFlow) error {
 		//   - INSERT INTO flows
 
 		// Insert or update local process
-		err := db.QueryRow(ctx, insertProcessesSQL, flow.Local.Addr, pgid, pname).Scan(&localProcessID)
+		err := conn.QueryRow(ctx, insertProcessesSQL,
+			flow.Local.Addr, pgid, pname, labelsOf(flow.Local), tenant, ownersOf(flow.Process), flow.VRF).Scan(&localProcessID)
 		if err != nil {
-			return xerrors.Errorf("query error: %v", err)
+			return xerrors.Errorf("query error: %w", err)
 		}
 
 		if flow.Direction == probe.FlowPassive {
 			// local node is passive open, peer node is active open.
 
 			// Insert or update local node
-			err := db.QueryRow(ctx, insertPassiveNodesSQL, localProcessID, flow.Local.Port).Scan(&localNodeID)
+			err := conn.QueryRow(ctx, insertPassiveNodesSQL, localProcessID, flow.Local.Port).Scan(&localNodeID)
 			switch {
 			case err == pgx.ErrNoRows:
-				err := db.QueryRow(
+				err := conn.QueryRow(
 					ctx,
 					findPassiveNodesByProcessSQL,
 					localProcessID,
 					flow.Local.Port,
 				).Scan(&localNodeID)
 				if err != nil {
-					return xerrors.Errorf("query error: %v", err)
+					return xerrors.Errorf("query error: %w", err)
 				}
 			case err != nil:
-				return xerrors.Errorf("query error: %v", err)
+				return xerrors.Errorf("query error: %w", err)
+			}
+			if q := flow.Queue; q != nil && q.Backlog > 0 {
+				_, err := conn.Exec(ctx, updateListenerQueueSQL, localNodeID, int64(q.AcceptQ), int64(q.Backlog))
+				if err != nil {
+					return xerrors.Errorf("update listener queue error: %w", err)
+				}
 			}
 
 			// Create or update peer node and process
-			err = db.QueryRow(ctx, findActiveNodesSQL, flow.Local.Port, flow.Peer.Addr).Scan(&peerNodeID)
+			err = conn.QueryRow(ctx, findActiveNodesSQL, flow.Local.Port, flow.Peer.Addr, tenant, flow.VRF).Scan(&peerNodeID)
 			switch {
 			case err == pgx.ErrNoRows:
-				err := db.QueryRow(ctx, insertProcessesSQL, flow.Peer.Addr, 0, "").Scan(&peerProcessID)
+				err := conn.QueryRow(ctx, insertProcessesSQL,
+					flow.Peer.Addr, 0, "", labelsOf(flow.Peer), tenant, nil, flow.VRF).Scan(&peerProcessID)
 				if err != nil {
-					return xerrors.Errorf("insert processes error: %v", err)
+					return xerrors.Errorf("insert processes error: %w", err)
 				}
-				err = db.QueryRow(ctx, insertActiveNodesSQL, peerProcessID).Scan(&peerNodeID)
+				err = conn.QueryRow(ctx, insertActiveNodesSQL, peerProcessID).Scan(&peerNodeID)
 				if err != nil {
-					return xerrors.Errorf("insert active_nodes error: %v", err)
+					return xerrors.Errorf("insert active_nodes error: %w", err)
 				}
 			case err != nil:
-				return xerrors.Errorf("find active_nodes error: %v", err)
-			default:
-				// TODO: update
+				return xerrors.Errorf("find active_nodes error: %w", err)
+			case len(flow.Peer.Labels) > 0:
+				_, err := conn.Exec(ctx, updateActiveNodeLabelsSQL, peerNodeID, labelsOf(flow.Peer))
+				if err != nil {
+					return xerrors.Errorf("update labels error: %w", err)
+				}
 			}
 
-			_, err = db.Exec(ctx, insertFlowsSQL, peerNodeID, localNodeID, flow.Connections)
+			var recvQ, sendQ interface{}
+			if q := flow.Queue; q != nil {
+				recvQ, sendQ = int64(q.RecvQ), int64(q.SendQ)
+			}
+			ageMin, ageAvg, ageMax := agesOf(flow)
+			err = conn.QueryRow(ctx, insertFlowsSQL, peerNodeID, localNodeID, flow.Connections, tenant, recvQ, sendQ,
+				nil, congOf(flow), ageMin, ageAvg, ageMax, subflowsOf(flow)).Scan(&flowID, &prev)
 			if err != nil {
-				return xerrors.Errorf("query error: %v", err)
+				return xerrors.Errorf("query error: %w", err)
 			}
 		} else if flow.Direction == probe.FlowActive {
 			// peer node is passive open, local node is active open.
 
 			// Insert or update local node
-			err := db.QueryRow(ctx, insertActiveNodesSQL, localProcessID).Scan(&localNodeID)
+			err := conn.QueryRow(ctx, insertActiveNodesSQL, localProcessID).Scan(&localNodeID)
 			switch {
 			case err == pgx.ErrNoRows:
-				err := db.QueryRow(ctx, findActiveNodesSQL, localProcessID).Scan(&localNodeID)
+				err := conn.QueryRow(ctx, findActiveNodesByProcessSQL, localProcessID).Scan(&localNodeID)
 				if err != nil {
-					return xerrors.Errorf("query error: %v", err)
+					return xerrors.Errorf("query error: %w", err)
 				}
 			case err != nil:
-				return xerrors.Errorf("query error: %v", err)
+				return xerrors.Errorf("query error: %w", err)
 			}
 
 			// Create or update peer node and process
-			err = db.QueryRow(ctx, findPassiveNodesSQL, flow.Peer.Addr, flow.Peer.Port).Scan(&peerNodeID)
+			err = conn.QueryRow(ctx, findPassiveNodesSQL, flow.Peer.Addr, flow.Peer.Port, tenant, flow.VRF).Scan(&peerNodeID)
 			switch {
 			case err == pgx.ErrNoRows:
-				err := db.QueryRow(ctx, insertProcessesSQL, flow.Peer.Addr, 0, "").Scan(&peerProcessID)
+				err := conn.QueryRow(ctx, insertProcessesSQL,
+					flow.Peer.Addr, 0, "", labelsOf(flow.Peer), tenant, nil, flow.VRF).Scan(&peerProcessID)
 				if err != nil {
-					return xerrors.Errorf("query error: %v", err)
+					return xerrors.Errorf("query error: %w", err)
 				}
-				err = db.QueryRow(ctx, insertPassiveNodesSQL, peerProcessID, flow.Peer.Port).Scan(&peerNodeID)
+				err = conn.QueryRow(ctx, insertPassiveNodesSQL, peerProcessID, flow.Peer.Port).Scan(&peerNodeID)
 				if err != nil {
-					return xerrors.Errorf("query error: %v", err)
+					return xerrors.Errorf("query error: %w", err)
 				}
 			case err != nil:
-				return xerrors.Errorf("query error: %v", err)
-			default:
-				// TODO: update
+				return xerrors.Errorf("query error: %w", err)
+			case len(flow.Peer.Labels) > 0:
+				_, err := conn.Exec(ctx, updatePassiveNodeLabelsSQL, peerNodeID, labelsOf(flow.Peer))
+				if err != nil {
+					return xerrors.Errorf("update labels error: %w", err)
+				}
 			}
 
-			_, err = db.Exec(ctx, insertFlowsSQL, localNodeID, peerNodeID, flow.Connections)
+			ageMin, ageAvg, ageMax := agesOf(flow)
+			err = conn.QueryRow(ctx, insertFlowsSQL, localNodeID, peerNodeID, flow.Connections, tenant, nil, nil,
+				congOf(flow), nil, ageMin, ageAvg, ageMax, subflowsOf(flow)).Scan(&flowID, &prev)
 			if err != nil {
-				return xerrors.Errorf("query error: localNodeID=%d, peerNodeID=%d: %v", localNodeID, peerNodeID, err)
+				return xerrors.Errorf("query error: localNodeID=%d, peerNodeID=%d: %w", localNodeID, peerNodeID, err)
+			}
+		} else {
+			continue
+		}
+
+		if c := flowChange(flow, flowID, prev, threshold); c != nil {
+			c.Tenant = tenant
+			if err := notify(ctx, conn, c); err != nil {
+				return err
 			}
 		}
 	}
 	if err := tx.Commit(ctx); err != nil {
-		return xerrors.Errorf("transaction commit error: %v", err)
+		return xerrors.Errorf("transaction commit error: %w", err)
 	}
 	return nil
 }
 
+// labelsOf returns the labels of the endpoint as the text of a non-nil
+// JSON object because the labels column does not allow NULL. The arguments
+// are passed as text, which the simple protocol encodes as well as the
+// extended one.
+func labelsOf(a *probe.AddrPort) string {
+	if a.Labels == nil {
+		return "{}"
+	}
+	b, _ := json.Marshal(a.Labels) // never fails for map[string]string
+	return string(b)
+}
+
+// ownersOf returns the owners of the process as the text of jsonb, or nil
+// if its sockets are not shared.
+func ownersOf(p *probe.Process) interface{} {
+	if p == nil || len(p.Owners) == 0 {
+		return nil
+	}
+	b, _ := json.Marshal(p.Owners) // never fails for the processes
+	return string(b)
+}
+
+// addrsArg returns the IPv4 addresses as the text of an array of inet for
+// the simple protocol, which doesn't encode []net.IP.
+func addrsArg(addrs []net.IP) string {
+	ss := make([]string, 0, len(addrs))
+	for _, addr := range addrs {
+		// avoid the IPv4-mapped IPv6 addresses
+		if v4 := addr.To4(); v4 != nil {
+			addr = v4
+		}
+		ss = append(ss, addr.String())
+	}
+	return "{" + strings.Join(ss, ",") + "}"
+}
+
 // Node represents a minimum unit of a graph tree.
 type Node struct {
 	IPAddr net.IP
-	Port   int    // 0 if active node
-	Pgid   int    // Process Group ID (Linux)
-	Pname  string // Process Name (Linux)
+	// VRF is the VRF of the address on the host of the agent writing the
+	// node, which is empty for the default VRF.
+	VRF        string
+	Port       uint16
+	Aggregated bool              // true if active node
+	Pgid       int               // Process Group ID (Linux)
+	Pname      string            // Process Name (Linux)
+	Labels     map[string]string // nil if no labels are attached
+	// Owners are the other process groups sharing the sockets of the
+	// process, which are nil unless they are shared.
+	Owners []*probe.Process
+	// AcceptQueue is the connections waiting for accept(2) on the listener
+	// of a passive node, and Backlog is the limit of them. They are zero
+	// unless the agent of the server writes them.
+	AcceptQueue int
+	Backlog     int
+}
+
+// SaturatedRatio is the ratio of the accept queue to the backlog of a
+// saturated listener, which precedes the overflow of the backlog dropping
+// the SYNs.
+const SaturatedRatio = 0.8
+
+// Saturated returns whether the accept queue of the listener of the node is
+// near its backlog.
+func (n *Node) Saturated() bool {
+	return n.Backlog > 0 && float64(n.AcceptQueue) >= SaturatedRatio*float64(n.Backlog)
+}
+
+// Addr returns the address of the node, which is suffixed with the VRF such
+// as 10.0.0.1%blue unless it is of the default VRF, so that the same
+// addresses in the VRFs are told apart.
+func (n *Node) Addr() string {
+	return vrfAddr(n.IPAddr, n.VRF)
+}
+
+func vrfAddr(ip net.IP, vrf string) string {
+	if vrf == "" {
+		return ip.String()
+	}
+	return ip.String() + "%" + vrf
 }
 
 func (n *Node) String() string {
-	port := fmt.Sprintf("%d", n.Port)
-	if n.Port == 0 {
-		port = "many"
+	port := strconv.FormatUint(uint64(n.Port), 10)
+	if n.Aggregated {
+		port = probe.AggregatedPort
+	}
+	s := fmt.Sprintf("%s:%s ('%s', pgid=%d)",
+		n.Addr(), port, n.Pname, n.Pgid)
+	if len(n.Labels) > 0 {
+		s += " " + FormatLabels(n.Labels)
+	}
+	if n.Backlog > 0 {
+		s += fmt.Sprintf(" backlog=%d/%d", n.AcceptQueue, n.Backlog)
 	}
-	return fmt.Sprintf("%s:%s ('%s', pgid=%d)",
-		n.IPAddr, port, n.Pname, n.Pgid)
+	return s
+}
+
+// FormatLabels formats labels as '[k1=v1,k2=v2]' sorted by key.
+func FormatLabels(labels map[string]string) string {
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	pairs := make([]string, 0, len(keys))
+	for _, k := range keys {
+		pairs = append(pairs, k+"="+labels[k])
+	}
+	return "[" + strings.Join(pairs, ",") + "]"
+}
+
+// nilIfEmpty normalizes empty labels scanned from the jsonb column.
+func nilIfEmpty(labels map[string]string) map[string]string {
+	if len(labels) == 0 {
+		return nil
+	}
+	return labels
+}
+
+func nilIfNoOwners(owners []*probe.Process) []*probe.Process {
+	if len(owners) == 0 {
+		return nil
+	}
+	return owners
 }
 
 // Flow represents a flow between a active node and a passive node.
 type Flow struct {
+	// ID is the ID of the flow in the CMDB, which is set only by ListFlows.
+	ID int64
+	// Tenant is the tenant of the flow, which differs from the tenant of
+	// the CMDB only across the tenants.
+	Tenant      string
 	ActiveNode  *Node
 	PassiveNode *Node
 	Connections int
+	// RecvQueue and SendQueue are the bytes queued in the sockets of the
+	// server of the flow, which are the maxima of its connections.
+	RecvQueue int
+	SendQueue int
+	// ClientCong and ServerCong are the congestion control algorithms of
+	// the sockets of the client and the server of the flow, such as "cubic"
+	// or "bbr", which are empty if the agent doesn't see them.
+	ClientCong string
+	ServerCong string
+	// Age is the ages of the connections of the flow written by the agent
+	// of either side, which is nil if the agents don't track them.
+	Age *probe.Age
+	// Subflows is the subflows of the MPTCP connections of the flow, which
+	// is zero for the plain TCP connections.
+	Subflows int
+	// Annotation is the note of the review of the edge of the flow, which
+	// is empty if the edge is not annotated.
+	Annotation string
+	// Updated is the time of the latest write of the flow, which is set
+	// only by ListFlows.
+	Updated time.Time
+}
+
+// UsesCong returns whether the socket of either side of the flow runs the
+// congestion control algorithm. The algorithms of a side may be several
+// ones joined with commas if its connections differ.
+func (f *Flow) UsesCong(cong string) bool {
+	for _, c := range []string{f.ClientCong, f.ServerCong} {
+		for _, name := range strings.Split(c, ",") {
+			if name == cong {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// congOf returns the congestion control algorithm of the flow to write, or
+// nil to keep the written one if the probe doesn't see it.
+func congOf(flow *probe.HostFlow) interface{} {
+	if flow.Cong == "" {
+		return nil
+	}
+	return flow.Cong
+}
+
+// agesOf returns the ages of the flow to write in seconds, or nils to keep
+// the written ones if the probe doesn't track them.
+func agesOf(flow *probe.HostFlow) (min, avg, max interface{}) {
+	if flow.Age == nil {
+		return nil, nil, nil
+	}
+	return int64(flow.Age.Min / time.Second), int64(flow.Age.Avg / time.Second), int64(flow.Age.Max / time.Second)
+}
+
+// subflowsOf returns the subflows of the flow to write, or nil to keep the
+// written ones if the connections are not of MPTCP, such as seen by the
+// agent of the other side without MPTCP.
+func subflowsOf(flow *probe.HostFlow) interface{} {
+	if flow.Subflows == 0 {
+		return nil
+	}
+	return flow.Subflows
+}
+
+// ageOf returns the ages of the seconds read from the CMDB, or nil if they
+// are NULL.
+func ageOf(min, avg, max *int64) *probe.Age {
+	if min == nil || avg == nil || max == nil {
+		return nil
+	}
+	return &probe.Age{
+		Min: time.Duration(*min) * time.Second,
+		Avg: time.Duration(*avg) * time.Second,
+		Max: time.Duration(*max) * time.Second,
+	}
 }
 
 // Flows represents a collection of flow.
@@ -310,10 +747,38 @@ type FindFlowsCond struct {
 	Addrs []net.IP
 	Since time.Time
 	Until time.Time
+
+	// Process is the name of the process of either side of the flows, or
+	// empty for any process. Port is the port of the server of the flows,
+	// or zero for any port. MinConnections is the fewest connections of
+	// the flows.
+	Process        string
+	Port           uint16
+	MinConnections int
+}
+
+// filterArgs returns the arguments of the filters of cond, which follow
+// the tenant in the queries of the flows.
+func (cond *FindFlowsCond) filterArgs() []interface{} {
+	var process interface{}
+	if cond.Process != "" {
+		process = cond.Process
+	}
+	return []interface{}{process, int(cond.Port), cond.MinConnections}
 }
 
 // FindPassiveFlows queries passive flows to CMDB by the slice of ipaddrs.
 func (db *DB) FindPassiveFlows(cond *FindFlowsCond) (Flows, error) {
+	var flows Flows
+	err := db.retry("find passive flows", &db.Conn, func(conn *pgx.Conn) error {
+		var err error
+		flows, err = findPassiveFlows(conn, cond, db.tenantScope())
+		return err
+	})
+	return flows, err
+}
+
+func findPassiveFlows(conn *pgx.Conn, cond *FindFlowsCond, tenant interface{}) (Flows, error) {
 	if len(cond.Addrs) < 1 {
 		return Flows{}, nil
 	}
@@ -321,42 +786,17 @@ func (db *DB) FindPassiveFlows(cond *FindFlowsCond) (Flows, error) {
 		cond.Until = time.Now()
 	}
 
-	// Avoid that pgtype handles addrs as ipv6 address.
-	for i, v := range cond.Addrs {
-		cond.Addrs[i] = v.To4()
-	}
 
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	rows, err := db.Query(ctx, `
-	SELECT
-		DISTINCT ON (pipv4, pn.pname)
-		pn.ipv4 AS pipv4,
-		pn.pname AS ppname,
-		pn.port AS pport,
-		pn.pgid AS ppgid,
-		active_processes.ipv4 AS aipv4,
-		active_processes.pname AS apname,
-		active_processes.pgid AS apgid,
-		connections,
-		flows.updated AS updated
-	FROM flows
-	INNER JOIN active_nodes ON active_nodes.node_id = flows.source_node_id
-	INNER JOIN processes AS active_processes ON active_nodes.process_id = active_processes.process_id
-	INNER JOIN (
-		SELECT passive_nodes.node_id, passive_nodes.port, passive_processes.* FROM passive_nodes
-		INNER JOIN processes AS passive_processes ON passive_processes.process_id = passive_nodes.process_id
-		WHERE passive_processes.ipv4 = ANY($1)
-	) AS pn ON pn.node_id = flows.destination_node_id
-	WHERE flows.updated BETWEEN $2 AND $3
-	ORDER BY pn.ipv4, pn.pname, flows.updated DESC
-`, cond.Addrs, cond.Since, cond.Until)
+	args := append([]interface{}{addrsArg(cond.Addrs), cond.Since, cond.Until, tenant}, cond.filterArgs()...)
+	rows, err := conn.Query(ctx, findPassiveFlowsSQL, args...)
 	switch {
 	case err == pgx.ErrNoRows:
 		return Flows{}, nil
 	case err != nil:
-		return Flows{}, xerrors.Errorf("find passive flows query error: %v", err)
+		return Flows{}, xerrors.Errorf("find passive flows query error: %w", err)
 	}
 	defer rows.Close()
 
@@ -364,39 +804,74 @@ func (db *DB) FindPassiveFlows(cond *FindFlowsCond) (Flows, error) {
 	for rows.Next() {
 		var (
 			pipv4       net.IP
+			pvrf        string
 			ppname      string
-			pport       int
+			pport       uint16
 			ppgid       int
+			plabels     map[string]string
+			powners     []*probe.Process
+			paccept     int
+			pbacklog    int
 			aipv4       net.IP
+			avrf        string
 			apname      string
 			apgid       int
+			alabels     map[string]string
+			aowners     []*probe.Process
 			connections int
+			recvQueue   int
+			sendQueue   int
+			clientCong  string
+			serverCong  string
+			ageMin      *int64
+			ageAvg      *int64
+			ageMax      *int64
+			subflows    int
+			annotation  string
 			updated     time.Time
+			ftenant     string
 		)
 		if err := rows.Scan(
-			&pipv4, &ppname, &pport, &ppgid, &aipv4, &apname, &apgid, &connections, &updated,
+			&pipv4, &pvrf, &ppname, &pport, &ppgid, &plabels, &powners, &paccept, &pbacklog,
+			&aipv4, &avrf, &apname, &apgid, &alabels, &aowners, &connections, &recvQueue, &sendQueue, &clientCong, &serverCong, &ageMin, &ageAvg, &ageMax, &subflows, &annotation, &updated, &ftenant,
 		); err != nil {
-			return nil, xerrors.Errorf("rows scan error: %v", err)
+			return nil, xerrors.Errorf("rows scan error: %w", err)
 		}
-		key := fmt.Sprintf("%s-%s", pipv4, ppname)
+		key := fmt.Sprintf("%s-%s", vrfAddr(pipv4, pvrf), ppname)
 		flows[key] = append(flows[key], &Flow{
+			Tenant: ftenant,
 			ActiveNode: &Node{
-				IPAddr: aipv4,
-				Port:   0,
-				Pgid:   apgid,
-				Pname:  apname,
+				IPAddr:     aipv4,
+				VRF:        avrf,
+				Aggregated: true,
+				Pgid:       apgid,
+				Pname:      apname,
+				Labels:     nilIfEmpty(alabels),
+				Owners:     nilIfNoOwners(aowners),
 			},
 			PassiveNode: &Node{
-				IPAddr: pipv4,
-				Port:   pport,
-				Pgid:   ppgid,
-				Pname:  ppname,
+				IPAddr:      pipv4,
+				VRF:         pvrf,
+				Port:        pport,
+				Pgid:        ppgid,
+				Pname:       ppname,
+				Labels:      nilIfEmpty(plabels),
+				Owners:      nilIfNoOwners(powners),
+				AcceptQueue: paccept,
+				Backlog:     pbacklog,
 			},
 			Connections: connections,
+			RecvQueue:   recvQueue,
+			SendQueue:   sendQueue,
+			ClientCong:  clientCong,
+			ServerCong:  serverCong,
+			Age:         ageOf(ageMin, ageAvg, ageMax),
+			Subflows:    subflows,
+			Annotation:  annotation,
 		})
 	}
 	if err := rows.Err(); err != nil {
-		return nil, xerrors.Errorf("rows error: %v", err)
+		return nil, xerrors.Errorf("rows error: %w", err)
 	}
 
 	return flows, nil
@@ -404,6 +879,16 @@ func (db *DB) FindPassiveFlows(cond *FindFlowsCond) (Flows, error) {
 
 // FindActiveFlows queries active flows to CMDB by the slice of ipaddrs.
 func (db *DB) FindActiveFlows(cond *FindFlowsCond) (Flows, error) {
+	var flows Flows
+	err := db.retry("find active flows", &db.Conn, func(conn *pgx.Conn) error {
+		var err error
+		flows, err = findActiveFlows(conn, cond, db.tenantScope())
+		return err
+	})
+	return flows, err
+}
+
+func findActiveFlows(conn *pgx.Conn, cond *FindFlowsCond, tenant interface{}) (Flows, error) {
 	if len(cond.Addrs) < 1 {
 		return Flows{}, nil
 	}
@@ -411,42 +896,17 @@ func (db *DB) FindActiveFlows(cond *FindFlowsCond) (Flows, error) {
 		cond.Until = time.Now()
 	}
 
-	// Avoid that pgtype handles addrs as ipv6 address.
-	for i, v := range cond.Addrs {
-		cond.Addrs[i] = v.To4()
-	}
 
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	rows, err := db.Query(ctx, `
-	SELECT
-		DISTINCT ON (aipv4, an.pname)
-		an.ipv4 AS aipv4,
-		an.pname AS apname,
-		passive_nodes.port AS pport,
-		an.pgid AS apgid,
-		passive_processes.ipv4 AS pipv4,
-		passive_processes.pname AS ppname,
-		passive_processes.pgid AS ppgid,
-		connections,
-		flows.updated AS updated
-	FROM flows
-	INNER JOIN passive_nodes ON passive_nodes.node_id = flows.destination_node_id
-	INNER JOIN processes AS passive_processes ON passive_nodes.process_id = passive_processes.process_id
-	INNER JOIN (
-		SELECT node_id, active_processes.* FROM active_nodes
-		INNER JOIN processes AS active_processes ON active_processes.process_id = active_nodes.process_id
-		WHERE active_processes.ipv4 = ANY($1)
-	) AS an ON an.node_id = flows.source_node_id
-	WHERE flows.updated BETWEEN $2 AND $3
-	ORDER BY an.ipv4, an.pname, flows.updated DESC
-`, cond.Addrs, cond.Since, cond.Until)
+	args := append([]interface{}{addrsArg(cond.Addrs), cond.Since, cond.Until, tenant}, cond.filterArgs()...)
+	rows, err := conn.Query(ctx, findActiveFlowsSQL, args...)
 	switch {
 	case err == pgx.ErrNoRows:
 		return Flows{}, nil
 	case err != nil:
-		return Flows{}, xerrors.Errorf("find active flows query error: %v", err)
+		return Flows{}, xerrors.Errorf("find active flows query error: %w", err)
 	}
 	defer rows.Close()
 
@@ -454,37 +914,74 @@ func (db *DB) FindActiveFlows(cond *FindFlowsCond) (Flows, error) {
 	for rows.Next() {
 		var (
 			aipv4       net.IP
+			avrf        string
 			apname      string
-			pport       int
+			pport       uint16
 			apgid       int
+			alabels     map[string]string
+			aowners     []*probe.Process
 			pipv4       net.IP
+			pvrf        string
 			ppname      string
 			ppgid       int
+			plabels     map[string]string
+			powners     []*probe.Process
+			paccept     int
+			pbacklog    int
 			connections int
+			recvQueue   int
+			sendQueue   int
+			clientCong  string
+			serverCong  string
+			ageMin      *int64
+			ageAvg      *int64
+			ageMax      *int64
+			subflows    int
+			annotation  string
 			updated     time.Time
+			ftenant     string
 		)
-		if err := rows.Scan(&aipv4, &apname, &pport, &apgid, &pipv4, &ppname, &ppgid, &connections, &updated); err != nil {
-			return nil, xerrors.Errorf("rows scan error: %v", err)
+		if err := rows.Scan(
+			&aipv4, &avrf, &apname, &pport, &apgid, &alabels, &aowners,
+			&pipv4, &pvrf, &ppname, &ppgid, &plabels, &powners, &paccept, &pbacklog, &connections, &recvQueue, &sendQueue, &clientCong, &serverCong, &ageMin, &ageAvg, &ageMax, &subflows, &annotation, &updated, &ftenant,
+		); err != nil {
+			return nil, xerrors.Errorf("rows scan error: %w", err)
 		}
-		key := fmt.Sprintf("%s-%s", aipv4, apname)
+		key := fmt.Sprintf("%s-%s", vrfAddr(aipv4, avrf), apname)
 		flows[key] = append(flows[key], &Flow{
+			Tenant: ftenant,
 			ActiveNode: &Node{
-				IPAddr: aipv4,
-				Port:   0,
-				Pgid:   apgid,
-				Pname:  apname,
+				IPAddr:     aipv4,
+				VRF:        avrf,
+				Aggregated: true,
+				Pgid:       apgid,
+				Pname:      apname,
+				Labels:     nilIfEmpty(alabels),
+				Owners:     nilIfNoOwners(aowners),
 			},
 			PassiveNode: &Node{
-				IPAddr: pipv4,
-				Port:   pport,
-				Pgid:   ppgid,
-				Pname:  ppname,
+				IPAddr:      pipv4,
+				VRF:         pvrf,
+				Port:        pport,
+				Pgid:        ppgid,
+				Pname:       ppname,
+				Labels:      nilIfEmpty(plabels),
+				Owners:      nilIfNoOwners(powners),
+				AcceptQueue: paccept,
+				Backlog:     pbacklog,
 			},
 			Connections: connections,
+			RecvQueue:   recvQueue,
+			SendQueue:   sendQueue,
+			ClientCong:  clientCong,
+			ServerCong:  serverCong,
+			Age:         ageOf(ageMin, ageAvg, ageMax),
+			Subflows:    subflows,
+			Annotation:  annotation,
 		})
 	}
 	if err := rows.Err(); err != nil {
-		return nil, xerrors.Errorf("rows error: %v", err)
+		return nil, xerrors.Errorf("rows error: %w", err)
 	}
 
 	return flows, nil
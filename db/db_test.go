@@ -4,7 +4,9 @@ import (
 	"context"
 	"net"
 	"os"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 
@@ -37,6 +39,13 @@ func TestCreateSchema(t *testing.T) {
 	if err = db.CreateSchema(); err != nil {
 		t.Fatal(err)
 	}
+	version, err := db.FindSchemaVersion()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if version != SchemaVersion {
+		t.Errorf("FindSchemaVersion() = %d, want %d", version, SchemaVersion)
+	}
 }
 
 func setupTestCase(t *testing.T) (*DB, func(t *testing.T)) {
@@ -85,6 +94,158 @@ func TestInsertOrUpdateHostFlows_empty(t *testing.T) {
 	}
 }
 
+func TestInsertOrUpdateHostFlows_parallel(t *testing.T) {
+	db, teardown := setupTestCase(t)
+	defer teardown(t)
+
+	if err := db.EnableParallelWrites(2, 3); err != nil {
+		t.Fatal(err)
+	}
+
+	flows := make([]*probe.HostFlow, 0, 20)
+	for i := 0; i < 20; i++ {
+		flows = append(flows, &probe.HostFlow{
+			Direction:   probe.FlowActive,
+			Local:       &probe.AddrPort{Addr: "10.0.10.1", Aggregated: true},
+			Peer:        &probe.AddrPort{Addr: "10.0.20.1", Port: uint16(8000 + i%5)},
+			Process:     &probe.Process{Pgid: 1000 + i%4, Name: "python"},
+			Connections: 1,
+		})
+	}
+
+	if err := db.InsertOrUpdateHostFlows(flows); err != nil {
+		t.Fatalf("%+v", err)
+	}
+
+	var n int
+	if err := db.QueryRow(context.Background(), "SELECT count(*) FROM flows").Scan(&n); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if n != 20 {
+		t.Errorf("the number of flows should be 20, but %d", n)
+	}
+}
+
+// TestDB_concurrentQueries queries the DB from many goroutines, as the
+// handlers of shawk serve do, while its connection is lost and reconnected.
+// Run it with -race.
+func TestDB_concurrentQueries(t *testing.T) {
+	db, teardown := setupTestCase(t)
+	defer teardown(t)
+	db.SetRetryPolicy(RetryPolicy{MaxRetries: 3, InitialBackoff: time.Millisecond, MaxBackoff: 10 * time.Millisecond})
+
+	flows := []*probe.HostFlow{
+		{
+			Direction:   probe.FlowActive,
+			Local:       &probe.AddrPort{Addr: "10.0.10.1", Aggregated: true},
+			Peer:        &probe.AddrPort{Addr: "10.0.10.2", Port: 80},
+			Process:     &probe.Process{Pgid: 1001, Name: "haproxy"},
+			Connections: 10,
+		},
+		{
+			Direction:   probe.FlowPassive,
+			Local:       &probe.AddrPort{Addr: "10.0.10.2", Port: 80},
+			Peer:        &probe.AddrPort{Addr: "10.0.10.1", Aggregated: true},
+			Process:     &probe.Process{Pgid: 2001, Name: "nginx"},
+			Connections: 10,
+		},
+	}
+	if err := db.InsertOrUpdateHostFlows(flows); err != nil {
+		t.Fatalf("%+v", err)
+	}
+
+	ctx := context.Background()
+	addrs := []net.IP{net.ParseIP("10.0.10.2")}
+	queries := []func() error{
+		func() error {
+			_, err := db.FindPassiveFlows(&FindFlowsCond{Addrs: addrs})
+			return err
+		},
+		func() error {
+			_, err := db.FindActiveFlows(&FindFlowsCond{Addrs: addrs})
+			return err
+		},
+		func() error {
+			_, err := db.ListFlows(&ListFlowsCond{})
+			return err
+		},
+		func() error {
+			_, err := db.FindTopTalkers(&AggregateCond{})
+			return err
+		},
+		func() error {
+			_, err := db.FindGraphStats(ctx, 10)
+			return err
+		},
+		func() error {
+			_, err := db.CountFlows(ctx)
+			return err
+		},
+		func() error {
+			return db.InsertOrUpdateHostFlows(flows)
+		},
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 8*len(queries))
+	for i := 0; i < 8; i++ {
+		for _, q := range queries {
+			wg.Add(1)
+			go func(q func() error) {
+				defer wg.Done()
+				for j := 0; j < 5; j++ {
+					if err := q(); err != nil {
+						errs <- err
+						return
+					}
+				}
+			}(q)
+		}
+		if i == 4 {
+			// The retries reconnect while the other goroutines query.
+			db.connMu.Lock()
+			db.Conn.Close(ctx)
+			db.connMu.Unlock()
+		}
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Errorf("the concurrent query should not fail: %+v", err)
+	}
+}
+
+func TestPartitionFlows(t *testing.T) {
+	flows := make([]*probe.HostFlow, 0, 8)
+	for i := 0; i < 8; i++ {
+		flows = append(flows, &probe.HostFlow{
+			Direction: probe.FlowActive,
+			Local:     &probe.AddrPort{Addr: "10.0.10.1", Aggregated: true},
+			Peer:      &probe.AddrPort{Addr: "10.0.20.1", Port: uint16(9000 - i)},
+			Process:   &probe.Process{Pgid: 1000 + i%2, Name: "python"},
+		})
+	}
+
+	groups := partitionFlows(flows, 4)
+	total := 0
+	groupOf := map[int]int{} // pgid -> group
+	for i, g := range groups {
+		total += len(g)
+		for j, f := range g {
+			if gi, ok := groupOf[f.Process.Pgid]; ok && gi != i {
+				t.Errorf("the process %d should not span groups", f.Process.Pgid)
+			}
+			groupOf[f.Process.Pgid] = i
+			if j > 0 && g[j-1].Peer.Port > f.Peer.Port {
+				t.Errorf("flows should be sorted by the peer: %s, %s", g[j-1], f)
+			}
+		}
+	}
+	if total != len(flows) {
+		t.Errorf("partitionFlows() should keep %d flows, but %d", len(flows), total)
+	}
+}
+
 func TestInsertOrUpdateHostFlows(t *testing.T) {
 	db, teardown := setupTestCase(t)
 	defer teardown(t)
@@ -92,15 +253,15 @@ func TestInsertOrUpdateHostFlows(t *testing.T) {
 	flows := []*probe.HostFlow{
 		{
 			Direction:   probe.FlowActive,
-			Local:       &probe.AddrPort{Addr: "10.0.10.1", Port: "many"},
-			Peer:        &probe.AddrPort{Addr: "10.0.10.2", Port: "5432"},
+			Local:       &probe.AddrPort{Addr: "10.0.10.1", Aggregated: true},
+			Peer:        &probe.AddrPort{Addr: "10.0.10.2", Port: 5432},
 			Process:     &probe.Process{Pgid: 1001, Name: "python"},
 			Connections: 10,
 		},
 		{
 			Direction:   probe.FlowPassive,
-			Local:       &probe.AddrPort{Addr: "10.0.10.1", Port: "80"},
-			Peer:        &probe.AddrPort{Addr: "10.0.10.2", Port: "many"},
+			Local:       &probe.AddrPort{Addr: "10.0.10.1", Port: 80},
+			Peer:        &probe.AddrPort{Addr: "10.0.10.2", Aggregated: true},
 			Process:     &probe.Process{Pgid: 1002, Name: "nginx"},
 			Connections: 12,
 		},
@@ -210,8 +371,8 @@ func TestInsertOrUpdateHostFlows_empty_process(t *testing.T) {
 	flows := []*probe.HostFlow{
 		{
 			Direction:   probe.FlowActive,
-			Local:       &probe.AddrPort{Addr: "10.0.10.1", Port: "many"},
-			Peer:        &probe.AddrPort{Addr: "10.0.10.2", Port: "5432"},
+			Local:       &probe.AddrPort{Addr: "10.0.10.1", Aggregated: true},
+			Peer:        &probe.AddrPort{Addr: "10.0.10.2", Port: 5432},
 			Connections: 10,
 		},
 	}
@@ -252,6 +413,267 @@ func TestInsertOrUpdateHostFlows_empty_process(t *testing.T) {
 	}
 }
 
+func TestInsertOrUpdateHostFlows_queue(t *testing.T) {
+	db, teardown := setupTestCase(t)
+	defer teardown(t)
+
+	server := &probe.HostFlow{
+		Direction:   probe.FlowPassive,
+		Local:       &probe.AddrPort{Addr: "10.0.10.2", Port: 80},
+		Peer:        &probe.AddrPort{Addr: "10.0.10.1", Aggregated: true},
+		Process:     &probe.Process{Pgid: 2000, Name: "nginx"},
+		Connections: 5,
+		Queue:       &probe.Queue{RecvQ: 10, SendQ: 2048, AcceptQ: 110, Backlog: 128},
+	}
+	// The client doesn't overwrite the queues written by the server.
+	client := &probe.HostFlow{
+		Direction:   probe.FlowActive,
+		Local:       &probe.AddrPort{Addr: "10.0.10.1", Aggregated: true},
+		Peer:        &probe.AddrPort{Addr: "10.0.10.2", Port: 80},
+		Connections: 5,
+		Queue:       &probe.Queue{RecvQ: 1, SendQ: 1},
+	}
+	for _, f := range []*probe.HostFlow{server, client} {
+		if err := db.InsertOrUpdateHostFlows([]*probe.HostFlow{f}); err != nil {
+			t.Fatalf("%+v", err)
+		}
+	}
+
+	flows, err := db.FindPassiveFlows(&FindFlowsCond{Addrs: []net.IP{net.ParseIP("10.0.10.2")}})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	for _, fs := range flows {
+		for _, f := range fs {
+			if f.RecvQueue != 10 || f.SendQueue != 2048 {
+				t.Errorf("the queues of the flow should be 10 and 2048, but %d and %d", f.RecvQueue, f.SendQueue)
+			}
+			if !f.PassiveNode.Saturated() {
+				t.Errorf("the listener of 110/128 should be saturated: %s", f.PassiveNode)
+			}
+		}
+	}
+}
+
+func TestInsertOrUpdateHostFlows_cong(t *testing.T) {
+	db, teardown := setupTestCase(t)
+	defer teardown(t)
+
+	client := &probe.HostFlow{
+		Direction:   probe.FlowActive,
+		Local:       &probe.AddrPort{Addr: "10.0.11.1", Aggregated: true},
+		Peer:        &probe.AddrPort{Addr: "10.0.11.2", Port: 443},
+		Connections: 3,
+		Cong:        "bbr",
+	}
+	server := &probe.HostFlow{
+		Direction:   probe.FlowPassive,
+		Local:       &probe.AddrPort{Addr: "10.0.11.2", Port: 443},
+		Peer:        &probe.AddrPort{Addr: "10.0.11.1", Aggregated: true},
+		Connections: 3,
+		Cong:        "cubic",
+	}
+	// The client not seeing the algorithm doesn't overwrite it.
+	unseen := *client
+	unseen.Cong = ""
+	for _, f := range []*probe.HostFlow{client, server, &unseen} {
+		if err := db.InsertOrUpdateHostFlows([]*probe.HostFlow{f}); err != nil {
+			t.Fatalf("%+v", err)
+		}
+	}
+
+	flows, err := db.FindActiveFlows(&FindFlowsCond{Addrs: []net.IP{net.ParseIP("10.0.11.1")}})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	var got [][2]string
+	for _, fs := range flows {
+		for _, f := range fs {
+			got = append(got, [2]string{f.ClientCong, f.ServerCong})
+		}
+	}
+	if diff := cmp.Diff([][2]string{{"bbr", "cubic"}}, got); diff != "" {
+		t.Errorf("the algorithms of the flow mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestInsertOrUpdateHostFlows_ages(t *testing.T) {
+	db, teardown := setupTestCase(t)
+	defer teardown(t)
+
+	server := &probe.HostFlow{
+		Direction:   probe.FlowPassive,
+		Local:       &probe.AddrPort{Addr: "10.0.13.2", Port: 443},
+		Peer:        &probe.AddrPort{Addr: "10.0.13.1", Aggregated: true},
+		Connections: 3,
+		Age:         &probe.Age{Min: 1500 * time.Millisecond, Avg: time.Minute, Max: time.Hour},
+	}
+	// The client not tracking the ages doesn't overwrite them.
+	client := &probe.HostFlow{
+		Direction:   probe.FlowActive,
+		Local:       &probe.AddrPort{Addr: "10.0.13.1", Aggregated: true},
+		Peer:        &probe.AddrPort{Addr: "10.0.13.2", Port: 443},
+		Connections: 3,
+	}
+	for _, f := range []*probe.HostFlow{server, client} {
+		if err := db.InsertOrUpdateHostFlows([]*probe.HostFlow{f}); err != nil {
+			t.Fatalf("%+v", err)
+		}
+	}
+
+	flows, err := db.FindPassiveFlows(&FindFlowsCond{Addrs: []net.IP{net.ParseIP("10.0.13.2")}})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	var got []*probe.Age
+	for _, fs := range flows {
+		for _, f := range fs {
+			got = append(got, f.Age)
+		}
+	}
+	want := []*probe.Age{{Min: time.Second, Avg: time.Minute, Max: time.Hour}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("the ages of the flow mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestInsertOrUpdateHostFlows_subflows(t *testing.T) {
+	db, teardown := setupTestCase(t)
+	defer teardown(t)
+
+	client := &probe.HostFlow{
+		Direction:   probe.FlowActive,
+		Local:       &probe.AddrPort{Addr: "10.0.15.1", Aggregated: true},
+		Peer:        &probe.AddrPort{Addr: "10.0.15.2", Port: 443},
+		Connections: 2,
+		Subflows:    5,
+	}
+	// The server seeing no subflows doesn't overwrite them.
+	server := &probe.HostFlow{
+		Direction:   probe.FlowPassive,
+		Local:       &probe.AddrPort{Addr: "10.0.15.2", Port: 443},
+		Peer:        &probe.AddrPort{Addr: "10.0.15.1", Aggregated: true},
+		Connections: 2,
+	}
+	for _, f := range []*probe.HostFlow{client, server} {
+		if err := db.InsertOrUpdateHostFlows([]*probe.HostFlow{f}); err != nil {
+			t.Fatalf("%+v", err)
+		}
+	}
+
+	flows, err := db.ListFlows(&ListFlowsCond{})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	var got []int
+	for _, f := range flows {
+		got = append(got, f.Subflows)
+	}
+	if diff := cmp.Diff([]int{5}, got); diff != "" {
+		t.Errorf("the subflows of the flow mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestInsertOrUpdateHostFlows_vrf(t *testing.T) {
+	db, teardown := setupTestCase(t)
+	defer teardown(t)
+
+	newFlow := func(vrf string, connections int64) *probe.HostFlow {
+		return &probe.HostFlow{
+			Direction:   probe.FlowPassive,
+			Local:       &probe.AddrPort{Addr: "10.0.14.2", Port: 80},
+			Peer:        &probe.AddrPort{Addr: "10.0.14.1", Aggregated: true},
+			Process:     &probe.Process{Name: "nginx", Pgid: 100},
+			Connections: connections,
+			VRF:         vrf,
+		}
+	}
+	// The same addresses in the VRF are the other processes.
+	if err := db.InsertOrUpdateHostFlows([]*probe.HostFlow{newFlow("", 1), newFlow("blue", 2)}); err != nil {
+		t.Fatalf("%+v", err)
+	}
+
+	flows, err := db.ListFlows(&ListFlowsCond{})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	got := map[string]int{}
+	for _, f := range flows {
+		got[f.ActiveNode.Addr()+" "+f.PassiveNode.Addr()] = f.Connections
+	}
+	want := map[string]int{
+		"10.0.14.1 10.0.14.2":           1,
+		"10.0.14.1%blue 10.0.14.2%blue": 2,
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("the flows of the VRFs mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestNode_Addr(t *testing.T) {
+	n := &Node{IPAddr: net.ParseIP("10.0.0.1"), Port: 80, Pname: "nginx"}
+	if got, want := n.Addr(), "10.0.0.1"; got != want {
+		t.Errorf("Addr() = %q, want %q", got, want)
+	}
+	n.VRF = "blue"
+	if got, want := n.Addr(), "10.0.0.1%blue"; got != want {
+		t.Errorf("Addr() = %q, want %q", got, want)
+	}
+	if got, want := n.String(), "10.0.0.1%blue:80 ('nginx', pgid=0)"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
+func TestAgeOf(t *testing.T) {
+	min, avg, max := int64(1), int64(60), int64(3600)
+	want := &probe.Age{Min: time.Second, Avg: time.Minute, Max: time.Hour}
+	if diff := cmp.Diff(want, ageOf(&min, &avg, &max)); diff != "" {
+		t.Errorf("ageOf() mismatch (-want +got):\n%s", diff)
+	}
+	if got := ageOf(nil, nil, nil); got != nil {
+		t.Errorf("ageOf() should be nil for NULL, but %+v", got)
+	}
+}
+
+func TestInsertOrUpdateHostFlows_owners(t *testing.T) {
+	db, teardown := setupTestCase(t)
+	defer teardown(t)
+
+	systemd := &probe.Process{Name: "systemd", Pgid: 1}
+	server := &probe.HostFlow{
+		Direction:   probe.FlowPassive,
+		Local:       &probe.AddrPort{Addr: "10.0.12.2", Port: 80},
+		Peer:        &probe.AddrPort{Addr: "10.0.12.1", Aggregated: true},
+		Process:     &probe.Process{Name: "nginx", Pgid: 100, Owners: []*probe.Process{systemd}},
+		Connections: 2,
+	}
+	// The scan not seeing the owners doesn't overwrite them.
+	unseen := *server
+	unseen.Process = &probe.Process{Name: "nginx", Pgid: 100}
+	for _, f := range []*probe.HostFlow{server, &unseen} {
+		if err := db.InsertOrUpdateHostFlows([]*probe.HostFlow{f}); err != nil {
+			t.Fatalf("%+v", err)
+		}
+	}
+
+	flows, err := db.FindPassiveFlows(&FindFlowsCond{Addrs: []net.IP{net.ParseIP("10.0.12.2")}})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	var got [][]*probe.Process
+	for _, fs := range flows {
+		for _, f := range fs {
+			got = append(got, f.PassiveNode.Owners)
+			if f.ActiveNode.Owners != nil {
+				t.Errorf("the client should have no owners, but %v", f.ActiveNode.Owners)
+			}
+		}
+	}
+	if diff := cmp.Diff([][]*probe.Process{{systemd}}, got); diff != "" {
+		t.Errorf("the owners of the server mismatch (-want +got):\n%s", diff)
+	}
+}
+
 func TestFindPassiveFlows(t *testing.T) {
 	db, teardown := setupTestCase(t)
 	defer teardown(t)
@@ -262,57 +684,57 @@ func TestFindPassiveFlows(t *testing.T) {
 		//                                              |-> redis(10.0.10.4:6379)
 		{
 			Direction:   probe.FlowActive,
-			Local:       &probe.AddrPort{Addr: "10.0.10.1", Port: "many"},
-			Peer:        &probe.AddrPort{Addr: "10.0.10.2", Port: "80"},
+			Local:       &probe.AddrPort{Addr: "10.0.10.1", Aggregated: true},
+			Peer:        &probe.AddrPort{Addr: "10.0.10.2", Port: 80},
 			Process:     &probe.Process{Pgid: 1001, Name: "haproxy"},
 			Connections: 100,
 		},
 		{
 			Direction:   probe.FlowPassive,
-			Local:       &probe.AddrPort{Addr: "10.0.10.2", Port: "80"},
-			Peer:        &probe.AddrPort{Addr: "10.0.10.1", Port: "many"},
+			Local:       &probe.AddrPort{Addr: "10.0.10.2", Port: 80},
+			Peer:        &probe.AddrPort{Addr: "10.0.10.1", Aggregated: true},
 			Process:     &probe.Process{Pgid: 2001, Name: "nginx"},
 			Connections: 12,
 		},
 		{
 			Direction:   probe.FlowActive,
-			Local:       &probe.AddrPort{Addr: "10.0.10.1", Port: "many"},
-			Peer:        &probe.AddrPort{Addr: "10.0.10.2", Port: "8000"},
+			Local:       &probe.AddrPort{Addr: "10.0.10.1", Aggregated: true},
+			Peer:        &probe.AddrPort{Addr: "10.0.10.2", Port: 8000},
 			Process:     &probe.Process{Pgid: 2002, Name: "gunicorn"},
 			Connections: 18,
 		},
 		{
 			Direction:   probe.FlowPassive,
-			Local:       &probe.AddrPort{Addr: "10.0.10.2", Port: "8000"},
-			Peer:        &probe.AddrPort{Addr: "10.0.10.2", Port: "many"},
+			Local:       &probe.AddrPort{Addr: "10.0.10.2", Port: 8000},
+			Peer:        &probe.AddrPort{Addr: "10.0.10.2", Aggregated: true},
 			Process:     &probe.Process{Pgid: 2002, Name: "gunicorn"},
 			Connections: 10,
 		},
 		{
 			Direction:   probe.FlowActive,
-			Local:       &probe.AddrPort{Addr: "10.0.10.2", Port: "many"},
-			Peer:        &probe.AddrPort{Addr: "10.0.10.3", Port: "5432"},
+			Local:       &probe.AddrPort{Addr: "10.0.10.2", Aggregated: true},
+			Peer:        &probe.AddrPort{Addr: "10.0.10.3", Port: 5432},
 			Process:     &probe.Process{Pgid: 2002, Name: "gunicorn"},
 			Connections: 21,
 		},
 		{
 			Direction:   probe.FlowActive,
-			Local:       &probe.AddrPort{Addr: "10.0.10.2", Port: "many"},
-			Peer:        &probe.AddrPort{Addr: "10.0.10.4", Port: "6379"},
+			Local:       &probe.AddrPort{Addr: "10.0.10.2", Aggregated: true},
+			Peer:        &probe.AddrPort{Addr: "10.0.10.4", Port: 6379},
 			Process:     &probe.Process{Pgid: 2002, Name: "gunicorn"},
 			Connections: 14,
 		},
 		{
 			Direction:   probe.FlowPassive,
-			Local:       &probe.AddrPort{Addr: "10.0.10.3", Port: "5432"},
-			Peer:        &probe.AddrPort{Addr: "10.0.10.2", Port: "many"},
+			Local:       &probe.AddrPort{Addr: "10.0.10.3", Port: 5432},
+			Peer:        &probe.AddrPort{Addr: "10.0.10.2", Aggregated: true},
 			Process:     &probe.Process{Pgid: 3001, Name: "postgres"},
 			Connections: 20,
 		},
 		{
 			Direction:   probe.FlowPassive,
-			Local:       &probe.AddrPort{Addr: "10.0.10.4", Port: "6379"},
-			Peer:        &probe.AddrPort{Addr: "10.0.10.2", Port: "many"},
+			Local:       &probe.AddrPort{Addr: "10.0.10.4", Port: 6379},
+			Peer:        &probe.AddrPort{Addr: "10.0.10.2", Aggregated: true},
 			Process:     &probe.Process{Pgid: 4001, Name: "redis"},
 			Connections: 19,
 		},
@@ -338,10 +760,10 @@ func TestFindPassiveFlows(t *testing.T) {
 		"10.0.10.2-": []*Flow{
 			{
 				ActiveNode: &Node{
-					IPAddr: net.ParseIP("10.0.10.1"),
-					Port:   0,
-					Pgid:   1001,
-					Pname:  "haproxy",
+					IPAddr:     net.ParseIP("10.0.10.1"),
+					Aggregated: true,
+					Pgid:       1001,
+					Pname:      "haproxy",
 				},
 				PassiveNode: &Node{
 					IPAddr: net.ParseIP("10.0.10.2"),
@@ -353,10 +775,10 @@ func TestFindPassiveFlows(t *testing.T) {
 		"10.0.10.2-nginx": []*Flow{
 			{
 				ActiveNode: &Node{
-					IPAddr: net.ParseIP("10.0.10.1"),
-					Port:   0,
-					Pgid:   1001,
-					Pname:  "haproxy",
+					IPAddr:     net.ParseIP("10.0.10.1"),
+					Aggregated: true,
+					Pgid:       1001,
+					Pname:      "haproxy",
 				},
 				PassiveNode: &Node{
 					IPAddr: net.ParseIP("10.0.10.2"),
@@ -370,8 +792,8 @@ func TestFindPassiveFlows(t *testing.T) {
 		"10.0.10.2-gunicorn": []*Flow{
 			{
 				ActiveNode: &Node{
-					IPAddr: net.ParseIP("10.0.10.2"),
-					Port:   0,
+					IPAddr:     net.ParseIP("10.0.10.2"),
+					Aggregated: true,
 				},
 				PassiveNode: &Node{
 					IPAddr: net.ParseIP("10.0.10.2"),
@@ -385,10 +807,10 @@ func TestFindPassiveFlows(t *testing.T) {
 		"10.0.10.3-": []*Flow{
 			{
 				ActiveNode: &Node{
-					IPAddr: net.ParseIP("10.0.10.2"),
-					Port:   0,
-					Pgid:   2002,
-					Pname:  "gunicorn",
+					IPAddr:     net.ParseIP("10.0.10.2"),
+					Aggregated: true,
+					Pgid:       2002,
+					Pname:      "gunicorn",
 				},
 				PassiveNode: &Node{
 					IPAddr: net.ParseIP("10.0.10.3"),
@@ -400,10 +822,10 @@ func TestFindPassiveFlows(t *testing.T) {
 		"10.0.10.3-postgres": []*Flow{
 			{
 				ActiveNode: &Node{
-					IPAddr: net.ParseIP("10.0.10.2"),
-					Port:   0,
-					Pgid:   2002,
-					Pname:  "gunicorn",
+					IPAddr:     net.ParseIP("10.0.10.2"),
+					Aggregated: true,
+					Pgid:       2002,
+					Pname:      "gunicorn",
 				},
 				PassiveNode: &Node{
 					IPAddr: net.ParseIP("10.0.10.3"),
@@ -417,10 +839,10 @@ func TestFindPassiveFlows(t *testing.T) {
 		"10.0.10.4-": []*Flow{
 			{
 				ActiveNode: &Node{
-					IPAddr: net.ParseIP("10.0.10.2"),
-					Port:   0,
-					Pgid:   2002,
-					Pname:  "gunicorn",
+					IPAddr:     net.ParseIP("10.0.10.2"),
+					Aggregated: true,
+					Pgid:       2002,
+					Pname:      "gunicorn",
 				},
 				PassiveNode: &Node{
 					IPAddr: net.ParseIP("10.0.10.4"),
@@ -432,10 +854,10 @@ func TestFindPassiveFlows(t *testing.T) {
 		"10.0.10.4-redis": []*Flow{
 			{
 				ActiveNode: &Node{
-					IPAddr: net.ParseIP("10.0.10.2"),
-					Port:   0,
-					Pgid:   2002,
-					Pname:  "gunicorn",
+					IPAddr:     net.ParseIP("10.0.10.2"),
+					Aggregated: true,
+					Pgid:       2002,
+					Pname:      "gunicorn",
 				},
 				PassiveNode: &Node{
 					IPAddr: net.ParseIP("10.0.10.4"),
@@ -463,43 +885,43 @@ func TestFindActiveFlows(t *testing.T) {
 		//                          |-> redis(10.0.10.4:6379)
 		{
 			Direction:   probe.FlowActive,
-			Local:       &probe.AddrPort{Addr: "10.0.10.1", Port: "many"},
-			Peer:        &probe.AddrPort{Addr: "10.0.10.2", Port: "80"},
+			Local:       &probe.AddrPort{Addr: "10.0.10.1", Aggregated: true},
+			Peer:        &probe.AddrPort{Addr: "10.0.10.2", Port: 80},
 			Process:     &probe.Process{Pgid: 1001, Name: "nginx"},
 			Connections: 100,
 		},
 		{
 			Direction:   probe.FlowPassive,
-			Local:       &probe.AddrPort{Addr: "10.0.10.2", Port: "8000"},
-			Peer:        &probe.AddrPort{Addr: "10.0.10.1", Port: "many"},
+			Local:       &probe.AddrPort{Addr: "10.0.10.2", Port: 8000},
+			Peer:        &probe.AddrPort{Addr: "10.0.10.1", Aggregated: true},
 			Process:     &probe.Process{Pgid: 2001, Name: "gunicorn"},
 			Connections: 10,
 		},
 		{
 			Direction:   probe.FlowActive,
-			Local:       &probe.AddrPort{Addr: "10.0.10.2", Port: "many"},
-			Peer:        &probe.AddrPort{Addr: "10.0.10.3", Port: "5432"},
+			Local:       &probe.AddrPort{Addr: "10.0.10.2", Aggregated: true},
+			Peer:        &probe.AddrPort{Addr: "10.0.10.3", Port: 5432},
 			Process:     &probe.Process{Pgid: 2001, Name: "gunicorn"},
 			Connections: 21,
 		},
 		{
 			Direction:   probe.FlowActive,
-			Local:       &probe.AddrPort{Addr: "10.0.10.2", Port: "many"},
-			Peer:        &probe.AddrPort{Addr: "10.0.10.4", Port: "6379"},
+			Local:       &probe.AddrPort{Addr: "10.0.10.2", Aggregated: true},
+			Peer:        &probe.AddrPort{Addr: "10.0.10.4", Port: 6379},
 			Process:     &probe.Process{Pgid: 2001, Name: "gunicorn"},
 			Connections: 14,
 		},
 		{
 			Direction:   probe.FlowPassive,
-			Local:       &probe.AddrPort{Addr: "10.0.10.3", Port: "5432"},
-			Peer:        &probe.AddrPort{Addr: "10.0.10.2", Port: "many"},
+			Local:       &probe.AddrPort{Addr: "10.0.10.3", Port: 5432},
+			Peer:        &probe.AddrPort{Addr: "10.0.10.2", Aggregated: true},
 			Process:     &probe.Process{Pgid: 3001, Name: "postgres"},
 			Connections: 20,
 		},
 		{
 			Direction:   probe.FlowPassive,
-			Local:       &probe.AddrPort{Addr: "10.0.10.4", Port: "6379"},
-			Peer:        &probe.AddrPort{Addr: "10.0.10.2", Port: "many"},
+			Local:       &probe.AddrPort{Addr: "10.0.10.4", Port: 6379},
+			Peer:        &probe.AddrPort{Addr: "10.0.10.2", Aggregated: true},
 			Process:     &probe.Process{Pgid: 4001, Name: "redis"},
 			Connections: 19,
 		},
@@ -525,8 +947,8 @@ func TestFindActiveFlows(t *testing.T) {
 		"10.0.10.1-": []*Flow{
 			{
 				ActiveNode: &Node{
-					IPAddr: net.ParseIP("10.0.10.1"),
-					Port:   0,
+					IPAddr:     net.ParseIP("10.0.10.1"),
+					Aggregated: true,
 				},
 				PassiveNode: &Node{
 					IPAddr: net.ParseIP("10.0.10.2"),
@@ -540,10 +962,10 @@ func TestFindActiveFlows(t *testing.T) {
 		"10.0.10.1-nginx": []*Flow{
 			{
 				ActiveNode: &Node{
-					IPAddr: net.ParseIP("10.0.10.1"),
-					Port:   0,
-					Pgid:   1001,
-					Pname:  "nginx",
+					IPAddr:     net.ParseIP("10.0.10.1"),
+					Aggregated: true,
+					Pgid:       1001,
+					Pname:      "nginx",
 				},
 				PassiveNode: &Node{
 					IPAddr: net.ParseIP("10.0.10.2"),
@@ -555,10 +977,10 @@ func TestFindActiveFlows(t *testing.T) {
 		"10.0.10.2-gunicorn": []*Flow{
 			{
 				ActiveNode: &Node{
-					IPAddr: net.ParseIP("10.0.10.2"),
-					Port:   0,
-					Pgid:   2001,
-					Pname:  "gunicorn",
+					IPAddr:     net.ParseIP("10.0.10.2"),
+					Aggregated: true,
+					Pgid:       2001,
+					Pname:      "gunicorn",
 				},
 				PassiveNode: &Node{
 					IPAddr: net.ParseIP("10.0.10.3"),
@@ -0,0 +1,28 @@
+// Package dbtest provides the flows of the CMDB for the tests of the
+// packages reading them.
+package dbtest
+
+import (
+	"net"
+
+	"github.com/yuuki/shawk/db"
+)
+
+// Flow returns the flow of the connections from the process of cpname on
+// the client to the process of spname listening on the port of the server.
+// labels are the pairs of the keys and the values of the labels of the
+// server.
+func Flow(client, cpname, server, spname string, port uint16, connections int, labels ...string) *db.Flow {
+	f := &db.Flow{
+		ActiveNode:  &db.Node{IPAddr: net.ParseIP(client), Aggregated: true, Pname: cpname},
+		PassiveNode: &db.Node{IPAddr: net.ParseIP(server), Port: port, Pname: spname},
+		Connections: connections,
+	}
+	for i := 0; i+1 < len(labels); i += 2 {
+		if f.PassiveNode.Labels == nil {
+			f.PassiveNode.Labels = map[string]string{}
+		}
+		f.PassiveNode.Labels[labels[i]] = labels[i+1]
+	}
+	return f
+}
@@ -0,0 +1,139 @@
+package db
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+	"golang.org/x/xerrors"
+)
+
+const (
+	// findDeletedProcessesSQL locks the processes in the prefixes of $1 of
+	// the tenant of $2, or of all the tenants if $2 is NULL, with the
+	// numbers of their flows.
+	findDeletedProcessesSQL = `
+		SELECT
+			processes.process_id,
+			processes.ipv4,
+			processes.vrf,
+			processes.pname,
+			processes.pgid,
+			(
+				SELECT count(*) FROM flows
+				WHERE flows.source_node_id IN (
+					SELECT node_id FROM active_nodes WHERE active_nodes.process_id = processes.process_id
+				) OR flows.destination_node_id IN (
+					SELECT node_id FROM passive_nodes WHERE passive_nodes.process_id = processes.process_id
+				)
+			)
+		FROM processes
+		WHERE processes.ipv4 <<= ANY($1::inet[]) AND ($2::varchar IS NULL OR processes.tenant = $2)
+		ORDER BY processes.ipv4, processes.vrf, processes.pname, processes.pgid
+		FOR UPDATE OF processes
+	`
+
+	// deleteProcessesSQL deletes the processes of $1, whose nodes and
+	// flows are deleted by the cascade.
+	deleteProcessesSQL = `
+		DELETE FROM processes WHERE process_id = ANY($1::bigint[])
+	`
+)
+
+// DeletedProcess is a process deleted with its nodes and flows.
+type DeletedProcess struct {
+	// Addr is the address of the process qualified by its VRF.
+	Addr  string
+	Pname string
+	Pgid  int
+	// Flows is the number of the flows from or to the process.
+	Flows int64
+}
+
+// Deleter is the CMDB deleting the processes of the decommissioned hosts.
+type Deleter interface {
+	DeleteProcesses(prefixes []*net.IPNet, dryRun bool) ([]*DeletedProcess, error)
+}
+
+var (
+	_ Deleter = (*DB)(nil)
+	_ Deleter = (*Shards)(nil)
+)
+
+// prefixesArg returns the prefixes as the text of an array of inet for the
+// simple protocol, as addrsArg.
+func prefixesArg(prefixes []*net.IPNet) string {
+	ss := make([]string, 0, len(prefixes))
+	for _, p := range prefixes {
+		ss = append(ss, p.String())
+	}
+	return "{" + strings.Join(ss, ",") + "}"
+}
+
+// idsArg returns the IDs as the text of an array of bigint for the simple
+// protocol.
+func idsArg(ids []int64) string {
+	ss := make([]string, 0, len(ids))
+	for _, id := range ids {
+		ss = append(ss, strconv.FormatInt(id, 10))
+	}
+	return "{" + strings.Join(ss, ",") + "}"
+}
+
+// DeleteProcesses deletes the processes in the prefixes with their nodes and
+// flows in a transaction, and returns them. It only returns them if dryRun.
+// The processes are of the tenant, or of all the tenants in the admin mode.
+func (db *DB) DeleteProcesses(prefixes []*net.IPNet, dryRun bool) ([]*DeletedProcess, error) {
+	var deleted []*DeletedProcess
+	err := db.retry("delete processes", &db.Conn, func(conn *pgx.Conn) error {
+		deleted = nil
+		ctx, cancel := context.WithTimeout(context.Background(), InsertOrUpdateTimeoutSec*time.Second)
+		defer cancel()
+
+		tx, err := conn.Begin(ctx)
+		if err != nil {
+			return xerrors.Errorf("begin transaction error: %w", err)
+		}
+		defer tx.Rollback(ctx)
+
+		rows, err := tx.Query(ctx, findDeletedProcessesSQL, prefixesArg(prefixes), db.tenantScope())
+		if err != nil {
+			return xerrors.Errorf("find deleted processes error: %w", err)
+		}
+		var ids []int64
+		for rows.Next() {
+			var (
+				id  int64
+				ip  net.IP
+				vrf string
+				p   DeletedProcess
+			)
+			if err := rows.Scan(&id, &ip, &vrf, &p.Pname, &p.Pgid, &p.Flows); err != nil {
+				rows.Close()
+				return xerrors.Errorf("scan deleted processes error: %w", err)
+			}
+			p.Addr = vrfAddr(ip, vrf)
+			ids = append(ids, id)
+			deleted = append(deleted, &p)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return xerrors.Errorf("find deleted processes error: %w", err)
+		}
+		if dryRun || len(ids) == 0 {
+			return nil
+		}
+
+		if _, err := tx.Exec(ctx, deleteProcessesSQL, idsArg(ids)); err != nil {
+			return xerrors.Errorf("delete processes error: %w", err)
+		}
+		if err := tx.Commit(ctx); err != nil {
+			return xerrors.Errorf("commit error: %w", err)
+		}
+		return nil
+	})
+	return deleted, err
+}
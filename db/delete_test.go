@@ -0,0 +1,64 @@
+package db
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/yuuki/shawk/probe"
+)
+
+func TestDeleteProcesses(t *testing.T) {
+	db, teardown := setupTestCase(t)
+	defer teardown(t)
+
+	flows := []*probe.HostFlow{
+		{
+			Direction: probe.FlowActive, Process: &probe.Process{Name: "app", Pgid: 100}, Connections: 2,
+			Local: &probe.AddrPort{Addr: "10.0.1.5", Aggregated: true},
+			Peer:  &probe.AddrPort{Addr: "10.0.2.1", Port: 5432},
+		},
+		{
+			Direction: probe.FlowActive, Process: &probe.Process{Name: "app", Pgid: 200}, Connections: 1,
+			Local: &probe.AddrPort{Addr: "10.0.3.1", Aggregated: true},
+			Peer:  &probe.AddrPort{Addr: "10.0.2.1", Port: 5432},
+		},
+	}
+	if err := db.InsertOrUpdateHostFlows(flows); err != nil {
+		t.Fatalf("%+v", err)
+	}
+
+	_, prefix, _ := net.ParseCIDR("10.0.1.0/24")
+	want := []*DeletedProcess{{Addr: "10.0.1.5", Pname: "app", Pgid: 100, Flows: 1}}
+
+	deleted, err := db.DeleteProcesses([]*net.IPNet{prefix}, true)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if diff := cmp.Diff(want, deleted); diff != "" {
+		t.Errorf("DeleteProcesses() mismatch (-want +got):\n%s", diff)
+	}
+	countFlows := func() int {
+		var n int
+		if err := db.QueryRow(context.Background(), "SELECT count(*) FROM flows").Scan(&n); err != nil {
+			t.Fatalf("%+v", err)
+		}
+		return n
+	}
+	if n := countFlows(); n != 2 {
+		t.Errorf("the dry run should keep the 2 flows, but %d", n)
+	}
+
+	deleted, err = db.DeleteProcesses([]*net.IPNet{prefix}, false)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if diff := cmp.Diff(want, deleted); diff != "" {
+		t.Errorf("DeleteProcesses() mismatch (-want +got):\n%s", diff)
+	}
+	if n := countFlows(); n != 1 {
+		t.Errorf("the flow of the deleted process should be deleted, but %d flows", n)
+	}
+}
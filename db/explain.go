@@ -0,0 +1,135 @@
+package db
+
+import (
+	"context"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+	"golang.org/x/xerrors"
+)
+
+// ExplainCond represents the parameters of the queries of Explain.
+type ExplainCond struct {
+	// Addr and Port are of the server the queries look up. The server of
+	// the latest flow is looked up if Addr is nil.
+	Addr net.IP
+	Port uint16
+	// Since and Until are the range of the update of the flows.
+	Since time.Time
+	Until time.Time
+	// Analyze runs the queries to show the actual times and rows.
+	Analyze bool
+}
+
+// Plan is the plan of a query.
+type Plan struct {
+	// Name is the name of the query such as 'find passive flows'.
+	Name string
+	// Lines are the lines of the plan in the text format of EXPLAIN.
+	Lines []string
+}
+
+type explainQuery struct {
+	name string
+	sql  string
+	args func(cond *ExplainCond, db *DB) []interface{}
+}
+
+// explainQueries are the main queries: the lookups of the dependencies and
+// the dependents, the page of all the flows, and the lookups of the nodes
+// by the writes of the agents, in the tenant of db.
+var explainQueries = []explainQuery{
+	{"find passive flows", findPassiveFlowsSQL, func(c *ExplainCond, db *DB) []interface{} {
+		return []interface{}{addrsArg([]net.IP{c.Addr}), c.Since, c.Until, db.tenantScope(), nil, 0, 0}
+	}},
+	{"find active flows", findActiveFlowsSQL, func(c *ExplainCond, db *DB) []interface{} {
+		return []interface{}{addrsArg([]net.IP{c.Addr}), c.Since, c.Until, db.tenantScope(), nil, 0, 0}
+	}},
+	{"list flows", listFlowsSQL, func(c *ExplainCond, db *DB) []interface{} {
+		return []interface{}{0, c.Since, c.Until, DefaultListLimit, db.tenantScope()}
+	}},
+	{"find passive nodes", findPassiveNodesSQL, func(c *ExplainCond, db *DB) []interface{} {
+		return []interface{}{c.Addr.String(), c.Port, db.tenant, ""}
+	}},
+	{"find active nodes", findActiveNodesSQL, func(c *ExplainCond, db *DB) []interface{} {
+		return []interface{}{c.Port, c.Addr.String(), db.tenant, ""}
+	}},
+}
+
+// Explain returns the plans of the main queries for cond, so that the
+// operators see whether the queries use the indexes on their CMDB.
+func (db *DB) Explain(ctx context.Context, cond *ExplainCond) ([]*Plan, error) {
+	db.connMu.Lock()
+	defer db.connMu.Unlock()
+	c := *cond
+	if c.Until.IsZero() {
+		c.Until = time.Now()
+	}
+	if c.Addr == nil {
+		var addr string
+		err := db.QueryRow(ctx, `
+			SELECT host(processes.ipv4), passive_nodes.port FROM flows
+			INNER JOIN passive_nodes ON passive_nodes.node_id = flows.destination_node_id
+			INNER JOIN processes ON processes.process_id = passive_nodes.process_id
+			WHERE ($1::varchar IS NULL OR flows.tenant = $1)
+			ORDER BY flows.updated DESC LIMIT 1
+		`, db.tenantScope()).Scan(&addr, &c.Port)
+		switch {
+		case err == pgx.ErrNoRows:
+			return nil, xerrors.New("the CMDB has no flows to look up: specify the address and the port")
+		case err != nil:
+			return nil, xerrors.Errorf("query the latest flow error: %w", err)
+		}
+		c.Addr = net.ParseIP(addr)
+	}
+
+	explain := "EXPLAIN "
+	if c.Analyze {
+		explain = "EXPLAIN (ANALYZE, BUFFERS) "
+	}
+	plans := make([]*Plan, 0, len(explainQueries))
+	for _, q := range explainQueries {
+		lines, err := explainLines(ctx, db.Conn, explain+q.sql, q.args(&c, db)...)
+		if err != nil {
+			return nil, xerrors.Errorf("explain %s error: %w", q.name, err)
+		}
+		plans = append(plans, &Plan{Name: q.name, Lines: lines})
+	}
+	return plans, nil
+}
+
+func explainLines(ctx context.Context, conn *pgx.Conn, sql string, args ...interface{}) ([]string, error) {
+	rows, err := conn.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var lines []string
+	for rows.Next() {
+		var line string
+		if err := rows.Scan(&line); err != nil {
+			return nil, err
+		}
+		lines = append(lines, line)
+	}
+	return lines, rows.Err()
+}
+
+// SeqScans returns the tables the plan scans sequentially, which are missing
+// or unused indexes unless the tables are small.
+func (p *Plan) SeqScans() []string {
+	var tables []string
+	for _, line := range p.Lines {
+		i := strings.Index(line, "Seq Scan on ")
+		if i < 0 {
+			continue
+		}
+		fields := strings.Fields(line[i+len("Seq Scan on "):])
+		if len(fields) > 0 {
+			tables = append(tables, fields[0])
+		}
+	}
+	return tables
+}
@@ -0,0 +1,56 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/yuuki/shawk/probe"
+)
+
+func TestPlan_SeqScans(t *testing.T) {
+	p := &Plan{Lines: []string{
+		"Hash Join  (cost=1.09..2.18 rows=1 width=8)",
+		"  ->  Seq Scan on passive_nodes  (cost=0.00..1.04 rows=1 width=12)",
+		"  ->  Index Scan using processes_ipv4_pgid_pname_key on processes  (cost=0.15..8.17 rows=1 width=8)",
+		"        ->  Parallel Seq Scan on flows  (cost=0.00..1.01 rows=1 width=16)",
+	}}
+	if diff := cmp.Diff([]string{"passive_nodes", "flows"}, p.SeqScans()); diff != "" {
+		t.Errorf("SeqScans() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestExplain(t *testing.T) {
+	db, teardown := setupTestCase(t)
+	defer teardown(t)
+
+	if _, err := db.Explain(context.Background(), &ExplainCond{}); err == nil {
+		t.Error("Explain() should return an error without flows to look up")
+	}
+
+	input := []*probe.HostFlow{
+		{
+			Direction:   probe.FlowActive,
+			Local:       &probe.AddrPort{Addr: "10.0.10.1", Aggregated: true},
+			Peer:        &probe.AddrPort{Addr: "10.0.10.2", Port: 80},
+			Process:     &probe.Process{Pgid: 1001, Name: "haproxy"},
+			Connections: 10,
+		},
+	}
+	if err := db.InsertOrUpdateHostFlows(input); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	plans, err := db.Explain(context.Background(), &ExplainCond{Analyze: true})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if len(plans) != len(explainQueries) {
+		t.Fatalf("Explain() should return %d plans, but %d", len(explainQueries), len(plans))
+	}
+	for _, p := range plans {
+		if len(p.Lines) == 0 {
+			t.Errorf("the plan of %s should not be empty", p.Name)
+		}
+	}
+}
@@ -0,0 +1,219 @@
+package db
+
+import (
+	"context"
+	"net"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+	"golang.org/x/xerrors"
+
+	"github.com/yuuki/shawk/probe"
+)
+
+// DefaultListLimit is the number of the flows of a page of ListFlows when
+// the limit is not given.
+const DefaultListLimit = 1000
+
+// ListFlowsCond represents a query condition for ListFlows. The flows are
+// paginated by their IDs instead of an offset, so that the pages are found
+// by the primary key however many flows precede them.
+type ListFlowsCond struct {
+	// After is the ID of the last flow of the previous page, or zero for
+	// the first page.
+	After int64
+	// Limit is the maximum number of the flows of the page. Zero is
+	// DefaultListLimit.
+	Limit int
+	Since time.Time
+	Until time.Time
+}
+
+// listFlowsSQL lists the flows after the ID of $1 updated between $2 and $3
+// up to $4 of the tenant of $5, or of all the tenants if $5 is NULL.
+const listFlowsSQL = `
+	SELECT
+		flows.flow_id,
+		active_processes.ipv4 AS aipv4,
+		active_processes.vrf AS avrf,
+		active_processes.pname AS apname,
+		active_processes.pgid AS apgid,
+		active_processes.labels AS alabels,
+		active_processes.owners AS aowners,
+		passive_processes.ipv4 AS pipv4,
+		passive_processes.vrf AS pvrf,
+		passive_processes.pname AS ppname,
+		passive_nodes.port AS pport,
+		passive_processes.pgid AS ppgid,
+		passive_processes.labels AS plabels,
+		passive_processes.owners AS powners,
+		passive_nodes.accept_queue AS paccept,
+		passive_nodes.backlog AS pbacklog,
+		connections,
+		flows.recv_queue,
+		flows.send_queue,
+		flows.client_cong,
+		flows.server_cong,
+		flows.age_min,
+		flows.age_avg,
+		flows.age_max,
+		flows.subflows,
+		COALESCE(flow_annotations.note, '') AS annotation,
+		flows.updated,
+		flows.tenant
+	FROM flows
+	INNER JOIN active_nodes ON active_nodes.node_id = flows.source_node_id
+	INNER JOIN processes AS active_processes ON active_nodes.process_id = active_processes.process_id
+	INNER JOIN passive_nodes ON passive_nodes.node_id = flows.destination_node_id
+	INNER JOIN processes AS passive_processes ON passive_nodes.process_id = passive_processes.process_id
+	LEFT JOIN flow_annotations ON flow_annotations.tenant = flows.tenant
+		AND flow_annotations.client = active_processes.ipv4
+		AND flow_annotations.server = passive_processes.ipv4
+		AND flow_annotations.port = passive_nodes.port
+		AND flow_annotations.vrf = passive_processes.vrf
+	WHERE flows.flow_id > $1 AND flows.updated BETWEEN $2 AND $3 AND ($5::varchar IS NULL OR flows.tenant = $5)
+	ORDER BY flows.flow_id
+	LIMIT $4
+`
+
+// ListFlows queries a page of the flows updated between cond.Since and
+// cond.Until, ordered by their IDs.
+func (db *DB) ListFlows(cond *ListFlowsCond) ([]*Flow, error) {
+	var flows []*Flow
+	err := db.retry("list flows", &db.Conn, func(conn *pgx.Conn) error {
+		var err error
+		flows, err = listFlows(conn, cond, db.tenantScope())
+		return err
+	})
+	return flows, err
+}
+
+func listFlows(conn *pgx.Conn, cond *ListFlowsCond, tenant interface{}) ([]*Flow, error) {
+	limit := cond.Limit
+	if limit <= 0 {
+		limit = DefaultListLimit
+	}
+	until := cond.Until
+	if until.IsZero() {
+		until = time.Now()
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	rows, err := conn.Query(ctx, listFlowsSQL, cond.After, cond.Since, until, limit, tenant)
+	if err != nil {
+		return nil, xerrors.Errorf("list flows query error: %w", err)
+	}
+	defer rows.Close()
+
+	flows := make([]*Flow, 0, limit)
+	for rows.Next() {
+		var (
+			id          int64
+			aipv4       net.IP
+			avrf        string
+			apname      string
+			apgid       int
+			alabels     map[string]string
+			aowners     []*probe.Process
+			pipv4       net.IP
+			pvrf        string
+			ppname      string
+			pport       uint16
+			ppgid       int
+			plabels     map[string]string
+			powners     []*probe.Process
+			paccept     int
+			pbacklog    int
+			connections int
+			recvQueue   int
+			sendQueue   int
+			clientCong  string
+			serverCong  string
+			ageMin      *int64
+			ageAvg      *int64
+			ageMax      *int64
+			subflows    int
+			annotation  string
+			updated     time.Time
+			ftenant     string
+		)
+		if err := rows.Scan(
+			&id, &aipv4, &avrf, &apname, &apgid, &alabels, &aowners,
+			&pipv4, &pvrf, &ppname, &pport, &ppgid, &plabels, &powners, &paccept, &pbacklog,
+			&connections, &recvQueue, &sendQueue, &clientCong, &serverCong, &ageMin, &ageAvg, &ageMax, &subflows, &annotation, &updated, &ftenant,
+		); err != nil {
+			return nil, xerrors.Errorf("rows scan error: %w", err)
+		}
+		flows = append(flows, &Flow{
+			ID:     id,
+			Tenant: ftenant,
+			ActiveNode: &Node{
+				IPAddr:     aipv4,
+				VRF:        avrf,
+				Aggregated: true,
+				Pgid:       apgid,
+				Pname:      apname,
+				Labels:     nilIfEmpty(alabels),
+				Owners:     nilIfNoOwners(aowners),
+			},
+			PassiveNode: &Node{
+				IPAddr:      pipv4,
+				VRF:         pvrf,
+				Port:        pport,
+				Pgid:        ppgid,
+				Pname:       ppname,
+				Labels:      nilIfEmpty(plabels),
+				Owners:      nilIfNoOwners(powners),
+				AcceptQueue: paccept,
+				Backlog:     pbacklog,
+			},
+			Connections: connections,
+			RecvQueue:   recvQueue,
+			SendQueue:   sendQueue,
+			ClientCong:  clientCong,
+			ServerCong:  serverCong,
+			Age:         ageOf(ageMin, ageAvg, ageMax),
+			Subflows:    subflows,
+			Annotation:  annotation,
+			Updated:     updated,
+		})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, xerrors.Errorf("rows error: %w", err)
+	}
+	return flows, nil
+}
+
+// EachFlow calls fn with each flow of cond in the order of the IDs, listing
+// them page by page from cond.After, so that only a page of the flows is
+// in memory at a time. It stops at the first error of fn or ctx.
+func EachFlow(ctx context.Context, s Store, cond *ListFlowsCond, fn func(*Flow) error) error {
+	page := *cond
+	if page.Limit <= 0 {
+		page.Limit = DefaultListLimit
+	}
+	if page.Until.IsZero() {
+		// Fix the range so that the pages are of the same snapshot of time.
+		page.Until = time.Now()
+	}
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		flows, err := s.ListFlows(&page)
+		if err != nil {
+			return err
+		}
+		for _, f := range flows {
+			if err := fn(f); err != nil {
+				return err
+			}
+		}
+		if len(flows) < page.Limit {
+			return nil
+		}
+		page.After = flows[len(flows)-1].ID
+	}
+}
@@ -0,0 +1,132 @@
+package db
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"golang.org/x/xerrors"
+
+	"github.com/yuuki/shawk/probe"
+)
+
+func TestListFlows(t *testing.T) {
+	db, teardown := setupTestCase(t)
+	defer teardown(t)
+
+	input := []*probe.HostFlow{
+		{
+			Direction:   probe.FlowActive,
+			Local:       &probe.AddrPort{Addr: "10.0.10.1", Aggregated: true},
+			Peer:        &probe.AddrPort{Addr: "10.0.10.2", Port: 80},
+			Process:     &probe.Process{Pgid: 1001, Name: "haproxy"},
+			Connections: 100,
+		},
+		{
+			Direction:   probe.FlowActive,
+			Local:       &probe.AddrPort{Addr: "10.0.10.2", Aggregated: true},
+			Peer:        &probe.AddrPort{Addr: "10.0.10.3", Port: 5432},
+			Process:     &probe.Process{Pgid: 2002, Name: "gunicorn"},
+			Connections: 21,
+		},
+		{
+			Direction:   probe.FlowActive,
+			Local:       &probe.AddrPort{Addr: "10.0.10.2", Aggregated: true},
+			Peer:        &probe.AddrPort{Addr: "10.0.10.4", Port: 6379},
+			Process:     &probe.Process{Pgid: 2002, Name: "gunicorn"},
+			Connections: 14,
+		},
+	}
+	if err := db.InsertOrUpdateHostFlows(input); err != nil {
+		t.Fatal(err)
+	}
+
+	first, err := db.ListFlows(&ListFlowsCond{Limit: 2})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if len(first) != 2 {
+		t.Fatalf("ListFlows() should return 2 flows, but %d", len(first))
+	}
+	rest, err := db.ListFlows(&ListFlowsCond{After: first[1].ID, Limit: 2})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+
+	var got []string
+	for _, f := range append(first, rest...) {
+		got = append(got, f.ActiveNode.String()+" -> "+f.PassiveNode.String())
+	}
+	want := []string{
+		"10.0.10.1:many ('haproxy', pgid=1001) -> 10.0.10.2:80 ('', pgid=0)",
+		"10.0.10.2:many ('gunicorn', pgid=2002) -> 10.0.10.3:5432 ('', pgid=0)",
+		"10.0.10.2:many ('gunicorn', pgid=2002) -> 10.0.10.4:6379 ('', pgid=0)",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ListFlows() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+// pageStore lists its flows, recording the conditions of the pages.
+type pageStore struct {
+	nopStore
+	flows []*Flow
+	conds []ListFlowsCond
+}
+
+func (s *pageStore) ListFlows(cond *ListFlowsCond) ([]*Flow, error) {
+	s.conds = append(s.conds, *cond)
+	var page []*Flow
+	for _, f := range s.flows {
+		if f.ID > cond.After && len(page) < cond.Limit {
+			page = append(page, f)
+		}
+	}
+	return page, nil
+}
+
+func TestEachFlow(t *testing.T) {
+	s := &pageStore{}
+	for id := int64(1); id <= 5; id++ {
+		s.flows = append(s.flows, &Flow{
+			ID:          id,
+			ActiveNode:  &Node{IPAddr: net.ParseIP("10.0.0.1"), Aggregated: true},
+			PassiveNode: &Node{IPAddr: net.ParseIP("10.0.0.2"), Port: 80},
+		})
+	}
+
+	var got []int64
+	err := EachFlow(context.Background(), s, &ListFlowsCond{Limit: 2}, func(f *Flow) error {
+		got = append(got, f.ID)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("EachFlow() should not return an error: %v", err)
+	}
+	if diff := cmp.Diff([]int64{1, 2, 3, 4, 5}, got); diff != "" {
+		t.Errorf("EachFlow() mismatch (-want +got):\n%s", diff)
+	}
+	var afters []int64
+	for _, c := range s.conds {
+		afters = append(afters, c.After)
+		if c.Until != s.conds[0].Until || c.Until.IsZero() {
+			t.Errorf("the pages should be listed until the same time, but %s", c.Until)
+		}
+	}
+	if diff := cmp.Diff([]int64{0, 2, 4}, afters); diff != "" {
+		t.Errorf("the cursors mismatch (-want +got):\n%s", diff)
+	}
+
+	errStop := xerrors.New("stop")
+	err = EachFlow(context.Background(), s, &ListFlowsCond{}, func(f *Flow) error { return errStop })
+	if !xerrors.Is(err, errStop) {
+		t.Errorf("EachFlow() should return the error of fn, but %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := EachFlow(ctx, s, &ListFlowsCond{}, func(f *Flow) error { return nil }); !xerrors.Is(err, context.Canceled) {
+		t.Errorf("EachFlow() should return the error of ctx, but %v", err)
+	}
+}
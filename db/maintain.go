@@ -0,0 +1,168 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"golang.org/x/xerrors"
+)
+
+// MaintainOption represents the operations of Maintain.
+type MaintainOption struct {
+	// Vacuum reclaims the dead rows of the updated and deleted flows.
+	Vacuum bool
+	// Analyze updates the statistics of the planner.
+	Analyze bool
+	// Reindex rebuilds the bloated indexes, which blocks the writes to the
+	// table while rebuilding.
+	Reindex bool
+}
+
+// TableStats is the statistics of a table.
+type TableStats struct {
+	Name      string
+	LiveRows  int64
+	DeadRows  int64
+	TotalSize int64 // bytes including the indexes and TOAST
+	// LastVacuum and LastAnalyze are the last times of the manual or the
+	// automatic runs, which are zero if never run.
+	LastVacuum  time.Time
+	LastAnalyze time.Time
+}
+
+// DeadRatio returns the ratio of the dead rows, which estimates the bloat.
+func (s *TableStats) DeadRatio() float64 {
+	if s.LiveRows+s.DeadRows == 0 {
+		return 0
+	}
+	return float64(s.DeadRows) / float64(s.LiveRows+s.DeadRows)
+}
+
+// IndexStats is the statistics of an index.
+type IndexStats struct {
+	Table string
+	Name  string
+	// Scans is the number of the scans since the statistics are reset.
+	Scans int64
+	Size  int64 // bytes
+}
+
+// graphTables returns the names of the tables of the graph.
+func graphTables() []string {
+	names := make([]string, 0, len(snapshotTables))
+	for _, t := range snapshotTables {
+		names = append(names, t.name)
+	}
+	return names
+}
+
+// Maintain runs the operations of opt on the tables of the graph on a
+// connection of its own, since VACUUM can't run in a transaction. done is
+// called after each operation on a table if not nil.
+func (db *DB) Maintain(ctx context.Context, opt *MaintainOption, done func(op, table string, elapsed time.Duration)) error {
+	conn, err := connect(ctx, db.conf)
+	if err != nil {
+		return xerrors.Errorf("could not connect to postgres: %w", err)
+	}
+	defer conn.Close(context.Background())
+
+	var ops []string
+	switch {
+	case opt.Vacuum && opt.Analyze:
+		ops = append(ops, "VACUUM (ANALYZE)")
+	case opt.Vacuum:
+		ops = append(ops, "VACUUM")
+	case opt.Analyze:
+		ops = append(ops, "ANALYZE")
+	}
+	if opt.Reindex {
+		ops = append(ops, "REINDEX TABLE")
+	}
+	for _, op := range ops {
+		for _, table := range graphTables() {
+			start := time.Now()
+			if _, err := conn.Exec(ctx, op+" "+table); err != nil {
+				return xerrors.Errorf("%s %s error: %w", op, table, err)
+			}
+			if done != nil {
+				done(op, table, time.Since(start))
+			}
+		}
+	}
+	return nil
+}
+
+// FindTableStats returns the statistics of the tables of the graph.
+func (db *DB) FindTableStats(ctx context.Context) ([]*TableStats, error) {
+	db.connMu.Lock()
+	defer db.connMu.Unlock()
+	rows, err := db.Query(ctx, `
+		SELECT
+			relname,
+			n_live_tup,
+			n_dead_tup,
+			pg_total_relation_size(relid),
+			GREATEST(last_vacuum, last_autovacuum),
+			GREATEST(last_analyze, last_autoanalyze)
+		FROM pg_stat_user_tables
+		WHERE schemaname = current_schema() AND relname = ANY($1)
+		ORDER BY relname
+	`, graphTables())
+	if err != nil {
+		return nil, xerrors.Errorf("query table stats error: %w", err)
+	}
+	defer rows.Close()
+
+	var stats []*TableStats
+	for rows.Next() {
+		var (
+			s                       TableStats
+			lastVacuum, lastAnalyze *time.Time
+		)
+		if err := rows.Scan(&s.Name, &s.LiveRows, &s.DeadRows, &s.TotalSize, &lastVacuum, &lastAnalyze); err != nil {
+			return nil, xerrors.Errorf("scan table stats error: %w", err)
+		}
+		if lastVacuum != nil {
+			s.LastVacuum = *lastVacuum
+		}
+		if lastAnalyze != nil {
+			s.LastAnalyze = *lastAnalyze
+		}
+		stats = append(stats, &s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, xerrors.Errorf("query table stats error: %w", err)
+	}
+	return stats, nil
+}
+
+// FindIndexStats returns the statistics of the indexes of the tables of the
+// graph in ascending order of the scans, so that the unused indexes come
+// first.
+func (db *DB) FindIndexStats(ctx context.Context) ([]*IndexStats, error) {
+	db.connMu.Lock()
+	defer db.connMu.Unlock()
+	rows, err := db.Query(ctx, `
+		SELECT relname, indexrelname, idx_scan, pg_relation_size(indexrelid)
+		FROM pg_stat_user_indexes
+		WHERE schemaname = current_schema() AND relname = ANY($1)
+		ORDER BY idx_scan, relname, indexrelname
+	`, graphTables())
+	if err != nil {
+		return nil, xerrors.Errorf("query index stats error: %w", err)
+	}
+	defer rows.Close()
+
+	var stats []*IndexStats
+	for rows.Next() {
+		var s IndexStats
+		if err := rows.Scan(&s.Table, &s.Name, &s.Scans, &s.Size); err != nil {
+			return nil, xerrors.Errorf("scan index stats error: %w", err)
+		}
+		stats = append(stats, &s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, xerrors.Errorf("query index stats error: %w", err)
+	}
+	return stats, nil
+}
@@ -0,0 +1,75 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/yuuki/shawk/probe"
+)
+
+func TestTableStats_DeadRatio(t *testing.T) {
+	tests := []struct {
+		stats TableStats
+		want  float64
+	}{
+		{TableStats{}, 0},
+		{TableStats{LiveRows: 80, DeadRows: 20}, 0.2},
+		{TableStats{DeadRows: 5}, 1},
+	}
+	for _, tt := range tests {
+		if got := tt.stats.DeadRatio(); got != tt.want {
+			t.Errorf("DeadRatio() of %+v = %v, want %v", tt.stats, got, tt.want)
+		}
+	}
+}
+
+func TestMaintain(t *testing.T) {
+	db, teardown := setupTestCase(t)
+	defer teardown(t)
+
+	input := []*probe.HostFlow{
+		{
+			Direction:   probe.FlowActive,
+			Local:       &probe.AddrPort{Addr: "10.0.10.1", Aggregated: true},
+			Peer:        &probe.AddrPort{Addr: "10.0.10.2", Port: 80},
+			Process:     &probe.Process{Pgid: 1001, Name: "haproxy"},
+			Connections: 10,
+		},
+	}
+	if err := db.InsertOrUpdateHostFlows(input); err != nil {
+		t.Fatalf("%+v", err)
+	}
+
+	var ops []string
+	opt := &MaintainOption{Vacuum: true, Analyze: true, Reindex: true}
+	err := db.Maintain(context.Background(), opt, func(op, table string, elapsed time.Duration) {
+		ops = append(ops, op+" "+table)
+	})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if len(ops) != 2*len(snapshotTables) {
+		t.Errorf("Maintain() should run 2 operations on %d tables, but %v", len(snapshotTables), ops)
+	}
+
+	tables, err := db.FindTableStats(context.Background())
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if len(tables) != len(snapshotTables) {
+		t.Fatalf("FindTableStats() should return %d tables, but %d", len(snapshotTables), len(tables))
+	}
+	for _, s := range tables {
+		if s.LastVacuum.IsZero() || s.LastAnalyze.IsZero() {
+			t.Errorf("%s should be vacuumed and analyzed: %+v", s.Name, s)
+		}
+	}
+	indexes, err := db.FindIndexStats(context.Background())
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if len(indexes) == 0 {
+		t.Error("FindIndexStats() should return the indexes")
+	}
+}
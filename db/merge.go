@@ -0,0 +1,339 @@
+package db
+
+import (
+	"context"
+	"net"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+	"golang.org/x/xerrors"
+)
+
+const (
+	findMergedProcessesSQL = `
+		SELECT process_id, vrf, pgid, pname FROM processes
+		WHERE ipv4 = $1 AND tenant = $2
+		ORDER BY process_id
+		FOR UPDATE
+	`
+
+	findMergingProcessSQL = `
+		SELECT process_id FROM processes
+		WHERE ipv4 = $1 AND tenant = $2 AND vrf = $3 AND pgid = $4 AND pname = $5
+		FOR UPDATE
+	`
+
+	// moveProcessSQL moves the process of $1 to the address of $2 with its
+	// nodes and flows.
+	moveProcessSQL = `
+		UPDATE processes SET ipv4 = $2, updated = CURRENT_TIMESTAMP WHERE process_id = $1
+	`
+
+	countProcessFlowsSQL = `
+		SELECT count(*) FROM flows
+		WHERE source_node_id IN (SELECT node_id FROM active_nodes WHERE process_id = $1)
+		OR destination_node_id IN (SELECT node_id FROM passive_nodes WHERE process_id = $1)
+	`
+
+	findActiveNodeSQL = `
+		SELECT node_id FROM active_nodes WHERE process_id = $1
+	`
+
+	findProcessPassiveNodesSQL = `
+		SELECT node_id, port FROM passive_nodes WHERE process_id = $1 ORDER BY port
+	`
+
+	deleteProcessSQL = `
+		DELETE FROM processes WHERE process_id = $1
+	`
+
+	// deleteNodeAliasSQL deletes the alias of $2 in the tenant of $1, which
+	// is an address merged into again.
+	deleteNodeAliasSQL = `
+		DELETE FROM node_aliases WHERE tenant = $1 AND alias = $2
+	`
+
+	// repointNodeAliasesSQL and upsertNodeAliasSQL record the address of $2
+	// as an alias of $3 in the tenant of $1, pointing the aliases of $2 to
+	// $3 so that the aliases don't chain.
+	repointNodeAliasesSQL = `
+		UPDATE node_aliases SET ipv4 = $3 WHERE tenant = $1 AND ipv4 = $2
+	`
+	upsertNodeAliasSQL = `
+		INSERT INTO node_aliases (tenant, alias, ipv4) VALUES ($1, $2, $3)
+		ON CONFLICT (tenant, alias) DO UPDATE SET ipv4 = EXCLUDED.ipv4, created = CURRENT_TIMESTAMP
+	`
+
+	// findNodeAliasSQL finds the address that the address of $1 was merged
+	// into in the tenant of $2, unless a process has the address again.
+	findNodeAliasSQL = `
+		SELECT host(ipv4) FROM node_aliases
+		WHERE alias = $1 AND tenant = $2
+		AND NOT EXISTS (SELECT 1 FROM processes WHERE ipv4 = $1 AND tenant = $2)
+	`
+)
+
+// nodeSide is the nodes of a side of the flows.
+type nodeSide struct {
+	// table is the table of the nodes, column is the column of the flows
+	// referencing them, and peer is the column referencing the peers.
+	table  string
+	column string
+	peer   string
+}
+
+var (
+	activeSide  = nodeSide{table: "active_nodes", column: "source_node_id", peer: "destination_node_id"}
+	passiveSide = nodeSide{table: "passive_nodes", column: "destination_node_id", peer: "source_node_id"}
+)
+
+// MergeResult is the result of merging the nodes of an address into another.
+type MergeResult struct {
+	// MovedProcesses are the processes moved to the address, and
+	// MergedProcesses are the ones merged into the same processes of it.
+	MovedProcesses  int
+	MergedProcesses int
+	// MovedFlows are the flows moved to the address, and MergedFlows are
+	// the ones merged into the same flows of it.
+	MovedFlows  int64
+	MergedFlows int64
+}
+
+// Merger is the CMDB merging the nodes of a host whose address changed.
+type Merger interface {
+	MergeNodes(from, to net.IP, dryRun bool) (*MergeResult, error)
+	FindNodeAlias(addr net.IP) (net.IP, error)
+}
+
+var (
+	_ Merger = (*DB)(nil)
+	_ Merger = (*Shards)(nil)
+)
+
+// MergeNodes merges the processes of the address of from into the address of
+// to in a transaction, with their nodes and flows, and records from as an
+// alias of to. The processes of to of the same VRF, process group and name
+// absorb the ones of from, and the others are moved. It only returns the
+// result if dryRun. The processes are of the tenant of the writes.
+func (db *DB) MergeNodes(from, to net.IP, dryRun bool) (*MergeResult, error) {
+	var result *MergeResult
+	err := db.retry("merge nodes", &db.Conn, func(conn *pgx.Conn) error {
+		result = &MergeResult{}
+		ctx, cancel := context.WithTimeout(context.Background(), InsertOrUpdateTimeoutSec*time.Second)
+		defer cancel()
+
+		tx, err := conn.Begin(ctx)
+		if err != nil {
+			return xerrors.Errorf("begin transaction error: %w", err)
+		}
+		defer tx.Rollback(ctx)
+
+		if err := mergeNodes(ctx, tx, from, to, db.tenant, result); err != nil {
+			return err
+		}
+		if dryRun {
+			return nil
+		}
+		if _, err := tx.Exec(ctx, deleteNodeAliasSQL, db.tenant, to.String()); err != nil {
+			return xerrors.Errorf("delete node alias error: %w", err)
+		}
+		for _, sql := range []string{repointNodeAliasesSQL, upsertNodeAliasSQL} {
+			if _, err := tx.Exec(ctx, sql, db.tenant, from.String(), to.String()); err != nil {
+				return xerrors.Errorf("record node alias error: %w", err)
+			}
+		}
+		if err := tx.Commit(ctx); err != nil {
+			return xerrors.Errorf("commit error: %w", err)
+		}
+		return nil
+	})
+	return result, err
+}
+
+type mergedProcess struct {
+	id    int64
+	vrf   string
+	pgid  int
+	pname string
+}
+
+func mergeNodes(ctx context.Context, tx pgx.Tx, from, to net.IP, tenant string, result *MergeResult) error {
+	rows, err := tx.Query(ctx, findMergedProcessesSQL, from.String(), tenant)
+	if err != nil {
+		return xerrors.Errorf("find merged processes error: %w", err)
+	}
+	var procs []*mergedProcess
+	for rows.Next() {
+		var p mergedProcess
+		if err := rows.Scan(&p.id, &p.vrf, &p.pgid, &p.pname); err != nil {
+			rows.Close()
+			return xerrors.Errorf("scan merged processes error: %w", err)
+		}
+		procs = append(procs, &p)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return xerrors.Errorf("find merged processes error: %w", err)
+	}
+
+	for _, p := range procs {
+		var into int64
+		err := tx.QueryRow(ctx, findMergingProcessSQL, to.String(), tenant, p.vrf, p.pgid, p.pname).Scan(&into)
+		switch {
+		case err == pgx.ErrNoRows:
+			var n int64
+			if err := tx.QueryRow(ctx, countProcessFlowsSQL, p.id).Scan(&n); err != nil {
+				return xerrors.Errorf("count flows error: %w", err)
+			}
+			if _, err := tx.Exec(ctx, moveProcessSQL, p.id, to.String()); err != nil {
+				return xerrors.Errorf("move process error: %w", err)
+			}
+			result.MovedProcesses++
+			result.MovedFlows += n
+			continue
+		case err != nil:
+			return xerrors.Errorf("find merging process error: %w", err)
+		}
+		if err := mergeProcess(ctx, tx, p.id, into, result); err != nil {
+			return err
+		}
+		result.MergedProcesses++
+	}
+	return nil
+}
+
+// mergeProcess merges the nodes and the flows of the process of from into
+// the process of into, and deletes from.
+func mergeProcess(ctx context.Context, tx pgx.Tx, from, into int64, result *MergeResult) error {
+	fromActive, err := findActiveNode(ctx, tx, from)
+	if err != nil {
+		return err
+	}
+	intoActive, err := findActiveNode(ctx, tx, into)
+	if err != nil {
+		return err
+	}
+	if fromActive != 0 {
+		if err := mergeNode(ctx, tx, activeSide, fromActive, intoActive, into, result); err != nil {
+			return err
+		}
+	}
+
+	fromPassive, err := findPassiveNodes(ctx, tx, from)
+	if err != nil {
+		return err
+	}
+	intoPassive, err := findPassiveNodes(ctx, tx, into)
+	if err != nil {
+		return err
+	}
+	for port, node := range fromPassive {
+		if err := mergeNode(ctx, tx, passiveSide, node, intoPassive[port], into, result); err != nil {
+			return err
+		}
+	}
+
+	if _, err := tx.Exec(ctx, deleteProcessSQL, from); err != nil {
+		return xerrors.Errorf("delete merged process error: %w", err)
+	}
+	return nil
+}
+
+// mergeNode merges the flows of the node of from into the node of into, or
+// moves the node to the process of process if into is 0. The flows of both
+// nodes to the same peer are merged into the flow of into, which is the
+// latest, keeping the earliest creation.
+func mergeNode(ctx context.Context, tx pgx.Tx, side nodeSide, from, into, process int64, result *MergeResult) error {
+	if into == 0 {
+		var n int64
+		err := tx.QueryRow(ctx, `SELECT count(*) FROM flows WHERE `+side.column+` = $1`, from).Scan(&n)
+		if err != nil {
+			return xerrors.Errorf("count flows error: %w", err)
+		}
+		if _, err := tx.Exec(ctx, `UPDATE `+side.table+` SET process_id = $2 WHERE node_id = $1`, from, process); err != nil {
+			return xerrors.Errorf("move node error: %w", err)
+		}
+		result.MovedFlows += n
+		return nil
+	}
+
+	_, err := tx.Exec(ctx, `
+		UPDATE flows SET created = LEAST(flows.created, merged.created)
+		FROM flows AS merged
+		WHERE merged.`+side.column+` = $1 AND flows.`+side.column+` = $2
+		AND flows.`+side.peer+` = merged.`+side.peer, from, into)
+	if err != nil {
+		return xerrors.Errorf("merge flows error: %w", err)
+	}
+	tag, err := tx.Exec(ctx, `
+		DELETE FROM flows USING flows AS kept
+		WHERE flows.`+side.column+` = $1 AND kept.`+side.column+` = $2
+		AND kept.`+side.peer+` = flows.`+side.peer, from, into)
+	if err != nil {
+		return xerrors.Errorf("merge flows error: %w", err)
+	}
+	result.MergedFlows += tag.RowsAffected()
+	tag, err = tx.Exec(ctx, `UPDATE flows SET `+side.column+` = $2 WHERE `+side.column+` = $1`, from, into)
+	if err != nil {
+		return xerrors.Errorf("move flows error: %w", err)
+	}
+	result.MovedFlows += tag.RowsAffected()
+	if _, err := tx.Exec(ctx, `DELETE FROM `+side.table+` WHERE node_id = $1`, from); err != nil {
+		return xerrors.Errorf("delete merged node error: %w", err)
+	}
+	return nil
+}
+
+func findActiveNode(ctx context.Context, tx pgx.Tx, process int64) (int64, error) {
+	var id int64
+	err := tx.QueryRow(ctx, findActiveNodeSQL, process).Scan(&id)
+	if err == pgx.ErrNoRows {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, xerrors.Errorf("find active node error: %w", err)
+	}
+	return id, nil
+}
+
+// findPassiveNodes returns the passive nodes of the process by their ports.
+func findPassiveNodes(ctx context.Context, tx pgx.Tx, process int64) (map[int]int64, error) {
+	rows, err := tx.Query(ctx, findProcessPassiveNodesSQL, process)
+	if err != nil {
+		return nil, xerrors.Errorf("find passive nodes error: %w", err)
+	}
+	defer rows.Close()
+	nodes := make(map[int]int64)
+	for rows.Next() {
+		var id int64
+		var port int
+		if err := rows.Scan(&id, &port); err != nil {
+			return nil, xerrors.Errorf("scan passive nodes error: %w", err)
+		}
+		nodes[port] = id
+	}
+	if err := rows.Err(); err != nil {
+		return nil, xerrors.Errorf("find passive nodes error: %w", err)
+	}
+	return nodes, nil
+}
+
+// FindNodeAlias returns the address that addr was merged into, or nil if
+// addr is not an alias or a process has it again.
+func (db *DB) FindNodeAlias(addr net.IP) (net.IP, error) {
+	var alias net.IP
+	err := db.retry("find node alias", &db.Conn, func(conn *pgx.Conn) error {
+		var s string
+		err := conn.QueryRow(context.Background(), findNodeAliasSQL, addr.String(), db.tenant).Scan(&s)
+		if err == pgx.ErrNoRows {
+			alias = nil
+			return nil
+		}
+		if err != nil {
+			return xerrors.Errorf("find node alias error: %w", err)
+		}
+		alias = net.ParseIP(s)
+		return nil
+	})
+	return alias, err
+}
@@ -0,0 +1,70 @@
+package db
+
+import (
+	"net"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/yuuki/shawk/probe"
+)
+
+func TestMergeNodes(t *testing.T) {
+	db, teardown := setupTestCase(t)
+	defer teardown(t)
+
+	// The host of app moved from 10.0.1.5 to 10.0.1.9, and both addresses
+	// have the flows to the same database.
+	flows := []*probe.HostFlow{
+		{
+			Direction: probe.FlowActive, Process: &probe.Process{Name: "app", Pgid: 100}, Connections: 2,
+			Local: &probe.AddrPort{Addr: "10.0.1.5", Aggregated: true},
+			Peer:  &probe.AddrPort{Addr: "10.0.2.1", Port: 5432},
+		},
+		{
+			Direction: probe.FlowActive, Process: &probe.Process{Name: "app", Pgid: 100}, Connections: 1,
+			Local: &probe.AddrPort{Addr: "10.0.1.5", Aggregated: true},
+			Peer:  &probe.AddrPort{Addr: "10.0.2.2", Port: 6379},
+		},
+		{
+			Direction: probe.FlowActive, Process: &probe.Process{Name: "app", Pgid: 100}, Connections: 3,
+			Local: &probe.AddrPort{Addr: "10.0.1.9", Aggregated: true},
+			Peer:  &probe.AddrPort{Addr: "10.0.2.1", Port: 5432},
+		},
+	}
+	if err := db.InsertOrUpdateHostFlows(flows); err != nil {
+		t.Fatalf("%+v", err)
+	}
+
+	from, to := net.ParseIP("10.0.1.5"), net.ParseIP("10.0.1.9")
+	result, err := db.MergeNodes(from, to, false)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	want := &MergeResult{MergedProcesses: 1, MovedFlows: 1, MergedFlows: 1}
+	if diff := cmp.Diff(want, result); diff != "" {
+		t.Errorf("MergeNodes() mismatch (-want +got):\n%s", diff)
+	}
+
+	found, err := db.FindActiveFlows(&FindFlowsCond{Addrs: []net.IP{to}})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	var servers []string
+	for _, fs := range found {
+		for _, f := range fs {
+			servers = append(servers, f.PassiveNode.String())
+		}
+	}
+	if len(servers) != 2 {
+		t.Errorf("10.0.1.9 should have the 2 flows of both addresses, but %v", servers)
+	}
+
+	alias, err := db.FindNodeAlias(from)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if !alias.Equal(to) {
+		t.Errorf("FindNodeAlias() = %v, want %v", alias, to)
+	}
+}
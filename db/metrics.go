@@ -0,0 +1,154 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"expvar"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+)
+
+// durationBuckets are the upper bounds of the buckets of the histograms in
+// seconds, from a lookup by an index to a write of a large batch.
+var durationBuckets = []float64{0.001, 0.005, 0.01, 0.05, 0.1, 0.5, 1, 5, 10}
+
+// Histogram is a histogram of the durations in seconds exposed by expvar.
+// The buckets are cumulative as in Prometheus, and "+Inf" counts all the
+// observations.
+type Histogram struct {
+	mu     sync.Mutex
+	counts []int64 // by durationBuckets followed by +Inf
+	sum    float64
+}
+
+// NewHistogram returns an empty histogram.
+func NewHistogram() *Histogram {
+	return &Histogram{counts: make([]int64, len(durationBuckets)+1)}
+}
+
+// Observe adds d to the histogram.
+func (h *Histogram) Observe(d time.Duration) {
+	s := d.Seconds()
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for i, le := range durationBuckets {
+		if s <= le {
+			h.counts[i]++
+		}
+	}
+	h.counts[len(durationBuckets)]++
+	h.sum += s
+}
+
+// String returns the histogram in JSON, which implements expvar.Var.
+func (h *Histogram) String() string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	buckets := make(map[string]int64, len(h.counts))
+	for i, le := range durationBuckets {
+		buckets[strconv.FormatFloat(le, 'g', -1, 64)] = h.counts[i]
+	}
+	buckets["+Inf"] = h.counts[len(durationBuckets)]
+	b, _ := json.Marshal(struct {
+		Count   int64            `json:"count"`
+		Sum     float64          `json:"sum"`
+		Buckets map[string]int64 `json:"buckets"`
+	}{h.counts[len(durationBuckets)], h.sum, buckets})
+	return string(b)
+}
+
+// Histograms of the CMDB exposed at /debug/vars. The operations are such as
+// 'write flows' including their retries, and the statements are the queries
+// and the execs sent to postgres.
+var (
+	OpDurations        = new(expvar.Map).Init()
+	StatementDurations = NewHistogram()
+)
+
+func init() {
+	expvar.Publish("shawk.db_op_duration_seconds", OpDurations)
+	expvar.Publish("shawk.db_statement_duration_seconds", StatementDurations)
+}
+
+var opHistogramsMu sync.Mutex
+
+// observeOp adds the duration of op to its histogram.
+func observeOp(op string, d time.Duration) {
+	h, ok := OpDurations.Get(op).(*Histogram)
+	if !ok {
+		opHistogramsMu.Lock()
+		if h, ok = OpDurations.Get(op).(*Histogram); !ok {
+			h = NewHistogram()
+			OpDurations.Set(op, h)
+		}
+		opHistogramsMu.Unlock()
+	}
+	h.Observe(d)
+}
+
+// DefaultSlowQueryThreshold is the duration of the statements logged as slow.
+const DefaultSlowQueryThreshold = time.Second
+
+// queryLogger is the logger of pgx, which observes the durations of the
+// statements and logs the slow ones with their parameters. The logs are
+// passed to next as well if not nil.
+type queryLogger struct {
+	next pgx.Logger
+	// threshold is the nanoseconds of the slow statements. Zero or less
+	// doesn't log them.
+	threshold int64
+}
+
+func newQueryLogger(next pgx.Logger) *queryLogger {
+	return &queryLogger{next: next, threshold: int64(DefaultSlowQueryThreshold)}
+}
+
+// Log implements pgx.Logger. pgx logs Exec and Query with their durations
+// at LogLevelInfo.
+func (l *queryLogger) Log(ctx context.Context, level pgx.LogLevel, msg string, data map[string]interface{}) {
+	if d, ok := data["time"].(time.Duration); ok && (msg == "Exec" || msg == "Query") {
+		StatementDurations.Observe(d)
+		if l.isSlow(d) {
+			logger.Warningf("slow query took %s: %s args=%v", d.Round(time.Millisecond), compactSQL(data["sql"]), data["args"])
+		}
+	}
+	if l.next != nil {
+		l.next.Log(ctx, level, msg, data)
+	}
+}
+
+func (l *queryLogger) isSlow(d time.Duration) bool {
+	threshold := time.Duration(atomic.LoadInt64(&l.threshold))
+	return threshold > 0 && d >= threshold
+}
+
+// compactSQL joins the lines of the statement into a line for the logs.
+func compactSQL(v interface{}) string {
+	sql, _ := v.(string)
+	b := make([]byte, 0, len(sql))
+	space := false
+	for i := 0; i < len(sql); i++ {
+		switch c := sql[i]; c {
+		case ' ', '\t', '\n', '\r':
+			space = len(b) > 0
+		default:
+			if space {
+				b = append(b, ' ')
+				space = false
+			}
+			b = append(b, c)
+		}
+	}
+	return string(b)
+}
+
+// SetSlowQueryThreshold replaces the duration of the statements and the
+// operations logged as slow. Zero disables the logs, while the durations
+// are still observed.
+func (db *DB) SetSlowQueryThreshold(d time.Duration) {
+	atomic.StoreInt64(&db.queries.threshold, int64(d))
+}
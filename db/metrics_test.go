@@ -0,0 +1,98 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jackc/pgx/v4"
+)
+
+func TestHistogram(t *testing.T) {
+	h := NewHistogram()
+	h.Observe(3 * time.Millisecond)
+	h.Observe(200 * time.Millisecond)
+	h.Observe(20 * time.Second)
+
+	var got struct {
+		Count   int64            `json:"count"`
+		Sum     float64          `json:"sum"`
+		Buckets map[string]int64 `json:"buckets"`
+	}
+	if err := json.Unmarshal([]byte(h.String()), &got); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if got.Count != 3 {
+		t.Errorf("count = %d, want 3", got.Count)
+	}
+	if got.Sum < 20.2 || got.Sum > 20.21 {
+		t.Errorf("sum = %v, want 20.203", got.Sum)
+	}
+	want := map[string]int64{
+		"0.001": 0, "0.005": 1, "0.01": 1, "0.05": 1, "0.1": 1,
+		"0.5": 2, "1": 2, "5": 2, "10": 2, "+Inf": 3,
+	}
+	if diff := cmp.Diff(want, got.Buckets); diff != "" {
+		t.Errorf("buckets mismatch (-want +got):\n%s", diff)
+	}
+}
+
+type recordLogger struct {
+	msgs []string
+}
+
+func (l *recordLogger) Log(ctx context.Context, level pgx.LogLevel, msg string, data map[string]interface{}) {
+	l.msgs = append(l.msgs, msg)
+}
+
+func TestQueryLogger(t *testing.T) {
+	next := &recordLogger{}
+	l := newQueryLogger(next)
+	before := counts(StatementDurations)
+
+	l.Log(context.Background(), pgx.LogLevelInfo, "Query", map[string]interface{}{
+		"sql": "SELECT 1", "args": []interface{}{}, "time": 2 * time.Second,
+	})
+	l.Log(context.Background(), pgx.LogLevelInfo, "Dialing PostgreSQL server", map[string]interface{}{"host": "localhost"})
+
+	if got := counts(StatementDurations) - before; got != 1 {
+		t.Errorf("observed %d statements, want 1", got)
+	}
+	if diff := cmp.Diff([]string{"Query", "Dialing PostgreSQL server"}, next.msgs); diff != "" {
+		t.Errorf("passed logs mismatch (-want +got):\n%s", diff)
+	}
+	if !l.isSlow(2 * time.Second) {
+		t.Error("2s should be slow by the default threshold")
+	}
+	l.threshold = 0
+	if l.isSlow(time.Hour) {
+		t.Error("zero threshold should log no slow queries")
+	}
+}
+
+func counts(h *Histogram) int64 {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.counts[len(durationBuckets)]
+}
+
+func TestObserveOp(t *testing.T) {
+	observeOp("test op", time.Millisecond)
+	observeOp("test op", time.Millisecond)
+	h, ok := OpDurations.Get("test op").(*Histogram)
+	if !ok {
+		t.Fatal("OpDurations should have the histogram of test op")
+	}
+	if got := counts(h); got != 2 {
+		t.Errorf("observed %d operations, want 2", got)
+	}
+}
+
+func TestCompactSQL(t *testing.T) {
+	got := compactSQL("\n\t\tSELECT 1\n\t\tFROM  flows\n\t")
+	if want := "SELECT 1 FROM flows"; got != want {
+		t.Errorf("compactSQL = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,193 @@
+package db
+
+import (
+	"context"
+	"net"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+	"golang.org/x/xerrors"
+
+	"github.com/yuuki/shawk/probe"
+)
+
+// NATExpiry is how long the translations written by the NAT gateways are
+// applied to the flows after they are last written.
+var NATExpiry = 10 * time.Minute
+
+const (
+	insertNATsSQL = `
+		INSERT INTO nat_translations
+		(tenant, client_ipv4, original_ipv4, original_port, translated_ipv4, translated_port, source_ipv4, connections)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (tenant, client_ipv4, original_ipv4, original_port, translated_ipv4, translated_port, source_ipv4)
+		DO UPDATE SET connections=EXCLUDED.connections, updated=CURRENT_TIMESTAMP
+	`
+
+	findNATsSQL = `
+		SELECT host(client_ipv4), host(original_ipv4), original_port,
+			host(translated_ipv4), translated_port, host(source_ipv4), connections
+		FROM nat_translations
+		WHERE tenant = $1 AND updated > $2
+	`
+
+	deleteExpiredNATsSQL = `
+		DELETE FROM nat_translations WHERE tenant = $1 AND updated <= $2
+	`
+)
+
+// NATWriter is the CMDB writing the translations of the NAT gateways.
+type NATWriter interface {
+	InsertOrUpdateNATs(nats []*probe.NAT) error
+}
+
+var (
+	_ NATWriter = (*DB)(nil)
+	_ NATWriter = (*Shards)(nil)
+)
+
+// InsertOrUpdateNATs writes the translations of the NAT gateway, and
+// deletes the expired ones of the tenant.
+func (db *DB) InsertOrUpdateNATs(nats []*probe.NAT) error {
+	return db.retry("write NATs", &db.Conn, func(conn *pgx.Conn) error {
+		ctx, cancel := context.WithTimeout(context.Background(), InsertOrUpdateTimeoutSec*time.Second)
+		defer cancel()
+
+		tx, err := conn.Begin(ctx)
+		if err != nil {
+			return xerrors.Errorf("begin transaction error: %w", err)
+		}
+		defer tx.Rollback(ctx)
+
+		for _, n := range nats {
+			_, err := tx.Exec(ctx, insertNATsSQL, db.tenant, n.Client,
+				n.Original.Addr, n.Original.Port, n.Translated.Addr, n.Translated.Port, n.Source, n.Connections)
+			if err != nil {
+				return xerrors.Errorf("insert NATs error: %w", err)
+			}
+		}
+		if _, err := tx.Exec(ctx, deleteExpiredNATsSQL, db.tenant, time.Now().Add(-NATExpiry)); err != nil {
+			return xerrors.Errorf("delete expired NATs error: %w", err)
+		}
+		if err := tx.Commit(ctx); err != nil {
+			return xerrors.Errorf("commit error: %w", err)
+		}
+		return nil
+	})
+}
+
+// findNATs returns the translations of the tenant not expired.
+func (db *DB) findNATs() ([]*probe.NAT, error) {
+	var nats []*probe.NAT
+	err := db.retry("find NATs", &db.Conn, func(conn *pgx.Conn) error {
+		nats = nil
+		rows, err := conn.Query(context.Background(), findNATsSQL, db.tenant, time.Now().Add(-NATExpiry))
+		if err != nil {
+			return xerrors.Errorf("query NATs error: %w", err)
+		}
+		defer rows.Close()
+		for rows.Next() {
+			n := &probe.NAT{Original: &probe.AddrPort{}, Translated: &probe.AddrPort{}}
+			if err := rows.Scan(&n.Client, &n.Original.Addr, &n.Original.Port,
+				&n.Translated.Addr, &n.Translated.Port, &n.Source, &n.Connections); err != nil {
+				return xerrors.Errorf("scan NATs error: %w", err)
+			}
+			nats = append(nats, n)
+		}
+		return rows.Err()
+	})
+	return nats, err
+}
+
+// translateFlows returns the flows through the NAT gateways as the flows
+// between the clients and the translated servers, so that a connection
+// seen by the agents on both sides is an edge:
+//
+//   - The active flow from a client to the original destination of DNAT,
+//     such as a virtual IP, is to the translated servers, whose peers are
+//     labeled with the original destination.
+//   - The passive flow of a translated server from the source of SNAT,
+//     such as the gateway, is from the clients translated into it, whose
+//     peers are labeled with the source.
+//
+// A flow translated into several servers or from several clients is split
+// into them by the connections of conntrack. The other flows are returned
+// as they are.
+func translateFlows(flows []*probe.HostFlow, nats []*probe.NAT) []*probe.HostFlow {
+	if len(nats) == 0 {
+		return flows
+	}
+	type dnatKey struct {
+		client, addr string
+		port         uint16
+	}
+	type snatKey struct {
+		server, source string
+		port           uint16
+	}
+	dnats := make(map[dnatKey][]*probe.NAT)
+	snats := make(map[snatKey][]*probe.NAT)
+	for _, n := range nats {
+		if n.DNAT() {
+			k := dnatKey{n.Client, n.Original.Addr, n.Original.Port}
+			dnats[k] = append(dnats[k], n)
+		}
+		if n.SNAT() {
+			k := snatKey{n.Translated.Addr, n.Source, n.Translated.Port}
+			snats[k] = append(snats[k], n)
+		}
+	}
+
+	translated := make([]*probe.HostFlow, 0, len(flows))
+	for _, f := range flows {
+		var split []*probe.HostFlow
+		switch f.Direction {
+		case probe.FlowActive:
+			split = splitFlow(f, dnats[dnatKey{f.Local.Addr, f.Peer.Addr, f.Peer.Port}], func(n *probe.NAT) *probe.AddrPort {
+				p := &probe.AddrPort{Addr: n.Translated.Addr, Port: n.Translated.Port}
+				p.SetLabel(probe.LabelNATOriginal, net.JoinHostPort(n.Original.Addr, n.Original.PortString()))
+				return p
+			})
+		case probe.FlowPassive:
+			split = splitFlow(f, snats[snatKey{f.Local.Addr, f.Peer.Addr, f.Local.Port}], func(n *probe.NAT) *probe.AddrPort {
+				p := &probe.AddrPort{Addr: n.Client, Aggregated: true}
+				p.SetLabel(probe.LabelNATSource, n.Source)
+				return p
+			})
+		}
+		if split == nil {
+			translated = append(translated, f)
+			continue
+		}
+		translated = append(translated, split...)
+	}
+	return translated
+}
+
+// splitFlow returns the flow to the peers of the translations, or nil if
+// there are no translations. The connections of a peer of several
+// translations are summed up.
+func splitFlow(f *probe.HostFlow, nats []*probe.NAT, peerOf func(*probe.NAT) *probe.AddrPort) []*probe.HostFlow {
+	if len(nats) == 0 {
+		return nil
+	}
+	var split []*probe.HostFlow
+	byPeer := make(map[string]*probe.HostFlow)
+	for _, n := range nats {
+		peer := peerOf(n)
+		if t, ok := byPeer[peer.String()]; ok {
+			t.Connections += n.Connections
+			continue
+		}
+		t := *f
+		t.Peer = peer
+		t.Connections = n.Connections
+		byPeer[peer.String()] = &t
+		split = append(split, &t)
+	}
+	// A flow to a peer is as many connections as the agent sees.
+	if len(split) == 1 {
+		split[0].Connections = f.Connections
+	}
+	return split
+}
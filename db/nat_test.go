@@ -0,0 +1,148 @@
+package db
+
+import (
+	"net"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/yuuki/shawk/probe"
+)
+
+// gatewayNATs are the translations of a gateway 10.0.0.1 of the virtual IP
+// 10.96.0.10:80 to two servers, and of SNAT to the internet.
+var gatewayNATs = []*probe.NAT{
+	{
+		Client: "10.0.0.5", Source: "10.0.0.1",
+		Original:    &probe.AddrPort{Addr: "10.96.0.10", Port: 80},
+		Translated:  &probe.AddrPort{Addr: "10.1.2.3", Port: 8080},
+		Connections: 3,
+	},
+	{
+		Client: "10.0.0.5", Source: "10.0.0.1",
+		Original:    &probe.AddrPort{Addr: "10.96.0.10", Port: 80},
+		Translated:  &probe.AddrPort{Addr: "10.1.2.4", Port: 8080},
+		Connections: 1,
+	},
+	{
+		Client: "10.0.0.6", Source: "10.0.0.1",
+		Original:    &probe.AddrPort{Addr: "10.96.0.10", Port: 80},
+		Translated:  &probe.AddrPort{Addr: "10.1.2.3", Port: 8080},
+		Connections: 2,
+	},
+	{
+		Client: "10.0.0.6", Source: "203.0.113.1",
+		Original:    &probe.AddrPort{Addr: "93.184.216.34", Port: 443},
+		Translated:  &probe.AddrPort{Addr: "93.184.216.34", Port: 443},
+		Connections: 1,
+	},
+}
+
+func TestTranslateFlows(t *testing.T) {
+	app := &probe.Process{Name: "app", Pgid: 100}
+	nginx := &probe.Process{Name: "nginx", Pgid: 200}
+	flows := []*probe.HostFlow{
+		// The client of the virtual IP.
+		{
+			Direction: probe.FlowActive, Process: app, Connections: 5,
+			Local: &probe.AddrPort{Addr: "10.0.0.5", Aggregated: true},
+			Peer:  &probe.AddrPort{Addr: "10.96.0.10", Port: 80},
+		},
+		// The server of the virtual IP, which sees the gateway.
+		{
+			Direction: probe.FlowPassive, Process: nginx, Connections: 5,
+			Local: &probe.AddrPort{Addr: "10.1.2.3", Port: 8080},
+			Peer:  &probe.AddrPort{Addr: "10.0.0.1", Aggregated: true},
+		},
+		// The client to the internet through SNAT only.
+		{
+			Direction: probe.FlowActive, Process: app, Connections: 1,
+			Local: &probe.AddrPort{Addr: "10.0.0.6", Aggregated: true},
+			Peer:  &probe.AddrPort{Addr: "93.184.216.34", Port: 443},
+		},
+		// Not through the gateway.
+		{
+			Direction: probe.FlowActive, Process: app, Connections: 2,
+			Local: &probe.AddrPort{Addr: "10.0.0.5", Aggregated: true},
+			Peer:  &probe.AddrPort{Addr: "10.1.2.3", Port: 8080},
+		},
+	}
+	original := map[string]string{probe.LabelNATOriginal: "10.96.0.10:80"}
+	source := map[string]string{probe.LabelNATSource: "10.0.0.1"}
+	want := []*probe.HostFlow{
+		{
+			Direction: probe.FlowActive, Process: app, Connections: 3,
+			Local: &probe.AddrPort{Addr: "10.0.0.5", Aggregated: true},
+			Peer:  &probe.AddrPort{Addr: "10.1.2.3", Port: 8080, Labels: original},
+		},
+		{
+			Direction: probe.FlowActive, Process: app, Connections: 1,
+			Local: &probe.AddrPort{Addr: "10.0.0.5", Aggregated: true},
+			Peer:  &probe.AddrPort{Addr: "10.1.2.4", Port: 8080, Labels: original},
+		},
+		{
+			Direction: probe.FlowPassive, Process: nginx, Connections: 3,
+			Local: &probe.AddrPort{Addr: "10.1.2.3", Port: 8080},
+			Peer:  &probe.AddrPort{Addr: "10.0.0.5", Aggregated: true, Labels: source},
+		},
+		{
+			Direction: probe.FlowPassive, Process: nginx, Connections: 2,
+			Local: &probe.AddrPort{Addr: "10.1.2.3", Port: 8080},
+			Peer:  &probe.AddrPort{Addr: "10.0.0.6", Aggregated: true, Labels: source},
+		},
+		flows[2],
+		flows[3],
+	}
+	if diff := cmp.Diff(want, translateFlows(flows, gatewayNATs)); diff != "" {
+		t.Errorf("translateFlows() mismatch (-want +got):\n%s", diff)
+	}
+	if got := translateFlows(flows, nil); len(got) != len(flows) {
+		t.Errorf("translateFlows() without the NATs should return the flows, but %d", len(got))
+	}
+}
+
+func TestInsertOrUpdateNATs(t *testing.T) {
+	db, teardown := setupTestCase(t)
+	defer teardown(t)
+
+	if err := db.InsertOrUpdateNATs(gatewayNATs); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	nats, err := db.findNATs()
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if len(nats) != len(gatewayNATs) {
+		t.Errorf("findNATs() should return %d NATs, but %d", len(gatewayNATs), len(nats))
+	}
+
+	// The agents on both sides of the virtual IP write an edge.
+	flows := []*probe.HostFlow{
+		{
+			Direction: probe.FlowActive, Process: &probe.Process{Name: "app", Pgid: 100}, Connections: 2,
+			Local: &probe.AddrPort{Addr: "10.0.0.6", Aggregated: true},
+			Peer:  &probe.AddrPort{Addr: "10.96.0.10", Port: 80},
+		},
+		{
+			Direction: probe.FlowPassive, Process: &probe.Process{Name: "nginx", Pgid: 200}, Connections: 2,
+			Local: &probe.AddrPort{Addr: "10.1.2.3", Port: 8080},
+			Peer:  &probe.AddrPort{Addr: "10.0.0.1", Aggregated: true},
+		},
+	}
+	if err := db.InsertOrUpdateHostFlows(flows); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	found, err := db.FindActiveFlows(&FindFlowsCond{Addrs: []net.IP{net.ParseIP("10.0.0.6")}})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	var servers []string
+	for _, fs := range found {
+		for _, f := range fs {
+			servers = append(servers, f.PassiveNode.String())
+		}
+	}
+	if len(servers) != 1 {
+		t.Errorf("the client should have a flow to the translated server, but %v", servers)
+	}
+}
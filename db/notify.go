@@ -0,0 +1,153 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/jackc/pgx/v4"
+	"golang.org/x/xerrors"
+
+	"github.com/yuuki/shawk/probe"
+)
+
+// NotifyChannel is the channel of Postgres on which the changes of the flows
+// are notified.
+const NotifyChannel = "shawk_flows"
+
+// The types of the changes.
+const (
+	// ChangeAdded is notified when a flow is written for the first time.
+	ChangeAdded = "added"
+	// ChangeRemoved is notified when a flow is deleted from the CMDB.
+	ChangeRemoved = "removed"
+	// ChangeThreshold is notified when the connections of a flow reach the
+	// threshold set by SetNotifyThreshold.
+	ChangeThreshold = "threshold"
+)
+
+// ChangeTypes are the types of the changes.
+var ChangeTypes = []string{ChangeAdded, ChangeRemoved, ChangeThreshold}
+
+// ChangeNode is an endpoint of the flow of a change. The port of a client is
+// zero.
+type ChangeNode struct {
+	Addr    string `json:"addr"`
+	Port    uint16 `json:"port,omitempty"`
+	Process string `json:"process"`
+}
+
+// Change is a change of the flows, which is notified as JSON on
+// NotifyChannel when the transaction writing it commits.
+type Change struct {
+	Type string `json:"type"`
+	// ID is the ID of the flow.
+	ID          int64 `json:"id"`
+	Connections int   `json:"connections"`
+	// Tenant is the tenant of the flow.
+	Tenant string `json:"tenant,omitempty"`
+	// Client and Server are nil if their processes have been deleted with
+	// the flow.
+	Client *ChangeNode `json:"client"`
+	Server *ChangeNode `json:"server"`
+}
+
+// SetNotifyThreshold sets the connections of a flow to notify
+// ChangeThreshold when they reach it. Zero doesn't notify it.
+func (db *DB) SetNotifyThreshold(n int) {
+	db.settingsMu.Lock()
+	defer db.settingsMu.Unlock()
+	db.notifyThreshold = n
+}
+
+func (db *DB) threshold() int {
+	db.settingsMu.RLock()
+	defer db.settingsMu.RUnlock()
+	return db.notifyThreshold
+}
+
+// flowChange returns the change of the flow written with the connections
+// of the previous write, or nil if it is not notified. prev is nil if the
+// flow has been written for the first time.
+func flowChange(flow *probe.HostFlow, id int64, prev *int, threshold int) *Change {
+	connections := int(flow.Connections)
+	var typ string
+	switch {
+	case prev == nil:
+		typ = ChangeAdded
+	case threshold > 0 && *prev < threshold && connections >= threshold:
+		typ = ChangeThreshold
+	default:
+		return nil
+	}
+
+	var pname string
+	if flow.Process != nil {
+		pname = flow.Process.Name
+	}
+	c := &Change{Type: typ, ID: id, Connections: connections}
+	if flow.Direction == probe.FlowActive {
+		c.Client = &ChangeNode{Addr: flow.Local.Addr, Process: pname}
+		c.Server = &ChangeNode{Addr: flow.Peer.Addr, Port: flow.Peer.Port}
+	} else {
+		c.Client = &ChangeNode{Addr: flow.Peer.Addr}
+		c.Server = &ChangeNode{Addr: flow.Local.Addr, Port: flow.Local.Port, Process: pname}
+	}
+	return c
+}
+
+// notify notifies the change in the transaction of the conn, which delivers
+// it to the listeners on commit.
+func notify(ctx context.Context, conn *pgx.Conn, c *Change) error {
+	payload, err := json.Marshal(c)
+	if err != nil {
+		return xerrors.Errorf("could not encode the change: %w", err)
+	}
+	if _, err := conn.Exec(ctx, `SELECT pg_notify($1, $2)`, NotifyChannel, string(payload)); err != nil {
+		return xerrors.Errorf("notify error: %w", err)
+	}
+	return nil
+}
+
+// Listen calls fn with each change of the flows of the tenants of the
+// queries notified until ctx is done or fn returns an error. It listens on a connection of its own, and
+// returns an error when the connection is lost; the changes notified
+// meanwhile are not delivered.
+func (db *DB) Listen(ctx context.Context, fn func(*Change) error) error {
+	conn, err := connect(ctx, db.conf)
+	if err != nil {
+		return xerrors.Errorf("could not connect to postgres: %w", err)
+	}
+	defer conn.Close(context.Background())
+
+	if _, err := conn.Exec(ctx, "LISTEN "+NotifyChannel); err != nil {
+		return xerrors.Errorf("listen error: %w", err)
+	}
+	for {
+		n, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return xerrors.Errorf("wait for notification error: %w", err)
+		}
+		c, err := decodeChange(n.Payload)
+		if err != nil {
+			logger.Warningf("%v", err)
+			continue
+		}
+		if !db.inScope(c.Tenant) {
+			continue
+		}
+		if err := fn(c); err != nil {
+			return err
+		}
+	}
+}
+
+func decodeChange(payload string) (*Change, error) {
+	var c Change
+	if err := json.Unmarshal([]byte(payload), &c); err != nil {
+		return nil, xerrors.Errorf("could not decode the change %q: %w", payload, err)
+	}
+	return &c, nil
+}
@@ -0,0 +1,132 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/yuuki/shawk/probe"
+)
+
+func TestFlowChange(t *testing.T) {
+	active := &probe.HostFlow{
+		Direction:   probe.FlowActive,
+		Local:       &probe.AddrPort{Addr: "10.0.0.1", Aggregated: true},
+		Peer:        &probe.AddrPort{Addr: "10.0.0.2", Port: 80},
+		Process:     &probe.Process{Name: "app"},
+		Connections: 10,
+	}
+	passive := &probe.HostFlow{
+		Direction:   probe.FlowPassive,
+		Local:       &probe.AddrPort{Addr: "10.0.0.2", Port: 80},
+		Peer:        &probe.AddrPort{Addr: "10.0.0.1", Aggregated: true},
+		Process:     &probe.Process{Name: "nginx"},
+		Connections: 10,
+	}
+	intPtr := func(n int) *int { return &n }
+
+	tests := []struct {
+		desc      string
+		flow      *probe.HostFlow
+		prev      *int
+		threshold int
+		want      *Change
+	}{
+		{
+			desc: "added active",
+			flow: active,
+			want: &Change{
+				Type: ChangeAdded, ID: 1, Connections: 10,
+				Client: &ChangeNode{Addr: "10.0.0.1", Process: "app"},
+				Server: &ChangeNode{Addr: "10.0.0.2", Port: 80},
+			},
+		},
+		{
+			desc: "added passive",
+			flow: passive,
+			want: &Change{
+				Type: ChangeAdded, ID: 1, Connections: 10,
+				Client: &ChangeNode{Addr: "10.0.0.1"},
+				Server: &ChangeNode{Addr: "10.0.0.2", Port: 80, Process: "nginx"},
+			},
+		},
+		{desc: "updated", flow: active, prev: intPtr(5)},
+		{
+			desc: "reached threshold", flow: active, prev: intPtr(5), threshold: 10,
+			want: &Change{
+				Type: ChangeThreshold, ID: 1, Connections: 10,
+				Client: &ChangeNode{Addr: "10.0.0.1", Process: "app"},
+				Server: &ChangeNode{Addr: "10.0.0.2", Port: 80},
+			},
+		},
+		{desc: "above threshold", flow: active, prev: intPtr(10), threshold: 10},
+		{desc: "below threshold", flow: active, prev: intPtr(5), threshold: 20},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got := flowChange(tt.flow, 1, tt.prev, tt.threshold)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("flowChange() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestListen(t *testing.T) {
+	db, teardown := setupTestCase(t)
+	defer teardown(t)
+	db.SetNotifyThreshold(50)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	changes := make(chan *Change, 10)
+	listening := make(chan error, 1)
+	go func() {
+		listening <- db.Listen(ctx, func(c *Change) error {
+			changes <- c
+			return nil
+		})
+	}()
+	// Wait for LISTEN on the connection of its own.
+	time.Sleep(500 * time.Millisecond)
+
+	flow := &probe.HostFlow{
+		Direction:   probe.FlowActive,
+		Local:       &probe.AddrPort{Addr: "10.0.10.1", Aggregated: true},
+		Peer:        &probe.AddrPort{Addr: "10.0.10.2", Port: 80},
+		Process:     &probe.Process{Pgid: 1001, Name: "haproxy"},
+		Connections: 10,
+	}
+	if err := db.InsertOrUpdateHostFlows([]*probe.HostFlow{flow}); err != nil {
+		t.Fatal(err)
+	}
+	flow.Connections = 100
+	if err := db.InsertOrUpdateHostFlows([]*probe.HostFlow{flow}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.Exec(context.Background(), `DELETE FROM flows`); err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+	for len(got) < 3 {
+		select {
+		case c := <-changes:
+			got = append(got, c.Type+" "+c.Client.Addr+" -> "+c.Server.Addr)
+		case err := <-listening:
+			t.Fatalf("Listen() should not return: %v", err)
+		case <-ctx.Done():
+			t.Fatalf("the changes should be notified, but %q", got)
+		}
+	}
+	want := []string{
+		"added 10.0.10.1 -> 10.0.10.2",
+		"threshold 10.0.10.1 -> 10.0.10.2",
+		"removed 10.0.10.1 -> 10.0.10.2",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("the changes mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,139 @@
+package db
+
+import (
+	"context"
+	"io"
+	"math/rand"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+	"golang.org/x/xerrors"
+
+	"github.com/yuuki/shawk/logging"
+)
+
+var logger = logging.New("db")
+
+// RetryPolicy configures the retries of the operations failed by transient
+// errors. The backoff doubles from InitialBackoff up to MaxBackoff.
+type RetryPolicy struct {
+	MaxRetries     int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+}
+
+var defaultRetryPolicy = RetryPolicy{
+	MaxRetries:     3,
+	InitialBackoff: 500 * time.Millisecond,
+	MaxBackoff:     10 * time.Second,
+}
+
+// SetRetryPolicy replaces the retry policy. Zero MaxRetries disables retries.
+func (db *DB) SetRetryPolicy(p RetryPolicy) {
+	db.settingsMu.Lock()
+	defer db.settingsMu.Unlock()
+	db.retryPolicy = p
+}
+
+// backoff returns the wait before the attempt-th retry, starting with 0.
+// It is randomized between the half and the whole to spread the retries of
+// the agents which have failed at once by a failover.
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	d := p.InitialBackoff
+	for i := 0; i < attempt && d < p.MaxBackoff; i++ {
+		d *= 2
+	}
+	if d > p.MaxBackoff {
+		d = p.MaxBackoff
+	}
+	if d <= 0 {
+		return 0
+	}
+	return d/2 + time.Duration(rand.Int63n(int64(d/2)+1))
+}
+
+// sqlStater is implemented by pgconn.PgError.
+type sqlStater interface {
+	SQLState() string
+}
+
+// retryableSQLStates are the SQLSTATEs worth retrying.
+// See https://www.postgresql.org/docs/current/errcodes-appendix.html.
+var retryableSQLStates = map[string]bool{
+	"40001": true, // serialization_failure
+	"40P01": true, // deadlock_detected
+	"53300": true, // too_many_connections
+	"57P01": true, // admin_shutdown
+	"57P02": true, // crash_shutdown
+	"57P03": true, // cannot_connect_now
+	"25006": true, // read_only_sql_transaction, after failing over to a standby
+}
+
+// isRetryable returns whether err is a transient error, such as a
+// connection reset, a serialization failure or a failover of postgres.
+// The other errors, such as a constraint violation, are fatal.
+func isRetryable(err error) bool {
+	var stater sqlStater
+	if xerrors.As(err, &stater) {
+		state := stater.SQLState()
+		// Class 08 is connection exception.
+		return strings.HasPrefix(state, "08") || retryableSQLStates[state]
+	}
+	var safe interface{ SafeToRetry() bool }
+	if xerrors.As(err, &safe) && safe.SafeToRetry() {
+		return true
+	}
+	var netErr net.Error
+	if xerrors.As(err, &netErr) {
+		return true
+	}
+	return xerrors.Is(err, io.EOF) || xerrors.Is(err, io.ErrUnexpectedEOF) ||
+		xerrors.Is(err, context.DeadlineExceeded)
+}
+
+// retry calls fn with *conn until it succeeds, it fails by a fatal error,
+// or the retries run out. A closed connection is reconnected before the
+// retry, so that the writes survive a restart or a failover of postgres.
+// The duration of op including the retries is observed in OpDurations.
+// The retries on Conn hold connMu, so that the other goroutines neither use
+// it meanwhile nor see it replaced.
+func (db *DB) retry(op string, conn **pgx.Conn, fn func(conn *pgx.Conn) error) error {
+	start := time.Now()
+	defer func() {
+		d := time.Since(start)
+		observeOp(op, d)
+		if db.queries != nil && db.queries.isSlow(d) {
+			logger.Warningf("slow %s took %s", op, d.Round(time.Millisecond))
+		}
+	}()
+	db.settingsMu.RLock()
+	p := db.retryPolicy
+	db.settingsMu.RUnlock()
+	if conn == &db.Conn {
+		db.connMu.Lock()
+		defer db.connMu.Unlock()
+	}
+	for attempt := 0; ; attempt++ {
+		err := fn(*conn)
+		if err == nil {
+			return nil
+		}
+		if attempt >= p.MaxRetries || !(isRetryable(err) || (*conn).IsClosed()) {
+			return err
+		}
+		wait := p.backoff(attempt)
+		logger.Warningf("%s failed, retrying in %s (%d/%d): %v", op, wait, attempt+1, p.MaxRetries, err)
+		time.Sleep(wait)
+
+		if (*conn).IsClosed() {
+			c, err := connect(context.Background(), db.conf)
+			if err != nil {
+				logger.Warningf("could not reconnect to postgres: %v", err)
+				continue
+			}
+			*conn = c
+		}
+	}
+}
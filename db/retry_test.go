@@ -0,0 +1,86 @@
+package db
+
+import (
+	"context"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"golang.org/x/xerrors"
+
+	"github.com/yuuki/shawk/probe"
+)
+
+type fakePgError struct {
+	code string
+}
+
+func (e *fakePgError) Error() string    { return "ERROR: (SQLSTATE " + e.code + ")" }
+func (e *fakePgError) SQLState() string { return e.code }
+
+func TestIsRetryable(t *testing.T) {
+	tests := []struct {
+		desc string
+		err  error
+		want bool
+	}{
+		{"serialization failure", xerrors.Errorf("query error: %w", &fakePgError{"40001"}), true},
+		{"deadlock", &fakePgError{"40P01"}, true},
+		{"connection failure", &fakePgError{"08006"}, true},
+		{"admin shutdown", &fakePgError{"57P01"}, true},
+		{"unique violation", &fakePgError{"23505"}, false},
+		{"syntax error", &fakePgError{"42601"}, false},
+		{"connection reset", &net.OpError{Op: "read", Err: xerrors.New("connection reset by peer")}, true},
+		{"unexpected EOF", xerrors.Errorf("begin transaction error: %w", io.ErrUnexpectedEOF), true},
+		{"unknown", xerrors.New("unknown"), false},
+	}
+	for _, tt := range tests {
+		if got := isRetryable(tt.err); got != tt.want {
+			t.Errorf("isRetryable(%s) = %v, want %v", tt.desc, got, tt.want)
+		}
+	}
+}
+
+func TestRetryPolicy_backoff(t *testing.T) {
+	p := RetryPolicy{MaxRetries: 5, InitialBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+	tests := []struct {
+		attempt int
+		max     time.Duration
+	}{
+		{0, 100 * time.Millisecond},
+		{1, 200 * time.Millisecond},
+		{3, 800 * time.Millisecond},
+		{10, time.Second},
+	}
+	for _, tt := range tests {
+		got := p.backoff(tt.attempt)
+		if got < tt.max/2 || got > tt.max {
+			t.Errorf("backoff(%d) should be in [%s, %s], but %s", tt.attempt, tt.max/2, tt.max, got)
+		}
+	}
+}
+
+func TestInsertOrUpdateHostFlows_reconnect(t *testing.T) {
+	db, teardown := setupTestCase(t)
+	defer teardown(t)
+	db.SetRetryPolicy(RetryPolicy{MaxRetries: 1, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond})
+
+	// emulate a restart of postgres.
+	if err := db.Conn.Close(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	flows := []*probe.HostFlow{
+		{
+			Direction:   probe.FlowActive,
+			Local:       &probe.AddrPort{Addr: "10.0.10.1", Aggregated: true},
+			Peer:        &probe.AddrPort{Addr: "10.0.20.1", Port: 80},
+			Process:     &probe.Process{Pgid: 1000, Name: "python"},
+			Connections: 1,
+		},
+	}
+	if err := db.InsertOrUpdateHostFlows(flows); err != nil {
+		t.Fatalf("the write should be retried on a new connection: %+v", err)
+	}
+}
@@ -0,0 +1,37 @@
+package db
+
+import (
+	"context"
+
+	"golang.org/x/xerrors"
+
+	"github.com/yuuki/shawk/statik"
+)
+
+// rolesSchema defines the roles, which are optional and so not in schemas.
+const rolesSchema = "/schema/roles.sql"
+
+// The roles created by CreateRoles.
+const (
+	// ReaderRole can only read the tables and the views.
+	ReaderRole = "shawk_reader"
+	// WriterRole can write the flows, but not alter the schema.
+	WriterRole = "shawk_writer"
+)
+
+// CreateRoles creates ReaderRole and WriterRole unless they exist, and grants
+// them the tables and the views of the schema, including the ones created
+// later by the role creating them. It is called after CreateSchema and
+// CreateViews, and again after the migrations.
+func (db *DB) CreateRoles() error {
+	db.connMu.Lock()
+	defer db.connMu.Unlock()
+	sql, err := statik.FindString(rolesSchema)
+	if err != nil {
+		return xerrors.Errorf("get schema error '%s': %v", rolesSchema, err)
+	}
+	if _, err := db.Exec(context.Background(), sql); err != nil {
+		return xerrors.Errorf("exec schema error '%s': %s", rolesSchema, err)
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+package db
+
+import (
+	"context"
+	"testing"
+)
+
+func TestCreateRoles(t *testing.T) {
+	db, teardown := setupTestCase(t)
+	defer teardown(t)
+
+	// The roles are created once and granted again.
+	for i := 0; i < 2; i++ {
+		if err := db.CreateRoles(); err != nil {
+			t.Fatalf("%+v", err)
+		}
+	}
+
+	tests := []struct {
+		role, table, privilege string
+		want                   bool
+	}{
+		{ReaderRole, "flows", "SELECT", true},
+		{ReaderRole, "flows", "INSERT", false},
+		{ReaderRole, "schema_info", "UPDATE", false},
+		{WriterRole, "processes", "INSERT", true},
+		{WriterRole, "flows", "DELETE", true},
+		{WriterRole, "flows", "TRUNCATE", false},
+		{WriterRole, "agent_settings", "SELECT", true},
+		{WriterRole, "agent_settings", "INSERT", false},
+		{WriterRole, "agent_settings", "UPDATE", false},
+	}
+	for _, tt := range tests {
+		var got bool
+		err := db.QueryRow(context.Background(), `SELECT has_table_privilege($1, $2, $3)`,
+			tt.role, tt.table, tt.privilege).Scan(&got)
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		if got != tt.want {
+			t.Errorf("%s should have %s on %s: %t, but %t", tt.role, tt.privilege, tt.table, tt.want, got)
+		}
+	}
+}
@@ -0,0 +1,227 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+	"golang.org/x/xerrors"
+)
+
+// ScanRetention is how long the records of the scans are kept.
+var ScanRetention = 7 * 24 * time.Hour
+
+const (
+	insertScanSQL = `
+		INSERT INTO scans
+		(tenant, host, agent_version, backend, started, finished, flows, errors, error, backlog, memory)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+	`
+
+	// upsertAgentSQL writes the health of the agent of the host of $2 in
+	// the tenant of $1 by its last record, keeping the last success unless
+	// $8 is newer.
+	upsertAgentSQL = `
+		INSERT INTO agents
+		(tenant, host, addrs, agent_version, backend, last_record, last_success, errors, error, backlog, memory)
+		VALUES ($1, $2, $3::inet[], $4, $5, $6, $7, $8, $9, $10, $11)
+		ON CONFLICT (tenant, host) DO UPDATE SET
+			addrs=EXCLUDED.addrs,
+			agent_version=EXCLUDED.agent_version,
+			backend=EXCLUDED.backend,
+			last_record=EXCLUDED.last_record,
+			last_success=GREATEST(agents.last_success, EXCLUDED.last_success),
+			errors=EXCLUDED.errors,
+			error=EXCLUDED.error,
+			backlog=EXCLUDED.backlog,
+			memory=EXCLUDED.memory
+	`
+
+	// findAgentsSQL finds the agents of the hosts having any address of $1,
+	// or all the agents if $1 is NULL, of the tenant of $2, or of all the
+	// tenants if $2 is NULL.
+	findAgentsSQL = `
+		SELECT host, ARRAY(SELECT host(a) FROM unnest(addrs) AS a), agent_version, backend,
+			last_record, last_success, errors, error, backlog, memory
+		FROM agents
+		WHERE ($1::inet[] IS NULL OR addrs && $1::inet[]) AND ($2::varchar IS NULL OR tenant = $2)
+		ORDER BY host
+	`
+
+	deleteExpiredScansSQL = `
+		DELETE FROM scans WHERE tenant = $1 AND host = $2 AND finished <= $3
+	`
+)
+
+// Scan is the record of the flows written by an agent, which tells how
+// fresh the flows of the host are and how healthy its collection is.
+type Scan struct {
+	Host         string
+	AgentVersion string
+	// Backend is how the agent read the flows, such as netlink.
+	Backend string
+	// Started and Finished are the period of the scans written, which
+	// starts when the last record finished.
+	Started  time.Time
+	Finished time.Time
+	Flows    int
+	// Errors is the number of the scans and the writes failed in the
+	// period, and Error is the error of the write if it failed.
+	Errors int
+	Error  string
+	// Addrs are the addresses of the host, whose flows the agent writes.
+	Addrs []net.IP
+	// LastSuccess is when the agent last scanned the flows successfully,
+	// or zero if never.
+	LastSuccess time.Time
+	// Backlog is the number of the flows, or the batches of them, waiting
+	// to be written, and Memory is the bytes of the memory of the agent
+	// obtained from the OS.
+	Backlog int
+	Memory  uint64
+}
+
+// AgentHealth is the health of an agent by its last record of the scans.
+type AgentHealth struct {
+	Host         string
+	Addrs        []net.IP
+	AgentVersion string
+	Backend      string
+	// LastRecord is when the agent last wrote the record of the scans, and
+	// LastSuccess is when it last scanned the flows successfully, or zero
+	// if never.
+	LastRecord  time.Time
+	LastSuccess time.Time
+	// Errors is the number of the scans and the writes failed in the last
+	// period, and Error is the error of the last write.
+	Errors  int
+	Error   string
+	Backlog int
+	Memory  uint64
+}
+
+// Problem returns why the agent is unhealthy at now, or empty if healthy.
+// The agent is unhealthy if it has written no record or scanned the flows
+// successfully within staleAfter, or its last write or scans failed.
+func (h *AgentHealth) Problem(now time.Time, staleAfter time.Duration) string {
+	switch {
+	case now.Sub(h.LastRecord) > staleAfter:
+		return fmt.Sprintf("no record for %s", now.Sub(h.LastRecord).Round(time.Second))
+	case h.Error != "":
+		return "write failed: " + h.Error
+	case h.LastSuccess.IsZero():
+		return "no scan succeeded"
+	case now.Sub(h.LastSuccess) > staleAfter:
+		return fmt.Sprintf("no scan succeeded for %s", now.Sub(h.LastSuccess).Round(time.Second))
+	case h.Errors > 0:
+		return fmt.Sprintf("%d scans failed", h.Errors)
+	}
+	return ""
+}
+
+// AgentHealthReader is the CMDB reading the health of the agents written
+// with the records of the scans.
+type AgentHealthReader interface {
+	// FindAgentHealth returns the health of the agents of the hosts having
+	// any of addrs, or of all the agents if addrs is nil, in the order of
+	// the hosts.
+	FindAgentHealth(addrs []net.IP) ([]*AgentHealth, error)
+}
+
+var (
+	_ AgentHealthReader = (*DB)(nil)
+	_ AgentHealthReader = (*Shards)(nil)
+)
+
+// ScanWriter is the CMDB writing the records of the scans.
+type ScanWriter interface {
+	InsertScan(scan *Scan) error
+}
+
+var (
+	_ ScanWriter = (*DB)(nil)
+	_ ScanWriter = (*Shards)(nil)
+)
+
+// InsertScan writes the record of the scan with the health of the agent, and
+// deletes the expired ones of the host.
+func (db *DB) InsertScan(scan *Scan) error {
+	return db.retry("write scan", &db.Conn, func(conn *pgx.Conn) error {
+		ctx, cancel := context.WithTimeout(context.Background(), InsertOrUpdateTimeoutSec*time.Second)
+		defer cancel()
+
+		tx, err := conn.Begin(ctx)
+		if err != nil {
+			return xerrors.Errorf("begin transaction error: %w", err)
+		}
+		defer tx.Rollback(ctx)
+
+		_, err = tx.Exec(ctx, insertScanSQL, db.tenant, scan.Host, scan.AgentVersion, scan.Backend,
+			scan.Started, scan.Finished, scan.Flows, scan.Errors, scan.Error, scan.Backlog, int64(scan.Memory))
+		if err != nil {
+			return xerrors.Errorf("insert scan error: %w", err)
+		}
+		var lastSuccess interface{}
+		if !scan.LastSuccess.IsZero() {
+			lastSuccess = scan.LastSuccess
+		}
+		_, err = tx.Exec(ctx, upsertAgentSQL, db.tenant, scan.Host, addrsArg(scan.Addrs), scan.AgentVersion, scan.Backend,
+			scan.Finished, lastSuccess, scan.Errors, scan.Error, scan.Backlog, int64(scan.Memory))
+		if err != nil {
+			return xerrors.Errorf("upsert agent error: %w", err)
+		}
+		if _, err := tx.Exec(ctx, deleteExpiredScansSQL, db.tenant, scan.Host, time.Now().Add(-ScanRetention)); err != nil {
+			return xerrors.Errorf("delete expired scans error: %w", err)
+		}
+		if err := tx.Commit(ctx); err != nil {
+			return xerrors.Errorf("commit error: %w", err)
+		}
+		return nil
+	})
+}
+
+// FindAgentHealth returns the health of the agents of the hosts having any of
+// addrs, or of all the agents if addrs is nil, of the tenant, or of all the
+// tenants in the admin mode.
+func (db *DB) FindAgentHealth(addrs []net.IP) ([]*AgentHealth, error) {
+	var addrsText interface{}
+	if addrs != nil {
+		addrsText = addrsArg(addrs)
+	}
+	var agents []*AgentHealth
+	err := db.retry("find agent health", &db.Conn, func(conn *pgx.Conn) error {
+		agents = []*AgentHealth{}
+		rows, err := conn.Query(context.Background(), findAgentsSQL, addrsText, db.tenantScope())
+		if err != nil {
+			return xerrors.Errorf("find agents query error: %w", err)
+		}
+		defer rows.Close()
+		for rows.Next() {
+			var (
+				h           AgentHealth
+				hostAddrs   []string
+				lastSuccess *time.Time
+				memory      int64
+			)
+			if err := rows.Scan(&h.Host, &hostAddrs, &h.AgentVersion, &h.Backend, &h.LastRecord, &lastSuccess,
+				&h.Errors, &h.Error, &h.Backlog, &memory); err != nil {
+				return xerrors.Errorf("scan agents error: %w", err)
+			}
+			for _, a := range hostAddrs {
+				h.Addrs = append(h.Addrs, net.ParseIP(a))
+			}
+			if lastSuccess != nil {
+				h.LastSuccess = *lastSuccess
+			}
+			h.Memory = uint64(memory)
+			agents = append(agents, &h)
+		}
+		if err := rows.Err(); err != nil {
+			return xerrors.Errorf("find agents query error: %w", err)
+		}
+		return nil
+	})
+	return agents, err
+}
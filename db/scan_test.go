@@ -0,0 +1,91 @@
+package db
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+)
+
+func TestInsertScan(t *testing.T) {
+	db, teardown := setupTestCase(t)
+	defer teardown(t)
+
+	now := time.Now()
+	scans := []*Scan{
+		// expired
+		{Host: "web-1", Backend: "netlink", Started: now.Add(-ScanRetention - time.Hour), Finished: now.Add(-ScanRetention - time.Minute)},
+		{
+			Host: "web-1", AgentVersion: "0.7.1", Backend: "netlink", Started: now.Add(-time.Minute), Finished: now, Flows: 3, Errors: 1, Error: "connection refused",
+			Addrs: []net.IP{net.ParseIP("10.0.0.5"), net.ParseIP("10.0.1.5")}, LastSuccess: now.Add(-30 * time.Second), Backlog: 2, Memory: 64 << 20,
+		},
+	}
+	for _, scan := range scans {
+		if err := db.InsertScan(scan); err != nil {
+			t.Fatalf("%+v", err)
+		}
+	}
+
+	var (
+		n   int
+		got Scan
+	)
+	err := db.QueryRow(context.Background(), `
+		SELECT count(*) OVER (), host, agent_version, backend, flows, errors, error
+		FROM scans WHERE host = 'web-1'
+	`).Scan(&n, &got.Host, &got.AgentVersion, &got.Backend, &got.Flows, &got.Errors, &got.Error)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if n != 1 {
+		t.Errorf("the expired scan should be deleted, but %d scans", n)
+	}
+	want := scans[1]
+	if got.AgentVersion != want.AgentVersion || got.Flows != want.Flows || got.Errors != want.Errors || got.Error != want.Error {
+		t.Errorf("InsertScan() wrote %+v, want %+v", got, want)
+	}
+
+	agents, err := db.FindAgentHealth([]net.IP{net.ParseIP("10.0.1.5")})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if len(agents) != 1 {
+		t.Fatalf("FindAgentHealth() should find the agent of web-1, but %+v", agents)
+	}
+	if h := agents[0]; h.Host != "web-1" || len(h.Addrs) != 2 || h.LastSuccess.IsZero() || h.Backlog != 2 || h.Memory != 64<<20 || h.Error != want.Error {
+		t.Errorf("FindAgentHealth() = %+v, want the health of the last scan %+v", h, want)
+	}
+	agents, err = db.FindAgentHealth([]net.IP{net.ParseIP("10.0.2.5")})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if len(agents) != 0 {
+		t.Errorf("FindAgentHealth() should find no agent of 10.0.2.5, but %+v", agents)
+	}
+}
+
+func TestAgentHealth_Problem(t *testing.T) {
+	now := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	healthy := AgentHealth{LastRecord: now.Add(-30 * time.Second), LastSuccess: now.Add(-30 * time.Second)}
+	tests := []struct {
+		desc   string
+		modify func(h *AgentHealth)
+		want   string
+	}{
+		{desc: "healthy", modify: func(h *AgentHealth) {}},
+		{desc: "no record", modify: func(h *AgentHealth) { h.LastRecord = now.Add(-10 * time.Minute) }, want: "no record for 10m0s"},
+		{desc: "write failed", modify: func(h *AgentHealth) { h.Error = "connection refused" }, want: "write failed: connection refused"},
+		{desc: "never scanned", modify: func(h *AgentHealth) { h.LastSuccess = time.Time{} }, want: "no scan succeeded"},
+		{desc: "scans failing", modify: func(h *AgentHealth) { h.LastSuccess = now.Add(-5 * time.Minute) }, want: "no scan succeeded for 5m0s"},
+		{desc: "scan failed", modify: func(h *AgentHealth) { h.Errors = 2 }, want: "2 scans failed"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			h := healthy
+			tt.modify(&h)
+			if got := h.Problem(now, 90*time.Second); got != tt.want {
+				t.Errorf("Problem() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
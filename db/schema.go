@@ -0,0 +1,75 @@
+package db
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v4"
+	"golang.org/x/xerrors"
+)
+
+// SchemaVersion is the version of the schema in assets/schema, which is
+// recorded into schema_info by CreateSchema.
+//
+// Version 1 is the schema created before schema_info.
+// Version 2 adds the labels of the processes.
+// Version 3 adds the notifications of the flows removed.
+// Version 4 aligns the indexes with the queries.
+// Version 5 adds the tenants of the processes and the flows.
+// Version 6 adds the translations of the NAT gateways.
+// Version 7 adds the socket queues of the listeners and the flows.
+// Version 8 adds the congestion control algorithms of the flows.
+// Version 9 adds the process groups sharing the sockets of the processes.
+// Version 10 adds the ages of the connections of the flows.
+// Version 11 adds the VRFs of the processes.
+// Version 12 adds the subflows of the MPTCP connections of the flows.
+// Version 13 adds the records of the scans of the agents.
+// Version 14 adds the aliases of the addresses merged into the others.
+// Version 15 adds the annotations of the edges.
+// Version 16 adds the progress of the syncs from the other CMDBs.
+// Version 17 adds the health of the agents.
+// Version 18 adds the settings of the agents.
+// Version 19 adds the VRFs of the annotations of the edges.
+const SchemaVersion = 19
+
+// undefinedTable is the SQLSTATE of undefined_table.
+const undefinedTable = "42P01"
+
+// FindSchemaVersion returns the latest version of the schema created in the
+// CMDB. It returns 1 for the schema created before schema_info.
+func (db *DB) FindSchemaVersion() (int, error) {
+	var version int
+	err := db.retry("FindSchemaVersion", &db.Conn, func(conn *pgx.Conn) error {
+		return conn.QueryRow(context.Background(),
+			`SELECT COALESCE(MAX(version), 1) FROM schema_info`).Scan(&version)
+	})
+	var stater sqlStater
+	if xerrors.As(err, &stater) && stater.SQLState() == undefinedTable {
+		return 1, nil
+	}
+	if err != nil {
+		return 0, xerrors.Errorf("query schema version error: %w", err)
+	}
+	return version, nil
+}
+
+// CheckSchemaVersion returns an error if the schema in the CMDB is not
+// compatible with this shawk.
+func (db *DB) CheckSchemaVersion() error {
+	version, err := db.FindSchemaVersion()
+	if err != nil {
+		return err
+	}
+	return checkSchemaVersion(version)
+}
+
+func checkSchemaVersion(version int) error {
+	switch {
+	case version < SchemaVersion:
+		return xerrors.Errorf("the CMDB schema is version %d, but this shawk requires version %d: run 'shawk create-scheme' to migrate it",
+			version, SchemaVersion)
+	case version > SchemaVersion:
+		return xerrors.Errorf("the CMDB schema is version %d, which is newer than version %d supported by this shawk: upgrade shawk",
+			version, SchemaVersion)
+	}
+	return nil
+}
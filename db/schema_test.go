@@ -0,0 +1,20 @@
+package db
+
+import "testing"
+
+func TestCheckSchemaVersion(t *testing.T) {
+	tests := []struct {
+		version int
+		wantErr bool
+	}{
+		{SchemaVersion - 1, true},
+		{SchemaVersion, false},
+		{SchemaVersion + 1, true},
+	}
+	for _, tt := range tests {
+		err := checkSchemaVersion(tt.version)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("checkSchemaVersion(%d) = %v, wantErr %v", tt.version, err, tt.wantErr)
+		}
+	}
+}
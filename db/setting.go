@@ -0,0 +1,138 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+	"golang.org/x/xerrors"
+)
+
+const (
+	// upsertAgentSettingSQL sets the setting of $3 to $4 for the agent of
+	// the host of $2, or for all the agents if $2 is empty, in the tenant
+	// of $1.
+	upsertAgentSettingSQL = `
+		INSERT INTO agent_settings (tenant, host, name, value) VALUES ($1, $2, $3, $4)
+		ON CONFLICT (tenant, host, name) DO UPDATE SET value = EXCLUDED.value, updated = CURRENT_TIMESTAMP
+	`
+
+	// deleteAgentSettingSQL removes the setting of $3 of the host of $2 in
+	// the tenant of $1.
+	deleteAgentSettingSQL = `
+		DELETE FROM agent_settings WHERE tenant = $1 AND host = $2 AND name = $3
+	`
+
+	// findAgentSettingsSQL finds the settings of the tenant of $1 for all
+	// the agents and for the agent of the host of $2, or the settings of
+	// all the hosts if $2 is NULL.
+	findAgentSettingsSQL = `
+		SELECT host, name, value, updated FROM agent_settings
+		WHERE tenant = $1 AND ($2::varchar IS NULL OR host = '' OR host = $2)
+		ORDER BY host, name
+	`
+)
+
+// AgentSetting is a setting of the agents managed in the CMDB, which is an
+// environment variable such as SHAWK_PROBE_IGNORE_PORTS. The agents apply
+// it over their environment on start and when it is changed.
+type AgentSetting struct {
+	// Host is the host of the agent of the setting, or empty for all the
+	// agents. The setting of the host overrides the one of all the agents.
+	Host    string    `json:"host"`
+	Name    string    `json:"name"`
+	Value   string    `json:"value"`
+	Updated time.Time `json:"updated"`
+}
+
+// AgentSettingStore is the CMDB managing the settings of the agents.
+type AgentSettingStore interface {
+	SetAgentSetting(s *AgentSetting) error
+	// UnsetAgentSetting removes the setting of the name of the host.
+	UnsetAgentSetting(host, name string) error
+	// FindAgentSettings returns the settings for all the agents and for
+	// the agent of the host, or the settings of all the hosts if host is
+	// empty, in the order of the hosts and the names.
+	FindAgentSettings(host string) ([]*AgentSetting, error)
+}
+
+var (
+	_ AgentSettingStore = (*DB)(nil)
+	_ AgentSettingStore = (*Shards)(nil)
+)
+
+// SetAgentSetting sets the setting in the tenant.
+func (db *DB) SetAgentSetting(s *AgentSetting) error {
+	return db.retry("set agent setting", &db.Conn, func(conn *pgx.Conn) error {
+		ctx, cancel := context.WithTimeout(context.Background(), InsertOrUpdateTimeoutSec*time.Second)
+		defer cancel()
+
+		if _, err := conn.Exec(ctx, upsertAgentSettingSQL, db.tenant, s.Host, s.Name, s.Value); err != nil {
+			return xerrors.Errorf("upsert agent setting error: %w", err)
+		}
+		return nil
+	})
+}
+
+// UnsetAgentSetting removes the setting of the name of the host in the
+// tenant.
+func (db *DB) UnsetAgentSetting(host, name string) error {
+	return db.retry("unset agent setting", &db.Conn, func(conn *pgx.Conn) error {
+		ctx, cancel := context.WithTimeout(context.Background(), InsertOrUpdateTimeoutSec*time.Second)
+		defer cancel()
+
+		if _, err := conn.Exec(ctx, deleteAgentSettingSQL, db.tenant, host, name); err != nil {
+			return xerrors.Errorf("delete agent setting error: %w", err)
+		}
+		return nil
+	})
+}
+
+// FindAgentSettings returns the settings of the tenant for all the agents
+// and for the agent of the host, or the settings of all the hosts if host is
+// empty.
+func (db *DB) FindAgentSettings(host string) ([]*AgentSetting, error) {
+	var hostArg interface{}
+	if host != "" {
+		hostArg = host
+	}
+	var settings []*AgentSetting
+	err := db.retry("find agent settings", &db.Conn, func(conn *pgx.Conn) error {
+		settings = []*AgentSetting{}
+		rows, err := conn.Query(context.Background(), findAgentSettingsSQL, db.tenant, hostArg)
+		if err != nil {
+			return xerrors.Errorf("find agent settings query error: %w", err)
+		}
+		defer rows.Close()
+		for rows.Next() {
+			var s AgentSetting
+			if err := rows.Scan(&s.Host, &s.Name, &s.Value, &s.Updated); err != nil {
+				return xerrors.Errorf("scan agent settings error: %w", err)
+			}
+			settings = append(settings, &s)
+		}
+		if err := rows.Err(); err != nil {
+			return xerrors.Errorf("find agent settings query error: %w", err)
+		}
+		return nil
+	})
+	return settings, err
+}
+
+// EffectiveAgentSettings returns the values of the settings of the agent of
+// the host by their names, where the settings of the host override the ones
+// of all the agents.
+func EffectiveAgentSettings(settings []*AgentSetting, host string) map[string]string {
+	values := make(map[string]string, len(settings))
+	for _, s := range settings {
+		if s.Host == "" {
+			values[s.Name] = s.Value
+		}
+	}
+	for _, s := range settings {
+		if s.Host != "" && s.Host == host {
+			values[s.Name] = s.Value
+		}
+	}
+	return values
+}
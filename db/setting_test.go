@@ -0,0 +1,59 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestAgentSettings(t *testing.T) {
+	db, teardown := setupTestCase(t)
+	defer teardown(t)
+
+	for _, s := range []*AgentSetting{
+		{Name: "SHAWK_PROBE_IGNORE_PORTS", Value: "9100"},
+		{Name: "SHAWK_PROBE_INTERVAL", Value: "5s"},
+		{Host: "web-1", Name: "SHAWK_PROBE_INTERVAL", Value: "1s"},
+		{Host: "web-2", Name: "SHAWK_LOG_LEVEL", Value: "debug"},
+	} {
+		if err := db.SetAgentSetting(s); err != nil {
+			t.Fatalf("%+v", err)
+		}
+	}
+	if err := db.UnsetAgentSetting("", "SHAWK_PROBE_IGNORE_PORTS"); err != nil {
+		t.Fatalf("%+v", err)
+	}
+
+	find := func(host string) []string {
+		t.Helper()
+		settings, err := db.FindAgentSettings(host)
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		var got []string
+		for _, s := range settings {
+			got = append(got, s.Host+" "+s.Name+"="+s.Value)
+		}
+		return got
+	}
+	want := []string{" SHAWK_PROBE_INTERVAL=5s", "web-1 SHAWK_PROBE_INTERVAL=1s"}
+	if diff := cmp.Diff(want, find("web-1")); diff != "" {
+		t.Errorf("FindAgentSettings() mismatch (-want +got):\n%s", diff)
+	}
+	if got := find(""); len(got) != 3 {
+		t.Errorf("FindAgentSettings() should find the settings of all the hosts, but %v", got)
+	}
+}
+
+func TestEffectiveAgentSettings(t *testing.T) {
+	settings := []*AgentSetting{
+		{Name: "SHAWK_PROBE_IGNORE_PORTS", Value: "9100"},
+		{Name: "SHAWK_PROBE_INTERVAL", Value: "5s"},
+		{Host: "web-1", Name: "SHAWK_PROBE_INTERVAL", Value: "1s"},
+		{Host: "web-2", Name: "SHAWK_LOG_LEVEL", Value: "debug"},
+	}
+	want := map[string]string{"SHAWK_PROBE_IGNORE_PORTS": "9100", "SHAWK_PROBE_INTERVAL": "1s"}
+	if diff := cmp.Diff(want, EffectiveAgentSettings(settings, "web-1")); diff != "" {
+		t.Errorf("EffectiveAgentSettings() mismatch (-want +got):\n%s", diff)
+	}
+}
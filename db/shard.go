@@ -0,0 +1,531 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"net"
+	"sort"
+	"sync"
+
+	"golang.org/x/xerrors"
+
+	"github.com/yuuki/shawk/probe"
+)
+
+// Shards is the Store on several databases beyond the write capacity of
+// one. The flows are written into the shard of the host observing them by
+// its hash, and the queries fan out to all the shards and merge the
+// results. The order of the shards must be kept, since adding or reordering
+// them moves the hosts to the other shards.
+type Shards struct {
+	stores []Store
+}
+
+var _ Store = (*Shards)(nil)
+
+// NewShards returns the Store on the stores, which are the shards in order.
+func NewShards(stores []Store) *Shards {
+	return &Shards{stores: stores}
+}
+
+// Stores returns the shards in order.
+func (s *Shards) Stores() []Store {
+	return s.stores
+}
+
+// shardOf returns the index of the shard of the flow, which is of the host
+// observing it, so that the flows of a host are in a shard.
+func (s *Shards) shardOf(flow *probe.HostFlow) int {
+	h := fnv.New32a()
+	h.Write([]byte(flow.Local.Addr))
+	return int(h.Sum32() % uint32(len(s.stores)))
+}
+
+// each calls fn with each shard concurrently, and returns the first error.
+func (s *Shards) each(fn func(i int, store Store) error) error {
+	errs := make([]error, len(s.stores))
+	var wg sync.WaitGroup
+	for i, store := range s.stores {
+		wg.Add(1)
+		go func(i int, store Store) {
+			defer wg.Done()
+			errs[i] = fn(i, store)
+		}(i, store)
+	}
+	wg.Wait()
+	for i, err := range errs {
+		if err != nil {
+			return xerrors.Errorf("shard %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// CreateSchema creates the tables in all the shards.
+func (s *Shards) CreateSchema() error {
+	return s.each(func(_ int, store Store) error {
+		return store.CreateSchema()
+	})
+}
+
+// CheckSchemaVersion returns an error if the schema of any shard is not of
+// SchemaVersion.
+func (s *Shards) CheckSchemaVersion() error {
+	return s.each(func(_ int, store Store) error {
+		return store.CheckSchemaVersion()
+	})
+}
+
+// InsertOrUpdateHostFlows writes the flows into their shards concurrently.
+func (s *Shards) InsertOrUpdateHostFlows(flows []*probe.HostFlow) error {
+	groups := make([][]*probe.HostFlow, len(s.stores))
+	for _, f := range flows {
+		i := s.shardOf(f)
+		groups[i] = append(groups[i], f)
+	}
+	return s.each(func(i int, store Store) error {
+		if len(groups[i]) == 0 {
+			return nil
+		}
+		return store.InsertOrUpdateHostFlows(groups[i])
+	})
+}
+
+// InsertOrUpdateNATs writes the translations into all the shards, since the
+// flows of the clients and of the servers through the NAT gateway may be in
+// any shard.
+func (s *Shards) InsertOrUpdateNATs(nats []*probe.NAT) error {
+	return s.each(func(i int, store Store) error {
+		w, ok := store.(NATWriter)
+		if !ok {
+			return xerrors.New("the CMDB doesn't translate the flows through NAT")
+		}
+		return w.InsertOrUpdateNATs(nats)
+	})
+}
+
+// InsertScan writes the record of the scan into the first shard, since the
+// flows of a host with several addresses may be in several shards.
+func (s *Shards) InsertScan(scan *Scan) error {
+	w, ok := s.stores[0].(ScanWriter)
+	if !ok {
+		return xerrors.New("the CMDB doesn't record the scans")
+	}
+	if err := w.InsertScan(scan); err != nil {
+		return xerrors.Errorf("shard 0: %w", err)
+	}
+	return nil
+}
+
+// FindAgentHealth reads the health of the agents from the first shard, where
+// InsertScan writes it.
+func (s *Shards) FindAgentHealth(addrs []net.IP) ([]*AgentHealth, error) {
+	r, ok := s.stores[0].(AgentHealthReader)
+	if !ok {
+		return nil, xerrors.New("the CMDB doesn't record the health of the agents")
+	}
+	agents, err := r.FindAgentHealth(addrs)
+	if err != nil {
+		return nil, xerrors.Errorf("shard 0: %w", err)
+	}
+	return agents, nil
+}
+
+// SetAgentSetting sets the setting in the first shard, which the agents
+// read the settings from.
+func (s *Shards) SetAgentSetting(setting *AgentSetting) error {
+	st, ok := s.stores[0].(AgentSettingStore)
+	if !ok {
+		return xerrors.New("the CMDB doesn't manage the settings of the agents")
+	}
+	if err := st.SetAgentSetting(setting); err != nil {
+		return xerrors.Errorf("shard 0: %w", err)
+	}
+	return nil
+}
+
+// UnsetAgentSetting removes the setting from the first shard.
+func (s *Shards) UnsetAgentSetting(host, name string) error {
+	st, ok := s.stores[0].(AgentSettingStore)
+	if !ok {
+		return xerrors.New("the CMDB doesn't manage the settings of the agents")
+	}
+	if err := st.UnsetAgentSetting(host, name); err != nil {
+		return xerrors.Errorf("shard 0: %w", err)
+	}
+	return nil
+}
+
+// FindAgentSettings reads the settings from the first shard.
+func (s *Shards) FindAgentSettings(host string) ([]*AgentSetting, error) {
+	st, ok := s.stores[0].(AgentSettingStore)
+	if !ok {
+		return nil, xerrors.New("the CMDB doesn't manage the settings of the agents")
+	}
+	settings, err := st.FindAgentSettings(host)
+	if err != nil {
+		return nil, xerrors.Errorf("shard 0: %w", err)
+	}
+	return settings, nil
+}
+
+// DeleteProcesses deletes the processes in the prefixes from all the shards,
+// since a process is in every shard of the flows from or to it. Each shard
+// deletes them in a transaction of its own.
+func (s *Shards) DeleteProcesses(prefixes []*net.IPNet, dryRun bool) ([]*DeletedProcess, error) {
+	results := make([][]*DeletedProcess, len(s.stores))
+	err := s.each(func(i int, store Store) error {
+		d, ok := store.(Deleter)
+		if !ok {
+			return xerrors.New("the CMDB doesn't delete the processes")
+		}
+		deleted, err := d.DeleteProcesses(prefixes, dryRun)
+		results[i] = deleted
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	type key struct {
+		addr, pname string
+		pgid        int
+	}
+	merged := make(map[key]*DeletedProcess)
+	var deleted []*DeletedProcess
+	for _, ps := range results {
+		for _, p := range ps {
+			k := key{p.Addr, p.Pname, p.Pgid}
+			if m, ok := merged[k]; ok {
+				m.Flows += p.Flows
+				continue
+			}
+			c := *p
+			merged[k] = &c
+			deleted = append(deleted, &c)
+		}
+	}
+	sort.SliceStable(deleted, func(i, j int) bool {
+		a, b := deleted[i], deleted[j]
+		if a.Addr != b.Addr {
+			return a.Addr < b.Addr
+		}
+		if a.Pname != b.Pname {
+			return a.Pname < b.Pname
+		}
+		return a.Pgid < b.Pgid
+	})
+	return deleted, nil
+}
+
+// MergeNodes merges the nodes in all the shards, since the processes of the
+// address are in every shard of the flows from or to them. Each shard merges
+// them in a transaction of its own.
+func (s *Shards) MergeNodes(from, to net.IP, dryRun bool) (*MergeResult, error) {
+	results := make([]*MergeResult, len(s.stores))
+	err := s.each(func(i int, store Store) error {
+		m, ok := store.(Merger)
+		if !ok {
+			return xerrors.New("the CMDB doesn't merge the nodes")
+		}
+		result, err := m.MergeNodes(from, to, dryRun)
+		results[i] = result
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	sum := &MergeResult{}
+	for _, r := range results {
+		sum.MovedProcesses += r.MovedProcesses
+		sum.MergedProcesses += r.MergedProcesses
+		sum.MovedFlows += r.MovedFlows
+		sum.MergedFlows += r.MergedFlows
+	}
+	return sum, nil
+}
+
+// FindNodeAlias returns the address that addr was merged into in any shard.
+func (s *Shards) FindNodeAlias(addr net.IP) (net.IP, error) {
+	aliases := make([]net.IP, len(s.stores))
+	err := s.each(func(i int, store Store) error {
+		m, ok := store.(Merger)
+		if !ok {
+			return nil
+		}
+		alias, err := m.FindNodeAlias(addr)
+		aliases[i] = alias
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, alias := range aliases {
+		if alias != nil {
+			return alias, nil
+		}
+	}
+	return nil, nil
+}
+
+// Annotate annotates the edge in all the shards, since the flows of the
+// edge may be in any shard of the hosts observing them.
+func (s *Shards) Annotate(a *Annotation) error {
+	return s.each(func(i int, store Store) error {
+		an, ok := store.(Annotator)
+		if !ok {
+			return xerrors.New("the CMDB doesn't annotate the edges")
+		}
+		return an.Annotate(a)
+	})
+}
+
+// FindAnnotations returns the annotations of the first shard, since all
+// the shards have the same ones.
+func (s *Shards) FindAnnotations() ([]*Annotation, error) {
+	an, ok := s.stores[0].(Annotator)
+	if !ok {
+		return nil, xerrors.New("the CMDB doesn't annotate the edges")
+	}
+	annotations, err := an.FindAnnotations()
+	if err != nil {
+		return nil, xerrors.Errorf("shard 0: %w", err)
+	}
+	return annotations, nil
+}
+
+// FindPassiveFlows merges the passive flows of all the shards.
+func (s *Shards) FindPassiveFlows(cond *FindFlowsCond) (Flows, error) {
+	return s.findFlows(func(store Store) (Flows, error) {
+		c := *cond
+		return store.FindPassiveFlows(&c)
+	})
+}
+
+// FindActiveFlows merges the active flows of all the shards.
+func (s *Shards) FindActiveFlows(cond *FindFlowsCond) (Flows, error) {
+	return s.findFlows(func(store Store) (Flows, error) {
+		c := *cond
+		return store.FindActiveFlows(&c)
+	})
+}
+
+// findFlows merges the flows of the shards by their groups. A flow found in
+// several shards, such as the flow of a host moved to another shard, is
+// merged into the one with the most connections.
+func (s *Shards) findFlows(find func(store Store) (Flows, error)) (Flows, error) {
+	results := make([]Flows, len(s.stores))
+	err := s.each(func(i int, store Store) error {
+		flows, err := find(store)
+		results[i] = flows
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	merged := make(Flows)
+	seen := make(map[string]*Flow)
+	for _, flows := range results {
+		for key, group := range flows {
+			for _, f := range group {
+				k := key + " " + flowKey(f)
+				if prev, ok := seen[k]; ok {
+					if f.Connections > prev.Connections {
+						*prev = *f
+					}
+					continue
+				}
+				seen[k] = f
+				merged[key] = append(merged[key], f)
+			}
+		}
+	}
+	return merged, nil
+}
+
+// flowKey returns the identity of the flow across the shards, whose
+// addresses are qualified by their VRFs.
+func flowKey(f *Flow) string {
+	return fmt.Sprintf("%s %s %s %d|%s:%d %s %d", f.Tenant,
+		f.ActiveNode.Addr(), f.ActiveNode.Pname, f.ActiveNode.Pgid,
+		f.PassiveNode.Addr(), f.PassiveNode.Port, f.PassiveNode.Pname, f.PassiveNode.Pgid)
+}
+
+// ListFlows returns a page of the flows of all the shards. The IDs of the
+// flows are unique across the shards by interleaving them: the ID of the
+// shard i of n is the ID in the shard times n plus i. The order of the IDs
+// of each shard is kept, so that the pages are found by the IDs as well.
+func (s *Shards) ListFlows(cond *ListFlowsCond) ([]*Flow, error) {
+	n := int64(len(s.stores))
+	limit := cond.Limit
+	if limit <= 0 {
+		limit = DefaultListLimit
+	}
+	results := make([][]*Flow, len(s.stores))
+	err := s.each(func(i int, store Store) error {
+		c := *cond
+		c.Limit = limit
+		c.After = 0
+		if cond.After >= int64(i) {
+			c.After = (cond.After - int64(i)) / n
+		}
+		flows, err := store.ListFlows(&c)
+		for _, f := range flows {
+			f.ID = f.ID*n + int64(i)
+		}
+		results[i] = flows
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	var merged []*Flow
+	for _, flows := range results {
+		merged = append(merged, flows...)
+	}
+	sort.Slice(merged, func(i, j int) bool { return merged[i].ID < merged[j].ID })
+	if len(merged) > limit {
+		merged = merged[:limit]
+	}
+	return merged, nil
+}
+
+// FindTopTalkers sums up the talkers of all the shards by their addresses
+// qualified by their VRFs and names.
+func (s *Shards) FindTopTalkers(cond *AggregateCond) ([]*Talker, error) {
+	results := make([][]*Talker, len(s.stores))
+	err := s.each(func(i int, store Store) error {
+		c := *cond
+		c.Limit = 0 // the top of a shard may not be the top of all
+		talkers, err := store.FindTopTalkers(&c)
+		results[i] = talkers
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	byKey := make(map[string]*Talker)
+	var talkers []*Talker
+	for _, ts := range results {
+		for _, t := range ts {
+			k := vrfAddr(t.IPAddr, t.VRF) + " " + t.Pname
+			if sum, ok := byKey[k]; ok {
+				sum.Connections += t.Connections
+				sum.Flows += t.Flows
+				continue
+			}
+			byKey[k] = t
+			talkers = append(talkers, t)
+		}
+	}
+	sort.Slice(talkers, func(i, j int) bool {
+		a, b := talkers[i], talkers[j]
+		if a.Connections != b.Connections {
+			return a.Connections > b.Connections
+		}
+		if !a.IPAddr.Equal(b.IPAddr) {
+			return a.IPAddr.String() < b.IPAddr.String()
+		}
+		if a.VRF != b.VRF {
+			return a.VRF < b.VRF
+		}
+		return a.Pname < b.Pname
+	})
+	if cond.Limit > 0 && len(talkers) > cond.Limit {
+		talkers = talkers[:cond.Limit]
+	}
+	return talkers, nil
+}
+
+// FindServiceEdges sums up the edges of all the shards by the services and
+// the ports. The clients and the servers are summed up as well, which
+// counts an address twice if it is in two shards.
+func (s *Shards) FindServiceEdges(cond *AggregateCond) ([]*ServiceEdge, error) {
+	results := make([][]*ServiceEdge, len(s.stores))
+	err := s.each(func(i int, store Store) error {
+		c := *cond
+		c.Limit = 0
+		edges, err := store.FindServiceEdges(&c)
+		results[i] = edges
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	byKey := make(map[string]*ServiceEdge)
+	var edges []*ServiceEdge
+	for _, es := range results {
+		for _, e := range es {
+			k := fmt.Sprintf("%s %s %d", e.Client, e.Server, e.Port)
+			if sum, ok := byKey[k]; ok {
+				sum.Connections += e.Connections
+				sum.Clients += e.Clients
+				sum.Servers += e.Servers
+				continue
+			}
+			byKey[k] = e
+			edges = append(edges, e)
+		}
+	}
+	sort.Slice(edges, func(i, j int) bool {
+		a, b := edges[i], edges[j]
+		if a.Connections != b.Connections {
+			return a.Connections > b.Connections
+		}
+		if a.Client != b.Client {
+			return a.Client < b.Client
+		}
+		if a.Server != b.Server {
+			return a.Server < b.Server
+		}
+		return a.Port < b.Port
+	})
+	if cond.Limit > 0 && len(edges) > cond.Limit {
+		edges = edges[:cond.Limit]
+	}
+	return edges, nil
+}
+
+// Listen calls fn with each change of the flows of all the shards until ctx
+// is done, fn returns an error, or the listening to a shard fails. fn is
+// not called concurrently.
+func (s *Shards) Listen(ctx context.Context, fn func(*Change) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var mu sync.Mutex
+	errc := make(chan error, len(s.stores))
+	for i, store := range s.stores {
+		l, ok := store.(interface {
+			Listen(ctx context.Context, fn func(*Change) error) error
+		})
+		if !ok {
+			errc <- xerrors.Errorf("shard %d doesn't notify the changes", i)
+			continue
+		}
+		go func(i int) {
+			err := l.Listen(ctx, func(c *Change) error {
+				mu.Lock()
+				defer mu.Unlock()
+				return fn(c)
+			})
+			if err != nil && ctx.Err() == nil {
+				err = xerrors.Errorf("shard %d: %w", i, err)
+			}
+			errc <- err
+		}(i)
+	}
+	// The first error stops the others.
+	err := <-errc
+	cancel()
+	for range s.stores[1:] {
+		<-errc
+	}
+	return err
+}
+
+// Shutdown closes the connections of all the shards.
+func (s *Shards) Shutdown() error {
+	return s.each(func(_ int, store Store) error {
+		return store.Shutdown()
+	})
+}
@@ -0,0 +1,379 @@
+package db
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/yuuki/shawk/probe"
+)
+
+// memStore is a Store keeping the flows written into it in memory, which
+// lists them by their IDs in the order of the writes.
+type memStore struct {
+	nopStore
+	written []*probe.HostFlow
+	talkers []*Talker
+	changes []*Change
+	scans   []*Scan
+	merged  *MergeResult
+	aliases map[string]net.IP
+	notes   []*Annotation
+}
+
+func (m *memStore) InsertOrUpdateHostFlows(flows []*probe.HostFlow) error {
+	m.written = append(m.written, flows...)
+	return nil
+}
+
+func (m *memStore) InsertScan(scan *Scan) error {
+	m.scans = append(m.scans, scan)
+	return nil
+}
+
+func (m *memStore) FindAgentHealth(addrs []net.IP) ([]*AgentHealth, error) {
+	var agents []*AgentHealth
+	for _, s := range m.scans {
+		agents = append(agents, &AgentHealth{Host: s.Host, Addrs: s.Addrs, LastRecord: s.Finished})
+	}
+	return agents, nil
+}
+
+func (m *memStore) DeleteProcesses(prefixes []*net.IPNet, dryRun bool) ([]*DeletedProcess, error) {
+	var deleted []*DeletedProcess
+	for _, f := range m.written {
+		for _, p := range prefixes {
+			if p.Contains(net.ParseIP(f.Local.Addr)) {
+				deleted = append(deleted, &DeletedProcess{Addr: f.Local.Addr, Pname: f.Process.Name, Pgid: f.Process.Pgid, Flows: 1})
+			}
+		}
+	}
+	return deleted, nil
+}
+
+func (m *memStore) MergeNodes(from, to net.IP, dryRun bool) (*MergeResult, error) {
+	return m.merged, nil
+}
+
+func (m *memStore) FindNodeAlias(addr net.IP) (net.IP, error) {
+	return m.aliases[addr.String()], nil
+}
+
+func (m *memStore) Annotate(a *Annotation) error {
+	m.notes = append(m.notes, a)
+	return nil
+}
+
+func (m *memStore) FindAnnotations() ([]*Annotation, error) {
+	return m.notes, nil
+}
+
+func (m *memStore) ListFlows(cond *ListFlowsCond) ([]*Flow, error) {
+	var flows []*Flow
+	for i, f := range m.written {
+		id := int64(i + 1)
+		if id <= cond.After {
+			continue
+		}
+		if len(flows) == cond.Limit {
+			break
+		}
+		flows = append(flows, &Flow{
+			ID:          id,
+			ActiveNode:  &Node{IPAddr: net.ParseIP(f.Local.Addr), VRF: f.VRF, Aggregated: true},
+			PassiveNode: &Node{IPAddr: net.ParseIP(f.Peer.Addr), VRF: f.VRF, Port: f.Peer.Port},
+			Connections: int(f.Connections),
+		})
+	}
+	return flows, nil
+}
+
+func (m *memStore) FindActiveFlows(cond *FindFlowsCond) (Flows, error) {
+	flows, _ := m.ListFlows(&ListFlowsCond{Limit: len(m.written)})
+	found := make(Flows)
+	for _, f := range flows {
+		f.ID = 0
+		key := f.ActiveNode.IPAddr.String()
+		found[key] = append(found[key], f)
+	}
+	return found, nil
+}
+
+func (m *memStore) FindTopTalkers(cond *AggregateCond) ([]*Talker, error) {
+	return m.talkers, nil
+}
+
+func (m *memStore) Listen(ctx context.Context, fn func(*Change) error) error {
+	for _, c := range m.changes {
+		if err := fn(c); err != nil {
+			return err
+		}
+	}
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func newTestShards(n int) (*Shards, []*memStore) {
+	mems := make([]*memStore, n)
+	stores := make([]Store, n)
+	for i := range mems {
+		mems[i] = &memStore{}
+		stores[i] = mems[i]
+	}
+	return NewShards(stores), mems
+}
+
+func hostFlow(local, peer string) *probe.HostFlow {
+	return &probe.HostFlow{
+		Direction:   probe.FlowActive,
+		Local:       &probe.AddrPort{Addr: local, Aggregated: true},
+		Peer:        &probe.AddrPort{Addr: peer, Port: 80},
+		Connections: 1,
+	}
+}
+
+func TestShards_InsertOrUpdateHostFlows(t *testing.T) {
+	shards, mems := newTestShards(3)
+	var flows []*probe.HostFlow
+	for i := 1; i <= 30; i++ {
+		flows = append(flows, hostFlow(net.IPv4(10, 0, 0, byte(i)).String(), "10.0.1.1"))
+	}
+	if err := shards.InsertOrUpdateHostFlows(flows); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	total := 0
+	for i, m := range mems {
+		if len(m.written) == 0 {
+			t.Errorf("shard %d should have some of the flows", i)
+		}
+		for _, f := range m.written {
+			if got := shards.shardOf(f); got != i {
+				t.Errorf("the flow of %s should be written into shard %d, but %d", f.Local.Addr, got, i)
+			}
+		}
+		total += len(m.written)
+	}
+	if total != len(flows) {
+		t.Errorf("the shards should have %d flows, but %d", len(flows), total)
+	}
+}
+
+func TestShards_InsertScan(t *testing.T) {
+	shards, mems := newTestShards(3)
+	scan := &Scan{Host: "web-1", Flows: 2}
+	if err := shards.InsertScan(scan); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if len(mems[0].scans) != 1 || len(mems[1].scans) != 0 || len(mems[2].scans) != 0 {
+		t.Errorf("the scan should be written into the first shard: %d, %d, %d",
+			len(mems[0].scans), len(mems[1].scans), len(mems[2].scans))
+	}
+}
+
+func TestShards_FindAgentHealth(t *testing.T) {
+	shards, mems := newTestShards(2)
+	mems[0].scans = []*Scan{{Host: "web-1"}}
+	mems[1].scans = []*Scan{{Host: "web-2"}}
+	agents, err := shards.FindAgentHealth(nil)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if len(agents) != 1 || agents[0].Host != "web-1" {
+		t.Errorf("the health should be read from the first shard, but %+v", agents)
+	}
+}
+
+func TestShards_DeleteProcesses(t *testing.T) {
+	shards, mems := newTestShards(2)
+	// The process is in both shards, as after the shards were added.
+	for _, m := range mems {
+		m.written = []*probe.HostFlow{
+			{Local: &probe.AddrPort{Addr: "10.0.1.5"}, Process: &probe.Process{Name: "nginx", Pgid: 100}},
+		}
+	}
+	mems[1].written = append(mems[1].written, &probe.HostFlow{
+		Local: &probe.AddrPort{Addr: "10.0.1.6"}, Process: &probe.Process{Name: "app", Pgid: 200},
+	}, &probe.HostFlow{
+		Local: &probe.AddrPort{Addr: "10.0.2.1"}, Process: &probe.Process{Name: "db", Pgid: 300},
+	})
+
+	_, prefix, _ := net.ParseCIDR("10.0.1.0/24")
+	deleted, err := shards.DeleteProcesses([]*net.IPNet{prefix}, true)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	want := []*DeletedProcess{
+		{Addr: "10.0.1.5", Pname: "nginx", Pgid: 100, Flows: 2},
+		{Addr: "10.0.1.6", Pname: "app", Pgid: 200, Flows: 1},
+	}
+	if diff := cmp.Diff(want, deleted); diff != "" {
+		t.Errorf("DeleteProcesses() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestShards_MergeNodes(t *testing.T) {
+	shards, mems := newTestShards(2)
+	mems[0].merged = &MergeResult{MovedProcesses: 2, MovedFlows: 10}
+	mems[1].merged = &MergeResult{MovedProcesses: 1, MergedProcesses: 1, MovedFlows: 3, MergedFlows: 1}
+	mems[1].aliases = map[string]net.IP{"10.0.1.5": net.ParseIP("10.0.1.9")}
+
+	result, err := shards.MergeNodes(net.ParseIP("10.0.1.5"), net.ParseIP("10.0.1.9"), false)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	want := &MergeResult{MovedProcesses: 3, MergedProcesses: 1, MovedFlows: 13, MergedFlows: 1}
+	if diff := cmp.Diff(want, result); diff != "" {
+		t.Errorf("MergeNodes() mismatch (-want +got):\n%s", diff)
+	}
+
+	alias, err := shards.FindNodeAlias(net.ParseIP("10.0.1.5"))
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if !alias.Equal(net.ParseIP("10.0.1.9")) {
+		t.Errorf("FindNodeAlias() = %v, want 10.0.1.9", alias)
+	}
+	if alias, _ := shards.FindNodeAlias(net.ParseIP("10.0.1.6")); alias != nil {
+		t.Errorf("FindNodeAlias() = %v, want nil", alias)
+	}
+}
+
+func TestShards_Annotate(t *testing.T) {
+	shards, mems := newTestShards(3)
+	a := &Annotation{Client: net.ParseIP("10.0.1.5"), Server: net.ParseIP("10.0.2.1"), Port: 5432, Note: "INFRA-123"}
+	if err := shards.Annotate(a); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	for i, m := range mems {
+		if len(m.notes) != 1 {
+			t.Errorf("shard %d should have the annotation, but %d", i, len(m.notes))
+		}
+	}
+	found, err := shards.FindAnnotations()
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if diff := cmp.Diff([]*Annotation{a}, found); diff != "" {
+		t.Errorf("FindAnnotations() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestShards_ListFlows(t *testing.T) {
+	shards, _ := newTestShards(3)
+	var flows []*probe.HostFlow
+	for i := 1; i <= 25; i++ {
+		flows = append(flows, hostFlow(net.IPv4(10, 0, 0, byte(i)).String(), "10.0.1.1"))
+	}
+	if err := shards.InsertOrUpdateHostFlows(flows); err != nil {
+		t.Fatalf("%+v", err)
+	}
+
+	var listed []*Flow
+	err := EachFlow(context.Background(), shards, &ListFlowsCond{Limit: 4}, func(f *Flow) error {
+		listed = append(listed, f)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if len(listed) != len(flows) {
+		t.Fatalf("EachFlow() should list %d flows, but %d", len(flows), len(listed))
+	}
+	seen := make(map[string]bool)
+	for i, f := range listed {
+		if i > 0 && f.ID <= listed[i-1].ID {
+			t.Errorf("the IDs should be ascending, but %d after %d", f.ID, listed[i-1].ID)
+		}
+		seen[f.ActiveNode.IPAddr.String()] = true
+	}
+	if len(seen) != len(flows) {
+		t.Errorf("EachFlow() should list each flow once, but %d distinct flows", len(seen))
+	}
+}
+
+func TestShards_FindActiveFlows(t *testing.T) {
+	shards, mems := newTestShards(2)
+	// The same flow in both shards, such as of a host moved between them.
+	mems[0].written = []*probe.HostFlow{hostFlow("10.0.0.1", "10.0.1.1")}
+	mems[1].written = []*probe.HostFlow{hostFlow("10.0.0.1", "10.0.1.1"), hostFlow("10.0.0.2", "10.0.1.1")}
+	mems[1].written[0].Connections = 5
+
+	flows, err := shards.FindActiveFlows(&FindFlowsCond{})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if n := len(flows["10.0.0.1"]); n != 1 {
+		t.Fatalf("the flow in both shards should be merged, but %d", n)
+	}
+	if c := flows["10.0.0.1"][0].Connections; c != 5 {
+		t.Errorf("the merged flow should have the most connections 5, but %d", c)
+	}
+	if n := len(flows["10.0.0.2"]); n != 1 {
+		t.Errorf("the flow of 10.0.0.2 should be found, but %d", n)
+	}
+}
+
+func TestShards_FindActiveFlowsVRF(t *testing.T) {
+	shards, mems := newTestShards(2)
+	// The same addresses in the VRFs of the hosts in the shards.
+	mems[0].written = []*probe.HostFlow{hostFlow("10.0.0.1", "10.0.1.1")}
+	mems[1].written = []*probe.HostFlow{hostFlow("10.0.0.1", "10.0.1.1")}
+	mems[1].written[0].VRF = "blue"
+
+	flows, err := shards.FindActiveFlows(&FindFlowsCond{})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if n := len(flows["10.0.0.1"]); n != 2 {
+		t.Errorf("the flows of the VRFs should not be merged, but %d", n)
+	}
+}
+
+func TestShards_FindTopTalkers(t *testing.T) {
+	shards, mems := newTestShards(2)
+	mems[0].talkers = []*Talker{
+		{IPAddr: net.ParseIP("10.0.0.1"), Pname: "app", Connections: 10, Flows: 1},
+		{IPAddr: net.ParseIP("10.0.0.2"), Pname: "batch", Connections: 8, Flows: 2},
+	}
+	mems[1].talkers = []*Talker{
+		{IPAddr: net.ParseIP("10.0.0.2"), Pname: "batch", Connections: 5, Flows: 1},
+		{IPAddr: net.ParseIP("10.0.0.3"), Pname: "cron", Connections: 1, Flows: 1},
+		{IPAddr: net.ParseIP("10.0.0.1"), VRF: "blue", Pname: "app", Connections: 9, Flows: 1},
+	}
+	got, err := shards.FindTopTalkers(&AggregateCond{Limit: 3})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	want := []*Talker{
+		{IPAddr: net.ParseIP("10.0.0.2"), Pname: "batch", Connections: 13, Flows: 3},
+		{IPAddr: net.ParseIP("10.0.0.1"), Pname: "app", Connections: 10, Flows: 1},
+		{IPAddr: net.ParseIP("10.0.0.1"), VRF: "blue", Pname: "app", Connections: 9, Flows: 1},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("FindTopTalkers() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestShards_Listen(t *testing.T) {
+	shards, mems := newTestShards(2)
+	mems[0].changes = []*Change{{Type: ChangeAdded, ID: 1}}
+	mems[1].changes = []*Change{{Type: ChangeAdded, ID: 2}}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	seen := 0
+	err := shards.Listen(ctx, func(c *Change) error {
+		seen++
+		if seen == 2 {
+			cancel()
+		}
+		return nil
+	})
+	if err != context.Canceled {
+		t.Errorf("Listen() should return context.Canceled, but %v", err)
+	}
+	if seen != 2 {
+		t.Errorf("Listen() should deliver the changes of both shards, but %d", seen)
+	}
+}
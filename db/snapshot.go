@@ -0,0 +1,374 @@
+package db
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+	"golang.org/x/xerrors"
+)
+
+// SnapshotFormat is the format of the snapshots, recorded in their manifests.
+const SnapshotFormat = "shawk-snapshot/v1"
+
+// snapshotManifest is the name of the manifest, the first file of a snapshot.
+const snapshotManifest = "manifest.json"
+
+// minSnapshotSchemaVersion is the oldest schema of the snapshots restored.
+// The columns added by the later schemas are restored by their defaults
+// from the snapshots of the older ones.
+const minSnapshotSchemaVersion = 3
+
+// tenantSchemaVersion is the schema adding the tenants. The snapshots of
+// the older schemas are restored into the default tenant.
+const tenantSchemaVersion = 5
+
+// vrfSchemaVersion is the schema adding the VRFs of the processes. The
+// snapshots of the older schemas are restored into the default VRF.
+const vrfSchemaVersion = 11
+
+// subflowsSchemaVersion is the schema adding the subflows of the MPTCP
+// connections of the flows.
+const subflowsSchemaVersion = 12
+
+// aliasSchemaVersion is the schema adding the aliases of the nodes merged.
+const aliasSchemaVersion = 14
+
+// annotationSchemaVersion is the schema adding the annotations of the edges.
+const annotationSchemaVersion = 15
+
+// annotationVRFSchemaVersion is the schema adding the VRFs of the
+// annotations. The annotations of the older schemas are restored into the
+// default VRF.
+const annotationVRFSchemaVersion = 19
+
+// queueSchemaVersion is the schema adding the socket queues of the
+// listeners and the flows.
+const queueSchemaVersion = 7
+
+// congSchemaVersion is the schema adding the congestion control algorithms
+// of the flows.
+const congSchemaVersion = 8
+
+// ownersSchemaVersion is the schema adding the owners of the sockets shared
+// across the processes.
+const ownersSchemaVersion = 9
+
+// ageSchemaVersion is the schema adding the ages of the connections of the
+// flows.
+const ageSchemaVersion = 10
+
+// snapshotColumns are the columns added to a table by a schema version.
+type snapshotColumns struct {
+	version int
+	columns []string
+}
+
+type snapshotTable struct {
+	name    string
+	columns []string
+	// serial is the column of the sequence, which is reset on restore, or
+	// empty if the table has no sequence.
+	serial string
+	// tenant is whether the table has the tenant column.
+	tenant bool
+	// vrf is whether the table has the vrf column.
+	vrf bool
+	// added are the columns added by the later schemas in the order of
+	// their versions.
+	added []snapshotColumns
+	// since is the schema adding the table, which is not in the snapshots
+	// of the older schemas.
+	since int
+}
+
+// in returns whether the table is in the snapshot of the schema version.
+func (t snapshotTable) in(version int) bool {
+	return version >= t.since
+}
+
+// columnsOf returns the columns of the table in the snapshot of the schema
+// version.
+func (t snapshotTable) columnsOf(version int) []string {
+	columns := t.columns[:len(t.columns):len(t.columns)]
+	if t.tenant && version >= tenantSchemaVersion {
+		columns = append(columns, "tenant")
+	}
+	if t.vrf && version >= vrfSchemaVersion {
+		columns = append(columns, "vrf")
+	}
+	for _, a := range t.added {
+		if version >= a.version {
+			columns = append(columns, a.columns...)
+		}
+	}
+	return columns
+}
+
+// snapshotTables are the tables of the graph in the order of their foreign
+// keys. schema_info is not included, since the schema of the CMDB is
+// created by CreateSchema, and the views are refreshed after restoring.
+var snapshotTables = []snapshotTable{
+	{
+		name:    "processes",
+		columns: []string{"process_id", "ipv4", "pgid", "pname", "labels", "created", "updated"},
+		serial:  "process_id",
+		tenant:  true,
+		vrf:     true,
+		added: []snapshotColumns{
+			{ownersSchemaVersion, []string{"owners"}},
+		},
+	},
+	{
+		name:    "active_nodes",
+		columns: []string{"node_id", "process_id"},
+		serial:  "node_id",
+	},
+	{
+		name:    "passive_nodes",
+		columns: []string{"node_id", "port", "process_id"},
+		serial:  "node_id",
+		added: []snapshotColumns{
+			{queueSchemaVersion, []string{"accept_queue", "backlog"}},
+		},
+	},
+	{
+		name:    "flows",
+		columns: []string{"flow_id", "source_node_id", "destination_node_id", "connections", "created", "updated"},
+		serial:  "flow_id",
+		tenant:  true,
+		added: []snapshotColumns{
+			{queueSchemaVersion, []string{"recv_queue", "send_queue"}},
+			{congSchemaVersion, []string{"client_cong", "server_cong"}},
+			{ageSchemaVersion, []string{"age_min", "age_avg", "age_max"}},
+			{subflowsSchemaVersion, []string{"subflows"}},
+		},
+	},
+	{
+		name:    "node_aliases",
+		columns: []string{"alias", "ipv4", "created"},
+		tenant:  true,
+		since:   aliasSchemaVersion,
+	},
+	{
+		name:    "flow_annotations",
+		columns: []string{"client", "server", "port", "note", "updated"},
+		tenant:  true,
+		since:   annotationSchemaVersion,
+		added: []snapshotColumns{
+			{annotationVRFSchemaVersion, []string{"vrf"}},
+		},
+	},
+}
+
+// SnapshotManifest describes a snapshot.
+type SnapshotManifest struct {
+	Format        string    `json:"format"`
+	SchemaVersion int       `json:"schema_version"`
+	Created       time.Time `json:"created"`
+	// Rows are the numbers of the rows by the tables.
+	Rows map[string]int64 `json:"rows"`
+}
+
+func (m *SnapshotManifest) check() error {
+	if m.Format != SnapshotFormat {
+		return xerrors.Errorf("unknown snapshot format %q: must be %q", m.Format, SnapshotFormat)
+	}
+	if m.SchemaVersion < minSnapshotSchemaVersion || m.SchemaVersion > SchemaVersion {
+		return xerrors.Errorf("the snapshot is of schema version %d, but this shawk restores versions %d to %d",
+			m.SchemaVersion, minSnapshotSchemaVersion, SchemaVersion)
+	}
+	return nil
+}
+
+// CreateSnapshot writes the processes, the nodes, the flows, the aliases of
+// the nodes and the annotations of the edges into w as a
+// gzipped tar of the manifest and the tables in the text format of COPY.
+// The tables are read in a transaction of REPEATABLE READ, so that the
+// snapshot is consistent while the agents write.
+func (db *DB) CreateSnapshot(ctx context.Context, w io.Writer) (*SnapshotManifest, error) {
+	db.connMu.Lock()
+	defer db.connMu.Unlock()
+	tx, err := db.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly})
+	if err != nil {
+		return nil, xerrors.Errorf("begin transaction error: %w", err)
+	}
+	defer tx.Rollback(context.Background())
+
+	m := &SnapshotManifest{
+		Format:        SnapshotFormat,
+		SchemaVersion: SchemaVersion,
+		Created:       time.Now().UTC(),
+		Rows:          make(map[string]int64, len(snapshotTables)),
+	}
+	// The tables are spooled into the temporary files, since the sizes
+	// precede the contents in tar.
+	files := make([]*os.File, 0, len(snapshotTables))
+	defer func() {
+		for _, f := range files {
+			f.Close()
+			os.Remove(f.Name())
+		}
+	}()
+	for _, t := range snapshotTables {
+		f, err := ioutil.TempFile("", "shawk-snapshot-"+t.name)
+		if err != nil {
+			return nil, xerrors.Errorf("could not create a temporary file: %w", err)
+		}
+		files = append(files, f)
+		sql := "SELECT " + strings.Join(t.columnsOf(SchemaVersion), ", ") + " FROM " + t.name
+		if t.serial != "" {
+			sql += " ORDER BY " + t.serial
+		}
+		sql = "COPY (" + sql + ") TO STDOUT"
+		tag, err := tx.Conn().PgConn().CopyTo(ctx, f, sql)
+		if err != nil {
+			return nil, xerrors.Errorf("copy %s error: %w", t.name, err)
+		}
+		m.Rows[t.name] = tag.RowsAffected()
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, xerrors.Errorf("commit transaction error: %w", err)
+	}
+
+	gw := gzip.NewWriter(w)
+	tw := tar.NewWriter(gw)
+	manifest, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return nil, xerrors.Errorf("could not encode the manifest: %w", err)
+	}
+	hdr := &tar.Header{Name: snapshotManifest, Mode: 0644, Size: int64(len(manifest)), ModTime: m.Created}
+	if err := tw.WriteHeader(hdr); err != nil {
+		return nil, xerrors.Errorf("could not write the snapshot: %w", err)
+	}
+	if _, err := tw.Write(manifest); err != nil {
+		return nil, xerrors.Errorf("could not write the snapshot: %w", err)
+	}
+	for i, t := range snapshotTables {
+		f := files[i]
+		fi, err := f.Stat()
+		if err != nil {
+			return nil, xerrors.Errorf("could not read the spooled %s: %w", t.name, err)
+		}
+		if _, err := f.Seek(0, io.SeekStart); err != nil {
+			return nil, xerrors.Errorf("could not read the spooled %s: %w", t.name, err)
+		}
+		hdr := &tar.Header{Name: t.name + ".copy", Mode: 0644, Size: fi.Size(), ModTime: m.Created}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return nil, xerrors.Errorf("could not write the snapshot: %w", err)
+		}
+		if _, err := io.Copy(tw, f); err != nil {
+			return nil, xerrors.Errorf("could not write the snapshot: %w", err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return nil, xerrors.Errorf("could not write the snapshot: %w", err)
+	}
+	if err := gw.Close(); err != nil {
+		return nil, xerrors.Errorf("could not write the snapshot: %w", err)
+	}
+	return m, nil
+}
+
+// ReadSnapshotManifest reads the manifest of the snapshot from r, and
+// returns the reader of the tables following it.
+func ReadSnapshotManifest(r io.Reader) (*SnapshotManifest, *tar.Reader, error) {
+	gr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, nil, xerrors.Errorf("not a snapshot of shawk: %w", err)
+	}
+	tr := tar.NewReader(gr)
+	hdr, err := tr.Next()
+	if err != nil {
+		return nil, nil, xerrors.Errorf("not a snapshot of shawk: %w", err)
+	}
+	if hdr.Name != snapshotManifest {
+		return nil, nil, xerrors.Errorf("not a snapshot of shawk: the first file is %q instead of %q", hdr.Name, snapshotManifest)
+	}
+	var m SnapshotManifest
+	if err := json.NewDecoder(tr).Decode(&m); err != nil {
+		return nil, nil, xerrors.Errorf("could not decode the manifest: %w", err)
+	}
+	if err := m.check(); err != nil {
+		return nil, nil, err
+	}
+	return &m, tr, nil
+}
+
+// RestoreSnapshot replaces the processes, the nodes, the flows, the aliases
+// of the nodes and the annotations of the edges with the snapshot written by CreateSnapshot in a transaction, so that the CMDB is
+// left as it was if the restore fails. The schema of the CMDB must be of
+// SchemaVersion, and the snapshot may be of an older schema, whose tables
+// added later are left empty. The tables are truncated rather than
+// deleted, which notifies no removed flows.
+func (db *DB) RestoreSnapshot(ctx context.Context, r io.Reader) (*SnapshotManifest, error) {
+	if err := db.CheckSchemaVersion(); err != nil {
+		return nil, err
+	}
+	m, tr, err := ReadSnapshotManifest(r)
+	if err != nil {
+		return nil, err
+	}
+
+	db.connMu.Lock()
+	defer db.connMu.Unlock()
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return nil, xerrors.Errorf("begin transaction error: %w", err)
+	}
+	defer tx.Rollback(context.Background())
+
+	if _, err := tx.Exec(ctx, "TRUNCATE "+strings.Join(graphTables(), ", ")); err != nil {
+		return nil, xerrors.Errorf("truncate error: %w", err)
+	}
+	for _, t := range snapshotTables {
+		if !t.in(m.SchemaVersion) {
+			continue
+		}
+		hdr, err := tr.Next()
+		if err != nil {
+			return nil, xerrors.Errorf("could not read %s of the snapshot: %w", t.name, err)
+		}
+		if hdr.Name != t.name+".copy" {
+			return nil, xerrors.Errorf("unexpected %q in the snapshot: expected %q", hdr.Name, t.name+".copy")
+		}
+		sql := "COPY " + t.name + " (" + strings.Join(t.columnsOf(m.SchemaVersion), ", ") + ") FROM STDIN"
+		tag, err := tx.Conn().PgConn().CopyFrom(ctx, tr, sql)
+		if err != nil {
+			return nil, xerrors.Errorf("copy %s error: %w", t.name, err)
+		}
+		if n := tag.RowsAffected(); n != m.Rows[t.name] {
+			return nil, xerrors.Errorf("the snapshot has %d rows of %s, but the manifest says %d", n, t.name, m.Rows[t.name])
+		}
+		if t.serial == "" {
+			continue
+		}
+		if _, err := tx.Exec(ctx, `SELECT setval(pg_get_serial_sequence($1, $2), COALESCE(MAX(`+t.serial+`), 0) + 1, false) FROM `+t.name,
+			t.name, t.serial); err != nil {
+			return nil, xerrors.Errorf("reset the sequence of %s error: %w", t.name, err)
+		}
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, xerrors.Errorf("commit transaction error: %w", err)
+	}
+	return m, nil
+}
+
+// CountFlows returns the number of the flows in the CMDB.
+func (db *DB) CountFlows(ctx context.Context) (int64, error) {
+	var n int64
+	err := db.retry("count flows", &db.Conn, func(conn *pgx.Conn) error {
+		return conn.QueryRow(ctx, `SELECT COUNT(*) FROM flows`).Scan(&n)
+	})
+	if err != nil {
+		return 0, xerrors.Errorf("count flows error: %w", err)
+	}
+	return n, nil
+}
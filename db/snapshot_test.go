@@ -0,0 +1,216 @@
+package db
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/yuuki/shawk/probe"
+)
+
+func TestSnapshot(t *testing.T) {
+	db, teardown := setupTestCase(t)
+	defer teardown(t)
+
+	input := []*probe.HostFlow{
+		{
+			Direction:   probe.FlowActive,
+			Local:       &probe.AddrPort{Addr: "10.0.10.1", Aggregated: true},
+			Peer:        &probe.AddrPort{Addr: "10.0.10.2", Port: 80},
+			Process:     &probe.Process{Pgid: 1001, Name: "haproxy"},
+			Connections: 10,
+			Cong:        "cubic",
+			Age:         probe.NewAge(time.Minute),
+			Subflows:    2,
+		},
+		{
+			Direction:   probe.FlowPassive,
+			Local:       &probe.AddrPort{Addr: "10.0.10.2", Port: 80, Labels: map[string]string{"role": "web"}},
+			Peer:        &probe.AddrPort{Addr: "10.0.10.1", Aggregated: true},
+			Process:     &probe.Process{Pgid: 2002, Name: "nginx", Owners: []*probe.Process{{Pgid: 1, Name: "systemd"}}},
+			Connections: 10,
+			Queue:       &probe.Queue{RecvQ: 100, SendQ: 200, AcceptQ: 3, Backlog: 128},
+			Cong:        "bbr",
+		},
+	}
+	if err := db.InsertOrUpdateHostFlows(input); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if _, err := db.Exec(context.Background(), "INSERT INTO node_aliases (alias, ipv4) VALUES ('10.0.9.2', '10.0.10.2')"); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Annotate(&Annotation{Client: net.ParseIP("10.0.10.1"), Server: net.ParseIP("10.0.10.2"), Port: 80, Note: "INFRA-123"}); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	want, err := db.ListFlows(&ListFlowsCond{})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	// The columns added by the later schemas are in the snapshot.
+	for _, f := range want {
+		if f.RecvQueue == 0 || f.SendQueue == 0 || f.PassiveNode.AcceptQueue == 0 || f.PassiveNode.Backlog == 0 {
+			t.Fatalf("the flows should be written with the queues, but %+v %+v", f, f.PassiveNode)
+		}
+		if f.ClientCong == "" || f.ServerCong == "" {
+			t.Fatalf("the flows should be written with the congestion control algorithms, but %+v", f)
+		}
+		if f.Age == nil || f.Subflows == 0 {
+			t.Fatalf("the flows should be written with the ages and the subflows, but %+v", f)
+		}
+		if f.Annotation == "" {
+			t.Fatalf("the flows should be annotated, but %+v", f)
+		}
+		if len(f.PassiveNode.Owners) == 0 {
+			t.Fatalf("the flows should be written with the owners of the server, but %+v", f.PassiveNode)
+		}
+	}
+
+	var buf bytes.Buffer
+	m, err := db.CreateSnapshot(context.Background(), &buf)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if m.Rows["flows"] != int64(len(want)) {
+		t.Errorf("the snapshot should have %d flows, but %d", len(want), m.Rows["flows"])
+	}
+
+	if _, err := db.Exec(context.Background(), "DELETE FROM processes"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.RestoreSnapshot(context.Background(), &buf); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	got, err := db.ListFlows(&ListFlowsCond{})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("the restored flows mismatch (-want +got):\n%s", diff)
+	}
+	if alias, err := db.FindNodeAlias(net.ParseIP("10.0.9.2")); err != nil || !alias.Equal(net.ParseIP("10.0.10.2")) {
+		t.Errorf("the alias should be restored, but %v (%v)", alias, err)
+	}
+
+	// The sequences continue after the restored rows.
+	input[0].Peer.Port = 8080
+	input[1].Local.Port = 8080
+	if err := db.InsertOrUpdateHostFlows(input); err != nil {
+		t.Fatalf("%+v", err)
+	}
+}
+
+func TestReadSnapshotManifest(t *testing.T) {
+	snapshot := func(name, manifest string) *bytes.Buffer {
+		var buf bytes.Buffer
+		gw := gzip.NewWriter(&buf)
+		tw := tar.NewWriter(gw)
+		tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(manifest))})
+		tw.Write([]byte(manifest))
+		tw.Close()
+		gw.Close()
+		return &buf
+	}
+	tests := []struct {
+		desc    string
+		input   *bytes.Buffer
+		wantErr string
+	}{
+		{desc: "valid", input: snapshot("manifest.json", `{"format":"shawk-snapshot/v1","schema_version":3,"rows":{"flows":1}}`)},
+		{desc: "not gzip", input: bytes.NewBufferString("flows"), wantErr: "not a snapshot of shawk"},
+		{desc: "no manifest", input: snapshot("flows.copy", ""), wantErr: `the first file is "flows.copy"`},
+		{desc: "unknown format", input: snapshot("manifest.json", `{"format":"pg_dump","schema_version":3}`), wantErr: "unknown snapshot format"},
+		{desc: "old schema", input: snapshot("manifest.json", `{"format":"shawk-snapshot/v1","schema_version":2}`), wantErr: "schema version 2"},
+		{desc: "new schema", input: snapshot("manifest.json", `{"format":"shawk-snapshot/v1","schema_version":99}`), wantErr: "schema version 99"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			m, _, err := ReadSnapshotManifest(tt.input)
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Errorf("ReadSnapshotManifest() should not return an error: %v", err)
+			case tt.wantErr == "" && m.Rows["flows"] != 1:
+				t.Errorf("ReadSnapshotManifest() should read the rows, but %v", m.Rows)
+			case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+				t.Errorf("ReadSnapshotManifest() should return an error containing %q, but %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestSnapshotTable_columnsOf(t *testing.T) {
+	processes := snapshotTables[0]
+	if diff := cmp.Diff(processes.columns, processes.columnsOf(4)); diff != "" {
+		t.Errorf("columnsOf(4) mismatch (-want +got):\n%s", diff)
+	}
+	want := append(append([]string{}, processes.columns...), "tenant")
+	if diff := cmp.Diff(want, processes.columnsOf(5)); diff != "" {
+		t.Errorf("columnsOf(5) mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(processes.columns, processes.columnsOf(4)); diff != "" {
+		t.Errorf("columnsOf(5) should not change the columns (-want +got):\n%s", diff)
+	}
+	want = append(append([]string{}, processes.columns...), "tenant", "owners")
+	if diff := cmp.Diff(want, processes.columnsOf(9)); diff != "" {
+		t.Errorf("columnsOf(9) mismatch (-want +got):\n%s", diff)
+	}
+	want = append(append([]string{}, processes.columns...), "tenant", "vrf", "owners")
+	if diff := cmp.Diff(want, processes.columnsOf(11)); diff != "" {
+		t.Errorf("columnsOf(11) mismatch (-want +got):\n%s", diff)
+	}
+	flows := snapshotTables[3]
+	want = append(append([]string{}, flows.columns...), "tenant")
+	if diff := cmp.Diff(want, flows.columnsOf(6)); diff != "" {
+		t.Errorf("columnsOf(6) of flows mismatch (-want +got):\n%s", diff)
+	}
+	want = append(append([]string{}, flows.columns...), "tenant", "recv_queue", "send_queue")
+	if diff := cmp.Diff(want, flows.columnsOf(7)); diff != "" {
+		t.Errorf("columnsOf(7) of flows mismatch (-want +got):\n%s", diff)
+	}
+	want = append(want, "client_cong", "server_cong")
+	if diff := cmp.Diff(want, flows.columnsOf(8)); diff != "" {
+		t.Errorf("columnsOf(8) of flows mismatch (-want +got):\n%s", diff)
+	}
+	want = append(want, "age_min", "age_avg", "age_max")
+	if diff := cmp.Diff(want, flows.columnsOf(10)); diff != "" {
+		t.Errorf("columnsOf(10) of flows mismatch (-want +got):\n%s", diff)
+	}
+	want = append(want, "subflows")
+	if diff := cmp.Diff(want, flows.columnsOf(12)); diff != "" {
+		t.Errorf("columnsOf(12) of flows mismatch (-want +got):\n%s", diff)
+	}
+	nodes := snapshotTables[1]
+	if diff := cmp.Diff(nodes.columns, nodes.columnsOf(5)); diff != "" {
+		t.Errorf("columnsOf(5) of active_nodes mismatch (-want +got):\n%s", diff)
+	}
+	listeners := snapshotTables[2]
+	if diff := cmp.Diff(listeners.columns, listeners.columnsOf(6)); diff != "" {
+		t.Errorf("columnsOf(6) of passive_nodes mismatch (-want +got):\n%s", diff)
+	}
+	want = append(append([]string{}, listeners.columns...), "accept_queue", "backlog")
+	if diff := cmp.Diff(want, listeners.columnsOf(7)); diff != "" {
+		t.Errorf("columnsOf(7) of passive_nodes mismatch (-want +got):\n%s", diff)
+	}
+	aliases := snapshotTables[4]
+	if aliases.in(13) || !aliases.in(14) {
+		t.Errorf("node_aliases should be in the snapshots since 14, but in(13) = %v, in(14) = %v", aliases.in(13), aliases.in(14))
+	}
+	annotations := snapshotTables[5]
+	if annotations.in(14) || !annotations.in(15) {
+		t.Errorf("flow_annotations should be in the snapshots since 15, but in(14) = %v, in(15) = %v", annotations.in(14), annotations.in(15))
+	}
+	want = append(append([]string{}, annotations.columns...), "tenant")
+	if diff := cmp.Diff(want, annotations.columnsOf(18)); diff != "" {
+		t.Errorf("columnsOf(18) of flow_annotations mismatch (-want +got):\n%s", diff)
+	}
+	want = append(want, "vrf")
+	if diff := cmp.Diff(want, annotations.columnsOf(19)); diff != "" {
+		t.Errorf("columnsOf(19) of flow_annotations mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,130 @@
+package db
+
+import (
+	"context"
+	"net"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+	"golang.org/x/xerrors"
+)
+
+// GraphStats is the overview of the graph in the CMDB.
+type GraphStats struct {
+	Processes int64 `json:"processes"`
+	// Clients and Servers are the nodes of the processes connecting and
+	// listening.
+	Clients int64 `json:"clients"`
+	Servers int64 `json:"servers"`
+	Flows   int64 `json:"flows"`
+	// ProcessesGrowth and FlowsGrowth are the processes and the flows
+	// created recently.
+	ProcessesGrowth Growth `json:"processes_growth"`
+	FlowsGrowth     Growth `json:"flows_growth"`
+	// Oldest is the creation of the oldest flow, and Newest is the latest
+	// update of the flows. They are nil without flows.
+	Oldest *time.Time `json:"oldest,omitempty"`
+	Newest *time.Time `json:"newest,omitempty"`
+	// TopHosts are the hosts in descending order of their edges.
+	TopHosts []*HostEdges `json:"top_hosts"`
+}
+
+// Growth is the rows created in the last day and in the last week.
+type Growth struct {
+	Day  int64 `json:"day"`
+	Week int64 `json:"week"`
+}
+
+// HostEdges is the number of the flows from or to the processes of a host.
+type HostEdges struct {
+	Addr  string `json:"addr"`
+	Edges int64  `json:"edges"`
+}
+
+const (
+	// findGraphCountsSQL counts the rows of the tenant of $1, or of all
+	// the tenants if $1 is NULL.
+	findGraphCountsSQL = `
+		WITH scoped_processes AS (
+			SELECT process_id, created FROM processes
+			WHERE ($1::varchar IS NULL OR tenant = $1)
+		), scoped_flows AS (
+			SELECT created, updated FROM flows
+			WHERE ($1::varchar IS NULL OR tenant = $1)
+		)
+		SELECT
+			(SELECT count(*) FROM scoped_processes),
+			(SELECT count(*) FROM active_nodes WHERE process_id IN (SELECT process_id FROM scoped_processes)),
+			(SELECT count(*) FROM passive_nodes WHERE process_id IN (SELECT process_id FROM scoped_processes)),
+			(SELECT count(*) FROM scoped_flows),
+			(SELECT count(*) FROM scoped_processes WHERE created > LOCALTIMESTAMP - interval '1 day'),
+			(SELECT count(*) FROM scoped_processes WHERE created > LOCALTIMESTAMP - interval '7 days'),
+			(SELECT count(*) FROM scoped_flows WHERE created > LOCALTIMESTAMP - interval '1 day'),
+			(SELECT count(*) FROM scoped_flows WHERE created > LOCALTIMESTAMP - interval '7 days'),
+			(SELECT min(created) FROM scoped_flows),
+			(SELECT max(updated) FROM scoped_flows)
+	`
+
+	// findTopHostsSQL finds the $2 hosts of the most flows from or to
+	// their processes of the tenant of $1, or of all the tenants if $1 is
+	// NULL. A flow between the processes of a host is an edge of it.
+	findTopHostsSQL = `
+		SELECT processes.ipv4, processes.vrf, count(DISTINCT flows.flow_id) AS edges
+		FROM flows
+		INNER JOIN active_nodes ON active_nodes.node_id = flows.source_node_id
+		INNER JOIN passive_nodes ON passive_nodes.node_id = flows.destination_node_id
+		INNER JOIN processes ON processes.process_id IN (active_nodes.process_id, passive_nodes.process_id)
+		WHERE ($1::varchar IS NULL OR flows.tenant = $1)
+		GROUP BY processes.ipv4, processes.vrf
+		ORDER BY edges DESC, processes.ipv4, processes.vrf
+		LIMIT $2
+	`
+)
+
+// FindGraphStats returns the overview of the graph of the tenant with the
+// top hosts of the most edges.
+func (db *DB) FindGraphStats(ctx context.Context, top int) (*GraphStats, error) {
+	var s *GraphStats
+	err := db.retry("find graph stats", &db.Conn, func(conn *pgx.Conn) error {
+		var err error
+		s, err = findGraphStats(ctx, conn, top, db.tenantScope())
+		return err
+	})
+	return s, err
+}
+
+func findGraphStats(ctx context.Context, conn *pgx.Conn, top int, tenant interface{}) (*GraphStats, error) {
+	var s GraphStats
+	err := conn.QueryRow(ctx, findGraphCountsSQL, tenant).Scan(
+		&s.Processes, &s.Clients, &s.Servers, &s.Flows,
+		&s.ProcessesGrowth.Day, &s.ProcessesGrowth.Week,
+		&s.FlowsGrowth.Day, &s.FlowsGrowth.Week,
+		&s.Oldest, &s.Newest,
+	)
+	if err != nil {
+		return nil, xerrors.Errorf("query graph counts error: %w", err)
+	}
+
+	rows, err := conn.Query(ctx, findTopHostsSQL, tenant, top)
+	if err != nil {
+		return nil, xerrors.Errorf("query top hosts error: %w", err)
+	}
+	defer rows.Close()
+	s.TopHosts = []*HostEdges{}
+	for rows.Next() {
+		var (
+			ip  net.IP
+			vrf string
+			h   HostEdges
+		)
+		if err := rows.Scan(&ip, &vrf, &h.Edges); err != nil {
+			return nil, xerrors.Errorf("scan top hosts error: %w", err)
+		}
+		h.Addr = vrfAddr(ip, vrf)
+		s.TopHosts = append(s.TopHosts, &h)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, xerrors.Errorf("query top hosts error: %w", err)
+	}
+	return &s, nil
+}
@@ -0,0 +1,49 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/yuuki/shawk/probe"
+)
+
+func TestFindGraphStats(t *testing.T) {
+	db, teardown := setupTestCase(t)
+	defer teardown(t)
+
+	flows := []*probe.HostFlow{
+		{
+			Direction: probe.FlowActive, Process: &probe.Process{Name: "app", Pgid: 100}, Connections: 2,
+			Local: &probe.AddrPort{Addr: "10.0.0.1", Aggregated: true},
+			Peer:  &probe.AddrPort{Addr: "10.0.0.2", Port: 80},
+		},
+		{
+			Direction: probe.FlowActive, Process: &probe.Process{Name: "app", Pgid: 100}, Connections: 1,
+			Local: &probe.AddrPort{Addr: "10.0.0.1", Aggregated: true},
+			Peer:  &probe.AddrPort{Addr: "10.0.0.3", Port: 5432},
+		},
+	}
+	if err := db.InsertOrUpdateHostFlows(flows); err != nil {
+		t.Fatalf("%+v", err)
+	}
+
+	stats, err := db.FindGraphStats(context.Background(), 2)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if stats.Processes != 3 || stats.Clients != 1 || stats.Servers != 2 || stats.Flows != 2 {
+		t.Errorf("FindGraphStats() should count 3 processes, 1 client, 2 servers and 2 flows, but %+v", stats)
+	}
+	if stats.FlowsGrowth != (Growth{Day: 2, Week: 2}) {
+		t.Errorf("the flows should be created in the last day, but %+v", stats.FlowsGrowth)
+	}
+	if stats.Oldest == nil || stats.Newest == nil {
+		t.Errorf("FindGraphStats() should return the oldest and the newest flows")
+	}
+	want := []*HostEdges{{Addr: "10.0.0.1", Edges: 2}, {Addr: "10.0.0.2", Edges: 1}}
+	if diff := cmp.Diff(want, stats.TopHosts); diff != "" {
+		t.Errorf("FindGraphStats() mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,127 @@
+package db
+
+import (
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+
+	"golang.org/x/xerrors"
+
+	"github.com/yuuki/shawk/probe"
+)
+
+// Store is the storage of the CMDB, which the commands and the agents use
+// without depending on the database. DB is the Store on Postgres.
+type Store interface {
+	// CreateSchema creates the tables.
+	CreateSchema() error
+	// CheckSchemaVersion returns an error if the schema is not of SchemaVersion.
+	CheckSchemaVersion() error
+	// InsertOrUpdateHostFlows writes the flows, updating the same flows.
+	InsertOrUpdateHostFlows(flows []*probe.HostFlow) error
+	// FindPassiveFlows returns the flows to the addresses of cond, grouped
+	// by the passive nodes.
+	FindPassiveFlows(cond *FindFlowsCond) (Flows, error)
+	// FindActiveFlows returns the flows from the addresses of cond, grouped
+	// by the active nodes.
+	FindActiveFlows(cond *FindFlowsCond) (Flows, error)
+	// ListFlows returns a page of the flows of cond, ordered by their IDs.
+	ListFlows(cond *ListFlowsCond) ([]*Flow, error)
+	// FindTopTalkers returns the client processes in descending order of
+	// the connections.
+	FindTopTalkers(cond *AggregateCond) ([]*Talker, error)
+	// FindServiceEdges returns the edges between the services in
+	// descending order of the connections.
+	FindServiceEdges(cond *AggregateCond) ([]*ServiceEdge, error)
+	// Shutdown closes the connections.
+	Shutdown() error
+}
+
+var _ Store = (*DB)(nil)
+
+// Backend is a kind of the Store, chosen by the scheme of SHAWK_CMDB_URL.
+type Backend struct {
+	// Open connects to the Store of the URL.
+	Open func(dbURL string) (Store, error)
+	// Validate returns an error if the URL is invalid without connecting.
+	// It may be nil.
+	Validate func(dbURL string) error
+}
+
+var (
+	backendsMu sync.RWMutex
+	backends   = map[string]Backend{}
+)
+
+func init() {
+	postgres := Backend{
+		Open: func(dbURL string) (Store, error) {
+			db, err := New(dbURL)
+			if err != nil {
+				return nil, err
+			}
+			return db, nil
+		},
+		Validate: func(dbURL string) error {
+			_, err := parseConfig(dbURL)
+			return err
+		},
+	}
+	Register("postgres", postgres)
+	Register("postgresql", postgres)
+}
+
+// Register registers the backend of the scheme, replacing the registered one.
+func Register(scheme string, b Backend) {
+	backendsMu.Lock()
+	defer backendsMu.Unlock()
+	backends[scheme] = b
+}
+
+// backendOf returns the backend of the scheme of dbURL. The URL without a
+// scheme is a connection string of Postgres such as 'host=127.0.0.1'.
+func backendOf(dbURL string) (Backend, error) {
+	scheme := "postgres"
+	if strings.Contains(dbURL, "://") {
+		u, err := url.Parse(dbURL)
+		if err != nil {
+			return Backend{}, err
+		}
+		scheme = u.Scheme
+	}
+
+	backendsMu.RLock()
+	defer backendsMu.RUnlock()
+	b, ok := backends[scheme]
+	if !ok {
+		schemes := make([]string, 0, len(backends))
+		for s := range backends {
+			schemes = append(schemes, s)
+		}
+		sort.Strings(schemes)
+		return Backend{}, xerrors.Errorf("unknown scheme %q: must be one of %s", scheme, strings.Join(schemes, ", "))
+	}
+	return b, nil
+}
+
+// Validate returns an error if dbURL is invalid for its backend.
+func Validate(dbURL string) error {
+	b, err := backendOf(dbURL)
+	if err != nil {
+		return err
+	}
+	if b.Validate == nil {
+		return nil
+	}
+	return b.Validate(dbURL)
+}
+
+// Open connects to the Store of dbURL by the backend of its scheme.
+func Open(dbURL string) (Store, error) {
+	b, err := backendOf(dbURL)
+	if err != nil {
+		return nil, err
+	}
+	return b.Open(dbURL)
+}
@@ -0,0 +1,218 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+	"golang.org/x/xerrors"
+)
+
+const (
+	// syncProcessSQL writes the process of a flow synced at the update of
+	// $8. A process is identified by its address, tenant, VRF, process
+	// group and name as written by the agents, and the labels and the
+	// owners of the later update take precedence.
+	syncProcessSQL = `
+		INSERT INTO processes (ipv4, pgid, pname, labels, tenant, owners, vrf, created, updated)
+		VALUES ($1, $2, $3, $4, $5, COALESCE($6::jsonb, '[]'), $7, $8, $8)
+		ON CONFLICT (ipv4, tenant, vrf, pgid, pname)
+		DO UPDATE SET
+			labels=CASE WHEN processes.updated < EXCLUDED.updated
+				THEN processes.labels || EXCLUDED.labels ELSE EXCLUDED.labels || processes.labels END,
+			owners=CASE WHEN processes.updated < EXCLUDED.updated AND $6::jsonb IS NOT NULL
+				THEN EXCLUDED.owners ELSE processes.owners END,
+			updated=GREATEST(processes.updated, EXCLUDED.updated)
+		RETURNING process_id
+	`
+
+	// syncFlowSQL writes a flow synced at the update of $13 unless the
+	// flow of the nodes is updated later, returning whether it is new.
+	syncFlowSQL = `
+		INSERT INTO flows
+		(source_node_id, destination_node_id, connections, tenant, recv_queue, send_queue, client_cong, server_cong,
+			age_min, age_avg, age_max, subflows, created, updated)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9::integer, $10::integer, $11::integer, $12, $13, $13)
+		ON CONFLICT (source_node_id, destination_node_id)
+		DO UPDATE SET connections=EXCLUDED.connections,
+			recv_queue=EXCLUDED.recv_queue,
+			send_queue=EXCLUDED.send_queue,
+			client_cong=EXCLUDED.client_cong,
+			server_cong=EXCLUDED.server_cong,
+			age_min=COALESCE(EXCLUDED.age_min, flows.age_min),
+			age_avg=COALESCE(EXCLUDED.age_avg, flows.age_avg),
+			age_max=COALESCE(EXCLUDED.age_max, flows.age_max),
+			subflows=EXCLUDED.subflows,
+			updated=EXCLUDED.updated
+		WHERE flows.updated < EXCLUDED.updated
+		RETURNING (xmax = 0)
+	`
+
+	findSyncMarkSQL = `
+		SELECT synced FROM syncs WHERE source = $1
+	`
+
+	// upsertSyncMarkSQL records the latest update of the flows synced from
+	// the source of $1, which never goes back.
+	upsertSyncMarkSQL = `
+		INSERT INTO syncs (source, synced) VALUES ($1, $2)
+		ON CONFLICT (source) DO UPDATE SET synced=GREATEST(syncs.synced, EXCLUDED.synced), updated=CURRENT_TIMESTAMP
+	`
+)
+
+// SyncResult is the numbers of the flows synced.
+type SyncResult struct {
+	// Inserted and Updated are the flows written as new and over the older
+	// ones, and Skipped are the flows older than the ones written.
+	Inserted int64
+	Updated  int64
+	Skipped  int64
+}
+
+// Add adds the numbers of r2 to r.
+func (r *SyncResult) Add(r2 *SyncResult) {
+	r.Inserted += r2.Inserted
+	r.Updated += r2.Updated
+	r.Skipped += r2.Skipped
+}
+
+// Syncer is the CMDB replicated from the other CMDBs by shawk sync.
+type Syncer interface {
+	// SyncFlows writes the flows listed from another CMDB with their
+	// tenants and updates, keeping the flows updated later.
+	SyncFlows(flows []*Flow) (*SyncResult, error)
+	// FindSyncMark returns the latest update of the flows synced from the
+	// source, or the zero time if never synced.
+	FindSyncMark(source string) (time.Time, error)
+	SetSyncMark(source string, mark time.Time) error
+}
+
+var _ Syncer = (*DB)(nil)
+
+// nodeLabelsArg returns the labels of the node as the text of jsonb.
+func nodeLabelsArg(n *Node) string {
+	if n.Labels == nil {
+		return "{}"
+	}
+	b, _ := json.Marshal(n.Labels) // never fails for map[string]string
+	return string(b)
+}
+
+// nodeOwnersArg returns the owners of the node as the text of jsonb, or nil
+// if its sockets are not shared.
+func nodeOwnersArg(n *Node) interface{} {
+	if len(n.Owners) == 0 {
+		return nil
+	}
+	b, _ := json.Marshal(n.Owners) // never fails for the processes
+	return string(b)
+}
+
+// SyncFlows writes the flows with their nodes in a transaction.
+func (db *DB) SyncFlows(flows []*Flow) (*SyncResult, error) {
+	var result *SyncResult
+	err := db.retry("sync flows", &db.Conn, func(conn *pgx.Conn) error {
+		var err error
+		result, err = syncFlows(conn, flows)
+		return err
+	})
+	return result, err
+}
+
+func syncFlows(conn *pgx.Conn, flows []*Flow) (*SyncResult, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), InsertOrUpdateTimeoutSec*time.Second)
+	defer cancel()
+
+	tx, err := conn.Begin(ctx)
+	if err != nil {
+		return nil, xerrors.Errorf("begin transaction error: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	process := func(n *Node, f *Flow) (int64, error) {
+		var id int64
+		err := tx.QueryRow(ctx, syncProcessSQL, n.IPAddr.String(), n.Pgid, n.Pname,
+			nodeLabelsArg(n), f.Tenant, nodeOwnersArg(n), n.VRF, f.Updated).Scan(&id)
+		if err != nil {
+			return 0, xerrors.Errorf("sync process error: %w", err)
+		}
+		return id, nil
+	}
+
+	result := &SyncResult{}
+	for _, f := range flows {
+		var activeNodeID, passiveNodeID int64
+		activeProcessID, err := process(f.ActiveNode, f)
+		if err != nil {
+			return nil, err
+		}
+		if err := tx.QueryRow(ctx, insertActiveNodesSQL, activeProcessID).Scan(&activeNodeID); err != nil {
+			return nil, xerrors.Errorf("sync active node error: %w", err)
+		}
+		passiveProcessID, err := process(f.PassiveNode, f)
+		if err != nil {
+			return nil, err
+		}
+		if err := tx.QueryRow(ctx, insertPassiveNodesSQL, passiveProcessID, f.PassiveNode.Port).Scan(&passiveNodeID); err != nil {
+			return nil, xerrors.Errorf("sync passive node error: %w", err)
+		}
+		if n := f.PassiveNode; n.Backlog > 0 {
+			if _, err := tx.Exec(ctx, updateListenerQueueSQL, passiveNodeID, n.AcceptQueue, n.Backlog); err != nil {
+				return nil, xerrors.Errorf("sync listener queue error: %w", err)
+			}
+		}
+
+		var ageMin, ageAvg, ageMax interface{}
+		if a := f.Age; a != nil {
+			ageMin, ageAvg, ageMax = int64(a.Min/time.Second), int64(a.Avg/time.Second), int64(a.Max/time.Second)
+		}
+		var inserted bool
+		err = tx.QueryRow(ctx, syncFlowSQL, activeNodeID, passiveNodeID, f.Connections, f.Tenant,
+			f.RecvQueue, f.SendQueue, f.ClientCong, f.ServerCong, ageMin, ageAvg, ageMax, f.Subflows, f.Updated,
+		).Scan(&inserted)
+		switch {
+		case err == pgx.ErrNoRows:
+			result.Skipped++
+		case err != nil:
+			return nil, xerrors.Errorf("sync flow error: %w", err)
+		case inserted:
+			result.Inserted++
+		default:
+			result.Updated++
+		}
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, xerrors.Errorf("commit error: %w", err)
+	}
+	return result, nil
+}
+
+// FindSyncMark returns the latest update of the flows synced from the
+// source, or the zero time if never synced.
+func (db *DB) FindSyncMark(source string) (time.Time, error) {
+	var mark time.Time
+	err := db.retry("find sync mark", &db.Conn, func(conn *pgx.Conn) error {
+		err := conn.QueryRow(context.Background(), findSyncMarkSQL, source).Scan(&mark)
+		if err == pgx.ErrNoRows {
+			mark = time.Time{}
+			return nil
+		}
+		if err != nil {
+			return xerrors.Errorf("find sync mark error: %w", err)
+		}
+		return nil
+	})
+	return mark, err
+}
+
+// SetSyncMark records the latest update of the flows synced from the
+// source, unless a later one is recorded.
+func (db *DB) SetSyncMark(source string, mark time.Time) error {
+	return db.retry("set sync mark", &db.Conn, func(conn *pgx.Conn) error {
+		if _, err := conn.Exec(context.Background(), upsertSyncMarkSQL, source, mark); err != nil {
+			return xerrors.Errorf("set sync mark error: %w", err)
+		}
+		return nil
+	})
+}
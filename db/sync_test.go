@@ -0,0 +1,85 @@
+package db
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/yuuki/shawk/probe"
+)
+
+func TestSyncFlows(t *testing.T) {
+	db, teardown := setupTestCase(t)
+	defer teardown(t)
+
+	flows := []*probe.HostFlow{
+		{
+			Direction: probe.FlowActive, Process: &probe.Process{Name: "app", Pgid: 100}, Connections: 2,
+			Local: &probe.AddrPort{Addr: "10.0.1.5", Aggregated: true},
+			Peer:  &probe.AddrPort{Addr: "10.0.2.1", Port: 5432},
+		},
+	}
+	if err := db.InsertOrUpdateHostFlows(flows); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	listed, err := db.ListFlows(&ListFlowsCond{})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if len(listed) != 1 {
+		t.Fatalf("ListFlows() should return 1 flow, but %d", len(listed))
+	}
+	updated := listed[0].Updated
+
+	older := *listed[0]
+	older.Connections = 1
+	older.Updated = updated.Add(-time.Hour)
+	newer := *listed[0]
+	newer.Connections = 5
+	newer.Updated = updated.Add(time.Hour)
+	added := *listed[0]
+	added.PassiveNode = &Node{IPAddr: net.ParseIP("10.0.2.2"), Port: 6379, Pname: "redis", Pgid: 300}
+	added.Updated = updated
+
+	result, err := db.SyncFlows([]*Flow{&older, &newer, &added})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if diff := cmp.Diff(&SyncResult{Inserted: 1, Updated: 1, Skipped: 1}, result); diff != "" {
+		t.Errorf("SyncFlows() mismatch (-want +got):\n%s", diff)
+	}
+
+	listed, err = db.ListFlows(&ListFlowsCond{})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	conns := map[uint16]int{}
+	for _, f := range listed {
+		conns[f.PassiveNode.Port] = f.Connections
+	}
+	if diff := cmp.Diff(map[uint16]int{5432: 5, 6379: 2}, conns); diff != "" {
+		t.Errorf("the flows updated later should be kept (-want +got):\n%s", diff)
+	}
+
+	mark, err := db.FindSyncMark("db-1:5432/shawk")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if !mark.IsZero() {
+		t.Errorf("FindSyncMark() should return the zero time before a sync, but %v", mark)
+	}
+	for _, m := range []time.Time{newer.Updated, updated} {
+		if err := db.SetSyncMark("db-1:5432/shawk", m); err != nil {
+			t.Fatalf("%+v", err)
+		}
+	}
+	mark, err = db.FindSyncMark("db-1:5432/shawk")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if !mark.Equal(newer.Updated) {
+		t.Errorf("FindSyncMark() = %v, want %v which never goes back", mark, newer.Updated)
+	}
+}
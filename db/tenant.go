@@ -0,0 +1,35 @@
+package db
+
+// DefaultTenant is the tenant of the agents without a tenant, which owns the
+// processes and the flows written before the tenants.
+const DefaultTenant = ""
+
+// SetTenant scopes the writes and the queries to the tenant, so that the
+// teams or the environments sharing the CMDB see only their own processes
+// and flows. The same address in two tenants is two processes.
+func (db *DB) SetTenant(tenant string) {
+	db.tenant = tenant
+}
+
+// SetAllTenants makes the queries read the flows of all the tenants, which
+// is the admin mode across the tenants. The writes are still of the tenant
+// of SetTenant.
+func (db *DB) SetAllTenants(all bool) {
+	db.allTenants = all
+}
+
+// tenantScope returns the tenant of the queries as their argument, which
+// is NULL to read all the tenants. The queries compare it by
+// '($N::varchar IS NULL OR tenant = $N)'.
+func (db *DB) tenantScope() interface{} {
+	if db.allTenants {
+		return nil
+	}
+	return db.tenant
+}
+
+// inScope returns whether the change of the tenant is in the scope of the
+// queries.
+func (db *DB) inScope(tenant string) bool {
+	return db.allTenants || tenant == db.tenant
+}
@@ -0,0 +1,96 @@
+package db
+
+import (
+	"net"
+	"testing"
+
+	"github.com/yuuki/shawk/probe"
+)
+
+func TestTenants(t *testing.T) {
+	db, teardown := setupTestCase(t)
+	defer teardown(t)
+
+	flowsOf := func(pname string) []*probe.HostFlow {
+		return []*probe.HostFlow{
+			{
+				Direction:   probe.FlowActive,
+				Local:       &probe.AddrPort{Addr: "10.0.10.1", Aggregated: true},
+				Peer:        &probe.AddrPort{Addr: "10.0.10.2", Port: 80},
+				Process:     &probe.Process{Pgid: 1001, Name: pname},
+				Connections: 10,
+			},
+		}
+	}
+	// The same addresses are written by the agents of two tenants.
+	db.SetTenant("team-a")
+	if err := db.InsertOrUpdateHostFlows(flowsOf("haproxy")); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	db.SetTenant("team-b")
+	if err := db.InsertOrUpdateHostFlows(flowsOf("envoy")); err != nil {
+		t.Fatalf("%+v", err)
+	}
+
+	cond := &FindFlowsCond{Addrs: []net.IP{net.ParseIP("10.0.10.1")}}
+	for _, tenant := range []string{"team-a", "team-b"} {
+		db.SetTenant(tenant)
+		flows, err := db.ListFlows(&ListFlowsCond{})
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		if len(flows) != 1 || flows[0].Tenant != tenant {
+			t.Errorf("ListFlows() of %s should return a flow of %s, but %+v", tenant, tenant, flows)
+		}
+		active, err := db.FindActiveFlows(cond)
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		if len(active) != 1 {
+			t.Errorf("FindActiveFlows() of %s should return the flows of a process, but %d", tenant, len(active))
+		}
+	}
+
+	db.SetTenant(DefaultTenant)
+	flows, err := db.ListFlows(&ListFlowsCond{})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if len(flows) != 0 {
+		t.Errorf("ListFlows() of the default tenant should return no flows, but %d", len(flows))
+	}
+
+	db.SetAllTenants(true)
+	flows, err = db.ListFlows(&ListFlowsCond{})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if len(flows) != 2 {
+		t.Errorf("ListFlows() across the tenants should return 2 flows, but %d", len(flows))
+	}
+	talkers, err := db.FindTopTalkers(&AggregateCond{})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if len(talkers) != 2 {
+		t.Errorf("FindTopTalkers() across the tenants should return 2 talkers, but %d", len(talkers))
+	}
+}
+
+func TestDB_inScope(t *testing.T) {
+	db := &DB{}
+	db.SetTenant("team-a")
+	if !db.inScope("team-a") || db.inScope("team-b") || db.inScope(DefaultTenant) {
+		t.Error("inScope() should be true only for team-a")
+	}
+	if db.tenantScope() != "team-a" {
+		t.Errorf("tenantScope() = %v, want team-a", db.tenantScope())
+	}
+	db.SetAllTenants(true)
+	if !db.inScope("team-b") {
+		t.Error("inScope() should be true for all the tenants")
+	}
+	if db.tenantScope() != nil {
+		t.Errorf("tenantScope() = %v, want nil", db.tenantScope())
+	}
+}
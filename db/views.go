@@ -0,0 +1,274 @@
+package db
+
+import (
+	"context"
+	"net"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+	"golang.org/x/xerrors"
+
+	"github.com/yuuki/shawk/statik"
+)
+
+// viewsSchema defines the materialized views, which are optional and so not
+// in schemas.
+const viewsSchema = "/schema/views.sql"
+
+// views are the materialized views refreshed by RefreshViews.
+var views = []string{"top_talkers", "service_edges"}
+
+// defaultViewMaxStale is how old the views may be to answer the queries.
+const defaultViewMaxStale = 10 * time.Minute
+
+// Talker is a client process with the total connections of its flows.
+type Talker struct {
+	IPAddr net.IP
+	// VRF is the VRF of the address, which is empty for the default VRF.
+	VRF         string
+	Pname       string
+	Connections int64
+	Flows       int64
+}
+
+// ServiceEdge is the flows between the processes of the same names, which
+// are regarded as services, to the port.
+type ServiceEdge struct {
+	Client      string
+	Server      string
+	Port        uint16
+	Connections int64
+	// Clients and Servers are the numbers of the addresses of each side.
+	Clients int64
+	Servers int64
+}
+
+// AggregateCond represents a query condition for FindTopTalkers or
+// FindServiceEdges.
+type AggregateCond struct {
+	// Limit is the maximum number of the results. Zero returns all.
+	Limit int
+	// Since and Until are the range of the last update of the flows. The
+	// views answer the query only if both are zero, since they aggregate
+	// the flows of all time.
+	Since time.Time
+	Until time.Time
+}
+
+// SetViewMaxStale sets how old the materialized views may be to answer the
+// aggregate queries instead of the flows. Zero never uses them.
+func (db *DB) SetViewMaxStale(d time.Duration) {
+	db.viewMaxStale = d
+}
+
+// CreateViews creates the materialized views, which CreateSchema doesn't.
+func (db *DB) CreateViews() error {
+	db.connMu.Lock()
+	defer db.connMu.Unlock()
+	sql, err := statik.FindString(viewsSchema)
+	if err != nil {
+		return xerrors.Errorf("get schema error '%s': %v", viewsSchema, err)
+	}
+	if _, err := db.Exec(context.Background(), sql); err != nil {
+		return xerrors.Errorf("exec schema error '%s': %s", viewsSchema, err)
+	}
+	return nil
+}
+
+// RefreshViews refreshes the materialized views on a connection of its own,
+// so that the queries are not blocked while refreshing. The views are
+// refreshed concurrently, which keeps them readable in the meantime.
+func (db *DB) RefreshViews(ctx context.Context) error {
+	conn, err := connect(ctx, db.conf)
+	if err != nil {
+		return xerrors.Errorf("could not connect to postgres: %w", err)
+	}
+	defer conn.Close(context.Background())
+
+	for _, view := range views {
+		if _, err := conn.Exec(ctx, `REFRESH MATERIALIZED VIEW CONCURRENTLY `+view); err != nil {
+			return xerrors.Errorf("refresh %s error: %w", view, err)
+		}
+		if _, err := conn.Exec(ctx, `
+			INSERT INTO view_refreshes (name, refreshed) VALUES ($1, CURRENT_TIMESTAMP)
+			ON CONFLICT (name) DO UPDATE SET refreshed=CURRENT_TIMESTAMP
+		`, view); err != nil {
+			return xerrors.Errorf("record the refresh of %s error: %w", view, err)
+		}
+	}
+	return nil
+}
+
+// viewAge returns how long ago the view was refreshed. ok is false if the
+// views are not created.
+func viewAge(conn *pgx.Conn, view string) (age time.Duration, ok bool, err error) {
+	var sec float64
+	err = conn.QueryRow(context.Background(), `
+		SELECT EXTRACT(EPOCH FROM (CURRENT_TIMESTAMP - refreshed)) FROM view_refreshes WHERE name = $1
+	`, view).Scan(&sec)
+	var stater sqlStater
+	switch {
+	case err == pgx.ErrNoRows, xerrors.As(err, &stater) && stater.SQLState() == undefinedTable:
+		return 0, false, nil
+	case err != nil:
+		return 0, false, xerrors.Errorf("query the refresh of %s error: %w", view, err)
+	}
+	return time.Duration(sec * float64(time.Second)), true, nil
+}
+
+// useView returns whether the view of the age answers the query of cond.
+func useView(cond *AggregateCond, age time.Duration, ok bool, maxStale time.Duration) bool {
+	return ok && maxStale > 0 && age <= maxStale && cond.Since.IsZero() && cond.Until.IsZero()
+}
+
+// limitOf returns the LIMIT of the query, where NULL is no limit.
+func limitOf(cond *AggregateCond) interface{} {
+	if cond.Limit > 0 {
+		return cond.Limit
+	}
+	return nil
+}
+
+// FindTopTalkers queries the client processes in descending order of the
+// connections, from the view top_talkers if it is fresh.
+func (db *DB) FindTopTalkers(cond *AggregateCond) ([]*Talker, error) {
+	var talkers []*Talker
+	err := db.retry("find top talkers", &db.Conn, func(conn *pgx.Conn) error {
+		var err error
+		talkers, err = findTopTalkers(conn, cond, db.viewMaxStale, db.tenantScope())
+		return err
+	})
+	return talkers, err
+}
+
+func findTopTalkers(conn *pgx.Conn, cond *AggregateCond, maxStale time.Duration, tenant interface{}) ([]*Talker, error) {
+	age, ok, err := viewAge(conn, "top_talkers")
+	if err != nil {
+		return nil, err
+	}
+	var rows pgx.Rows
+	if useView(cond, age, ok, maxStale) {
+		// The rows of the tenants are summed up across the tenants.
+		rows, err = conn.Query(context.Background(), `
+		SELECT ipv4, vrf, pname, SUM(connections)::bigint AS connections, SUM(flows)::bigint AS flows
+		FROM top_talkers
+		WHERE ($2::varchar IS NULL OR tenant = $2)
+		GROUP BY ipv4, vrf, pname
+		ORDER BY connections DESC, ipv4, vrf, pname
+		LIMIT $1
+	`, limitOf(cond), tenant)
+	} else {
+		until := cond.Until
+		if until.IsZero() {
+			until = time.Now()
+		}
+		rows, err = conn.Query(context.Background(), `
+		SELECT
+			processes.ipv4,
+			processes.vrf,
+			processes.pname,
+			SUM(flows.connections)::bigint AS connections,
+			COUNT(*)::bigint AS flows
+		FROM flows
+		INNER JOIN active_nodes ON active_nodes.node_id = flows.source_node_id
+		INNER JOIN processes ON processes.process_id = active_nodes.process_id
+		WHERE flows.updated BETWEEN $2 AND $3 AND ($4::varchar IS NULL OR flows.tenant = $4)
+		GROUP BY processes.ipv4, processes.vrf, processes.pname
+		ORDER BY connections DESC, processes.ipv4, processes.vrf, processes.pname
+		LIMIT $1
+	`, limitOf(cond), cond.Since, until, tenant)
+	}
+	if err != nil {
+		return nil, xerrors.Errorf("find top talkers query error: %w", err)
+	}
+	defer rows.Close()
+
+	var talkers []*Talker
+	for rows.Next() {
+		t := &Talker{}
+		if err := rows.Scan(&t.IPAddr, &t.VRF, &t.Pname, &t.Connections, &t.Flows); err != nil {
+			return nil, xerrors.Errorf("rows scan error: %w", err)
+		}
+		talkers = append(talkers, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, xerrors.Errorf("rows error: %w", err)
+	}
+	return talkers, nil
+}
+
+// FindServiceEdges queries the edges between the services in descending
+// order of the connections, from the view service_edges if it is fresh.
+func (db *DB) FindServiceEdges(cond *AggregateCond) ([]*ServiceEdge, error) {
+	var edges []*ServiceEdge
+	err := db.retry("find service edges", &db.Conn, func(conn *pgx.Conn) error {
+		var err error
+		edges, err = findServiceEdges(conn, cond, db.viewMaxStale, db.tenantScope())
+		return err
+	})
+	return edges, err
+}
+
+func findServiceEdges(conn *pgx.Conn, cond *AggregateCond, maxStale time.Duration, tenant interface{}) ([]*ServiceEdge, error) {
+	age, ok, err := viewAge(conn, "service_edges")
+	if err != nil {
+		return nil, err
+	}
+	var rows pgx.Rows
+	if useView(cond, age, ok, maxStale) {
+		// The addresses of the tenants are distinct processes, so that
+		// the clients and the servers are summed up as well.
+		rows, err = conn.Query(context.Background(), `
+		SELECT
+			client, server, port,
+			SUM(connections)::bigint AS connections,
+			SUM(clients)::bigint AS clients,
+			SUM(servers)::bigint AS servers
+		FROM service_edges
+		WHERE ($2::varchar IS NULL OR tenant = $2)
+		GROUP BY client, server, port
+		ORDER BY connections DESC, client, server, port
+		LIMIT $1
+	`, limitOf(cond), tenant)
+	} else {
+		until := cond.Until
+		if until.IsZero() {
+			until = time.Now()
+		}
+		rows, err = conn.Query(context.Background(), `
+		SELECT
+			active_processes.pname AS client,
+			passive_processes.pname AS server,
+			passive_nodes.port,
+			SUM(flows.connections)::bigint AS connections,
+			COUNT(DISTINCT active_processes.ipv4)::bigint AS clients,
+			COUNT(DISTINCT passive_processes.ipv4)::bigint AS servers
+		FROM flows
+		INNER JOIN active_nodes ON active_nodes.node_id = flows.source_node_id
+		INNER JOIN processes AS active_processes ON active_processes.process_id = active_nodes.process_id
+		INNER JOIN passive_nodes ON passive_nodes.node_id = flows.destination_node_id
+		INNER JOIN processes AS passive_processes ON passive_processes.process_id = passive_nodes.process_id
+		WHERE flows.updated BETWEEN $2 AND $3 AND ($4::varchar IS NULL OR flows.tenant = $4)
+		GROUP BY active_processes.pname, passive_processes.pname, passive_nodes.port
+		ORDER BY connections DESC, client, server, passive_nodes.port
+		LIMIT $1
+	`, limitOf(cond), cond.Since, until, tenant)
+	}
+	if err != nil {
+		return nil, xerrors.Errorf("find service edges query error: %w", err)
+	}
+	defer rows.Close()
+
+	var edges []*ServiceEdge
+	for rows.Next() {
+		e := &ServiceEdge{}
+		if err := rows.Scan(&e.Client, &e.Server, &e.Port, &e.Connections, &e.Clients, &e.Servers); err != nil {
+			return nil, xerrors.Errorf("rows scan error: %w", err)
+		}
+		edges = append(edges, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, xerrors.Errorf("rows error: %w", err)
+	}
+	return edges, nil
+}
@@ -0,0 +1,109 @@
+package db
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/yuuki/shawk/probe"
+)
+
+func TestUseView(t *testing.T) {
+	since := time.Date(2020, 12, 20, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		desc     string
+		cond     AggregateCond
+		age      time.Duration
+		ok       bool
+		maxStale time.Duration
+		want     bool
+	}{
+		{desc: "fresh", age: time.Minute, ok: true, maxStale: 10 * time.Minute, want: true},
+		{desc: "stale", age: time.Hour, ok: true, maxStale: 10 * time.Minute},
+		{desc: "not created", maxStale: 10 * time.Minute},
+		{desc: "disabled", age: time.Minute, ok: true},
+		{desc: "ranged", cond: AggregateCond{Since: since}, age: time.Minute, ok: true, maxStale: 10 * time.Minute},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			if got := useView(&tt.cond, tt.age, tt.ok, tt.maxStale); got != tt.want {
+				t.Errorf("useView() = %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFindTopTalkers_views(t *testing.T) {
+	db, teardown := setupTestCase(t)
+	defer teardown(t)
+
+	input := []*probe.HostFlow{
+		{
+			Direction:   probe.FlowActive,
+			Local:       &probe.AddrPort{Addr: "10.0.10.1", Aggregated: true},
+			Peer:        &probe.AddrPort{Addr: "10.0.10.2", Port: 80},
+			Process:     &probe.Process{Pgid: 1001, Name: "haproxy"},
+			Connections: 100,
+		},
+		{
+			Direction:   probe.FlowActive,
+			Local:       &probe.AddrPort{Addr: "10.0.10.2", Aggregated: true},
+			Peer:        &probe.AddrPort{Addr: "10.0.10.3", Port: 5432},
+			Process:     &probe.Process{Pgid: 2002, Name: "gunicorn"},
+			Connections: 21,
+		},
+		{
+			Direction:   probe.FlowActive,
+			Local:       &probe.AddrPort{Addr: "10.0.10.2", Aggregated: true},
+			Peer:        &probe.AddrPort{Addr: "10.0.10.4", Port: 6379},
+			Process:     &probe.Process{Pgid: 2002, Name: "gunicorn"},
+			Connections: 14,
+		},
+	}
+	if err := db.InsertOrUpdateHostFlows(input); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []*Talker{
+		{IPAddr: net.ParseIP("10.0.10.1"), Pname: "haproxy", Connections: 100, Flows: 1},
+		{IPAddr: net.ParseIP("10.0.10.2"), Pname: "gunicorn", Connections: 35, Flows: 2},
+	}
+	// The flows answer the query before the views are created.
+	got, err := db.FindTopTalkers(&AggregateCond{})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("FindTopTalkers() mismatch (-want +got):\n%s", diff)
+	}
+
+	if err := db.CreateViews(); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if err := db.RefreshViews(context.Background()); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	got, err = db.FindTopTalkers(&AggregateCond{Limit: 1})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if diff := cmp.Diff(want[:1], got); diff != "" {
+		t.Errorf("FindTopTalkers() from the view mismatch (-want +got):\n%s", diff)
+	}
+
+	edges, err := db.FindServiceEdges(&AggregateCond{})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	wantEdges := []*ServiceEdge{
+		{Client: "haproxy", Server: "", Port: 80, Connections: 100, Clients: 1, Servers: 1},
+		{Client: "gunicorn", Server: "", Port: 5432, Connections: 21, Clients: 1, Servers: 1},
+		{Client: "gunicorn", Server: "", Port: 6379, Connections: 14, Clients: 1, Servers: 1},
+	}
+	if diff := cmp.Diff(wantEdges, edges); diff != "" {
+		t.Errorf("FindServiceEdges() mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,104 @@
+package db
+
+import (
+	"context"
+	"hash/fnv"
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/jackc/pgx/v4"
+	"golang.org/x/xerrors"
+
+	"github.com/yuuki/shawk/probe"
+)
+
+// EnableParallelWrites opens concurrency connections, which write host flows
+// by transactions of at most batchSize flows concurrently.
+func (db *DB) EnableParallelWrites(batchSize, concurrency int) error {
+	if batchSize < 1 || concurrency < 1 {
+		return xerrors.Errorf("batch size (%d) and concurrency (%d) must be positive", batchSize, concurrency)
+	}
+	ctx := context.Background()
+	writers := make([]*pgx.Conn, 0, concurrency)
+	for i := 0; i < concurrency; i++ {
+		conn, err := connect(ctx, db.conf)
+		if err != nil {
+			// Do not leak the connections opened before the failure.
+			for _, w := range writers {
+				w.Close(ctx)
+			}
+			return xerrors.Errorf("Could not connect to postgres: %v", err)
+		}
+		writers = append(writers, conn)
+	}
+	db.writers = append(db.writers, writers...)
+	db.batchSize = batchSize
+	return nil
+}
+
+// partitionFlows partitions the flows into n groups by the local process.
+// Upserting a process locks its row until the transaction ends, so that
+// transactions sharing the local process would be serialized, or deadlock
+// with each other. The flows in each group are sorted by the peer to lock
+// the peer processes in a consistent order.
+func partitionFlows(flows []*probe.HostFlow, n int) [][]*probe.HostFlow {
+	groups := make([][]*probe.HostFlow, n)
+	for _, f := range flows {
+		h := fnv.New32a()
+		h.Write([]byte(f.Local.Addr))
+		if f.Process != nil {
+			h.Write([]byte(strconv.Itoa(f.Process.Pgid)))
+			h.Write([]byte(f.Process.Name))
+		}
+		i := h.Sum32() % uint32(n)
+		groups[i] = append(groups[i], f)
+	}
+	for _, g := range groups {
+		sort.Slice(g, func(i, j int) bool {
+			if g[i].Peer.Addr != g[j].Peer.Addr {
+				return g[i].Peer.Addr < g[j].Peer.Addr
+			}
+			return g[i].Peer.Port < g[j].Peer.Port
+		})
+	}
+	return groups
+}
+
+func (db *DB) insertOrUpdateHostFlowsParallel(flows []*probe.HostFlow) error {
+	groups := partitionFlows(flows, len(db.writers))
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr error
+	)
+	for i, group := range groups {
+		wg.Add(1)
+		go func(conn **pgx.Conn, group []*probe.HostFlow) {
+			defer wg.Done()
+			for start := 0; start < len(group); start += db.batchSize {
+				end := start + db.batchSize
+				if end > len(group) {
+					end = len(group)
+				}
+				// A deadlock is retried because the peer processes may be
+				// shared with another group.
+				batch := group[start:end]
+				err := db.retry("write flows", conn, func(conn *pgx.Conn) error {
+					return insertOrUpdateHostFlows(conn, batch, db.tenant, db.threshold())
+				})
+				if err != nil {
+					mu.Lock()
+					if firstErr == nil {
+						firstErr = err
+					}
+					mu.Unlock()
+					return
+				}
+			}
+		}(&db.writers[i], group)
+	}
+	wg.Wait()
+	return firstErr
+}
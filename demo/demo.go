@@ -0,0 +1,234 @@
+// Package demo generates the synthetic topology of the services on the
+// hosts, and the flows that the agents on the hosts would scan, so that the
+// queries, the API and the performance of the CMDB are evaluated without
+// deploying the agents.
+package demo
+
+import (
+	"fmt"
+	"math/rand"
+	"net"
+	"strconv"
+
+	"golang.org/x/xerrors"
+
+	"github.com/yuuki/shawk/probe"
+)
+
+// LabelService is the label of the service of the hosts, which
+// `shawk graph --format servicegraph` names the services by.
+const LabelService = "service.name"
+
+// The limits of the topology.
+const (
+	MaxServices = 250
+	// MaxHostsPerService is the hosts in the /16 subnet of a service.
+	MaxHostsPerService = 65000
+)
+
+// The tiers of the services, which call only the services of the later
+// tiers, so that the topology is acyclic as the usual deployments.
+const (
+	tierFrontend = iota
+	tierApp
+	tierData
+)
+
+type kind struct {
+	name    string
+	process string
+	port    uint16
+}
+
+// kinds are the kinds of the services of the tiers.
+var kinds = [][]kind{
+	tierFrontend: {{"frontend", "nginx", 80}},
+	tierApp: {
+		{"api", "app", 8080}, {"auth", "app", 8081}, {"checkout", "app", 8082},
+		{"catalog", "app", 8083}, {"cart", "app", 8084}, {"search", "app", 8085},
+		{"notification", "app", 8086},
+	},
+	tierData: {
+		{"postgres", "postgres", 5432}, {"redis", "redis-server", 6379},
+		{"kafka", "java", 9092}, {"elasticsearch", "java", 9200},
+		{"memcached", "memcached", 11211}, {"mysql", "mysqld", 3306},
+	},
+}
+
+// Service is a service running on its hosts.
+type Service struct {
+	Name    string
+	Process string
+	Port    uint16
+	Hosts   []string
+	// Calls is the services the service connects to.
+	Calls []*Service
+
+	tier int
+	pgid int
+}
+
+// Topology is the services and their dependencies.
+type Topology struct {
+	Services []*Service
+}
+
+// Option is the size of the topology.
+type Option struct {
+	Hosts    int
+	Services int
+	// Seed makes the topology and the flows reproducible.
+	Seed int64
+}
+
+// Validate returns an error if the topology can't be generated.
+func (o *Option) Validate() error {
+	if o.Services <= 0 || o.Services > MaxServices {
+		return xerrors.Errorf("--services must be 1 to %d, but %d", MaxServices, o.Services)
+	}
+	if o.Hosts < o.Services {
+		return xerrors.Errorf("--hosts must be at least --services (%d), but %d", o.Services, o.Hosts)
+	}
+	if max := o.Services * MaxHostsPerService; o.Hosts > max {
+		return xerrors.Errorf("--hosts must be at most %d for %d services, but %d", max, o.Services, o.Hosts)
+	}
+	return nil
+}
+
+// New generates the topology of the option. The first service is the
+// frontend, about the two fifths of the rest are the data stores, and the
+// others are the applications. The hosts of the i-th service are in
+// 10.i.0.0/16.
+func New(o *Option) (*Topology, error) {
+	if err := o.Validate(); err != nil {
+		return nil, err
+	}
+	rnd := rand.New(rand.NewSource(o.Seed))
+
+	data := 0
+	if o.Services > 1 {
+		data = (o.Services - 1) * 2 / 5
+		if data == 0 {
+			data = 1
+		}
+	}
+	apps := o.Services - 1 - data
+
+	t := &Topology{}
+	used := map[string]int{}
+	add := func(tier, i int) {
+		k := kinds[tier][i%len(kinds[tier])]
+		name := k.name
+		if used[k.name]++; used[k.name] > 1 {
+			name += "-" + strconv.Itoa(used[k.name])
+		}
+		t.Services = append(t.Services, &Service{
+			Name: name, Process: k.process, Port: k.port,
+			tier: tier, pgid: 1000 + len(t.Services),
+		})
+	}
+	add(tierFrontend, 0)
+	for i := 0; i < apps; i++ {
+		add(tierApp, i)
+	}
+	for i := 0; i < data; i++ {
+		add(tierData, i)
+	}
+
+	// Spread the hosts evenly, giving the remainder to the first services.
+	for i, s := range t.Services {
+		n := o.Hosts / o.Services
+		if i < o.Hosts%o.Services {
+			n++
+		}
+		for j := 0; j < n; j++ {
+			s.Hosts = append(s.Hosts, hostAddr(i, j))
+		}
+	}
+
+	var appSvcs, dataSvcs []*Service
+	for _, s := range t.Services {
+		switch s.tier {
+		case tierApp:
+			appSvcs = append(appSvcs, s)
+		case tierData:
+			dataSvcs = append(dataSvcs, s)
+		}
+	}
+	frontend := t.Services[0]
+	frontend.Calls = appSvcs
+	if len(appSvcs) == 0 {
+		frontend.Calls = dataSvcs
+	}
+	for i, s := range appSvcs {
+		// An application may call a later application, and one to three
+		// data stores.
+		if i+1 < len(appSvcs) && rnd.Intn(2) == 0 {
+			s.Calls = append(s.Calls, appSvcs[i+1+rnd.Intn(len(appSvcs)-i-1)])
+		}
+		for _, j := range rnd.Perm(len(dataSvcs))[:min(len(dataSvcs), 1+rnd.Intn(3))] {
+			s.Calls = append(s.Calls, dataSvcs[j])
+		}
+	}
+	return t, nil
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// hostAddr returns the address of the j-th host of the i-th service, which
+// skips the network and the broadcast addresses of the /24 subnets.
+func hostAddr(i, j int) string {
+	return net.IPv4(10, byte(i), byte(j/254), byte(j%254+1)).String()
+}
+
+// fanout is the maximum number of the hosts of a service that a host of
+// its client connects to.
+const fanout = 3
+
+// Flows returns the flows of the hosts that the agents on them would scan:
+// the active flows from the hosts of a service to at most three hosts of
+// each service it calls, and the passive flows of the other ends. The
+// connections are random in each call.
+func (t *Topology) Flows(rnd *rand.Rand) map[string][]*probe.HostFlow {
+	flows := map[string][]*probe.HostFlow{}
+	for _, s := range t.Services {
+		for i, addr := range s.Hosts {
+			for _, callee := range s.Calls {
+				for k := 0; k < min(fanout, len(callee.Hosts)); k++ {
+					peer := callee.Hosts[(i+k)%len(callee.Hosts)]
+					conns := int64(1 + rnd.Intn(20))
+					flows[addr] = append(flows[addr], &probe.HostFlow{
+						Direction:   probe.FlowActive,
+						Local:       &probe.AddrPort{Addr: addr, Aggregated: true, Labels: map[string]string{LabelService: s.Name}},
+						Peer:        &probe.AddrPort{Addr: peer, Port: callee.Port, Labels: map[string]string{LabelService: callee.Name}},
+						Process:     &probe.Process{Name: s.Process, Pgid: s.pgid},
+						Connections: conns,
+					})
+					flows[peer] = append(flows[peer], &probe.HostFlow{
+						Direction:   probe.FlowPassive,
+						Local:       &probe.AddrPort{Addr: peer, Port: callee.Port, Labels: map[string]string{LabelService: callee.Name}},
+						Peer:        &probe.AddrPort{Addr: addr, Aggregated: true, Labels: map[string]string{LabelService: s.Name}},
+						Process:     &probe.Process{Name: callee.Process, Pgid: callee.pgid},
+						Connections: conns,
+					})
+				}
+			}
+		}
+	}
+	return flows
+}
+
+// String returns the summary of the topology.
+func (t *Topology) String() string {
+	hosts, calls := 0, 0
+	for _, s := range t.Services {
+		hosts += len(s.Hosts)
+		calls += len(s.Calls)
+	}
+	return fmt.Sprintf("%d services on %d hosts with %d dependencies", len(t.Services), hosts, calls)
+}
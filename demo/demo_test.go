@@ -0,0 +1,103 @@
+package demo
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/yuuki/shawk/probe"
+)
+
+func TestNew(t *testing.T) {
+	topo, err := New(&Option{Hosts: 20, Services: 8, Seed: 1})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+
+	var names []string
+	hosts := 0
+	for _, s := range topo.Services {
+		names = append(names, s.Name)
+		hosts += len(s.Hosts)
+		for _, c := range s.Calls {
+			if c.tier <= s.tier && !(c.tier == tierApp && s.tier == tierApp) {
+				t.Errorf("%s should not call %s of an earlier tier", s.Name, c.Name)
+			}
+		}
+	}
+	want := []string{"frontend", "api", "auth", "checkout", "catalog", "cart", "postgres", "redis"}
+	if diff := cmp.Diff(want, names); diff != "" {
+		t.Errorf("services mismatch (-want +got):\n%s", diff)
+	}
+	if hosts != 20 {
+		t.Errorf("the hosts should be 20, but %d", hosts)
+	}
+	if got := topo.Services[0].Hosts; len(got) != 3 || got[0] != "10.0.0.1" || got[2] != "10.0.0.3" {
+		t.Errorf("the hosts of the frontend should be 10.0.0.1 to 10.0.0.3, but %v", got)
+	}
+
+	again, _ := New(&Option{Hosts: 20, Services: 8, Seed: 1})
+	if topo.String() != again.String() {
+		t.Errorf("the same seed should generate the same topology, but %q and %q", topo, again)
+	}
+}
+
+func TestNew_names(t *testing.T) {
+	topo, err := New(&Option{Hosts: 20, Services: 20})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	seen := map[string]bool{}
+	for _, s := range topo.Services {
+		if seen[s.Name] {
+			t.Errorf("the name %s should be unique", s.Name)
+		}
+		seen[s.Name] = true
+	}
+	if !seen["api-2"] {
+		t.Errorf("the second api should be named api-2, but %v", seen)
+	}
+}
+
+func TestHostAddr(t *testing.T) {
+	for _, tt := range []struct {
+		i, j int
+		want string
+	}{
+		{0, 0, "10.0.0.1"},
+		{3, 253, "10.3.0.254"},
+		{3, 254, "10.3.1.1"},
+		{249, MaxHostsPerService - 1, "10.249.255.230"},
+	} {
+		if got := hostAddr(tt.i, tt.j); got != tt.want {
+			t.Errorf("hostAddr(%d, %d) should be %s, but %s", tt.i, tt.j, tt.want, got)
+		}
+	}
+}
+
+func TestTopology_Flows(t *testing.T) {
+	topo, err := New(&Option{Hosts: 2, Services: 2})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	flows := topo.Flows(rand.New(rand.NewSource(1)))
+
+	var got []string
+	for _, addr := range []string{"10.0.0.1", "10.1.0.1"} {
+		for _, f := range flows[addr] {
+			got = append(got, f.Direction.String()+" "+f.Local.Addr+":"+f.Local.PortString()+" "+
+				f.Peer.Addr+":"+f.Peer.PortString()+" "+f.Process.Name+" "+f.Peer.Labels[LabelService])
+		}
+	}
+	want := []string{
+		"active 10.0.0.1:many 10.1.0.1:5432 nginx postgres",
+		"passive 10.1.0.1:5432 10.0.0.1:many postgres frontend",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Flows() mismatch (-want +got):\n%s", diff)
+	}
+	if a, p := flows["10.0.0.1"][0], flows["10.1.0.1"][0]; a.Connections != p.Connections || a.Direction != probe.FlowActive {
+		t.Errorf("the both ends should have the same connections, but %d and %d", a.Connections, p.Connections)
+	}
+}
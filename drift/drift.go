@@ -0,0 +1,111 @@
+// Package drift compares the dependencies between the services declared by
+// the architecture with the flows observed in the CMDB, so that the
+// documents of the architecture are kept true to the running systems.
+package drift
+
+import (
+	"sort"
+
+	"github.com/yuuki/shawk/graph"
+)
+
+// Edge is the dependency of the client service on the server service.
+type Edge struct {
+	Client string
+	Server string
+	// Line is the line number of the edge in the declaration.
+	Line int
+}
+
+// Declared is the declared dependencies between the services.
+type Declared struct {
+	// Services are the clients of the declaration in order.
+	Services []string
+	// Edges are the dependencies in order.
+	Edges []*Edge
+
+	edges map[pair]*Edge
+}
+
+type pair struct{ client, server string }
+
+func (p pair) reverse() pair { return pair{p.server, p.client} }
+
+// Kind is the kind of the drift.
+type Kind string
+
+// The kinds of the drifts.
+const (
+	// Undeclared is the flows between the services not declared.
+	Undeclared Kind = "undeclared"
+	// Unobserved is the declared edge without the flows.
+	Unobserved Kind = "unobserved"
+	// Reversed is the declared edge whose flows are only from the server
+	// to the client.
+	Reversed Kind = "reversed"
+)
+
+// Finding is a drift of the observed flows from the declaration.
+type Finding struct {
+	Kind Kind
+	// Client and Server are of the declared edge, or of the observed flows
+	// for Undeclared.
+	Client string
+	Server string
+	// Line is the line number of the declared edge, or zero for
+	// Undeclared.
+	Line int
+	// Connections is the connections of the observed flows, which are from
+	// Server to Client for Reversed, and zero for Unobserved.
+	Connections int
+}
+
+// Check returns the drifts of the edges of g merged by their services from
+// d: the undeclared ones in the order of the services, and then the
+// reversed and the unobserved ones in the order of the declaration. The
+// flows within a service are not drifts.
+func Check(d *Declared, g *graph.Graph) []*Finding {
+	observed := map[pair]int{}
+	for _, e := range g.Edges {
+		p := pair{e.From.Service(), e.To.Service()}
+		if p.client == p.server {
+			continue
+		}
+		observed[p] += e.Connections
+	}
+
+	var undeclared []*Finding
+	for p, conns := range observed {
+		if _, ok := d.edges[p]; ok {
+			continue
+		}
+		if _, ok := d.edges[p.reverse()]; ok && observed[p.reverse()] == 0 {
+			// reported as the reversed edge
+			continue
+		}
+		undeclared = append(undeclared, &Finding{Kind: Undeclared, Client: p.client, Server: p.server, Connections: conns})
+	}
+	sort.Slice(undeclared, func(i, j int) bool {
+		a, b := undeclared[i], undeclared[j]
+		if a.Client != b.Client {
+			return a.Client < b.Client
+		}
+		return a.Server < b.Server
+	})
+
+	var reversed, unobserved []*Finding
+	for _, e := range d.Edges {
+		p := pair{e.Client, e.Server}
+		if observed[p] > 0 || p.client == p.server {
+			continue
+		}
+		if conns := observed[p.reverse()]; conns > 0 {
+			if _, ok := d.edges[p.reverse()]; !ok {
+				reversed = append(reversed, &Finding{Kind: Reversed, Client: e.Client, Server: e.Server, Line: e.Line, Connections: conns})
+				continue
+			}
+		}
+		unobserved = append(unobserved, &Finding{Kind: Unobserved, Client: e.Client, Server: e.Server, Line: e.Line})
+	}
+	return append(append(undeclared, reversed...), unobserved...)
+}
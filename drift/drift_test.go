@@ -0,0 +1,91 @@
+package drift
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/yuuki/shawk/db"
+	"github.com/yuuki/shawk/db/dbtest"
+	"github.com/yuuki/shawk/graph"
+)
+
+const testDeclared = `
+# the services of the shop
+web:
+  - api
+  - "cache"
+api: [postgres, queue]   # the queue is for the orders
+worker: ['api']
+postgres: []
+`
+
+func TestParse(t *testing.T) {
+	d, err := Parse(strings.NewReader(testDeclared))
+	if err != nil {
+		t.Fatalf("Parse() should not return an error: %+v", err)
+	}
+	if diff := cmp.Diff([]string{"web", "api", "worker", "postgres"}, d.Services); diff != "" {
+		t.Errorf("Services mismatch (-want +got):\n%s", diff)
+	}
+	want := []*Edge{
+		{Client: "web", Server: "api", Line: 4},
+		{Client: "web", Server: "cache", Line: 5},
+		{Client: "api", Server: "postgres", Line: 6},
+		{Client: "api", Server: "queue", Line: 6},
+		{Client: "worker", Server: "api", Line: 7},
+	}
+	if diff := cmp.Diff(want, d.Edges); diff != "" {
+		t.Errorf("Edges mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestParse_error(t *testing.T) {
+	tests := []struct {
+		in      string
+		wantErr string
+	}{
+		{"  - api\n", "line 1: the list item"},
+		{"web\n", "line 1: \"web\" must be a service followed by ':'"},
+		{"web: api\n", "line 1: the servers of \"web\" must be a list"},
+		{"web:\n  api\n", "line 2: the indented \"api\" must be a list item"},
+		{"web: [api]\nweb: [db]\n", "line 2: service \"web\" is already declared"},
+		{"web:\n  - api\n  - api\n", "line 3: web -> api is already declared at line 2"},
+		{"web:\n  - ''\n", "line 2: the server must not be empty"},
+	}
+	for _, tt := range tests {
+		_, err := Parse(strings.NewReader(tt.in))
+		if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("Parse(%q) should return an error containing %q, but %v", tt.in, tt.wantErr, err)
+		}
+	}
+}
+
+func TestCheck(t *testing.T) {
+	d, err := Parse(strings.NewReader(testDeclared))
+	if err != nil {
+		t.Fatal(err)
+	}
+	g := graph.New([]*db.Flow{
+		dbtest.Flow("10.0.0.1", "web", "10.0.0.2", "api", 8080, 10),
+		dbtest.Flow("10.0.0.1", "web", "10.0.0.5", "cache", 6379, 4),
+		// undeclared
+		dbtest.Flow("10.0.0.1", "web", "10.0.0.3", "postgres", 5432, 2),
+		// reversed: declared as worker -> api
+		dbtest.Flow("10.0.0.2", "api", "10.0.0.4", "worker", 9000, 3),
+		// within the service
+		dbtest.Flow("10.0.0.2", "api", "10.0.0.6", "api", 8080, 1),
+		dbtest.Flow("10.0.0.2", "api", "10.0.0.3", "postgres", 5432, 5),
+	})
+
+	got := Check(d, g)
+	want := []*Finding{
+		{Kind: Undeclared, Client: "web", Server: "postgres", Connections: 2},
+		{Kind: Reversed, Client: "worker", Server: "api", Line: 7, Connections: 3},
+		{Kind: Unobserved, Client: "api", Server: "queue", Line: 6},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Check() mismatch (-want +got):\n%s", diff)
+	}
+}
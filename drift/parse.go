@@ -0,0 +1,107 @@
+package drift
+
+import (
+	"bufio"
+	"io"
+	"strings"
+
+	"golang.org/x/xerrors"
+)
+
+// Parse parses the declared dependencies written in a subset of YAML, a
+// mapping of the clients to the lists of the servers they depend on:
+//
+//	# the comments start with '#'
+//	web:
+//	  - api
+//	  - cache
+//	api: [db, queue]
+//	batch: []
+//
+// The names are of the services as graph.Component.Service names them, such
+// as the values of the label service.name or the names of the processes,
+// and may be quoted.
+func Parse(r io.Reader) (*Declared, error) {
+	d := &Declared{edges: map[pair]*Edge{}}
+	var client string
+	clients := map[string]bool{}
+	sc := bufio.NewScanner(r)
+	for line := 1; sc.Scan(); line++ {
+		text := sc.Text()
+		if i := strings.IndexByte(text, '#'); i >= 0 {
+			text = text[:i]
+		}
+		if strings.TrimSpace(text) == "" {
+			continue
+		}
+		indented := text[0] == ' ' || text[0] == '\t'
+		text = strings.TrimSpace(text)
+		switch {
+		case indented && strings.HasPrefix(text, "-"):
+			if client == "" {
+				return nil, xerrors.Errorf("line %d: the list item %q needs a service before it such as 'web:'", line, text)
+			}
+			if err := d.add(line, client, strings.TrimSpace(text[1:])); err != nil {
+				return nil, xerrors.Errorf("line %d: %w", line, err)
+			}
+		case !indented:
+			i := strings.IndexByte(text, ':')
+			if i < 0 {
+				return nil, xerrors.Errorf("line %d: %q must be a service followed by ':' such as 'web:'", line, text)
+			}
+			client = unquote(strings.TrimSpace(text[:i]))
+			if client == "" {
+				return nil, xerrors.Errorf("line %d: the service must not be empty", line)
+			}
+			if clients[client] {
+				return nil, xerrors.Errorf("line %d: service %q is already declared", line, client)
+			}
+			clients[client] = true
+			d.Services = append(d.Services, client)
+			rest := strings.TrimSpace(text[i+1:])
+			if rest == "" {
+				continue
+			}
+			if !strings.HasPrefix(rest, "[") || !strings.HasSuffix(rest, "]") {
+				return nil, xerrors.Errorf("line %d: the servers of %q must be a list such as '[api, db]'", line, client)
+			}
+			for _, server := range strings.Split(rest[1:len(rest)-1], ",") {
+				if strings.TrimSpace(server) == "" {
+					continue
+				}
+				if err := d.add(line, client, strings.TrimSpace(server)); err != nil {
+					return nil, xerrors.Errorf("line %d: %w", line, err)
+				}
+			}
+		default:
+			return nil, xerrors.Errorf("line %d: the indented %q must be a list item such as '- api'", line, text)
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, xerrors.Errorf("could not read the declared dependencies: %w", err)
+	}
+	return d, nil
+}
+
+func (d *Declared) add(line int, client, server string) error {
+	server = unquote(server)
+	if server == "" {
+		return xerrors.New("the server must not be empty")
+	}
+	p := pair{client, server}
+	if prev, ok := d.edges[p]; ok {
+		return xerrors.Errorf("%s -> %s is already declared at line %d", client, server, prev.Line)
+	}
+	e := &Edge{Client: client, Server: server, Line: line}
+	d.edges[p] = e
+	d.Edges = append(d.Edges, e)
+	return nil
+}
+
+// unquote removes the quotes of YAML around the name if any.
+func unquote(s string) string {
+	if len(s) >= 2 && (s[0] == '"' || s[0] == '\'') && s[len(s)-1] == s[0] {
+		return s[1 : len(s)-1]
+	}
+	return s
+}
@@ -0,0 +1,233 @@
+package consul
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/xerrors"
+
+	"github.com/yuuki/shawk/logging"
+	"github.com/yuuki/shawk/probe"
+)
+
+// Label keys attached to flow endpoints.
+const (
+	LabelService = "consul.service"
+	LabelTags    = "consul.tags"
+)
+
+const requestTimeout = 10 * time.Second
+
+var logger = logging.New("enricher/consul")
+
+// Option represents an option for the Consul enricher.
+type Option struct {
+	Address         string // the base URL of the Consul HTTP API such as http://127.0.0.1:8500
+	Token           string // ACL token
+	Datacenter      string // empty means the datacenter of the agent
+	RefreshInterval time.Duration
+}
+
+// catalogService is an entry of /v1/catalog/service/:service.
+type catalogService struct {
+	Address        string   `json:"Address"`
+	ServiceName    string   `json:"ServiceName"`
+	ServiceAddress string   `json:"ServiceAddress"`
+	ServicePort    int      `json:"ServicePort"`
+	ServiceTags    []string `json:"ServiceTags"`
+}
+
+type serviceEntry struct {
+	name string
+	tags string
+}
+
+// Enricher labels flow endpoints with the services registered in the Consul catalog.
+// The catalog is refreshed in the background, so that Enrich never waits
+// for Consul.
+type Enricher struct {
+	opt        *Option
+	httpClient *http.Client
+
+	mu         sync.Mutex
+	byService  map[string]map[string]*serviceEntry // the instances of the services by <addr>:<port>
+	byAddr     map[string]*serviceEntry            // key is <addr>:<port>
+	refreshed  time.Time                           // the last attempt to refresh
+	refreshing bool
+	wg         sync.WaitGroup // waits for the refresh in the tests
+	err        error          // the error of the last refresh not returned yet
+}
+
+// NewEnricher creates an Enricher.
+func NewEnricher(opt *Option) *Enricher {
+	return &Enricher{
+		opt:        opt,
+		httpClient: &http.Client{Timeout: requestTimeout},
+		byService:  map[string]map[string]*serviceEntry{},
+		byAddr:     map[string]*serviceEntry{},
+	}
+}
+
+// Name returns the name of the enricher.
+func (e *Enricher) Name() string {
+	return "consul"
+}
+
+func (e *Enricher) get(path string, v interface{}) error {
+	u, err := url.Parse(strings.TrimSuffix(e.opt.Address, "/") + path)
+	if err != nil {
+		return xerrors.Errorf("invalid consul address %q: %w", e.opt.Address, err)
+	}
+	if e.opt.Datacenter != "" {
+		q := u.Query()
+		q.Set("dc", e.opt.Datacenter)
+		u.RawQuery = q.Encode()
+	}
+	req, err := http.NewRequest(http.MethodGet, u.String(), nil)
+	if err != nil {
+		return xerrors.Errorf("could not create request %s: %w", path, err)
+	}
+	if e.opt.Token != "" {
+		req.Header.Set("X-Consul-Token", e.opt.Token)
+	}
+
+	resp, err := e.httpClient.Do(req)
+	if err != nil {
+		return xerrors.Errorf("GET %s: %w", path, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := ioutil.ReadAll(resp.Body)
+		return xerrors.Errorf("GET %s: unexpected status %s: %s", path, resp.Status, body)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return xerrors.Errorf("GET %s: could not decode response: %w", path, err)
+	}
+	return nil
+}
+
+// refresh rebuilds the mapping from <addr>:<port> to the services. The
+// services failing to get keep their instances of the last refresh, and the
+// services removed from the catalog are forgotten.
+func (e *Enricher) refresh() error {
+	var services map[string][]string
+	if err := e.get("/v1/catalog/services", &services); err != nil {
+		return xerrors.Errorf("could not list services: %w", err)
+	}
+
+	e.mu.Lock()
+	prev := e.byService
+	e.mu.Unlock()
+
+	byService := make(map[string]map[string]*serviceEntry, len(services))
+	var failed []string
+	for name := range services {
+		instances, err := e.getService(name)
+		if err != nil {
+			logger.Errorf("could not get service %s: %+v", name, err)
+			failed = append(failed, name)
+			instances = prev[name]
+		}
+		byService[name] = instances
+	}
+	byAddr := make(map[string]*serviceEntry)
+	for _, instances := range byService {
+		for addr, ent := range instances {
+			byAddr[addr] = ent
+		}
+	}
+
+	e.mu.Lock()
+	e.byService, e.byAddr = byService, byAddr
+	e.mu.Unlock()
+	logger.Debugf("refreshed %d service instances", len(byAddr))
+
+	if len(failed) > 0 {
+		sort.Strings(failed)
+		return xerrors.Errorf("could not get %d of %d services: %s", len(failed), len(services), strings.Join(failed, ","))
+	}
+	return nil
+}
+
+// getService returns the instances of the service by <addr>:<port>.
+func (e *Enricher) getService(name string) (map[string]*serviceEntry, error) {
+	var entries []catalogService
+	if err := e.get("/v1/catalog/service/"+url.PathEscape(name), &entries); err != nil {
+		return nil, err
+	}
+	instances := make(map[string]*serviceEntry, len(entries))
+	for _, ent := range entries {
+		if ent.ServicePort == 0 {
+			continue
+		}
+		// ServiceAddress is empty if the service uses the address of the node.
+		addr := ent.ServiceAddress
+		if addr == "" {
+			addr = ent.Address
+		}
+		tags := append([]string{}, ent.ServiceTags...)
+		sort.Strings(tags)
+		instances[net.JoinHostPort(addr, strconv.Itoa(ent.ServicePort))] = &serviceEntry{
+			name: ent.ServiceName,
+			tags: strings.Join(tags, ","),
+		}
+	}
+	return instances, nil
+}
+
+// label labels the endpoint if its address and port belong to a service.
+func (e *Enricher) label(a *probe.AddrPort) {
+	if a.Aggregated {
+		return
+	}
+	ent, ok := e.byAddr[net.JoinHostPort(a.Addr, a.PortString())]
+	if !ok {
+		return
+	}
+	a.SetLabel(LabelService, ent.name)
+	if ent.tags != "" {
+		a.SetLabel(LabelTags, ent.tags)
+	}
+}
+
+// Enrich labels the listening side of the flows with the Consul services.
+// It starts refreshing the catalog in the background once per refresh
+// interval unless the last one is still running, and labels with the
+// mapping of the last refresh meanwhile. The error of the refresh is
+// returned by the next Enrich.
+func (e *Enricher) Enrich(flows []*probe.HostFlow) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if !e.refreshing && time.Since(e.refreshed) >= e.opt.RefreshInterval {
+		// Keep labeling with the stale mapping if Consul is unavailable,
+		// and retry after the interval.
+		e.refreshed = time.Now()
+		e.refreshing = true
+		e.wg.Add(1)
+		go func() {
+			defer e.wg.Done()
+			err := e.refresh()
+			e.mu.Lock()
+			e.refreshing, e.err = false, err
+			e.mu.Unlock()
+		}()
+	}
+
+	for _, flow := range flows {
+		e.label(flow.Local)
+		e.label(flow.Peer)
+	}
+	err := e.err
+	e.err = nil
+	return err
+}
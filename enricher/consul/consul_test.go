@@ -0,0 +1,210 @@
+package consul
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/yuuki/shawk/probe"
+)
+
+func TestEnrich(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("X-Consul-Token"); got != "secret" {
+			http.Error(w, "ACL not found", http.StatusForbidden)
+			return
+		}
+		switch r.URL.Path {
+		case "/v1/catalog/services":
+			w.Write([]byte(`{"web": ["v2", "http"], "postgres": []}`))
+		case "/v1/catalog/service/web":
+			w.Write([]byte(`[
+				{"Address": "10.0.0.1", "ServiceName": "web", "ServiceAddress": "", "ServicePort": 8080, "ServiceTags": ["v2", "http"]}
+			]`))
+		case "/v1/catalog/service/postgres":
+			w.Write([]byte(`[
+				{"Address": "10.0.0.2", "ServiceName": "postgres", "ServiceAddress": "10.0.1.2", "ServicePort": 5432, "ServiceTags": []}
+			]`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer ts.Close()
+
+	e := NewEnricher(&Option{
+		Address:         ts.URL,
+		Token:           "secret",
+		RefreshInterval: time.Minute,
+	})
+
+	flows := []*probe.HostFlow{
+		{
+			Direction: probe.FlowPassive,
+			Local:     &probe.AddrPort{Addr: "10.0.0.1", Port: 8080},
+			Peer:      &probe.AddrPort{Addr: "10.0.0.5", Aggregated: true},
+		},
+		{
+			Direction: probe.FlowActive,
+			Local:     &probe.AddrPort{Addr: "10.0.0.1", Aggregated: true},
+			Peer:      &probe.AddrPort{Addr: "10.0.1.2", Port: 5432},
+		},
+		{
+			Direction: probe.FlowActive,
+			Local:     &probe.AddrPort{Addr: "10.0.0.1", Aggregated: true},
+			Peer:      &probe.AddrPort{Addr: "10.0.0.2", Port: 5432},
+		},
+	}
+	// The first Enrich starts the refresh in the background.
+	if err := e.Enrich(flows); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	e.wg.Wait()
+	if err := e.Enrich(flows); err != nil {
+		t.Fatalf("%+v", err)
+	}
+
+	tests := []struct {
+		got  map[string]string
+		want map[string]string
+	}{
+		{
+			got:  flows[0].Local.Labels,
+			want: map[string]string{LabelService: "web", LabelTags: "http,v2"},
+		},
+		{got: flows[0].Peer.Labels, want: nil},
+		{got: flows[1].Local.Labels, want: nil},
+		{
+			got:  flows[1].Peer.Labels,
+			want: map[string]string{LabelService: "postgres"},
+		},
+		{
+			// the service address takes precedence over the node address.
+			got:  flows[2].Peer.Labels,
+			want: nil,
+		},
+	}
+	for i, tt := range tests {
+		if diff := cmp.Diff(tt.want, tt.got); diff != "" {
+			t.Errorf("#%d Enrich() mismatch (-want +got):\n%s", i, diff)
+		}
+	}
+}
+
+func TestEnrich_apiError(t *testing.T) {
+	var calls int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		http.Error(w, "ACL not found", http.StatusForbidden)
+	}))
+	defer ts.Close()
+
+	e := NewEnricher(&Option{Address: ts.URL, RefreshInterval: time.Minute})
+
+	flows := []*probe.HostFlow{
+		{
+			Direction: probe.FlowActive,
+			Local:     &probe.AddrPort{Addr: "10.0.0.1", Aggregated: true},
+			Peer:      &probe.AddrPort{Addr: "10.0.1.2", Port: 5432},
+		},
+	}
+	if err := e.Enrich(flows); err != nil {
+		t.Fatalf("Enrich() should not wait for the refresh: %v", err)
+	}
+	e.wg.Wait()
+	if err := e.Enrich(flows); err == nil {
+		t.Error("Enrich() should return an error when Consul rejects the request")
+	}
+	// the failed refresh is not retried until the interval passes.
+	prev := calls
+	if err := e.Enrich(flows); err != nil {
+		t.Errorf("Enrich() should not retry within the interval: %v", err)
+	}
+	if calls != prev {
+		t.Errorf("Consul should not be called again, but %d more times", calls-prev)
+	}
+}
+
+func TestEnrich_partialRefresh(t *testing.T) {
+	var (
+		mu     sync.Mutex
+		failed bool
+	)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		switch r.URL.Path {
+		case "/v1/catalog/services":
+			if failed {
+				w.Write([]byte(`{"web": [], "postgres": []}`))
+			} else {
+				w.Write([]byte(`{"web": [], "postgres": [], "redis": []}`))
+			}
+		case "/v1/catalog/service/web":
+			port := 8080
+			if failed {
+				port = 8081
+			}
+			fmt.Fprintf(w, `[{"Address": "10.0.0.1", "ServiceName": "web", "ServicePort": %d}]`, port)
+		case "/v1/catalog/service/postgres":
+			if failed {
+				http.Error(w, "internal error", http.StatusInternalServerError)
+				return
+			}
+			w.Write([]byte(`[{"Address": "10.0.0.2", "ServiceName": "postgres", "ServicePort": 5432}]`))
+		case "/v1/catalog/service/redis":
+			w.Write([]byte(`[{"Address": "10.0.0.3", "ServiceName": "redis", "ServicePort": 6379}]`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer ts.Close()
+
+	e := NewEnricher(&Option{Address: ts.URL, RefreshInterval: time.Minute})
+	if err := e.Enrich(nil); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	e.wg.Wait()
+
+	// postgres fails and redis is removed from the catalog at the next
+	// refresh.
+	mu.Lock()
+	failed = true
+	mu.Unlock()
+	e.refreshed = time.Time{}
+	if err := e.Enrich(nil); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	e.wg.Wait()
+
+	newFlow := func(addr string, port uint16) *probe.HostFlow {
+		return &probe.HostFlow{
+			Direction: probe.FlowActive,
+			Local:     &probe.AddrPort{Addr: "10.0.0.9", Aggregated: true},
+			Peer:      &probe.AddrPort{Addr: addr, Port: port},
+		}
+	}
+	flows := []*probe.HostFlow{
+		newFlow("10.0.0.1", 8080),
+		newFlow("10.0.0.1", 8081),
+		newFlow("10.0.0.2", 5432),
+		newFlow("10.0.0.3", 6379),
+	}
+	if err := e.Enrich(flows); err == nil {
+		t.Error("Enrich() should return the error of the failed service")
+	}
+
+	got := make([]string, len(flows))
+	for i, f := range flows {
+		got[i] = f.Peer.Labels[LabelService]
+	}
+	// the failed service keeps its instances of the last refresh.
+	want := []string{"", "web", "postgres", ""}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Enrich() mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,120 @@
+// +build linux
+
+package dns
+
+import (
+	"io"
+	"sync"
+	"syscall"
+	"time"
+
+	"golang.org/x/xerrors"
+)
+
+const (
+	// ethPAll is ETH_P_ALL in the network byte order, which the packet
+	// socket takes as its protocol.
+	ethPAll = 0x0300
+	// snapLen is the bytes read of a packet, which hold a DNS response
+	// over UDP with EDNS.
+	snapLen = 4096
+	// pollInterval is how often the capture checks whether it is closed
+	// while no packet arrives.
+	pollInterval = time.Second
+)
+
+// filter is the classic BPF accepting the UDP packets from port 53 of IPv4
+// or IPv6, as payloadOf does, so that the other packets are not copied into
+// the probe. The packets of the SOCK_DGRAM socket begin with the network
+// headers.
+var filter = []syscall.SockFilter{
+	/* 0 */ *syscall.LsfStmt(syscall.BPF_LD|syscall.BPF_B|syscall.BPF_ABS, 0),
+	/* 1 */ *syscall.LsfStmt(syscall.BPF_ALU|syscall.BPF_RSH|syscall.BPF_K, 4),
+	/* 2 */ *syscall.LsfJump(syscall.BPF_JMP|syscall.BPF_JEQ|syscall.BPF_K, 4, 0, 7),
+	// IPv4
+	/* 3 */ *syscall.LsfStmt(syscall.BPF_LD|syscall.BPF_B|syscall.BPF_ABS, 9),
+	/* 4 */ *syscall.LsfJump(syscall.BPF_JMP|syscall.BPF_JEQ|syscall.BPF_K, syscall.IPPROTO_UDP, 0, 11),
+	/* 5 */ *syscall.LsfStmt(syscall.BPF_LD|syscall.BPF_H|syscall.BPF_ABS, 6),
+	/* 6 */ *syscall.LsfJump(syscall.BPF_JMP|syscall.BPF_JSET|syscall.BPF_K, 0x3fff, 9, 0),
+	/* 7 */ *syscall.LsfStmt(syscall.BPF_LDX|syscall.BPF_B|syscall.BPF_MSH, 0),
+	/* 8 */ *syscall.LsfStmt(syscall.BPF_LD|syscall.BPF_H|syscall.BPF_IND, 0),
+	/* 9 */ *syscall.LsfStmt(syscall.BPF_JMP|syscall.BPF_JA, 4),
+	// IPv6
+	/* 10 */ *syscall.LsfJump(syscall.BPF_JMP|syscall.BPF_JEQ|syscall.BPF_K, 6, 0, 5),
+	/* 11 */ *syscall.LsfStmt(syscall.BPF_LD|syscall.BPF_B|syscall.BPF_ABS, 6),
+	/* 12 */ *syscall.LsfJump(syscall.BPF_JMP|syscall.BPF_JEQ|syscall.BPF_K, syscall.IPPROTO_UDP, 0, 3),
+	/* 13 */ *syscall.LsfStmt(syscall.BPF_LD|syscall.BPF_H|syscall.BPF_ABS, 40),
+	// the source port
+	/* 14 */ *syscall.LsfJump(syscall.BPF_JMP|syscall.BPF_JEQ|syscall.BPF_K, portDNS, 0, 1),
+	/* 15 */ *syscall.LsfStmt(syscall.BPF_RET|syscall.BPF_K, snapLen),
+	/* 16 */ *syscall.LsfStmt(syscall.BPF_RET|syscall.BPF_K, 0),
+}
+
+type capture struct {
+	fd   int
+	done chan struct{}
+	wg   sync.WaitGroup
+}
+
+// Capture starts recording the DNS responses received or forwarded by the
+// host into e, until the returned Closer is closed. It requires
+// CAP_NET_RAW. The responses over TCP aren't captured.
+func Capture(e *Enricher) (io.Closer, error) {
+	fd, err := syscall.Socket(syscall.AF_PACKET, syscall.SOCK_DGRAM, ethPAll)
+	if err != nil {
+		return nil, xerrors.Errorf("could not open the packet socket: %w", err)
+	}
+	if err := syscall.AttachLsf(fd, filter); err != nil {
+		syscall.Close(fd)
+		return nil, xerrors.Errorf("could not attach the filter of the DNS responses: %w", err)
+	}
+	tv := syscall.NsecToTimeval(pollInterval.Nanoseconds())
+	if err := syscall.SetsockoptTimeval(fd, syscall.SOL_SOCKET, syscall.SO_RCVTIMEO, &tv); err != nil {
+		syscall.Close(fd)
+		return nil, xerrors.Errorf("could not set the timeout of the packet socket: %w", err)
+	}
+
+	c := &capture{fd: fd, done: make(chan struct{})}
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+		c.loop(e)
+	}()
+	return c, nil
+}
+
+func (c *capture) loop(e *Enricher) {
+	buf := make([]byte, snapLen)
+	for {
+		select {
+		case <-c.done:
+			return
+		default:
+		}
+		n, _, err := syscall.Recvfrom(c.fd, buf, 0)
+		if err != nil {
+			if err == syscall.EAGAIN || err == syscall.EINTR {
+				continue
+			}
+			logger.Errorf("could not receive a packet: %v", err)
+			return
+		}
+		payload, ok := payloadOf(buf[:n])
+		if !ok {
+			continue
+		}
+		resp, err := parseResponse(payload)
+		if err != nil {
+			logger.Debugf("could not parse a DNS response: %v", err)
+			continue
+		}
+		e.record(resp)
+	}
+}
+
+// Close stops the capture.
+func (c *capture) Close() error {
+	close(c.done)
+	c.wg.Wait()
+	return syscall.Close(c.fd)
+}
@@ -0,0 +1,14 @@
+// +build !linux
+
+package dns
+
+import (
+	"io"
+
+	"golang.org/x/xerrors"
+)
+
+// Capture is not supported but on Linux.
+func Capture(e *Enricher) (io.Closer, error) {
+	return nil, xerrors.New("capturing the DNS responses requires Linux")
+}
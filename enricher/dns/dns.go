@@ -0,0 +1,94 @@
+// Package dns labels the peers with the names which the applications on the
+// host resolved into their addresses, by capturing the DNS responses passing
+// the host. The names are more accurate than the reverse lookups for the
+// endpoints of CDNs and clouds, whose PTR records name the providers.
+package dns
+
+import (
+	"sync"
+	"time"
+
+	"github.com/yuuki/shawk/logging"
+	"github.com/yuuki/shawk/probe"
+)
+
+// LabelName is the label of the name resolved into the address of the peer.
+const LabelName = "dns.name"
+
+// maxEntries bounds the addresses kept, so that a flood of responses doesn't
+// exhaust the memory. The addresses beyond it are dropped until the others
+// expire.
+const maxEntries = 65536
+
+var logger = logging.New("enricher/dns")
+
+type entry struct {
+	name    string
+	expires time.Time
+}
+
+// Enricher labels the peers of the flows with the names resolved into their
+// addresses.
+type Enricher struct {
+	// retention is how long the addresses are kept after their TTLs
+	// expire, because the connections outlive the TTLs.
+	retention time.Duration
+
+	mu     sync.Mutex
+	byAddr map[string]*entry
+	now    func() time.Time
+}
+
+// NewEnricher creates an Enricher keeping the addresses for retention after
+// their TTLs. The responses are recorded by Capture.
+func NewEnricher(retention time.Duration) *Enricher {
+	return &Enricher{
+		retention: retention,
+		byAddr:    make(map[string]*entry),
+		now:       time.Now,
+	}
+}
+
+// Name returns the name of the enricher.
+func (e *Enricher) Name() string {
+	return "dns"
+}
+
+// record records the addresses of a response. The latest name resolved into
+// an address wins.
+func (e *Enricher) record(resp *response) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	now := e.now()
+	for _, a := range resp.answers {
+		addr := a.ip.String()
+		if _, ok := e.byAddr[addr]; !ok && len(e.byAddr) >= maxEntries {
+			continue
+		}
+		e.byAddr[addr] = &entry{
+			name:    resp.name,
+			expires: now.Add(time.Duration(a.ttl)*time.Second + e.retention),
+		}
+	}
+}
+
+// Enrich labels the peers of the flows whose addresses were resolved, and
+// forgets the expired addresses.
+func (e *Enricher) Enrich(flows []*probe.HostFlow) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	now := e.now()
+	for addr, ent := range e.byAddr {
+		if now.After(ent.expires) {
+			delete(e.byAddr, addr)
+		}
+	}
+	for _, flow := range flows {
+		if ent, ok := e.byAddr[flow.Peer.Addr]; ok {
+			flow.Peer.SetLabel(LabelName, ent.name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package dns
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/yuuki/shawk/probe"
+)
+
+func TestEnrich(t *testing.T) {
+	now := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	e := NewEnricher(time.Minute)
+	e.now = func() time.Time { return now }
+	e.record(&response{
+		name: "www.example.com",
+		answers: []answer{
+			{ip: net.ParseIP("192.0.2.1").To4(), ttl: 30},
+			{ip: net.ParseIP("2001:db8::1"), ttl: 300},
+		},
+	})
+
+	newFlows := func() []*probe.HostFlow {
+		return []*probe.HostFlow{
+			{Local: &probe.AddrPort{Addr: "10.0.0.1"}, Peer: &probe.AddrPort{Addr: "192.0.2.1", Port: 443}},
+			{Local: &probe.AddrPort{Addr: "10.0.0.1"}, Peer: &probe.AddrPort{Addr: "2001:db8::1", Port: 443}},
+			{Local: &probe.AddrPort{Addr: "10.0.0.1"}, Peer: &probe.AddrPort{Addr: "192.0.2.9", Port: 443}},
+		}
+	}
+	labels := func(flows []*probe.HostFlow) []string {
+		got := make([]string, len(flows))
+		for i, f := range flows {
+			got[i] = f.Peer.Labels[LabelName]
+		}
+		return got
+	}
+
+	flows := newFlows()
+	if err := e.Enrich(flows); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"www.example.com", "www.example.com", ""}
+	if diff := cmp.Diff(want, labels(flows)); diff != "" {
+		t.Errorf("Enrich() mismatch (-want +got):\n%s", diff)
+	}
+
+	// The TTL of 192.0.2.1 and the retention have passed.
+	now = now.Add(2 * time.Minute)
+	flows = newFlows()
+	if err := e.Enrich(flows); err != nil {
+		t.Fatal(err)
+	}
+	want = []string{"", "www.example.com", ""}
+	if diff := cmp.Diff(want, labels(flows)); diff != "" {
+		t.Errorf("Enrich() mismatch (-want +got):\n%s", diff)
+	}
+	if _, ok := e.byAddr["192.0.2.1"]; ok {
+		t.Error("Enrich() should forget the expired address")
+	}
+}
@@ -0,0 +1,166 @@
+package dns
+
+import (
+	"encoding/binary"
+	"net"
+	"strings"
+
+	"golang.org/x/xerrors"
+)
+
+const (
+	headerLen = 12
+	portDNS   = 53
+
+	typeA    = 1
+	typeAAAA = 28
+	classIN  = 1
+
+	flagResponse = 1 << 15
+	rcodeMask    = 0xf
+
+	// maxPointers bounds the compression pointers followed in a name, so
+	// that a loop of the pointers ends.
+	maxPointers = 16
+)
+
+// answer is an address resolved for a name.
+type answer struct {
+	ip  net.IP
+	ttl uint32
+}
+
+// response is a DNS response, whose addresses are of the name asked by the
+// question, following the CNAMEs.
+type response struct {
+	name    string
+	answers []answer
+}
+
+// payloadOf returns the UDP payload of an IPv4 or IPv6 packet from port 53,
+// or false if the packet is of another protocol or port. The fragments are
+// ignored.
+func payloadOf(packet []byte) ([]byte, bool) {
+	if len(packet) < 1 {
+		return nil, false
+	}
+	var udp []byte
+	switch packet[0] >> 4 {
+	case 4:
+		if len(packet) < 20 || packet[9] != 17 {
+			return nil, false
+		}
+		// the offset and the flag of more fragments
+		if binary.BigEndian.Uint16(packet[6:8])&0x3fff != 0 {
+			return nil, false
+		}
+		ihl := int(packet[0]&0xf) * 4
+		if ihl < 20 || len(packet) < ihl {
+			return nil, false
+		}
+		udp = packet[ihl:]
+	case 6:
+		// The extension headers are not followed.
+		if len(packet) < 40 || packet[6] != 17 {
+			return nil, false
+		}
+		udp = packet[40:]
+	default:
+		return nil, false
+	}
+	if len(udp) < 8 || binary.BigEndian.Uint16(udp[0:2]) != portDNS {
+		return nil, false
+	}
+	return udp[8:], true
+}
+
+// parseResponse parses the addresses of the A and AAAA records in a DNS
+// response. The responses of errors and the queries are rejected.
+func parseResponse(b []byte) (*response, error) {
+	if len(b) < headerLen {
+		return nil, xerrors.Errorf("the message is too short: %d bytes", len(b))
+	}
+	flags := binary.BigEndian.Uint16(b[2:4])
+	if flags&flagResponse == 0 {
+		return nil, xerrors.New("the message is not a response")
+	}
+	if rcode := flags & rcodeMask; rcode != 0 {
+		return nil, xerrors.Errorf("the response is of rcode %d", rcode)
+	}
+	qdcount := int(binary.BigEndian.Uint16(b[4:6]))
+	ancount := int(binary.BigEndian.Uint16(b[6:8]))
+	if qdcount != 1 {
+		return nil, xerrors.Errorf("the response should have a question, but %d", qdcount)
+	}
+
+	name, off, err := readName(b, headerLen)
+	if err != nil {
+		return nil, err
+	}
+	off += 4 // the type and the class
+	resp := &response{name: name}
+	for i := 0; i < ancount; i++ {
+		if _, off, err = readName(b, off); err != nil {
+			return nil, err
+		}
+		if len(b) < off+10 {
+			return nil, xerrors.New("the answer is truncated")
+		}
+		typ := binary.BigEndian.Uint16(b[off : off+2])
+		class := binary.BigEndian.Uint16(b[off+2 : off+4])
+		ttl := binary.BigEndian.Uint32(b[off+4 : off+8])
+		rdlen := int(binary.BigEndian.Uint16(b[off+8 : off+10]))
+		off += 10
+		if len(b) < off+rdlen {
+			return nil, xerrors.New("the data of the answer is truncated")
+		}
+		rdata := b[off : off+rdlen]
+		off += rdlen
+		if class != classIN {
+			continue
+		}
+		if typ == typeA && rdlen == net.IPv4len || typ == typeAAAA && rdlen == net.IPv6len {
+			resp.answers = append(resp.answers, answer{ip: net.IP(append([]byte{}, rdata...)), ttl: ttl})
+		}
+	}
+	return resp, nil
+}
+
+// readName reads the name at off of the message in lower case without the
+// trailing dot, and returns the offset following it.
+func readName(b []byte, off int) (string, int, error) {
+	var labels []string
+	next := -1 // the offset following the name before the first pointer
+	for pointers := 0; ; {
+		if off >= len(b) {
+			return "", 0, xerrors.New("the name is truncated")
+		}
+		l := int(b[off])
+		switch {
+		case l == 0:
+			if next < 0 {
+				next = off + 1
+			}
+			return strings.ToLower(strings.Join(labels, ".")), next, nil
+		case l&0xc0 == 0xc0:
+			if off+1 >= len(b) {
+				return "", 0, xerrors.New("the pointer of the name is truncated")
+			}
+			if pointers++; pointers > maxPointers {
+				return "", 0, xerrors.New("the name has too many pointers")
+			}
+			if next < 0 {
+				next = off + 2
+			}
+			off = int(binary.BigEndian.Uint16(b[off:off+2]) & 0x3fff)
+		case l&0xc0 != 0:
+			return "", 0, xerrors.Errorf("unknown label type 0x%x", l&0xc0)
+		default:
+			if off+1+l > len(b) {
+				return "", 0, xerrors.New("the label of the name is truncated")
+			}
+			labels = append(labels, string(b[off+1:off+1+l]))
+			off += 1 + l
+		}
+	}
+}
@@ -0,0 +1,142 @@
+package dns
+
+import (
+	"encoding/binary"
+	"net"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// exampleResponse is the response to the A query of www.example.com, whose
+// answers are a CNAME to edge.example.net and the A records of it in IN and
+// CH, with the compressed names.
+func exampleResponse() []byte {
+	b := []byte{
+		0x12, 0x34, // ID
+		0x81, 0x80, // QR, RD, RA, NOERROR
+		0, 1, // QDCOUNT
+		0, 3, // ANCOUNT
+		0, 0, // NSCOUNT
+		0, 0, // ARCOUNT
+	}
+	// question at 12
+	b = append(b, 3, 'W', 'W', 'W', 7, 'e', 'x', 'a', 'm', 'p', 'l', 'e', 3, 'c', 'o', 'm', 0)
+	b = append(b, 0, typeA, 0, classIN)
+	// CNAME
+	b = append(b, 0xc0, 12, 0, 5, 0, classIN, 0, 0, 0, 60, 0, 18)
+	cname := len(b)
+	b = append(b, 4, 'e', 'd', 'g', 'e', 7, 'e', 'x', 'a', 'm', 'p', 'l', 'e', 3, 'n', 'e', 't', 0)
+	// A
+	b = append(b, 0xc0, byte(cname), 0, typeA, 0, classIN, 0, 0, 0, 30, 0, 4, 192, 0, 2, 1)
+	// A of the class CH
+	b = append(b, 0xc0, byte(cname), 0, typeA, 0, 3, 0, 0, 0, 30, 0, 4, 192, 0, 2, 2)
+	return b
+}
+
+func TestParseResponse(t *testing.T) {
+	resp, err := parseResponse(exampleResponse())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &response{
+		name:    "www.example.com",
+		answers: []answer{{ip: net.ParseIP("192.0.2.1").To4(), ttl: 30}},
+	}
+	if diff := cmp.Diff(want, resp, cmp.AllowUnexported(response{}, answer{})); diff != "" {
+		t.Errorf("parseResponse() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestParseResponse_AAAA(t *testing.T) {
+	b := []byte{0, 0, 0x81, 0x80, 0, 1, 0, 1, 0, 0, 0, 0}
+	b = append(b, 2, 'd', 'b', 0, 0, typeAAAA, 0, classIN)
+	b = append(b, 0xc0, 12, 0, typeAAAA, 0, classIN, 0, 0, 1, 0, 0, 16)
+	b = append(b, net.ParseIP("2001:db8::1")...)
+
+	resp, err := parseResponse(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &response{
+		name:    "db",
+		answers: []answer{{ip: net.ParseIP("2001:db8::1"), ttl: 256}},
+	}
+	if diff := cmp.Diff(want, resp, cmp.AllowUnexported(response{}, answer{})); diff != "" {
+		t.Errorf("parseResponse() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestParseResponse_error(t *testing.T) {
+	query := exampleResponse()
+	query[2] &^= 0x80
+	nxdomain := exampleResponse()
+	nxdomain[3] |= 3
+	loop := []byte{0, 0, 0x81, 0x80, 0, 1, 0, 0, 0, 0, 0, 0, 0xc0, 12}
+	tests := map[string][]byte{
+		"short":     {0, 0, 0x81},
+		"query":     query,
+		"nxdomain":  nxdomain,
+		"truncated": exampleResponse()[:60],
+		"loop":      loop,
+	}
+	for name, b := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := parseResponse(b); err == nil {
+				t.Error("parseResponse() should return an error")
+			}
+		})
+	}
+}
+
+func udpPacket(header []byte, srcPort uint16, payload []byte) []byte {
+	udp := make([]byte, 8)
+	binary.BigEndian.PutUint16(udp[0:2], srcPort)
+	binary.BigEndian.PutUint16(udp[2:4], 40000)
+	binary.BigEndian.PutUint16(udp[4:6], uint16(8+len(payload)))
+	return append(append(header, udp...), payload...)
+}
+
+func ipv4Header(proto byte, fragment uint16) []byte {
+	h := make([]byte, 20)
+	h[0] = 0x45
+	binary.BigEndian.PutUint16(h[6:8], fragment)
+	h[9] = proto
+	return h
+}
+
+func ipv6Header(next byte) []byte {
+	h := make([]byte, 40)
+	h[0] = 0x60
+	h[6] = next
+	return h
+}
+
+func TestPayloadOf(t *testing.T) {
+	payload := []byte("dns")
+	tests := []struct {
+		name   string
+		packet []byte
+		want   bool
+	}{
+		{"IPv4", udpPacket(ipv4Header(17, 0), portDNS, payload), true},
+		{"IPv4 with DF", udpPacket(ipv4Header(17, 0x4000), portDNS, payload), true},
+		{"IPv4 fragment", udpPacket(ipv4Header(17, 0x2000), portDNS, payload), false},
+		{"IPv4 TCP", udpPacket(ipv4Header(6, 0), portDNS, payload), false},
+		{"IPv4 other port", udpPacket(ipv4Header(17, 0), 5353, payload), false},
+		{"IPv6", udpPacket(ipv6Header(17), portDNS, payload), true},
+		{"IPv6 extension header", udpPacket(ipv6Header(0), portDNS, payload), false},
+		{"empty", nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := payloadOf(tt.packet)
+			if ok != tt.want {
+				t.Fatalf("payloadOf() = _, %v, want %v", ok, tt.want)
+			}
+			if ok && string(got) != string(payload) {
+				t.Errorf("payloadOf() = %q, want %q", got, payload)
+			}
+		})
+	}
+}
@@ -0,0 +1,150 @@
+package ec2
+
+import (
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/xerrors"
+
+	"github.com/yuuki/shawk/cloud/aws"
+	"github.com/yuuki/shawk/logging"
+	"github.com/yuuki/shawk/probe"
+	"github.com/yuuki/shawk/probe/netlink/netutil"
+)
+
+// Label keys attached to flow endpoints.
+const (
+	LabelInstanceID     = "ec2.instance-id"
+	LabelName           = "ec2.name"
+	LabelENI            = "ec2.eni"
+	LabelSecurityGroups = "ec2.security-groups"
+	LabelDescription    = "ec2.description"
+)
+
+var logger = logging.New("enricher/ec2")
+
+// describer is the subset of the EC2 API used by the enricher.
+type describer interface {
+	DescribeNetworkInterfaces() ([]aws.NetworkInterface, error)
+	DescribeInstances() ([]aws.Instance, error)
+}
+
+// endpoint is the EC2 identity of a private address.
+type endpoint struct {
+	eni            string
+	instanceID     string
+	name           string
+	securityGroups string
+	description    string
+}
+
+func (e *endpoint) apply(a *probe.AddrPort) {
+	a.SetLabel(LabelENI, e.eni)
+	if e.securityGroups != "" {
+		a.SetLabel(LabelSecurityGroups, e.securityGroups)
+	}
+	if e.instanceID != "" {
+		a.SetLabel(LabelInstanceID, e.instanceID)
+		if e.name != "" {
+			a.SetLabel(LabelName, e.name)
+		}
+	} else if e.description != "" {
+		// ENIs without instances belong to managed services such as ELB or RDS.
+		a.SetLabel(LabelDescription, e.description)
+	}
+}
+
+// Enricher labels private addresses with the EC2 instances and the network
+// interfaces owning them. The EC2 API is called at most once per
+// refreshInterval to stay within the API rate limits.
+type Enricher struct {
+	api             describer
+	refreshInterval time.Duration
+
+	mu        sync.Mutex
+	byIP      map[string]*endpoint
+	refreshed time.Time // the last attempt to refresh
+}
+
+// NewEnricher creates an Enricher.
+func NewEnricher(api *aws.EC2, refreshInterval time.Duration) *Enricher {
+	return &Enricher{
+		api:             api,
+		refreshInterval: refreshInterval,
+		byIP:            map[string]*endpoint{},
+	}
+}
+
+// Name returns the name of the enricher.
+func (e *Enricher) Name() string {
+	return "ec2"
+}
+
+func (e *Enricher) refresh() error {
+	instances, err := e.api.DescribeInstances()
+	if err != nil {
+		return xerrors.Errorf("could not describe instances: %w", err)
+	}
+	names := make(map[string]string, len(instances))
+	for i := range instances {
+		names[instances[i].InstanceID] = instances[i].Name()
+	}
+
+	enis, err := e.api.DescribeNetworkInterfaces()
+	if err != nil {
+		return xerrors.Errorf("could not describe network interfaces: %w", err)
+	}
+	byIP := make(map[string]*endpoint, len(enis))
+	for _, eni := range enis {
+		groups := make([]string, 0, len(eni.Groups))
+		for _, g := range eni.Groups {
+			groups = append(groups, g.GroupID)
+		}
+		ep := &endpoint{
+			eni:            eni.NetworkInterfaceID,
+			instanceID:     eni.InstanceID,
+			name:           names[eni.InstanceID],
+			securityGroups: strings.Join(groups, ","),
+			description:    eni.Description,
+		}
+		for _, ip := range eni.PrivateIPAddresses {
+			byIP[ip] = ep
+		}
+	}
+
+	e.byIP = byIP
+	logger.Debugf("refreshed %d network interfaces and %d instances", len(enis), len(instances))
+	return nil
+}
+
+func (e *Enricher) label(a *probe.AddrPort) {
+	ip := net.ParseIP(a.Addr)
+	if ip == nil || !netutil.IsPrivateIP(ip) {
+		return
+	}
+	if ep, ok := e.byIP[a.Addr]; ok {
+		ep.apply(a)
+	}
+}
+
+// Enrich labels the private endpoints of the flows with EC2 identities.
+func (e *Enricher) Enrich(flows []*probe.HostFlow) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	var err error
+	if time.Since(e.refreshed) >= e.refreshInterval {
+		// Keep labeling with the stale mapping if the API is unavailable,
+		// and retry after the interval.
+		e.refreshed = time.Now()
+		err = e.refresh()
+	}
+
+	for _, flow := range flows {
+		e.label(flow.Local)
+		e.label(flow.Peer)
+	}
+	return err
+}
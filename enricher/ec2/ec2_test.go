@@ -0,0 +1,127 @@
+package ec2
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/yuuki/shawk/cloud/aws"
+	"github.com/yuuki/shawk/probe"
+)
+
+type fakeDescriber struct {
+	enis      []aws.NetworkInterface
+	instances []aws.Instance
+	err       error
+	calls     int
+}
+
+func (f *fakeDescriber) DescribeNetworkInterfaces() ([]aws.NetworkInterface, error) {
+	return f.enis, f.err
+}
+
+func (f *fakeDescriber) DescribeInstances() ([]aws.Instance, error) {
+	f.calls++
+	return f.instances, f.err
+}
+
+func TestEnrich(t *testing.T) {
+	api := &fakeDescriber{
+		enis: []aws.NetworkInterface{
+			{
+				NetworkInterfaceID: "eni-0001",
+				PrivateIPAddresses: []string{"10.0.0.10", "10.0.0.11"},
+				Groups:             []aws.SecurityGroup{{GroupID: "sg-web"}, {GroupID: "sg-ssh"}},
+				InstanceID:         "i-0001",
+			},
+			{
+				NetworkInterfaceID: "eni-0002",
+				Description:        "RDSNetworkInterface",
+				PrivateIPAddresses: []string{"10.0.1.20"},
+				Groups:             []aws.SecurityGroup{{GroupID: "sg-db"}},
+			},
+		},
+		instances: []aws.Instance{
+			{InstanceID: "i-0001", Tags: []aws.Tag{{Key: "Name", Value: "web-1"}}},
+		},
+	}
+	e := &Enricher{api: api, refreshInterval: time.Minute, byIP: map[string]*endpoint{}}
+
+	flows := []*probe.HostFlow{
+		{
+			Direction: probe.FlowActive,
+			Local:     &probe.AddrPort{Addr: "10.0.0.11", Aggregated: true},
+			Peer:      &probe.AddrPort{Addr: "10.0.1.20", Port: 5432},
+		},
+		{
+			Direction: probe.FlowActive,
+			Local:     &probe.AddrPort{Addr: "10.0.0.10", Aggregated: true},
+			Peer:      &probe.AddrPort{Addr: "52.0.0.1", Port: 443},
+		},
+	}
+	if err := e.Enrich(flows); err != nil {
+		t.Fatalf("%+v", err)
+	}
+
+	web := map[string]string{
+		LabelENI:            "eni-0001",
+		LabelInstanceID:     "i-0001",
+		LabelName:           "web-1",
+		LabelSecurityGroups: "sg-web,sg-ssh",
+	}
+	tests := []struct {
+		got  map[string]string
+		want map[string]string
+	}{
+		{got: flows[0].Local.Labels, want: web},
+		{
+			got: flows[0].Peer.Labels,
+			want: map[string]string{
+				LabelENI:            "eni-0002",
+				LabelDescription:    "RDSNetworkInterface",
+				LabelSecurityGroups: "sg-db",
+			},
+		},
+		{got: flows[1].Local.Labels, want: web},
+		// public addresses are not looked up.
+		{got: flows[1].Peer.Labels, want: nil},
+	}
+	for i, tt := range tests {
+		if diff := cmp.Diff(tt.want, tt.got); diff != "" {
+			t.Errorf("#%d Enrich() mismatch (-want +got):\n%s", i, diff)
+		}
+	}
+
+	// the cached mapping is used within the refresh interval.
+	if err := e.Enrich(flows); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if api.calls != 1 {
+		t.Errorf("the API should be called once, but %d times", api.calls)
+	}
+}
+
+func TestEnrich_apiError(t *testing.T) {
+	api := &fakeDescriber{err: errors.New("RequestLimitExceeded")}
+	e := &Enricher{api: api, refreshInterval: time.Minute, byIP: map[string]*endpoint{}}
+
+	flows := []*probe.HostFlow{
+		{
+			Direction: probe.FlowActive,
+			Local:     &probe.AddrPort{Addr: "10.0.0.10", Aggregated: true},
+			Peer:      &probe.AddrPort{Addr: "10.0.1.20", Port: 5432},
+		},
+	}
+	if err := e.Enrich(flows); err == nil {
+		t.Error("Enrich() should return an error when the API fails")
+	}
+	// the failed refresh is not retried until the interval passes.
+	if err := e.Enrich(flows); err != nil {
+		t.Errorf("Enrich() should not retry within the interval: %v", err)
+	}
+	if api.calls != 1 {
+		t.Errorf("the API should be called once, but %d times", api.calls)
+	}
+}
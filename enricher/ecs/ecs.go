@@ -0,0 +1,176 @@
+package ecs
+
+import (
+	"sync"
+	"time"
+
+	"golang.org/x/xerrors"
+
+	"github.com/yuuki/shawk/cloud/aws"
+	"github.com/yuuki/shawk/logging"
+	"github.com/yuuki/shawk/probe"
+)
+
+// Label keys attached to flow endpoints.
+const (
+	LabelCluster    = "ecs.cluster"
+	LabelService    = "ecs.service"
+	LabelTaskARN    = "ecs.task-arn"
+	LabelTaskFamily = "ecs.task-family"
+)
+
+var logger = logging.New("enricher/ecs")
+
+// lister is the subset of the ECS API used by the enricher.
+type lister interface {
+	ListTasks(cluster string) ([]string, error)
+	DescribeTasks(cluster string, arns []string) ([]aws.Task, error)
+}
+
+// endpoint is the ECS identity of an address of a task.
+type endpoint struct {
+	cluster string
+	service string
+	taskARN string
+	family  string
+}
+
+func (e *endpoint) apply(a *probe.AddrPort) {
+	a.SetLabel(LabelCluster, e.cluster)
+	a.SetLabel(LabelTaskARN, e.taskARN)
+	if e.family != "" {
+		a.SetLabel(LabelTaskFamily, e.family)
+	}
+	if e.service != "" {
+		a.SetLabel(LabelService, e.service)
+	}
+}
+
+// Option is the option of the Enricher.
+type Option struct {
+	// Task is the task of the agent, whose addresses label the local
+	// endpoints.
+	Task *aws.TaskMetadata
+	// API maps the peer addresses to the tasks of Clusters if not nil.
+	API      *aws.ECS
+	Clusters []string
+	// RefreshInterval is the interval of calling the ECS API.
+	RefreshInterval time.Duration
+}
+
+// Enricher labels the local endpoints with the ECS task of the agent, and
+// the private addresses of the peers with the tasks and the services of the
+// clusters. The ECS API is called at most once per refreshInterval to stay
+// within the API rate limits.
+type Enricher struct {
+	local map[string]*endpoint
+
+	api             lister
+	clusters        []string
+	refreshInterval time.Duration
+
+	mu        sync.Mutex
+	byIP      map[string]*endpoint
+	refreshed time.Time // the last attempt to refresh
+}
+
+// NewEnricher creates an Enricher.
+func NewEnricher(opt *Option) *Enricher {
+	e := &Enricher{
+		local:           taskEndpoints(opt.Task),
+		clusters:        opt.Clusters,
+		refreshInterval: opt.RefreshInterval,
+		byIP:            map[string]*endpoint{},
+	}
+	// Avoid a typed nil in the interface.
+	if opt.API != nil {
+		e.api = opt.API
+	}
+	return e
+}
+
+// taskEndpoints maps the addresses of the task to its identity.
+func taskEndpoints(task *aws.TaskMetadata) map[string]*endpoint {
+	local := map[string]*endpoint{}
+	if task == nil {
+		return local
+	}
+	ep := &endpoint{
+		cluster: task.ClusterName(),
+		service: task.ServiceName,
+		taskARN: task.TaskARN,
+		family:  task.Family,
+	}
+	for _, addr := range task.Addrs() {
+		local[addr] = ep
+	}
+	return local
+}
+
+// Name returns the name of the enricher.
+func (e *Enricher) Name() string {
+	return "ecs"
+}
+
+func (e *Enricher) refresh() error {
+	byIP := map[string]*endpoint{}
+	ntasks := 0
+	for _, cluster := range e.clusters {
+		arns, err := e.api.ListTasks(cluster)
+		if err != nil {
+			return xerrors.Errorf("could not list tasks of %s: %w", cluster, err)
+		}
+		tasks, err := e.api.DescribeTasks(cluster, arns)
+		if err != nil {
+			return xerrors.Errorf("could not describe tasks of %s: %w", cluster, err)
+		}
+		for i := range tasks {
+			ep := &endpoint{
+				cluster: cluster,
+				service: tasks[i].Service(),
+				taskARN: tasks[i].TaskARN,
+				family:  tasks[i].Family(),
+			}
+			for _, addr := range tasks[i].Addrs() {
+				byIP[addr] = ep
+			}
+		}
+		ntasks += len(tasks)
+	}
+
+	e.byIP = byIP
+	logger.Debugf("refreshed %d tasks of %d clusters", ntasks, len(e.clusters))
+	return nil
+}
+
+func (e *Enricher) label(a *probe.AddrPort, local bool) {
+	if local {
+		if ep, ok := e.local[a.Addr]; ok {
+			ep.apply(a)
+			return
+		}
+	}
+	if ep, ok := e.byIP[a.Addr]; ok {
+		ep.apply(a)
+	}
+}
+
+// Enrich labels the endpoints of the flows with ECS identities.
+func (e *Enricher) Enrich(flows []*probe.HostFlow) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	var err error
+	if e.api != nil && time.Since(e.refreshed) >= e.refreshInterval {
+		// Keep labeling with the stale mapping if the API is unavailable,
+		// and retry after the interval.
+		e.refreshed = time.Now()
+		err = e.refresh()
+	}
+
+	for _, flow := range flows {
+		e.label(flow.Local, true)
+		e.label(flow.Peer, false)
+	}
+	return err
+}
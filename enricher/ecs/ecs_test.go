@@ -0,0 +1,163 @@
+package ecs
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/yuuki/shawk/cloud/aws"
+	"github.com/yuuki/shawk/probe"
+)
+
+type fakeLister struct {
+	tasks map[string][]aws.Task
+	err   error
+	calls int
+}
+
+func (f *fakeLister) ListTasks(cluster string) ([]string, error) {
+	f.calls++
+	if f.err != nil {
+		return nil, f.err
+	}
+	arns := []string{}
+	for _, t := range f.tasks[cluster] {
+		arns = append(arns, t.TaskARN)
+	}
+	return arns, nil
+}
+
+func (f *fakeLister) DescribeTasks(cluster string, arns []string) ([]aws.Task, error) {
+	return f.tasks[cluster], f.err
+}
+
+func decode(t *testing.T, data string, v interface{}) {
+	t.Helper()
+	if err := json.Unmarshal([]byte(data), v); err != nil {
+		t.Fatalf("%+v", err)
+	}
+}
+
+func TestEnrich(t *testing.T) {
+	var task aws.TaskMetadata
+	decode(t, `{
+	"Cluster": "arn:aws:ecs:us-west-2:123456789012:cluster/prod",
+	"TaskARN": "arn:aws:ecs:us-west-2:123456789012:task/prod/web-1",
+	"Family": "web",
+	"ServiceName": "web",
+	"Containers": [{"Networks": [{"NetworkMode": "awsvpc", "IPv4Addresses": ["10.0.2.15"]}]}]
+}`, &task)
+	var db, migrate aws.Task
+	decode(t, `{
+	"taskArn": "arn:aws:ecs:us-west-2:123456789012:task/prod/db-1",
+	"taskDefinitionArn": "arn:aws:ecs:us-west-2:123456789012:task-definition/db:7",
+	"group": "service:db",
+	"attachments": [{"details": [{"name": "privateIPv4Address", "value": "10.0.3.20"}]}]
+}`, &db)
+	decode(t, `{
+	"taskArn": "arn:aws:ecs:us-west-2:123456789012:task/prod/migrate-1",
+	"taskDefinitionArn": "arn:aws:ecs:us-west-2:123456789012:task-definition/migrate:1",
+	"group": "family:migrate",
+	"attachments": [{"details": [{"name": "privateIPv4Address", "value": "10.0.3.30"}]}]
+}`, &migrate)
+
+	api := &fakeLister{tasks: map[string][]aws.Task{"prod": {db, migrate}}}
+	e := NewEnricher(&Option{Task: &task, Clusters: []string{"prod"}, RefreshInterval: time.Minute})
+	e.api = api
+
+	flows := []*probe.HostFlow{
+		{
+			Direction: probe.FlowActive,
+			Local:     &probe.AddrPort{Addr: "10.0.2.15", Aggregated: true},
+			Peer:      &probe.AddrPort{Addr: "10.0.3.20", Port: 5432},
+		},
+		{
+			Direction: probe.FlowPassive,
+			Local:     &probe.AddrPort{Addr: "10.0.2.15", Port: 8080},
+			Peer:      &probe.AddrPort{Addr: "10.0.3.30", Aggregated: true},
+		},
+		{
+			Direction: probe.FlowActive,
+			Local:     &probe.AddrPort{Addr: "127.0.0.1", Aggregated: true},
+			Peer:      &probe.AddrPort{Addr: "52.0.0.1", Port: 443},
+		},
+	}
+	if err := e.Enrich(flows); err != nil {
+		t.Fatalf("%+v", err)
+	}
+
+	web := map[string]string{
+		LabelCluster:    "prod",
+		LabelService:    "web",
+		LabelTaskARN:    "arn:aws:ecs:us-west-2:123456789012:task/prod/web-1",
+		LabelTaskFamily: "web",
+	}
+	tests := []struct {
+		got  map[string]string
+		want map[string]string
+	}{
+		{got: flows[0].Local.Labels, want: web},
+		{
+			got: flows[0].Peer.Labels,
+			want: map[string]string{
+				LabelCluster:    "prod",
+				LabelService:    "db",
+				LabelTaskARN:    "arn:aws:ecs:us-west-2:123456789012:task/prod/db-1",
+				LabelTaskFamily: "db",
+			},
+		},
+		{got: flows[1].Local.Labels, want: web},
+		// a standalone task has no service.
+		{
+			got: flows[1].Peer.Labels,
+			want: map[string]string{
+				LabelCluster:    "prod",
+				LabelTaskARN:    "arn:aws:ecs:us-west-2:123456789012:task/prod/migrate-1",
+				LabelTaskFamily: "migrate",
+			},
+		},
+		// the addresses out of the tasks are not labeled.
+		{got: flows[2].Local.Labels, want: nil},
+		{got: flows[2].Peer.Labels, want: nil},
+	}
+	for i, tt := range tests {
+		if diff := cmp.Diff(tt.want, tt.got); diff != "" {
+			t.Errorf("#%d Enrich() mismatch (-want +got):\n%s", i, diff)
+		}
+	}
+
+	// the cached mapping is used within the refresh interval.
+	if err := e.Enrich(flows); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if api.calls != 1 {
+		t.Errorf("the API should be called once, but %d times", api.calls)
+	}
+}
+
+func TestEnrich_apiError(t *testing.T) {
+	api := &fakeLister{err: errors.New("ThrottlingException")}
+	e := NewEnricher(&Option{Clusters: []string{"prod"}, RefreshInterval: time.Minute})
+	e.api = api
+
+	flows := []*probe.HostFlow{
+		{
+			Direction: probe.FlowActive,
+			Local:     &probe.AddrPort{Addr: "10.0.2.15", Aggregated: true},
+			Peer:      &probe.AddrPort{Addr: "10.0.3.20", Port: 5432},
+		},
+	}
+	if err := e.Enrich(flows); err == nil {
+		t.Error("Enrich() should return an error when the API fails")
+	}
+	// the failed refresh is not retried until the interval passes.
+	if err := e.Enrich(flows); err != nil {
+		t.Errorf("Enrich() should not retry within the interval: %v", err)
+	}
+	if api.calls != 1 {
+		t.Errorf("the API should be called once, but %d times", api.calls)
+	}
+}
@@ -0,0 +1,30 @@
+package enricher
+
+import (
+	"github.com/yuuki/shawk/logging"
+	"github.com/yuuki/shawk/probe"
+)
+
+var logger = logging.New("enricher")
+
+// Enricher attaches metadata such as workload identities to host flows
+// before they are stored into the CMDB.
+type Enricher interface {
+	// Name returns the name of the enricher for logging.
+	Name() string
+	// Enrich labels the endpoints of the given flows in place.
+	Enrich(flows []*probe.HostFlow) error
+}
+
+// Chain is a sequence of enrichers applied in order.
+type Chain []Enricher
+
+// Apply applies the enrichers to the flows. A failure of an enricher is only
+// logged because the flows are still worth storing without the metadata.
+func (c Chain) Apply(flows []*probe.HostFlow) {
+	for _, e := range c {
+		if err := e.Enrich(flows); err != nil {
+			logger.Errorf("%s enricher error: %+v", e.Name(), err)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+// Package environ labels the local endpoints with the services named by the
+// environment variables of their processes.
+package environ
+
+import (
+	"os"
+
+	"golang.org/x/xerrors"
+
+	"github.com/yuuki/shawk/probe"
+	"github.com/yuuki/shawk/probe/netlink/netutil"
+)
+
+// LabelService is the label of the service named by the process.
+const LabelService = "service.name"
+
+// Enricher labels the local endpoints of the flows with the value of an
+// environment variable of their processes, so that the owners of the
+// applications name their components by setting it.
+type Enricher struct {
+	key string
+}
+
+// NewEnricher creates an Enricher reading the environment variable of key.
+func NewEnricher(key string) *Enricher {
+	return &Enricher{key: key}
+}
+
+// Name returns the name of the enricher.
+func (e *Enricher) Name() string {
+	return "environ"
+}
+
+// Enrich labels the local endpoints of the flows whose processes set the
+// environment variable. The environment is read from the leader of the
+// process group, so the processes whose leaders have exited, or which
+// are not permitted to inspect, are not labeled.
+func (e *Enricher) Enrich(flows []*probe.HostFlow) error {
+	fsys := netutil.ProcFS()
+	services := make(map[int]string)
+	for _, flow := range flows {
+		if flow.Process == nil || flow.Process.Pgid == 0 {
+			continue
+		}
+		pgid := flow.Process.Pgid
+		service, ok := services[pgid]
+		if !ok {
+			var err error
+			service, _, err = netutil.LookupProcessEnv(fsys, pgid, e.key)
+			switch {
+			case err == nil:
+			case os.IsNotExist(err), os.IsPermission(err):
+			default:
+				return xerrors.Errorf("could not read the environment of pgid %d: %w", pgid, err)
+			}
+			services[pgid] = service
+		}
+		if service != "" {
+			flow.Local.SetLabel(LabelService, service)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package environ
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/yuuki/shawk/probe"
+	"github.com/yuuki/shawk/probe/netlink/netutil"
+)
+
+func TestEnrich(t *testing.T) {
+	root := t.TempDir()
+	for pid, environ := range map[string]string{
+		"100": "PATH=/usr/bin\x00SHAWK_SERVICE=checkout\x00",
+		"200": "PATH=/usr/bin\x00",
+	} {
+		if err := os.MkdirAll(filepath.Join(root, pid), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(filepath.Join(root, pid, "environ"), []byte(environ), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	netutil.SetProcFS(netutil.DirFS(root))
+	defer netutil.SetProcFS(netutil.DirFS("/proc"))
+
+	flows := []*probe.HostFlow{
+		{
+			Direction: probe.FlowPassive,
+			Local:     &probe.AddrPort{Addr: "10.0.0.1", Port: 8080},
+			Peer:      &probe.AddrPort{Addr: "10.0.0.2", Aggregated: true},
+			Process:   &probe.Process{Name: "java", Pgid: 100},
+		},
+		// The process doesn't name its service.
+		{
+			Direction: probe.FlowActive,
+			Local:     &probe.AddrPort{Addr: "10.0.0.1", Aggregated: true},
+			Peer:      &probe.AddrPort{Addr: "10.0.0.3", Port: 5432},
+			Process:   &probe.Process{Name: "cron", Pgid: 200},
+		},
+		// The leader of the process group has exited.
+		{
+			Direction: probe.FlowActive,
+			Local:     &probe.AddrPort{Addr: "10.0.0.1", Aggregated: true},
+			Peer:      &probe.AddrPort{Addr: "10.0.0.4", Port: 6379},
+			Process:   &probe.Process{Name: "worker", Pgid: 300},
+		},
+		{
+			Direction: probe.FlowActive,
+			Local:     &probe.AddrPort{Addr: "10.0.0.1", Aggregated: true},
+			Peer:      &probe.AddrPort{Addr: "10.0.0.5", Port: 443},
+		},
+	}
+	if err := NewEnricher("SHAWK_SERVICE").Enrich(flows); err != nil {
+		t.Fatalf("%+v", err)
+	}
+
+	got := make([]map[string]string, 0, len(flows))
+	for _, f := range flows {
+		got = append(got, f.Local.Labels)
+	}
+	want := []map[string]string{{LabelService: "checkout"}, nil, nil, nil}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Enrich() mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,31 @@
+// Package host labels the local endpoints with the identity of the host.
+package host
+
+import (
+	"github.com/yuuki/shawk/probe"
+)
+
+// Enricher attaches the fixed labels to the local endpoints of flows.
+type Enricher struct {
+	labels map[string]string
+}
+
+// NewEnricher creates an Enricher with the labels of the host.
+func NewEnricher(labels map[string]string) *Enricher {
+	return &Enricher{labels: labels}
+}
+
+// Name returns the name of the enricher.
+func (e *Enricher) Name() string {
+	return "host"
+}
+
+// Enrich labels the local endpoints of the flows.
+func (e *Enricher) Enrich(flows []*probe.HostFlow) error {
+	for _, flow := range flows {
+		for k, v := range e.labels {
+			flow.Local.SetLabel(k, v)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,152 @@
+// Package iface labels the local endpoints with the network interfaces of
+// their addresses, so that the flows of a multi-homed host are analyzed per
+// network segment.
+package iface
+
+import (
+	"bufio"
+	"net"
+	"os"
+	"strings"
+
+	"golang.org/x/xerrors"
+
+	"github.com/yuuki/shawk/probe"
+	"github.com/yuuki/shawk/probe/netlink/netutil"
+)
+
+const (
+	// LabelInterface is the label of the name of the interface.
+	LabelInterface = "net.interface"
+	// LabelSubnet is the label of the subnet of the address on the
+	// interface, such as 10.0.1.0/24.
+	LabelSubnet = "net.subnet"
+	// LabelVLAN is the label of the VLAN ID of the interface, which is
+	// only of the 802.1Q interfaces.
+	LabelVLAN = "net.vlan"
+)
+
+// Interface is a network interface and its addresses.
+type Interface struct {
+	Name  string
+	Addrs []*net.IPNet
+}
+
+// Interfaces returns the interfaces of the host, which are read by the
+// netlink on Linux.
+func Interfaces() ([]*Interface, error) {
+	ifaces, err := net.Interfaces()
+	if err != nil {
+		return nil, xerrors.Errorf("could not list the interfaces: %w", err)
+	}
+	list := make([]*Interface, 0, len(ifaces))
+	for _, ifi := range ifaces {
+		addrs, err := ifi.Addrs()
+		if err != nil {
+			return nil, xerrors.Errorf("could not list the addresses of %s: %w", ifi.Name, err)
+		}
+		i := &Interface{Name: ifi.Name}
+		for _, addr := range addrs {
+			if ipnet, ok := addr.(*net.IPNet); ok {
+				i.Addrs = append(i.Addrs, ipnet)
+			}
+		}
+		list = append(list, i)
+	}
+	return list, nil
+}
+
+// Enricher labels the local endpoints of the flows with the interfaces
+// having their addresses.
+type Enricher struct {
+	interfaces func() ([]*Interface, error)
+}
+
+// NewEnricher creates an Enricher of the interfaces of the host.
+func NewEnricher() *Enricher {
+	return &Enricher{interfaces: Interfaces}
+}
+
+// Name returns the name of the enricher.
+func (e *Enricher) Name() string {
+	return "interface"
+}
+
+// segment is the network segment of a local address.
+type segment struct {
+	iface  string
+	subnet string
+}
+
+// Enrich labels the local endpoints of the flows. The interfaces are read
+// on every call, so that the addresses added or moved are followed. The
+// addresses of no interface, such as the rewritten ones of the node, are not
+// labeled.
+func (e *Enricher) Enrich(flows []*probe.HostFlow) error {
+	ifaces, err := e.interfaces()
+	if err != nil {
+		return err
+	}
+	vlans, err := readVLANs(netutil.ProcFS())
+	if err != nil {
+		return err
+	}
+	segments := make(map[string]*segment)
+	for _, i := range ifaces {
+		for _, addr := range i.Addrs {
+			ip := addr.IP.String()
+			if _, ok := segments[ip]; ok {
+				// The first interface wins, such as for an anycast
+				// address on lo.
+				continue
+			}
+			subnet := &net.IPNet{IP: addr.IP.Mask(addr.Mask), Mask: addr.Mask}
+			segments[ip] = &segment{iface: i.Name, subnet: subnet.String()}
+		}
+	}
+	for _, flow := range flows {
+		s, ok := segments[flow.Local.Addr]
+		if !ok {
+			continue
+		}
+		flow.Local.SetLabel(LabelInterface, s.iface)
+		flow.Local.SetLabel(LabelSubnet, s.subnet)
+		if vlan, ok := vlans[s.iface]; ok {
+			flow.Local.SetLabel(LabelVLAN, vlan)
+		}
+	}
+	return nil
+}
+
+// readVLANs returns the VLAN IDs by the names of the 802.1Q interfaces from
+// net/vlan/config of fsys, which is
+//
+//	VLAN Dev name	 | VLAN ID
+//	Name-Type: VLAN_NAME_TYPE_RAW_PLUS_VID_NO_PAD
+//	eth0.100       | 100  | eth0
+//
+// It is empty without the 8021q module.
+func readVLANs(fsys netutil.FS) (map[string]string, error) {
+	f, err := fsys.Open("net/vlan/config")
+	if err != nil {
+		if os.IsNotExist(err) {
+			return map[string]string{}, nil
+		}
+		return nil, xerrors.Errorf("could not open net/vlan/config: %w", err)
+	}
+	defer f.Close()
+
+	vlans := make(map[string]string)
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		fields := strings.Split(sc.Text(), "|")
+		if len(fields) != 3 {
+			continue
+		}
+		vlans[strings.TrimSpace(fields[0])] = strings.TrimSpace(fields[1])
+	}
+	if err := sc.Err(); err != nil {
+		return nil, xerrors.Errorf("could not read net/vlan/config: %w", err)
+	}
+	return vlans, nil
+}
@@ -0,0 +1,98 @@
+package iface
+
+import (
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/yuuki/shawk/probe"
+	"github.com/yuuki/shawk/probe/netlink/netutil"
+)
+
+func mustParseCIDR(t *testing.T, s string) *net.IPNet {
+	t.Helper()
+	ip, ipnet, err := net.ParseCIDR(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ipnet.IP = ip
+	return ipnet
+}
+
+func TestEnrich(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, "net", "vlan"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	config := "VLAN Dev name\t | VLAN ID\n" +
+		"Name-Type: VLAN_NAME_TYPE_RAW_PLUS_VID_NO_PAD\n" +
+		"eth1.100       | 100  | eth1\n"
+	if err := ioutil.WriteFile(filepath.Join(root, "net", "vlan", "config"), []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+	netutil.SetProcFS(netutil.DirFS(root))
+	defer netutil.SetProcFS(netutil.DirFS("/proc"))
+
+	e := &Enricher{interfaces: func() ([]*Interface, error) {
+		return []*Interface{
+			{Name: "lo", Addrs: []*net.IPNet{mustParseCIDR(t, "127.0.0.1/8"), mustParseCIDR(t, "::1/128")}},
+			{Name: "eth0", Addrs: []*net.IPNet{mustParseCIDR(t, "10.0.1.5/24"), mustParseCIDR(t, "fd00::5/64")}},
+			{Name: "eth1.100", Addrs: []*net.IPNet{mustParseCIDR(t, "192.168.100.5/24")}},
+		}, nil
+	}}
+
+	flows := []*probe.HostFlow{
+		{
+			Direction: probe.FlowPassive,
+			Local:     &probe.AddrPort{Addr: "10.0.1.5", Port: 8080},
+			Peer:      &probe.AddrPort{Addr: "10.0.1.6", Aggregated: true},
+		},
+		{
+			Direction: probe.FlowActive,
+			Local:     &probe.AddrPort{Addr: "192.168.100.5", Aggregated: true},
+			Peer:      &probe.AddrPort{Addr: "192.168.100.7", Port: 5432},
+		},
+		{
+			Direction: probe.FlowActive,
+			Local:     &probe.AddrPort{Addr: "fd00::5", Aggregated: true},
+			Peer:      &probe.AddrPort{Addr: "fd00::7", Port: 443},
+		},
+		// The address is of no interface.
+		{
+			Direction: probe.FlowPassive,
+			Local:     &probe.AddrPort{Addr: "172.17.0.2", Port: 80},
+			Peer:      &probe.AddrPort{Addr: "172.17.0.3", Aggregated: true},
+		},
+	}
+	if err := e.Enrich(flows); err != nil {
+		t.Fatalf("%+v", err)
+	}
+
+	got := make([]map[string]string, 0, len(flows))
+	for _, f := range flows {
+		got = append(got, f.Local.Labels)
+	}
+	want := []map[string]string{
+		{LabelInterface: "eth0", LabelSubnet: "10.0.1.0/24"},
+		{LabelInterface: "eth1.100", LabelSubnet: "192.168.100.0/24", LabelVLAN: "100"},
+		{LabelInterface: "eth0", LabelSubnet: "fd00::/64"},
+		nil,
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Enrich() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestReadVLANs_noModule(t *testing.T) {
+	got, err := readVLANs(netutil.DirFS(t.TempDir()))
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if diff := cmp.Diff(map[string]string{}, got); diff != "" {
+		t.Errorf("readVLANs() mismatch (-want +got):\n%s", diff)
+	}
+}
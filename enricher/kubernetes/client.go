@@ -0,0 +1,236 @@
+package kubernetes
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/xerrors"
+)
+
+const (
+	// serviceAccountDir is where kubelet mounts the credentials of the pod.
+	serviceAccountDir = "/var/run/secrets/kubernetes.io/serviceaccount"
+
+	requestTimeout = 10 * time.Second
+
+	// listLimit is the number of the objects in a page of a list, so that
+	// the API server and the probe never handle all of them at once.
+	listLimit = 500
+)
+
+// Client is a minimal read-only client for the Kubernetes API server.
+type Client struct {
+	baseURL    string
+	tokenFile  string
+	httpClient *http.Client
+}
+
+// InCluster returns whether the process runs inside a Kubernetes pod.
+func InCluster() bool {
+	if os.Getenv("KUBERNETES_SERVICE_HOST") == "" {
+		return false
+	}
+	_, err := os.Stat(filepath.Join(serviceAccountDir, "token"))
+	return err == nil
+}
+
+// NewInClusterClient creates a client authenticated by the service account of the pod.
+func NewInClusterClient() (*Client, error) {
+	host, port := os.Getenv("KUBERNETES_SERVICE_HOST"), os.Getenv("KUBERNETES_SERVICE_PORT")
+	if host == "" || port == "" {
+		return nil, xerrors.New("KUBERNETES_SERVICE_HOST and KUBERNETES_SERVICE_PORT must be set in the pod")
+	}
+
+	caFile := filepath.Join(serviceAccountDir, "ca.crt")
+	ca, err := ioutil.ReadFile(caFile)
+	if err != nil {
+		return nil, xerrors.Errorf("could not read %s: %w", caFile, err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(ca) {
+		return nil, xerrors.Errorf("no certificate found in %s", caFile)
+	}
+
+	return &Client{
+		baseURL:   "https://" + net.JoinHostPort(host, port),
+		tokenFile: filepath.Join(serviceAccountDir, "token"),
+		httpClient: &http.Client{
+			Timeout: requestTimeout,
+			Transport: &http.Transport{
+				TLSClientConfig: &tls.Config{RootCAs: pool},
+			},
+		},
+	}, nil
+}
+
+// get requests the API path and decodes the JSON response into v.
+func (c *Client) get(path string, v interface{}) error {
+	req, err := http.NewRequest(http.MethodGet, c.baseURL+path, nil)
+	if err != nil {
+		return xerrors.Errorf("could not create request %s: %w", path, err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if c.tokenFile != "" {
+		// Re-read the token for every request because projected
+		// service account tokens are rotated by kubelet.
+		token, err := ioutil.ReadFile(c.tokenFile)
+		if err != nil {
+			return xerrors.Errorf("could not read %s: %w", c.tokenFile, err)
+		}
+		req.Header.Set("Authorization", "Bearer "+strings.TrimSpace(string(token)))
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return xerrors.Errorf("GET %s: %w", path, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := ioutil.ReadAll(resp.Body)
+		return xerrors.Errorf("GET %s: unexpected status %s: %s", path, resp.Status, body)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return xerrors.Errorf("GET %s: could not decode response: %w", path, err)
+	}
+	return nil
+}
+
+type ownerReference struct {
+	Kind string `json:"kind"`
+	Name string `json:"name"`
+}
+
+type objectMeta struct {
+	Name            string            `json:"name"`
+	Namespace       string            `json:"namespace"`
+	Labels          map[string]string `json:"labels"`
+	OwnerReferences []ownerReference  `json:"ownerReferences"`
+}
+
+type podIP struct {
+	IP string `json:"ip"`
+}
+
+type pod struct {
+	Metadata objectMeta `json:"metadata"`
+	Spec     struct {
+		NodeName    string `json:"nodeName"`
+		HostNetwork bool   `json:"hostNetwork"`
+	} `json:"spec"`
+	Status struct {
+		PodIP  string  `json:"podIP"`
+		PodIPs []podIP `json:"podIPs"`
+	} `json:"status"`
+}
+
+// ips returns the all addresses of the pod including dual-stack ones.
+func (p *pod) ips() []string {
+	ips := make([]string, 0, len(p.Status.PodIPs)+1)
+	for _, ip := range p.Status.PodIPs {
+		ips = append(ips, ip.IP)
+	}
+	if len(ips) == 0 && p.Status.PodIP != "" {
+		ips = append(ips, p.Status.PodIP)
+	}
+	return ips
+}
+
+type service struct {
+	Metadata objectMeta `json:"metadata"`
+	Spec     struct {
+		ClusterIP  string   `json:"clusterIP"`
+		ClusterIPs []string `json:"clusterIPs"`
+	} `json:"spec"`
+}
+
+// ips returns the all cluster addresses of the service.
+// Headless services have "None" as the cluster address.
+func (s *service) ips() []string {
+	candidates := s.Spec.ClusterIPs
+	if len(candidates) == 0 {
+		candidates = []string{s.Spec.ClusterIP}
+	}
+	ips := make([]string, 0, len(candidates))
+	for _, ip := range candidates {
+		if ip != "" && ip != "None" {
+			ips = append(ips, ip)
+		}
+	}
+	return ips
+}
+
+// listMeta is the metadata of a page of a list. Continue is the token to
+// get the next page, which is empty on the last page.
+type listMeta struct {
+	Continue string `json:"continue"`
+}
+
+// pagePath returns the API path to get the page of the list continued by
+// the token, which is empty for the first page.
+func pagePath(path string, query url.Values, token string) string {
+	q := url.Values{"limit": {strconv.Itoa(listLimit)}}
+	for k, v := range query {
+		q[k] = v
+	}
+	if token != "" {
+		q.Set("continue", token)
+	}
+	return path + "?" + q.Encode()
+}
+
+// listPods lists the running pods in all namespaces by pages. The pods of
+// the other nodes are listed too, since they are the peers of the flows of
+// the node. The pods not running are not listed, since their addresses may
+// have been reused by the others.
+func (c *Client) listPods() ([]pod, error) {
+	query := url.Values{"fieldSelector": {"status.phase=Running"}}
+	var (
+		pods  []pod
+		token string
+	)
+	for {
+		var list struct {
+			Metadata listMeta `json:"metadata"`
+			Items    []pod    `json:"items"`
+		}
+		if err := c.get(pagePath("/api/v1/pods", query, token), &list); err != nil {
+			return nil, err
+		}
+		pods = append(pods, list.Items...)
+		if token = list.Metadata.Continue; token == "" {
+			return pods, nil
+		}
+	}
+}
+
+// listServices lists the services in all namespaces by pages.
+func (c *Client) listServices() ([]service, error) {
+	var (
+		services []service
+		token    string
+	)
+	for {
+		var list struct {
+			Metadata listMeta  `json:"metadata"`
+			Items    []service `json:"items"`
+		}
+		if err := c.get(pagePath("/api/v1/services", nil, token), &list); err != nil {
+			return nil, err
+		}
+		services = append(services, list.Items...)
+		if token = list.Metadata.Continue; token == "" {
+			return services, nil
+		}
+	}
+}
@@ -0,0 +1,242 @@
+package kubernetes
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/xerrors"
+
+	"github.com/yuuki/shawk/logging"
+	"github.com/yuuki/shawk/probe"
+	"github.com/yuuki/shawk/probe/netlink/netutil"
+)
+
+// Label keys attached to flow endpoints.
+const (
+	LabelNode      = "k8s.node"
+	LabelNamespace = "k8s.namespace"
+	LabelPod       = "k8s.pod"
+	LabelWorkload  = "k8s.workload"
+	LabelService   = "k8s.service"
+)
+
+// hostProcRoot is where the DaemonSet manifest mounts /proc of the host
+// if the pod does not share the PID namespace of the host.
+const hostProcRoot = "/host/proc"
+
+var logger = logging.New("enricher/kubernetes")
+
+// NodeName returns the name of the node the pod is scheduled on.
+// The DaemonSet manifest exposes it as NODE_NAME by the downward API,
+// otherwise the hostname is used because the pod runs with hostNetwork.
+func NodeName() (string, error) {
+	if name := os.Getenv("NODE_NAME"); name != "" {
+		return name, nil
+	}
+	name, err := os.Hostname()
+	if err != nil {
+		return "", xerrors.Errorf("could not detect node name: %w", err)
+	}
+	return name, nil
+}
+
+// SetupHostContext makes the probe observe the host instead of the pod.
+// If the pod does not run with hostPID but the host /proc is mounted,
+// the probe reads the procfs from it unless PROC_ROOT points it elsewhere.
+// It returns an error if the pod does not share the network namespace of
+// the host, because netlink would then only report the sockets of the pod
+// itself.
+func SetupHostContext() error {
+	procRoot := netutil.DefaultProcRoot
+	if procRoot == "/proc" {
+		if _, err := os.Stat(filepath.Join(hostProcRoot, "1")); err == nil {
+			procRoot = hostProcRoot
+			logger.Infof("Using %s as the host procfs", procRoot)
+			netutil.SetProcFS(netutil.DirFS(procRoot))
+		}
+	}
+
+	self, err := os.Readlink("/proc/self/ns/net")
+	if err != nil {
+		logger.Warningf("could not inspect the network namespace: %v", err)
+		return nil
+	}
+	host, err := os.Readlink(filepath.Join(procRoot, "1", "ns", "net"))
+	if err != nil {
+		logger.Warningf("could not inspect the network namespace of the host: %v", err)
+		return nil
+	}
+	if self != host {
+		return xerrors.Errorf(
+			"the pod is not in the network namespace of the host (%s != %s): set 'hostNetwork: true' in the pod spec",
+			self, host)
+	}
+	return nil
+}
+
+// workload is the identity of a pod or a service.
+type workload struct {
+	namespace string
+	pod       string
+	kind      string
+	name      string
+	node      string
+	service   string
+}
+
+// apply labels the endpoint with the identity.
+func (w *workload) apply(a *probe.AddrPort) {
+	a.SetLabel(LabelNamespace, w.namespace)
+	if w.pod != "" {
+		a.SetLabel(LabelPod, w.pod)
+	}
+	if w.name != "" {
+		a.SetLabel(LabelWorkload, w.kind+"/"+w.name)
+	}
+	if w.node != "" {
+		a.SetLabel(LabelNode, w.node)
+	}
+	if w.service != "" {
+		a.SetLabel(LabelService, w.service)
+	}
+}
+
+// workloadOf resolves the controller which owns the pod.
+// Pods of Deployments are owned by ReplicaSets named '<deployment>-<pod-template-hash>'.
+func workloadOf(p *pod) *workload {
+	w := &workload{
+		namespace: p.Metadata.Namespace,
+		pod:       p.Metadata.Name,
+		node:      p.Spec.NodeName,
+	}
+	if len(p.Metadata.OwnerReferences) == 0 {
+		w.kind, w.name = "Pod", p.Metadata.Name
+		return w
+	}
+	owner := p.Metadata.OwnerReferences[0]
+	w.kind, w.name = owner.Kind, owner.Name
+	if hash := p.Metadata.Labels["pod-template-hash"]; owner.Kind == "ReplicaSet" && hash != "" {
+		if strings.HasSuffix(owner.Name, "-"+hash) {
+			w.kind, w.name = "Deployment", strings.TrimSuffix(owner.Name, "-"+hash)
+		}
+	}
+	return w
+}
+
+// Enricher labels flow endpoints with Kubernetes workload identities. The
+// pods and services are listed in the background, so that Enrich never
+// waits for the API server.
+type Enricher struct {
+	client          *Client
+	nodeName        string
+	refreshInterval time.Duration
+
+	mu         sync.Mutex
+	byIP       map[string]*workload
+	refreshed  time.Time // the last attempt to refresh
+	refreshing bool
+	wg         sync.WaitGroup // waits for the refresh in the tests
+	err        error          // the error of the last refresh not returned yet
+}
+
+// NewEnricher creates an Enricher. The pods and services are listed at most
+// once per refreshInterval.
+func NewEnricher(client *Client, nodeName string, refreshInterval time.Duration) *Enricher {
+	return &Enricher{
+		client:          client,
+		nodeName:        nodeName,
+		refreshInterval: refreshInterval,
+		byIP:            map[string]*workload{},
+	}
+}
+
+// Name returns the name of the enricher.
+func (e *Enricher) Name() string {
+	return "kubernetes"
+}
+
+// refresh rebuilds the mapping from IP addresses to workloads.
+func (e *Enricher) refresh() error {
+	pods, err := e.client.listPods()
+	if err != nil {
+		return xerrors.Errorf("could not list pods: %w", err)
+	}
+	services, err := e.client.listServices()
+	if err != nil {
+		return xerrors.Errorf("could not list services: %w", err)
+	}
+
+	byIP := make(map[string]*workload, len(pods)+len(services))
+	for i := range pods {
+		p := &pods[i]
+		// Pods with hostNetwork share the address of the node,
+		// so that the address does not identify them.
+		if p.Spec.HostNetwork {
+			continue
+		}
+		w := workloadOf(p)
+		for _, ip := range p.ips() {
+			byIP[ip] = w
+		}
+	}
+	for i := range services {
+		s := &services[i]
+		w := &workload{
+			namespace: s.Metadata.Namespace,
+			service:   s.Metadata.Name,
+		}
+		for _, ip := range s.ips() {
+			byIP[ip] = w
+		}
+	}
+
+	e.mu.Lock()
+	e.byIP = byIP
+	e.mu.Unlock()
+	logger.Debugf("refreshed %d pods and %d services", len(pods), len(services))
+	return nil
+}
+
+// Enrich labels the local endpoints with the node name, and the peers whose
+// address belongs to a pod or a service with its identity. The probe in the
+// network namespace of the node only sees the sockets of the node and of the
+// pods with hostNetwork, so the local endpoints are the addresses of the
+// node, and the flows of the other pods in their own network namespaces are
+// only seen from the other side. It starts refreshing the mapping in the
+// background once per refresh interval unless the last one is still
+// running, and labels with the mapping of the last refresh meanwhile. The
+// error of the refresh is returned by the next Enrich.
+func (e *Enricher) Enrich(flows []*probe.HostFlow) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if !e.refreshing && time.Since(e.refreshed) >= e.refreshInterval {
+		// Keep labeling with the stale mapping if the API server is unavailable,
+		// and retry after the interval.
+		e.refreshed = time.Now()
+		e.refreshing = true
+		e.wg.Add(1)
+		go func() {
+			defer e.wg.Done()
+			err := e.refresh()
+			e.mu.Lock()
+			e.refreshing, e.err = false, err
+			e.mu.Unlock()
+		}()
+	}
+
+	for _, flow := range flows {
+		if e.nodeName != "" {
+			flow.Local.SetLabel(LabelNode, e.nodeName)
+		}
+		if w, ok := e.byIP[flow.Peer.Addr]; ok {
+			w.apply(flow.Peer)
+		}
+	}
+	err := e.err
+	e.err = nil
+	return err
+}
@@ -0,0 +1,234 @@
+package kubernetes
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/yuuki/shawk/probe"
+)
+
+const testPods = `{"items": [
+	{
+		"metadata": {
+			"name": "web-7d4b9c8f5-x2x9z",
+			"namespace": "shop",
+			"labels": {"pod-template-hash": "7d4b9c8f5"},
+			"ownerReferences": [{"kind": "ReplicaSet", "name": "web-7d4b9c8f5"}]
+		},
+		"spec": {"nodeName": "node-a"},
+		"status": {"podIP": "10.1.0.10", "podIPs": [{"ip": "10.1.0.10"}]}
+	},
+	{
+		"metadata": {
+			"name": "db-0",
+			"namespace": "shop",
+			"ownerReferences": [{"kind": "StatefulSet", "name": "db"}]
+		},
+		"spec": {"nodeName": "node-b"},
+		"status": {"podIP": "10.1.1.20"}
+	},
+	{
+		"metadata": {"name": "kube-proxy-abcde", "namespace": "kube-system"},
+		"spec": {"nodeName": "node-b", "hostNetwork": true},
+		"status": {"podIP": "192.168.0.2"}
+	}
+]}`
+
+const testServices = `{"items": [
+	{
+		"metadata": {"name": "db", "namespace": "shop"},
+		"spec": {"clusterIP": "10.96.0.30", "clusterIPs": ["10.96.0.30"]}
+	},
+	{
+		"metadata": {"name": "db-headless", "namespace": "shop"},
+		"spec": {"clusterIP": "None"}
+	}
+]}`
+
+func newTestEnricher(t *testing.T) *Enricher {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/pods":
+			if got := r.URL.Query().Get("fieldSelector"); got != "status.phase=Running" {
+				http.Error(w, "unexpected field selector "+got, http.StatusBadRequest)
+				return
+			}
+			w.Write([]byte(testPods))
+		case "/api/v1/services":
+			w.Write([]byte(testServices))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(ts.Close)
+
+	client := &Client{baseURL: ts.URL, httpClient: ts.Client()}
+	return NewEnricher(client, "node-a", time.Minute)
+}
+
+func TestEnrich(t *testing.T) {
+	e := newTestEnricher(t)
+
+	flows := []*probe.HostFlow{
+		// The sockets of the node, since the probe is in the network
+		// namespace of the node.
+		{
+			Direction: probe.FlowActive,
+			Local:     &probe.AddrPort{Addr: "192.168.0.1", Aggregated: true},
+			Peer:      &probe.AddrPort{Addr: "10.96.0.30", Port: 5432},
+		},
+		{
+			Direction: probe.FlowPassive,
+			Local:     &probe.AddrPort{Addr: "192.168.0.1", Port: 80},
+			Peer:      &probe.AddrPort{Addr: "192.168.0.2", Aggregated: true},
+		},
+		{
+			Direction: probe.FlowActive,
+			Local:     &probe.AddrPort{Addr: "192.168.0.1", Aggregated: true},
+			Peer:      &probe.AddrPort{Addr: "10.1.1.20", Port: 5432},
+		},
+		{
+			Direction: probe.FlowPassive,
+			Local:     &probe.AddrPort{Addr: "192.168.0.1", Port: 10250},
+			Peer:      &probe.AddrPort{Addr: "10.1.0.10", Aggregated: true},
+		},
+	}
+	// The first Enrich starts the refresh in the background.
+	if err := e.Enrich(flows); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	e.wg.Wait()
+	if err := e.Enrich(flows); err != nil {
+		t.Fatalf("%+v", err)
+	}
+
+	tests := []struct {
+		got  map[string]string
+		want map[string]string
+	}{
+		{
+			got:  flows[0].Local.Labels,
+			want: map[string]string{LabelNode: "node-a"},
+		},
+		{
+			got: flows[0].Peer.Labels,
+			want: map[string]string{
+				LabelNamespace: "shop",
+				LabelService:   "db",
+			},
+		},
+		{
+			got:  flows[1].Local.Labels,
+			want: map[string]string{LabelNode: "node-a"},
+		},
+		{
+			// pods with hostNetwork are not identified by the address.
+			got:  flows[1].Peer.Labels,
+			want: nil,
+		},
+		{
+			got: flows[2].Peer.Labels,
+			want: map[string]string{
+				LabelNode:      "node-b",
+				LabelNamespace: "shop",
+				LabelPod:       "db-0",
+				LabelWorkload:  "StatefulSet/db",
+			},
+		},
+		{
+			got: flows[3].Peer.Labels,
+			want: map[string]string{
+				LabelNode:      "node-a",
+				LabelNamespace: "shop",
+				LabelPod:       "web-7d4b9c8f5-x2x9z",
+				LabelWorkload:  "Deployment/web",
+			},
+		},
+	}
+	for i, tt := range tests {
+		if diff := cmp.Diff(tt.want, tt.got); diff != "" {
+			t.Errorf("#%d Enrich() mismatch (-want +got):\n%s", i, diff)
+		}
+	}
+}
+
+func TestEnrich_apiError(t *testing.T) {
+	var calls int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		http.Error(w, "forbidden", http.StatusForbidden)
+	}))
+	defer ts.Close()
+
+	client := &Client{baseURL: ts.URL, httpClient: ts.Client()}
+	e := NewEnricher(client, "node-a", time.Minute)
+
+	flows := []*probe.HostFlow{
+		{
+			Direction: probe.FlowActive,
+			Local:     &probe.AddrPort{Addr: "10.1.0.10", Aggregated: true},
+			Peer:      &probe.AddrPort{Addr: "10.96.0.30", Port: 5432},
+		},
+	}
+	if err := e.Enrich(flows); err != nil {
+		t.Fatalf("Enrich() should not wait for the refresh: %v", err)
+	}
+	e.wg.Wait()
+	if err := e.Enrich(flows); err == nil {
+		t.Error("Enrich() should return an error when the API server rejects the request")
+	}
+	// the node name is still attached.
+	if v := flows[0].Local.Labels[LabelNode]; v != "node-a" {
+		t.Errorf("local node label should be 'node-a', but '%v'", v)
+	}
+	// the failed refresh is not retried until the interval passes.
+	prev := calls
+	if err := e.Enrich(flows); err != nil {
+		t.Errorf("Enrich() should not retry within the interval: %v", err)
+	}
+	if calls != prev {
+		t.Errorf("the API server should not be called again, but %d more times", calls-prev)
+	}
+}
+
+func TestListServices_pages(t *testing.T) {
+	pages := map[string]string{
+		"": `{"metadata": {"continue": "page2"}, "items": [
+			{"metadata": {"name": "web", "namespace": "shop"}, "spec": {"clusterIP": "10.96.0.10"}}
+		]}`,
+		"page2": `{"metadata": {}, "items": [
+			{"metadata": {"name": "db", "namespace": "shop"}, "spec": {"clusterIP": "10.96.0.30"}}
+		]}`,
+	}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("limit"); got != strconv.Itoa(listLimit) {
+			http.Error(w, "unexpected limit "+got, http.StatusBadRequest)
+			return
+		}
+		page, ok := pages[r.URL.Query().Get("continue")]
+		if !ok {
+			http.Error(w, "expired continue token", http.StatusGone)
+			return
+		}
+		w.Write([]byte(page))
+	}))
+	defer ts.Close()
+
+	client := &Client{baseURL: ts.URL, httpClient: ts.Client()}
+	services, err := client.listServices()
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	var got []string
+	for _, s := range services {
+		got = append(got, s.Metadata.Name)
+	}
+	if diff := cmp.Diff([]string{"web", "db"}, got); diff != "" {
+		t.Errorf("listServices() mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,155 @@
+// Package fluentd ships the flows of the agent to Fluentd or Fluent Bit by
+// the forward protocol, so that their pipelines buffer and route the flows
+// as the other logs.
+package fluentd
+
+import (
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/xerrors"
+
+	"github.com/yuuki/shawk/probe"
+)
+
+const (
+	dialTimeout  = 10 * time.Second
+	writeTimeout = 10 * time.Second
+)
+
+// maxEntries is the maximum number of the records of a message, which keeps
+// the messages below the buffer sizes of the forward inputs.
+const maxEntries = 1000
+
+// ParseAddr returns the network and the address of the forward input, which
+// is HOST:PORT over TCP such as '127.0.0.1:24224', or unix://PATH of a unix
+// domain socket.
+func ParseAddr(s string) (network, addr string, err error) {
+	if strings.HasPrefix(s, "unix://") {
+		path := strings.TrimPrefix(s, "unix://")
+		if path == "" {
+			return "", "", xerrors.Errorf("the path of %q is empty", s)
+		}
+		return "unix", path, nil
+	}
+	if _, _, err := net.SplitHostPort(s); err != nil {
+		return "", "", xerrors.Errorf("%q must be HOST:PORT or unix://PATH", s)
+	}
+	return "tcp", s, nil
+}
+
+// Writer sends the flows of the host as the records of the tag to the
+// forward input in the Forward Mode of the protocol.
+type Writer struct {
+	network string
+	addr    string
+	tag     string
+	host    string
+
+	mu   sync.Mutex
+	conn net.Conn
+}
+
+// NewWriter returns the Writer to the forward input of addr, which sends the
+// flows of the host as the records of the tag such as 'shawk.flow'.
+func NewWriter(addr, tag, host string) (*Writer, error) {
+	network, a, err := ParseAddr(addr)
+	if err != nil {
+		return nil, err
+	}
+	return &Writer{network: network, addr: a, tag: tag, host: host}, nil
+}
+
+// record returns the record of the flow of the host.
+func record(f *probe.HostFlow, host string) map[string]interface{} {
+	r := map[string]interface{}{
+		"host":        host,
+		"direction":   f.Direction.String(),
+		"local":       net.JoinHostPort(f.Local.Addr, f.Local.PortString()),
+		"peer":        net.JoinHostPort(f.Peer.Addr, f.Peer.PortString()),
+		"connections": f.Connections,
+	}
+	if f.Process != nil {
+		r["process"] = f.Process.Name
+		r["pgid"] = f.Process.Pgid
+	}
+	if len(f.Local.Labels) > 0 {
+		r["local_labels"] = f.Local.Labels
+	}
+	if len(f.Peer.Labels) > 0 {
+		r["peer_labels"] = f.Peer.Labels
+	}
+	if f.VRF != "" {
+		r["vrf"] = f.VRF
+	}
+	return r
+}
+
+// encodeMessage encodes the flows as a message of the Forward Mode, which
+// is [tag, [[time, record], ...], {"size": n}].
+func encodeMessage(tag, host string, flows []*probe.HostFlow, t time.Time) []byte {
+	var e encoder
+	e.encodeArrayLen(3)
+	e.encodeString(tag)
+	e.encodeArrayLen(len(flows))
+	for _, f := range flows {
+		e.encodeArrayLen(2)
+		e.encodeEventTime(t)
+		e.encodeValue(record(f, host))
+	}
+	e.encodeValue(map[string]interface{}{"size": len(flows)})
+	return e.Bytes()
+}
+
+// WriteFlows sends the flows observed at t in the messages of at most
+// maxEntries records. A connection lost is connected again and the message
+// is sent once more.
+func (w *Writer) WriteFlows(flows []*probe.HostFlow, t time.Time) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for len(flows) > 0 {
+		n := len(flows)
+		if n > maxEntries {
+			n = maxEntries
+		}
+		msg := encodeMessage(w.tag, w.host, flows[:n], t)
+		if err := w.write(msg); err != nil {
+			if err := w.write(msg); err != nil {
+				return err
+			}
+		}
+		flows = flows[n:]
+	}
+	return nil
+}
+
+func (w *Writer) write(msg []byte) error {
+	if w.conn == nil {
+		conn, err := net.DialTimeout(w.network, w.addr, dialTimeout)
+		if err != nil {
+			return xerrors.Errorf("could not connect to fluentd %s: %w", w.addr, err)
+		}
+		w.conn = conn
+	}
+	w.conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+	if _, err := w.conn.Write(msg); err != nil {
+		w.conn.Close()
+		w.conn = nil
+		return xerrors.Errorf("could not send the flows to fluentd %s: %w", w.addr, err)
+	}
+	return nil
+}
+
+// Close closes the connection to the forward input.
+func (w *Writer) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.conn == nil {
+		return nil
+	}
+	err := w.conn.Close()
+	w.conn = nil
+	return err
+}
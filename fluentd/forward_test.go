@@ -0,0 +1,222 @@
+package fluentd
+
+import (
+	"bufio"
+	"encoding/binary"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/yuuki/shawk/probe"
+)
+
+// decode decodes a value of MessagePack of the types encoded by encoder.
+// EventTime is decoded into time.Time, and the integers into int64.
+func decode(t *testing.T, r *bufio.Reader) interface{} {
+	t.Helper()
+	read := func(n int) []byte {
+		b := make([]byte, n)
+		if _, err := io.ReadFull(r, b); err != nil {
+			t.Fatal(err)
+		}
+		return b
+	}
+	uint := func(n int) uint64 {
+		var b [8]byte
+		copy(b[8-n:], read(n))
+		return binary.BigEndian.Uint64(b[:])
+	}
+	str := func(n int) string { return string(read(n)) }
+	array := func(n int) []interface{} {
+		a := make([]interface{}, n)
+		for i := range a {
+			a[i] = decode(t, r)
+		}
+		return a
+	}
+	dict := func(n int) map[string]interface{} {
+		m := make(map[string]interface{}, n)
+		for i := 0; i < n; i++ {
+			k := decode(t, r).(string)
+			m[k] = decode(t, r)
+		}
+		return m
+	}
+	b := read(1)[0]
+	switch {
+	case b <= 0x7f:
+		return int64(b)
+	case b >= 0xe0:
+		return int64(int8(b))
+	case b&0xe0 == 0xa0:
+		return str(int(b & 0x1f))
+	case b&0xf0 == 0x90:
+		return array(int(b & 0x0f))
+	case b&0xf0 == 0x80:
+		return dict(int(b & 0x0f))
+	}
+	switch b {
+	case 0xc0:
+		return nil
+	case 0xc2:
+		return false
+	case 0xc3:
+		return true
+	case 0xd0:
+		return int64(int8(uint(1)))
+	case 0xd1:
+		return int64(int16(uint(2)))
+	case 0xd2:
+		return int64(int32(uint(4)))
+	case 0xd3:
+		return int64(uint(8))
+	case 0xd9:
+		return str(int(uint(1)))
+	case 0xda:
+		return str(int(uint(2)))
+	case 0xdc:
+		return array(int(uint(2)))
+	case 0xde:
+		return dict(int(uint(2)))
+	case 0xd7:
+		if typ := read(1)[0]; typ != 0 {
+			t.Fatalf("unknown extension type %d", typ)
+		}
+		return time.Unix(int64(uint(4)), int64(uint(4)))
+	}
+	t.Fatalf("unknown format 0x%x", b)
+	return nil
+}
+
+func TestEncoder(t *testing.T) {
+	tests := []struct {
+		value interface{}
+		want  []byte
+	}{
+		{nil, []byte{0xc0}},
+		{true, []byte{0xc3}},
+		{5, []byte{0x05}},
+		{-1, []byte{0xff}},
+		{200, []byte{0xd1, 0x00, 0xc8}},
+		{int64(-200), []byte{0xd1, 0xff, 0x38}},
+		{int64(1) << 40, []byte{0xd3, 0, 0, 0x01, 0, 0, 0, 0, 0}},
+		{uint16(5432), []byte{0xd1, 0x15, 0x38}},
+		{"app", []byte{0xa3, 'a', 'p', 'p'}},
+		{map[string]string{"b": "2", "a": "1"}, []byte{0x82, 0xa1, 'a', 0xa1, '1', 0xa1, 'b', 0xa1, '2'}},
+	}
+	for _, tt := range tests {
+		var e encoder
+		e.encodeValue(tt.value)
+		if diff := cmp.Diff(tt.want, e.Bytes()); diff != "" {
+			t.Errorf("encodeValue(%v) mismatch (-want +got):\n%s", tt.value, diff)
+		}
+	}
+}
+
+func TestWriter_WriteFlows(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+	received := make(chan []interface{})
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		r := bufio.NewReader(conn)
+		for {
+			if _, err := r.Peek(1); err != nil {
+				close(received)
+				return
+			}
+			received <- decode(t, r).([]interface{})
+		}
+	}()
+
+	w, err := NewWriter(ln.Addr().String(), "shawk.flow", "web-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	flow := &probe.HostFlow{
+		Direction:   probe.FlowActive,
+		Local:       &probe.AddrPort{Addr: "10.0.0.1", Aggregated: true},
+		Peer:        &probe.AddrPort{Addr: "10.0.0.3", Port: 5432, Labels: map[string]string{"k8s.service": "db"}},
+		Connections: 10,
+		Process:     &probe.Process{Name: "app", Pgid: 200},
+	}
+	flows := make([]*probe.HostFlow, maxEntries+1)
+	for i := range flows {
+		flows[i] = flow
+	}
+	now := time.Unix(1760000000, 123456789)
+	if err := w.WriteFlows(flows, now); err != nil {
+		t.Fatal(err)
+	}
+	w.Close()
+
+	var entries int
+	for msg := range received {
+		if msg[0] != "shawk.flow" {
+			t.Errorf("the tag should be shawk.flow, but %v", msg[0])
+		}
+		es := msg[1].([]interface{})
+		if size := msg[2].(map[string]interface{})["size"]; size != int64(len(es)) {
+			t.Errorf("the size should be %d, but %v", len(es), size)
+		}
+		if entries == 0 {
+			entry := es[0].([]interface{})
+			if !entry[0].(time.Time).Equal(now) {
+				t.Errorf("the time should be %s, but %s", now, entry[0])
+			}
+			want := map[string]interface{}{
+				"host":        "web-1",
+				"direction":   "active",
+				"local":       "10.0.0.1:many",
+				"peer":        "10.0.0.3:5432",
+				"connections": int64(10),
+				"process":     "app",
+				"pgid":        int64(200),
+				"peer_labels": map[string]interface{}{"k8s.service": "db"},
+			}
+			if diff := cmp.Diff(want, entry[1]); diff != "" {
+				t.Errorf("the record mismatch (-want +got):\n%s", diff)
+			}
+		}
+		entries += len(es)
+	}
+	if entries != len(flows) {
+		t.Errorf("the messages should have %d records, but %d", len(flows), entries)
+	}
+}
+
+func TestParseAddr(t *testing.T) {
+	tests := []struct {
+		addr    string
+		network string
+		want    string
+		wantErr bool
+	}{
+		{addr: "127.0.0.1:24224", network: "tcp", want: "127.0.0.1:24224"},
+		{addr: "unix:///var/run/fluent.sock", network: "unix", want: "/var/run/fluent.sock"},
+		{addr: "127.0.0.1", wantErr: true},
+		{addr: "unix://", wantErr: true},
+	}
+	for _, tt := range tests {
+		network, addr, err := ParseAddr(tt.addr)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("ParseAddr(%q) should return an error", tt.addr)
+			}
+			continue
+		}
+		if err != nil || network != tt.network || addr != tt.want {
+			t.Errorf("ParseAddr(%q) = %q, %q, %v, want %q, %q", tt.addr, network, addr, err, tt.network, tt.want)
+		}
+	}
+}
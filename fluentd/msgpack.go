@@ -0,0 +1,144 @@
+package fluentd
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"sort"
+	"time"
+)
+
+// encoder encodes the values of the forward protocol into MessagePack. It
+// supports only the types of the records of the flows.
+type encoder struct {
+	bytes.Buffer
+}
+
+func (e *encoder) writeUint(prefix byte, n uint64, size int) {
+	e.WriteByte(prefix)
+	var b [8]byte
+	binary.BigEndian.PutUint64(b[:], n)
+	e.Write(b[8-size:])
+}
+
+func (e *encoder) encodeNil() {
+	e.WriteByte(0xc0)
+}
+
+func (e *encoder) encodeBool(v bool) {
+	if v {
+		e.WriteByte(0xc3)
+	} else {
+		e.WriteByte(0xc2)
+	}
+}
+
+func (e *encoder) encodeInt(v int64) {
+	switch {
+	case v >= 0 && v <= 0x7f:
+		e.WriteByte(byte(v)) // positive fixint
+	case v < 0 && v >= -32:
+		e.WriteByte(byte(v)) // negative fixint
+	case v >= math.MinInt8 && v <= math.MaxInt8:
+		e.writeUint(0xd0, uint64(uint8(v)), 1)
+	case v >= math.MinInt16 && v <= math.MaxInt16:
+		e.writeUint(0xd1, uint64(uint16(v)), 2)
+	case v >= math.MinInt32 && v <= math.MaxInt32:
+		e.writeUint(0xd2, uint64(uint32(v)), 4)
+	default:
+		e.writeUint(0xd3, uint64(v), 8)
+	}
+}
+
+func (e *encoder) encodeString(s string) {
+	n := len(s)
+	switch {
+	case n <= 31:
+		e.WriteByte(0xa0 | byte(n)) // fixstr
+	case n <= math.MaxUint8:
+		e.writeUint(0xd9, uint64(n), 1)
+	case n <= math.MaxUint16:
+		e.writeUint(0xda, uint64(n), 2)
+	default:
+		e.writeUint(0xdb, uint64(n), 4)
+	}
+	e.WriteString(s)
+}
+
+func (e *encoder) encodeArrayLen(n int) {
+	switch {
+	case n <= 15:
+		e.WriteByte(0x90 | byte(n)) // fixarray
+	case n <= math.MaxUint16:
+		e.writeUint(0xdc, uint64(n), 2)
+	default:
+		e.writeUint(0xdd, uint64(n), 4)
+	}
+}
+
+func (e *encoder) encodeMapLen(n int) {
+	switch {
+	case n <= 15:
+		e.WriteByte(0x80 | byte(n)) // fixmap
+	case n <= math.MaxUint16:
+		e.writeUint(0xde, uint64(n), 2)
+	default:
+		e.writeUint(0xdf, uint64(n), 4)
+	}
+}
+
+// encodeEventTime encodes the time as EventTime of the forward protocol,
+// which is the extension type 0 of the seconds and the nanoseconds.
+func (e *encoder) encodeEventTime(t time.Time) {
+	e.Write([]byte{0xd7, 0x00}) // fixext 8
+	var b [8]byte
+	binary.BigEndian.PutUint32(b[:4], uint32(t.Unix()))
+	binary.BigEndian.PutUint32(b[4:], uint32(t.Nanosecond()))
+	e.Write(b[:])
+}
+
+// encodeValue encodes v of the types of the records.
+func (e *encoder) encodeValue(v interface{}) {
+	switch v := v.(type) {
+	case nil:
+		e.encodeNil()
+	case bool:
+		e.encodeBool(v)
+	case int:
+		e.encodeInt(int64(v))
+	case int64:
+		e.encodeInt(v)
+	case uint16:
+		e.encodeInt(int64(v))
+	case string:
+		e.encodeString(v)
+	case map[string]string:
+		e.encodeMapLen(len(v))
+		for _, k := range sortedKeys(v) {
+			e.encodeString(k)
+			e.encodeString(v[k])
+		}
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		e.encodeMapLen(len(v))
+		for _, k := range keys {
+			e.encodeString(k)
+			e.encodeValue(v[k])
+		}
+	default:
+		panic("fluentd: unsupported type of a record") // the records are built here
+	}
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,85 @@
+package graph
+
+import (
+	"encoding/json"
+	"io"
+	"strconv"
+)
+
+type cytoscape struct {
+	Elements cytoscapeElements `json:"elements"`
+}
+
+type cytoscapeElements struct {
+	Nodes []cytoscapeElement `json:"nodes"`
+	Edges []cytoscapeElement `json:"edges"`
+}
+
+type cytoscapeElement struct {
+	Data interface{} `json:"data"`
+}
+
+type cytoscapeHost struct {
+	ID    string `json:"id"`
+	Label string `json:"label"`
+}
+
+type cytoscapeNode struct {
+	ID      string `json:"id"`
+	Parent  string `json:"parent"`
+	Label   string `json:"label"`
+	Host    string `json:"host"`
+	Process string `json:"process"`
+	Tags    string `json:"tags"`
+}
+
+type cytoscapeEdge struct {
+	ID          string `json:"id"`
+	Source      string `json:"source"`
+	Target      string `json:"target"`
+	Label       string `json:"label"`
+	Port        uint16 `json:"port"`
+	Connections int    `json:"connections"`
+}
+
+// RenderCytoscape writes the graph as the elements JSON of Cytoscape.js,
+// which Cytoscape Desktop also imports. The hosts are the compound nodes,
+// which are the parents of their components.
+func RenderCytoscape(w io.Writer, g *Graph) error {
+	doc := cytoscape{Elements: cytoscapeElements{
+		Nodes: []cytoscapeElement{},
+		Edges: []cytoscapeElement{},
+	}}
+	for _, h := range g.Hosts {
+		doc.Elements.Nodes = append(doc.Elements.Nodes, cytoscapeElement{
+			Data: cytoscapeHost{ID: h.Addr, Label: h.Addr},
+		})
+		for _, c := range h.Components {
+			doc.Elements.Nodes = append(doc.Elements.Nodes, cytoscapeElement{
+				Data: cytoscapeNode{
+					ID:      c.ID,
+					Parent:  h.Addr,
+					Label:   c.Name(),
+					Host:    c.Addr,
+					Process: c.Process,
+					Tags:    c.Tags(),
+				},
+			})
+		}
+	}
+	for i, e := range g.Edges {
+		doc.Elements.Edges = append(doc.Elements.Edges, cytoscapeElement{
+			Data: cytoscapeEdge{
+				ID:          "e" + strconv.Itoa(i),
+				Source:      e.From.ID,
+				Target:      e.To.ID,
+				Label:       edgeLabel(e),
+				Port:        e.Port,
+				Connections: e.Connections,
+			},
+		})
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(doc)
+}
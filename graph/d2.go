@@ -0,0 +1,46 @@
+package graph
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// d2Key quotes the key of D2, since the addresses contain the dots, which
+// separate the keys of the containers.
+func d2Key(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+// RenderD2 writes the graph in the D2 language: the processes are the
+// shapes in the containers of their hosts, which are in the containers of
+// their groups if the graph is grouped.
+func RenderD2(w io.Writer, g *Graph) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintln(bw, "direction: right")
+
+	paths := map[*Component]string{}
+	for _, gr := range g.groups() {
+		indent, prefix := "", ""
+		if gr.Name != "" {
+			fmt.Fprintf(bw, "%s: {\n", d2Key(gr.Name))
+			indent, prefix = "  ", d2Key(gr.Name)+"."
+		}
+		for _, h := range gr.Hosts {
+			fmt.Fprintf(bw, "%s%s: {\n", indent, d2Key(h.Addr))
+			for _, c := range h.Components {
+				fmt.Fprintf(bw, "%s  %s\n", indent, d2Key(c.Name()))
+				paths[c] = prefix + d2Key(h.Addr) + "." + d2Key(c.Name())
+			}
+			fmt.Fprintf(bw, "%s}\n", indent)
+		}
+		if gr.Name != "" {
+			fmt.Fprintln(bw, "}")
+		}
+	}
+	for _, e := range g.Edges {
+		fmt.Fprintf(bw, "%s -> %s: %s\n", paths[e.From], paths[e.To], d2Key(edgeLabel(e)))
+	}
+	return bw.Flush()
+}
@@ -0,0 +1,48 @@
+package graph
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// RenderDOT writes the graph in the DOT language, clustering the components
+// by the hosts, which are clustered by their groups if the graph is grouped.
+func RenderDOT(w io.Writer, g *Graph) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintln(bw, "digraph shawk {")
+	fmt.Fprintln(bw, "  rankdir=LR;")
+	fmt.Fprintln(bw, "  node [shape=box];")
+	for _, gr := range g.groups() {
+		indent := "  "
+		if gr.Name != "" {
+			// The prefix keeps the clusters of the groups apart from the
+			// ones of the hosts.
+			fmt.Fprintf(bw, "  subgraph %s {\n", strconv.Quote("cluster_group_"+gr.Name))
+			fmt.Fprintf(bw, "    label=%s;\n", strconv.Quote(gr.Name))
+			indent = "    "
+		}
+		for _, h := range gr.Hosts {
+			fmt.Fprintf(bw, "%ssubgraph %s {\n", indent, strconv.Quote("cluster_"+h.Addr))
+			fmt.Fprintf(bw, "%s  label=%s;\n", indent, strconv.Quote(h.Addr))
+			for _, c := range h.Components {
+				fmt.Fprintf(bw, "%s  %s [label=%s];\n", indent, strconv.Quote(c.ID), strconv.Quote(c.Name()))
+			}
+			fmt.Fprintf(bw, "%s}\n", indent)
+		}
+		if gr.Name != "" {
+			fmt.Fprintln(bw, "  }")
+		}
+	}
+	for _, e := range g.Edges {
+		var attrs string
+		if e.Saturated() {
+			attrs = ", color=red"
+		}
+		fmt.Fprintf(bw, "  %s -> %s [label=%s%s];\n",
+			strconv.Quote(e.From.ID), strconv.Quote(e.To.ID), strconv.Quote(edgeLabel(e)), attrs)
+	}
+	fmt.Fprintln(bw, "}")
+	return bw.Flush()
+}
@@ -0,0 +1,97 @@
+package graph
+
+import (
+	"encoding/xml"
+	"io"
+	"strconv"
+)
+
+type gexf struct {
+	XMLName xml.Name  `xml:"gexf"`
+	XMLNS   string    `xml:"xmlns,attr"`
+	Version string    `xml:"version,attr"`
+	Graph   gexfGraph `xml:"graph"`
+}
+
+type gexfGraph struct {
+	DefaultEdgeType string           `xml:"defaultedgetype,attr"`
+	Attributes      []gexfAttributes `xml:"attributes"`
+	Nodes           []gexfNode       `xml:"nodes>node"`
+	Edges           []gexfEdge       `xml:"edges>edge"`
+}
+
+type gexfAttributes struct {
+	Class      string          `xml:"class,attr"`
+	Attributes []gexfAttribute `xml:"attribute"`
+}
+
+type gexfAttribute struct {
+	ID    string `xml:"id,attr"`
+	Title string `xml:"title,attr"`
+	Type  string `xml:"type,attr"`
+}
+
+type gexfNode struct {
+	ID        string         `xml:"id,attr"`
+	Label     string         `xml:"label,attr"`
+	AttValues []gexfAttValue `xml:"attvalues>attvalue"`
+}
+
+type gexfEdge struct {
+	ID        string         `xml:"id,attr"`
+	Source    string         `xml:"source,attr"`
+	Target    string         `xml:"target,attr"`
+	Weight    int            `xml:"weight,attr"`
+	AttValues []gexfAttValue `xml:"attvalues>attvalue"`
+}
+
+type gexfAttValue struct {
+	For   string `xml:"for,attr"`
+	Value string `xml:"value,attr"`
+}
+
+// RenderGEXF writes the graph in GEXF 1.2, the native format of Gephi. The
+// nodes have the host, process and tags attributes, and the weights of the
+// edges are the connections.
+func RenderGEXF(w io.Writer, g *Graph) error {
+	doc := gexf{
+		XMLNS:   "http://gexf.net/1.2",
+		Version: "1.2",
+		Graph: gexfGraph{
+			DefaultEdgeType: "directed",
+			Attributes: []gexfAttributes{
+				{Class: "node", Attributes: []gexfAttribute{
+					{ID: "host", Title: "host", Type: "string"},
+					{ID: "process", Title: "process", Type: "string"},
+					{ID: "tags", Title: "tags", Type: "string"},
+				}},
+				{Class: "edge", Attributes: []gexfAttribute{
+					{ID: "port", Title: "port", Type: "integer"},
+				}},
+			},
+		},
+	}
+	for _, h := range g.Hosts {
+		for _, c := range h.Components {
+			doc.Graph.Nodes = append(doc.Graph.Nodes, gexfNode{
+				ID:    c.ID,
+				Label: c.Name(),
+				AttValues: []gexfAttValue{
+					{For: "host", Value: c.Addr},
+					{For: "process", Value: c.Process},
+					{For: "tags", Value: c.Tags()},
+				},
+			})
+		}
+	}
+	for i, e := range g.Edges {
+		doc.Graph.Edges = append(doc.Graph.Edges, gexfEdge{
+			ID:        strconv.Itoa(i),
+			Source:    e.From.ID,
+			Target:    e.To.ID,
+			Weight:    e.Connections,
+			AttValues: []gexfAttValue{{For: "port", Value: strconv.Itoa(int(e.Port))}},
+		})
+	}
+	return writeXML(w, doc)
+}
@@ -0,0 +1,243 @@
+// Package graph renders the dependency graph in the CMDB into the formats of
+// the diagram tools, such as Graphviz and PlantUML, of the graph analysis
+// tools, such as Gephi, and of the web frontends, such as Cytoscape.js.
+package graph
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net"
+	"sort"
+	"strings"
+
+	"golang.org/x/xerrors"
+
+	"github.com/yuuki/shawk/db"
+)
+
+// Component is a process on a host, which is a vertex of the graph.
+type Component struct {
+	// ID identifies the component in the graph.
+	ID string
+	// Addr is the address of the host, which is suffixed with the VRF such
+	// as 10.0.0.1%blue unless it is of the default VRF.
+	Addr    string
+	Process string
+	Labels  map[string]string
+}
+
+// Name returns the name of the component to show, which is the name of the
+// process, or the address if the process is unknown.
+func (c *Component) Name() string {
+	if c.Process == "" {
+		return c.Addr
+	}
+	return c.Process
+}
+
+// Tags returns the labels of the component as "key=value" joined with
+// commas in the order of the keys.
+func (c *Component) Tags() string {
+	tags := make([]string, 0, len(c.Labels))
+	for k, v := range c.Labels {
+		tags = append(tags, k+"="+v)
+	}
+	sort.Strings(tags)
+	return strings.Join(tags, ",")
+}
+
+// Host is a host with its components.
+type Host struct {
+	Addr       string
+	Components []*Component
+}
+
+// Edge is the flows from a client component to the port of a server
+// component.
+type Edge struct {
+	From        *Component
+	To          *Component
+	Port        uint16
+	Connections int
+	// AcceptQueue and Backlog are of the listener of the port, which are
+	// zero unless the agent of the server writes them.
+	AcceptQueue int
+	Backlog     int
+}
+
+// Saturated returns whether the accept queue of the listener of the edge is
+// near its backlog.
+func (e *Edge) Saturated() bool {
+	n := db.Node{AcceptQueue: e.AcceptQueue, Backlog: e.Backlog}
+	return n.Saturated()
+}
+
+// Graph is the hosts and the edges between their components, in the order
+// of the addresses and the names.
+type Graph struct {
+	Hosts []*Host
+	Edges []*Edge
+	// Groups is nil unless the hosts are grouped by GroupBy.
+	Groups []*Group
+}
+
+// New builds the graph of the flows. The flows between the same components
+// to the same port are merged into an edge.
+func New(flows []*db.Flow) *Graph {
+	components := map[string]*Component{}
+	component := func(n *db.Node) *Component {
+		id := n.Addr() + "/" + n.Pname
+		c, ok := components[id]
+		if !ok {
+			c = &Component{ID: id, Addr: n.Addr(), Process: n.Pname}
+			components[id] = c
+		}
+		if len(n.Labels) > 0 && c.Labels == nil {
+			c.Labels = n.Labels
+		}
+		return c
+	}
+
+	edges := map[string]*Edge{}
+	for _, f := range flows {
+		from, to := component(f.ActiveNode), component(f.PassiveNode)
+		key := fmt.Sprintf("%s\t%s\t%d", from.ID, to.ID, f.PassiveNode.Port)
+		e, ok := edges[key]
+		if !ok {
+			e = &Edge{From: from, To: to, Port: f.PassiveNode.Port}
+			edges[key] = e
+		}
+		e.Connections += f.Connections
+		if f.PassiveNode.Backlog > 0 && f.PassiveNode.AcceptQueue >= e.AcceptQueue {
+			e.AcceptQueue, e.Backlog = f.PassiveNode.AcceptQueue, f.PassiveNode.Backlog
+		}
+	}
+
+	g := &Graph{}
+	hosts := map[string]*Host{}
+	for _, c := range components {
+		h, ok := hosts[c.Addr]
+		if !ok {
+			h = &Host{Addr: c.Addr}
+			hosts[c.Addr] = h
+			g.Hosts = append(g.Hosts, h)
+		}
+		h.Components = append(h.Components, c)
+	}
+	sort.Slice(g.Hosts, func(i, j int) bool { return lessAddr(g.Hosts[i].Addr, g.Hosts[j].Addr) })
+	for _, h := range g.Hosts {
+		sort.Slice(h.Components, func(i, j int) bool { return h.Components[i].Process < h.Components[j].Process })
+	}
+
+	for _, e := range edges {
+		g.Edges = append(g.Edges, e)
+	}
+	sort.Slice(g.Edges, func(i, j int) bool {
+		a, b := g.Edges[i], g.Edges[j]
+		if a.From != b.From {
+			return lessComponent(a.From, b.From)
+		}
+		if a.To != b.To {
+			return lessComponent(a.To, b.To)
+		}
+		return a.Port < b.Port
+	})
+	return g
+}
+
+// lessAddr compares the addresses, which may be suffixed with the VRFs such
+// as 10.0.0.1%blue, by the IPs and then by the VRFs.
+func lessAddr(a, b string) bool {
+	aip, avrf := splitVRF(a)
+	bip, bvrf := splitVRF(b)
+	if c := bytes.Compare(net.ParseIP(aip).To16(), net.ParseIP(bip).To16()); c != 0 {
+		return c < 0
+	}
+	return avrf < bvrf
+}
+
+func splitVRF(addr string) (string, string) {
+	if i := strings.IndexByte(addr, '%'); i >= 0 {
+		return addr[:i], addr[i+1:]
+	}
+	return addr, ""
+}
+
+func lessComponent(a, b *Component) bool {
+	if a.Addr != b.Addr {
+		return lessAddr(a.Addr, b.Addr)
+	}
+	return a.Process < b.Process
+}
+
+// A Renderer writes the graph in a format.
+type Renderer func(w io.Writer, g *Graph) error
+
+// The formats of the graph.
+const (
+	// FormatDOT is the DOT language of Graphviz.
+	FormatDOT = "dot"
+	// FormatPlantUML is a deployment diagram of PlantUML.
+	FormatPlantUML = "plantuml"
+	// FormatGraphML is GraphML for the graph analysis tools such as Gephi.
+	FormatGraphML = "graphml"
+	// FormatGEXF is GEXF, the native format of Gephi.
+	FormatGEXF = "gexf"
+	// FormatCytoscape is the elements JSON of Cytoscape.js.
+	FormatCytoscape = "cytoscape"
+	// FormatD2 is the D2 diagram scripting language.
+	FormatD2 = "d2"
+	// FormatHCL is the locals of HCL for the Terraform modules.
+	FormatHCL = "hcl"
+	// FormatServiceGraph is the metrics of the service graphs of Tempo in
+	// the Prometheus text format.
+	FormatServiceGraph = "servicegraph"
+)
+
+var renderers = map[string]Renderer{
+	FormatDOT:       RenderDOT,
+	FormatPlantUML:  RenderPlantUML,
+	FormatGraphML:   RenderGraphML,
+	FormatGEXF:      RenderGEXF,
+	FormatCytoscape: RenderCytoscape,
+	FormatD2:        RenderD2,
+	FormatHCL:       RenderHCL,
+
+	FormatServiceGraph: RenderServiceGraph,
+}
+
+// Formats returns the names of the formats in order.
+func Formats() []string {
+	formats := make([]string, 0, len(renderers))
+	for f := range renderers {
+		formats = append(formats, f)
+	}
+	sort.Strings(formats)
+	return formats
+}
+
+// ValidateFormat returns an error if the format is unknown.
+func ValidateFormat(format string) error {
+	if _, ok := renderers[format]; !ok {
+		return xerrors.Errorf("--format must be one of %s, but %q", strings.Join(Formats(), ", "), format)
+	}
+	return nil
+}
+
+// Render writes the graph in the format.
+func Render(w io.Writer, g *Graph, format string) error {
+	if err := ValidateFormat(format); err != nil {
+		return err
+	}
+	return renderers[format](w, g)
+}
+
+// edgeLabel returns the label of the edge, which is the port and the
+// connections, and the backlog of the listener if it is saturated.
+func edgeLabel(e *Edge) string {
+	if e.Saturated() {
+		return fmt.Sprintf(":%d (%d) backlog %d/%d", e.Port, e.Connections, e.AcceptQueue, e.Backlog)
+	}
+	return fmt.Sprintf(":%d (%d)", e.Port, e.Connections)
+}
@@ -0,0 +1,617 @@
+package graph
+
+import (
+	"bytes"
+	"encoding/xml"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/yuuki/shawk/db"
+	"github.com/yuuki/shawk/db/dbtest"
+)
+
+func testGraph() *Graph {
+	return New([]*db.Flow{
+		dbtest.Flow("10.0.0.10", "app", "10.0.0.20", "postgres", 5432, 3, "role", "db", "env", "prod"),
+		dbtest.Flow("10.0.0.9", "haproxy", "10.0.0.10", "app", 80, 10),
+		// merged into the edge above, since the active ports are aggregated
+		dbtest.Flow("10.0.0.9", "haproxy", "10.0.0.10", "app", 80, 2),
+		dbtest.Flow("10.0.0.10", "app", "10.0.0.30", "", 6379, 1),
+	})
+}
+
+func TestNew(t *testing.T) {
+	g := testGraph()
+
+	var hosts []string
+	for _, h := range g.Hosts {
+		var names []string
+		for _, c := range h.Components {
+			names = append(names, c.Name())
+		}
+		hosts = append(hosts, h.Addr+" "+strings.Join(names, ","))
+	}
+	wantHosts := []string{"10.0.0.9 haproxy", "10.0.0.10 app", "10.0.0.20 postgres", "10.0.0.30 10.0.0.30"}
+	if diff := cmp.Diff(wantHosts, hosts); diff != "" {
+		t.Errorf("hosts mismatch (-want +got):\n%s", diff)
+	}
+
+	var edges []string
+	for _, e := range g.Edges {
+		edges = append(edges, e.From.ID+" -> "+e.To.ID+" "+edgeLabel(e))
+	}
+	wantEdges := []string{
+		"10.0.0.9/haproxy -> 10.0.0.10/app :80 (12)",
+		"10.0.0.10/app -> 10.0.0.20/postgres :5432 (3)",
+		"10.0.0.10/app -> 10.0.0.30/ :6379 (1)",
+	}
+	if diff := cmp.Diff(wantEdges, edges); diff != "" {
+		t.Errorf("edges mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestNew_vrf(t *testing.T) {
+	blue := dbtest.Flow("10.0.0.10", "app", "10.0.0.20", "postgres", 5432, 2)
+	blue.ActiveNode.VRF, blue.PassiveNode.VRF = "blue", "blue"
+	g := New([]*db.Flow{
+		dbtest.Flow("10.0.0.10", "app", "10.0.0.20", "postgres", 5432, 3),
+		blue,
+	})
+
+	var hosts []string
+	for _, h := range g.Hosts {
+		hosts = append(hosts, h.Addr)
+	}
+	// The same addresses in the VRF are the other hosts.
+	want := []string{"10.0.0.10", "10.0.0.10%blue", "10.0.0.20", "10.0.0.20%blue"}
+	if diff := cmp.Diff(want, hosts); diff != "" {
+		t.Errorf("hosts mismatch (-want +got):\n%s", diff)
+	}
+	if len(g.Edges) != 2 {
+		t.Errorf("the flows of the VRFs should be 2 edges, but %d", len(g.Edges))
+	}
+
+	groups := map[string]bool{}
+	for _, h := range g.Hosts {
+		groups[subnetGrouper(24)(h)] = true
+	}
+	if diff := cmp.Diff(map[string]bool{"10.0.0.0/24": true, "10.0.0.0/24%blue": true}, groups); diff != "" {
+		t.Errorf("subnets mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestSaturatedEdge(t *testing.T) {
+	saturated := func(f *db.Flow) *db.Flow {
+		f.PassiveNode.AcceptQueue, f.PassiveNode.Backlog = 110, 128
+		return f
+	}
+	g := New([]*db.Flow{
+		saturated(dbtest.Flow("10.0.0.8", "haproxy", "10.0.0.10", "app", 80, 2)),
+		saturated(dbtest.Flow("10.0.0.9", "haproxy", "10.0.0.10", "app", 80, 10)),
+		dbtest.Flow("10.0.0.10", "app", "10.0.0.20", "postgres", 5432, 3),
+	})
+
+	var b bytes.Buffer
+	if err := RenderDOT(&b, g); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		`"10.0.0.9/haproxy" -> "10.0.0.10/app" [label=":80 (10) backlog 110/128", color=red];`,
+		`"10.0.0.10/app" -> "10.0.0.20/postgres" [label=":5432 (3)"];`,
+	} {
+		if !strings.Contains(b.String(), want) {
+			t.Errorf("RenderDOT() should contain %q, but\n%s", want, b.String())
+		}
+	}
+	// The edges to the same listener are a saturated listener.
+	if m := NewMetrics(g, g, nil); m.SaturatedListeners != 1 {
+		t.Errorf("the saturated listeners should be 1, but %d", m.SaturatedListeners)
+	}
+}
+
+func TestRender(t *testing.T) {
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{
+			FormatDOT,
+			`digraph shawk {
+  rankdir=LR;
+  node [shape=box];
+  subgraph "cluster_10.0.0.9" {
+    label="10.0.0.9";
+    "10.0.0.9/haproxy" [label="haproxy"];
+  }
+  subgraph "cluster_10.0.0.10" {
+    label="10.0.0.10";
+    "10.0.0.10/app" [label="app"];
+  }
+  subgraph "cluster_10.0.0.20" {
+    label="10.0.0.20";
+    "10.0.0.20/postgres" [label="postgres"];
+  }
+  subgraph "cluster_10.0.0.30" {
+    label="10.0.0.30";
+    "10.0.0.30/" [label="10.0.0.30"];
+  }
+  "10.0.0.9/haproxy" -> "10.0.0.10/app" [label=":80 (12)"];
+  "10.0.0.10/app" -> "10.0.0.20/postgres" [label=":5432 (3)"];
+  "10.0.0.10/app" -> "10.0.0.30/" [label=":6379 (1)"];
+}
+`,
+		},
+		{
+			FormatPlantUML,
+			`@startuml
+left to right direction
+node "10.0.0.9" as h_10_0_0_9 {
+  component "haproxy" as c_10_0_0_9_haproxy
+}
+node "10.0.0.10" as h_10_0_0_10 {
+  component "app" as c_10_0_0_10_app
+}
+node "10.0.0.20" as h_10_0_0_20 {
+  component "postgres" as c_10_0_0_20_postgres
+}
+node "10.0.0.30" as h_10_0_0_30 {
+  component "10.0.0.30" as c_10_0_0_30_
+}
+c_10_0_0_9_haproxy --> c_10_0_0_10_app : :80 (12)
+c_10_0_0_10_app --> c_10_0_0_20_postgres : :5432 (3)
+c_10_0_0_10_app --> c_10_0_0_30_ : :6379 (1)
+@enduml
+`,
+		},
+		{
+			FormatHCL,
+			`# The dependency graph observed by shawk.
+locals {
+  shawk_hosts = {
+    "10.0.0.9"  = ["10.0.0.9/haproxy"]
+    "10.0.0.10" = ["10.0.0.10/app"]
+    "10.0.0.20" = ["10.0.0.20/postgres"]
+    "10.0.0.30" = ["10.0.0.30/"]
+  }
+
+  shawk_components = {
+    "10.0.0.9/haproxy" = {
+      host    = "10.0.0.9"
+      process = "haproxy"
+      labels  = {}
+    }
+    "10.0.0.10/app" = {
+      host    = "10.0.0.10"
+      process = "app"
+      labels  = {}
+    }
+    "10.0.0.20/postgres" = {
+      host    = "10.0.0.20"
+      process = "postgres"
+      labels  = { "env" = "prod", "role" = "db" }
+    }
+    "10.0.0.30/" = {
+      host    = "10.0.0.30"
+      process = ""
+      labels  = {}
+    }
+  }
+
+  shawk_edges = [
+    {
+      from        = "10.0.0.9/haproxy"
+      to          = "10.0.0.10/app"
+      port        = 80
+      connections = 12
+    },
+    {
+      from        = "10.0.0.10/app"
+      to          = "10.0.0.20/postgres"
+      port        = 5432
+      connections = 3
+    },
+    {
+      from        = "10.0.0.10/app"
+      to          = "10.0.0.30/"
+      port        = 6379
+      connections = 1
+    },
+  ]
+}
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			var b bytes.Buffer
+			if err := Render(&b, testGraph(), tt.format); err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tt.want, b.String()); diff != "" {
+				t.Errorf("Render() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+
+	if err := Render(&bytes.Buffer{}, testGraph(), "svg"); err == nil || !strings.Contains(err.Error(), "cytoscape, d2, dot, gexf, graphml, hcl, plantuml, servicegraph") {
+		t.Errorf("Render() should return an error for an unknown format, but %v", err)
+	}
+}
+
+func TestRender_cytoscape(t *testing.T) {
+	var b bytes.Buffer
+	if err := Render(&b, New([]*db.Flow{
+		dbtest.Flow("10.0.0.10", "app", "10.0.0.20", "postgres", 5432, 3, "role", "db"),
+	}), FormatCytoscape); err != nil {
+		t.Fatal(err)
+	}
+	want := `{
+  "elements": {
+    "nodes": [
+      {
+        "data": {
+          "id": "10.0.0.10",
+          "label": "10.0.0.10"
+        }
+      },
+      {
+        "data": {
+          "id": "10.0.0.10/app",
+          "parent": "10.0.0.10",
+          "label": "app",
+          "host": "10.0.0.10",
+          "process": "app",
+          "tags": ""
+        }
+      },
+      {
+        "data": {
+          "id": "10.0.0.20",
+          "label": "10.0.0.20"
+        }
+      },
+      {
+        "data": {
+          "id": "10.0.0.20/postgres",
+          "parent": "10.0.0.20",
+          "label": "postgres",
+          "host": "10.0.0.20",
+          "process": "postgres",
+          "tags": "role=db"
+        }
+      }
+    ],
+    "edges": [
+      {
+        "data": {
+          "id": "e0",
+          "source": "10.0.0.10/app",
+          "target": "10.0.0.20/postgres",
+          "label": ":5432 (3)",
+          "port": 5432,
+          "connections": 3
+        }
+      }
+    ]
+  }
+}
+`
+	if diff := cmp.Diff(want, b.String()); diff != "" {
+		t.Errorf("Render() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestRender_xml(t *testing.T) {
+	wantTags := map[string]string{
+		"10.0.0.9/haproxy":   "",
+		"10.0.0.10/app":      "",
+		"10.0.0.20/postgres": "env=prod,role=db",
+		"10.0.0.30/":         "",
+	}
+	wantWeights := map[string]int{
+		"10.0.0.9/haproxy -> 10.0.0.10/app":   12,
+		"10.0.0.10/app -> 10.0.0.20/postgres": 3,
+		"10.0.0.10/app -> 10.0.0.30/":         1,
+	}
+
+	t.Run(FormatGraphML, func(t *testing.T) {
+		var b bytes.Buffer
+		if err := Render(&b, testGraph(), FormatGraphML); err != nil {
+			t.Fatal(err)
+		}
+		var doc graphML
+		if err := xml.Unmarshal(b.Bytes(), &doc); err != nil {
+			t.Fatalf("the output should be a valid XML: %v", err)
+		}
+		tags, weights := map[string]string{}, map[string]int{}
+		for _, n := range doc.Graph.Nodes {
+			for _, d := range n.Data {
+				if d.Key == "tags" {
+					tags[n.ID] = d.Value
+				}
+			}
+		}
+		for _, e := range doc.Graph.Edges {
+			for _, d := range e.Data {
+				if d.Key == "weight" {
+					weights[e.Source+" -> "+e.Target], _ = strconv.Atoi(d.Value)
+				}
+			}
+		}
+		if diff := cmp.Diff(wantTags, tags); diff != "" {
+			t.Errorf("tags mismatch (-want +got):\n%s", diff)
+		}
+		if diff := cmp.Diff(wantWeights, weights); diff != "" {
+			t.Errorf("weights mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run(FormatGEXF, func(t *testing.T) {
+		var b bytes.Buffer
+		if err := Render(&b, testGraph(), FormatGEXF); err != nil {
+			t.Fatal(err)
+		}
+		var doc gexf
+		if err := xml.Unmarshal(b.Bytes(), &doc); err != nil {
+			t.Fatalf("the output should be a valid XML: %v", err)
+		}
+		tags, weights := map[string]string{}, map[string]int{}
+		for _, n := range doc.Graph.Nodes {
+			for _, v := range n.AttValues {
+				if v.For == "tags" {
+					tags[n.ID] = v.Value
+				}
+			}
+		}
+		for _, e := range doc.Graph.Edges {
+			weights[e.Source+" -> "+e.Target] = e.Weight
+		}
+		if diff := cmp.Diff(wantTags, tags); diff != "" {
+			t.Errorf("tags mismatch (-want +got):\n%s", diff)
+		}
+		if diff := cmp.Diff(wantWeights, weights); diff != "" {
+			t.Errorf("weights mismatch (-want +got):\n%s", diff)
+		}
+	})
+}
+
+func TestGraph_GroupBy(t *testing.T) {
+	tests := []struct {
+		by      string
+		want    []string
+		wantErr string
+	}{
+		{"", nil, ""},
+		{"subnet", []string{"10.0.0.0/24 10.0.0.9,10.0.0.10,10.0.0.20,10.0.0.30"}, ""},
+		{"subnet/30", []string{"10.0.0.8/30 10.0.0.9,10.0.0.10", "10.0.0.20/30 10.0.0.20", "10.0.0.28/30 10.0.0.30"}, ""},
+		{"tag:role", []string{"role=db 10.0.0.20", " 10.0.0.9,10.0.0.10,10.0.0.30"}, ""},
+		{"subnet/33", nil, "prefix length from 1 to 32"},
+		{"tag:", nil, "--group-by must be one of"},
+		{"zone", nil, "--group-by must be one of"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.by, func(t *testing.T) {
+			g := testGraph()
+			err := g.GroupBy(tt.by)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("GroupBy(%q) should return an error containing %q, but %v", tt.by, tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, gr := range g.Groups {
+				var addrs []string
+				for _, h := range gr.Hosts {
+					addrs = append(addrs, h.Addr)
+				}
+				got = append(got, gr.Name+" "+strings.Join(addrs, ","))
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("GroupBy(%q) mismatch (-want +got):\n%s", tt.by, diff)
+			}
+		})
+	}
+}
+
+func TestRender_d2(t *testing.T) {
+	tests := []struct {
+		by   string
+		want string
+	}{
+		{
+			"",
+			`direction: right
+"10.0.0.9": {
+  "haproxy"
+}
+"10.0.0.10": {
+  "app"
+}
+"10.0.0.20": {
+  "postgres"
+}
+"10.0.0.30": {
+  "10.0.0.30"
+}
+"10.0.0.9"."haproxy" -> "10.0.0.10"."app": ":80 (12)"
+"10.0.0.10"."app" -> "10.0.0.20"."postgres": ":5432 (3)"
+"10.0.0.10"."app" -> "10.0.0.30"."10.0.0.30": ":6379 (1)"
+`,
+		},
+		{
+			"tag:role",
+			`direction: right
+"role=db": {
+  "10.0.0.20": {
+    "postgres"
+  }
+}
+"10.0.0.9": {
+  "haproxy"
+}
+"10.0.0.10": {
+  "app"
+}
+"10.0.0.30": {
+  "10.0.0.30"
+}
+"10.0.0.9"."haproxy" -> "10.0.0.10"."app": ":80 (12)"
+"10.0.0.10"."app" -> "role=db"."10.0.0.20"."postgres": ":5432 (3)"
+"10.0.0.10"."app" -> "10.0.0.30"."10.0.0.30": ":6379 (1)"
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.by, func(t *testing.T) {
+			g := testGraph()
+			if err := g.GroupBy(tt.by); err != nil {
+				t.Fatal(err)
+			}
+			var b bytes.Buffer
+			if err := Render(&b, g, FormatD2); err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tt.want, b.String()); diff != "" {
+				t.Errorf("Render() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestRender_dotGroupBy(t *testing.T) {
+	g := testGraph()
+	if err := g.GroupBy("tag:role"); err != nil {
+		t.Fatal(err)
+	}
+	var b bytes.Buffer
+	if err := Render(&b, g, FormatDOT); err != nil {
+		t.Fatal(err)
+	}
+	want := `digraph shawk {
+  rankdir=LR;
+  node [shape=box];
+  subgraph "cluster_group_role=db" {
+    label="role=db";
+    subgraph "cluster_10.0.0.20" {
+      label="10.0.0.20";
+      "10.0.0.20/postgres" [label="postgres"];
+    }
+  }
+  subgraph "cluster_10.0.0.9" {
+    label="10.0.0.9";
+    "10.0.0.9/haproxy" [label="haproxy"];
+  }
+  subgraph "cluster_10.0.0.10" {
+    label="10.0.0.10";
+    "10.0.0.10/app" [label="app"];
+  }
+  subgraph "cluster_10.0.0.30" {
+    label="10.0.0.30";
+    "10.0.0.30/" [label="10.0.0.30"];
+  }
+  "10.0.0.9/haproxy" -> "10.0.0.10/app" [label=":80 (12)"];
+  "10.0.0.10/app" -> "10.0.0.20/postgres" [label=":5432 (3)"];
+  "10.0.0.10/app" -> "10.0.0.30/" [label=":6379 (1)"];
+}
+`
+	if diff := cmp.Diff(want, b.String()); diff != "" {
+		t.Errorf("Render() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestHCLString(t *testing.T) {
+	if got, want := hclString(`${var.x} "%{if}"`), `"$${var.x} \"%%{if}\""`; got != want {
+		t.Errorf("hclString() should be %s, but %s", want, got)
+	}
+}
+
+func TestRender_servicegraph(t *testing.T) {
+	// The app of 10.0.0.10 is named api also as the client by its label.
+	g := New([]*db.Flow{
+		dbtest.Flow("10.0.0.9", "haproxy", "10.0.0.10", "app", 80, 10, "k8s.service", "api"),
+		// merged into the edge above by the service
+		dbtest.Flow("10.0.0.9", "haproxy", "10.0.0.11", "app", 80, 2, "k8s.service", "api"),
+		dbtest.Flow("10.0.0.10", "app", "10.0.0.20", "postgres", 5432, 3),
+		dbtest.Flow("10.0.0.10", "app", "10.0.0.30", "java", 9092, 4, "service.name", "kafka"),
+		dbtest.Flow("10.0.0.10", "app", "203.0.113.1", "", 443, 1),
+	})
+	var b bytes.Buffer
+	if err := Render(&b, g, FormatServiceGraph); err != nil {
+		t.Fatal(err)
+	}
+	want := `# HELP traces_service_graph_request_total The connections between the services observed by shawk.
+# TYPE traces_service_graph_request_total counter
+traces_service_graph_request_total{client="api",server="203.0.113.1",connection_type="virtual_node"} 1
+traces_service_graph_request_total{client="api",server="kafka",connection_type="messaging_system"} 4
+traces_service_graph_request_total{client="api",server="postgres",connection_type="database"} 3
+traces_service_graph_request_total{client="haproxy",server="api",connection_type=""} 12
+`
+	if diff := cmp.Diff(want, b.String()); diff != "" {
+		t.Errorf("Render() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestNewMetrics(t *testing.T) {
+	g := testGraph()
+	// Only the flows of haproxy have been updated recently.
+	recent := New([]*db.Flow{dbtest.Flow("10.0.0.9", "haproxy", "10.0.0.10", "app", 80, 10)})
+	m := NewMetrics(g, recent, nil)
+	if m.Hosts != 4 || m.Nodes != 4 || m.Edges != 3 || m.StaleNodes != 2 {
+		t.Errorf("NewMetrics() = hosts %d, nodes %d, edges %d, stale nodes %d, want 4, 4, 3, 2",
+			m.Hosts, m.Nodes, m.Edges, m.StaleNodes)
+	}
+	if m.EdgesAdded != 0 || m.EdgesRemoved != 0 {
+		t.Errorf("the first metrics should add and remove no edges, but %d and %d", m.EdgesAdded, m.EdgesRemoved)
+	}
+	if diff := cmp.Diff(map[string]int{"app": 1, "postgres": 1, "10.0.0.30": 1}, m.FanIn); diff != "" {
+		t.Errorf("FanIn mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(map[string]int{"haproxy": 1, "app": 2}, m.FanOut); diff != "" {
+		t.Errorf("FanOut mismatch (-want +got):\n%s", diff)
+	}
+
+	next := New([]*db.Flow{
+		dbtest.Flow("10.0.0.9", "haproxy", "10.0.0.10", "app", 80, 10),
+		dbtest.Flow("10.0.0.10", "app", "10.0.0.20", "postgres", 5432, 3),
+		dbtest.Flow("10.0.0.10", "app", "10.0.0.21", "postgres", 5432, 1),
+	})
+	m = NewMetrics(next, next, m)
+	if m.EdgesAdded != 1 || m.EdgesRemoved != 1 {
+		t.Errorf("the metrics should add and remove an edge, but %d and %d", m.EdgesAdded, m.EdgesRemoved)
+	}
+}
+
+func TestRenderMetrics(t *testing.T) {
+	m := &Metrics{
+		Hosts: 2, Nodes: 3, Edges: 2, EdgesAdded: 1, StaleNodes: 1, SaturatedListeners: 1,
+		FanIn:     map[string]int{"postgres": 1, `a"b`: 2},
+		FanOut:    map[string]int{"app": 1},
+		Collected: time.Unix(1600000000, 0),
+	}
+	var b bytes.Buffer
+	if err := RenderMetrics(&b, m); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"# TYPE shawk_graph_nodes gauge\nshawk_graph_nodes 3\n",
+		"shawk_graph_edges_added 1\n",
+		"shawk_graph_edges_removed 0\n",
+		"shawk_graph_stale_nodes 1\n",
+		"shawk_graph_saturated_listeners 1\n",
+		"shawk_graph_collected_timestamp_seconds 1600000000\n",
+		"shawk_service_fan_in{service=\"a\\\"b\"} 2\nshawk_service_fan_in{service=\"postgres\"} 1\n",
+		"shawk_service_fan_out{service=\"app\"} 1\n",
+	} {
+		if !strings.Contains(b.String(), want) {
+			t.Errorf("RenderMetrics() should contain %q, but\n%s", want, b.String())
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package graph
+
+import (
+	"encoding/xml"
+	"io"
+	"strconv"
+)
+
+type graphML struct {
+	XMLName xml.Name     `xml:"graphml"`
+	XMLNS   string       `xml:"xmlns,attr"`
+	Keys    []graphMLKey `xml:"key"`
+	Graph   graphMLGraph `xml:"graph"`
+}
+
+type graphMLKey struct {
+	ID   string `xml:"id,attr"`
+	For  string `xml:"for,attr"`
+	Name string `xml:"attr.name,attr"`
+	Type string `xml:"attr.type,attr"`
+}
+
+type graphMLGraph struct {
+	ID          string        `xml:"id,attr"`
+	EdgeDefault string        `xml:"edgedefault,attr"`
+	Nodes       []graphMLNode `xml:"node"`
+	Edges       []graphMLEdge `xml:"edge"`
+}
+
+type graphMLNode struct {
+	ID   string        `xml:"id,attr"`
+	Data []graphMLData `xml:"data"`
+}
+
+type graphMLEdge struct {
+	ID     string        `xml:"id,attr"`
+	Source string        `xml:"source,attr"`
+	Target string        `xml:"target,attr"`
+	Data   []graphMLData `xml:"data"`
+}
+
+type graphMLData struct {
+	Key   string `xml:"key,attr"`
+	Value string `xml:",chardata"`
+}
+
+// RenderGraphML writes the graph in GraphML. The nodes have the label, host,
+// process and tags attributes, and the edges have the port and the weight of
+// the connections, which Gephi reads as the edge weight.
+func RenderGraphML(w io.Writer, g *Graph) error {
+	doc := graphML{
+		XMLNS: "http://graphml.graphdrawing.org/xmlns",
+		Keys: []graphMLKey{
+			{ID: "label", For: "node", Name: "label", Type: "string"},
+			{ID: "host", For: "node", Name: "host", Type: "string"},
+			{ID: "process", For: "node", Name: "process", Type: "string"},
+			{ID: "tags", For: "node", Name: "tags", Type: "string"},
+			{ID: "port", For: "edge", Name: "port", Type: "int"},
+			{ID: "weight", For: "edge", Name: "weight", Type: "double"},
+		},
+		Graph: graphMLGraph{ID: "shawk", EdgeDefault: "directed"},
+	}
+	for _, h := range g.Hosts {
+		for _, c := range h.Components {
+			doc.Graph.Nodes = append(doc.Graph.Nodes, graphMLNode{
+				ID: c.ID,
+				Data: []graphMLData{
+					{Key: "label", Value: c.Name()},
+					{Key: "host", Value: c.Addr},
+					{Key: "process", Value: c.Process},
+					{Key: "tags", Value: c.Tags()},
+				},
+			})
+		}
+	}
+	for i, e := range g.Edges {
+		doc.Graph.Edges = append(doc.Graph.Edges, graphMLEdge{
+			ID:     "e" + strconv.Itoa(i),
+			Source: e.From.ID,
+			Target: e.To.ID,
+			Data: []graphMLData{
+				{Key: "port", Value: strconv.Itoa(int(e.Port))},
+				{Key: "weight", Value: strconv.Itoa(e.Connections)},
+			},
+		})
+	}
+	return writeXML(w, doc)
+}
+
+// writeXML writes the document with the XML declaration.
+func writeXML(w io.Writer, doc interface{}) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
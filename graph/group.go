@@ -0,0 +1,135 @@
+package graph
+
+import (
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+
+	"golang.org/x/xerrors"
+)
+
+// defaultSubnetPrefix is the prefix length of the subnets to group the hosts
+// by, if --group-by subnet has no length.
+const defaultSubnetPrefix = 24
+
+// Group is the hosts in a subnet or with a tag. The hosts in no group are in
+// the group without the name.
+type Group struct {
+	Name  string
+	Hosts []*Host
+}
+
+// grouper returns the name of the group of the host.
+type grouper func(h *Host) string
+
+// parseGroupBy parses the --group-by option, which is "subnet", "subnet/N"
+// or "tag:KEY". It returns nil for the empty option.
+func parseGroupBy(by string) (grouper, error) {
+	switch {
+	case by == "":
+		return nil, nil
+	case by == "subnet" || strings.HasPrefix(by, "subnet/"):
+		prefix := defaultSubnetPrefix
+		if s := strings.TrimPrefix(by, "subnet"); s != "" {
+			n, err := strconv.Atoi(s[1:])
+			if err != nil || n < 1 || n > 32 {
+				return nil, xerrors.Errorf("--group-by subnet/N must have the prefix length from 1 to 32, but %q", by)
+			}
+			prefix = n
+		}
+		return subnetGrouper(prefix), nil
+	case strings.HasPrefix(by, "tag:") && len(by) > len("tag:"):
+		return tagGrouper(strings.TrimPrefix(by, "tag:")), nil
+	}
+	return nil, xerrors.Errorf("--group-by must be one of subnet, subnet/N or tag:KEY, but %q", by)
+}
+
+// subnetGrouper groups the IPv4 hosts by the subnets of the prefix length.
+// The IPv6 hosts are grouped by the /64 subnets. The subnets of the VRFs are
+// apart from the same subnets of the others.
+func subnetGrouper(prefix int) grouper {
+	return func(h *Host) string {
+		addr, vrf := splitVRF(h.Addr)
+		ip := net.ParseIP(addr)
+		mask := net.CIDRMask(prefix, 32)
+		if ip4 := ip.To4(); ip4 != nil {
+			ip = ip4
+		} else {
+			mask = net.CIDRMask(64, 128)
+		}
+		ipnet := net.IPNet{IP: ip.Mask(mask), Mask: mask}
+		if vrf != "" {
+			return ipnet.String() + "%" + vrf
+		}
+		return ipnet.String()
+	}
+}
+
+// tagGrouper groups the hosts by the value of the label of their components.
+func tagGrouper(key string) grouper {
+	return func(h *Host) string {
+		for _, c := range h.Components {
+			if v, ok := c.Labels[key]; ok {
+				return key + "=" + v
+			}
+		}
+		return ""
+	}
+}
+
+// ValidateGroupBy returns an error if the --group-by option is invalid.
+func ValidateGroupBy(by string) error {
+	_, err := parseGroupBy(by)
+	return err
+}
+
+// GroupBy groups the hosts of the graph by "subnet", "subnet/N" or
+// "tag:KEY". The empty option clears the groups.
+func (g *Graph) GroupBy(by string) error {
+	group, err := parseGroupBy(by)
+	if err != nil {
+		return err
+	}
+	g.Groups = nil
+	if group == nil {
+		return nil
+	}
+
+	var ungrouped *Group
+	groups := map[string]*Group{}
+	for _, h := range g.Hosts {
+		name := group(h)
+		if name == "" {
+			if ungrouped == nil {
+				ungrouped = &Group{}
+			}
+			ungrouped.Hosts = append(ungrouped.Hosts, h)
+			continue
+		}
+		gr, ok := groups[name]
+		if !ok {
+			gr = &Group{Name: name}
+			groups[name] = gr
+			g.Groups = append(g.Groups, gr)
+		}
+		gr.Hosts = append(gr.Hosts, h)
+	}
+	// the hosts are already in order, so are the subnets of them
+	if strings.HasPrefix(by, "tag:") {
+		sort.SliceStable(g.Groups, func(i, j int) bool { return g.Groups[i].Name < g.Groups[j].Name })
+	}
+	if ungrouped != nil {
+		g.Groups = append(g.Groups, ungrouped)
+	}
+	return nil
+}
+
+// groups returns the groups of the graph, or a group without the name of all
+// the hosts if the graph is not grouped.
+func (g *Graph) groups() []*Group {
+	if g.Groups == nil {
+		return []*Group{{Hosts: g.Hosts}}
+	}
+	return g.Groups
+}
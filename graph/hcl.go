@@ -0,0 +1,98 @@
+package graph
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// hclString quotes the string of HCL, escaping the template sequences too.
+func hclString(s string) string {
+	q := strconv.Quote(s)
+	return strings.NewReplacer("${", "$${", "%{", "%%{").Replace(q)
+}
+
+// writeHCLAttrs writes the pairs of the names and the values aligned as
+// terraform fmt does.
+func writeHCLAttrs(w io.Writer, indent string, attrs [][2]string) {
+	width := 0
+	for _, a := range attrs {
+		if len(a[0]) > width {
+			width = len(a[0])
+		}
+	}
+	for _, a := range attrs {
+		fmt.Fprintf(w, "%s%-*s = %s\n", indent, width, a[0], a[1])
+	}
+}
+
+// RenderHCL writes the graph as the locals of HCL, so that the Terraform
+// modules consume the topology: shawk_hosts maps the addresses to the IDs
+// of their components, shawk_components maps the IDs to the hosts, the
+// processes and the labels, and shawk_edges lists the edges between the
+// IDs.
+func RenderHCL(w io.Writer, g *Graph) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintln(bw, "# The dependency graph observed by shawk.")
+	fmt.Fprintln(bw, "locals {")
+
+	hosts := make([][2]string, 0, len(g.Hosts))
+	for _, h := range g.Hosts {
+		ids := make([]string, 0, len(h.Components))
+		for _, c := range h.Components {
+			ids = append(ids, hclString(c.ID))
+		}
+		hosts = append(hosts, [2]string{hclString(h.Addr), "[" + strings.Join(ids, ", ") + "]"})
+	}
+	fmt.Fprintln(bw, "  shawk_hosts = {")
+	writeHCLAttrs(bw, "    ", hosts)
+	fmt.Fprintln(bw, "  }")
+
+	fmt.Fprintln(bw)
+	fmt.Fprintln(bw, "  shawk_components = {")
+	for _, h := range g.Hosts {
+		for _, c := range h.Components {
+			fmt.Fprintf(bw, "    %s = {\n", hclString(c.ID))
+			writeHCLAttrs(bw, "      ", [][2]string{
+				{"host", hclString(c.Addr)},
+				{"process", hclString(c.Process)},
+				{"labels", hclLabels(c.Labels)},
+			})
+			fmt.Fprintln(bw, "    }")
+		}
+	}
+	fmt.Fprintln(bw, "  }")
+
+	fmt.Fprintln(bw)
+	fmt.Fprintln(bw, "  shawk_edges = [")
+	for _, e := range g.Edges {
+		fmt.Fprintln(bw, "    {")
+		writeHCLAttrs(bw, "      ", [][2]string{
+			{"from", hclString(e.From.ID)},
+			{"to", hclString(e.To.ID)},
+			{"port", strconv.Itoa(int(e.Port))},
+			{"connections", strconv.Itoa(e.Connections)},
+		})
+		fmt.Fprintln(bw, "    },")
+	}
+	fmt.Fprintln(bw, "  ]")
+	fmt.Fprintln(bw, "}")
+	return bw.Flush()
+}
+
+// hclLabels returns the labels as an object of HCL in a line, in the order
+// of the keys.
+func hclLabels(labels map[string]string) string {
+	if len(labels) == 0 {
+		return "{}"
+	}
+	tags := make([]string, 0, len(labels))
+	for k, v := range labels {
+		tags = append(tags, hclString(k)+" = "+hclString(v))
+	}
+	sort.Strings(tags)
+	return "{ " + strings.Join(tags, ", ") + " }"
+}
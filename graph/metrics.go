@@ -0,0 +1,130 @@
+package graph
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// Metrics is the size and the health of the graph as the gauges of
+// Prometheus.
+type Metrics struct {
+	Hosts int
+	Nodes int
+	Edges int
+	// EdgesAdded and EdgesRemoved are the edges since the previous
+	// metrics, which are zero for the first ones.
+	EdgesAdded   int
+	EdgesRemoved int
+	// StaleNodes are the nodes without the flows updated recently.
+	StaleNodes int
+	// SaturatedListeners are the ports of the servers whose accept queues
+	// are near their backlogs.
+	SaturatedListeners int
+	// FanIn and FanOut are the numbers of the client services and the
+	// server services of each service.
+	FanIn  map[string]int
+	FanOut map[string]int
+	// Collected is the time of the metrics.
+	Collected time.Time
+
+	edges map[string]bool
+}
+
+// edgeKey identifies the edge across the graphs.
+func edgeKey(e *Edge) string {
+	return e.From.ID + "\t" + e.To.ID + "\t" + strconv.Itoa(int(e.Port))
+}
+
+// NewMetrics returns the metrics of the graph g. recent is the graph of the
+// flows updated recently, whose nodes are not stale. prev is the previous
+// metrics to count the edges added and removed since then, or nil.
+func NewMetrics(g, recent *Graph, prev *Metrics) *Metrics {
+	m := &Metrics{
+		Hosts:  len(g.Hosts),
+		Edges:  len(g.Edges),
+		FanIn:  map[string]int{},
+		FanOut: map[string]int{},
+		edges:  make(map[string]bool, len(g.Edges)),
+	}
+	fresh := map[string]bool{}
+	for _, h := range recent.Hosts {
+		for _, c := range h.Components {
+			fresh[c.ID] = true
+		}
+	}
+	for _, h := range g.Hosts {
+		for _, c := range h.Components {
+			m.Nodes++
+			if !fresh[c.ID] {
+				m.StaleNodes++
+			}
+		}
+	}
+
+	type pair struct{ client, server string }
+	pairs := map[pair]bool{}
+	saturated := map[string]bool{}
+	for _, e := range g.Edges {
+		m.edges[edgeKey(e)] = true
+		if l := e.To.ID + "\t" + strconv.Itoa(int(e.Port)); e.Saturated() && !saturated[l] {
+			saturated[l] = true
+			m.SaturatedListeners++
+		}
+		p := pair{e.From.Service(), e.To.Service()}
+		if p.client == p.server || pairs[p] {
+			continue
+		}
+		pairs[p] = true
+		m.FanOut[p.client]++
+		m.FanIn[p.server]++
+	}
+
+	if prev != nil {
+		for k := range m.edges {
+			if !prev.edges[k] {
+				m.EdgesAdded++
+			}
+		}
+		for k := range prev.edges {
+			if !m.edges[k] {
+				m.EdgesRemoved++
+			}
+		}
+	}
+	return m
+}
+
+// RenderMetrics writes the metrics in the Prometheus text format.
+func RenderMetrics(w io.Writer, m *Metrics) error {
+	bw := bufio.NewWriter(w)
+	gauge := func(name, help string, v interface{}) {
+		fmt.Fprintf(bw, "# HELP %s %s\n# TYPE %s gauge\n%s %v\n", name, help, name, name, v)
+	}
+	gauge("shawk_graph_hosts", "The hosts of the graph.", m.Hosts)
+	gauge("shawk_graph_nodes", "The processes of the graph.", m.Nodes)
+	gauge("shawk_graph_edges", "The edges between the processes of the graph.", m.Edges)
+	gauge("shawk_graph_edges_added", "The edges added since the previous collection.", m.EdgesAdded)
+	gauge("shawk_graph_edges_removed", "The edges removed since the previous collection.", m.EdgesRemoved)
+	gauge("shawk_graph_stale_nodes", "The processes without the flows updated recently.", m.StaleNodes)
+	gauge("shawk_graph_saturated_listeners", "The listeners whose accept queues are near their backlogs.", m.SaturatedListeners)
+	gauge("shawk_graph_collected_timestamp_seconds", "The time of the collection of the metrics of the graph.", m.Collected.Unix())
+
+	services := func(name, help string, counts map[string]int) {
+		keys := make([]string, 0, len(counts))
+		for k := range counts {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		fmt.Fprintf(bw, "# HELP %s %s\n# TYPE %s gauge\n", name, help, name)
+		for _, k := range keys {
+			fmt.Fprintf(bw, "%s{service=\"%s\"} %d\n", name, promLabel(k), counts[k])
+		}
+	}
+	services("shawk_service_fan_in", "The client services of the service.", m.FanIn)
+	services("shawk_service_fan_out", "The server services of the service.", m.FanOut)
+	return bw.Flush()
+}
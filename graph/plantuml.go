@@ -0,0 +1,45 @@
+package graph
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// plantUMLAlias returns the alias of the id, which PlantUML allows only
+// letters, digits and underscores in.
+func plantUMLAlias(prefix, id string) string {
+	return prefix + strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' {
+			return r
+		}
+		return '_'
+	}, id)
+}
+
+// plantUMLString escapes the double quotes of the string in PlantUML.
+func plantUMLString(s string) string {
+	return `"` + strings.ReplaceAll(s, `"`, `'`) + `"`
+}
+
+// RenderPlantUML writes the graph as a deployment diagram of PlantUML: the
+// hosts are the nodes, and the processes are the components in them.
+func RenderPlantUML(w io.Writer, g *Graph) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintln(bw, "@startuml")
+	fmt.Fprintln(bw, "left to right direction")
+	for _, h := range g.Hosts {
+		fmt.Fprintf(bw, "node %s as %s {\n", plantUMLString(h.Addr), plantUMLAlias("h_", h.Addr))
+		for _, c := range h.Components {
+			fmt.Fprintf(bw, "  component %s as %s\n", plantUMLString(c.Name()), plantUMLAlias("c_", c.ID))
+		}
+		fmt.Fprintln(bw, "}")
+	}
+	for _, e := range g.Edges {
+		fmt.Fprintf(bw, "%s --> %s : %s\n",
+			plantUMLAlias("c_", e.From.ID), plantUMLAlias("c_", e.To.ID), edgeLabel(e))
+	}
+	fmt.Fprintln(bw, "@enduml")
+	return bw.Flush()
+}
@@ -0,0 +1,105 @@
+package graph
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// serviceLabels are the keys of the labels naming the service of a
+// component in the order of the preference: the OpenTelemetry resource
+// attribute, and the services and the workloads of the enrichers.
+var serviceLabels = []string{"service.name", "k8s.service", "consul.service", "k8s.workload", "ecs.service", "ec2.name"}
+
+// Service returns the name of the service of the component, which is the
+// value of the first label of serviceLabels, or the name of the component.
+func (c *Component) Service() string {
+	for _, k := range serviceLabels {
+		if v := c.Labels[k]; v != "" {
+			return v
+		}
+	}
+	return c.Name()
+}
+
+// The connection types of the service graphs of Tempo.
+const (
+	connectionDatabase  = "database"
+	connectionMessaging = "messaging_system"
+	// connectionVirtual is the server which is not instrumented, such as
+	// the host which no shawk probes.
+	connectionVirtual = "virtual_node"
+)
+
+// The well-known servers of the connection types by their processes and
+// ports.
+var (
+	databaseProcesses = map[string]bool{
+		"postgres": true, "mysqld": true, "mariadbd": true, "redis-server": true,
+		"mongod": true, "memcached": true, "etcd": true, "clickhouse-server": true,
+	}
+	databasePorts = map[uint16]bool{
+		5432: true, 3306: true, 6379: true, 27017: true, 11211: true, 2379: true, 9042: true,
+	}
+	messagingProcesses = map[string]bool{"nats-server": true, "beam.smp": true, "mosquitto": true}
+	messagingPorts     = map[uint16]bool{9092: true, 5672: true, 4222: true, 1883: true}
+)
+
+// connectionType returns the connection_type of the edge, which is empty for
+// the calls between the services.
+func connectionType(e *Edge) string {
+	switch {
+	case databaseProcesses[e.To.Process] || databasePorts[e.Port]:
+		return connectionDatabase
+	case messagingProcesses[e.To.Process] || messagingPorts[e.Port]:
+		return connectionMessaging
+	case e.To.Process == "" && len(e.To.Labels) == 0:
+		return connectionVirtual
+	}
+	return ""
+}
+
+// promLabel escapes the value of a label of the Prometheus text format.
+func promLabel(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s)
+}
+
+// RenderServiceGraph writes the edges merged by the services as the metric
+// traces_service_graph_request_total of the service graphs of Grafana Tempo
+// and of the servicegraph connector of the OpenTelemetry Collector, in the
+// Prometheus text format. The values are the connections of the flows
+// rather than the requests, and aren't monotonic, so the metric merges the
+// topology into the service graphs of the traces, but not the rates.
+func RenderServiceGraph(w io.Writer, g *Graph) error {
+	type key struct{ client, server, connectionType string }
+	totals := map[key]int{}
+	for _, e := range g.Edges {
+		k := key{client: e.From.Service(), server: e.To.Service(), connectionType: connectionType(e)}
+		totals[k] += e.Connections
+	}
+	keys := make([]key, 0, len(totals))
+	for k := range totals {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		a, b := keys[i], keys[j]
+		if a.client != b.client {
+			return a.client < b.client
+		}
+		if a.server != b.server {
+			return a.server < b.server
+		}
+		return a.connectionType < b.connectionType
+	})
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintln(bw, "# HELP traces_service_graph_request_total The connections between the services observed by shawk.")
+	fmt.Fprintln(bw, "# TYPE traces_service_graph_request_total counter")
+	for _, k := range keys {
+		fmt.Fprintf(bw, "traces_service_graph_request_total{client=\"%s\",server=\"%s\",connection_type=\"%s\"} %d\n",
+			promLabel(k.client), promLabel(k.server), promLabel(k.connectionType), totals[k])
+	}
+	return bw.Flush()
+}
@@ -0,0 +1,169 @@
+package logging
+
+import (
+	"io/ioutil"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/xerrors"
+)
+
+// backupTimeFormat is the suffix of the rotated files, which sorts in time order.
+const backupTimeFormat = "20060102T150405.000"
+
+// RotatingFile is a log file rotated by its size or age. The rotated files
+// are renamed to '<path>.<time>', and the oldest ones beyond MaxBackups are
+// removed.
+type RotatingFile struct {
+	path string
+	// MaxSize is the size in bytes to rotate the file. Zero disables it.
+	MaxSize int64
+	// MaxAge is the age to rotate the file. Zero disables it.
+	MaxAge time.Duration
+	// MaxBackups is the number of the rotated files to keep. Zero keeps all.
+	MaxBackups int
+
+	mu     sync.Mutex
+	file   *os.File
+	size   int64
+	opened time.Time
+}
+
+// OpenFile opens the log file at path, appending to it if it exists.
+func OpenFile(path string) (*RotatingFile, error) {
+	f := &RotatingFile{path: path}
+	if err := f.open(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func (f *RotatingFile) open() error {
+	file, err := os.OpenFile(f.path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644)
+	if err != nil {
+		return xerrors.Errorf("could not open log file: %w", err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return xerrors.Errorf("could not stat log file: %w", err)
+	}
+	f.file, f.size, f.opened = file, info.Size(), time.Now()
+	return nil
+}
+
+// Write writes p into the file, rotating it beforehand if p exceeds
+// MaxSize or the file is older than MaxAge.
+func (f *RotatingFile) Write(p []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if (f.MaxSize > 0 && f.size > 0 && f.size+int64(len(p)) > f.MaxSize) ||
+		(f.MaxAge > 0 && time.Since(f.opened) > f.MaxAge) {
+		if err := f.rotate(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := f.file.Write(p)
+	f.size += int64(n)
+	return n, err
+}
+
+func (f *RotatingFile) rotate() error {
+	if err := f.file.Close(); err != nil {
+		return xerrors.Errorf("could not close log file: %w", err)
+	}
+	backup := f.path + "." + time.Now().Format(backupTimeFormat)
+	if err := os.Rename(f.path, backup); err != nil {
+		return xerrors.Errorf("could not rotate log file: %w", err)
+	}
+	if err := f.open(); err != nil {
+		return err
+	}
+	return f.prune()
+}
+
+// backups returns the files rotated by f, which are named by
+// backupTimeFormat, so that the other files such as the ones of logrotate
+// are left as they are.
+func (f *RotatingFile) backups() ([]string, error) {
+	dir, base := filepath.Split(f.path)
+	if dir == "" {
+		dir = "."
+	}
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var backups []string
+	for _, e := range entries {
+		name := e.Name()
+		if !strings.HasPrefix(name, base+".") || e.IsDir() {
+			continue
+		}
+		if _, err := time.Parse(backupTimeFormat, strings.TrimPrefix(name, base+".")); err != nil {
+			continue
+		}
+		backups = append(backups, filepath.Join(dir, name))
+	}
+	return backups, nil
+}
+
+// prune removes the oldest rotated files beyond MaxBackups.
+func (f *RotatingFile) prune() error {
+	if f.MaxBackups <= 0 {
+		return nil
+	}
+	backups, err := f.backups()
+	if err != nil {
+		return xerrors.Errorf("could not list rotated log files: %w", err)
+	}
+	if len(backups) <= f.MaxBackups {
+		return nil
+	}
+	sort.Strings(backups)
+	for _, b := range backups[:len(backups)-f.MaxBackups] {
+		if err := os.Remove(b); err != nil {
+			return xerrors.Errorf("could not remove rotated log file: %w", err)
+		}
+	}
+	return nil
+}
+
+// Reopen closes and opens the file again, so that the writes go to a new
+// file after an external tool such as logrotate has moved it.
+func (f *RotatingFile) Reopen() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.file.Close(); err != nil {
+		return xerrors.Errorf("could not close log file: %w", err)
+	}
+	return f.open()
+}
+
+// ReopenOn reopens the file whenever the process receives one of sigs.
+func (f *RotatingFile) ReopenOn(sigs ...os.Signal) {
+	sigch := make(chan os.Signal, 1)
+	signal.Notify(sigch, sigs...)
+	go func() {
+		for range sigch {
+			if err := f.Reopen(); err != nil {
+				// The logger may write into the closed file, so that the
+				// error is reported to stderr.
+				os.Stderr.WriteString(err.Error() + "\n")
+			}
+		}
+	}()
+}
+
+// Close closes the file.
+func (f *RotatingFile) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.file.Close()
+}
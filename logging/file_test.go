@@ -0,0 +1,82 @@
+package logging
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRotatingFile_size(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "shawk.log")
+	f, err := OpenFile(path)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	defer f.Close()
+	f.MaxSize = 10
+	f.MaxBackups = 2
+	// the files of the others are not pruned.
+	for _, name := range []string{path + ".1", path + ".gz", path + ".20201220T000000.000.gz"} {
+		if err := ioutil.WriteFile(name, nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, line := range []string{"line1\n", "line2\n", "line3\n", "line4\n"} {
+		if _, err := f.Write([]byte(line)); err != nil {
+			t.Fatalf("%+v", err)
+		}
+		// the rotated files are named by milliseconds.
+		time.Sleep(2 * time.Millisecond)
+	}
+
+	body, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if got := string(body); got != "line4\n" {
+		t.Errorf("the current file should only have the last line, but %q", got)
+	}
+	backups, err := f.backups()
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if len(backups) != 2 {
+		t.Errorf("the number of rotated files should be 2, but %d", len(backups))
+	}
+	for _, name := range []string{path + ".1", path + ".gz", path + ".20201220T000000.000.gz"} {
+		if _, err := os.Stat(name); err != nil {
+			t.Errorf("%s should not be pruned: %v", name, err)
+		}
+	}
+}
+
+func TestRotatingFile_Reopen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "shawk.log")
+	f, err := OpenFile(path)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	defer f.Close()
+
+	f.Write([]byte("before\n"))
+	// emulate logrotate
+	if err := os.Rename(path, path+".1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Reopen(); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	f.Write([]byte("after\n"))
+
+	body, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if got := string(body); strings.Contains(got, "before") || !strings.Contains(got, "after") {
+		t.Errorf("the reopened file should only have the writes after reopening, but %q", got)
+	}
+}
@@ -1,6 +1,11 @@
 package logging
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+
+	"golang.org/x/xerrors"
+)
 
 type level uint8
 
@@ -33,3 +38,13 @@ func (l level) String() string {
 	}
 	return fmt.Sprintf("level(%d)", l)
 }
+
+// ParseLevel parses the name of the log level such as "info" or "DEBUG".
+func ParseLevel(s string) (level, error) {
+	for lv := TRACE; lv <= FATAL; lv++ {
+		if strings.EqualFold(s, lv.String()) {
+			return lv, nil
+		}
+	}
+	return 0, xerrors.Errorf("unknown log level %q: it must be one of trace, debug, info, warning, error or fatal", s)
+}
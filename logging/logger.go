@@ -5,6 +5,7 @@ import (
 	"io"
 	"log"
 	"os"
+	"sync"
 )
 
 // Logger represents an logger for logging.
@@ -18,12 +19,18 @@ func New(tag string) *Logger {
 	return &Logger{tag: tag, stream: os.Stderr}
 }
 
-// global log level
-var logLv = INFO
+// global log level, which is guarded by lvMu since it is changed by
+// reloading the config.
+var (
+	logLv = INFO
+	lvMu  sync.RWMutex
+)
 var lgr = log.New(os.Stderr, "", log.LstdFlags)
 
 // SetLogLevel congigures log settings.
 func SetLogLevel(lv level) {
+	lvMu.Lock()
+	defer lvMu.Unlock()
 	if logLv != lv {
 		logLv = lv
 		if logLv <= DEBUG {
@@ -34,6 +41,13 @@ func SetLogLevel(lv level) {
 	}
 }
 
+// Enabled returns whether the logs of the level are written.
+func Enabled(lv level) bool {
+	lvMu.RLock()
+	defer lvMu.RUnlock()
+	return lv >= logLv
+}
+
 // SetOutput sets the output destination for the logger.
 func SetOutput(w io.Writer) {
 	lgr.SetOutput(w)
@@ -44,7 +58,7 @@ func (logger *Logger) message(lv level, message string) string {
 }
 
 func (logger *Logger) log(lv level, message string, args ...interface{}) {
-	if lv >= logLv {
+	if Enabled(lv) {
 		// caller -> Infof() -> log()
 		const depth = 3
 		err := lgr.Output(depth, fmt.Sprintf(logger.message(lv, message), args...))
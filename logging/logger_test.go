@@ -21,6 +21,17 @@ func TestSetLogLevel(t *testing.T) {
 	}
 }
 
+func TestParseLevel(t *testing.T) {
+	for _, s := range []string{"debug", "DEBUG", "Debug"} {
+		if lv, err := ParseLevel(s); err != nil || lv != DEBUG {
+			t.Errorf("ParseLevel(%q) should be DEBUG, but %v, %v", s, lv, err)
+		}
+	}
+	if _, err := ParseLevel("verbose"); err == nil {
+		t.Error("ParseLevel(\"verbose\") should return an error")
+	}
+}
+
 func TestLogf(t *testing.T) {
 	SetLogLevel(TRACE)
 
@@ -6,12 +6,19 @@ import (
 	"io"
 	"log"
 	"os"
+	"strings"
+	"time"
 
-	"github.com/joho/godotenv"
-
+	"github.com/yuuki/shawk/alert"
 	"github.com/yuuki/shawk/command"
 	"github.com/yuuki/shawk/config"
+	"github.com/yuuki/shawk/db"
+	"github.com/yuuki/shawk/graph"
 	"github.com/yuuki/shawk/logging"
+	"github.com/yuuki/shawk/probe"
+	"github.com/yuuki/shawk/probe/netlink/netutil"
+	"github.com/yuuki/shawk/secgroup"
+	"github.com/yuuki/shawk/siem"
 	"github.com/yuuki/shawk/statik"
 	"github.com/yuuki/shawk/version"
 )
@@ -47,7 +54,7 @@ func (c *CLI) Run(args []string) int {
 	}
 
 	if env := os.Getenv("SHAWK_ENV_FILE"); env != "" {
-		if err := godotenv.Load(env); err != nil {
+		if err := config.LoadEnvFile(env); err != nil {
 			log.Fatalf("Cannot start load config from env: %v\n", err)
 		}
 	}
@@ -57,8 +64,10 @@ func (c *CLI) Run(args []string) int {
 	}
 
 	var (
-		debug bool
-		help  bool
+		debug       bool
+		help        bool
+		showVersion bool
+		asJSON      bool
 	)
 	flags := flag.NewFlagSet("shawk", flag.ContinueOnError)
 	flags.SetOutput(c.errStream)
@@ -67,6 +76,8 @@ func (c *CLI) Run(args []string) int {
 	}
 	flags.BoolVar(&help, "help", false, "")
 	flags.BoolVar(&debug, "debug", false, "")
+	flags.BoolVar(&showVersion, "version", false, "")
+	flags.BoolVar(&asJSON, "json", false, "")
 	if err := flags.Parse(args[1:]); err != nil {
 		return exitCodeErr
 	}
@@ -75,7 +86,13 @@ func (c *CLI) Run(args []string) int {
 		printHelp(c.outStream)
 		return exitCodeOK
 	}
+	if showVersion {
+		return c.printVersion(args[0], asJSON)
+	}
 
+	// config.Load has validated the level.
+	lv, _ := logging.ParseLevel(config.Config.LogLevel)
+	logging.SetLogLevel(lv)
 	if debug {
 		logging.SetLogLevel(logging.DEBUG)
 	}
@@ -88,9 +105,56 @@ func (c *CLI) Run(args []string) int {
 		err = c.doProbe(args[2:])
 	case "create-scheme":
 		err = c.doCreateScheme(args[2:])
+	case "top":
+		err = c.doTop(args[2:])
+	case "serve":
+		err = c.doServe(args[2:])
+	case "export":
+		err = c.doExport(args[2:])
+	case "watch":
+		err = c.doWatch(args[2:])
+	case "secgroup":
+		err = c.doSecGroup(args[2:])
+	case "policy":
+		err = c.doPolicy(args[2:])
+	case "drift":
+		err = c.doDrift(args[2:])
+	case "listeners":
+		err = c.doListeners(args[2:])
+	case "helper":
+		err = c.doHelper(args[2:])
+	case "snapshot":
+		err = c.doSnapshot(args[2:])
+	case "annotate":
+		err = c.doAnnotate(args[2:])
+	case "agent-config":
+		err = c.doAgentConfig(args[2:])
+	case "agents":
+		err = c.doAgents(args[2:])
+	case "merge":
+		err = c.doMerge(args[2:])
+	case "sync":
+		err = c.doSync(args[2:])
+	case "delete":
+		err = c.doDelete(args[2:])
+	case "stats":
+		err = c.doStats(args[2:])
+	case "db":
+		err = c.doDB(args[2:])
+	case "demo":
+		err = c.doDemo(args[2:])
+	case "zeek":
+		err = c.doZeek(args[2:])
+	case "graph":
+		err = c.doGraph(args[2:])
 	case "version":
-		version.PrintVersion(c.errStream)
-		return exitCodeOK
+		flags := c.prepareFlags("version", versionHelpText)
+		flags.BoolVar(&asJSON, "json", false, "")
+		if err := parseFlags(flags, args[2:]); err != nil {
+			fmt.Fprintf(c.errStream, "%v\n", err)
+			return exitCodeErr
+		}
+		return c.printVersion(args[0], asJSON)
 	case "credits":
 		text, err := statik.FindString("/CREDITS")
 		if err != nil {
@@ -109,6 +173,9 @@ func (c *CLI) Run(args []string) int {
 
 	if err != nil {
 		fmt.Fprintf(c.errStream, "%+v\n", err)
+		if advice := netutil.Advice(err); advice != "" {
+			fmt.Fprintf(c.errStream, "hint: %s\n", advice)
+		}
 		return exitCodeErr
 	}
 
@@ -121,8 +188,30 @@ var helpText = `Usage: shawk [options]
 
 Commands:
   look           show dependencies starting from a specified node.
+  graph          write the dependency graph for diagram tools.
   probe          start agent for collecting flows and processes.
   create-scheme  create CMDB scheme.
+  top            show the flows of this host interactively.
+  serve          serve the GraphQL API of the dependency graph.
+  export         write all the flows in the CMDB as JSON lines.
+  watch          print the changes of the flows in the CMDB.
+  secgroup       recommend the security group rules of EC2 for the flows.
+  policy check   check the flows in the CMDB against a policy.
+  drift          compare the flows in the CMDB with the declared dependencies between the services.
+  listeners      report the listening ports of this host not in the allow list.
+  helper         serve the sockets and the processes of this host to the unprivileged agent.
+  snapshot       create or restore a snapshot of the flows in the CMDB.
+  annotate       annotate the edges with the notes of their reviews.
+  agent-config   set the settings of the agents in the CMDB.
+  agents         report the versions of the agents to track the rollout of their upgrades.
+  merge          merge the nodes of the previous address of a host into its current one.
+  sync           replicate the flows of another CMDB into the CMDB.
+  delete         delete the processes and the flows of the hosts or the ranges from the CMDB.
+  stats          report the overview of the graph and the storage of the CMDB.
+  db maintain    vacuum, analyze and reindex the tables of the CMDB.
+  db explain     print the plans of the main queries of the CMDB.
+  demo           write the flows of a synthetic topology into the CMDB.
+  zeek           write the flows of conn.log of Zeek into the CMDB.
 
   version        print version
   credits        print credits
@@ -131,11 +220,35 @@ Commands:
 Options:
   --help         print help
   --debug        enable debug logging
+  --version      print version, as JSON with --json
 
 Environs:
   SHAWK_ENV_FILE=/path/to/envfile
 `
 
+var versionHelpText = `
+Usage: shawk version [options]
+
+print the version and the build metadata.
+
+Options:
+  --json         print the build metadata including the Go version as JSON
+`
+
+// printVersion prints the version of the binary of the path, as JSON into
+// the stdout if asJSON.
+func (c *CLI) printVersion(path string, asJSON bool) int {
+	if !asJSON {
+		version.PrintVersion(c.errStream, path)
+		return exitCodeOK
+	}
+	if err := version.PrintVersionJSON(c.outStream, path); err != nil {
+		fmt.Fprintf(c.errStream, "%+v\n", err)
+		return exitCodeErr
+	}
+	return exitCodeOK
+}
+
 func printHelp(w io.Writer) {
 	fmt.Fprint(w, helpText)
 }
@@ -149,6 +262,18 @@ func (c *CLI) prepareFlags(name, help string) *flag.FlagSet {
 	return flags
 }
 
+// parseFlags parses the options, and rejects the arguments left, such as a
+// misspelled option after a positional word.
+func parseFlags(flags *flag.FlagSet, args []string) error {
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() > 0 {
+		return fmt.Errorf("unexpected arguments: %s", strings.Join(flags.Args(), " "))
+	}
+	return nil
+}
+
 var lookHelpText = `
 Usage: shawk look [options]
 
@@ -158,7 +283,18 @@ Options:
   --ipv4 ADDR              	filter flows regarding a specific ipv4 address as a root node
   --since                   filter flows since a specific date (relative duration such as '5m', '2h45m')
   --until                   filter flows until a specific date (relative duration such as '5m', '2h45m')
+  --direction DIRECTION     show only the 'passive' flows from the clients or the 'active' flows to the servers (default: all)
+  --process NAME            filter flows with the process of either side named NAME
+  --port PORT               filter flows to the servers of PORT
+  --min-connections N       filter flows with at least N connections
   --depth                   depth of dependency graph
+  --sort ORDER              sort the flows of each node by connections, peer, port or process (default: connections)
+  --limit N                 show at most N flows of each node (default: 0, which shows all)
+  --color auto|always|never colorize the output (default: auto, which colorizes it only on a terminal unless NO_COLOR is set)
+  --format FORMAT           'text' or 'template=TEMPLATE' of Go such as 'template={{.ActiveNode.Addr}} {{.Connections}}'
+                            executed for each flow (default: text)
+  --watch                   re-run the query every --interval, highlighting the flows appeared, disappeared or changed
+  --interval DURATION       interval to re-run the query with --watch (default: 5s)
 `
 
 const defaultDepth = 1
@@ -169,16 +305,54 @@ func (c *CLI) doLook(args []string) error {
 	flags.StringVar(&param.IPv4, "ipv4", "", "")
 	flags.StringVar(&param.Since, "since", "", "")
 	flags.StringVar(&param.Until, "until", "", "")
+	flags.StringVar(&param.Direction, "direction", command.DirectionAll, "")
+	flags.StringVar(&param.Process, "process", "", "")
+	flags.IntVar(&param.Port, "port", 0, "")
+	flags.IntVar(&param.MinConnections, "min-connections", 0, "")
 	flags.IntVar(&param.Depth, "depth", defaultDepth, "")
-	if err := flags.Parse(args); err != nil {
+	flags.StringVar(&param.Sort, "sort", probe.SortConnections, "")
+	flags.IntVar(&param.Limit, "limit", 0, "")
+	flags.StringVar(&param.Color, "color", command.ColorAuto, "")
+	flags.StringVar(&param.Format, "format", command.FormatText, "")
+	flags.BoolVar(&param.Watch, "watch", false, "")
+	flags.DurationVar(&param.Interval, "interval", 5*time.Second, "")
+	if err := parseFlags(flags, args); err != nil {
 		return err
 	}
+	return command.Look(&param)
+}
+
+var graphHelpText = `
+Usage: shawk graph [options]
+
+write the dependency graph around a specified node for diagram tools.
+
+Options:
+  --ipv4 ADDR               address of the root node
+  --depth N                 depth of dependency graph (default: 1)
+  --since                   filter flows since a specific date (relative duration such as '5m', '2h45m')
+  --until                   filter flows until a specific date (relative duration such as '5m', '2h45m')
+  --format FORMAT           dot for Graphviz, plantuml for a deployment diagram of PlantUML, graphml/gexf for Gephi,
+                            cytoscape for Cytoscape.js, d2 for D2, hcl for the locals of Terraform,
+                            servicegraph for the service graph metrics of Tempo, or 'template=TEMPLATE' of Go
+                            executed for each flow (default: dot)
+  --group-by KEY            group the hosts into the containers by 'subnet', 'subnet/N' such as 'subnet/16' (default: /24),
+                            or 'tag:KEY' such as 'tag:role' (dot and d2 only)
+`
 
-	if param.Depth <= 0 || param.Depth > command.MaxGraphDepth {
-		return fmt.Errorf("depth must be 0 < depth < %d, but specified %d",
-			command.MaxGraphDepth, param.Depth)
+func (c *CLI) doGraph(args []string) error {
+	var param command.GraphParam
+	flags := c.prepareFlags("graph", graphHelpText)
+	flags.StringVar(&param.IPv4, "ipv4", "", "")
+	flags.IntVar(&param.Depth, "depth", defaultDepth, "")
+	flags.StringVar(&param.Since, "since", "", "")
+	flags.StringVar(&param.Until, "until", "", "")
+	flags.StringVar(&param.Format, "format", graph.FormatDOT, "")
+	flags.StringVar(&param.GroupBy, "group-by", "", "")
+	if err := parseFlags(flags, args); err != nil {
+		return err
 	}
-	return command.Look(&param)
+	return command.Graph(&param)
 }
 
 var probeHelpText = `
@@ -188,14 +362,40 @@ start agent for collecting flows and processes.
 
 Options:
   --env
-  --once                    run once only if --mode='polling'
+  --once                    run once only if --mode='polling', and print the flows
+  --sort ORDER              sort the flows printed by --once by connections, peer, port or process (default: connections)
+  --limit N                 print at most N flows by --once (default: 0, which prints all)
+  --format FORMAT           format of the flows printed by --once or --replay: 'text' or 'template=TEMPLATE' of Go
+                            such as 'template={{.Peer.Addr}} {{.Connections}}' executed for each flow, or 'openmetrics'
+                            for the gauges of the OpenMetrics text format by --once (default: text)
+  --record DIR              write the raw sockets and processes of each scan as a snapshot under DIR, only if --mode='polling'
+  --replay DIR              print the flows of the snapshots recorded under DIR instead of scanning this host
+  --kubernetes              run as a Kubernetes DaemonSet and label flows with workload identities
+  --node-name NAME          label the endpoints of this host with the name
+  --node-ip ADDR            record the flows of this host under the address instead of the source address of each socket
+  --log-file PATH           write logs into the file instead of stderr, reopened on SIGUSR1
+  --log-max-size MB         rotate the log file when it exceeds the size (default: 100, 0 disables it)
+  --log-max-age DURATION    rotate the log file when it gets older than the duration such as '24h' (default: disabled)
+  --log-max-backups N       number of the rotated log files to keep (default: 5, 0 keeps all)
 `
 
 func (c *CLI) doProbe(args []string) error {
 	var param command.ProbeParam
 	flags := c.prepareFlags("probe", probeHelpText)
 	flags.BoolVar(&param.Once, "once", false, "")
-	if err := flags.Parse(args); err != nil {
+	flags.StringVar(&param.Sort, "sort", probe.SortConnections, "")
+	flags.IntVar(&param.Limit, "limit", 0, "")
+	flags.StringVar(&param.Format, "format", command.FormatText, "")
+	flags.StringVar(&param.Record, "record", "", "")
+	flags.StringVar(&param.Replay, "replay", "", "")
+	flags.BoolVar(&param.Kubernetes, "kubernetes", false, "")
+	flags.StringVar(&param.NodeName, "node-name", "", "")
+	flags.StringVar(&param.NodeIP, "node-ip", "", "")
+	flags.StringVar(&param.LogFile, "log-file", "", "")
+	flags.IntVar(&param.LogMaxSize, "log-max-size", 100, "")
+	flags.DurationVar(&param.LogMaxAge, "log-max-age", 0, "")
+	flags.IntVar(&param.LogMaxBackups, "log-max-backups", 5, "")
+	if err := parseFlags(flags, args); err != nil {
 		return err
 	}
 
@@ -206,13 +406,654 @@ var createSchemeHelpText = `
 Usage: shawk create-scheme [options]
 
 create CMDB scheme.
+
+Options:
+  --views                   also create the materialized views of the top talkers and the edges between the services
+  --with-roles              also create the roles shawk_reader of the queries and shawk_writer of the agents
 `
 
 func (c *CLI) doCreateScheme(args []string) error {
 	var param command.CreateSchemeParam
 	flags := c.prepareFlags("create-scheme", createSchemeHelpText)
-	if err := flags.Parse(args); err != nil {
+	flags.BoolVar(&param.Views, "views", false, "")
+	flags.BoolVar(&param.WithRoles, "with-roles", false, "")
+	if err := parseFlags(flags, args); err != nil {
 		return err
 	}
 	return command.CreateScheme(&param)
 }
+
+var topHelpText = `
+Usage: shawk top [options]
+
+show the flows of this host interactively, sorted by the connections.
+
+Options:
+  --interval DURATION       interval to refresh the flows (default: 2s)
+  --filter all|public|private
+                            filter the flows by the peer address (default: all)
+  -n, --numeric             show the addresses without resolving the hostnames
+
+Keys:
+  q                         quit
+  /                         filter the flows including the text
+  d                         switch the direction: all, active and passive
+  up, down, j, k            select a flow
+  enter                     drill into the peer of the selected flow
+  esc                       leave the peer or clear the filter
+  r                         refresh now
+`
+
+func (c *CLI) doTop(args []string) error {
+	param := command.TopParam{Filter: probe.FilterAll}
+	flags := c.prepareFlags("top", topHelpText)
+	flags.DurationVar(&param.Interval, "interval", 2*time.Second, "")
+	flags.StringVar(&param.Filter, "filter", probe.FilterAll, "")
+	flags.BoolVar(&param.Numeric, "n", false, "")
+	flags.BoolVar(&param.Numeric, "numeric", false, "")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+	return command.Top(&param)
+}
+
+var serveHelpText = `
+Usage: shawk serve [options]
+
+serve the GraphQL API of the dependency graph in the CMDB.
+
+Options:
+  --listen ADDR             address to listen on (default: 127.0.0.1:8000)
+  --refresh-views DURATION  refresh the materialized views created by 'create-scheme --views' every interval such as '5m' (default: 0, which doesn't)
+  --maintain DURATION       vacuum and analyze the tables of the CMDB every interval such as '24h' as 'db maintain' (default: 0, which doesn't)
+  --metrics-interval DURATION  collect the metrics of the graph on /metrics at most every interval (default: 1m, 0 collects them on each scrape)
+  --stale-after DURATION    count the processes without the flows updated for the duration as stale on /metrics (default: 24h)
+  --allow-origin ORIGINS    allow the pages of the comma-separated origins such as 'https://dashboard.example.com' to open the WebSocket of /changes (default: none, only the pages of the same host)
+
+Endpoints:
+  POST /graphql               run the GraphQL query of the JSON body
+  GET  /graphql?query=        run the GraphQL query of the parameter
+  GET  /graphql/schema        print the schema of the GraphQL API
+  GET  /api/v1/dependencies   list the flows of the servers of ?addr=
+  GET  /api/v1/dependents     list the flows of the clients of ?addr=
+  GET  /api/v1/paths          list the shortest paths between ?from= and ?to=
+  GET  /api/v1/talkers        list the client processes by the connections
+  GET  /api/v1/edges          list the edges between the services by the connections
+  GET  /api/v1/flows          list a page of all the flows after ?after=
+  GET  /changes               stream the changes of the flows as server-sent events or the WebSocket, filtered by ?type=
+  GET  /annotations           list the annotations of the edges
+  PUT  /annotations           annotate an edge with the note of the JSON body, or remove the annotation with an empty note
+  GET  /agents/versions       report the versions of the agents with a record in the last ?since= against ?latest=
+  GET  /openapi.json          print the OpenAPI spec of the REST API
+  GET  /metrics               print the metrics of the graph for Prometheus
+  GET  /metrics/servicegraph  print the edges between the services of the last ?since= as the service graph metrics of Tempo
+`
+
+func (c *CLI) doServe(args []string) error {
+	var param command.ServeParam
+	flags := c.prepareFlags("serve", serveHelpText)
+	flags.StringVar(&param.Listen, "listen", "127.0.0.1:8000", "")
+	flags.DurationVar(&param.RefreshViews, "refresh-views", 0, "")
+	flags.DurationVar(&param.Maintain, "maintain", 0, "")
+	flags.DurationVar(&param.MetricsInterval, "metrics-interval", time.Minute, "")
+	flags.DurationVar(&param.StaleAfter, "stale-after", 24*time.Hour, "")
+	flags.StringVar(&param.AllowOrigins, "allow-origin", "", "")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+	return command.Serve(&param)
+}
+
+var exportHelpText = `
+Usage: shawk export [options]
+
+write all the flows in the CMDB as JSON lines, querying them page by page.
+
+Options:
+  --since                   export flows since a specific date (relative duration such as '5m', '2h45m')
+  --until                   export flows until a specific date (relative duration such as '5m', '2h45m')
+  --page-size N             number of the flows queried at a time (default: 1000)
+  --syslog URL              send the flows to the syslog collector such as 'udp://127.0.0.1:514' or 'tcp://127.0.0.1:514' instead of stdout
+  --syslog-format cef|rfc5424  format of the syslog messages (default: cef)
+  --cong ALGORITHM          export only the flows running the congestion control algorithm such as 'bbr' on either side (default: all)
+  --compress none|gzip      compression of the flows written to stdout (default: none)
+`
+
+func (c *CLI) doExport(args []string) error {
+	var param command.ExportParam
+	flags := c.prepareFlags("export", exportHelpText)
+	flags.StringVar(&param.Since, "since", "", "")
+	flags.StringVar(&param.Until, "until", "", "")
+	flags.IntVar(&param.PageSize, "page-size", db.DefaultListLimit, "")
+	flags.StringVar(&param.Syslog, "syslog", "", "")
+	flags.StringVar(&param.SyslogFormat, "syslog-format", siem.FormatCEF, "")
+	flags.StringVar(&param.Cong, "cong", "", "")
+	flags.StringVar(&param.Compress, "compress", command.CompressNone, "")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+	return command.Export(&param)
+}
+
+var watchHelpText = `
+Usage: shawk watch [options]
+
+print the changes of the flows written into the CMDB until interrupted.
+
+Options:
+  --type TYPES              comma-separated types of the changes to print: added, removed or threshold (default: all)
+  --format text|json        format of the changes (default: text)
+  --alertmanager URLS       post the changes as the alerts to the comma-separated URLs of Alertmanager such as 'http://127.0.0.1:9093' (default: none)
+  --alert-resolve DURATION  resolve the alerts in Alertmanager after the duration (default: 1h)
+  --syslog URL              send the changes to the syslog collector such as 'udp://127.0.0.1:514' or 'tcp://127.0.0.1:514' (default: none)
+  --syslog-format cef|rfc5424  format of the syslog messages (default: cef)
+`
+
+func (c *CLI) doWatch(args []string) error {
+	var param command.WatchParam
+	flags := c.prepareFlags("watch", watchHelpText)
+	flags.StringVar(&param.Types, "type", "", "")
+	flags.StringVar(&param.Format, "format", command.FormatText, "")
+	flags.StringVar(&param.Alertmanager, "alertmanager", "", "")
+	flags.DurationVar(&param.AlertResolve, "alert-resolve", alert.DefaultResolveAfter, "")
+	flags.StringVar(&param.Syslog, "syslog", "", "")
+	flags.StringVar(&param.SyslogFormat, "syslog-format", siem.FormatCEF, "")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+	return command.Watch(&param)
+}
+
+var secgroupHelpText = `
+Usage: shawk secgroup [options]
+
+write the security group rules permitting exactly the flows between the network
+interfaces of EC2, one security group per set of the current security groups,
+and the current rules of TCP that no flow uses.
+
+Options:
+  --since                   use flows since a specific date (relative duration such as '5m', '2h45m')
+  --until                   use flows until a specific date (relative duration such as '5m', '2h45m')
+  --region REGION           region of EC2 (default: SHAWK_EC2_REGION or the region of this instance)
+  --format json|terraform   format of the rules (default: json)
+`
+
+func (c *CLI) doSecGroup(args []string) error {
+	var param command.SecGroupParam
+	flags := c.prepareFlags("secgroup", secgroupHelpText)
+	flags.StringVar(&param.Since, "since", "", "")
+	flags.StringVar(&param.Until, "until", "", "")
+	flags.StringVar(&param.Region, "region", "", "")
+	flags.StringVar(&param.Format, "format", secgroup.FormatJSON, "")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+	return command.SecGroup(&param)
+}
+
+var policyHelpText = `
+Usage: shawk policy check [options]
+
+check the flows in the CMDB against the rules of the policy, and exit with 1
+after printing the flows violating it.
+
+The policy consists of the lines of:
+  group NAME SELECTOR...                       name the nodes matching any of the selectors
+  allow|deny SOURCE -> DESTINATION [port PORTS] allow or deny the flows, the first matching rule winning
+  default allow|deny                           the action of the flows no rule matches (default: allow)
+
+SELECTOR is an address, a CIDR block, process:NAME, label:KEY=VALUE or *.
+
+Options:
+  --file FILE               file of the policy, or - for stdin
+  --since                   check flows since a specific date (relative duration such as '5m', '2h45m')
+  --until                   check flows until a specific date (relative duration such as '5m', '2h45m')
+  --format text|json        format of the violations (default: text)
+`
+
+func (c *CLI) doPolicy(args []string) error {
+	if len(args) == 0 || args[0] != "check" {
+		fmt.Fprint(c.errStream, policyHelpText)
+		return fmt.Errorf("policy needs the sub command: check")
+	}
+	var param command.PolicyCheckParam
+	flags := c.prepareFlags("policy check", policyHelpText)
+	flags.StringVar(&param.File, "file", "", "")
+	flags.StringVar(&param.Since, "since", "", "")
+	flags.StringVar(&param.Until, "until", "", "")
+	flags.StringVar(&param.Format, "format", command.FormatText, "")
+	if err := parseFlags(flags, args[1:]); err != nil {
+		return err
+	}
+	return command.PolicyCheck(&param)
+}
+
+var driftHelpText = `
+Usage: shawk drift [options]
+
+compare the flows in the CMDB merged by their services with the dependencies
+declared in the file, and exit with 1 after printing the undeclared flows, the
+declared dependencies without the flows, and the ones whose flows are only in
+the reverse direction.
+
+The file maps each client service to the servers it depends on in YAML:
+  web:
+    - api
+  api: [postgres, queue]
+
+The services are named by the labels service.name, k8s.service, consul.service,
+k8s.workload, ecs.service or ec2.name, or by the processes.
+
+Options:
+  --file FILE               file of the declared dependencies, or - for stdin
+  --since                   compare flows since a specific date (relative duration such as '5m', '2h45m')
+  --until                   compare flows until a specific date (relative duration such as '5m', '2h45m')
+  --format text|json        format of the drifts (default: text)
+`
+
+func (c *CLI) doDrift(args []string) error {
+	var param command.DriftParam
+	flags := c.prepareFlags("drift", driftHelpText)
+	flags.StringVar(&param.File, "file", "", "")
+	flags.StringVar(&param.Since, "since", "", "")
+	flags.StringVar(&param.Until, "until", "", "")
+	flags.StringVar(&param.Format, "format", command.FormatText, "")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+	return command.Drift(&param)
+}
+
+var listenersHelpText = `
+Usage: shawk listeners [options]
+
+print the listening ports of this host with the processes listening on them,
+as the lines of the allow list. With --allow, print only the listeners not
+allowed by the allow list, and exit with 1 if any.
+
+The allow list consists of the lines of:
+  PORTS [PROCESS...]                           allow the processes, or any process, to listen on the ports
+
+PORTS is a port or a range such as 8000-8080, and '#' starts a comment.
+
+Options:
+  --allow FILE              file of the allow list or the baseline, or - for stdin
+  --format text|json        format of the listeners (default: text)
+`
+
+func (c *CLI) doListeners(args []string) error {
+	var param command.ListenersParam
+	flags := c.prepareFlags("listeners", listenersHelpText)
+	flags.StringVar(&param.Allow, "allow", "", "")
+	flags.StringVar(&param.Format, "format", command.FormatText, "")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+	return command.Listeners(&param)
+}
+
+var helperHelpText = `
+Usage: shawk helper [options]
+
+dump the sockets by netlink and resolve their processes from /proc for the agent
+on the unix socket, so that 'shawk probe' runs without root or the capabilities
+with SHAWK_PROBE_HELPER_SOCKET set to the socket in the polling mode. The helper
+only inspects the kernel and /proc, and the agent aggregates the flows, resolves
+the names and writes into the CMDB.
+
+Options:
+  --socket PATH             unix socket to listen on (default: /run/shawk/helper.sock)
+  --user NAME               user of the agent permitted to connect to the socket (default: root only)
+`
+
+func (c *CLI) doHelper(args []string) error {
+	var param command.HelperParam
+	flags := c.prepareFlags("helper", helperHelpText)
+	flags.StringVar(&param.Socket, "socket", "/run/shawk/helper.sock", "")
+	flags.StringVar(&param.User, "user", "", "")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+	return command.Helper(&param)
+}
+
+var snapshotHelpText = `
+Usage: shawk snapshot create|restore [options]
+
+create a consistent and compressed snapshot of the processes, the nodes, the
+flows, the aliases of the nodes and the annotations of the edges in the CMDB, or
+replace them with a snapshot, such as before migrating the CMDB or to copy it
+into another environment.
+
+Options:
+  --file FILE               file of the snapshot, or - for stdout or stdin
+  --force                   restore the snapshot even if the CMDB has flows, which are removed
+`
+
+func (c *CLI) doSnapshot(args []string) error {
+	if len(args) == 0 || (args[0] != "create" && args[0] != "restore") {
+		fmt.Fprint(c.errStream, snapshotHelpText)
+		return fmt.Errorf("snapshot needs the sub command: create or restore")
+	}
+	var param command.SnapshotParam
+	flags := c.prepareFlags("snapshot "+args[0], snapshotHelpText)
+	flags.StringVar(&param.File, "file", "", "")
+	flags.BoolVar(&param.Force, "force", false, "")
+	if err := parseFlags(flags, args[1:]); err != nil {
+		return err
+	}
+	if args[0] == "create" {
+		if param.Force {
+			return fmt.Errorf("--force is only available for snapshot restore")
+		}
+		return command.SnapshotCreate(&param)
+	}
+	return command.SnapshotRestore(&param)
+}
+
+var dbHelpText = `
+Usage: shawk db maintain|explain [options]
+
+maintain: vacuum and analyze the tables of the CMDB, and report the dead rows of
+the tables and the scans of the indexes, so that the CMDB of a long-running
+installation doesn't slowly degrade.
+
+explain: print the plans of the main queries of the CMDB to see whether they use
+the indexes.
+
+Options of maintain:
+  --reindex                 also rebuild the indexes, which blocks the writes of the agents while rebuilding
+  --report-only             only report the tables and the indexes
+  --color auto|always|never colorize the output (default: auto, which colorizes it only on a terminal unless NO_COLOR is set)
+
+Options of explain:
+  --addr ADDR               address of the server the queries look up (default: the server of the latest flow)
+  --port PORT               port of the server the queries look up
+  --since                   query flows since a specific date (relative duration such as '5m', '2h45m')
+  --analyze                 run the queries to show the actual times and rows
+`
+
+func (c *CLI) doDB(args []string) error {
+	if len(args) > 0 && args[0] == "explain" {
+		var param command.DBExplainParam
+		flags := c.prepareFlags("db explain", dbHelpText)
+		flags.StringVar(&param.Addr, "addr", "", "")
+		flags.IntVar(&param.Port, "port", 0, "")
+		flags.StringVar(&param.Since, "since", "", "")
+		flags.BoolVar(&param.Analyze, "analyze", false, "")
+		if err := parseFlags(flags, args[1:]); err != nil {
+			return err
+		}
+		return command.DBExplain(&param)
+	}
+	if len(args) == 0 || args[0] != "maintain" {
+		fmt.Fprint(c.errStream, dbHelpText)
+		return fmt.Errorf("db needs the sub command: maintain or explain")
+	}
+	var param command.DBMaintainParam
+	flags := c.prepareFlags("db maintain", dbHelpText)
+	flags.BoolVar(&param.Reindex, "reindex", false, "")
+	flags.BoolVar(&param.ReportOnly, "report-only", false, "")
+	flags.StringVar(&param.Color, "color", command.ColorAuto, "")
+	if err := parseFlags(flags, args[1:]); err != nil {
+		return err
+	}
+	return command.DBMaintain(&param)
+}
+
+var annotateHelpText = `
+Usage: shawk annotate [options]
+
+annotate the edge from a client to the port of a server with a note of its
+review, such as "expected: backup traffic" or a ticket, which shawk look and the
+API show with the flows of the edge. The annotations are kept while the flows
+expire and are written again. The edges in a VRF are annotated with --vrf.
+
+Options:
+  --client ADDR             address of the client of the edge
+  --server ADDR             address of the server of the edge
+  --port PORT               port of the server of the edge
+  --vrf VRF                 VRF of the server of the edge (default: the default VRF)
+  --note NOTE               note of the edge, which replaces the previous one
+  --remove                  remove the annotation of the edge
+  --list                    list the annotations instead
+  --format FORMAT           output format of --list: 'text' or 'json' (default: text)
+  --color WHEN              colorize the output: 'auto', 'always' or 'never' (default: auto)
+`
+
+func (c *CLI) doAnnotate(args []string) error {
+	var param command.AnnotateParam
+	flags := c.prepareFlags("annotate", annotateHelpText)
+	flags.StringVar(&param.Client, "client", "", "")
+	flags.StringVar(&param.Server, "server", "", "")
+	flags.IntVar(&param.Port, "port", 0, "")
+	flags.StringVar(&param.VRF, "vrf", "", "")
+	flags.StringVar(&param.Note, "note", "", "")
+	flags.BoolVar(&param.Remove, "remove", false, "")
+	flags.BoolVar(&param.List, "list", false, "")
+	flags.StringVar(&param.Format, "format", command.FormatText, "")
+	flags.StringVar(&param.Color, "color", command.ColorAuto, "")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+	return command.Annotate(&param)
+}
+
+var agentConfigHelpText = `
+Usage: shawk agent-config [options]
+
+set a setting of the agents in the CMDB, which is an environment variable of
+shawk probe such as SHAWK_PROBE_IGNORE_PORTS. The agents apply the settings
+over their environment and the env file on start, and reload them every
+SHAWK_RELOAD_INTERVAL. The settings of connecting to the CMDB are only local.
+
+Options:
+  --host HOST               host of the agent of the setting, which overrides the one of all the agents (default: all the agents)
+  --set NAME=VALUE          set the setting such as 'SHAWK_PROBE_IGNORE_PORTS=9100'
+  --unset NAME              remove the setting, which reverts the agents to their local one
+  --list                    list the settings of all the hosts, or the ones applied to --host
+  --format FORMAT           output format of --list: 'text' or 'json' (default: text)
+  --color WHEN              colorize the output: 'auto', 'always' or 'never' (default: auto)
+`
+
+func (c *CLI) doAgentConfig(args []string) error {
+	var param command.AgentConfigParam
+	flags := c.prepareFlags("agent-config", agentConfigHelpText)
+	flags.StringVar(&param.Host, "host", "", "")
+	flags.StringVar(&param.Set, "set", "", "")
+	flags.StringVar(&param.Unset, "unset", "", "")
+	flags.BoolVar(&param.List, "list", false, "")
+	flags.StringVar(&param.Format, "format", command.FormatText, "")
+	flags.StringVar(&param.Color, "color", command.ColorAuto, "")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+	return command.AgentConfig(&param)
+}
+
+var agentsHelpText = `
+Usage: shawk agents [options]
+
+report the versions of the agents recorded in the CMDB, which the agents write
+with their scans, from the newest. The agents older than the latest version
+are outdated, where the latest version is the one published at --latest-url,
+or the newest version of the agents.
+
+Options:
+  --since                   only the agents with a record since a specific date (relative duration such as '5m', '2h45m') (default: 1h)
+  --latest-url URL          URL of the latest version published, such as 'https://api.github.com/repos/yuuki/shawk/releases/latest'
+  --format FORMAT           output format: 'text' or 'json' (default: text)
+  --color WHEN              colorize the output: 'auto', 'always' or 'never' (default: auto)
+`
+
+func (c *CLI) doAgents(args []string) error {
+	var param command.AgentsParam
+	flags := c.prepareFlags("agents", agentsHelpText)
+	flags.StringVar(&param.Since, "since", "1h", "")
+	flags.StringVar(&param.LatestURL, "latest-url", "", "")
+	flags.StringVar(&param.Format, "format", command.FormatText, "")
+	flags.StringVar(&param.Color, "color", command.ColorAuto, "")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+	return command.Agents(&param)
+}
+
+var mergeHelpText = `
+Usage: shawk merge [options]
+
+merge the processes, the nodes and the flows of the previous address of a host,
+which changed by DHCP or re-provisioning, into its current address in a
+transaction, so that the history of its dependencies continues. The previous
+address is recorded as an alias, which shawk look follows.
+
+Options:
+  --from ADDR               previous address of the host
+  --to ADDR                 current address of the host
+  --dry-run                 only count the processes and the flows to merge
+`
+
+func (c *CLI) doMerge(args []string) error {
+	var param command.MergeParam
+	flags := c.prepareFlags("merge", mergeHelpText)
+	flags.StringVar(&param.From, "from", "", "")
+	flags.StringVar(&param.To, "to", "", "")
+	flags.BoolVar(&param.DryRun, "dry-run", false, "")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+	return command.Merge(&param)
+}
+
+var syncHelpText = `
+Usage: shawk sync [options]
+
+replicate the flows updated since the previous sync with their processes from
+a CMDB into another, such as from the CMDBs of the regions into a global one.
+The processes are identified by their addresses, tenants, VRFs, process groups
+and names, and the flows updated later in the destination are kept.
+
+Options:
+  --from URL                URL of the source CMDB
+  --to URL                  URL of the destination CMDB
+  --full                    sync all the flows instead of the ones updated since the previous sync
+  --page-size N             number of the flows synced in a transaction (default: 1000)
+`
+
+func (c *CLI) doSync(args []string) error {
+	var param command.SyncParam
+	flags := c.prepareFlags("sync", syncHelpText)
+	flags.StringVar(&param.From, "from", "", "")
+	flags.StringVar(&param.To, "to", "", "")
+	flags.BoolVar(&param.Full, "full", false, "")
+	flags.IntVar(&param.PageSize, "page-size", db.DefaultListLimit, "")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+	return command.Sync(&param)
+}
+
+var deleteHelpText = `
+Usage: shawk delete [options]
+
+delete the processes of the decommissioned hosts or the reclaimed address ranges
+with their nodes and flows from the CMDB in a transaction, and list them. The
+processes are of the tenant, or of all the tenants with SHAWK_CMDB_ALL_TENANTS.
+
+Options:
+  --host ADDRS              comma-separated addresses of the hosts
+  --cidr CIDRS              comma-separated address ranges such as '10.0.1.0/24'
+  --dry-run                 only list the processes to delete
+  --color auto|always|never colorize the output (default: auto, which colorizes it only on a terminal unless NO_COLOR is set)
+`
+
+func (c *CLI) doDelete(args []string) error {
+	var param command.DeleteParam
+	flags := c.prepareFlags("delete", deleteHelpText)
+	flags.StringVar(&param.Hosts, "host", "", "")
+	flags.StringVar(&param.CIDRs, "cidr", "", "")
+	flags.BoolVar(&param.DryRun, "dry-run", false, "")
+	flags.StringVar(&param.Color, "color", command.ColorAuto, "")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+	return command.Delete(&param)
+}
+
+var statsHelpText = `
+Usage: shawk stats [options]
+
+report the numbers of the processes, the nodes and the flows in the CMDB with
+the ones created in the last day and week, the oldest and the newest flows, the
+hosts of the most edges, and the storage of the tables, as a quick overview of
+the graph without psql.
+
+Options:
+  --top N                   number of the hosts of the most edges (default: 10)
+  --format text|json        format of the report (default: text)
+  --color auto|always|never colorize the output (default: auto, which colorizes it only on a terminal unless NO_COLOR is set)
+`
+
+func (c *CLI) doStats(args []string) error {
+	var param command.StatsParam
+	flags := c.prepareFlags("stats", statsHelpText)
+	flags.IntVar(&param.Top, "top", 10, "")
+	flags.StringVar(&param.Format, "format", command.FormatText, "")
+	flags.StringVar(&param.Color, "color", command.ColorAuto, "")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+	return command.Stats(&param)
+}
+
+var demoHelpText = `
+Usage: shawk demo [options]
+
+write the flows of a synthetic topology of a frontend, applications and data
+stores into the CMDB, as the agents on its hosts would, to evaluate the queries,
+the API and the performance without deploying the agents.
+
+Options:
+  --hosts N                 number of the hosts (default: 20)
+  --services N              number of the services, at most 250 (default: 8)
+  --seed N                  seed of the topology and the connections (default: 1)
+  --interval DURATION       write the flows again every DURATION until interrupted (default: 0, which writes them once)
+`
+
+func (c *CLI) doDemo(args []string) error {
+	var param command.DemoParam
+	flags := c.prepareFlags("demo", demoHelpText)
+	flags.IntVar(&param.Hosts, "hosts", 20, "")
+	flags.IntVar(&param.Services, "services", 8, "")
+	flags.Int64Var(&param.Seed, "seed", 1, "")
+	flags.DurationVar(&param.Interval, "interval", 0, "")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+	return command.Demo(&param)
+}
+
+var zeekHelpText = `
+Usage: shawk zeek [options]
+
+write the flows of the established TCP connections of conn.log of Zeek into the
+CMDB, aggregated by the clients and the ports of the servers, so that the hosts
+seen on a network tap but running no agent are in the graph.
+
+Options:
+  --file PATH               conn.log in the TSV or JSON format, or its archive ending with .gz
+  --follow                  keep reading the lines appended to the file across the rotations until interrupted
+  --interval DURATION       write the flows read with --follow every DURATION (default: 1m)
+`
+
+func (c *CLI) doZeek(args []string) error {
+	var param command.ZeekParam
+	flags := c.prepareFlags("zeek", zeekHelpText)
+	flags.StringVar(&param.File, "file", "", "")
+	flags.BoolVar(&param.Follow, "follow", false, "")
+	flags.DurationVar(&param.Interval, "interval", time.Minute, "")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+	return command.Zeek(&param)
+}
@@ -2,7 +2,9 @@ package main
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
+	"runtime"
 	"strings"
 	"testing"
 
@@ -25,6 +27,26 @@ func TestRun_version(t *testing.T) {
 	}
 }
 
+func TestRun_versionJSON(t *testing.T) {
+	for _, args := range []string{"shawk --version --json", "shawk version --json"} {
+		outStream, errStream := new(bytes.Buffer), new(bytes.Buffer)
+		cli := &CLI{outStream: outStream, errStream: errStream}
+
+		status := cli.Run(strings.Split(args, " "))
+		if status != exitCodeOK {
+			t.Errorf("%q should exit with %d, but %d: %s", args, exitCodeOK, status, errStream)
+		}
+
+		var info version.Info
+		if err := json.Unmarshal(outStream.Bytes(), &info); err != nil {
+			t.Fatalf("%q should print JSON: %v", args, err)
+		}
+		if info.Name != "shawk" || info.Version != version.GetVersion() || info.GoVersion != runtime.Version() {
+			t.Errorf("%q should print the build metadata, but %+v", args, info)
+		}
+	}
+}
+
 func TestRun_parseError(t *testing.T) {
 	outStream, errStream := new(bytes.Buffer), new(bytes.Buffer)
 	cli := &CLI{outStream: outStream, errStream: errStream}
@@ -72,3 +94,19 @@ func TestRun_lookError(t *testing.T) {
 		t.Errorf("expected %q to contain %q", expected, errStream.String())
 	}
 }
+
+func TestRun_unexpectedArguments(t *testing.T) {
+	outStream, errStream := new(bytes.Buffer), new(bytes.Buffer)
+	cli := &CLI{outStream: outStream, errStream: errStream}
+	args := strings.Split("shawk look 10.0.0.10", " ")
+
+	status := cli.Run(args)
+	if status != exitCodeErr {
+		t.Errorf("expected %d to eq %d", status, exitCodeErr)
+	}
+
+	expected := "unexpected arguments: 10.0.0.10"
+	if !strings.Contains(errStream.String(), expected) {
+		t.Errorf("expected %q to contain %q", expected, errStream.String())
+	}
+}
@@ -0,0 +1,234 @@
+package client
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"golang.org/x/xerrors"
+
+	"github.com/yuuki/shawk/db"
+	"github.com/yuuki/shawk/probe"
+)
+
+// fakeFinder finds the flows among its flows instead of the CMDB.
+type fakeFinder struct {
+	db.Store
+	flows   []*db.Flow
+	queries int
+}
+
+func (f *fakeFinder) find(cond *db.FindFlowsCond, node func(*db.Flow) *db.Node) db.Flows {
+	f.queries++
+	flows := db.Flows{}
+	for _, fl := range f.flows {
+		for _, addr := range cond.Addrs {
+			if n := node(fl); n.IPAddr.Equal(addr) {
+				key := n.IPAddr.String() + "-" + n.Pname
+				flows[key] = append(flows[key], fl)
+			}
+		}
+	}
+	return flows
+}
+
+func (f *fakeFinder) FindPassiveFlows(cond *db.FindFlowsCond) (db.Flows, error) {
+	return f.find(cond, func(fl *db.Flow) *db.Node { return fl.PassiveNode }), nil
+}
+
+func (f *fakeFinder) FindActiveFlows(cond *db.FindFlowsCond) (db.Flows, error) {
+	return f.find(cond, func(fl *db.Flow) *db.Node { return fl.ActiveNode }), nil
+}
+
+func flow(active, passive string, port uint16) *db.Flow {
+	return &db.Flow{
+		ActiveNode:  &db.Node{IPAddr: net.ParseIP(active).To4(), Aggregated: true, Pname: "client"},
+		PassiveNode: &db.Node{IPAddr: net.ParseIP(passive).To4(), Port: port, Pname: "server"},
+		Connections: 1,
+	}
+}
+
+func TestClient_Query(t *testing.T) {
+	finder := &fakeFinder{flows: []*db.Flow{
+		flow("10.0.0.2", "10.0.0.1", 80),   // a client of the address
+		flow("10.0.0.5", "10.0.0.2", 8080), // a client of the client
+		flow("10.0.0.1", "10.0.0.3", 5432), // a server of the address
+		flow("10.0.0.3", "10.0.0.4", 6379), // a server of the server
+		flow("10.0.0.3", "10.0.0.1", 80),   // a cycle
+	}}
+	c := NewWithStore(finder)
+
+	type dep struct {
+		Active, Passive string
+		Direction       probe.FlowDirection
+		Depth           int
+	}
+	summarize := func(deps []*Dependency) []dep {
+		var got []dep
+		for _, d := range deps {
+			got = append(got, dep{d.ActiveNode.IPAddr.String(), d.PassiveNode.IPAddr.String(), d.Direction, d.Depth})
+		}
+		return got
+	}
+
+	tests := []struct {
+		depth int
+		want  []dep
+	}{
+		{1, []dep{
+			{"10.0.0.2", "10.0.0.1", probe.FlowPassive, 1},
+			{"10.0.0.3", "10.0.0.1", probe.FlowPassive, 1},
+			{"10.0.0.1", "10.0.0.3", probe.FlowActive, 1},
+		}},
+		{2, []dep{
+			{"10.0.0.2", "10.0.0.1", probe.FlowPassive, 1},
+			{"10.0.0.3", "10.0.0.1", probe.FlowPassive, 1},
+			{"10.0.0.5", "10.0.0.2", probe.FlowPassive, 2},
+			{"10.0.0.1", "10.0.0.3", probe.FlowPassive, 2},
+			{"10.0.0.3", "10.0.0.4", probe.FlowActive, 2},
+		}},
+	}
+	for _, tt := range tests {
+		got, err := c.Query(context.Background(), &Query{Addr: net.ParseIP("10.0.0.1"), Depth: tt.depth})
+		if err != nil {
+			t.Fatalf("Query() should not return an error: %+v", err)
+		}
+		if diff := cmp.Diff(tt.want, summarize(got)); diff != "" {
+			t.Errorf("Query(depth=%d) mismatch (-want +got):\n%s", tt.depth, diff)
+		}
+	}
+
+	// Only the servers of the address
+	got, err := c.Query(context.Background(), &Query{Addr: net.ParseIP("10.0.0.1"), Depth: 2, Direction: probe.FlowActive})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []dep{
+		{"10.0.0.1", "10.0.0.3", probe.FlowActive, 1},
+		{"10.0.0.3", "10.0.0.1", probe.FlowActive, 2},
+		{"10.0.0.3", "10.0.0.4", probe.FlowActive, 2},
+	}
+	if diff := cmp.Diff(want, summarize(got)); diff != "" {
+		t.Errorf("Query(active) mismatch (-want +got):\n%s", diff)
+	}
+
+	// The walk stops when no new node is found.
+	finder.queries = 0
+	if _, err := c.Query(context.Background(), &Query{Addr: net.ParseIP("10.0.0.1"), Depth: MaxDepth}); err != nil {
+		t.Fatal(err)
+	}
+	if finder.queries != 6 {
+		t.Errorf("Query() should query 6 times, but %d", finder.queries)
+	}
+}
+
+func TestClient_Query_invalid(t *testing.T) {
+	c := NewWithStore(&fakeFinder{})
+	for _, q := range []*Query{
+		{Addr: net.ParseIP("fe80::1"), Depth: 1},
+		{Addr: net.ParseIP("10.0.0.1"), Depth: 0},
+		{Addr: net.ParseIP("10.0.0.1"), Depth: MaxDepth + 1},
+		{Addr: net.ParseIP("10.0.0.1"), Depth: 1, Direction: probe.FlowUnknown},
+	} {
+		if _, err := c.Query(context.Background(), q); err == nil {
+			t.Errorf("Query(%+v) should return an error", q)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := c.Dependencies(ctx, net.ParseIP("10.0.0.1"), 1); !xerrors.Is(err, context.Canceled) {
+		t.Errorf("Dependencies() should return the error of ctx, but %v", err)
+	}
+}
+
+func TestClient_Paths(t *testing.T) {
+	c := NewWithStore(&fakeFinder{flows: []*db.Flow{
+		flow("10.0.0.1", "10.0.0.2", 80),
+		flow("10.0.0.1", "10.0.0.3", 80),
+		flow("10.0.0.2", "10.0.0.4", 5432),
+		flow("10.0.0.3", "10.0.0.4", 5432),
+		flow("10.0.0.4", "10.0.0.5", 6379),
+	}})
+	pathStrings := func(paths [][]*Flow) []string {
+		var ss []string
+		for _, p := range paths {
+			s := p[0].ActiveNode.IPAddr.String()
+			for _, f := range p {
+				s += " -> " + f.PassiveNode.IPAddr.String()
+			}
+			ss = append(ss, s)
+		}
+		return ss
+	}
+
+	tests := []struct {
+		to    string
+		depth int
+		want  []string
+	}{
+		{"10.0.0.4", 2, []string{"10.0.0.1 -> 10.0.0.2 -> 10.0.0.4", "10.0.0.1 -> 10.0.0.3 -> 10.0.0.4"}},
+		{"10.0.0.5", 3, []string{"10.0.0.1 -> 10.0.0.2 -> 10.0.0.4 -> 10.0.0.5", "10.0.0.1 -> 10.0.0.3 -> 10.0.0.4 -> 10.0.0.5"}},
+		{"10.0.0.5", 2, nil},
+		{"10.0.0.9", MaxDepth, nil},
+	}
+	for _, tt := range tests {
+		paths, err := c.Paths(context.Background(), &Query{Addr: net.ParseIP("10.0.0.1"), Depth: tt.depth}, net.ParseIP(tt.to))
+		if err != nil {
+			t.Fatalf("Paths() should not return an error: %+v", err)
+		}
+		if diff := cmp.Diff(tt.want, pathStrings(paths)); diff != "" {
+			t.Errorf("Paths(%s, %d) mismatch (-want +got):\n%s", tt.to, tt.depth, diff)
+		}
+	}
+}
+
+// pagedStore lists its flows by their IDs.
+type pagedStore struct {
+	db.Store
+	flows []*db.Flow
+}
+
+func (s *pagedStore) ListFlows(cond *db.ListFlowsCond) ([]*db.Flow, error) {
+	var page []*db.Flow
+	for _, f := range s.flows {
+		if f.ID > cond.After && len(page) < cond.Limit {
+			page = append(page, f)
+		}
+	}
+	return page, nil
+}
+
+func TestClient_ListFlows(t *testing.T) {
+	s := &pagedStore{}
+	for i, addr := range []string{"10.0.0.2", "10.0.0.3", "10.0.0.4"} {
+		f := flow("10.0.0.1", addr, 80)
+		f.ID = int64(i + 1)
+		s.flows = append(s.flows, f)
+	}
+	c := NewWithStore(s)
+
+	var got []int64
+	var after int64
+	for {
+		flows, next, err := c.ListFlows(context.Background(), &Page{After: after, Limit: 2})
+		if err != nil {
+			t.Fatalf("ListFlows() should not return an error: %v", err)
+		}
+		for _, f := range flows {
+			got = append(got, f.ID)
+		}
+		if next == 0 {
+			break
+		}
+		after = next
+	}
+	if diff := cmp.Diff([]int64{1, 2, 3}, got); diff != "" {
+		t.Errorf("ListFlows() mismatch (-want +got):\n%s", diff)
+	}
+
+	if _, _, err := c.ListFlows(context.Background(), &Page{After: -1}); err == nil {
+		t.Error("ListFlows() should return an error for a negative cursor")
+	}
+}
@@ -0,0 +1,27 @@
+// +build linux
+
+package probe
+
+import (
+	"github.com/yuuki/shawk/probe/ebpf"
+	"github.com/yuuki/shawk/probe/netlink"
+)
+
+// trace traces the connections with eBPF until ctx is done.
+var trace = ebpf.StartTracer
+
+func collect(opt *Options) ([]*HostFlow, error) {
+	mapFlows, err := netlink.GetHostFlows(&netlink.GetHostFlowsOption{
+		Numeric:   opt.Numeric,
+		Processes: opt.Processes,
+		Filter:    opt.Filter,
+	})
+	if err != nil {
+		return nil, err
+	}
+	flows := make([]*HostFlow, 0, len(mapFlows))
+	for _, f := range mapFlows {
+		flows = append(flows, f)
+	}
+	return flows, nil
+}
@@ -0,0 +1,18 @@
+// +build !linux
+
+package probe
+
+import (
+	"context"
+
+	"golang.org/x/xerrors"
+)
+
+// trace traces the connections with eBPF until ctx is done.
+var trace = func(ctx context.Context, cb func(*HostFlow)) error {
+	return xerrors.New("not supported on this platform")
+}
+
+func collect(opt *Options) ([]*HostFlow, error) {
+	return nil, xerrors.New("not supported on this platform")
+}
@@ -0,0 +1,122 @@
+// Package probe is the stable API to collect the flows of the host from Go
+// programs, which embed the collection of shawk instead of running the shawk
+// binary.
+//
+// The identifiers of this package follow semantic versioning: they are not
+// removed or changed incompatibly within a major version of shawk. The other
+// packages of shawk are internal to the commands and may change at any time.
+//
+//	flows, err := probe.Collect(ctx, &probe.Options{Processes: true})
+//	if err != nil {
+//		return err
+//	}
+//	for _, f := range flows {
+//		fmt.Println(f)
+//	}
+package probe
+
+import (
+	"context"
+	"time"
+
+	"golang.org/x/xerrors"
+
+	"github.com/yuuki/shawk/enricher"
+	"github.com/yuuki/shawk/probe"
+)
+
+// HostFlow is a flow between a local endpoint and a peer of the host, which
+// aggregates the connections by the port of the passive side.
+type HostFlow = probe.HostFlow
+
+// AddrPort is an endpoint of a flow.
+type AddrPort = probe.AddrPort
+
+// Process is the process owning the local endpoint of a flow.
+type Process = probe.Process
+
+// FlowDirection is the direction of a flow seen from the host.
+type FlowDirection = probe.FlowDirection
+
+// Enricher labels the endpoints of the flows, such as with the identities
+// of the workloads.
+type Enricher = enricher.Enricher
+
+const (
+	// FlowActive is the flow connected from the host.
+	FlowActive = probe.FlowActive
+	// FlowPassive is the flow accepted by the host.
+	FlowPassive = probe.FlowPassive
+)
+
+// The filters of the flows by the address of the peer.
+const (
+	// FilterAll collects the flows of all the peers.
+	FilterAll = probe.FilterAll
+	// FilterPublic collects the flows of the peers of public addresses.
+	FilterPublic = probe.FilterPublic
+	// FilterPrivate collects the flows of the peers of private addresses.
+	FilterPrivate = probe.FilterPrivate
+)
+
+// Options is the options of Collect and Watch. The zero value collects the
+// flows of all the peers without their processes.
+type Options struct {
+	// Numeric does not resolve the names of the peers.
+	Numeric bool
+	// Processes resolves the processes of the local endpoints, which
+	// requires root to resolve the processes of the other users.
+	Processes bool
+	// Filter is one of FilterAll, FilterPublic and FilterPrivate.
+	// Empty is FilterAll.
+	Filter string
+	// Enrichers label the flows in order. A failure of an enricher is
+	// logged, and the flows are returned without its labels.
+	Enrichers []Enricher
+}
+
+func (o *Options) validate() error {
+	switch o.Filter {
+	case "", FilterAll, FilterPublic, FilterPrivate:
+		return nil
+	}
+	return xerrors.Errorf("unknown filter %q", o.Filter)
+}
+
+// Collect collects the flows of the host once, sorted by the peers.
+// opt may be nil.
+func Collect(ctx context.Context, opt *Options) ([]*HostFlow, error) {
+	if opt == nil {
+		opt = &Options{}
+	}
+	if err := opt.validate(); err != nil {
+		return nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	flows, err := collect(opt)
+	if err != nil {
+		return nil, xerrors.Errorf("could not collect the flows: %w", err)
+	}
+	enricher.Chain(opt.Enrichers).Apply(flows)
+	probe.SortFlows(flows, probe.SortPeer)
+	return flows, nil
+}
+
+// Watch collects the flows every interval until ctx is done, and calls fn
+// with the flows or the error of each collection. It returns the error of
+// ctx, or an error if the interval or opt is invalid. See Watcher for the
+// changes of the flows.
+func Watch(ctx context.Context, interval time.Duration, opt *Options, fn func([]*HostFlow, error)) error {
+	w := NewWatcher(interval, opt)
+	batches, err := w.Start(ctx)
+	if err != nil {
+		return err
+	}
+	defer w.Stop()
+	for b := range batches {
+		fn(b.Flows, b.Err)
+	}
+	return ctx.Err()
+}
@@ -0,0 +1,121 @@
+// +build linux
+
+package probe
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/yuuki/shawk/probe/netlink/netutil"
+)
+
+func useFakeInetDiag(t *testing.T) {
+	d, err := netutil.LoadFakeInetDiag(filepath.Join("..", "..", "probe", "netlink", "testdata", "inetdiag"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	prev := netutil.CurrentInetDiag()
+	netutil.SetInetDiag(d)
+	t.Cleanup(func() { netutil.SetInetDiag(prev) })
+}
+
+type labelEnricher struct{}
+
+func (labelEnricher) Name() string { return "label" }
+
+func (labelEnricher) Enrich(flows []*HostFlow) error {
+	for _, f := range flows {
+		f.Local.SetLabel("env", "test")
+	}
+	return nil
+}
+
+func TestCollect(t *testing.T) {
+	useFakeInetDiag(t)
+
+	flows, err := Collect(context.Background(), &Options{
+		Numeric:   true,
+		Filter:    FilterPublic,
+		Enrichers: []Enricher{labelEnricher{}},
+	})
+	if err != nil {
+		t.Fatalf("Collect() should not return an error: %+v", err)
+	}
+	var got []string
+	for _, f := range flows {
+		got = append(got, f.String())
+		if f.Local.Labels["env"] != "test" {
+			t.Errorf("the enricher should label the flow: %s", f)
+		}
+	}
+	want := []string{
+		"10.0.0.1:many\t-->\t198.51.100.7:443\t1",
+		"10.0.0.1:80\t<--\t203.0.113.5:many\t1",
+		"[2001:db8::1]:8080\t<--\t[2001:db8::2]:many\t1",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Collect() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestCollect_invalid(t *testing.T) {
+	if _, err := Collect(context.Background(), &Options{Filter: "lan"}); err == nil {
+		t.Error("Collect() should return an error for an unknown filter")
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := Collect(ctx, nil); err != context.Canceled {
+		t.Errorf("Collect() should return the error of ctx, but %v", err)
+	}
+}
+
+func TestWatch(t *testing.T) {
+	useFakeInetDiag(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var calls int
+	err := Watch(ctx, time.Millisecond, &Options{Numeric: true}, func(flows []*HostFlow, err error) {
+		if err != nil {
+			t.Errorf("Watch() should not pass an error: %+v", err)
+		}
+		if len(flows) == 0 {
+			t.Error("Watch() should pass the flows")
+		}
+		if calls++; calls == 3 {
+			cancel()
+		}
+	})
+	if err != context.Canceled {
+		t.Errorf("Watch() should return the error of ctx, but %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("fn should be called 3 times, but %d", calls)
+	}
+}
+
+func TestWatcher_polling(t *testing.T) {
+	useFakeInetDiag(t)
+
+	w := NewWatcher(time.Millisecond, &Options{Numeric: true, Filter: FilterPublic})
+	batches, err := w.Start(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Stop()
+
+	first, second := <-batches, <-batches
+	if first.Err != nil || second.Err != nil {
+		t.Fatalf("the batches should not have an error: %v, %v", first.Err, second.Err)
+	}
+	if len(first.Events) != len(first.Flows) || len(first.Flows) != 3 {
+		t.Errorf("the first batch should add all the 3 flows: %d flows, %d events", len(first.Flows), len(first.Events))
+	}
+	if len(second.Events) != 0 {
+		t.Errorf("the second batch should have no changes, but %d", len(second.Events))
+	}
+}
@@ -0,0 +1,280 @@
+package probe
+
+import (
+	"context"
+	"net"
+	"sync"
+	"time"
+
+	"golang.org/x/xerrors"
+
+	"github.com/yuuki/shawk/enricher"
+	"github.com/yuuki/shawk/probe"
+	"github.com/yuuki/shawk/probe/netlink/netutil"
+)
+
+// EventType is the type of the change of a flow.
+type EventType int
+
+const (
+	// EventAdded is a flow not seen in the previous batch.
+	EventAdded EventType = iota + 1
+	// EventChanged is a flow whose connections or process have changed.
+	EventChanged
+	// EventRemoved is a flow disappeared since the previous batch. The
+	// streaming watcher does not remove flows because the tracer does not
+	// report the closes of the connections.
+	EventRemoved
+)
+
+// String returns string representation.
+func (t EventType) String() string {
+	switch t {
+	case EventAdded:
+		return "added"
+	case EventChanged:
+		return "changed"
+	case EventRemoved:
+		return "removed"
+	}
+	return "unknown"
+}
+
+// Event is a change of a flow between the batches.
+type Event struct {
+	Type EventType
+	// Flow is the flow of the batch, or the last one seen if removed.
+	Flow *HostFlow
+}
+
+// Batch is the result of a collection of the Watcher.
+type Batch struct {
+	// Time is when the batch is collected.
+	Time time.Time
+	// Flows are the flows of the batch sorted by the peers, which are all
+	// the flows of the host when polling, or the flows traced during the
+	// interval when streaming.
+	Flows []*HostFlow
+	// Events are the changes since the previous batch.
+	Events []Event
+	// Err is the error of the collection. Flows and Events are empty if
+	// Err is not nil, and the next batch is diffed against the last one
+	// without an error.
+	Err error
+}
+
+// Watcher collects the flows continuously and delivers them in batches.
+type Watcher struct {
+	interval time.Duration
+	opt      *Options
+	// Streaming traces the connections with eBPF instead of polling them.
+	Streaming bool
+
+	mu     sync.Mutex
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewWatcher creates a Watcher, which collects the flows every interval
+// with the options. opt may be nil.
+func NewWatcher(interval time.Duration, opt *Options) *Watcher {
+	if opt == nil {
+		opt = &Options{}
+	}
+	return &Watcher{interval: interval, opt: opt}
+}
+
+// Start starts watching and returns the channel of the batches, which is
+// closed when ctx is done or Stop is called. The watcher waits for the
+// batch to be received before collecting the next one.
+func (w *Watcher) Start(ctx context.Context) (<-chan *Batch, error) {
+	if w.interval <= 0 {
+		return nil, xerrors.Errorf("interval must be positive, but %s", w.interval)
+	}
+	if err := w.opt.validate(); err != nil {
+		return nil, err
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.cancel != nil {
+		return nil, xerrors.New("the watcher is already started")
+	}
+	ctx, w.cancel = context.WithCancel(ctx)
+	w.done = make(chan struct{})
+
+	batches := make(chan *Batch)
+	go func() {
+		defer close(w.done)
+		defer close(batches)
+		d := &differ{known: map[probe.FlowKey]*HostFlow{}, remove: !w.Streaming}
+		if w.Streaming {
+			w.stream(ctx, d, batches)
+		} else {
+			w.poll(ctx, d, batches)
+		}
+	}()
+	return batches, nil
+}
+
+// Stop stops watching and waits until the channel is closed. The watcher
+// can be started again.
+func (w *Watcher) Stop() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.cancel == nil {
+		return
+	}
+	w.cancel()
+	<-w.done
+	w.cancel, w.done = nil, nil
+}
+
+func send(ctx context.Context, batches chan<- *Batch, b *Batch) bool {
+	select {
+	case batches <- b:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+func (w *Watcher) poll(ctx context.Context, d *differ, batches chan<- *Batch) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	for {
+		flows, err := Collect(ctx, w.opt)
+		if ctx.Err() != nil {
+			return
+		}
+		b := &Batch{Time: time.Now(), Err: err}
+		if err == nil {
+			b.Flows, b.Events = flows, d.diff(flows)
+		}
+		if !send(ctx, batches, b) {
+			return
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (w *Watcher) stream(ctx context.Context, d *differ, batches chan<- *Batch) {
+	var (
+		mu     sync.Mutex
+		traced = probe.HostFlows{}
+	)
+	traceCtx, stopTrace := context.WithCancel(ctx)
+	defer stopTrace()
+	traceErr := make(chan error, 1)
+	go func() {
+		traceErr <- trace(traceCtx, func(f *HostFlow) {
+			if !w.opt.matches(f) {
+				return
+			}
+			mu.Lock()
+			traced.Insert(f)
+			mu.Unlock()
+		})
+	}()
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case err := <-traceErr:
+			if err == nil {
+				return
+			}
+			send(ctx, batches, &Batch{Time: time.Now(), Err: xerrors.Errorf("could not trace the flows: %w", err)})
+			return
+		case <-ticker.C:
+		}
+
+		mu.Lock()
+		hf := traced
+		traced = probe.HostFlows{}
+		mu.Unlock()
+		if !w.opt.Numeric {
+			hf.SetLookupedNames()
+		}
+		flows := make([]*HostFlow, 0, len(hf))
+		for _, f := range hf {
+			flows = append(flows, f)
+		}
+		enricher.Chain(w.opt.Enrichers).Apply(flows)
+		probe.SortFlows(flows, probe.SortPeer)
+		if !send(ctx, batches, &Batch{Time: time.Now(), Flows: flows, Events: d.diff(flows)}) {
+			return
+		}
+	}
+}
+
+// matches returns whether the filter of the options passes the flow.
+func (o *Options) matches(f *HostFlow) bool {
+	switch o.Filter {
+	case FilterPublic:
+		return !netutil.IsPrivateIP(net.ParseIP(f.Peer.Addr))
+	case FilterPrivate:
+		return netutil.IsPrivateIP(net.ParseIP(f.Peer.Addr))
+	}
+	return true
+}
+
+// differ diffs the batches against the flows seen so far.
+type differ struct {
+	known map[probe.FlowKey]*HostFlow
+	// remove reports the flows not in the batch as removed.
+	remove bool
+}
+
+func (d *differ) diff(flows []*HostFlow) []Event {
+	var events []Event
+	seen := make(map[probe.FlowKey]bool, len(flows))
+	for _, f := range flows {
+		key := f.Key()
+		seen[key] = true
+		prev, ok := d.known[key]
+		switch {
+		case !ok:
+			events = append(events, Event{Type: EventAdded, Flow: f})
+		case changed(prev, f):
+			events = append(events, Event{Type: EventChanged, Flow: f})
+		}
+		d.known[key] = f
+	}
+	if !d.remove {
+		return events
+	}
+	var removed []*HostFlow
+	for key, f := range d.known {
+		if !seen[key] {
+			removed = append(removed, f)
+			delete(d.known, key)
+		}
+	}
+	probe.SortFlows(removed, probe.SortPeer)
+	for _, f := range removed {
+		events = append(events, Event{Type: EventRemoved, Flow: f})
+	}
+	return events
+}
+
+func changed(a, b *HostFlow) bool {
+	if a.Connections != b.Connections {
+		return true
+	}
+	var pa, pb string
+	if a.Process != nil {
+		pa = a.Process.Name
+	}
+	if b.Process != nil {
+		pb = b.Process.Name
+	}
+	return pa != pb
+}
@@ -0,0 +1,129 @@
+package probe
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/yuuki/shawk/probe"
+)
+
+func passiveFlow(peer string, conns int64) *HostFlow {
+	return &HostFlow{
+		Direction:   FlowPassive,
+		Local:       &AddrPort{Addr: "10.0.0.1", Port: 80},
+		Peer:        &AddrPort{Addr: peer, Aggregated: true},
+		Connections: conns,
+	}
+}
+
+func eventStrings(events []Event) []string {
+	var ss []string
+	for _, e := range events {
+		ss = append(ss, e.Type.String()+" "+e.Flow.String())
+	}
+	return ss
+}
+
+func TestDiffer(t *testing.T) {
+	d := &differ{known: map[probe.FlowKey]*HostFlow{}, remove: true}
+	batches := [][]*HostFlow{
+		{passiveFlow("10.0.0.2", 1), passiveFlow("10.0.0.3", 1)},
+		{passiveFlow("10.0.0.2", 1), passiveFlow("10.0.0.3", 2), passiveFlow("10.0.0.4", 1)},
+		{passiveFlow("10.0.0.4", 1)},
+	}
+	want := [][]string{
+		{"added 10.0.0.1:80\t<--\t10.0.0.2:many\t1", "added 10.0.0.1:80\t<--\t10.0.0.3:many\t1"},
+		{"changed 10.0.0.1:80\t<--\t10.0.0.3:many\t2", "added 10.0.0.1:80\t<--\t10.0.0.4:many\t1"},
+		{"removed 10.0.0.1:80\t<--\t10.0.0.2:many\t1", "removed 10.0.0.1:80\t<--\t10.0.0.3:many\t2"},
+	}
+	for i, flows := range batches {
+		if diff := cmp.Diff(want[i], eventStrings(d.diff(flows))); diff != "" {
+			t.Errorf("diff() of batch %d mismatch (-want +got):\n%s", i, diff)
+		}
+	}
+
+	// The streaming differ does not remove the flows.
+	d = &differ{known: map[probe.FlowKey]*HostFlow{}}
+	d.diff(batches[0])
+	if events := d.diff(batches[2]); len(events) != 1 || events[0].Type != EventAdded {
+		t.Errorf("diff() should not remove the flows when streaming: %q", eventStrings(events))
+	}
+}
+
+func TestWatcher_streaming(t *testing.T) {
+	prev := trace
+	defer func() { trace = prev }()
+	traced := make(chan struct{})
+	trace = func(ctx context.Context, cb func(*HostFlow)) error {
+		cb(passiveFlow("10.0.0.2", 0))
+		cb(passiveFlow("10.0.0.2", 0))
+		cb(passiveFlow("203.0.113.5", 0))
+		close(traced)
+		<-ctx.Done()
+		return nil
+	}
+
+	w := NewWatcher(10*time.Millisecond, &Options{Numeric: true, Filter: FilterPrivate})
+	w.Streaming = true
+	batches, err := w.Start(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.Start(context.Background()); err == nil {
+		t.Error("Start() should return an error while started")
+	}
+	<-traced
+
+	for b := range batches {
+		if b.Err != nil {
+			t.Fatalf("the batch should not have an error: %+v", b.Err)
+		}
+		if len(b.Flows) == 0 {
+			continue
+		}
+		want := []string{"added 10.0.0.1:80\t<--\t10.0.0.2:many\t2"}
+		if diff := cmp.Diff(want, eventStrings(b.Events)); diff != "" {
+			t.Errorf("events mismatch (-want +got):\n%s", diff)
+		}
+		break
+	}
+	w.Stop()
+	if _, ok := <-batches; ok {
+		t.Error("Stop() should close the channel")
+	}
+}
+
+func TestWatcher_traceError(t *testing.T) {
+	prev := trace
+	defer func() { trace = prev }()
+	trace = func(ctx context.Context, cb func(*HostFlow)) error {
+		return context.DeadlineExceeded
+	}
+
+	w := NewWatcher(time.Hour, nil)
+	w.Streaming = true
+	batches, err := w.Start(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Stop()
+	b := <-batches
+	if b == nil || b.Err == nil {
+		t.Fatalf("the batch should have the error of the tracer: %+v", b)
+	}
+	if _, ok := <-batches; ok {
+		t.Error("the channel should be closed after the error of the tracer")
+	}
+}
+
+func TestWatcher_invalid(t *testing.T) {
+	if _, err := NewWatcher(0, nil).Start(context.Background()); err == nil {
+		t.Error("Start() should return an error for a zero interval")
+	}
+	if _, err := NewWatcher(time.Second, &Options{Filter: "lan"}).Start(context.Background()); err == nil {
+		t.Error("Start() should return an error for an unknown filter")
+	}
+}
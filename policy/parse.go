@@ -0,0 +1,183 @@
+package policy
+
+import (
+	"bufio"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+
+	"golang.org/x/xerrors"
+)
+
+// Parse parses the policy written in the DSL:
+//
+//	# the comments start with '#'
+//	group NAME SELECTOR...
+//	allow|deny SOURCE -> DESTINATION [port PORTS]
+//	default allow|deny
+//
+// A SELECTOR is an address, a CIDR block, 'process:NAME', 'label:KEY=VALUE'
+// or '*' for any node, and a group matches the nodes any of its selectors
+// match. SOURCE and DESTINATION are the names of the groups or selectors.
+// PORTS are the comma-separated ports or ranges of the destination such as
+// '80,8000-8080'. The first rule matching a flow decides whether the flow
+// is allowed, and the flows no rule matches are allowed unless 'default
+// deny'.
+func Parse(r io.Reader) (*Policy, error) {
+	p := &Policy{Default: Allow, groups: map[string]selectors{}}
+	sc := bufio.NewScanner(r)
+	for line := 1; sc.Scan(); line++ {
+		text := sc.Text()
+		if i := strings.IndexByte(text, '#'); i >= 0 {
+			text = text[:i]
+		}
+		fields := strings.Fields(text)
+		if len(fields) == 0 {
+			continue
+		}
+		if err := p.parseLine(line, fields); err != nil {
+			return nil, xerrors.Errorf("line %d: %w", line, err)
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, xerrors.Errorf("could not read the policy: %w", err)
+	}
+	return p, nil
+}
+
+func (p *Policy) parseLine(line int, fields []string) error {
+	switch fields[0] {
+	case "group":
+		if len(fields) < 3 {
+			return xerrors.New("'group' needs a name and selectors such as 'group db 10.0.2.0/24'")
+		}
+		name := fields[1]
+		if _, ok := p.groups[name]; ok {
+			return xerrors.Errorf("group %q is already defined", name)
+		}
+		if isSelector(name) {
+			return xerrors.Errorf("group name %q must not be a selector", name)
+		}
+		var sels selectors
+		for _, f := range fields[2:] {
+			s, err := p.selector(f)
+			if err != nil {
+				return err
+			}
+			sels = append(sels, s...)
+		}
+		p.groups[name] = sels
+	case "default":
+		if len(fields) != 2 {
+			return xerrors.New("'default' needs 'allow' or 'deny'")
+		}
+		a, err := parseAction(fields[1])
+		if err != nil {
+			return err
+		}
+		p.Default = a
+	case string(Allow), string(Deny):
+		rule, err := p.parseRule(fields)
+		if err != nil {
+			return err
+		}
+		rule.Line = line
+		p.Rules = append(p.Rules, rule)
+	default:
+		return xerrors.Errorf("unknown statement %q: must be one of group, allow, deny or default", fields[0])
+	}
+	return nil
+}
+
+func parseAction(s string) (Action, error) {
+	switch Action(s) {
+	case Allow, Deny:
+		return Action(s), nil
+	}
+	return "", xerrors.Errorf("action must be 'allow' or 'deny', but %q", s)
+}
+
+func (p *Policy) parseRule(fields []string) (*Rule, error) {
+	if len(fields) != 4 && len(fields) != 6 || fields[2] != "->" {
+		return nil, xerrors.Errorf("'%s' must be such as '%s app -> db port 5432'", fields[0], fields[0])
+	}
+	rule := &Rule{Action: Action(fields[0]), Text: strings.Join(fields, " ")}
+	var err error
+	if rule.src, err = p.selector(fields[1]); err != nil {
+		return nil, err
+	}
+	if rule.dst, err = p.selector(fields[3]); err != nil {
+		return nil, err
+	}
+	if len(fields) == 6 {
+		if fields[4] != "port" {
+			return nil, xerrors.Errorf("unexpected %q: the ports must follow 'port'", fields[4])
+		}
+		if rule.ports, err = parsePorts(fields[5]); err != nil {
+			return nil, err
+		}
+	}
+	return rule, nil
+}
+
+func isSelector(s string) bool {
+	return s == "*" || strings.Contains(s, ":") || net.ParseIP(s) != nil || strings.Contains(s, "/")
+}
+
+// selector parses the name of a group or a selector.
+func (p *Policy) selector(s string) (selectors, error) {
+	if sels, ok := p.groups[s]; ok {
+		return sels, nil
+	}
+	switch {
+	case s == "*":
+		return selectors{anySelector{}}, nil
+	case strings.HasPrefix(s, "process:"):
+		name := strings.TrimPrefix(s, "process:")
+		if name == "" {
+			return nil, xerrors.Errorf("%q needs the name of the process", s)
+		}
+		return selectors{processSelector(name)}, nil
+	case strings.HasPrefix(s, "label:"):
+		kv := strings.SplitN(strings.TrimPrefix(s, "label:"), "=", 2)
+		if len(kv) != 2 || kv[0] == "" {
+			return nil, xerrors.Errorf("%q must be such as 'label:role=db'", s)
+		}
+		return selectors{labelSelector{key: kv[0], value: kv[1]}}, nil
+	case strings.Contains(s, "/"):
+		_, ipnet, err := net.ParseCIDR(s)
+		if err != nil {
+			return nil, xerrors.Errorf("invalid CIDR block %q", s)
+		}
+		return selectors{cidrSelector{ipnet}}, nil
+	case net.ParseIP(s) != nil:
+		ip := net.ParseIP(s)
+		bits := 128
+		if ip.To4() != nil {
+			ip, bits = ip.To4(), 32
+		}
+		return selectors{cidrSelector{&net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)}}}, nil
+	}
+	return nil, xerrors.Errorf("unknown group or selector %q", s)
+}
+
+func parsePorts(s string) ([]portRange, error) {
+	var ports []portRange
+	for _, p := range strings.Split(s, ",") {
+		from, to := p, p
+		if i := strings.IndexByte(p, '-'); i >= 0 {
+			from, to = p[:i], p[i+1:]
+		}
+		f, err := strconv.ParseUint(from, 10, 16)
+		if err != nil {
+			return nil, xerrors.Errorf("invalid port %q", p)
+		}
+		t, err := strconv.ParseUint(to, 10, 16)
+		if err != nil || t < f {
+			return nil, xerrors.Errorf("invalid port %q", p)
+		}
+		ports = append(ports, portRange{from: uint16(f), to: uint16(t)})
+	}
+	return ports, nil
+}
@@ -0,0 +1,124 @@
+// Package policy checks the flows in the CMDB against the rules of which
+// nodes may connect to which, so that the changes of the topology are gated
+// in CI/CD and audited.
+package policy
+
+import (
+	"net"
+
+	"github.com/yuuki/shawk/db"
+)
+
+// Action is whether a rule allows or denies the flows.
+type Action string
+
+// The actions of the rules.
+const (
+	Allow Action = "allow"
+	Deny  Action = "deny"
+)
+
+// Rule allows or denies the flows from the source to the ports of the
+// destination.
+type Rule struct {
+	Action Action
+	// Line is the line number of the rule in the policy.
+	Line int
+	// Text is the rule as written in the policy.
+	Text string
+
+	src, dst selectors
+	ports    []portRange // nil matches any port
+}
+
+// Policy is the rules and the action of the flows no rule matches.
+type Policy struct {
+	Rules   []*Rule
+	Default Action
+
+	groups map[string]selectors
+}
+
+// Violation is a flow denied by a rule, or by the default of the policy if
+// Rule is nil.
+type Violation struct {
+	Flow *db.Flow
+	Rule *Rule
+}
+
+// Decide returns the first rule matching the flow and its action, or nil
+// and the default if no rule matches.
+func (p *Policy) Decide(f *db.Flow) (*Rule, Action) {
+	for _, r := range p.Rules {
+		if r.match(f) {
+			return r, r.Action
+		}
+	}
+	return nil, p.Default
+}
+
+// Check returns the violations of the flows in order.
+func (p *Policy) Check(flows []*db.Flow) []*Violation {
+	var vs []*Violation
+	for _, f := range flows {
+		if r, a := p.Decide(f); a == Deny {
+			vs = append(vs, &Violation{Flow: f, Rule: r})
+		}
+	}
+	return vs
+}
+
+func (r *Rule) match(f *db.Flow) bool {
+	if !r.src.match(f.ActiveNode) || !r.dst.match(f.PassiveNode) {
+		return false
+	}
+	if r.ports == nil {
+		return true
+	}
+	for _, p := range r.ports {
+		if p.from <= f.PassiveNode.Port && f.PassiveNode.Port <= p.to {
+			return true
+		}
+	}
+	return false
+}
+
+type portRange struct {
+	from, to uint16
+}
+
+// selector matches the nodes of the flows.
+type selector interface {
+	match(n *db.Node) bool
+}
+
+// selectors matches the nodes any of them matches.
+type selectors []selector
+
+func (ss selectors) match(n *db.Node) bool {
+	for _, s := range ss {
+		if s.match(n) {
+			return true
+		}
+	}
+	return false
+}
+
+type anySelector struct{}
+
+func (anySelector) match(*db.Node) bool { return true }
+
+type cidrSelector struct{ *net.IPNet }
+
+func (s cidrSelector) match(n *db.Node) bool { return s.Contains(n.IPAddr) }
+
+type processSelector string
+
+func (s processSelector) match(n *db.Node) bool { return n.Pname == string(s) }
+
+type labelSelector struct{ key, value string }
+
+func (s labelSelector) match(n *db.Node) bool {
+	v, ok := n.Labels[s.key]
+	return ok && v == s.value
+}
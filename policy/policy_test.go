@@ -0,0 +1,88 @@
+package policy
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/yuuki/shawk/db"
+	"github.com/yuuki/shawk/db/dbtest"
+)
+
+const testPolicy = `
+# the db tier may only accept from the app tier
+group app 10.0.1.0/24 process:haproxy
+group db  label:role=db 10.0.2.0/24
+
+allow app -> db port 5432,6432-6433
+deny  *   -> db
+allow 10.0.0.0/8 -> *
+default deny
+`
+
+func TestPolicy_Check(t *testing.T) {
+	p, err := Parse(strings.NewReader(testPolicy))
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+
+	flows := []*db.Flow{
+		dbtest.Flow("10.0.1.10", "app", "10.0.2.20", "postgres", 5432, 1),
+		dbtest.Flow("10.0.1.10", "app", "10.0.2.20", "pgbouncer", 6433, 1),
+		dbtest.Flow("10.0.9.10", "haproxy", "10.0.2.20", "postgres", 5432, 1),
+		// the app tier on a wrong port
+		dbtest.Flow("10.0.1.10", "app", "10.0.2.20", "sshd", 22, 1),
+		// the db by the label out of its subnet
+		dbtest.Flow("10.0.3.10", "batch", "10.0.8.8", "mysqld", 3306, 1, "role", "db"),
+		dbtest.Flow("10.0.3.10", "batch", "10.0.1.10", "app", 80, 1),
+		// denied by default
+		dbtest.Flow("192.168.0.1", "curl", "10.0.1.10", "app", 80, 1),
+	}
+	var got []string
+	for _, v := range p.Check(flows) {
+		rule := "default"
+		if v.Rule != nil {
+			rule = v.Rule.Text
+		}
+		got = append(got, v.Flow.ActiveNode.Pname+" -> "+v.Flow.PassiveNode.Pname+": "+rule)
+	}
+	want := []string{
+		"app -> sshd: deny * -> db",
+		"batch -> mysqld: deny * -> db",
+		"curl -> app: default",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Check() mismatch (-want +got):\n%s", diff)
+	}
+
+	if v := p.Check(flows)[0]; v.Rule.Line != 7 {
+		t.Errorf("the line of the rule should be 7, but %d", v.Rule.Line)
+	}
+}
+
+func TestParse_error(t *testing.T) {
+	tests := []struct {
+		policy  string
+		wantErr string
+	}{
+		{"permit * -> *", "line 1: unknown statement \"permit\""},
+		{"\nallow app -> *", "line 2: unknown group or selector \"app\""},
+		{"allow * => *", "must be such as 'allow app -> db port 5432'"},
+		{"allow * -> * ports 80", "the ports must follow 'port'"},
+		{"allow * -> * port 80-70", "invalid port \"80-70\""},
+		{"allow * -> * port 70000", "invalid port \"70000\""},
+		{"group db", "'group' needs a name and selectors"},
+		{"group db 10.0.2.0/24\ngroup db 10.0.3.0/24", "group \"db\" is already defined"},
+		{"group 10.0.0.1 10.0.2.0/24", "must not be a selector"},
+		{"group db 10.0.2.0/33", "invalid CIDR block"},
+		{"group db label:role", "must be such as 'label:role=db'"},
+		{"default reject", "action must be 'allow' or 'deny'"},
+	}
+	for _, tt := range tests {
+		_, err := Parse(strings.NewReader(tt.policy))
+		if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("Parse(%q) should return an error containing %q, but %v", tt.policy, tt.wantErr, err)
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package probe
+
+import (
+	"bufio"
+	"io"
+	"strconv"
+	"strings"
+
+	"golang.org/x/xerrors"
+)
+
+// ListenerAllowList is the listening ports expected on a host.
+type ListenerAllowList struct {
+	entries []*allowEntry
+}
+
+type allowEntry struct {
+	from, to uint16
+	// processes are the names of the processes allowed to listen on the
+	// ports, or nil for any process.
+	processes map[string]bool
+}
+
+// ParseListenerAllowList parses the allow list of the listeners, the lines
+// of:
+//
+//	# the comments start with '#'
+//	PORTS [PROCESS...]
+//
+// PORTS is a port or a range such as '8000-8080', and the PROCESSes are the
+// names of the processes allowed to listen on them, or any process if none.
+// The lines printed by Listener.String are the baseline of the listeners.
+func ParseListenerAllowList(r io.Reader) (*ListenerAllowList, error) {
+	l := &ListenerAllowList{}
+	sc := bufio.NewScanner(r)
+	for line := 1; sc.Scan(); line++ {
+		text := sc.Text()
+		if i := strings.IndexByte(text, '#'); i >= 0 {
+			text = text[:i]
+		}
+		fields := strings.Fields(text)
+		if len(fields) == 0 {
+			continue
+		}
+		e, err := parseAllowEntry(fields)
+		if err != nil {
+			return nil, xerrors.Errorf("line %d: %w", line, err)
+		}
+		l.entries = append(l.entries, e)
+	}
+	if err := sc.Err(); err != nil {
+		return nil, xerrors.Errorf("could not read the allow list: %w", err)
+	}
+	return l, nil
+}
+
+func parseAllowEntry(fields []string) (*allowEntry, error) {
+	e := &allowEntry{}
+	from, to := fields[0], fields[0]
+	if i := strings.IndexByte(fields[0], '-'); i >= 0 {
+		from, to = fields[0][:i], fields[0][i+1:]
+	}
+	for _, p := range []struct {
+		s    string
+		port *uint16
+	}{{from, &e.from}, {to, &e.to}} {
+		n, err := strconv.ParseUint(p.s, 10, 16)
+		if err != nil || n == 0 {
+			return nil, xerrors.Errorf("%q must be a port or a range of the ports such as '8000-8080'", fields[0])
+		}
+		*p.port = uint16(n)
+	}
+	if e.from > e.to {
+		return nil, xerrors.Errorf("the range %q must not be reversed", fields[0])
+	}
+	if len(fields) > 1 {
+		e.processes = make(map[string]bool, len(fields)-1)
+		for _, name := range fields[1:] {
+			e.processes[name] = true
+		}
+	}
+	return e, nil
+}
+
+// Allows returns whether an entry of the allow list has the port of the
+// listener and all of its processes. The listeners of the unknown
+// processes are allowed only by the entries of any process.
+func (l *ListenerAllowList) Allows(ln *Listener) bool {
+	for _, e := range l.entries {
+		if ln.Port < e.from || e.to < ln.Port {
+			continue
+		}
+		if e.processes == nil {
+			return true
+		}
+		names := ln.ProcessNames()
+		allowed := len(names) > 0
+		for _, name := range names {
+			if !e.processes[name] {
+				allowed = false
+				break
+			}
+		}
+		if allowed {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,73 @@
+package probe
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestListenerAllowList(t *testing.T) {
+	l, err := ParseListenerAllowList(strings.NewReader(`
+# the baseline of the web servers
+22 sshd # 0.0.0.0,::
+80 envoy nginx
+8000-8080
+`))
+	if err != nil {
+		t.Fatalf("ParseListenerAllowList() should not return an error: %+v", err)
+	}
+	tests := []struct {
+		listener *Listener
+		want     bool
+	}{
+		{&Listener{Port: 22, Processes: []*Process{{Name: "sshd", Pgid: 1}}}, true},
+		{&Listener{Port: 22, Processes: []*Process{{Name: "nc", Pgid: 2}}}, false},
+		// unknown processes
+		{&Listener{Port: 22}, false},
+		{&Listener{Port: 80, Processes: []*Process{{Name: "nginx", Pgid: 1}, {Name: "envoy", Pgid: 2}}}, true},
+		{&Listener{Port: 80, Processes: []*Process{{Name: "nginx", Pgid: 1}, {Name: "python3", Pgid: 2}}}, false},
+		{&Listener{Port: 8080, Processes: []*Process{{Name: "java", Pgid: 1}}}, true},
+		{&Listener{Port: 8081}, false},
+		{&Listener{Port: 4444, Processes: []*Process{{Name: "nc", Pgid: 3}}}, false},
+	}
+	for _, tt := range tests {
+		if got := l.Allows(tt.listener); got != tt.want {
+			t.Errorf("Allows(%s) = %t, want %t", tt.listener, got, tt.want)
+		}
+	}
+}
+
+func TestListenerAllowList_baseline(t *testing.T) {
+	ln := &Listener{
+		Port:      80,
+		Addrs:     []string{"0.0.0.0", "::"},
+		Processes: []*Process{{Name: "nginx", Pgid: 100}, {Name: "nginx", Pgid: 200}, {Name: "envoy", Pgid: 300}},
+	}
+	if got, want := ln.String(), "80 envoy nginx # 0.0.0.0,::"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+	l, err := ParseListenerAllowList(strings.NewReader(ln.String()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !l.Allows(ln) {
+		t.Errorf("the baseline should allow the listener %s", ln)
+	}
+}
+
+func TestParseListenerAllowList_error(t *testing.T) {
+	tests := []struct {
+		in      string
+		wantErr string
+	}{
+		{"ssh sshd\n", `line 1: "ssh" must be a port`},
+		{"22\n0\n", `line 2: "0" must be a port`},
+		{"8080-8000\n", `the range "8080-8000" must not be reversed`},
+		{"70000\n", `"70000" must be a port`},
+	}
+	for _, tt := range tests {
+		_, err := ParseListenerAllowList(strings.NewReader(tt.in))
+		if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("ParseListenerAllowList(%q) should return an error containing %q, but %v", tt.in, tt.wantErr, err)
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package probe
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"golang.org/x/xerrors"
+
+	"github.com/yuuki/shawk/probe/netlink/netutil"
+)
+
+// conntrackFilename is the table of conntrack in procfs, which exists when
+// nf_conntrack is loaded, such as on the NAT gateways.
+const conntrackFilename = "net/nf_conntrack"
+
+// ErrNoConntrack is returned when the host doesn't track the connections.
+var ErrNoConntrack = xerrors.New("conntrack is not available: load nf_conntrack")
+
+// tuple is the original or the reply tuple of conntrack.
+type tuple struct {
+	src, dst     string
+	sport, dport uint16
+}
+
+// ConntrackNATs returns the established TCP connections translated by NAT
+// on this host, aggregated by the clients, the original destinations, the
+// translated servers and the translated sources.
+func ConntrackNATs() ([]*NAT, error) {
+	f, err := netutil.ProcFS().Open(conntrackFilename)
+	if os.IsNotExist(err) {
+		return nil, ErrNoConntrack
+	}
+	if err != nil {
+		return nil, xerrors.Errorf("could not open %s: %w", conntrackFilename, err)
+	}
+	defer f.Close()
+	return ParseConntrack(f)
+}
+
+// ParseConntrack parses the table of conntrack in the format of
+// /proc/net/nf_conntrack or `conntrack -L`, and returns the NATs of the
+// established TCP connections.
+func ParseConntrack(r io.Reader) ([]*NAT, error) {
+	type natKey struct {
+		client, source string
+		orig, trans    tuple
+	}
+	nats := make(map[natKey]*NAT)
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		orig, reply, ok := parseConntrackLine(scanner.Text())
+		if !ok {
+			continue
+		}
+		// The reply is from the translated server to the translated client.
+		key := natKey{
+			client: orig.src,
+			source: reply.dst,
+			orig:   tuple{dst: orig.dst, dport: orig.dport},
+			trans:  tuple{dst: reply.src, dport: reply.sport},
+		}
+		if key.client == key.source && key.orig == key.trans {
+			continue
+		}
+		n, ok := nats[key]
+		if !ok {
+			n = &NAT{
+				Client:     orig.src,
+				Original:   &AddrPort{Addr: orig.dst, Port: orig.dport},
+				Translated: &AddrPort{Addr: reply.src, Port: reply.sport},
+				Source:     reply.dst,
+			}
+			nats[key] = n
+		}
+		n.Connections++
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, xerrors.Errorf("could not read conntrack: %w", err)
+	}
+
+	list := make([]*NAT, 0, len(nats))
+	for _, n := range nats {
+		list = append(list, n)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].String() < list[j].String()
+	})
+	return list, nil
+}
+
+// parseConntrackLine returns the tuples of the line of an established TCP
+// connection, such as:
+//
+//	ipv4 2 tcp 6 431999 ESTABLISHED src=10.0.0.5 dst=10.96.0.10 sport=40000 dport=80 src=10.1.2.3 dst=10.0.0.1 sport=8080 dport=40000 [ASSURED] mark=0 use=1
+//
+// The leading family of /proc/net/nf_conntrack is absent from `conntrack -L`.
+func parseConntrackLine(line string) (orig, reply tuple, ok bool) {
+	fields := strings.Fields(line)
+	for len(fields) > 0 && fields[0] != "tcp" {
+		fields = fields[1:]
+	}
+	if len(fields) < 4 || fields[3] != "ESTABLISHED" {
+		return orig, reply, false
+	}
+	var tuples [2]tuple
+	i := -1
+	for _, f := range fields[4:] {
+		kv := strings.SplitN(f, "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		if kv[0] == "src" {
+			i++
+		}
+		if i < 0 || i > 1 {
+			continue
+		}
+		t := &tuples[i]
+		switch kv[0] {
+		case "src":
+			t.src = kv[1]
+		case "dst":
+			t.dst = kv[1]
+		case "sport", "dport":
+			port, err := strconv.ParseUint(kv[1], 10, 16)
+			if err != nil {
+				return orig, reply, false
+			}
+			if kv[0] == "sport" {
+				t.sport = uint16(port)
+			} else {
+				t.dport = uint16(port)
+			}
+		}
+	}
+	if i != 1 || tuples[0].dst == "" || tuples[1].src == "" {
+		return orig, reply, false
+	}
+	return tuples[0], tuples[1], true
+}
@@ -0,0 +1,58 @@
+package probe
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseConntrack(t *testing.T) {
+	table := strings.Join([]string{
+		// DNAT of a virtual IP to two servers, and SNAT to the gateway.
+		"ipv4     2 tcp      6 431999 ESTABLISHED src=10.0.0.5 dst=10.96.0.10 sport=40000 dport=80 src=10.1.2.3 dst=10.0.0.1 sport=8080 dport=40000 [ASSURED] mark=0 zone=0 use=2",
+		"ipv4     2 tcp      6 431999 ESTABLISHED src=10.0.0.5 dst=10.96.0.10 sport=40001 dport=80 src=10.1.2.3 dst=10.0.0.1 sport=8080 dport=40001 [ASSURED] mark=0 zone=0 use=2",
+		"ipv4     2 tcp      6 431999 ESTABLISHED src=10.0.0.5 dst=10.96.0.10 sport=40002 dport=80 src=10.1.2.4 dst=10.0.0.1 sport=8080 dport=40002 [ASSURED] mark=0 zone=0 use=2",
+		// SNAT only, in the format of `conntrack -L`.
+		"tcp      6 431999 ESTABLISHED src=10.0.0.6 dst=93.184.216.34 sport=50000 dport=443 src=93.184.216.34 dst=203.0.113.1 sport=443 dport=50000 [ASSURED] mark=0 use=1",
+		// Not translated.
+		"ipv4     2 tcp      6 431999 ESTABLISHED src=10.0.0.7 dst=10.0.0.8 sport=50000 dport=22 src=10.0.0.8 dst=10.0.0.7 sport=22 dport=50000 [ASSURED] mark=0 zone=0 use=2",
+		// Not established, and not TCP.
+		"ipv4     2 tcp      6 117 SYN_SENT src=10.0.0.5 dst=10.96.0.10 sport=40003 dport=80 [UNREPLIED] src=10.1.2.3 dst=10.0.0.1 sport=8080 dport=40003 mark=0 zone=0 use=2",
+		"ipv4     2 udp      17 28 src=10.0.0.5 dst=10.96.0.53 sport=5353 dport=53 src=10.1.2.5 dst=10.0.0.5 sport=53 dport=5353 mark=0 zone=0 use=2",
+	}, "\n")
+
+	nats, err := ParseConntrack(strings.NewReader(table))
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	want := []*NAT{
+		{
+			Client: "10.0.0.5", Source: "10.0.0.1",
+			Original:    &AddrPort{Addr: "10.96.0.10", Port: 80},
+			Translated:  &AddrPort{Addr: "10.1.2.3", Port: 8080},
+			Connections: 2,
+		},
+		{
+			Client: "10.0.0.5", Source: "10.0.0.1",
+			Original:    &AddrPort{Addr: "10.96.0.10", Port: 80},
+			Translated:  &AddrPort{Addr: "10.1.2.4", Port: 8080},
+			Connections: 1,
+		},
+		{
+			Client: "10.0.0.6", Source: "203.0.113.1",
+			Original:    &AddrPort{Addr: "93.184.216.34", Port: 443},
+			Translated:  &AddrPort{Addr: "93.184.216.34", Port: 443},
+			Connections: 1,
+		},
+	}
+	if diff := cmp.Diff(want, nats); diff != "" {
+		t.Errorf("ParseConntrack() mismatch (-want +got):\n%s", diff)
+	}
+	if !nats[0].DNAT() || !nats[0].SNAT() {
+		t.Error("the NAT of the virtual IP should be of both DNAT and SNAT")
+	}
+	if nats[2].DNAT() || !nats[2].SNAT() {
+		t.Error("the NAT to the internet should be of only SNAT")
+	}
+}
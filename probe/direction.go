@@ -0,0 +1,93 @@
+package probe
+
+// LabelDirectionConfidence is the confidence of the direction of the flow
+// inferred without the listening sockets, such as in the restricted
+// environments or of the connections captured on the network, which is
+// labeled on the server as ConfidenceHigh, ConfidenceMedium or
+// ConfidenceLow. The flows of the known listening sockets have no label.
+const LabelDirectionConfidence = "direction.confidence"
+
+// The confidences of the inferred directions.
+const (
+	// ConfidenceHigh is the direction both the ports and the symmetry of
+	// the connections agree on.
+	ConfidenceHigh = "high"
+	// ConfidenceMedium is the direction either of them tells.
+	ConfidenceMedium = "medium"
+	// ConfidenceLow is the direction they disagree on, or told only by the
+	// lower port.
+	ConfidenceLow = "low"
+)
+
+// Endpoint is an address and a port of a connection.
+type Endpoint struct {
+	Addr string
+	Port uint16
+}
+
+// portClass returns the class of the port: 0 for the well-known ports, 1
+// for the registered ports and 2 for the ephemeral ports, which the servers
+// are less likely to listen on as the class is higher. The ephemeral ports
+// start at 32768 of Linux rather than at 49152 of IANA.
+func portClass(port uint16) int {
+	switch {
+	case port < 1024:
+		return 0
+	case port < 32768:
+		return 1
+	}
+	return 2
+}
+
+// DirectionInferrer infers the servers of the connections without the
+// listening sockets by the classes of the ports and by the symmetry of the
+// connections: a server is connected from more peers than its clients are
+// connected to, since the clients connect from a new port each time.
+type DirectionInferrer struct {
+	peers map[Endpoint]map[Endpoint]struct{}
+}
+
+// NewDirectionInferrer returns a DirectionInferrer.
+func NewDirectionInferrer() *DirectionInferrer {
+	return &DirectionInferrer{peers: make(map[Endpoint]map[Endpoint]struct{})}
+}
+
+// Observe adds the connection between a and b, which Infer sees for the
+// symmetry of the connections. All the connections should be observed
+// before inferring any of them.
+func (d *DirectionInferrer) Observe(a, b Endpoint) {
+	for _, e := range [][2]Endpoint{{a, b}, {b, a}} {
+		peers, ok := d.peers[e[0]]
+		if !ok {
+			peers = make(map[Endpoint]struct{})
+			d.peers[e[0]] = peers
+		}
+		peers[e[1]] = struct{}{}
+	}
+}
+
+// Infer returns whether a is the server of the connection between a and b,
+// and the confidence of it.
+func (d *DirectionInferrer) Infer(a, b Endpoint) (bool, string) {
+	var byPort, bySymmetry *bool
+	if ca, cb := portClass(a.Port), portClass(b.Port); ca != cb {
+		v := ca < cb
+		byPort = &v
+	}
+	if pa, pb := len(d.peers[a]), len(d.peers[b]); pa != pb && (pa > 1 || pb > 1) {
+		v := pa > pb
+		bySymmetry = &v
+	}
+	switch {
+	case byPort != nil && bySymmetry != nil && *byPort == *bySymmetry:
+		return *byPort, ConfidenceHigh
+	case byPort != nil && bySymmetry != nil:
+		// The ports are less wrong than a few connections.
+		return *byPort, ConfidenceLow
+	case byPort != nil:
+		return *byPort, ConfidenceMedium
+	case bySymmetry != nil:
+		return *bySymmetry, ConfidenceMedium
+	}
+	return a.Port < b.Port, ConfidenceLow
+}
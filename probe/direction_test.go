@@ -0,0 +1,41 @@
+package probe
+
+import "testing"
+
+func TestDirectionInferrer(t *testing.T) {
+	d := NewDirectionInferrer()
+	web := Endpoint{Addr: "10.0.0.10", Port: 8080}
+	for _, c := range []Endpoint{
+		{Addr: "10.0.0.1", Port: 20001},
+		{Addr: "10.0.0.2", Port: 40002},
+		{Addr: "10.0.0.3", Port: 50003},
+	} {
+		d.Observe(web, c)
+	}
+	d.Observe(Endpoint{Addr: "10.0.0.10", Port: 40010}, Endpoint{Addr: "10.0.0.20", Port: 5432})
+	d.Observe(Endpoint{Addr: "10.0.0.10", Port: 9000}, Endpoint{Addr: "10.0.0.30", Port: 9001})
+
+	tests := []struct {
+		a, b           Endpoint
+		wantServer     bool
+		wantConfidence string
+	}{
+		// the ports and the symmetry
+		{web, Endpoint{Addr: "10.0.0.3", Port: 50003}, true, ConfidenceHigh},
+		{Endpoint{Addr: "10.0.0.3", Port: 50003}, web, false, ConfidenceHigh},
+		// the symmetry of the registered ports
+		{Endpoint{Addr: "10.0.0.1", Port: 20001}, web, false, ConfidenceMedium},
+		// the registered port of the server to the ephemeral port
+		{Endpoint{Addr: "10.0.0.10", Port: 40010}, Endpoint{Addr: "10.0.0.20", Port: 5432}, false, ConfidenceMedium},
+		// the well-known port against the symmetry
+		{Endpoint{Addr: "10.0.0.40", Port: 443}, web, true, ConfidenceLow},
+		// only the lower port
+		{Endpoint{Addr: "10.0.0.10", Port: 9000}, Endpoint{Addr: "10.0.0.30", Port: 9001}, true, ConfidenceLow},
+	}
+	for _, tt := range tests {
+		server, confidence := d.Infer(tt.a, tt.b)
+		if server != tt.wantServer || confidence != tt.wantConfidence {
+			t.Errorf("Infer(%v, %v) = %t, %s, want %t, %s", tt.a, tt.b, server, confidence, tt.wantServer, tt.wantConfidence)
+		}
+	}
+}
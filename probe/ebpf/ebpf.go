@@ -1,13 +1,15 @@
 package ebpf
 
 import (
-	"fmt"
+	"context"
 	"syscall"
+	"time"
 
 	bpflib "github.com/iovisor/gobpf/elf"
 	"github.com/weaveworks/tcptracer-bpf/pkg/tracer"
 	"github.com/yuuki/shawk/logging"
 	"github.com/yuuki/shawk/probe"
+	"github.com/yuuki/shawk/probe/netlink/netutil"
 	"golang.org/x/xerrors"
 )
 
@@ -19,15 +21,23 @@ var logger = logging.New("ebpf")
 
 type tcpTracer struct {
 	evChan chan interface{}
-	lost   uint64
+	// done unblocks the callbacks of tcp-tracer when the tracer stops.
+	done chan struct{}
+	lost uint64
 }
 
 func (t *tcpTracer) TCPEventV4(ev tracer.TcpV4) {
-	t.evChan <- ev
+	select {
+	case t.evChan <- ev:
+	case <-t.done:
+	}
 }
 
 func (t *tcpTracer) TCPEventV6(ev tracer.TcpV6) {
-	t.evChan <- ev
+	select {
+	case t.evChan <- ev:
+	case <-t.done:
+	}
 }
 
 func (t *tcpTracer) LostV4(count uint64) {
@@ -57,13 +67,23 @@ func IsSupportedLinux() (bool, error) {
 	return true, nil
 }
 
-// StartTracer starts an ebpf tracing process.
-func StartTracer(cb func(*probe.HostFlow)) error {
+// StartTracer starts an ebpf tracing process. It blocks until ctx is
+// canceled, and then detaches the kprobes.
+func StartTracer(ctx context.Context, cb func(*probe.HostFlow)) error {
+	return StartTracerWithHeartbeat(ctx, cb, nil, 0)
+}
+
+// StartTracerWithHeartbeat is StartTracer calling heartbeat from the event
+// loop of the tracer when it starts and every interval, so that the caller
+// knows that the tracer is alive even while no connection is traced.
+func StartTracerWithHeartbeat(ctx context.Context, cb func(*probe.HostFlow), heartbeat func(), interval time.Duration) error {
 	t := &tcpTracer{}
 	t.evChan = make(chan interface{})
+	t.done = make(chan struct{})
 	tr, err := tracer.NewTracer(t)
 	if err != nil {
-		return xerrors.Errorf("failed to create an instance of tcp-tracer: %w", err)
+		return xerrors.Errorf("failed to create an instance of tcp-tracer: %w",
+			netutil.ClassifyError("load tcp-tracer", "eBPF kprobes", err))
 	}
 
 	tr.Start()
@@ -71,7 +91,27 @@ func StartTracer(cb func(*probe.HostFlow)) error {
 	// TODO: scan /proc
 	// Should tr.AddFdInstallWatcher be executed each listening process here?
 
-	for ev := range t.evChan {
+	var beat <-chan time.Time
+	if heartbeat != nil {
+		heartbeat()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		beat = ticker.C
+	}
+
+	for {
+		var ev interface{}
+		select {
+		case <-ctx.Done():
+			close(t.done)
+			tr.Stop()
+			return nil
+		case <-beat:
+			heartbeat()
+			continue
+		case ev = <-t.evChan:
+		}
+
 		switch v := ev.(type) {
 		case tracer.TcpV4:
 			var pgid int
@@ -84,24 +124,27 @@ func StartTracer(cb func(*probe.HostFlow)) error {
 			}
 			proc := &probe.Process{Name: v.Comm, Pgid: pgid}
 
+			var flow *probe.HostFlow
 			if v.Type == tracer.EventConnect {
-				cb(&probe.HostFlow{
+				flow = &probe.HostFlow{
 					Direction: probe.FlowActive,
-					Local:     &probe.AddrPort{Addr: v.SAddr.String(), Port: "many"},
-					Peer:      &probe.AddrPort{Addr: v.DAddr.String(), Port: fmt.Sprintf("%d", v.DPort)},
+					Local:     &probe.AddrPort{Addr: v.SAddr.String(), Aggregated: true},
+					Peer:      &probe.AddrPort{Addr: v.DAddr.String(), Port: v.DPort},
 					Process:   proc,
-				})
+				}
 			} else if v.Type == tracer.EventAccept {
-				cb(&probe.HostFlow{
+				flow = &probe.HostFlow{
 					Direction: probe.FlowPassive,
-					Local:     &probe.AddrPort{Addr: v.SAddr.String(), Port: fmt.Sprintf("%d", v.SPort)},
-					Peer:      &probe.AddrPort{Addr: v.DAddr.String(), Port: "many"},
+					Local:     &probe.AddrPort{Addr: v.SAddr.String(), Port: v.SPort},
+					Peer:      &probe.AddrPort{Addr: v.DAddr.String(), Aggregated: true},
 					Process:   proc,
-				})
+				}
+			}
+			if flow != nil {
+				probe.CurrentNodeIdentity().Apply(flow)
+				cb(flow)
 			}
 			// TODO: handling close
 		}
 	}
-
-	return nil
 }
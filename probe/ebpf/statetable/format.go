@@ -0,0 +1,119 @@
+package statetable
+
+import (
+	"bufio"
+	"io"
+	"strconv"
+	"strings"
+
+	"golang.org/x/xerrors"
+)
+
+// field is a field of the records of a tracepoint.
+type field struct {
+	offset int
+	size   int
+}
+
+// format is the format of the records of a tracepoint, which is read from
+// events/<system>/<event>/format of tracefs.
+type format struct {
+	id     uint64
+	fields map[string]field
+}
+
+// parseFormat parses the format of a tracepoint such as
+//
+//	name: inet_sock_set_state
+//	ID: 1395
+//	format:
+//		field:unsigned short common_type;	offset:0;	size:2;	signed:0;
+//		...
+//		field:int newstate;	offset:20;	size:4;	signed:1;
+//		field:__u8 saddr_v6[16];	offset:52;	size:16;	signed:0;
+func parseFormat(r io.Reader) (*format, error) {
+	f := &format{fields: map[string]field{}}
+	hasID := false
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		switch {
+		case strings.HasPrefix(line, "ID:"):
+			id, err := strconv.ParseUint(strings.TrimSpace(line[len("ID:"):]), 10, 64)
+			if err != nil {
+				return nil, xerrors.Errorf("could not parse '%s': %w", line, err)
+			}
+			f.id, hasID = id, true
+		case strings.HasPrefix(line, "field:"):
+			name, fd, err := parseField(line)
+			if err != nil {
+				return nil, err
+			}
+			f.fields[name] = fd
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, xerrors.Errorf("could not read the format: %w", err)
+	}
+	if !hasID {
+		return nil, xerrors.New("the format has no ID")
+	}
+	return f, nil
+}
+
+// parseField parses a line of a field such as
+// 'field:__u8 saddr_v6[16];	offset:52;	size:16;	signed:0;'.
+func parseField(line string) (string, field, error) {
+	var (
+		name string
+		fd   field
+	)
+	hasOffset, hasSize := false, false
+	for _, kv := range strings.Split(line, ";") {
+		kv = strings.TrimSpace(kv)
+		i := strings.Index(kv, ":")
+		if i < 0 {
+			continue
+		}
+		k, v := kv[:i], kv[i+1:]
+		switch k {
+		case "field":
+			decl := strings.Fields(v)
+			if len(decl) == 0 {
+				return "", field{}, xerrors.Errorf("'%s' should have the declaration of the field", line)
+			}
+			name = decl[len(decl)-1]
+			if j := strings.Index(name, "["); j >= 0 {
+				name = name[:j]
+			}
+		case "offset", "size":
+			n, err := strconv.Atoi(v)
+			if err != nil {
+				return "", field{}, xerrors.Errorf("could not parse '%s' of '%s': %w", kv, line, err)
+			}
+			if k == "offset" {
+				fd.offset, hasOffset = n, true
+			} else {
+				fd.size, hasSize = n, true
+			}
+		}
+	}
+	if name == "" || !hasOffset || !hasSize {
+		return "", field{}, xerrors.Errorf("'%s' should have the name, the offset and the size of the field", line)
+	}
+	return name, fd, nil
+}
+
+// lookup returns the field of the name, which has the size in sizes.
+func (f *format) lookup(name string, sizes ...int) (field, error) {
+	fd, ok := f.fields[name]
+	if !ok {
+		return field{}, xerrors.Errorf("the tracepoint has no field '%s'", name)
+	}
+	for _, size := range sizes {
+		if fd.size == size {
+			return fd, nil
+		}
+	}
+	return field{}, xerrors.Errorf("the field '%s' should be of %v bytes, but %d", name, sizes, fd.size)
+}
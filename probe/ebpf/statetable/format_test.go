@@ -0,0 +1,83 @@
+package statetable
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// formatV5 is the format of sock:inet_sock_set_state on Linux 5.x.
+const formatV5 = `name: inet_sock_set_state
+ID: 1395
+format:
+	field:unsigned short common_type;	offset:0;	size:2;	signed:0;
+	field:unsigned char common_flags;	offset:2;	size:1;	signed:0;
+	field:unsigned char common_preempt_count;	offset:3;	size:1;	signed:0;
+	field:int common_pid;	offset:4;	size:4;	signed:1;
+
+	field:const void * skaddr;	offset:8;	size:8;	signed:0;
+	field:int oldstate;	offset:16;	size:4;	signed:1;
+	field:int newstate;	offset:20;	size:4;	signed:1;
+	field:__u16 sport;	offset:24;	size:2;	signed:0;
+	field:__u16 dport;	offset:26;	size:2;	signed:0;
+	field:__u16 family;	offset:28;	size:2;	signed:0;
+	field:__u16 protocol;	offset:30;	size:2;	signed:0;
+	field:__u8 saddr[4];	offset:32;	size:4;	signed:0;
+	field:__u8 daddr[4];	offset:36;	size:4;	signed:0;
+	field:__u8 saddr_v6[16];	offset:40;	size:16;	signed:0;
+	field:__u8 daddr_v6[16];	offset:56;	size:16;	signed:0;
+
+print fmt: "family=%s protocol=%s sport=%hu dport=%hu"
+`
+
+func TestParseFormat(t *testing.T) {
+	f, err := parseFormat(strings.NewReader(formatV5))
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if f.id != 1395 {
+		t.Errorf("id should be 1395, but %d", f.id)
+	}
+	got := map[string]field{}
+	for _, name := range []string{"newstate", "family", "protocol", "saddr_v6", "skaddr"} {
+		got[name] = f.fields[name]
+	}
+	want := map[string]field{
+		"newstate": {offset: 20, size: 4},
+		"family":   {offset: 28, size: 2},
+		"protocol": {offset: 30, size: 2},
+		"saddr_v6": {offset: 40, size: 16},
+		"skaddr":   {offset: 8, size: 8},
+	}
+	if diff := cmp.Diff(want, got, cmp.AllowUnexported(field{})); diff != "" {
+		t.Errorf("parseFormat() mismatch (-want +got):\n%s", diff)
+	}
+
+	if _, err := f.lookup("protocol", 1, 2); err != nil {
+		t.Errorf("lookup() should not raise error: %v", err)
+	}
+	if _, err := f.lookup("newstate", 2); err == nil {
+		t.Error("lookup() should raise error for the unexpected size")
+	}
+	if _, err := f.lookup("unknown", 4); err == nil {
+		t.Error("lookup() should raise error for the unknown field")
+	}
+}
+
+func TestParseFormat_error(t *testing.T) {
+	tests := map[string]string{
+		"no id":      "format:\n\tfield:int newstate;\toffset:20;\tsize:4;\tsigned:1;\n",
+		"bad id":     "ID: x\n",
+		"no offset":  "ID: 1\nformat:\n\tfield:int newstate;\tsize:4;\tsigned:1;\n",
+		"bad size":   "ID: 1\nformat:\n\tfield:int newstate;\toffset:20;\tsize:x;\tsigned:1;\n",
+		"empty decl": "ID: 1\nformat:\n\tfield:;\toffset:20;\tsize:4;\tsigned:1;\n",
+	}
+	for name, in := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := parseFormat(strings.NewReader(in)); err == nil {
+				t.Error("parseFormat() should raise error")
+			}
+		})
+	}
+}
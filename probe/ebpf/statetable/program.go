@@ -0,0 +1,318 @@
+package statetable
+
+import (
+	"encoding/binary"
+
+	"golang.org/x/xerrors"
+)
+
+// The opcodes of the eBPF instructions used by the program. The program is
+// assembled here instead of compiled by clang, so that the probe needs
+// neither clang nor the kernel headers on the host.
+const (
+	opLdxB  = 0x71 // dst = *(u8 *)(src + off)
+	opLdxH  = 0x69 // dst = *(u16 *)(src + off)
+	opLdxW  = 0x61 // dst = *(u32 *)(src + off)
+	opLdxDW = 0x79 // dst = *(u64 *)(src + off)
+	opStxB  = 0x73 // *(u8 *)(dst + off) = src
+	opStxH  = 0x6b // *(u16 *)(dst + off) = src
+	opStxW  = 0x63 // *(u32 *)(dst + off) = src
+	opStxDW = 0x7b // *(u64 *)(dst + off) = src
+	opStDW  = 0x7a // *(u64 *)(dst + off) = imm
+	opMovX  = 0xbf // dst = src
+	opMovK  = 0xb7 // dst = imm
+	opAddK  = 0x07 // dst += imm
+	opRshK  = 0x77 // dst >>= imm
+	opLdDW  = 0x18 // dst = imm64, which takes two instructions
+	opJa    = 0x05 // goto off
+	opJeqK  = 0x15 // if dst == imm goto off
+	opJneK  = 0x55 // if dst != imm goto off
+	opCall  = 0x85 // call imm
+	opExit  = 0x95 // return r0
+	insnLen = 8
+
+	// pseudoMapFD marks the immediate of opLdDW as the fd of a map.
+	pseudoMapFD = 1
+
+	funcMapLookupElem     = 1
+	funcMapUpdateElem     = 2
+	funcMapDeleteElem     = 3
+	funcGetCurrentPidTgid = 14
+)
+
+const (
+	r0 = iota
+	r1
+	r2
+	r3
+	r4
+	r5
+	r6
+	r7
+	r8
+	r9
+	r10 // frame pointer
+)
+
+// The TCP states of the kernel, which are the values of newstate.
+const (
+	tcpSynSent = 2
+	tcpClose   = 7
+	tcpListen  = 10
+
+	ipprotoTCP = 6
+)
+
+// The key and the value of the table on the stack of the program. The key
+// is the family, the ports in host byte order and the addresses, which are
+// IPv4-mapped for AF_INET. The value is the state, the pid opening the
+// socket, and the inode of the socket found by the netlink at start.
+//
+// The pids of the sockets connecting are kept in another map by the
+// addresses of the sockets, because connect(2) changes the state before
+// binding the local port.
+const (
+	keySize   = 40
+	valueSize = 16
+
+	pidKeySize   = 8
+	pidValueSize = 4
+
+	keyOff      = -keySize
+	valueOff    = keyOff - valueSize
+	pidKeyOff   = valueOff - pidKeySize
+	pidValueOff = pidKeyOff - 8
+
+	keyFamily = 0
+	keySport  = 2
+	keyDport  = 4
+	keySaddr  = 8
+	keyDaddr  = 24
+
+	valueState = 0
+	valuePid   = 4
+	valueInode = 8
+)
+
+// insn is an eBPF instruction. jump is the label of the target of a jump,
+// which is resolved into off by assemble.
+type insn struct {
+	op   uint8
+	dst  uint8
+	src  uint8
+	off  int16
+	imm  int32
+	jump string
+}
+
+// asm is a program being assembled.
+type asm struct {
+	insns  []insn
+	labels map[string]int
+}
+
+func (a *asm) emit(i insn) {
+	a.insns = append(a.insns, i)
+}
+
+func (a *asm) label(name string) {
+	a.labels[name] = len(a.insns)
+}
+
+// ldMapFD loads the map into dst, which takes two instructions.
+func (a *asm) ldMapFD(dst uint8, fd int) {
+	a.emit(insn{op: opLdDW, dst: dst, src: pseudoMapFD, imm: int32(fd)})
+	a.emit(insn{})
+}
+
+// assemble resolves the labels and encodes the instructions.
+func (a *asm) assemble(order binary.ByteOrder) ([]byte, error) {
+	b := make([]byte, len(a.insns)*insnLen)
+	for pc, i := range a.insns {
+		if i.jump != "" {
+			target, ok := a.labels[i.jump]
+			if !ok {
+				return nil, xerrors.Errorf("undefined label '%s'", i.jump)
+			}
+			i.off = int16(target - pc - 1)
+		}
+		p := b[pc*insnLen:]
+		p[0] = i.op
+		// The registers are bitfields, whose order follows the byte order.
+		if order == binary.BigEndian {
+			p[1] = i.dst<<4 | i.src
+		} else {
+			p[1] = i.src<<4 | i.dst
+		}
+		order.PutUint16(p[2:], uint16(i.off))
+		order.PutUint32(p[4:], uint32(i.imm))
+	}
+	return b, nil
+}
+
+// ldxOp returns the load of the size of a field.
+func ldxOp(size int) uint8 {
+	switch size {
+	case 1:
+		return opLdxB
+	case 2:
+		return opLdxH
+	}
+	return opLdxW
+}
+
+// buildProgram assembles the program attached to sock:inet_sock_set_state of
+// the format, which keeps the TCP sockets in the table of tableFD by their
+// state changes. The sockets are deleted on TCP_CLOSE. The pid is recorded
+// on TCP_SYN_SENT into the map of pidsFD and on TCP_LISTEN, which change in
+// the context of the process calling connect(2) or listen(2).
+func buildProgram(f *format, tableFD, pidsFD int, order binary.ByteOrder) ([]byte, error) {
+	skaddr, err := f.lookup("skaddr", 8)
+	if err != nil {
+		return nil, err
+	}
+	protocol, err := f.lookup("protocol", 1, 2)
+	if err != nil {
+		return nil, err
+	}
+	newstate, err := f.lookup("newstate", 4)
+	if err != nil {
+		return nil, err
+	}
+	family, err := f.lookup("family", 2)
+	if err != nil {
+		return nil, err
+	}
+	sport, err := f.lookup("sport", 2)
+	if err != nil {
+		return nil, err
+	}
+	dport, err := f.lookup("dport", 2)
+	if err != nil {
+		return nil, err
+	}
+	saddr, err := f.lookup("saddr_v6", 16)
+	if err != nil {
+		return nil, err
+	}
+	daddr, err := f.lookup("daddr_v6", 16)
+	if err != nil {
+		return nil, err
+	}
+
+	a := &asm{labels: map[string]int{}}
+	a.emit(insn{op: opMovX, dst: r6, src: r1})
+	a.emit(insn{op: ldxOp(protocol.size), dst: r2, src: r6, off: int16(protocol.offset)})
+	a.emit(insn{op: opJneK, dst: r2, imm: ipprotoTCP, jump: "exit"})
+
+	// The key is zeroed for the verifier, which rejects reading the
+	// uninitialized stack.
+	for off := keyOff; off < 0; off += 8 {
+		a.emit(insn{op: opStDW, dst: r10, off: int16(off)})
+	}
+	for _, c := range []struct {
+		fd  field
+		off int
+	}{
+		{family, keyFamily},
+		{sport, keySport},
+		{dport, keyDport},
+	} {
+		a.emit(insn{op: opLdxH, dst: r2, src: r6, off: int16(c.fd.offset)})
+		a.emit(insn{op: opStxH, dst: r10, src: r2, off: int16(keyOff + c.off)})
+	}
+	// The addresses are copied by bytes, because they are unaligned in the
+	// records of some kernels.
+	for _, c := range []struct {
+		fd  field
+		off int
+	}{
+		{saddr, keySaddr},
+		{daddr, keyDaddr},
+	} {
+		for i := 0; i < 16; i++ {
+			a.emit(insn{op: opLdxB, dst: r2, src: r6, off: int16(c.fd.offset + i)})
+			a.emit(insn{op: opStxB, dst: r10, src: r2, off: int16(keyOff + c.off + i)})
+		}
+	}
+	a.emit(insn{op: opLdxDW, dst: r2, src: r6, off: int16(skaddr.offset)})
+	a.emit(insn{op: opStxDW, dst: r10, src: r2, off: pidKeyOff})
+
+	a.emit(insn{op: opLdxW, dst: r7, src: r6, off: int16(newstate.offset)})
+	a.emit(insn{op: opJeqK, dst: r7, imm: tcpClose, jump: "delete"})
+	a.emit(insn{op: opJeqK, dst: r7, imm: tcpSynSent, jump: "connect"})
+
+	// The state of a known socket is updated in place, keeping its pid
+	// and inode.
+	a.ldMapFD(r1, tableFD)
+	a.emit(insn{op: opMovX, dst: r2, src: r10})
+	a.emit(insn{op: opAddK, dst: r2, imm: keyOff})
+	a.emit(insn{op: opCall, imm: funcMapLookupElem})
+	a.emit(insn{op: opJeqK, dst: r0, imm: 0, jump: "insert"})
+	a.emit(insn{op: opStxW, dst: r0, src: r7, off: valueState})
+	a.emit(insn{op: opJa, jump: "exit"})
+
+	a.label("insert")
+	a.emit(insn{op: opStDW, dst: r10, off: valueOff})
+	a.emit(insn{op: opStDW, dst: r10, off: valueOff + 8})
+	a.emit(insn{op: opStxW, dst: r10, src: r7, off: valueOff + valueState})
+	a.emit(insn{op: opJneK, dst: r7, imm: tcpListen, jump: "connected"})
+	a.emit(insn{op: opCall, imm: funcGetCurrentPidTgid})
+	a.emit(insn{op: opRshK, dst: r0, imm: 32})
+	a.emit(insn{op: opStxW, dst: r10, src: r0, off: valueOff + valuePid})
+	a.emit(insn{op: opJa, jump: "update"})
+
+	// The socket connected takes the pid recorded on connect(2).
+	a.label("connected")
+	a.ldMapFD(r1, pidsFD)
+	a.emit(insn{op: opMovX, dst: r2, src: r10})
+	a.emit(insn{op: opAddK, dst: r2, imm: pidKeyOff})
+	a.emit(insn{op: opCall, imm: funcMapLookupElem})
+	a.emit(insn{op: opJeqK, dst: r0, imm: 0, jump: "update"})
+	a.emit(insn{op: opLdxW, dst: r2, src: r0, off: 0})
+	a.emit(insn{op: opStxW, dst: r10, src: r2, off: valueOff + valuePid})
+	a.ldMapFD(r1, pidsFD)
+	a.emit(insn{op: opMovX, dst: r2, src: r10})
+	a.emit(insn{op: opAddK, dst: r2, imm: pidKeyOff})
+	a.emit(insn{op: opCall, imm: funcMapDeleteElem})
+
+	a.label("update")
+	a.ldMapFD(r1, tableFD)
+	a.emit(insn{op: opMovX, dst: r2, src: r10})
+	a.emit(insn{op: opAddK, dst: r2, imm: keyOff})
+	a.emit(insn{op: opMovX, dst: r3, src: r10})
+	a.emit(insn{op: opAddK, dst: r3, imm: valueOff})
+	a.emit(insn{op: opMovK, dst: r4, imm: 0}) // BPF_ANY
+	a.emit(insn{op: opCall, imm: funcMapUpdateElem})
+	a.emit(insn{op: opJa, jump: "exit"})
+
+	a.label("connect")
+	a.emit(insn{op: opCall, imm: funcGetCurrentPidTgid})
+	a.emit(insn{op: opRshK, dst: r0, imm: 32})
+	a.emit(insn{op: opStxW, dst: r10, src: r0, off: pidValueOff})
+	a.ldMapFD(r1, pidsFD)
+	a.emit(insn{op: opMovX, dst: r2, src: r10})
+	a.emit(insn{op: opAddK, dst: r2, imm: pidKeyOff})
+	a.emit(insn{op: opMovX, dst: r3, src: r10})
+	a.emit(insn{op: opAddK, dst: r3, imm: pidValueOff})
+	a.emit(insn{op: opMovK, dst: r4, imm: 0}) // BPF_ANY
+	a.emit(insn{op: opCall, imm: funcMapUpdateElem})
+	a.emit(insn{op: opJa, jump: "exit"})
+
+	// The pid is deleted too for the socket failing to connect.
+	a.label("delete")
+	a.ldMapFD(r1, tableFD)
+	a.emit(insn{op: opMovX, dst: r2, src: r10})
+	a.emit(insn{op: opAddK, dst: r2, imm: keyOff})
+	a.emit(insn{op: opCall, imm: funcMapDeleteElem})
+	a.ldMapFD(r1, pidsFD)
+	a.emit(insn{op: opMovX, dst: r2, src: r10})
+	a.emit(insn{op: opAddK, dst: r2, imm: pidKeyOff})
+	a.emit(insn{op: opCall, imm: funcMapDeleteElem})
+
+	a.label("exit")
+	a.emit(insn{op: opMovK, dst: r0, imm: 0})
+	a.emit(insn{op: opExit})
+
+	return a.assemble(order)
+}
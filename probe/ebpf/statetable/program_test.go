@@ -0,0 +1,96 @@
+package statetable
+
+import (
+	"encoding/binary"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestBuildProgram(t *testing.T) {
+	f, err := parseFormat(strings.NewReader(formatV5))
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	prog, err := buildProgram(f, 42, 43, binary.LittleEndian)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if len(prog)%insnLen != 0 {
+		t.Fatalf("the program should consist of %d-byte instructions, but %d bytes", insnLen, len(prog))
+	}
+
+	n := len(prog) / insnLen
+	maps := map[int32]int{}
+	for pc := 0; pc < n; pc++ {
+		p := prog[pc*insnLen:]
+		op, dst, src := p[0], p[1]&0x0f, p[1]>>4
+		off := int16(binary.LittleEndian.Uint16(p[2:]))
+		imm := int32(binary.LittleEndian.Uint32(p[4:]))
+		switch op {
+		case opJa, opJeqK, opJneK:
+			if target := pc + 1 + int(off); target <= pc || target >= n {
+				t.Errorf("the jump at %d should go forward into the program, but to %d", pc, target)
+			}
+		case opLdDW:
+			if src != pseudoMapFD {
+				t.Errorf("the load at %d should be of a map, but src=%d", pc, src)
+			}
+			maps[imm]++
+			pc++ // the second half of the load
+		case opLdxB, opLdxH, opLdxW:
+			if src == r6 && (off < 0 || int(off) >= 72) {
+				t.Errorf("the load at %d should be in the record, but at %d", pc, off)
+			}
+		case opStxB, opStxH, opStxW, opStDW:
+			if dst == r10 && (off < pidValueOff || off >= 0) {
+				t.Errorf("the store at %d should be in the stack of the key and the value, but at %d", pc, off)
+			}
+		}
+	}
+	// The table is looked up, updated and deleted, and the pids are
+	// updated on connect, looked up and deleted on connected, and deleted
+	// on close.
+	if diff := cmp.Diff(map[int32]int{42: 3, 43: 4}, maps); diff != "" {
+		t.Errorf("the references to the maps mismatch (-want +got):\n%s", diff)
+	}
+	if last := prog[len(prog)-insnLen]; last != opExit {
+		t.Errorf("the program should end by exit, but 0x%x", last)
+	}
+}
+
+func TestBuildProgram_oldKernel(t *testing.T) {
+	// The tracepoint has no family before Linux 4.20.
+	old := strings.Replace(formatV5, "\tfield:__u16 family;\toffset:28;\tsize:2;\tsigned:0;\n", "", 1)
+	f, err := parseFormat(strings.NewReader(old))
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if _, err := buildProgram(f, 42, 43, binary.LittleEndian); err == nil {
+		t.Error("buildProgram() should raise error without the family")
+	}
+}
+
+func TestAssemble_bigEndian(t *testing.T) {
+	a := &asm{labels: map[string]int{}}
+	a.emit(insn{op: opJa, jump: "exit"})
+	a.emit(insn{op: opMovX, dst: r6, src: r1})
+	a.label("exit")
+	a.emit(insn{op: opExit})
+	b, err := a.assemble(binary.BigEndian)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if off := int16(binary.BigEndian.Uint16(b[2:])); off != 1 {
+		t.Errorf("the jump should skip an instruction, but %d", off)
+	}
+	if regs := b[insnLen+1]; regs != r6<<4|r1 {
+		t.Errorf("the registers should be 0x%x on big-endian, but 0x%x", r6<<4|r1, regs)
+	}
+
+	a.emit(insn{op: opJa, jump: "undefined"})
+	if _, err := a.assemble(binary.BigEndian); err == nil {
+		t.Error("assemble() should raise error for the undefined label")
+	}
+}
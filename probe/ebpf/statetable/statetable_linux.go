@@ -0,0 +1,402 @@
+// +build linux
+
+// Package statetable keeps a live table of the TCP sockets of the host in a
+// BPF map by an eBPF program on the sock:inet_sock_set_state tracepoint, so
+// that the probe reads the sockets from the map instead of dumping all of
+// them by the netlink on every scan.
+package statetable
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"unsafe"
+
+	"github.com/elastic/gosigar/sys"
+	"github.com/elastic/gosigar/sys/linux"
+	"github.com/yuuki/shawk/probe/netlink/netutil"
+	"golang.org/x/sys/unix"
+	"golang.org/x/xerrors"
+)
+
+// DefaultSize is the default number of the sockets the table holds.
+const DefaultSize = 65536
+
+var byteOrder = sys.GetEndian()
+
+// tracefsDirs are the mount points of tracefs, which is mounted on
+// /sys/kernel/tracing by the recent kernels and under debugfs by the older.
+var tracefsDirs = []string{"/sys/kernel/tracing", "/sys/kernel/debug/tracing"}
+
+const formatPath = "events/sock/inet_sock_set_state/format"
+
+// Table is the table of the TCP sockets kept by the eBPF program. It
+// implements netutil.InetDiag.
+type Table struct {
+	mapFD   int
+	pidsFD  int
+	progFD  int
+	eventFD int
+
+	closeOnce sync.Once
+}
+
+var _ netutil.InetDiag = (*Table)(nil)
+
+// Open loads the program holding up to size sockets, attaches it to the
+// tracepoint, and fills the table with the sockets existing before it by
+// the netlink. The table requires Linux 4.20 or later, whose tracepoint has
+// the family of the sockets, and CAP_SYS_ADMIN.
+func Open(size int) (*Table, error) {
+	if size <= 0 {
+		size = DefaultSize
+	}
+	f, err := readFormat()
+	if err != nil {
+		return nil, err
+	}
+
+	t := &Table{mapFD: -1, pidsFD: -1, progFD: -1, eventFD: -1}
+	t.mapFD, err = createMap(keySize, valueSize, size)
+	if err != nil {
+		return nil, xerrors.Errorf("could not create the BPF map: %w", err)
+	}
+	t.pidsFD, err = createMap(pidKeySize, pidValueSize, size)
+	if err != nil {
+		t.Close()
+		return nil, xerrors.Errorf("could not create the BPF map: %w", err)
+	}
+	prog, err := buildProgram(f, t.mapFD, t.pidsFD, byteOrder)
+	if err != nil {
+		t.Close()
+		return nil, err
+	}
+	t.progFD, err = loadProgram(prog)
+	if err != nil {
+		t.Close()
+		return nil, xerrors.Errorf("could not load the BPF program: %w", err)
+	}
+	t.eventFD, err = attachTracepoint(f.id, t.progFD)
+	if err != nil {
+		t.Close()
+		return nil, xerrors.Errorf("could not attach the BPF program to sock:inet_sock_set_state: %w", err)
+	}
+	if err := t.seed(); err != nil {
+		t.Close()
+		return nil, err
+	}
+	return t, nil
+}
+
+// Close detaches the program and releases the table.
+func (t *Table) Close() error {
+	t.closeOnce.Do(func() {
+		for _, fd := range []int{t.eventFD, t.progFD, t.pidsFD, t.mapFD} {
+			if fd >= 0 {
+				unix.Close(fd)
+			}
+		}
+	})
+	return nil
+}
+
+func readFormat() (*format, error) {
+	var lastErr error
+	for _, dir := range tracefsDirs {
+		r, err := os.Open(filepath.Join(dir, formatPath))
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		defer r.Close()
+		return parseFormat(r)
+	}
+	return nil, xerrors.Errorf("could not read the format of sock:inet_sock_set_state, which requires Linux 4.20 or later and tracefs: %w", lastErr)
+}
+
+// seed fills the table with the sockets by the netlink, which were opened
+// before the program was attached. The sockets in TIME_WAIT are not filled,
+// because they never change the state by the tracepoint. The sockets are
+// dumped again after filling the table, and the filled ones closed between
+// the dumps are deleted, because the program missed their closes.
+func (t *Table) seed() error {
+	d := netutil.CurrentInetDiag()
+	seeded := make(map[[keySize]byte]struct{})
+	for _, family := range []netutil.AddressFamily{netutil.AFInet, netutil.AFInet6} {
+		msgs, err := d.Dump(family)
+		if err != nil {
+			return xerrors.Errorf("could not dump the sockets to fill the table: %w", err)
+		}
+		for _, m := range msgs {
+			if linux.TCPState(m.State) == linux.TCP_TIME_WAIT {
+				continue
+			}
+			key, value := encodeEntry(m)
+			// The sockets changing their states since the attach are
+			// already in the table.
+			err := updateElem(t.mapFD, key[:], value[:], unix.BPF_NOEXIST)
+			switch err {
+			case nil:
+				seeded[key] = struct{}{}
+			case unix.EEXIST:
+			default:
+				return xerrors.Errorf("could not fill the table: %w", err)
+			}
+		}
+	}
+
+	for _, family := range []netutil.AddressFamily{netutil.AFInet, netutil.AFInet6} {
+		msgs, err := d.Dump(family)
+		if err != nil {
+			return xerrors.Errorf("could not dump the sockets to fill the table: %w", err)
+		}
+		for _, m := range msgs {
+			key, _ := encodeEntry(m)
+			delete(seeded, key)
+		}
+	}
+	for key := range seeded {
+		if err := deleteElem(t.mapFD, key[:]); err != nil && err != unix.ENOENT {
+			return xerrors.Errorf("could not delete the closed socket from the table: %w", err)
+		}
+	}
+	return nil
+}
+
+// Dump returns the sockets of the family in the table. The queues and the
+// congestion control algorithms of the sockets are unknown, and the sockets
+// opened after Open have the pids instead of the inodes.
+func (t *Table) Dump(family netutil.AddressFamily) ([]*netutil.InetDiagMsg, error) {
+	var msgs []*netutil.InetDiagMsg
+	err := t.walk(func(key [keySize]byte, value [valueSize]byte) {
+		if m := decodeEntry(key, value); netutil.AddressFamily(m.Family) == family {
+			msgs = append(msgs, m)
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return msgs, nil
+}
+
+// walk calls fn for each entry of the table. The entries changing during
+// the walk may be skipped or seen twice by the kernel, so the keys already
+// seen are skipped.
+func (t *Table) walk(fn func(key [keySize]byte, value [valueSize]byte)) error {
+	seen := make(map[[keySize]byte]struct{})
+	var (
+		key, next [keySize]byte
+		value     [valueSize]byte
+	)
+	cur := []byte(nil)
+	for {
+		if err := getNextKey(t.mapFD, cur, next[:]); err != nil {
+			if err == unix.ENOENT {
+				return nil
+			}
+			return xerrors.Errorf("could not walk the table: %w", err)
+		}
+		key = next
+		cur = key[:]
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		if err := lookupElem(t.mapFD, key[:], value[:]); err != nil {
+			if err == unix.ENOENT {
+				// closed during the walk
+				continue
+			}
+			return xerrors.Errorf("could not look up the table: %w", err)
+		}
+		fn(key, value)
+	}
+}
+
+// encodeEntry returns the key and the value of the socket in the table.
+func encodeEntry(m *netutil.InetDiagMsg) (key [keySize]byte, value [valueSize]byte) {
+	byteOrder.PutUint16(key[keyFamily:], uint16(m.Family))
+	byteOrder.PutUint16(key[keySport:], uint16(m.SrcPort()))
+	byteOrder.PutUint16(key[keyDport:], uint16(m.DstPort()))
+	if netutil.AddressFamily(m.Family) == netutil.AFInet {
+		// The tracepoint maps the IPv4 addresses into IPv6.
+		key[keySaddr+10], key[keySaddr+11] = 0xff, 0xff
+		key[keyDaddr+10], key[keyDaddr+11] = 0xff, 0xff
+		copy(key[keySaddr+12:keySaddr+16], m.ID.Src[:4])
+		copy(key[keyDaddr+12:keyDaddr+16], m.ID.Dst[:4])
+	} else {
+		copy(key[keySaddr:keySaddr+16], m.ID.Src[:])
+		copy(key[keyDaddr:keyDaddr+16], m.ID.Dst[:])
+	}
+	byteOrder.PutUint32(value[valueState:], uint32(m.State))
+	byteOrder.PutUint32(value[valueInode:], m.Inode)
+	return key, value
+}
+
+// decodeEntry returns the socket of the entry of the table.
+func decodeEntry(key [keySize]byte, value [valueSize]byte) *netutil.InetDiagMsg {
+	m := &netutil.InetDiagMsg{}
+	m.Family = uint8(byteOrder.Uint16(key[keyFamily:]))
+	m.State = uint8(byteOrder.Uint32(value[valueState:]))
+	putPort(m.ID.SPort[:], byteOrder.Uint16(key[keySport:]))
+	putPort(m.ID.DPort[:], byteOrder.Uint16(key[keyDport:]))
+	if netutil.AddressFamily(m.Family) == netutil.AFInet {
+		copy(m.ID.Src[:4], key[keySaddr+12:keySaddr+16])
+		copy(m.ID.Dst[:4], key[keyDaddr+12:keyDaddr+16])
+	} else {
+		copy(m.ID.Src[:], key[keySaddr:keySaddr+16])
+		copy(m.ID.Dst[:], key[keyDaddr:keyDaddr+16])
+	}
+	m.Pid = byteOrder.Uint32(value[valuePid:])
+	m.Inode = byteOrder.Uint32(value[valueInode:])
+	return m
+}
+
+// putPort writes the port in big-endian as inet_diag_sockid.
+func putPort(b []byte, port uint16) {
+	b[0], b[1] = byte(port>>8), byte(port)
+}
+
+func bpf(cmd int, attr unsafe.Pointer, size uintptr) (uintptr, error) {
+	r, _, errno := unix.Syscall(unix.SYS_BPF, uintptr(cmd), uintptr(attr), size)
+	if errno != 0 {
+		return 0, errno
+	}
+	return r, nil
+}
+
+func ptr(b []byte) uint64 {
+	if len(b) == 0 {
+		return 0
+	}
+	return uint64(uintptr(unsafe.Pointer(&b[0])))
+}
+
+type mapCreateAttr struct {
+	mapType    uint32
+	keySize    uint32
+	valueSize  uint32
+	maxEntries uint32
+	mapFlags   uint32
+}
+
+func createMap(keySize, valueSize uint32, size int) (int, error) {
+	attr := mapCreateAttr{
+		mapType:    unix.BPF_MAP_TYPE_HASH,
+		keySize:    keySize,
+		valueSize:  valueSize,
+		maxEntries: uint32(size),
+	}
+	fd, err := bpf(unix.BPF_MAP_CREATE, unsafe.Pointer(&attr), unsafe.Sizeof(attr))
+	return int(fd), err
+}
+
+type mapElemAttr struct {
+	mapFD uint32
+	_     uint32
+	key   uint64
+	value uint64 // or next_key
+	flags uint64
+}
+
+func lookupElem(fd int, key, value []byte) error {
+	attr := mapElemAttr{mapFD: uint32(fd), key: ptr(key), value: ptr(value)}
+	_, err := bpf(unix.BPF_MAP_LOOKUP_ELEM, unsafe.Pointer(&attr), unsafe.Sizeof(attr))
+	runtime.KeepAlive(key)
+	runtime.KeepAlive(value)
+	return err
+}
+
+func updateElem(fd int, key, value []byte, flags uint64) error {
+	attr := mapElemAttr{mapFD: uint32(fd), key: ptr(key), value: ptr(value), flags: flags}
+	_, err := bpf(unix.BPF_MAP_UPDATE_ELEM, unsafe.Pointer(&attr), unsafe.Sizeof(attr))
+	runtime.KeepAlive(key)
+	runtime.KeepAlive(value)
+	return err
+}
+
+func deleteElem(fd int, key []byte) error {
+	attr := mapElemAttr{mapFD: uint32(fd), key: ptr(key)}
+	_, err := bpf(unix.BPF_MAP_DELETE_ELEM, unsafe.Pointer(&attr), unsafe.Sizeof(attr))
+	runtime.KeepAlive(key)
+	return err
+}
+
+// getNextKey writes the key next to key into next, or the first key if key
+// is nil.
+func getNextKey(fd int, key, next []byte) error {
+	attr := mapElemAttr{mapFD: uint32(fd), key: ptr(key), value: ptr(next)}
+	_, err := bpf(unix.BPF_MAP_GET_NEXT_KEY, unsafe.Pointer(&attr), unsafe.Sizeof(attr))
+	runtime.KeepAlive(key)
+	runtime.KeepAlive(next)
+	return err
+}
+
+type progLoadAttr struct {
+	progType    uint32
+	insnCnt     uint32
+	insns       uint64
+	license     uint64
+	logLevel    uint32
+	logSize     uint32
+	logBuf      uint64
+	kernVersion uint32
+	progFlags   uint32
+}
+
+// loadProgram loads the program, and loads it again with the log of the
+// verifier on failure to return the reason.
+func loadProgram(prog []byte) (int, error) {
+	license := []byte("GPL\x00")
+	log := make([]byte, 1<<16)
+	defer runtime.KeepAlive(prog)
+	defer runtime.KeepAlive(license)
+	defer runtime.KeepAlive(log)
+
+	attr := progLoadAttr{
+		progType: unix.BPF_PROG_TYPE_TRACEPOINT,
+		insnCnt:  uint32(len(prog) / insnLen),
+		insns:    ptr(prog),
+		license:  ptr(license),
+	}
+	fd, err := bpf(unix.BPF_PROG_LOAD, unsafe.Pointer(&attr), unsafe.Sizeof(attr))
+	if err == nil {
+		return int(fd), nil
+	}
+	attr.logLevel, attr.logSize, attr.logBuf = 1, uint32(len(log)), ptr(log)
+	if fd, err := bpf(unix.BPF_PROG_LOAD, unsafe.Pointer(&attr), unsafe.Sizeof(attr)); err == nil {
+		return int(fd), nil
+	}
+	if msg := strings.TrimSpace(strings.TrimRight(string(log), "\x00")); msg != "" {
+		return -1, xerrors.Errorf("%w: %s", err, msg)
+	}
+	return -1, err
+}
+
+// attachTracepoint attaches the program to the tracepoint of id by a perf
+// event, which runs the program on all the CPUs.
+func attachTracepoint(id uint64, progFD int) (int, error) {
+	attr := unix.PerfEventAttr{
+		Type:        unix.PERF_TYPE_TRACEPOINT,
+		Config:      id,
+		Sample_type: unix.PERF_SAMPLE_RAW,
+		Sample:      1,
+		Wakeup:      1,
+	}
+	attr.Size = uint32(unsafe.Sizeof(attr))
+	fd, err := unix.PerfEventOpen(&attr, -1, 0, -1, unix.PERF_FLAG_FD_CLOEXEC)
+	if err != nil {
+		return -1, xerrors.Errorf("could not open the perf event: %w", err)
+	}
+	if err := unix.IoctlSetInt(fd, unix.PERF_EVENT_IOC_SET_BPF, progFD); err != nil {
+		unix.Close(fd)
+		return -1, xerrors.Errorf("could not set the BPF program: %w", err)
+	}
+	if err := unix.IoctlSetInt(fd, unix.PERF_EVENT_IOC_ENABLE, 0); err != nil {
+		unix.Close(fd)
+		return -1, xerrors.Errorf("could not enable the perf event: %w", err)
+	}
+	return fd, nil
+}
@@ -0,0 +1,146 @@
+// +build linux
+
+package statetable
+
+import (
+	"net"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/elastic/gosigar/sys/linux"
+	"github.com/google/go-cmp/cmp"
+	"github.com/yuuki/shawk/probe/netlink/netutil"
+)
+
+func newMsg(family netutil.AddressFamily, src, dst string, sport, dport uint16, inode uint32) *netutil.InetDiagMsg {
+	m := &netutil.InetDiagMsg{}
+	m.Family = uint8(family)
+	m.State = uint8(linux.TCP_ESTABLISHED)
+	putPort(m.ID.SPort[:], sport)
+	putPort(m.ID.DPort[:], dport)
+	if family == netutil.AFInet {
+		copy(m.ID.Src[:4], net.ParseIP(src).To4())
+		copy(m.ID.Dst[:4], net.ParseIP(dst).To4())
+	} else {
+		copy(m.ID.Src[:], net.ParseIP(src).To16())
+		copy(m.ID.Dst[:], net.ParseIP(dst).To16())
+	}
+	m.Inode = inode
+	return m
+}
+
+func TestEncodeEntry(t *testing.T) {
+	tests := []*netutil.InetDiagMsg{
+		newMsg(netutil.AFInet, "10.0.0.1", "10.0.0.2", 80, 40000, 100),
+		newMsg(netutil.AFInet6, "fe80::1", "fe80::2", 443, 50000, 200),
+	}
+	for _, m := range tests {
+		key, value := encodeEntry(m)
+		got := decodeEntry(key, value)
+		if diff := cmp.Diff(m, got); diff != "" {
+			t.Errorf("decodeEntry(encodeEntry()) mismatch (-want +got):\n%s", diff)
+		}
+	}
+}
+
+func TestDecodeEntry(t *testing.T) {
+	// The entry written by the program for a connect(2) to 10.0.0.2:443,
+	// whose addresses are IPv4-mapped.
+	var (
+		key   [keySize]byte
+		value [valueSize]byte
+	)
+	byteOrder.PutUint16(key[keyFamily:], uint16(netutil.AFInet))
+	byteOrder.PutUint16(key[keySport:], 40000)
+	byteOrder.PutUint16(key[keyDport:], 443)
+	copy(key[keySaddr:], net.ParseIP("10.0.0.1").To16())
+	copy(key[keyDaddr:], net.ParseIP("10.0.0.2").To16())
+	byteOrder.PutUint32(value[valueState:], tcpSynSent)
+	byteOrder.PutUint32(value[valuePid:], 1234)
+
+	m := decodeEntry(key, value)
+	got := []interface{}{m.SrcIP().String(), m.DstIP().String(), m.SrcPort(), m.DstPort(), linux.TCPState(m.State), m.Pid, m.Inode}
+	want := []interface{}{"10.0.0.1", "10.0.0.2", 40000, 443, linux.TCP_SYN_SENT, uint32(1234), uint32(0)}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("decodeEntry() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+// TestOpen loads the program into the kernel, and checks that the table is
+// filled with the sockets existing before and opened after it. It requires
+// root and tracefs, and is skipped otherwise.
+func TestOpen(t *testing.T) {
+	if os.Geteuid() != 0 {
+		t.Skip("loading the BPF program requires root")
+	}
+	if _, err := readFormat(); err != nil {
+		t.Skipf("%+v", err)
+	}
+
+	ln, err := net.Listen("tcp4", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	defer ln.Close()
+	lport := uint16(ln.Addr().(*net.TCPAddr).Port)
+
+	table, err := Open(1024)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	defer table.Close()
+
+	conn, err := net.Dial("tcp4", ln.Addr().String())
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	defer conn.Close()
+	peer, err := ln.Accept()
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	defer peer.Close()
+	cport := uint16(conn.LocalAddr().(*net.TCPAddr).Port)
+
+	find := func(sport, dport uint16) *netutil.InetDiagMsg {
+		msgs, err := table.Dump(netutil.AFInet)
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		for _, m := range msgs {
+			if uint16(m.SrcPort()) == sport && uint16(m.DstPort()) == dport {
+				return m
+			}
+		}
+		return nil
+	}
+
+	// The listener is filled by the netlink at Open.
+	if m := find(lport, 0); m == nil || linux.TCPState(m.State) != linux.TCP_LISTEN || m.Inode == 0 {
+		t.Errorf("the listener existing before Open should be in the table with its inode, but %+v", m)
+	}
+	// The connection is filled by the program.
+	m := find(cport, lport)
+	if m == nil {
+		t.Fatalf("the connection opened after Open should be in the table")
+	}
+	got := []interface{}{m.SrcIP().String(), m.DstIP().String(), linux.TCPState(m.State), m.Pid}
+	want := []interface{}{"127.0.0.1", "127.0.0.1", linux.TCP_ESTABLISHED, uint32(os.Getpid())}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("the connection mismatch (-want +got):\n%s", diff)
+	}
+
+	// The connection reset by SO_LINGER 0 is closed at once and deleted.
+	if err := conn.(*net.TCPConn).SetLinger(0); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	conn.Close()
+	deadline := time.Now().Add(time.Second)
+	for find(cport, lport) != nil {
+		if time.Now().After(deadline) {
+			t.Fatalf("the closed connection should be deleted from the table")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -0,0 +1,99 @@
+package probe
+
+import (
+	"net"
+	"sync"
+)
+
+// LabelNodeName is the label of the name of the host given by --node-name.
+const LabelNodeName = "host.name"
+
+// NodeIdentity is the stable identity of the host. A multi-homed host opens
+// sockets on whichever address the routes choose, so that the host would be
+// fragmented into a node per address without it.
+type NodeIdentity struct {
+	// Name labels the endpoints of the host if not empty.
+	Name string
+	// IP replaces the addresses of the host if not empty.
+	IP string
+
+	hostAddrs map[string]struct{}
+}
+
+// NewNodeIdentity creates a NodeIdentity. hostAddrs are the addresses of the
+// host, which are replaced by ip also when they appear as the peer of a flow.
+func NewNodeIdentity(name, ip string, hostAddrs []string) *NodeIdentity {
+	n := &NodeIdentity{Name: name, IP: ip, hostAddrs: make(map[string]struct{}, len(hostAddrs))}
+	for _, a := range hostAddrs {
+		n.hostAddrs[a] = struct{}{}
+	}
+	return n
+}
+
+// isHostAddr returns whether addr is an address of the host to be replaced.
+// Loopback addresses are kept because they never leave the host.
+func (n *NodeIdentity) isHostAddr(addr string, local bool) bool {
+	if ip := net.ParseIP(addr); ip == nil || ip.IsLoopback() {
+		return false
+	}
+	if local {
+		return true
+	}
+	_, ok := n.hostAddrs[addr]
+	return ok
+}
+
+// Apply replaces the addresses of the host in the flow with n.IP, and labels
+// the endpoints of the host with n.Name.
+func (n *NodeIdentity) Apply(f *HostFlow) {
+	if n == nil {
+		return
+	}
+	for _, e := range []struct {
+		a     *AddrPort
+		local bool
+	}{{f.Local, true}, {f.Peer, false}} {
+		if !n.isHostAddr(e.a.Addr, e.local) {
+			continue
+		}
+		if n.IP != "" {
+			e.a.Addr = n.IP
+		}
+		if n.Name != "" {
+			e.a.SetLabel(LabelNodeName, n.Name)
+		}
+	}
+}
+
+// Rewrite applies n to the flows, merging the flows which have become
+// identical. It returns flows as it is if n is nil.
+func (n *NodeIdentity) Rewrite(flows HostFlows) HostFlows {
+	if n == nil {
+		return flows
+	}
+	rewritten := make(HostFlows, len(flows))
+	for _, f := range flows {
+		n.Apply(f)
+		rewritten.Merge(f)
+	}
+	return rewritten
+}
+
+var (
+	nodeIdentityMu sync.RWMutex
+	nodeIdentity   *NodeIdentity
+)
+
+// CurrentNodeIdentity returns the identity set by SetNodeIdentity, or nil.
+func CurrentNodeIdentity() *NodeIdentity {
+	nodeIdentityMu.RLock()
+	defer nodeIdentityMu.RUnlock()
+	return nodeIdentity
+}
+
+// SetNodeIdentity makes the probes record the flows under n.
+func SetNodeIdentity(n *NodeIdentity) {
+	nodeIdentityMu.Lock()
+	defer nodeIdentityMu.Unlock()
+	nodeIdentity = n
+}
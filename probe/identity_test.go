@@ -0,0 +1,96 @@
+package probe
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestNodeIdentity_Rewrite(t *testing.T) {
+	n := NewNodeIdentity("web-1", "10.0.0.1", []string{"10.0.0.1", "10.0.1.1", "192.168.0.1"})
+
+	flows := HostFlows{}
+	for _, f := range []*HostFlow{
+		// the same peer reached from two interfaces
+		{
+			Direction:   FlowActive,
+			Local:       &AddrPort{Addr: "10.0.1.1", Aggregated: true},
+			Peer:        &AddrPort{Addr: "10.0.2.10", Port: 5432},
+			Connections: 2,
+		},
+		{
+			Direction:   FlowActive,
+			Local:       &AddrPort{Addr: "192.168.0.1", Aggregated: true},
+			Peer:        &AddrPort{Addr: "10.0.2.10", Port: 5432},
+			Connections: 3,
+		},
+		// a connection inside the host
+		{
+			Direction:   FlowActive,
+			Local:       &AddrPort{Addr: "10.0.1.1", Aggregated: true},
+			Peer:        &AddrPort{Addr: "192.168.0.1", Port: 80},
+			Connections: 1,
+		},
+		// loopback is kept
+		{
+			Direction:   FlowActive,
+			Local:       &AddrPort{Addr: "127.0.0.1", Aggregated: true},
+			Peer:        &AddrPort{Addr: "127.0.0.1", Port: 6379},
+			Connections: 1,
+		},
+	} {
+		flows[f.Key()] = f
+	}
+
+	got := n.Rewrite(flows)
+	if len(got) != 3 {
+		t.Fatalf("the number of flows should be 3, but %d", len(got))
+	}
+
+	labels := map[string]string{LabelNodeName: "web-1"}
+	tests := []struct {
+		key  FlowKey
+		want *HostFlow
+	}{
+		{
+			key: FlowKey{Direction: FlowActive, LocalAddr: "10.0.0.1", PeerAddr: "10.0.2.10", PeerPort: 5432},
+			want: &HostFlow{
+				Direction:   FlowActive,
+				Local:       &AddrPort{Addr: "10.0.0.1", Aggregated: true, Labels: labels},
+				Peer:        &AddrPort{Addr: "10.0.2.10", Port: 5432},
+				Connections: 5,
+			},
+		},
+		{
+			key: FlowKey{Direction: FlowActive, LocalAddr: "10.0.0.1", PeerAddr: "10.0.0.1", PeerPort: 80},
+			want: &HostFlow{
+				Direction:   FlowActive,
+				Local:       &AddrPort{Addr: "10.0.0.1", Aggregated: true, Labels: labels},
+				Peer:        &AddrPort{Addr: "10.0.0.1", Port: 80, Labels: labels},
+				Connections: 1,
+			},
+		},
+		{
+			key: FlowKey{Direction: FlowActive, LocalAddr: "127.0.0.1", PeerAddr: "127.0.0.1", PeerPort: 6379},
+			want: &HostFlow{
+				Direction:   FlowActive,
+				Local:       &AddrPort{Addr: "127.0.0.1", Aggregated: true},
+				Peer:        &AddrPort{Addr: "127.0.0.1", Port: 6379},
+				Connections: 1,
+			},
+		},
+	}
+	for _, tt := range tests {
+		if diff := cmp.Diff(tt.want, got[tt.key]); diff != "" {
+			t.Errorf("Rewrite() mismatch for %+v (-want +got):\n%s", tt.key, diff)
+		}
+	}
+}
+
+func TestNodeIdentity_nil(t *testing.T) {
+	var n *NodeIdentity
+	flows := HostFlows{}
+	if got := n.Rewrite(flows); len(got) != 0 {
+		t.Errorf("nil identity should return the flows as it is")
+	}
+}
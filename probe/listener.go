@@ -0,0 +1,117 @@
+package probe
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// LabelListenerGroup is the processes sharing the listening port of a server
+// by SO_REUSEPORT, which are "pname/pgid" joined with commas in the order of
+// the pgids.
+const LabelListenerGroup = "listener.group"
+
+// ListenerGroup is the processes listening on a port, which are more than
+// one if they share the port by SO_REUSEPORT, such as the instances of nginx
+// or envoy started separately.
+type ListenerGroup struct {
+	processes []*Process // distinct by the pgids in the order of them
+}
+
+// Add adds a process listening on the port. The processes of the same
+// process group are a process.
+func (g *ListenerGroup) Add(p *Process) {
+	i := sort.Search(len(g.processes), func(i int) bool { return g.processes[i].Pgid >= p.Pgid })
+	if i < len(g.processes) && g.processes[i].Pgid == p.Pgid {
+		return
+	}
+	g.processes = append(g.processes, nil)
+	copy(g.processes[i+1:], g.processes[i:])
+	g.processes[i] = p
+}
+
+// Processes returns the processes of the group in the order of the pgids.
+func (g *ListenerGroup) Processes() []*Process {
+	return g.processes
+}
+
+// Shared returns whether the processes of more than one process group
+// listen on the port.
+func (g *ListenerGroup) Shared() bool {
+	return len(g.processes) > 1
+}
+
+// Process returns the process of the flows whose sockets are owned by no
+// known process, such as the connections not accepted yet. It is the
+// process listening on the port, or the group named by the names of its
+// processes without a pgid if it is shared, instead of any one of them.
+func (g *ListenerGroup) Process() *Process {
+	switch len(g.processes) {
+	case 0:
+		return nil
+	case 1:
+		return g.processes[0]
+	}
+	seen := make(map[string]bool, len(g.processes))
+	var names []string
+	for _, p := range g.processes {
+		if !seen[p.Name] {
+			seen[p.Name] = true
+			names = append(names, p.Name)
+		}
+	}
+	sort.Strings(names)
+	return &Process{Name: strings.Join(names, ",")}
+}
+
+// String returns the processes of the group as the value of
+// LabelListenerGroup.
+func (g *ListenerGroup) String() string {
+	return FormatProcesses(g.processes)
+}
+
+// FormatProcesses returns the processes as "pname/pgid" joined with commas.
+func FormatProcesses(processes []*Process) string {
+	members := make([]string, 0, len(processes))
+	for _, p := range processes {
+		members = append(members, p.Name+"/"+strconv.Itoa(p.Pgid))
+	}
+	return strings.Join(members, ",")
+}
+
+// Listener is a listening port of the host, and the processes listening on
+// it.
+type Listener struct {
+	Port uint16 `json:"port"`
+	// Addrs are the local addresses the sockets of the port are bound to,
+	// such as 0.0.0.0 and ::, in order.
+	Addrs []string `json:"addrs"`
+	// Processes are the processes listening on the port in the order of
+	// the pgids, which are empty if they are unknown.
+	Processes []*Process `json:"processes"`
+}
+
+// ProcessNames returns the distinct names of the processes of the listener
+// in order.
+func (l *Listener) ProcessNames() []string {
+	seen := make(map[string]bool, len(l.Processes))
+	var names []string
+	for _, p := range l.Processes {
+		if !seen[p.Name] {
+			seen[p.Name] = true
+			names = append(names, p.Name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// String returns the listener as a line of the allow list of the
+// listeners, followed by the addresses as a comment.
+func (l *Listener) String() string {
+	s := strconv.Itoa(int(l.Port))
+	if names := l.ProcessNames(); len(names) > 0 {
+		s += " " + strings.Join(names, " ")
+	}
+	return s + " # " + strings.Join(l.Addrs, ",")
+}
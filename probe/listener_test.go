@@ -0,0 +1,35 @@
+package probe
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestListenerGroup(t *testing.T) {
+	g := &ListenerGroup{}
+	if p := g.Process(); p != nil {
+		t.Errorf("the empty group should have no process, but %+v", p)
+	}
+
+	g.Add(&Process{Name: "nginx", Pgid: 200})
+	g.Add(&Process{Name: "nginx", Pgid: 200})
+	if g.Shared() {
+		t.Error("the workers of a process group should not be shared")
+	}
+	if diff := cmp.Diff(&Process{Name: "nginx", Pgid: 200}, g.Process()); diff != "" {
+		t.Errorf("Process() mismatch (-want +got):\n%s", diff)
+	}
+
+	g.Add(&Process{Name: "nginx", Pgid: 100})
+	g.Add(&Process{Name: "envoy", Pgid: 300})
+	if !g.Shared() {
+		t.Error("the process groups listening on the port should be shared")
+	}
+	if diff := cmp.Diff(&Process{Name: "envoy,nginx"}, g.Process()); diff != "" {
+		t.Errorf("Process() mismatch (-want +got):\n%s", diff)
+	}
+	if got, want := g.String(), "nginx/100,nginx/200,envoy/300"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
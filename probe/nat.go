@@ -0,0 +1,46 @@
+package probe
+
+import "strconv"
+
+// Labels attached to the endpoints of the flows translated by NAT.
+const (
+	// LabelNATOriginal is the address and the port that the clients
+	// connected to before the destination NAT, such as a virtual IP.
+	LabelNATOriginal = "nat.original"
+	// LabelNATSource is the address that the source NAT translated the
+	// client into.
+	LabelNATSource = "nat.source"
+)
+
+// NAT is the connections of a client translated by a NAT gateway, which
+// are the original and the reply tuples of conntrack.
+type NAT struct {
+	// Client is the address of the client before the source NAT.
+	Client string `json:"client"`
+	// Original is the address and the port that the client connected to.
+	Original *AddrPort `json:"original"`
+	// Translated is the address and the port of the server that the
+	// destination NAT translated Original into, which is Original without
+	// the destination NAT.
+	Translated *AddrPort `json:"translated"`
+	// Source is the address that the server sees as the client, which is
+	// Client without the source NAT.
+	Source      string `json:"source"`
+	Connections int64  `json:"connections"`
+}
+
+// DNAT returns whether the destination is translated.
+func (n *NAT) DNAT() bool {
+	return n.Original.Addr != n.Translated.Addr || n.Original.Port != n.Translated.Port
+}
+
+// SNAT returns whether the source is translated.
+func (n *NAT) SNAT() bool {
+	return n.Client != n.Source
+}
+
+// String returns the string representation of NAT.
+func (n *NAT) String() string {
+	return n.Client + "\t-->\t" + n.Original.String() + "\t=>\t" +
+		n.Source + "\t-->\t" + n.Translated.String() + "\t" + strconv.FormatInt(n.Connections, 10)
+}
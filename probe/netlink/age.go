@@ -0,0 +1,63 @@
+// +build linux
+
+package netlink
+
+import (
+	"sync"
+	"time"
+
+	"github.com/yuuki/shawk/probe/netlink/netutil"
+)
+
+// connKey identifies a connection across the scans. The cookie tells apart
+// the connections reusing the addresses and the ports.
+type connKey struct {
+	src, dst     [16]byte
+	sport, dport [2]byte
+	cookie       [2]uint32
+}
+
+func connKeyOf(m *netutil.InetDiagMsg) connKey {
+	return connKey{
+		src:    m.ID.Src,
+		dst:    m.ID.Dst,
+		sport:  m.ID.SPort,
+		dport:  m.ID.DPort,
+		cookie: m.ID.Cookie,
+	}
+}
+
+// ageTracker tracks when the probe first saw the connections, because the
+// kernel tells no ages of them. The connections existing before the first
+// scan are as old as the first scan.
+type ageTracker struct {
+	mu    sync.Mutex
+	first map[connKey]time.Time
+}
+
+// observe records the connections of a scan at now, forgets the connections
+// closed since the previous scan, and returns the ages of the connections.
+func (t *ageTracker) observe(conns []*netutil.InetDiagMsg, now time.Time) map[connKey]time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	first := make(map[connKey]time.Time, len(conns))
+	ages := make(map[connKey]time.Duration, len(conns))
+	for _, conn := range conns {
+		key := connKeyOf(conn)
+		seen, ok := t.first[key]
+		if !ok {
+			seen = now
+		}
+		first[key] = seen
+		ages[key] = now.Sub(seen)
+	}
+	t.first = first
+	return ages
+}
+
+var (
+	connAges = &ageTracker{}
+	// now is replaced by the tests.
+	now = time.Now
+)
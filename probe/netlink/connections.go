@@ -3,7 +3,8 @@
 package netlink
 
 import (
-	"fmt"
+	"sort"
+	"time"
 
 	"github.com/elastic/gosigar/sys/linux"
 	"golang.org/x/xerrors"
@@ -17,11 +18,22 @@ type GetHostFlowsOption struct {
 	Numeric   bool
 	Processes bool
 	Filter    string
+	// Ages tracks the ages of the connections across the calls, which are
+	// counted from the first call seeing them.
+	Ages bool
 }
 
+// vrfsOf returns the VRFs by the indexes of the interfaces, which is
+// replaced by the tests.
+var vrfsOf = netutil.VRFs
+
 // GetHostFlows gets host flows by netlink, and try to get by procfs if it fails.
+// A temporary failure of netlink is retried once before falling back.
 func GetHostFlows(opt *GetHostFlowsOption) (probe.HostFlows, error) {
 	flows, err := GetHostFlowsByNetlink(opt)
+	if err != nil && netutil.IsTransient(err) {
+		flows, err = GetHostFlowsByNetlink(opt)
+	}
 	if err != nil {
 		var netlinkErr *netutil.NetlinkError
 		if xerrors.As(err, &netlinkErr) {
@@ -35,14 +47,13 @@ func GetHostFlows(opt *GetHostFlowsOption) (probe.HostFlows, error) {
 
 // GetHostFlowsByNetlink gets host flows by Linux netlink API.
 func GetHostFlowsByNetlink(opt *GetHostFlowsOption) (probe.HostFlows, error) {
-	var userEnts netutil.UserEnts
-	if opt.Processes {
-		var err error
-		userEnts, err = netutil.BuildUserEntries()
-		if err != nil {
-			return nil, err
-		}
+	if r := currentRecorder(); r != nil {
+		return recordHostFlows(r, opt)
 	}
+	return getHostFlowsByNetlink(opt)
+}
+
+func getHostFlowsByNetlink(opt *GetHostFlowsOption) (probe.HostFlows, error) {
 	conns, err := netutil.NetlinkConnections()
 	if err != nil {
 		return nil, err
@@ -51,18 +62,46 @@ func GetHostFlowsByNetlink(opt *GetHostFlowsOption) (probe.HostFlows, error) {
 	if err != nil {
 		return nil, err
 	}
+	ephemeral := ephemeralPorts()
+	var ages map[connKey]time.Duration
+	if opt.Ages {
+		ages = connAges.observe(conns, now())
+	}
 
-	ports := make([]string, 0, len(lconns))
-	lportEnt := make(netutil.UserEntByLport, len(lconns))
+	ports := make([]uint16, 0, len(lconns))
+	// The processes may share a port by SO_REUSEPORT, which are of the
+	// listening sockets of the port.
+	linodes := make(map[uint16][]uint32, len(lconns))
+	// The listening sockets known by the pids instead of the inodes, such
+	// as of the state table of eBPF.
+	lpids := make(map[uint16][]uint32, len(lconns))
+	// The accept queue of a listening socket is its Recv-Q, and the
+	// backlog is its Send-Q.
+	lqueues := make(map[uint16]*probe.Queue, len(lconns))
 	for _, lconn := range lconns {
-		sport := fmt.Sprintf("%d", lconn.SrcPort())
+		sport := uint16(lconn.SrcPort())
 		ports = append(ports, sport)
-		if userEnts != nil {
-			lportEnt[sport] = userEnts[lconn.Inode]
+		if lconn.Inode == 0 && lconn.Pid != 0 {
+			lpids[sport] = append(lpids[sport], lconn.Pid)
+		} else {
+			linodes[sport] = append(linodes[sport], lconn.Inode)
+		}
+		q, ok := lqueues[sport]
+		if !ok {
+			q = &probe.Queue{}
+			lqueues[sport] = q
+		}
+		if lconn.RQueue > q.AcceptQ {
+			q.AcceptQ = lconn.RQueue
+		}
+		if lconn.WQueue > q.Backlog {
+			q.Backlog = lconn.WQueue
 		}
 	}
 
-	flows := probe.HostFlows{}
+	// Select the connections before resolving their processes, so that
+	// /proc is walked only for the sockets whose flows survive the filter.
+	selected := make([]*netutil.InetDiagMsg, 0, len(conns))
 	for _, conn := range conns {
 		switch linux.TCPState(conn.State) {
 		case linux.TCP_LISTEN:
@@ -84,56 +123,299 @@ func GetHostFlowsByNetlink(opt *GetHostFlowsOption) (probe.HostFlows, error) {
 				continue
 			}
 		}
+		selected = append(selected, conn)
+	}
+
+	// The subflows of a MPTCP connection are one connection, which is of
+	// the initial subflow, or of a joined one if the initial one has
+	// closed.
+	subflows := make(map[uint32]int64)
+	mptcpConns := make(map[uint32]*netutil.InetDiagMsg)
+	for _, conn := range selected {
+		sf := conn.Subflow
+		if sf == nil {
+			continue
+		}
+		subflows[sf.Token]++
+		if c, ok := mptcpConns[sf.Token]; !ok || c.Subflow.Join && !sf.Join {
+			mptcpConns[sf.Token] = conn
+		}
+	}
+
+	// The sockets bound to the interfaces, such as by SO_BINDTODEVICE, may
+	// be in the VRFs, whose address spaces overlap the others.
+	var vrfs map[uint32]string
+	for _, conn := range selected {
+		if conn.ID.If != 0 {
+			vrfs, err = vrfsOf()
+			if err != nil {
+				return nil, err
+			}
+			break
+		}
+	}
 
-		var ent *netutil.UserEnt
+	var userEnts netutil.UserEnts
+	lgroups := make(map[uint16]*probe.ListenerGroup, len(linodes)+len(lpids))
+	pidProcs := make(map[uint32]*probe.Process)
+	// processOfPid returns the process of pid, or nil if it has exited.
+	processOfPid := func(pid uint32) *probe.Process {
+		p, ok := pidProcs[pid]
+		if !ok {
+			if ent, err := netutil.UserEntOf(int(pid)); err == nil {
+				p = processOf([]*netutil.UserEnt{ent})
+			}
+			pidProcs[pid] = p
+		}
+		return p
+	}
+	if opt.Processes {
+		inodes := make(map[uint32]struct{}, len(selected))
+		for _, conn := range selected {
+			// inode 0 means that it provides no process information
+			if conn.Inode != 0 {
+				inodes[conn.Inode] = struct{}{}
+			}
+			for _, ino := range linodes[uint16(conn.SrcPort())] {
+				if ino != 0 {
+					inodes[ino] = struct{}{}
+				}
+			}
+		}
+		userEnts, err = netutil.CurrentUserEntsBuilder().BuildUserEntriesFor(inodes)
+		if err != nil {
+			return nil, err
+		}
+		for port, inos := range linodes {
+			for _, ino := range inos {
+				if p := processOf(userEnts[ino]); p != nil {
+					if lgroups[port] == nil {
+						lgroups[port] = &probe.ListenerGroup{}
+					}
+					lgroups[port].Add(p)
+				}
+			}
+		}
+		for port, pids := range lpids {
+			for _, pid := range pids {
+				if p := processOfPid(pid); p != nil {
+					if lgroups[port] == nil {
+						lgroups[port] = &probe.ListenerGroup{}
+					}
+					lgroups[port].Add(p)
+				}
+			}
+		}
+	}
+
+	infer := inferDirections(len(ports), len(selected), func(observe func(local, remote probe.Endpoint)) {
+		for _, conn := range selected {
+			observe(
+				probe.Endpoint{Addr: conn.SrcIP().String(), Port: uint16(conn.SrcPort())},
+				probe.Endpoint{Addr: conn.DstIP().String(), Port: uint16(conn.DstPort())},
+			)
+		}
+	})
+
+	flows := probe.HostFlows{}
+	for _, conn := range selected {
+		var nsubflows int64
+		if sf := conn.Subflow; sf != nil {
+			if mptcpConns[sf.Token] != conn {
+				continue
+			}
+			nsubflows = subflows[sf.Token]
+		}
+
+		var proc *probe.Process
 		// inode 0 means that it provides no process information
-		if userEnts != nil && conn.Inode != 0 {
-			ent = userEnts[conn.Inode]
+		switch {
+		case userEnts != nil && conn.Inode != 0:
+			proc = processOf(userEnts[conn.Inode])
+		case opt.Processes && conn.Pid != 0:
+			proc = processOfPid(conn.Pid)
 		}
 
-		lport, rport := fmt.Sprintf("%d", conn.SrcPort()), fmt.Sprintf("%d", conn.DstPort())
-		if contains(ports, lport) {
+		lport, rport := uint16(conn.SrcPort()), uint16(conn.DstPort())
+		passive, confidence := accepted(ports, ephemeral, lport, rport), ""
+		if infer != nil {
+			passive, confidence = infer.Infer(
+				probe.Endpoint{Addr: conn.SrcIP().String(), Port: lport},
+				probe.Endpoint{Addr: conn.DstIP().String(), Port: rport},
+			)
+		}
+		if passive {
 			// passive open
-			if ent == nil {
-				ent = lportEnt[lport]
-			}
 			hf := &probe.HostFlow{
 				Direction: probe.FlowPassive,
 				Local:     &probe.AddrPort{Addr: conn.SrcIP().String(), Port: lport},
-				Peer:      &probe.AddrPort{Addr: conn.DstIP().String(), Port: "many"},
+				Peer:      &probe.AddrPort{Addr: conn.DstIP().String(), Aggregated: true},
+				Queue:     &probe.Queue{RecvQ: conn.RQueue, SendQ: conn.WQueue},
+				Cong:      conn.Cong,
+				Subflows:  nsubflows,
+				VRF:       vrfs[conn.ID.If],
 			}
-			if ent != nil {
-				hf.Process = &probe.Process{
-					Name: ent.Pname(),
-					Pgid: ent.Pgrp(),
-				}
+			if ages != nil {
+				hf.Age = probe.NewAge(ages[connKeyOf(conn)])
+			}
+			if lq := lqueues[lport]; lq != nil {
+				hf.Queue.AcceptQ, hf.Queue.Backlog = lq.AcceptQ, lq.Backlog
+			}
+			// The process accepting the connection owns its socket, and
+			// the others are of the processes listening on the port.
+			lgroup := lgroups[lport]
+			switch {
+			case proc != nil:
+				hf.Process = proc
+			case lgroup != nil:
+				hf.Process = lgroup.Process()
+			}
+			if lgroup != nil && lgroup.Shared() {
+				hf.Local.SetLabel(probe.LabelListenerGroup, lgroup.String())
+			}
+			if confidence != "" {
+				hf.Local.SetLabel(probe.LabelDirectionConfidence, confidence)
 			}
 			flows.Insert(hf)
 		} else {
 			// active open
 			hf := &probe.HostFlow{
 				Direction: probe.FlowActive,
-				Local:     &probe.AddrPort{Addr: conn.SrcIP().String(), Port: "many"},
+				Local:     &probe.AddrPort{Addr: conn.SrcIP().String(), Aggregated: true},
 				Peer:      &probe.AddrPort{Addr: conn.DstIP().String(), Port: rport},
+				Queue:     &probe.Queue{RecvQ: conn.RQueue, SendQ: conn.WQueue},
+				Cong:      conn.Cong,
+				Subflows:  nsubflows,
+				VRF:       vrfs[conn.ID.If],
 			}
-			if ent != nil {
-				hf.Process = &probe.Process{
-					Name: ent.Pname(),
-					Pgid: ent.Pgrp(),
-				}
+			if ages != nil {
+				hf.Age = probe.NewAge(ages[connKeyOf(conn)])
+			}
+			hf.Process = proc
+			if confidence != "" {
+				hf.Peer.SetLabel(probe.LabelDirectionConfidence, confidence)
 			}
 			flows.Insert(hf)
 		}
 	}
 
+	flows = probe.CurrentNodeIdentity().Rewrite(flows)
 	if !opt.Numeric {
-		for _, flow := range flows {
-			flow.SetLookupedName()
-		}
+		flows.SetLookupedNames()
 	}
 	return flows, nil
 }
 
+// GetListeners gets the listening ports of the host by netlink with the
+// processes listening on them, in the order of the ports.
+func GetListeners() ([]*probe.Listener, error) {
+	conns, err := netutil.NetlinkConnections()
+	if err != nil {
+		return nil, err
+	}
+	lconns, err := netutil.NetlinkFilterByLocalListeningPorts(conns)
+	if err != nil {
+		return nil, err
+	}
+	inodes := make(map[uint32]struct{}, len(lconns))
+	for _, lconn := range lconns {
+		// inode 0 means that it provides no process information
+		if lconn.Inode != 0 {
+			inodes[lconn.Inode] = struct{}{}
+		}
+	}
+	userEnts, err := netutil.CurrentUserEntsBuilder().BuildUserEntriesFor(inodes)
+	if err != nil {
+		return nil, err
+	}
+
+	listeners := make(map[uint16]*probe.Listener, len(lconns))
+	lgroups := make(map[uint16]*probe.ListenerGroup, len(lconns))
+	for _, lconn := range lconns {
+		port := uint16(lconn.SrcPort())
+		l, ok := listeners[port]
+		if !ok {
+			l = &probe.Listener{Port: port}
+			listeners[port] = l
+			lgroups[port] = &probe.ListenerGroup{}
+		}
+		if addr := lconn.SrcIP().String(); !containsString(l.Addrs, addr) {
+			l.Addrs = append(l.Addrs, addr)
+		}
+		var p *probe.Process
+		switch {
+		case lconn.Inode != 0:
+			p = processOf(userEnts[lconn.Inode])
+		case lconn.Pid != 0:
+			if ent, err := netutil.UserEntOf(int(lconn.Pid)); err == nil {
+				p = processOf([]*netutil.UserEnt{ent})
+			}
+		}
+		if p != nil {
+			lgroups[port].Add(p)
+		}
+	}
+
+	ports := make([]int, 0, len(listeners))
+	for port := range listeners {
+		ports = append(ports, int(port))
+	}
+	sort.Ints(ports)
+	result := make([]*probe.Listener, 0, len(ports))
+	for _, port := range ports {
+		l := listeners[uint16(port)]
+		sort.Strings(l.Addrs)
+		l.Processes = lgroups[uint16(port)].Processes()
+		result = append(result, l)
+	}
+	return result, nil
+}
+
+func containsString(ss []string, s string) bool {
+	for _, v := range ss {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// processOf returns the process owning a socket, or nil if there are no
+// owners. The owners of the other process groups are its Owners. The
+// process is of the owner inheriting the socket from another process
+// group, such as the service activated by systemd, or else of the lowest
+// pgid.
+func processOf(ents []*netutil.UserEnt) *probe.Process {
+	if len(ents) == 0 {
+		return nil
+	}
+	pids := make(map[int]int, len(ents)) // pid to pgid
+	for _, ent := range ents {
+		pids[ent.Pid()] = ent.Pgrp()
+	}
+	g := &probe.ListenerGroup{}
+	primary := -1
+	for _, ent := range ents {
+		g.Add(&probe.Process{Name: ent.Pname(), Pgid: ent.Pgrp()})
+		if pgid, ok := pids[ent.Ppid()]; ok && pgid != ent.Pgrp() && primary < 0 {
+			primary = ent.Pgrp()
+		}
+	}
+	var proc *probe.Process
+	var owners []*probe.Process
+	for _, p := range g.Processes() {
+		if proc == nil && (primary < 0 || p.Pgid == primary) {
+			proc = p
+			continue
+		}
+		owners = append(owners, p)
+	}
+	if len(owners) > 0 {
+		return &probe.Process{Name: proc.Name, Pgid: proc.Pgid, Owners: owners}
+	}
+	return proc
+}
+
 // GetHostFlowsByProcfs gets host flows from procfs.
 func GetHostFlowsByProcfs() (probe.HostFlows, error) {
 	conns, err := netutil.ProcfsConnections()
@@ -144,7 +426,8 @@ func GetHostFlowsByProcfs() (probe.HostFlows, error) {
 	if err != nil {
 		return nil, err
 	}
-	flows := probe.HostFlows{}
+	ephemeral := ephemeralPorts()
+	established := make([]*netutil.ConnectionStat, 0, len(conns))
 	for _, conn := range conns {
 		switch conn.Status {
 		case linux.TCP_LISTEN:
@@ -154,29 +437,92 @@ func GetHostFlowsByProcfs() (probe.HostFlows, error) {
 		case linux.TCP_SYN_RECV:
 			continue
 		}
+		established = append(established, conn)
+	}
+	infer := inferDirections(len(ports), len(established), func(observe func(local, remote probe.Endpoint)) {
+		for _, conn := range established {
+			observe(
+				probe.Endpoint{Addr: conn.Laddr.IP, Port: uint16(conn.Laddr.Port)},
+				probe.Endpoint{Addr: conn.Raddr.IP, Port: uint16(conn.Raddr.Port)},
+			)
+		}
+	})
 
-		lport := fmt.Sprintf("%d", conn.Laddr.Port)
-		rport := fmt.Sprintf("%d", conn.Raddr.Port)
-		if contains(ports, lport) {
-			flows.Insert(&probe.HostFlow{
+	flows := probe.HostFlows{}
+	for _, conn := range established {
+		lport := uint16(conn.Laddr.Port)
+		rport := uint16(conn.Raddr.Port)
+		passive, confidence := accepted(ports, ephemeral, lport, rport), ""
+		if infer != nil {
+			passive, confidence = infer.Infer(
+				probe.Endpoint{Addr: conn.Laddr.IP, Port: lport},
+				probe.Endpoint{Addr: conn.Raddr.IP, Port: rport},
+			)
+		}
+		var hf *probe.HostFlow
+		if passive {
+			hf = &probe.HostFlow{
 				Direction: probe.FlowPassive,
 				Local:     &probe.AddrPort{Addr: conn.Laddr.IP, Port: lport},
-				Peer:      &probe.AddrPort{Addr: conn.Raddr.IP, Port: "many"},
-			})
+				Peer:      &probe.AddrPort{Addr: conn.Raddr.IP, Aggregated: true},
+			}
+			if confidence != "" {
+				hf.Local.SetLabel(probe.LabelDirectionConfidence, confidence)
+			}
 		} else {
-			flows.Insert(&probe.HostFlow{
+			hf = &probe.HostFlow{
 				Direction: probe.FlowActive,
-				Local:     &probe.AddrPort{Addr: conn.Laddr.IP, Port: "many"},
+				Local:     &probe.AddrPort{Addr: conn.Laddr.IP, Aggregated: true},
 				Peer:      &probe.AddrPort{Addr: conn.Raddr.IP, Port: rport},
-			})
+			}
+			if confidence != "" {
+				hf.Peer.SetLabel(probe.LabelDirectionConfidence, confidence)
+			}
 		}
+		flows.Insert(hf)
 	}
-	return flows, nil
+	return probe.CurrentNodeIdentity().Rewrite(flows), nil
+}
+
+// ephemeralPorts returns the ephemeral ports of the host, or the default of
+// Linux if they are unknown.
+func ephemeralPorts() netutil.PortRange {
+	r, err := netutil.EphemeralPorts(netutil.ProcFS())
+	if err != nil {
+		r = netutil.DefaultEphemeralPorts
+		logger.Debugf("could not read the ephemeral ports, assuming %d-%d: %v", r.From, r.To, err)
+	}
+	return r
+}
+
+// inferDirections returns the inferrer of the directions of the connections
+// observed by each, if no listening socket is seen while there are
+// connections, such as in a container seeing only the connections of the
+// host, or else nil to classify them by the listening ports.
+func inferDirections(listening, conns int, each func(observe func(local, remote probe.Endpoint))) *probe.DirectionInferrer {
+	if listening > 0 || conns == 0 {
+		return nil
+	}
+	infer := probe.NewDirectionInferrer()
+	each(infer.Observe)
+	return infer
+}
+
+// accepted returns whether the connection from the local port to the remote
+// port was accepted by the host. It is if the local port is listening, or
+// else if the local port is out of the ephemeral ports and the remote port
+// is in them, such as while the listener is closed briefly by a restart of
+// the server.
+func accepted(listening []uint16, ephemeral netutil.PortRange, lport, rport uint16) bool {
+	if contains(listening, lport) {
+		return true
+	}
+	return !ephemeral.Contains(lport) && ephemeral.Contains(rport)
 }
 
-func contains(strs []string, s string) bool {
-	for _, str := range strs {
-		if str == s {
+func contains(ports []uint16, port uint16) bool {
+	for _, p := range ports {
+		if p == port {
 			return true
 		}
 	}
@@ -0,0 +1,518 @@
+// +build linux
+
+package netlink
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/elastic/gosigar/sys/linux"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/yuuki/shawk/probe"
+	"github.com/yuuki/shawk/probe/netlink/netutil"
+)
+
+// useFakeInetDiag replays testdata/inetdiag instead of the netlink of the kernel.
+func useFakeInetDiag(t *testing.T) *netutil.FakeInetDiag {
+	d, err := netutil.LoadFakeInetDiag(filepath.Join("testdata", "inetdiag"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	prev := netutil.CurrentInetDiag()
+	netutil.SetInetDiag(d)
+	t.Cleanup(func() { netutil.SetInetDiag(prev) })
+	return d
+}
+
+// flowStrings returns the sorted string representations of the flows.
+func flowStrings(flows probe.HostFlows) []string {
+	ss := make([]string, 0, len(flows))
+	for _, f := range flows {
+		ss = append(ss, f.String())
+	}
+	sort.Strings(ss)
+	return ss
+}
+
+func TestGetHostFlowsByNetlink(t *testing.T) {
+	var (
+		passivePrivate = []string{
+			"10.0.0.1:80\t<--\t10.0.0.2:many\t1",
+			"10.0.0.1:80\t<--\t10.0.0.3:many\t1",
+			"10.0.0.1:8080\t<--\t10.0.0.7:many\t1",
+			"127.0.0.1:6379\t<--\t127.0.0.1:many\t1",
+		}
+		passivePublic = []string{
+			"10.0.0.1:80\t<--\t203.0.113.5:many\t1",
+			"[2001:db8::1]:8080\t<--\t[2001:db8::2]:many\t1",
+		}
+		activePrivate = []string{
+			"10.0.0.1:many\t-->\t10.0.0.4:5432\t3",
+			"127.0.0.1:many\t-->\t127.0.0.1:6379\t1",
+			"[fd00::1]:many\t-->\t[fd00::9]:53\t1",
+		}
+		activePublic = []string{
+			"10.0.0.1:many\t-->\t198.51.100.7:443\t1",
+		}
+	)
+	concat := func(lists ...[]string) []string {
+		var all []string
+		for _, l := range lists {
+			all = append(all, l...)
+		}
+		sort.Strings(all)
+		return all
+	}
+
+	tests := []struct {
+		filter string
+		want   []string
+	}{
+		{probe.FilterAll, concat(passivePrivate, passivePublic, activePrivate, activePublic)},
+		{probe.FilterPublic, concat(passivePublic, activePublic)},
+		{probe.FilterPrivate, concat(passivePrivate, activePrivate)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.filter, func(t *testing.T) {
+			useFakeInetDiag(t)
+
+			flows, err := GetHostFlowsByNetlink(&GetHostFlowsOption{Numeric: true, Filter: tt.filter})
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			if diff := cmp.Diff(tt.want, flowStrings(flows)); diff != "" {
+				t.Errorf("GetHostFlowsByNetlink() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+// stubInetDiag returns the messages of the address families.
+type stubInetDiag map[netutil.AddressFamily][]*netutil.InetDiagMsg
+
+func (d stubInetDiag) Dump(family netutil.AddressFamily) ([]*netutil.InetDiagMsg, error) {
+	return d[family], nil
+}
+
+func newDiagMsg(state linux.TCPState, src, dst string, sport, dport uint16, inode uint32) *netutil.InetDiagMsg {
+	m := &netutil.InetDiagMsg{InetDiagHeader: netutil.InetDiagHeader{Family: uint8(netutil.AFInet), State: uint8(state), Inode: inode}}
+	copy(m.ID.Src[:], net.ParseIP(src).To4())
+	copy(m.ID.Dst[:], net.ParseIP(dst).To4())
+	m.ID.SPort = [2]byte{byte(sport >> 8), byte(sport)}
+	m.ID.DPort = [2]byte{byte(dport >> 8), byte(dport)}
+	return m
+}
+
+func TestGetHostFlowsByNetlink_reuseport(t *testing.T) {
+	prevDiag, prevBuilder := netutil.CurrentInetDiag(), netutil.CurrentUserEntsBuilder()
+	defer func() {
+		netutil.SetInetDiag(prevDiag)
+		netutil.SetUserEntsBuilder(prevBuilder)
+	}()
+	// Two instances of nginx listen on the port 80 by SO_REUSEPORT.
+	netutil.SetInetDiag(stubInetDiag{netutil.AFInet: {
+		newDiagMsg(linux.TCP_LISTEN, "0.0.0.0", "0.0.0.0", 80, 0, 1),
+		newDiagMsg(linux.TCP_LISTEN, "0.0.0.0", "0.0.0.0", 80, 0, 2),
+		newDiagMsg(linux.TCP_ESTABLISHED, "10.0.0.1", "10.0.0.2", 80, 40000, 10),
+		newDiagMsg(linux.TCP_ESTABLISHED, "10.0.0.1", "10.0.0.3", 80, 40001, 0),
+	}})
+	var ents netutil.FakeUserEnts
+	err := json.Unmarshal([]byte(`{
+		"1": {"inode": 1, "pid": 100, "pname": "nginx", "pgrp": 100},
+		"2": {"inode": 2, "pid": 300, "pname": "nginx", "pgrp": 300},
+		"10": {"inode": 10, "pid": 301, "pname": "nginx", "pgrp": 300}
+	}`), &ents)
+	if err != nil {
+		t.Fatal(err)
+	}
+	netutil.SetUserEntsBuilder(ents)
+
+	flows, err := GetHostFlowsByNetlink(&GetHostFlowsOption{Numeric: true, Processes: true, Filter: probe.FilterAll})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	// The connection accepted by an instance is of it, and the other is of
+	// the group instead of either instance.
+	want := []string{
+		"10.0.0.1:80\t<--\t10.0.0.2:many\t1\t(\"nginx\",pgid=300)",
+		"10.0.0.1:80\t<--\t10.0.0.3:many\t1\t(\"nginx\",pgid=0)",
+	}
+	if diff := cmp.Diff(want, flowStrings(flows)); diff != "" {
+		t.Errorf("GetHostFlowsByNetlink() mismatch (-want +got):\n%s", diff)
+	}
+	for _, f := range flows {
+		if got := f.Local.Labels[probe.LabelListenerGroup]; got != "nginx/100,nginx/300" {
+			t.Errorf("the server %s should be labeled with the listener group, but %q", f, got)
+		}
+	}
+}
+
+func TestGetHostFlowsByNetlink_sharedSocket(t *testing.T) {
+	prevDiag, prevBuilder := netutil.CurrentInetDiag(), netutil.CurrentUserEntsBuilder()
+	defer func() {
+		netutil.SetInetDiag(prevDiag)
+		netutil.SetUserEntsBuilder(prevBuilder)
+	}()
+	// systemd activates nginx with the listening socket, which its workers
+	// inherit, and the worker accepting a connection passes it to a
+	// process of another group by SCM_RIGHTS.
+	netutil.SetInetDiag(stubInetDiag{netutil.AFInet: {
+		newDiagMsg(linux.TCP_LISTEN, "0.0.0.0", "0.0.0.0", 80, 0, 1),
+		newDiagMsg(linux.TCP_ESTABLISHED, "10.0.0.1", "10.0.0.2", 80, 40000, 10),
+	}})
+	var ents netutil.FakeUserEnts
+	err := json.Unmarshal([]byte(`{
+		"1": [
+			{"inode": 1, "pid": 1, "pname": "systemd", "ppid": 0, "pgrp": 1},
+			{"inode": 1, "pid": 100, "pname": "nginx", "ppid": 1, "pgrp": 100},
+			{"inode": 1, "pid": 101, "pname": "nginx", "ppid": 100, "pgrp": 100}
+		],
+		"10": [
+			{"inode": 10, "pid": 101, "pname": "nginx", "ppid": 100, "pgrp": 100},
+			{"inode": 10, "pid": 200, "pname": "php-fpm", "ppid": 1, "pgrp": 200}
+		]
+	}`), &ents)
+	if err != nil {
+		t.Fatal(err)
+	}
+	netutil.SetUserEntsBuilder(ents)
+
+	flows, err := GetHostFlowsByNetlink(&GetHostFlowsOption{Numeric: true, Processes: true, Filter: probe.FilterAll})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	var got []*probe.Process
+	for _, f := range flows {
+		got = append(got, f.Process)
+	}
+	want := []*probe.Process{{
+		Name: "nginx", Pgid: 100,
+		Owners: []*probe.Process{{Name: "php-fpm", Pgid: 200}},
+	}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("GetHostFlowsByNetlink() mismatch (-want +got):\n%s", diff)
+	}
+	if p := processOf(ents[1]); p.Pgid != 100 || probe.FormatProcesses(p.Owners) != "systemd/1" {
+		t.Errorf("the listener activated by systemd should be of nginx, but %+v", p)
+	}
+}
+
+func TestGetHostFlowsByNetlink_pid(t *testing.T) {
+	prevDiag, prevBuilder := netutil.CurrentInetDiag(), netutil.CurrentUserEntsBuilder()
+	defer func() {
+		netutil.SetInetDiag(prevDiag)
+		netutil.SetUserEntsBuilder(prevBuilder)
+	}()
+	root := t.TempDir()
+	for pid, stat := range map[string]string{
+		"100": "100 (nginx) S 1 100 100",
+		"200": "200 (curl) S 1 200 200",
+	} {
+		if err := os.MkdirAll(filepath.Join(root, pid, "fd"), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(filepath.Join(root, pid, "stat"), []byte(stat), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	netutil.SetProcFS(netutil.DirFS(root))
+	defer netutil.SetProcFS(netutil.DirFS("/proc"))
+
+	// The state table knows the pids opening the sockets instead of their
+	// inodes, except for the accepted sockets.
+	listener := newDiagMsg(linux.TCP_LISTEN, "0.0.0.0", "0.0.0.0", 80, 0, 0)
+	listener.Pid = 100
+	active := newDiagMsg(linux.TCP_ESTABLISHED, "10.0.0.1", "10.0.0.3", 40001, 443, 0)
+	active.Pid = 200
+	gone := newDiagMsg(linux.TCP_ESTABLISHED, "10.0.0.1", "10.0.0.4", 40002, 443, 0)
+	gone.Pid = 300
+	netutil.SetInetDiag(stubInetDiag{netutil.AFInet: {
+		listener,
+		newDiagMsg(linux.TCP_ESTABLISHED, "10.0.0.1", "10.0.0.2", 80, 40000, 0),
+		active,
+		gone,
+	}})
+	netutil.SetUserEntsBuilder(netutil.FakeUserEnts{})
+
+	flows, err := GetHostFlowsByNetlink(&GetHostFlowsOption{Numeric: true, Processes: true, Filter: probe.FilterAll})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	got := map[string]*probe.Process{}
+	for _, f := range flows {
+		got[f.Peer.Addr] = f.Process
+	}
+	want := map[string]*probe.Process{
+		"10.0.0.2": {Name: "nginx", Pgid: 100},
+		"10.0.0.3": {Name: "curl", Pgid: 200},
+		"10.0.0.4": nil,
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("GetHostFlowsByNetlink() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestGetHostFlowsByNetlink_mptcp(t *testing.T) {
+	prevDiag := netutil.CurrentInetDiag()
+	defer netutil.SetInetDiag(prevDiag)
+
+	subflow := func(conn *netutil.InetDiagMsg, token uint32, join bool) *netutil.InetDiagMsg {
+		conn.Subflow = &netutil.Subflow{Token: token, Join: join}
+		return conn
+	}
+	netutil.SetInetDiag(stubInetDiag{netutil.AFInet: {
+		// a connection of two paths to the server.
+		subflow(newDiagMsg(linux.TCP_ESTABLISHED, "10.0.0.1", "10.0.0.2", 40001, 443, 0), 1, false),
+		subflow(newDiagMsg(linux.TCP_ESTABLISHED, "10.0.1.1", "10.0.0.2", 40002, 443, 0), 1, true),
+		// a connection whose initial subflow has closed.
+		subflow(newDiagMsg(linux.TCP_ESTABLISHED, "10.0.1.1", "10.0.0.2", 40003, 443, 0), 2, true),
+		subflow(newDiagMsg(linux.TCP_ESTABLISHED, "10.0.0.1", "10.0.0.2", 40004, 443, 0), 2, true),
+		newDiagMsg(linux.TCP_ESTABLISHED, "10.0.0.1", "10.0.0.2", 40005, 443, 0),
+	}})
+
+	flows, err := GetHostFlowsByNetlink(&GetHostFlowsOption{Numeric: true, Filter: probe.FilterAll})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	type counts struct{ Connections, Subflows int64 }
+	got := map[string]counts{}
+	for _, f := range flows {
+		got[f.Local.Addr] = counts{f.Connections, f.Subflows}
+	}
+	want := map[string]counts{
+		"10.0.0.1": {Connections: 2, Subflows: 2},
+		"10.0.1.1": {Connections: 1, Subflows: 2},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("GetHostFlowsByNetlink() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestGetHostFlowsByNetlink_vrf(t *testing.T) {
+	prevDiag := netutil.CurrentInetDiag()
+	defer netutil.SetInetDiag(prevDiag)
+	prevVRFs := vrfsOf
+	defer func() { vrfsOf = prevVRFs }()
+	vrfsOf = func() (map[uint32]string, error) {
+		return map[uint32]string{3: "blue", 4: "blue"}, nil
+	}
+
+	// The same addresses in the VRF bound by the listener and by the
+	// slave interface are another address space.
+	listener := newDiagMsg(linux.TCP_LISTEN, "0.0.0.0", "0.0.0.0", 80, 0, 0)
+	passive := newDiagMsg(linux.TCP_ESTABLISHED, "10.0.0.1", "10.0.0.2", 80, 40000, 0)
+	passive.ID.If = 3
+	active := newDiagMsg(linux.TCP_ESTABLISHED, "10.0.0.1", "10.0.0.3", 40001, 443, 0)
+	active.ID.If = 4
+	netutil.SetInetDiag(stubInetDiag{netutil.AFInet: {
+		listener,
+		newDiagMsg(linux.TCP_ESTABLISHED, "10.0.0.1", "10.0.0.2", 80, 40001, 0),
+		passive,
+		newDiagMsg(linux.TCP_ESTABLISHED, "10.0.0.1", "10.0.0.3", 40002, 443, 0),
+		active,
+	}})
+
+	flows, err := GetHostFlowsByNetlink(&GetHostFlowsOption{Numeric: true, Filter: probe.FilterAll})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	got := map[string]int64{}
+	for _, f := range flows {
+		got[f.Peer.Addr+"/"+f.VRF] = f.Connections
+	}
+	want := map[string]int64{
+		"10.0.0.2/":     1,
+		"10.0.0.2/blue": 1,
+		"10.0.0.3/":     1,
+		"10.0.0.3/blue": 1,
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("GetHostFlowsByNetlink() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestGetHostFlowsByNetlink_ages(t *testing.T) {
+	prevDiag := netutil.CurrentInetDiag()
+	defer netutil.SetInetDiag(prevDiag)
+	prevNow := now
+	defer func() { now = prevNow }()
+	t0 := time.Date(2020, 12, 20, 12, 0, 0, 0, time.UTC)
+	connAges = &ageTracker{}
+
+	a := newDiagMsg(linux.TCP_ESTABLISHED, "10.0.0.1", "10.0.0.2", 40001, 443, 0)
+	b := newDiagMsg(linux.TCP_ESTABLISHED, "10.0.0.1", "10.0.0.2", 40002, 443, 0)
+	c := newDiagMsg(linux.TCP_ESTABLISHED, "10.0.0.1", "10.0.0.2", 40003, 443, 0)
+	scans := []struct {
+		at    time.Duration
+		conns []*netutil.InetDiagMsg
+		want  *probe.Age
+	}{
+		{0, []*netutil.InetDiagMsg{a, b}, &probe.Age{}},
+		{time.Minute, []*netutil.InetDiagMsg{a, b, c}, &probe.Age{Min: 0, Avg: 40 * time.Second, Max: time.Minute}},
+		{2 * time.Minute, []*netutil.InetDiagMsg{c}, probe.NewAge(time.Minute)},
+		// a was closed and is seen again as a new connection.
+		{3 * time.Minute, []*netutil.InetDiagMsg{a, c}, &probe.Age{Min: 0, Avg: time.Minute, Max: 2 * time.Minute}},
+	}
+	for _, scan := range scans {
+		now = func() time.Time { return t0.Add(scan.at) }
+		netutil.SetInetDiag(stubInetDiag{netutil.AFInet: scan.conns})
+		flows, err := GetHostFlowsByNetlink(&GetHostFlowsOption{Numeric: true, Filter: probe.FilterAll, Ages: true})
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		if len(flows) != 1 {
+			t.Fatalf("the connections should be a flow, but %d flows", len(flows))
+		}
+		for _, f := range flows {
+			if diff := cmp.Diff(scan.want, f.Age); diff != "" {
+				t.Errorf("the ages of the flow at %s mismatch (-want +got):\n%s", scan.at, diff)
+			}
+		}
+	}
+
+	flows, err := GetHostFlowsByNetlink(&GetHostFlowsOption{Numeric: true, Filter: probe.FilterAll})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	for _, f := range flows {
+		if f.Age != nil {
+			t.Errorf("the ages should not be tracked without the option, but %+v", f.Age)
+		}
+	}
+}
+
+func TestGetHostFlows_fallbackToProcfs(t *testing.T) {
+	d := useFakeInetDiag(t)
+	d.Errs = map[netutil.AddressFamily]error{netutil.AFInet: syscall.EPROTONOSUPPORT}
+
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, "net"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	tcp := "  sl  local_address rem_address   st tx_queue rx_queue tr tm->when retrnsmt   uid  timeout inode\n" +
+		"   0: 0500000A:8000 0600000A:1F90 01 00000000:00000000 00:00000000 00000000  1000        0 100 1 0000000000000000 20 4 30 10 -1\n"
+	if err := ioutil.WriteFile(filepath.Join(root, "net", "tcp"), []byte(tcp), 0644); err != nil {
+		t.Fatal(err)
+	}
+	netutil.SetProcFS(netutil.DirFS(root))
+	defer netutil.SetProcFS(netutil.DirFS("/proc"))
+
+	flows, err := GetHostFlows(&GetHostFlowsOption{Numeric: true, Filter: probe.FilterAll})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	want := []string{"10.0.0.5:many\t-->\t10.0.0.6:8080\t1"}
+	if diff := cmp.Diff(want, flowStrings(flows)); diff != "" {
+		t.Errorf("GetHostFlows() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestGetHostFlowsByProcfs_ephemeralPorts(t *testing.T) {
+	root := t.TempDir()
+	for _, dir := range []string{"net", "sys/net/ipv4"} {
+		if err := os.MkdirAll(filepath.Join(root, dir), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// 10.0.0.5:5432 <- 10.0.0.6:50000 is accepted by the listener closed
+	// briefly while 10.0.0.5:80 listens, and 10.0.0.5:40000 ->
+	// 10.0.0.6:8080 is connected.
+	tcp := "  sl  local_address rem_address   st tx_queue rx_queue tr tm->when retrnsmt   uid  timeout inode\n" +
+		"   2: 0500000A:0050 00000000:0000 0A 00000000:00000000 00:00000000 00000000  1000        0 102 1 0000000000000000 20 4 30 10 -1\n" +
+		"   0: 0500000A:1538 0600000A:C350 01 00000000:00000000 00:00000000 00000000  1000        0 100 1 0000000000000000 20 4 30 10 -1\n" +
+		"   1: 0500000A:9C40 0600000A:1F90 01 00000000:00000000 00:00000000 00000000  1000        0 101 1 0000000000000000 20 4 30 10 -1\n"
+	if err := ioutil.WriteFile(filepath.Join(root, "net", "tcp"), []byte(tcp), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(root, "sys/net/ipv4/ip_local_port_range"), []byte("32768\t60999\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	netutil.SetProcFS(netutil.DirFS(root))
+	defer netutil.SetProcFS(netutil.DirFS("/proc"))
+
+	flows, err := GetHostFlowsByProcfs()
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	want := []string{
+		"10.0.0.5:5432\t<--\t10.0.0.6:many\t1",
+		"10.0.0.5:many\t-->\t10.0.0.6:8080\t1",
+	}
+	if diff := cmp.Diff(want, flowStrings(flows)); diff != "" {
+		t.Errorf("GetHostFlowsByProcfs() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestAccepted(t *testing.T) {
+	ephemeral := netutil.PortRange{From: 32768, To: 60999}
+	tests := []struct {
+		lport, rport uint16
+		want         bool
+	}{
+		// listening
+		{80, 50000, true},
+		{8443, 80, true},
+		// the listener closed briefly
+		{5432, 50000, true},
+		// connected from an ephemeral port
+		{40000, 8080, false},
+		// neither port is ephemeral
+		{5432, 8080, false},
+		// both ports are ephemeral
+		{40000, 50000, false},
+	}
+	for _, tt := range tests {
+		if got := accepted([]uint16{80, 8443}, ephemeral, tt.lport, tt.rport); got != tt.want {
+			t.Errorf("accepted(%d, %d) = %t, want %t", tt.lport, tt.rport, got, tt.want)
+		}
+	}
+}
+
+func TestGetHostFlowsByProcfs_inferDirections(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, "net"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	// No listening socket is seen: 10.0.0.5:8080 is connected from the
+	// ephemeral ports of two peers, and 10.0.0.5:9000 connects to
+	// 10.0.0.7:9001.
+	tcp := "  sl  local_address rem_address   st tx_queue rx_queue tr tm->when retrnsmt   uid  timeout inode\n" +
+		"   0: 0500000A:1F90 0600000A:C350 01 00000000:00000000 00:00000000 00000000  1000        0 100 1 0000000000000000 20 4 30 10 -1\n" +
+		"   1: 0500000A:1F90 0800000A:C351 01 00000000:00000000 00:00000000 00000000  1000        0 101 1 0000000000000000 20 4 30 10 -1\n" +
+		"   2: 0500000A:2328 0700000A:2329 01 00000000:00000000 00:00000000 00000000  1000        0 102 1 0000000000000000 20 4 30 10 -1\n"
+	if err := ioutil.WriteFile(filepath.Join(root, "net", "tcp"), []byte(tcp), 0644); err != nil {
+		t.Fatal(err)
+	}
+	netutil.SetProcFS(netutil.DirFS(root))
+	defer netutil.SetProcFS(netutil.DirFS("/proc"))
+
+	flows, err := GetHostFlowsByProcfs()
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	var got []string
+	for _, f := range flows {
+		server := f.Local
+		if f.Direction == probe.FlowActive {
+			server = f.Peer
+		}
+		got = append(got, f.String()+"\t"+server.Labels[probe.LabelDirectionConfidence])
+	}
+	sort.Strings(got)
+	want := []string{
+		"10.0.0.5:8080\t<--\t10.0.0.6:many\t1\thigh",
+		"10.0.0.5:8080\t<--\t10.0.0.8:many\t1\thigh",
+		"10.0.0.5:9000\t<--\t10.0.0.7:many\t1\tlow",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("GetHostFlowsByProcfs() mismatch (-want +got):\n%s", diff)
+	}
+}
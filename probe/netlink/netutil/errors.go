@@ -0,0 +1,119 @@
+package netutil
+
+import (
+	"fmt"
+	"syscall"
+
+	"golang.org/x/xerrors"
+)
+
+// NetlinkError represents netlink error.
+type NetlinkError struct {
+	msg string
+	err error
+}
+
+func (e *NetlinkError) Error() string {
+	return fmt.Sprintf("Netlink error: %s", e.msg)
+}
+
+// Unwrap returns the classified cause, such as PermissionError.
+func (e *NetlinkError) Unwrap() error {
+	return e.err
+}
+
+// PermissionError represents that the probe is not permitted to inspect the
+// sockets, the processes or the kernel.
+type PermissionError struct {
+	Op  string
+	Err error
+}
+
+func (e *PermissionError) Error() string {
+	return fmt.Sprintf("%s: permission denied: %v", e.Op, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *PermissionError) Unwrap() error {
+	return e.Err
+}
+
+// KernelUnsupportedError represents that the running kernel does not
+// provide the feature the probe depends on.
+type KernelUnsupportedError struct {
+	Feature string
+	Err     error
+}
+
+func (e *KernelUnsupportedError) Error() string {
+	if e.Err == nil {
+		return fmt.Sprintf("%s is not supported by this kernel", e.Feature)
+	}
+	return fmt.Sprintf("%s is not supported by this kernel: %v", e.Feature, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *KernelUnsupportedError) Unwrap() error {
+	return e.Err
+}
+
+// TransientError represents a temporary failure which may succeed if the
+// operation is retried.
+type TransientError struct {
+	Op  string
+	Err error
+}
+
+func (e *TransientError) Error() string {
+	return fmt.Sprintf("%s: temporary failure: %v", e.Op, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *TransientError) Unwrap() error {
+	return e.Err
+}
+
+// ClassifyError wraps err by PermissionError, KernelUnsupportedError or
+// TransientError according to its errno. feature names what op needs from
+// the kernel. err is returned as it is if the errno is not known.
+func ClassifyError(op, feature string, err error) error {
+	var errno syscall.Errno
+	if !xerrors.As(err, &errno) {
+		return err
+	}
+	switch errno {
+	case syscall.EPERM, syscall.EACCES:
+		return &PermissionError{Op: op, Err: err}
+	case syscall.EPROTONOSUPPORT, syscall.EAFNOSUPPORT, syscall.EOPNOTSUPP, syscall.ENOSYS:
+		return &KernelUnsupportedError{Feature: feature, Err: err}
+	case syscall.EINTR, syscall.EAGAIN, syscall.EBUSY, syscall.ENOBUFS, syscall.ENOMEM:
+		return &TransientError{Op: op, Err: err}
+	}
+	return err
+}
+
+// IsTransient returns whether err is worth retrying.
+func IsTransient(err error) bool {
+	var transientErr *TransientError
+	return xerrors.As(err, &transientErr)
+}
+
+// Advice returns how to fix err for the user, or an empty string if there
+// is no advice for it.
+func Advice(err error) string {
+	var (
+		permErr   *PermissionError
+		kernelErr *KernelUnsupportedError
+	)
+	switch {
+	case xerrors.As(err, &permErr):
+		return "run shawk as root, or grant it CAP_NET_ADMIN, CAP_SYS_PTRACE and CAP_DAC_READ_SEARCH " +
+			"(and CAP_SYS_ADMIN for the streaming mode)"
+	case xerrors.As(err, &kernelErr):
+		return "load the inet_diag and tcp_diag kernel modules, or use the polling mode " +
+			"(SHAWK_PROBE_MODE=polling) on kernels older than 4.1"
+	case IsTransient(err):
+		return "the failure is temporary; retry it later"
+	}
+	return ""
+}
@@ -0,0 +1,71 @@
+package netutil
+
+import (
+	"os"
+	"syscall"
+	"testing"
+
+	"golang.org/x/xerrors"
+)
+
+func TestClassifyError(t *testing.T) {
+	tests := []struct {
+		desc string
+		err  error
+		// check reports whether the classified error has the expected type.
+		check func(error) bool
+	}{
+		{
+			desc: "permission denied",
+			err:  &os.PathError{Op: "open", Path: "/proc/1/fd", Err: syscall.EACCES},
+			check: func(err error) bool {
+				var e *PermissionError
+				return xerrors.As(err, &e)
+			},
+		},
+		{
+			desc: "protocol not supported",
+			err:  syscall.EPROTONOSUPPORT,
+			check: func(err error) bool {
+				var e *KernelUnsupportedError
+				return xerrors.As(err, &e)
+			},
+		},
+		{
+			desc:  "no buffer space",
+			err:   xerrors.Errorf("recvfrom: %w", syscall.ENOBUFS),
+			check: IsTransient,
+		},
+		{
+			desc: "unknown errno",
+			err:  syscall.EINVAL,
+			check: func(err error) bool {
+				return err == syscall.EINVAL
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			err := ClassifyError("op", "feature", tt.err)
+			if !tt.check(err) {
+				t.Errorf("ClassifyError(%v) got unexpected %T: %v", tt.err, err, err)
+			}
+			if !xerrors.Is(err, tt.err) {
+				t.Errorf("ClassifyError(%v) should wrap the original error", tt.err)
+			}
+		})
+	}
+}
+
+func TestAdvice(t *testing.T) {
+	err := xerrors.Errorf("NetlinkInetDiag: %w", &NetlinkError{
+		msg: "operation not permitted",
+		err: ClassifyError("netlink inet_diag", "sock_diag netlink", syscall.EPERM),
+	})
+	if Advice(err) == "" {
+		t.Error("Advice() should return advice for a wrapped PermissionError")
+	}
+	if got := Advice(xerrors.New("unknown")); got != "" {
+		t.Errorf("Advice() should return no advice for an unknown error, but %q", got)
+	}
+}
@@ -0,0 +1,172 @@
+// +build linux
+
+package netutil
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net"
+	"sync"
+	"time"
+
+	"golang.org/x/xerrors"
+)
+
+// helperTimeout is the deadline of a request to the helper, which walks
+// /proc for the processes of the sockets.
+const helperTimeout = 30 * time.Second
+
+// Operations of the requests to the helper.
+const (
+	helperOpDump     = "dump"
+	helperOpUserEnts = "userents"
+)
+
+// helperRequest is a request to the helper, which is sent as a JSON line on
+// a new connection.
+type helperRequest struct {
+	Op     string   `json:"op"`
+	Family uint8    `json:"family,omitempty"`
+	Inodes []uint32 `json:"inodes,omitempty"`
+}
+
+// helperResponse is the response of the helper. Dump is the raw netlink
+// responses of RecordInetDiag, which are parsed by the client.
+type helperResponse struct {
+	Error    string   `json:"error,omitempty"`
+	Dump     []byte   `json:"dump,omitempty"`
+	UserEnts UserEnts `json:"userents,omitempty"`
+}
+
+// HelperClient is an InetDiag and a UserEntsBuilder querying the helper
+// served by ServeHelper on the unix socket, so that the probe inspects the
+// sockets and the processes of the other users without the privileges.
+type HelperClient struct {
+	path string
+}
+
+// NewHelperClient returns the HelperClient of the helper on the unix socket
+// of path.
+func NewHelperClient(path string) *HelperClient {
+	return &HelperClient{path: path}
+}
+
+func (c *HelperClient) call(req *helperRequest) (*helperResponse, error) {
+	conn, err := net.DialTimeout("unix", c.path, helperTimeout)
+	if err != nil {
+		return nil, xerrors.Errorf("could not connect to the helper %s: %w", c.path, err)
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(helperTimeout))
+	if err := json.NewEncoder(conn).Encode(req); err != nil {
+		return nil, xerrors.Errorf("could not send the request to the helper %s: %w", c.path, err)
+	}
+	var resp helperResponse
+	if err := json.NewDecoder(conn).Decode(&resp); err != nil {
+		return nil, xerrors.Errorf("could not receive the response of the helper %s: %w", c.path, err)
+	}
+	if resp.Error != "" {
+		return nil, xerrors.Errorf("helper %s: %s", c.path, resp.Error)
+	}
+	return &resp, nil
+}
+
+// Dump returns the sockets of the address family dumped by the helper.
+func (c *HelperClient) Dump(family AddressFamily) ([]*InetDiagMsg, error) {
+	resp, err := c.call(&helperRequest{Op: helperOpDump, Family: uint8(family)})
+	if err != nil {
+		return nil, err
+	}
+	return ParseInetDiagDump(resp.Dump)
+}
+
+// BuildUserEntriesFor returns the processes owning the inodes resolved by
+// the helper.
+func (c *HelperClient) BuildUserEntriesFor(inodes map[uint32]struct{}) (UserEnts, error) {
+	req := &helperRequest{Op: helperOpUserEnts, Inodes: make([]uint32, 0, len(inodes))}
+	for ino := range inodes {
+		req.Inodes = append(req.Inodes, ino)
+	}
+	resp, err := c.call(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.UserEnts == nil {
+		return UserEnts{}, nil
+	}
+	return resp.UserEnts, nil
+}
+
+// ServeHelper answers the requests of HelperClient on l until ctx is done,
+// dumping the sockets by the netlink of the kernel and resolving their
+// processes from /proc. It only serves the raw sockets and the processes,
+// which are aggregated into the flows by the unprivileged probe.
+func ServeHelper(ctx context.Context, l net.Listener) error {
+	go func() {
+		<-ctx.Done()
+		l.Close()
+	}()
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			var ne net.Error
+			if xerrors.As(err, &ne) && ne.Temporary() {
+				logger.Warningf("could not accept a connection to the helper: %v", err)
+				time.Sleep(100 * time.Millisecond)
+				continue
+			}
+			return xerrors.Errorf("could not accept a connection to the helper: %w", err)
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			serveHelperConn(conn)
+		}()
+	}
+}
+
+func serveHelperConn(conn net.Conn) {
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(helperTimeout))
+	var req helperRequest
+	if err := json.NewDecoder(conn).Decode(&req); err != nil {
+		logger.Warningf("could not receive the request to the helper: %v", err)
+		return
+	}
+	resp := handleHelperRequest(&req)
+	if err := json.NewEncoder(conn).Encode(resp); err != nil {
+		logger.Warningf("could not send the response of the helper: %v", err)
+	}
+}
+
+func handleHelperRequest(req *helperRequest) *helperResponse {
+	switch req.Op {
+	case helperOpDump:
+		family := AddressFamily(req.Family)
+		if family != AFInet && family != AFInet6 {
+			return &helperResponse{Error: "unsupported address family"}
+		}
+		var b bytes.Buffer
+		if err := RecordInetDiag(family, &b); err != nil {
+			return &helperResponse{Error: err.Error()}
+		}
+		return &helperResponse{Dump: b.Bytes()}
+	case helperOpUserEnts:
+		inodes := make(map[uint32]struct{}, len(req.Inodes))
+		for _, ino := range req.Inodes {
+			inodes[ino] = struct{}{}
+		}
+		ents, err := BuildUserEntriesFor(inodes)
+		if err != nil {
+			return &helperResponse{Error: err.Error()}
+		}
+		return &helperResponse{UserEnts: ents}
+	}
+	return &helperResponse{Error: "unknown operation " + req.Op}
+}
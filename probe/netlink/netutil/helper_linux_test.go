@@ -0,0 +1,80 @@
+// +build linux
+
+package netutil
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"path/filepath"
+	"strings"
+	"testing"
+
+)
+
+func startHelper(t *testing.T) *HelperClient {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "helper.sock")
+	l, err := net.Listen("unix", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- ServeHelper(ctx, l) }()
+	t.Cleanup(func() {
+		cancel()
+		if err := <-done; err != nil {
+			t.Errorf("ServeHelper() should stop without error: %v", err)
+		}
+	})
+	return NewHelperClient(path)
+}
+
+func TestHelperClient_Dump(t *testing.T) {
+	c := startHelper(t)
+	SetInetDiag(c)
+	defer SetInetDiag(netlinkInetDiag{})
+
+	conns, err := NetlinkConnections()
+	if err != nil {
+		t.Fatalf("should not raise error: %v", err)
+	}
+	var b bytes.Buffer
+	if err := RecordInetDiag(AFInet, &b); err != nil {
+		t.Fatalf("should not raise error: %v", err)
+	}
+	// The sockets of the host may change between the dumps.
+	if want, err := ParseInetDiagDump(b.Bytes()); err != nil || (len(want) > 0) != (len(conns) > 0) {
+		t.Errorf("NetlinkConnections() by the helper should return the sockets of the host, but %d sockets (%v)", len(conns), err)
+	}
+
+	if _, err := c.Dump(AddressFamily(1)); err == nil || !strings.Contains(err.Error(), "unsupported address family") {
+		t.Errorf("Dump() should return the error of the helper, but %v", err)
+	}
+}
+
+func TestHelperClient_BuildUserEntriesFor(t *testing.T) {
+	root := newProcFixture(t, 3, 2)
+	SetProcFS(DirFS(root))
+	defer SetProcFS(DirFS("/proc"))
+
+	c := startHelper(t)
+	ents, err := c.BuildUserEntriesFor(map[uint32]struct{}{4: {}, 100: {}})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if len(ents) != 1 {
+		t.Fatalf("the number of entries should be 1, but %d", len(ents))
+	}
+	if owners := ents[4]; len(owners) != 1 || owners[0].Pname() != "proc2" || owners[0].Pgrp() != 2 {
+		t.Errorf("inode 4 should belong to proc2, but %v", owners)
+	}
+}
+
+func TestHelperClient_unavailable(t *testing.T) {
+	c := NewHelperClient(filepath.Join(t.TempDir(), "none.sock"))
+	if _, err := c.Dump(AFInet); err == nil || !strings.Contains(err.Error(), "could not connect to the helper") {
+		t.Errorf("Dump() should return an error without the helper, but %v", err)
+	}
+}
@@ -0,0 +1,319 @@
+package netutil
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
+
+	"github.com/elastic/gosigar/sys"
+	"golang.org/x/xerrors"
+)
+
+const (
+	// inetDiagCong is the attribute of the congestion control algorithm.
+	// The extensions of gosigar are shifted by one from the attributes, so
+	// the bit of the request is built from it instead.
+	inetDiagCong = 4
+	// inetDiagULPInfo is the attribute of the upper layer protocol, such
+	// as the MPTCP of the subflows, which the kernel adds without request
+	// for the callers with CAP_NET_ADMIN.
+	inetDiagULPInfo = 19
+	// inetULPInfoMPTCP is the attribute of the subflow of MPTCP nested in
+	// inetDiagULPInfo, and mptcpSubflowAttrTokenLoc and
+	// mptcpSubflowAttrFlags are nested in it.
+	inetULPInfoMPTCP         = 3
+	mptcpSubflowAttrTokenLoc = 2
+	mptcpSubflowAttrFlags    = 8
+	// mptcpSubflowFlagJoinLoc and mptcpSubflowFlagJoinRem mark the
+	// subflows joining the connection by MP_JOIN, instead of the initial
+	// subflow of MP_CAPABLE.
+	mptcpSubflowFlagJoinRem = 1 << 2
+	mptcpSubflowFlagJoinLoc = 1 << 3
+	// nlaTypeMask clears the flags of the types of the nested attributes.
+	nlaTypeMask = 0x3fff
+	// sizeofInetDiagMsg is the size of inet_diag_msg, which is followed by
+	// the attributes.
+	sizeofInetDiagMsg = 72
+)
+
+// The layout of the netlink messages, which is the same on every host, so
+// that the recorded dumps are parsed without the netlink of the kernel.
+const (
+	nlmsgHdrLen  = 16
+	nlmsgAlignTo = 4
+	nlmsgError   = 2
+	nlmsgDone    = 3
+	sizeofRtAttr = 4
+	rtaAlignTo   = 4
+)
+
+var byteOrder = sys.GetEndian()
+
+// AddressFamily is the address family of the sockets dumped by InetDiag.
+type AddressFamily uint8
+
+// The address families of the kernel.
+const (
+	AFInet  AddressFamily = 2
+	AFInet6 AddressFamily = 10
+)
+
+// String returns the name of the address family, which names the dumps
+// recorded by RecordInetDiag.
+func (af AddressFamily) String() string {
+	switch af {
+	case AFInet:
+		return "ipv4"
+	case AFInet6:
+		return "ipv6"
+	}
+	return fmt.Sprintf("UNKNOWN (%d)", uint8(af))
+}
+
+// InetDiagHeader is the inet_diag_msg of a socket, which precedes the
+// attributes.
+type InetDiagHeader struct {
+	Family  uint8 // Address family.
+	State   uint8 // TCP State
+	Timer   uint8
+	Retrans uint8
+
+	ID InetDiagSockID
+
+	Expires uint32
+	RQueue  uint32 // Recv-Q
+	WQueue  uint32 // Send-Q
+	UID     uint32 // UID
+	Inode   uint32 // Inode of socket.
+}
+
+// InetDiagSockID is the inet_diag_sockid of a socket.
+type InetDiagSockID struct {
+	SPort  [2]byte  // Source port (big-endian).
+	DPort  [2]byte  // Destination port (big-endian).
+	Src    [16]byte // Source IP
+	Dst    [16]byte // Destination IP
+	If     uint32
+	Cookie [2]uint32
+}
+
+// SrcPort returns the source (local) port.
+func (m InetDiagHeader) SrcPort() int { return int(binary.BigEndian.Uint16(m.ID.SPort[:])) }
+
+// DstPort returns the destination (remote) port.
+func (m InetDiagHeader) DstPort() int { return int(binary.BigEndian.Uint16(m.ID.DPort[:])) }
+
+// SrcIP returns the source (local) IP.
+func (m InetDiagHeader) SrcIP() net.IP { return diagIP(m.ID.Src, AddressFamily(m.Family)) }
+
+// DstIP returns the destination (remote) IP.
+func (m InetDiagHeader) DstIP() net.IP { return diagIP(m.ID.Dst, AddressFamily(m.Family)) }
+
+func diagIP(data [16]byte, af AddressFamily) net.IP {
+	if af == AFInet {
+		return net.IPv4(data[0], data[1], data[2], data[3])
+	}
+	return net.IP(data[:])
+}
+
+// InetDiagMsg is an inet_diag_msg with the attributes requested by the probe.
+type InetDiagMsg struct {
+	InetDiagHeader
+	// Cong is the congestion control algorithm of the socket, such as
+	// "cubic" or "bbr". It is empty without the attribute, such as for the
+	// listening sockets or in the dumps recorded before it was requested.
+	Cong string
+	// Pid is the process opening the socket, which is known instead of
+	// the inode by the sources other than the netlink, such as the state
+	// table of eBPF. It is 0 if unknown.
+	Pid uint32
+	// Subflow is nil unless the socket is a subflow of MPTCP.
+	Subflow *Subflow
+}
+
+// Subflow is a subflow of a MPTCP connection, which is a TCP socket of a
+// path of the connection.
+type Subflow struct {
+	// Token identifies the MPTCP connection on the host, which is shared by
+	// its subflows.
+	Token uint32
+	// Join is whether the subflow joined the connection by MP_JOIN after
+	// the initial subflow.
+	Join bool
+}
+
+// InetDiag dumps the sockets by sock_diag netlink.
+type InetDiag interface {
+	// Dump returns the sockets of the address family. The caller may
+	// modify the returned messages.
+	Dump(family AddressFamily) ([]*InetDiagMsg, error)
+}
+
+// parseInetDiagMsgs appends the messages in data to diags, and returns
+// whether the dump is done.
+func parseInetDiagMsgs(diags []*InetDiagMsg, data []byte) ([]*InetDiagMsg, bool, error) {
+	for len(data) >= nlmsgHdrLen {
+		l := int(byteOrder.Uint32(data[0:4]))
+		if l < nlmsgHdrLen || l > len(data) {
+			return nil, false, xerrors.Errorf("could not parse netlink messages: %w", syscall.EINVAL)
+		}
+		body := data[nlmsgHdrLen:l]
+		switch byteOrder.Uint16(data[4:6]) {
+		case nlmsgDone:
+			return diags, true, nil
+		case nlmsgError:
+			return nil, false, parseNetlinkError(body)
+		}
+		d := &InetDiagMsg{}
+		if err := binary.Read(bytes.NewReader(body), byteOrder, &d.InetDiagHeader); err != nil {
+			return nil, false, xerrors.Errorf("could not parse inet_diag_msg: %w", err)
+		}
+		if len(body) > sizeofInetDiagMsg {
+			d.Cong = congOf(body[sizeofInetDiagMsg:])
+			d.Subflow = subflowOf(body[sizeofInetDiagMsg:])
+		}
+		diags = append(diags, d)
+
+		next := (l + nlmsgAlignTo - 1) &^ (nlmsgAlignTo - 1)
+		if next > len(data) {
+			next = len(data)
+		}
+		data = data[next:]
+	}
+	return diags, false, nil
+}
+
+// parseNetlinkError returns the errno of the kernel in the data of
+// NLMSG_ERROR.
+func parseNetlinkError(data []byte) error {
+	if len(data) < 4 {
+		return xerrors.New("received netlink error (data too short to read errno)")
+	}
+	return syscall.Errno(-int32(byteOrder.Uint32(data[0:4])))
+}
+
+// congOf returns the value of the attribute of the congestion control
+// algorithm among the attributes, or empty if there is none.
+func congOf(attrs []byte) string {
+	v, ok := attrOf(attrs, inetDiagCong)
+	if !ok {
+		return ""
+	}
+	return strings.TrimRight(string(v), "\x00")
+}
+
+// subflowOf returns the subflow of MPTCP in the attributes of the upper
+// layer protocol, or nil if the socket is not a subflow.
+func subflowOf(attrs []byte) *Subflow {
+	ulp, ok := attrOf(attrs, inetDiagULPInfo)
+	if !ok {
+		return nil
+	}
+	info, ok := attrOf(ulp, inetULPInfoMPTCP)
+	if !ok {
+		return nil
+	}
+	token, ok := attrOf(info, mptcpSubflowAttrTokenLoc)
+	if !ok || len(token) < 4 {
+		return nil
+	}
+	sf := &Subflow{Token: byteOrder.Uint32(token)}
+	if flags, ok := attrOf(info, mptcpSubflowAttrFlags); ok && len(flags) >= 4 {
+		sf.Join = byteOrder.Uint32(flags)&(mptcpSubflowFlagJoinLoc|mptcpSubflowFlagJoinRem) != 0
+	}
+	return sf
+}
+
+// attrOf returns the value of the attribute of the type among the
+// attributes, ignoring the flags of the nested attributes.
+func attrOf(attrs []byte, typ uint16) ([]byte, bool) {
+	for len(attrs) >= sizeofRtAttr {
+		l := int(byteOrder.Uint16(attrs[0:2]))
+		if l < sizeofRtAttr || l > len(attrs) {
+			return nil, false
+		}
+		if byteOrder.Uint16(attrs[2:4])&nlaTypeMask == typ {
+			return attrs[sizeofRtAttr:l], true
+		}
+		next := (l + rtaAlignTo - 1) &^ (rtaAlignTo - 1)
+		if next >= len(attrs) {
+			return nil, false
+		}
+		attrs = attrs[next:]
+	}
+	return nil, false
+}
+
+var (
+	inetDiagMu sync.RWMutex
+	inetDiag   = defaultInetDiag
+)
+
+// CurrentInetDiag returns the InetDiag the probe queries.
+func CurrentInetDiag() InetDiag {
+	inetDiagMu.RLock()
+	defer inetDiagMu.RUnlock()
+	return inetDiag
+}
+
+// SetInetDiag points the probe at d instead of the netlink of the kernel.
+func SetInetDiag(d InetDiag) {
+	inetDiagMu.Lock()
+	defer inetDiagMu.Unlock()
+	inetDiag = d
+}
+
+// ParseInetDiagDump parses the netlink responses recorded by RecordInetDiag.
+func ParseInetDiagDump(data []byte) ([]*InetDiagMsg, error) {
+	diags, _, err := parseInetDiagMsgs(nil, data)
+	if err != nil {
+		return nil, err
+	}
+	if diags == nil {
+		diags = []*InetDiagMsg{}
+	}
+	return diags, nil
+}
+
+// FakeInetDiag is an InetDiag replaying recorded dumps, so that the probe
+// can be tested without a live Linux box.
+type FakeInetDiag struct {
+	// Dumps are the dumps recorded by RecordInetDiag by the address family.
+	// The family without dump has no sockets.
+	Dumps map[AddressFamily][]byte
+	// Errs are returned by Dump instead of the dumps to simulate failures.
+	Errs map[AddressFamily]error
+}
+
+// LoadFakeInetDiag loads the dumps named by the address family, such as
+// "ipv4" and "ipv6", from dir.
+func LoadFakeInetDiag(dir string) (*FakeInetDiag, error) {
+	d := &FakeInetDiag{Dumps: make(map[AddressFamily][]byte)}
+	for _, family := range []AddressFamily{AFInet, AFInet6} {
+		data, err := ioutil.ReadFile(filepath.Join(dir, family.String()))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, xerrors.Errorf("could not load inet_diag dump: %w", err)
+		}
+		d.Dumps[family] = data
+	}
+	return d, nil
+}
+
+// Dump parses the dump of the family each time, so that the messages
+// modified by the caller do not leak into the next call.
+func (d *FakeInetDiag) Dump(family AddressFamily) ([]*InetDiagMsg, error) {
+	if err := d.Errs[family]; err != nil {
+		return nil, err
+	}
+	return ParseInetDiagDump(d.Dumps[family])
+}
@@ -0,0 +1,80 @@
+// +build linux
+
+package netutil
+
+import (
+	"io"
+	"os"
+	"syscall"
+
+	"github.com/elastic/gosigar/sys/linux"
+	"golang.org/x/xerrors"
+)
+
+// defaultInetDiag queries the netlink of the kernel.
+var defaultInetDiag InetDiag = netlinkInetDiag{}
+
+type netlinkInetDiag struct{}
+
+func (netlinkInetDiag) Dump(family AddressFamily) ([]*InetDiagMsg, error) {
+	buf := netlinkBufferPool.Get().(*[]byte)
+	defer netlinkBufferPool.Put(buf)
+	return dumpInetDiag(family, *buf, nil)
+}
+
+// dumpInetDiag requests the TCP sockets of the address family with their
+// congestion control algorithms, reading the responses into buf, and
+// copies the raw responses into w if it is not nil.
+func dumpInetDiag(family AddressFamily, buf []byte, w io.Writer) ([]*InetDiagMsg, error) {
+	req := linux.NewInetDiagReqV2(linux.AddressFamily(family))
+	req.Data[2] = 1 << (inetDiagCong - 1) // idiag_ext of inet_diag_req_v2
+
+	s, err := syscall.Socket(syscall.AF_NETLINK, syscall.SOCK_RAW, syscall.NETLINK_INET_DIAG)
+	if err != nil {
+		return nil, err
+	}
+	defer syscall.Close(s)
+
+	b := make([]byte, syscall.NLMSG_HDRLEN+len(req.Data))
+	byteOrder.PutUint32(b[0:4], uint32(len(b)))
+	byteOrder.PutUint16(b[4:6], req.Header.Type)
+	byteOrder.PutUint16(b[6:8], req.Header.Flags)
+	copy(b[syscall.NLMSG_HDRLEN:], req.Data)
+	if err := syscall.Sendto(s, b, 0, &syscall.SockaddrNetlink{Family: syscall.AF_NETLINK}); err != nil {
+		return nil, err
+	}
+
+	if len(buf) == 0 {
+		buf = make([]byte, os.Getpagesize())
+	}
+	var diags []*InetDiagMsg
+	for {
+		n, _, err := syscall.Recvfrom(s, buf, 0)
+		if err != nil {
+			return nil, err
+		}
+		if n < syscall.NLMSG_HDRLEN {
+			return nil, syscall.EINVAL
+		}
+		if w != nil {
+			if _, err := w.Write(buf[:n]); err != nil {
+				return nil, err
+			}
+		}
+		var done bool
+		diags, done, err = parseInetDiagMsgs(diags, buf[:n])
+		if err != nil || done {
+			return diags, err
+		}
+	}
+}
+
+// RecordInetDiag writes the raw netlink responses for the sockets of the
+// address family into w, which FakeInetDiag replays on a host of the same
+// byte order.
+func RecordInetDiag(family AddressFamily, w io.Writer) error {
+	if _, err := dumpInetDiag(family, nil, w); err != nil {
+		return xerrors.Errorf("NetlinkInetDiag: %w", newNetlinkError(err))
+	}
+	return nil
+}
@@ -0,0 +1,15 @@
+// +build !linux
+
+package netutil
+
+import "syscall"
+
+// defaultInetDiag fails on the hosts without sock_diag netlink, which only
+// replay the dumps by SetInetDiag.
+var defaultInetDiag InetDiag = unsupportedInetDiag{}
+
+type unsupportedInetDiag struct{}
+
+func (unsupportedInetDiag) Dump(family AddressFamily) ([]*InetDiagMsg, error) {
+	return nil, syscall.EAFNOSUPPORT
+}
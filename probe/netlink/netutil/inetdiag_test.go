@@ -0,0 +1,148 @@
+package netutil
+
+import (
+	"path/filepath"
+	"syscall"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+const (
+	// sockDiagByFamily is the type of the messages of the sockets.
+	sockDiagByFamily = 20
+	// tcpEstablished is the state of the established sockets.
+	tcpEstablished = 1
+)
+
+// netlinkMsg returns a netlink message of the type with the data.
+func netlinkMsg(typ uint16, data []byte) []byte {
+	b := make([]byte, nlmsgHdrLen+len(data))
+	byteOrder.PutUint32(b[0:4], uint32(len(b)))
+	byteOrder.PutUint16(b[4:6], typ)
+	copy(b[nlmsgHdrLen:], data)
+	return b
+}
+
+// rtAttr returns an attribute of the type with the value padded.
+func rtAttr(typ uint16, value []byte) []byte {
+	b := make([]byte, (sizeofRtAttr+len(value)+rtaAlignTo-1)&^(rtaAlignTo-1))
+	byteOrder.PutUint16(b[0:2], uint16(sizeofRtAttr+len(value)))
+	byteOrder.PutUint16(b[2:4], typ)
+	copy(b[sizeofRtAttr:], value)
+	return b
+}
+
+func TestParseInetDiagDump_cong(t *testing.T) {
+	msg := make([]byte, sizeofInetDiagMsg)
+	msg[0] = uint8(AFInet)
+	msg[1] = tcpEstablished
+	byteOrder.PutUint32(msg[68:72], 100) // idiag_inode
+
+	var data []byte
+	// a socket with another attribute before the algorithm.
+	data = append(data, netlinkMsg(sockDiagByFamily,
+		append(append(append([]byte{}, msg...), rtAttr(5, []byte{1})...), rtAttr(inetDiagCong, []byte("bbr\x00"))...))...)
+	// a socket without the attribute.
+	data = append(data, netlinkMsg(sockDiagByFamily, msg)...)
+	data = append(data, netlinkMsg(nlmsgDone, make([]byte, 4))...)
+
+	diags, err := ParseInetDiagDump(data)
+	if err != nil {
+		t.Fatalf("should not raise error: %v", err)
+	}
+	got := make([]string, 0, len(diags))
+	for _, d := range diags {
+		if d.Inode != 100 {
+			t.Errorf("Inode = %d, want 100", d.Inode)
+		}
+		got = append(got, d.Cong)
+	}
+	if diff := cmp.Diff([]string{"bbr", ""}, got); diff != "" {
+		t.Errorf("ParseInetDiagDump() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestParseInetDiagDump_subflow(t *testing.T) {
+	msg := make([]byte, sizeofInetDiagMsg)
+	msg[0] = uint8(AFInet)
+	msg[1] = tcpEstablished
+	u32 := func(v uint32) []byte {
+		b := make([]byte, 4)
+		byteOrder.PutUint32(b, v)
+		return b
+	}
+	subflow := func(token, flags uint32) []byte {
+		info := append(rtAttr(mptcpSubflowAttrTokenLoc, u32(token)), rtAttr(mptcpSubflowAttrFlags, u32(flags))...)
+		ulp := append(rtAttr(1, []byte("mptcp\x00")), rtAttr(inetULPInfoMPTCP|0x8000, info)...)
+		return append(append([]byte{}, msg...), rtAttr(inetDiagULPInfo|0x8000, ulp)...)
+	}
+
+	var data []byte
+	// the initial subflow of MP_CAPABLE.
+	data = append(data, netlinkMsg(sockDiagByFamily, subflow(0xcafe, 1<<0|1<<1))...)
+	// a subflow joining by MP_JOIN.
+	data = append(data, netlinkMsg(sockDiagByFamily, subflow(0xcafe, mptcpSubflowFlagJoinLoc))...)
+	// a socket of TLS, which is another upper layer protocol.
+	data = append(data, netlinkMsg(sockDiagByFamily,
+		append(append([]byte{}, msg...), rtAttr(inetDiagULPInfo, rtAttr(1, []byte("tls\x00")))...))...)
+	data = append(data, netlinkMsg(sockDiagByFamily, msg)...)
+	data = append(data, netlinkMsg(nlmsgDone, make([]byte, 4))...)
+
+	diags, err := ParseInetDiagDump(data)
+	if err != nil {
+		t.Fatalf("should not raise error: %v", err)
+	}
+	got := make([]*Subflow, 0, len(diags))
+	for _, d := range diags {
+		got = append(got, d.Subflow)
+	}
+	want := []*Subflow{{Token: 0xcafe}, {Token: 0xcafe, Join: true}, nil, nil}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ParseInetDiagDump() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestParseInetDiagDump_error(t *testing.T) {
+	// NLMSG_ERROR carries the negative errno.
+	code := -int32(syscall.EPERM)
+	errno := make([]byte, 4)
+	byteOrder.PutUint32(errno, uint32(code))
+	if _, err := ParseInetDiagDump(netlinkMsg(nlmsgError, errno)); err != syscall.EPERM {
+		t.Errorf("ParseInetDiagDump() should return the errno of NLMSG_ERROR, but %v", err)
+	}
+	if _, err := ParseInetDiagDump(netlinkMsg(sockDiagByFamily, make([]byte, 4))); err == nil {
+		t.Error("ParseInetDiagDump() should raise error for the truncated message")
+	}
+}
+
+func TestLoadFakeInetDiag(t *testing.T) {
+	d, err := LoadFakeInetDiag(filepath.Join("..", "testdata", "inetdiag"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, family := range []AddressFamily{AFInet, AFInet6} {
+		diags, err := d.Dump(family)
+		if err != nil {
+			t.Fatalf("should not raise error: %v", err)
+		}
+		if len(diags) == 0 {
+			t.Fatalf("Dump(%s) should replay the recorded sockets", family)
+		}
+		for _, m := range diags {
+			if AddressFamily(m.Family) != family {
+				t.Errorf("Dump(%s) should return the sockets of the family, but %s", family, AddressFamily(m.Family))
+			}
+		}
+		// The replayed messages are not shared between the calls.
+		again, _ := d.Dump(family)
+		if again[0] == diags[0] {
+			t.Errorf("Dump(%s) should return new messages for each call", family)
+		}
+	}
+
+	d.Errs = map[AddressFamily]error{AFInet: syscall.EPROTONOSUPPORT}
+	if _, err := d.Dump(AFInet); err != syscall.EPROTONOSUPPORT {
+		t.Errorf("Dump() should return the simulated error, but %v", err)
+	}
+}
@@ -1,9 +1,13 @@
 package netutil
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"net"
 	"strings"
+	"sync"
+	"time"
 
 	"golang.org/x/xerrors"
 )
@@ -73,18 +77,96 @@ func (u *UserEnt) SetInode(inode uint32) {
 	u.inode = inode
 }
 
-// UserEnts represents a hashmap of UserEnt as key is the inode.
-type UserEnts map[uint32]*UserEnt
+// userEntJSON is the JSON representation of UserEnt in the recorded scans.
+type userEntJSON struct {
+	Inode uint32 `json:"inode"`
+	Fd    int    `json:"fd"`
+	Pid   int    `json:"pid"`
+	Pname string `json:"pname"`
+	Ppid  int    `json:"ppid"`
+	Pgrp  int    `json:"pgrp"`
+}
+
+// MarshalJSON implements json.Marshaler.
+func (u *UserEnt) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&userEntJSON{
+		Inode: u.inode, Fd: u.fd, Pid: u.pid, Pname: u.pname, Ppid: u.ppid, Pgrp: u.pgrp,
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (u *UserEnt) UnmarshalJSON(data []byte) error {
+	var v userEntJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*u = UserEnt{inode: v.Inode, fd: v.Fd, pid: v.Pid, pname: v.Pname, ppid: v.Ppid, pgrp: v.Pgrp}
+	return nil
+}
+
+// UserEnts represents a hashmap of UserEnt as key is the inode. The
+// entries of an inode are all the processes owning the socket in the order
+// of the pids, which are more than one if it is shared by fork(2) or passed
+// by SCM_RIGHTS, such as by prefork servers or systemd socket activation.
+type UserEnts map[uint32][]*UserEnt
+
+const (
+	// ResolveConcurrency is the maximum number of lookups in flight.
+	ResolveConcurrency = 16
+	// ResolveTimeout is the timeout of each lookup.
+	ResolveTimeout = 2 * time.Second
+)
+
+// lookupAddr is replaced in testing.
+var lookupAddr = net.DefaultResolver.LookupAddr
 
 // ResolveAddr lookup first hostname from IP Address.
 func ResolveAddr(addr string) string {
-	hostnames, _ := net.LookupAddr(addr)
+	ctx, cancel := context.WithTimeout(context.Background(), ResolveTimeout)
+	defer cancel()
+	hostnames, _ := lookupAddr(ctx, addr)
 	if len(hostnames) > 0 {
 		return strings.TrimSuffix(hostnames[0], ".")
 	}
 	return addr
 }
 
+// ResolveAddrs looks up the hostnames of the addresses by at most
+// ResolveConcurrency workers, so that a slow DNS server delays the scan by
+// about len(addrs) / ResolveConcurrency * ResolveTimeout at worst.
+// The map from the address to the hostname includes the address itself
+// if it cannot be resolved.
+func ResolveAddrs(addrs []string) map[string]string {
+	queue := make(chan string)
+	names := make(map[string]string, len(addrs))
+	var (
+		mu sync.Mutex
+		wg sync.WaitGroup
+	)
+	workers := ResolveConcurrency
+	if len(addrs) < workers {
+		workers = len(addrs)
+	}
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for addr := range queue {
+				name := ResolveAddr(addr)
+				mu.Lock()
+				names[addr] = name
+				mu.Unlock()
+			}
+		}()
+	}
+	for _, addr := range addrs {
+		queue <- addr
+	}
+	close(queue)
+	wg.Wait()
+	return names
+}
+
 // LocalIPAddrs gets the string slice of localhost IPaddrs.
 func LocalIPAddrs() ([]string, error) {
 	addrs, err := net.InterfaceAddrs()
@@ -7,49 +7,132 @@ import (
 	"encoding/hex"
 	"fmt"
 	"io"
-	"io/ioutil"
 	"net"
 	"os"
-	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"syscall"
 
-	"github.com/EricLagergren/go-gnulib/dirent"
 	"github.com/elastic/gosigar/sys/linux"
 	gnet "github.com/shirou/gopsutil/net"
 	"github.com/yuuki/shawk/logging"
-	"golang.org/x/sys/unix"
 	"golang.org/x/xerrors"
 )
 
 var logger = logging.New("netutil")
 
-// NetlinkError represents netlink error.
-type NetlinkError struct {
-	msg string
+var (
+	// netlinkBufferPool reuses the buffers to receive netlink messages.
+	// The messages are copied into InetDiagMsg, so that the buffer can be
+	// reused after parsing.
+	netlinkBufferPool = sync.Pool{
+		New: func() interface{} {
+			// Default size used in libnl.
+			b := make([]byte, os.Getpagesize())
+			return &b
+		},
+	}
+	// bufferPool reuses the buffers to read procfs files.
+	bufferPool = sync.Pool{
+		New: func() interface{} { return new(bytes.Buffer) },
+	}
+	// linkBufferPool reuses the buffers to read symbolic links of file descriptors.
+	linkBufferPool = sync.Pool{
+		New: func() interface{} {
+			b := make([]byte, 128)
+			return &b
+		},
+	}
+)
+
+// NetlinkConnections returns connection stats of both IPv4 and IPv6.
+func NetlinkConnections() ([]*InetDiagMsg, error) {
+	diag := CurrentInetDiag()
+	v4, err := diag.Dump(AFInet)
+	if err != nil {
+		return nil, xerrors.Errorf("NetlinkInetDiag: %w", newNetlinkError(err))
+	}
+	v6, err := diag.Dump(AFInet6)
+	if err != nil {
+		// IPv6 may be disabled on the host.
+		logger.Debugf("could not get IPv6 connections: %v", err)
+	}
+	return mergeInetDiagMsgs(v4, v6), nil
+}
+
+// newNetlinkError wraps the error of the netlink, where NLMSG_ERROR carries
+// the errno of the kernel as parsed by parseNetlinkError.
+func newNetlinkError(err error) *NetlinkError {
+	return &NetlinkError{
+		msg: err.Error(),
+		err: ClassifyError("netlink inet_diag", "sock_diag netlink", err),
+	}
 }
 
-func (e *NetlinkError) Error() string {
-	return fmt.Sprintf("Netlink error: %s", e.msg)
+// diagMsgKey identifies a socket across address families.
+type diagMsgKey struct {
+	src, dst     [16]byte
+	sport, dport [2]byte
+	inode        uint32
 }
 
-// NetlinkConnections returns connection stats.
-func NetlinkConnections() ([]*linux.InetDiagMsg, error) {
-	req := linux.NewInetDiagReq()
-	msgs, err := linux.NetlinkInetDiag(req)
-	if err != nil {
-		return nil, xerrors.Errorf("NetlinkInetDiag: %w", &NetlinkError{})
+// v4MappedPrefix is the prefix of IPv4-mapped IPv6 addresses (::ffff:0:0/96).
+var v4MappedPrefix = [12]byte{10: 0xff, 11: 0xff}
+
+// unmapV4 converts the message of an IPv6 socket connected by IPv4 into the
+// IPv4 representation.
+func unmapV4(m *InetDiagMsg) {
+	if m.Family != uint8(AFInet6) {
+		return
+	}
+	var src, dst [12]byte
+	copy(src[:], m.ID.Src[:12])
+	copy(dst[:], m.ID.Dst[:12])
+	if src != v4MappedPrefix || (dst != v4MappedPrefix && dst != [12]byte{}) {
+		return
+	}
+	m.Family = uint8(AFInet)
+	copy(m.ID.Src[:4], m.ID.Src[12:])
+	copy(m.ID.Dst[:4], m.ID.Dst[12:])
+	for i := 4; i < 16; i++ {
+		m.ID.Src[i], m.ID.Dst[i] = 0, 0
+	}
+}
+
+// mergeInetDiagMsgs merges the messages of IPv4 and IPv6 requests. The IPv6
+// sockets with IPv4-mapped addresses are converted into IPv4, and the
+// duplicated sockets are removed.
+func mergeInetDiagMsgs(v4, v6 []*InetDiagMsg) []*InetDiagMsg {
+	msgs := make([]*InetDiagMsg, 0, len(v4)+len(v6))
+	seen := make(map[diagMsgKey]struct{}, len(v4)+len(v6))
+	for _, list := range [][]*InetDiagMsg{v4, v6} {
+		for _, m := range list {
+			unmapV4(m)
+			key := diagMsgKey{
+				src:   m.ID.Src,
+				dst:   m.ID.Dst,
+				sport: m.ID.SPort,
+				dport: m.ID.DPort,
+				inode: m.Inode,
+			}
+			if _, ok := seen[key]; ok {
+				continue
+			}
+			seen[key] = struct{}{}
+			msgs = append(msgs, m)
+		}
 	}
-	return msgs, nil
+	return msgs
 }
 
 // UserEntByLport is a map that key is listening port, value is UserEnt structure.
-type UserEntByLport map[string]*UserEnt
+type UserEntByLport map[uint16]*UserEnt
 
 // NetlinkFilterByLocalListeningPorts filters ConnectionStat slice by the local listening ports.
-func NetlinkFilterByLocalListeningPorts(conns []*linux.InetDiagMsg) ([]*linux.InetDiagMsg, error) {
-	lconns := []*linux.InetDiagMsg{}
+func NetlinkFilterByLocalListeningPorts(conns []*InetDiagMsg) ([]*InetDiagMsg, error) {
+	lconns := []*InetDiagMsg{}
 	for _, conn := range conns {
 		if linux.TCPState(conn.State) != linux.TCP_LISTEN {
 			continue
@@ -63,23 +146,24 @@ func NetlinkFilterByLocalListeningPorts(conns []*linux.InetDiagMsg) ([]*linux.In
 }
 
 // NetlinkLocalListeningPorts returns the local listening ports.
-func NetlinkLocalListeningPorts() ([]string, error) {
+func NetlinkLocalListeningPorts() ([]uint16, error) {
 	msgs, err := NetlinkConnections()
 	if err != nil {
 		return nil, err
 	}
-	ports := make([]string, 0, len(msgs))
+	ports := make([]uint16, 0, len(msgs))
 	for _, diag := range msgs {
 		if linux.TCPState(diag.State) != linux.TCP_LISTEN {
 			continue
 		}
-		ports = append(ports, fmt.Sprintf("%v", diag.SrcPort()))
+		ports = append(ports, uint16(diag.SrcPort()))
 	}
 	return ports, nil
 }
 
 const (
-	tcpProcFilename = "/proc/net/tcp"
+	// tcpProcFilename is relative to the procfs root.
+	tcpProcFilename = "net/tcp"
 )
 
 // Addr is <addr>:<port>.
@@ -98,41 +182,103 @@ type ConnectionStat struct {
 // ProcfsConnections returns connection stats.
 // ref. https://github.com/shirou/gopsutil/blob/c23bcca55e77b8389d84b09db8c5ac2b472070ef/net/net_linux.go#L656
 func ProcfsConnections() ([]*ConnectionStat, error) {
-	body, err := ioutil.ReadFile(tcpProcFilename)
+	f, err := ProcFS().Open(tcpProcFilename)
 	if err != nil {
-		return nil, err
+		return nil, ClassifyError("open procfs", "procfs", err)
 	}
+	defer f.Close()
 
-	lines := bytes.Split(body, []byte("\n"))
-	conns := make([]*ConnectionStat, 0, len(lines)-1)
-	for _, line := range lines[1:] {
-		l := strings.Fields(string(line))
-		if len(l) < 10 {
+	buf := bufferPool.Get().(*bytes.Buffer)
+	defer bufferPool.Put(buf)
+	buf.Reset()
+	if _, err := buf.ReadFrom(f); err != nil {
+		return nil, xerrors.Errorf("could not read %s: %w", tcpProcFilename, err)
+	}
+	return parseProcNetTCP(buf.Bytes()), nil
+}
+
+// nextField splits the first whitespace-separated field from b.
+func nextField(b []byte) (field, rest []byte) {
+	i := 0
+	for i < len(b) && b[i] == ' ' {
+		i++
+	}
+	j := i
+	for j < len(b) && b[j] != ' ' {
+		j++
+	}
+	return b[i:j], b[j:]
+}
+
+func parseProcNetTCP(body []byte) []*ConnectionStat {
+	// The first line is the header.
+	n := bytes.Count(body, []byte("\n"))
+	stats := make([]ConnectionStat, 0, n)
+	conns := make([]*ConnectionStat, 0, n)
+	for first := true; len(body) > 0; first = false {
+		line := body
+		if i := bytes.IndexByte(body, '\n'); i >= 0 {
+			line, body = body[:i], body[i+1:]
+		} else {
+			body = nil
+		}
+		if first {
+			continue
+		}
+
+		var fields [10][]byte
+		rest := line
+		for i := range fields {
+			fields[i], rest = nextField(rest)
+		}
+		if len(fields[9]) == 0 {
 			continue
 		}
-		laddr := l[1]
-		raddr := l[2]
-		status, err := strconv.ParseUint(l[3], 16, 8)
+		status, err := parseHex(fields[3])
 		if err != nil {
 			logger.Tracef("decode error: %v", err)
 		}
-		la, err := decodeAddress(laddr)
+		la, err := decodeAddress(fields[1])
 		if err != nil {
 			continue
 		}
-		ra, err := decodeAddress(raddr)
+		ra, err := decodeAddress(fields[2])
 		if err != nil {
 			continue
 		}
 
-		conns = append(conns, &ConnectionStat{
+		stats = append(stats, ConnectionStat{
 			Laddr:  la,
 			Raddr:  ra,
 			Status: linux.TCPState(status),
 		})
+		conns = append(conns, &stats[len(stats)-1])
 	}
 
-	return conns, nil
+	return conns
+}
+
+// parseHex parses a hexadecimal number without converting b into string.
+func parseHex(b []byte) (uint32, error) {
+	if len(b) == 0 || len(b) > 8 {
+		return 0, xerrors.Errorf("invalid hex number '%s'", b)
+	}
+	var n uint32
+	for _, c := range b {
+		var d byte
+		switch {
+		case '0' <= c && c <= '9':
+			d = c - '0'
+		case 'a' <= c && c <= 'f':
+			d = c - 'a' + 10
+		case 'A' <= c && c <= 'F':
+			d = c - 'A' + 10
+		default:
+			return 0, xerrors.Errorf("invalid hex number '%s'", b)
+		}
+		n = n<<4 | uint32(d)
+	}
+	return n, nil
 }
 
 // decodeAddress decode addresse represents addr in proc/net/*
@@ -140,44 +286,47 @@ func ProcfsConnections() ([]*ConnectionStat, error) {
 // "0500000A:0016" -> "10.0.0.5", 22
 // "0085002452100113070057A13F025401:0035" -> "2400:8500:1301:1052:a157:7:154:23f", 53
 // ref. https://github.com/shirou/gopsutil/blob/c23bcca55e77b8389d84b09db8c5ac2b472070ef/net/net_linux.go#L600
-func decodeAddress(src string) (Addr, error) {
-	t := strings.Split(src, ":")
-	if len(t) != 2 {
+func decodeAddress(src []byte) (Addr, error) {
+	i := bytes.IndexByte(src, ':')
+	if i == -1 {
 		return Addr{}, xerrors.Errorf("does not contain port, %s", src)
 	}
-	addr := t[0]
-	port, err := strconv.ParseInt("0x"+t[1], 0, 64)
+	port, err := parseHex(src[i+1:])
 	if err != nil {
 		return Addr{}, xerrors.Errorf("invalid port, %s", src)
 	}
-	decoded, err := hex.DecodeString(addr)
+	var decoded [net.IPv6len]byte
+	if i > 2*len(decoded) {
+		return Addr{}, xerrors.Errorf("invalid address, %s", src)
+	}
+	n, err := hex.Decode(decoded[:], src[:i])
 	if err != nil {
 		return Addr{}, xerrors.Errorf("decode error, %s", err)
 	}
 	// Assumes this is little_endian
-	ip := net.IP(gnet.Reverse(decoded))
+	ip := net.IP(gnet.Reverse(decoded[:n]))
 	return Addr{
 		IP:   ip.String(),
-		Port: uint32(port),
+		Port: port,
 	}, nil
 }
 
 // FilterByLocalListeningPorts filters ConnectionStat slice by the local listening ports.
-func FilterByLocalListeningPorts(conns []*ConnectionStat) ([]string, error) {
-	ports := []string{}
+func FilterByLocalListeningPorts(conns []*ConnectionStat) ([]uint16, error) {
+	ports := []uint16{}
 	for _, conn := range conns {
 		if conn.Status != linux.TCP_LISTEN {
 			continue
 		}
 		if conn.Laddr.IP == "0.0.0.0" || conn.Laddr.IP == "127.0.0.1" || conn.Laddr.IP == "::" {
-			ports = append(ports, fmt.Sprintf("%d", conn.Laddr.Port))
+			ports = append(ports, uint16(conn.Laddr.Port))
 		}
 	}
 	return ports, nil
 }
 
 // LocalListeningPorts returns the local listening ports.
-func LocalListeningPorts() ([]string, error) {
+func LocalListeningPorts() ([]uint16, error) {
 	conns, err := ProcfsConnections()
 	if err != nil {
 		return nil, err
@@ -191,9 +340,9 @@ type procStat struct {
 	Pgrp  int    // process group id
 }
 
-func parseProcStat(root string, pid int) (*procStat, error) {
-	stat := fmt.Sprintf("%s/%d/stat", root, pid)
-	f, err := os.Open(stat)
+func parseProcStat(fsys FS, pid int) (*procStat, error) {
+	stat := strconv.Itoa(pid) + "/stat"
+	f, err := fsys.Open(stat)
 	if err != nil {
 		return nil, xerrors.Errorf("could not open %s: %w", stat, err)
 	}
@@ -223,6 +372,22 @@ func parseProcStat(root string, pid int) (*procStat, error) {
 	}, nil
 }
 
+// UserEntOf returns the UserEnt of the process of pid without the socket,
+// for the sockets known by the pids instead of the inodes.
+func UserEntOf(pid int) (*UserEnt, error) {
+	stat, err := parseProcStat(ProcFS(), pid)
+	if err != nil {
+		return nil, err
+	}
+	return &UserEnt{
+		fd:    -1,
+		pid:   pid,
+		pname: stat.Pname,
+		ppid:  stat.Ppid,
+		pgrp:  stat.Pgrp,
+	}, nil
+}
+
 const socketPrefix = "socket:["
 
 // parse inode number from 'socket:[<inode number>]'.
@@ -244,134 +409,151 @@ func parseSocketInode(lnk string) (uint32, error) {
 	return uint32(ino), nil
 }
 
-func binaryToString(s []int8) string {
-	var buff bytes.Buffer
-	for _, chr := range s {
-		if chr == 0x00 { // remove null
-			break
-		}
-		buff.WriteByte(byte(chr))
-	}
-	return buff.String()
-}
-
 // BuildUserEntries scans under /proc/%pid/fd/.
 func BuildUserEntries() (UserEnts, error) {
-	root := os.Getenv("PROC_ROOT")
-	if root == "" {
-		root = "/proc"
+	return buildUserEntries(nil)
+}
+
+// BuildUserEntriesFor scans under /proc/%pid/fd/ only for the given socket
+// inodes. All the processes are scanned even after all the inodes are
+// found, because another process may share a socket.
+func BuildUserEntriesFor(inodes map[uint32]struct{}) (UserEnts, error) {
+	if len(inodes) == 0 {
+		return UserEnts{}, nil
 	}
+	return buildUserEntries(inodes)
+}
+
+// readdirChunk is the number of names read from a directory at once.
+const readdirChunk = 256
 
-	// Use dirent package instread of os.ReadDir for speeding up.
-	// see https://stackoverflow.com/questions/41419056/golang-os-file-readdir-using-lstat-on-all-files-can-it-be-optimised.
-	stream, err := dirent.Open(root)
+// walkDirnames calls fn for each name in dir. The names are read by chunks
+// instead of reading the whole directory into memory and sorting it, because
+// /proc and /proc/<pid>/fd can have tens of thousands of entries on a huge
+// host. The directory is closed when walkDirnames returns.
+func walkDirnames(fsys FS, dir string, fn func(name string) error) error {
+	f, err := fsys.Open(dir)
 	if err != nil {
-		return nil, xerrors.Errorf("dirent.Open %s: %v", root, err)
+		return err
 	}
-	defer stream.Close()
-
-	userEnts := make(UserEnts)
+	defer f.Close()
 
 	for {
-		entry, err := stream.Read()
-		if err != nil {
-			if err == io.EOF {
-				break
+		names, err := f.Readdirnames(readdirChunk)
+		for _, name := range names {
+			if err := fn(name); err != nil {
+				return err
 			}
-			return nil, xerrors.Errorf("stream.Read %s: %v", root, err)
 		}
-		if entry.Type != unix.DT_DIR {
-			// find only "<pid>"" directory
-			continue
+		if err == io.EOF {
+			return nil
 		}
-		dirName := binaryToString(entry.Name[:])
-
-		pid, err := strconv.Atoi(dirName)
 		if err != nil {
-			continue
+			return err
 		}
+	}
+}
 
-		// skip self process
-		if pid == os.Getpid() {
-			continue
-		}
+// buildUserEntries scans the processes owning the inodes, or all processes
+// if inodes is nil.
+func buildUserEntries(inodes map[uint32]struct{}) (UserEnts, error) {
+	fsys := ProcFS()
+	userEnts := make(UserEnts)
+	self := os.Getpid()
 
-		pidDir := filepath.Join(root, dirName)
-		fdDir := filepath.Join(pidDir, "fd")
+	linkBuf := linkBufferPool.Get().(*[]byte)
+	defer linkBufferPool.Put(linkBuf)
 
-		// exists fd?
-		fi, err := os.Stat(fdDir)
-		switch {
-		case err != nil:
-			return nil, xerrors.Errorf("stat %s: %v", fdDir, err)
-		case !fi.IsDir():
-			continue
-		}
-
-		fdStream, err := dirent.Open(fdDir)
+	err := walkDirnames(fsys, ".", func(dirName string) error {
+		// find only "<pid>" directory
+		pid, err := strconv.Atoi(dirName)
 		if err != nil {
-			pathErr := err.(*os.PathError)
-			errno := pathErr.Err.(syscall.Errno)
-			if errno == syscall.EACCES {
-				// ignore "open: <path> permission denied"
-				continue
-			}
-			return nil, xerrors.Errorf("dirent.Open %s: %v", fdDir, err)
+			return nil
+		}
+		// skip self process
+		if pid == self {
+			return nil
 		}
-		defer fdStream.Close()
 
+		fdDir := dirName + "/fd"
 		var stat *procStat
-
-		for {
-			fdEntry, err := fdStream.Read()
-			if err != nil {
-				if err == io.EOF {
-					break
-				}
-				return nil, xerrors.Errorf("fdStream.Read %s: %v", fdEntry, err)
-			}
-			fdName := binaryToString(fdEntry.Name[:])
-
+		err = walkDirnames(fsys, fdDir, func(fdName string) error {
 			fd, err := strconv.Atoi(fdName)
 			if err != nil {
-				continue
+				return nil
 			}
-			fdpath := filepath.Join(fdDir, fdName)
-			lnk, err := os.Readlink(fdpath)
-			if err != nil {
-				pathErr := err.(*os.PathError)
-				errno := pathErr.Err.(syscall.Errno)
-				if errno == syscall.ENOENT {
-					// ignore "readlink: no such file or directory"
-					// because fdpath is disappear depending on timing
-					continue
-				}
-				return nil, xerrors.Errorf("readlink %s: %v", fdpath, err)
+			fdpath := fdDir + "/" + fdName
+			n, err := fsys.Readlink(fdpath, *linkBuf)
+			switch {
+			case err == nil:
+			case os.IsNotExist(err):
+				// ignore "readlink: no such file or directory"
+				// because fdpath is disappear depending on timing
+				return nil
+			case os.IsPermission(err):
+				// ignore "readlink: permission denied"
+				return nil
+			default:
+				return xerrors.Errorf("could not read the link: %w", err)
 			}
-			ino, err := parseSocketInode(lnk)
+			// Skip regular files, pipes and so on without allocating the link.
+			lnk := (*linkBuf)[:n]
+			if !bytes.HasPrefix(lnk, []byte(socketPrefix)) {
+				return nil
+			}
+			ino, err := parseSocketInode(string(lnk))
 			if err != nil {
-				return nil, err
+				return err
 			}
 			if ino == 0 {
-				continue
+				return nil
+			}
+			if inodes != nil {
+				if _, ok := inodes[ino]; !ok {
+					return nil
+				}
 			}
 
 			if stat == nil {
-				stat, err = parseProcStat(root, pid)
+				stat, err = parseProcStat(fsys, pid)
 				if err != nil {
-					return nil, err
+					return err
 				}
 			}
 
-			userEnts[ino] = &UserEnt{
+			// A process owning a socket by several fds is an owner.
+			ents := userEnts[ino]
+			if n := len(ents); n > 0 && ents[n-1].pid == pid {
+				return nil
+			}
+			userEnts[ino] = append(ents, &UserEnt{
 				inode: ino,
 				fd:    fd,
 				pid:   pid,
 				pname: stat.Pname,
 				ppid:  stat.Ppid,
 				pgrp:  stat.Pgrp,
+			})
+			return nil
+		})
+		if pathErr, ok := err.(*os.PathError); ok {
+			switch pathErr.Err {
+			case syscall.EACCES, syscall.ENOENT, syscall.ENOTDIR:
+				// ignore the processes which are not permitted to inspect,
+				// or have exited while walking.
+				return nil
 			}
 		}
+		return err
+	})
+	if err != nil {
+		return nil, xerrors.Errorf("could not walk procfs: %w", ClassifyError("walk procfs", "procfs", err))
+	}
+	// /proc is not read in the order of the pids.
+	for _, ents := range userEnts {
+		if len(ents) > 1 {
+			sort.Slice(ents, func(i, j int) bool { return ents[i].pid < ents[j].pid })
+		}
 	}
 	return userEnts, nil
 }
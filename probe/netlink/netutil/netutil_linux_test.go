@@ -3,9 +3,18 @@
 package netutil
 
 import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net"
 	"os"
 	"path/filepath"
+	"strconv"
+	"syscall"
 	"testing"
+
+	"github.com/elastic/gosigar/sys/linux"
+	"github.com/google/go-cmp/cmp"
 )
 
 func TestNetlinkConnections(t *testing.T) {
@@ -18,12 +27,22 @@ func TestNetlinkConnections(t *testing.T) {
 	}
 }
 
+func TestLocalListeningPorts(t *testing.T) {
+	ports, err := LocalListeningPorts()
+	if err != nil {
+		t.Fatalf("should not raise error: %v", err)
+	}
+	if len(ports) == 0 {
+		t.Error("localIPAddrs() should not be len == 0")
+	}
+}
+
 func TestParseProcStat(t *testing.T) {
 	cur, _ := os.Getwd()
 	root := filepath.Join(cur, "../testdata")
 	pid := 10000
 
-	stat, err := parseProcStat(root, pid)
+	stat, err := parseProcStat(DirFS(root), pid)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -49,3 +68,381 @@ func TestParseSocketInode(t *testing.T) {
 		t.Errorf("inode should be 16408, but %v", ino)
 	}
 }
+
+// newProcNetTCP generates the content of /proc/net/tcp with n connections.
+func newProcNetTCP(n int) []byte {
+	var b bytes.Buffer
+	b.WriteString("  sl  local_address rem_address   st tx_queue rx_queue tr tm->when retrnsmt   uid  timeout inode\n")
+	for i := 0; i < n; i++ {
+		fmt.Fprintf(&b, "%4d: 0500000A:%04X 0600000A:1F90 01 00000000:00000000 00:00000000 00000000  1000        0 %d 1 0000000000000000 20 4 30 10 -1\n",
+			i, 32768+i%28000, 100000+i)
+	}
+	return b.Bytes()
+}
+
+func TestParseProcNetTCP(t *testing.T) {
+	conns := parseProcNetTCP(newProcNetTCP(2))
+	if len(conns) != 2 {
+		t.Fatalf("the number of connections should be 2, but %d", len(conns))
+	}
+	want := &ConnectionStat{
+		Laddr:  Addr{IP: "10.0.0.5", Port: 32769},
+		Raddr:  Addr{IP: "10.0.0.6", Port: 8080},
+		Status: linux.TCP_ESTABLISHED,
+	}
+	if diff := cmp.Diff(want, conns[1]); diff != "" {
+		t.Errorf("parseProcNetTCP() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestProcfsConnections_fixture(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, "net"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(root, "net", "tcp"), newProcNetTCP(3), 0644); err != nil {
+		t.Fatal(err)
+	}
+	SetProcFS(DirFS(root))
+	defer SetProcFS(DirFS("/proc"))
+
+	conns, err := ProcfsConnections()
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if len(conns) != 3 {
+		t.Errorf("the number of connections should be 3, but %d", len(conns))
+	}
+}
+
+func BenchmarkParseProcNetTCP(b *testing.B) {
+	body := newProcNetTCP(100000)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		parseProcNetTCP(body)
+	}
+}
+
+func BenchmarkNetlinkConnections(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := NetlinkConnections(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkBuildUserEntries(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := BuildUserEntries(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestBuildUserEntriesFor_empty(t *testing.T) {
+	ents, err := BuildUserEntriesFor(map[uint32]struct{}{})
+	if err != nil {
+		t.Fatalf("should not raise error: %v", err)
+	}
+	if len(ents) != 0 {
+		t.Errorf("BuildUserEntriesFor() should return no entries, but %d", len(ents))
+	}
+}
+
+func TestBuildUserEntriesFor_unknownInode(t *testing.T) {
+	// an inode which no process owns is not found after walking all processes.
+	ents, err := BuildUserEntriesFor(map[uint32]struct{}{0xffffffff: {}})
+	if err != nil {
+		t.Fatalf("should not raise error: %v", err)
+	}
+	if len(ents) != 0 {
+		t.Errorf("BuildUserEntriesFor() should return no entries, but %d", len(ents))
+	}
+}
+
+func newDiagMsg(family AddressFamily, src, dst string, sport, dport uint16, inode uint32) *InetDiagMsg {
+	m := &InetDiagMsg{InetDiagHeader: InetDiagHeader{Family: uint8(family), Inode: inode}}
+	if family == AFInet {
+		copy(m.ID.Src[:], net.ParseIP(src).To4())
+		copy(m.ID.Dst[:], net.ParseIP(dst).To4())
+	} else {
+		copy(m.ID.Src[:], net.ParseIP(src).To16())
+		copy(m.ID.Dst[:], net.ParseIP(dst).To16())
+	}
+	m.ID.SPort = [2]byte{byte(sport >> 8), byte(sport)}
+	m.ID.DPort = [2]byte{byte(dport >> 8), byte(dport)}
+	return m
+}
+
+func TestMergeInetDiagMsgs(t *testing.T) {
+	v4 := []*InetDiagMsg{
+		newDiagMsg(AFInet, "10.0.0.1", "10.0.0.2", 40000, 5432, 100),
+	}
+	v6 := []*InetDiagMsg{
+		// the same socket reported as IPv4-mapped IPv6.
+		newDiagMsg(AFInet6, "::ffff:10.0.0.1", "::ffff:10.0.0.2", 40000, 5432, 100),
+		// a dual-stack socket connected by IPv4.
+		newDiagMsg(AFInet6, "::ffff:10.0.0.1", "::ffff:10.0.0.3", 80, 50000, 200),
+		newDiagMsg(AFInet6, "2001:db8::1", "2001:db8::2", 80, 50001, 300),
+	}
+
+	msgs := mergeInetDiagMsgs(v4, v6)
+
+	type conn struct {
+		Family   uint8
+		Src, Dst string
+		Inode    uint32
+	}
+	got := make([]conn, 0, len(msgs))
+	for _, m := range msgs {
+		got = append(got, conn{m.Family, m.SrcIP().String(), m.DstIP().String(), m.Inode})
+	}
+	want := []conn{
+		{uint8(AFInet), "10.0.0.1", "10.0.0.2", 100},
+		{uint8(AFInet), "10.0.0.1", "10.0.0.3", 200},
+		{uint8(AFInet6), "2001:db8::1", "2001:db8::2", 300},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("mergeInetDiagMsgs() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+// newProcFixture creates a synthetic /proc with the processes, each of
+// which has the file descriptors of sockets.
+func newProcFixture(tb testing.TB, procs, fds int) string {
+	root := tb.TempDir()
+	ino := 1
+	for pid := 1; pid <= procs; pid++ {
+		pidDir := filepath.Join(root, strconv.Itoa(pid))
+		if err := os.MkdirAll(filepath.Join(pidDir, "fd"), 0755); err != nil {
+			tb.Fatal(err)
+		}
+		stat := fmt.Sprintf("%d (proc%d) S 1 %d %d 0 -1", pid, pid, pid, pid)
+		if err := ioutil.WriteFile(filepath.Join(pidDir, "stat"), []byte(stat), 0644); err != nil {
+			tb.Fatal(err)
+		}
+		for fd := 0; fd < fds; fd++ {
+			lnk := fmt.Sprintf("socket:[%d]", ino)
+			if err := os.Symlink(lnk, filepath.Join(pidDir, "fd", strconv.Itoa(fd))); err != nil {
+				tb.Fatal(err)
+			}
+			ino++
+		}
+	}
+	return root
+}
+
+func TestBuildUserEntries_fixture(t *testing.T) {
+	root := newProcFixture(t, 3, 2)
+	SetProcFS(DirFS(root))
+	defer SetProcFS(DirFS("/proc"))
+
+	ents, err := BuildUserEntries()
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if len(ents) != 6 {
+		t.Fatalf("the number of entries should be 6, but %d", len(ents))
+	}
+	if owners := ents[4]; len(owners) != 1 || owners[0].Pname() != "proc2" || owners[0].Pgrp() != 2 {
+		t.Errorf("inode 4 should belong to proc2, but %v", owners)
+	}
+
+	ents, err = BuildUserEntriesFor(map[uint32]struct{}{5: {}})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if len(ents) != 1 || len(ents[5]) != 1 {
+		t.Errorf("only inode 5 should be found, but %v", ents)
+	}
+}
+
+func TestBuildUserEntries_shared(t *testing.T) {
+	root := newProcFixture(t, 3, 2)
+	SetProcFS(DirFS(root))
+	defer SetProcFS(DirFS("/proc"))
+	// proc3 inherits the socket of proc1 by another fd.
+	if err := os.Symlink("socket:[1]", filepath.Join(root, "3", "fd", "9")); err != nil {
+		t.Fatal(err)
+	}
+
+	ents, err := BuildUserEntriesFor(map[uint32]struct{}{1: {}})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	var got []int
+	for _, ent := range ents[1] {
+		got = append(got, ent.Pid())
+	}
+	if diff := cmp.Diff([]int{1, 3}, got); diff != "" {
+		t.Errorf("the owners of inode 1 mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestUserEntOf(t *testing.T) {
+	root := newProcFixture(t, 2, 0)
+	SetProcFS(DirFS(root))
+	defer SetProcFS(DirFS("/proc"))
+
+	ent, err := UserEntOf(2)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	got := []interface{}{ent.Pid(), ent.Pname(), ent.Ppid(), ent.Pgrp(), ent.Inode()}
+	if diff := cmp.Diff([]interface{}{2, "proc2", 1, 2, uint32(0)}, got); diff != "" {
+		t.Errorf("UserEntOf() mismatch (-want +got):\n%s", diff)
+	}
+
+	if _, err := UserEntOf(3); err == nil {
+		t.Error("UserEntOf() should raise error for the process not found")
+	}
+}
+
+func BenchmarkBuildUserEntries_huge(b *testing.B) {
+	root := newProcFixture(b, 3000, 10)
+	SetProcFS(DirFS(root))
+	defer SetProcFS(DirFS("/proc"))
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := BuildUserEntries(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestRecordInetDiag(t *testing.T) {
+	var b bytes.Buffer
+	if err := RecordInetDiag(AFInet, &b); err != nil {
+		t.Fatalf("should not raise error: %v", err)
+	}
+	d := &FakeInetDiag{Dumps: map[AddressFamily][]byte{AFInet: b.Bytes()}}
+	SetInetDiag(d)
+	defer SetInetDiag(netlinkInetDiag{})
+
+	conns, err := NetlinkConnections()
+	if err != nil {
+		t.Fatalf("should not raise error: %v", err)
+	}
+	if len(conns) == 0 {
+		t.Error("NetlinkConnections() should replay the recorded connections")
+	}
+
+	// The replayed messages are not shared between the calls, because
+	// NetlinkConnections modifies them.
+	again, err := d.Dump(AFInet)
+	if err != nil {
+		t.Fatalf("should not raise error: %v", err)
+	}
+	if again[0] == conns[0] {
+		t.Error("Dump() should return new messages for each call")
+	}
+}
+
+func TestRecorder(t *testing.T) {
+	dir := t.TempDir()
+	r, err := NewRecorder(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	conns, err := r.Dump(AFInet)
+	if err != nil {
+		t.Fatalf("should not raise error: %v", err)
+	}
+	r.ents[1] = []*UserEnt{
+		{inode: 1, fd: 3, pid: 1, pname: "systemd", ppid: 0, pgrp: 1},
+		{inode: 1, fd: 3, pid: 10, pname: "nginx", ppid: 1, pgrp: 10},
+	}
+	snapshot, err := r.Save()
+	if err != nil {
+		t.Fatalf("should not raise error: %v", err)
+	}
+
+	snapshots, err := Snapshots(dir)
+	if err != nil {
+		t.Fatalf("should not raise error: %v", err)
+	}
+	if diff := cmp.Diff([]string{snapshot}, snapshots); diff != "" {
+		t.Errorf("Snapshots() mismatch (-want +got):\n%s", diff)
+	}
+
+	d, ents, err := LoadSnapshot(snapshot)
+	if err != nil {
+		t.Fatalf("should not raise error: %v", err)
+	}
+	replayed, err := d.Dump(AFInet)
+	if err != nil {
+		t.Fatalf("should not raise error: %v", err)
+	}
+	if len(replayed) != len(conns) {
+		t.Errorf("the snapshot should replay %d sockets, but %d", len(conns), len(replayed))
+	}
+	got, _ := ents.BuildUserEntriesFor(map[uint32]struct{}{1: {}, 2: {}})
+	want := UserEnts{1: {
+		{inode: 1, fd: 3, pid: 1, pname: "systemd", ppid: 0, pgrp: 1},
+		{inode: 1, fd: 3, pid: 10, pname: "nginx", ppid: 1, pgrp: 10},
+	}}
+	if diff := cmp.Diff(want, got, cmp.AllowUnexported(UserEnt{})); diff != "" {
+		t.Errorf("BuildUserEntriesFor() mismatch (-want +got):\n%s", diff)
+	}
+
+	// The next snapshot holds only the next scan.
+	if next, err := r.Save(); err != nil || next == snapshot {
+		t.Errorf("Save() should write a new snapshot, but %q, %v", next, err)
+	}
+	if _, err := Snapshots(t.TempDir()); err == nil {
+		t.Error("Snapshots() should raise an error for the directory without snapshots")
+	}
+}
+
+// linkMsg returns a RTM_NEWLINK message of the link with the attributes.
+func linkMsg(index uint32, attrs ...[]byte) []byte {
+	data := make([]byte, syscall.SizeofIfInfomsg)
+	byteOrder.PutUint32(data[4:8], index)
+	for _, attr := range attrs {
+		data = append(data, attr...)
+	}
+	return netlinkMsg(syscall.RTM_NEWLINK, data)
+}
+
+func TestParseVRFs(t *testing.T) {
+	u32 := func(v uint32) []byte {
+		b := make([]byte, 4)
+		byteOrder.PutUint32(b, v)
+		return b
+	}
+	var data []byte
+	data = append(data, linkMsg(1, rtAttr(syscall.IFLA_IFNAME, []byte("lo\x00")))...)
+	data = append(data, linkMsg(2, rtAttr(syscall.IFLA_IFNAME, []byte("eth0\x00")))...)
+	// The kind is followed by IFLA_INFO_DATA of the table.
+	vrf := append(rtAttr(iflaInfoKind, []byte("vrf\x00")), rtAttr(2, rtAttr(1, u32(10)))...)
+	data = append(data, linkMsg(3, rtAttr(syscall.IFLA_IFNAME, []byte("blue\x00")), rtAttr(iflaLinkinfo, vrf))...)
+	data = append(data, linkMsg(4, rtAttr(syscall.IFLA_IFNAME, []byte("eth1\x00")), rtAttr(iflaMaster, u32(3)))...)
+	// a slave of a bridge, which is not a VRF.
+	bridge := rtAttr(iflaInfoKind, []byte("bridge\x00"))
+	data = append(data, linkMsg(5, rtAttr(syscall.IFLA_IFNAME, []byte("br0\x00")), rtAttr(iflaLinkinfo, bridge))...)
+	data = append(data, linkMsg(6, rtAttr(syscall.IFLA_IFNAME, []byte("eth2\x00")), rtAttr(iflaMaster, u32(5)))...)
+	data = append(data, netlinkMsg(syscall.NLMSG_DONE, make([]byte, 4))...)
+
+	msgs, err := syscall.ParseNetlinkMessage(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := parseVRFs(msgs)
+	if err != nil {
+		t.Fatalf("should not raise error: %v", err)
+	}
+	if diff := cmp.Diff(map[uint32]string{3: "blue", 4: "blue"}, got); diff != "" {
+		t.Errorf("parseVRFs() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestVRFs(t *testing.T) {
+	if _, err := VRFs(); err != nil {
+		t.Fatalf("should not raise error: %v", err)
+	}
+}
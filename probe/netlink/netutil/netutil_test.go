@@ -1,8 +1,12 @@
 package netutil
 
 import (
+	"context"
+	"fmt"
 	"net"
+	"sync"
 	"testing"
+	"time"
 )
 
 func TestLocalIPAddrss(t *testing.T) {
@@ -15,16 +19,6 @@ func TestLocalIPAddrss(t *testing.T) {
 	}
 }
 
-func TestLocalListeningPorts(t *testing.T) {
-	ports, err := LocalListeningPorts()
-	if err != nil {
-		t.Fatalf("should not raise error: %v", err)
-	}
-	if len(ports) == 0 {
-		t.Error("localIPAddrs() should not be len == 0")
-	}
-}
-
 func TestIsPrivateIP(t *testing.T) {
 	tests := []struct {
 		in  string
@@ -42,3 +36,57 @@ func TestIsPrivateIP(t *testing.T) {
 		}
 	}
 }
+
+func TestResolveAddrs(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		inflight int
+		peak     int
+	)
+	lookupAddr = func(ctx context.Context, addr string) ([]string, error) {
+		mu.Lock()
+		inflight++
+		if inflight > peak {
+			peak = inflight
+		}
+		mu.Unlock()
+		defer func() {
+			mu.Lock()
+			inflight--
+			mu.Unlock()
+		}()
+
+		if addr == "10.0.0.1" {
+			// emulate a DNS server which does not respond.
+			<-ctx.Done()
+			return nil, ctx.Err()
+		}
+		time.Sleep(10 * time.Millisecond)
+		return []string{"host-" + addr + "."}, nil
+	}
+	defer func() { lookupAddr = net.DefaultResolver.LookupAddr }()
+
+	addrs := []string{"10.0.0.1"}
+	for i := 2; i <= 100; i++ {
+		addrs = append(addrs, fmt.Sprintf("10.0.0.%d", i))
+	}
+	start := time.Now()
+	names := ResolveAddrs(addrs)
+	elapsed := time.Since(start)
+
+	if len(names) != len(addrs) {
+		t.Fatalf("the number of names should be %d, but %d", len(addrs), len(names))
+	}
+	if got := names["10.0.0.1"]; got != "10.0.0.1" {
+		t.Errorf("unresolved address should be itself, but %q", got)
+	}
+	if got := names["10.0.0.2"]; got != "host-10.0.0.2" {
+		t.Errorf("resolved name should be 'host-10.0.0.2', but %q", got)
+	}
+	if peak > ResolveConcurrency {
+		t.Errorf("lookups in flight should be at most %d, but %d", ResolveConcurrency, peak)
+	}
+	if elapsed > ResolveTimeout+time.Second {
+		t.Errorf("a slow lookup should not block the others: elapsed %s", elapsed)
+	}
+}
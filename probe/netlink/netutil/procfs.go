@@ -0,0 +1,159 @@
+package netutil
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	"golang.org/x/xerrors"
+)
+
+// FS is a read-only procfs. It follows io/fs.FS: names are slash-separated
+// paths relative to the root, such as "net/tcp" or "1234/fd/3", and "."
+// is the root itself. Errors are returned as *os.PathError, so that
+// os.IsNotExist and os.IsPermission work on them.
+type FS interface {
+	// Open opens the named file or directory.
+	Open(name string) (File, error)
+	// Readlink reads the named symbolic link into buf, and returns the
+	// number of bytes read.
+	Readlink(name string, buf []byte) (int, error)
+}
+
+// File is a file or a directory opened by FS. *os.File implements it.
+type File interface {
+	io.Reader
+	io.Closer
+	Readdirnames(n int) ([]string, error)
+}
+
+// DirFS returns a FS for the tree rooted at dir, such as /proc, the procfs
+// of the host mounted into a container, or a fixture tree.
+func DirFS(dir string) FS {
+	return dirFS(dir)
+}
+
+type dirFS string
+
+func (dir dirFS) join(name string) string {
+	if name == "." {
+		return string(dir)
+	}
+	return string(dir) + "/" + name
+}
+
+func (dir dirFS) Open(name string) (File, error) {
+	f, err := os.Open(dir.join(name))
+	if err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func (dir dirFS) Readlink(name string, buf []byte) (int, error) {
+	return readlink(dir.join(name), buf)
+}
+
+// DefaultProcRoot is the root of the procfs the probe reads by default,
+// which is PROC_ROOT if it is set, such as the procfs of the host mounted
+// into a container, or /proc.
+var DefaultProcRoot = defaultProcRoot()
+
+func defaultProcRoot() string {
+	if root := os.Getenv("PROC_ROOT"); root != "" {
+		return root
+	}
+	return "/proc"
+}
+
+var (
+	procFSMu sync.RWMutex
+	procFS   = DirFS(DefaultProcRoot)
+)
+
+// ProcFS returns the procfs the probe reads, which is DefaultProcRoot
+// unless SetProcFS changes it.
+func ProcFS() FS {
+	procFSMu.RLock()
+	defer procFSMu.RUnlock()
+	return procFS
+}
+
+// SetProcFS points the probe at fsys instead of DefaultProcRoot.
+func SetProcFS(fsys FS) {
+	procFSMu.Lock()
+	defer procFSMu.Unlock()
+	procFS = fsys
+}
+
+// LookupProcessEnv returns the value of the environment variable of the
+// process read from <pid>/environ, and whether it is set. The environment
+// is the one the process started with, which doesn't see setenv(3).
+func LookupProcessEnv(fsys FS, pid int, key string) (string, bool, error) {
+	f, err := fsys.Open(strconv.Itoa(pid) + "/environ")
+	if err != nil {
+		return "", false, err
+	}
+	defer f.Close()
+	data, err := ioutil.ReadAll(f)
+	if err != nil {
+		return "", false, err
+	}
+	prefix := []byte(key + "=")
+	for _, kv := range bytes.Split(data, []byte{0}) {
+		if bytes.HasPrefix(kv, prefix) {
+			return string(kv[len(prefix):]), true, nil
+		}
+	}
+	return "", false, nil
+}
+
+// PortRange is the ports from From to To inclusive.
+type PortRange struct {
+	From uint16
+	To   uint16
+}
+
+// Contains returns whether the port is in the range.
+func (r PortRange) Contains(port uint16) bool {
+	return r.From <= port && port <= r.To
+}
+
+// DefaultEphemeralPorts is the default of net.ipv4.ip_local_port_range of
+// Linux.
+var DefaultEphemeralPorts = PortRange{From: 32768, To: 60999}
+
+// ephemeralPortsFilename is relative to the procfs root.
+const ephemeralPortsFilename = "sys/net/ipv4/ip_local_port_range"
+
+// EphemeralPorts returns the range of the local ports the kernel picks for
+// the connections without bind(2), which are shared by IPv4 and IPv6, read
+// from sys/net/ipv4/ip_local_port_range of fsys.
+func EphemeralPorts(fsys FS) (PortRange, error) {
+	f, err := fsys.Open(ephemeralPortsFilename)
+	if err != nil {
+		return PortRange{}, err
+	}
+	defer f.Close()
+	data, err := ioutil.ReadAll(f)
+	if err != nil {
+		return PortRange{}, err
+	}
+	fields := strings.Fields(string(data))
+	if len(fields) != 2 {
+		return PortRange{}, xerrors.Errorf("%s must be two ports, but %q", ephemeralPortsFilename, data)
+	}
+	var ports [2]uint16
+	for i, field := range fields {
+		n, err := strconv.ParseUint(field, 10, 16)
+		if err != nil {
+			return PortRange{}, xerrors.Errorf("%s must be two ports, but %q", ephemeralPortsFilename, data)
+		}
+		ports[i] = uint16(n)
+	}
+	return PortRange{From: ports[0], To: ports[1]}, nil
+}
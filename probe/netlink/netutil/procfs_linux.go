@@ -0,0 +1,18 @@
+// +build linux
+
+package netutil
+
+import (
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// readlink reads the link into buf without allocating a string.
+func readlink(name string, buf []byte) (int, error) {
+	n, err := unix.Readlink(name, buf)
+	if err != nil {
+		return 0, &os.PathError{Op: "readlink", Path: name, Err: err}
+	}
+	return n, nil
+}
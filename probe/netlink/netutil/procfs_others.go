@@ -0,0 +1,13 @@
+// +build !linux
+
+package netutil
+
+import "os"
+
+func readlink(name string, buf []byte) (int, error) {
+	lnk, err := os.Readlink(name)
+	if err != nil {
+		return 0, err
+	}
+	return copy(buf, lnk), nil
+}
@@ -0,0 +1,155 @@
+package netutil
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDirFS(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, "1", "fd"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(root, "1", "stat"), []byte("1 (init) S 0 1 1"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink("socket:[100]", filepath.Join(root, "1", "fd", "3")); err != nil {
+		t.Fatal(err)
+	}
+	fsys := DirFS(root)
+
+	f, err := fsys.Open(".")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	names, err := f.Readdirnames(-1)
+	f.Close()
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	sort.Strings(names)
+	if diff := cmp.Diff([]string{"1"}, names); diff != "" {
+		t.Errorf("Readdirnames() mismatch (-want +got):\n%s", diff)
+	}
+
+	f, err = fsys.Open("1/stat")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	body, err := ioutil.ReadAll(f)
+	f.Close()
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if got := string(body); got != "1 (init) S 0 1 1" {
+		t.Errorf("the content of 1/stat should be the fixture, but %q", got)
+	}
+
+	buf := make([]byte, 128)
+	n, err := fsys.Readlink("1/fd/3", buf)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if got := string(buf[:n]); got != "socket:[100]" {
+		t.Errorf("Readlink() should return 'socket:[100]', but %q", got)
+	}
+
+	if _, err := fsys.Open("2/stat"); !os.IsNotExist(err) {
+		t.Errorf("Open() should return a not-exist error, but %v", err)
+	}
+	if _, err := fsys.Readlink("1/fd/4", buf); !os.IsNotExist(err) {
+		t.Errorf("Readlink() should return a not-exist error, but %v", err)
+	}
+}
+
+func TestDefaultProcRoot(t *testing.T) {
+	defer os.Unsetenv("PROC_ROOT")
+
+	os.Unsetenv("PROC_ROOT")
+	if got := defaultProcRoot(); got != "/proc" {
+		t.Errorf("defaultProcRoot() = %q, want /proc", got)
+	}
+	os.Setenv("PROC_ROOT", "/host/proc")
+	if got := defaultProcRoot(); got != "/host/proc" {
+		t.Errorf("defaultProcRoot() = %q, want PROC_ROOT", got)
+	}
+}
+
+func TestLookupProcessEnv(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, "100"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	environ := "PATH=/usr/bin\x00SHAWK_SERVICE=checkout\x00SHAWK_SERVICE_TIER=web\x00"
+	if err := ioutil.WriteFile(filepath.Join(root, "100", "environ"), []byte(environ), 0644); err != nil {
+		t.Fatal(err)
+	}
+	fsys := DirFS(root)
+
+	tests := []struct {
+		key   string
+		value string
+		ok    bool
+	}{
+		{"SHAWK_SERVICE", "checkout", true},
+		{"SHAWK_SERVICE_TIER", "web", true},
+		{"SHAWK", "", false},
+	}
+	for _, tt := range tests {
+		value, ok, err := LookupProcessEnv(fsys, 100, tt.key)
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		if value != tt.value || ok != tt.ok {
+			t.Errorf("LookupProcessEnv(%q) = %q, %t, want %q, %t", tt.key, value, ok, tt.value, tt.ok)
+		}
+	}
+	if _, _, err := LookupProcessEnv(fsys, 200, "SHAWK_SERVICE"); !os.IsNotExist(err) {
+		t.Errorf("LookupProcessEnv() of an exited process should return a not-exist error, but %v", err)
+	}
+}
+
+func TestEphemeralPorts(t *testing.T) {
+	tests := []struct {
+		content string
+		want    PortRange
+		wantErr bool
+	}{
+		{"32768\t60999\n", PortRange{From: 32768, To: 60999}, false},
+		{"1024 65535\n", PortRange{From: 1024, To: 65535}, false},
+		{"32768\n", PortRange{}, true},
+		{"low high\n", PortRange{}, true},
+	}
+	for _, tt := range tests {
+		root := t.TempDir()
+		dir := filepath.Join(root, "sys", "net", "ipv4")
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(filepath.Join(dir, "ip_local_port_range"), []byte(tt.content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		got, err := EphemeralPorts(DirFS(root))
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("EphemeralPorts(%q) should return an error", tt.content)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		if got != tt.want {
+			t.Errorf("EphemeralPorts(%q) = %+v, want %+v", tt.content, got, tt.want)
+		}
+	}
+
+	if _, err := EphemeralPorts(DirFS(t.TempDir())); !os.IsNotExist(err) {
+		t.Errorf("EphemeralPorts() of no file should be not exist, but %v", err)
+	}
+}
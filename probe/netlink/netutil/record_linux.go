@@ -0,0 +1,220 @@
+// +build linux
+
+package netutil
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"golang.org/x/xerrors"
+)
+
+// userEntsFile is the file of the processes owning the sockets in a
+// snapshot, next to the dumps named by the address family.
+const userEntsFile = "userents.json"
+
+// UserEntsBuilder resolves the processes owning the sockets.
+type UserEntsBuilder interface {
+	// BuildUserEntriesFor returns the processes owning the socket inodes.
+	BuildUserEntriesFor(inodes map[uint32]struct{}) (UserEnts, error)
+}
+
+type procUserEntsBuilder struct{}
+
+func (procUserEntsBuilder) BuildUserEntriesFor(inodes map[uint32]struct{}) (UserEnts, error) {
+	return BuildUserEntriesFor(inodes)
+}
+
+var (
+	userEntsBuilderMu sync.RWMutex
+	userEntsBuilder   UserEntsBuilder = procUserEntsBuilder{}
+)
+
+// CurrentUserEntsBuilder returns the UserEntsBuilder the probe queries.
+func CurrentUserEntsBuilder() UserEntsBuilder {
+	userEntsBuilderMu.RLock()
+	defer userEntsBuilderMu.RUnlock()
+	return userEntsBuilder
+}
+
+// SetUserEntsBuilder points the probe at b instead of /proc.
+func SetUserEntsBuilder(b UserEntsBuilder) {
+	userEntsBuilderMu.Lock()
+	defer userEntsBuilderMu.Unlock()
+	userEntsBuilder = b
+}
+
+// FakeUserEnts is a UserEntsBuilder returning the recorded processes.
+type FakeUserEnts UserEnts
+
+// UnmarshalJSON implements json.Unmarshaler. It also decodes the snapshots
+// recorded before the sockets had several owners, whose inodes have an
+// entry instead of a list.
+func (f *FakeUserEnts) UnmarshalJSON(data []byte) error {
+	var raws map[uint32]json.RawMessage
+	if err := json.Unmarshal(data, &raws); err != nil {
+		return err
+	}
+	ents := make(FakeUserEnts, len(raws))
+	for ino, raw := range raws {
+		if bytes.HasPrefix(bytes.TrimSpace(raw), []byte("{")) {
+			var ent UserEnt
+			if err := json.Unmarshal(raw, &ent); err != nil {
+				return err
+			}
+			ents[ino] = []*UserEnt{&ent}
+			continue
+		}
+		var owners []*UserEnt
+		if err := json.Unmarshal(raw, &owners); err != nil {
+			return err
+		}
+		ents[ino] = owners
+	}
+	*f = ents
+	return nil
+}
+
+// BuildUserEntriesFor returns the recorded processes of the inodes.
+func (f FakeUserEnts) BuildUserEntriesFor(inodes map[uint32]struct{}) (UserEnts, error) {
+	ents := make(UserEnts, len(inodes))
+	for ino := range inodes {
+		if ent, ok := f[ino]; ok {
+			ents[ino] = ent
+		}
+	}
+	return ents, nil
+}
+
+// Recorder is an InetDiag and a UserEntsBuilder that query the kernel and
+// /proc as usual, and keep the raw responses, so that Save writes them as a
+// snapshot of the scan which LoadSnapshot replays.
+type Recorder struct {
+	dir string
+
+	mu    sync.Mutex
+	seq   int
+	dumps map[AddressFamily][]byte
+	ents  UserEnts
+}
+
+// NewRecorder returns the Recorder writing the snapshots under dir.
+func NewRecorder(dir string) (*Recorder, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, xerrors.Errorf("could not create the directory of the snapshots: %w", err)
+	}
+	return &Recorder{dir: dir, dumps: map[AddressFamily][]byte{}, ents: UserEnts{}}, nil
+}
+
+// Dump returns the sockets of the address family, keeping the raw netlink
+// responses.
+func (r *Recorder) Dump(family AddressFamily) ([]*InetDiagMsg, error) {
+	var b bytes.Buffer
+	msgs, err := dumpInetDiag(family, nil, &b)
+	if err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.dumps[family] = b.Bytes()
+	return msgs, nil
+}
+
+// BuildUserEntriesFor returns the processes owning the inodes from /proc,
+// keeping them.
+func (r *Recorder) BuildUserEntriesFor(inodes map[uint32]struct{}) (UserEnts, error) {
+	ents, err := BuildUserEntriesFor(inodes)
+	if err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for ino, ent := range ents {
+		r.ents[ino] = ent
+	}
+	return ents, nil
+}
+
+// Save writes the responses kept since the last Save into a new snapshot
+// directory, named by the time and the sequence so that the names sort in
+// the order of the scans, and returns its path.
+func (r *Recorder) Save() (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.seq++
+	dir := filepath.Join(r.dir, fmt.Sprintf("%s-%06d", time.Now().UTC().Format("20060102T150405Z"), r.seq))
+	if err := os.Mkdir(dir, 0755); err != nil {
+		return "", xerrors.Errorf("could not create the snapshot: %w", err)
+	}
+	for family, data := range r.dumps {
+		if err := ioutil.WriteFile(filepath.Join(dir, family.String()), data, 0644); err != nil {
+			return "", xerrors.Errorf("could not write the snapshot: %w", err)
+		}
+	}
+	data, err := json.Marshal(r.ents)
+	if err != nil {
+		return "", xerrors.Errorf("could not encode the processes: %w", err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, userEntsFile), data, 0644); err != nil {
+		return "", xerrors.Errorf("could not write the snapshot: %w", err)
+	}
+	r.dumps = map[AddressFamily][]byte{}
+	r.ents = UserEnts{}
+	return dir, nil
+}
+
+// LoadSnapshot loads the snapshot written by Recorder.Save. The snapshot
+// without the processes resolves none.
+func LoadSnapshot(dir string) (*FakeInetDiag, FakeUserEnts, error) {
+	d, err := LoadFakeInetDiag(dir)
+	if err != nil {
+		return nil, nil, err
+	}
+	ents := FakeUserEnts{}
+	data, err := ioutil.ReadFile(filepath.Join(dir, userEntsFile))
+	if os.IsNotExist(err) {
+		return d, ents, nil
+	}
+	if err != nil {
+		return nil, nil, xerrors.Errorf("could not load the processes: %w", err)
+	}
+	if err := json.Unmarshal(data, &ents); err != nil {
+		return nil, nil, xerrors.Errorf("could not decode the processes %s: %w", filepath.Join(dir, userEntsFile), err)
+	}
+	return d, ents, nil
+}
+
+// Snapshots returns the snapshot directories under dir in the order of the
+// names, or dir itself if it is a snapshot.
+func Snapshots(dir string) ([]string, error) {
+	if isSnapshot(dir) {
+		return []string{dir}, nil
+	}
+	fis, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, xerrors.Errorf("could not read the snapshots: %w", err)
+	}
+	var dirs []string
+	for _, fi := range fis {
+		if p := filepath.Join(dir, fi.Name()); fi.IsDir() && isSnapshot(p) {
+			dirs = append(dirs, p)
+		}
+	}
+	sort.Strings(dirs)
+	if len(dirs) == 0 {
+		return nil, xerrors.Errorf("no snapshots in %s", dir)
+	}
+	return dirs, nil
+}
+
+func isSnapshot(dir string) bool {
+	_, err := os.Stat(filepath.Join(dir, AFInet.String()))
+	return err == nil
+}
@@ -0,0 +1,88 @@
+// +build linux
+
+package netutil
+
+import (
+	"strings"
+	"syscall"
+
+	"golang.org/x/xerrors"
+)
+
+const (
+	// iflaMaster and iflaLinkinfo are the attributes of RTM_NEWLINK, which
+	// the syscall package lacks, and iflaInfoKind is nested in iflaLinkinfo.
+	iflaMaster   = 10
+	iflaLinkinfo = 18
+	iflaInfoKind = 1
+)
+
+// VRFs returns the names of the VRFs by the indexes of the interfaces in
+// them, which are the VRF devices themselves and the interfaces enslaved to
+// them, so that the sockets bound to either by SO_BINDTODEVICE are placed in
+// their VRFs. It is empty on the hosts without VRFs.
+func VRFs() (map[uint32]string, error) {
+	b, err := syscall.NetlinkRIB(syscall.RTM_GETLINK, syscall.AF_UNSPEC)
+	if err != nil {
+		return nil, xerrors.Errorf("could not dump the links: %w", err)
+	}
+	msgs, err := syscall.ParseNetlinkMessage(b)
+	if err != nil {
+		return nil, xerrors.Errorf("could not parse the links: %w", err)
+	}
+	return parseVRFs(msgs)
+}
+
+// parseVRFs returns the VRFs of the links of the RTM_NEWLINK messages.
+func parseVRFs(msgs []syscall.NetlinkMessage) (map[uint32]string, error) {
+	names := make(map[uint32]string)
+	masters := make(map[uint32]uint32)
+	devices := make(map[uint32]bool)
+	for i := range msgs {
+		m := &msgs[i]
+		if m.Header.Type != syscall.RTM_NEWLINK {
+			continue
+		}
+		if len(m.Data) < syscall.SizeofIfInfomsg {
+			return nil, xerrors.Errorf("the link message is too short: %d bytes", len(m.Data))
+		}
+		index := uint32(byteOrder.Uint32(m.Data[4:8])) // ifi_index
+		attrs, err := syscall.ParseNetlinkRouteAttr(m)
+		if err != nil {
+			return nil, xerrors.Errorf("could not parse the attributes of the link %d: %w", index, err)
+		}
+		for _, attr := range attrs {
+			switch attr.Attr.Type {
+			case syscall.IFLA_IFNAME:
+				names[index] = strings.TrimRight(string(attr.Value), "\x00")
+			case iflaMaster:
+				if len(attr.Value) >= 4 {
+					masters[index] = byteOrder.Uint32(attr.Value)
+				}
+			case iflaLinkinfo:
+				devices[index] = linkKind(attr.Value) == "vrf"
+			}
+		}
+	}
+
+	vrfs := make(map[uint32]string)
+	for index, name := range names {
+		switch {
+		case devices[index]:
+			vrfs[index] = name
+		case devices[masters[index]]:
+			vrfs[index] = names[masters[index]]
+		}
+	}
+	return vrfs, nil
+}
+
+// linkKind returns the kind of the link in the nested attributes of
+// IFLA_LINKINFO, such as "vrf" or "bridge".
+func linkKind(b []byte) string {
+	v, ok := attrOf(b, iflaInfoKind)
+	if !ok {
+		return ""
+	}
+	return strings.TrimRight(string(v), "\x00")
+}
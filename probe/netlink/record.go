@@ -0,0 +1,112 @@
+// +build linux
+
+package netlink
+
+import (
+	"sync"
+
+	"golang.org/x/xerrors"
+
+	"github.com/yuuki/shawk/logging"
+	"github.com/yuuki/shawk/probe"
+	"github.com/yuuki/shawk/probe/netlink/netutil"
+)
+
+var logger = logging.New("netlink")
+
+var (
+	recorderMu sync.Mutex
+	recorder   *netutil.Recorder
+	// scanMu serializes the recorded scans, so that each snapshot holds
+	// the responses of a scan.
+	scanMu sync.Mutex
+)
+
+func currentRecorder() *netutil.Recorder {
+	recorderMu.Lock()
+	defer recorderMu.Unlock()
+	return recorder
+}
+
+// Record makes GetHostFlowsByNetlink write the raw netlink responses and the
+// processes of each scan as a snapshot under dir, which Replay feeds back.
+// The returned function stops the recording.
+func Record(dir string) (func(), error) {
+	r, err := netutil.NewRecorder(dir)
+	if err != nil {
+		return nil, err
+	}
+	recorderMu.Lock()
+	defer recorderMu.Unlock()
+	if recorder != nil {
+		return nil, xerrors.New("the scans are already recorded")
+	}
+	recorder = r
+	return func() {
+		recorderMu.Lock()
+		defer recorderMu.Unlock()
+		recorder = nil
+	}, nil
+}
+
+func recordHostFlows(r *netutil.Recorder, opt *GetHostFlowsOption) (probe.HostFlows, error) {
+	scanMu.Lock()
+	defer scanMu.Unlock()
+
+	prevDiag, prevBuilder := netutil.CurrentInetDiag(), netutil.CurrentUserEntsBuilder()
+	netutil.SetInetDiag(r)
+	netutil.SetUserEntsBuilder(r)
+	defer func() {
+		netutil.SetInetDiag(prevDiag)
+		netutil.SetUserEntsBuilder(prevBuilder)
+	}()
+
+	flows, err := getHostFlowsByNetlink(opt)
+	if err != nil {
+		return nil, err
+	}
+	// A failure of the recording doesn't fail the scan.
+	if dir, err := r.Save(); err != nil {
+		logger.Warningf("could not record the scan: %v", err)
+	} else {
+		logger.Debugf("recorded the scan into %s", dir)
+	}
+	return flows, nil
+}
+
+// Replay feeds the snapshots recorded by Record under dir, or the snapshot
+// of dir itself, back through GetHostFlowsByNetlink in the order of the
+// scans, and calls fn with the flows of each snapshot. The flows are the
+// same as the scans returned on the recorded host, except the names of the
+// addresses unless opt.Numeric and the identity of the node, which are
+// resolved on this host.
+func Replay(dir string, opt *GetHostFlowsOption, fn func(snapshot string, flows probe.HostFlows) error) error {
+	snapshots, err := netutil.Snapshots(dir)
+	if err != nil {
+		return err
+	}
+	scanMu.Lock()
+	defer scanMu.Unlock()
+
+	prevDiag, prevBuilder := netutil.CurrentInetDiag(), netutil.CurrentUserEntsBuilder()
+	defer func() {
+		netutil.SetInetDiag(prevDiag)
+		netutil.SetUserEntsBuilder(prevBuilder)
+	}()
+	for _, snapshot := range snapshots {
+		d, ents, err := netutil.LoadSnapshot(snapshot)
+		if err != nil {
+			return err
+		}
+		netutil.SetInetDiag(d)
+		netutil.SetUserEntsBuilder(ents)
+		flows, err := getHostFlowsByNetlink(opt)
+		if err != nil {
+			return xerrors.Errorf("could not replay %s: %w", snapshot, err)
+		}
+		if err := fn(snapshot, flows); err != nil {
+			return err
+		}
+	}
+	return nil
+}
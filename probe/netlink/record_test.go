@@ -0,0 +1,49 @@
+// +build linux
+
+package netlink
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/yuuki/shawk/probe"
+	"github.com/yuuki/shawk/probe/netlink/netutil"
+)
+
+func TestReplay(t *testing.T) {
+	prev := netutil.CurrentInetDiag()
+
+	var got []string
+	var snapshots []string
+	opt := &GetHostFlowsOption{Numeric: true, Processes: true, Filter: probe.FilterPrivate}
+	err := Replay(filepath.Join("testdata", "snapshots"), opt, func(snapshot string, flows probe.HostFlows) error {
+		snapshots = append(snapshots, filepath.Base(snapshot))
+		got = append(got, flowStrings(flows)...)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if diff := cmp.Diff([]string{"20261016T000000Z-000001"}, snapshots); diff != "" {
+		t.Errorf("Replay() snapshots mismatch (-want +got):\n%s", diff)
+	}
+	// The connection without inode doesn't resolve its process.
+	want := []string{
+		"10.0.0.1:80\t<--\t10.0.0.2:many\t1\t(\"nginx\",pgid=100)",
+		"10.0.0.1:80\t<--\t10.0.0.3:many\t1\t(\"nginx\",pgid=100)",
+		"10.0.0.1:8080\t<--\t10.0.0.7:many\t1",
+		"10.0.0.1:many\t-->\t10.0.0.4:5432\t1",
+		"10.0.0.1:many\t-->\t10.0.0.4:5432\t2\t(\"app\",pgid=200)",
+		"127.0.0.1:6379\t<--\t127.0.0.1:many\t1",
+		"127.0.0.1:many\t-->\t127.0.0.1:6379\t1",
+		"[fd00::1]:many\t-->\t[fd00::9]:53\t1",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Replay() mismatch (-want +got):\n%s", diff)
+	}
+	if netutil.CurrentInetDiag() != prev {
+		t.Error("Replay() should restore the InetDiag")
+	}
+}
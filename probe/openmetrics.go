@@ -0,0 +1,74 @@
+package probe
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"sort"
+	"strings"
+)
+
+// OpenMetricsContentType is the content type of the exposition written by
+// WriteOpenMetrics.
+const OpenMetricsContentType = "application/openmetrics-text; version=1.0.0; charset=utf-8"
+
+// openMetricsLabel escapes the value of a label of the OpenMetrics text
+// format.
+func openMetricsLabel(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s)
+}
+
+// WriteOpenMetrics writes the flows as the gauge shawk_flow_connections of
+// the OpenMetrics text format, labeled with the direction, the local and the
+// peer addresses, and the process of each flow, so that the textfile
+// collector of node_exporter or a scrape turns the flows into metrics. The
+// flows of the same labels, such as of different VRFs, are added up.
+func WriteOpenMetrics(w io.Writer, flows []*HostFlow) error {
+	type key struct{ direction, local, peer, process, vrf string }
+	totals := map[key]int64{}
+	for _, f := range flows {
+		k := key{
+			direction: f.Direction.String(),
+			local:     net.JoinHostPort(f.Local.Addr, f.Local.PortString()),
+			peer:      net.JoinHostPort(f.Peer.Addr, f.Peer.PortString()),
+			vrf:       f.VRF,
+		}
+		if f.Process != nil {
+			k.process = f.Process.Name
+		}
+		totals[k] += f.Connections
+	}
+	keys := make([]key, 0, len(totals))
+	for k := range totals {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		a, b := keys[i], keys[j]
+		switch {
+		case a.direction != b.direction:
+			return a.direction < b.direction
+		case a.local != b.local:
+			return a.local < b.local
+		case a.peer != b.peer:
+			return a.peer < b.peer
+		case a.process != b.process:
+			return a.process < b.process
+		}
+		return a.vrf < b.vrf
+	})
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintln(bw, "# TYPE shawk_flow_connections gauge")
+	fmt.Fprintln(bw, "# HELP shawk_flow_connections The connections of the flows of this host.")
+	for _, k := range keys {
+		fmt.Fprintf(bw, "shawk_flow_connections{direction=\"%s\",local=\"%s\",peer=\"%s\",process=\"%s\"",
+			k.direction, openMetricsLabel(k.local), openMetricsLabel(k.peer), openMetricsLabel(k.process))
+		if k.vrf != "" {
+			fmt.Fprintf(bw, ",vrf=\"%s\"", openMetricsLabel(k.vrf))
+		}
+		fmt.Fprintf(bw, "} %d\n", totals[k])
+	}
+	fmt.Fprintln(bw, "# EOF")
+	return bw.Flush()
+}
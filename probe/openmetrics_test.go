@@ -0,0 +1,54 @@
+package probe
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestWriteOpenMetrics(t *testing.T) {
+	flows := []*HostFlow{
+		{
+			Direction:   FlowActive,
+			Local:       &AddrPort{Name: "web-1", Addr: "10.0.0.1", Aggregated: true},
+			Peer:        &AddrPort{Addr: "10.0.0.3", Port: 5432},
+			Connections: 10,
+			Process:     &Process{Name: "app", Pgid: 200},
+		},
+		{
+			Direction:   FlowPassive,
+			Local:       &AddrPort{Addr: "10.0.0.1", Port: 80},
+			Peer:        &AddrPort{Addr: "10.0.0.20", Aggregated: true},
+			Connections: 2,
+			Process:     &Process{Name: `ngi"nx`, Pgid: 100},
+		},
+		{
+			Direction:   FlowActive,
+			Local:       &AddrPort{Addr: "10.0.0.1", Aggregated: true},
+			Peer:        &AddrPort{Addr: "10.0.0.3", Port: 5432},
+			Connections: 1,
+			Process:     &Process{Name: "app", Pgid: 300},
+		},
+		{
+			Direction:   FlowActive,
+			Local:       &AddrPort{Addr: "10.0.0.1", Aggregated: true},
+			Peer:        &AddrPort{Addr: "10.0.0.3", Port: 443},
+			Connections: 2,
+			VRF:         "blue",
+		},
+	}
+	var b bytes.Buffer
+	if err := WriteOpenMetrics(&b, flows); err != nil {
+		t.Fatal(err)
+	}
+	want := "# TYPE shawk_flow_connections gauge\n" +
+		"# HELP shawk_flow_connections The connections of the flows of this host.\n" +
+		`shawk_flow_connections{direction="active",local="10.0.0.1:many",peer="10.0.0.3:443",process="",vrf="blue"} 2` + "\n" +
+		`shawk_flow_connections{direction="active",local="10.0.0.1:many",peer="10.0.0.3:5432",process="app"} 11` + "\n" +
+		`shawk_flow_connections{direction="passive",local="10.0.0.1:80",peer="10.0.0.20:many",process="ngi\"nx"} 2` + "\n" +
+		"# EOF\n"
+	if diff := cmp.Diff(want, b.String()); diff != "" {
+		t.Errorf("WriteOpenMetrics() mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -4,7 +4,12 @@ import (
 	"encoding/json"
 	"fmt"
 	"net"
+	"sort"
 	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/xerrors"
 
 	"github.com/yuuki/shawk/probe/netlink/netutil"
 )
@@ -46,34 +51,143 @@ func (c FlowDirection) MarshalJSON() ([]byte, error) {
 	return json.Marshal(c.String())
 }
 
-// AddrPort are <addr>:<port>
+// UnmarshalJSON parses the direction written by MarshalJSON.
+func (c *FlowDirection) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return xerrors.Errorf("could not parse direction: %w", err)
+	}
+	switch s {
+	case "active":
+		*c = FlowActive
+	case "passive":
+		*c = FlowPassive
+	case "unknown":
+		*c = FlowUnknown
+	default:
+		return xerrors.Errorf("unknown direction %q", s)
+	}
+	return nil
+}
+
+// AggregatedPort is the string representation of aggregated ports.
+const AggregatedPort = "many"
+
+// AddrPort are <addr>:<port>. The ephemeral ports of the side that opens
+// connections are aggregated into one AddrPort, which has Aggregated set
+// and no Port.
 type AddrPort struct {
-	Name string `json:"name"`
-	Addr string `json:"addr"`
-	Port string `json:"port"`
+	Name       string            `json:"name"`
+	Addr       string            `json:"addr"`
+	Port       uint16            `json:"port"`
+	Aggregated bool              `json:"aggregated"`
+	Labels     map[string]string `json:"labels,omitempty"`
+}
+
+// PortString returns the port as string, or AggregatedPort if aggregated.
+func (a *AddrPort) PortString() string {
+	if a.Aggregated {
+		return AggregatedPort
+	}
+	return strconv.FormatUint(uint64(a.Port), 10)
 }
 
 // String returns the string representation of the AddrPort.
 func (a *AddrPort) String() string {
 	if a.Name == "" {
-		return net.JoinHostPort(a.Addr, a.Port)
+		return net.JoinHostPort(a.Addr, a.PortString())
 	}
-	return net.JoinHostPort(a.Name, a.Port)
+	return net.JoinHostPort(a.Name, a.PortString())
 }
 
-// PortInt returnts integer representation.
-func (a *AddrPort) PortInt() int {
-	if a.Port == "many" {
-		return 0
+// SetLabel attaches a label such as a workload identity to the endpoint.
+func (a *AddrPort) SetLabel(key, value string) {
+	if a.Labels == nil {
+		a.Labels = make(map[string]string)
 	}
-	i, _ := strconv.Atoi(a.Port)
-	return i
+	a.Labels[key] = value
 }
 
 // Process represents a OS process.
 type Process struct {
 	Name string `json:"name"`
 	Pgid int    `json:"pgid"`
+	// Owners are the process groups other than the process sharing its
+	// sockets, such as systemd activating them or the process passing them
+	// by SCM_RIGHTS, in the order of the pgids. It is nil unless the
+	// sockets are shared across the process groups.
+	Owners []*Process `json:"owners,omitempty"`
+}
+
+// mergeOwners merges the owners of the process of o into the flow. The
+// process is copied, because it may be shared by the other flows.
+func (f *HostFlow) mergeOwners(o *HostFlow) {
+	if f.Process == nil || o.Process == nil || len(o.Process.Owners) == 0 {
+		return
+	}
+	g := &ListenerGroup{processes: append([]*Process(nil), f.Process.Owners...)}
+	for _, owner := range o.Process.Owners {
+		g.Add(owner)
+	}
+	p := *f.Process
+	p.Owners = g.processes
+	f.Process = &p
+}
+
+// Queue is the depth of the socket queues of a flow, which are the maxima
+// of its connections.
+type Queue struct {
+	// RecvQ is the bytes received but not read by the process.
+	RecvQ uint32 `json:"recv_q"`
+	// SendQ is the bytes sent but not acknowledged by the peer.
+	SendQ uint32 `json:"send_q"`
+	// AcceptQ is the connections waiting for accept(2) on the listening
+	// socket of a passive flow, and Backlog is the limit of them.
+	AcceptQ uint32 `json:"accept_q,omitempty"`
+	Backlog uint32 `json:"backlog,omitempty"`
+}
+
+// merge takes the maxima of the queues of q and o.
+func (q *Queue) merge(o *Queue) {
+	if o.RecvQ > q.RecvQ {
+		q.RecvQ = o.RecvQ
+	}
+	if o.SendQ > q.SendQ {
+		q.SendQ = o.SendQ
+	}
+	if o.AcceptQ > q.AcceptQ {
+		q.AcceptQ = o.AcceptQ
+	}
+	if o.Backlog > q.Backlog {
+		q.Backlog = o.Backlog
+	}
+}
+
+// Age is the ages of the connections of a flow, which are how long the
+// probe has seen them since it first saw them.
+type Age struct {
+	Min time.Duration `json:"min"`
+	Avg time.Duration `json:"avg"`
+	Max time.Duration `json:"max"`
+}
+
+// NewAge returns the Age of a connection of the age.
+func NewAge(age time.Duration) *Age {
+	return &Age{Min: age, Avg: age, Max: age}
+}
+
+// merge merges the ages of o of m connections into the ages of a of n
+// connections.
+func (a *Age) merge(o *Age, n, m int64) {
+	if o.Min < a.Min {
+		a.Min = o.Min
+	}
+	if o.Max > a.Max {
+		a.Max = o.Max
+	}
+	if n+m > 0 {
+		a.Avg = (a.Avg*time.Duration(n) + o.Avg*time.Duration(m)) / time.Duration(n+m)
+	}
 }
 
 // HostFlow represents a `host flow`.
@@ -83,6 +197,73 @@ type HostFlow struct {
 	Peer        *AddrPort     `json:"peer"`
 	Connections int64         `json:"connections"`
 	Process     *Process      `json:"process,omitempty"`
+	// Queue is nil if the probe doesn't see the socket queues.
+	Queue *Queue `json:"queue,omitempty"`
+	// Cong is the congestion control algorithm of the connections, such
+	// as "cubic" or "bbr", or the distinct ones joined with commas in
+	// order if they differ. It is empty if the probe doesn't see it.
+	Cong string `json:"cong,omitempty"`
+	// Age is nil if the probe doesn't track the ages of the connections.
+	Age *Age `json:"age,omitempty"`
+	// Subflows is the number of the subflows of the MPTCP connections of
+	// the flow, which are the paths of the connections counted as one
+	// connection each. It is zero for the plain TCP connections.
+	Subflows int64 `json:"subflows,omitempty"`
+	// VRF is the name of the VRF of the sockets of the flow, whose
+	// addresses are of the address space of the VRF. It is empty for the
+	// sockets in the default VRF.
+	VRF string `json:"vrf,omitempty"`
+}
+
+// mergeAge merges the ages of o into the flow, before their connections
+// are added up.
+func (f *HostFlow) mergeAge(o *HostFlow) {
+	switch {
+	case o.Age == nil:
+	case f.Age == nil:
+		a := *o.Age
+		f.Age = &a
+	default:
+		m := o.Connections
+		if m == 0 {
+			// inserted as a connection
+			m = 1
+		}
+		f.Age.merge(o.Age, f.Connections, m)
+	}
+}
+
+// mergeCong merges the congestion control algorithms of o into the flow.
+func (f *HostFlow) mergeCong(o *HostFlow) {
+	if o.Cong == "" || o.Cong == f.Cong {
+		return
+	}
+	if f.Cong == "" {
+		f.Cong = o.Cong
+		return
+	}
+	seen := make(map[string]bool)
+	var congs []string
+	for _, c := range strings.Split(f.Cong+","+o.Cong, ",") {
+		if !seen[c] {
+			seen[c] = true
+			congs = append(congs, c)
+		}
+	}
+	sort.Strings(congs)
+	f.Cong = strings.Join(congs, ",")
+}
+
+// mergeQueue merges the queues of o into the flow.
+func (f *HostFlow) mergeQueue(o *HostFlow) {
+	switch {
+	case o.Queue == nil:
+	case f.Queue == nil:
+		q := *o.Queue
+		f.Queue = &q
+	default:
+		f.Queue.merge(o.Queue)
+	}
 }
 
 // String returns the string representation of HostFlow.
@@ -91,6 +272,9 @@ func (f *HostFlow) String() string {
 	if f.Process != nil {
 		entStr = fmt.Sprintf("\t(\"%s\",pgid=%d)", f.Process.Name, f.Process.Pgid)
 	}
+	if f.VRF != "" {
+		entStr += "\tvrf=" + f.VRF
+	}
 	switch f.Direction {
 	case FlowActive:
 		return fmt.Sprintf("%s\t-->\t%s\t%d%s", f.Local, f.Peer, f.Connections, entStr)
@@ -100,9 +284,32 @@ func (f *HostFlow) String() string {
 	return ""
 }
 
-// UniqKey returns the unique identifier key for connections flow.
-func (f *HostFlow) UniqKey() string {
-	return f.Direction.String() + "-" + f.Local.String() + "-" + f.Peer.String()
+// FlowKey is the unique identifier of a host flow. Aggregated ports are
+// represented as port 0.
+type FlowKey struct {
+	Direction FlowDirection
+	LocalAddr string
+	LocalPort uint16
+	PeerAddr  string
+	PeerPort  uint16
+	Pgid      int
+	VRF       string
+}
+
+// Key returns the unique identifier key for connections flow.
+func (f *HostFlow) Key() FlowKey {
+	key := FlowKey{
+		Direction: f.Direction,
+		LocalAddr: f.Local.Addr,
+		LocalPort: f.Local.Port,
+		PeerAddr:  f.Peer.Addr,
+		PeerPort:  f.Peer.Port,
+		VRF:       f.VRF,
+	}
+	if f.Process != nil {
+		key.Pgid = f.Process.Pgid
+	}
+	return key
 }
 
 // SetLookupedName replaces f.Addr into lookuped name.
@@ -112,26 +319,64 @@ func (f *HostFlow) SetLookupedName() {
 }
 
 // HostFlows represents a group of host flow by unique key.
-type HostFlows map[string]*HostFlow
+type HostFlows map[FlowKey]*HostFlow
 
-// MarshalJSON converts map into list.
+// MarshalJSON converts map into list of FlowRecord.
 func (hf HostFlows) MarshalJSON() ([]byte, error) {
-	list := make([]HostFlow, 0, len(hf))
+	list := make([]*FlowRecord, 0, len(hf))
 	for _, f := range hf {
-		list = append(list, *f)
+		list = append(list, NewFlowRecord(f))
 	}
 	return json.Marshal(list)
 }
 
+// SetLookupedNames replaces the addresses of all flows into lookuped names.
+// Each distinct address is resolved only once, concurrently.
+func (hf HostFlows) SetLookupedNames() {
+	seen := make(map[string]struct{}, len(hf))
+	addrs := make([]string, 0, len(hf))
+	for _, f := range hf {
+		for _, a := range []string{f.Local.Addr, f.Peer.Addr} {
+			if _, ok := seen[a]; !ok {
+				seen[a] = struct{}{}
+				addrs = append(addrs, a)
+			}
+		}
+	}
+	names := netutil.ResolveAddrs(addrs)
+	for _, f := range hf {
+		f.Local.Name = names[f.Local.Addr]
+		f.Peer.Name = names[f.Peer.Addr]
+	}
+}
+
 // Insert inserts a flow into the HostFlows.
 func (hf HostFlows) Insert(flow *HostFlow) {
-	key := flow.UniqKey()
-	if _, ok := hf[key]; !ok {
-		hf[key] = flow
-	} else {
-		if hf[key].Process == nil {
-			hf[key].Process = flow.Process
-		}
+	key := flow.Key()
+	if f, ok := hf[key]; ok {
+		f.mergeAge(flow)
+		f.Connections++
+		f.Subflows += flow.Subflows
+		f.mergeQueue(flow)
+		f.mergeCong(flow)
+		f.mergeOwners(flow)
+		return
+	}
+	hf[key] = flow
+	flow.Connections++
+}
+
+// Merge merges a flow, which has counted its connections, into the HostFlows.
+func (hf HostFlows) Merge(flow *HostFlow) {
+	key := flow.Key()
+	if f, ok := hf[key]; ok {
+		f.mergeAge(flow)
+		f.Connections += flow.Connections
+		f.Subflows += flow.Subflows
+		f.mergeQueue(flow)
+		f.mergeCong(flow)
+		f.mergeOwners(flow)
+		return
 	}
-	hf[key].Connections++
+	hf[key] = flow
 }
@@ -0,0 +1,197 @@
+package probe
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestHostFlowsInsert(t *testing.T) {
+	flows := HostFlows{}
+	newFlow := func(peerPort uint16, pgid int) *HostFlow {
+		return &HostFlow{
+			Direction: FlowActive,
+			Local:     &AddrPort{Addr: "10.0.0.1", Aggregated: true},
+			Peer:      &AddrPort{Addr: "10.0.0.2", Port: peerPort},
+			Process:   &Process{Name: "app", Pgid: pgid},
+		}
+	}
+	flows.Insert(newFlow(5432, 100))
+	flows.Insert(newFlow(5432, 100))
+	flows.Insert(newFlow(5432, 200))
+	flows.Insert(newFlow(6379, 100))
+
+	if len(flows) != 3 {
+		t.Fatalf("the number of flows should be 3, but %d", len(flows))
+	}
+	if got := flows[newFlow(5432, 100).Key()].Connections; got != 2 {
+		t.Errorf("the connections of the same flow should be 2, but %d", got)
+	}
+	if got := flows[newFlow(5432, 200).Key()].Connections; got != 1 {
+		t.Errorf("the connections of another process should be 1, but %d", got)
+	}
+}
+
+func TestHostFlowsInsertQueue(t *testing.T) {
+	flows := HostFlows{}
+	newFlow := func(q *Queue) *HostFlow {
+		return &HostFlow{
+			Direction: FlowPassive,
+			Local:     &AddrPort{Addr: "10.0.0.1", Port: 80},
+			Peer:      &AddrPort{Addr: "10.0.0.2", Aggregated: true},
+			Queue:     q,
+		}
+	}
+	flows.Insert(newFlow(nil))
+	flows.Insert(newFlow(&Queue{RecvQ: 10, SendQ: 200, AcceptQ: 3, Backlog: 128}))
+	flows.Insert(newFlow(&Queue{RecvQ: 30, SendQ: 100, AcceptQ: 5, Backlog: 128}))
+
+	got := flows[newFlow(nil).Key()].Queue
+	want := &Queue{RecvQ: 30, SendQ: 200, AcceptQ: 5, Backlog: 128}
+	if got == nil || *got != *want {
+		t.Errorf("the queue of the flow should be the maxima %+v, but %+v", want, got)
+	}
+}
+
+func TestHostFlowsInsertCong(t *testing.T) {
+	flows := HostFlows{}
+	newFlow := func(cong string) *HostFlow {
+		return &HostFlow{
+			Direction: FlowActive,
+			Local:     &AddrPort{Addr: "10.0.0.1", Aggregated: true},
+			Peer:      &AddrPort{Addr: "10.0.0.2", Port: 443},
+			Cong:      cong,
+		}
+	}
+	for _, c := range []string{"", "cubic", "bbr", "cubic"} {
+		flows.Insert(newFlow(c))
+	}
+	if got, want := flows[newFlow("").Key()].Cong, "bbr,cubic"; got != want {
+		t.Errorf("the algorithms of the flow should be %q, but %q", want, got)
+	}
+}
+
+func TestHostFlowsInsertAge(t *testing.T) {
+	flows := HostFlows{}
+	newFlow := func(age time.Duration) *HostFlow {
+		return &HostFlow{
+			Direction: FlowActive,
+			Local:     &AddrPort{Addr: "10.0.0.1", Aggregated: true},
+			Peer:      &AddrPort{Addr: "10.0.0.2", Port: 443},
+			Age:       NewAge(age),
+		}
+	}
+	for _, age := range []time.Duration{time.Minute, time.Hour, 2 * time.Minute} {
+		flows.Insert(newFlow(age))
+	}
+	got := flows[newFlow(0).Key()].Age
+	want := &Age{Min: time.Minute, Avg: 21 * time.Minute, Max: time.Hour}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("the ages of the flow mismatch (-want +got):\n%s", diff)
+	}
+
+	// The merged flow of 2 connections weighs twice.
+	merged := newFlow(6 * time.Minute)
+	merged.Connections = 2
+	flows.Merge(merged)
+	want = &Age{Min: time.Minute, Avg: 15 * time.Minute, Max: time.Hour}
+	if diff := cmp.Diff(want, flows[merged.Key()].Age); diff != "" {
+		t.Errorf("the ages of the merged flow mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestHostFlowsInsertSubflows(t *testing.T) {
+	flows := HostFlows{}
+	newFlow := func(subflows int64) *HostFlow {
+		return &HostFlow{
+			Direction: FlowActive,
+			Local:     &AddrPort{Addr: "10.0.0.1", Aggregated: true},
+			Peer:      &AddrPort{Addr: "10.0.0.2", Port: 443},
+			Subflows:  subflows,
+		}
+	}
+	for _, n := range []int64{2, 0, 3} {
+		flows.Insert(newFlow(n))
+	}
+	f := flows[newFlow(0).Key()]
+	if f.Connections != 3 || f.Subflows != 5 {
+		t.Errorf("the flow should be of 3 connections and 5 subflows, but %d and %d", f.Connections, f.Subflows)
+	}
+}
+
+func TestHostFlowsInsertVRF(t *testing.T) {
+	flows := HostFlows{}
+	newFlow := func(vrf string) *HostFlow {
+		return &HostFlow{
+			Direction: FlowActive,
+			Local:     &AddrPort{Addr: "10.0.0.1", Aggregated: true},
+			Peer:      &AddrPort{Addr: "10.0.0.2", Port: 443},
+			VRF:       vrf,
+		}
+	}
+	for _, vrf := range []string{"", "blue", "red", "blue"} {
+		flows.Insert(newFlow(vrf))
+	}
+	// The same addresses in the VRFs are of the different address spaces.
+	if len(flows) != 3 {
+		t.Fatalf("the number of flows should be 3, but %d", len(flows))
+	}
+	if got := flows[newFlow("blue").Key()].Connections; got != 2 {
+		t.Errorf("the connections of the flow in the VRF should be 2, but %d", got)
+	}
+}
+
+func TestHostFlowsInsertOwners(t *testing.T) {
+	flows := HostFlows{}
+	systemd := &Process{Name: "systemd", Pgid: 1}
+	nginx := &Process{Name: "nginx", Pgid: 100}
+	newFlow := func(owners ...*Process) *HostFlow {
+		return &HostFlow{
+			Direction: FlowPassive,
+			Local:     &AddrPort{Addr: "10.0.0.1", Port: 80},
+			Peer:      &AddrPort{Addr: "10.0.0.2", Aggregated: true},
+			Process:   &Process{Name: "nginx", Pgid: 200, Owners: owners},
+		}
+	}
+	flows.Insert(newFlow())
+	flows.Insert(newFlow(systemd))
+	flows.Insert(newFlow(nginx, systemd))
+
+	got := flows[newFlow().Key()].Process
+	want := &Process{Name: "nginx", Pgid: 200, Owners: []*Process{systemd, nginx}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("the process of the flow mismatch (-want +got):\n%s", diff)
+	}
+}
+
+// newSnapshot emulates the connections on a busy host, which has 100 local
+// processes connecting to 1000 peers.
+func newSnapshot(n int) []*HostFlow {
+	snapshot := make([]*HostFlow, 0, n)
+	for i := 0; i < n; i++ {
+		snapshot = append(snapshot, &HostFlow{
+			Direction: FlowActive,
+			Local:     &AddrPort{Addr: "10.0.0.1", Aggregated: true},
+			Peer:      &AddrPort{Addr: fmt.Sprintf("10.1.%d.%d", i%1000/250, i%250), Port: 5432},
+			Process:   &Process{Name: "app", Pgid: i % 100},
+		})
+	}
+	return snapshot
+}
+
+func BenchmarkHostFlowsInsert(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		// Insert modifies the flows it keeps, so that each iteration
+		// inserts a new snapshot.
+		b.StopTimer()
+		snapshot := newSnapshot(100000)
+		b.StartTimer()
+		flows := HostFlows{}
+		for _, f := range snapshot {
+			flows.Insert(f)
+		}
+	}
+}
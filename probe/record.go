@@ -0,0 +1,134 @@
+package probe
+
+import (
+	"encoding/json"
+	"strconv"
+
+	"golang.org/x/xerrors"
+)
+
+// RecordVersion is the version of FlowRecord written by this shawk.
+//
+// Version 1 has the ports as string, such as "80" or "many" for the
+// aggregated ports, and no labels. It is written by the agents without
+// schema_version.
+// Version 2 has the ports as integer with the aggregated flag, and labels.
+const RecordVersion = 2
+
+// FlowRecord is the wire and storage representation of HostFlow, which is
+// versioned so that the agents and the collectors can be upgraded
+// independently.
+type FlowRecord struct {
+	SchemaVersion int `json:"schema_version"`
+	*HostFlow
+}
+
+// NewFlowRecord creates a FlowRecord of the current version.
+func NewFlowRecord(f *HostFlow) *FlowRecord {
+	return &FlowRecord{SchemaVersion: RecordVersion, HostFlow: f}
+}
+
+// UnmarshalJSON decodes a FlowRecord of any version up to RecordVersion into
+// the current representation. A record without schema_version is version 1.
+func (r *FlowRecord) UnmarshalJSON(data []byte) error {
+	var header struct {
+		SchemaVersion *int `json:"schema_version"`
+	}
+	if err := json.Unmarshal(data, &header); err != nil {
+		return xerrors.Errorf("could not parse flow record: %w", err)
+	}
+	version := 1
+	if header.SchemaVersion != nil {
+		version = *header.SchemaVersion
+	}
+
+	var (
+		f   *HostFlow
+		err error
+	)
+	switch version {
+	case 1:
+		f, err = decodeRecordV1(data)
+	case RecordVersion:
+		f = &HostFlow{}
+		err = json.Unmarshal(data, f)
+	default:
+		return xerrors.Errorf("unsupported flow record version %d: this shawk supports up to version %d, so upgrade it",
+			version, RecordVersion)
+	}
+	if err != nil {
+		return xerrors.Errorf("could not parse flow record version %d: %w", version, err)
+	}
+	if f.Local == nil || f.Peer == nil {
+		return xerrors.Errorf("flow record version %d lacks local or peer", version)
+	}
+	r.SchemaVersion = RecordVersion
+	r.HostFlow = f
+	return nil
+}
+
+// addrPortV1 is AddrPort of version 1.
+type addrPortV1 struct {
+	Name string `json:"name"`
+	Addr string `json:"addr"`
+	Port string `json:"port"`
+}
+
+func (a *addrPortV1) convert() (*AddrPort, error) {
+	if a == nil {
+		return nil, nil
+	}
+	ap := &AddrPort{Name: a.Name, Addr: a.Addr}
+	if a.Port == AggregatedPort {
+		ap.Aggregated = true
+		return ap, nil
+	}
+	port, err := strconv.ParseUint(a.Port, 10, 16)
+	if err != nil {
+		return nil, xerrors.Errorf("invalid port %q: %w", a.Port, err)
+	}
+	ap.Port = uint16(port)
+	return ap, nil
+}
+
+func decodeRecordV1(data []byte) (*HostFlow, error) {
+	var v1 struct {
+		Direction   FlowDirection `json:"direction"`
+		Local       *addrPortV1   `json:"local"`
+		Peer        *addrPortV1   `json:"peer"`
+		Connections int64         `json:"connections"`
+		Process     *Process      `json:"process,omitempty"`
+	}
+	if err := json.Unmarshal(data, &v1); err != nil {
+		return nil, err
+	}
+	local, err := v1.Local.convert()
+	if err != nil {
+		return nil, err
+	}
+	peer, err := v1.Peer.convert()
+	if err != nil {
+		return nil, err
+	}
+	return &HostFlow{
+		Direction:   v1.Direction,
+		Local:       local,
+		Peer:        peer,
+		Connections: v1.Connections,
+		Process:     v1.Process,
+	}, nil
+}
+
+// DecodeFlowRecords decodes a JSON list of FlowRecord of any supported
+// version, such as the one written by HostFlows.MarshalJSON.
+func DecodeFlowRecords(data []byte) (HostFlows, error) {
+	var records []FlowRecord
+	if err := json.Unmarshal(data, &records); err != nil {
+		return nil, xerrors.Errorf("could not decode flow records: %w", err)
+	}
+	flows := make(HostFlows, len(records))
+	for _, r := range records {
+		flows.Merge(r.HostFlow)
+	}
+	return flows, nil
+}
@@ -0,0 +1,76 @@
+package probe
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestFlowRecord_roundTrip(t *testing.T) {
+	flows := HostFlows{}
+	flows.Merge(&HostFlow{
+		Direction:   FlowActive,
+		Local:       &AddrPort{Name: "web-1", Addr: "10.0.0.1", Aggregated: true, Labels: map[string]string{"k8s.pod": "web-1"}},
+		Peer:        &AddrPort{Name: "db-1", Addr: "10.0.0.2", Port: 5432},
+		Connections: 3,
+		Process:     &Process{Name: "nginx", Pgid: 100},
+	})
+
+	data, err := json.Marshal(flows)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `"schema_version":2`) {
+		t.Errorf("schema_version is not written: %s", data)
+	}
+	got, err := DecodeFlowRecords(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(flows, got); diff != "" {
+		t.Errorf("DecodeFlowRecords() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestDecodeFlowRecords_v1(t *testing.T) {
+	data := `[{"direction":"passive",
+		"local":{"name":"db-1","addr":"10.0.0.2","port":"5432"},
+		"peer":{"name":"web-1","addr":"10.0.0.1","port":"many"},
+		"connections":2}]`
+
+	got, err := DecodeFlowRecords([]byte(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := HostFlows{}
+	want.Merge(&HostFlow{
+		Direction:   FlowPassive,
+		Local:       &AddrPort{Name: "db-1", Addr: "10.0.0.2", Port: 5432},
+		Peer:        &AddrPort{Name: "web-1", Addr: "10.0.0.1", Aggregated: true},
+		Connections: 2,
+	})
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("DecodeFlowRecords() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestDecodeFlowRecords_error(t *testing.T) {
+	tests := []struct {
+		desc string
+		data string
+	}{
+		{"newer version", `[{"schema_version":3,"direction":"active","local":{},"peer":{}}]`},
+		{"invalid v1 port", `[{"direction":"active","local":{"port":"http"},"peer":{"port":"80"}}]`},
+		{"unknown direction", `[{"schema_version":2,"direction":"sideways","local":{},"peer":{}}]`},
+		{"no peer", `[{"schema_version":2,"direction":"active","local":{}}]`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			if _, err := DecodeFlowRecords([]byte(tt.data)); err == nil {
+				t.Error("DecodeFlowRecords() should return an error")
+			}
+		})
+	}
+}
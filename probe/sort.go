@@ -0,0 +1,118 @@
+package probe
+
+import (
+	"bytes"
+	"net"
+	"sort"
+	"strings"
+
+	"golang.org/x/xerrors"
+)
+
+// The orders of the flows.
+const (
+	// SortConnections sorts the flows by the connections in descending order.
+	SortConnections = "connections"
+	// SortPeer sorts the flows by the address and the port of the peer.
+	SortPeer = "peer"
+	// SortPort sorts the flows by the port of the passive side.
+	SortPort = "port"
+	// SortProcess sorts the flows by the name of the process.
+	SortProcess = "process"
+)
+
+// SortOrders are the orders available for --sort.
+var SortOrders = []string{SortConnections, SortPeer, SortPort, SortProcess}
+
+// ValidateSortOrder returns an error if by is not one of SortOrders.
+func ValidateSortOrder(by string) error {
+	for _, o := range SortOrders {
+		if by == o {
+			return nil
+		}
+	}
+	return xerrors.Errorf("--sort must be one of %s, but %q", strings.Join(SortOrders, ", "), by)
+}
+
+// SortKey is the values of a flow to sort it by.
+type SortKey struct {
+	Connections int64
+	PeerAddr    net.IP
+	PeerPort    uint16
+	// Port is the port of the passive side, which serves the flow.
+	Port    uint16
+	Process string
+}
+
+// SortKey returns the values of the flow to sort it by.
+func (f *HostFlow) SortKey() SortKey {
+	k := SortKey{
+		Connections: f.Connections,
+		PeerAddr:    net.ParseIP(f.Peer.Addr),
+		PeerPort:    f.Peer.Port,
+		Port:        f.Local.Port,
+	}
+	if f.Direction == FlowActive {
+		k.Port = f.Peer.Port
+	}
+	if f.Process != nil {
+		k.Process = f.Process.Name
+	}
+	return k
+}
+
+func compareInt(a, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// Compare compares k with o by the order, and then by the other orders
+// in the order of SortOrders. It returns -1, 0 or 1.
+func (k SortKey) Compare(o SortKey, by string) int {
+	compare := map[string]func() int{
+		// in descending order
+		SortConnections: func() int { return compareInt(o.Connections, k.Connections) },
+		SortPeer: func() int {
+			if c := bytes.Compare(k.PeerAddr.To16(), o.PeerAddr.To16()); c != 0 {
+				return c
+			}
+			return compareInt(int64(k.PeerPort), int64(o.PeerPort))
+		},
+		SortPort:    func() int { return compareInt(int64(k.Port), int64(o.Port)) },
+		SortProcess: func() int { return strings.Compare(k.Process, o.Process) },
+	}
+	if c := compare[by]; c != nil {
+		if r := c(); r != 0 {
+			return r
+		}
+	}
+	for _, order := range SortOrders {
+		if order == by {
+			continue
+		}
+		if r := compare[order](); r != 0 {
+			return r
+		}
+	}
+	return 0
+}
+
+// SortFlows sorts the flows by the order. The flows of the same values are
+// sorted by their string representations, so that the order is stable.
+func SortFlows(flows []*HostFlow, by string) {
+	keys := make(map[*HostFlow]SortKey, len(flows))
+	for _, f := range flows {
+		keys[f] = f.SortKey()
+	}
+	sort.Slice(flows, func(i, j int) bool {
+		if c := keys[flows[i]].Compare(keys[flows[j]], by); c != 0 {
+			return c < 0
+		}
+		return flows[i].String() < flows[j].String()
+	})
+}
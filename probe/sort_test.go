@@ -0,0 +1,68 @@
+package probe
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestSortFlows(t *testing.T) {
+	newFlows := func() []*HostFlow {
+		return []*HostFlow{
+			{
+				Direction:   FlowPassive,
+				Local:       &AddrPort{Addr: "10.0.0.1", Port: 80},
+				Peer:        &AddrPort{Addr: "10.0.0.20", Aggregated: true},
+				Connections: 2,
+				Process:     &Process{Name: "nginx", Pgid: 100},
+			},
+			{
+				Direction:   FlowActive,
+				Local:       &AddrPort{Addr: "10.0.0.1", Aggregated: true},
+				Peer:        &AddrPort{Addr: "10.0.0.3", Port: 5432},
+				Connections: 10,
+				Process:     &Process{Name: "app", Pgid: 200},
+			},
+			{
+				Direction:   FlowActive,
+				Local:       &AddrPort{Addr: "10.0.0.1", Aggregated: true},
+				Peer:        &AddrPort{Addr: "10.0.0.3", Port: 443},
+				Connections: 2,
+			},
+		}
+	}
+
+	tests := []struct {
+		by   string
+		want []string
+	}{
+		{SortConnections, []string{"10.0.0.3:5432", "10.0.0.3:443", "10.0.0.20:many"}},
+		// 10.0.0.3 < 10.0.0.20 as addresses, not as strings.
+		{SortPeer, []string{"10.0.0.3:443", "10.0.0.3:5432", "10.0.0.20:many"}},
+		{SortPort, []string{"10.0.0.20:many", "10.0.0.3:443", "10.0.0.3:5432"}},
+		// The flow without process comes first, and the ties are sorted by connections.
+		{SortProcess, []string{"10.0.0.3:443", "10.0.0.3:5432", "10.0.0.20:many"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.by, func(t *testing.T) {
+			flows := newFlows()
+			SortFlows(flows, tt.by)
+			var got []string
+			for _, f := range flows {
+				got = append(got, f.Peer.String())
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("SortFlows() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestValidateSortOrder(t *testing.T) {
+	if err := ValidateSortOrder(SortPeer); err != nil {
+		t.Errorf("ValidateSortOrder(%q) should not return an error: %v", SortPeer, err)
+	}
+	if err := ValidateSortOrder("bytes"); err == nil {
+		t.Error("ValidateSortOrder(\"bytes\") should return an error")
+	}
+}
@@ -0,0 +1,96 @@
+package secgroup
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// RenderJSON writes the report as JSON.
+func RenderJSON(w io.Writer, r *Report) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
+
+// RenderTerraform writes the recommendations as the aws_security_group
+// resources of the Terraform AWS provider, followed by the unused rules as
+// comments.
+func RenderTerraform(w io.Writer, r *Report) error {
+	bw := bufio.NewWriter(w)
+	names := map[string]int{}
+	for i, rec := range r.Recommendations {
+		if i > 0 {
+			fmt.Fprintln(bw)
+		}
+		name := terraformName(rec)
+		if n := names[name]; n > 0 {
+			names[name]++
+			name += "_" + strconv.Itoa(n+1)
+		} else {
+			names[name] = 1
+		}
+		fmt.Fprintf(bw, "# replaces %s\n", strings.Join(rec.SecurityGroups, ", "))
+		fmt.Fprintf(bw, "resource \"aws_security_group\" %q {\n", name)
+		fmt.Fprintf(bw, "  name        = %q\n", strings.Replace(name, "_", "-", -1))
+		fmt.Fprintf(bw, "  description = %q\n", "Flows into "+strings.Join(rec.NetworkInterfaces, ", ")+" observed by shawk")
+		if rec.VpcID != "" {
+			fmt.Fprintf(bw, "  vpc_id      = %q\n", rec.VpcID)
+		}
+		for _, rule := range rec.Ingress {
+			fmt.Fprintln(bw)
+			fmt.Fprintln(bw, "  ingress {")
+			if rule.Description != "" {
+				fmt.Fprintf(bw, "    description      = %q\n", rule.Description)
+			}
+			fmt.Fprintf(bw, "    protocol         = %q\n", rule.Protocol)
+			fmt.Fprintf(bw, "    from_port        = %d\n", rule.FromPort)
+			fmt.Fprintf(bw, "    to_port          = %d\n", rule.ToPort)
+			if len(rule.CIDRs) > 0 {
+				fmt.Fprintf(bw, "    cidr_blocks      = %s\n", hclList(rule.CIDRs))
+			}
+			if len(rule.IPv6CIDRs) > 0 {
+				fmt.Fprintf(bw, "    ipv6_cidr_blocks = %s\n", hclList(rule.IPv6CIDRs))
+			}
+			fmt.Fprintln(bw, "  }")
+		}
+		fmt.Fprintln(bw, "}")
+	}
+	if len(r.Unused) > 0 {
+		if len(r.Recommendations) > 0 {
+			fmt.Fprintln(bw)
+		}
+		fmt.Fprintln(bw, "# The rules no flow uses:")
+		for _, u := range r.Unused {
+			fmt.Fprintf(bw, "#   %s (%s): %s %s from %s\n", u.GroupID, u.GroupName, u.Protocol, u.ports(), u.Source)
+		}
+	}
+	return bw.Flush()
+}
+
+// terraformName returns the name of the resource of the recommendation,
+// which consists of only letters, digits and underscores.
+func terraformName(rec *Recommendation) string {
+	name := rec.Name
+	if strings.Trim(name, "+") == "" {
+		name = strings.Join(rec.SecurityGroups, "_")
+	}
+	return "shawk_" + strings.Map(func(r rune) rune {
+		switch {
+		case 'a' <= r && r <= 'z', 'A' <= r && r <= 'Z', '0' <= r && r <= '9', r == '_':
+			return r
+		}
+		return '_'
+	}, name)
+}
+
+func hclList(ss []string) string {
+	quoted := make([]string, 0, len(ss))
+	for _, s := range ss {
+		quoted = append(quoted, strconv.Quote(s))
+	}
+	return "[" + strings.Join(quoted, ", ") + "]"
+}
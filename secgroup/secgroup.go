@@ -0,0 +1,341 @@
+// Package secgroup recommends the security group rules, which permit exactly
+// the flows observed between the EC2 network interfaces, and finds the rules
+// of the current security groups that no flow uses.
+package secgroup
+
+import (
+	"io"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+
+	"golang.org/x/xerrors"
+
+	"github.com/yuuki/shawk/cloud/aws"
+	"github.com/yuuki/shawk/db"
+)
+
+// protocolTCP is the protocol of the flows, since shawk observes only TCP.
+const protocolTCP = "tcp"
+
+// Rule is an inbound rule permitting the sources to connect to a port.
+type Rule struct {
+	Description string   `json:"description,omitempty"`
+	Protocol    string   `json:"protocol"`
+	FromPort    int      `json:"from_port"`
+	ToPort      int      `json:"to_port"`
+	CIDRs       []string `json:"cidr_blocks,omitempty"`
+	IPv6CIDRs   []string `json:"ipv6_cidr_blocks,omitempty"`
+}
+
+// Recommendation is the security group for the network interfaces sharing
+// the same security groups, which permits the flows into them.
+type Recommendation struct {
+	// Name is the names of the current security groups joined with '+'.
+	Name  string `json:"name"`
+	VpcID string `json:"vpc_id,omitempty"`
+	// SecurityGroups is the IDs of the current security groups that the
+	// recommended one replaces.
+	SecurityGroups    []string `json:"security_groups"`
+	NetworkInterfaces []string `json:"network_interfaces"`
+	Ingress           []*Rule  `json:"ingress"`
+}
+
+// UnusedRule is a source of an inbound rule of a current security group,
+// from which no flow is observed.
+type UnusedRule struct {
+	GroupID   string `json:"group_id"`
+	GroupName string `json:"group_name"`
+	Protocol  string `json:"protocol"`
+	FromPort  int    `json:"from_port"`
+	ToPort    int    `json:"to_port"`
+	// Source is a CIDR block or the ID of a security group.
+	Source string `json:"source"`
+}
+
+// Report is the recommended security groups and the unused rules.
+type Report struct {
+	Recommendations []*Recommendation `json:"recommendations"`
+	Unused          []*UnusedRule     `json:"unused"`
+}
+
+// inbound is a flow into a network interface.
+type inbound struct {
+	src     net.IP
+	srcENI  *aws.NetworkInterface // nil if the source is out of the region
+	port    int
+	process string
+}
+
+// New builds the report of the flows with the network interfaces and the
+// security groups of the region. The flows into the addresses out of the
+// network interfaces are ignored, since no security group of the region
+// controls them. The rules of only the security groups attached to the
+// network interfaces seen in the flows are checked whether they are unused,
+// and the rules of other protocols than TCP are never reported, since shawk
+// can't observe them.
+func New(flows []*db.Flow, enis []aws.NetworkInterface, groups []aws.SecurityGroupRules) *Report {
+	byIP := map[string]*aws.NetworkInterface{}
+	for i := range enis {
+		for _, ip := range enis[i].PrivateIPAddresses {
+			byIP[ip] = &enis[i]
+		}
+	}
+
+	seen := map[string]*aws.NetworkInterface{}
+	inbounds := map[string][]*inbound{} // by the ID of the destination
+	for _, f := range flows {
+		src := byIP[f.ActiveNode.IPAddr.String()]
+		if src != nil {
+			seen[src.NetworkInterfaceID] = src
+		}
+		dst := byIP[f.PassiveNode.IPAddr.String()]
+		if dst == nil {
+			continue
+		}
+		seen[dst.NetworkInterfaceID] = dst
+		inbounds[dst.NetworkInterfaceID] = append(inbounds[dst.NetworkInterfaceID], &inbound{
+			src:     f.ActiveNode.IPAddr,
+			srcENI:  src,
+			port:    int(f.PassiveNode.Port),
+			process: f.PassiveNode.Pname,
+		})
+	}
+
+	return &Report{
+		Recommendations: recommend(seen, inbounds),
+		Unused:          unused(seen, inbounds, groups),
+	}
+}
+
+func groupIDs(eni *aws.NetworkInterface) []string {
+	ids := make([]string, 0, len(eni.Groups))
+	for _, g := range eni.Groups {
+		ids = append(ids, g.GroupID)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func hasGroup(eni *aws.NetworkInterface, id string) bool {
+	for _, g := range eni.Groups {
+		if g.GroupID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// recommend merges the flows into the network interfaces sharing the same
+// security groups into a recommendation, and the flows to the same port into
+// a rule.
+func recommend(seen map[string]*aws.NetworkInterface, inbounds map[string][]*inbound) []*Recommendation {
+	recs := map[string]*Recommendation{}
+	type ruleKey struct {
+		rec  string
+		port int
+	}
+	rules := map[ruleKey]*Rule{}
+	sources := map[*Rule]map[string]bool{}
+	processes := map[*Rule]map[string]bool{}
+
+	for id, ins := range inbounds {
+		eni := seen[id]
+		key := strings.Join(groupIDs(eni), ",")
+		rec, ok := recs[key]
+		if !ok {
+			names := make([]string, 0, len(eni.Groups))
+			for _, g := range eni.Groups {
+				names = append(names, g.GroupName)
+			}
+			sort.Strings(names)
+			rec = &Recommendation{
+				Name:           strings.Join(names, "+"),
+				VpcID:          eni.VpcID,
+				SecurityGroups: groupIDs(eni),
+			}
+			recs[key] = rec
+		}
+		rec.NetworkInterfaces = append(rec.NetworkInterfaces, id)
+
+		for _, in := range ins {
+			rk := ruleKey{rec: key, port: in.port}
+			r, ok := rules[rk]
+			if !ok {
+				r = &Rule{Protocol: protocolTCP, FromPort: in.port, ToPort: in.port}
+				rules[rk] = r
+				sources[r] = map[string]bool{}
+				processes[r] = map[string]bool{}
+				rec.Ingress = append(rec.Ingress, r)
+			}
+			if !sources[r][in.src.String()] {
+				sources[r][in.src.String()] = true
+				if in.src.To4() != nil {
+					r.CIDRs = append(r.CIDRs, in.src.String()+"/32")
+				} else {
+					r.IPv6CIDRs = append(r.IPv6CIDRs, in.src.String()+"/128")
+				}
+			}
+			if in.process != "" && !processes[r][in.process] {
+				processes[r][in.process] = true
+				if r.Description != "" {
+					r.Description += ","
+				}
+				r.Description += in.process
+			}
+		}
+	}
+
+	list := make([]*Recommendation, 0, len(recs))
+	for _, rec := range recs {
+		sort.Strings(rec.NetworkInterfaces)
+		sort.Slice(rec.Ingress, func(i, j int) bool { return rec.Ingress[i].FromPort < rec.Ingress[j].FromPort })
+		for _, r := range rec.Ingress {
+			sortCIDRs(r.CIDRs)
+			sortCIDRs(r.IPv6CIDRs)
+		}
+		list = append(list, rec)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return strings.Join(list[i].SecurityGroups, ",") < strings.Join(list[j].SecurityGroups, ",")
+	})
+	return list
+}
+
+func sortCIDRs(cidrs []string) {
+	sort.Slice(cidrs, func(i, j int) bool {
+		a, _, _ := net.ParseCIDR(cidrs[i])
+		b, _, _ := net.ParseCIDR(cidrs[j])
+		return string(a.To16()) < string(b.To16())
+	})
+}
+
+// unused returns the sources of the rules of the security groups attached to
+// the seen network interfaces, from which no flow is observed.
+func unused(seen map[string]*aws.NetworkInterface, inbounds map[string][]*inbound, groups []aws.SecurityGroupRules) []*UnusedRule {
+	var list []*UnusedRule
+	for _, g := range groups {
+		var members []string
+		for id, eni := range seen {
+			if hasGroup(eni, g.GroupID) {
+				members = append(members, id)
+			}
+		}
+		if len(members) == 0 {
+			continue
+		}
+		for _, p := range g.Permissions {
+			if !permitsTCP(&p) {
+				continue
+			}
+			used := func(match func(in *inbound) bool) bool {
+				for _, id := range members {
+					for _, in := range inbounds[id] {
+						if inPorts(&p, in.port) && match(in) {
+							return true
+						}
+					}
+				}
+				return false
+			}
+			report := func(source string) {
+				list = append(list, &UnusedRule{
+					GroupID:   g.GroupID,
+					GroupName: g.GroupName,
+					Protocol:  p.IPProtocol,
+					FromPort:  p.FromPort,
+					ToPort:    p.ToPort,
+					Source:    source,
+				})
+			}
+			for _, cidr := range p.CIDRs {
+				_, ipnet, err := net.ParseCIDR(cidr)
+				if err != nil {
+					continue
+				}
+				if !used(func(in *inbound) bool { return ipnet.Contains(in.src) }) {
+					report(cidr)
+				}
+			}
+			for _, id := range p.GroupIDs {
+				id := id
+				if !used(func(in *inbound) bool { return in.srcENI != nil && hasGroup(in.srcENI, id) }) {
+					report(id)
+				}
+			}
+		}
+	}
+	sort.SliceStable(list, func(i, j int) bool { return list[i].GroupID < list[j].GroupID })
+	return list
+}
+
+// permitsTCP returns whether the rule permits TCP.
+func permitsTCP(p *aws.IPPermission) bool {
+	switch p.IPProtocol {
+	case protocolTCP, "6", "-1":
+		return true
+	}
+	return false
+}
+
+// inPorts returns whether the rule permits the port.
+func inPorts(p *aws.IPPermission, port int) bool {
+	if p.IPProtocol == "-1" {
+		return true
+	}
+	return p.FromPort <= port && port <= p.ToPort
+}
+
+// ports returns the ports of the unused rule to show.
+func (u *UnusedRule) ports() string {
+	switch {
+	case u.Protocol == "-1":
+		return "all"
+	case u.FromPort == u.ToPort:
+		return strconv.Itoa(u.FromPort)
+	}
+	return strconv.Itoa(u.FromPort) + "-" + strconv.Itoa(u.ToPort)
+}
+
+// A Renderer writes the report in a format.
+type Renderer func(w io.Writer, r *Report) error
+
+// The formats of the report.
+const (
+	// FormatJSON is the report as JSON.
+	FormatJSON = "json"
+	// FormatTerraform is the resources of the Terraform AWS provider.
+	FormatTerraform = "terraform"
+)
+
+var renderers = map[string]Renderer{
+	FormatJSON:      RenderJSON,
+	FormatTerraform: RenderTerraform,
+}
+
+// Formats returns the names of the formats in order.
+func Formats() []string {
+	formats := make([]string, 0, len(renderers))
+	for f := range renderers {
+		formats = append(formats, f)
+	}
+	sort.Strings(formats)
+	return formats
+}
+
+// ValidateFormat returns an error if the format is unknown.
+func ValidateFormat(format string) error {
+	if _, ok := renderers[format]; !ok {
+		return xerrors.Errorf("--format must be one of %s, but %q", strings.Join(Formats(), ", "), format)
+	}
+	return nil
+}
+
+// Render writes the report in the format.
+func Render(w io.Writer, r *Report, format string) error {
+	if err := ValidateFormat(format); err != nil {
+		return err
+	}
+	return renderers[format](w, r)
+}
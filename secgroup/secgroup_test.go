@@ -0,0 +1,137 @@
+package secgroup
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/yuuki/shawk/cloud/aws"
+	"github.com/yuuki/shawk/db"
+	"github.com/yuuki/shawk/db/dbtest"
+)
+
+func testReport() *Report {
+	enis := []aws.NetworkInterface{
+		{
+			NetworkInterfaceID: "eni-lb", VpcID: "vpc-1",
+			PrivateIPAddresses: []string{"10.0.0.5"},
+			Groups:             []aws.SecurityGroup{{GroupID: "sg-lb", GroupName: "lb"}},
+		},
+		{
+			NetworkInterfaceID: "eni-web1", VpcID: "vpc-1",
+			PrivateIPAddresses: []string{"10.0.1.10"},
+			Groups:             []aws.SecurityGroup{{GroupID: "sg-web", GroupName: "web"}, {GroupID: "sg-default", GroupName: "default"}},
+		},
+		{
+			NetworkInterfaceID: "eni-web2", VpcID: "vpc-1",
+			PrivateIPAddresses: []string{"10.0.1.11"},
+			Groups:             []aws.SecurityGroup{{GroupID: "sg-default", GroupName: "default"}, {GroupID: "sg-web", GroupName: "web"}},
+		},
+		{
+			NetworkInterfaceID: "eni-db", VpcID: "vpc-1",
+			PrivateIPAddresses: []string{"10.0.2.20"},
+			Groups:             []aws.SecurityGroup{{GroupID: "sg-db", GroupName: "db"}},
+		},
+	}
+	groups := []aws.SecurityGroupRules{
+		{
+			GroupID: "sg-web", GroupName: "web", VpcID: "vpc-1",
+			Permissions: []aws.IPPermission{
+				{IPProtocol: "tcp", FromPort: 80, ToPort: 80, GroupIDs: []string{"sg-lb"}},
+				{IPProtocol: "tcp", FromPort: 22, ToPort: 22, CIDRs: []string{"0.0.0.0/0"}},
+				{IPProtocol: "udp", FromPort: 53, ToPort: 53, CIDRs: []string{"10.0.0.0/8"}},
+			},
+		},
+		{
+			GroupID: "sg-db", GroupName: "db", VpcID: "vpc-1",
+			Permissions: []aws.IPPermission{
+				{IPProtocol: "tcp", FromPort: 5432, ToPort: 5432, CIDRs: []string{"10.0.1.0/24", "192.168.0.0/16"}},
+			},
+		},
+		{
+			GroupID: "sg-default", GroupName: "default", VpcID: "vpc-1",
+			Permissions: []aws.IPPermission{
+				{IPProtocol: "-1", GroupIDs: []string{"sg-default"}},
+			},
+		},
+		// not attached to the network interfaces seen in the flows
+		{
+			GroupID: "sg-batch", GroupName: "batch", VpcID: "vpc-1",
+			Permissions: []aws.IPPermission{
+				{IPProtocol: "tcp", FromPort: 0, ToPort: 65535, CIDRs: []string{"0.0.0.0/0"}},
+			},
+		},
+	}
+	return New([]*db.Flow{
+		dbtest.Flow("10.0.0.5", "", "10.0.1.10", "nginx", 80, 1),
+		dbtest.Flow("10.0.0.5", "", "10.0.1.11", "nginx", 80, 1),
+		dbtest.Flow("10.0.1.10", "", "10.0.2.20", "postgres", 5432, 1),
+		dbtest.Flow("10.0.1.11", "", "10.0.2.20", "postgres", 5432, 1),
+		dbtest.Flow("10.0.1.11", "", "10.0.2.20", "pgbouncer", 6432, 1),
+		// out of the region
+		dbtest.Flow("10.0.1.10", "", "172.16.0.1", "", 443, 1),
+	}, enis, groups)
+}
+
+func TestNew(t *testing.T) {
+	got := testReport()
+	want := &Report{
+		Recommendations: []*Recommendation{
+			{
+				Name:              "db",
+				VpcID:             "vpc-1",
+				SecurityGroups:    []string{"sg-db"},
+				NetworkInterfaces: []string{"eni-db"},
+				Ingress: []*Rule{
+					{Description: "postgres", Protocol: "tcp", FromPort: 5432, ToPort: 5432, CIDRs: []string{"10.0.1.10/32", "10.0.1.11/32"}},
+					{Description: "pgbouncer", Protocol: "tcp", FromPort: 6432, ToPort: 6432, CIDRs: []string{"10.0.1.11/32"}},
+				},
+			},
+			{
+				Name:              "default+web",
+				VpcID:             "vpc-1",
+				SecurityGroups:    []string{"sg-default", "sg-web"},
+				NetworkInterfaces: []string{"eni-web1", "eni-web2"},
+				Ingress: []*Rule{
+					{Description: "nginx", Protocol: "tcp", FromPort: 80, ToPort: 80, CIDRs: []string{"10.0.0.5/32"}},
+				},
+			},
+		},
+		Unused: []*UnusedRule{
+			{GroupID: "sg-db", GroupName: "db", Protocol: "tcp", FromPort: 5432, ToPort: 5432, Source: "192.168.0.0/16"},
+			{GroupID: "sg-default", GroupName: "default", Protocol: "-1", Source: "sg-default"},
+			{GroupID: "sg-web", GroupName: "web", Protocol: "tcp", FromPort: 22, ToPort: 22, Source: "0.0.0.0/0"},
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("New() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestRenderTerraform(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Render(&buf, testReport(), FormatTerraform); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	got := buf.String()
+	for _, want := range []string{
+		`resource "aws_security_group" "shawk_default_web" {`,
+		`  name        = "shawk-default-web"`,
+		`  vpc_id      = "vpc-1"`,
+		`    cidr_blocks      = ["10.0.1.10/32", "10.0.1.11/32"]`,
+		`#   sg-web (web): tcp 22 from 0.0.0.0/0`,
+		`#   sg-default (default): -1 all from sg-default`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("the output should contain %q, but\n%s", want, got)
+		}
+	}
+}
+
+func TestValidateFormat(t *testing.T) {
+	if err := ValidateFormat("yaml"); err == nil || !strings.Contains(err.Error(), "json, terraform") {
+		t.Errorf("ValidateFormat() should list the formats, but %v", err)
+	}
+}
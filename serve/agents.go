@@ -0,0 +1,56 @@
+package serve
+
+import (
+	"net/http"
+	"time"
+
+	"golang.org/x/xerrors"
+
+	"github.com/yuuki/shawk/db"
+	"github.com/yuuki/shawk/version"
+)
+
+// serveAgentVersions serves the versions of the agents with a record in the
+// last ?since=, or of all the agents, against the latest version of
+// ?latest=, or the newest version of the agents. It responds 501 if ar is
+// nil.
+func serveAgentVersions(ar db.AgentHealthReader) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.Header().Set("Allow", "GET")
+			writeError(w, http.StatusMethodNotAllowed, xerrors.Errorf("%s is not allowed", r.Method))
+			return
+		}
+		if ar == nil {
+			writeError(w, http.StatusNotImplemented, xerrors.New("the CMDB doesn't record the agents"))
+			return
+		}
+		var since time.Time
+		if s := r.URL.Query().Get("since"); s != "" {
+			d, err := time.ParseDuration(s)
+			if err != nil || d <= 0 {
+				writeError(w, http.StatusBadRequest, xerrors.Errorf("since must be a positive duration such as '1h', but %q", s))
+				return
+			}
+			since = time.Now().Add(-d)
+		}
+		latest := r.URL.Query().Get("latest")
+		if latest != "" && !version.Valid(latest) {
+			writeError(w, http.StatusBadRequest, xerrors.Errorf("latest must be a semantic version such as '0.8.0', but %q", latest))
+			return
+		}
+		agents, err := ar.FindAgentHealth(nil)
+		if err != nil {
+			logger.Errorf("%+v", err)
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+		versions := make(map[string]string, len(agents))
+		for _, h := range agents {
+			if !h.LastRecord.Before(since) {
+				versions[h.Host] = h.AgentVersion
+			}
+		}
+		writeJSON(w, http.StatusOK, version.NewRollout(versions, latest))
+	}
+}
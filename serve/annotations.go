@@ -0,0 +1,87 @@
+package serve
+
+import (
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+
+	"golang.org/x/xerrors"
+
+	"github.com/yuuki/shawk/db"
+)
+
+// annotationRequest is the body of PUT /annotations. The empty note removes
+// the annotation of the edge.
+type annotationRequest struct {
+	Client string `json:"client"`
+	Server string `json:"server"`
+	Port   int    `json:"port"`
+	VRF    string `json:"vrf"`
+	Note   string `json:"note"`
+}
+
+func (r *annotationRequest) annotation() (*db.Annotation, error) {
+	if r.Port < 1 || r.Port > 65535 {
+		return nil, xerrors.New("the port must be between 1 and 65535")
+	}
+	a := &db.Annotation{
+		Client: net.ParseIP(r.Client),
+		Server: net.ParseIP(r.Server),
+		Port:   uint16(r.Port),
+		VRF:    r.VRF,
+		Note:   r.Note,
+	}
+	if err := a.Validate(); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// annotationList is the response of GET /annotations.
+type annotationList struct {
+	Annotations []*db.Annotation `json:"annotations"`
+}
+
+// serveAnnotations lists the annotations of the edges on GET, and annotates
+// an edge on PUT. It responds 501 if an is nil.
+func serveAnnotations(an db.Annotator) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodPut {
+			w.Header().Set("Allow", "GET, PUT")
+			writeError(w, http.StatusMethodNotAllowed, xerrors.Errorf("%s is not allowed", r.Method))
+			return
+		}
+		if an == nil {
+			writeError(w, http.StatusNotImplemented, xerrors.New("the CMDB doesn't annotate the edges"))
+			return
+		}
+		if r.Method == http.MethodGet {
+			annotations, err := an.FindAnnotations()
+			if err != nil {
+				logger.Errorf("%+v", err)
+				writeError(w, http.StatusInternalServerError, err)
+				return
+			}
+			writeJSON(w, http.StatusOK, &annotationList{Annotations: annotations})
+			return
+		}
+
+		var req annotationRequest
+		if err := json.NewDecoder(io.LimitReader(r.Body, maxRequestBytes)).Decode(&req); err != nil {
+			writeError(w, http.StatusBadRequest, xerrors.Errorf("invalid request: %w", err))
+			return
+		}
+		a, err := req.annotation()
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+		if err := an.Annotate(a); err != nil {
+			logger.Errorf("%+v", err)
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
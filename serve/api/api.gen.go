@@ -0,0 +1,487 @@
+// Code generated by serve/api/gen from assets/openapi.json. DO NOT EDIT.
+
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+// Age is the ages of the connections of a flow in seconds since the agent first saw them, which is omitted unless the agent of either side tracks them.
+type Age struct {
+	// Min is the age of the youngest connection.
+	Min int `json:"min"`
+	// Avg is the average age of the connections.
+	Avg int `json:"avg"`
+	// Max is the age of the oldest connection.
+	Max int `json:"max"`
+}
+
+// Congestion is the congestion control algorithms of the sockets of a flow, which is omitted unless the agent of either side writes them.
+type Congestion struct {
+	// Client is the algorithm of the client, such as cubic or bbr, or empty if unknown.
+	Client string `json:"client"`
+	// Server is the algorithm of the server, such as cubic or bbr, or empty if unknown.
+	Server string `json:"server"`
+}
+
+// Error is the schema Error.
+type Error struct {
+	Message string `json:"message"`
+}
+
+// Flow is a flow from a client to a server.
+type Flow struct {
+	Client      *Node `json:"client"`
+	Server      *Node `json:"server"`
+	Connections int   `json:"connections"`
+	Age         *Age  `json:"age,omitempty"`
+	// Annotation is the note of the review of the edge from the client to the port of the server, such as 'expected: backup traffic'. It is omitted if the edge is not annotated.
+	Annotation string      `json:"annotation,omitempty"`
+	Congestion *Congestion `json:"congestion,omitempty"`
+	// Depth is the number of the hops from the address, which is omitted in the pages of the flows.
+	Depth int `json:"depth,omitempty"`
+	// ID is the ID of the flow in the CMDB, which is only in the pages of the flows.
+	ID    int    `json:"id,omitempty"`
+	Queue *Queue `json:"queue,omitempty"`
+	// Subflows is the subflows of the MPTCP connections of the flow, which are the paths of the connections counted as one connection each. It is omitted for the plain TCP connections.
+	Subflows int `json:"subflows,omitempty"`
+}
+
+// FlowList is the schema FlowList.
+type FlowList struct {
+	Flows []*Flow `json:"flows"`
+}
+
+// FlowPage is a page of the flows.
+type FlowPage struct {
+	Flows []*Flow `json:"flows"`
+	// Next is the cursor of the next page, or null at the last page.
+	Next *int `json:"next"`
+}
+
+// Node is an endpoint of a flow.
+type Node struct {
+	Addr string `json:"addr"`
+	// Port is null if the node is a client, whose ports are aggregated.
+	Port    *int              `json:"port"`
+	Process string            `json:"process"`
+	Pgid    int               `json:"pgid"`
+	Labels  map[string]string `json:"labels"`
+	// VRF is the VRF of the address, which is omitted for the default VRF.
+	VRF string `json:"vrf,omitempty"`
+}
+
+// Path is a path of the flows.
+type Path struct {
+	Length int     `json:"length"`
+	Flows  []*Flow `json:"flows"`
+}
+
+// PathList is the schema PathList.
+type PathList struct {
+	Paths []*Path `json:"paths"`
+}
+
+// Queue is the socket queues of the server of a flow, which is omitted unless the agent of the server writes them.
+type Queue struct {
+	// RecvQueue is the bytes received but not read by the server, the maximum of the connections.
+	RecvQueue int `json:"recvQueue"`
+	// SendQueue is the bytes sent but not acknowledged by the client, the maximum of the connections.
+	SendQueue int `json:"sendQueue"`
+	// AcceptQueue is the connections waiting for accept(2) on the listener.
+	AcceptQueue int `json:"acceptQueue"`
+	// Backlog is the limit of the accept queue of the listener.
+	Backlog int `json:"backlog"`
+	// Saturated is whether the accept queue is near the backlog.
+	Saturated bool `json:"saturated"`
+}
+
+// ServiceEdge is the flows from the processes of a name to the port of the processes of another name.
+type ServiceEdge struct {
+	Client      string `json:"client"`
+	Server      string `json:"server"`
+	Port        int    `json:"port"`
+	Connections int    `json:"connections"`
+	// Clients is the number of the addresses of the clients.
+	Clients int `json:"clients"`
+	// Servers is the number of the addresses of the servers.
+	Servers int `json:"servers"`
+}
+
+// ServiceEdgeList is the schema ServiceEdgeList.
+type ServiceEdgeList struct {
+	Edges []*ServiceEdge `json:"edges"`
+}
+
+// Talker is a client process with the total connections of its flows.
+type Talker struct {
+	Addr        string `json:"addr"`
+	Process     string `json:"process"`
+	Connections int    `json:"connections"`
+	// Flows is the number of the servers of the process.
+	Flows int `json:"flows"`
+	// VRF is the VRF of the address, which is omitted for the default VRF.
+	VRF string `json:"vrf,omitempty"`
+}
+
+// TalkerList is the schema TalkerList.
+type TalkerList struct {
+	Talkers []*Talker `json:"talkers"`
+}
+
+// DependenciesParams is the parameters of GET /dependencies.
+type DependenciesParams struct {
+	// Addr is the IPv4 address to walk the graph from.
+	Addr string
+	// Depth is the number of the hops to walk.
+	Depth int
+	// Since is the time to find the flows updated since.
+	Since string
+	// Until is the time to find the flows updated until.
+	Until string
+}
+
+// ParseDependenciesParams parses and validates the parameters of GET /dependencies.
+func ParseDependenciesParams(q url.Values) (*DependenciesParams, error) {
+	p := &DependenciesParams{}
+	if v := q.Get("addr"); v != "" {
+		if err := validateIPv4("addr", v); err != nil {
+			return nil, err
+		}
+		p.Addr = v
+	} else {
+		return nil, &ParamError{Param: "addr", Message: "is required"}
+	}
+	if v := q.Get("depth"); v != "" {
+		n, err := parseInt("depth", v, intPtr(1), intPtr(4))
+		if err != nil {
+			return nil, err
+		}
+		p.Depth = n
+	} else {
+		p.Depth = 1
+	}
+	if v := q.Get("since"); v != "" {
+		p.Since = v
+	}
+	if v := q.Get("until"); v != "" {
+		p.Until = v
+	}
+	return p, nil
+}
+
+// DependentsParams is the parameters of GET /dependents.
+type DependentsParams struct {
+	// Addr is the IPv4 address to walk the graph from.
+	Addr string
+	// Depth is the number of the hops to walk.
+	Depth int
+	// Since is the time to find the flows updated since.
+	Since string
+	// Until is the time to find the flows updated until.
+	Until string
+}
+
+// ParseDependentsParams parses and validates the parameters of GET /dependents.
+func ParseDependentsParams(q url.Values) (*DependentsParams, error) {
+	p := &DependentsParams{}
+	if v := q.Get("addr"); v != "" {
+		if err := validateIPv4("addr", v); err != nil {
+			return nil, err
+		}
+		p.Addr = v
+	} else {
+		return nil, &ParamError{Param: "addr", Message: "is required"}
+	}
+	if v := q.Get("depth"); v != "" {
+		n, err := parseInt("depth", v, intPtr(1), intPtr(4))
+		if err != nil {
+			return nil, err
+		}
+		p.Depth = n
+	} else {
+		p.Depth = 1
+	}
+	if v := q.Get("since"); v != "" {
+		p.Since = v
+	}
+	if v := q.Get("until"); v != "" {
+		p.Until = v
+	}
+	return p, nil
+}
+
+// EdgesParams is the parameters of GET /edges.
+type EdgesParams struct {
+	// Limit is the maximum number of the results.
+	Limit int
+	// Since is the time to find the flows updated since.
+	Since string
+	// Until is the time to find the flows updated until.
+	Until string
+}
+
+// ParseEdgesParams parses and validates the parameters of GET /edges.
+func ParseEdgesParams(q url.Values) (*EdgesParams, error) {
+	p := &EdgesParams{}
+	if v := q.Get("limit"); v != "" {
+		n, err := parseInt("limit", v, intPtr(1), intPtr(1000))
+		if err != nil {
+			return nil, err
+		}
+		p.Limit = n
+	} else {
+		p.Limit = 20
+	}
+	if v := q.Get("since"); v != "" {
+		p.Since = v
+	}
+	if v := q.Get("until"); v != "" {
+		p.Until = v
+	}
+	return p, nil
+}
+
+// FlowsParams is the parameters of GET /flows.
+type FlowsParams struct {
+	// After is the cursor of the page, which is next of the previous page.
+	After int
+	// Limit is the maximum number of the flows of the page.
+	Limit int
+	// Since is the time to find the flows updated since.
+	Since string
+	// Until is the time to find the flows updated until.
+	Until string
+}
+
+// ParseFlowsParams parses and validates the parameters of GET /flows.
+func ParseFlowsParams(q url.Values) (*FlowsParams, error) {
+	p := &FlowsParams{}
+	if v := q.Get("after"); v != "" {
+		n, err := parseInt("after", v, intPtr(0), nil)
+		if err != nil {
+			return nil, err
+		}
+		p.After = n
+	} else {
+		p.After = 0
+	}
+	if v := q.Get("limit"); v != "" {
+		n, err := parseInt("limit", v, intPtr(1), intPtr(10000))
+		if err != nil {
+			return nil, err
+		}
+		p.Limit = n
+	} else {
+		p.Limit = 1000
+	}
+	if v := q.Get("since"); v != "" {
+		p.Since = v
+	}
+	if v := q.Get("until"); v != "" {
+		p.Until = v
+	}
+	return p, nil
+}
+
+// PathsParams is the parameters of GET /paths.
+type PathsParams struct {
+	// From is the IPv4 address of the client.
+	From string
+	// To is the IPv4 address of the server.
+	To string
+	// MaxDepth is the maximum length of the paths.
+	MaxDepth int
+	// Since is the time to find the flows updated since.
+	Since string
+	// Until is the time to find the flows updated until.
+	Until string
+}
+
+// ParsePathsParams parses and validates the parameters of GET /paths.
+func ParsePathsParams(q url.Values) (*PathsParams, error) {
+	p := &PathsParams{}
+	if v := q.Get("from"); v != "" {
+		if err := validateIPv4("from", v); err != nil {
+			return nil, err
+		}
+		p.From = v
+	} else {
+		return nil, &ParamError{Param: "from", Message: "is required"}
+	}
+	if v := q.Get("to"); v != "" {
+		if err := validateIPv4("to", v); err != nil {
+			return nil, err
+		}
+		p.To = v
+	} else {
+		return nil, &ParamError{Param: "to", Message: "is required"}
+	}
+	if v := q.Get("maxDepth"); v != "" {
+		n, err := parseInt("maxDepth", v, intPtr(1), intPtr(4))
+		if err != nil {
+			return nil, err
+		}
+		p.MaxDepth = n
+	} else {
+		p.MaxDepth = 4
+	}
+	if v := q.Get("since"); v != "" {
+		p.Since = v
+	}
+	if v := q.Get("until"); v != "" {
+		p.Until = v
+	}
+	return p, nil
+}
+
+// TalkersParams is the parameters of GET /talkers.
+type TalkersParams struct {
+	// Limit is the maximum number of the results.
+	Limit int
+	// Since is the time to find the flows updated since.
+	Since string
+	// Until is the time to find the flows updated until.
+	Until string
+}
+
+// ParseTalkersParams parses and validates the parameters of GET /talkers.
+func ParseTalkersParams(q url.Values) (*TalkersParams, error) {
+	p := &TalkersParams{}
+	if v := q.Get("limit"); v != "" {
+		n, err := parseInt("limit", v, intPtr(1), intPtr(1000))
+		if err != nil {
+			return nil, err
+		}
+		p.Limit = n
+	} else {
+		p.Limit = 20
+	}
+	if v := q.Get("since"); v != "" {
+		p.Since = v
+	}
+	if v := q.Get("until"); v != "" {
+		p.Until = v
+	}
+	return p, nil
+}
+
+// Server implements the operations of the API.
+type Server interface {
+	// Dependencies returns the flows to the servers of the address, and of their servers.
+	Dependencies(ctx context.Context, p *DependenciesParams) (*FlowList, error)
+	// Dependents returns the flows from the clients of the address, and of their clients.
+	Dependents(ctx context.Context, p *DependentsParams) (*FlowList, error)
+	// Edges returns the edges between the services, which are the processes of the same names, in descending order of the connections.
+	Edges(ctx context.Context, p *EdgesParams) (*ServiceEdgeList, error)
+	// Flows returns a page of all the flows, ordered by their IDs.
+	Flows(ctx context.Context, p *FlowsParams) (*FlowPage, error)
+	// Paths returns the shortest paths of the flows from an address to another.
+	Paths(ctx context.Context, p *PathsParams) (*PathList, error)
+	// Talkers returns the client processes in descending order of the connections.
+	Talkers(ctx context.Context, p *TalkersParams) (*TalkerList, error)
+}
+
+// NewRouter returns the handler of the operations of s.
+func NewRouter(s Server) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/dependencies", func(w http.ResponseWriter, r *http.Request) {
+		if !allowGet(w, r) {
+			return
+		}
+		p, err := ParseDependenciesParams(r.URL.Query())
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		res, err := s.Dependencies(r.Context(), p)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, res)
+	})
+	mux.HandleFunc("/dependents", func(w http.ResponseWriter, r *http.Request) {
+		if !allowGet(w, r) {
+			return
+		}
+		p, err := ParseDependentsParams(r.URL.Query())
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		res, err := s.Dependents(r.Context(), p)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, res)
+	})
+	mux.HandleFunc("/edges", func(w http.ResponseWriter, r *http.Request) {
+		if !allowGet(w, r) {
+			return
+		}
+		p, err := ParseEdgesParams(r.URL.Query())
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		res, err := s.Edges(r.Context(), p)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, res)
+	})
+	mux.HandleFunc("/flows", func(w http.ResponseWriter, r *http.Request) {
+		if !allowGet(w, r) {
+			return
+		}
+		p, err := ParseFlowsParams(r.URL.Query())
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		res, err := s.Flows(r.Context(), p)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, res)
+	})
+	mux.HandleFunc("/paths", func(w http.ResponseWriter, r *http.Request) {
+		if !allowGet(w, r) {
+			return
+		}
+		p, err := ParsePathsParams(r.URL.Query())
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		res, err := s.Paths(r.Context(), p)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, res)
+	})
+	mux.HandleFunc("/talkers", func(w http.ResponseWriter, r *http.Request) {
+		if !allowGet(w, r) {
+			return
+		}
+		p, err := ParseTalkersParams(r.URL.Query())
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		res, err := s.Talkers(r.Context(), p)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, res)
+	})
+	return mux
+}
@@ -0,0 +1,327 @@
+// Package api is the REST API of the dependency graph defined by
+// assets/openapi.json. The types, the parsers of the parameters and the
+// router are generated from the spec into api.gen.go.
+package api
+
+//go:generate go run ./gen -spec ../../assets/openapi.json -o api.gen.go
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/xerrors"
+
+	"github.com/yuuki/shawk/logging"
+	"github.com/yuuki/shawk/pkg/client"
+	"github.com/yuuki/shawk/probe"
+)
+
+var logger = logging.New("serve/api")
+
+// ParamError is an invalid parameter of a request.
+type ParamError struct {
+	Param   string
+	Message string
+}
+
+func (e *ParamError) Error() string {
+	return e.Param + " " + e.Message
+}
+
+func intPtr(n int) *int { return &n }
+
+func validateIPv4(name, v string) error {
+	if ip := net.ParseIP(v); ip == nil || ip.To4() == nil {
+		return &ParamError{Param: name, Message: "must be an IPv4 address"}
+	}
+	return nil
+}
+
+func validateEnum(name, v string, values []string) error {
+	for _, e := range values {
+		if v == e {
+			return nil
+		}
+	}
+	return &ParamError{Param: name, Message: "must be one of " + strings.Join(values, ", ")}
+}
+
+func parseInt(name, v string, min, max *int) (int, error) {
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		return 0, &ParamError{Param: name, Message: "must be an integer"}
+	}
+	if min != nil && n < *min {
+		return 0, &ParamError{Param: name, Message: "must be at least " + strconv.Itoa(*min)}
+	}
+	if max != nil && n > *max {
+		return 0, &ParamError{Param: name, Message: "must be at most " + strconv.Itoa(*max)}
+	}
+	return n, nil
+}
+
+// ParseTime parses the time of since or until, which is a relative duration
+// to now such as '2h', or an RFC 3339 timestamp. Empty is the zero time.
+func ParseTime(s string, now time.Time) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	if d, err := time.ParseDuration(s); err == nil {
+		return now.Add(-d), nil
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return time.Time{}, xerrors.Errorf("%q is neither a duration nor an RFC 3339 time", s)
+	}
+	return t, nil
+}
+
+func allowGet(w http.ResponseWriter, r *http.Request) bool {
+	if r.Method == http.MethodGet {
+		return true
+	}
+	w.Header().Set("Allow", http.MethodGet)
+	writeJSON(w, http.StatusMethodNotAllowed, &Error{Message: r.Method + " is not allowed"})
+	return false
+}
+
+func writeJSON(w http.ResponseWriter, code int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		logger.Errorf("could not write the response: %v", err)
+	}
+}
+
+// writeError writes 400 for a ParamError, or 500 for the others.
+func writeError(w http.ResponseWriter, err error) {
+	var perr *ParamError
+	if xerrors.As(err, &perr) {
+		writeJSON(w, http.StatusBadRequest, &Error{Message: perr.Error()})
+		return
+	}
+	logger.Errorf("%+v", err)
+	writeJSON(w, http.StatusInternalServerError, &Error{Message: err.Error()})
+}
+
+// server is the Server querying the CMDB by the client.
+type server struct {
+	client *client.Client
+	now    func() time.Time
+}
+
+// NewHandler returns the handler of the API querying by c.
+func NewHandler(c *client.Client) http.Handler {
+	return NewRouter(&server{client: c, now: time.Now})
+}
+
+func (s *server) query(addr string, depth int, since, until string, dir probe.FlowDirection) (*client.Query, error) {
+	q := &client.Query{Addr: net.ParseIP(addr), Depth: depth, Direction: dir}
+	now := s.now()
+	var err error
+	if q.Since, err = ParseTime(since, now); err != nil {
+		return nil, &ParamError{Param: "since", Message: err.Error()}
+	}
+	if q.Until, err = ParseTime(until, now); err != nil {
+		return nil, &ParamError{Param: "until", Message: err.Error()}
+	}
+	return q, nil
+}
+
+func newNode(n *client.Node) *Node {
+	node := &Node{
+		Addr:    n.IPAddr.String(),
+		Process: n.Pname,
+		Pgid:    n.Pgid,
+		Labels:  n.Labels,
+		VRF:     n.VRF,
+	}
+	if !n.Aggregated {
+		node.Port = intPtr(int(n.Port))
+	}
+	if node.Labels == nil {
+		node.Labels = map[string]string{}
+	}
+	return node
+}
+
+func newFlow(f *client.Flow, depth int) *Flow {
+	flow := &Flow{
+		Client:      newNode(f.ActiveNode),
+		Server:      newNode(f.PassiveNode),
+		Connections: f.Connections,
+		Depth:       depth,
+	}
+	// Only the agent of the server writes the backlog of the listener.
+	if n := f.PassiveNode; n.Backlog > 0 {
+		flow.Queue = &Queue{
+			RecvQueue:   f.RecvQueue,
+			SendQueue:   f.SendQueue,
+			AcceptQueue: n.AcceptQueue,
+			Backlog:     n.Backlog,
+			Saturated:   n.Saturated(),
+		}
+	}
+	if f.ClientCong != "" || f.ServerCong != "" {
+		flow.Congestion = &Congestion{Client: f.ClientCong, Server: f.ServerCong}
+	}
+	if a := f.Age; a != nil {
+		flow.Age = &Age{
+			Min: int(a.Min / time.Second),
+			Avg: int(a.Avg / time.Second),
+			Max: int(a.Max / time.Second),
+		}
+	}
+	flow.Subflows = f.Subflows
+	flow.Annotation = f.Annotation
+	return flow
+}
+
+// FlowOf returns the flow listed with its ID, as in the pages of GET /flows.
+func FlowOf(f *client.Flow) *Flow {
+	flow := newFlow(f, 0)
+	flow.ID = int(f.ID)
+	return flow
+}
+
+func (s *server) walk(ctx context.Context, q *client.Query) (*FlowList, error) {
+	deps, err := s.client.Query(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	list := &FlowList{Flows: make([]*Flow, 0, len(deps))}
+	for _, d := range deps {
+		list.Flows = append(list.Flows, newFlow(d.Flow, d.Depth))
+	}
+	return list, nil
+}
+
+// Dependencies walks the servers.
+func (s *server) Dependencies(ctx context.Context, p *DependenciesParams) (*FlowList, error) {
+	q, err := s.query(p.Addr, p.Depth, p.Since, p.Until, probe.FlowActive)
+	if err != nil {
+		return nil, err
+	}
+	return s.walk(ctx, q)
+}
+
+// Dependents walks the clients.
+func (s *server) Dependents(ctx context.Context, p *DependentsParams) (*FlowList, error) {
+	q, err := s.query(p.Addr, p.Depth, p.Since, p.Until, probe.FlowPassive)
+	if err != nil {
+		return nil, err
+	}
+	return s.walk(ctx, q)
+}
+
+// Flows lists a page of the flows, so that the clients export all the flows
+// without the server loading them at once.
+func (s *server) Flows(ctx context.Context, p *FlowsParams) (*FlowPage, error) {
+	now := s.now()
+	page := &client.Page{After: int64(p.After), Limit: p.Limit}
+	var err error
+	if page.Since, err = ParseTime(p.Since, now); err != nil {
+		return nil, &ParamError{Param: "since", Message: err.Error()}
+	}
+	if page.Until, err = ParseTime(p.Until, now); err != nil {
+		return nil, &ParamError{Param: "until", Message: err.Error()}
+	}
+	flows, next, err := s.client.ListFlows(ctx, page)
+	if err != nil {
+		return nil, err
+	}
+	res := &FlowPage{Flows: make([]*Flow, 0, len(flows))}
+	for _, f := range flows {
+		res.Flows = append(res.Flows, FlowOf(f))
+	}
+	if next != 0 {
+		res.Next = intPtr(int(next))
+	}
+	return res, nil
+}
+
+func (s *server) aggregate(limit int, since, until string) (*client.Aggregate, error) {
+	a := &client.Aggregate{Limit: limit}
+	now := s.now()
+	var err error
+	if a.Since, err = ParseTime(since, now); err != nil {
+		return nil, &ParamError{Param: "since", Message: err.Error()}
+	}
+	if a.Until, err = ParseTime(until, now); err != nil {
+		return nil, &ParamError{Param: "until", Message: err.Error()}
+	}
+	return a, nil
+}
+
+// Talkers finds the top talkers.
+func (s *server) Talkers(ctx context.Context, p *TalkersParams) (*TalkerList, error) {
+	a, err := s.aggregate(p.Limit, p.Since, p.Until)
+	if err != nil {
+		return nil, err
+	}
+	talkers, err := s.client.TopTalkers(ctx, a)
+	if err != nil {
+		return nil, err
+	}
+	list := &TalkerList{Talkers: make([]*Talker, 0, len(talkers))}
+	for _, t := range talkers {
+		list.Talkers = append(list.Talkers, &Talker{
+			Addr:        t.IPAddr.String(),
+			Process:     t.Pname,
+			Connections: int(t.Connections),
+			Flows:       int(t.Flows),
+			VRF:         t.VRF,
+		})
+	}
+	return list, nil
+}
+
+// Edges finds the edges between the services.
+func (s *server) Edges(ctx context.Context, p *EdgesParams) (*ServiceEdgeList, error) {
+	a, err := s.aggregate(p.Limit, p.Since, p.Until)
+	if err != nil {
+		return nil, err
+	}
+	edges, err := s.client.ServiceEdges(ctx, a)
+	if err != nil {
+		return nil, err
+	}
+	list := &ServiceEdgeList{Edges: make([]*ServiceEdge, 0, len(edges))}
+	for _, e := range edges {
+		list.Edges = append(list.Edges, &ServiceEdge{
+			Client:      e.Client,
+			Server:      e.Server,
+			Port:        int(e.Port),
+			Connections: int(e.Connections),
+			Clients:     int(e.Clients),
+			Servers:     int(e.Servers),
+		})
+	}
+	return list, nil
+}
+
+// Paths finds the shortest paths.
+func (s *server) Paths(ctx context.Context, p *PathsParams) (*PathList, error) {
+	q, err := s.query(p.From, p.MaxDepth, p.Since, p.Until, 0)
+	if err != nil {
+		return nil, err
+	}
+	paths, err := s.client.Paths(ctx, q, net.ParseIP(p.To))
+	if err != nil {
+		return nil, err
+	}
+	list := &PathList{Paths: make([]*Path, 0, len(paths))}
+	for _, fs := range paths {
+		path := &Path{Length: len(fs), Flows: make([]*Flow, 0, len(fs))}
+		for i, f := range fs {
+			path.Flows = append(path.Flows, newFlow(f, i+1))
+		}
+		list.Paths = append(list.Paths, path)
+	}
+	return list, nil
+}
@@ -0,0 +1,222 @@
+package api
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/yuuki/shawk/db"
+	"github.com/yuuki/shawk/db/dbtest"
+	"github.com/yuuki/shawk/pkg/client"
+	"github.com/yuuki/shawk/probe"
+)
+
+// fakeStore finds the flows among its flows instead of the CMDB.
+type fakeStore struct {
+	db.Store
+	flows []*db.Flow
+	cond  *db.AggregateCond
+}
+
+func (s *fakeStore) find(cond *db.FindFlowsCond, node func(*db.Flow) *db.Node) db.Flows {
+	flows := db.Flows{}
+	for _, f := range s.flows {
+		for _, addr := range cond.Addrs {
+			if n := node(f); n.IPAddr.Equal(addr) {
+				key := n.IPAddr.String() + "-" + n.Pname
+				flows[key] = append(flows[key], f)
+			}
+		}
+	}
+	return flows
+}
+
+func (s *fakeStore) FindPassiveFlows(cond *db.FindFlowsCond) (db.Flows, error) {
+	return s.find(cond, func(f *db.Flow) *db.Node { return f.PassiveNode }), nil
+}
+
+func (s *fakeStore) FindActiveFlows(cond *db.FindFlowsCond) (db.Flows, error) {
+	return s.find(cond, func(f *db.Flow) *db.Node { return f.ActiveNode }), nil
+}
+
+func (s *fakeStore) ListFlows(cond *db.ListFlowsCond) ([]*db.Flow, error) {
+	var page []*db.Flow
+	for i, f := range s.flows {
+		if id := int64(i + 1); id > cond.After && len(page) < cond.Limit {
+			flow := *f
+			flow.ID = id
+			page = append(page, &flow)
+		}
+	}
+	return page, nil
+}
+
+// FindTopTalkers returns a talker of each flow, recording the condition.
+func (s *fakeStore) FindTopTalkers(cond *db.AggregateCond) ([]*db.Talker, error) {
+	s.cond = cond
+	var talkers []*db.Talker
+	for _, f := range s.flows {
+		talkers = append(talkers, &db.Talker{IPAddr: f.ActiveNode.IPAddr, Pname: f.ActiveNode.Pname, Connections: int64(f.Connections), Flows: 1})
+	}
+	return talkers, nil
+}
+
+// FindServiceEdges returns an edge of each flow, recording the condition.
+func (s *fakeStore) FindServiceEdges(cond *db.AggregateCond) ([]*db.ServiceEdge, error) {
+	s.cond = cond
+	var edges []*db.ServiceEdge
+	for _, f := range s.flows {
+		edges = append(edges, &db.ServiceEdge{
+			Client: f.ActiveNode.Pname, Server: f.PassiveNode.Pname, Port: f.PassiveNode.Port,
+			Connections: int64(f.Connections), Clients: 1, Servers: 1,
+		})
+	}
+	return edges, nil
+}
+
+func TestAPI(t *testing.T) {
+	saturated := dbtest.Flow("10.0.0.2", "app", "10.0.0.3", "postgres", 5432, 2)
+	saturated.RecvQueue, saturated.SendQueue = 0, 4096
+	saturated.PassiveNode.AcceptQueue, saturated.PassiveNode.Backlog = 120, 128
+	saturated.ClientCong = "bbr"
+	saturated.Age = &probe.Age{Min: 90 * time.Second, Avg: 30 * time.Minute, Max: 2 * time.Hour}
+	saturated.Subflows = 3
+	saturated.Annotation = "INFRA-123"
+	store := &fakeStore{flows: []*db.Flow{
+		dbtest.Flow("10.0.0.1", "app", "10.0.0.2", "nginx", 80, 2),
+		saturated,
+	}}
+	ts := httptest.NewServer(NewHandler(client.NewWithStore(store)))
+	defer ts.Close()
+
+	tests := []struct {
+		target string
+		code   int
+		want   string
+	}{
+		{
+			"/dependencies?addr=10.0.0.1", http.StatusOK,
+			`{"flows":[{"client":{"addr":"10.0.0.1","port":null,"process":"app","pgid":0,"labels":{}},"server":{"addr":"10.0.0.2","port":80,"process":"nginx","pgid":0,"labels":{}},"connections":2,"depth":1}]}`,
+		},
+		{
+			"/dependents?addr=10.0.0.3&depth=2&since=1h", http.StatusOK,
+			`{"flows":[` +
+				`{"client":{"addr":"10.0.0.2","port":null,"process":"app","pgid":0,"labels":{}},"server":{"addr":"10.0.0.3","port":5432,"process":"postgres","pgid":0,"labels":{}},"connections":2,"age":{"min":90,"avg":1800,"max":7200},"annotation":"INFRA-123","congestion":{"client":"bbr","server":""},"depth":1,"queue":{"recvQueue":0,"sendQueue":4096,"acceptQueue":120,"backlog":128,"saturated":true},"subflows":3},` +
+				`{"client":{"addr":"10.0.0.1","port":null,"process":"app","pgid":0,"labels":{}},"server":{"addr":"10.0.0.2","port":80,"process":"nginx","pgid":0,"labels":{}},"connections":2,"depth":2}]}`,
+		},
+		{"/paths?from=10.0.0.1&to=10.0.0.9", http.StatusOK, `{"paths":[]}`},
+		{
+			"/flows?limit=1", http.StatusOK,
+			`{"flows":[{"client":{"addr":"10.0.0.1","port":null,"process":"app","pgid":0,"labels":{}},"server":{"addr":"10.0.0.2","port":80,"process":"nginx","pgid":0,"labels":{}},"connections":2,"id":1}],"next":1}`,
+		},
+		{
+			"/flows?after=1", http.StatusOK,
+			`{"flows":[{"client":{"addr":"10.0.0.2","port":null,"process":"app","pgid":0,"labels":{}},"server":{"addr":"10.0.0.3","port":5432,"process":"postgres","pgid":0,"labels":{}},"connections":2,"age":{"min":90,"avg":1800,"max":7200},"annotation":"INFRA-123","congestion":{"client":"bbr","server":""},"id":2,"queue":{"recvQueue":0,"sendQueue":4096,"acceptQueue":120,"backlog":128,"saturated":true},"subflows":3}],"next":null}`,
+		},
+		{
+			"/talkers", http.StatusOK,
+			`{"talkers":[{"addr":"10.0.0.1","process":"app","connections":2,"flows":1},{"addr":"10.0.0.2","process":"app","connections":2,"flows":1}]}`,
+		},
+		{
+			"/edges?limit=5", http.StatusOK,
+			`{"edges":[{"client":"app","server":"nginx","port":80,"connections":2,"clients":1,"servers":1},{"client":"app","server":"postgres","port":5432,"connections":2,"clients":1,"servers":1}]}`,
+		},
+		{"/edges?limit=0", http.StatusBadRequest, `{"message":"limit must be at least 1"}`},
+		{"/flows?after=-1", http.StatusBadRequest, `{"message":"after must be at least 0"}`},
+		{"/flows?limit=10001", http.StatusBadRequest, `{"message":"limit must be at most 10000"}`},
+		{"/dependencies", http.StatusBadRequest, `{"message":"addr is required"}`},
+		{"/dependencies?addr=fe80::1", http.StatusBadRequest, `{"message":"addr must be an IPv4 address"}`},
+		{"/dependencies?addr=10.0.0.1&depth=5", http.StatusBadRequest, `{"message":"depth must be at most 4"}`},
+		{"/dependencies?addr=10.0.0.1&depth=x", http.StatusBadRequest, `{"message":"depth must be an integer"}`},
+		{"/dependencies?addr=10.0.0.1&until=tomorrow", http.StatusBadRequest, `{"message":"until \"tomorrow\" is neither a duration nor an RFC 3339 time"}`},
+	}
+	for _, tt := range tests {
+		resp, err := http.Get(ts.URL + tt.target)
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, _ := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		if resp.StatusCode != tt.code {
+			t.Errorf("GET %s = %d, want %d", tt.target, resp.StatusCode, tt.code)
+		}
+		if got := strings.TrimSpace(string(body)); got != tt.want {
+			t.Errorf("GET %s = %s, want %s", tt.target, got, tt.want)
+		}
+	}
+
+	// The views may answer the query of all time.
+	if _, err := http.Get(ts.URL + "/talkers"); err != nil {
+		t.Fatal(err)
+	}
+	if want := (&db.AggregateCond{Limit: 20}); !cmp.Equal(want, store.cond) {
+		t.Errorf("GET /talkers should query %+v, but %+v", want, store.cond)
+	}
+}
+
+func TestParsePathsParams(t *testing.T) {
+	got, err := ParsePathsParams(map[string][]string{"from": {"10.0.0.1"}, "to": {"10.0.0.2"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &PathsParams{From: "10.0.0.1", To: "10.0.0.2", MaxDepth: 4}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ParsePathsParams() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+// TestGenerated checks that api.gen.go is generated from the current spec.
+func TestGenerated(t *testing.T) {
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go is not found")
+	}
+	dir, err := ioutil.TempDir("", "shawk-api")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	out := filepath.Join(dir, "api.gen.go")
+	cmd := exec.Command("go", "run", "./gen", "-spec", "../../assets/openapi.json", "-o", out)
+	if b, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("could not generate: %v\n%s", err, b)
+	}
+	want, _ := ioutil.ReadFile("api.gen.go")
+	got, _ := ioutil.ReadFile(out)
+	if !bytes.Equal(want, got) {
+		t.Error("api.gen.go is out of date: run go generate ./serve/api")
+	}
+}
+
+func TestParseTime(t *testing.T) {
+	now := time.Date(2020, 12, 20, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{"", "0001-01-01T00:00:00Z", false},
+		{"2h", "2020-12-20T10:00:00Z", false},
+		{"2020-12-19T00:00:00+09:00", "2020-12-18T15:00:00Z", false},
+		{"yesterday", "", true},
+	}
+	for _, tt := range tests {
+		got, err := ParseTime(tt.in, now)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseTime(%q) error = %v, want error %t", tt.in, err, tt.wantErr)
+			continue
+		}
+		if err == nil && got.UTC().Format("2006-01-02T15:04:05Z07:00") != tt.want {
+			t.Errorf("ParseTime(%q) = %s, want %s", tt.in, got.UTC(), tt.want)
+		}
+	}
+}
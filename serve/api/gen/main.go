@@ -0,0 +1,318 @@
+// Command gen generates the types, the parsers of the parameters and the
+// router of serve/api from the OpenAPI spec.
+//
+// It supports the subset of OpenAPI 3.0 the spec of shawk uses: the GET
+// operations with the query parameters of string and integer, and the
+// schemas of objects, arrays, maps of strings and $ref.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"log"
+	"sort"
+	"strings"
+)
+
+type schema struct {
+	Ref                  string             `json:"$ref"`
+	Type                 string             `json:"type"`
+	Format               string             `json:"format"`
+	Description          string             `json:"description"`
+	Nullable             bool               `json:"nullable"`
+	Required             []string           `json:"required"`
+	Properties           map[string]*schema `json:"properties"`
+	Items                *schema            `json:"items"`
+	AdditionalProperties *schema            `json:"additionalProperties"`
+	Minimum              *int               `json:"minimum"`
+	Maximum              *int               `json:"maximum"`
+	Default              interface{}        `json:"default"`
+	Enum                 []string           `json:"enum"`
+}
+
+type parameter struct {
+	Ref         string  `json:"$ref"`
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Required    bool    `json:"required"`
+	Description string  `json:"description"`
+	Schema      *schema `json:"schema"`
+}
+
+type response struct {
+	Ref     string `json:"$ref"`
+	Content map[string]struct {
+		Schema *schema `json:"schema"`
+	} `json:"content"`
+}
+
+type operation struct {
+	OperationID string               `json:"operationId"`
+	Summary     string               `json:"summary"`
+	Parameters  []*parameter         `json:"parameters"`
+	Responses   map[string]*response `json:"responses"`
+}
+
+type spec struct {
+	Paths      map[string]map[string]*operation `json:"paths"`
+	Components struct {
+		Parameters map[string]*parameter `json:"parameters"`
+		Responses  map[string]*response  `json:"responses"`
+		Schemas    map[string]*schema    `json:"schemas"`
+	} `json:"components"`
+}
+
+func refName(ref string) string {
+	return ref[strings.LastIndex(ref, "/")+1:]
+}
+
+// initialisms are the names spelled in upper case in Go.
+var initialisms = map[string]string{"id": "ID", "ip": "IP", "url": "URL", "vrf": "VRF"}
+
+// exported returns the exported Go name of the name in the spec.
+func exported(name string) string {
+	if s, ok := initialisms[name]; ok {
+		return s
+	}
+	return strings.ToUpper(name[:1]) + name[1:]
+}
+
+// goType returns the type of the schema in Go.
+func goType(s *schema) string {
+	if s.Ref != "" {
+		return "*" + refName(s.Ref)
+	}
+	var t string
+	switch s.Type {
+	case "string":
+		t = "string"
+	case "integer":
+		t = "int"
+	case "boolean":
+		t = "bool"
+	case "number":
+		t = "float64"
+	case "array":
+		return "[]" + goType(s.Items)
+	case "object":
+		if s.AdditionalProperties != nil {
+			return "map[string]" + goType(s.AdditionalProperties)
+		}
+		log.Fatalf("inline objects are not supported")
+	default:
+		log.Fatalf("unsupported type %q", s.Type)
+	}
+	if s.Nullable {
+		return "*" + t
+	}
+	return t
+}
+
+// comment writes the description as a doc comment of the name.
+func comment(b *bytes.Buffer, indent, name, desc string) {
+	if desc == "" {
+		return
+	}
+	desc = strings.TrimSuffix(desc, ".")
+	fmt.Fprintf(b, "%s// %s is %s%s.\n", indent, name, strings.ToLower(desc[:1]), desc[1:])
+}
+
+func (sp *spec) writeSchemas(b *bytes.Buffer) {
+	names := make([]string, 0, len(sp.Components.Schemas))
+	for name := range sp.Components.Schemas {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		s := sp.Components.Schemas[name]
+		if s.Description != "" {
+			comment(b, "", name, s.Description)
+		} else {
+			fmt.Fprintf(b, "// %s is the schema %s.\n", name, name)
+		}
+		fmt.Fprintf(b, "type %s struct {\n", name)
+
+		// The required properties in order, and then the others by name.
+		props := append([]string(nil), s.Required...)
+		required := map[string]bool{}
+		for _, p := range s.Required {
+			required[p] = true
+		}
+		var optional []string
+		for p := range s.Properties {
+			if !required[p] {
+				optional = append(optional, p)
+			}
+		}
+		sort.Strings(optional)
+		props = append(props, optional...)
+
+		for _, p := range props {
+			ps := s.Properties[p]
+			comment(b, "\t", exported(p), ps.Description)
+			tag := p
+			if !required[p] {
+				tag += ",omitempty"
+			}
+			fmt.Fprintf(b, "\t%s %s `json:%q`\n", exported(p), goType(ps), tag)
+		}
+		fmt.Fprintf(b, "}\n\n")
+	}
+}
+
+func (sp *spec) parameter(p *parameter) *parameter {
+	if p.Ref != "" {
+		return sp.Components.Parameters[refName(p.Ref)]
+	}
+	return p
+}
+
+// resultType returns the type of the response of 200.
+func (sp *spec) resultType(op *operation) string {
+	r := op.Responses["200"]
+	if r.Ref != "" {
+		r = sp.Components.Responses[refName(r.Ref)]
+	}
+	return goType(r.Content["application/json"].Schema)
+}
+
+type route struct {
+	path string
+	op   *operation
+}
+
+func (sp *spec) routes() []route {
+	var routes []route
+	for path, ops := range sp.Paths {
+		for method, op := range ops {
+			if method != "get" {
+				log.Fatalf("%s %s: only GET is supported", method, path)
+			}
+			routes = append(routes, route{path, op})
+		}
+	}
+	sort.Slice(routes, func(i, j int) bool { return routes[i].path < routes[j].path })
+	return routes
+}
+
+func (sp *spec) writeParams(b *bytes.Buffer, r route) {
+	name := exported(r.op.OperationID) + "Params"
+	fmt.Fprintf(b, "// %s is the parameters of GET %s.\n", name, r.path)
+	fmt.Fprintf(b, "type %s struct {\n", name)
+	for _, p := range r.op.Parameters {
+		p = sp.parameter(p)
+		comment(b, "\t", exported(p.Name), p.Description)
+		fmt.Fprintf(b, "\t%s %s\n", exported(p.Name), goType(p.Schema))
+	}
+	fmt.Fprintf(b, "}\n\n")
+
+	fmt.Fprintf(b, "// Parse%s parses and validates the parameters of GET %s.\n", name, r.path)
+	fmt.Fprintf(b, "func Parse%s(q url.Values) (*%s, error) {\n", name, name)
+	fmt.Fprintf(b, "\tp := &%s{}\n", name)
+	for _, p := range r.op.Parameters {
+		p = sp.parameter(p)
+		if p.In != "query" {
+			log.Fatalf("%s: only the query parameters are supported", p.Name)
+		}
+		field := "p." + exported(p.Name)
+		fmt.Fprintf(b, "\tif v := q.Get(%q); v != \"\" {\n", p.Name)
+		switch p.Schema.Type {
+		case "string":
+			if p.Schema.Format == "ipv4" {
+				fmt.Fprintf(b, "\t\tif err := validateIPv4(%q, v); err != nil {\n\t\t\treturn nil, err\n\t\t}\n", p.Name)
+			}
+			if len(p.Schema.Enum) > 0 {
+				fmt.Fprintf(b, "\t\tif err := validateEnum(%q, v, %#v); err != nil {\n\t\t\treturn nil, err\n\t\t}\n", p.Name, p.Schema.Enum)
+			}
+			fmt.Fprintf(b, "\t\t%s = v\n", field)
+		case "integer":
+			bound := func(n *int) string {
+				if n == nil {
+					return "nil"
+				}
+				return fmt.Sprintf("intPtr(%d)", *n)
+			}
+			fmt.Fprintf(b, "\t\tn, err := parseInt(%q, v, %s, %s)\n", p.Name, bound(p.Schema.Minimum), bound(p.Schema.Maximum))
+			fmt.Fprintf(b, "\t\tif err != nil {\n\t\t\treturn nil, err\n\t\t}\n")
+			fmt.Fprintf(b, "\t\t%s = n\n", field)
+		default:
+			log.Fatalf("%s: unsupported type %q of the parameter", p.Name, p.Schema.Type)
+		}
+		switch {
+		case p.Required:
+			fmt.Fprintf(b, "\t} else {\n\t\treturn nil, &ParamError{Param: %q, Message: \"is required\"}\n", p.Name)
+		case p.Schema.Default != nil:
+			fmt.Fprintf(b, "\t} else {\n\t\t%s = %#v\n", field, p.Schema.Default)
+		}
+		fmt.Fprintf(b, "\t}\n")
+	}
+	fmt.Fprintf(b, "\treturn p, nil\n}\n\n")
+}
+
+func (sp *spec) writeRouter(b *bytes.Buffer, routes []route) {
+	fmt.Fprintf(b, "// Server implements the operations of the API.\n")
+	fmt.Fprintf(b, "type Server interface {\n")
+	for _, r := range routes {
+		name := exported(r.op.OperationID)
+		if r.op.Summary != "" {
+			fmt.Fprintf(b, "\t// %s returns %s%s\n", name, strings.ToLower(r.op.Summary[:1]), r.op.Summary[1:])
+		}
+		fmt.Fprintf(b, "\t%s(ctx context.Context, p *%sParams) (%s, error)\n", name, name, sp.resultType(r.op))
+	}
+	fmt.Fprintf(b, "}\n\n")
+
+	fmt.Fprintf(b, "// NewRouter returns the handler of the operations of s.\n")
+	fmt.Fprintf(b, "func NewRouter(s Server) http.Handler {\n")
+	fmt.Fprintf(b, "\tmux := http.NewServeMux()\n")
+	for _, r := range routes {
+		name := exported(r.op.OperationID)
+		fmt.Fprintf(b, "\tmux.HandleFunc(%q, func(w http.ResponseWriter, r *http.Request) {\n", r.path)
+		fmt.Fprintf(b, "\t\tif !allowGet(w, r) {\n\t\t\treturn\n\t\t}\n")
+		fmt.Fprintf(b, "\t\tp, err := Parse%sParams(r.URL.Query())\n", name)
+		fmt.Fprintf(b, "\t\tif err != nil {\n\t\t\twriteError(w, err)\n\t\t\treturn\n\t\t}\n")
+		fmt.Fprintf(b, "\t\tres, err := s.%s(r.Context(), p)\n", name)
+		fmt.Fprintf(b, "\t\tif err != nil {\n\t\t\twriteError(w, err)\n\t\t\treturn\n\t\t}\n")
+		fmt.Fprintf(b, "\t\twriteJSON(w, http.StatusOK, res)\n")
+		fmt.Fprintf(b, "\t})\n")
+	}
+	fmt.Fprintf(b, "\treturn mux\n}\n")
+}
+
+func main() {
+	specPath := flag.String("spec", "", "path to the OpenAPI spec in JSON")
+	out := flag.String("o", "", "path to the Go file to generate")
+	pkg := flag.String("p", "api", "package name")
+	flag.Parse()
+
+	data, err := ioutil.ReadFile(*specPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+	var sp spec
+	if err := json.Unmarshal(data, &sp); err != nil {
+		log.Fatalf("could not parse %s: %v", *specPath, err)
+	}
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// Code generated by serve/api/gen from %s. DO NOT EDIT.\n\n", strings.TrimLeft(*specPath, "./"))
+	fmt.Fprintf(&b, "package %s\n\n", *pkg)
+	fmt.Fprintf(&b, "import (\n\t\"context\"\n\t\"net/http\"\n\t\"net/url\"\n)\n\n")
+	sp.writeSchemas(&b)
+	routes := sp.routes()
+	for _, r := range routes {
+		sp.writeParams(&b, r)
+	}
+	sp.writeRouter(&b, routes)
+
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		log.Fatalf("could not format the generated code: %v\n%s", err, b.Bytes())
+	}
+	if err := ioutil.WriteFile(*out, src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,233 @@
+package serve
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/xerrors"
+
+	"github.com/yuuki/shawk/db"
+)
+
+// Listener is the Store notifying the changes of the flows, such as the
+// CMDB on Postgres.
+type Listener interface {
+	Listen(ctx context.Context, fn func(*db.Change) error) error
+}
+
+// The intervals of the stream of the changes.
+var (
+	// heartbeatInterval is the interval of the comments keeping the
+	// stream alive through the proxies.
+	heartbeatInterval = 15 * time.Second
+	// relistenInterval is the wait before listening again after the
+	// connection to the CMDB is lost.
+	relistenInterval = 5 * time.Second
+)
+
+// subscriberBuffer is the number of the changes buffered for a subscriber.
+// The changes beyond it are dropped for the slow subscriber.
+const subscriberBuffer = 64
+
+// changeHub relays the changes of a Listen to the subscribers, so that the
+// subscribers share a connection to the CMDB. It listens only while any
+// subscribes.
+type changeHub struct {
+	listener Listener
+
+	mu     sync.Mutex
+	subs   map[chan *db.Change]struct{}
+	cancel context.CancelFunc
+}
+
+func newChangeHub(l Listener) *changeHub {
+	return &changeHub{listener: l, subs: map[chan *db.Change]struct{}{}}
+}
+
+// subscribe returns the channel of the changes and the function to
+// unsubscribe it.
+func (h *changeHub) subscribe() (<-chan *db.Change, func()) {
+	ch := make(chan *db.Change, subscriberBuffer)
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.subs[ch] = struct{}{}
+	if h.cancel == nil {
+		ctx, cancel := context.WithCancel(context.Background())
+		h.cancel = cancel
+		go h.listen(ctx)
+	}
+	return ch, func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		delete(h.subs, ch)
+		if len(h.subs) == 0 && h.cancel != nil {
+			h.cancel()
+			h.cancel = nil
+		}
+	}
+}
+
+// listen listens to the changes until ctx is done, listening again when
+// the connection is lost.
+func (h *changeHub) listen(ctx context.Context) {
+	for {
+		err := h.listener.Listen(ctx, h.publish)
+		if ctx.Err() != nil {
+			return
+		}
+		logger.Warningf("stopped listening to the changes, retrying in %s: %v", relistenInterval, err)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(relistenInterval):
+		}
+	}
+}
+
+func (h *changeHub) publish(c *db.Change) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for ch := range h.subs {
+		select {
+		case ch <- c:
+		default:
+			logger.Warningf("dropped the change of the flow %d for a slow subscriber", c.ID)
+		}
+	}
+	return nil
+}
+
+// parseChangeTypes parses the comma-separated types of the changes. Empty
+// is all the types.
+func parseChangeTypes(s string) (map[string]bool, error) {
+	types := map[string]bool{}
+	if s == "" {
+		for _, t := range db.ChangeTypes {
+			types[t] = true
+		}
+		return types, nil
+	}
+	for _, t := range strings.Split(s, ",") {
+		known := false
+		for _, ct := range db.ChangeTypes {
+			known = known || t == ct
+		}
+		if !known {
+			return nil, xerrors.Errorf("type must be some of %s, but %q", strings.Join(db.ChangeTypes, ", "), t)
+		}
+		types[t] = true
+	}
+	return types, nil
+}
+
+// serveChanges streams the changes as the server-sent events named by the
+// types of the changes, or as the messages of a WebSocket if the request
+// upgrades to it, until the client disconnects.
+func serveChanges(hub *changeHub) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.Header().Set("Allow", http.MethodGet)
+			writeError(w, http.StatusMethodNotAllowed, xerrors.Errorf("%s is not allowed", r.Method))
+			return
+		}
+		if hub == nil {
+			writeError(w, http.StatusNotImplemented, xerrors.New("the CMDB doesn't notify the changes"))
+			return
+		}
+		types, err := parseChangeTypes(r.URL.Query().Get("type"))
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+		if isWebSocket(r) {
+			streamWebSocket(w, r, hub, types)
+			return
+		}
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			writeError(w, http.StatusInternalServerError, xerrors.New("streaming is not supported"))
+			return
+		}
+
+		changes, unsubscribe := hub.subscribe()
+		defer unsubscribe()
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, ": listening\n\n")
+		flusher.Flush()
+
+		heartbeat := time.NewTicker(heartbeatInterval)
+		defer heartbeat.Stop()
+		for {
+			select {
+			case <-r.Context().Done():
+				return
+			case <-heartbeat.C:
+				fmt.Fprint(w, ": heartbeat\n\n")
+			case c := <-changes:
+				if !types[c.Type] {
+					continue
+				}
+				data, err := json.Marshal(c)
+				if err != nil {
+					logger.Errorf("could not encode the change: %v", err)
+					continue
+				}
+				fmt.Fprintf(w, "event: %s\ndata: %s\n\n", c.Type, data)
+			}
+			flusher.Flush()
+		}
+	}
+}
+
+// streamWebSocket streams the changes of the types as the text messages of
+// JSON of a WebSocket, pinging the client on the heartbeat, until the client
+// closes it or a write fails.
+func streamWebSocket(w http.ResponseWriter, r *http.Request, hub *changeHub, types map[string]bool) {
+	ws, err := upgradeWebSocket(w, r)
+	if err != nil {
+		return
+	}
+	defer ws.Close()
+
+	changes, unsubscribe := hub.subscribe()
+	defer unsubscribe()
+
+	closed := make(chan error, 1)
+	go func() {
+		closed <- ws.readLoop()
+	}()
+
+	heartbeat := time.NewTicker(heartbeatInterval)
+	defer heartbeat.Stop()
+	for {
+		var err error
+		select {
+		case <-closed:
+			return
+		case <-heartbeat.C:
+			err = ws.writeFrame(opPing, nil)
+		case c := <-changes:
+			if !types[c.Type] {
+				continue
+			}
+			data, merr := json.Marshal(c)
+			if merr != nil {
+				logger.Errorf("could not encode the change: %v", merr)
+				continue
+			}
+			err = ws.writeFrame(opText, data)
+		}
+		if err != nil {
+			logger.Warningf("closed the WebSocket of %s: %v", r.RemoteAddr, err)
+			return
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package serve
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yuuki/shawk/db"
+)
+
+// listenStore notifies the changes sent to it.
+type listenStore struct {
+	*fakeStore
+	changes chan *db.Change
+	stopped chan struct{}
+}
+
+func (s *listenStore) Listen(ctx context.Context, fn func(*db.Change) error) error {
+	defer func() { s.stopped <- struct{}{} }()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case c := <-s.changes:
+			if err := fn(c); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func TestChanges(t *testing.T) {
+	store := &listenStore{
+		fakeStore: &fakeStore{},
+		changes:   make(chan *db.Change),
+		stopped:   make(chan struct{}, 1),
+	}
+	ts := httptest.NewServer(NewHandler(store))
+	defer ts.Close()
+
+	resp, err := http.Get(ts.URL + "/changes?type=added,threshold")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Errorf("Content-Type = %q, want text/event-stream", ct)
+	}
+	r := bufio.NewReader(resp.Body)
+	if line, _ := r.ReadString('\n'); line != ": listening\n" {
+		t.Fatalf("the stream should start with a comment, but %q", line)
+	}
+	r.ReadString('\n')
+
+	store.changes <- &db.Change{Type: db.ChangeRemoved, ID: 1}
+	store.changes <- &db.Change{
+		Type: db.ChangeAdded, ID: 2, Connections: 3,
+		Client: &db.ChangeNode{Addr: "10.0.0.1", Process: "app"},
+		Server: &db.ChangeNode{Addr: "10.0.0.2", Port: 80, Process: "nginx"},
+	}
+	var got []string
+	for i := 0; i < 3; i++ {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, line)
+	}
+	want := "event: added\n" +
+		`data: {"type":"added","id":2,"connections":3,"client":{"addr":"10.0.0.1","process":"app"},"server":{"addr":"10.0.0.2","port":80,"process":"nginx"}}` + "\n\n"
+	if s := strings.Join(got, ""); s != want {
+		t.Errorf("the event = %q, want %q", s, want)
+	}
+
+	// The hub stops listening when the last subscriber leaves.
+	resp.Body.Close()
+	select {
+	case <-store.stopped:
+	case <-time.After(time.Second):
+		t.Error("the hub should stop listening without subscribers")
+	}
+}
+
+func TestChanges_errors(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+	listening := httptest.NewServer(NewHandler(&listenStore{fakeStore: &fakeStore{}}))
+	defer listening.Close()
+
+	tests := []struct {
+		url  string
+		code int
+	}{
+		{ts.URL + "/changes", http.StatusNotImplemented},
+		{listening.URL + "/changes?type=moved", http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		resp, err := http.Get(tt.url)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != tt.code {
+			t.Errorf("GET %s = %d, want %d", tt.url, resp.StatusCode, tt.code)
+		}
+	}
+}
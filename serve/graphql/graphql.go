@@ -0,0 +1,491 @@
+// Package graphql is a small executor of GraphQL queries over a schema
+// defined by Go functions. It supports the queries with the variables,
+// the aliases, the fragments and the @skip and @include directives, but
+// neither the mutations, the subscriptions nor the introspection.
+package graphql
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"reflect"
+	"strings"
+
+	"golang.org/x/xerrors"
+)
+
+// Object is an object type of the schema.
+type Object struct {
+	Name   string
+	Fields map[string]*Field
+}
+
+// Field is a field of an object type.
+type Field struct {
+	// Args are the arguments of the field.
+	Args []*Arg
+	// Type is the object type of the value, or of the elements if the value
+	// is a slice. It is nil if the value is a scalar.
+	Type *Object
+	// Resolve returns the value of the field of the source, which is the
+	// value of the parent object.
+	Resolve func(ctx context.Context, source interface{}, args map[string]interface{}) (interface{}, error)
+}
+
+// Arg is an argument of a field.
+type Arg struct {
+	Name string
+	// Type is String, Int, Float or Boolean, followed by '!' if required.
+	Type string
+	// Default is the value if the argument is omitted.
+	Default interface{}
+}
+
+// Schema is the schema of the queries.
+type Schema struct {
+	Query *Object
+	// MaxDepth is the maximum depth of the selections of a query, and
+	// MaxFields is the maximum number of the fields selected by a query
+	// with its fragments expanded. The queries beyond them are rejected
+	// before resolving any field. Zero doesn't limit them.
+	MaxDepth  int
+	MaxFields int
+}
+
+// Request is a request of GraphQL over HTTP.
+type Request struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName,omitempty"`
+	Variables     map[string]interface{} `json:"variables,omitempty"`
+}
+
+// Error is an error of the response.
+type Error struct {
+	Message string        `json:"message"`
+	Path    []interface{} `json:"path,omitempty"`
+}
+
+// Response is the result of a query.
+type Response struct {
+	Data   interface{} `json:"data,omitempty"`
+	Errors []*Error    `json:"errors,omitempty"`
+}
+
+// orderedMap is an object of the response, which keeps the order of the
+// fields of the query.
+type orderedMap struct {
+	keys   []string
+	values map[string]interface{}
+}
+
+func (m *orderedMap) set(k string, v interface{}) {
+	if _, ok := m.values[k]; !ok {
+		m.keys = append(m.keys, k)
+	}
+	m.values[k] = v
+}
+
+// MarshalJSON encodes the fields in order.
+func (m *orderedMap) MarshalJSON() ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteByte('{')
+	for i, k := range m.keys {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		key, _ := json.Marshal(k)
+		b.Write(key)
+		b.WriteByte(':')
+		v, err := json.Marshal(m.values[k])
+		if err != nil {
+			return nil, err
+		}
+		b.Write(v)
+	}
+	b.WriteByte('}')
+	return b.Bytes(), nil
+}
+
+type executor struct {
+	schema    *Schema
+	doc       *document
+	variables map[string]interface{}
+	errors    []*Error
+}
+
+// Do executes the query of req.
+func (s *Schema) Do(ctx context.Context, req *Request) *Response {
+	doc, err := parse(req.Query)
+	if err != nil {
+		return &Response{Errors: []*Error{{Message: err.Error()}}}
+	}
+	op, err := doc.operation(req.OperationName)
+	if err != nil {
+		return &Response{Errors: []*Error{{Message: err.Error()}}}
+	}
+	if op.kind != "query" {
+		return &Response{Errors: []*Error{{Message: op.kind + " is not supported"}}}
+	}
+	vars, err := coerceVariables(op.vars, req.Variables)
+	if err != nil {
+		return &Response{Errors: []*Error{{Message: err.Error()}}}
+	}
+	if err := s.checkComplexity(doc, op); err != nil {
+		return &Response{Errors: []*Error{{Message: err.Error()}}}
+	}
+
+	e := &executor{schema: s, doc: doc, variables: vars}
+	data := e.selectionSet(ctx, s.Query, op.selections, nil, nil)
+	return &Response{Data: data, Errors: e.errors}
+}
+
+func (d *document) operation(name string) (*operation, error) {
+	if name == "" {
+		if len(d.operations) > 1 {
+			return nil, xerrors.New("operationName is required for the document of multiple operations")
+		}
+		return d.operations[0], nil
+	}
+	for _, op := range d.operations {
+		if op.name == name {
+			return op, nil
+		}
+	}
+	return nil, xerrors.Errorf("unknown operation %q", name)
+}
+
+// complexity is the depth and the number of the fields of selections.
+type complexity struct {
+	depth  int
+	fields int
+}
+
+// maxComplexity caps the numbers of the fields, which grow exponentially by
+// the fragments spread several times each.
+const maxComplexity = 1 << 30
+
+func (c *complexity) add(o complexity) {
+	if o.depth > c.depth {
+		c.depth = o.depth
+	}
+	c.fields += o.fields
+	if c.fields > maxComplexity {
+		c.fields = maxComplexity
+	}
+}
+
+// measurer measures the selections of a document, measuring each fragment
+// once.
+type measurer struct {
+	doc       *document
+	fragments map[string]complexity
+	visiting  map[string]bool
+}
+
+func (m *measurer) measure(sels []selection) (complexity, error) {
+	var c complexity
+	for _, s := range sels {
+		switch s := s.(type) {
+		case *field:
+			sub, err := m.measure(s.selections)
+			if err != nil {
+				return c, err
+			}
+			sub.depth++
+			sub.fields++
+			c.add(sub)
+		case *fragmentSpread:
+			sub, err := m.fragment(s.name)
+			if err != nil {
+				return c, err
+			}
+			c.add(sub)
+		case *inlineFragment:
+			sub, err := m.measure(s.selections)
+			if err != nil {
+				return c, err
+			}
+			c.add(sub)
+		}
+	}
+	return c, nil
+}
+
+func (m *measurer) fragment(name string) (complexity, error) {
+	if c, ok := m.fragments[name]; ok {
+		return c, nil
+	}
+	f, ok := m.doc.fragments[name]
+	if !ok {
+		return complexity{}, nil
+	}
+	if m.visiting[name] {
+		return complexity{}, xerrors.Errorf("fragment %s spreads itself", name)
+	}
+	m.visiting[name] = true
+	c, err := m.measure(f.selections)
+	if err != nil {
+		return c, err
+	}
+	delete(m.visiting, name)
+	m.fragments[name] = c
+	return c, nil
+}
+
+// checkComplexity returns an error if the selections of op are beyond the
+// limits of the schema, or a fragment spreads itself. The directives are
+// not evaluated, so the fields skipped are counted as well.
+func (s *Schema) checkComplexity(doc *document, op *operation) error {
+	m := &measurer{doc: doc, fragments: map[string]complexity{}, visiting: map[string]bool{}}
+	c, err := m.measure(op.selections)
+	if err != nil {
+		return err
+	}
+	if s.MaxDepth > 0 && c.depth > s.MaxDepth {
+		return xerrors.Errorf("the query is %d fields deep, which exceeds the maximum depth %d", c.depth, s.MaxDepth)
+	}
+	if s.MaxFields > 0 && c.fields > s.MaxFields {
+		return xerrors.Errorf("the query selects %d fields, which exceeds the maximum %d", c.fields, s.MaxFields)
+	}
+	return nil
+}
+
+func coerceVariables(defs []*varDef, values map[string]interface{}) (map[string]interface{}, error) {
+	vars := make(map[string]interface{}, len(defs))
+	for _, d := range defs {
+		v, ok := values[d.name]
+		if !ok {
+			v = d.defValue
+		}
+		c, err := coerce(v, d.typ)
+		if err != nil {
+			return nil, xerrors.Errorf("variable $%s: %w", d.name, err)
+		}
+		vars[d.name] = c
+	}
+	return vars, nil
+}
+
+// coerce converts the value of the literal or the JSON into the type.
+func coerce(v interface{}, typ string) (interface{}, error) {
+	required := strings.HasSuffix(typ, "!")
+	typ = strings.TrimSuffix(typ, "!")
+	if v == nil {
+		if required {
+			return nil, xerrors.Errorf("%s! must not be null", typ)
+		}
+		return nil, nil
+	}
+	if strings.HasPrefix(typ, "[") {
+		elem := strings.TrimSuffix(strings.TrimPrefix(typ, "["), "]")
+		list, ok := v.([]interface{})
+		if !ok {
+			list = []interface{}{v}
+		}
+		out := make([]interface{}, 0, len(list))
+		for _, e := range list {
+			c, err := coerce(e, elem)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, c)
+		}
+		return out, nil
+	}
+	switch typ {
+	case "String", "ID":
+		if s, ok := v.(string); ok {
+			return s, nil
+		}
+	case "Int":
+		switch n := v.(type) {
+		case int:
+			return n, nil
+		case int64:
+			return int(n), nil
+		case float64:
+			// JSON numbers of the variables
+			if n == float64(int(n)) {
+				return int(n), nil
+			}
+		}
+	case "Float":
+		switch n := v.(type) {
+		case int:
+			return float64(n), nil
+		case int64:
+			return float64(n), nil
+		case float64:
+			return n, nil
+		}
+	case "Boolean":
+		if b, ok := v.(bool); ok {
+			return b, nil
+		}
+	default:
+		return nil, xerrors.Errorf("unknown type %s", typ)
+	}
+	return nil, xerrors.Errorf("%v is not %s", v, typ)
+}
+
+// resolveValue replaces the variables in the value.
+func (e *executor) resolveValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case variable:
+		return e.variables[string(v)]
+	case enumValue:
+		return string(v)
+	case []interface{}:
+		out := make([]interface{}, 0, len(v))
+		for _, x := range v {
+			out = append(out, e.resolveValue(x))
+		}
+		return out
+	case *objectValue:
+		out := make(map[string]interface{}, len(v.fields))
+		for _, f := range v.fields {
+			out[f.name] = e.resolveValue(f.value)
+		}
+		return out
+	}
+	return v
+}
+
+func (e *executor) args(defs []*Arg, args []*argument) (map[string]interface{}, error) {
+	given := make(map[string]interface{}, len(args))
+	for _, a := range args {
+		given[a.name] = e.resolveValue(a.value)
+	}
+	out := make(map[string]interface{}, len(defs))
+	for _, d := range defs {
+		v, ok := given[d.Name]
+		if !ok || v == nil {
+			v = d.Default
+		}
+		c, err := coerce(v, d.Type)
+		if err != nil {
+			return nil, xerrors.Errorf("argument %s: %w", d.Name, err)
+		}
+		out[d.Name] = c
+		delete(given, d.Name)
+	}
+	for name := range given {
+		return nil, xerrors.Errorf("unknown argument %s", name)
+	}
+	return out, nil
+}
+
+// included evaluates @skip and @include.
+func (e *executor) included(dirs []*directive) bool {
+	for _, d := range dirs {
+		if len(d.args) != 1 || d.args[0].name != "if" {
+			continue
+		}
+		cond, _ := e.resolveValue(d.args[0].value).(bool)
+		switch d.name {
+		case "skip":
+			if cond {
+				return false
+			}
+		case "include":
+			if !cond {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// collect flattens the fragments of the selections on the object.
+func (e *executor) collect(obj *Object, sels []selection, fields []*field) []*field {
+	for _, s := range sels {
+		switch s := s.(type) {
+		case *field:
+			if e.included(s.directives) {
+				fields = append(fields, s)
+			}
+		case *fragmentSpread:
+			f, ok := e.doc.fragments[s.name]
+			if ok && e.included(s.directives) && f.typeCond == obj.Name {
+				fields = e.collect(obj, f.selections, fields)
+			}
+		case *inlineFragment:
+			if e.included(s.directives) && (s.typeCond == "" || s.typeCond == obj.Name) {
+				fields = e.collect(obj, s.selections, fields)
+			}
+		}
+	}
+	return fields
+}
+
+func (e *executor) fail(path []interface{}, err error) {
+	e.errors = append(e.errors, &Error{
+		Message: err.Error(),
+		Path:    append([]interface{}(nil), path...),
+	})
+}
+
+func (e *executor) selectionSet(ctx context.Context, obj *Object, sels []selection, source interface{}, path []interface{}) *orderedMap {
+	m := &orderedMap{values: map[string]interface{}{}}
+	for _, f := range e.collect(obj, sels, nil) {
+		key := f.name
+		if f.alias != "" {
+			key = f.alias
+		}
+		fpath := append(path, key)
+		if f.name == "__typename" {
+			m.set(key, obj.Name)
+			continue
+		}
+		def, ok := obj.Fields[f.name]
+		if !ok {
+			e.fail(fpath, xerrors.Errorf("unknown field %s of %s", f.name, obj.Name))
+			m.set(key, nil)
+			continue
+		}
+		args, err := e.args(def.Args, f.args)
+		if err != nil {
+			e.fail(fpath, err)
+			m.set(key, nil)
+			continue
+		}
+		v, err := def.Resolve(ctx, source, args)
+		if err != nil {
+			e.fail(fpath, err)
+			m.set(key, nil)
+			continue
+		}
+		m.set(key, e.complete(ctx, def.Type, f, v, fpath))
+	}
+	return m
+}
+
+// complete completes the value of the field by the selections.
+func (e *executor) complete(ctx context.Context, obj *Object, f *field, v interface{}, path []interface{}) interface{} {
+	if v == nil {
+		return nil
+	}
+	rv := reflect.ValueOf(v)
+	if (rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Map) && rv.IsNil() {
+		return nil
+	}
+	if obj == nil {
+		if len(f.selections) > 0 {
+			e.fail(path, xerrors.Errorf("field %s of a scalar must not have a selection", f.name))
+			return nil
+		}
+		return v
+	}
+	if len(f.selections) == 0 {
+		e.fail(path, xerrors.Errorf("field %s of %s must have a selection", f.name, obj.Name))
+		return nil
+	}
+	if rv.Kind() == reflect.Slice {
+		list := make([]interface{}, 0, rv.Len())
+		for i := 0; i < rv.Len(); i++ {
+			list = append(list, e.complete(ctx, obj, f, rv.Index(i).Interface(), append(path, i)))
+		}
+		return list
+	}
+	return e.selectionSet(ctx, obj, f.selections, v, path)
+}
@@ -0,0 +1,172 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"golang.org/x/xerrors"
+)
+
+type testHost struct {
+	Name  string
+	Peers []*testHost
+}
+
+func testSchema() *Schema {
+	db := &testHost{Name: "db"}
+	web := &testHost{Name: "web", Peers: []*testHost{db}}
+	hosts := map[string]*testHost{"db": db, "web": web}
+
+	host := &Object{Name: "Host", Fields: map[string]*Field{}}
+	host.Fields["name"] = &Field{Resolve: func(ctx context.Context, source interface{}, args map[string]interface{}) (interface{}, error) {
+		return source.(*testHost).Name, nil
+	}}
+	host.Fields["peers"] = &Field{
+		Type: host,
+		Args: []*Arg{{Name: "limit", Type: "Int", Default: int64(10)}},
+		Resolve: func(ctx context.Context, source interface{}, args map[string]interface{}) (interface{}, error) {
+			peers := source.(*testHost).Peers
+			if n := args["limit"].(int); n < len(peers) {
+				peers = peers[:n]
+			}
+			return peers, nil
+		},
+	}
+	return &Schema{Query: &Object{Name: "Query", Fields: map[string]*Field{
+		"host": {
+			Type: host,
+			Args: []*Arg{{Name: "name", Type: "String!"}},
+			Resolve: func(ctx context.Context, source interface{}, args map[string]interface{}) (interface{}, error) {
+				h, ok := hosts[args["name"].(string)]
+				if !ok {
+					return (*testHost)(nil), nil
+				}
+				return h, nil
+			},
+		},
+		"fail": {Resolve: func(ctx context.Context, source interface{}, args map[string]interface{}) (interface{}, error) {
+			return nil, xerrors.New("failed")
+		}},
+	}}}
+}
+
+func TestSchema_Do(t *testing.T) {
+	tests := []struct {
+		desc string
+		req  Request
+		want string
+	}{
+		{
+			desc: "nested fields",
+			req:  Request{Query: `{ host(name: "web") { name peers { name } } }`},
+			want: `{"data":{"host":{"name":"web","peers":[{"name":"db"}]}}}`,
+		},
+		{
+			desc: "variables, aliases and __typename",
+			req: Request{
+				Query:     `query Q($n: String!, $limit: Int = 0) { h: host(name: $n) { __typename name peers(limit: $limit) { name } } }`,
+				Variables: map[string]interface{}{"n": "web"},
+			},
+			want: `{"data":{"h":{"__typename":"Host","name":"web","peers":[]}}}`,
+		},
+		{
+			desc: "fragments and directives",
+			req: Request{
+				Query: `query { host(name: "web") { ...f ... on Host { peers @skip(if: true) { name } } } }
+					fragment f on Host { name }`,
+			},
+			want: `{"data":{"host":{"name":"web"}}}`,
+		},
+		{
+			desc: "null object",
+			req:  Request{Query: `{ host(name: "none") { name } }`},
+			want: `{"data":{"host":null}}`,
+		},
+		{
+			desc: "field error",
+			req:  Request{Query: `{ fail host(name: "db") { name } }`},
+			want: `{"data":{"fail":null,"host":{"name":"db"}},"errors":[{"message":"failed","path":["fail"]}]}`,
+		},
+		{
+			desc: "missing required argument",
+			req:  Request{Query: `{ host { name } }`},
+			want: `{"data":{"host":null},"errors":[{"message":"argument name: String! must not be null","path":["host"]}]}`,
+		},
+		{
+			desc: "unknown field",
+			req:  Request{Query: `{ host(name: "db") { addr } }`},
+			want: `{"data":{"host":{"addr":null}},"errors":[{"message":"unknown field addr of Host","path":["host","addr"]}]}`,
+		},
+		{
+			desc: "syntax error",
+			req:  Request{Query: `{ host(name: "db") { name }`},
+			want: `{"errors":[{"message":"syntax error at 27: unexpected end of the query"}]}`,
+		},
+		{
+			desc: "mutation",
+			req:  Request{Query: `mutation { host(name: "db") { name } }`},
+			want: `{"errors":[{"message":"mutation is not supported"}]}`,
+		},
+		{
+			desc: "cyclic fragments",
+			req: Request{Query: `{ host(name: "web") { ...f } }
+				fragment f on Host { peers { ...g } }
+				fragment g on Host { ...f }`},
+			want: `{"errors":[{"message":"fragment f spreads itself"}]}`,
+		},
+		{
+			desc: "within the limits",
+			req:  Request{Query: `{ host(name: "web") { peers { peers { name } } } }`},
+			want: `{"data":{"host":{"peers":[{"peers":[]}]}}}`,
+		},
+		{
+			desc: "too deep",
+			req:  Request{Query: `{ host(name: "web") { ...f } } fragment f on Host { peers { peers { peers { name } } } }`},
+			want: `{"errors":[{"message":"the query is 5 fields deep, which exceeds the maximum depth 4"}]}`,
+		},
+		{
+			desc: "too many fields",
+			req:  Request{Query: `{ a: host(name: "web") { ...f } b: host(name: "db") { ...f } } fragment f on Host { name n: name peers { name } }`},
+			want: `{"errors":[{"message":"the query selects 10 fields, which exceeds the maximum 8"}]}`,
+		},
+	}
+	s := testSchema()
+	s.MaxDepth = 4
+	s.MaxFields = 8
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			b, err := json.Marshal(s.Do(context.Background(), &tt.req))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := string(b); got != tt.want {
+				t.Errorf("Do() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLex(t *testing.T) {
+	tokens, err := lex(`{ a(s: "x\"é", n: -1.5e3) # comment
+	...f }`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, tk := range tokens[:len(tokens)-1] {
+		got = append(got, tk.value)
+	}
+	want := []string{"{", "a", "(", "s", ":", "x\"é", "n", ":", "-1.5e3", ")", "...", "f", "}"}
+	if len(got) != len(want) {
+		t.Fatalf("lex() = %q, want %q", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("lex()[%d] = %q, want %q", i, got[i], want[i])
+		}
+	}
+	if _, err := lex(`{ a(s: "unterminated) }`); err == nil {
+		t.Error("lex() should return an error for an unterminated string")
+	}
+}
@@ -0,0 +1,143 @@
+package graphql
+
+import (
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/xerrors"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenPunct
+	tokenName
+	tokenInt
+	tokenFloat
+	tokenString
+)
+
+type token struct {
+	kind  tokenKind
+	value string
+	pos   int
+}
+
+// lex splits the query into the tokens. The commas and the comments are
+// ignored as the spec of GraphQL.
+func lex(src string) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(src); {
+		c := src[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == ',':
+			i++
+		case c == '#':
+			for i < len(src) && src[i] != '\n' {
+				i++
+			}
+		case strings.HasPrefix(src[i:], "..."):
+			tokens = append(tokens, token{tokenPunct, "...", i})
+			i += 3
+		case strings.IndexByte("!$():=@[]{}|", c) >= 0:
+			tokens = append(tokens, token{tokenPunct, string(c), i})
+			i++
+		case c == '_' || isLetter(c):
+			start := i
+			for i < len(src) && (src[i] == '_' || isLetter(src[i]) || isDigit(src[i])) {
+				i++
+			}
+			tokens = append(tokens, token{tokenName, src[start:i], start})
+		case c == '-' || isDigit(c):
+			start := i
+			kind := tokenInt
+			i++
+			for i < len(src) && isDigit(src[i]) {
+				i++
+			}
+			if i < len(src) && src[i] == '.' {
+				kind = tokenFloat
+				i++
+				for i < len(src) && isDigit(src[i]) {
+					i++
+				}
+			}
+			if i < len(src) && (src[i] == 'e' || src[i] == 'E') {
+				kind = tokenFloat
+				i++
+				if i < len(src) && (src[i] == '+' || src[i] == '-') {
+					i++
+				}
+				for i < len(src) && isDigit(src[i]) {
+					i++
+				}
+			}
+			tokens = append(tokens, token{kind, src[start:i], start})
+		case c == '"':
+			s, n, err := lexString(src[i:])
+			if err != nil {
+				return nil, xerrors.Errorf("syntax error at %d: %w", i, err)
+			}
+			tokens = append(tokens, token{tokenString, s, i})
+			i += n
+		default:
+			r, _ := utf8.DecodeRuneInString(src[i:])
+			return nil, xerrors.Errorf("syntax error at %d: unexpected character %q", i, r)
+		}
+	}
+	return append(tokens, token{tokenEOF, "", len(src)}), nil
+}
+
+// lexString reads the string starting with '"', and returns the value and
+// the length read. Block strings are not supported.
+func lexString(src string) (string, int, error) {
+	var b strings.Builder
+	for i := 1; i < len(src); i++ {
+		switch c := src[i]; c {
+		case '"':
+			return b.String(), i + 1, nil
+		case '\n', '\r':
+			return "", 0, xerrors.New("unterminated string")
+		case '\\':
+			i++
+			if i >= len(src) {
+				return "", 0, xerrors.New("unterminated string")
+			}
+			switch e := src[i]; e {
+			case '"', '\\', '/':
+				b.WriteByte(e)
+			case 'b':
+				b.WriteByte('\b')
+			case 'f':
+				b.WriteByte('\f')
+			case 'n':
+				b.WriteByte('\n')
+			case 'r':
+				b.WriteByte('\r')
+			case 't':
+				b.WriteByte('\t')
+			case 'u':
+				if i+5 > len(src) {
+					return "", 0, xerrors.New("invalid unicode escape")
+				}
+				r, err := strconv.ParseUint(src[i+1:i+5], 16, 32)
+				if err != nil {
+					return "", 0, xerrors.New("invalid unicode escape")
+				}
+				b.WriteRune(rune(r))
+				i += 4
+			default:
+				return "", 0, xerrors.Errorf("invalid escape \\%c", e)
+			}
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return "", 0, xerrors.New("unterminated string")
+}
+
+func isLetter(c byte) bool { return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') }
+
+func isDigit(c byte) bool { return c >= '0' && c <= '9' }
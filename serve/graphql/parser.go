@@ -0,0 +1,431 @@
+package graphql
+
+import (
+	"strconv"
+
+	"golang.org/x/xerrors"
+)
+
+// document is a parsed query.
+type document struct {
+	operations []*operation
+	fragments  map[string]*fragment
+}
+
+type operation struct {
+	kind       string // query, mutation or subscription
+	name       string
+	vars       []*varDef
+	selections []selection
+}
+
+type varDef struct {
+	name     string
+	typ      string // such as "Int!"
+	defValue interface{}
+}
+
+// selection is a *field, a *fragmentSpread or an *inlineFragment.
+type selection interface{}
+
+type field struct {
+	alias      string
+	name       string
+	args       []*argument
+	directives []*directive
+	selections []selection
+}
+
+type argument struct {
+	name  string
+	value interface{}
+}
+
+type directive struct {
+	name string
+	args []*argument
+}
+
+type fragment struct {
+	typeCond   string
+	selections []selection
+}
+
+type fragmentSpread struct {
+	name       string
+	directives []*directive
+}
+
+type inlineFragment struct {
+	typeCond   string
+	directives []*directive
+	selections []selection
+}
+
+// The values other than the literals of Go: nil, bool, int64, float64,
+// string, []interface{} and *objectValue.
+type (
+	variable  string
+	enumValue string
+)
+
+type objectValue struct {
+	fields []*argument
+}
+
+type parser struct {
+	tokens []token
+	i      int
+}
+
+func parse(src string) (*document, error) {
+	tokens, err := lex(src)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens}
+	doc := &document{fragments: map[string]*fragment{}}
+	for p.peek().kind != tokenEOF {
+		switch t := p.peek(); {
+		case t.kind == tokenPunct && t.value == "{":
+			sels, err := p.selectionSet()
+			if err != nil {
+				return nil, err
+			}
+			doc.operations = append(doc.operations, &operation{kind: "query", selections: sels})
+		case t.kind == tokenName && t.value == "fragment":
+			p.next()
+			name, err := p.name()
+			if err != nil {
+				return nil, err
+			}
+			f := &fragment{}
+			if err := p.keyword("on"); err != nil {
+				return nil, err
+			}
+			if f.typeCond, err = p.name(); err != nil {
+				return nil, err
+			}
+			if f.selections, err = p.selectionSet(); err != nil {
+				return nil, err
+			}
+			doc.fragments[name] = f
+		case t.kind == tokenName:
+			op, err := p.operation()
+			if err != nil {
+				return nil, err
+			}
+			doc.operations = append(doc.operations, op)
+		default:
+			return nil, p.unexpected()
+		}
+	}
+	if len(doc.operations) == 0 {
+		return nil, xerrors.New("the document has no operation")
+	}
+	return doc, nil
+}
+
+func (p *parser) peek() token { return p.tokens[p.i] }
+
+func (p *parser) next() token {
+	t := p.tokens[p.i]
+	if t.kind != tokenEOF {
+		p.i++
+	}
+	return t
+}
+
+func (p *parser) unexpected() error {
+	t := p.peek()
+	if t.kind == tokenEOF {
+		return xerrors.Errorf("syntax error at %d: unexpected end of the query", t.pos)
+	}
+	return xerrors.Errorf("syntax error at %d: unexpected %q", t.pos, t.value)
+}
+
+// skip consumes the punctuator if it is next.
+func (p *parser) skip(punct string) bool {
+	if t := p.peek(); t.kind == tokenPunct && t.value == punct {
+		p.i++
+		return true
+	}
+	return false
+}
+
+func (p *parser) expect(punct string) error {
+	if !p.skip(punct) {
+		return p.unexpected()
+	}
+	return nil
+}
+
+func (p *parser) name() (string, error) {
+	if t := p.peek(); t.kind == tokenName {
+		p.i++
+		return t.value, nil
+	}
+	return "", p.unexpected()
+}
+
+func (p *parser) keyword(kw string) error {
+	if t := p.peek(); t.kind == tokenName && t.value == kw {
+		p.i++
+		return nil
+	}
+	return p.unexpected()
+}
+
+func (p *parser) operation() (*operation, error) {
+	kind, err := p.name()
+	if err != nil {
+		return nil, err
+	}
+	switch kind {
+	case "query", "mutation", "subscription":
+	default:
+		p.i--
+		return nil, p.unexpected()
+	}
+	op := &operation{kind: kind}
+	if p.peek().kind == tokenName {
+		op.name, _ = p.name()
+	}
+	if p.skip("(") {
+		for !p.skip(")") {
+			if err := p.expect("$"); err != nil {
+				return nil, err
+			}
+			v := &varDef{}
+			if v.name, err = p.name(); err != nil {
+				return nil, err
+			}
+			if err := p.expect(":"); err != nil {
+				return nil, err
+			}
+			if v.typ, err = p.typeRef(); err != nil {
+				return nil, err
+			}
+			if p.skip("=") {
+				if v.defValue, err = p.value(true); err != nil {
+					return nil, err
+				}
+			}
+			op.vars = append(op.vars, v)
+		}
+	}
+	if _, err := p.directives(); err != nil {
+		return nil, err
+	}
+	if op.selections, err = p.selectionSet(); err != nil {
+		return nil, err
+	}
+	return op, nil
+}
+
+func (p *parser) typeRef() (string, error) {
+	var typ string
+	if p.skip("[") {
+		elem, err := p.typeRef()
+		if err != nil {
+			return "", err
+		}
+		if err := p.expect("]"); err != nil {
+			return "", err
+		}
+		typ = "[" + elem + "]"
+	} else {
+		name, err := p.name()
+		if err != nil {
+			return "", err
+		}
+		typ = name
+	}
+	if p.skip("!") {
+		typ += "!"
+	}
+	return typ, nil
+}
+
+func (p *parser) selectionSet() ([]selection, error) {
+	if err := p.expect("{"); err != nil {
+		return nil, err
+	}
+	var sels []selection
+	for !p.skip("}") {
+		if p.skip("...") {
+			if t := p.peek(); t.kind == tokenName && t.value != "on" {
+				name, _ := p.name()
+				dirs, err := p.directives()
+				if err != nil {
+					return nil, err
+				}
+				sels = append(sels, &fragmentSpread{name: name, directives: dirs})
+				continue
+			}
+			f := &inlineFragment{}
+			var err error
+			if p.keyword("on") == nil {
+				if f.typeCond, err = p.name(); err != nil {
+					return nil, err
+				}
+			}
+			if f.directives, err = p.directives(); err != nil {
+				return nil, err
+			}
+			if f.selections, err = p.selectionSet(); err != nil {
+				return nil, err
+			}
+			sels = append(sels, f)
+			continue
+		}
+		f, err := p.field()
+		if err != nil {
+			return nil, err
+		}
+		sels = append(sels, f)
+	}
+	if len(sels) == 0 {
+		return nil, xerrors.New("syntax error: empty selection set")
+	}
+	return sels, nil
+}
+
+func (p *parser) field() (*field, error) {
+	name, err := p.name()
+	if err != nil {
+		return nil, err
+	}
+	f := &field{name: name}
+	if p.skip(":") {
+		f.alias = name
+		if f.name, err = p.name(); err != nil {
+			return nil, err
+		}
+	}
+	if f.args, err = p.arguments(false); err != nil {
+		return nil, err
+	}
+	if f.directives, err = p.directives(); err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind == tokenPunct && t.value == "{" {
+		if f.selections, err = p.selectionSet(); err != nil {
+			return nil, err
+		}
+	}
+	return f, nil
+}
+
+func (p *parser) arguments(constant bool) ([]*argument, error) {
+	if !p.skip("(") {
+		return nil, nil
+	}
+	var args []*argument
+	for !p.skip(")") {
+		name, err := p.name()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(":"); err != nil {
+			return nil, err
+		}
+		v, err := p.value(constant)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, &argument{name: name, value: v})
+	}
+	return args, nil
+}
+
+func (p *parser) directives() ([]*directive, error) {
+	var dirs []*directive
+	for p.skip("@") {
+		name, err := p.name()
+		if err != nil {
+			return nil, err
+		}
+		args, err := p.arguments(false)
+		if err != nil {
+			return nil, err
+		}
+		dirs = append(dirs, &directive{name: name, args: args})
+	}
+	return dirs, nil
+}
+
+// value parses a value. The variables are not allowed if constant.
+func (p *parser) value(constant bool) (interface{}, error) {
+	if p.peek().kind == tokenEOF {
+		return nil, p.unexpected()
+	}
+	t := p.next()
+	switch t.kind {
+	case tokenInt:
+		n, err := strconv.ParseInt(t.value, 10, 64)
+		if err != nil {
+			return nil, xerrors.Errorf("syntax error at %d: %q is not an Int", t.pos, t.value)
+		}
+		return n, nil
+	case tokenFloat:
+		f, err := strconv.ParseFloat(t.value, 64)
+		if err != nil {
+			return nil, xerrors.Errorf("syntax error at %d: %q is not a Float", t.pos, t.value)
+		}
+		return f, nil
+	case tokenString:
+		return t.value, nil
+	case tokenName:
+		switch t.value {
+		case "true":
+			return true, nil
+		case "false":
+			return false, nil
+		case "null":
+			return nil, nil
+		}
+		return enumValue(t.value), nil
+	case tokenPunct:
+		switch t.value {
+		case "$":
+			if constant {
+				break
+			}
+			name, err := p.name()
+			if err != nil {
+				return nil, err
+			}
+			return variable(name), nil
+		case "[":
+			list := []interface{}{}
+			for !p.skip("]") {
+				v, err := p.value(constant)
+				if err != nil {
+					return nil, err
+				}
+				list = append(list, v)
+			}
+			return list, nil
+		case "{":
+			obj := &objectValue{}
+			for !p.skip("}") {
+				name, err := p.name()
+				if err != nil {
+					return nil, err
+				}
+				if err := p.expect(":"); err != nil {
+					return nil, err
+				}
+				v, err := p.value(constant)
+				if err != nil {
+					return nil, err
+				}
+				obj.fields = append(obj.fields, &argument{name: name, value: v})
+			}
+			return obj, nil
+		}
+	}
+	p.i--
+	return nil, p.unexpected()
+}
@@ -0,0 +1,115 @@
+package serve
+
+import (
+	"compress/gzip"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"golang.org/x/xerrors"
+)
+
+// acceptsGzip returns whether the client of r accepts the responses
+// compressed by gzip, that is, Accept-Encoding has gzip or * without q=0.
+func acceptsGzip(r *http.Request) bool {
+	for _, v := range r.Header["Accept-Encoding"] {
+		for _, t := range strings.Split(v, ",") {
+			params := strings.Split(t, ";")
+			coding := strings.TrimSpace(params[0])
+			if !strings.EqualFold(coding, "gzip") && coding != "*" {
+				continue
+			}
+			accepted := true
+			for _, p := range params[1:] {
+				p = strings.TrimSpace(p)
+				if len(p) > 2 && strings.EqualFold(p[:2], "q=") {
+					q, err := strconv.ParseFloat(p[2:], 64)
+					accepted = err == nil && q > 0
+				}
+			}
+			return accepted
+		}
+	}
+	return false
+}
+
+// gzipResponseWriter compresses the body of the response by gzip, except the
+// responses of no body.
+type gzipResponseWriter struct {
+	http.ResponseWriter
+	gz          *gzip.Writer
+	wroteHeader bool
+	compress    bool
+}
+
+func (w *gzipResponseWriter) WriteHeader(code int) {
+	if w.wroteHeader {
+		return
+	}
+	w.wroteHeader = true
+	if code >= http.StatusOK && code != http.StatusNoContent && code != http.StatusNotModified {
+		w.compress = true
+		w.Header().Set("Content-Encoding", "gzip")
+		w.Header().Del("Content-Length")
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *gzipResponseWriter) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	if !w.compress {
+		return w.ResponseWriter.Write(b)
+	}
+	if w.gz == nil {
+		w.gz = gzip.NewWriter(w.ResponseWriter)
+	}
+	return w.gz.Write(b)
+}
+
+func (w *gzipResponseWriter) close() error {
+	if w.gz == nil {
+		return nil
+	}
+	return w.gz.Close()
+}
+
+// gzipHandler negotiates the compression of h by gzip, since the JSON of the
+// flows compresses well. It decompresses the body of a request of
+// Content-Encoding gzip, and compresses the response if the client accepts
+// it. The streams are not to be wrapped, since they are never flushed.
+func gzipHandler(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch coding := strings.TrimSpace(r.Header.Get("Content-Encoding")); {
+		case coding == "", strings.EqualFold(coding, "identity"):
+		case strings.EqualFold(coding, "gzip"):
+			gr, err := gzip.NewReader(r.Body)
+			if err != nil {
+				writeError(w, http.StatusBadRequest, xerrors.Errorf("invalid gzip body: %w", err))
+				return
+			}
+			defer gr.Close()
+			r.Body = gr
+			r.Header.Del("Content-Encoding")
+			r.ContentLength = -1
+		default:
+			w.Header().Set("Accept-Encoding", "gzip")
+			writeError(w, http.StatusUnsupportedMediaType, xerrors.Errorf("Content-Encoding %q is not supported", coding))
+			return
+		}
+
+		w.Header().Add("Vary", "Accept-Encoding")
+		if !acceptsGzip(r) {
+			h.ServeHTTP(w, r)
+			return
+		}
+		gw := &gzipResponseWriter{ResponseWriter: w}
+		defer func() {
+			if err := gw.close(); err != nil {
+				logger.Errorf("could not write the response: %v", err)
+			}
+		}()
+		h.ServeHTTP(gw, r)
+	})
+}
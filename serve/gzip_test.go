@@ -0,0 +1,102 @@
+package serve
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestAcceptsGzip(t *testing.T) {
+	tests := []struct {
+		header string
+		want   bool
+	}{
+		{"", false},
+		{"gzip", true},
+		{"deflate, gzip;q=0.5", true},
+		{"*", true},
+		{"gzip;q=0", false},
+		{"br", false},
+	}
+	for _, tt := range tests {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		if tt.header != "" {
+			r.Header.Set("Accept-Encoding", tt.header)
+		}
+		if got := acceptsGzip(r); got != tt.want {
+			t.Errorf("acceptsGzip(%q) = %v, want %v", tt.header, got, tt.want)
+		}
+	}
+}
+
+func TestGzipHandler(t *testing.T) {
+	h := NewHandler(&fakeStore{})
+
+	t.Run("compressed response", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/graphql/schema", nil)
+		r.Header.Set("Accept-Encoding", "gzip")
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+
+		if got := w.Header().Get("Content-Encoding"); got != "gzip" {
+			t.Fatalf("Content-Encoding = %q, want gzip", got)
+		}
+		gr, err := gzip.NewReader(w.Body)
+		if err != nil {
+			t.Fatalf("the response should be gzip: %v", err)
+		}
+		body, err := ioutil.ReadAll(gr)
+		if err != nil {
+			t.Fatalf("could not decompress the response: %v", err)
+		}
+		if string(body) != SchemaSDL {
+			t.Errorf("the decompressed response should be the schema, but %q", body)
+		}
+	})
+
+	t.Run("identity response", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/graphql/schema", nil)
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+
+		if got := w.Header().Get("Content-Encoding"); got != "" {
+			t.Errorf("Content-Encoding = %q, want none", got)
+		}
+		if w.Body.String() != SchemaSDL {
+			t.Errorf("the response should be the schema, but %q", w.Body.String())
+		}
+	})
+
+	t.Run("compressed request", func(t *testing.T) {
+		var body bytes.Buffer
+		gw := gzip.NewWriter(&body)
+		gw.Write([]byte(`{"query": "{ dependencies(addr: \"10.0.0.1\") { connections } }"}`))
+		gw.Close()
+		r := httptest.NewRequest(http.MethodPost, "/graphql", &body)
+		r.Header.Set("Content-Encoding", "gzip")
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+
+		if w.Code != http.StatusOK {
+			t.Errorf("the status should be 200, but %d: %s", w.Code, w.Body.String())
+		}
+	})
+
+	t.Run("unsupported request", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader("{}"))
+		r.Header.Set("Content-Encoding", "br")
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+
+		if w.Code != http.StatusUnsupportedMediaType {
+			t.Errorf("the status should be 415, but %d", w.Code)
+		}
+		if got := w.Header().Get("Accept-Encoding"); got != "gzip" {
+			t.Errorf("Accept-Encoding = %q, want gzip", got)
+		}
+	})
+}
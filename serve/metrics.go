@@ -0,0 +1,87 @@
+package serve
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/yuuki/shawk/db"
+	"github.com/yuuki/shawk/graph"
+)
+
+// The intervals of the metrics of the graph.
+var (
+	// MetricsInterval is how long the metrics of the graph are reused by
+	// the scrapes, which is the interval of the edges added and removed.
+	MetricsInterval = time.Minute
+	// StaleAfter is how long a process is in the graph without its flows
+	// updated until it is stale.
+	StaleAfter = 24 * time.Hour
+)
+
+// graphCollector collects the metrics of the graph on a scrape if the last
+// ones are older than MetricsInterval, so that the scrapes of several
+// Prometheus servers don't load the flows each time.
+type graphCollector struct {
+	store db.Store
+
+	mu   sync.Mutex
+	last *graph.Metrics
+}
+
+func newGraphCollector(store db.Store) *graphCollector {
+	return &graphCollector{store: store}
+}
+
+// collect returns the metrics of the graph, collecting them if the last
+// ones are older than MetricsInterval.
+func (c *graphCollector) collect(ctx context.Context) (*graph.Metrics, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := time.Now()
+	if c.last != nil && now.Sub(c.last.Collected) < MetricsInterval {
+		return c.last, nil
+	}
+
+	g, err := c.loadGraph(ctx, &db.ListFlowsCond{Until: now})
+	if err != nil {
+		return nil, err
+	}
+	recent, err := c.loadGraph(ctx, &db.ListFlowsCond{Since: now.Add(-StaleAfter), Until: now})
+	if err != nil {
+		return nil, err
+	}
+	m := graph.NewMetrics(g, recent, c.last)
+	m.Collected = now
+	c.last = m
+	return m, nil
+}
+
+func (c *graphCollector) loadGraph(ctx context.Context, cond *db.ListFlowsCond) (*graph.Graph, error) {
+	var flows []*db.Flow
+	err := db.EachFlow(ctx, c.store, cond, func(f *db.Flow) error {
+		flows = append(flows, f)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return graph.New(flows), nil
+}
+
+// serveMetrics serves the metrics of the graph in the Prometheus text
+// format.
+func serveMetrics(c *graphCollector) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		m, err := c.collect(r.Context())
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		if err := graph.RenderMetrics(w, m); err != nil {
+			logger.Errorf("could not write the metrics: %v", err)
+		}
+	}
+}
@@ -0,0 +1,290 @@
+package serve
+
+import (
+	"context"
+	"net"
+	"sort"
+	"time"
+
+	"golang.org/x/xerrors"
+
+	"github.com/yuuki/shawk/pkg/client"
+	"github.com/yuuki/shawk/probe"
+	"github.com/yuuki/shawk/serve/api"
+	"github.com/yuuki/shawk/serve/graphql"
+)
+
+// SchemaSDL is the schema of the GraphQL API in the schema definition language.
+const SchemaSDL = `# The times of since and until are relative durations to now such as "2h",
+# or RFC 3339 timestamps. The dependencies are walked up to depth hops.
+type Query {
+  # The host of the address.
+  host(addr: String!): Host!
+  # The flows to the servers of the address, and of their servers.
+  dependencies(addr: String!, depth: Int = 1, since: String, until: String): [Flow!]!
+  # The flows from the clients of the address, and of their clients.
+  dependents(addr: String!, depth: Int = 1, since: String, until: String): [Flow!]!
+  # The shortest paths of the flows from an address to another.
+  paths(from: String!, to: String!, maxDepth: Int = 4, since: String, until: String): [Path!]!
+}
+
+type Host {
+  addr: String!
+  dependencies(depth: Int = 1, since: String, until: String): [Flow!]!
+  dependents(depth: Int = 1, since: String, until: String): [Flow!]!
+}
+
+type Node {
+  addr: String!
+  # null if the node is a client, whose ports are aggregated.
+  port: Int
+  process: String!
+  pgid: Int!
+  labels: [Label!]!
+  # null for the default VRF.
+  vrf: String
+  host: Host!
+}
+
+type Label {
+  key: String!
+  value: String!
+}
+
+type Flow {
+  client: Node!
+  server: Node!
+  connections: Int!
+  # The number of the hops from the address.
+  depth: Int!
+  # The note of the review of the edge, or null if it is not annotated.
+  annotation: String
+}
+
+type Path {
+  length: Int!
+  flows: [Flow!]!
+}
+`
+
+// host is the value of Host.
+type host struct {
+	addr net.IP
+}
+
+// label is the value of Label.
+type label struct {
+	key, value string
+}
+
+// pathValue is the value of Path. The flows are of depth 1 to the length.
+type pathValue struct {
+	flows []*client.Dependency
+}
+
+func parseAddr(s string) (net.IP, error) {
+	addr := net.ParseIP(s)
+	if addr == nil || addr.To4() == nil {
+		return nil, xerrors.Errorf("%q is not an IPv4 address", s)
+	}
+	return addr, nil
+}
+
+// query returns the query of the arguments of the traversals.
+func query(addr net.IP, args map[string]interface{}, depthArg string, dir probe.FlowDirection) (*client.Query, error) {
+	q := &client.Query{Addr: addr, Direction: dir}
+	q.Depth, _ = args[depthArg].(int)
+	now := time.Now()
+	var err error
+	since, _ := args["since"].(string)
+	if q.Since, err = api.ParseTime(since, now); err != nil {
+		return nil, xerrors.Errorf("since: %w", err)
+	}
+	until, _ := args["until"].(string)
+	if q.Until, err = api.ParseTime(until, now); err != nil {
+		return nil, xerrors.Errorf("until: %w", err)
+	}
+	return q, nil
+}
+
+// The limits of the queries, since each level of the hosts nested in the
+// flows queries the CMDB for each flow of the level above.
+const (
+	maxQueryDepth  = 10
+	maxQueryFields = 200
+)
+
+type resolveFunc = func(ctx context.Context, source interface{}, args map[string]interface{}) (interface{}, error)
+
+// newSchema creates the schema resolved by c.
+func newSchema(c *client.Client) *graphql.Schema {
+	var (
+		hostType  = &graphql.Object{Name: "Host", Fields: map[string]*graphql.Field{}}
+		nodeType  = &graphql.Object{Name: "Node", Fields: map[string]*graphql.Field{}}
+		labelType = &graphql.Object{Name: "Label", Fields: map[string]*graphql.Field{}}
+		flowType  = &graphql.Object{Name: "Flow", Fields: map[string]*graphql.Field{}}
+		pathType  = &graphql.Object{Name: "Path", Fields: map[string]*graphql.Field{}}
+	)
+	rangeArgs := func(args ...*graphql.Arg) []*graphql.Arg {
+		return append(args, &graphql.Arg{Name: "since", Type: "String"}, &graphql.Arg{Name: "until", Type: "String"})
+	}
+	walk := func(addrOf func(source interface{}, args map[string]interface{}) (net.IP, error), dir probe.FlowDirection) resolveFunc {
+		return func(ctx context.Context, source interface{}, args map[string]interface{}) (interface{}, error) {
+			addr, err := addrOf(source, args)
+			if err != nil {
+				return nil, err
+			}
+			q, err := query(addr, args, "depth", dir)
+			if err != nil {
+				return nil, err
+			}
+			return c.Query(ctx, q)
+		}
+	}
+	addrArg := func(source interface{}, args map[string]interface{}) (net.IP, error) {
+		return parseAddr(args["addr"].(string))
+	}
+	hostAddr := func(source interface{}, args map[string]interface{}) (net.IP, error) {
+		return source.(*host).addr, nil
+	}
+	scalar := func(f func(source interface{}) interface{}) *graphql.Field {
+		return &graphql.Field{Resolve: func(ctx context.Context, source interface{}, args map[string]interface{}) (interface{}, error) {
+			return f(source), nil
+		}}
+	}
+
+	hostType.Fields["addr"] = scalar(func(s interface{}) interface{} { return s.(*host).addr.String() })
+	hostType.Fields["dependencies"] = &graphql.Field{
+		Type:    flowType,
+		Args:    rangeArgs(&graphql.Arg{Name: "depth", Type: "Int", Default: 1}),
+		Resolve: walk(hostAddr, probe.FlowActive),
+	}
+	hostType.Fields["dependents"] = &graphql.Field{
+		Type:    flowType,
+		Args:    rangeArgs(&graphql.Arg{Name: "depth", Type: "Int", Default: 1}),
+		Resolve: walk(hostAddr, probe.FlowPassive),
+	}
+
+	nodeType.Fields["addr"] = scalar(func(s interface{}) interface{} { return s.(*client.Node).IPAddr.String() })
+	nodeType.Fields["port"] = scalar(func(s interface{}) interface{} {
+		if n := s.(*client.Node); !n.Aggregated {
+			return int(n.Port)
+		}
+		return nil
+	})
+	nodeType.Fields["process"] = scalar(func(s interface{}) interface{} { return s.(*client.Node).Pname })
+	nodeType.Fields["pgid"] = scalar(func(s interface{}) interface{} { return s.(*client.Node).Pgid })
+	nodeType.Fields["vrf"] = scalar(func(s interface{}) interface{} {
+		if n := s.(*client.Node); n.VRF != "" {
+			return n.VRF
+		}
+		return nil
+	})
+	nodeType.Fields["labels"] = &graphql.Field{
+		Type: labelType,
+		Resolve: func(ctx context.Context, source interface{}, args map[string]interface{}) (interface{}, error) {
+			n := source.(*client.Node)
+			labels := make([]*label, 0, len(n.Labels))
+			for k, v := range n.Labels {
+				labels = append(labels, &label{k, v})
+			}
+			sort.Slice(labels, func(i, j int) bool { return labels[i].key < labels[j].key })
+			return labels, nil
+		},
+	}
+	nodeType.Fields["host"] = &graphql.Field{
+		Type: hostType,
+		Resolve: func(ctx context.Context, source interface{}, args map[string]interface{}) (interface{}, error) {
+			return &host{addr: source.(*client.Node).IPAddr}, nil
+		},
+	}
+
+	labelType.Fields["key"] = scalar(func(s interface{}) interface{} { return s.(*label).key })
+	labelType.Fields["value"] = scalar(func(s interface{}) interface{} { return s.(*label).value })
+
+	node := func(f func(d *client.Dependency) *client.Node) *graphql.Field {
+		return &graphql.Field{
+			Type: nodeType,
+			Resolve: func(ctx context.Context, source interface{}, args map[string]interface{}) (interface{}, error) {
+				return f(source.(*client.Dependency)), nil
+			},
+		}
+	}
+	flowType.Fields["client"] = node(func(d *client.Dependency) *client.Node { return d.ActiveNode })
+	flowType.Fields["server"] = node(func(d *client.Dependency) *client.Node { return d.PassiveNode })
+	flowType.Fields["connections"] = scalar(func(s interface{}) interface{} { return s.(*client.Dependency).Connections })
+	flowType.Fields["depth"] = scalar(func(s interface{}) interface{} { return s.(*client.Dependency).Depth })
+	flowType.Fields["annotation"] = scalar(func(s interface{}) interface{} {
+		if d := s.(*client.Dependency); d.Annotation != "" {
+			return d.Annotation
+		}
+		return nil
+	})
+
+	pathType.Fields["length"] = scalar(func(s interface{}) interface{} { return len(s.(*pathValue).flows) })
+	pathType.Fields["flows"] = &graphql.Field{
+		Type: flowType,
+		Resolve: func(ctx context.Context, source interface{}, args map[string]interface{}) (interface{}, error) {
+			return source.(*pathValue).flows, nil
+		},
+	}
+
+	return &graphql.Schema{MaxDepth: maxQueryDepth, MaxFields: maxQueryFields, Query: &graphql.Object{Name: "Query", Fields: map[string]*graphql.Field{
+		"host": {
+			Type: hostType,
+			Args: []*graphql.Arg{{Name: "addr", Type: "String!"}},
+			Resolve: func(ctx context.Context, source interface{}, args map[string]interface{}) (interface{}, error) {
+				addr, err := addrArg(source, args)
+				if err != nil {
+					return nil, err
+				}
+				return &host{addr: addr}, nil
+			},
+		},
+		"dependencies": {
+			Type:    flowType,
+			Args:    rangeArgs(&graphql.Arg{Name: "addr", Type: "String!"}, &graphql.Arg{Name: "depth", Type: "Int", Default: 1}),
+			Resolve: walk(addrArg, probe.FlowActive),
+		},
+		"dependents": {
+			Type:    flowType,
+			Args:    rangeArgs(&graphql.Arg{Name: "addr", Type: "String!"}, &graphql.Arg{Name: "depth", Type: "Int", Default: 1}),
+			Resolve: walk(addrArg, probe.FlowPassive),
+		},
+		"paths": {
+			Type: pathType,
+			Args: rangeArgs(
+				&graphql.Arg{Name: "from", Type: "String!"},
+				&graphql.Arg{Name: "to", Type: "String!"},
+				&graphql.Arg{Name: "maxDepth", Type: "Int", Default: client.MaxDepth},
+			),
+			Resolve: func(ctx context.Context, source interface{}, args map[string]interface{}) (interface{}, error) {
+				from, err := parseAddr(args["from"].(string))
+				if err != nil {
+					return nil, err
+				}
+				to, err := parseAddr(args["to"].(string))
+				if err != nil {
+					return nil, err
+				}
+				q, err := query(from, args, "maxDepth", 0)
+				if err != nil {
+					return nil, err
+				}
+				paths, err := c.Paths(ctx, q, to)
+				if err != nil {
+					return nil, err
+				}
+				values := make([]*pathValue, 0, len(paths))
+				for _, p := range paths {
+					v := &pathValue{flows: make([]*client.Dependency, 0, len(p))}
+					for i, f := range p {
+						v.flows = append(v.flows, &client.Dependency{Flow: f, Direction: probe.FlowActive, Depth: i + 1})
+					}
+					values = append(values, v)
+				}
+				return values, nil
+			},
+		},
+	}}}
+}
@@ -0,0 +1,149 @@
+// Package serve provides the HTTP API of the dependency graph in the CMDB.
+package serve
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"time"
+
+	"golang.org/x/xerrors"
+
+	"github.com/yuuki/shawk/db"
+	"github.com/yuuki/shawk/logging"
+	"github.com/yuuki/shawk/pkg/client"
+	"github.com/yuuki/shawk/serve/api"
+	"github.com/yuuki/shawk/serve/graphql"
+	"github.com/yuuki/shawk/statik"
+)
+
+var logger = logging.New("serve")
+
+// maxRequestBytes is the maximum size of the body of a request.
+const maxRequestBytes = 1 << 20
+
+// NewHandler returns the handler of the API querying the store.
+//
+//	POST /graphql               the GraphQL query of the JSON body
+//	GET  /graphql?query=        the GraphQL query of the parameters
+//	GET  /graphql/schema        the schema of the GraphQL API
+//	GET  /api/v1/...            the REST API
+//	GET  /openapi.json          the OpenAPI spec of the REST API
+//	GET  /changes               the server-sent events or the WebSocket of the changes of the flows
+//	GET  /annotations           the annotations of the edges
+//	PUT  /annotations           the annotation of an edge of the JSON body
+//	GET  /agents/versions       the versions of the agents for the rollout of their upgrades
+//	GET  /metrics               the metrics of the graph for Prometheus
+//	GET  /metrics/servicegraph  the edges between the services as the metrics of Tempo
+//
+// /changes responds 501 unless the store is a Listener, /annotations
+// unless it is a db.Annotator, and /agents/versions unless it is a
+// db.AgentHealthReader. The others negotiate the compression of the
+// requests and the responses by gzip.
+func NewHandler(store db.Store) http.Handler {
+	c := client.NewWithStore(store)
+	schema := newSchema(c)
+	var hub *changeHub
+	if l, ok := store.(Listener); ok {
+		hub = newChangeHub(l)
+	}
+	an, _ := store.(db.Annotator)
+	ar, _ := store.(db.AgentHealthReader)
+	mux := http.NewServeMux()
+	mux.HandleFunc("/annotations", serveAnnotations(an))
+	mux.HandleFunc("/agents/versions", serveAgentVersions(ar))
+	mux.HandleFunc("/metrics", serveMetrics(newGraphCollector(store)))
+	mux.HandleFunc("/metrics/servicegraph", serveServiceGraph(store))
+	mux.Handle("/api/v1/", http.StripPrefix("/api/v1", api.NewHandler(c)))
+	mux.HandleFunc("/openapi.json", func(w http.ResponseWriter, r *http.Request) {
+		spec, err := statik.FindString("/openapi.json")
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, spec)
+	})
+	mux.HandleFunc("/graphql", func(w http.ResponseWriter, r *http.Request) {
+		var req graphql.Request
+		switch r.Method {
+		case http.MethodGet:
+			q := r.URL.Query()
+			req.Query, req.OperationName = q.Get("query"), q.Get("operationName")
+			if v := q.Get("variables"); v != "" {
+				if err := json.Unmarshal([]byte(v), &req.Variables); err != nil {
+					writeError(w, http.StatusBadRequest, xerrors.Errorf("invalid variables: %w", err))
+					return
+				}
+			}
+		case http.MethodPost:
+			if err := json.NewDecoder(io.LimitReader(r.Body, maxRequestBytes)).Decode(&req); err != nil {
+				writeError(w, http.StatusBadRequest, xerrors.Errorf("invalid request: %w", err))
+				return
+			}
+		default:
+			w.Header().Set("Allow", "GET, POST")
+			writeError(w, http.StatusMethodNotAllowed, xerrors.Errorf("%s is not allowed", r.Method))
+			return
+		}
+		if req.Query == "" {
+			writeError(w, http.StatusBadRequest, xerrors.New("query is required"))
+			return
+		}
+		writeJSON(w, http.StatusOK, schema.Do(r.Context(), &req))
+	})
+	mux.HandleFunc("/graphql/schema", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		io.WriteString(w, SchemaSDL)
+	})
+
+	root := http.NewServeMux()
+	root.HandleFunc("/changes", serveChanges(hub))
+	root.Handle("/", gzipHandler(mux))
+	return root
+}
+
+func writeJSON(w http.ResponseWriter, code int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		logger.Errorf("could not write the response: %v", err)
+	}
+}
+
+// writeError writes the error in the format of the GraphQL responses.
+func writeError(w http.ResponseWriter, code int, err error) {
+	writeJSON(w, code, &graphql.Response{Errors: []*graphql.Error{{Message: err.Error()}}})
+}
+
+// ShutdownTimeout is the time to wait for the requests in flight on shutdown.
+var ShutdownTimeout = 10 * time.Second
+
+// Run serves the API on addr until ctx is done.
+func Run(ctx context.Context, addr string, store db.Store) error {
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return xerrors.Errorf("could not listen %s: %w", addr, err)
+	}
+	srv := &http.Server{Handler: NewHandler(store)}
+	logger.Infof("Serving the GraphQL API on http://%s/graphql", ln.Addr())
+
+	errc := make(chan error, 1)
+	go func() {
+		errc <- srv.Serve(ln)
+	}()
+	select {
+	case err := <-errc:
+		return xerrors.Errorf("server error: %w", err)
+	case <-ctx.Done():
+	}
+
+	sctx, cancel := context.WithTimeout(context.Background(), ShutdownTimeout)
+	defer cancel()
+	if err := srv.Shutdown(sctx); err != nil {
+		return xerrors.Errorf("could not shut down the server: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,380 @@
+package serve
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yuuki/shawk/db"
+	"github.com/yuuki/shawk/db/dbtest"
+	"github.com/yuuki/shawk/version"
+)
+
+// fakeStore finds the flows among its flows instead of the CMDB.
+type fakeStore struct {
+	db.Store
+	flows []*db.Flow
+}
+
+func (s *fakeStore) find(cond *db.FindFlowsCond, node func(*db.Flow) *db.Node) db.Flows {
+	flows := db.Flows{}
+	for _, f := range s.flows {
+		for _, addr := range cond.Addrs {
+			if n := node(f); n.IPAddr.Equal(addr) {
+				key := n.IPAddr.String() + "-" + n.Pname
+				flows[key] = append(flows[key], f)
+			}
+		}
+	}
+	return flows
+}
+
+func (s *fakeStore) FindPassiveFlows(cond *db.FindFlowsCond) (db.Flows, error) {
+	return s.find(cond, func(f *db.Flow) *db.Node { return f.PassiveNode }), nil
+}
+
+func (s *fakeStore) FindActiveFlows(cond *db.FindFlowsCond) (db.Flows, error) {
+	return s.find(cond, func(f *db.Flow) *db.Node { return f.ActiveNode }), nil
+}
+
+func (s *fakeStore) ListFlows(cond *db.ListFlowsCond) ([]*db.Flow, error) {
+	if cond.After > 0 {
+		return nil, nil
+	}
+	return s.flows, nil
+}
+
+func newTestServer() *httptest.Server {
+	return httptest.NewServer(NewHandler(&fakeStore{flows: []*db.Flow{
+		dbtest.Flow("10.0.0.1", "app", "10.0.0.2", "nginx", 80, 2, "tier", "nginx"),
+		dbtest.Flow("10.0.0.2", "app", "10.0.0.3", "postgres", 5432, 2, "tier", "postgres"),
+	}}))
+}
+
+func TestGraphQL(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+
+	tests := []struct {
+		desc  string
+		query string
+		want  string
+	}{
+		{
+			desc:  "dependencies",
+			query: `{ dependencies(addr: "10.0.0.1", depth: 2) { client { addr port } server { addr port process labels { key value } } connections depth } }`,
+			want: `{"data":{"dependencies":[` +
+				`{"client":{"addr":"10.0.0.1","port":null},"server":{"addr":"10.0.0.2","port":80,"process":"nginx","labels":[{"key":"tier","value":"nginx"}]},"connections":2,"depth":1},` +
+				`{"client":{"addr":"10.0.0.2","port":null},"server":{"addr":"10.0.0.3","port":5432,"process":"postgres","labels":[{"key":"tier","value":"postgres"}]},"connections":2,"depth":2}]}}`,
+		},
+		{
+			desc:  "annotation of an edge not annotated",
+			query: `{ dependencies(addr: "10.0.0.1") { annotation } }`,
+			want:  `{"data":{"dependencies":[{"annotation":null}]}}`,
+		},
+		{
+			desc:  "dependents of a host through a node",
+			query: `{ dependents(addr: "10.0.0.3") { client { host { dependents { client { addr } } } } } }`,
+			want:  `{"data":{"dependents":[{"client":{"host":{"dependents":[{"client":{"addr":"10.0.0.1"}}]}}}]}}`,
+		},
+		{
+			desc:  "paths",
+			query: `{ paths(from: "10.0.0.1", to: "10.0.0.3") { length flows { server { process } } } }`,
+			want:  `{"data":{"paths":[{"length":2,"flows":[{"server":{"process":"nginx"}},{"server":{"process":"postgres"}}]}]}}`,
+		},
+		{
+			desc:  "invalid address",
+			query: `{ host(addr: "fe80::1") { addr } }`,
+			want:  `{"data":{"host":null},"errors":[{"message":"\"fe80::1\" is not an IPv4 address","path":["host"]}]}`,
+		},
+		{
+			desc:  "invalid since",
+			query: `{ dependencies(addr: "10.0.0.1", since: "yesterday") { depth } }`,
+			want:  `{"data":{"dependencies":null},"errors":[{"message":"since: \"yesterday\" is neither a duration nor an RFC 3339 time","path":["dependencies"]}]}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			body, _ := json.Marshal(map[string]string{"query": tt.query})
+			resp, err := http.Post(ts.URL+"/graphql", "application/json", strings.NewReader(string(body)))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer resp.Body.Close()
+			got, _ := ioutil.ReadAll(resp.Body)
+			if resp.StatusCode != http.StatusOK {
+				t.Errorf("status = %d, want 200", resp.StatusCode)
+			}
+			if strings.TrimSpace(string(got)) != tt.want {
+				t.Errorf("response = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGraphQL_get(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+
+	q := url.Values{
+		"query":     {`query($a: String!) { host(addr: $a) { addr } }`},
+		"variables": {`{"a": "10.0.0.2"}`},
+	}
+	resp, err := http.Get(ts.URL + "/graphql?" + q.Encode())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	got, _ := ioutil.ReadAll(resp.Body)
+	if want := `{"data":{"host":{"addr":"10.0.0.2"}}}`; strings.TrimSpace(string(got)) != want {
+		t.Errorf("response = %s, want %s", got, want)
+	}
+
+	for _, tt := range []struct {
+		method, target string
+		code           int
+	}{
+		{http.MethodGet, "/graphql", http.StatusBadRequest},
+		{http.MethodGet, "/graphql?query={}&variables=x", http.StatusBadRequest},
+		{http.MethodDelete, "/graphql", http.StatusMethodNotAllowed},
+		{http.MethodGet, "/graphql/schema", http.StatusOK},
+	} {
+		req, _ := http.NewRequest(tt.method, ts.URL+tt.target, nil)
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != tt.code {
+			t.Errorf("%s %s = %d, want %d", tt.method, tt.target, resp.StatusCode, tt.code)
+		}
+	}
+}
+
+func TestServiceGraph(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+
+	resp, err := http.Get(ts.URL + "/metrics/servicegraph?since=10m")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, _ := ioutil.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status should be 200, but %d: %s", resp.StatusCode, body)
+	}
+	for _, want := range []string{
+		`traces_service_graph_request_total{client="app",server="nginx",connection_type=""} 2`,
+		`traces_service_graph_request_total{client="app",server="postgres",connection_type="database"} 2`,
+	} {
+		if !strings.Contains(string(body), want) {
+			t.Errorf("the response should contain %q, but\n%s", want, body)
+		}
+	}
+
+	resp, err = http.Get(ts.URL + "/metrics/servicegraph?since=yesterday")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("status should be 400 for an invalid since, but %d", resp.StatusCode)
+	}
+}
+
+func TestMetrics(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+
+	resp, err := http.Get(ts.URL + "/metrics")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, _ := ioutil.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status should be 200, but %d: %s", resp.StatusCode, body)
+	}
+	for _, want := range []string{
+		"shawk_graph_edges 2\n",
+		"shawk_graph_edges_added 0\n",
+		`shawk_service_fan_out{service="app"} 2`,
+		`shawk_service_fan_in{service="nginx"} 1`,
+	} {
+		if !strings.Contains(string(body), want) {
+			t.Errorf("the response should contain %q, but\n%s", want, body)
+		}
+	}
+}
+
+func TestGraphCollector(t *testing.T) {
+	interval := MetricsInterval
+	defer func() { MetricsInterval = interval }()
+
+	store := &fakeStore{flows: []*db.Flow{dbtest.Flow("10.0.0.1", "app", "10.0.0.2", "nginx", 80, 2, "tier", "nginx")}}
+	c := newGraphCollector(store)
+	first, err := c.collect(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The metrics are reused within the interval.
+	store.flows = append(store.flows, dbtest.Flow("10.0.0.1", "app", "10.0.0.3", "postgres", 5432, 2, "tier", "postgres"))
+	m, err := c.collect(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m != first {
+		t.Error("collect() should reuse the metrics within MetricsInterval")
+	}
+
+	MetricsInterval = 0
+	m, err = c.collect(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.Edges != 2 || m.EdgesAdded != 1 || m.EdgesRemoved != 0 {
+		t.Errorf("collect() should add an edge, but edges %d, added %d, removed %d", m.Edges, m.EdgesAdded, m.EdgesRemoved)
+	}
+}
+
+// annotatedStore is the fakeStore annotating the edges in memory.
+type annotatedStore struct {
+	fakeStore
+	annotations []*db.Annotation
+}
+
+func (s *annotatedStore) Annotate(a *db.Annotation) error {
+	s.annotations = append(s.annotations, a)
+	return nil
+}
+
+func (s *annotatedStore) FindAnnotations() ([]*db.Annotation, error) {
+	return s.annotations, nil
+}
+
+func TestAnnotations(t *testing.T) {
+	store := &annotatedStore{}
+	ts := httptest.NewServer(NewHandler(store))
+	defer ts.Close()
+
+	put := func(body string) int {
+		req, _ := http.NewRequest(http.MethodPut, ts.URL+"/annotations", strings.NewReader(body))
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+	if code := put(`{"client":"10.0.0.1","server":"10.0.0.2","port":873,"note":"expected: backup traffic"}`); code != http.StatusNoContent {
+		t.Errorf("status = %d, want 204", code)
+	}
+	if code := put(`{"client":"10.0.0.1","server":"10.0.0.2","port":873,"vrf":"blue","note":"INFRA-123"}`); code != http.StatusNoContent {
+		t.Errorf("status = %d, want 204", code)
+	}
+	for _, body := range []string{
+		`{"client":"10.0.0.1","server":"fe80::1","port":873,"note":"x"}`,
+		`{"client":"10.0.0.1","server":"10.0.0.2","port":0,"note":"x"}`,
+		`{"client":"10.0.0.1","server":"10.0.0.2","port":65536,"note":"x"}`,
+		`{"client":"10.0.0.1","server":"10.0.0.2","port":-1,"note":"x"}`,
+		`{"client":"10.0.0.1","server":"10.0.0.2","port":873,"vrf":"vrf-of-a-long-name","note":"x"}`,
+		`not json`,
+	} {
+		if code := put(body); code != http.StatusBadRequest {
+			t.Errorf("status of %s = %d, want 400", body, code)
+		}
+	}
+
+	resp, err := http.Get(ts.URL + "/annotations")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	var list struct {
+		Annotations []struct {
+			Client string `json:"client"`
+			VRF    string `json:"vrf"`
+			Note   string `json:"note"`
+		} `json:"annotations"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&list); err != nil {
+		t.Fatal(err)
+	}
+	if len(list.Annotations) != 2 || list.Annotations[0].Client != "10.0.0.1" || list.Annotations[0].Note != "expected: backup traffic" ||
+		list.Annotations[1].VRF != "blue" {
+		t.Errorf("annotations = %+v, want the annotations of 10.0.0.1 in the default VRF and blue", list.Annotations)
+	}
+}
+
+func TestAnnotations_notImplemented(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+
+	resp, err := http.Get(ts.URL + "/annotations")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNotImplemented {
+		t.Errorf("status = %d, want 501", resp.StatusCode)
+	}
+}
+
+// agentStore is the fakeStore recording the agents.
+type agentStore struct {
+	fakeStore
+	agents []*db.AgentHealth
+}
+
+func (s *agentStore) FindAgentHealth(addrs []net.IP) ([]*db.AgentHealth, error) {
+	return s.agents, nil
+}
+
+func TestAgentVersions(t *testing.T) {
+	now := time.Now()
+	store := &agentStore{agents: []*db.AgentHealth{
+		{Host: "web-1", AgentVersion: "0.8.0", LastRecord: now},
+		{Host: "web-2", AgentVersion: "0.7.1", LastRecord: now},
+		{Host: "web-3", AgentVersion: "0.7.0", LastRecord: now.Add(-2 * time.Hour)},
+	}}
+	ts := httptest.NewServer(NewHandler(store))
+	defer ts.Close()
+
+	get := func(query string) (*version.Rollout, int) {
+		resp, err := http.Get(ts.URL + "/agents/versions" + query)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return nil, resp.StatusCode
+		}
+		var r version.Rollout
+		if err := json.NewDecoder(resp.Body).Decode(&r); err != nil {
+			t.Fatal(err)
+		}
+		return &r, resp.StatusCode
+	}
+	r, _ := get("?since=1h&latest=0.9.0")
+	if r == nil || r.Agents != 2 || r.Outdated != 2 || r.Latest != "0.9.0" || !r.Published {
+		t.Errorf("rollout = %+v, want 2 agents outdated against 0.9.0", r)
+	}
+	r, _ = get("")
+	if r == nil || r.Agents != 3 || r.Outdated != 2 || r.Latest != "0.8.0" || r.Published {
+		t.Errorf("rollout = %+v, want 2 of 3 agents outdated against 0.8.0", r)
+	}
+	for _, query := range []string{"?since=yesterday", "?latest=latest"} {
+		if _, code := get(query); code != http.StatusBadRequest {
+			t.Errorf("status of %s = %d, want 400", query, code)
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package serve
+
+import (
+	"net/http"
+	"time"
+
+	"golang.org/x/xerrors"
+
+	"github.com/yuuki/shawk/db"
+	"github.com/yuuki/shawk/graph"
+)
+
+// serveServiceGraph serves the edges between the services of the flows
+// updated in the last ?since=, or of all the flows, as the metrics of the
+// service graphs of Tempo, so that Prometheus scrapes them.
+func serveServiceGraph(store db.Store) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		cond := &db.ListFlowsCond{}
+		if s := r.URL.Query().Get("since"); s != "" {
+			d, err := time.ParseDuration(s)
+			if err != nil || d <= 0 {
+				writeError(w, http.StatusBadRequest, xerrors.Errorf("since must be a positive duration such as '10m', but %q", s))
+				return
+			}
+			cond.Since = time.Now().Add(-d)
+		}
+		var flows []*db.Flow
+		err := db.EachFlow(r.Context(), store, cond, func(f *db.Flow) error {
+			flows = append(flows, f)
+			return nil
+		})
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		if err := graph.RenderServiceGraph(w, graph.New(flows)); err != nil {
+			logger.Errorf("could not write the service graph: %v", err)
+		}
+	}
+}
@@ -0,0 +1,245 @@
+package serve
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/binary"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/xerrors"
+)
+
+// websocketGUID is the GUID of RFC 6455 appended to the key of a handshake.
+const websocketGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+// The opcodes of the frames of RFC 6455.
+const (
+	opText  = 0x1
+	opClose = 0x8
+	opPing  = 0x9
+	opPong  = 0xa
+)
+
+// The status codes of the close frames of RFC 6455.
+const (
+	closeNormal = 1000
+	closeTooBig = 1009
+)
+
+// maxControlPayload is the maximum payload of a control frame.
+const maxControlPayload = 125
+
+// AllowedOrigins are the origins such as 'https://dashboard.example.com'
+// whose pages may open the WebSocket besides the pages of the same host.
+var AllowedOrigins []string
+
+// websocketWriteTimeout is the timeout of writing a frame, which closes the
+// connection of a client not reading it.
+var websocketWriteTimeout = 10 * time.Second
+
+// isWebSocket returns whether r is the handshake of a WebSocket.
+func isWebSocket(r *http.Request) bool {
+	return headerHasToken(r.Header, "Connection", "upgrade") && strings.EqualFold(r.Header.Get("Upgrade"), "websocket")
+}
+
+func headerHasToken(h http.Header, name, token string) bool {
+	for _, v := range h[name] {
+		for _, t := range strings.Split(v, ",") {
+			if strings.EqualFold(strings.TrimSpace(t), token) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// websocketAccept returns Sec-WebSocket-Accept of the key of a handshake.
+func websocketAccept(key string) string {
+	sum := sha1.Sum([]byte(key + websocketGUID))
+	return base64.StdEncoding.EncodeToString(sum[:])
+}
+
+// wsConn is the server side of a WebSocket, which writes the messages and
+// answers the control frames of the client. The messages of the client are
+// discarded.
+type wsConn struct {
+	conn net.Conn
+	r    *bufio.Reader
+
+	mu sync.Mutex // serializes the frames written
+}
+
+// allowedOrigin returns whether the page of the Origin of r may open the
+// WebSocket. The browsers don't apply the same-origin policy to the
+// WebSockets, so the pages of the other sites would read the changes with
+// the cookies or the network of the users otherwise. The clients other than
+// the browsers send no Origin.
+func allowedOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	u, err := url.Parse(origin)
+	if err != nil {
+		return false
+	}
+	if strings.EqualFold(u.Host, r.Host) {
+		return true
+	}
+	for _, o := range AllowedOrigins {
+		if strings.EqualFold(strings.TrimSuffix(o, "/"), origin) {
+			return true
+		}
+	}
+	return false
+}
+
+// upgradeWebSocket completes the handshake of r, and returns the WebSocket
+// hijacked from w. It writes the error response if r is not a valid
+// handshake or comes from a page of the origin not allowed.
+func upgradeWebSocket(w http.ResponseWriter, r *http.Request) (*wsConn, error) {
+	if !allowedOrigin(r) {
+		err := xerrors.Errorf("the origin %q is not allowed", r.Header.Get("Origin"))
+		writeError(w, http.StatusForbidden, err)
+		return nil, err
+	}
+	if r.Header.Get("Sec-WebSocket-Version") != "13" {
+		w.Header().Set("Sec-WebSocket-Version", "13")
+		err := xerrors.New("Sec-WebSocket-Version must be 13")
+		writeError(w, http.StatusUpgradeRequired, err)
+		return nil, err
+	}
+	key := r.Header.Get("Sec-WebSocket-Key")
+	if key == "" {
+		err := xerrors.New("Sec-WebSocket-Key is required")
+		writeError(w, http.StatusBadRequest, err)
+		return nil, err
+	}
+	hj, ok := w.(http.Hijacker)
+	if !ok {
+		err := xerrors.New("WebSocket is not supported")
+		writeError(w, http.StatusInternalServerError, err)
+		return nil, err
+	}
+	conn, rw, err := hj.Hijack()
+	if err != nil {
+		return nil, xerrors.Errorf("could not hijack the connection: %w", err)
+	}
+	rw.WriteString("HTTP/1.1 101 Switching Protocols\r\n" +
+		"Upgrade: websocket\r\n" +
+		"Connection: Upgrade\r\n" +
+		"Sec-WebSocket-Accept: " + websocketAccept(key) + "\r\n\r\n")
+	if err := rw.Flush(); err != nil {
+		conn.Close()
+		return nil, xerrors.Errorf("could not write the handshake: %w", err)
+	}
+	return &wsConn{conn: conn, r: rw.Reader}, nil
+}
+
+// writeFrame writes a frame of the opcode, which is never fragmented nor
+// masked as the frames of a server.
+func (c *wsConn) writeFrame(op byte, payload []byte) error {
+	header := []byte{0x80 | op, 0}
+	switch n := len(payload); {
+	case n <= maxControlPayload:
+		header[1] = byte(n)
+	case n <= 0xffff:
+		header[1] = 126
+		header = append(header, 0, 0)
+		binary.BigEndian.PutUint16(header[2:], uint16(n))
+	default:
+		header[1] = 127
+		header = append(header, make([]byte, 8)...)
+		binary.BigEndian.PutUint64(header[2:], uint64(n))
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.conn.SetWriteDeadline(time.Now().Add(websocketWriteTimeout))
+	if _, err := c.conn.Write(append(header, payload...)); err != nil {
+		return xerrors.Errorf("could not write the frame: %w", err)
+	}
+	return nil
+}
+
+// writeClose writes the close frame of the status code.
+func (c *wsConn) writeClose(code uint16) error {
+	payload := make([]byte, 2)
+	binary.BigEndian.PutUint16(payload, code)
+	return c.writeFrame(opClose, payload)
+}
+
+// readLoop reads the frames of the client until it closes the WebSocket or
+// the connection fails, answering the pings and the close.
+func (c *wsConn) readLoop() error {
+	for {
+		var head [2]byte
+		if _, err := io.ReadFull(c.r, head[:]); err != nil {
+			return err
+		}
+		op := head[0] & 0x0f
+		masked := head[1]&0x80 != 0
+		n := uint64(head[1] & 0x7f)
+		switch n {
+		case 126:
+			var ext [2]byte
+			if _, err := io.ReadFull(c.r, ext[:]); err != nil {
+				return err
+			}
+			n = uint64(binary.BigEndian.Uint16(ext[:]))
+		case 127:
+			var ext [8]byte
+			if _, err := io.ReadFull(c.r, ext[:]); err != nil {
+				return err
+			}
+			n = binary.BigEndian.Uint64(ext[:])
+		}
+		if n > maxRequestBytes {
+			c.writeClose(closeTooBig)
+			return xerrors.Errorf("the frame of %d bytes is too big", n)
+		}
+		var mask [4]byte
+		if masked {
+			if _, err := io.ReadFull(c.r, mask[:]); err != nil {
+				return err
+			}
+		}
+		payload := make([]byte, n)
+		if _, err := io.ReadFull(c.r, payload); err != nil {
+			return err
+		}
+		if masked {
+			for i := range payload {
+				payload[i] ^= mask[i%4]
+			}
+		}
+
+		switch op {
+		case opPing:
+			if len(payload) > maxControlPayload {
+				payload = payload[:maxControlPayload]
+			}
+			if err := c.writeFrame(opPong, payload); err != nil {
+				return err
+			}
+		case opClose:
+			code := uint16(closeNormal)
+			if len(payload) >= 2 {
+				code = binary.BigEndian.Uint16(payload)
+			}
+			c.writeClose(code)
+			return nil
+		}
+	}
+}
+
+func (c *wsConn) Close() error {
+	return c.conn.Close()
+}
@@ -0,0 +1,177 @@
+package serve
+
+import (
+	"bufio"
+	"encoding/binary"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yuuki/shawk/db"
+)
+
+func TestWebsocketAccept(t *testing.T) {
+	// The example of RFC 6455.
+	if got, want := websocketAccept("dGhlIHNhbXBsZSBub25jZQ=="), "s3pPLMBiTxaQ9kYGzzhZRbK+xOo="; got != want {
+		t.Errorf("websocketAccept() = %q, want %q", got, want)
+	}
+}
+
+// dialWebSocket opens the WebSocket of the target of the server.
+func dialWebSocket(t *testing.T, ts *httptest.Server, target string) (net.Conn, *bufio.Reader) {
+	conn, err := net.Dial("tcp", ts.Listener.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	io.WriteString(conn, "GET "+target+" HTTP/1.1\r\n"+
+		"Host: "+ts.Listener.Addr().String()+"\r\n"+
+		"Upgrade: websocket\r\n"+
+		"Connection: keep-alive, Upgrade\r\n"+
+		"Sec-WebSocket-Key: dGhlIHNhbXBsZSBub25jZQ==\r\n"+
+		"Sec-WebSocket-Version: 13\r\n\r\n")
+	r := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(r, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != http.StatusSwitchingProtocols {
+		t.Fatalf("status = %d, want 101", resp.StatusCode)
+	}
+	if got := resp.Header.Get("Sec-WebSocket-Accept"); got != "s3pPLMBiTxaQ9kYGzzhZRbK+xOo=" {
+		t.Errorf("Sec-WebSocket-Accept = %q", got)
+	}
+	return conn, r
+}
+
+// readFrame reads an unmasked frame of the server.
+func readFrame(t *testing.T, r *bufio.Reader) (byte, []byte) {
+	var head [2]byte
+	if _, err := io.ReadFull(r, head[:]); err != nil {
+		t.Fatal(err)
+	}
+	n := int(head[1] & 0x7f)
+	if n == 126 {
+		var ext [2]byte
+		io.ReadFull(r, ext[:])
+		n = int(binary.BigEndian.Uint16(ext[:]))
+	}
+	payload := make([]byte, n)
+	if _, err := io.ReadFull(r, payload); err != nil {
+		t.Fatal(err)
+	}
+	return head[0] & 0x0f, payload
+}
+
+// writeMaskedFrame writes a frame masked as the frames of a client.
+func writeMaskedFrame(conn net.Conn, op byte, payload []byte) {
+	mask := []byte{1, 2, 3, 4}
+	frame := append([]byte{0x80 | op, 0x80 | byte(len(payload))}, mask...)
+	for i, b := range payload {
+		frame = append(frame, b^mask[i%4])
+	}
+	conn.Write(frame)
+}
+
+func TestChanges_webSocket(t *testing.T) {
+	store := &listenStore{
+		fakeStore: &fakeStore{},
+		changes:   make(chan *db.Change),
+		stopped:   make(chan struct{}, 1),
+	}
+	ts := httptest.NewServer(NewHandler(store))
+	defer ts.Close()
+
+	conn, r := dialWebSocket(t, ts, "/changes?type=added")
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(5 * time.Second))
+
+	writeMaskedFrame(conn, opPing, []byte("hi"))
+	if op, payload := readFrame(t, r); op != opPong || string(payload) != "hi" {
+		t.Errorf("the answer of the ping = %x %q, want the pong of it", op, payload)
+	}
+
+	store.changes <- &db.Change{Type: db.ChangeRemoved, ID: 1}
+	store.changes <- &db.Change{
+		Type: db.ChangeAdded, ID: 2, Connections: 3,
+		Client: &db.ChangeNode{Addr: "10.0.0.1", Process: "app"},
+		Server: &db.ChangeNode{Addr: "10.0.0.2", Port: 80, Process: "nginx"},
+	}
+	op, payload := readFrame(t, r)
+	want := `{"type":"added","id":2,"connections":3,"client":{"addr":"10.0.0.1","process":"app"},"server":{"addr":"10.0.0.2","port":80,"process":"nginx"}}`
+	if op != opText || string(payload) != want {
+		t.Errorf("the message = %x %s, want the text %s", op, payload, want)
+	}
+
+	writeMaskedFrame(conn, opClose, []byte{0x03, 0xe8})
+	if op, payload := readFrame(t, r); op != opClose || binary.BigEndian.Uint16(payload) != closeNormal {
+		t.Errorf("the answer of the close = %x %v, want the close of 1000", op, payload)
+	}
+
+	// The hub stops listening when the last subscriber leaves.
+	select {
+	case <-store.stopped:
+	case <-time.After(time.Second):
+		t.Error("the hub should stop listening without subscribers")
+	}
+}
+
+func TestChanges_webSocketVersion(t *testing.T) {
+	ts := httptest.NewServer(NewHandler(&listenStore{fakeStore: &fakeStore{}}))
+	defer ts.Close()
+
+	req, _ := http.NewRequest(http.MethodGet, ts.URL+"/changes", nil)
+	req.Header.Set("Connection", "Upgrade")
+	req.Header.Set("Upgrade", "websocket")
+	req.Header.Set("Sec-WebSocket-Key", "dGhlIHNhbXBsZSBub25jZQ==")
+	req.Header.Set("Sec-WebSocket-Version", "8")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusUpgradeRequired || !strings.Contains(resp.Header.Get("Sec-WebSocket-Version"), "13") {
+		t.Errorf("status = %d with version %q, want 426 with 13", resp.StatusCode, resp.Header.Get("Sec-WebSocket-Version"))
+	}
+}
+
+func TestChanges_webSocketOrigin(t *testing.T) {
+	ts := httptest.NewServer(NewHandler(&listenStore{fakeStore: &fakeStore{}}))
+	defer ts.Close()
+	defer func(origins []string) { AllowedOrigins = origins }(AllowedOrigins)
+	AllowedOrigins = []string{"https://dashboard.example.com"}
+
+	tests := []struct {
+		origin string
+		want   int
+	}{
+		{"https://evil.example.com", http.StatusForbidden},
+		{"http://dashboard.example.com", http.StatusForbidden},
+		{"https://dashboard.example.com", http.StatusUpgradeRequired},
+		{ts.URL, http.StatusUpgradeRequired},
+		{"", http.StatusUpgradeRequired},
+	}
+	for _, tt := range tests {
+		req, _ := http.NewRequest(http.MethodGet, ts.URL+"/changes", nil)
+		req.Header.Set("Connection", "Upgrade")
+		req.Header.Set("Upgrade", "websocket")
+		req.Header.Set("Sec-WebSocket-Key", "dGhlIHNhbXBsZSBub25jZQ==")
+		// The unsupported version answers the handshakes of the origins
+		// allowed without upgrading them.
+		req.Header.Set("Sec-WebSocket-Version", "8")
+		if tt.origin != "" {
+			req.Header.Set("Origin", tt.origin)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != tt.want {
+			t.Errorf("status of the origin %q = %d, want %d", tt.origin, resp.StatusCode, tt.want)
+		}
+	}
+}
@@ -0,0 +1,229 @@
+// Package siem formats the flows and the changes of the flows as the events
+// of CEF or RFC 5424 syslog, and sends them to a syslog collector of SIEM
+// such as Splunk, QRadar or Sentinel.
+package siem
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/yuuki/shawk/db"
+	"github.com/yuuki/shawk/version"
+)
+
+// The formats of the events.
+const (
+	// FormatCEF is ArcSight Common Event Format in a syslog message.
+	FormatCEF = "cef"
+	// FormatRFC5424 is a syslog message with the structured data.
+	FormatRFC5424 = "rfc5424"
+)
+
+// Formats are the formats of the events.
+var Formats = []string{FormatCEF, FormatRFC5424}
+
+// EventFlow is the type of the event of a flow in the CMDB, in addition to
+// the types of the changes.
+const EventFlow = "flow"
+
+// sdID is the ID of the structured data of RFC 5424. 32473 is the private
+// enterprise number reserved for the documentation, since shawk has none.
+const sdID = "shawk@32473"
+
+// facilityLocal0 is the syslog facility of the events.
+const facilityLocal0 = 16
+
+// The severities of the types of the events in CEF, from 0 to 10, and in
+// syslog.
+var (
+	cefSeverities = map[string]int{
+		EventFlow:          1,
+		db.ChangeAdded:     5,
+		db.ChangeRemoved:   5,
+		db.ChangeThreshold: 7,
+	}
+	syslogSeverities = map[string]int{
+		EventFlow:          6, // informational
+		db.ChangeAdded:     5, // notice
+		db.ChangeRemoved:   5,
+		db.ChangeThreshold: 4, // warning
+	}
+	names = map[string]string{
+		EventFlow:          "Flow",
+		db.ChangeAdded:     "Dependency added",
+		db.ChangeRemoved:   "Dependency removed",
+		db.ChangeThreshold: "Connections reached the threshold",
+	}
+)
+
+// Event is a flow or a change of a flow from the client to the server.
+type Event struct {
+	Type string
+	Time time.Time
+	// FlowID is the ID of the flow, which is zero for the flows not listed
+	// by the IDs.
+	FlowID        int64
+	Tenant        string
+	ClientAddr    string
+	ClientProcess string
+	ServerAddr    string
+	ServerPort    uint16
+	ServerProcess string
+	Connections   int
+}
+
+// FlowEvent returns the event of the flow at now.
+func FlowEvent(f *db.Flow, now time.Time) *Event {
+	return &Event{
+		Type:          EventFlow,
+		Time:          now,
+		FlowID:        f.ID,
+		Tenant:        f.Tenant,
+		ClientAddr:    f.ActiveNode.IPAddr.String(),
+		ClientProcess: f.ActiveNode.Pname,
+		ServerAddr:    f.PassiveNode.IPAddr.String(),
+		ServerPort:    f.PassiveNode.Port,
+		ServerProcess: f.PassiveNode.Pname,
+		Connections:   f.Connections,
+	}
+}
+
+// ChangeEvent returns the event of the change at now. The nodes deleted
+// with the flow are empty.
+func ChangeEvent(c *db.Change, now time.Time) *Event {
+	e := &Event{
+		Type:        c.Type,
+		Time:        now,
+		FlowID:      c.ID,
+		Tenant:      c.Tenant,
+		Connections: c.Connections,
+	}
+	if c.Client != nil {
+		e.ClientAddr = c.Client.Addr
+		e.ClientProcess = c.Client.Process
+	}
+	if c.Server != nil {
+		e.ServerAddr = c.Server.Addr
+		e.ServerPort = c.Server.Port
+		e.ServerProcess = c.Server.Process
+	}
+	return e
+}
+
+// Formatter formats the events as syslog messages.
+type Formatter struct {
+	Format   string
+	Hostname string
+	PID      int
+}
+
+// NewFormatter returns the Formatter of the format on this host.
+func NewFormatter(format string) *Formatter {
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "-"
+	}
+	return &Formatter{Format: format, Hostname: hostname, PID: os.Getpid()}
+}
+
+// Message returns the syslog message of the event without the framing of
+// the transport.
+func (f *Formatter) Message(e *Event) string {
+	pri := facilityLocal0*8 + syslogSeverities[e.Type]
+	header := fmt.Sprintf("<%d>1 %s %s shawk %d %s", pri,
+		e.Time.UTC().Format("2006-01-02T15:04:05.000000Z"), f.Hostname, f.PID, e.Type)
+	if f.Format == FormatCEF {
+		return header + " - " + cef(e)
+	}
+	return header + " " + structuredData(e) + " " + summary(e)
+}
+
+// cef returns the event in CEF.
+func cef(e *Event) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "CEF:0|shawk|shawk|%s|%s|%s|%d|",
+		cefHeader(version.GetVersion()), e.Type, cefHeader(names[e.Type]), cefSeverities[e.Type])
+	ext := []string{
+		"rt=" + strconv.FormatInt(e.Time.UnixNano()/int64(time.Millisecond), 10),
+		"proto=TCP",
+		"cnt=" + strconv.Itoa(e.Connections),
+	}
+	add := func(k, v string) {
+		if v != "" {
+			ext = append(ext, k+"="+cefValue(v))
+		}
+	}
+	add("src", e.ClientAddr)
+	add("sproc", e.ClientProcess)
+	add("dst", e.ServerAddr)
+	if e.ServerPort != 0 {
+		add("dpt", strconv.Itoa(int(e.ServerPort)))
+	}
+	add("dproc", e.ServerProcess)
+	if e.FlowID != 0 {
+		add("externalId", strconv.FormatInt(e.FlowID, 10))
+	}
+	if e.Tenant != "" {
+		add("cs1Label", "tenant")
+		add("cs1", e.Tenant)
+	}
+	b.WriteString(strings.Join(ext, " "))
+	return b.String()
+}
+
+var (
+	cefHeaderEscaper = strings.NewReplacer(`\`, `\\`, `|`, `\|`)
+	cefValueEscaper  = strings.NewReplacer(`\`, `\\`, `=`, `\=`, "\n", `\n`, "\r", `\r`)
+	sdValueEscaper   = strings.NewReplacer(`\`, `\\`, `"`, `\"`, `]`, `\]`)
+)
+
+func cefHeader(s string) string { return cefHeaderEscaper.Replace(s) }
+
+func cefValue(s string) string { return cefValueEscaper.Replace(s) }
+
+// structuredData returns the event as the structured data of RFC 5424.
+func structuredData(e *Event) string {
+	var b strings.Builder
+	b.WriteString("[" + sdID)
+	param := func(k, v string) {
+		if v != "" {
+			fmt.Fprintf(&b, ` %s="%s"`, k, sdValueEscaper.Replace(v))
+		}
+	}
+	if e.FlowID != 0 {
+		param("flow_id", strconv.FormatInt(e.FlowID, 10))
+	}
+	param("tenant", e.Tenant)
+	param("client_addr", e.ClientAddr)
+	param("client_process", e.ClientProcess)
+	param("server_addr", e.ServerAddr)
+	if e.ServerPort != 0 {
+		param("server_port", strconv.Itoa(int(e.ServerPort)))
+	}
+	param("server_process", e.ServerProcess)
+	param("connections", strconv.Itoa(e.Connections))
+	b.WriteString("]")
+	return b.String()
+}
+
+// summary returns the message of the event for humans.
+func summary(e *Event) string {
+	return fmt.Sprintf("%s: %s --> %s %d", names[e.Type],
+		formatNode(e.ClientAddr, 0, e.ClientProcess),
+		formatNode(e.ServerAddr, e.ServerPort, e.ServerProcess), e.Connections)
+}
+
+// formatNode formats the node like the text of watch.
+func formatNode(addr string, port uint16, process string) string {
+	if addr == "" {
+		return "(deleted)"
+	}
+	p := "many"
+	if port != 0 {
+		p = strconv.Itoa(int(port))
+	}
+	return fmt.Sprintf("%s:%s ('%s')", addr, p, process)
+}
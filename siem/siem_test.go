@@ -0,0 +1,67 @@
+package siem
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/yuuki/shawk/db"
+)
+
+func TestFormatter_Message(t *testing.T) {
+	now := time.Date(2020, 12, 20, 12, 0, 0, 0, time.UTC)
+	f := &Formatter{Hostname: "collector-1", PID: 42}
+	added := ChangeEvent(&db.Change{
+		Type: db.ChangeAdded, ID: 7, Connections: 3, Tenant: "team-a",
+		Client: &db.ChangeNode{Addr: "10.0.0.1", Process: "app"},
+		Server: &db.ChangeNode{Addr: "10.0.0.2", Port: 80, Process: "ng|inx=1"},
+	}, now)
+	flow := FlowEvent(&db.Flow{
+		ID:          8,
+		ActiveNode:  &db.Node{IPAddr: net.ParseIP("10.0.0.1"), Aggregated: true, Pname: "app"},
+		PassiveNode: &db.Node{IPAddr: net.ParseIP("10.0.0.3"), Port: 5432, Pname: `post"gres]`},
+		Connections: 10,
+	}, now)
+	removed := ChangeEvent(&db.Change{Type: db.ChangeRemoved, ID: 9, Connections: 1}, now)
+
+	tests := []struct {
+		desc   string
+		format string
+		event  *Event
+		want   string
+	}{
+		{
+			desc: "cef of a change", format: FormatCEF, event: added,
+			want: `<133>1 2020-12-20T12:00:00.000000Z collector-1 shawk 42 added - ` +
+				`CEF:0|shawk|shawk|0.7.1|added|Dependency added|5|rt=1608465600000 proto=TCP cnt=3 ` +
+				`src=10.0.0.1 sproc=app dst=10.0.0.2 dpt=80 dproc=ng|inx\=1 externalId=7 cs1Label=tenant cs1=team-a`,
+		},
+		{
+			desc: "cef of a flow", format: FormatCEF, event: flow,
+			want: `<134>1 2020-12-20T12:00:00.000000Z collector-1 shawk 42 flow - ` +
+				`CEF:0|shawk|shawk|0.7.1|flow|Flow|1|rt=1608465600000 proto=TCP cnt=10 ` +
+				`src=10.0.0.1 sproc=app dst=10.0.0.3 dpt=5432 dproc=post"gres] externalId=8`,
+		},
+		{
+			desc: "rfc5424 of a flow", format: FormatRFC5424, event: flow,
+			want: `<134>1 2020-12-20T12:00:00.000000Z collector-1 shawk 42 flow ` +
+				`[shawk@32473 flow_id="8" client_addr="10.0.0.1" client_process="app" server_addr="10.0.0.3" server_port="5432" server_process="post\"gres\]" connections="10"] ` +
+				`Flow: 10.0.0.1:many ('app') --> 10.0.0.3:5432 ('post"gres]') 10`,
+		},
+		{
+			desc: "rfc5424 of the deleted nodes", format: FormatRFC5424, event: removed,
+			want: `<133>1 2020-12-20T12:00:00.000000Z collector-1 shawk 42 removed ` +
+				`[shawk@32473 flow_id="9" connections="1"] Dependency removed: (deleted) --> (deleted) 1`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			f.Format = tt.format
+			if diff := cmp.Diff(tt.want, f.Message(tt.event)); diff != "" {
+				t.Errorf("Message() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
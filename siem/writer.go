@@ -0,0 +1,96 @@
+package siem
+
+import (
+	"net"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"golang.org/x/xerrors"
+)
+
+const dialTimeout = 10 * time.Second
+
+// Writer sends the events to a syslog collector over UDP or TCP. The
+// messages over TCP are framed by the octet counting of RFC 6587.
+type Writer struct {
+	network   string
+	addr      string
+	formatter *Formatter
+
+	mu   sync.Mutex
+	conn net.Conn
+}
+
+// ParseURL returns the network and the address of the URL of a collector
+// such as udp://127.0.0.1:514 or tcp://siem.example.com:6514.
+func ParseURL(s string) (network, addr string, err error) {
+	u, err := url.Parse(s)
+	if err != nil {
+		return "", "", xerrors.Errorf("invalid URL %q: %w", s, err)
+	}
+	switch u.Scheme {
+	case "udp", "tcp":
+	default:
+		return "", "", xerrors.Errorf("the scheme of %q must be udp or tcp", s)
+	}
+	if _, _, err := net.SplitHostPort(u.Host); err != nil {
+		return "", "", xerrors.Errorf("the host of %q must be HOST:PORT", s)
+	}
+	return u.Scheme, u.Host, nil
+}
+
+// NewWriter returns the Writer to the collector of the URL, which writes
+// the events in the format.
+func NewWriter(collector, format string) (*Writer, error) {
+	network, addr, err := ParseURL(collector)
+	if err != nil {
+		return nil, err
+	}
+	return &Writer{network: network, addr: addr, formatter: NewFormatter(format)}, nil
+}
+
+// Write sends the event. A connection lost over TCP is connected again and
+// the event is sent once more.
+func (w *Writer) Write(e *Event) error {
+	msg := w.formatter.Message(e)
+	if w.network == "tcp" {
+		msg = strconv.Itoa(len(msg)) + " " + msg
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	err := w.write(msg)
+	if err != nil && w.network == "tcp" {
+		err = w.write(msg)
+	}
+	return err
+}
+
+func (w *Writer) write(msg string) error {
+	if w.conn == nil {
+		conn, err := net.DialTimeout(w.network, w.addr, dialTimeout)
+		if err != nil {
+			return xerrors.Errorf("could not connect to the collector %s: %w", w.addr, err)
+		}
+		w.conn = conn
+	}
+	if _, err := w.conn.Write([]byte(msg)); err != nil {
+		w.conn.Close()
+		w.conn = nil
+		return xerrors.Errorf("could not send the event to the collector %s: %w", w.addr, err)
+	}
+	return nil
+}
+
+// Close closes the connection to the collector.
+func (w *Writer) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.conn == nil {
+		return nil
+	}
+	err := w.conn.Close()
+	w.conn = nil
+	return err
+}
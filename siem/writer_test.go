@@ -0,0 +1,112 @@
+package siem
+
+import (
+	"bufio"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yuuki/shawk/db"
+)
+
+func TestParseURL(t *testing.T) {
+	tests := []struct {
+		url     string
+		network string
+		addr    string
+		wantErr bool
+	}{
+		{url: "udp://127.0.0.1:514", network: "udp", addr: "127.0.0.1:514"},
+		{url: "tcp://siem.example.com:6514", network: "tcp", addr: "siem.example.com:6514"},
+		{url: "http://127.0.0.1:514", wantErr: true},
+		{url: "udp://127.0.0.1", wantErr: true},
+	}
+	for _, tt := range tests {
+		network, addr, err := ParseURL(tt.url)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("ParseURL(%q) should return an error", tt.url)
+			}
+			continue
+		}
+		if err != nil || network != tt.network || addr != tt.addr {
+			t.Errorf("ParseURL(%q) = %q, %q, %v, want %q, %q", tt.url, network, addr, err, tt.network, tt.addr)
+		}
+	}
+}
+
+func TestWriter_tcp(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+
+	w, err := NewWriter("tcp://"+ln.Addr().String(), FormatRFC5424)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	defer w.Close()
+	e := ChangeEvent(&db.Change{Type: db.ChangeAdded, ID: 1, Connections: 1}, time.Now())
+	for i := 0; i < 2; i++ {
+		if err := w.Write(e); err != nil {
+			t.Fatalf("%+v", err)
+		}
+	}
+
+	conn, err := ln.Accept()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	r := bufio.NewReader(conn)
+	for i := 0; i < 2; i++ {
+		length, err := r.ReadString(' ')
+		if err != nil {
+			t.Fatal(err)
+		}
+		n, err := strconv.Atoi(strings.TrimSpace(length))
+		if err != nil {
+			t.Fatalf("the message should be framed by its length, but %q", length)
+		}
+		msg := make([]byte, n)
+		if _, err := io.ReadFull(r, msg); err != nil {
+			t.Fatal(err)
+		}
+		if !strings.HasPrefix(string(msg), "<133>1 ") {
+			t.Errorf("the message should be of syslog, but %q", msg)
+		}
+	}
+}
+
+func TestWriter_udp(t *testing.T) {
+	pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pc.Close()
+
+	w, err := NewWriter("udp://"+pc.LocalAddr().String(), FormatCEF)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	defer w.Close()
+	e := ChangeEvent(&db.Change{Type: db.ChangeThreshold, ID: 1, Connections: 100}, time.Now())
+	if err := w.Write(e); err != nil {
+		t.Fatalf("%+v", err)
+	}
+
+	pc.SetReadDeadline(time.Now().Add(5 * time.Second))
+	buf := make([]byte, 2048)
+	n, _, err := pc.ReadFrom(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if msg := string(buf[:n]); !strings.HasPrefix(msg, "<132>1 ") || !strings.Contains(msg, "CEF:0|shawk|") {
+		t.Errorf("the datagram should be a message of CEF, but %q", msg)
+	}
+}
@@ -8,6 +8,6 @@ import (
 )
 
 func init() {
-	data := "PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x07\x00	\x00CREDITSUT\x05\x00\x01\x80Cm8\xec\xbd\xebr\xe3:\xb6&\xf8\x9fO\x81vD\xc7\xb6{\x98\xca\xcb\xae\xeb\xde\xd5'Ji+3u\x8eS\xf2\x91\xe4\x9d\x95\xd1\xd1\x11\x03\x91\x90\x842E\xa8\x00\xd0NUEE\xf4\x83L?\xc0\xfc\x9cW\x98\x98'9O2\x81\xb5p#EY\xce4\xabvU7\xf5co\xa7D\xe2\xb2\x00\xac\xeb\x87\xb5\xde\x0br\xae7\x8c(M\xcb\x9c\xca\x9c\x14|)\xa9\xdc_$\x1b\xadw\xea\x87\x97/\xd7\xa2\xa0\xe5z \xe4\xfae\xf2\xe2\x99\x9f\xe4R\xec\xf6\x92\xaf7\x9a\x9cg\x17\xe4\xcd\xabW\xbf%\x8b\x0d#\xef\x05\x19Vz#\xa4\x1a\x90aQ\x10xD\x11\xc9\x14\x93\xf7,\x1f$\xc9\x8c\xe5\\i\xc9\x97\x95\xe6\xa2$\xb4\xccI\xa5\x18\xe1%Q\xa2\x92\x19\x83o\x96\xbc\xa4rOVBnUJ\x1e\xb8\xde\x10!\xe1\xff\xa2\xd2\xc9V\xe4|\xc53j\x1aH	\x95\x8c\xec\x98\xdcr\xadYNvR\xdc\xf3\x9c\xe5Do\xa8&\x86 +Q\x14\xe2\x81\x97k\x92\x892\xe7\xe6%e^J\xb6L\xff\x90$\x84\x90\xffB\xea\x83RD\xac\xdch2\x913\xb2\xad\x94&\x92i\xcaKh\x92.\xc5\xbd\xf9\xc9\x92 )\x85\xe6\x19K\x89\xdepE\n\xae\xb4i \xee\xad\xcc\x1bC\xc9\xb9\xca\n\xca\xb7L\x0e\xdaG\xc0\xcb\x98\x08n\x04;)\xf2*ca\x10\x89\x1f\x04y\xce \x12;\xb1\\d\xd5\x96\x95\x9a\xba\xb5y)$\x11z\xc3$\xd9R\xcd$\xa7\x85\n$\x86u\xd1\x1b\x96\xc4C\xb7\xf3\x990\x0e\xaf\x99VK\xbaef0\xef\x85X\x17\x8c\x8c\xcbl@J\x11~\x03zs\xad\x92L\x94\xd8\x8e\x90\x8al\xe9\x9e,\x99\xd9\x1c9\xd1\x82\xb02\x17R1\xb3\x0fvRl\x85f\x04\xa9\xa1\x15\xc9\x99\xe4\xf7,'+)\xb6	\xcc_\x89\x95~0;\xc3\xee\x19\xa2v,3\x9b\x86\xec$7[I\x9a\xedR\xe2\xc6Q\n\xc6\x9d,>\x8c\xe7d>}\xb7\xf84\x9c\x8d\xc8xNnf\xd3\x9f\xc6W\xa3+\xf2\xf63Y|\x18\x91\xcb\xe9\xcd\xe7\xd9\xf8\xfd\x87\x05\xf90\xbd\xbe\x1a\xcd\xe6d8\xb9\"\x97\xd3\xc9b6~{\xbb\x98\xce\xe6\xc9\xd9pN\xc6\xf33\xf8a8\xf9LF\x7f\xb8\x99\x8d\xe6s2\x9d\x91\xf1\xc7\x9b\xeb\xf1\xe8\x8a|\x1a\xcef\xc3\xc9b<\x9a\xa7d<\xb9\xbc\xbe\xbd\x1aO\xde\xa7\xe4\xed\xed\x82L\xa6\x8b\xe4z\xfcq\xbc\x18]\x91\xc54\x85N\x0f_#\xd3w\xe4\xe3hv\xf9a8Y\x0c\xdf\x8e\xaf\xc7\x8b\xcf\xd0\xdf\xbb\xf1bb\xfaz7\x9d%Cr3\x9c-\xc6\x97\xb7\xd7\xc3\x19\xb9\xb9\x9d\xddL\xe7#b\xa6u5\x9e_^\x0f\xc7\x1fGW\x032\x9e\x90\xc9\x94\x8c~\x1aM\x16d\xfeax}]\x9fe2\xfd4\x19\xcd\xcc\xd0\xe3)\x92\xb7#r=\x1e\xbe\xbd\x1e\x99\x8e`\x92W\xe3\xd9\xe8raf\x13\xfe\xba\x1c_\x8d&\x8b\xe1u\x9a\xccoF\x97\xe3\xe1uJF\x7f\x18}\xbc\xb9\x1e\xce>\xa7\xb6\xcd\xf9\xe8\xdfoG\x93\xc5xxM\xae\x86\x1f\x87\xefGsr~\x82\"7\xb3\xe9\xe5\xedl\xf4\xd1\x0cy\xfa\x8e\xcco\xdf\xce\x17\xe3\xc5\xedbD\xdeO\xa7W@\xe7\xf9h\xf6\xd3\xf8r4\xff\x91\\O\xe7@\xac\xdb\xf9(M\xae\x86\x8b!t|3\x9b\xbe\x1b/\xe6?\x9a\xbf\xdf\xde\xce\xc7@\xb3\xf1d1\x9a\xcdno\x16\xe3\xe9\xe4\x82|\x98~\x1a\xfd4\x9a\x91\xcb\xe1\xed|t\x05\xc4\x9dN\xccT\x93\xc5\x87\xd1t\xf6\xd94jh\x00\xb4O\xc9\xa7\x0f\xa3\xc5\x87\xd1\xcc\xd0\x13(54$\x98/f\xe3\xcbE\xfc\xd8tF\x16\xd3\xd9\"	s$\x93\xd1\xfb\xeb\xf1\xfb\xd1\xe4rd~\x9d\x9aV>\x8d\xe7\xa3\x0b2\x9c\x8d\xe7\xe6\x811tK>\x0d?\x93\xe9-L\xd9,\xd1\xed|\x94\xc0\x9f\xd1\x86Ma!\xc9\xf8\x1d\x19^\xfd46\xc3\xb6\x0f\xdfL\xe7\xf3\xb1\xdd&@\xb2\xcb\x0f\x96\xdc\x83$\xf9\xaf\xcf\xfc$\xc9\x9a\xebM\xb5\x1cdb\xfbr\xf8\xe7J\xb2\x97k\xf1\x82\x96\x8ak&\xb7A\xf6<\xf6\xd0)as\xea\x93\x18\xc9\xf3q\xbc \xd7<c\xa5b\xe4\xfc\xe3xq\x91\x1c\xc8\xa8\xd7\xbf$\x1fy&\x85a\x0e\xe4R\xc8\x9d\x90\xc0\xe8\x92\xe4\xc6\xb3\x01\xc2\x15\xd90\xc9\x96{\xb2\x96\xb4\xd4,O\xc9J2\xe0_\xd9\x86\xca\xb5\xe1\xb0\x82\xd0rox\x87\x12%\x11K#\x17\x0c?\xa5 \x12\x12\xb1\"u\x1ed8/UJd\x9c\x1a\x11Ug\xb2+^0\x85\xa2\xfbln\xdf8\xbb\x80NrF\x0b\xc7\x99\xe7M\x86&\x99\xe1\xb9\x19\xca@^fE\x95\x9b1\xb8\x9f\x0b\xbe\xe5\xb6\x07\xf3:\xca\xe1D\x0b\xc3OS\x18gJ@\x8e\x9a\xff3\x98\xd6\xaeZ\x16\\mR\xe2\xd99K\x892_\x02US'\x0f\x14+\n#w82\xeext)J\x19a\x05\xb2%\x912\xdf<l\xc4\xb6>\x13\xae\x92U%K\xae6\xc8\xe1sA\x94\x80\x1e\xff\xc82m\xbe9&\xbe\x7fH`\xc9\x1bb\xd8J@+\xe8\xb8\x8a\x98\xbb\xfbImhQ\x18\xa9\x82\x04c9\xe1eb\xber\xd3\x91\xa6{\xa3BiN\x0b\xb2\x13\xd2\xeb\x03\xf1\xd0AV\x8c\xdaE\x85\x15\x00)\xf94^|0G\xd6\xf2m8{\xe6(\xff\xdbxr\x95Fr!\xb1\x0c>\x12\x06\x8e\xf3\x91\xc0\xf9\xe0$?*\x02\xd2$\xe2\xff\xa4\x9d\xffO\xae\xc8d:\x19O\xde\xcd\xc6\x93\xf7\xc0D\x8f\x08\x81dx\xbb\xf8`\x98=0\xea\xa6\xd4;\x94\x00 RR\xcf\xc5\x1d/K\xda\x99\xe4pB\x86\x97\x86\xd3\x9ai\x04\x8ei\x98c\x8d\x0d\xa6\x9e\x0d\xbe\x9bM?\xa6\x89\xe5\x80S\xc7i'#l\xc5\x90\x9a\xd4V\xc4\xb0Z\xe4\x93\xbeAr5\x1a^\x8f'\xef\x0d\xaf\xaf-_\xd7\\p$yvM\xd7L\xae%+\x0d\xa3[\x97U\xc1\x97m\xbc\xf0\xd8\xa3\xa78\xde\xa9O\xf2~rK\xde\x8f&\xa3\xd9\xf0\x9a\xdc\xdc\xbe\xbd\x1e_\x92\xeb\xf1\xe5h2\x1f\x19\xf5\xac\xed\xf3\x13\x93pR\xbeO\xc9\x9b\xdf\x92\x7f\xadJf\xb4\xfa_'	\x89\xd8\xe8%\xa8\xfa\xbf&\xef\x0cG\xf4\x07\xf9\x9d\xa8\xca\xdcj\xe3\xa0\xdc\xfd\xceL\xf5\x87\x97/Wj\x05\xe6\xc6\xbf$dt\xcf\xe4^\x94\xe6\xd4G\xda\xba\x16p|\xe1\xc8\x06\xaeC\xee\x99\\R\xcd\xb7\xf6X&\xc4\xb1T\xcb\x8a<\x0bM\xc9\xb2\xd2\x86/\x97k\xc3!\xb86\xcd\x97B\x13j\x98\x06\xd8\x1bG\xa6\x0b\x9f\x1b\xc9\xe8vY0\xf3\x14X/\x86j\xacd\x92\x16\xe4\x06\x18\x9f\x17)\\\x11\n\x82\x00\x99g\xc1V\xda\x8ff%dRc\xf6\xa81\xdf\xf12\x07\xd6\xf1 \xe4\x9d\x1a\xb8N\xec[\xca\xbcF\xb6Bi\xd2\xf2\xeeN\xd2L\xf3\x8c\x16\xf82\x1899S|]\xb2\xdcpqM\xef\x18\xa1\x0ftO\xf6\xa2\x920\xb0\xdc\xf0WaX\x9cm	\xc8\x82\xb6\x02\x8e\x80\x90\xb7{\x02:6U:M\xf4\xc9\x19\xf3R\xb32\xc7uZW\x14\xe4!k\xf6\x98\x1c\xf4h\x18\xea=n'\x98?5\x9a\xfaZ\xd2\xed\x8b\x17Z\x90\xad\x19\xba\xaa\x8c\x000RlKy\xa9\xa0\xb9@CC\x19\xd3\x08\xd7\xca\xc8+cL\x92O`\xda\xb0G\xb6\x9e1#\x8d5\xf2\xc8\x9c<\xc9\xc5\x8a\x98Y\xb8\x1e\x7f4c\xa1\xbb]a\x84\x00-\x940\xf322\x1eW\xc3\x90\x8fHV0\n\xc6\x88\xd9\x89\x86\xf4\xcb=\x8c\x90:\x83\x97|\x16\x15\xc9h	-\x99\xdfL+@-;\x7fe\xc4\xba\x80\x9d\xf0i\xc3J\xf2\xc0\x8c]B\xef\xccp@\xc9P^\x8e>0Xs\xc9VLJ\xb3\xb7\xb5p4O\xcd\x0eOv\x92gl@\xc8\xb4\x92Gf[\xdf5\xa4Fz\xb0\x8e\xf7\xa2J6\xf4\x1e\xb7H\xb4\x85\xa2\x93\x18\xc4|m|\xe4\xdc.\xb7\\\xe3\xf6\xd7\x1b\xb6%|e\x9a$\x0f\\m\x8c\x02c\xbb \x92e\x8c\xdf\xb3\x9aE-$\x10j\xcd4\x1cZx1y\xa0\xa5\xf9g\xf4\xaay&\xda\xc6\xbe{!a\xb5w\x9ce\xcez4\x16s\xc9\x1e\x12\x18g\xa07*\x03\xb6\xb9\xbbR<\xf8vs\xd0/`\xcf\xf0rm\xcf\xa70\xafj\xa3~\xc0\xba\xa1\xda\x04\xabQ2\xa4\xe1N\xb2{Vj\xdc\x19\nLN\x92\xb3ro\xd6\xc8L\x02\xdb\xb4~\x0f\xb3\x8f\xd5\x9d\xfd	Ng%\xa59R2\xd2\xca\x06\xc0\x17$[	\xb3\xf0\xe6A\xb3(I\xc6$8\x1c$S;Q*\xbe\xe4\x05\xd7f1,\x99[W)\xa6Rj\xba\xe7+\xb3\x03\xad\xaaG\xb8\xfe\xe1\xb0=-\xe0;\xd0\xb9\xa2\x8d`\x8e\x08\xcc\x11(\xf3NH\xc2\xbe\xd0\xed\xae`\xe9\xa3#PU\xb6	'>%\x0f\x1bfZI\xd6F\xd7\x06\x8a\xc0\xe9&+f'\x0b\xae\x8d\x1dU\x8a\x18]\x15\x95>\xc92\xbe\xe3\xac\xd4\ngD\xb7,\xb1\xe3R\x07\x1b+\xb7'\x0f\x1ajlq\xbda{8t\xa9{:\x89\xb6\x1eR\xcb\xef\xca\x01!\xc32\x0f\x83R\x1b\xf1@`g\xdb\x8d\xc2\xe4\xd6\xa8\xf5\xd0j\x02\x9bIo\x18w\xdb\x04\xe8t\xc5\xeeY!\x8c\xde\x8b\x03\xb0L	\x19\xed\xcdu\xdb\xf6\xb2~\x94\x07A\x94f;\xf5Cr\xfe\xfa\xc2\xd8\x0bL\xeaH\xb9\xb5\x8a\xbc\xaai\xda\xe7o.\x88X\xad\x98\xc4\xfde\x18\x93=\xfc\xc9\x9a\xdf\xbb}W\xb0\xb5Qf\x836l\xc5n\xac\xe9;\xe5\xdeo\x14\xbf\xea\xe0\x17\xf2\xb3\xfa\x0e\xad\x19dy\xdf\xb9\xe9\x00\xff\x85i\xde\\\x93\xac`T\x16{\xc2\xbe\xec\n\xc3\xdc\x13\xb7\x12\xa0\xf3\x93R\x90\x07*\x8d,\x01\xdf\x16\x8e\xba\xc6]\x06\xd8\xf1R\xe8\x0d\xb2\x7f\xe84\xf1\x9d*z\xc7Bw\x92\xfd\xa9\xe2\x92Yz\xa3o\x90\xe5A\x00-\x19\xd9Ry\xc7\x8c\x0d\x96 3\xc9S\\E\x1c\x16\x07\xfe\xbc,\xd8\xd6,EQ\x80\x02\xb1d\x84jK\x9b\x9c0)E\xc9D\xa5\x8a=H\x06\x1c\x89\xd9\xee\x86\x1dpQ)\xdf\x1f\xd0m.\xb6@4\x9e\xb5pa\xc3-p^\x84f\x19Sp\x02y\xa9\xb4\x11yB\x12Y\x95\xc9\xe14\x1a\x87\xdb\xbc\xc0s\xd8[\xdb\x94\xd0\xc2X}k\xf0\xc6\x91--\xab\x15\xcdt%\x99L,\xa7S\x02\xb8\x8c\x91\xe9\x8a\xac\x8c\xcc\x04\x0b\xb40b\xaa\xcc\xc4vG5_\x16\xcc\xbb\xf4\x08\xe5\x86\x03$n}\xcb\xb5[\x89HN\xb4pf\xe4eD\xed\x95f[\xaay\x96\xec\xa8\xd6L\x96\x815,\xcd\x91\x10YVI\xf0rBg\x92Q$\xa6u\xe8\x99}\xc1\xcb\x9c\xdf\xf3\xbc\xa2\x85!P\x02v\xeb\xc3\x86g\x1bP!\x0d\x87P\xac\xd8\x1b\xfe\x82\xaa\x04W(\xda\xab\xd2Pu\xa7\xe9\xb2`u\xd6\xfa\xc0P\xdc\x85\xc50\x04\xb1\x14v\x046[\n\xd8\xbc\xd8\xf0%G\x1ea\xb51f\xf7\xabP,qC\x1d\x102\xb63\xf3\x9b\x88J\xaeXlH\x02\x95\xad.\x91\x0b\xd0x`4\xe0\xab'\x92\xd1|\x0f.\xce/F\xdd\xb2\xb6\xab\x14\xf7\xdc\x1dV\xe8\xd2\xbdi\x9aZUfu\xfd\xe6H\xc2\xd8SB\x15\xc8*'\xad\x90\xdd4\x18;*Up\xc2yi\xc6\x97\x12f\x14t\xc7\xb6\x0d1\xf5F2\xaa\x99\xa1S&J\x98J\x01:\x8f\xdf\x82;\xf3\xb3\xe1{sM5S\x86_VE\x1e\xf4o\xf7\x80\x950\xe0\xaep\xac\xc4l?\xef\xfc\x17\xab\xa0\xfb\x89\x92T\x9a\x17\x83]%wf\xd6fsV\x9aI\x85\xba>\xec\x18\xa1,{\xcf\x05\xc8f\xa3q\xc0\xb9\xbc\x17\x1c\x9d\xdc\xe0\xee\xa5\x05\xc9\xcd\x0e\x95\xf8\xb0\x1b\x0d\xeay@\x1eT\xe9\xfd\xac33\xfe\x04D\x08\xd7\x84\xadVf\xeb\xdf\x9b]\xb6\x93b'9\xd3T\xee\x07VM@5\xc0,V\xe0ET\x19\xd1\x83\xac(q\xfde\xb4\xb4\x1c\xc5\xf9\xb2#\x15\xc0u]\x8a\xf2\x85\x19\x8b7\x13\xec\x0e\xb7B\x07\xd4\xad\xe0\xcb7\xfb\xd0pp^\xae#&n}\xf6\xb5\xf8\x88\xf5\x9f\x1c7\x84\x16\xa3\xd9G\xef\xc7\xbe\x1a\x1b{zn\x1e~5 Wl\xc5K\xec\x11\xde?[D\x02\xe6\x0cuSX\\w\x84\xbe\xf7\x87\xe8\xa8\n\x8e\x0dy\x93\xf2\x0c\xb5\xed-\xa3\xa5\n\xc2\xeeE\xc1\xef\x18)\xe8\x83e\xea\xa8Ok\xd10\xac\x120kR\xcbW\x14Ql\xcb\x0d\x91\xaaL\x1bIF\xd5\x9d\x1f7#7H\xe8x\xd8F\xc1\xf7}R`\x7fF\xd1\xb7\x06ZN*\xbbJ\\%n\xf4\x84\x8ch\xb6q\x8f\xa0Y\x98\xe7\x92)\x05\xf2\x85\x9c\xedEu6 \xe4\xcc\xbe\xc0\xd4\x19,\xc9Y\xd0h\xce\\d#fpB\x12!\xd7\xb4\xe4\x7f\xa6\x81\xde\x0bA\xceP\x1e\x9f\x11\x8acCB9\xb3\x19\x94O\xa3M\xe5t\x07g\x0e\xa2#Tj\xb7\x0e\xe6\x9d\x84\x97f\x9fS\xb51K\x84\xd2\x12\xddiN\xb5\x08\x9aAj)\xac7\xb4\xb4\xb2\x04\xb4Wc\xc4\x95	\xfbB3TI,\x9b\x97LU\x05\x08\x07\x18\x1c7{\xbd(\x0c%\xec\xc0#	vf\xc7\x94\x18\xe5\x80;\xa3\nTA\xf8\xebl	\xc6\x95y\xd0t\x1c?\x05\xc4\x18\x92\xb3L\xdc3\xc9r\xf8\xee\xcc\x92\"\n3U\xa5\xef\xd3.v\xd4<\xb4\x9eX%\xca\xfe\xec\x89lN7]S\xcd\x0e\xe9\x9c\xc36\x01\x13\x01\x85#\x88\x04\xaaS\x1f\x8d\x8c\xa9\xf7\x00\x0c\x10\x18\x08*\xc8\x92e\x86k\x82\xd7\xd4lM*y\xb1'\x05\x87\xddf\x0c'^\xae\xccj0\xe0\x87\xb8\xe1\x80?e\xf0DX\xa3\x82>\xa4\x84}1\x82\x8d\xb0/,\xab\xb4ux\x983\x9f8&I\xbc\x06\x87\xfe\xe8\x9d\xe4\xf7\x14\xd5s\xb3f7v\x9e\xe0\xe7F\x0f\xa8\xf2|\xa4\x16\xc5#\xe7\x8d\xa0+\x89\x99\xcaE\xea6\x06\xbd\xa7\xbc\x80\xa1\xa2\xe6\x9e\x80\x139C\xed\x14b\xba[\xd3{Uj	\xf6\x01,\x96\x11\xa6\xf7h{PE\x1eXQ\xf8\x95\xc8Dy\xcf\x9a\xdb\xdd\x9cSs\xe6\xad\x8a\xe0\xa7\x00\xbc\x81\x95\xa6{\xdbtb6\xbf\xb5i`\x15\x8c:e\xcdO4Q\x06\x84|\x04\x85\xa1\xd4\x0c\xa4\xba(q])\x08E#\xf5\x8c\"\x95P/xH\xc9\xb4\x19\x8c\x8dC\x97\x82hIKe\x94n\xf0m\xa0&m\xfdN8~^\xaeq\xcf\x96\xa1\x9f{\x86\x1d\xc0\x17+\x9a1\xc3\xb7w\x05\xdd+r6\xdc\xa1|1Ku\x0d\xca\xfa\x04\xfc\xd5\xea,\xb1\x06\x11\xa8\x06\xb8\xf3\xd0\xd6\xb5\x8bG\xb1\xc7\x92;q\xba\x93b\xcbK\x06\xb2\xda(\x10\xcb\x82%+F\xb5\xb7\x88\x8cq\xe1{F_\x85\xef\xfb0\x98l\x8d\x8cD\xb3\xa2@[\xccR\xe9q\x8d\xder\xd2s\xbba\xed\xde\x88'a_\xe0V?v1e\xe77p\x0c\x16\xc3\xc0HUh\x03\xda\x0d\x8c\xd9\x89\x15\x1c)\xd8k\x82\xdcs\xf6`\x17\xc6{\x0e\x03\x03\x1f\xaf\xc0\xf3\x15\xd6a'\x99B\xc5\xc0\x87\xcda\x92\x99\xd8n)H\x1aI\xc4\x0e\xd8r\x9054\xd9\xb2\xb2J\xd1\xd2E\x8a\x13\xae\xd9\xd6\xa9\xb4\xd0\xd2\x961\xb0`\x0dc\x94\\3\x89qfB^\x0f\xc8\x1c\xed\xceKcw:\x99\x7f\x16\x19\xa3g\xd6N\x8e\xd9\x11\xaa\x05+&\x0d\x17\x04\\@\xcc\xe7\xc1\xb9\x85\xc72>\xach^h#\x94\xa6\x18Y\xc1\xe6\xc3\xd92\xba\x07\xf6\x9c\xb8Fi\x8d\xf1\xce\x1d\xa6d\xec\x88\x16^\x8f\x08\x89\xe7\x11\x192\x87\xdf\xc4j\xc5\x8d\x1e\x96xTJnt\n\x96\x1b=\x92\x9a\xa3)\xd6%\xff3\xcb=lE\x91\xa5\xc8\xf7)\x112u\xa4\xcc(\xaa\x88\xbe#\xe5\xa2\xf9H\x05\xb3\x06\xe6\xd8gUA\xbd\xabmk\xc8P\xd0r]\xd15K\x89(qx	76^nT:P\xc6\xe8V\x94\xeb\xc8\xbc\x85i\x03g\xb5\xec\xc55\x11\xd6h\x0e\xb6\x0d\xb9\x06x\x0d7\x02\x1e\xa4\xa3\xe5\xcaA\x8d\xb0g\xd4\x8b\x0f+[\x13/[\xe1)\xb3\x95\xc8\xc3F\x14\xcc\xee\xfcsz\x81\xaeW\x1f\xa0\x82\x87K!\xb7\xb4p\x8b\x9e\xechvG\xd7\xc8\xe4?\xd2?\nI.\xc5v'J\xef\x13\xf7\x96\x92\xe1JA%\xa0\x1a\x1fO\xa2\xc7\xe1\x8c//\x08@t\x14\x11%\xeaZ\xc8X\xad~\x1e\x06l-D\xaa\x93\x83~\xcd\xd1\x17\x84ow\x05\x8a3J\x0e7\x0e,\x18\x0e\x8e\x96\x89\x7f\xd6\xca$u P0*\x99E\xf8 \xf0\x1f\x1a:\x0c\x08\x19&g\x8dQ\x9c\xd9mc\x8e\x9c(5\xfb\xa2S\xb7U\xc9\x16\x1e5\xba\x1a\x86\xf72\xf7Rr~\xc7d\xc9\n\xc3\xe2\xcb\\<X\xfb\x15I\xa3\x04\x11\xe5\x85\xb7\xbf\x1d\x8e\xc4l\x17\nB\x18\x1fN\xce\xb9\xd9\x06\xfb\x0b#\x91q\x82\xc8\xb8\xeb\xbbBV\x86\x8b\xc0\xa65\xdd\xf3\x82Ig\x16$1\xba\x07%\x8ey\xae$\"\x1c[<m;\xc9tx\xcf\xb4\xe9\x1c6\xb0C/\x85D'\x1fD\x84\x91\xd1\xd4\xd8	\xaf\xb7\x89\x08#$RQ$\xba\xee\x15\x8b\xac\xc95\xa8\xf4\x9a\xa5\xce]a7\xcf\n\xc7\x19\xe6\nl\xfa\x02\x06fZ\x8b;\xb3\x01b\xebg\nS\x0d!l\x95I\xbe\x03\xab\x11\x01A\xa2\xb0&_\xd0\x1b\x06\x84|\x10\x0f\xc6dM\x8d8\xcc\x05\xc3\x8d\xee\xce\x9ck\xf6;\x954\x8f+\x10\xb5faj!P\x03\x87o\xf7\xd1\x16\x84\xa8\x84\xf3%\xbb]+\xad\x1d\x17\xa9\x9b\x1c\x80D\x86\x8c\xe0\xa9o\x0c\xd6\x9c\xc7$\xbc\\?\x908\xceA\xc3\xc1\xda\xb6\x82\x89\x17\xfa\x91\n\xe1-4\x8b#\x88`\x06pP\xed:\xe2\x8f\xd6Qo	\xee\xf0`\x8dc\x85\x01$\x07\x1f\x04\xf9\\\xe6$\xdf\x97t\xcb\x8dv\xbfO\n^\xde\x19\x8e]-=i<\xd8\xce\xd9\x01\xee\x98\x80\xef#v~Yo]\x9a8A\xba\xdc\x9b\xf9\xf0\xad\xd1?r\xaa\xe1Xl\xab\xd2\x99\xaf`\xe8\xe2&X\x15\xe2\x81,\x99~`\xcc:\x01\x92x\x0cQ\xf0\x8cJ\xadj\xe4uG\xa3\x8d\xae\xe8\xd9\x8fw\x8fW\xf8\x9d\x07W*\xf0\xa3I\xe6\x0e\x00\xa1\x95\x16\xe0\xdf\x82	\xa2\x1dv\xd8w\xad\xbb\x04\xbb{|,\xf5C\xda\xe4x\xe8\x96\xa1:Qt\x1b\xcd\xec\xcd\x80\xbc\xa5\x8ag$\xc0X\xd0\x80\x8c\x10\x98\x16\xcc\xd2\xa2:\xc1\xa6t?;\xf5M3\x943\x07\xfe\xe7\x1b\xe7\xd7\x077\xb0\xd1\xff\xa4d\xf7\x02\xcd\x95\x08|	@T\xcd\xf2\xa4\x0e\xba$[\xa6\x9d'\xd2\xf5\xcf\xbe\x18{\x87\x1b\x8d\x95\xaeV\\n\x15z\xc7\xab\x12@,,O\xea\xaek\xc7U\x0e\xed=k\x96\x8aJ\xef*\x8d\xab\"\xab\x12\xb19Il=\x82\xa9j\xff\xbd\xdc\xd7\xc9\x01\xf2\x8f\xe3\x02bK)Y\xf3{Vz\x88\"\x08;\xf0\x89q]i\xab\x85\x87\xc6\x9b\xf3\xa3\xd9])\x1e\n\x96\xaf\x19\xce,q\xd1\xa1\x15YQ\x8e\xa1,\x8f\xb34\xe7\xe3\x9e\x16(\x99#\xb0\xe5r_\xb7\x06a\x81!\xecA\xf7`\xeb\xa4@\x18k\x03\xa09[\x1bV\x148\xc9\x05\x84\x10Q\xad\xf6\x96l\xec`R\x82\x14F3\xa2v-\\\xa8\x1b\xc6\xf8\xc0\x15s\x91\xdb\x84\x97f\xd3@(\xd2\x8d\xc6\xaa\xeb\x8d\xce\x85\x0f\x99\xd9=\xa6\x84\xd92\xc8\x84\x13\xb1\"\x1bz\x8f\x87\x8em\xd1x\xabk\xb1\xecKVT\n\xddq\xa6\x89\xbd\xa8R\x8b\x065\x14\xc2\x08\xa2\xe1y+\x9a\xb9\xd0\xd6\n\xdd\xe9e`\xcb\x0f\xe82\xaa\xc3\x84!$(\xb6\xbbb\x0f\xb4H\xdc	PM\xf3\xc1\x1cJo\xe2\x81\xc3\xc5\xc1b#\xbd\x06)\x9cXs\x10X\x97_9\xd8\x1b\xe8\xbe\xac\x94\xf7\xae\xc4\x83l,Zb\xa7\x8a\x11)\xf0\xe4\xd7(!J\\\xa0%\xdb\xd0b\x95\xda\xf3\x0d_\xa1\xf7\x81\x8b2\xb1\xdeC3\x94\x14\x0e2\xcc\x0d\x9d\xa2\x91\x9f{\x8bG\xc6\x99\xf6\xe8\x1d\xc3\xb0\x1e\x86\xb1\xfd4X\x1e&.*\xed\"\x11\xdcX\xdb\x05\xae\xd7\x86\xefP\x04\xedE\x05{\xf5\xd2\xd3\xcd\xba9|x=\xe32\xab\xb6\xc6\x020\xba}\x0d b\xf6\x88\xd1\xd5\xcd\x1b	\x12'\xecQ`0d\xc9\n\xf10 d\xee\x90j\xa0\xbe\xd7` ?\x12\x85\x94 \xaf_\x81{W\x19\xad\xa1*K\x961\xa5\xa8\xdc\xc3\x00\xbf\x1f\x18>\xe2\xc2\x1d\xb7\x18\xee@s|\x86\x07\xf6\x9d!\xcf\xb0\xd4\xfc\xc5%\x0c\xf9\xde(\x92\xa2$\xd7\xf68NDm\xf1\x02\xdc,gl\xcbr/\xf6\x8d\xae\xe4\x9c\xcbD\xb3lS\x8aB\xac\x8d0I\xb6\x8cB\xf42\xd0(r\x07\x15\xf4\x81\xac\xaab\xc5\x8b\x02\xf6\xcd\xb2\xe0k{:\xec\xf3\xc6\x0c*X\xf2\xfa\xb5\x13A\x9f\xc67\xd3\x88qh\xc9\xa8\xde\x13\x9a\x8b\x9d\x06o\x1by\xf3\x8a\\\xb1\x8cm\x97L\x92\xd7\xbf\xfd\xed\xaf\xcc\x99J\x14\xdfrcL\x81\x0b\xd6m\x11\xb7U\x1d\xf0\xb0\\\xdb\x95sd\xb0!\x1e7\x07\x15\x80\x0ex\xc0\x80+\xd4y%\x86\x80\x1f\xa8!\x84\x99\xac\x0dU\x8a\x07\x06\xb6\xc4J\xc8%\xcf\x93\x83nj4#\xae?Rw\x96\x80\x86Q{\xd5X\x7fHxd\xa8\xec\x0b\x93\x19\x87\x0dcYr\x8bx\x84M\xec\x03\xe4\"i\x1eQ\x14\x856\x1e\x0exz\x98	`g\xb4\x15Y \xc8\x9c\xc9\x00ZM\xddI\x1f\x1bX`\x11\xa26.V\x84\x95\x86\xbb\x82\xf9H\xd7\x86\xe1\xeaX\xb9\x05\xdd$\xc5\xe3\x8e\xa1T\x89\xbb\x8c3\xf5\x9d%\xa6\x9d\x99\xa7\xe6\xc1\xa2%\xed\xd4\x84\xd5\xfb\xc5 :\xb7?9X\xd6%\xba\xd2b	dW\xb7\x81\xdcr\x13\xb3\xf2\xf9;USiP\xb8$\xceA\xc75\xd8n\x86x[\x96\xf3j\xdb\xce\xa6K\xb5\xe3Y\x85\xb1X\x08\x0b\x07\x07V\xb1w V\xb3\xb3\x195\xeb\x8f\xf0\xb2G\xdd\\?&w\x8c\xed\xcc\x8a\xd1\x0c\xdd\xe8\xf8=\xb2\x18\xaf\x08\xd6\x95&\xa3\xfe\x94\xfb\xa4\x14\xe5\x0b\xa7\x9e\xdc\xfbhMn-w\x9aeB:U\xdc\xb2\xa0_\x87p\x06n\xa5\xfc\x91\x01X\xfa\xd1\xa5be\xc6\x90w\xec\xbd\xb7\xedG\x18\xc6\x1a\x0eOQ$\x11j\xa2\xdd\xfbE(\x08u\x1f\xe4\x8d\xfd\xe0~!\x11\xd4\x03\x00g\xc93\xd0LJa\xff6\xc2(\x905^\x14\xa3H$\xee \x98v\x10\x96\xa0\xaa\xddN\x18\xa6'\x83\x8b0`\x06\x02\x14\x04\x86\xf0\xcbx\xb3}t\xba\x9d\xd5\x8c\x7f\x8a\xe3\xeb\x8d]\x17\xfb\xf8\x0f\x14U\xabm4]b\xde\xda\xe6VS\xac\xbdd\xbd.\xce\x1d\x16\xefZ\xc7\"X\xe2U\x04\xb7\xb4\xbfh\xdb\xb16\xc0\xc5l\x80\x06\x16T\xb1:\x9a\x99\x10B\xe8\x05(\xaf\xe8\xef3\xc2>\xa3\x12#\xb2\xd6\xbd\xd8\xba)=\xb8\x87\xb3\x1c\x9a\xe1\xd6\xa3c\xf1\x1f\x14pk\xf7\xb4\xd4\xc6\xbab6\x0e\xb8\xfc\xa6\xbe \xc8\x0e\xef{,\\\x9b9\x81'\xa3v\xf5\x08\xce\x03>ki\x05\xcd\xfc\xda\xa9\xca\xd6*\x04\xaf\x91\x9d\x0d\xba;\xe3\x1f\x8cA\xe4\x08m\x16\xd0\xb4pv\xe4\xe0\x9c\xd9\x89f\x17\x01\x1a\xe4tX\x10\x11\xa5\xe6\xd2[\xc2\x91\x0f.\x8a\xf9\x99\xf7\xdd\xa40Z(Jf\x9e3\xba\"\x9a\xe1\x82\xec\x84RL9\x00\x01\x0d\xd1\xb1F\x03\x00,\xd1\x0e\x8b\x80, \x8d\xcfcC\xd4{n\x81{#GR\xd2\x029L\xea\xb8\x07\x8c:\x16\x1f\xd60\xb3\xe0I\xb0FS\xbbdk*\xf3\x82)\xd8\xb1\x16\xd9\xb4G\xe7;8\x13\x01GU3\\\x0cc1z\x14\xbc_\xb7\xc1bZ:k5\xc2K\xd2\xbd\x8d\xd6\x07\xdf\x0cn\xce\xf2\x9e\x16\xdclD\x0b\x99\x88\xee]\xac<\xf4\x8d(\xb6\xa3\x12\xf9\xb9\xc3x9\x07\x17!\xf9\x05\x19G\xe2rC\xd5#A\x16\x95\"\xbfB\xed\x19\xc3\x1e\xd0\xca\xd1\x90\xcb\x8f\x866\xd6\xb3T\x13^\xcd\x9e\xec\x84\xbcO\xda\x02\x12@\xef\xb4=\x1d\xef\x05e64\x01\xb3\xf0>\x080|@\x17G\xd4\x0ez\xe0\xd1U\xe8u\x85\xba\x0ee\xefG\x02\xe5\x1d\xe5l\xfc-g;V\xe6\xac\xd4.T^wC\x81\xe9k\xb4\xf6\x12\x03D\xa08\xd5\xe0F5E\x07\xf8{\xbd\x85Ll\x97\xe0\xcfw\xb1Q\xe7\xd6AucK()\x8c<\xf1~\xf9\xd4\x18\x8c\xc6\xd8-	%\xf7\xa2\xa8\xf0j %J\x0bI\xd7 nj\x81H\xa7\nD\xc1\xe529\xa3\xeb\xb5\xd9\xd0\x9a\x9d\xb9U\x8aI\x04\x93\xc7k\xa76>\x1dD\xbe\x1dy\xe2\x9c\xa7\xa8\x9a\x81\x90E0\x96\x90u\xc5I\x1c\xb4\xff\x9dE%'K\xb6\x17@\x12\xeb\xfd\n\x11}k\xf4\xa2!3 d\\\x82\xc9\xd6\xb6|\x10\x9f/\x89\x9fQ\xf0ff\x14\xd1\x83\xd1\x914|(\xd6\x1eB\xd43\xe8\n\xae!\xd8;\xbf\x8ae\xeaD\x94/\xac8}'\xe4\xf6\x88,\xad;JZ\\\xc5^\x02\"3J\x82\x04T\xe4\x17@\xfc_\x1e\x15\x84Q(oK\xb3\x0d/\xd9\x0b\xc9h\x0e<\xaf\xd5#\xd6\xe8\xac\xa9\xd2\xe0~*Y\x10\xac\x0ft\x1f\x89\xd4K\xdf_\xc3\x8d\x0e\x8a\x01\xdb.E\x8e\x1e\\\x08\xe5m\xf6\nt`\x8b\xee\x82F\xce\x83g:z\xa2e\x8f^\xa4\xa0\xefmw\xb4\xe4\xce\xaf\x84\\\xa2\xdd\xd5\xc7\xbf\xa0\xb6BI^I\xf4\x9f\xb9\xd6\xb1A\x94`\x95\xd2b\x8b\xb8\x01\xd8\xb3\xe0\xa3\x0d(@\xcd$\xe2\xf0\x82h\xffY\xe7L\x91\xa9\xd9\xfb\xb5\xa0\x05\xa6\x04\xb8>j{\x9a\x18\xd5A\x03\xbc\x8c\x91=\xa3\x12]\xb7\xd1#(9#\xff\x93S&w(\xad$\"\xab\x912\x91\x92\x89\x8e%tj\xf8\xa9lE\xce\n\x90\x97kkd:)nE\xb7\xd54bJ\xd9\x18&\xe0pq\x11\xbc2\xfd\x98\xdf\x16%|\xbc8~\x07\xd8\x11Y=\xea\xa8\xf31m\xdf\x0f8\x11 \xf8\xd3\xf7C\xeab\xa3\xa0\xb9[)\xbe\x15\x88\x03\xb0^#\xc9\xa8\x12\xa5\x85\x9a`\xe8\xdb\xf5Y\xec\xeb1\x0d\xab\xcf\x04\xef\x97W\x8baW\x9d\xbf\xb9\x88\xd0\xac\xd6<xl\xf7\x1b\x95\x9b:l\x05\xc6\x1eaw\x94\xc2\x9a A\x83\xb3\xfb9\xe2\xaeu\xeb2^:\x8b\xe5\x88\x16\xecp?\x12\x04F\xde[\xafU\xeb\x00c\x1d\x8e\x16\x9a\xc9\x92\x82z\xc1\x95s*\xa1\xa3Xd\x19U\xa0\x99\xa19JJQfb\xbbe2\xe3\x08\xac46\xaai\xc5\xf9\x95c\xe4z\xfb\xf0Q\x86\xfa\xc3\xe3\xedH\x9c	>\xb1t\n\xe2\xaf\x96A/:r\xf0\x97\xd6\x1a\x83\xe3\x8ckd\xc9\x8f\x91\x19\xf0\xd3\xc3.-h\xc6\xc8y\x13\xaa\x8f\xebq\x81\xaa%R0x\xa9\xa3U\x7ft\xc1\xadE\x85\x91\x0b\xbaw@\x9b\xf0%v\x0e;\x00ZYU\x12\xbd\x83\xb8\x1bPPy=\xc9\x1a\x06\xb5\x9b\x02O\xd9w\x0d\x0b8\"\x13\"y\xcd\x978\x12ka\xb8&\xeb\xacT\x1d\xec\xdd\xf4\xe8V\xc2\x83\x87\x98?<\xde\xdc\xd0\x10\\T\xb8\xed\xcf\xd13\x84\xfc\x00\xf8\x9d!{\xf0\xe6\xec/\xa0\x0d`\x1e\x96\xd9\xa9x	,\x84+\xf2|G\xf2\x17\x0drc\"q\xb4\xba\x00\x9f\x1f\xbc\xc3\x8a\x94\xec\x8b\xbfn\x1b\xcfRQh\x15\x11\xd6F\xa1\xe3\x182<J\xdd\x01!\xb3\x9a\x99\x01\x9a\x91\x9d\xe4F(\xbc\xceq\xf4\xf5\xd4\x9e\x0d3Z\xe7\xdct\xc9\x1f\xc2\x95\x8e`\x85F\x81Z\x10\x1b^d\x84\x88\xb52;\x19\xe3\xcc\xaafM*{j\xd8\xd1SS\x81_p\xc7\x98|\xa1\xc5\x0b\xf3\x7f\x04~y\xb0\x9f\xa30\xb4cF\xceK\xf4\x17` \x90\x01\x9c\x04i\xd7\x12\x03\xaf\xc7\x06M\x13v\x87\xd6|\x81\x92\x91%Cn\xbb\x02\x81a\x97\xc9\x06\xb0\x01\x1a\x11\x8e\x8c\xf5\xddXC;\xe2\x11\xb9\xb5#\xd0<\x00\xd1\x82W\x9b[X\xa81\x12\x84\xaa\xc7\x85\xb9\x0d\xbf\x98\xd9zgI\xfb\xf92'\xa3\x16s\xdf\xa7\xe1\xd4.}\x14;\xaf\x87R\x0e\xf8`\x84>\xba5\x16\xdd\x0d\n\xd03\x18J$\x9e\x012\xa8\xaa-Z\x18\xf0\x88\xb3r<\xc0)\xd1\xb4\\\xc3\xf5\x06\xbc\x8f\x8e\xca\xce\x8eI\xbd\x8fq2r\x0bL\xdc\x0bU\xf7pJVt\xcb\x8b}\x9a\x08\xb3\x8b+\xc56\xa2\xc8]\xe4J\x05\xf1\xe7\xc2\xc6>\xde\x0d\x92\xb9\xc0\xd6x\x99E\x19\x07\xc0w@I\xfe\xc0\xc0\x95\x0f\x16\x02\xc9\x99Qq1\x97\x80\xbd6\x85\xf82P\x1d\xb8E\xfb\xd5&\x9b&\xb9\xa8\x96zU\x15\x00\x93R!\xe4 \x99\x12\xc5=\xd2yE\xef\x05\xa0\x15A\xed\xa0kw\xc3&\x06N\xb9\x1b\x0dA6\x01D+BV\x19\x9b'%g5B\xd5\xe0\xd4\x89\xde\xef@Q\x14\x08\x9e\x13e@\x0fQM\xb2\x82*{g\x06\xc7\xde\xf0Ih\x1b4\xae\xfc}\x86F\xe7\x04'\x01\xa7\x83\xc2\x95\x8a\x80\xb3i<\x9a\xd0LWn\x94\xb8D\xec\xcb\x8ee\xa89\xc2v\xdea\x18\xc0\xa5D\xd0\x1b\x9fPf`\xb6\x9d\xd3![\xc9\xde\x18\xb9[\xac\xa8\x0d\xf0\x16DW@\x92\xa0\x14\x18\x89\x9eWF\x95FR\x95\xa2|\xe1;\xc0\xe1V%4\x0d\x8a\x80\xf9\x06R\xff\x00J\x11\xc2\x0f\xa0J\x98=\x06\x1eM\xf4\x99\x01\x9d#\xb0\x96\x9b\x8b9Dgc\x84\xeb\xa0u<\x066\x05\x7f;TP|\xc4\"`\xe0\x96\xe9\x8d\xc8Uj\xf6F\xc6\xf2J\x9a\xb1\xe1\xe5'\x0bT'wl\x8f\xe4E\xae\xc7C\xdb\x8e\xdb\x02\x89-^\x08<\x08\x08\x13b-W\xb5\x0e]\x1b\x0e\x86W\x1b \xa4\xf9\xa1\x07\xef\x13\xcc\"t\\\x9dc\xb5\xe1m+\xa5\x13U\xadV\x1c\x84}M\xc6h\x8c4j^V\x86\x19T%\xf0Q\xab\xf5\xeaM4vdZ\x89\xe3\x92\xbc\x04\x05\x96*\xe6n\x88 \x1b@?\x11\xce\x0bq9\x10\xd7\\2\xb0\xf1\xeb\xc1 \xb3s\x96\x8c\x95dK-6t\xbc\xaaE\xd0\xca\x03V\x19\xfba\x1d\xd3\xb7\xe6\x9e\xe9\x0ecz1$geo\xd0\xa2\x0d\x18S7\x00\x83\"U\x1fokQ\x95\xf8\x08&\xcaB\xea\xba\x8aN\xa2\x85\x8b\xacb\xd7(\xf5\x17}@\x01\xa8\xad&W\x1eP\x1d	8\xaf\xd7Yp\xd5\x8e\xe9\x8a\xeb\xbdWJ\x134\x9f\x01\xa7r\xde\xea\xdb\xac\x8fP\x81p\xa4\x99f\x92\xff\xd9\xe2\x8cY\xd2*\xc2p\xdeu\xe7\xb6#*\xf8\x11\x97,6z\x134\xf4\xc9\xb136 \xe4me\xa3G\xb1;\xdb\xbby\xc0\xa1\x93\xf0\x15)\xad`3k]\n\x8c\xfeFJ\xa0\xcd@\x86\xe6*\x85\xeb\xbb\xfb\xf8l5\xf6\xa4\xbdh\x8djw\x8d\xe2\x80\xd7\xf3X3\x1dyR\x13\xd8w\xb6A\x94\x1d\xb3\xe9\xc7\x0b\x8fY\x8a\xc7\x1f\x19Q\xc7\xa6~\x08\xcc\xa3I\xa3	w\xca\xe2\xe6\x9c=o\x14G@\xa1\xbb\xd0\x11b\xf7v9\xd5\x0c\x81\x116\xf0\x03g6\x1c\x1bO\x07\x19M\xc5\xae\x92\xdfW\xa9\xddJ\xc9\x01y\xfcn\xe6\xa7\x1a5\x82\xc2[?4q\x06\x81\xd5\xf5s\x06>\x91\x87\x0d+#\x9ea\x0f9\xd7\x8a\x15+\x8f\xa2p\xb1\xcc\xdc\xf02\x86H(\x90V\xc0\xeeC\xdc\x18\xb9\x8f\xebHHr\xcfE\x01\x97\xef`rU\x81x=\xb8\xb7)2Q\xb8\xabb1\xa4\x8efR(\x157d\xf1\x19\x8f\x9c\x05\xe4\nG\xd7\xd9\xa9\xc2\xe0\x8d\x8b\x83\x9e\xad\x87\x07/$\xc1\xcb\xde!\x82\xbal\xb1O\\j\x0f\x96c\x92\x01\x1b\x1c!\x0d\xa8\xf0q\x9cp\xd2D\xcdY\xc3\xd5\xa6\xd0C\xb3\xb1\x14\xfe\x8a\xe0\x8e*\xf5`\x06,\xa4\x91f\xb0'\xaarG\xb3;\x88\xbaKF\x81 \xe1\xc6\x1dP\xeb\xd7\x032\x0cA\x99\x05s\xde\xd4\xb3\xe8\xdb\x10\xddPg\xa0\xcaG\xb8\x1b\xb3\xc7-LZ7}\x9b\xee\xb6\x99\xd9\xb3\x16\x8c\x83\x17)\xf0\xee\x1f\x80\x0dK\xf0\x94\x83;\xc7\x8a\xbd\x10o\x1b$\xed\x83\xc0\x9e\xa9\x0d?\xd9@\x93\xc3L`@\xcc\xc5:@\x8dL\x96\x0c\x01#x\xb7-\\1\xde\x93\x07\xbcC\x13c\xd4c/V\x0d\x88\xe1\xef\x7fc\xb4	\x1d}\x07W\x9d\n\xfa\x80\x168m\x1d{\x82no\x07N\x8f\x01\xb4>h\x8b\xbe?\xf3K\x9c;0\x04\x93\x12\xc7\xd0\x8dy\x13\xb5\x8d\xb1\xaa\x16*\xb8\xec$k\xa3\x92\xe0\xad\x85\xe4\x00\x1b\x82\xc9\xb8\x8c\x00r\xd3n\x9f\xc1Q4\x0cz\xaa\xdap1f\x1a\xd4\xe6\n\xc0\x1b(\x89d[a\xb12\xed\xdd\xb8`6\xd5\xf6f\x92as\xe0\xed)\xf7\x8el	\xc4$\xce\x8f\xec\x12K<\xe72\x0b\xa0]\x82\xc1\"\xf1`\x87A\x0b0\xe2l\x9e\n\xb4?\x1e\xdc\x04\x1b\x00\xef\xc1E\x884\x80\x7f%92|\xc3',SLm\xe0\xd8:E\xc0b\xaa\x07\xa4\xea\xa0;\x88\x1d\xba\xf4\x0e\xe0\xecm\x05}\xd4r>\x122\x11\xda,#\\@\xe1\x16\xf7\xe6\x92\xf0\xb8;\xd8\xcd\xa0\x03pU\x8f\x80\xdb\x8b\xca\xcc\xa6e\x80~\x15\xe1r\x80U\x9c\x830\nc2\x16%\x83\x8c\x02xZ\\\xdb\x17\x8f2\x8a:F	~\n\x91\x8f+\x8bF\x02k\xd2a/\x84\xc4\x80\x97\xf9\xb2\xe0N\x89\xf0\x0e)\x87ev^\x9a&\xc2A\x91\xd7\xbf\x04f\xfa\xfaW\xcd1\xfchtLxiyAf\xfe\x96\xe9\x0eS\xbfz\xf1\x15n\xeeD\xbeg\x8c\xb7y\xcc\x0b\xc6E\x91\\>\x0b\x03\xb7YQk\xe4Ai\x0c\x849\x08\xb5B#6\xdc\xea\x02\xb2Hz\x8c\xcd\x19\xcd\xc3&\xee\xe3:\x8c>\xbb0\xc7\xdf\x03\xde\xb6\\y\xdb\xab&\x83\x85\xe4k^z\xe36\xecY;\xfcp\xd1\x16\x7fo3v\xc0\xbe\xf3s	\x89*\xac\xa3.\xa2\xd0\x03\xdc\xd6S\x91\xeb\xd0\xbbap \xd4\xa7\\\nS\xc9/\xc8\xb5[l\x8dW\xf7\xd0\xb1\x01\xa2\xd2,\xbc\xf3a\x98\xc1\xf8L\xac\x18\xdb\x87\xfc\x16\xf1Jx\x1b\xdd\x0d8t\xc4.\xc8\x15\xcb\n$\xa6\x16\x88&o@\xea$\xcd\x99\x99\x1e\x82\x18\xad\xf9\x011\x84\xad\xddk\xe6	\x1cE\x1a\x1eG\xe3\xd2j\x82@\x1f\x15\xfa]\xc5;\x8d\x979\xdb\x965L]\x98	\x0dIK\xdc\x92Y\xb7\xac#\xfe\xbe\x0e\xf00\xdcY\xd5\xa6K\xce\xdd\xe5\xda\xc62BC\\_\xe0)\xc4\xfcZ\xe0}\x80t\x00[+\xb6a8\x91\xd6\xdePFW\x8e\xd6\xe5>~\xceJN\xc4\x0b\xb5\xb6\xeb\xef\x18\xf3-\xdcf\xb1\xd0\x1a\x14r\xad\x04\xf0W\x03\x90\xcb5\x10lM\xa8	\xc8\xecL@\xb6\x11\xc3\xd7\xce\xac_>\x89\x12P\xaa3\x98\xbb=\x89[F\x9d\xa9\x1c`\xb7\xc1\xbb\xee\x84k\x1d\xfd\x97\x03p\xc9\x1a=N\xbas\xc4\xd9\xa3\xd5C}\x12\xc7CLR$\xa0\x8f\x02\xde(\x1a\x8bN\xe1\xa4\xa4e\"\x81a[9\x8b\x0b\xc0\xe0\xb2\x99\xd1M\x0e2\xd0\xf9\xf1%\xbe\xc185'h\x16\x88\x020\xd6_\x00(\xe3\xbd\x96\x1a :V\xfd\"\xf9\xdf&X\xc2\xa6\xac\xcf<\x8a\xc8\xc3\xc8\x0f\xf3\xe5\xb5\xe4\xc7n\x19\xb5\xb1\xdb\x00\xc0\xae*y\x0f\xb9\xbb\x0c\xa7:6\xfe\xd8G\x01\xc3E5\xf7`\xd0\x8f\xd8\x060\xdf\x04SA\x19\xed\xc0#\xf0<z-\xbe\xc9\x94\x02^\x84j\x14\x8b\xd6\xa9p\xb0qQ\xdb\x0dh	s \xec\xeb`:\xda\xed\xc4\xcb\x1cXF\xb9N\x0e\"\x1d5E\xd9\xeb\xf8\xc3\x034Vt~D\xf3D\xa5N\xa1\xb2xu\x1b\x15\x0e7m#\xb4\x93\xd3\xb9|\xcaU\xc3\xfb\xb4S\xc0\x83\x11\xf0#X\xad\x98\xff4\x0ej\xd8\xe9Z?\xc2\x03E`\xfbo\x06`\xa0\xf0\x12\xfd\x111\xe8\x03.\xa3\xf9\xeb\"!\xffSc\xe5\xec=n\x18\x83\x11\x86\xaa\xd8'~'\x1db\x00!\x91\x96Q]5\xdb\xeetti\x04\x8d|\xdf[\xe2\xb3M\x99\x13y/\xb8\xb53\x01,W\xbfb\xa5\xed\x04l\xfa\xc1X\xb6\xd4U\xf2\x18\xba\x00\xac\x04\xb0\xe0>\xe9b\xf3\x1e\x14C\x0fKb\x96`-\xe9nSc[\xaf\xd1\xf5\xf1!B\x84\x81\xf2\xce\xa8\xb2i\x0e\xc1\xfcnU\x11\xb5\xd5\x84e\xe2\xf3Db\xd05rU7\x15@\x02>\"\xf0/\xa0\x01|\x91x%\x14\xa3\xc9\xd63\x0c\x0e\xb5R\xf3\xa2U\x8f\xac]\xa9*\xf3d\x85I~\x02\x11\xeb\xb7z\xc2\x15`\xb3k)f\x0fH\x03\xb0\xca\xa5\xb9\xb7\x8d\xaf(\x87\x94Lp\x80V6`\x89\xcf\x06r@\x9e\xa0-\x8bu\x18\xf0+'\x98\x7f]\x0b\xf2\xabW$\x07\xadf\xa5\xedJ\xc0e\x0c\xbfE?\n\xc9\x04P\xbdv\x03\xe9IDL\"\"Fs:\x98\x92{\x03f\xc2\x99\x8a\xe6\x92\x9c\x9e\x8b\xcd\xb5\xcfQOXq\xa94\xd1|\xcb\xbc1\x12\x84\x9b\xe55bu|\xc7\xb8k\xb4\xa8\x9f^\x04;.i\x0e7\xdc8\xc8*\x1b]\x0c\xadz\xfa~\x1f\xd37\xb1p\x8f\x8c\xf1\x9d7\x9cqP\xe8\xdc\x0b\xfc\xc1\xfczp\xc6\xea\x8e\x1c\xef\xd6\x0b\xa7\xd2P\xcc\x1f2\xb1r\x97\x10@\x9b\x02\xbb\xcc\x93\"\xa4Z\xb6\x1d\xc0D\xcdl\x0eO\xf3\xc0\xc9\x15\xff0\xb4\x05\x1e9\xdfwnq\x17:^\xeah\x07\xa0,\xb5\xc8\xcc?U\xb4\x00\xbbT\xf8\xc4 %{\xa8'e\xd5.\xe5\xa0\x97\xb25\xe8\xb2\xd1f\x0c\xcd~;\x00\xef\xdf\x0e\xee-\x19K\xc3*\xa36|\xf8\x01\xaf\xb35\xeeJ8\xe0d\x1c\x1c\xc1|e\x07\x17\xcd\x84\xcc\x11\xab\xe2\x06*d\x02W\xfcjp\xa4p\xf5qXf\xbc((\xe2\xb8}\x92\x94C\xbc!x\xf3Ae\xb6Q\x08\xeaB\\\xecO\x95\xbbfp\"\xfa\x9dD\xc3\xb2\xe3)\xf8\x1d\x03&\xefw\x87s\x1bPO\xa5\xe8\xde\xb6Q/ ZZ\xcb8\x14\xa3\x8f\x0d\xa7\xc6\x13Y\xc7\x1e\xb7I\x10\x04\x9c7\xee`2{\x0d\x1b-G\xcc\xb3\x13\x9d}\x97\xc5\x12\xaf\xea\xb5,B={\xdcr\x1f\xa5\xd6\xc1{\x87H\xe4\x83\xdb\xa5\xa9E\x03\x80^a\x05V\xa0\xc1\xc1\xb9w\xa9\xcfa\x8f\x18My\xe8$\x9f}\xc4*\xd3W\xe2\xa1TZ2\xba%3\x8fz\x81\x97 '\x95\xe7<G.Z\xd5\x0c\x8e\xbatu|JE\n\xee\xa1}\xe9\x8d	\xf0\xef\xc9\xaaL\xbd\xb6\x80\x1eg\xbb*\xa0wa\x9f\xb5\\\xee5\xc2\xd6\xcf\x82\xcdHZ\x80Z\x14\xee<\xe1\xa5L\x0eT[\xc6\xb1\x10\xe0,^\x87t\xad&\x98r\xe7\x8c\x95\x1a\x8c\xa7\x10\xff9C\x8d?\x8e\x08\xf9\x98\x13\xf6\x83\xf741\xcfU\x9c\x89\x0bU\xb0Z*?#\xf7\xa9R\x0c!\xb7\xa2d\xee\x19\x80\xa7\xa1\xe2q\xd8\xc6\x96\xc95\xee\x9c8\xcd\x17\xf0\xb7c\xc7\x15\x93\xdd \x88\xd9A\xb6Jr8;\x8bq\xc7 \x91\xc6\xc4\x96I<W\xc3\x84\xa3%\x8e\xd9\x07\xc2L\n\x15=\xf0\xb0\xa1\xda\x1c\xd1\xc0\xcf\xdde\x03\x8c\xb5`\xb0}\xff\x1dda\xcc\xe1\n%\xbaa \xc8\xc9\x94&\x1b\x9a\xa3\x81P\x15y\x02\x9e\xb8*Jxg\x13fz\x8d+%\xbb\xa22\xe3\xb2W\x14\x9b\x97*\x8e\x06\xea\xe2)\xf8\xedzdLF\x9dI\x9a\xbf\x03\xa2_\x93z2b{\xdf\xcf\x8bz\xb6Z	\xa9\xd5\x81\xdal\xedm\xc3yZL(\xe5\"o\xf6\x8e\xa1\x8f}6\xee\xe3\x1b\x91\x0f\x97\xde\x8f)\xd2\xb5\xbc\x10\xd6(L\xe2\xfe\xc3\x89e,%R\xecia#e\"\xc2\xcf\xe1\xd5\xad0\x96\xe68\x8e\xa5T\xda\xc73.\xb9\x06\xffZ\xc1\xb5\xbdx\x9a\xd4\x90\xc2\x10Xz\x81w q\xfd\x01\x8e\n\xff\x86\xa0OA\x1fT\xc5\xf5\x859Cl\xed\x8c\xf8$R\xd4\xed\xc3\x81a\xe7!\n\x92\xa2TJ\xa1\x02\x05f\xd4q\xb0FH\xc1N\x0b\x9b\xf3x\x0b\xe8&\xeb\xf5\x8as\xc1\x99~\x02\xf0\xc9^)y\xfdz@n\\*K\x97i\xce\x17\":s\xc0\x9b\x86\xcah\xce\x94\xf7\xe8\xc2\x85\x80\x163\xbe!\xa4I\x08Y\xd6\x92\xc4\xdc\x84\xac\x9bp\x87\x0d\x05Ob\xcf[\xa5BJ\xc2p\x0bB[\x88\x82\x1d\xe6w\xaa6j\x9fu\xcf\xce\xa8\xf1d\xc8\x81\x13\x93\xddF\xa9\x0c\x7f\xab}\x9d\x88\x07\x8bf\xb2|\xb2\x88]\xd8\xbe\xe14\x80\x96\n\xcciJ3\xab\xe4\x08\x99\x18A\x8a\xaa\xbe\xfb6u\x92\xa2*r\x0c\x0bF+\x0e\n\xf7\x96\x96\xa5Qw\xfd}\xf0\xe4\x10/\xbdjn\x0e[\xa1\x04\xfd\xba\x8a\xb4\x10%M\x96\x95\xbf\xaa\xe3\"\xd38\xd5\xa3C\x82\xc0\x13\xe4\xfdj(J\xee\xec\xb7\xdd\xe7m\xe9\x1bOt\x12;^aB!\x93Kj\x17R\x14g!\xcf\x9b\xf6\xc0\n\xe7^\xb5\xe9E\xa3\x02-\n\xcf\x18\x12\x0d]v\n\x1e\xf1h\xd7\x9a\xab\x00B\x0d\x0d\xe99\xc2\xbb\x9da\xd4\x91\x12F\xc1\xaf\xe1s\x0f\xa4f\x1f\x17\xf9\x03\xcf\x03\xd7y\x81	sj&w\xc4\xfa\xeb\x9b\xf0\xc8\x1e4\xcaE\x82\xf9,\x00\x97e\xd6\xd2\x1et\x84\xba\xc3)\xc7#\x8e\x80\xe4\x90\x92\xe2\x11\x95\x04{\xb7\x13?\xb61\xd0\x91\xe5\xae~\xba\xba3xS\xc1\x8b+\xf0>\x9d\xd5'\x89L\xa2\xdc;\xf7HB\xd7\x92Y\xef\x14\x86\xdf\xb9F\xff\x9b\xbd\\FrV\nk\xbe\xa4hJ	\xab\xf70\xb0n!pz\xee\xb3\xcd\x95\xae\xe5\x03]\xd8f&v\xef`\x7f\xf7\xac\xa4x\x8b\x13\n4T\xd6\xef\x8fO\xc4)'/0\x9b\xed\x19\xac\xf3\x99O\xde^_A\x007\xa0v\xe1\xf3b\xda<\xeb\x08T?2\xdb\x83y\xb9\xad\x11\xdfd\x87v\xdbPN\x0d\xf5\xf5\xae\x84\xd5\x80+\x81\x05\xea\xe0\xe5\xc1P\xf1\x8a\x9a>\x06N\x8d\x15\x06\x97!\xa2\x8e!\x86\x10@\xe2\xf3\xa3\xd7+?Y\xaf\xcb\xe3\xf7\x91<\xb0\x9d&\x0e\xd3\x10u\xd2\xb8\xef\xe0\x854\xa0\x0c\xcc\x93\x00'\xe1\xc1\xc5\x90h\x1fP\xad\x94\x8e\xa1\xaf\xeev\xd8\x91\xb9j\x01>G\x91\xf8\xce\x03PUJL\xdc-H\xcev\xd2\xe8g\xc6F\x01@\x8a%\xd1\x92\x95l\xc5\xbdo\xb5\xb1!V.m{\xe4\x85yp	\xcb\xce\xbf\xf7=\xa41GJ\x9e\xc0\x91\x0ea\x04>'\xb6K7\x9d\x14\xb1\xd9\xe4-\xa2p\x0f`@\xc8\xd9\xbf57\x8b\xcbW\xe8=36\x92\xe23\xf3\x00\xe3G \x80s\x014\xb6\x16\xb1)P\"\xc8rr\xe0\xde\xb6\xb9NQ\xffr>\x17\x1c\x18\xde\x1a\x0c\xa2\xa1q\x030\xbc\x89\xd2\xc7\x1b\xac1\xd4\x83\xe7\x86e\xae0}\xac\xcb/m\x83\xa2\x89m\x00E\x98\x8f\x87\xa3&\x8b\xdb\xa1\xe0\xec\x9e\x05\x10\x86=u)\xd9URU\x14\x01Y\xa86g\xa2,\xad\xb7\xc3\x06O\x8cp-\xea\xa0:!\x13\xbb\xd0\xc8\xdb\xc2\xe9Mc\x03\x19l\xb7\x9d\x14Y\xe5l\xad{\xb6\xb7\x96pz`:\xc3\xddu\x88\x1f\xb6\xf1!P\x0b,	\xad!h\x8dW\xde\x92=\xc7+h\xdc\xe5\x15rh_?6'0\x12\x1f\xd00suI\xfebK\xe9\xc0\x9a.[v	\x14\xdb\xc0\xe1s\xd5pa\xe3V\xb6.\x9f\xa2\x88\xaf\xae\xd4\xbb@\xc5\x0f\x1c\xe1\x10\xa9\xf6y\x0d\xac\xc2:l\x12\x86+r\x96s\x95I\x0e\"E\xc8=\\\x8bm\xcb\x8c\x87q:\xcc\xf1\x97\x89]\x84\x1eBd8\x90\x06\xa2\xdf\xaai\xbe\xa0n\xadBF\xaf\x90l\x015\x83`\xe84\xe0I^{	\x10\xa4:\x1c\xf5\xb8\x152\xa8\x1b]M\xe1\x80\xa4\xb2\x9e\x1cP^\x83\x19l\x04S\xd8\x9e>\x08\x18\x01*m,\xd0\xd5\xd8[\x1a\x0d\xd2\x82H\xc3]G\xf0\x93\xb9\xa2\x1b8\xc0\x009\x011\xb8\xa3\xfb-\xe0\x9c\xd0L\x8e{\xa8\xa5\xa4\xb0yi\x9c\x7f\xd5f\x08\x84\x0d\xe6\xd9J#A_\xdc_\xb3m\xd4\xcd|9B\xcf\xaa\x83\xe3\x159\x89\xf3\xd3\x1d\x9c\x0e\xe7xM\xe1NR\xbc}\x9a\x0c\x1f\x92\x92\x1er\x85\xfa5\xbc\x1aK\xf3 Z\x0b\xde9G\xfc\x1c\x87\x8c\xbe\xb9w/a\x86\x7f\xf3\xf5\x05\n\x8f\xe5\x05\xd9I\x8e\xf7\x1b\x11\xe2Y\xe6m]G\xf5\\m\xcd\x08T=\xdc\x1dm\xe5xb\xe9\xab\xf6\xd6\x0f\xb0\x0d\xa4\x98\xb11\xf0\x11\xe4\x98\xd0\xc1n\xd0\xc0\xd6\x12\x7f\xab\xb4N\x93\x07\xaaB\x89H\xefu\x7f\xf3\x1b\xf2\x91\xcal\x03u\xd2\x1c\xbe\x08\xfc\x9c\x0d\x90\\\xb8\xa9\x01\x99\xe4d\xe5c|\xd6\x9c\x8e\xa0:` \xf3-\x96-p\xfd{\xdd!g+\xef\xa6\xa9\xa5\x13\xb7\xc0\x94}\x12T\xe4%\xab#'\xbd\xdb=\x8et\xba\x89\xda\xacV\xaf\xdf\x0c\xc8D\x90\xb9/]$Vd\n\xa9\xdc\xbe\x83j\\\xb9\xd8:\xfd\xad\x91\xec\x0f]\x14\xb9MRF\xce\x9d}\x08\xb9\xec*H\x0b\x83\xe1\x8cH\x7f\x0c\x83\xbdp(\xb6RK\x9a\xf3\xcc\xc3\xf2]\x17m!\xb7\xbdKn\xc7\xbedF\xdc\x9a~\xbdo\xe8\xf8\xbb\x83\xa0~b\xb5\x06\xc7h\xea\"^	\x9b\xdb\xc0\xdd+S|[\x15\x9a\xba\xda0\x88\xd4;H\xcbUs	\xb8\xfc(\xee\x9a\x98\xd4\x98r%z\xcd\x8a\x97\x03\xbf|\xec\xfe\xb1\x03\xe4\x9aP\xc8|\xd2t\x159\x9ehH\x0b\x0e\xbc\x10\x13wW\xeb\x08\xd4\xab2\xbanQ\xb0\xcc\x08\\k\xc7\x81\n\xe4\xef_z\x8d':\xb3\xae\xa0g\x10\xf9\xc0\xb8\"(\xa6\xbd\xa5bK\xd4\xa1/\xd0\x93\x0dJ\xfb\xd8\x96|}\x8b\x1a\x95\xbc	\x0e\x81\x86\x954\x87\x18\xd1\x99\x0e\xa3V\xbf<\x16\xa72z\xfd\xfd\x80\xdc\xaa\xa8\x98\xcd\xfb\xc9-\x19\x1a\x0bR<V\x95\xe2\x9b\x80\x80^\xa1l\xe6G)\xef,GZ\xf2\x92\x1d\xc4'\x9c4\x82\xbf\x9dw\xc7\xe2S[\xabi<:|b\xaf\x93\xa1\x8e\x96\x84\xa4\x1c!\xd1k\x9cy\xa1Q\xb7\xc1\xde\x8di\x87 C(>\x06\xe9\xd7\xd2O\x00P\xc7_\xa1;`\xb2\x89\xc3\xd5:\xac\xf5\xa1\xba\xff\x84\xd9\xa5\x89\x0f\xbc}\x0fx\xa0\x8cI\x84\xedE9\xfc\xbd\xd5\xe5M,\x04\x11D\xa3\xb5t\xb1\xf8q\xbc]\x85\xfb\xe5\x17\x032c\xf7\xdc\xb0\xaa\x9fj\xf5\x96\x1a\xee\x91\xc5#\xf5\x07\x11\xd9j\xb3\x8fI\xdb\x9a-\xaaU\xb2\x87\x1a`L?^y\x11\xcf\x19\xdf\xe2\xb9\xe5[\x06\xd9\x0d\xb3M\xbd\x1d3\xbf\xc4\x98x6W\x1f/\x89\xdaq\xc9\xfdU^\x8bZ\xf4^/0n\xcc(\x11Dh^\xc8\x99\xa6\xbc\x80\xd29X\xc5\x04\xba\xf0\x85\x8cP'6\xe4\x8e\xe2Ln{re\xd3\xa2\x82\xf2`vS\xc5\x15H:\xf7DYm\x97Lz\xe4W\xe2\xc1\xe5\x16\x03\xea\xb4A\x0f!\xc6\x17\xea7\xba\x1a\xb4J\x1a\xb4:\xb3n\xdd\x82\xeapt\xce|-GH}\x1fN)\x0c\x03\x91\xd4\xe0\xbb\x8b|<G*\xedX\x83\x1b\x06B\xb5\x1fa\xe2G\x88U \x9a#p;!8Jk\xfb&	\xfb\xe6\x10\x18\xe7uv\xa4\x93\xd1v\xeb\x14u\xaa\xde\xf1-\x14\xe0c\xd9F\xb8(\x85k\x04\xfcO~|I\xdb\xf8\xa2}\xedD{<\xc2\x83\x05\xdcI\xf1e\x8fU\x15Y\xc6\x8d\x99\x01<\x01\xcbT%\xcd\x1af\x8fl}\xd3\x84\x05\xee;<\xbfi\xfa;e\x9d)u\xd8W=\xa8\x1a\xc8\x14C>\"\x1f\xbd\x13wH\x12h\xdd\xbd\xe1l\xfeX\x8c\\\xc3\x82:u\xcbO\xc2Pum}&1Z\x12\x12\x17Ytn\x12\xe3\xfe#\xfcP)joD\x8aBC]\xa2\xe5\xdeV\x99#\xa2\x05\xe1\x02\x9a\x01rqoN\xc0\xb4\xb8\xad\x14\n\x1b\x9b\xd0\xa4\xb6)\x91\xd9\xfdr\xe0\xa1\xe1\xb8\x95>Yp8\xb2\xb8\x0f#,h=\x99\x86\xca\xd5\xefl9\xe5\x9b\xd9\xf4\xfdl\xf81u\xf5\xa8G\x7fX\x8c&\x0br3\x9a}\x1c/\x16\xa3+\xf2\xf6s2\xbc\xb9\xb9\x1e_Bq\xe8\xeb\xe1\xa7\x01!\xa3?\\\x8en\x16\xe4\xd3\x87\xd1$Tv&\xf3\xc5\xd0\xbc0\x9e\x90O\xb3\xf1b<y\x0f\x0d\xfaR\xd3\x89+5=\x9c\\\xbd\xf4\x15\x9c\xa1\xa4\xf5\xc8\x17\xdb\x8e\xc7\xe4\xean\x1f\x94\xddN\xeae\xb7\xc7\xd0\x90\xad\xbe=\xba\x82Z\xd2\x07\xf5\xb7\xd3\x96\x02\xdc)\xd4\x8c\xb6\xcf>^\x89\x1b\xaai\x1d+\xc6\x9d\xd8b\xdc\x03\x82$\x9c,\xc6\xb3\x11\x99\x8d\xe7\xffF\x86sG\xd8\x7f\xbf\x1d\xfa\x86nF\xb3w\xd3\xd9\xc7\xe1\xe4r\xe4+\xfa\xe3\x9c\x93\xf1\x1cK_\x7f\x9e\xde\x1a\x11\xf1az{}U#\x8a!\xd4\x88\\\x8d\xde\x8d.\x17\xe3\x9fF\xa9y\x92\x0c\xe7\xf3\xdb\x8f#K\xef\xf9\x82L\xdf%\xc3\xebk2\x19]\x8e\xe6\xf3\xe1\xec3\x99\x8ff?\x8d/\x81\x0e\xb3\xd1\xcdp<\xc3*\xe0\xb3\x19\x96\xdb\xc6m\xf4\xab\x01\x82\xcb}\xc0\xe3\xda\xa1\x96\x91cDU\xc5o'\xd7\x86\x12\xb3\xd1\xbf\xdf\x8eg\xb0KH}\x97\x98\xf6\x87\xefg#\xact\x1e\xf6D\xf2i|}\x8d\xf5\xc5\x1b5\xc8Sb\x0b\x8f\x87\x8d\xf1\x99|\xfa0%\x1f\xa7W\xe3wfY\xec\xc6\xb9\x9cN~\x1a}\x9e'1U\x86\xf3h\xcb\x0e\xdfN\x0daBI\xf3\xc5\x14\xa8d\xd6\xcd\x963\x8f+\xb3\x0f'\x9f\x13[X;%\xf3\x9b\xd1\xe5\xd8\xfc1\x9e\\\x8e\xafF\x93\xc5\xf0\x1aI5\x99\x8f\xfe\xfd\xd6,\xed\xf0\xda\xd7Dw\xa5\xccm\x11\xf3\xc5\x87Qb\xcb\x93\x8f'n\xe3,\xa6P\xb2<\x1e\xec\xf9\xa3U\xe1\xaf\xa7s\xb3\x03\x93\xab\xe1bH`\xc4\x8b!y;2O\xcfF\x93\xab\xd1\x0c\xce\xd8\xf0\xf2\xf2v6\\@g\xe6\x8d\xd1\x9c\xcco\xe7\x8b\xe1x\x82\xaba\xe6\x0bG|<\xbbJ\xdc!\x83}\xfbn8\xbe\xbe\x9d57\x9e\xe9yz3\x82&a\x03F+\x81O\xcc/\xd2\xc4,>\x19\xbf#\xf3\xdb\xcb\x0fv\xd9H\xed(\x7f&\x1f\x86s\xf2v4\x9a\x90\xe1\xd5Oc8\x8e\xb6\x9f\xe9|>\xb64\x99\xbeK\xa0\x05KG\xdc}\xbf\x1e`I\x91\x9dda\x07\xce\x0f.\xa9\xc4\xc2+\xaf1=\x7f#\xc6<X\xd46r\x80\xdf\xfb\x0c\x1f\x88\xb4\x0d\x85\xfcP\xf1)DF\x0b{y\x05\xd3\n[|\xb3\xe5\xc2x]\n!\xc2\x89Q	\xd9\x03:@+\xa9]\xa6\x06TPmK\xf4\xc1]\x16Q\x9ad\x85\xc0\x9b\xa0;#\x02\xa1@\x82JhI\xe8R\x89\xa2\xd2\x0c\xb3&\xa3\xfaatt~\xcf\x8bh\xec->\x93H\x07\x0b@\xd2\xda\xdd\xa0p\xb1\xa0N\x88p\xdd\x19#\xa0\x07\xf03\x02\x85\x8au%\x9b9][>\xa3	\xae\xf3\x91\xc2\x83\xe1\xf3\x01\xcb9\x0d\x81D\x08\xe7Z8h\xf9g#\xf2&\xec\xc1\x0d@E\x11$[\xce\x07l\x01\xd0fC\xc5\x82=d\x9b\x86ZM6Bb\xe7\xb1\x86{\x8eJ';a\xe3/\x95j\xdc-E\xabv\xc9\x94\xc6\x04F\x82\xd0l\x03\x1eu\x0f\x03\xb5q1\xae\x93z\xb9lT\x87\x98\xab\x81\x8f\xc5$\xea\xc5w]5U\x1f_R1\xe4|a1b)\xa1ZS\xeb\x0c\x0c\xea\xab\xbb:\xe55\x7f\x87	\x1c\x83\x1fZ\xd1\x95\x99\x9a\x19\xb1\x7f{\xeb\x1eV\xda\xde\xb6\x00\x10Q\x04\xb3\x87\xe9\x98\x9d\x18\x15\xc2L@\xff\x82\xf7l$\x18\xb7J=#1\xb4\x04M\xd8\x92\xa0\xa0{\xbb\xd4o`\xfe\x9ce\xa1\x04d\x81\x16rn\x14I\x01F\x1d\xfa\x17\\\xea\x9cU\xe5\x13\xbb\x9a\xd9\xac\x8cnj7\xd7_\x0c9\xe1}\x97\xe0-\x9a\xffw\n\xae\x13\xd9\xa6\x97\x92\xb3\x15\xe19\x96\x9a}\xb0\x97G\x8c\xb2=\xf8\xabMI\xe4\xb4\xac\xf3\xcb\x0b\xf2\x97=\xa3\xf2\xaf\x84\xfc\x05\x9a\x10\xee\xfa\xde_\xb1_\xc8\x93\x1a\x15K\xad-\xf7\x0f\xce\x91T_d\xaeI\xad\xc8\xb2\xbd7\xd4\x1eQ|TK\xa6\xaaf_\xd8\x0b?\x8f\xd5\xc6\xb7f\xcc\x81k!\xe0(\xf0\xfa\xd1y\xfd\xba\xe9\xc5\xa1e3h'@\x98\xa7/Y\xb5\x11\xbb\x90\xb2	\xacq\xd4\xecWU\x81)i\x8d=\xea\xd45#A\x9c\xca\xf6\xa3\xbfW\xcb\xeem\x94\xc59?\x03\xb3\xc2kWM\xcdK\xc8\xa3\x8a\x17	\x8a\xd7\x9c\xa1%hZx\xcc\x0ew!\x0c4\x93]\xca\xa8\xcf\xa2\xaa\xedk\x8fl\xae#\xeb\x8e7\x8c\x17\xbd\xe2Dd\x81\x96h\x0e\x96B\xa7D1F~\xb7\xd1z\xf7\xc3\xcb\x97\x0f\x0f\x0f\x83uY\x0d\x84\\\xbftp\x8f\x97\xff2H\x92a\xa1\xc0\xa6\xa8\xa56\x11\xa5+\x85\x07\xfeo,7\x0e	\xef\x0b\x96i)J\x9e\xc1\xa1\xd8\xd1\x1d\x93dKy\x11\x0b\xca]l\x87Z\x94u\x11;[R\xc7\xe5\\\xa5\x15j\xe8!ub\xcf(To\x85I\xc1m`\xae\x91\xc7 >\xa5^\x12q+rf\xaf\xac\xfee'\xc5\x1fY\xa6\xffz\xf4$\x1a>`N\xe3_\x0fw fd\x06j\x0e\xdf\xce\xa7\xd7\xb7\x8b\xd1\xf5\xe7\xd8\x86\xf9\x11V\xd3.$\xd1\xfb\x1d#\xff'\xd4w\x7f\xf8n\x10\x9ak\x9e\xe4 4\x80\x8b\xb3\xc2\xf4\x83.\xc9\xda\xc1\x86\x16\xf0\x1c\xfb\xeb\xd0\xde\x87\xf0c\xdc]\xf6]<\x90A\x92,\xccI\xd9\xef\x84\xde0\x08\x14\x86\xb2\x83n|0\x06\xff\xb6\xddy\xae6}|\xc7\xb9\x9e\x07\xf6\x88\xa7\x93\x90\xe9\nT\x10\x1f\xd2\x0e\xdc\xd2u\x9dl\x81\xf8K\x16,\xda\x1f\xad\\\x7f\x7f;\x0eI\x8fm\xf5\x06\x18P\x05^\x06rF\x97\xe6\xe0.\xc5\x973\x8f\x98\xb4C\x06\x94\xe9\x9aY\x06\xc3\xb6\xbbB\xec\x99\x84\xab\xd1\xd8\x8c+~\xe0J\xf81y\x01h.c\xd9\x16i\x82\xf5\xd5 \xde\xa5\xf8\xba\xc4\xdc_n\xa3\x04\xed\xee,\x04\xf0}6\xf7\x15\x89J}\xbc\xf3\xd1\xf4\xfa\x91\xd1P\xca\x99\x86:\x92\xa8\x9b\x99/\xac=\xadC!\x7f8\x9e\xc9\xc9\xe3\x89>\xc3Gx\x8cw\xf6 \xc6-N\x13\x86\xd5\xfb\xfd\xfa`\xce\xb0\xa8\x10\xb5\xfbAE\xb7$\"\x8eL\x89\xaa\x96RT\x9a\x83\x88\xb4\xa9\xd8\xe0\x9a\x1df\xfb\x85\x1b\x9d\xe6t\x021\x901\x9bi\xdb\x91\x14\xbc\x84\xd4\x13q\x8f6H\xa4\xad\x03\xd2\x96\xca\xb1\x8d;O\x16\x9e\xa3\x07\x87Bx\xb0\xc0\x82\\\xa4\xc4!V\x0cA\xae\x99RL\x1e\xf3\xeb\xf1RiF\xf3\x968\xcd\xdbJ\xe3]\x9b\x94\xec \x0b=\x80gZ\x97b\xb7\xe1\x85Pb\xb7\xd9\xbf|\xd8\xec_\x94B\xbf(\xd6\xbbb\xb0\xd1\xdb\xc2\xacN\xf2_\x9f\xf9I\x925\xd7\x9bj9\xc8\xc4\xf6\xe5G\x9eIa8\xc7\xcb\xb5x\xf1\xc0K.\x123&\xf5\xc3\xcb\x97\x8f?\xf5\xe2\x99\x1f\xe0!\x1f\xc7\x0bO\xbc\xf3\x8f\xe3\xc5E\x92Dl4\xbb o^\xbd\xfe%\xf1\xbd'\xc9M\x94{]\x11\xa3l-\xf7!\xa0YG>\xf9 3\xe6\xc4#b\xe9\xae\xce\xa3\xccK\xdc2y\x8d\x17t\xaeP\x8c\xce]@\xc5\xc3\x86\xe5\xe8\xceA\x0ft\xea\xca\xd9\x05t\x923Z\xb8x\xbc\xd7dB\x9a\x8d\xe8\xa6n\x00\x1e\xbb\x9f#3N{\xc4A\xe2 \x1f\x88\xe8B\xd5+\x058>K\x9d\x1a\x15\x15Qgi\x04\xa9L\x9d\xc6\xa6XQ$\xf5p\xf7\xbc&#\xc2\xb9A\x12)\x1f\"\xab\xcd\x84\xabdU\xc9\x1257\xed\x95\xfa\xda=\x89X\xb1\xafU\x89X\xf8;\x9fY\xa3\x82\x89\x8f\x9f\xc5\xf0@w]\xda\xc5\x99\xe3t-4\x9aN\xed~\x83\xc3G\x1fL\xd3\x88\xaa\x0f#2\x9f\xbe[|\x1a\xa2\x8b\xd0\xba\xe1\xae\x9c\xef-=p\xbe\x91\xba\xf3\x0d\xbdnd:K\x0e}nm\xde\x0d\xd3\xe1\xa3\x9e\xb649\xa5\xed\x81\x819\x99N\xc6\x93w\xb3\xf1\xe4\xfd\xe8\xe3h\xb2\x18\xd4\xbcS\xf3\x0f\xc3\xebkp\xc7\x0co\x17\x1f\xa6\xb39\xbap\xea\xfe\xa6y\xe4 zg]O\x97\xd7\xc3\xf1\xc7\xd4\xfbw\x9ck#1\x8f\xe1\xe8\xc8\xa7\x0f#\xf0v\x8c'd8!Cp\x9f\x99i\\N'\x8b\xd9\xf0r\x91\x92\xc5t\xb6\xf0\xaf~\x1a\xcfG\xa9w\x14\xbd\x9bM?\xa6\x89u\x17\x81\x9b\xc8\xbc7A'\x1c\xfa]j+b\x9d\xb4\xd6\xa9\x84n\x96\xab\xd1\xf0z<y?7/\x9b)\xba\x87;g~\x93\xfb{\xc6\xca\x97\xef\x85\xd6\xfb6\xb6W\xfb\xfd\x14C;\xf59\xe4loR2a\xb4 \xf7\xb4$?1V\x92\xf3\x92\xd1\xe2\x9e\x96\xa6\xd7\xdf\xafA\xc5\xcd\xc4\xf6\"\x89J\"bf\x12fL\xc9\x19\xab\xe56\xa76]](\xc1h\xbeY\xf2\xd2\xc8@\xa33([\x0c<\xd4G\xaf\xa5\xf0K\xb1\xac\x86C\xad\x93\x96K\xf7-'\xdc\xd5G\xfc\x81$\xc9\xeb\x01\xa9\x8f	\xf3\x85D\x99\xadl:+\x8fnig\x0cxE\xd5\xa8\xa7\xae\xb4u\xdc\x9f\x05V\x86\xc1\x04-j@\x927\x87c\xe0eL\x057\x86\xb8n\xed\x91a$`\xa1r\xf5\xb5\xc3p\xb6dM~$\x90'\x03\xf82\xc6\xe7\xdd=\xcd\xa8\\\xa2\x8f\xfd\xc7\x13\x18\x10\xc3\xc4\xc6\xf3v.\xf6\xf6s=\x06A\xa2\x18\x04\x9e\xd8\xf1\xdb\xdb\x85a\x10.\xd60\x9c\\%\x86\x13\x04\xc6\xd6\x12 8\x15W Q\\!\xf9\xf6\xb8B`x\xb3Qr5\x9e\x03w\x1a]\x1d\xe1u\xd1,\xa7\x9f&#\xeb\xe1\x8f\xa6Xcw0\xc9\xab\xf1ld8\xd6x\x12\xfer^\xef\xc8\x15>\xfa\xc3\xe8\xe3\xcd\xf5p\xf69=\xea\nO\xceOP\xe4f6\xbd\xbc\x9d\x01\xaf6d\x98\xdf\xbe\x9d/\xc6\x8b\xdb\xc5\x88\xbc\x9fN\xaf\x80\xce\x18\xa3\x18\xcd\x7fL\xac\x0b\xdc\xf0\xbd\x14\xfc\xdf\xd0\xf1\xcdl\xfan\xbc\x98\xffh\xfe~{;\x1f\x03\xcd\xc6\x93\xc5h6\xbb\xbd1\x0c\xf4\x82|\x98~\x1a\xfd4\x9a\x91\xcb\xe1\xed|t\x05\x8b9\x9d\x00g_|\x18Mg \xbc\xdayy`\xdf\xf3\xc5l|\xb9\x88\x1f3\\x:[Ds$\x93\xd1\xfb\xeb\xf1\xfb\x11\x04p\"N\x7f\xe19\xfdxb\xfd\x1f\x9f\xa3\xe8\x002r\xf3\xe7x\xee\xf9vJ\x9cW\xfdQ\x8f9\x89<\xe6\xd6\xc6\xbc\xe7\xec\xc1G\xb7\xad\xe7\xce'A\nN\x9b\x9a\x02W\xd7\xda \x83\xdd\xc6\x16\xeb\x84\xb3n\x13\xea\x98G\xad\x8dg\xfd\xe3\xa1J5\x80\xda|\x06%\x8e\x99\xac9&\xde\x13\x05\xcf Wyb\xddS\xf6\x8e\x83\xcd\xb2\x88.\x9f\xd4)\"\xef$co\xe7W\xe4\x06\xdd\x04\x83\x8e\x05\xd8\\\xd3\xecn\xf4\x05\xbd\xb0/\x1f\xb6\xbcM\x8a\x1d>tJT\x9d\xfa<Yw\xff\x9e@\xe7\xc4\xf5\xde\xad\x02\xefo\x03u\xa2\xc0\x13\x8b0\xeeD\x81'Z$\xdd(\xf0\x01\xaf\x9a<C\x81'-\n|\xd2+\xf0\xdf\xa8\xc0;y\x96t\xa0\xc0\x93\xa0\xc0'\xcfP\xe0[\x98~\xf2\xed\n<\x89\x15\xf8\xe4\xb9\n<\x89\x14\xf8S\xec\xed\xd4\xa7\xc6\xfe2V\xde\xd1B\xf3\x97K\x9a\xdd\x89\xd5\xaa\x8d\xfb\x1d<s\x8a\xb9\x9d\xfa<\x99\xf9\xfd\x82\\\xb2\xf2\x8e\x0c\x0b\xfd\xff\xfd?=\xe3\xeb\x19_\xcf\xf8z\xc6\xf7\xed\x9f:\xe3\x13\xd9\x9d\x144\xdb\xe4\xcb\x97t\x97\xb7\xf2\xbd\xc6#\xa7\xd8\xda\xa9O2\xdc\xd1,\x04_\xdbQ\x12\xf8\xb1pd\xf2f\xf0*%\xffJ\xcb\xca\xd8\xdeo^\xbd\xfa\xc5\xd1\x97\"78\x85n\xeaA	\x88\xb5\xb5A/`s\x80\x0d5\x1b\xdd\xcc\xa6W\xb7\xb0X)<u5\x9e\xa3e8\x9eN\xa0\x81\xd7\x03r\xe5/\xac+\x0f\xf48\xb33:\xb3Gw\xcb(2\xb4Vh\xadM\x02\x9az\x0f\x02pG\xdb\x14\x98\x1f5\x9f\x8c\xbd#\x8f\x88\xda\x00\xf5\xf1\xc8\xef\xdf\xfaX\x81c\xd9\xcdq	y0\xb0\xc0\x8e\xc4C\x89\x17rm~\x95Z\xb6^\xdbN\xdb\x1b.\x9f$\xc8\x1f\x07%\x8eA\xe38\x00\x00	\x8d\xa0\xe9\x83AT%\x0fXg\x8a\x95\xd5\xdd(\xb0\x98\xabm\x06\xbd\x1d\xf0\x93\x07\xdf\xfa\xba\xfe6Og\xc8\xcf\x80i\x0e}-y\xac\xc8b[r	o\xac\x9b\x84j\xdb\xe1\x00.\x91h\xc0\xbc\x1c\xcfP\xe0f\xe5\xf3\x14\xe0\xe5\xdas~\x81\xaf\x8a\x07&S\x9b\x99\x14/\x94\xe2\xdf '\xfd\xbde\xdb\x8a/D\x057\x06iI\xd7\x1e\xdf\x0b\xf7\xcbq`!\xb9\x04\\\x1e\xc2\\\xa8\x0e\xb5@B\x12\x00\xbcE\xc6\xf9\x05.\x8f\xda\xf0\x9dii\xc5W\x1at\x80\xcc4}\xfe\xcbW\xff\xf9\xa2qA\xd15Ti\x7f\x01Dm\xa8t\xf5}\xb8i\x12oE\x83\xf1Zk=\x1agX\xf2\xcf\xa2:\x83ko\xe6/yv\x11\xaf:D\xc1}5;!I\xbc?l\x03Qq\xfa8?\xb6\xbb*\xd9\xc8\x84\x11\xfa\xc5\xfb\xdfg\xe8\xa1k\xec\xb4\x1dT\xeb\xb1\x19\xca\xb66\x8b5D\xf9j\xe5\xb1\xdd\x02\x07EeY\xe1m~PTli-\xa7\x19D^\xc9\xf4\xd0QG\x88/\x13h\xcf\xff\x8a\xaf+\x19\xa9Sa\xe8S\xd0%\x0e\x87\x0e9v\xccw\xe1\xe2\n\xdc\x91\xd82c\x0b\xfb\n\x89\xc4&u\nA]\x9b\xb4,\xae\x9c\x1b\n6n\xd3\xfa\x04m\x1b\x8di\xe2\x9d\xc2zU\x8d\x94\xac!jy\xa0\x1f\xc6\xdc\x0b\xee\xdbX\xcc\xb8\x16\xceS\xc9rN\x01\x0f\x10M\xfb\x93\x90w\x07L\x01\xb3\xc48\xd8\x91\xd9i\xe1\x08\xf0\xd2g4p\x07\x00Ig\xa7\x057,\xc3=\xbf\x000\xf2p\x1f(e\x8c\xf9\xc9\xa2\xaa\x9d\xe4P=s\xec-V\xc10+\xb7\x91->\xe5=\xdc\xf9\xc4&\xce!Y\x05\xdcB#<\xf2\xc8\x86<\xdd\xac\xcc\xf9\x17\xb2d\x85x\xb8\x08T\xb8b\x92\xdfc\x91EC\x10u\xd6\xdc\x01x\x9b\xa9\x8d\x06v\xf6\xb6%\xa4\x81\x1b\xb8\xbf\x81{\x0e\xb0\x0b	H\x1d\xb3{\x90W}rW\xadC*\xf9\xc0\x0cX\xce\xb5\x90xq	/\x80\x01@\xa0\x14\xda\xdd\xd3c\x05]\xda\x9a^q\x19\xa4\xdai\xb2\x8dy'X\xbd\xe08-C\x86\xb5\xc35?\xe4\xc7\x8eO\xad\x1a\x17\xd1\x9a\xe4\xb3\xd4\xab\xdd\xfd\x86\xef\x810X\xd9\xc0\x9dO_\x15\xce\xd0\xc5e+c\xc5\x1e\xaf\xb1\x19\xc2-y	\xfb\xa4\xa4[v\xe1\x16=*~-Vi$#=Q\x0f\x06e\xa8\xc3\xc4*\xac\xfa\xa5\xcb\xc2\x02\xd7vZV\xbcy\x06\xfc\x91\x8d\xfak\xa6@w\xb2\xd4\x8f\x03\x8aY\xc5k\x02{\xd8\xde\xc4p$u\x97\x7f?\xd9\xd2*\xc7\x06\x9fF\x87B\x1b\xae\x8f\xb9Y\x1d1\xab\xa5\x8d\xfahA\x9c\xde\x01\xbb\x8b\xfb\x1a\xd0\xf6(@G\x07\xa5\x00@\xb2\xb8U\x96\x98\x98\xfc\x11i\x11+*\x86+C\xf7f\xbf/\xd9\x86BB\x8f\x88P\x8d^\x9e&\xed\xc9\x99\x9f\xd3\x99m+\x94\x08si\x8c\x03\xb2,5\xab\xb0\xc4L\xf8>\xa1q\xbd\x02\x0d\x142\x8b\x88\xce\x02\xa1\x0c\x9dt\xe41\x06\xfa\xab\xf4QQ\xe4yW\xdc\x87(c\xb4\xdb\x96r\xc8\x08Up\xa5UZ\x0b\xa49UHA\xd1B\x15\xb3p\xaeT\x05)/\xa0\x16\x8c{\xc2\xe70\xb0\xda\x8a\xd7\xb5b\xa2\xa7\x11\x1b\xa9\xed\x82\x88\xda\x04\xf3\x0cd\x95\x02)\x0f=n\x81_Z5\xf2\x13p\xbc \x9a\xc2\xe5\xf0\xfa\\\xdd~\xccD\xa9v<\xab\xf02\xb2-&\xe0\x98\x92\xd1\x8el;\xa1\xec\xab\xd9\x8af\x8d\x80\xb0\xad;\xd10\xab\xb3\x89\xd0\x84\x92\xf8\xac\x0e\xce\x0e\x8fpC\xbf\xf6\xd3v'\xf0\xa4\xca\x13\x13\x10]\x10\xf5NC9\xa6\xb8Ha\xdcO8\x84xi\x1a\x92\xfezH\x95\x8d\x0bF\xc7\x0f\x19\xd1\x9b\x01y\x0f9;\xc4*\xc2\x1ez\xc8\xd1\xbc\xee\xe3h5f\xa2c\x16se@BG\x04\xaay\xab\x1c\x90\x9dPb+\x9c\xd1\xc2m\xbf(\xb9V#\xedV)^8\xd7V\x9cv+%\\Jv/\xe0\n\xff\x814\x8fR\xa8\xf8xmj\xd4A(\xa9}\xc0\xe9\x02;\xf79\x8cl\x05\x8d4|c\xcbC\x1fx\x9d\"\x8fTt\x08</\x06e\xf9\xa0\xc7\x16q\x0e\xbc\x05\x17\xe8\xfbh\x810U\xdf\xff\x02\xabs\x1e\xb2\xaa\xc7\xa9\xe1C\x9e\xe5\x8b\x96\x048\x98\x19\x0dp\xc7F\xcbs\x03\xaa0\x17\x9e-n\x8d9\xd3\xf0\xbf\x98)\xcd\xd6n\x0e\x89\xd8m\xf6\xd3\xc0f\xdc\xcc\x00u\x0fk\xe4zuwi]\xed%[?)N\xdaf\x9f\x8d&\xb7\xdcc#1u=\xdft`L\x0e\xa73\xca\xfa\x077fl\x0b\xf1\xc1?W\x17\x00\x98fV\"\xc67\xb9Q\xd6s\xd9|\xc1M\xc8\x01\x01`\x03\x82k\xd3(y\xf5\xc1\xd9.\x1e\xb0f'\xca\xba\x01\x19\xaf\xcc\xfa\xdbvx\xa94\xd7fO\xfbEqI0}>3\xc8\x00\x87R9\xce\x8b\xe9N\xe3\xb7e\xc7\xf4\x83\x0f:A\x83+>\xc6\xe0@&\xe0\xc0]\xad\x90\xd0N\x94\x02o\xdf\x96\x1d\xce\xe5\xa18\xcc\xa3o\xdbp\xc6\xa8=)\x87\xe9{\xac\xc8sZ\x15J\x07\x9f\xb9\xdb\x89Z\xef\xb2\xcd\xa9\xf6\x9b\xcfS\x97+\xb0\x13\xed\x95\x8f_4Q)\x03\x9f ( Q\x1a\\\xa8\x06\xdc\x8b\xf9\xd1#Z\x1e\x82\xd5\xf7\xb6\xec\xfe!\xde\x07[\xa9\x9b\xcd(\xc2\x8fp\xb2f\xed\x8d\xb0\xb5\xb6\x8c\x1dG\x05\xfd\xe0\xe4\xec9\xbd\xc0\x99VJ\xdb\xf2V>A\xc5a*);=\xfb\xae\xf9\x1cL4\xbe\xae\x10\xd7q2b\xd4\xf5\xb9\x8c\xfaD\xc7MP\xa5\x8d\x1d\x05\xe1\x10p\xeaH\x04$o1\x99\x89\xbd\x90\x14u_\xab\xdab\xda\xc40\xb6\x85\xfe\x98v\xea=gQ\xcf\x08s\xf25A\"\x13\x9e\xd8\xcb\xf7\xcd\xc9E\x1d\xfb\x0e\xe3@	\xc5\xa0\x08J\xc7\xd4\xee\xee\xa8\xecP\x1a)\x13\xe6\x13U\xa1\xf2\x97\xad|Z\x99\xc6x\x9a,\x95\xd447\xe4\x9e\xfe\xc2\x96\x19\x9c\xcdD\xba\xb37\x07\\\xc0\xca\xd6j\x8bgr(\xaa\xebD\xcb/\xdc%\x8eO\x98\x91\xce\xa6\x0f\xa5\xe4l2]\x8c/GgD\xb3/\x1a\xeffQ\x15\x15\xda\x89)\x16\x9f\xae\x88\x05\xb4\x9c\x94\x03\xcabU\xdd\xd0\x94\xaf\xfb	 m\xb01\xe3;2md\xf5\x90\x94\xa8\x19\xcb\xd4\x803\xe0D`\n\xe9S\xe8\x1a5\xd3N\xe1V\xbab\xeaB{c\x8d\x882\xf6\xd2\xdbW\xc2i\x85\x925\xea\x077L\xea\xc6\x18h\x1d]\x8f\x8aw\x95zt\x0c?\xc6\xcc\xbc\xb6\xc9\xe2s]w@\x11\xbe\n|\xa6v\xc7\xa8\xad}!\xd3C*S\xa7\xebE^.k\x1b\xb4Pi\xd58)\xa0@\xdcC\xfdy\xf0\xa5p\x99\xbf\xc0|enm|\x01q\xba\xdb1*\x07pE\xc2eg=$s\xb4\xde\xa0<\xa0)\xed\x9d|\xb4\x88\x8c\xd7\xd2\x16<\x89\xa9\x03g+\xaaF\xe8\x15\xc7\xcfQ\x85%\xb8N*\x1e\xcaxGF\xad\xb8\xa1[\n=\xe5$\xa4H}\xc5\xf3\xda\xd6\x916\x9d+\xcdsV\xe6\x95\xbf\x85Y\xdb1\x8e\xb1\xa0\xfd\xe7o!\x87f\xa0?\xcc\xbe\x1a\xd2I\xb4\x1e&\x7fe\xd9g\x19\xab\xef\xbfP\xd2@\xb7\xc4-ZI\x14\xac\x8a\x90\x8c\xc3\xdd\xc8\xad;Y\xc2R@r\x1c[\xde\xf7H\xce\x8c\xa0\xe5\xb6h\xf0\xb6\x99\xf6\x90\x116\x13\xc5\x8a\xc4\xaae4i86\xae\xd0J\xbb)\x12{\xe7\xfcQ\x82\xf6L\xd7\xb6\x89\xfa\x00\x0e\xa2U5)\xec\xb5n,g`\xf7Q\xe2\x96\xb2\x96\x97\xaca	\xd4\x16\xe4\x97`\xec\xb8 9\xd8\xaaA\x0bT\x03r\x8bW\xba\xcd\xa2E\x05\x80\xf0\xc6n\x08\x90\xd8~\xcd\xfc\x1bZd\xe4\xcc\x8a\xdcXG]W\xb6\xa1\xe5\x1ezl:r|\xf0\xbey\xbd\xf5i\xa6\x99CF\xd0z\xa1S{CV\x92\xb8\xda-\xbe\xdfL\xdf\xa5\x1d\xe6\x00\xf2+Cb\"\xc3\x9a\x8c\x18\x81\xa1\xa9j\xc7\xa4byT\xf6*Z\x12\x7f\x15\x17\xf6\x89\xad\xd0\x15L\"\x9f\xb0\xce\xe5\xd9\x01\x8b\xccV\xb6w\x9b\x1e\x18\xaf'\x08\xd6\x88\x05?R\xd3\xf6\xb0\xb1\x80_\x0d\xc8\xc2\xd7=\x1c\xe0\x8d\xc4\x83{b\x98\\\xb4\x9em\xcc]\xab\x02\xf5\xc5v\xdeRH\xb1VE\xd1\xa5h\x85\x92\xd7\xbe\xf6c\xbc\x84n\xa7\x043U\xc6\xf5m\xa2\x12	\xb0\xa4\x95\xd2bK\xe5\xde!\xe7s\xa62\xc9\x97v)l[q\xd5\xcc\xc8?\xebN\x13\xf7I\\K\x97*\xb3!\x02\x90R\xbf>\x9a\xc3\xc6\x1d\x02?\xd4\xe5\xbeQe8\xb0\x01XE0^\x82\x17,\x0d\x0bf\xcf\xbe\nC\x85\x82\xd4\x8cf\xee\xec\xc6\xc6\xad\x7f\x9akU_\xdc\x0bL\xe8\xe3Q\xe3o\x87\xf3\xf1\xdc\x11\xb7\x81;\xb1\x897\xa2\xb0|\x0d\x87R\xc7\xc9\x86\x99x\xac\xac\xb7:\xd3\x16lQ\x8aNu$\x95\x05\xd0\x1c\xb0X\xb1\"\x8b\xf1\xe2z\x94\x92\xc9t\xf2\"\x06\x9f\xa4\x87\x18\x96\xe6uk\xdbF\xdb\xa5kW\xf0\xc5\xd6\x1c\x8aj\xbe\xb8\x0b\xb1`\x15\xd6\xb7Kt\xb9\xd5%f\xad\\\xf9\xc1\xe8\nn\xec/%\x16\xbfU\xc1\xc5\x7fw\xa4%W\xc0\xd9=\xac\x0b\xce&0\xf58om\x1chm\xc9E\x0b{\xef7\xc7\x13\xdf\x90\xb1\x91\xbcpk\x1ds\xd3c\x1b\xa5\xb0IB\xf4\x86	\xb9\x8f\\-.\x92\xa5\x85\xd4\xb1\xcb\xa0d\xeb\x82\xafY\x99\xb1\x8b\xd4G\xbb\xd3\x9a+\xd7{~N\xeew\x9fE>g\x05_\x82B\x07\x83[K\x01\x85\xfc\xb0\x1d\xd7\xa5&4\xd3\n\xa2\xe3\xed\xe7\xc3\xe6-\x89\xc5\x87\x90d\xe9\x96\xac\xe0.\xb7\xa8YqXZ\xba\xa5\xeb\xba\x0f\xdf\xbc\xed \x01\x01\x1c`\xb3\x04F\xd1g\xc8wmC	>\x11&\x80\xbdl\xa3\x8eCg\x1bjH\xc4$\xa1\x12c\xe6P\xab\xc1.\xbeO\x94UsM\x18jV\x9e\xc7T\xf8\x0d/\xa3\x1a\xadu\xa0\x02\xb2\x80Gc\xe2nTf\xda\x85\xc0\x0d\xbb\x16\"\x7f\xe0E\xec;\xbc#J\x8b\xdd\x0e\xd2-\x1b\x9d\xa0\xd2\xb6\xac^%Q\x1a\xd1bU\x95A\xb9\x01!\xd8\x82\x04\xc9\xc4vk6oL\x0f\xec\x18\xf2\xe9B\xf6\x04\xbe:p\xc4\xd96\xbc3\x9d\xe6\x98\xad\xd0R\x023\xa0 \x11\x1c\xb8\xc16\x8f'\xc0\x17>3T\xf8\x14\xa5\x8f\x89\ncF\x87\xe2\xd3\xc6\xa8\xee\xf5\xe3\x1aI\xdaS\x8e\x98\xd4k\xa1.q\x9b@Og-\xd8n\x13\xf4\x93\x15\x03~\x926r\xc3\xa9j\x87nP\x97@\"u\xf5\x82\xb5?\x8f>z\x1b\xf2\xde\xd42\xb4!\x9e\xd2b3\x0f\x93\xd9s\xc7A\xbd\x81\x11\xcaF\x96v8^\xfa\xd7\x92\xbdz-;\xd3`\xc2\xb8\xf1\x94A\xe7\xb6a\x11p\xe2\xda\xaf\x0d#\x0dl\x14\xc6\x8b\x95$|\x14%p\xf4\xe0)\x8a\xb6\x81\xf5	\x97X\x17\xf1s\x9c,\xd6\xd5R\xf6\xb4\x81\x1c\xc3\xb9\xbb\x1d_\xe4-\xaes*\xb7\xc0\x89\x9cr\xed\xa9\x18\x8es%e\x88\x96Y\xcf1U\x8aI\xb8'\x82N\xd4\xf4\xd0o\xbc\xdc[e#L(\xaa\xa9\xe6\xf8	\xbcVKf\x14vcQ\xcbMv2\x03\xd1\xf0\xe6f4\xb9\x1a\xff\xe1\x07\x97\x82\xc8\xa6\x11\x05\xf8B\x0c\xdd#\x98B\x19\xab\x1fx\xabi\xf1\xc4\x17\xda\x93\xf9\xd8F\x96\x82\x17L\xee\n\xc3\xad\xdd]>o\xc9\xaf8+rEX	I\xa2\x80\xe9/%\xcd\xee\x98V\xe4\xec/\x7f=\xf3\x8c\x0f<\x13V\xda\xed\xddf\xb2U\x04\xc0\xea\x8b,\xe9\x019\xbf\x12\xe5w\x1e/\x10\x9dQ\xd7\xf8\x7f\xbap\xb9b\xbfhw\xe5g\xc9\xc28\xdc]\xc4('\x056\x02\x9c\xaa\xd4D\xedKM\xbf\xf8@(\x18\xf58\x80\x01\xf9\xc4|!2x\xda\xfaI\x1d\x17\x87gq\xdf\xa8(\xb1\x0f\xaa\x99>g\x95\x0b\xad\xc6\xa8aDU\xdbf\x94y\xf1l'98\xae\x0d\x0f>\x83\x9c\xce\x9b\x16l2\xd4\xcc\xa2\x8a\xfbx\xbc\xab\xbf`\xe3\xae\xde=\x13\x9c\x1cTf\x1b~\xef8e\x08&\xfe\x05\x13\x07\xd9m\x10\x97\x11\xa9o\x904\x86|Z\xa0\xb9\xfd\xe9\xec\xe2G\xd3\xc4>J\x87\x8f\x02\xca:\xc8\x9d\xa2\x0e\xc9G|\xdd<\xbfg\xbc\x12\x13\xd9\xf5\x88\x86o$\xaeq[\x95j\xb7\xa1O\x81J\xaf\xc7\x97\xa3\xc9|\xf4\xe2\xcd\xe0\x15\xbc\xf2\x14\x1d\xfc\x98v\xe12\xa4\xc6~\xc8\x1a\xbd\xdc\xf0\x1aI\x87\x8e\xe9\xd8\xcfT\xb0\x9dj\x0dd\xb3y\x83\x1a\xc1\x0d\x16\x92\xd1\x17\xb4\\Wt\xcdlYn\xde\xc0\xeeY\x7fH\xd0\xc8\xd5\xe1\xbc:\xbf\x99\xed\xfc\xa92\x7fi\xf38\xf3\xf6+\xda\xed\x0f\x9e\x023\x9f\xfa\xf8=t\xfc\xf3\xb7\x86C\xab\x1e\x0f\xdd\xe3\xa1{<t\x8f\x87\xee\xf1\xd0=\x1e\xba\xc7C\xf7x\xe8\x1e\x0f\xdd\xe3\xa1\xdbzy\x9a\xb4\xef\xf1\xd0=\x1e\xba\xc7C\xff3\"n{<\xf4?\xf2\xea\xf4x\xe8\x1e\x0f\xdd\xe3\xa1{<t\x8f\x87\xee\xf1\xd0=\x1e\xba\xc7C\xf7x\xe8\x1e\x0f\xdd\xe3\xa1{<t\x8f\x87\xee\xf1\xd0=\x1e\xba\xc7C?\x05\xaea[\xe9\xf1\xd0\xf5\xfd\xd4\xe3\xa1{<t\x8f\x87\xee\xf1\xd0=\x1e\xba\xc7C\x7f\x03\x1e:\xc4\x80\x9ci\x8a8A2\xc4@\xf4?;\xb4\xf51\x80`\x8fm\xed\x14\xdbz\n\xbaz\xeaS\x83\xb6\xe6\xf4\x9ee\xeb\xcd\xcb\xb5x\xa1v\xec\xa1\x0d\xd4\xda|\xe4\x14\\\xf5\xd4'\x19\xcf/\xddtZ\x12\x95\xbfy\xf1\xe6\xd5\xeb_\x91+c\xfd\\\x8a\xa2\xe0\xa5\"\xbf3c\xf8=\x0cd`\x0c\x8b\x7f\xa9e0o/Of\x99k-r\x18')\xb7L%q\x91\xf3\x867\x1e\xd8~Kn\xf4\xc3BD`\x11&_\x93\x95\x1b\xbdT\x04Sq\xdbP\xc2\x13Rl\x03_	\xb9\xaa\x89+Q3'\xc3\xeb\xebh\x07'\x90\x1ez6z?\x9c\xd9L\xda\xb5z=\xa1\x10\xfb\xf5u{5\xfe\xe4\x91\xaa9\x8f\xe7\xcfn)z\xe3\xcb\xda\x1cV\xbf9Z\xe8\xdd&\xd9v\xc5n>}\x18.\xe6S\xa843\x1b\xcdo\xaf\x17.C69\xa8\\\x13\x15\xaei\xd4\x0eK\xda2p\xb7\xd4\x95\x81\xac\xdc\xe3\xe9\xed\xdc\xa6\xecN\x1be\xe7\x93\xe3u\xc4l\xf6\xed\x9b\xd1\xec\xddt\xf6q\x08\xad\xbe\xab\x93\xbf\xf3\xf3+\xb2;&\xcd\xd9\x0c\xa5\xcaU\xeb)n}\xf0\xd4Y=\xf5\xe9\xa1\xe9=4\xfd\xc0\xc5\xdbC\xd3{hz\x0fM\xa7=4\xbd\x87\xa6\xf7\xd0\xf4\x1e\x9a\xdeC\xd3\xc3\xf0zh\xba\xee\xa1\xe9=4\x1d\xb7{\x0fM\xef\x14\xfc\xdcC\xd3\xff\x91W\xa7\x87\xa6\xf7\xd0\xf4\x1e\x9a\xdeC\xd3{hz\x0fM\xef\xa1\xe9=4\xbd\x87\xa6\xf7\xd0\xf4\x1e\x9a\xdeC\xd3{hz\x0fM\xef\xa1\xe9=4\xfd)h+\xdbJ\x0fM\xaf\xef\xa7\x1e\x9a\xdeC\xd3{hz\x0fM\xef\xa1\xe9=4\xfdY\xd0\xf47\xaf^\xff\x92\\\x01P0%\xe32\x1b\xf4\xa8\xf4\x1e\x95\xfe\xb3\xa0\xd2=X\xb5*\xb9>\x81g\xc5GN!UO}z$k\x8fd=\xf0\x08\xf5H\xd6\x1e\xc9\xda#Y{$k\x8fd\xed\x91\xac=\x92\xb5G\xb2\xf6H\xd6\x1e\xc9\xda#Y{$\xeb\xdf\x18+\xd9#Y\xff\x91W\xa7G\xb2\xf6H\xd6\x1e\xc9\xda#Y{$k\x8fd\xed\x91\xac=\x92\xb5G\xb2\xf6H\xd6\x1e\xc9\xda#Y{$k\x8fd\xed\x91\xac=\x92\xf5)\x08\x0d\xdbJ\x8fd\xad\xef\xa7\x1e\xc9\xda#Y{$k\x8fd\xed\x91\xac=\x92\xb5G\xb2Z\xb2\x9d\xc4\x06\xf6H\xd6\x7f\\$++\xa8\xd2<{\xb9\x16\x8a\xaf\xa9lC\xb26\x1f9\x85T=\xf5q\xdb\xe6\xc8\xe7\x89(\xd6\xaf\x84\xb0\x9ay=\n_}\x1ev\xb5\x0d\xb8\xda\x15j\xb5;\xc8j'x\xd5\xb6\xc7O\x83U\xbb@\xaav\x04S}>F\xb5\x03\x80\xea\xf3\xd1\xa9\xdd@S\xbb\xc1\xa5>\x0f\x94\xfa-\x88\xd4\xce\xe0\xa8]`Q\x9f\x04D}&\n\xf5\xd9\x10\xd4g\xe3O\x9f\x0e>}>\xf2\xb4\x0b\xd8\xe9s1\xa7\xdf\x028\xed\x08m\xda\x11\xd4\xb4;\x9ci\x87 \xd3\xce\x10\xa6\xdd\xc1K\xf5\xd3\xb1\xa5\xcf\x04\x96\xeanP\xa5\x1d@J\xbb\xc3\x93v\x06&mi\xffi2\xb9\x89$\xed\x08F\xda\x15\x86\xb4C\x00iW\xe8\xd1\xda\x82\xebo\x85\x8ev\x82\x1b\xed\x0e4\xda\x15b\xb4\x1b\xb8\xe8\xd7`E;\x03\x8av\x81C|>\x08\xf1\xb9\xf8\xd0n\xc0\xa1\x9f\xbe\x19\x19\xda\x0d,\xf4\x1fc-:C\x83v\x03\x05\xed\x04\x07\xfaL\x10h\x17\x08\xd0N\xe0\x9f\xcf\xc4~v\x01\xfc\xec\x04\xf5\xf9l\xc8g\x07x\xcf\xa7\x83=;Az\x9e\xd2\xbc\x9e\x00\xf3|>\xc6\xf3	\x00\xcfg\xa1;\xbf\x1a\xda\xd9\x15\xae\xf3k@\x9d\x1d!:\x9f\x0f\xe7|\x16\x96\xf3	\x80\xc3\x13@N\xfd(\x8a\xb3\x0b\x08gW\xf8\xcd.\xc0\x9b] 7\xbb\x80mv\x83\xd9|>`\xb3#\xb4\xe6\xf3\xa1\x9a\x1d\xe14\x9f\x0f\xd2\xec\x00\xa1\xd9\x0d<\xb3\x0blf\x17\xc0\xcc\xc7Q\x99\xdd@2\xbb\xc0cv\x01\xc6|.\x12\xb3\x1b\x18\xa6~\"\x06\xb3;\x00f7\xe8\xcbN\xa0\x97\xcf\xc2]v\x04\xba\xd4] .\xbf\nn\xd9\x1d\xd6\xb2#\xa0eg(\xcb. \x96\x1d\xe0+\x9f\x07\xae|.\xb2\xf2\x99p\x81\xa4CL\xe5\xb3\x01\x95\xdd\xa0)\xbb\x81Rv\x85\xa3\xec\x06D\xd9\x01\x82\xb2\x13\xf8d'\xd8\xc9\x8e\x80\x93\xdd\xa0&\xbb\x80L~\xea\x00/\xf9|\xb0\xe4\xb3\x91\x92\xdf\x06\x93\xec\x08#\xa9\x9f\x0d\x90\xec\x04\x1d\xd9	4\xd2\xab\xf5\xcf\xc1Ev\x02\x8a|6\"\xf2\xb9p\xc8\x8e\xb0\x90\xcf\x03B>\x8a\x82\x1c\xde\xdc\x8c&W\xe3?\xfc`V\x0b,\xef\xdd\xae@\xf3\xac\x0e\xfc2\xbf\xc1 \x1e|:\x8d\xc5\x13\x9fNmH\x9f\xd4,s\xd3\xc2R\xf0\x82\xc9]ax-ZLi0\x8cW\x9c\x15\xb9\"\xac\xcc\n\xa1\x90e/%\xcd\xee\x98V\xe4\xec\xbf\xfd\xf73\xe4_`\xdf[\xf9\xb4w;\x068\xa3\xb5\xac\"\xf3t@\xce\xafD\xf9\x9d\x8fc\xbb\x93\xe7\x9a\xfdO\x17\x04\x8c_\xb0\x00\xd5FTEn\xd4k?\x02\xab\x96G\"6\xb1\xe1Ds\x08\xd4\xbe\xd4\xf4\x8b\x8f\xe0\x81\x8d\x8c]\x0f\xc8'Fh\xa1\x04\x91\x0c\x9f\xb6~C\xe0\xc1\xf0 n\x0e\xa5@sD\x03\x074\xbe\x9d\x13\x9c. \xb8d\x01/\x01\xa1=\x18\x832o\x9d\xed$\x07\xb7\xada\xa2g\x86\xc7\xebZ\xc8\xce\xc2.\xcc\x00\x19U\x1c\xa3\xc5\x96T.T\xe8\x1d\x1b\xc1O@e\xb6\xe1\xf7\xc0\xedB,\xec\xbf\xed\xf7\xfb\xfd\x7f'\xff\x0d\x86+V\xcd\xc0\xe0\x7fO\x12\xbb\x13\xbe\x19<\xfbl\xe4\xecW\xc1fO\xe0\x11k\x90\xd9\xa7\xa8\xc6\xa7\xf0\xb2\xcf\x07\xcb>S\xf5\x0dH\xd9.`\xb2\x7fW\x8c\xecZ\xbc\x10\x05\xb3\xffkC\xc8\xd6\x1f8\x85\x7f=\xf5I\x0cK\xf88^x\x02\x9d\x7f\x1c/.\xe2\xc3\xf0\xff\xfe\xdf\xe4\xcd\xab\xd7\xdf\xbfx\xf3\xea\xf5\xaf\xc9g\xaa\xaa\x0d\x97\x82|\xa4ZU[\xa1EJ~\xb7\xa5Z\x97\x83?\xee~\xbf\xdeR^\x98A\xfeK\x92\xdc\x04\x83\x93\xabZ\xfc\xd1\x98#+#\x91\xcc\xc9\xb2QE\xe7\x15eR\x19\x8e\x00\x9b\x1aD\x81\xdb\xd7	F\x11\x1dD\xce*\xf5N\x81\xaf9$\xad\xe3\x1f\xce\xdc\x7f\xfc\x8f\xffkn\xdf\xf9\x8f\xff\xf1?/\xa0\xa3\x9c\xd1\x82\xf02\xd1\xe0\xdf\xb4\x0d:\xa3H2\xb33\xad\"\x16\xd4\xbeC\x9b	\xf6\x80U\x16\xb4H\xc0\x87d\xc6\x9aZ\x8f@J\xb6\x0c\xa6\x06\xd1d\xb5Ik~\xbbF@\xd9(\x1e\x8a\xa1\xff\x9f3\x95\xd8s\xecF\x872Z\x0b\xdc\x99\xda\x92Ia$\xd0\xfb\xfc\xedL\x0c#\xa9d\xc9\x95E\x99\xe5\"Q\x02z\x8cC\xc9G\x828\x0b\xe7\xfa8\xe4\xac0\x04\xb3\x08\x91+\xc1\xfe\xe4}51\xd8\xcd\xa8\xae.\x92%\x01\xa0\xa0)*\xf0FM\xb3\xbe\x9b\xda\xd0\x07I\xb2\xf80\"\xf3\xe9\xbb\xc5\xa7\xe1lD\xc6sr3\x9b\xfe4\xbe\x1a]\x99\x85\x04\x0e\xf1\x1f\xff\xe3\x7f\xa6M\xc3\xf8s\x9d\x1b\x8c\xfep33\xb6\xe7t\x96\x8c?\xde\\\x8fGW)\x19O.\xafo\xaf\xc6\x93\xf7\xe4\xed\xed\x82L\xa6\x0br=\xfe8^\x8c\xae\xc8bJL\xa71\xa3yw`\xd2&5{\xb6\xc5\x98\x05\xd5c2\x9d\xc4\x96\xf1\x80\x8c'd2%\xa3\x9fF\x93\x05\x99\x7f\x18^_\x9b\xae\x92\xe1\xed\xe2\xc3tf\x19\xda\xcd\xe7\xd9\xf8\xfd\x87\x05\xf90\xbd\xbe\x1a\xcd\xe6\xe4\xed\x88\\\x8f\x87o\xafG\xd8\xd5\xe43\xb9\xbc\x1e\x8e?\xa6\xe4j\xf8q\xf8\x1e\xd9\xe0t\xf1a4K\xccc\xd6\xe0\xfe\xf4ad\xbe2\xfd\x0d'd\x08\xc0q3\x8d\xcb\xe9d1\x1b^.R\xb2\x98\xce\x16\xfe\xd5O\xe3\xf9(%\xc3\xd9xn\x08\xf2n6\xfd\x98&\x86\x9c\xd3w\xe6\x91\xf1\xc4\xbc7\x19a+\x86\xd4\xa4\xb6*\xd3\x19\xfc\xfbv>\xf2\x0d\x92\xab\xd1\xf0z<y?7/\x9b)\xba\x87\xff\x06\xccQi\x9a\xdd\xbd\x84\xff\x1e\xe1\x8e\xf1\x13\xa7\xd8\xdf\xa9\xcfi\xf6x\x9e]\x18\xfe\xf8\x0br\xb9\x91\\\x91\x0f\xbcd\xaa[\xee\x978\x03\xf6\xab\xb9\xdf\x99;Yg\x81\xf3%>\xb2\xd3\x01\xe7K\xd0X\xee\x80\xf3%\xb5\xa0\xf7\xb7r\xbe\xa4\xc1\xf9\xc8\xff\x02\x9c\xcfjF=\xd7\xfb\xdf\x98\xeb\xad\xa4zYU<o\xe7x\xfe\xd7S\xdc\xec\xd4'fk\x97\x17^\xed\xfb\x8d1\x02>\xd2/|K\xde\xc2\x91&\xbf[\xfe>\x139\xdb\x8a\xf2\x8e\xed\x07\xb2\xeaJ\xdfK\x1a\x98\x8f\xaf\xe5xI\x1b\xc7#_\xc7\xf1\x92S\xba\xde\x138^\xf2\x04\x8eG\x1e\xe7x\xc9\xd38^S\xd7kp\xbc\xe4o\xc6\xf1\x92\x06\xc7#\x7fo\x8e\x97\x04\x8eG\xbe\x91\xe3%\x07\xa1\x8b\xa7p\xbc\xe4i\x1c\x8f\x9c\xe0x\xc9\xd38\x1e9\xc1\xf1\x92\xaf\xe2x\xe4\x08\xc7K\xbe\x89\xe3\x91\xbf\x1d\xc7\x13k\xb4q\xb3\xed\xae\x9d\xe9\xc5\x0f\x9c\xe2k\xa7>\x87\xea\xdc\xaf\xc1U\xf6^\x90!^\xae\x18\x90aQ8\x16 \x99b\xf2\x1e\xe0}ul\x1fFz0\xa6i\xc1\xf5\xe6\x9b%/\xa9\xc4\xeb\x01\xea\x10\x9c\x17C\n\xf0f!\x9e|\xf0u\xd5\x80x\xc7\xce\xb2y)\xd92\x8d \xbc\xff\xd2\x00\x1c\xc2\xd1\x8b\xa1\xfe\x11v\x0d=~u\x1e\x908_%0\x80\x82+\x8d.\xb0\xd0[\x997\x86\x92\xfb0\xeb\xa0}\x04\xbc\x8c\x89\xe0F\xe0\xe0\x8f~\x10I\x93\x11}\xdb \x9c\x8a[\x97\x12\x96\xf5\xda;GT3\xc9i\x11\xdd\xb4r>\xb6\xa4\x86\xd6\xc4\xf9L\xac\x87\xc9\xb4\xea\x9c\x82\xefa\x0b\xc2}zR\x8a\xf0\x1b\xd0\x9bk\x95D(U\x05\xbe\xba%D\x9b\x80Q\xb32\x17\x12#O;)\xb6B3\x17]W\xb5KW\x0d\xb7\x87\x13M\xde{\xb5\x93<\xbaY\x12\xb85\xb0\xd7\xf1\xbc\x9d\xbf\xbe\xfd\x0c\x87\xf7\x90)Y\xff9^5\x9e\xce\xe6\x89\xf3\xca\x99\x1f\x0c\x8f:d\xb9\x11C\x8d\xb8o\xea\xd8o\x12\xd8o\n\x9d\x1e\xbe\xd6\xa2yB\x7f\x11+N\xdaY\xf1lD\xae\xc6s\xe0\x9b\xa3\xabc\\\xd8\xcf2\x99~\x9a\x8cf\xd6\x8d\xe8\xa7\xd8\xa2z^\x8dg#\xc3K\xc7\x93\xf0\xd7\xe5\xf8j4Y\x0c\xaf\xd3d~3\xba\x1c\x0f\xaf\x8d\xc2=\xfaxs=\x9c}Nm\x9b\xf3\xd1\xbf\xdf\x8e&\x8b\xf1\xf0\xda3\xf1\xf3\x13\x14\xb9\x99M/og E\x0c\x19\xe6\xb7o\xe7\x8b\xf1\xe2v1\"\xef\xa7\xd3+\xa0\xf3|4\xfbi|9\x9a\xffH\xae\xa7s \xd6\xed|\x94&W\xc3\xc5\x10:\xbe\x99M\xdf\x8d\x17\xf3\x1f\xcd\xdfoo\xe7c\xa0\xd9x\xb2\x18\xcdf\xb77\x86\xb5_\x90\x0f\xd3O\xa3\x9fF3r9\xbc\x9d\x8f\xae\x80\xb8S#A>\x1b\x11<\x9d\x81Xm\x972A\xb0\xcc\x17\xb3\xf1\xe5\"~\xcc\xc8\x87\xe9l\x91\x849\x92\xc9\xe8\xfd\xf5\xf8\xfdhr9\xaa\xc9\xa0\x0b/\x83@p}&\x9f\x86\x9f\x9d \xb2\"&\x81?\xa3\x0d\x9b\xc2B\x92\xf1;2\xbc\xfail\x86m\x1f\xbe\x99\xce\xe7c\xbbM\x80d\x97\x1f,\xb9\xbb\x17B\x9a)\xbd\x17\x95T\xacX5\xfe\xd9.\x94\x1e{\xe1\x94\x10:\xf5Ij	=~C\xb0\xf9\x81\x16\xa2P\xee\x0e`\x1f\x98\xe8\x03\x13\x7f\xab\xc0\x04\x17\xf7\\	\xf9r-\x96\xbb\xd6\xed_\x7f\xe0\xd4v>\xf5I\xc8\xa9\xcf\x13sw|}\xfa\x8e\xd3\x1b\x13#\xc4\xcfJ\xe2\xd1\x17\xa0\xfb\xba\x9c\x1e\x1d\xa5\xf5\xe8.\xb3G'\xc9=\xba\xc9\xef\xd1I\x8a\x8f\xce\xb2|t\x96\xe8\xe3\xd9\xb9>\xbe1\xddG\x97\x19?:J\xfa\xf1\xd4\xbc\x1f\xcfO\xfd\xd1E\xf6\x8f.\x12\x80|U\x0e\x90N\xd2\x80t\x94	\xa4\x83d \xdf\x98\x0f\xa4\xbb\x94 \xdde\x05\xe941H\xb7\xb9A\xbaL\x0f\xd2i\x86\x90\xafK\x12\xf2\xfc<!\xdd\xa5\n\xe9&[H\xa7	C\xba\xcc\x19\xd2e\xda\x90\xee2\x87t\x98<\xa4\xdb\xfc!\x1d\xa6\x10\xe9,\x8bHW\x89D:\xcd%\xd2a:\x91\xce2\x8a|eR\x91\xbe\x00\xdd\xd1\x04#\x9d\xe5\x18yV\x9a\x91\xbe\x00]_\x80\xee\x1b\xd3\x8ft\x95\x81\xe4\xf9IH:\xcaC\xd2U*\x92.\xb2\x91t\x93\x90\xe4\xabr\x92\xf4\x05\xe8\xbe1E\xc9\xb7d)\xe90Q\xc9W\xe6*\xe9.]I'\x19K\x9e\x9b\xb4\xa4\x93\xbc%'S\x97t\x94\xbd\xa4\xc3\x04&\x1d\xe50\xe9(\x8dIG\x99L:Kf\xd2I>\x93\xeeR\x9at\x92\xd5\xa4\xbb\xc4&\x9d\xe46\xe9&\xbdIg\x19N:Jr\xd2Q\x9e\x93\x93\xa9N:\xcbv\xd2Q\xc2\x93\x8er\x9et\x90\xf6\xa4\xb3\xcc'_\x91\xfc\xa4/@\xd7\x17\xa0{rR\x94\xee\xf2\xa2t\x99\x1a\xa5\xa3\xec(}\x01\xba\xbe\x00\xddWeM\xe90qJ_\x80\xee\xd1$*\xdd\xe5Q\xe9,\x95JG\xd9T:J\xa8\xd2IN\x95.\xd2\xaa|sf\x95\xbe\x00]_\x80\xee\xeb3\xaet\x97t\xe5\xd9yW\x9eP\x80\xee9\xd9W:I\xc0\xf2\xac\x1c,\x7f\xf9\xeb\x99g|\xcfM\xc3\xd2I&\x96\x0e\x92\xb1t\x94\x8f\xe5Y)Y\xbe6+K\xad\x92\xe1_\xf6\xfb\xfd\xfe\xaf\xe4/G\x12\xb3\xfc\x15\x1e\xb7\x9b\xe4\x9b!\xd0?ca\xc3\xa7b\xa1\xfb\xba\x86\xffPu\x0d\xffH\xb3\xbb\xece\xb6\xa9\xca;\xc9h\xceZ+\x1b\x1e>t\n\x02}\xeasxm\xed\xb7\xe4_iv\x87\xa9\x08\xb4\x99i\x99$Q\xf6\x82\xfe\x96n\x7fK\xb7\xbf\xa5\xdb\xdf\xd2\xfd\xf6\xcf\xe3l\xef\xe5\xfd\x9b'q>\xf3\xdc)\xe6v\xea\xd33\xbf\x9e\xf9\xf5\xcc\xafg~?/\xf3\xdb\xad3Q\x96\xc7\x99\x9e\xfd\xfd\x143;\xf5\xe9\x99]\xcf\xeczf\xd73\xbb\x9f\x9b\xd9q\xf1\x18\xab\xe3\xa2gt=\xa3\xeb\x19]\xcf\xe8\xfe\xe9\x19\xddV\xb4\xa7\x17\xad\xfd~\x8a\x99\x9d\xfa\x9cN.\x8a\xb9\x97\x7f\xdd\xc2\x02;\xe1z}\x82\xd1\x7f\xca\x04\xa3\xc9\xcf\xc1\xf5\xfa\x04\xa3?k\x82\xd1\xe4\xef\xc1\xf5vT)s\xba\x1f\xe3|\xfe\x99S\xdc\xed\xd4\xa7W\xf5zU\xafW\xf5zU\xef\xe7V\xf5vRh\xf1\xfd\xa3,\x0f\x9f8\xc5\xd0N}z\x86\xd73\xbc\x9e\xe1\xf5\x0c\xef\x1f\x83\xe1=\x1a\xab\x8d\x1f:\xc5\xd6N}z\xb6\xd7\xb3\xbd\x9e\xed\xf5l\xef\xe7f{\xf6\xde\xd6)\xfb6~\xec\x14k;\xf59`}o^\xf5\xac\xafg}=\xeb\xebY\xdf\xdf\x95\xf5\xe9\xfd\xeeQ\x9e\x07\xbf\x9fbf\xa7>\x87z\xde\xf7=\xb3\xeb\x99]\xcf\xeczf\xf7wev_\x1e\xe3t_z6\xd7\xb3\xb9\x9e\xcd\xf5l\xee\xef\xc8\xe6Nq\xb1S\x9fv.\xf7\xf2\xfe\x17\x8f2:\xf3\xfb)^v\xea\xd3\xf3\xba\x9e\xd7\xf5\xbc\xae\xe7u?\x17\xaf\x13\x1b\xf1r-r\xa1Yy\xdf\xca\xecj\x0f\x9c\xe2f\xa7>\xad\xdcNlJ\xf2\x96J-zF\xd73\xba\x9e\xd1\xf5\x8c\xce\xd9\xae\xdd\xb2\xba;V(\xb6\xdf\x98=)\x1e\x98|\xc9\xca{\xac\xf1\xd3\xc6\xf6\x8e?|\x8a\xc5\x9d\xfa\xb4\xb1\xc0\x7f\x83\xde\xc8\x07\xd7]G\xac/$\xc4H\xbe\x89\xf5\xb5b\x8f	/\x93\xaf`}\x8fc\x8f\x0d;9\xcd\xfa\x9e\x82=\xb6\xbc\"y\x1e\xf6\xb8\xc9\xfa\x92\x9f\x03{\x0c\xd9\xb3\xfe\xde\xac\xaf\xc7\x1e\xff\xac\xd8c\x98\xe2\xdf\xcamw'J%\xa4f\xe5\xcb\xb5x\xf1\xc0\xcb\\<\xa8\x176\xb7}\xf1\x02s\xd5eL\xb5\xf2\xc1'\xbez\x8a\xeb\x9d\xfa$\xe7\x8d\x8b\x1a\xb5;\x1a\xbeb\xf8\x96\xca{^\x92\xff\x83\xf8q\x91\xf7\xdb\xe5\x07r.v\xac|\x81\xc5r~\xef\x7f\x1b\xe4\xa6\x99\x8eY\xe9\xb7i\x91\xe4;wT\xbf\xfbf-\xf2$+}\x82\x16\xf9\x15\xac\xf4\x98\x16\xf9\x8d\xac\xf4g\xb9\xc6\xd1\xb1\x16\xf9\x1d\xb0\xd2\xef\x9e\xceJ\xbfU\x8b<L`\xda!+=\xa5E>\x91\x95\x9e\xd2\"\xbf\x8e\x95\x1e\xd3\"\xbf\x8d\x95\xfe\xcd\" w\xf2\xe5N2\xad\xf7\xad\xcc\xd2\xffx\x8a\xdd\x9d\xfa\x9c\xbe\xb6\xf6\xe6\xd5\xeb7\xe4\xdf\x18\xd7\x1b2\xa3\x92gw\xdd2\xba\xfe\xbe\xda\x93\x19]\x7f_\xad\xd7\x19\xbf\x82\xd1u\xa73\xfeM\xef\xab\xdd\xc9\x97\x9a}\xd1G\xd8\x1c\xfct\x8a\x89\x9d\xfa\xf4\xdc\xac\xe7f=7\xeb\xb9\xd9\xdf\x81\x9b\x15|\xf9r\xf7\xa76ff\x7f9\xc5\xabN}\x0e\x8d\xd5\xd7/\xde\xbcz\xfd}J\xbe\xdb\xfd\xe9\xbb8I\xb4Jn\xdc\x16\x8e\xde\xb9\xc4w\xc8\xdb\x82\xde1\xf2\x91\xff\x99IZ\xee\xbb\xe5\x82\xdfn\xbcv\x10\x02\xe9\x8d\xd7\xaf\xe6\x82\x1d\x1b\xaf_\xcd\x05{\xe3\xf5\xa9\\\xf0\x9f\xc4x\xddR\xad\xc1\x91\x97\x89B@\x1d\xf56\x8e\xd8\xf2\xd4)\xeew\xeas\xda\x9c\xb5L\xf3W\xe43U\xd5\x86KA>R\xad\xaa\xad\xd0\xa2[&\xd8\xab\x82Of\x82\xff\x10\xaa`\x1f\x0c\xf9\xdfO\x15\xfc\x9b\x06C<{\xe3\x8a\x1e\xf1\xe35\x1f9\xc5\xdeN}\x1a|.\xb0\xb8\xe1b~\xfbq\xba\x98\x92\xdfA\x97\x83?\xee~\xbf\xdeR^\x98Q\xfcK\x0d\x0cC\xceG_vT\xff\xc3\x843z\x8d\xf0\xe7b\x86\xbdF\xd8k\x84\xcf\xf9\xd4\x98\xa1\xd8\xb1\xd2\x951\x96\xca\xb0\xbc\x9c\xaf\x99ju\xfb\x1d}\xf6\x14\xfb;\xf5\xf1\xe5Y\x8e\x7f\xea\xc5e\x1e{>*;\x93\x92\x7f\xa5eE\xe5\x9e\xbcy\xf5\xea\x17G_rS=V\x15\x06\x86\xd7V\x81	v\xd4\xadY\xfa\xd9\xe8f6\xbd\xba\x85\x15N\xe1\xa9\xab\xf1|1\x1b\xbf\xbd\x05 \x94i\xe0\xf5\x80\\\xb1\x15/])P;\x1c_\x14\xc7\x9e\xf7-\xa3\xc8\x05[K\x90\xb6W\x95\xb5M\xd1fiW(\xa4\xb7\xe2%V\xae\x99\xb3\x0c\x1byM\xf4F\x8aj\xbd!\xbf\xf5\xfc\xdf\xf1\xf9\xe6\xb8\x84<\x18X\xe0aP\x0f\xc8\xf0!Vj\xae\xf7\x84Vz#$\xff3\xf4\x17\xd5dj\xbe\x01\xc5\x92\xb8B\xa1\xc5\x8f\x14\xf4=\xbb\x86B\x84#h\xfa`\x10U\xc9C\xedZ\x9aA+n\x14-\xe5\xe7\xe0'\xee\xaamC\x99BQ\xa4P\xe5\xd1\xfe\xa3\x08\xe5\xbe\xcc\xb7X\xce&\x13\xdb\xad\xaf\xe5e\x1ft\x85\x88\xa8\xb6\x1d\x0e\xc8;[>'TwvT\xf5\x0b\xee\xd6\xe8\xcc\xb6r\x06SQ\xe4\x9c_\xe0\xab\xe2\x81\xc9\xd4V\x1a\x84r=\xbe\xd6 \xd4\xac\xaf\xd7\xf7\xc3\x9f\x80\x02\x92liI\xd7X\xe2\xd5\xd5\xc0\xc3\x81\xa5\xben\xe3r\xefK3\x86J\x9c\xa1>\xa3i\xe5\x9c\xf3\x0b\\\x1e\xb5\xe1;\xd3\xd2\x8a\xaf4(\x0e\x99i\xfa\xfc\x97\xaf\xfe3\x94Z\xdc\n\xc9,\xe1]C\x95\xf6\xa5l\xd5\x86J\xa6\\\x8b\xfc\x82,Y\xc9V\x1c\n\xff\xd5Z\x8f\xc6\x19\x96\xfc\xb3\xa8\xce\xc8\xb9\x90\xf0\x97<\xbb\x88W\x9d\x96@\x93{\x9eWX\x8e9\xde\x1f\xb6\x01[ \x937\xca\x15Y\xe5\x08K\x837\x8bd\x9a~\xb1b\xf9\x19\x96,o\xec\xb4\x9dd+&m)\xd9-\x9c\xc0-\xbd3]\xd4k6\xbb\xba[^\xbbi)\xba\xe8\xb5,\x04\xaa\x90L\xe4,\xad\xebX\xae\xf6\x17<\x90\xba\xf3\xbf\xe2\xebJF:X\x18\xfa\x14\x14\x90\xc3\xa1\x1b\xa5\x0f\xbe\xc3\x92\x92f@P\xac{\xcb\xb2\x0d-yF\xdd\x01\xd1\x92\x96\xcaWV3\x84\x85o\n\xfb\xcf\x15\xa1qA\xf7\xb4>A\xdbFc\x9a\x99\xd8\xee\xb89P\x02\xb5#\x9cf(\xd1^\xaf\x03\x1fq\xafL\x94\xf7\xc8\xbeA\x0d\xc3\xb3\xbbe9\xa7D\xefw\xf1\xb4?	yw\xc0\x14\xa0^\xa5\x191\xf0!\xb3\xd3\xd2\xb8t\xa9\x9d\x86?\x00H:;\xad-\xcd\x19\xa1\xf7\x94\x17P\xdb\xeb\xa0\x9cUj\xb8\xa9\xd9\x80\x99\xab2O=_h\xe8t\x8e\xbd\xc5z\x9ba+PA\x0f)\xe4Fk\x9b8\xa7%a_\xe8vW\x80\xa6\xe9Ky[}z\xb8\xdb\xb12\xe7_\xc8\x92\x15\xe2\xe1\"P\xa1Y\x88\xf2\xac\xb9\x03\xb0v_\x1b\x0d\xec\xecmKH\x037\xf0%UX\x88\xec\x1c\xca\xddJ~ov\xbf\x14[\xe4U\xbev\xad9\x0b\x0f\x1b\x8ee\x01\xdd\x11\xcc\xb9\x16\xd2\x1cw\xc9\xee9,\xa5\xd9\xc5\xa5\xd0\xf6\x9c\x10V\xd0\xa5\x90\xee_\xbe\x94e\xed4\xd9\xc6\x8c\x94c\x8a\x95:\xad\x95:\xa7\xa5\xad\xceL\x8b\x965?\xe4\xc7\x8eO\xadj\xc7?=\xac\xe3\x89\xd43\xbb\xd9\xae\x1d4o\xa5\x86d[\xca\xfd\xf9\x84:\xdbP\x12X\x8a-Lc\xcb$+\xf6\xa4\xe0\xe5\x1d\x10n\xc9K\xd8'%\xdd\xb2\x0b\xb7\xe8\xbc\xd4L\xaeh\x06B\"\x8dd\xa4'\xea\xb1\xe2\xa2a\xd5\xe3\x92\xcd\xad+\xde<\x03\xe1\x1aB\xe8\xcf\x13\xd0\x1e8'KCa\xe2r\xdf\xa8\x91\x1f\x15\xaat$\x85YQ\xfd\x84\xca\xa8\xe1PD\xb5\xdb\x1d1}\x05w-\x88\xd3;\x8eWsG>~\xa0V\xb8U\x06q\xf7\xa8\xb4\x88\x15\x15\xc3\x95\xa1\xfbP\x8c4.\xf4~\xd0\xcb\xd3\xa4=9\xf3sr\x15.Q\xde{\xb6,V\x84\x15,\xd3R\x94<K\xcd*,m\xf5\xe0\x07i\xde\x83\xda\x87\xdb\xaat\x05\x1c\xcd)\x88\x89\x1e\xd5\xaf7t\xd2*\x1c\x16\xa0\xbfJ\x1f\x15E\x9ew\xc5}\x882\x1a\x13\xd9R^\x98\x97\x0b\xae\xb4Jc\x91\xe5U!\xb5W\x9amU\xcc\xc2\xb9R\x15\x94w\xcf@F\xda'l}L\xc9\xac\xb6\xe2u\xad\x98\xe8i\xc4Fj\xbb \xa2\xb6\xa1[\xceUV),\x9dlz\xdc\x02\xbf\x8c\nk\xa7\x91hb_\x1c\x11\xeasu\xfb1\x13\xa5\xda\xf1\xac\x12\x95*\xf6Po\xde\xb0\xbe\xa8z\xb5m'g\x8a\xafK\xe0\xfd\xa1\xcc\xe3\x91\x9dh\x98\xd5\xd9DhBk\xe5\xd5\x07g\x87G\xb8\xa1_\xfbi\xbb\x13xR\xe5\x89	\x88~\x8bz\xa7\xa1v\xb2d\x19\x03N\xbe\xdc\xd7\xfa	\x87\xd0\x16\xad.L\xb7\x99\x90;!}\x01\xf2\xe8\xf8!#z3 \xef\xa1\xbe\xbfXEaL\xa7Y\x91y\xdd1\xd2j\xccD\xc7,\xe6\xca\x87\xf5z#\x17\x17\xe8\x05P\x00\xd8hx;\xa6+\x1a\x97\xab.\xf2\x07nt\x8dR\x94/`\xe5\x15\xbf\x87\x7f\xbep\xfe0)\xf6\xb4\xd0\xfb\x17+\xc9XJ\xb8\x94\xec^@9\xcf\x03in\xed?\xd3\xa1\xb3\xb6Xj\xd4\xc1\x9d\xd9\xc7\x07\x9c.\xb0s\xf0Me\xc5\xdel\xd4]A\xf7i\xf8f\xc7$\x8a\xda\x86\xab*rc\x91F\x19o\xf3+(\xcb\x07=\xb6\x88s\xe0-\xb8@\xdfG\x0btC\xa1\xfc\xf3?\xff\xea\x9c\xdb\xd2\xb0T\x11\xa5\xddaD\x07&\x1aD\x17d\x87s\x8dVoK\xefXJ6\xf4\x9e\x81\x96\xe7\xcb\xd1\x83\xe5\xb3Z\x19=O\x80\xa30\xb5\xff\xe5[\xac\xc0m(\xe2\xf9\x80U\x94\xadV\x08l\xc6\xcd\xcc\x90\x00\xd7\xc8\xf5\nUb\x8dh(\x8b=R\xd9\xf0.;4[N\x1a\x9f\x8d&\xb7\xdc\x1f\x16\x94\xf6|\xb3d\x19S\x8aJ\x0e\xa7s%y\xb9v\x16\x0d\xe3N\xf6\xc5\x07\xff\\]\x10Z\x88\x92Y\x89\x98\x89\xed\x92\x97^\xab\x87\xd7\x9a/\xb8	\xb9R\xbb\xb0\x01\xc1\x1fj\x94\xbc\xfa\xe0l\x17\x0ff)\x9c\xac\x1b\x901\x94\xe7\xb6\xed\xf0Ri\xae\xcd\x9e\xf6\x8b\xa2m-qWC\x1b\x98\x9c5\xdc\xa3\x82\xf5^\xb7\x96B\xa9\x17@0\x02\x85\xf5+\xa3?\xe1\xbf\xa1\xdeoA\x1fT\xc5\xb5\x99j\xc1\xd6(\x04\xa8\xf6\x83\x0f:A\x83+>\xc6\xe0@&\xe0\xc0\x955\xb5C;YX\x9c\xbd\x9b\x96[\x8f-h\xaaz\xc3P\x15\xab\xefD\xa729c\xd4\x9e\x14ghD\xd5\xfeWn\xdda0(\x1d\xec\x8d\x0b_\xa5\x9az?oN\xb5\xdf|\x9e\xba\xb6\x92r\x8e\xac\xe0\x17\x032c\xb1gh\xe0\x0b'{\xce\xd6\xe4B5\x9fy\xcc\x8f\x1e\xd1\xf2`I\x8c\xda\xc8r^mm\x11p\xa3\xd1\xa0\x9f\xdf\xb6R7\x9bQ\x84\x1f\xe1di0\x85\x80 akm\x19\xc3U>\xe2r\xc7\xa7\xce\xe9\x05\xce\xb4R\x9a\xac\xcdxCyy\xc92\xbe\xe3\xcc0\xadX\xf5\xf5\xd6\xa1\xf9\x1cL\xb4\x115\xb1+\xf6#\x88Q\xd7\xe72\xea\x13\x1d7A\x956v\x14\xc4P\xc0\xa9#\xcd\x16\x92b\xcbK\xb3O\xd0zTQ\xf7\x86\xc5\xf9-m\xda4\xa6\xfb\x1a\x88\x81\x05\xc9U\xbd\xe7,\xeaY2M9D[\xe0\xe9\xc8\x84'\xb6\xca~srQ\xc7\xbe\xc38\xbabC\x0f(\x1dS\xbb\xbbS\xc3\x16sf\xf4\xa64R&\xcc\x87\xeap\xdc\xec\xdc\xd0\x05\xd12\x9e&K%5\xcd\x0d\xb9\xa7k\x03\x06\x97\x0bPhwLB\xe1o\x17\xe5\xa2R\x07\xc1E\xac\x06\xdf\x9ch\x9dh\xf9\x85aZ~\xfd\xad\xe1g\x96\xfal2]\x8c/GgXC\x1e*\x93S\xe5\xfa0*w\xd4O|\xba\"\x16\xd0rR\x0e(\x0b\xeb\x155\xe5LOJ$\xa39\xd8\x98YT\xd5\xbc\x8d\xac\xce+\x1f\x93\xdf25\xe0\x0c8\x11\x98B\xfa\x14\xbaF\xcd\xb4S\xb8\x95\xae\xb0\xd9\xa8&\x05\xa3\xca\x98S\xb1\x9b\xde\xbe\x12N+\x94\xfbW?\xb8aR7\xc6@\xeb\xa8(z\xbc\xab\xd4\xa3c\xf81f\xe6\xb5M\x16\x9f\xeb\xba\x03\x8a\xf0U\xe03Fd\xae\x83\x04<l_\xc8\xf4\x90\xca\xd4\xe9z\x91\x97\xcb\xda\x06-TZ5N\n(\x10\xf7L\xe2b\xc55\xfa\xdd\xda\x94Bn\x8d\xc1l\x14\x0bF\xe5\x80,6h\x85\x19\xfeuH\xe6h\xbdAy@S\xda;\xf9h\x11\x19\xafFC\xa9\x0f\xc7\x9e-\x8c\xb8\x12\x1d\xf9\xe6\xbd\xd8\xa0yn\xfe\x96\xc6\xde\x89wd\xd4\x8a\x1b\xba\xa5\xd0SNB\x8a\xd4W<\xafm\x1d\xb0\xa7hi:ee^m\x9d\xdaZ\xdb1\x8e\xb1\xa0\xfd\xe7\x96\xb3\xc9\xd3\x80\xc0\xce\x89A\x8b\xf6\xc3\x04\xde*\xb2d\xa8\x07\xc8\xaa\xb9\xff\x900\xc7\xe2\x16\xad$\nV\x05\xa8\xad\xe0\xacG\x05\xa0\xe1\xf8\x8a\x96\xc24b\xe7\x11\x0fYH\x92s\xa3\xb5\xd6\xb4\xdc\x16\x0d\xde6\xd3\x1e2\xc2f\xa2X\x91X\xb5\x8c&\x0d\xc7f\x05\xc6\xe2\xfe\x88)\x12{\xe7\xfcQ\x82\xf6L\xd7\xb6\x89\xfa\x00\x0e\xa2U5)\xec\xb5\xeeLlQ\x956\xfb(qK\xc9bK\xa5a	\xd4\x16\xe4\x97`\xec\xb8\xc8:\xd8\xaaA\x0bT\x03r[\x16L)X4\xf6eW\xf0\x8c\x1b\xf3\x17Z\x8c\x02$\xb6_3\xff\x86\x16\x199\xb3\"7\xd6Q\xd7\x95mh\xb9\x87\x1e\x9b\x8e\x1c\x1f\xf1\x0f\xde\xe7\xaf1\xcd\x1c\x9c\xc2\x0c3\xda0\xd8\x04\xaa\xae\xb9\x8b>\xe2\xfb\x13\xa1\xcdK>z\xa3\x1dP\xc1\x18e\xe6\xd8\xae\xc1\xbc3b\x04\x86\xa6\xaa\x1d\x93\x8a\xe5\x0c\x03A\xe6\x18DKb;B\xed\x02\x1d\xa4\x9a\x05\x93h-\x19n\xfc\xbd=!`\x91\xb1/,\x8bX<0^O\x10\xc9\xd6Tb\\\xa9i{\xd8X\xc0\xaf\x06d\xe1\x14\x10e\xd8b\xa4G\xe7\x028\xa7F\x95;FX \xb4\x04\x07m\xde\xb6\x9d\x97t\xcbT\xa4\xd1(c\x10B\xa6jb\xff)$\xb1{\x18\x1fv\x9b\xd6\x8d\xd8\xed\x94`\xa6J\xf6\xa7\x8a\xdb\xe8\x91\x11\xe8J\x94 \xd2aI+\xa5\xc5\x96\xca=\x8c\x86\x97$g*\x93|i\x97\xc2\xb6\x85\x9e\xda\x03\xff\xac;Mn\xdd\xac4h\x11\x01H\xa9_\x0f\xc8\x15W`:1i\x9e\xfaD\xa5\xa1\xcb\xde\x1f\x02?\xd4\xe5\x1e\x0dX\xb0\xbc\x8d\x89\x15\xd8\x00\xac\"\x18/\xc1\x0b\x96\x86\x05\xb3g_\x85\xa1\x9e\x9b\xb12\x9a\xb9\xb3\x1b\x1b\xb7\xfei\xaeU}q/\x88\x80\x88\x9f\x05\xa8\x90\xb7\xc3\xf9x\xee\x88\xdb\x00\xab\x8cG\x16\xf9\xe1\xc3\xf25\xf0\n\xe3\x18\x01\xfe\xb2\x93f\x92~&\x1c\xf8J\x1e\xb9I\xd3\x16@R\x8aNu$\x95E\xdd\x1c\xb0X\xb1\"\x8b\xf1\xe2z\x94\x92\xc9t\xf2\"F\xac\xa4\x87\xc0\x17!\xeb\xd8\x17\xdb\xc6!\x02\x06\xa5-F\x0b\x0bV\x18[M\xedD\xa98D\x1d 2\x83Va}\xbb\xd0\xddN\x8a\x9d\xe4F=\x87	\xafH\x05\xbeR\xd8\x7f\x81\xe3F\xfeRbA_\xd5\x16l\x15\xc7\xae\xb9\x02\xce\xee\xb1`p6\x81\xa9\xdb8+xc\xe3@\xeb\xa11\x8b{\xef7\x03r\x1d0^bE\xae9]\xf2\x02\x82\xe7c#y	\xbb7{\xd7\x8c\x03\xdb(\x05)\xc0\xd9\xa97L\xc8}\xe4jq\x91,-\xa4\x8e]\x06%[\x17|\xcd\xca\x8c]\xa4>\xda\x9d\xd6\\\xb9\xde\xf3sr\xbf\x9f\xa3\xa2\xa0H\xce\n\xbe\x04\x85\x0e\x06\xb7\x96B)\x1f\xb7p]jB3\xad :\xde~>\x90{\xd6\xc4\x87\x90d\xe9\x96\xac\xe0\xd0\xb1\xf5\x08\xc0\xd2\xd2-]\xd7}\xf8\xe6m\x07	\x08\xe0\x00\xb5c\x19\x0fN6^f<7\x8a-\x86\x12\x8c\x02\x83>]N\x0b\xd7\xa8\xe3\xd0\xd9\x86\x1a\x121I\xa8\xc4\x98\xb9\x91\xe2^V\xab\xaa\xd0MC\x17\xa8Yy\x1eS\xe17\xbc\xb4\x8b\x19\xf1UwT\x81\x05<\x1a\x13w\xa32\xd3.\x04n\xd8\xb5\x10\xf9\x03/b\xdf\xe1\x1dQZ\xecv\xff?{g\xd7\x9c6\xf2\xa5\xf1\xfb\xfe\x14\xe7\xef\x9b\x89\xa70$\x99\x97\x9d\xc9LM\x8dl\x14[\xb5\x18X$\xc7qm\xed\x85\x0c\x0d\xa8,K\x94$\x9c0\xa9|\xf7\xadnu\xeb\x05D$\x0cN9\x99GW\x89\x11\xadV\xabu\x9e>\xa7\x7f\xe7\xe0\xce$\x11x\xbfX\x8a\x8eO]\xcf_F\xa9\x1a\xb9\xfet\x19\xe4\x8b\x1b)\x82\x15$\xc88\xbc\xbf\x17\x93\xb78\x1e\xe9\x85y|\xdc\x92\xf3P,\xd0\xd7\x03q\xaa\x8d,\x98\xeeN\x1e<\xb9I\x9a\x8e\xc4\"\x8ccO\x0d\x82\x86\x1bT\xf3\xe9\x1b\xf0{\x9b\x8c\xb1\xd0\x041\n\xda\xf2\x8a+\x1b\xb9P\x17^\x8a\xeb\xb9X\xba\x97_\xd7\x82\xd2\xd6\x05bZ\xd9*t<\x0f\xc34\n*#\x9d\xa5\xcdv\x19s%\x97\xa6\\\xda\x93\x16\xb9\xb2\x87n0\xe6\xe9M,\xd20\xa8\xb2~+9\xef\xf8}\xe0%\xd9\xfb\x98\xed\xde\xfa\xba\xef\x14\xde\xfa*\n%\xd7-\x1davR\xa0S\xccFY\xd12\xd1\xfe\x95\xa7-h\xe6`\\\x84\x1f\x84'\x94\xba\x92\xd9\x80\xc9\xf1,4\x9c\xdf\x9f$Z\x02\xbf\xb0\x1b\x92\xad\xb9\xd5\xb6\x88\x0c\xe2\xaa?\x0bC\x9a\x9bQ\xd9_\xb9\xd2\xc9wQr\x8b\x9eG\x8a\n\xd3@\xc5\x84\x85\xcf\xe4MS\xfb,^\xf8\xf4}\x97c3\xcd\xc6f\xc2\xa7<\x98\xa4\xdf\x98\x87\xfe\xa4\"t\xeeF\xf7\xd2\x12\xe9\xc5u6\x8a\xf9\xeb\xbc\x8c\xa2|\xb7LE\x8e\xdd8\xe6\x91x}T\x10\xb5\xb5\x197\xbe]\xa9\xc5F~C+1\x02\xf9\x98f\x8b\xf9\x0f\x85\xd9XX6f}I'\xb0\xd9\xef\n]\xad\xc2\xe0\xe4\xe7\xa5T\xde_\xa9\x1b\x8e\xef\xc4c\xb4\x82q\xfa}\xf5\x84'\x85\xb5m\x99\xe9k\x15\xc9=\xc5\x18k6\xee\xf8\x0f\xd1\x84^7\x8a\x07\x96\x9a\x19\x15\xe6\xd4\xcb-/P\xee\x82\x9c\xc2\x99\x0f\x9fIQ\xc1;KA\xe8R</;\x91\xdc$#\x12\xeb\xd8\xc0\x9euf\xf6m\xf3\xe4u\xfb\xa5\xfcN\x93\xa5\xd46\x91Pp\x10+\x86\x93J\x03\xa6\xfb\xe7\xc5\xa5\x13\xb6-\x95\xf6\\'\xe9\x15\x92\x1c7\x9b\xf3R\x17\xf4\xb6\xac\xd4\x9f\xa97&\xdf\x0dfKw\xc6i\x16>\xf0H\xaeI\x8a+\x03\xe5\xd6\xe6\x0b\xabx\xf3\xbe\x9e\x98m\xf5\x849>\x11\x1dn\x00\xb7\x16N\xae\xa3W\xeb\x8eg@\xb7\x02n\x05\xdc\n\xb8\x15p+\xe0V\xc0\xad\x80[\x01\xb7\x02n\x05\xdcZu\x95fj\x0f\xb8\x15p+\xe0\xd6o\x11\x9f\x04\xdc\xfa\x9c\x9f\x0e\xe0V\xc0\xad\x80[\x01\xb7\x02n\x05\xdc\n\xb8\x15p+\xe0V\xc0\xad\x80[\x01\xb7\x02n\x05\xdc\n\xb8\x15pk\x13hC\xb5\x02\xb8\xb5<\x9f\x00\xb7\x02n\x05\xdc\n\xb8\x15p+\xe0\xd6\xfd\xe1VG.\x8a\x82\xe5Gz\x1b.\x83\x89|l\xdf<\xe5\n\xc8\xf5{\x80\\\xa3e\xd0\x04o\x95\xa7\xd5\x81\xabu\x07\xc0V\x80\xad\xeb\x01\"\x80\xad\x00[\x01\xb6\x02l\x05\xd8\n\xb0\x15`+\xc0V\x80\xad\x00[\x01\xb6\x02l}bt\x12`\xebs~:\x00[\x01\xb6\x02l\x05\xd8\n\xb0\x15`+\xc0V\x80\xad\x00[\x01\xb6\x02l\x05\xd8\n\xb0\x15`+\xc0V\x80\xadM@\x0d\xd5\n\xc0\xd6\xf2|\x02\xd8\n\xb0\x15`+\xc0V\x80\xad\x00[\xf7\x05[\x7f\xfe\xae\xaa\xb6\x82g\xfd6y\xd6h\xd5\x99\xc8i\x98\xf08\xa9DY\xcbg\xd4Q\xaau\x07\x03\xa3\nF\x15\x8c\xaa\x96?0\xaa`T\xd3\xae\x83Q\x05\xa3\nF\x15\x8c*\x18U\xfd\x94\xc1\xa8\x82Q\x05\xa3\x9a9\x01`Tw\xa2 \xc1\xa8>\xe7\xa7\x03F\x15\x8c*\x18U0\xaa`T\xc1\xa8\x82Q\x05\xa3\nF\x15\x8c*\x18U0\xaa`T\xc1\xa8\x82Q\x05\xa3\xda\x04\xbeP\xad\x80Q-\xcf'0\xaa`T\xc1\xa8\x82Q\x05\xa3\nF\xf5\x11\x8c\xaa1\x1c\x9a\xfd\xae\xf5\xfe\x8dx\x842Z\xb0X\xf8\xa9\xf7XF\xf7\xc4g\xb2+\x1f\xb2\xbd$\"r\x1a~\xa1\xa50\n*E\x13T#\xb7\xa1\xe7\xf3h\xe1\x0bk\x9dzs\xad\xdc\x93\x9fz\xdc\x9f\xc4\xc4\x83\xb1\x1f\xc6\xa9\xd1\xbf\x8d\xdc\xf1\x1dOb:\xfa\xf4\xf9(3|22\xa1\xd4n\xa5'\x93\xb4\xaa\xca\xeb+x\xd2mz\xd1\x0d\x83\x1f2^\xa0\xf0\x8e\xea\xc6\xffsL\xd2[\x97nj<\x0f\x97\xfeD,\xf1\xb3~(\xef\xa0 \xdb\xaa\x11i\xa9\x82\x84\xe2U\x90\xb8\x1f\xb3\x8dP\xe9\xd4\xa7\x1dh\xd35'\xd7\x8fC\x8axz\xb6\x8a\x93j+.\xcfM\xe7M\x1c\xcb\x15k\xeav\xc9e\xe6B\x8b\xb1\xdeZ\xbd\xe59\xb2\"wHuOb\xf1\xc5\xa3E\xe4\xc9\xc0\xb5\xb0\xc1GB+\x92\xd2\xce\xa7\x82_D7\xb9\x1b{\xd9~\xbc\x1a9\xbd\xef\x9a\x85g\xf2 \x87\x1b\x8d\xe7\xde\x83\xb6\x94k\xb0\xb3\x18\xba3\xf7\xde\xf7\xe2$\x8c8\x19)\xd6\x00\xec\x19\xd8s3\xec\xf9\xb0\xdc\xf3\xe2n\xd6\xe1Q$f`\x05\xf3\\\xf8\xb4\x8eg\xae;X\xfe\x1a\xbc\x18\x1f\x8bW\xe1\x97\x16u\x85\xc3|6\xe7\x01_\xd1\x9f\x13\xf7\x81\xff=\x96\xffi\x07<\xf9\x8b\x19\xbe\xaf\x956\xe2\xc2o\x95\xdb?\xe5\x8d\x9ft\x85\x9d:\x9b\n\xe8\x10\x7f\xb9\xf5\x02\xe1\x85\n\xab\x12on\xdb\x14CB)3+\x9f\x83\x8ct\x94\xf7g\x92-\x1b2)\xf9\xc1\x937\x8c\xfd\xb8\xb6\x15%\xd7{E\xb6\xa4\xb0{AY<b\xc3\xce\xb4\xf4\xeaA\x18\x06\xd1D\xf1b\xc1d\xad'\x93\xcc\xf1mW\xf5\xc0\x0b\x8a#\xa0{\xa07\xc6\xbe\xd0	\xa6B0;vB\xdb\xfcu\xf0S-\x97\x14\x0f\xe7&<\xf2\\\xbf\x80\x03f\xe6\xa7\xb4\x97\xc7\x98sa\xd9d\x0f\xde:\xd7\xc6\xc8$\xcb\xa6\xe1h\xf0\xce\xea\x9a]:\xbd!\xe7\xc2\xa4\xb3\xc1\xf0fd\x9d_8t1\xe8u\xcdQ\xa6\xe0)\xa2>\x18\xd9\xfaeg\xe2\x03\xf1\n\x9b\xef\x87#\xe1\xa3\x0eFd]\x0e{\x96\xd9-\xbc\xf5-\xb2\xfag\xbd\xab\xae\xd5?o\xd1\xe9\x95C\xfd\x81C=\xeb\xd2r\xcc.9\x83\x96\xb8(\xdb\xfc\x9a0\x0fk\xfe\xb0\xecH\xc9\x1f\xae\xf0\x84\xc9\x18\x99\xack\xd9g=\xc3\xba4\xbbm\xb2\xfa\xd4\x1f\x90\xf9\xce\xec;d_\x18\xbd^\xe5]*\xdb\x94\xdf\xe3\xa9I=\xcb8\xed\x99L^\xa9\x7fC]kd\x9e9\xe2v\xf2\x7f\x9dY]\xb3\xef\x18\xbd\x16\xd9C\xf3\xcc\x12\xff0\xdf\x9b\x97\xc3\x9e1\xbai\xa96m\xf3\x7f\xae\xcc\xbec\x19=\xd65.\x8ds\xd3\xa6\x175C2\x1c\x0d\xce\xaeF2* \xc6\xc1\xbe:\xb5\x1d\xcb\xb9rL:\x1f\x0c\xbab\xa0\x99m\x8e\xdeYg\xa6\xfd\x07\xf5\x06\xb6\x1c-\x99\\\xd05\x1cC^x8\x1a\xbc\xb5\x1c\xfb\x0f\xf1\xef\xd3+\xdb\x92\x83f\xf5\x1ds4\xba\x1a\n\x03|L\x17\x83k\xf3\x9d9bg\xc6\x95mv\xe5\xe8\x0e\xfa\xf2V\x9d\x0bs0\xba\x11\x8d\x8a1P\xc1\x88\xeb\x0b\xd3\xb90Gb@\xe5H\x19b\x08lgd\x9d9\x85\xd3\xd8`D\xce`\xe4\x14\xee\x91\xfa\xe6y\xcf:7\xfbg\xa6\xe8\xcd@\xb4rm\xd9\xe61\x19#\xcb\x16'X\xe9e\xaf\x8d\x1b\x12\x92!\xd6\x8e\x17\xa6\xb8!&\xffY\x98\xb1-\xf9$\xc9zKF\xf7\x9d%\xba\xadN\x1e\x0el\xdbR\xf3D\x0e\xd9\xd9\x05\xa5\xc3}\xe8|\x96\xc5=\xff\xc7\x8d&\x9dYx2\xf1\xa6S\xdf\xbb\xad\xb4\xef\x9bg\xd5\xd9\xf1\xbac\xd3\xce\xff\xd4\xa2\xa1\x9bD\xde\xf8\x8e.\xe5\xe5\x9e\xbbag\xa9a\x17K\x9b\xfdL;+\x9a\xf6]mj\xbb\xb2\x03;[\xf6\xbd\xfa\xa0\xec:+\xd9\xf5\xc7Zu\xe9\x15\xcdy\x1eg\xf6R\x17Z\xbbxq\xb6p\xbd\x95\x91\x18\xb9\xfa\xe3\xc1$\x8c\xd2\xa8\xcc\"\n\xef\xc3\x843\x15\xaf\x8eK \xb7b\xa6t:\x82\x8e{fk\xafE\xe4\xe5$*\xcb\x97]\x87\x13\x1b&V\x96{\x8b\x0dSbS\xf1\xb5Fb\xc3\xaa\xc5\x86v\x10\x1bV'6\xd4HlX\x13\xb1\xa1\x06b\xc3\x9a\x89\x0d\xd5\x88\x0dk.6\xf4\x05\xb1a;\x8b\x0dm\x8a\x0d{\xbc\xd8\x90\x12\x1b\xf6\x8c\xc4&r\xefV\xbe\xdf\x91t\xcf]\x95\xce\x94O\xa8\x93\x90\xba#s<\xb7\x1fH\xaeDr%\x92+U+H\xaeDr\xa5\xea:\x92+\x91\\\x89\xe4J$W\"\xb9R?e$W\"\xb9\x12\xc9\x95\x99\x13\x80\xe4\xca\x9d\xd2\xf7\x90\\\xf9\x9c\x9f\x0e\x92+\x91\\\x89\xe4J$W\"\xb9\x12\xc9\x95H\xaeDr%\x92+\x91\\\x89\xe4J$W\"\xb9\x12\xc9\x95H\xaeDre\x13\xc4[\xb5\x82\xe4\xca\xf2|Br%\x92+\x91\\\x89\xe4J$W\"\xb9\xf2_\x97\\\xf9\xbf\xff\x87\xe4\xca\xe7\x99\\y\x1e\x863\x9f\xe3\x87d\x90Q\xb9CFe\x1d\xeb\\w\x94X\xe8x\xeeE\xe1\xb23\x0b\x17\xf12\xf1\xfc*\x1az\xfd\x94:\xde\xb9\xee`\xba\xa5\xf5g\x94\xde\xec\xa9\xdd\xcd\xdc\xeal\x03g\x92\xd2am\xb6\x99\xaf\xf3s\x8b\xae\x8d\xff6n\x8cK\x83l\xd9\xd5'K\xd8)\xc5\x8fZ\xec\xb1	;*\x13\x93\x0e\x9d\x8a\xb9k\xbaL\xbb\xaa\x0b;g\xec\x14r1\xf7I\xda\xd9\x04E\x1f\x9b\xb7\xf3#\xf5\xd5{\x9a\xa8\xf4\x1dm\xba\xb2\x89\xa7\xf81\n\xc2\xfc\xa4\xca\x1c\x1f\xa6B\x95_L\xf1\xa1\x1dR|D\x83\xd5Y>\xf4\x14Y>\xa4\xb2|\xd8^Y>\xb4\x96\xe5\xc3v\xcc\xf29DJ\xe9\xe0\xba_\x97\xe4\xc3j\x93|\x1ae\x94\xea$\x1f\xb6\x7fFi\x9e\xe4\xc3\xf6\xcd(-'\xf9\xb0=3Ju\x92\x0f;HF\xe9\x81\x92|\xb4\xb20\xc9\x91\x06\xae\xdfIS\x14:\xa9Aj\xcfBm+\x8a\n\"\xf7\xf2'\xd2\xc4\xa4\xe6Y\xbd~\xb3P\xc8m\x87\x07\xe3Px\xe7y#\xe2J\x1b2\xf2\xf2w\xb9\x8e=\x0fu\x85\x8b6=\x95\x8a\x1c<\xeds_\x1d\xd9+\xe7\xb2]\xdd\x83\x862\xc2\xaa\xc5l\xd7N\xb0*\x0di, l#\xf1\xb3ZB\n\xab\xf5J\xe5`\x1b\xd9\xa1\x8f\x90\x0d\xb6Kf\xe8\xc15\x83\x154\xe3q\x99\xa1l\x8bf\xec\x96\x19\xca\xb6i\xc6N\x99\xa1\x0d4\xe3+$\x86\xb2\xc7jF)1\x94\xed\xad\x19\xe2V\xd9\xb3\xd1\x8c\xc3T!\xa8\xf3u\xea\x8e5_(\\\xc4\x8bH\x88\xc5\x84\x8f\xbd{w\x8b;\xb4qV\x8d\xc3S{0!=\x97\x96\x93y\x8e/.-\xe7\xb8\xc2\xd7\xf9\x85lye\xfd\xdb\xb3\xc3|\xd7\xcf\x8bK\xb0*\x9f\xb4h\x1aqi\xb74\x7f\xaa)\x17\x1e\xc5a\xa0\x9c{\x19,\x93R\xc0t8<\xb3=j\xe3D\xef\x90\x94\x8dkJz\xa5\x11\x08[}\xe3\xe8X^d\xc2]\x9fe\x94\xca\x9a!\x8b\xb8\xb0\xb5*\x94\x9d\xc7\xce77\xa7\xe4\xd7S\xfdei\xe8\xbd%\xfb\xd9R{\xb3-\xba\xe7\xf2\xb6$\x7f\x1c\xcf[%\xd6b\x0dA\x16:\x10s\xdfg%\xf2/\xeb]\x1a\xc7LB%\xc4j\x88\xe2\x94\x0e\xcd\xa0.u'^\xcc\xa6\xcb(\xf0b\x95\xee4	)\x0e\xe5\x15\x8b\xd8\xf1\x16t\xcf\xd9\xea@)\x81\xf3\xe2\xe2^\xae\xfa(\xdb;/d]1\x85\xac\xa9}\xbbxy\x1b'n\xba\x17\xb2\x08\xa3l\x1dP\xec\xba\xd4\x08\xb3Z\"\x94\xe1o\xadGDn\xca\x11\x90\\\x0ft}\x99\x82\x08T\xac\x92\xe5\x1b\xfcE\xd3\xdfb\xf5\xbeB\xbfK\xfdA\xbf\xb8\xf7\xb8\xc5\xf83\xe3\xca\xb9\x10F^\x1a\xe8u\xb5\xdb\xb4\xfcRJZ\x99\xf5\xd66lK\xd6\xbc\xd1'CfA\x8b\xdb\xc8-\xa5\xcc\x96/\x9a\xbfVf\xfe\xde\x8e\x06\x97-\xa6,\xdf@[\xd8\xbe\x99\xb6\"\x86\x9aJOD\x98X\xbd\xa6V\x0dR\xd74zV\xff\\\xd8\xf8\xd2\xe3k3vB\xa7:\x9b\xad\xea'\x80g\xcb\x7fn=\x7f\xe6\x8d;\xd3\xc5\xa4\xa5@\xe7\xb9\nn\xe6\x13T\xbd(o\xd8\xd1\xd1QcS\xf4\x13\x0df\xcb\x7f\xe8T\xb6\x7fXKD\xe1\x94\x1d\xce\x12\x89W\xe5`\x96\x88\x92\x90\x1d\xc6\x12\xe5\x0c2\xdb\xc3\x12Q\x85%b_\xdb\x12\xc9m\xc8\xef\xc1\x12\xe9\x15(;\x80%\xa2\xdc\x12\xb1=,Q\xc52\x8d=\xde\x12Q\xd1\x12\xb1},\x11\x95-\x91\xb0\x1c\x07^\x8cy\xd1r\x11\xf3\xa0\xe3\x87\xb3hYY\xeeo\xfd\x94\xbaeV\xdd\xd1\xd8\xf6\xfdL\xb6w\x1f\x06d\xc6w\x9e?\x89ypX\xfb\x87\x95Xc\xfb\x87\x95\xd8!\xed\x1fVb\x8d\xed\xdf\xfaJ\xac\xce\xb6\xd5\x1de\xdb\x97D<\x19\xcf\xa3N\xc2\xe3\xc4\x9b\xae*\x8d\xdf\xfa9u\xd6\xad\xee`\x05\xcbWa\xf3^\x9f\xbc~\xf9\xea7\xbat\x13\x1a\xadx\x9a\xc3\xeb\xac|\x1e\xd1\xe92\x08\xc4\x0b\x0d\x1b\xf8/\xb6\x81\xdf\xcd\x1a\x106\xb0\xb1\x0d\x94\xb7\xf8T6\xf0\x03w\x1f\xb8,P\xd2I\xc6\x8b$r\xc7<:\xb9]L\xabl\xe1\xd6s\xebl^\xdd\x91\x11.\xdb\x0f\x94nC\xe96\x94nS\xad\xa0t\x1bJ\xb7\xa9\xae\xa3t\x1bJ\xb7\xa1t\x1bJ\xb7\xa1t\x9b~\xca(\xdd\x86\xd2m(\xdd\x969\x01(\xdd\xb6Sq0\x94n{\xceO\x07\xa5\xdbP\xba\x0d\xa5\xdbP\xba\x0d\xa5\xdbP\xba\x0d\xa5\xdb\x08\xa5\xdbP\xba\x0d\xa5\xdbP\xba\x0d\xa5\xdbP\xba\x0d\xa5\xdbP\xba\x0d\xa5\xdb\x1a\xd4\x92P\xad\xa0t[y>\xa1t\x1bJ\xb7\xa1t\x1bJ\xb7\xa1t\x1bJ\xb7=\xa2t[\xa9\xe2\xd6\xaf'\xaf_\xbe\xfa/\xba\xcexA\xea%\x13\x94\xdeB\xe9\xad\xafWzKV%\x91\x0f\xe9cg\x1c\xad\x16I\x98\x13\xad\x15\x9f\xd5\x11\xaau\x07\n\x9d\xa0\xd0	\n\x9d\xa0\xd0	\n\x9d|\xdb\x85N\x0e-=\x01O\xaauG|P'*u\x07D\x07\xa2\x03\xd1\x81\xe8@t :Em\x89Wq\xb5\xe8\x88\x0f\xeaD\xa5\xee\x80\xe8@t :\x10\x1d\x88\x0eD\xa7\xa8-	\xff\xb8\xc5\xd5\x91\x9f\xd4\xc9J\xdd\x01\xd9\x81\xec@v ;\x90\x1d\xc8NQ\\>\xf2(\n\xa3-\xfe\x8e\xfe\xb0N\\\xea\x8e\xcd2M\x10\x9f\xc7\xd9}\x88\x0f\xc4\x07\xe2\x03\xf1\xf9\x86\xc5gq7k{Ag<\xe7\xe3\xbb\xf6\xc3\xab\x82\xf2\xac\x7fR'+u\x07;\x0fe[tB\x06E\xb2\xce\x06\x8fU)\x17\xf7^\x925\x92\xc08\x0f\x19mJ\xd4\xcb\x13Y>\xfa|\x19'\xeeCH}\x8f\xdf\xf3\x15\x8f\xe8\xcfY\xfa\x97\xbf\x03\xf5\x97v\xc0\x93\xbf\xd8\x93\xfd\x9a\xd7\x81\x04L\xfe\x9a\x171\xf6j=\x99qW\xfd\xa2}\x7f\xce\x8b\xd8\xeb\xcd>4T\xb0\xcdn\xb0\xe7\xf1{^t\x10A\xc0oa}\xa7\xbf\x85\xc5RA\xa0g#\x08t\x98\xdf\xc2\xaa3\xf8uGA\x10\xbc`\x1c\x06\xf1<\xe2\x0f\xdc\xbd\xf5y\xc7\x0fg\xaf~i?\xbc\xde\x14\x88\xadg\xd6	B\xdd\xc1\xd6~\xf7\xd4\xf0\xdd\x80ly!\xc6\xf6%/\xf7\xc6.wa.w\xe1-\x0f\x01[\xeeOZ\x1e\x0c\xb3<\x04c\xf95\x00K5\x99W\xee\xbd_9\xcb\xf5\x07u\x93\xb6\xee\xd0\x08\xee\xf6\x03\xb5AC\xd4\x06Em\xd0\xb4\x15\xd4\x06EmP\xd5u\xd4\x06EmP\xd4\x06EmP\xd4\x06\xd5O\x19\xb5AQ\x1b\x14\xb5A3'\x00\xb5Aw\xaa>\x89\xda\xa0\xcf\xf9\xe9\xa06(j\x83\xa26(j\x83\xa26(j\x83\xa26(j\x83\xa26(j\x83\xa26(j\x83\xa26(j\x83\xa26(j\x836\xa10T+\xa8\x0dZ\x9eO\xa8\x0d\x8a\xda\xa0\xa8\x0d\x8a\xda\xa0\xa8\x0d\x8a\xda\xa0\x8f\xa8\x0dj\x0c\x87f\xbfk\xbd\x7f#\x1e\xa1\x8c\x16,\x16~\xea=\x96\xd1=\xf1\x99\xec\xca\x87l/\x89\x88\x9c\x86_h)\x8c\x82J\xd1\x04\xd5\xc8m\xe8\xf9<Z\xf8\xc2Z\xeb\x04\x80\xcc\x93\x9fz\xdc\x9f\xc4\xc4\x83\xb1\x1f\xc6\xa9\xd1\xbf\x8d\xdc\xf1\x1dOb:\xfa\xf4\xf9(3|22\xa1\xd4n\xa5'\x93\xb4\xaa\xca\xeb+x\xd2mz\xd1\x0d\x83\x1f2^\xa0\xf0\x8e\xea\xc6\xffsL\xd2[\x97nj<\x0f\x97\xfeD,\xf1\xb3~\xe8\x04\x86\\\xb6U#\xd2R\x05	\xc5\xab q?f\x1b\xa1\xd2\xa9O;\xd0\xa6kN\xae\x1f\x87\x14\xf1\xf4l\x15'\xd5V\\\x9e\x9b\xce\x9b8N\x93\xd6\xa4\xdb%\x97\x99\x0b-\xc6zk\xf5\x96\xe7\xc8\x8a\xdc!\xd5=\x89\xc5\x17\x8f\x16\x91'\x03\xd7\xc2\x06\x1f	\xadH\xe6\x9b\xa9\x0e\xb2\x9b\xdc\x8d\xbdl?^\x8d\x9c\xdew\xcd\xc23y\x90\xc3\x8d\xc6s\xefA[\xca|3\xf1\xd3j\xb5Z}\xa6O:\xd9nm\x97\xf5\xb3<]M\x92G\x13\xcf\xa85\x9bu\xaf	\x01\xbd\xff\xf2\xfb[\xad5\x9b\xf08i'a\xe8\x17\xf3\x90\x0b\x7f\xacC\x9c\xeb\x8e2\xd7\xff\x1b\x15\x1b\xd74\xcf7\x06\xf8\xef4\xcb\x0f1\xc5\xf7\x9f\xdf\x07\x9b\xdc\x87\x98\xd9O?\xad\xff?\x00\x00\xff\xffPK\x07\x08\xd9\x1d\xbf\xa5\xecq\x00\x00\x0f\xbc\x03\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x10\x00	\x00schema/flows.sqlUT\x05\x00\x01\x80Cm8\xbcT\xd1n\xda0\x14}\xcfW\xdc\xb7\x12-H<l/\xabZ)\x0b\xee\x16\x15B\x17\x12\xa9}\x8a\xdc\xe4\xc2\xac\x11;\xb2\x0d\xd3\xfe~\x8aC\nfY\x08EZ^0\xf2=\xbe\xe7\x9c{\xec &~B \xf1\xbf\xcc\x08\x84\x0f\x10-\x12 \xcf\xe12YB%E\x8eJ\xa1\x82\x91\x03\x00\xed\xff\x8c\x15\xf0\xca\xd6\n%\xa3\x1bS\x1f\xa5\xb3\x19<\xc5\xe1\xdc\x8f_\xe0\x91\xbcx\xa6\x9cU\xbb\x8f\xe6\x97\xa3~+k\xb6\xaa5+\x9a-\x8dk\x94\x87C\x82o$x\x84\x91\xd9\xbf\xbf\x83\x89\x0bS\xf2\xe0\xa7\xb3\x04&\x1e\x8c\xc7\x06x7\x81\x12)W\xb0\xa2l\xb3\x95\x08Z@N+]/\xf7\x14\x81\xf1\x95\x90%\xd5L\xf0\xa6!\xa7%\x02\xc0\x8e\xca\xfc\x07\x95\xa3O\x13\xf7\xd0\xb4mqscz$\x8b\xe9\xe23|\xc8\xcbb\xc38\x1ax.\x91j,@\xb3\x12\x95\xa6e\xf578H\xe3\x98DI\x96\x84s\xb2L\xfc\xf9S\xa3t[\x15\xef@\x1ah\x1a\x85\xdfS\x02\xa3\xdaG\xcf(\xf7\x1a\x1d\xae\xe3\xde:\xcex\x0c\xb9\xe0\x1cs\x0d\x8a\x15\xe8\xf4\x0c\x92\xe6\x9a\xed0\xe3\xa2x\x9be\xbd\x1e:H{\xee\x8c\x1f\xa6	1y 1\x89\x02b\xa5\xe5\x00pa\x11\xc1\x94\xccHB \xf0\x97\x81?%'\xea\x8ej[Y\x1b\xa64\xf2\xb3\xaa*\xaa\xd4U\xb2\x84\xd4\xbd!\xac\xf7\xefa\xe2\xfeW\x17<\xc3\xcbx\xb1\xd7\x1eFS\xf2\xdc\xa7=\xab\x11\xd9O\xfc]\xb7\xb1]I\x97a\xf4\x15^\xb5Dl\x04\xd5\x16\xf7x\xba\xda\x88_\xad\x97\xf5:k\xee\xa9\xf5\x0d\xf1V\x89\xad\xcc\x1b\x12\xd6\x11=\xce\xd9!\xdd#;\x9d\xab\x0f*Pi\xc6\xcd\x05\xb7\xba\xf4\x8d\xc6\xce\xcb\xb9\x0e\xfb\xcb\xc5\x04W\x96\xfc\x7f\xc5\xe5\xb8\xfe\x90\x9a\xf6\xe58\xfd.{\x0f\xe0\xe8%\xb9\xee$+u\xf6\x94\xbc.S\xcf\x05\xd1\x04&\xeb\x00f{\xbem.\x9bdYy\xec@y\xad\xcc!]m\xfa\xef$1\xc0\x83\x8bHu\xb18!z\xa1#6z\x08\x87\x01\xc6\\e\x88{\xeb\xfc	\x00\x00\xff\xffPK\x07\x08\xf9\xd7x,\x14\x02\x00\x007\x08\x00\x00PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\x00!(\xd9\x1d\xbf\xa5\xecq\x00\x00\x0f\xbc\x03\x00\x07\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x00\x00\x00\x00CREDITSUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\x00!(\xf9\xd7x,\x14\x02\x00\x007\x08\x00\x00\x10\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81*r\x00\x00schema/flows.sqlUT\x05\x00\x01\x80Cm8PK\x05\x06\x00\x00\x00\x00\x02\x00\x02\x00\x85\x00\x00\x00\x85t\x00\x00\x00\x00"
+	data := "PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x07\x00	\x00CREDITSUT\x05\x00\x01\x80Cm8\xec\xbd\xefr\x1b\xb9\x92/\xf8\xbd\x9e\x02\xcb\x88\x8d\x966\xcat\xbb\xcf\xbf9\xdd\xf7\xdeXZ\xa2m\xde\x91I\x0dI\xb5\x8f\xbf-X\x05\x92\x18\x17\x0b\x9c\xaa\xa2d\xce\x89\xf3P\xfb\x0e\xfb`\x1b\xbfD&\x80\")wO\x9f\x8e\xd8\x8d\x1b\xe2\x17\xcbd\x15\x90H$\xf2\x7f&\xde;u\xd5m\x8dj;]\x97\xba)UeW\x8dn\x8e\xd7\xd9\xb6\xeb\xf6\xed\x8f\xaf_o\\\xa5\xeb\xcd\xd05\x9b\xd7\xd9\xab\x7f\xf2\x93\xdd\xb8\xfd\xb1\xb1\x9bm\xa7\xae\x8ak\xf5\xc3\xf7\xdf\xffU-\xb7F\xbdwjt\xe8\xb6\xaei\x87jTU\x8a\x1eiUcZ\xd3<\x9ar\x98esS\xda\xb6k\xec\xea\xd0YW+]\x97\xea\xd0\x1aek\xd5\xbaCS\x18\xfafek\xdd\x1c\xd5\xda5\xbb6WO\xb6\xdb*\xd7\xd0\xbf\xee\xd0e;W\xda\xb5-4\x06\xc8\x95n\x8c\xda\x9bfg\xbb\xce\x94j\xdf\xb8G[\x9aRu[\xdd) d\xed\xaa\xca=\xd9z\xa3\nW\x97\x16/\xb5x)\xdb\x99\xee\xc7,SJ\xfd\x1f\xaa\x0fT\xab\xdcZ\xa0)\\i\xd4\xee\xd0v\xaa1\x9d\xb65\x0d\xa9W\xee\x11?1\n\xb2\xdau\xb60\xb9\xea\xb6\xb6U\x95m;\x0c\x90\xceV\x97'\xa0\x94\xb6-*mw\xa6\x19^\x86\xc0\xd6)\x12\x04\x82}\xe3\xcaCa\"\x10Y\x00B\xfd3@d\xbc\xb0\xd2\x15\x87\x9d\xa9;-{\xf3\xda5\xcau[\xd3\xa8\x9d\xeeLcu\xd5F\x14c?\x00J\x96\x82\xce\xeb\x99\x1aK\xafa\xd4Z\xef\x0c0\xf2\xde\xb9Me\xd4\xa4.\x86\xaav\xf17\xc2\xb7\xed\xda\xacp\xb5\x1f\xc75\xad\xda\xe9\xa3Z\x19\x10G\xa9:\xa7L]\xba\xa65\xa0\x83}\xe3v\xae3\xf8\xb7<\x14]\xabJ\xd3\xd8GS\xaau\xe3v\x19mB\xeb\xd6\xdd\x13(\x83iF\xb5{S\x80h\xd4\xbe\xb1 \xa5\x06\xe4R{\xc2i[\x82;[~\x98,\xd4b\xf6n\xf9i4\x1f\xab\xc9B\xdd\xcfg?On\xc7\xb7\xea\xedg\xb5\xfc0V7\xb3\xfb\xcf\xf3\xc9\xfb\x0fK\xf5avw;\x9e/\xd4hz\xabnf\xd3\xe5|\xf2\xf6a9\x9b/\xb2\xc1h\xa1&\x8b\x01\xfd0\x9a~V\xe3\xbf\xdd\xcf\xc7\x8b\x85\x9a\xcd\xd5\xe4\xe3\xfd\xddd|\xab>\x8d\xe6\xf3\xd1t9\x19/r5\x99\xde\xdc=\xdcN\xa6\xefs\xf5\xf6a\xa9\xa6\xb3ev7\xf98Y\x8eo\xd5r\x96\xd3\xa4\xe7\xaf\xa9\xd9;\xf5q<\xbf\xf90\x9a.Go'w\x93\xe5g\x9a\xef\xddd9\xc5\\\xeff\xf3l\xa4\xeeG\xf3\xe5\xe4\xe6\xe1n4W\xf7\x0f\xf3\xfb\xd9b\xac\xb0\xac\xdb\xc9\xe2\xe6n4\xf98\xbe\x1d\xaa\xc9TMgj\xfc\xf3x\xbaT\x8b\x0f\xa3\xbb\xbb\xfe*\xb3\xd9\xa7\xe9x\x0e\xd0\xd3%\xaa\xb7cu7\x19\xbd\xbd\x1bc\"Z\xe4\xedd>\xbeYb5\xf1\xaf\x9b\xc9\xedx\xba\x1c\xdd\xe5\xd9\xe2~|3\x19\xdd\xe5j\xfc\xb7\xf1\xc7\xfb\xbb\xd1\xfcs\xcec.\xc6\xff\xf60\x9e.'\xa3;u;\xfa8z?^\xa8\xab_\xc0\xc8\xfd|v\xf30\x1f\x7f\x04\xc8\xb3wj\xf1\xf0v\xb1\x9c,\x1f\x96c\xf5~6\xbb%</\xc6\xf3\x9f'7\xe3\xc5O\xean\x06\xcc\xbfS\x0f\x8bq\x9e\xdd\x8e\x96#\x9a\xf8~>{7Y.~\xc2\xdfo\x1f\x16\x13\xc2\xd9d\xba\x1c\xcf\xe7\x0f\xf7\xcb\xc9lz\xad>\xcc>\x8d\x7f\x1e\xcf\xd5\xcd\xe8a1\xc6F\xde\xaa\xd9\x14K\xcd\x96\x1f\xc6\xb3\xf9gL\x0d\x1c\x10\xees\xf5\xe9\xc3x\xf9a<\x07>	S# c\xb1\x9cOn\x96\xe9c\xb3\xb9Z\xce\xe6\xcb,\xaeQM\xc7\xef\xef&\xef\xc7\xd3\x9b1~\x9da\x94O\x93\xc5\xf8Z\x8d\xe6\x93\xc5d\xfa\x1eC\x02\xc3\x9fF\x9f\xd5\xeca\x89y\xb1E\x0f\x8bqF\x7f&\x04\x9b\xd3F\xaa\xc9;5\xba\xfdy\x02\xb0\xf9\xe1\xfb\xd9b1a2!\x94\xdd|`t\x0f\xb3\xec\xbf\xff\x93\x9f,\xdb\xd8n{X\x0d\x0b\xb7{=\xfa\xcfCc^o\xdc+]\xb7\xb63\xcd.\xca\x9eo=\x14\xc5\xcao\xfbd\x90<\x1f'Kug\x0bS\xb7F]}\x9c,\xaf\xb33\x19\xf5\xe6O\xea\xa3-\x1a\x07\xe6\xa0n\\\xb3w\x0d1\xba,\xbb\x0fl@\xd9VmMcVG\xb5it\xdd\x992W\xeb\xc6\x10\xff*\xb6\xba\xd9\x80\xcd;\xa5\xeb#xG\xebj\xe5V\x90\x0b\x90/\x9aDB\xe6\xd6\xaa\xcf\x83\xc0\xfeu\xdb\xba\xc2j\x88\xa8>\x93]\xdb\xca\xb4^t\x0f\x16\xcc\xb5\x06\xd74Iit%\x9cyq\xca\xd0\x1a\x03)Z`\x8c\\\xd9\xba\xa8\x0e%`\x90\x9f+\xbb\xb3\xcc\xc6\xc1\x82\xbd\x1c\xce:\x07~\x9a\x13\x9c\xb9\"9\x8a\x7f\x0d-k\x7fXU\xb6\xdd\xe6*\xb0s\x93\xab\x16_\x12Vs\x91\x07\xad\xa9*\xc8\x1d\xeb\x19w\n\x1d=\x03\xd0\x89\xafv\x8c\xa2\x16\xdf<m\xdd\xae\xf7\xac\xb2m\xb6>4\xb5m\xb7\x9e\xc3\x97N\xb5\x8ef\xfcwStx\xe79\xf1\xfdc\x96-\xcf\xc50K@\x0f\x026 2w\xf9\xa9\xdd\xea\xaaR+\xc3\x083\xa5\xb2u\x86\xafd9\x0d\xa6\x87\n\xd5Y]\xa9\xbdk\x82>\x90\x82N\xb2b|YT\xb0\x00\xc8\xd5\xa7\xc9\xf2\x03\x8e,\xf3m\xe2\x198\xca\xff:\x99\xde\xe6\x89\\\xc8\x98\xc1'\xc2@d\x81\x8a\xb2\x80\x8e\xfd7E@\x9e%\xfc_]\xe6\xff\xd3[5\x9dM'\xd3w\xf3\xc9\xf4=1\xd1g\x84@6zX~\x00\xb3\x9f\xcd/H\xbds	@\"%\x0f\\\\xYv\x99I\x8e\xa6jt\x03N\x0b\x9cD\x8e	\xe6\xd8c\x83y`\x83\xef\xe6\xb3\x8fy\xc6\x1cp&\x9cv:\xf6\xa3\x00\xd5\xaa\xb7#`\xb5\x9eO\x86\x01\xd5\xedxt7\x99\xbe\x07\xaf\xefm\xdf\xef\xcd\x05\xc7\x8d-\xee\xf4\xc64\x9b\xc6\xd4`t\x9b\xfaP\xd9\xd5%^\xf8\xdc\xa3\x91\xb7\xfd\xb6O\xf6~\xfa\xa0\xde\x8f\xa7\xe3\xf9\xe8N\xdd?\xbc\xbd\x9b\xdc\xa8\xbb\xc9\xcdx\xba\x18C=\xbb\xf4\xf9\xd94tR\xfe\x90\xab\x1f\xfe\xaa\xfe\xe7\xa16\xd0\xea\xff\x92e*a\xa37\xa4\xea\xffE\xbdkLr\x90\xdf\xb9C]\xb26N\xca\xdd\x7f\xc3R\x7f|\xfdz\xdd\xae\xc9\xdc\xf8\x1f\x99\x1a?\x9a\xe6\xe8j\x9c\xfaD[\xef\x1cN\xde\x91\x8el\xe4:\xea\xd14+\xdd\xd9\x1d\x1f\xcbL	KeV\x14Xh\xaeV\x87N\x15[]o\xc0\xfcl\x87\xe1k\xd7)\x0d\xa6A\xf6F\xba\xc6\xd3\xcf}c\xf4nU\x19<\x05\x86BX3\xb5it\xa5\xee\x89\xf1\x05\x91b[\xa5I\x10x\xe6Y\x99u\x17\xa0Y\xbb&\xeb1{\xaf1\x7f\xb1uI\x1c\xf2\xc95_\xda\xa1L\xc2o\xb5xM\xed\\\xdbEe5\xbe\xbbot\xd1\xd9BW\xfee\xd8+\xaa4\xad\xdd\xd4\xa6\x04\x17\xef\xf4\x17\xa3\xf4\x93>\xaa\xa3;4\x04X	\xfe\xea\xc0\xe2X\xe4\x10Z\xbc\xad@\x83\x0c\x95z{T\xa4c\xeb\xb6\xcb\xb3\xee\x17Wl\xeb\xce\xd4\xe0\x92\x9dS\x9b\x83&yhNg\xcc\xcef\x04C}\xf4\xe4D\xeb\xd7\xd0\xd47\x8d\xde\xbdz\xd59\xb5\x03\xe8\xed\xa11\xd8\xae\xc6\xec\xb4\xad[Z@\xc4!0\x83Al\xd7B^\xc1\x98T\x9f x\xb7\xe6\x1b\xa4\x073\x12\xd6\xc87v1\xa0\xdc\xad\x15\xf0&3\xfe\x04X\xf4~_A\x08\xe8\xaau\xaas\x19d\xbc\xdfI\xa0O5\xa62\xba\x052 [\x80\xfa\xd5\x91 \xd4b\xf0\xaa\xcf\xee\xa0\n]\xd3H\xf8\x0d\xa3\x10\xb6x\xfd-\xc4\xba#J\xf8\xb45\xb5z2\xb0K\xf4\x17\x80\x03\x0c\x04xr\xfc\x04L4fm\x9a\x06\xb4\xdd9\xc1y\x0e\n\xcf\xf6\x8d-\xccP\xa9\xd9\xa1yf\xb5}\xaaQ=\xd4\x93u|t\x87l\xab\x1f=\x89\xf0\xe0x,9\x89Q\xcc\xf7\xe0SW\xbc\xdd\xcd\x06Vm\x03Z\xda)\xbb\xc6\x90\xea\xc9\xb6\xdb\xeb<L\xa1\x1aS\x18\xfbhz\x16\xb5k\x08Q\x1b\xd3\x01M\xfe\xc5\xecI\xd7\xf8o\xf2*\x9eI\xc88L\xef\x1a\xda\xed\xbd5\x85X\x8f\xb0\x98k\xf3\x94\x11\x9c\x11\xdf\x803\x0c\xf7\xa5vOa\xdc\xd2aL\xa2\x19[o\xf8|:\xbc\xdaA\xfd\xa0}\xf3j\x13\xedFm<\x0e\xf7\x8dy4u\xe7)\x03\x84\xebv\xaa4\xf5\x11{\x84E\xf81\xd9\xef\x01:n\xbf\xf0O@m{h\x1a\x1c\xa9&\xd1\xca\x86\xc4\x17\x1a\xb3v\x8d\xc9\xe9AlJV\x98\x06\x8a%\x14\xbd\xbd\xab[\xbb\xb2\x95\xed\xb0\x19\x8c\xe6\x8b\xbb\x94b)\xc7\xf4v\x0d\ndUO\xd9\xee\xc7\xf3\xf1:G\xdfa\xd1)!\xe0\x88\x80\xfa=f\xde\xb9F\x99\xafz\xb7\xafL\xfeM\x08\xdaC\xb1\x8d'>WO[\x83Q\xb2\x0dt\xed\x16 \xe1\x10j\xb56\xbcXrm\xecu\xdb*W\x8b\xd2\xd7\x98\xc2\xee\xad\xa9\xbb\x96\xfe\xdb\xea\x9d\xc9\x98@\xdb3\xc2*\xf9\xe4\xd1@\x91\xbb\x88\x03\xe8H\x87.\x172\xcc\x12\xd2\xeb\xb6=\xaa\x1c*5\xaa\xcb\x08T\xbbuOxf'\x84b\x9a\x1d\xd4z\xfc\xf7\x98\x111u[c\x85L\x08O\xb7\xe6\xd1T\x0ez\xaf\x07\x80\x99\x92\x17-\xf7w\x97\xc8\x8b\xfd(ON\xb5\x9d\xd9\xb7?fWo\xaea/\x98\xa6K\x94[W\x9fl.(\xfb\xea\x87k\xe5\xd6k\xd3\x10\xd0\xc4\x98X`e\x1b\xfb(tW\x99\x8d\xaeRm\x98\xc5n\xaa\xe9\x8br\x1f\x08%\xec:f-\xc3\xaa\xbe\xc3\x83\xc2\xf2\xbe\x93\xe5\x90\x11\x82\x07\xb1\xc4\xa22\xba\xa9\x8e\xca|\xddW`\xee\x99\xec\x04X~\xabj\xa7\x9et\x03YB\xbe-\x0fu\x8f\xbb\x0c\xfd\xc4+\xd7m\x81?\xcc\xa3\xeb2\x0b\x93\xb6\xfa\x0b\x0b\x03L\xd7\x98\xff8\xd8\xc60\xbe	~k\xca(\x80VF\xedt\xf3\xc5\x94J\xb7\x99g&e\xeew\xd1{\x08-\xf1\xe7Uev\xd8\x8a\xaa\x02{\xc5[\xbac\xdc\x94\xca4\x8d\xab\x8d;\xb4\xd5\x11<\x9d!\xc1\x11\x01;\xb0\xee\xd0\x86\xf9\x08o\x0b\xb7\x83\xbc~\xb4\xc5\x05.\x0cn\xe1\xd7\xa5tQ\x98\x96\xcc#[\xb7\x1dD\x9ekTs\xa8\xb3\xf3e\x9c\x1cn\xbc`K0/\xb3\xcb\x95\xae\xe0\x19\xdd\x907N\xedt}X\xeb\xa2;4\xa6\xc9\x98\xd3\xb5\x8e\xb8\x0cdz\xab\xd6P\xd7\xc8\xcdWAL\xd5\x85\xdb\xedugW\x95aR\xdc\x1a\xa5\xedN\xb9u&\xfb[od'\x129q\x813\xd3,F\xb5\xc7\xb63;\xdd\xd9\"\xdb\xeb\xae3M\x1dY\xc3\nG\xc2\x15\xc5\xa1i\x15\x1b\xb7\xba1\x1a\x0f\x04\x87\x1eX\x84\xadK\xfbh\xcb\x03\\\x8e\x9d\xcb\xc8n}\xda\xdab\x0b]l\x0f\x0e\xd1\x9a\xea\x08\xfe\x02\xba\"\xcd\x8f\xb4\xa9C\x0d\xac\xee;\xbd\xaaL\x9f\xb5>\x19/\xeeD\x91\xf2\xa4\xc7\x1b'\x08\x06I\x11\x9bw[\xbb\xb2D \xa2\x8d\x19\xa6W\xd7\x9aL@\x1d*5\xe1\x95\x05\"\xd2\x8dmMjH\x12\x96Y\x97(\x1di<\x04\x0d\x0c\xcdR5F\x97 +e\xbeB\xdd\xf2P\x91\x93U\x0e+M)o\x02i\xeb\x03v7\x10G\x16a\xcf\x95nIV\x89\xb4r\xdd\x05\xc6N{I\x94\xfa\xce\xd6\xa0\x82\\\x19(\xe8\xc2\xb6\x81\xccn\xdb\x18\xdd\x19\x10m\xe1@\x9duW\x91\xce#{\xad\xf6\xf8\x19|o\xd1\xe9\xce\xb4\xe0\x97\x87\xaa\x8c\xfa\xb7<\xc0\x12\x86\xdc\x15\xc2J@~\xc1\xf9\xef\xd6Q\xf7s\xb5:t\xb6\x1a\xee\x0f\xcd\x1e\xab\x06q\x1e:\xd3\xb4^\xd7'\x8a\xc1\xf7\xc4TJ\x07<\x92\xc6\x01\x1a\xd1\x8f\xce\x02\x7f\xa4V\x15\xb0\xe0K(\xa4\xd83\xdd\x05h\xa0\x9d\x81C\xc0\x8d\xa3RuA\x15\x80?#\x11b;e\xd6kSt\xf6\x11T\xb6o\xdc\xbe\xb1\xa6\xd3\xcd\x114\x15\xd5\x00lV\xe4E\xbam\x0f\xc2\x8a2\x99\xaf\xd05s\x14\xf1e'*\x80L]\xbb\xfa\x15`	f\x02S8\x0b\x1d`*	(\x80\x0e\xc1\xc1m\xbdI\x988\xfb\xec{\xf1\x11\x0e\x7f<o\x08-\xc7\xf3\x8f\xc1\x8f};\x81=\xbd\xc0\xc3\xdf\x0f\xd5\xadY\xdb\xda{\\\xe8\xfd\xc12\x110\x03\xaf\x9b\xd2\xe62\x1d\xaa?\x84C\xf4\xac\n\xee\x07\n&\xe5\xc0k\xdb;\xa3\xeb6\n\xbbW\x95\xfdbT\xa5\x9f\x98\xa9c\xc7\xe8\x84\xf4\x0d\xab\x0czy\x9b\xb3\xca\xd1\xaa\xd6\xec,\x90t(:\xe8\xf9\xbae\xabk\x00\x96t\xef\x11\x9d\x82\x0d\x05?\xcc	v\xe1\x15}6\xd0Ju\xe0]\xb2m\xc6Ru\xa8\xd4X\x17[y\x84\xe4\x99.\xcb\xc6\xb4\xd8Y\xdd\xaa\xc1\xd1\x1d\x06C\xa5\x06\xfc\x82i\x07\xb4%\x83\xa8\xd1\x0c$\xb2\x9128\xd7(\xd7ltm\xffSG|/\x9d\x1a\xd0^\x1e\x07J{\xd8<\xa2X~{\xe5\x13\xdaT\xa9\xf7t\xe6\xf0\x9f\xbdn:\xd9\x07\xbc\x03\x0f\xa2Vk\xddnA\x1f^Z\x82\x9f\x87\x95'~\xb2\x9c1\xdcmu\xcd\xb2\x84\xb4W\xb7V\xba\xce\xccW]x\x95\x84\xd9|c\xdaCE\xc2\x01\x13\x01\x19\x85\xae*`B\x0dN%\xd8\x80a\xca\xa0\x1cX1\xaa\x00\xbc\x7fy\xb0\xd2\xc0\xa1\xab\x07xJ\xa5O\x11\xcd\x8c\xd4\xa0p\x8f\xa61%=?`T$a\xa6C\x1d\xa4&ov2<\x8d\x9e\xb1\x12\xc5?\x07$\xe3t\xeb\x8d\xee\xcc9\x9eK\"\x93n+\xbeU0\x06\xb0\x9e\\<\xadY\x8a\xbd'0\x10\xaf\x83B!+mc\npM\xf2\x9a\x824uc\xab\xa3\xaa,Q\x1b\xfc\x06\xb6^c7\x0c\xf1COp\xa0v[\xd0\x13q\x8f*\xfd\x94+\xf3\xb50\xfbN\x99\xaf\xa68t\xec\xf0\xc0\x99\xcf\x84I\xaa\xa0\xc1\xe1Wh\xe1\xf6QwF\xf6\xec\x9e\xd7	B`\x0fh\x1b\xf8H/\x8a\xa7\xaeN\x82\xae\xacX\xf9\xa0\xebu.\x84\xa1\x1f\xb5\xad\x08T\xaf\xb9g\xe4D.\xbc\x1f\x98b\xba;\xcc~@\\\x0f\x16\n\x9d_\x08\xd3Go{\xe8V=\x99\xaa\n;Q\xb8\xfa\xd1\x9c\x92;\xce)\xce<\xab\x08a	\xd8\x07ejL\xcfCg ~\xb6ih\x17\\\x13\xccOo\xca\x0e\x95\xfaH\nC\xdd\x19\xf2\xb1\xb8\x9avRipg\x9c\xf6\xc6\x1d6\xdbL\x07\xc1\xa3j\xd3\x81\x84\xfc\x8eC}\xed\x1a]\xb7P\xbaq,X\x93f\xbf\x93\x87\xdf\xd6\x1bZ\xd1\xa8\x8e\xf3<R(\xb3\xf1_\xacua\xc0\xb7\xf7\x95>\xb6j0\xda\x83\x02\x1b\x84\x07\xd4\x1d)\xebS\n\xe6\xb6\x83\x8c\x0d\"R\x0d\xa0\x8c\xe8\x0e\x9b\x1e6\x0f\xd3\xd7\x8f\xa6\xb6\"N\x11\x1b\xb5\xb5!Y\x0d\x05\x02\xde\xad\xb5\xd1\xa4.\x10\xbe`\\\x84\x99\xbd\xaf\"\xcc}\x1eLf##\xebL\x055l\xcb\x8b\xf8%\x8d\x9e9\xe9\x15\x13,\xd3F\xba\x08~\xc1\xb2~,1e\xf1\x1b\x08\x83\xf5a`\x8fU\x1crb\xf9	c\x16\xb1\xe2	\x8e\xec5\xa7\x1e\xady\xe2\x8d	\x9eC~\x8e\xd45\xd8\xe8\xc9>\xec\x91\x9d\x00SS\x87\xd8=\x91B\xe1v;M.\xbcF\xb9=(%\x915:\xdb\x99\xfa\x90+\x1d1\xael\x07'\x88g14\xd2\xce\x18h?\x90\x9aE\x83\x80\x98\x8f3+\xf5f\xa8\x16\xde\xee\xbcqe\x94\xf9\x83\xc4\x18\x1d\xb0\x9d\x9c\xb2}\xaf\x16\xc0#\x84`\xb7kv\xccS\xe9\xa1\x0c\xcf\xf3\xb1L\x0f+Ih\xdbA(\xcd|d\x05\xb6\xae\xf0O\x9c-\xe8\x1e~\xe6L\x06\xd5=\xc6\xbb\x90\x9c\x92\x89 -\xbe\x9e \x92v\x8e\x19\xb2\xa5\xdf\xdczm\xa1\x87e!+\xa5\x84NaJ\xe8\x91\x1a&\xb9\xdb\xd4\xf6?M\x19\xd2VZ\xb5r\xe5\x11\xae\x8b\\PYh\xaf\"\x86\x89Z\x89\xe6{,`\x0fp\xec\x8bC\xa5\x1bQ\xa8v@\x03\xf2]\x0e\x1a\xb1-x\x9e\x01^\x06\xd7\x9d-\xa1\xd2\x912\xa6w\xae\xde$\xe6--\x1b\xaf\xd2\xdc\xba\x0bC\xc4=Z\x90m\xa3\xee(\xbd\xc6B\xc0\x83\x0d\xd4\xcc\x95\xa3\x1a\xc1g4\x88\x0f\x96\xadY\x90\xad\x98\n\x0cP#6\x06\xb7\nMx\xa5\xaf\x81<~\xbb\x14$\xd4\xae\xd9\xe9J6=\xdb\xeb\xe2\x8b&\x9f\xb7V\x1f\xf5\xbf\xbbF\xdd\xb8\xdd\xde\x81\x0c\xbd\x9e\x1c,%p\xa5\xa8\x12\xe8\xce?\x9e%\x8f\xd3\x19_]+J\xd1\x81\x17\xc6\xebZ\x9e\xb1\x02M)\x95\x89\x85\xa8\xbb\xecl^\x1c}\xa7,|D$\xce\xb4:'\x1c\xac\x80\x81\xd3u\x16\x9ee\x99\xd4\x9e	\x14\x1f\x95,\x92\xfc P/F\xd9\xc1]\x93\x0dN\xa0\x180\xd9\xe0\xc8\xb9\xba3_\xbb\\HU\xed\xe8Q\xe8j>\xbcW\x08\x0e\xb2\xab/\xa6\xa9M\x05\x16_\x97\xee\x89\xedW\x8f\x9a\xd6)W_\x0b\x0e\x98\xf2\n\x05r\xd1$\x84\xfd\xc3\xd9\x95\x05\x19\x1c\xaf!\x91\xfd\x02q^O\xa9\xa29\x80\x8b\x90^\x82\xe9me\x1a1\x0b\xd8\xa6\x8c\x1e{\xc2\xa8F\x849\x1c[\x7f\xda\xf6\x8d\xe9\xe2{\x18\x13\x07<P\xe8\x8dk\xbc\x93\x8f\"\xc2\x9e\xd1\xf4\xd8\x89\xed\x8f	d\n\x92\xaa*;\xf1\x8a%\xd6\xe4\x86b#\x08\x0b\xb3\xbb\xc2c\xe8\n{\xda;\x01\xc4\xa6\xaf	0\x8c\x96N\x06j\x83+\x1a\x1c\xea\x98,5\x86\xb0\xdb\xa2\xb1{\xf0M\xe7\x13\x82\\\xc5&_\xd4\x1b\x86J}pO0Ys\x88\xc3\xd2\x19H\xa1 \x17\xc3\xb0\xdf\xb5\xd9\xe9q%\xa4\xf6,\xcc\xce9\xaf\x81\xd3\xb7\xc7\x84\x04a\x90e\xccPZ\xa1\xda\x86\xed\xb8D\xdd\xb4\x94H\x044\x02\xfeS`q\x1e\xb3\xf8r\xff@z8\x87'\x0e\xd6K;\x98\xf1\xe2\xda\x84\xe3\x96\xc1B\xe3<\x82$\xcd\x80\x0e*\x0b\x14\xff#;\xea\x99\xb6$\x1f,\x919x\xc2\x07\x90$}\x90\xe4s]\xaa\xf2X\xeb\x1d\xe2Q\xd51\xabl\x0doZ{X\x05\xd4\x88>\x10\xec\x009&\xf0-\xf4B\x10\xec\xad\xcb31\xda\x10D\xa9;\xbb\x83\xeeS\xea\x8e\x8e\xc5\xeeP\xb3\xf0\xc2f	\x11\xac+\xf7\xa4V\xa6{2\x86\x9d\x00Y\nC\x12<\xd3M\x17R\x13\x82\x1c\x83\x80\xbd\x84W\xa2\xef\x1e\xf5\x08\xc7\x0e\x1e\xdc\xa6%?Zc\xe4\x00\xc0\x01\xea\xc8\xbfE\x0b\xf4v\xd8\xf9\xdc\xbd\xe92\x7f\x10\xc31\xbd\x08K\xff\x90\x9el\x0d\xbbet\x97\xc1!\x1eW\xf6\xc3P\xbd\xd5\xad-TLcii\x9a$\x03\x93\x93Y.\xa8ND\xd1\xf2\xb3\xa8o\xf0:\xc0-\x135\xc2\xbe\xe9\xe4\x0f>\xde\xb4Mc\x1e\x9d7WD\x8bS\x9c\x88\xda\x992K\xbc\x16x|g:\xf1D\xb2JF~b[Xh\xacz\xbd\xb6pw\x90w\xfcPS\x12\x8b)\x13\x13K\xd8\xdde{\x8f\xcdRw\xe8\xf6\x87\xce\xefJs\xa8}nN\x96Z\x8f@\xa4\xfc\x7fu\xec9\xce\xbd\xfc\xb3~\x03\xfdH\xb9\xda\xd8GS\x87\x14E\x12v\xe4\x13\xb3\xdd\x01\x8e/\x1d\x06\xe3\xc3\x9c:J\x94.\x10*\xa8L\xb91~e\x99D\x87\xd6j\xad-\xf1q\x15\xf2,q>\x1euEs\xe8$\xd9r\x95\xf8*`\x0d\xd2\x06S\xd8C\x1f\xa1\xf6\x99\x1ck\xa5m	\xe6l\x0f,\xf6\xa5\x90]\xea@\xef\xd8\x9aG\x84G\xc4\xc4K\xb6\xaau\xaa\x82f\xa4y/X\x1f\xf7\x07\xec	\xeeM\x8e\xdc\xc2\xbb\xb0v\xa0k\x0e\xc2Du\xfddr\x17BfLc\xad\x03\xc9x&\x0c\x0f\xe6VS\xc4\x82\x02.X\xd0\x89\x16k\xbe\x16\xd5\xa1\xf5\xee8\x0cqt\x07\xe2\xe5\x8c!\xfc?\xc3J\xd4Z\x17\x12*\xc3sB\x01\x9e-\xb3\xcb(\xa05\xe0\x04\xd2\x18\xeed\xdbm\x89I\xe2\x040\x13I\xf6\xd2\xd6\xbc:@\n\x7f\xbd\xa4\xc5&z\x8d\xc70\x92\x82\x03\xeb\n;G\x14JBg{hE[\xef\x01y\xb2i\x19/\xd5G\xb7(E\xaa\x87	W\xe3\xd7F\xad\xccVW\xeb\x9c\xcf7}\xe5\xbd\x0f\xd6\xd5\x19{\x0f\xc1Es\xbc\xe1\xd7FKO\xfd\xdc;\x7fd\x18*\xf6\x8eA\x028\n\"6q\x19\xa6\x8c\x0bw\x87N\"\x11\x16\xd6vE\xba\\\xbb\xb5{\",\xc0B\xb4z\x13\xf0\xc6n\x8e\x10^/lS\x1cv\xb0\x00\x10.\xe9%\x88\x80F\xa0\xab\xe3\x0d\xda\x96\x94F\xe1u.\xd5\xcaT\xeei\xa8\xd4B2\xd5\x00|?\x0d\xe4'x_\x00\x95z\xf3=\xb9w[h\x0d\x87\xbaF\xf8\xb8\x85O\x17\x00\xfea\x08\xee\x06w9Fx\x80\x8b\xfc;6\xc7\xe7Xt\xab\xde\x81\xcf\x8f\xea\xce\xbe\xba!\x90\x11\x08\xc6\xa8w|\x1c\xa7\xae\xb7y1\xdd\xac4f\x87\xe4t\xd6\xc3u\x1d\x9d\xcb\xaa3\xc5\xb6v\x95\xdb@\x98d;\xa3[\xd8\xea\x11G\x89;\xa8\xd2Oj}\xa8\xd6\xb6\xaa\x00\xa2[Uv\xc3\xa7\x83\x9f\x87\x19T\x99\xec\xcd\x1b\x11\x7f\x9f&\xf7\xb3\xb8o\xaa\x83O\xff\xa8t\xe9\xf6@\xaf\xab\xd5\x0f\xdf\xab[S\x98\xdd\xca4\xea\xcd_\xff\xfag\x9c\xa9\xac\xb5;[\xe9\x06\x13\xb6\x81D\x84T%\xf1\x10>\xc4\x1e\x1a8\xc4#khc\xa2\x03\x8e\x04\x1b\xf1}^\x99\xd3OO\x1a\xee\x11,\x96C\x95\xee	\xae\x18\x87C\xb5\xb2ev6M\x0fgJ\xe6\x13\x93\x81\xfd\x0c\x80\xa5\x0f!\xac?\x8fx\xd0\x0d\"\x94\xa6),\x11\x0c\xb3\xe4\x0b\xe2\x91\x888\x04\xc8\x1dSadm^\x14b\x19\x92OO+\x81\x92F\x93\x02*\x12db2 g\xb4\xe9;\xe9\xdd\xfa\xc4\"\xf4\xda\xb8[+S\x83\xbb\x92\xf9\xa87`\xb8]\xaa\xdc\x92nB8$\xd7_\xb7\xb5\x8d\xa72k\xda\xef\x18\x99\xbc\xb2\x80\xcd\xb3M\xcb.c\x93v\xef\x8f\xc3\xe4\xdc\xfe,iY7\xde\x95\x96J \xde\xdd\x93\xcc-Y\x18;\\\xbfk{*\x8d\x17.\x998\xe8l\x07+\x80\x90\xb73\xa5=\xec.\xb3\xe9\xba\xdd\xdb\xe2\xe0c\xb1p\xad'\x0e,\x04j\xc0\x05\xda-(\xdb\xc0S\xcf\xe9e\xdfts\xfd\x94}1f\x8f\x1d\x83\x7f\x1bG\xd6\xa7\x8bz\x16\x13\x14\xc1\x9e \x00K\xd5\xf51\x83\xefD\xd4\x93\xc7\x10\xad)\xd9r\xd7E\xe1\x1a.\x94\x10\x16\xf4\x97\x18\xce\xc0N\x16\xae\xfc\x06\x00\x8c?\xbdjM]\x90!\x0e\xf4\x88\xb7\xed'\x02\x03\xca	\xa0\xceb\x8c\xe1\x19\xef\x97\xd2$\xd4\xd9\x84\xef\xfb\xc1\x83\xf0\xa6$g\x9a\x86R\x8c@W\xb5\xe3\xbf!\x8c\"Z\xd3M\x81\"\x91\xc9A\x80\x12\xe0\xd3\x12\xda\xc3\x1eI\xb4\x18D\x80\x96\x98$\xceAH\x05!\x10\xfe\x94\x12\xdbG\xf1\xe5\xb3f\xfcs\x1a_?\xd14\xd8K&\x11\x84\xbe\xa2\xca\xdaFz\xde\xda\xd4\xda\xb6\xac)\xf6^b\xaf\x0b\xbb[zT+,\xc2dAE\x90\xad\xfd\xe3%\x8a\xe5\x00\x97\xe1\x00\xcd\x9a\x93I\xa2 \xf3EHJ_\x93\x89\x02\x96\xe2\x85}\xa1\x9b\xe6\x18\x1d\xba\x97\x892$\xf7XSR\xae\xa3e\x8f\x0e\xe7\x7f\xc0\xc9V\x99GdV\x95\xba3\x1c\x07\\\xfd\xa6\xb9\xe0xni\x92\x90\x0bw\x81_\xf2\xc9H%\xb5?\x0f\xfeY\xc6\x15\x0d\xf3\x171\x05\xd8*$\xaf\x11[\xd5PWM\xef\x07[GDc\x031\xc2\xe0\x99\x93;\xe0\x85\x16\xd715HtXl,Xs\x13,\xe1\xc4\x07\x17\x17D\xe3\xcb\xa2|\xb4\x10^\xc4\xa7-\x84\xfc\x0e*D\x8d\x00\xbbk[\xe3M\x93\x10\x0d\xe0U\xf5\x06\xa0\xc4\x12X\x88\x94\xe6\xe5Y@\x9e\x9e\xc7\x13Q/+\xfd\x0b\x8d\xa2KO+\xba\xf2\x1c&\x17QG\x9eC\xe1\x12\xd1\xae\x97\xe4I(\x1cm\xce[\xb6\xd1MY!\xdd\x04\xba6<\xe5[\x1c\x1d\x8a\xe3\xc3\x99h\xcaS\xc3\x05\x8c\x05z\x14\xbd\xdf\xb7\xc1R\\\x8aK)\xc9\x97\xd4G\x8e\xd6G\xdf\x0c\x0db\xebG]Y\x10\"\xa7L\xc4A9\xa7\x8c\x124Z\xb3\xd7\xf03UG\xc9\xda*\xc5\xc1\xa5Ty\x8d\x8c\x8b0\xf1V\xb3C\x84\x1c.\xa7A\x966\xf7\xfc\x8a\x0e\x14\x87=\x08\x92gC.?!\x8a\xc0\x9e\xa5\x9e\xf0:\x9d\x89\x17\x14|\xd2tD\xbce%3=?K\x8e\x83\xdb\xd0\x10\x84\xbe\xe0\x83\x80v\x8aYw\x18\xaau\xec\x81\x87qb\xab\xa0+\xf4u(\xae\x8f\x04q\x05\xccq\xfc\xad4{\xa4\x1a\xd4\x9d\x84\xca\xfbn\xa8\xd5\x91\xf3\x9fj\x1f \"\xc5)\xa4\xed\x9e):\xc4\xdf\xfb#\x14n\xb7\xb2\xb5x\x9b,+]Z\xd4\x8d\x1db*\x90'\xc1/\x9f\xc3`\x84\xb1[+\xad\x1e]u\xd8\x11\xf9j\xd5v\xae\xd1\x1b\x127\xbd@\xa4\xa8\x02Ip\xb9\xce\x06z\xb3i\x8c\x8f\xd8\xf2.\xa5(\x02\x9c\xc8\xcc\x8d\xf1\xe9\xa8\x013\xe4\x19;]Y5\xc3\x08\x9c\x8c\xe5\x9a\xbe\xe2\xe4\xce\xc6\xff\x8e\xb3\x92\xb3\x959:B	{\xbfbD\x9f-n\xa2o\x18}\x13\xb8\x96R.\x11\xf5G \x04q8YQ81\xaa\xd0\x07\xb2\x14\x93#\xd9\xb9\xbe\xf6\x10\xa3\x9eA\xd7\n\x03\x11\xed\xfc9\x95\xa9SW\xbfbq\xfa\x0e\xa5\xb5\x974\xb8\x13\xda\xba\xe4*\x16\x1e\xc9\xea\x0elwfW\xad\xfa#!\xffO\xcf\nB\x96\xd8\x10\x9f;]lmm^!\xff\x89\xcc\x9b\x8b\x1e\xb1\x93\xc9N-rOO\xb5\xf0@X\xf8\xfa\x98\x88\xd4\x9b0_o\x1d\x16\xa9\x0e\x8d2\xbb\x95+\x11F\xc2\xff\xb5\xdao\x8f-\xe9\xc0\x9c\xddE\x83\\E\xcft\xf2\xc4\x05\x1a\xbd\xceAB\xc8\xa5\xab\xad\xf8\x95<\x97\xb8\xec\xea\xb3_\xbd\xb6\xa2Uyh\x08\x01atO\xf4\xf4nqh\xe1j\xa4\xbc\x01\xa2Y\xe8L![\nVF\xe3\xf3\xf0\xa2h\xff\xfft\xcd\x9a\xa0\x96\xfaZ\xd2\x02sE\\\x9f GH\xcdh\xb2b\x90yu4\xbaA\xa4\xb0L\x1f\xf1\x923\xf1?\x892\xb9\xc7\xe1e\x0fk\xc3\x98I\x94L\x8c/N\x8d\xb0\x94\x9d+\x11\xd8A\x05\x03\x1b\x99\"\xc5Yt\x9b\xf6\x0cS\x1c\xc3D\xa8\xdc/\x07\xec\x83)\xec\x99\xcd\xe4\x8a\x854E\x97\x95H\x1a\x80!b=\xeaY\xe7c~\x99\x1eh\x08O\x13\xbf\x9e\x1er\xd6\xae\xbd\xe6\xceR|\xe7\x1ap\x14\xcd^\xa3\xc6\xe8\xd6\xd5\x9cj\xe2C\xdf2'l\xa94\xa6\xc1\xfaL\xf4~\x05\xb5\x98N\x122\x91\x99\x81Jv\xd2\xb7\xa9\x1f*\xb7\x96\xdc\n\x1f{$\xea\xa8\x1d\xd7YF\x0d\x8e\xe99\xe1\xae\xfd\x84\xf7t\xeb8\x97#\xd9\xb0sz\xe4\xc4\xc8G\xf6Z]\xdc\xd1T\x87\xd3\x152X5\x84>\xc4\x10\xd7\x16yG\xb1+\n\x0d\xbeN\x08\x03\xeb\xab\x1d\xf2iwp,\xe0;R\xc6	\x04\xf1+C\xcb	Z\xcd)\xe5\x11\xf8\xa4\x1ai\x06\xd6\xdb\xc4dG\xd20\xfc\xc4J\x14\xc4?\xaf\x18Q\xe5\xb3\x07\x7f\xc5\xd6\x18\xd0\xcf{\xc4\xe8\xf7\x91\x19\xb8\xd1h\xec}\x85\x08\xed\xd5i\xaa\xbe\xdf\x0f\xb07\x84X\xd6\xeb\x9e\x97:\xd9\xf5on8[T>r\xa1\x8f\x92h\x13\xbf\xf4\x93\xebNHu}h \xdc\x84\x1a\xbc\xa0\nz\x12\x1b\x06\xbdJ\x81_Cw'\x16pB:\x90\xd2D0\x0c	[\x182d\x8f\xcal{F\xbb\xf9\xb3\xa4D\xab\xe1\x9c?\x7f\xbc-p\x08\x93\x86\xc9\xfe\xca{\x86\xbc\xd0\x00}\x80\x9b$\xde\x9c\xe35\x8dA\xcc\x83-\xea6\xdd\x02N\xe1J<\xdf\x89\xfc\xc5x;\x0d\xe7\x86\xf5V\x17\xe5\xe7G\xef0\xd2\x85\xbf\x86r\xdbt\x95\xad\xa6Q}\x865\xfcG\xc8\xc1\xfa\xd6&\x0f\x95\x9a\xf7\xcc\x0c\xd2\x8cx\x91[\xd7v\xed7_\xcf\xf9l\x00Zqn\xe2\x8c\xa0\xf9C,\xe9\x88Vh\x12\xa8\x05\xc2\xda 2b\xc4\xbaE\xd1\x89\x8f3\xb7=k\xb2\xe5Sc\x9e=5\x07\xf2\x0b\xee\x8di^u\xee\x15\xfe\xf5\x89_l\xb0D\x0c\x13:\x00\xb9\x85\xaf.\x04\x02\x0d\x12\xfc\x19w'\xa7\x1c\xe7\xa8\x87?\x1a\x82)\x94\xc5\x0c\x91\x16X\xfa\xca\x00\x0e:v\x1e\x19\xf8\x95\x03\xd8\xf0x'G\x86}7lh'<\xa2d;\xc2\x9b\x07\xc0\x18\x976\xcbl\xc9\xb6\xc3H@\x84\"\xf5y\xc0o\x8a\xf0Ki\xca\x8cX\xc7s\xb4\x0e\xd5_\xab^\xcc\xfd\x98\xc7S\xbb\nQ\xec\xb2\x1fJ9\xc5P\x88m\x8e\xd4\x00\x9exxf @\x07\x04J\"\x9e\x91\x15Z\xb7\x07\xa8\x01,c\x079\xdb(\x9c\xd1P\x1f\xb3\x0e\xf5\xa1\xb4j\xaaG\xf7\xca\xce\xde4\xdd\x91\x1f\xa5p\x01\x12lR%K\x1e\xce\xd5Z\xeflu\xccQ:\xb4u\x87\xd6l]UJ\xe4\xaa\x8d\xe2O\xc2\xc6!\xdeM\x9aZUr9C\x91t\x1c \xdf\x81V%\x92\x1f\x91-\xa8\xd4\xa4V%\xb2:v\xb6\xe6CG\x8b\xd4\xb2,\xc0\xa8\xd5\xe9b\xf3\xact\x87U\xb7>T\x94&\xd5\xc6\x90CcZW\x91\xe1\\\xab\xb5~\x84\xc9\xb5\xf6j\x07\xb2\x99|\xbeA\x9a8%\x15\x0dQ6\xad\x8e\xbd\x07\xa0m4\xb9\x1a\xf4\x10\xd5K\xa7\xce\xba\xe3\x1e\xea\n\xd6\x0d\x19\xe8\xea\x98=\xa4;UTT\xe4\x15\xca<P\x92\x952\x0b	\x1a\x1fB=C\x84\x8e&\xc7\xb8\xfc\x0b$\x88M\xf3lN\x1e\xcdP\xff\"\xdb\xe9\xb7\xc8|\x85\x07\x9f\x12=@C\xf4?\x7f\xa0\xa8\xb4\x04\xa32`H,\x92\xbf\xb3\x8bh?\x81\x9ck\xdc\xd21\xc8[\x90\x94\x80dQ)\x80D/\x0fP\xa5=\xaa\xe0B\x0e\x13xp\x0f59jH\x11\xc07\xd4\xfa\x87\xb2\x14)\xfc@\xaa\x04r*\xc8\xa3\xe9}f\x86\xf3\x16\x05C\x0c\x07\x1d\xfc\xc1\xc4\xa7\xeb\x90\xf5\xaa&\xc4\xa6\xe8o\xc9\nJ\x8fX<7\x08\xd6o]\xd9\xe6\xa0\x8d\xc2\x94\x88\n\xe4\\\xfb\xc5\x89\xea\xea\x8b9z\xf4\x92%\xca,\x90\xc6\x16n[&\xe5M\xe4A\xf0iB\xe6B\xa9\xd6\x99m\x1c\xd2\xf0z\x00\x82\x03e\xfa\xec}\xbc\x0e\xab\xff\x19\xc9\x04\xe7j\n\x1e\\BY{@\x86\xa29\x951\x1ci\xecl}\x80\x86}\xa8\xc9\xed\xc8\x99\xf0\xf81\xcc\xed\x19g&Z\x01\x8aP\x1d\x1dE\xa9\x10\xc13\xe2\x902M\xcc\xcb\xa9\xe0\xddZ\x19\xb2\xf1\xfb\xc1 P\xce\n9.;\xcd\xb9\xa1\x93u/\x82\xd67\xc9O3b\x85\xe9\xb3\xb9\x87\xe9\xb0AY/%g\xcd\x15\xb4x\xa8\xbf\xfd11(Q\xf5}\xb5\x96n\xb3\x10\xc1\xf4\xb2\x90\x13\xa8{'\x91\xcb\x15\xd7\xa9k4\x16\xfa\x00{Yo7m\x1b\x12\xaa\x13\x01\x17\xf4:N\xae\xda\x9b\xee`\xbbc\xa8\x1f\xcd\xbc\xf9\x0c\x86\xa9\xae.\xfa6\xfb\x10\xb6$\x1cu\x81\xa0\xf5\x7fr\x9e\xb1\xc9.\x91\n\xaf\xdb\x94\x97\x90\n\xa2\x01wM\x8c\xde\xcc\x1b\xfa\xea\xb93\x86\xb2\xfb\x03,\xbf\x13?wp\xf3\x90C'\xb3kU\xb3`\xc3^\xa3?\x16\x1c\xaa\x89\x12\xc8\x1d\xc8Z\x8e\x08!\xc7\xe1\x98\x9e\xad\x13\x9a\xf4\x92\x9b\xd3vz\x18\xbfZ\xa7\xc5\xbc\xa9'5#\xba\xe3\xe4>/;\xe6\xb3\x8f\xd7!g\x89\x8f4\x14\xa8\xd4\x88zn\xe9q\x8d!\xb5*;\x19BNY:\x9c\x04\x8d\xa08RK3	\x1d\x81\x8e\xd5a\x0f\xffq\x9b%\xa9R\xa4\x18\xc7c\x13\xf0\xd0$KY\x1d\xfbt\x953)eg\xe8	\xd4l\x7fiP\x08\x8a`\xfd\xe8L\x8cY\xd6\xf5K\xa4\xe5\xc3;h\xb8\x96&=\xe4\xb6kM\xb5\x0eY\x14\x12\xcb,Q-\x03\xce\xa0)L\xcd\xae\x89\x187\xa6\xf3\x13,\x0f\xd7\xa8G\xeb*\xa0\x83\xbeo\x0e\x94\x03X\x97`\xd8\x9d+\x90\xd7\xb8v\xcdIJ\x9dF\x0b\xa46\x1d\x88\xf33\xbeq\x16<Wxv\x9fE\x15\xce\xb3\x93\xa0\xe7\xc5\xc3\x03\x87'\xe0\xda\xe9\xe8\x10\xa1\xb8mQ\x1d3i\xedaJ\xdfd\x00\xfc\x0b\xc6\xf0I\xaap\xd4\xfd\xd9pae\xd8\xd6Y\xaa\xb8b\x166\\\xb9\x85\x1e\xc9$x\x19\xa4D\x10\xa5\xe7O\x00\xd85\x90fx%;\xd4\x88\x89P\xd4\x1d\xdeI\xce|`3\x8b\xb0\xf5\x97\xa1\x1a\xc5\xa0\xcc\xd2\x887u\x90|\x1b\xa3\x1b\xed\x80T\xf9$\xef\x064\xcei\xd2g\xd9FRm\x06\x9a\xe5d\x1c_HA\x9eyH?\xcagG\xba?\xdc9,\xf6b\xbcm\x98]\x06\x82\x8b\xe9\x1a\x93\x06\x9a\x18}\x1c\x10\xe3\xa8\xa9W#\xb3\x15\x98(\x8a@K\xe8\xf5\xb1\xc4\xf8\xa8\x9e|\x0dM\x9a\xa3\x1e\xbd\xd4!:E\xf1\x84.\xd4\x7f\xfbh\x93w\xf4q\xbeJ\x84\x03)md\x81\xeb\x8b\xb0#\xcc_\x1dCr\xba\xc8\xa1^\xd0\x96\xab<\x9bNN 4T\xb6y\x90\x1c\x90	C\x87y\x93\xe0\xc5\xc7\xaa.`A\xba\x93l\xa0\x92 \xca\xb1:f\xbdx\xbad\xcey\x01$\xcb\xbe\xbc\x82g\xb3a\xf6\xc7s\xc5\x87[#\xe8#\xac;\x84\x8a\xb8\x02%k\xcc\xceq\xae\xcc\xe5i$\x98\x0d\x85\x9b*\x93\xc0\xe6@2x\x85\xd1\x96QL\xe2\xea\x19*a\xe4\x89\xcb,&\xedr\xb0\xc8=1\x18\xba\xc2\xceK\x9f\n(=H\x926u\x0cJ\xc7\x04\xef\xe1u\xcc\x15!\xffJ\xf6\x0c\xf8\xae\x0eL1\xe7\xc0\xf1\x8a\xba\xdc\x00\x90\x13$e\xfd\xa4;\x8a\x1dJ{\x078\xeb.'}\xc4\xd98i\xab\xc36BQ\xa7\x83\x1e\xc3\x98\xb1\x06\xfb4\xe8\x00&\x113\xe0\x90\xc4\xa2\xcb\xf2\x02\x80a\x17\xa98\x80\x15\xe7(\x8cbT\n\x16%\xfc\x05\xc8\xc6\xc1\xd6\xc9\xd8\xd7\xdfd\x14r\xe0\xd8\x9f\x05\xee\x12#\x1f\xb7\x9c\x8d\x845\x89\x00\x05v(\xe0\x85/Q\x85\xe8\x95\x88\xe0\x90\x92\\f\xf1\xd2\x9cf8\xb4\xea\xcd\x9fH\x12\xbc\xf9\xf3)N~\x82\x8e)\x11\x88y\xa82%\xb3\xa5y\x0c\xa1K\xd6BM\x99\xfa\x9e}\xbcMr\x0e\x9c\x8f\x8bzt\x85.\x0c\xd2\x15\xb5\x87\x1e\xac\x87\x1d\x8bg\xa1V\x1a\x84\xeb\xe8$ \xebQ\x0fz\x84\x0b\xbe\x96\xc6}\xb6\x8b\xd0\x17\xd7`(!\xe1mg\xdb`{\x85E`>\xd7\xd8\x8de\xcaH \xca\x05\xfcXh\xfbL_\ni\x88\x10\xd6\x12\x1bU\xb0\xa3.\xc1\x10\x82Z\xe0\xc1\xd1u\xc8\xc7\\\x00\xd1\xa1\xe5R\\Jy\xad\xeed\xb3\xb9t\x8f\x08\xd7\x8bJh\xd3\xe2\xc3\x000\xa1\x13\xab\x8f\xed\xa3\x05ko'\x98\xeaL\x008Nd\xae\x91CXy\xcf\x05\"- 6I<c\xa6\xdb\xe8\xd2`y`\xf4\xc1\xfc\x00\x0e\xdc\x8ei\x0dOx(\xf2\xf8\xb87.Y\x13\xa4F\x1em\x9cw\x9dR\x9a\xadK\xb3\xab\xa3\x86\xd5[I\xd2\xb4D\xb6\x8c\xdd\xb2\x82\xfcc?\xc1\x03jO\xcb%\xd0\xfc\xc8\x95\x14\xd7\x9el#\xa7\xdd\\\xfbS\x08\x85\x16v\xc6AW\xa8\xc68\xec\xf6\xa1\x85`<p\x9d;UF\xd7\x82\xeb\xfa\x98>\xc7\x92\xb35\xcf\x8e\x1bj\x8c\xed\x0e[\xa98\xb5\xc6\x0b\xb9\x8b\x08\x08\xa5\x01\xded?\xc9`K\xd82'\xb3549r|\xc1x\x07\xec\x97\xcf\x92\x06\x94\xed\x80\xd6\xce'\x11\xfe\x036\x95E\xf3{\xf3}\xf4\xae\x8bp\xedg\xff\x95\x94\xb8\xe4\x9aTL\xd1W|L\xe1\x85\xf1\x0c\xa2\x9f\xff\xe4\xbd\xc1\x89\x80\xee\xc7\xcb\xd3\x04\x1bZOP8\xb5\xba\xb0\x90\xc8\xb0Y\xce\xd2\xd3x\x8fu\x13\xc6h\xec@\x17\xe0\xcb\xc2\x80ikN\xeaOG\x02\x87\xd2\x11b\x82\xb2k\x12\xfb\xfa\xbc\xd1@\x04\xe4\xa2`\x89L\xa3\xbfr\x96\x0f\x19\x93\xf8\x19\xb4IX@H\xeb\"\xd4\xb0\xdb(\x81\xbd=4\x8f\x90\xa4\xc4\xa9\x9e\x83?\xf5Q\x10\xb8\x80\xe1\\\x1a\xc6@\xd6\xb9m@\xeb\xcdHE'\xed d\xe0\x85\xec5\xd6\xea\xa9\x92	e\xb7\xa0\x02/\x16\xd9\xa9pF\xb8\xfd\xd6\x11\xfe@\xf0\xeb\xae\x89\xe4\x84\xb0\"XF\xbd\xc9\xce\"\x1d\xa9\x82\xda\x05\x1d\x7ft\x96\x8d%*\xc5#\xa7K\xa6'*\x17\x85\x8a\xf3\xd59*,\xa9\x85:QP\x83\xce\xc5\xfbF\x07\x82_\xd3mb\x04\xfc\x94\x85\x0e\xe0\xa2\x9c\x01\x15-\xa7\x96\xb2\x1f\xe1I\x1fik\xfeeH\x06\x8aE\x08\x93\x95\x1eQ\xc5\xb0\xc7\xb1\\$t\x0f8u\xc3q\x1d7\xc1\x00\x89\xdeV\xc7,PR\xa4^9v\xb0\x8b\x91\xce\xd6uf\xb7\xe7ViT4\xd2\xb9\xdel\x19+\x89t\x88\x15\x9a\xb7xS\x8d\x92\xe5\xfa%V\x1d/\x80\xdb\x0f\xa6\xb2\xa5\xaf\x92\xa7\xe9\x1a\xf5\x91\x9b\xbe\x08B\xcf\xeb\xa0\xa0\xd4\xda\xa6\x84cKo\x1a\xbd\xdf\xa6\xf9\x9co\xdex\xd7\xc7\x87$#\x0c\x1a]\x81\xe4G\xc4\x81\xd9\xfc\x0ejAD\x01\xf9\x97H\x13n2\x9e\\\x82\xae\x89\xab\xfaT\x01\x84%\xdc\x18[\xf3\x9e_\xe9\xeb,(\xa1@C\xf0\x0cC\x9e\x1d\xea\xceV\x17\xf5\xc8^IU]fk[\xf7\x91\xd8\xaf\xea\x89%\xc0\xa0c\x8d\x04\xd0\xeaH\x8b\xed\x0d\x9e\xf1\xe0km\xd1\x0b\x802\xdd\xb0{\xc0\x08\xab\x06\x11\x1d\xd4'hgR\x1d\x06r\xa1\xcd|\xff\xf5\xce\xa9?\x7f\xafJ\xd2j\xd6\x1d\xbb\xce\xe1I\x89$\xfa\xd15\x06\xcc\x8d\xd3\xdb\xffKH\xcc\x12$&k:[\x92\xbc\x01V\xb0FFA\\K\xf6\xcbk\xe1\x86\xffp`n\x8dZ\xdb\x06i-vgB\"\xa3\xa4\xb6\x97\xc2k\xdc\xfay\x8a\x912Z\xaf\x9f^\x8b\x82\xa7\xbb\xa4\x08\xcf\x83\x1b+\x0e\n\x1f\x15M\x11\x1f\xf0\xfb\x87\x14\xbf\x19\xa7{\x14\xc6\xeeCw\x18\x0f\x14Qx\xc2\x1f\xf0\xeb\xd9\x19\xeb;r\x82[/\x9eJ\xb04\xde\"\xd2\xb0\xa4\x11\x07\xd2e\xc9.\x0b\xa8\x90\xdc\x8dp\x88i\xa1X\x8dT\xf9\xf4\x18\xc9\xa4\x0f\x0d\x8dE\x1e\xb90w\xc9y\x17]\xba\xd5	\x05\xe4I\xcd\x9b\xfa\x8f\x83\xae@\xb4d\xd5\xd2\xe6\xa8\xda<E\xfe\x10\x8a\xd3\xd0r0H\xd9^\xea2\xb4\x19\xe0\xec\xafC\xf2\xfe\xed\xa9n	\x96\x06+\xa3\x1c>\xfc\xe0\xcb\xd9Nj%8\xfdP\xb8\xb67\x1ai\x94\xbe\xe2\x02\xd3\xa2\x011'\x80\xba&k\x0eu\xe23H\xbc\x1f\xc4r\x0b[U\xb87Cx,\xeff\x9f\x99g\xe4\xcd\xa7d\x10\x8eBh	q\x99\xff8H\x99\xc1/D\xbf\xb3\x04,\x86\x07=\x9b\x88\xc9\x07\xea\xe05*\xee\xc7VS\x18Fx)\xd4\x8b-\x80p\xdc\xc0G\xf7\x9dH\x9eS\x13\xa3J,\xf6\xe7$\x08\x8eM\xbaHJ\xdf\x81\xda\xac\xd9n\x95>;	\xcbef\xce\xa5z\x176\xa1\xdf=\x0e\xce\xb8\xd0Z\xc75\x01\x8c\xf3\xea\xd2\x9c\xb3\x01J[\x04\x81\x15qpj8\x87\xd6\xe7D#\xd0\x94G\"\xf9\xf8\x11V\xa6o\xddS\xdd\xa2\x80k\xa7\xe6\x12\x1di\xe9%\xeaI\x158O\xe2ZJa\xea\x19\x1c}\xe9*|\xaaM\x14\xdcs\xfb2\x18\x139W\xe1J\x07y\xf18\x13\xcd\xb1\xce\xec\xe7\xec\xf5r\xef\x1d\xeb\xfeY\xe0\x0e\xa7\xe8U\xe3\x9aX\xf3\x84(\xf2\xbe\xb2\x84\xb5U\x1a\x0b\x01\x03\x89:\xa4h\x1d\xdcrg\x00_fwL\xe3?\x946\xd0\x8fY\x85\x98\x13\xdf\x0c\x83\xe2H\xees\x95v\xe2\xf2*X\x8c\xe3B\xa2Vd\xd9\xa1\xb5\x0bz\xa4\xd6\xac\xa6\x1dV\x94\x9e\x86\xe1.\x8d\x81&\xfb\xf8-\x1d\x9c\xbb\x07>w\\)i\x9b\x93\x98%e\xabV\xe7\xab\xe3\x1cw\xb0\\6-u\x97\xa5k\x05\x13N\xb68I\x8e\xe34\x93\xaaM\x1e@\xd2\x0e\x8eh\xe4\xe7l\xae\xd2\xb3\x12l?~\x87\xca@SR	\xa5w\xc3P2\xaai\x115\xa18\x89o\xa7G\x9e8\xd1\x11Ml\x98\x194\xae\\\xed\xab\x03\xb6\x87K\x14O\x8b*\x9e\x0d\xd4\xa5K\x08\xe4\xfa\x0cLPg\xb2\xd3\xdf\x112\xb2]p\x19\xfaf\xc4\\\xef\x17D\xbdY\xaf]\xd3\xb5gj3\xdb\xdb\xe0<\x17L\xa8V\"o\\c(\xd0J\xed\xb7\xe8\xa1\x10\xf9T\xf4\xde\x8f7FE\xba\xd7x\x97\x8d\xc2,\x9d?\x9eXt\xd4m\xdcQW\x1c)sI\xfe\x1c	\xb6\x04\x96S8\xce\x95\xf8\xa8e\x84\x15\xa3\xd5\x04N8\xaa\xab\xc1\xf9\\\x9d\xf5\xb2\xa3)\xb0\xf4\xca\xd7@\x02\xa9\xe8\xfbe\x1a\xff\x7f\x18\x7f\xf0\xfd\xb4\x07\xdb]Cw6\x1b1\xe2\xb3DQ\xe7\x87#\xc3.c\x14$\xf7R)\xa7\x1b(\xe8\x8f\x90\xd6\x08\xf7I\xab\x81\"l\xfb\x0e\xe1C\xae%\x0f\xfe\x06\xf1-\xc4\xc4'.)y\xf3f\xa8\xee\xa5\x95\xa5t\x9a\x0b\x17\x111\xeb8S\x19q\xa6\x82G\xb7%\xdfZ\xc4a\x88\xe0\xb8\xf5\x19\x0c\xdc\xf8N\x82\x8c\xe9\xcf\xb6\xf55l^\xf0d|\xde\x0e\xa1\xef}\x99TAH\x8a\x02\x83\xf9]\xdb\x83Z\x9c\x81\x03N\xfcJ~\xc2\x93\xb1\x07\x0e\xdbG\x84v\x8eR\xe9\xea\xe4\xeb\xcc=q6\x13\xd7\xb3W\xa9\x0b;\x0c\x1cz=+]!jvT\xba`%\xc75\x19Lk\xaf\xea\xcb\xb7\x1c\xb0\xf1=\xf3(,\x98\xec8\x19\x0f;]\xd7\xc89\n\x17\x06d'\xcd\x1a@\x01\xa7\xc4\x01^\xec\xb3\xaa$,v\x8a\x94<\x83W\xa6t\xbd\xc8\xb4_\xea\xb3 Q\xe0	\x8aRv\xa2(\xc9\xd9\xefEwy\xdb/\xcc\xed3\xaf\xb2\xd4\xf1J\x0b\x8a\x9d\\r\xdeHW\x0d$l\xce\xd1]a\x8f\xc4;\xb8\xbdhrA\x0b\xce\x0cJ\x87	i\x98\xbb\xb5-=\xc2\x92\xf2\xc4U@\xa1\x86\x88\xca\xa8H\xa4P'J\x18\x1c&\xf5\xab\xd0{ \x07\x1dW%\x9ai\x05\xaeC\x1dL\x050aKr*z\xe8\xf8\xaeM\xfa0\xf1\x0b~\x07\xa0\\P\x8d>N;.\xa01\x15\x9c\xea8\xe88\xe5\xca\x9fr\x7f\xc4c\xcf\x17R<\xbf\xa5\x92\xf8\xd9\xc9G\x12\xe3\xa5\xa7\x84A8\x98\xd4'\xd7\xc6\xf9J\x85 \xae\xc8\xfb4\xe8/\xd23\x89\xfa(\xee\x91Lo\x1a\xc3\xde\xa9\x06Z\xcc\xcev\xf8_.\xc5eH\x0e@\xad%\xcc\x97\x9c\x98+e A\xef\x01K\xf7\xa3gWRM\x05\xb1\xef\x1d/g\xba0T\x80\xe4\x1d\x90\x7f\xe1\x1eM\x0d\x0f\xbc\x1f7k\x0f\xec\xf7\xf7O\x08g\x05@\xd7`5N\x0dh\x9f\x07\x9cy\xaeNv\x10\x9e<8H\xba#\xe7\x86\xc5\xab\x04\xf0x\xad\x9eY\xed\xd9\xba\x844\xd2Jv\x1a\xf7R\x96\xd3\x89\xfa\x8a\x1e*\xb6\xdePI`E^SW\x9f\x81\x9aS\x82\xd9\xb3\xc9\xa9|,\xa5\x03\x0c\xc0\x8by\x04l}\xbb\xdad\x9cI~z\xf3\x13{]\x84\x9cCp,UB\xf3\x90\xd8\xae\xb9\x17f\x95v\x94\x92\xcc\x0d\xce\x89\x0e]_\xc0/Q\xd1\x013\xa1mmp\x97\xe4\x99\xb8\x91|\xfe\x02{\xf6\x90\xfa*\xd5a\xcf\xac\xb5s\xe0\xa9\xad\xcb\xc2\xe41Q\x15\xdd\xd7q3\x08\xae\x98\xda\xe3\xea<\x1c\xf0\x86\x12R\x18E+S\x9b\xb5\x15O\xc1\xe9\x91\x0em\xdb\x13W\x16\x96\xe5g\xf8C\x98!O9R\xf6+8\xd2)*\x93\x9e\xd8\xd2nZ\xb4R,\xb0\x8c\x16Q\x08\xa5@\xa7\x1e\xfc\xeb)\xb1H\xbf\xc2\xe0\x99\xe1\x08M\xe8\xcc\xc3M]!\x18\xc4\x05\xd0_4\xbb\xa1\xa2\xd3\x1ejiJ\xa2~\xad\xa4\xf3 \xe7\xd9q\x17\x96\x00\x98\xaf\x1a\x14\x04\xa7of\xfd7\xbd\xf4\x91S\xdaK\xf5\xb0%\xc4\xf6\xda\xb7\x8f\x95\xfe\xd2\x1c\x14\xcdx\x80X\x96\xcf~\x17h\xb2\xc0\xd6\xcaT\xd6 \xc2#I\x18|\xeap\xf3W\xd3\x1e\xc00\xd0\xa4\xa7\xe1\xcc\xec\x9a\x9d\xa0\\Q\x03+\x14^\xf0\xc4\xacpM\xc6\x1b\x8d\xfd\xcb\x93\x1cEi\xcc\xc3\x16\xe1\x8a\xca\xd8\x8b\x83\xd8Z\x8f\xe6H6\x9d[\xe7g\xa63\xce\x18q\xa3\x8b|\x88\xd4\x02F!\x1b\x82l\xbc\x8a\xba\xd7\xdb\x12Q\xd0\xf8\xc7]\xc8\xf6\x0d\xb0\x89\x0d\x9b\x85\x80\x8c\xd2\xa1{Y\xcfRJ\x07f\xef\xee9\x95\xc0\xcf\xc6\xe0\xdb\xb6od3)\xb3\xcb\xa7\xaa\"e\xb4'Sx\xc5\x0f\x88\xc0\x1f\xac\x13\x82\xdd\xb1\xc2::E\x8cm\xd5\x00\xcdG\x1aK\"\xc55\xc7\x01\xbc\x1b\x97:\xe3a7m\x0d\xae\xa2\xda\xc2\xed\x93\xec!\x9f\x19\x9e\x87v/\xad\xf4/\x14\x93\x816\xd5\xb6\xb1\xa3\x17tB\xe9NY\xbfJ\x9e<MO\x8aw\x9d\x84\x14\xa4~:\xea\xf3VH\xd2V\x8a\x1b*I\xdaL\x82*\xf6\xe4\x80\xb0u4\x83!\x98\"y\x86 `\x92P\xc9\xb1@\xb9co\x05\x0d\x92S\xbac}g\xbd	\xe5\x96\xd2^)\xf6y\"1\xb8\xd7G\xcc \xbdz\xd3\x19z-)\xb8/\x8d\xf8W\xb9\x9d\xe1\x91\xefi\xf5l%\x13\xa3<O\xa4M\xb4\x14\xd2\xb1	im\xb8\x8e0\xb0\xea\xe8x\xf5\x9c\x84\x0d\xfb3\"\x0e\x8eW(4}\xf29e\xf8\xd4\x94\xf4\x9c+\xf4\xcb\xf0\xd2\xb1\xb9\xb7XL\xdeA\xf0\x9c\x1fGZ\xb3\xd8\xeb\xb0\xa0\xfd\xd7\xd7D^\x14\x84h\xac\xafoD\x8c\x8c\x0b\xd9\xcf\xa6\x16\xf2\x91<\xf7\x96\x15-\xa9\xd1\xe6\x1e\xfc\xd0e\xe5\xd6\xde\x14\xbe\x10H\x01l\x86\xfc\x16\x98\x86\x9d')\xddP\xd5Gr\x7f@\xd0k\x9ft\x88&\xe5\xd1\xeb\xfe\xc3\xbf\xa8\x8f\xba)\xb6tO\x9a\xe4\x17mCG\xd9\xa8(\xc4J\x0d\xc8\xc5\xaeA\xa2\xb9\x962\x1bv1J\xaa\x0e\x19\xc8H\x80D\xe6\x81\xcc\x1ft\x87\xd2\xac\x83\x9b&\xd5\xed$U\xe6\x98E\x15ye\x12e\xa4s\xd1\xed\x9eF:e\xa1\xdc\xd5\xea\xcd\x0fCt\xb6Z\x84\xab\x8b\xdcZ\xcd0b\xfb\x1d\xdd\xc6U\xba\x1dK\x92^\xcf\x0f$\x08\x92\x8b\xa4\xe4&e\xeaJ\xecC\xeaew@/,\xb8\xb8\xf3\x9e\xfe\x18\x81\xbd\xf6\x0b\xc0\x166\xba\xb4|\x8bE2\xc5\x89#\x95\x98\xf2\x91\xed\xba\xcc|- n\xb1@\xa6\xb5o\xbd\x1b\x02\x0dr[\x838Q\xfb\"\xbeu\xdc\xdb@\xea\xcaZ\xbb;T\x9d\x96\xbba\xe8\\\x9f\xb7\xe5J\xaf\x10\x08\xfdQ\xa4L\xac\xe9\xa8_w\xfa\x1a\x8b\x973\xbf|\xea\xfea\x00qoY\x07\x93\xfd\xb4\x85\xa8\xf0D\xa8\xe6\x808\x89\x89\xf3L4\x1e\x98V\xe1\xaa\n	\xe3Z\xec8R\x81\xc4\xb0\x8d\x1aOrf\xe5B\xcf(\xf2\xb3\xc4\x91A\xf0{\x93\x99\xaf\xa8\x83^T\x95\x01mt\xb5\x0f\x8fDp\xe9\xba\xec;N\x82W\x00\xaef\xb3np\x88\xa1\xfb@\x9f\xf3\x80\xf441\xf1\xf2\x10!\xbe\xf9\xc3\x10\x19\xdd\xd1\xee\xc5u\x14#X\x90\xee[\xb7R\xfc\xa6D\xc0\xa0P\xf6\xed\xb2\xca\xd6_\x98#\xadlm\xce\xe2\x13\"\x8dz\x17Np~\xea\xc5\xdb4\xbe	\xbe\xe2r2\xaf\xa3e\xb1)G\x90#]\xday!\xe9\x8b\x11c%\xbc\x11|\xa6\xe2> \x14\x9f&\xe9\xf7\xdaO@\x10\xb1t\xe2(h\xbaH\xefgI\xafc9W\xf7\x7f\xc5\xe6\xe4YH#\xfa\x03\xe5\x03\x15\xa6\xa1\x04\xa3\xd0\x8c\x85\xe4+[]\xc1\xc4\"\xc8Sh\x19/|\xb7\xb9\xaf\xae\xf2\xf4\xf2\xc7\xa1\x9a\x9bG\x0bV%m\xa6Nw:\x94@<w\xff \x0ef\xe8>\xd6\xf0h|\xa9\x16\"\x8c\xbc\xab\x9c\xe2\xf9\xcd\x9b\x17\x89\xbc)\x9c\x03\xc0\xed\x0e\xdci\x01S\xbb7\x0e\xd6\x87\x0cm\xe9\xd5\x87\xf6H{\xdb\xd8P\xca\xcbY\x8b2\xb3\xcfr\x06\x94>\x89\x10/\x94\xb8\xd3\xbe\x82\x8f\x80o1\xa1)\xc2EFD\xc0\x84\xee$\xce\xc4\xc3a\xc3\xe1\xda\x87\xe9\x02e\xc9\xd6\x9b\x83mI\xd2\xc9\x13\xf5\x01]\x05C\xe6W\xc6gTd\xb7\x11m0\xa4\x10\xfb\x17bv\x9d\xe8\xc5	\xae\xb2\x13\\\x0d\xd8\xad\x8b\"\x88pt\x06\xe1.G\x88D\xb6L\xe4\xa6\xc3\xccgRc\xec\xd4\xc7#\x07\x80{e\x8a\x9c`\x83[\x12\xaa\x04\xc2L\xd6\x08)\xbc>\x87@(!:J{t\x93E\xba9O\x8c\x0b\xf1S\x8f'\x18$2\xaa\x9f^T\xbd\xe7I(\xa6\x8f\x15[\x07\x1d\x0b\x00\xca \x88\xedD\xf8\xb2K\xf0%\xf7j\x8ahO\xfd\xd4g\x1b\xb8o\xdc\xd7#D\xa7*M\x81\xb6\n\x9e'\xf8k\xaa\xb2\xc7\xde\x99\xfa&\xe9c\x08N\xdc\x97|~\x0c\xfd]\xcb\xce\x94~\xdaW?\xa8\x1a\xd1\x94\xa6|$>z\x11w\x1e%\xb4\x9f\xf2\x86\xd8\xfc\xa9\x18\xb9\xa3\x0d\x15u\x8b\x9f\xf4\xe9\xf1\x1b\xf6\x99\xa4\xd9\x92\xae\xe1\x83\x05S J\x83^\xaf\xf2\xda\xf5\xdeH\x14\x85\x13u	U\xcb\x9c\x00\xcdW=\xa5	 ^3\xf0\\<\x98\x13\xb4,\xb9)\x94\x08[\xe9\xacG\x94\xb4\x95o\xfe4\x0c\xa9\xe1^\x97\xfb\xc4\xc9\xe1\xf43n\xa0\x1f\xab\xc9BMg\xf1\xe6\xeaw|\x9d\xf2\xfd|\xf6~>\xfa\x98\xcb}\xd4\xe3\xbf-q\x0f\xff\xfdx\xfeq\xb2\\\x8eo\xd5\xdb\xcf\xd9\xe8\xfe\xfenrC\x97C\xdf\x8d>\xe1\xc2\xa4\xbf\xdd\x8c\xef\x97\xea\xd3\x87\xf14^p\xaf\x16\xcb\x11^\x98L\xd5\xa7\xf9d\x89\x9b\xee1A\xb8j:\x93\xab\xa6G\xd3\xdb\xd7\xe1\x06g\xba\xd2z\xbc\x00\x1c?On\xc7)Lr\xef\xf6\xd9\xb5\xdbY\xff\xda\xed	\x16(\xb7o\xe3\x8e\xfc\xb9:\xbf\x7f;\xbfp\x01wNwF\xf3\xb32\xfa\xe5\x9b\xb8\x01\xb4z\xee2\xee\xec\xfea~?[\x8c\xe1x\xfd0V\xe3\xe9r2\x1f\xab\xf9d\xf1\xafj\xb4\x10\xc4\xfe\xdb\xc3(\x0ct?\x9e\xbf\x9b\xcd?\x8e\xa67\xe3p\xa3\xbf_s6Y\xd0r\xd5\xe7\xd9\x03D\xc4\x87\xd9\xc3\xddm\x0f)@\xd4X\xdd\x8e\xdf\xe1\xa2\xec\x9f\xc79\x9eT\xa3\xc5\xe2\xe1\xe3\x98\xf1\xbdX\xaa\xd9\xbbltw\xa7\xa6\xe3\x9b\xf1b1\x9a\x7fV\x8b\xf1\xfc\xe7\xc9\x0d\xe1a>\xbe\x1fM\xe6\xb8C\xfbf6\x9fc\x94\xd9\x94\xe8\xe4\xcd\x9f\x87\xea.\xde1\xef\xd6\xeaN\xb2\x96\xe9\xf7\xf4V\xf1\x87\xe9\xddx\xb1P\xf3\xf1\xbf=L\xe6D%\xaaO%\x18\x7f\xf4~>&D'4\x91}\x9a\xdc\xdd\xd1\xa5\xe9\x810\x94'\x8c\\\xf1\xc5\xe3\x910>\xabO\x1ff\xea\xe3\xecv\xf2n2\x0e\x84s3\x9b\xfe<\xfe\xbc\xc8R\xac\x8c\x16	\xc9\x8e\xde\xce\x80\x98x\xa5\xf9rFX\x02\xcd\xf3u\xe6\xe9\xcd\xec\xa3\xe9\xe7\x8c/\xd6\xce\xd5\xe2~|3\x19\xdd\xd1\xef\x93\xdb\xf1t9\xba\x03\\7\xb3\xe9b\xfco\x0f\xd8\xda\xd1\x9d\x0c\x12\xae2\xe7K\xcc\x97\x1f\xc6\x19_O>\x99\xf2%\xe9\x98\x1b\xdf\xa5\xc0^}\xf3V\xf8\xbb\x19\xee\x90\x7f\x97\xdd\x8e\x96#\x0cE\xff\xbe\x1d\xe3\xe9\xf9xz;\x06\xba'\xd3\xd1\xcd\xcd\xc3|\xb4\xa4\xbb\xd0\xf1\xc6x\xa1\x16\x0f\x8b\xe5h2\xf5\xbb\x81\xf5\xd2\x11\x9f\xcco39d@\xb0z7\x9a\xdc=\xccO	\x0fp\xce\xee\xc74$\x11`\xb2\x13\x9e\xf2\x16\xd7y\x86\xcdW\x93wj\xf1p\xf3\x81\xb7-^\xc6\x8eY>\xab\x0f\xa3\x85z;\x1eO\xd5\xe8\xf6\xe7	\x1dG&\xf0\xd9b1a\x9c\xcc\xdee4\x02\xe3\x91\xa8\xeb\xcd_\x86\xfe.\x9a}c\"\x05.\xce\x8aTR\xe1%\xddy=\xd3\x0b\x151\xba.\xbd\x9d\x1d\x86\x89\xe9\xf7\x92\xe2\x9a\xf9L[6\x0dW\x86\x15\x9f\xca\x15H\x8b\xa1\xe2\x15\xdfV\x98k\x1f\x99\x0b\xfb\x8e\xd8\xa4W\xe7\x19TB\x83\x08\x06L!\xdcO\xe0\xed\x7f(*G\x19I?\xb1\xcd\x8e^LE\xe5|%\xe8\x1e\"\xd0\"\x17\xad\xcd\xe0\xd3Z\xb5\xae\xc2\x8d\xad\xd45\x99\x16\x03GBa\x1fm\x95\xc0~\xc1g\x92\xe8`1\x91\xb4W\x1b\x14\x0b\x16\xfa\x88\x88\xe5\xce>\x02z\x96~\x86\xe9\x1a\xd3\x1d\x9a\xd3\x9e\xae\x17>\xe3\xa9\xdf\xe7g.\x1e\x8c\x9f\x0f(\xe4v\xa8\xec\xa9\x8e\x9c\xce\xb5\x94\xd4\xf2\xcf\xb0\xb4\xa7\xe6I$v\xcb;\x0dM\x8f\xaf\xf3A\xc4\xd2<\x85\xc6\x83!\x9d\x81\xaf\x99\xe6\x08	\xeb$\x1b\xaasl\xbb\x0cU\xeb\x14\x7f\x81\xf7@4i\xbe\xd4\x0c\x7f\xaf\x90\xe0\x02\xab\x16\xb6P\xb1%\x8f:,&ec\\\xccvY\xff\xbal\xaf\x0e\x19\xb9\x03\xbf\xd0\xe8\x8e\x10\\\x8c\xbd\xeb\xdaC|I\xee~\x94\xbb\xd1\xa8\x1fd\x8ez(\xcdi\x03Q}\x95\xd2\xa9\xa0\xf9\xcbu\x08\x13\xf87U\xab\xd7XZ\xe7\x92\xb7w\xf2p\xdbq-%5\xcaL\xd2\xec\xf1\x00Qb\xe8U^\x1dq\x91\x13\xc7S9\x12\xec\xcf\x8c\x10\x91\xefHL#!\xd5^\xae\x04%\xdd[Z\xbf\x91\xa7`\x10\x12*\x060\x96\xd9-\xa2\xf6\x8e\x8c:L\x1d[\xe7\xac\x0f\xa1%3V\xb3\x86\xee\xcc\xc4\xf5wx\x9a\xe9}i\xf0\xc6=8\xb0\xfe\xefZ*'\xe2\xa1W\x8d5kDP4\x16\x8b$'q\x90\x0f\xff\xc1]\xfaD\xcb\xba\xba\xb9V\x7fGk\xba\x7f(\xf5w\x1a\xc2I\xf9\xde?\xfc\xbcK\xbe\xa3U\xd26z\xdb\xfdc\xb8c\xbc\xb7\xc9p\xd5\xa4\x97,s\xddP\xdcq\xb6@~YK\xd6\\/\x0d\xfd\x1d5\xa9\x18\xe8\xdbw\xe3\xb3\x19s\xe6Z\xe0\x11s)\xf5\xba\xea\x97\x9b^\x9f[6\xc3\xcb\x08\x88\xeb\x94\n\x07\xb5ExAZ6\xc1Ze\xcd~}\xa8|KZ\x04\xdbD]\x83\x04a\xa5\xea\xf3O\xa1\xae\x16\x1d4\x08Nq~Ff\xe5\xcb\xaeN5/\xd7<\xabx\xa9\xa8x-\x8c	%\x8e\xdf0F\xd6\x12\xc2\xf0f\xb2\xb4\x8cB<\"\xa5k\xf6\xaf\x97'\x99u\xcf\x0fL\xa3\xf4\x1a\x91E\\zs\xb0v]\xaep\xbf\xe8\x7f\xdbv\xdd\xfe\xc7\xd7\xaf\x9f\x9e\x9e\x86\x9b\xfa0t\xcd\xe65[$\xed\xeb\xff1\xcc\xb2\x11R\xf7tY\xf6Z\x9b\xb8Z\xae\xc2\x83\xe7\x06M\xcdA\x90hx\x0f?_\xe3j[\xd0\xa1\xd8\xeb\xbdA\x1d\xab\xadRA)\xbbJv(H\x12\xd5\x03\xa9\xb3\x05\x15-_\xd0\xaf]nZ\xd1\xc0G\xd3!-\x0dg\x14\x99\xc0\x9e'>\xc1\x8d\x89\xae\xb0\xe01\x14\xa9\xd4u\xaf[/\x1a\xd7p\xc9\xea\xdf\xf7\x8dC\xb2\xe8?\x9e=\x89\xe0\x038\x8d\xff8\xa7@\xdf\x91\x99\xb09z\xbb\x98\xdd=,\xc7w\x9fS\x1b\xe6'\xdaM\xdeH\xd5\x1d\xf7F\xfd_-\x90\xf4\xf4\xdd0\x0ewz\x92\xa3\xd0\x80}\xf6d*\xcc\x03Vsr\xb0i\x04\x7f\x8e\xc5\x97\x11}\xcd?\xa5\xd3\x15\xdf\xa5\x80\x0c\xb3\x0cmi\xb6\xc7=\xbc\xc1T\xeb\x12\xaf\x1d\x14\xf8\x08\x06\xff\x9f\xe2;\xa1<\xb9\x9b>\xadq\xee\xf7\x81}\xc6\xd3\xa9\xd4\x0c~\xfaC\x13B\xda\xbc\xdf\xdf\xb5a\xealGlpe\xa2E\xfb\x13\xcb\xf5\xf7\x0f\x13nf\xa3\x0bv3<\x11@\x10\x97\xbaV\x03\xbdr\x87N\xad\xdc\xd7A(4b\x90)\xcb\x14I\x96\xc4`\xccn_\xb9#\xdax\xb1\xa7:^~\xc0\x00\xedLs\x8d\x03\xdd\xc2\xb2\xadrtH\xd15\xdd\xe6O\xbd\x8f|\xef/a\xd9Q\xbb\x1b\xc4\x00\xbeH~\xea\xad\x12\xae\xfax'\xc7\xfa\xe4\xc8\x80b{\xf7Hz\xdd\x0c\xd8g{:\x1c\xeb\xfb;:\x9e\xd9/\x1eO\xd0\xd57Yyp\xf6\x90\xc7\xa0K\xdb\x84\xd5\x1b\x8f)^\x86\xef\x19\x96\\D-?p\x16q\xfa,\x08Y#5\xacq\xb8(\xd6\xf0m^G\xda\xb0\x8c\xefk\xa0\x8aN\x9cNB\x86g\xccX6CR\xd9\x1ayu\xbd\x199H\xd4\xb1\x03\x92\xaf\xca\xe1\xc1\xc5\x93\x85\xe4M\x9fE\xcc\x97{\xf8\xc4\x02\xdc\xd5-\x19+\xe0\x8dwH\x00k\x9e\xf3\xeb!?\xc8\xe8\xf2\xcc\x0d\xeb\x9b\xe9P\xad\x0d\xf2\x86\xa9\x10\x0b\xc93\x17\xb7b\xbf\xb5\x95k\xdd~{|\xfd\xb4=\xbe\xaa]\xf7\xaa\xda\xec\xab\xe1\xb6\xdbU`\x9e\xd9\x7f\xff'?Y\xb6\xb1\xdd\xf6\xb0\x1a\x16n\xf7\xfa\xa3E\xfe\xab[w\xaf7\xee\xd5\x93\xad\xad\xcb@\x1e\xed\x8f\xaf_\x7f\xfb\xa9W\xff\xe4\x87x\xc8\xc7\xc92\x10\xd5\xd5\xc7\xc9\xf2:\xcb\x126Z\\\xab\x1f\xbe\x7f\xf3'\x15f\xcf\xb2xY\x18\xa8\x05\xca\xd6\xea\x18\x03\x9a\xfd\xcc\xa7\x10d\xf6=\xf1\x94[I\xe9\xbc\x97y\x99l\x930N:5\xc9etR.\xcbw\x92\xa3\nS]\x81v\x06\xe2\x12\x1d\xe0\xeaW$$\xe9J\xe2\xf1\xf2S\xd2f#\x94\xa8\xe6\x9c\xd1 \xf72\x83\xe9$f\\\xcc8\xc8$\xe5\x03\xb2Y\xb2=p'$-\x8b\xd5\xa8\xe4\x12u\x93')\x95\xb9hl\xc8B\xcc8~\xed\xd6=\xe8\xb8aV87\x1eEm\x08\x91\xa5\xcf\"}b}hj\xaf\xb9I\xe1\xc7I\x9dD\xaa\xd8G)\xf2\xa3\x17\x15l\x89\x86\x9de\x91+\xf1\xb34=\x90\x7f\nqfN&\x85\xaa\xa0t\xb2\x9c&\xedS'\x8d!\xcf\x96	Q\xf5a\xac\x16\xb3w\xcbO#\xef\"d7\xdc\xad\xf8\xde\xf23\xe7\x9b\xea;\xdf\xbc\xd7M\xcd\xe6\xd9\xb9\xcf\xed\x82\xcb\x8d\\\x01\xdf\xf4\xb4\xe5\xd9/i{\xf0\x03\xa9\xe9l:\x99\xbe\x9bO\xa6\xef\xc7\x1f\xc7\xd3\xe5\xb0\xe7\x9dZ|\x80\xf7\x0b\xee\x98\xd1\xc3\xf2\xc3l\x0e\xf8\xce\xfcM\x8b\xc4A\xf4\x8e]O7w\xa3\xc9\xc7<\xf8w\xc4\xb5\x91\xc1\x8f\xe4\xa1S\x9f>\x8c\xc9?5\x99\xaa\xd1T\x8d\xc8}\x06\x9c\xdc\xcc\xa6\xcb\xf9\xe8f	O\xea|\x19^\x85g4\x0f\x8e\xa2w\xf3\xd9\xc7<cw\x11\xfc\x94S\xbc7\xf5N8B\xb5\xea\xed\x08;i\xd9\xa9D\xb0\xa8\xdb\xf1\xe8n2}\xbf\x00\x04X\xa2l\xdf\xef\xce\xfc\xa6\x8f\x8f\xc6\xd4\xaf\xdf\xbb\xae;^b{\xbd\xdf#\xeb\xfam\x9fs\xce\xf6C\xae\xa6\x06=0t\xad~Fu\xe0Umt\xf5\xa8k@\xf5\x7fnH\xc5-\xdc\xee:K\xaeDDx\xacy40%\xe7Q\x9dK\xdb\xd5\xc5+\x18q\xbeV\xb6\x86\x0c\x84\x9a\xdd\xe6\xea\xe4~\xf4^\x0b\xbf\x9c\xd4\xc5\x90\xb5~\xa9\xe8\xfe\xc2	\x97\xfb\x11\x7fTY\xf6f\xa8\xfa0\xb5\xb1\xdf\xb6\xefl\xc5\xed\xacBv\xcbe\xc6\x90\x87\xabL\xe4j\xeb\xc8Q\x98i\xa4\xc0D-j\xa8\xb2\x1f\xcea\xb0u\x8a\x05\x81!\xbd\xb7\xf6\x190\xa0 \x03\x92\xff*\x18bK\xf6\xe4\x07\x06c\xbe\x0c\xb59v\xe2I\xaeK\x0c\xb1\xff\x14\x89C\x05&6Y\\\xe6bo?\xf7c\x10\xec\xb3\x0c>\xaa\xe5|\xf2\xf6a	\x06\xc1\xfc\x0e?d\xe0\x04\x91\xb1]\x08\x10\xfcR\\A%q\x85\xec\xb7\xc7\x15\xc4\xbcU\xa3\xf98\xbb\x9d,\x88;\x8do\x9f\xe1u	\x83\x9b}\x9a\x8e\xd9\xc3\x9f,\xb1\xc7\xeeh\x91\xb7\x13\xf8\xff\xb1\x9a\xf8\x97x\xbd\x13W\xf8\xf8o\xe3\x8f\xf7w\xa3\xf9\xe7\xfcYWxv\xf5\x0b\x18\xb9\x9f\xcfn\x1e\xe6\xc4\xab\xc1\xfb\x16\x0fo\x17\xcb\xc9\xf2a9V\xefg\xb3[\xc2\xb3\x8fQ\x8c\x17?e\xec\x02\x07\xdf\x033^\x8eh\xe2\xfb\xf9\xec\xddd\xb9\xf8	\x7f\xbf}XLHHL\xa6\xcb\xf1|\xfep\x0f6|\xad>\xcc>\x8d\x7f\x1e\xcf\xd5\xcd\xe8\x01\xaegl\xe6\x0c|\x9a\xc8`6'\xe1u\x99\x97G\xf6\xbdX\xce'7\xcb\xf41p\xe1\xd9|\x99\xacQM\xc7\xef\xef&\xef\xc7\xd3\x9b\xc8\x98\xc1\xe9\xaf\x03\xa7'\xf1\xf0Y}\x1a}N\xa2\x03X\x10@X~\x98,\x02\xdf\xce\x95x\xd5\xbf\xe91W\x89\xc7\x9cmL\\\x8a\x1f\xa2\xdb\xec\xb9\x0bM\x90\xa2\xd3\xa6\xa7\xc0\xf5\xb56\xb0'\xca\xd9\x91\xf6\xbc\xdcO\x86\x06e\x1b\x8f\xfd\xe3\xf1\x96jh\x81\xb1{-w\xb2\xb6\xbe\xf1\x9eC\x17\"\xb4\xe3\xc8\xd8=\xc55\x0e\xdce\xd1\xbb|rQD\x10@\x7f\xbb\xb8\x85\xc3\x17n\x82\xe1\xef,\xc0\x16\x9d.\xbe\x8c\xbfz/\xec\xeb\xa7\x9d\xbd$\xc5\xce\x1f\x8aB\xe9\xb7}~\xb5\xee\xfe\x07E\x93+\x99\xfd\xf7U\xe0C5\xd0\xef\xa2\xc0+\xce0\xfe]\x14xd\xa7\xfc>\n\xbc$\xa0\xbau\x0f\xba\xff\xa2\x02\xaf.(\xf0\xd9\x8b\x02\xff\x1b\x15xq\xd7f\xbf\x83\x02\xaf\xa2\x02\x9f\x9dK\xf1_\xad\xc0_`\xfa\xd9oW\xe0\x85\xa3\x93\x02\x9f\xfd\xb3\n|\xd0\\~o\xf6W\x98\xfa\x8b\xae:\xfbz\xa5\x8b/n\xbd\xbe\xc4\xfd\xce\x9e\x89l\xec\xb7}~5\xf3\xfb\xa3\xba1\xf5\x175\xaa\xba\xff\xe7\xff~a|/\x8c\xef\x85\xf1\xbd0\xbe\xdf\x8b\xf1\xb9\xe2K\xe3t\xb1-W\xaf\xf5\xbe\xbc\xc8\xf7N\x1e\x89\x0c\xec\xb7}\xb2\xd1^\x171\xf8\xda\xcby8\xf9p:\xb2\xfaa\xf8}\xae\xfe\xa7\xae\x0f\xf0@\xfc\xf0\xfd\xf7\x7f|\xf6\xa5\xc4\x0d\xaei\x9a~\xcc\x101\x99\x8b\xa9\x17$\x15\xc9\x86\x9a\x8f\xef\xe7\xb3\xdb\x07\xda\xf2\x9c\x9e\xba\x9d\xc0\xcey\xfb\x80oh\x807Cu\x1b\n\xd6%.\xaa\xd4\x80\x1d\xd0\x03v:\xa2Z8\xe9Z{\x92Z\xcbM@\xf3\xe0A\xc0\xd79/\x0c\xcf\x06\xce\xc6	\xdbT#\xef3jc\xaaO\xc8\xfc\xfekH\xd9\x16\xb3\xe5\x14.\xd7\x9c\x01\x16\xd9\x11z\x1c\xd0\x0d#\xdc_%\xe4\x8d\x86\x08\xbb\xba\xf8\x06\xd7\x90y\xcf\xb9\x14!2\x1e\x12\x00(IhLC\x9f\x01q\xa89\x8b\x02\xe3#0Zo\x02\x14\xc8\x8a\xa8*\x1e\xc6{;\xe8\xa7\x90=\xcd\xbd\x02r\xe9\xd3\x89\xff\xf8\xfe\x0c\x08\xea\xd3\xb7\x1c\x98\xa4\x1bYx$~P\xdc$\xba\xe3	\x87\xe8N\xc09/\xcfw(\xe0Ab\x9f\x02lt\xab\xae,Jv\x8c\xbf\xbe\x1d\x82\xa9\x81d\x80)W\xfb.\xa5$'C\xdd2\x8f\x12.\xa2\xc2\x93;]k\xae^\x92\xfe\xb8\x1e\xb0\xd8\\\x82\x8a\x87|/T\xc9Z`\xcc\xa0h\x88\x16}e\xed\xb5\xdf\xd0vk\xf7\x18im\xd7hwk\x9a\x02C_\xfd\xe9\xfb\xff\xfd:\xed\x9f.\xc9\x00\n\x11\xed\xd0	\xba\xdd\xea\x86/\x8f\xb9\xb2\x18\xd2WE\x93\xf1\xda\x1b=\x813n\xf9gw\x18P\xd9\x1b\xfej\x06\xd7\xe9\xae\xeb:\xbd\xcd\xce5|\x05\xad\xa7\x0f\x1e\x80+/A\x0c\xd1&\x08%g\xa7\x9dN\xe3\xbc\xbe\xd6\x9d\xa2\xa0\xbb\xd3s\xb8o\xcc\xda4\xdc\xa1l\xc7]\xac)\xca\x97\xba/\xf9\xc6`\x95XhH\xd5\x80mO\x16\x9a\x0f]\x04K\x91S\x86\xe0\x95\xcc\xfb\xea\x12\x0f\xe3\x1f\xc8\xe5\xfc\xaf\xed\xe6\xc0\xf7\x19P (\x82>\xa3kM\xceA\x87\xfe\x06\xb7kR\xb8B5\x12;\x03s=\xdc\x90\xa8\xb8\xa9\x93\xdcn\x03\xc4\xd27\xdc\x1a\x0fI<\xd2\n\x00\x0f\xa51\xa4\xd5\xa1c(N\x96\x89\x16T\x16\x07\xaawE\xd6\x86\x82\xebg\xfa\xa1 \x8eW\xca)30a\xc5SiJ\xab)\x1f Y\xf6'\xd7|9c\n\x14\x0e\x0fiG\xa0\xe3x\x04l-\xcb\x08\x07\xc0\xa3\x8e\x97E\x15\x96\xb1\xce/&\x181_\xa2+\xaf\xa5?\x19\x91\x92\xe6a\"w\xe3\xe8\x91\xb0\xb7\xd4\xf6D\x82\x1f\xe5\x91\xc5\xebZ\x00-\x0fqE\xcd*\xa8\nM\xd9\xc4#\xcb\x9e\xdc\xd1\x1eW\x12\xdb\xaf(GwO\xd7\x11\x0b\xb7\xa6\xb1\xe8\x13\xfeh\x14\x10\xd2\xf60\"m\x11/\xe3\x80W\xcf#y\x1c\x08\xe0\xa1\x02\x17G\xb1\xc4\x1c\xa0\xfe\xc6\xed<\xaf\xfa$\xa5\xd6\xb1\x95|d\x06\xa6\xb4\x9d\x83c\xd9\x17\xf4\xd0\xd1P\x94\x04J\xbb\x8d\xcb\xa5+\xbd\xe2;\xbd\xd2k\x90z\xa7\x89\xc1\nN\xb0\xfe\x85\xe3\xb8\xcdG:x\x9f\xef\xf99?\xe6\xd1D\xd6\x85\x1d=E\x1fc\xafW\xfbM\xdf\x13b\xfc\xcd\x06<V\xbc\x15\x0ex\xa1e\xec\x0c\x15\xdaQ\x19\x1b\x10\xb7\xc2\xd5\x7f\xab#\xe5\xd3]\xcb\xa6\x87<\x10\xb8T\x84\xf4{H=\x03\nl\xda\xb8u\xdc\xf5\x1b\xe9\xc2Be;\x17v\xfc\xf4\x0c\x84#\x9b\xccw\xda\x02],\x80\xb0\xb9 \x9f\xde\x9e`\x8dR\x89!(\x95\xe2\xdfO|\xb5\xcas\xc0sa\n\xd2\x1cjTI\xf3\xed\x9f<J{Xq\xd4\xa7s\xbc;|g\x91\x15\xb7\xab\x1c\x05\x9a\x88\xcboN\xd4\n\x1e\x0c\x98?~[Z\xa4\x8a\n\xb82M\x8f\x0c\xb0\x95\xd9\xeaj-\x89r\x17\x95\x97_'\xed\xd5 \xaci\xc0p\xc5+\xc2\xa4\x8dq\xcc,\xcb\x91e\xb8\xf2\x9d\xf0CC\xe3\xfe\x0d4p\x05\xf3HLJ\x01Q\xd0\x16\xba\xc4cL\x0c\xa1=\xe1\xd4'<\x9a\x87\xea\xcf\xe1\xea4\xdb\x0d\x01@\x889\x84\x9f\xda<\x15YA\x15j\xe9\xd2\xc2 \xfb@\xbe\xb6E\xeb\x1c\xa8\x1a$#\xf9	)\x117\xac\xad\x04]+Ez\x9e\xb0\x91\x1e\x15$\xba\x15\x90\x8d\x88\xdb\xa1%)O3\xee(V\xc8j$\x08$ODS,\x0e\xef\xafU\xe8\x11\x95\xe4{[\x1c|12\x9a\xe5\x83\xf5q\x94\x0c\xda\x11\xaf-^\xfb\nR\xc4\x1ea\xcc\xcb\x94\x08f5@sS\xad\xd2\xb3:\x1c\x9c\x1f\xe1\x13\xfd:,[N\xe0/\xaa<)\x02\xc1\x1fw'\x93\xc6\xeb\x98B\xce\xe5\xea\xd8\x9b\x87\x07\x82[\x80\x8a\xa6q\xa3EL\xa9\xe2\xb8`r\xfc<#\xfaa\xa8\xdeC\xad\xc2\xb41\xb4\xcc\xacu\xa8\x16}\xe7\xeeEc&9f)W\xa6L\xe8\x807\xd7\xf4\xf2lH/@6\xa9\x86\x86\x87\x1b\xce4g\xc6\xaa\xb4\xb9\x96o\xae\x11\xdan\xd5\xee\x95$\xe5p\xb9\xf6\xabu\x83\xd6\x7f\xb6i\xcc\xa3\xa3\x12~\x1e$2\x15\xb6\xff0\xa1X[\xc8~i\xc0\xf9\xcd9\xa7\x8b\xec<\xf40\xe2\x1b4\xf2\xf8\x0d_\x0f}\x96/\x13\xed\xb6\x98e\xab\"/&e\xf9l\xc6\x0b\xe2\x9cx\x8b\xdf\xa0?$\x1b\xe4[\xf5\xfd/\xb0;W\xb1\xab:\xf7\xfb\x96\x16\x11\\\xef|}\xda\xe7\x85\xeb\x0br\x9fw\x0c-O\xb6\x89\xfc\x84\xe1rk\xf8\xfc\xa4s\x1az04\x1d\xdf\xdd\x1c\xfa@H\xf7\xd3\xc8fx \x9fuO{$\xb3J-\xad\xdc\xbdD1@\x01\x8d\xdb\xe6\xf9g\x13\xd2C\xff\xef\xc3	v\x03\xdf\x94dLt\xbc\x92\x8e\x15R(kE\xf6\x85}\xb1\xae\xbej\xd12\x92\xbb\xca\x90\x01\x18+\xb9\xbd\xac\xb7\xcd\xe9\x0b<\x0c[\xb8\xac\x94\x90k\x13J^\x1f8\x9e\xe2\x89\xaa\xc2Y\xd6\x0d\x91T\xf9\xd9\x1dx\x1c$$\xda\xee\xd0\x85\x95\xc7&\x98\xa1\x9f\x19\x04\"\x1b\xeei_L\x1e\xe17v\xc7\x0c[\xc4\xc3\x80\xa1\xf6\xd6\xfaM\x06\x07\x1e\xc5\x80\xcb]!q\x9c\xa4!_\xe8\x94\x9fv\x10\x91>\x14\xe7}\xf4y\x0c1F\x99\x8f\x89\xa1\x119 \x8b<\xd1\xaa\xbc~\xc79\xe5\x9d\xb0)\xcdj\x87Q\xb8\xd9O\x88/`\x17\x9egX`\x9e\x15\xfc\xf14+e\x18\x1a\x04\x05\xce\xd6\xf7\x1eI\x87\x19\x9e\x84'\xfd%-\x8f\xb6\x04j#]\xbb\x7f\x9e\xef\xc3\x8aP\xaaT\xe6\xd2\xac\xe6\xd40a\x93$\x98B\xb4\xad\x91\xb4v\xc6t'\x190\x91wqv<J3\xae\xf1\x8eO\xba\xa1R\x97\xd8E\xe4\xbc\x95\x14/\x8f\xdf\xc5\xe7l\xa1i\xb9B\xdc1\xaa\xe0	s\xae\x929\xbd\xe3&\xaa\xd2\xb0\xa3`\xbfc\xf7\x0b\xdd\x80\x84\x1a\xf44\xe4\xce\x7f\xb6\x08d\x82O\xef\xd6\x16\xac\x03\xa6\xfb\x86\x1b\x99\xd28\xfd\x99\x8bdf\x14\xf9\xe1\xa6R\x16\xdc\x8c\xdep\x83F}<[\\2q\x980\x12D\x8e(B\x94\x8e9Swr\xedP\xbc\xb6\x9fI4\xdeB\xc5\x9617d\xbe\x00\xcf)K\xedkn\x9e{\xca\x18\x04\x1cw\"\xdds\xe5@\xe7zw\xb5\x851h\xe0\xd3\x85\xf6\x91V^K3\x01`\x81\x95f\xd4\xec\xa9\xc1t\xb6\x9c\xdc\x8c\x07\xaa\xc3e\xef\xc07\x8e]\xbch'\xc5X@\x14\xfc\xb1\x91\x05\x9cN}	\xb3D\x9d\xc9P\x0c\x01U\xab\xeb\x12\xf2!!:s\x11\xad!%%\x19\x86\x99\x1aq\x06\xbf\x10ZB\xfek\xf0\x9a\x0cs\x19\xc3\x17\xf1\n\x8b#T\xac\xa1\x9fY2\x8c[\x9f\x9cV\xba\xb2\xa6\xfdQ\xc0\xd4\x02c\xc4u\xa4\xbdtY\xba\xfd&\x0c?\xc9\x80gD\x96\x9e\xeb\x9e\xc7\x8dj\x1d\x02\x9f\xe9\xd5\x18]\x1a\xdf5)\x91\x06\xf0Y\xd7K\xbc\\\xab\xe3\xc5\x11h\xba\xfeI!\x05\x02u\xa9\xb4Y\xd4\xaf\xec\x15\x16y\x0c4\x1f.\x10\xd7\xfb\xbd\xd1\xcdP-\x93\xee\xac\xe7hf\\b\xbf\xc9\x9b\x0d\x89\x92Tn\xe8*1^k\xbeG5\x0e\xc2g\x8b\xbb\xc2\xa6\xbe\xf9 6tY\xe2\xef\x066qJ\x91\xc9(\x02:c\xe8\xd7\x9c\x04\xf0\x18WoPd\x91\x0c\x04\xe1\x8d@\x08&5uy\x08U\x98=\x8a\x91~U\xa0\x82(6\x92a\xe8\xe4\x11\x82\x93v\x12	\xe8\x01\xe0X\xb2\x1c\xba\x8c%\xc3\xe8\x96\x11\xf3\\\xdc\xe2\"\x8a\x02\xdfL\x9aqHEn\xdf\xc9\x12)\x03%'\xbc\x8e\x14\xe4\xb4gF\xd07/\xd9W\x0c\xcf\xe5\x90\x91\x1f&\xf2,\x1c\xd0shB\x8d\xa3\x92V\xaf\xcf\x98\"\xa9wNp\xef\xc7\x83\x96\xcd\x90\x88\xae\x81\xf9\xf2\xbe\xbe\x91\x04s\xb0\x81\x89\xd6\x0d_r%w\x17\xf0@'}\xc9N,\x81\xde\x86\xfc\x89LQI} [5\xaa\xc8\xedP=\xf8\x92nlZr\x01\x10\x8d\x18\x81\x90\x05\x80\xa3\x9fh\x91\x893+\xaa\xc1\xcf\xbb\xaex\xa0\xd5\x91f<u\xe4\x84\xac\xa5\xe8}\xfe\xaf\x18\xce\x928\x010\x13\x82\xe1\n\xd9&Q\x90\x86\xfc\xfei\xfb\xae\x90\x8d\x0c\xa3\x0cltC\xc67r\xba	\xb4\xf6\x80J\x0dS&\xd7^\x05H\x04V\xd6.\xe4\x86\xae@\xa2\xb1a\x9d\xf4\xd9!\x8b\x8co\xb6\x17\xa2\x078\xbc\x83\xb8\xed\x86\xee\x88\x05dg\xb6\x07\xc7\x02\xfe<T\xcbp\xef!\xd8\xa2m\xcf\xeb\xc4H\xe5N\xcb<|}\x8b\xdcx\x1e\xb8\xcd\x85\x8b\x14{\xb7(J\x8bV\xc4]\xe3\xdd\x8f\x91AF\xd1\x10\xcdTn\xdf\xe5i\"\xb9\"\x81\xb6\xf4\xd0vn\xa7\x9b#\xdf+\x0f'S\xd1\xd8\x15\xb3\x16\x86*\xbd5\x93\x0d\x14\xbc,\xa7I\xf8\x10\xf7\xea\x16\xc1\x98\x88\x00\x8f\xa9\xbf<\xdb\xc3F\x0eA\x00u\x15\xaa\xd6\xa0|\xa0\xc5B`\x03\xb4\x8bd\xbcD/X\x1e7\x8c\xcf~\x1b\x08\x1e\xa1\x8d\x92\\:<@\xd8\xc3\xf4i\xb8/{\x9b{\x0d\xbf\x96\xaeC\xd6\xf8\xdb\xd1b\xb2\x10\xe4\x9e$\xdc\xa1s\xc9l\x9e\x86\xe5{	x\xfd<\xd9\xb8\x92\x90+\x1b\xac\xce\xfcBn\x11\xf8\xd4QJ\xa8\xad99G\x0c\x90[\xab\xe5dy7\xceQ5\xf3*M>\xc9\xcf\x93\xf7N\xcb\xady\x8c\xf32\x9ca\xb8\xf0\x85\xef\x1cJ\xee|\x91\x82X\xb2\n\xfb\xe4\x92\x14\xb7Jc\xd6\x83\\\x9f\x98\x94\xe0\n\xd9\x04z\xa2>\x16\xa0K9\xd2\x8dm\xbf\xb4iA\x1a\xb0\x03\x98z\x97b\xf4\x02\xad\xe7\xc6\xac\xa7\xbd\x7fy\xbe\xf1\x0dz\xc7\xd7\x8e\xaa\xd6\xbb\xa4}k\xed\xb8IH\xb75\xae9\xca\xd6'\x91\xac\x0e\xcd\xec\x13\x97Am6\x95\xdd\xa0\xe7\xe3u\x1e\xa2\xdd1\xbe\x94\xf2\xf1\xc3/\xd2{\xe8\"_\x9a\xca\xaep\x95\xbc\xe1.\xcb\xaemC\xdcB\xa6D\x0f\x93\x0e4\xdb<s>\xb8oI*>\x10\xa6\x90\x13\x8e\x0e$\xbew\x04v\x9c\xb6V\xef\xf4\xa6\xef\xc3\xc7\xdb\x92\x12\x10\x93\x03\xf8Fv\xc1\x8f\xad\xd1J\xac\xee8\x94\x10\x1aa\xd2\x9d\x01<\xa8phxDu\x81~]\xba\xb1D!\x90\xe2<Pl\x94\x95v\x16\xc6\x98\xee\x10x\x0c\xb8\x16i\x96\xd2\xfc%\xf2U\x1e\x86\xa8\xeb\xea\x9b1q\x81\n\xcb\xae\\K\xe0m\x9c+\xd1\xfd l;\x86i;\xb7\xdfS\xbbe\xe8\x04\x07\x00\x8ek\xf5p\x9d\x1b\xd2\x12t\xb5>\xd4\xac[\xb0\xaer)\x13\x04Q\x00\xb4gN\xf1\xe1'\xa6~\xba\xa0C(\xe8}y\x13\x98Fp\xa6\xeb\xd2w+dL\xf8\x0e(\xd4\xca)$a\xf0\xc2\xfc	\x08\x17\x9f\x01\x0b\xd2=\x0cp&\x17c\xc6nP\xea\xd3\x16\xaa{\xff\xb82\x08\x81\xb3~3\xdc&Z\xa84ns\xde\xd3)\x08\xe5\xe6)\xcd\x06f\xe6\xda\x10?\xc9Oz\xc3\xe1\x16mr\x832\xf7;\xe6r_p\x17\xce\xa3\x9c\xae\xa4\x89N\xafC\x9b\xcf\xa7\xe4\xdc\xcc\xf3f\xf6V8\xa8p\x8b\xe4\xda\xc8\x9a\xc1	\xd2?\x198\xae\x8f2Z\xeap\"]\x1dun\x0e\xe6a\xa9\xf25\x18id\xa3\xb4]\xa4\xe9\xc4(\x8a\x8c\xb3N<E	\x19\xb0O\x186\x93]c\xaa\xd8,V\xeeR\x0e\xb8\xa1\x1e\xc3|;'z\xd0]p\x9d\xebfG\x9cH\x94\xeb\x80E\x1e\xc2\xd6\x05:\x08\x87h\x19{\x8e5\xaa\xc0\xa1\xf2\xb2\x135?#W\x88p\xafl\xf0H\xd2\xf1.\xe24(\xf3\xb2\xbdi|W'm\x91<\x01\xffR\x07\xa2\xd1\xfd\xfdxz;\xf9\xdb\x8f\xd2\x82\x88\xdb\x88n\x8d\xea\xa7\xeeq\x0be\x7f\xfbA\x08j/\x7f\xe5\x0b\x97\x9b\xf9\xf0 +g+\xd3\xec+xD\xa5\x96/\xf8\xb2\xd7\xd6Te\xabLMM\xa2\x80R\xb5B\x94\x12w\x92\x0d\xfe\xfe\x0f	\xd36\x86<\x13|\xdf\xeeQ\x88\x89o\x11 \xab/\xb1\xa4\x87\xea\xea\xd6\xd5\xdf\x85|\x01\x1e\x04\xc0\xc9\xe0\xff\xdb\xb5\xf4\x8a\xfd\xdaI\xc9\xcf\xcaD8\xd8W\x91\xf6\xa4`\xdf78\x15n\xf1=\xd6\x9d\xfe\x1a\x02\xa1kp\x06\xf8\xf1t7T\x9fL\xb8\x88\x8c\x9e\xe6rI\xe1\xe2\xf4\xac\xa7\x9b6i\xec\xe3\xd5\xcc\xd0\xb3\x8a}\x01\xbdzgW'\\\x1c\x17;\xaa\xc1\xbeA\xec\x1e\x1dP6f\x00Y\xd1\x8f\x8es\xf2\x0b\xc04\xba\xb5FX&c\x8e\x0d\\q\x07\xa4N\x0e\xf4\xfe\xc6-j\x9e\x18b0\xf1\xef\xbeq\x10\xf3\x86x\xdf\xd6)E\xe5i\xca'\x97\xc8\xf3K\x83\xeb\x9f\x00\x86X\x1c\xb0\xeb\xbd\x80b\x07\xb9(\xea\xb6N\xef\xcd\x0b4\xc3\xa3\x90\xb9$\x1c\xc7\xd7\xf1\xf7<\xc1\x91Tu'\x04\xfdKI\xa5w\x93\x9b\xf1t1~\xf5\xc3\xf0{z\xe5\xd7\xe8\xe0\xcfi\x17\x9cU\x86a\x82\xc0\xe8\xe1K\xc0;i:\xf4\x9c\x8e\xfdO*\xd8\xa2Z\x13\xda\xb8oP\x00A\xc8\x98\xfb\xa1\x16\xaa\xd2\xf5\xe6\xa07\x86\xaf\xe5>\xcd\xddc\x7fH\xcc\xf6\x8fZf\x18\xf4w\xaf\xcc\x16\x7fjS\xbe\xe6>\xce\xf6r\x89\xf6\xe5\x07_\xfd\x93\x9f@C\xcf\x7f\xfa'\xe0[\xcf\xff\xb6t\xe8\xf6%\x1f\xfa%\x1f\xfa%\x1f\xfa%\x1f\xfa%\x1f\xfa%\x1f\xfa%\x1f\xfa%\x1f\xfa%\x1f\xfa%\x1f\xfa%\x1f\xfa%\x1f\xfa%\x1f\xfa%\x1f\xfa%\x1f\xfa%\x1f\xfa%\x1f\xfa%\x1f\xfa%\x1f\xfa%\x1f\xfa%\x1f\xfa%\x1f\xfa%\x1f\xfa%\x1f\xfa%\x1f\xfa%\x1f\xfa%\x1f\xfa%\x1f\xfa%\x1f\xfa%\x1f\xfa%\x1f\xfa%\x1f\xfa%\x1f\xfa%\x1f\xfa%\x1f\xfa%\x1f\xfa%\x1f\xfa%\x1f\xfa\xff\xa7\xf9\xd0\xff/{g\xb4\xdc&\x92\xf5\xf1\xfb~\x8a\xfe|\xf3\xc5[\x8a\xec8\xbb;\xb33\xa9\xa9`	[lI\xa0\x05d\x8f+\x95\x0b,\x11\x9b\xb1,T\x803q\xa5R\x95\x07\xd9y\x80}\x8d}\x94<\xc9\xd6i\xba\xa1\x91\x90\x8c\x9aN\x9cd\x0e\x173I\x00\x01\x0d\xfc9\xdd\xe7\xd7\xe7\xffYy\xe8\x12c\x15Su\xc13 \x99Q#/T\xf6\xad\xa3\xad)\xb2\xad_\x8am\x951U\x95\xa5\x82\xb6\xce\x82\xb7\xe1\xf4\xea\x1a\xfc\xd6\xd2e\xf8{\x1d\xd4\xba\xbaI	\xa6\xaa-\xc4\xf2z\xa2\x8dj\x1c\xd6\x8e\x9e\x1e\x1d>\xfb;\xed\xc3l\xd0^<\x9fG\x8b\x94\xbe\x80sx	\xff\x99va\xa2\xe5/\x95\n\xe6\xf5\xf6d|\xb2II@\xaf\xd8\xab\xf1I\x16D\xe0\xee+\xd5I\xd84\x98\x1aW\xb7b:saD\xc4\xec\x08\xc8\x1a\xf9\xbe\xa5*w>k\x9f\x9b\x88\xf1\xd2*\x0d\xcc\xc1\xd8<\x8b\xb2V5\x15\x165\x1e\x85\"\xd6\xe5\x13L\xe0\xa1\xa6\xaeyj\xb8\xbc\x92v\xc5\xaf\xa7p\x1b\x80\xbd\xd6\xcdv\xc0-|\x8bk\xce\xf6\xfa\xd9\xd5J\xd9\xcc\xf4\xa6\xb0\xb5Yw\xbf\xd9h\xf4\xce\xdd\x05\xf8_\xc9\xf9\xc0\xf0=\x879\xcd\xb8\xa67\x19\xfa\xc2\xdb\x8b\xae9\xd7H\xc65+\xdea\xa4\xae\x02w\x8d\xaf\x0c\xab\xcam9\x13\x8f{\x15\x94nb\xb9\x0d\x01\xd9\xec#\xc6m\x07\xc6\xa6{\xe2\xb8#\x83\xb9\xd5\x9cT\x9b_\xfb\xfb\x1bOo\xc2\x04^\xdf\xd2\xaa<\xad}\x8bk7,\xdfJ\xb5\x05\xd1tD\xd3\x11MG4\x1d\xd1tD\xd3\x11MG4\x1d\xd1tD\xd3\x11MG4\x1d\xd1tD\xd3\x11MG4\x1d\xd1tD\xd3\x11MG4\x1d\xd1tD\xd3\x11MG4\x1d\xd1tD\xd3\x11MG4\x1d\xd1tD\xd3\x11MG4\x1d\xd1tD\xd3\x11MG4\x1d\xd1tD\xd3\x11M\x7fl4\xfd\xe8\xf0\xd9\xdfh\x9f\x81\x82\x1dj-\xa6]\xa4\xd2\x91J\x7f\x14*\xbd\x80U\xef\x16Q\xf6\x00\xcf\x9ao\xc2\x81T\xe5\xa5xn\x90dE\x92\x15IV$Y\x91dE\x92\x15IV$Y\x91dE\x92\x15IV$Y\x91dE\x92\x15IV$Y\x91dE\x92\x15IV$Y\x91dE\x92\x15IV$Y\x91dE\x92\x15IV$Y\x91dE\x92\x15IV$Y\x91dE\x92\x15IV$Y\x91dE\x92\x15IV$Y\x91d\xfd3\x93\xac\xe1<H\xb3hzp\x15\xa7\xd1U\x90\xd4\x91\xac\xab\x9bp Uy\xe1o[K\x8auG\x84\x15\xaek\xcb#JH;v\xb5\x0e\\\xe5thX\xc1O6\xf6W7S\xab\xfa\x90U-\xbc\xaa\x1a\xac\xaa\x83T\xd5\x84\xa9\n0\x83\x8f_*0\xaa\x1a\x00\xd5\xf6t\xaa\x1e4U\x0f\x97\xda\x0eJU!R\xb5\xe1\xa8[\xe3n\xf1\xb9y\x80E\xcd\xd7v\xc4\xd8b=\x88\xda\x92Bm\x8d\xa0\xae\\\x1a\xf4\x0fv\xe3O\xf9\xd5q\x16n\x1b|\xda\x9e<\xd5\x81\x9d\xb6eNU\x80SM\xb4\xa9&\xd4T\x1fg\n\x1f%M\x90\xe9J^X\x9d0\xd5\x87\x97VZqS\xe2\x9e\x7fB\xdb\x81\xa5\x9a\xa8R\x0dH\xa9>\x9eT\x1bL\xaa\x8d$\xd5\x84\x91\xf2\x14\x9a\x08\x9a\x94\x19\xd2\xea\xaf\xb7\x02Hu\xd1\xa3\xc55\xbd\xa96\xefn\xe8\xa8\x16nT\x1f4\xaa\x8b\x18\xd5\x83\x8b\xee\xc2\x8aj\x03E\xab\xc9\xae\xf5\xc1\x94&\x1cb{\x08\xb1\xbck\x82\xf5\xdb\x8d\x0f\xd5\x03\x87\x16\x94~}:V\x0e9\xe4x\xa3K\xc8s\xe9v\xa8c\xa1_\xc7\xbd\xd0F\x83\xeaAA\xb5p\xa0-!\xd0b7\x8eg\xaa\x10\xa0Z\xf0\xcf\x96\xec\xa7\x0e\xf0S\x0b\xf5\xd9\x1a\xf9\xd4\xc0{6\x87=\xb5\x90\x9e\x0fE^\x0d0\xcf\xf6\x8cg\x03\xc0\xb3\x15\xdd\xb9vqrQ\x83:\xb4S\x17\xd7\xb9\x0b\xd4\xf9d*\xf1\xab\xeaDgq(e\x9c\xb3\x0e:,\x90+\xa9o.7\xb6\xc8\x115\x01\x0e7`\x86\xe2\x17\xb6S\x9c:\x10\xce\xa2eZ\xf2\x9b\xa2W\xd7\x06\xde\xe41T+r\xb3\xbe=k\x1br#\xb6\xa9\x87\xd9l\x0flV\xc6\xa4\x18>\xa9Dk\xb6G55q\x9a\xbc%\xa4[K\x85)bCH\x93\xbf4m\x08Mq\xc6\xbcU\x9a<\xe85x\xa6\x0e6S\x07\x98\xb9\x9d\xca\xacm\x932\x90o\x88d\xea\xe01\xeb3!\xbb\xc1\x98mI\xcc\xea\xa1U1\xcc\xa6\x0c\xa6>\x00S\x0f}\xa9\x05\xbd\xacv\x7f\x84k)\x9c\xa0\xc4\x16\xe7\xfb\xc7\x89\xd4{bE\x814A\x97\xe5)r\x9eG\x85\xb8\xdc	\xb7\xd4\xc7Zj\x02-\xcb\xee_\xe1g\xacFY\xea@,5\xf0\x95\xed\xe0\xca\xe2v\xc9\x1c\xe6\x0edeK\\\x80hd*[\x03\x95zhJ=(\xa5.\x8eR\x0fD)\xd5CQ%(\xb5\xe0\x93Z\xd8IM\xe0\xa4\x1ejR\x072\xa9\x83\x97l\x0fK\xb6&%\xd50IM\x8cd\x19\xbd\xa8\x02\x92Z\xe8H\x91\x0b(\xa1>\x054R\xbc\xfd\xad\xb8HA?\xb6\x82\"[\x13\x91mqHM,d;\x10r+\x05i\x8c\xc7\xa6\xdd\xb7~\xfd	\xee\x16<Q\x00\x95\xdf\xd7\xa0\x8e\xb0\x8e\x9d\x04\xbc\xaay\x17\xc5o\xb8u\x07\x86b\xa0\xe1*Cc\xd0>\x97q4\x0f\x93\xe5\x1c\xe6\xdc\xe4=&>2\xc7\xb6\x8c\xc2\xf9,\xa5\xe1b:\x8f\x01[\xbe\xbc\xa7\x97P\xa2%\xccR\xba\xf7\xea5\xabQ\x93\x84lN&\xff>\xdd\x0b\x8c\x96)#\xefYI\xdd\xd3.}\xd2\x8f\x17\xff\x9fq\xa1\x0d\x05>%~\xf6\xff\xf6)\x9b\xa4\xc8\x06^\xd2\xeb\xf8n>\x83\x99M\xc5\x19\xf0Q,\xe9\x13+\xd2\x89\xf0MH\xef\x17Y\xf0\xae\xc8\xe0\xb1\x81\x1b\x18\x15\x08\xb2.=\x0fi0O!\xe9\xc2\x14\x8aY\xfdCq\x19\xd8\x9fm\x08\x12>\x0f\xd2\x94\x85\x99\xf0\xa2\xf1\x88o)\xa6\x99\xf1~5\x9c\x0f?{\x86 \xf0`0\x0dnC\xba\xb7L`r\xd7\x8c\x82\x88\xee\xc1\xa7\xac\x9a\xc1\xe5\xa5\xbe\xe0\x04\xc3 \x8d\xf2\xeaC\xbc\xa9x\xc7Qt\xad\xe5q\x82 \x99^Go\x19\x14^\xe6\xc2^\xdd\xdf\xdf\xdf\xbf\xa6\xaf\xd8\xe9\xc6o\xa4\x9e)K\x0c\xbe&\x84\xcb\x80\\\xd5t\xa72\xb0\xadk\xc0\xee\x84\xcd>\xc0#V\x90Y\x1d\x95_\xcb\x11\n\xd5\xb2\xaf\xdaHY\x1d\x05_\xbf(#{\x15?\x8d\xe7!\xff_\x1d![\xdd\xe0i\xcb\x85\x80$\x8c,\xbf\xb8;OF\x96\xbf/\xbf\x0c\xff\xfd\x0f\x94B~\xfe\xf4\xe8\xf0\xd9\x0f\xf4\"H\xef\xae\xa3$\xa6\xa3 K\xefn\xe3,\xee\xd0\x17\xb7A\x96-\xba\xbf-_^\xdd\x06\xd1\x1cN\xf2\x17B\xc6e\x873J+5i\xc2Y\x87B&\x17\x9eV\x91\xe1\x15\xa3\xa2a\x92\xc6\x0b^\xd5\x18nE\x91\x03 ,\xf6.\x109\xd0\x10)\x80\xaf\x0cH2\xcdI\xf3w\xee\xd3\xc7\x7f{|\x9fO\x1f\xff\xd8g\x07\x9a\x85\xc1\x9cF\x0b\x027Q\xac,\x86\x0c\x92\x10b\x17\x1e\x88\x95\xb8\x9eX]>\n\xec\xa9\xe2\xc1B\x16\x13\x96\xb0\x04\x9d\xe8\xf0q\xaf\x0e\xf0A\x90\xbcf\xd9\xe4\xf4\xba#\x0d\xc5\xae%\x94\x0f\xe2\x84U\xba\x81_\x88\xc2\x94\xf0\xe8L\x9c\x1d\xdb\x06N\x9duI\xd8\xd8|\xca\xa1\xb8\xdf\xaf\x8b1\x7f~% $w\xc9\"J9e6\x8bI\x1a\xb3#\xca\x15\x866T\xe9\xf0\xc5\xd0\x87\xa4y\\Y\xa1\xc9\xd9M(\xb1I\xb1\xaa\x18\xab)\xc4\x1b$i>'\xa2fI\x02\x87\x07\xf4\x93\x05\xf0\x10\xa6\xf1\xd4u\xe5\xd4\xbb\x84\xf8\x03\x93z\xce\x89\x7fn\xb8&\xb5<:v\x9d3\xabo\xf6\xe1F2\x85\xf8\xf4\xf1\x8f\xcej\xc7\xf8\xa2\xaa\x06\xe6\xafc\x17&\xf39.\xb1F\xe3\xa1e\xf6;\xd4\xb2{\xc3I\xdf\xb2O\xe9\xf1\xc4\x87\xb1P:\xb4F\x96o\xf6\xa9\xefP8\xa8,4'ks\x04Ie\x82`Mg\x96\x85\x1e\xb6c\xcb=\xe3.\xb5lj;\xd4<3m\x9fz\x03c8\x84C\x11c\xe2\x0f\x1c\x97\x0b\xda\xf8\xc2\xb5N\x07>\x1d8\xc3\xbe\xe9z\xf4\xd8\xa4C\xcb8\x1e\x9a\xf9\\D\xfb\x82\xf6\x86\x865\xea\xd0\xbe12N\xf3	\x03\x8e?0]2\xb4\x8a\x0e\xf7\xf9\xc0\x84\x7f\x82\xe3\x1965X\xd1ch\x93\x9ec\xfb\xae\xd1\xf3;\xd4w\\\xbf\xd8\xf5\xdc\xf2\xcc\x0e5\\\xcb\x83\x069q\x9dQ\x87\xc0\xb4\x04\xe7\x046\xb1l\xd8\xcf6\xf3_\x81\xa6\xa6\x95\xbb\xe2\xb8\xec\xef\x13\xcf,~\x90\xf6Mch\xd9\xa7\x1e\xec\x0c\x97(n\xe1g\x10\xc74\x0b\xa67\x07\xec\xbf\x1b\xd4Q\xde\xa2\x14:\xb5\xe5ay\x84\x8c\xe0\xd1\xe1\xb3\xbf\xd2\xdeu\x12\xa5t\x10-\xc2T\xaf\xfa\x11\x01\xa1\xed\xac~{\xe2\xcd\xda+\x95\x8f\x14\xd9J\xfecm\x94\x8f\xe4\x9de\x0d\xcaWHE+\xe5#+\xcaG\xbf\x03\xe5\xe3\x91\x11\xaa\xde\x9fX\xf5\xde$\xe9\xc1\xdd]4\xabW\xbcbm\xa9[j\x8b,k\xbd\xfd\"\xec\xfb\x11:\x01\xa3\xe0]tK\x8f\xe1\xedM\xe9\x8b\xcb\x97@\x8d\xde\xc6\x8b\x9b\xf0\xbe\x9b\xdc\xe9\x8a\xf7\xc8\n\xf3\xb1\xab\xe2\x91:\xc5\x13=\xdb\x86\x8aG\x1e\x8a\xf5\x1a(\x1ei\x1e\xeb\x89q\xc8U\xc5#j\xb1\xde\x8a\xe2\x91\xcf\x16\xeb\x91\x95X\x8f\xea\x8b\xf5\x9a)\x1e)\x15\x8f*\xc6yd5\xce\xa3M\xe2<\xd2,\xce\xa3\x0f\xc4y\xa4\x99\xe2\xd1\x07\x14\x8f\xec\x14\xe7\xd1\x0d\x8aG\x94\x14\x8f~\xbe8/\xbe\xca;\xc1\xd3\xdbe\xbd\xe8\xc9\x1b\x94\n\xa6\xb6\xac\x87s?\xb0\xa1\xb2\xd3\x98\x1ay\xe5\xfa.5\xe6s\xd1\xdd\x83\xc9)\xc9[\x18] U\xb6\x8f\xbd\xba<\xa7\xc9\xe1z\xf8\x17`<\x93|\xdaU\xba\x0e\xe7\xc9d^>\xb30\x7f\xf3!{_`5E\x0d\xcd\xba~\x1b\xecDn\xc3\xec'6\xda\xf3\x97\x15\xe0\x90)\x8c\x8c\xfaK\xd5\x08\xd9+\xb9\xd2\xdf#b\xac\x92\xc5\x9c\xf3(e\xe9\xd5R9\xb88\xc8\xb22+\xd2\xac\xdd\xfa3\x88\x16r#\x883\x10\xf8cq\x12d\xb5\xd3\xa9v\x12\"\xc4\xad\x8e\n\xf0`\x93\xcf\"\x0b\xb2\x10j\xe2K\xa5\xfd\xc5\x18[\xa9\xddP\x973\xbf\x1e\x9b\xd7\xac\x81#\x8aA\xc1S\xf6\x082g(\xa0\x8c\x8au\xa9\x00\xd9\xa6\xe5\x88w\xca\xc6\xea.Cx8@\xdci\xb8\x98\xc5	\xa4\x19Yf\xf86\x86\x8a\xac\xac5\xb2\xb42\xe9je\xd8C|\x9a\n\xbb\xa2e\x12I3K\xca\x9e9\xebJ[^\xbd\xbe\x1e_@\xb8R#J\xdcL'\x9fj\xec\xb8\x1e\xe1J\xccV@_t]r%A\x95\xe2\xcd\x8e\xe8f\x93\xb2\x9b\xdda\x07]\xdf\xad&\xf2d\xc7\x93\xa4\x98\xd4\x07\x9f\xaeI\xfb\x96\xc7t\xd3\xeco\xe8m\x97WI\x9cs\xdbt\xf9\x84\xfb\xe2\x12k:\xdc}\xcb5AK-\xbb\xfcS\xcf\xea\x9b\xb6o\x0c;\xc4\x1b\x9b=\xcb\x18B\xc0m\x8e\xc6C\xc3\xbd\xe8\xf0\xdf\xf4\xcc\x7fML\xdb\xb7\x8ca\x11\xb6>y\xa0E\xc6\xae\xd3\x9b\xb8,\x8f\x0e\xcd\xe0M\x8e=\xdf\xf2'\xbeIO\x1d\xa7\xcf\xda\xd93\xdd3\xabgz?\xd3\xa1\x03\x83\x1a'\xa0\xc8\x1d\xd27|\x83\x1dx\xec:'\x96\xef\xfd\x0c\x7f>\x9ex\x16k3\xcb\xf6M\xd7\x9d\x8c\xe1\x03\xb1O\x07\xce\xb9yf\xba\xb4gL<\x13nd\x9f:6\xdcO\xf8\x04;.\xfb\xac\xd6\x7fe\xca\x01\x04\xcfw\xad\x9e/o\x06\xdf\x07\xc7\xf5Iy\x8d\xd46O\x87\xd6\xa9i\xf7\xca \x19\xa2\xee\xfd\"\xeaf\xa1\xfa\x05=7.\xc4\x87\x88\x07\xd5\xf0\x15\xab<\xb0\x1dj\x9e\x996\xb5N\xa8\xd1?\xb3\xe0\xb4\xf9\xc6c\xc7\xf3\xac\xfcT\xe1\x9f\xbcIo\xc0\x9b[\xffG(\x0b\xd3\x0c\xd2.i8\x7f\xb3\xf2\xd7\xfa\x8f\xd2\xb6\x1d\xca\xcf\x8d\xda\"}\xa4\x8e\x0e\x9f\xfdH\xf3\xf3\xe9fq<O\xc5<HLL`b\xe2s%&\xa2\xf8m\x94\xc6\xc9\xc1U|\xb9\xac}\xfc\xab\x1b\x94\x0f\xae\xda\"\x80\xd6\xcdK5\xe1\xb6m\xfb\x1d\xcbw4\xa9\xe0\x81\x06th@\x87\x06th@\x87\x06th@\x87\x06th@\x87\x06th@\x87\x06th@\x87\x06th@\x87\x06th@\x87\x06th@\x87\x06th@\x87\x06th@\x87\x06th@\x87\x06th@\x87\x06th@\x87\x06th@\x87\x06th@\x87\x06th@\x87\x06th@\x87\x06th@\xf7\x9d\x1a\xd0\xb5\xa9\xbe\xa2\xa5\x00K\xab\x1a,\xef?\xec\xf1\xdfh_\x86EK%\x16\x0d\xc5X4\xd5ciU\x92e\xd7\xaa,\x15'\xc3\xf7P\x98\xe5\x03}/\xe6`\xacdY?\x10\xa9D\x93rm\x96G46lZ\xa4\x05}\x0d\xbf*_\xc3\xdf\x82\xe9\xcd\xf4`z}\xb7\xb8\x81\x01\xda\xb0\xd6\xd9p}#N:+/\xeb\xd3\xd6\xfeA\xff\x19Lo\xf2R\x04\x19\\\xe9\x82\x10\xa9z\x01\xce\xd2\xc5Y\xba8K\x17g\xe9j\x9a\xa5\xbb\xa6h\x07o\x8f\x1a)\x1flW\xca\x98\xda\x82\xe2\x87\xe2\x87\xe2\x87\xe2\xf7\xb8\xe2\xb7\xbc\x9a\xc6\x8b\xc5f\xd1\xe3\xebK\xd9R[P\xecP\xecP\xecP\xec\x1e[\xec\xa2x\x9b\xd4E1\n\x1d\n\x1d\n\x1d\n\xdd7/t\xb7q}y\xd1\xcazY\xb8T\x96\xa6\xb5\x97\x7f\xa8\x19\xd5\xd3\xa2zX`\xf4\x9b,0J\x1e#\xbc\xc3\xb2\xca\x8fZV\xb9\xf2\xd1\xd2]\xdaE\xa8\xda2HSx\xbb\xb7)_\xb1\x8d$dJ\x0b\x86z\x18\xeaa\xa8\x87\xa1\xdec\x87z\xcb$\xce\xe2\xe7[%/\xdfB\x16/\x95\x05\x05\x0f\x05\x0f\x05\x0f\x05\xef\xeb\x10\xbc\xad\xb9Z\xa1y\x98\xa8\xc5D-&j1Q\xfb=$j\xf9\xbc\xad\x87\xfa\xb7\xf2f\xb2\x8c\xa9,k\xd2wt\x88\x80\x1e\x02z\x08\xe8!\xa0\xf7\x05\x01\xbd\xe5Uv\xbf\xdc:\xa6\xc7\xd6K\xba\xa5\xb4\xac\xc7y\xcfQ\xecP\xecP\xecP\xec\xbe\xa8\xd8\xbd\xdb\xa6t\xefP\xe6\x1a\xc9\xdc\xff\xd8\xfb\xd6\xe6\xb6q,\xed\xef\xfc\x15x]\xf5V\xec]YN2=3;\x9d\xa9\xa9V,%\xd6\x8ecy%\xb9\xd3\xf9\x08\x91\x90\x8c5E\xa8\x01\xca\x8ezk\xff\xfb\xd6s\x08\x80\xa0D_\xa2\xb8\xa7o\xe0\x87\xae\x8eE\xe2z\xf0\x9c\x0b\x1e\xe0\xc4(^\x8c\xe2\xc5(\xde\xf3D\xf1\x02\xc0\xda\xebi\x0d\xe2}>\xb9\xfd\xe6A\xa0\xc3\xef\x11\xeb\"\xd6E\xac\x8bX\xf7[\xc5:u\xadN\x16*S\xa5(n[\xc1\xae\xf1B\x00\\{=\xad\x0e\xac\xba.\xd8[\xaeK\x15\xb7f\xe3\xd6l\xdc\x9a\x8d[\xb3nk\xf6y\xcd\xba\x1b\x91\x1b\xb1\xb9\x86L\xaa;\xa1ODq[\xe5\xf8i\x83\xbd\xfb_\x0e\xe1l\x9f\xa7\x0d\x02\xffI\xb5\xb13\xd7\xb6g\x82>\xcb=fj\x9e\xec\x05}\xec\x1eVJ\xf2\x05\xac\x14\xf6X\xd8.y\x1c\xfa\x9e\xc2=\xb64\x8e\xe4\x1e\xe8c\xfbA_\xd2d\xa5\xb0\x9f\x0d\xfa\xd8\x16+%\xf9W\xb3R\"\xf7\xf8\x17\xe5\x1e\xe3\xc7\xe4\xe7b\xa5\xdc(\xdc\xebY\x8a\xe2d\xa1\x8e\xefd\x91\xa9;s\x8c;Ne\xc1\xf3\xe3*\xffH*L+\x0e>\xf1\xd3\x00\xe0\xf6z\x92\xc3\xad\x83\x1aG\xc9.P\xfe\x15\x99bne\xc1\xfe\x9d\xf9v\xb1\xf7\xcb\xd9\x19;T+Q\x1cW\xc9r\xbe\xf3\xbfu3q\xf4\xecP\xba\x9f\x15\xc9^\xb8\xa5\xfab\xef\x1d\x90G\xa1\xf4	; _\x00\xa5\xecy\xa1\x94\xfdRP\xfa\x8c\x04\xbf\x17\x04\xa5/\x1e\xd9\x01a_\xbf\x03\xc2\xf6\xda\x01y\"\x94>F\xf0c\xcfC\xf0\xfb2(\xbd\xcf\x8ad\xbf.\x82\xdf\x8d>YiQ\x96\x9bV\xb0\xf4?\x86\xd0\xb6\xcf\xf3\xf8\xb1\xb5\xd7/_\xbdf\xff\xc4=_l\xcc\xb5Lo\x9e\xd7fL\xf6e2\xb7\xda\x8c\xc9s\x02]<\xaf\x16\xcf\xab\xc5\xf3j?\xf7y\xb5\x1b}\x82\x04,\xf7\xc0\x1c\xfd\x14\xe0\xd5^OD\xb3\x88f\x11\xcd\"\x9a\xfd\x0b\xd0,\x97\xb3\x93\xd5\x8fm`f\x7f	qi\x9fg\xd7Y}u\x8c\xd0^\x87\xbdX\xfd\xf8\"\xbc$\xda$\x97N\x84\x83oN\xe9\xd4\xc6+\xf66\xe77\x82}\x90?	\x8d\x9c\x00\xcf\x8a\x82{o\x81\xdc\x17\x07lb\xc7\xd7\xa0\xe0\xd3\xe8{OAA\x0f\x1b_\x85\x82\xbfG\xe7\xf5\x8bQp_\xfa\x1e\xdbk\x0b\xe4\x89(\x18\x9d\xd7\xfd\x9d\xd7%/K\x8a\x01\xa6*W\x94G\xbd\x0d\x11[\xde\n\x80n\xaf\xe7qw\xd6\x82\xe6_\xd8'n\xd6\xd7R+\xf6\x81\x97f\xbdT\xa5\x8a\x8e\xed\x1f\xd9\xb1\x8d\x9b!q3\xe4Y7C<\xbcI\xc3\xef\x89\xe3m\xbf\x12B\xd9>\xcf\x16\xce\xd5\x10\xd7\x9bN\xae>\x8c\xa6#\xf6w\xaa\xb2\xfb\xdf\xab\xef\x16K.s\xb4\xe2\x1f\x0d2\x0c;\x1c|^\xf1\xf2W\xb3\x9d\x11-\xc2h\x11F\x8b\xf07o\x11b\xdb\xd4\xa51\xd6\x06\xa6a&\x17\xc2\xb4\x86\xfd\xee}7@\xba\xbd\x1e\x9f\x9e\xe5\xfe\xa7\x99\\\xe6\xa1\xf7\x83\xb43\x1d\xf6\x9f\xbcX#\xd1\xe3\xeb\x97/\xbf\xb9\xf7#\xd7\xd5\xfb\xb2\xc2P\xf3\xda20\x91D]a\xea\xc7\x83\xcb\xf1\xa8\x7fE3\xdc!=\xde\x1fN\xa6\xe3\xe1\xdb+\xfc\x85\nx\xd5e}1\x97\x85K\x05j\x9b\xe3\x93\xe2\xd8\xf5\xbe\x14\xbc\x08\xd2z6s\xcb\xde\x93U\xd6\x16\x85w\xbdm\x08\xcb\x92\x1bd\xc3\x92E\x95vi\"\x08\x80\x0d{\xc5\xcak\xad\xd6\x8bk\xf67\x1f\x11p\xbb9\xdb\xedRz\xa7a\xb5A\xa7\xee\n\xe4\xb4\xd4\x0c\x99\xee\xca\x0d\xe3\xeb\xf2Zi\xf9\x13\xd5g\xcbif+\xaa\xbe\xa0dI\xd2Ta\x0c\x97\x00\xd5\x8eC\xd0\x00\xb1\xe09\x1bP\xd1;\x8dX\x176\xa5!\xca\xe7)\x95\xe2Z\x81}\xaa\xad\xf4s\xf4\x93t\xd9\xb6!\xf0Z\xe5\x1d\x06\x1de\xff\x91\xd7\xe9\xbe\xf0\xd7*\x1d\"rA\xf9\\^\xf6E\x97\xa4\x9a\x97\xb6\xc2.{g\xd3\xe7\xd8\x8cN\xd6)\x97v\xf4e8G\x07\xb6\x94\x03\x9ah\xc3\x0e\xe5\x11ua\x05F\x1aL{\x0d\xdbZ\xe9 \xd7 \xe5\xac\xaf\x12$9\xc1\xaf~\xa2\x11\xd0l\xc9\x0b\xbe\xa8r\x1b\xbb\x1cxU\xc3:>o\xe3l\xe3S3\xd6\x998}\xb2_\xcaYx(\xe5Q5=\xe6Z\xaeP\xd2\\\xceK2\x1cR\xa4\x8e;\xfc\xf3\xcb\xff\x7f\x84\x17\x97\n\x99\xff\xe6Ak\xd4\xba\xf4\xa9l\xcd5\xd7\xc2\xb8\x12\xe5\x11\x9b\x89B\xcce\x8a\x98E\xa3\xf4\xa0\x9d\xf5\x94\x7fR\xeb\x03v\xa84\xfd\x9f>8\ng\x9d\x17\x94\x7f\xf1Vfk\x94\xa5Y(\x1f\xb6\x00\x9b \x13\xc2PGX\xac\x9c\xb9\xd4\xe0u\xa6\xda\xba\xde*-\xfe\x01\x96\xd7r[\xd2VZ\xcc\x05e\x83\xa3_\xb1\x02\x97\xfc\x06U42H\xbbEX\xbbz-I\x17\xfd^jETa\xa9\xcaD\xa7\xe9p\xdab\xaa\x17:n\xfd\xcf\xe5b\xad\x03\x87\xb4n\xfa\x88\x0c\x90\xdd\xa6\xc3\x03\xa6\x06W)%\xd1\xa0\xb9VK\xb6\x14\xe95/d\xca\xdd\x02)5/\x8c\xcf\xac\x86\x89\xa3\xbf\xe4\xdc\xe5\xa7\xe6aB\xf7\xd0|\x9b\xad]\xfe\xfc\xadn\"k\x97\xc4\x82R\xd48\xdb\xcd:E{\xa3\xc3!z\xa5\xaa\xb8\xad\xe0\x9b\xcc0\x92P\xb6\x14\x99\xe4\x0c\xc7\xa7\x83n#\x15\xe3\x0e( S\x1dd\xab\xc2!\xc8q\xbd\x04d\xe1\xba\xe1\x17@5t4J\x1d\xb6\xe4\x99`\xfc\x96\xcb\x1ca\x91 \xad\x99\xc5\xa5\x0e\xd0\x14\x02\x98r+J.\xdb\xdb\x8e\x83\xeb\xe0-\x8c\xe4!\xd3`YB\xb7d\xceX\xbc\xab\x13S\x1f\xf2\x82\x89\xcf|\xb9\xca\xc9\xd2\xb4\xe9|3gO\xf7V+Qd\xf23\x9b\x89\\\xdd\x1d\xd5\xa3\xb0\x9d\x88\xb21\"\x90\x00\xd4\xd1>\x06\xb6\xf7\xb6\xa4j\x0c\\\xc3g\x1c\x99\xddTAK1C\x1d\"#\xe99\xaa\x13\x0cC0\xb1\x16\xee\xaeez\x1d\x80\x81\xc8d\xa94\x96\xbb\x16\xb7\x92\xd6\x1f\xa4\xb8PU\xb4\xc3t\x98\xc8\xf9Li\xf7/\x9f\xca\xb2\xb1\x9al\xb3\xb4@\xfa2Q\x94\x9dF\xaas\x0e1\x95\x0b0\xd0Z\xe6|\x17\x8fmi\x0e\x95\xfd\x8c\xee\xe4\xf1\xac\x96>\xa4\xd9\xce\x1d\x15o\xb5\x86\x16K.\xfd\xfa\xa4<\xdb\x90\x14\x8c\x0buc)4\xd2\x05\xe7\xb2\xb8\xa1\x81\x9b\xc9\x82 \x07y\xf4\x8e\xdc\xa4\xcb\xa2\x14z\xce\x91\xf0^\xcd\x9d\xe87\x06u\xa7QX\x04B\xcd\xebY\xf7{	R\x15\xad3\xbe\xbd\x06\xfc\x92\x0d\xea\xf3\x03h\x17\x9c\x8b\xa1\xd4\x89\x89\x8bMsN\xd0G\x97\xdf\xdc\x0d)\xf5\x8a\x97O\xc8\x8cZ/\x8a w\xbb-\xa5\xce\xe0^*;;J\xdf\x9f\xcd\x1d\x83\xdabV\xd8\xc2\xaa,\x9c\x0fj\x0b+,d\xa8\x94\xcaV\xcf\x94\xcf]\x1a$z\xdf\xa9\xe5i\xda\x9e\x1d\xf8>\xb9\x0c\x97\x95\xbe\xf7\xb0\xac\xe6L\xe4\"-\xb5*d\xda\xc1,\xccxNr\x84\xf4\xde\xe05\xc2\xf8X\x17vE0\xac\x02[\x92\x15%?P\xb0\x16JS/\x16\x1a\x7f\xb3\x85\xd4[\x18m\x8bj\xd6\xa1\x8a\xa0M\x0cq\x08\xa8\x8d\\\x9a\xd2tB\x95\xe5M!\xb31\xa5Xz\xdd\x07\xf1\x95\xc6\xac)\xbd{J:\xd2\xbea\xf3cja\xad\x15ok\x85\x83\xde	`\xa4!\x05\x81m\x85q\xcb\xa4I\xd7\x86\xb4<\xd5\xb8$\xbc\xb4f$$\xb1\x13\xa8&\xf1\xd9\xe9\xe3f_\x9d<\xa6\xaa0+\x99\xae\xd5\xda\xe4\x1b\xd0KoD\xe6A	\xd9\xabm\xdf2a\xe4\xa2 \xec\xaf\xd3<\xde#\x89\x00\xab\x83\x0bU2^\xef\xfbIUt\x0fv\x97\xf0\x96}\xed\xbb\xedV\xe0\xa3&O8\x80\xd5~~\xb3\xd2:w\xb2\x16\xa9 $\x9fm\x1a\xf5\xd8\x82\x10X%6o\x99\xa3\xdaT\xe9\x15P\xda\xa6d\xb5\x9a\x08\xa3[\x01\xd1\xeb.{\x0f\xb3\n\xd5\xd6\xd1-\x0b\xad]6i\x06FZ\x9d\x99`\x99\x85\xa8\xbc\x9b\xaf7\x88\xf7\x93]\x80,\xc4\x1c\x16\xdeJ\x94\xeb:\x0b\xf7\x9d\xd2yv'ak\x14\xaa8\xa6\x997\xf2\x96\xfey\xec\xe2aZmx^n\x8e\x11'\xeb0\xa9\xb5\xb8U\x94\xce\xd3\xb6\xa5\x9eJ\x1bWG\x85\xce\xdb\xc2V\xa4\x06\xf2\x8b]\xa4\xab\xe1\x9cv+\xd3\x1cy\xc3\xcd*\xe7\x1b\x1b\xad\xc2_VBW\xaav+n\x1fll\x06\x8b\xc0c1\x19\xcb;5\xb6\xa8s\xc2\x96j\x82\xfe\x14L\xd0%\x07\xe8\xfe\x0ef\xe7\xd0\xa6\x86\xe5\x86\x99\xd2-F\x12\x1fS9DGlU\xf55\x98\xbd%\xbf\x11\x1dv\xcdo\x81>Y\x9d\x8e\x1e~\xb4\x9a\xcf\xe1\x90*\"\xd0t\xec\x7f\xe5\x12;\x0b6\xe9\xb4\xc3\x01k(\xdb$\xa7\x98\x1aW\xd0\x1d\x04\xb4\x9a#W+\x92*\xc3\xddD\x8el\x8cIy\x8dL\xbf\xb6i6\x9dt\xf5n z3\x9b\x19:\x1c]\x8f\x9b\x85H\x851\\KZ\x9ds-\x8b\x85\xf3h\x84t\xba\xcf\x7f)Uqh\x8e\x18\xcfU!\xacFL\xd5r&\x0bo\xd5\xd3g\xdb\x1f\xd8b\xac\x87k\xb5-m\x0e\xc1\xc8k6\xceVq\x87\xa9p\xba\xae\xcb\x86s\xcc\xbf-G\x16\xa6\x94\xe5\xba\xf4=\xcfeis\x89\xbb\x1c\xda\x04r\xd6q?\xac\x15\x96\xb7\xad\xb52\xe6\x98\x06\x0c\xddH\xd5\x1a\xf6S\xf5oDCY\xce\xef\xccZ\x96\xe8j.\x16\xf8\x94F\xcc5\xde\x16\x03@m\xf4\xf5A\x80\x03F\xd9\x86\x1b\xebj\xd7\xe5\xa4\xae\x14\xa57\xae[n>\xe0\x83\x93\x12C\n_\xff\xab\x95	g29g\xd4\xe2\x98s4j\x04\xb4*\xcfYU\x95MjO\\\xf8,\xd5\xdc\x9a\x1d\x82e\xbc\xf4\xc2\xe7G\x17\xec{x`\x15\x14|\xd3e\xe3 \xa7\xbd*\xba>\x91\xbbG\xb6m\x14jl \xdaJ\x1f\xb3\xf2hJ`6\x8aL\xae\x976	8|\x84*\xceoKi\x18\x95\xd5\"\xbb\x0f\xc9:\xb5+D\xd3Z\x8b\xd6R\x88\xf2\xa1\x90\xbb\xf3\xab\x8e\xf0\x0d[\xaeM\xc9\x16ho\x9d^^\x8bT\xae\xa4(J?\x92\xb6{\xf6[<;\x1d\xdd\xe2\xd1X@}\x83N\xf8:gA\x9dU\xe0\xa66\xa5\xe1G\xd1\x862\xbcd\xae!BZ-e\x01X\xa8\xf6@\x9d\x98\xe0\x01\xc4y\x91F?\xe0\xbac\xd9\xbb\x84\xe4\xa6Ys\x1a\xd4\xac\x05b\xba\x1dg7[\xdf\xd7\x99\x9eh\xd1v\xe7\x82\x8a\xdd`\x07j\xa9\xe3\xb6\x1e*\xed\xd8\xb1\xd2\xdd\x01,f\x02v\x13MeP\x08/k#\xc4\xf6\x8d\x9c\xa5\xb6\xf6lCj\xd3r\xab\xd0\xd3\x95A\x8d\xcb\x14\xfe\x0d-\x83n\xfa-\x7f\xae\xcb\xda\xacp\x16\xfcvG\x9b\x83\x96\x1d\xb1a\xdd\x00k4\x0bL\xf5\xc1\xc5h:<\x1d\x1c0\x90]i\xbc\xb1\xecl\x1dL\x96\xe1\x88\xf9\x81B\xac\xaf\x86\x80\xed\xaa\xdbF\x96\xa43(\xca\xb6\x80q\x86<\xd7\xd0\x0f\x81\xd0\x89\xd6au\x11\xfcp\xf8-\xa8\x11hW\x1d\xa1.t\x9e2\xaeA1\xed#\x8c\x1e\xeet\x8e\x84\x8d\x97,\x17\xdc\xc0\x9dr\xb0\x81G\xcd\xb7V+\xa5\xfb7\xdf\xbafr\xd7\xc6z\xack\xd9kH\x95y\xb0\x0do\\\x81;B\x16\xae\xebf\x00\x8a\xc9y\x8d3P\x99\x8bZ\x03n\xf7\xf1\x0dS:\x14R[\x1bw\xb6^\x10\xe5\x9amZK\xa0\xea\x9a+\x85\x0c\x88[\x81\xfd<\x8a\xa5\xd49\xfa\xdd\xdc\x14\x88\xcf\xe5\xf9\x06\x86\x85\xe0\xba\xcb\xa6\xe4\x99\x92/mv\x87\xd9\x8e%\xe6\x9b\xa2\xd9\xd0(\xb2\xf0A>\x9e\x07\xce+,\x94fs\xec\xda\"\x9c\xde\x04\xae\xa6\xa8\xd5\x06\xcf2\xfc\xbfFL7\x94\xc8\xa0)\xae\xe9v\x84\x9e\xb2\x12\x801\xaaX\x18\x995D\x87\xfc)\x844\xb3L\x14\xd9z\xe9\xcc\xd6\x86\xc48`\x81\x14\xd4j#(\x86V\x1e\xad\x06\x17\xc4\xe0y\xfbb\xa2h\x15X(d\x07\xe8\xf5\xb6\xfcU\x03s\xdf\xbeE\xeb\x10\xd5^\x050\x9d\x0c\x05k\x00\xe8\xad K=\x15\x18g\xdb\x8f\xb0\xc9J\xb3L\xc2jmX\xb9-\xfe\x95mO\xfb\x96QUL\x8dYX\xa0\xbb\xad\xe9\xd4\xc1R\x14\x03DkwE\xc2\xe8\x9c\x1b\xfb\xaa<X\xd9\xb6%\xce\xd6@}\x9d\xa6\xbdQ\x1b\xa5V\x0b{\xab\x1b\xb1d2\xa5!G\xb6 \x88d\xe0\xa9ly\x02\x8d	\xf93\xb9\xa2\x8e<F\xbejm\"\x9b.\xbb*ra\x0c\x9a\xca\xc4\xe7U.S	\xf7\x97J\xac\x1b\xe1:\x80\xfeoY\x91A0\xab6\x83\xef\x0f]\xd9\x82f\x1b\xaa\xd1\xca\xb1w\xc6=\x07\xb4\x8e>\x7f\x89\xe3\xec\xe8\x14hf 0U\x11J\x07C\xd6\xb5\xdf_\xa8\x12\x1f\xf9\xdd\x9b\xd2\xb1\xb6\xe0\x94\x01F\x17D\xb6\x93\x85m\x9aY\x83$!2\xf2)Hn]\x08\xaf\xde\xb1\xb4\xd6E\x15 -\x853\x7f\x19_hQ	\xfe\xc6\xae\x10\xf2\xc8\xc4g\x91\x06\x10\x8f\xe6\xd4\x03\xa2\xc5\x82kRU;\xbe\x87\xdd\x0b\xf8K\x97M\x9d\x01b\x00\x8b\x81\x1d\x9d)B\xce\xb22\xb9C\x86E\xc5\xb3\xab\x1a\x8d\xafm'\x10\xa95\x81E\x83\xa8WuS5\x05\x86h90+\xc3\xd5\xcbN\x0d\xb9\x16;I\xa9\xddT-~\\K\xbb{\x04\x85nTA*\x9d\xa6tmJ\xb5\xe4z\x83U\x02\xf9\xcd\x84I\xb5\x9c\xd9\xa9\xb0eU\x91ZW\x93\x8f	\xb8\xd5\xe4\xe6\xcdj\x03\xf7^\xa0\x02\xaa\x91\xfak\x97\xf5\xa5!\xd7	\x9b\xb6s\xf6\x91k\xb8\"\x1b\xbf\x08|S\x11?\x85\x03K\x9e7\\\xac\x1a\x06h\x16\xc9y\xa9\xa3`\x1d\xdf}\xb7\xf6\x8d\x17xlmd\x14\xd2\xb1\xbd\xf1s\x18\xbe\x8d\xf0ecr\x8f\x10\xd7\xe2\x85#\xa8\xb0\xb7\xbd\xc9p\xe2\x06w\xeb\x10\x071Lp\"\xc2o\xcb7\xc8+B\x02K\xb0\xba5V\xba\xef\x89$\\\xc9\x820i\xa7\x85\x9d	\x9c\xda\xb0\xbbj\xa8\xa4\xd8ZG\xb6(5g\xd3\xe1\xf4|\xd0\x01a\xe58\xa4\xefuv\xa9\xd0J7\xb9/\xb6\x8c]\x06L\xa5m\xa1\xbe\x8d\xca\xb1\xb3\xa0\x85Y\xa9\xc2H\x88\x0f\x10&\x13X\xda\x15\xb7\xb5\x0e\x1a\xf1\xd5J\xab\x95\x06\x97\xb5\xa0\x0e\xcf\xd9\x9ab\xa5$\x7f5\xe2\x16\x8b\xa6<qc\xd6K\xc8\xa5[\xd2Z\x9a\x1b\x13\x9e\xf8\xc4\xe8\xa0M\xda\xed\xb3R4\xb6^V\xa6\xc5\x99\xadd\xef?\xba\xec\xbc\xe6x\xa99;\x97|&s	\xe1\x1b\x82\xf0\xc4\xc4-\xd4\x19\x16EUF\xa1XN\xc1\xce\xf2Z(\xbdqS\x1f\xecd\x95J\x97a\xc8\xa0\x10\x8b\\.p\x19\xc1Q\xc7\xefv\xd7\xfbK!\x8e\xaf\x1f\x95\xf7C\xc2\x1b\xe2O\xe4rF\xdb\x96\xd4\xb8\x05\xe2\x11~\xdf\xc2UY\x82\x81\x00\x99\xd5\xf7\xac\x8f\n=\x1b\xea\x03A\x197e\xb9\xa4\x8amD\x80\xa6\x96/\xf9\xa2\x19\xc3\xc7\xd7U0\x02\"\xeb\xfe\xcf\xac\x04\xf6\xd6\xdd\xf8\xc8\"\x95\x19\xb6\x92\xab\xad\x04\x180UL\x17\x1br\xb6P\x87\xd0\x88\x88\xf2\xb4D\xb8ZK\x92\x10hq[P\xb5k\xbc\xbdeF\xa3\xb9\xf6\x18\x03\xd4R\x98\x0c;\x99\x01\xae\xdab\x08\xad\x0e\x1f\xdc\x13w\xadB\xb7sU	\xecB\xa9\xecN\xe6ad\xf7\x86\x99R\xadV\x1cQB\xd8\x04k4|\xcee\xbe\x06\x1d\x01,\x88|\xbe.\xacmam\x956&\x08v\x01 \xbc\xe1xT\x15\x0bs\xd4!9\x84\x81\xde\xd47\x1e4|0\x9dg\xb7\x926I\xe7\x96\xbea\x8c\xb4\x83\xe0H\x18\xb6c\xd5\n\xf8[\x97\xf5\xd2T\xac\x80\x97\x1ey\xd1\xce^\xad\xa8\x83E\xf1\xf1\x1a\xa6{s\xb9\xda&\xf8E\xfb\xe0v\x9b\xb3B\xd3k\x85\xc8c\xa9\xaaH\xa7\x1bP\x8c\x0c\xe6\x7f\x017s.\xc8C\xe80N-\xe4EJ\x0b\xdb\xacWU\x18\xd4\xa2\xdf\x86\xe4N,\x0bPKl9nu\x91\x08\xdb\xee\xcfr\x1b\x852\xeep\xa2\xbd\xea\x07\xd2H\x97\x94\x97\xce\xbf\x92\x0eA\x9d\xcd\xc6\xce\xd4\x1d<!Te\x9b\xe3\xb5\x7fPp\xdd?b\xb4\x14~E\xaa\xa2\xb6\xb9\xedf\x1e\xba\xea\xfe\x0c \xada\x94\xdaK\x96N\xbd\x8b\xe2\xca\x99\x07\x91\xa2@\x0clL\x18>\x93\xa4Hg\xb5\xe01\xbevl\xe6~l21\x17EV}q\xad\xf2\xac%t\xce\xf5\x92\x90\xc8\x19\xd7~\x14m\x11\xb2H\xd7Z\xd7\xbbe6r\xcc\x8d\x11\x1a&\xaf\x0d\xa2vv\xc4\x15*\xbc26lIj\x0e\xb3K\x07c\xea\x8dy7\xbd\xe1\xfe.\xcf\xeb\xb6T\x02<\xb8\xe8C\xaf\xb6\xd1\xe0\xe8\xf7z\x0f\xe8\xf5\xcbW\x7fa}\x95\xde\x08\xdda\xc3\"\xad\xbe\xb73\xec\xf0\x1dR\xdc\xe4\xf4uB\xe6\x9e\xe5\x18\xdb\x8f\x0e\x8e\xde\xa0\ng7\xc2\xa4\x03\xf4@~\xc8\xc0q\xe6\x96,\xac\xbb@\"le\xccW@F\xaf\x93\x9b\x8a\x08\xdd\x88\xe7\xf9\x17\x19/\x93\xe4\x89\xdc\xc0\xf3\xe1\xe9\xe0b28~\xdd}I\xdf<\xc5\x94\xbaOIXr\x10\x8a\xf1\xeb\xbe1`\xae}\xd24^\xb8\xcfT\xfaJ;\xc9YH4n\x13!\x1aM\x98[\xca\x1b\xe9\x9f\xb9LY\xce\x8b\xc5\x9a/\x04[\xa8[\xa1\x8bm\n\x96uk\xebc/f\xb7_?3\xb7UB\xa5\x1e\xa3\xc1O \xb7\x06/\x1f\x7f\xe5\xe3e\xe9\xfe\xa7\xb9\x12\x1ez\x7f?vk$\xb7Frk$\xb7Frk$\xb7Frk$\xb7Frk$\xb7Frk$\xb7Frk$\xb7Frk$\xb7Frk$\xb7Frk$\xb7Frk$\xb7Frk$\xb7Frk$\xb7Frk$\xb7Frk$\xb7Frk$\xb7Frk$\xb7Frk$\xb7Frk$\xb7Fr\xeb\x1f\x9c\xdc\nr+\x0e_\x9e\xcbb\xfd\x99\xbdS\xeb\"\xa3i\xfb\xcd\xb3\\#\xc9\xf5\xf7@r\xd5\xeb\xe2)\xf4Vz\xcd\xf2S\xf7~\\ :\x12[#\xb15\x12[#\xb15\x12[#\xb15\x12[#\xb15\x12[#\xb15\x12[#\xb15\x12[#\xb15\x12[#\xb15\x12[#\xb15\x12[#\xb15\x12[#\xb15\x12[#\xb15\x12[#\xb15\x12[#\xb15\x12[#\xb15\x12[#\xb15\x12[#\xb15\x12[#\xb15\x12[#\xb15\x12[\x7f9b\xeb7\xbf\xab[[#\x9f\xf5\xb7\xc9g\xd5\x9b\x93\x8c.\x0f.\x85)[\xa9\xac\xcd7,\x19u\xef'\x89\x97\xaf\xc6\xcbW\xe3\xe5\xab\xf1\xf2\xd5x\xf9j\xbc|5^\xbe\x1a/_\x8d\x97\xaf\xc6\xcbW\xe3\xe5\xab\xf1\xf2\xd5x\xf9j\xbc|5^\xbe\x1a/_\x8d\x97\xaf\xc6\xcbW\xe3\xe5\xab\xf1\xf2\xd5x\xf9j\xbc|5^\xbe\x1a/_\x8d\x97\xaf\xc6\xcbW\xe3\xe5\xab\xf1\xf2\xd5x\xf9j\xbc|5^\xbe\x1a/_\x8d\x97\xaf\xc6\xcbW\xe3\xe5\xab\xf1\xf2\xd5x\xf9j\xbc|5^\xbe\xfa\xfc\x97\xaf\xf6./\x07\x17\xfd\xe1\x0f\xdfb\n1\x1e@\xd4M\x0b\x17\x15\xbfQS\xb0\xa8=\xa1c\xfa\xc4\x0f:p\xd90\x8e\x8dh\x82-d\xa6d.\xf4*\x87\xd9Yy\xce6\x06I/K\x91g\x86\x89\"\xcd\x15V\xeel\xc3f\xd8\xa5\x14\xa5a\x07\xff\xf3\xbfn\x9bV\x0b\x8aLXm\xb7q\xc2$3\x18\xfa\xf3\x0d4M\xe0Iw\xd9a_\x15/<_\xc0\x16\x82\xfa\\\xe1\xff\xef\x88\x91\xb7N\x81\x0ds\xad\xd6y\x06\x13\xdf\xb7\xc3z\x07\x81\xda\xb6\x85\x10R\x15%3\x9b\xa2\xe4\x9f}.\xff9\x90\x01\xdb<\xbc\xec\xb2\x8f\x82\xf1\xdc(\xec\xed\xd1\xdb6N\xea\xa8)\xf4.,\x96\x9c\x1bC\x16+\xad\xfc\xca\xcc\\9\xaf\xcb\xc6\x02\xd0*\xdb\x8d\x0c\x0b\xa9\xde\x872|)\xd8\xc1J\xc3\xdd\xc9\x180\xf8\x00\xa6m\x93\xdag\xc9/h\xa6\xe0F\xfa\xfdx;r\x96E\xe0\xc2\x01a\x90\x83\xeb\xf4Z\xde:R\xd3\xa9/\x91\xc8\xce\x18\xbaS\xbe\xcc\xa5)A<\xebU\xd4\x9ex\x8do\xbc\xc6\xf7i\xd7\xf8&5\x83y\xbf\xa7\xc1{^\xdd,N\x84\xd6\x90\xc0\x16\xces\xf0\xab\xa5-\xef\xfd$\xf528L\x8f\xb0\x14\xfe\xdca}~+\xd8\xe9\xb5(\xc4\x86\xfd=\xe3\xb7\xe2\xbb\x94\xfe\xd1-D\xf9\x8f\xa4\x97\xe7N\xd3\x82\x07\xa2o1\xd0Is\xe3\x87\x96?\x04\x1d.}E\xe8\xc0_f\xb2\xe0z\x83\xb5\xbb4\xbb\xdb6aH\xa8C\x0e	\xcdC\x89 \xa7\x0f\xf6\xf8m\xb7\xb6\x0d\x19\xfah)\xcao\x93\xe4\xdf\xb6\xb6\xa2\xc8\xde\x0b\xb9%\xc1\xee\x05-5\x8aG\x04\x014\x07\xec\xd6z\x000\xa0\x88 \x1e\x84\x1e5[\x92y\xc7\xb7\xdb\xd6\x02Y\x84#\xe0Z\xe06\xc6\x1ehD\x82\xc0\x874_\xda\x08\x87\xf9\x8d0tB\x14\xc8\x13oQ-y)\xc0\xa3\x0b\xe8\x80\x1e~\xc2)\xed&\xc9\xf4l8a\x93\xd1\xbb\xe9\xc7\xdex\xc0\x86\x13v9\x1e}?\xec\x0f\xfa\xec\xed'6=\x1b\xb0\xd3\xd1\xe5\xa7\xf1\xf0\xfd\xd9\x94\x9d\x8d\xce\xfb\x83\xb1'\xb2O\xc7\xc3\xb7W\xd3\xd1x\xe2\x16{\x02\xd5\x8e%<\xf8\xe1r\x0c\x1fu4f\xc3\x0f\x97\xe7\xc3A?\xf0\xb7;lxqz~\xd5\x1f^\xbc\xef\xb0\xb7WSD\x1b\xd8\xf9\xf0\xc3p:\xe8\xb3\xe9\xa8\x83J\x93\xdd\xcf\xe0\x98o\xf9\xc3\xd4\x90\x86?\xdc\xe2	\xb3\xdex\x90\xf4\x87\x93\xd3\xf3\xde\xf0\xc3\xa0\xdfe\xc3\x0bv1b\x83\xef\x07\x17S69\xeb\x9d\x9f\xb7\xf6\x12mo\xf4\xf1\xed\x80\x9d\x0f{o\xcf\x07	\xd5t\xf1\x89\xf5\x87\xe3\xc1\xe9\x14\xdd\xa9\xff\xeft\xd8\x1f\\L{\xe7\x1d6\xb9\x1c\x9c\x0e\xf1?\x83\x1f\x06\x1f.\xcf{\xe3O\x1d[\xe6d\xf0_W\x83\x8b\xe9\xb0w\x9e\xf4{\x1fz\xef\x07\x13v\xf8\xc8\x90\\\x8eG\xa7Wc\x8a\n`\x1c&Wo'\xd3\xe1\xf4j:`\xefG\xa3>B\x18\xc9d0\xfe~x:\x98\xbca\xe7#\x0c\xfd\xbb\xeapA\xbf7\xedQ\xc5\x97\xe3\xd1\xbb\xe1t\xf2\x06\xff\xff\xf6j2\xa4A\x1b^L\x07\xe3\xf1\xd5%\x00\xf8\x88\x9d\x8d>\x0e\xbe\x1f\x8c\x93\xd3\xde\xd5d\xd0\xa7\xd1\x1d]PW\xa7g\x83\xd1\xf8\x13\xaa\xc6\x18\xd8`\xc4\xc7\xb3\xc1\xf4l0\xc6\x80\xd2H\xf50\x18\x93\xe9xx:\x0d^KFc6\x1d\x8d\xa7A\x1f\xd9\xc5\xe0\xfd\xf9\xf0\xfd\xe0\xe2t\x80\xd6\x8cP\xca\xc7\xe1dp\xc4z\xe3\xe1dx\xf1\x1eE\xa2\xda\x8f\xbdOltE]\xc6\x1c]M\x06	\xcc\xc8Pb;4\x93l\xf8\x8e\xf5\xfa\xdf\x0f\xd1l\xfb\xf2\xe5h2\x19VM\xc5\x9f&W\xa7g\xac\x1a\xee\xe7>\xcf\xb2Z\x8a\x9f\xb8\xceN\x16\xea\x18\xc1\xf5\\\xceZ\xf1}\xf7\xad\x1a\xb1\xf7{vq\xfeO\x1d\xd0u\xb4Lo\xd8\x07\xaa\xee\xd7\x0e\xecI\x05\xec\xb0m\xbe\x0e\xda\x93\x10\xda\xbf\x1c\xd8\xdb\x1a\xf0\xc5\xc8\xfeUm\xb0\xb8\x9e4p}_TGw`\xfc\xfa83\x82\xa4i\xed=\x1ao\xb8\xce\x04T:\x05eE\x91)\x8d\xb8\x1c\x85b\x97\xaa\x14\x89\x8dW\x83\x9eZ\x13\xb9+\xb5\xe5\xecCG\xd0\xa8m\xaf\x95\x965\x135\xa9\xcd\xae\xe7S6\xc9pr\xc0\xbeZ\xd9$V\xd9\xb0=\x95M\xd2\xael\xd8\x17(\x9b\xe41e\xc3\x9e\xa4l\x92\xa7(\x1b\xf6\x04e\x93<M\xd9\xb0G\x94M\xf2te\xc3\x1eP6\xc9\x17+\x1b\xb6\xabl\x92\xfd\x95\x0d\xb3\xca&\xf9\x15)\x1b\xcdo6y~B\xec\x9e\x9b6=\xd3|\xa1V\x16\xfb=\xde\xf1\xbc\xff\x89\x87+\xe3\xe1\xcax\xb82\x1e\xae\x8c\x87+\xe3\xe1\xcax\xb82\x1e\xae\x8c\x87+\xe3\xe1\xcax\xb82\x1e\xae\x8c\x87+\xe3\xe1\xcax\xb82\x1e\xae\x8c\x87+\xe3\xe1\xcax\xb82\x1e\xae\xfc\x03\x1e\xae\xfc?\xf6\xae\xf77n\xe3h\x7f\xdf\xbfb\xdf\x03^\xbc\xd2\x0b\xead9N\x9b\x18EQ\x19rU\xb7\xb1dX\x0e\xdc\xa0\xe8\x07\x1eo\xefna\x1e\xf7\xc0\xe5If\x82\xfc\xef\xc5\x0cg\x7f\xf0\xc8\x93%\x1e\x9d\xc6\xc9\xf0C\x10\x8b\xbc\xe5\xfe\x9c\x99]>\xcf3L\xaedr%\x93+\x99\\\xc9\xe4J&W2\xb9\x92\xc9\x95L\xaedr%\x93+\x99\\\xc9\xe4J&W2\xb9\x92\xc9\x95L\xaedr%\x93+\xef%W\xfe\xeb\xdfL\xae\xfcu\x92+/\x8dY\xe6\x8a\x13\xc9p\"\x99\xffV\"\x19\xbb\xd2\xa5\xd9\x9e.\xcd\xc6n+\x9d\xf7\xa1\xa1w\x1f!X\xf3\xe0K\xb8\x97\xed\xb2^\x9b\x00\xfb\xc5\xcd\x85\x93\xec\xf4\xdb@\x08cUn\xee\xa6\xa2\xcb\xd7y\x96\xc8\xf7\xe7\xff8\xff\xe1\xfc\xf5\xb9\xbc\xc1\xaa~6\xc2NK\x8f=\x11\x0721\xe5\xd8T\xcc\x01\x94\x9dq\xb9\x98\xa3\x921\x07\xf3v\xfe_^\xd1\xb6\xb8\"\xfa\x8e;6\xf0\x13\x8f\x12T\xc8\x82V\xe8^\x8e\x0f4\n\xac\xe9\xbd\x14\x1f\xf9\x08\x8a\x0f\x14\xd8\xcf\xf2\x91\x9f\x83\xe5#\x89\xe5#F\xa1\x94\xba\x9f\x89G\xb2|\xc6\xa0\x94^\xbf\xbf\xfa\x14\xc9G\x8c\xc3(u$\x1fq8\xa34\x90|\xc4\xa1\x8c\xd26\xc9G\xb4H>r0\xc9G\x8c\xc2(\x1d\x89\xe4\xe3<\x8b\x80\x93\xe7\xb2H\xf3S\x88\xfbLq\xda\x18\xa4\xe9\xd28[\x11{\x10\x14J\x86t\x19s2\xcf\xb4\xfc\x96\x06\xdc\xed\xa9*23\xd7\xc52\x142\x15\xa2\xebF\x9e|\x8b_\x9d.\x8dS\xb8\x98\xca\xcf\xe5EF\xa7}\x1e\xeaG\x0e\xe7}\x0ev#b7\xc4\x1eF>\x154/\x86\x11?\x85\x0f\x14\x1b\x07\"\xf7\xb8\x90(Z\xef\xf5\x1c\xa2\xc3\x0e\x1d\xe06\xc4c\x98\xa1\xa3\xfb\x0c\xe1\"\xe4\xc1\xccP\xb1\xc7g<\x8e\x19*\xce\xe5\x08\xcc\xd0\x07\xf8\x0c\xf9I\x9fq01T\x0c\xf5\x19-\x15\x021@\x85\xa0K\x0c\x15\x07\xa8\x10\x8c\xeb3\xc6Q!\x88\xf75C\xae\x9d\xbd\x90\xd9XPYZ\x9e\xceU\xa6\xd7\xe9\x9e\xedP\xe7)\xf2[\x83/\x01\xae\xe7\xf5\xabw~\xf3z\xf4\xfa\xd5\xbb\xe3\x9e\xbd\xce\xd7\xf2\x06\xdf\x9c\xd0\x91\xc1\x1b\xbf\xfca/\x05I\x00\\&\x105O\xe4\x02\x0e\xef\xc0\x88\xe2Yh\x02gSp.\xb6Q\xa55\x05m\xee!\x16o\x12\xc5\n'\xaa\xefm\x0f\x98\xfd\xe8\x0bI\xdb\xb8\xc2\xe9\x8b\xa5\x13\x88\x1b\xfa\xc5\xe4\x18_2Wi\xee,\xb2\xbb\xe5\x9c\x1f\x1cP\x82\xb8\x02aS\xfc\xd9\xb9\xbb\x1d6\xbf\xf8sl\xbd\x15\xcd\xd1{\x82\xf5L\x08\xa2\x90\xc8\xb5\xc2\x9c%\x1bH\xe5bWI\xd8\x07\xa8N~\x17\x90u\xb1*\xcfE+\xad\x82\xaf\x1d>\x03UG{ZQ\x17a\x96\x80\xbb\x95Y\xb7\x9e\x95\xda\x8a\xc5\xb6,\xb4]\xd1\x19\xbf\x91\xd6\xe0\x1b\xe3\x9c.}n\xfb\xb9\x10\xef\xba\xb2\x0b\xeep	\xba\x1b\x07 \x18uw\xcb\x7f;\xf7\xfa`\xba\x10\xf0\xd5\xc55\xa7\x84\xd7\xdb*m\xbe\x85@\xf6\x14\xa7\xff\x10W\x1d\xd5\x03^\xf6o+\xc8\xf0'\xbb\x1aS?\xb45\xa5\x82?p\xfa2\x91\x16MO\x94\x8c~\xe7^\xd3\x9f\x88\xd6\x17\xc3^\xbb\x7fu!\xaf\xae\xaf\xe2o\x8f{4h\xc4\xf9\xf7\xef\xfe\x06F\xfe\xfam\xf0\x03\xde\xdbu%\x01p\xfb\x91xZ\xbf#\xb7\xefa\xcd\x9f_\xc9sL1\x0b}\x12,%\xb2\xe5c^|\xe2\xcd\xdf_\xdf^\xbfN\x04Q\xe2\xaf]T~\xf5\xb2)\x05\xbaZ\xb6F\xe4\xfam \xceS\x81\xf2\xe2\xe5\xf9w\xaf\xae.Ai\xa65|S!N\xe4\x0b\x97*\xb0\xc7R\x99\xe5\xf6\xc7\x99\xce\x97:;]l\xe6	\xa5\n\\\xa5vg\x82\xd2By.&\x93\xc9\x83M\xd1W\xf2z\xb9\xfdQ\xbe\xc0\xf2\xc7\xb5D\x00r\x1c\xcf\x12I]\x88\xd6\xfa=\xc4\x12A\xe6\xb6q,\x91_\xba\x0bq\x80%\x92=\x96H\xfc\xd2\x96H\xa6\x91a\xfd\x92-\x91\xd3&\x11#X\"\x19,\x918\xc0\x12\xf5l\xed\xc5pK\xe4\x94\xa0\xd0\x12\x89C,Q\xcblM\xd1r\x8c\x1c\x8c\xe9r\xbb\xb1\xaa8\xcd\xcd\xb2\xdc\xf6\xca\xfd\xed>\x12\x02\xaaa\xd7\x83m\xdf3y\xa3\xd7\xa6\x90/\xed\x07\x9d\xcf\xad*8\x12\xe3H\x8c#\xb1\xdfs$\x16\xdb\xb1!W\xdb\xf6U\xa5\xaa\xb2UyZ)[\xe9E\xddk\xfcv\x9f	vl\xd8%\"\xcb\xd7\xb3\xf5|z\xf2\xf4\xc9\xd97\xf2uZ\xc9\xb7\xb5\x02D\xc7\\\xbe\xabs\xf8`\xb7-\n\xd8Z\xb1\x0d\xfc\x1d\xdb\xc0\xdfL\x0c\xc8\xbb\xd1\x07\xefF\xe1\xa6\xff\xe43\xb6H\xdb\x9dJo\x15\xa0\xa8\xeci\x95m\xaa2\xcdTy2\xdb,\xfal\xe1\xdeg\x83u\x1bv9\x00\xd7=W\x1b\x9fs\xdf\xf3\x11r'\x91\x7fO\x8b-|\x1dy\xfa\xe4\xc9\xb3\xbd?\xfa\x84R9\xd6\xae\x0f\xb3\x86\x9b\n84\x96o_\xbey{}\xf1=N\x93\x04g\xee\xc5+8\xd8}\xf1=\xfc\x05\x0b8\x9b\xca\x0b\x9f8\x9d`JR\x06\xa1vZ\xee\x98\x1e{/i\x03\x10\xdb]\x1e\x0e5\xacC\x86A\xe8\xf1\x02(}\x80\xfd\xb9Q\xf8\xb4\x95g\xb2Z\x95f\xbb\\\xc9o=6\xd7\x99\xf9\xddz\xed\xe4\xednK\xe1c\xa2x	B\xf8\x9dt\xf33\x87>\xec\xfb\x05\xb2\xb6\xb4m|\x96\xc3\x90S?D\x15\x88\x92\x80w*\xb1-\x88o\x04\xe5\xa7\x19\x96\xe2j\xd1\x03\xd8\xc5[\xda\xf1>)\xad{#\x05K\xff\xc8\x03@\x12\x1cW\x83\x91i\xbe\x8bR\x85\xe8A\x87\x87H+z\xe1\xc32\xe5\xbbVQ)\x13\xecO+\x8f\xf416ac\xee\x00\xb0\xdad\xe1\x85J\x04t6\xa6Pm#\xa2\x9b\xc7\xb0\x07\x00\xb2\x01\x99\xe6\xc1G{\xd4pS18\x89j\x90\xee\xb3\xda\x83\xd9\x03\x0b#0\x93\xa0\x94#\xad\x8f\x9b\x01\xb5+\xbd\x81\x92\x16zQa&\xef\x0c\x8a>\xfa\xfa\xc9\xff\x1e\xc3\x83kHO\x80\xd3\xc6\xadB\xb3\xad<\xf9\xc7\xae\xd2RYW\xa2>\x963U\xa8\x85F\xa8t\xab\xf4\xa8\x9ea\xc8\x7f0\xdb\x89<2%\xfe_99\x8eG=\x05\x0e\xd4'\x92\xc4\x13\xa5\x00&C8R\xa1y\xe6\xc8\x94\xbb\xb4\x02xoC$\x9e\xc0\xf2Z\xef\xce\xb4M\xa9\x16\xaa\x04\xfc\x1c\xde\x85\x15\xb8N1\xe7\x7f\xfc}\xd9\xba\x01\xbe\x17\xa6\xee\x83\xacH\x08>io3\xa9\x98\xe6\x81\xc4\xb1\xf1\x16z\xb9-\xa3mh\xe8\xb2k\x8c?\xbaU\x87}/V\xb8\x01\xe1C\x85\x90\xde\xb8V\xd9*-t\x96\xba\x05\xd2\xa4\xfbv\xacN\x188\xfcK\xeei\x16i\xcc\xb3\x8e\xa3\xb7\x99Ou\xbc\xd3L\x80\xd6kXP\x06+G\xcd\x0c\xa4\xd6V\x83]\xc7QKo\x1b\xeb\x8dQ\x18A\x92\xd4\\\xa7\xb2\xaa7.s\x064\x1b\x90\xea\x1d\xa3\x00n\x0c\xe6\x16\xc1\x8eVz\x13\x96@\x9cz\x99\xde\x18\xa5\x92O\xe4:\x05\xb6\xf4m\xaas\xe4ot\x00\x81	XSX\x94\x99\xe3\xe5\xba\xe4#\x9dm\xad3o\xf1\xd1\x1d\xc0\xa2\x11s\xdc\xf4\x90\xab-\x15q\x94\x16R}L\xd7\x9b\x1c\x02\xcd\x00\xbd\xa2\x8f;\xe7\x9b\x8d*\xe6\xfac\x03\xce;\x0e\xbd\xb0K\x0bn\xf5\x08\xcc\x00\xe8\x91\xfe>\xa0\xd6SI0\xfe\x89\xaf\xf8\xcc\x1dv\xc3R\x8c\xe5\x94\x8f\xdbl\x1fX\x0b\xcdaw\xc8\xa1\xa2\xe6\xba2\x80 \x93\xa5\xba\xd5\xd6\xe5\xbe.L\xb3\x83\xb0\x89Ty:3\xa5\xfbW@\x9e\xc5\xab\x89\xaa\x05\xb8DeU\x019\xea#rh\n\xd3\x14\xf8li\xde3\xe6]{L\xa59_G\x9e&\xe92\x1f\x9a\xa5\x0f\xb3\x99\xc6\x0e\x8b'\xafQ\xaau\xaa\xfd\xfaDf\"\xcc\x14XU\xd8\x8c\xb5*U^\xcb\\\x17\x1f\xb0\xe3f\x1ap\x8e5\x82\xde\x8e\xdd\xa0#\xe4f\x91\x02U\xde,\xdc\xd4ou\xea\xbe\xa4\xe3a\xd4=\xfbM\x9b\xa2w\xc4w\xd7\x80_\xb2\xd1\xfb|\x07\xd2\x82s\xbb\x06?\xb8iQ\xb7\xc7$\x86\xf6\xbb.\xc5V\xb9\x0c\xee\xf7rI\xc2\xa2\x88\xd8\xaeTJ\xe0\xbcV\x86F\xc7\x94\xfb\xf9\xaf\xe0\xcez\xc2\n*\xac\xe1-\xdc\xeb-h\xb2`\xa0R\x19z}\xa0o\xc4\xd4\xd8\xce[\x1e\xe6\xed\xe5\xc4\xb7\xc9q\x02\x1a\x7f\xef\xcd\xb2YH\x95\xab\xac*M\xa1\xb3\x04FaF|+\x87S\x84\xe0c[\x90\x7f\x91\xb0\n\xa8$\x9aJ\xbe\xa3 Z\xa8lX,8\xa3[\xb4\xaf.c\x8a\x8aj\xbf\xc3\x14Q\x9d\xe4:\xd59\xfc\x18p\xa66!\x8f\x84\xb6\xdc\x87B\xb6\xb6\x95Z{\xdf\x07\xd3W[\xbbU\xe0B2\xf4\x91\xf4\x04\xa5k\x02L.F+>\xd6\x8a;=\x89\xccHk\x16@k\x1d\xbf\xc0, \xc6\xcd\xb6\x16\xbd<\xbeq\x8d\xf6\x92\xc2H\x98\x89I\xe4\x9a\x82\x00H\xbb\xadn>f\xa6\xb0\x1b\x9dm\xcd\xd6\xe652t\xd5\xdc\x1b% \x8fS\xdb\xe6\xca\xeae\x81\xb6?\x00\xe5\xf7\xccDp\x15\x93+S\xc940U\x01\xf24\xe9.\xe1\x9d\xf8\xda7\xdb\xad\xc0O\x86<q\x07\x82}\\\xef\xbc4\xb0\xcdJ\x95)\xb4\xe4\xb3\xba\xf5\x1e*\x08\x8e\x12\x1a\x9a\x1f\xf0\x92\x8a\xcc\x94\x1b\xb0\xd2Db!O\xf4\xde\xe72{:\x95\x97\x10V\xc1k\xc3!\x96\x0b\xe2\xe5M\xfb\\\xe41\x0c\xf4.\xc3):\xe5\xb7\x8e\xe0\x98B\x84\xb7Q\xd56\xf0\x16\xefL\x99\xcf\xef4\xc4\x1a\x85)Np\xe4\xad\xbe\xc5\x7f\x9e8pFi\xea4\xaf\xea\x93E\xa9T\"uY\xaa[\x83\x84\x08\xaaK\x18J:M\x87\x17zD\x1d\x08\x13\x80\xe5\xef*rD\xe6\x1c\x81\x12Y^\xc3D\xdd\xe4iM\x87U\xf0\x97\x8d*\x1bW\xbbsZ\x1fa*\xa2E\xe0mq\xbf^B\x8f;G\xdb\xd2\x0c\xd0W\xd1\x00\xbdI\xc1\xe8\xfe\x06F\xe7\x88\xd2\x95\xa5\x1d\xc1\x06\xdbl\x88\x8eIR(\x1e\xbdu\xfaA%\x8dP\x00Dyn\xba\xe0>\x1a	\x8dPk\xf8V\x92\xd0\x7f\xf5\x1a\xce\xd2 \xce\x98\x87]\x12\x05\xca\x84U\x8cu\x86\xee`\x826c\xe4\xde\n\xbc5\xed\x84Q\xb0\x97\x8dU\xaejD\xc0k\x9e\x8d\xa6\xde\x8c\xb8tq\xefz\xbbY\xa8LY\x9b\x96\x1aW\xe7\x02\xd0InG\xa3\xb4\xf3}\xfe\x97\xda\x14G\xf6X\xa6\xb9)\x14y\xc4\xcc\xac\x01)\xea\xa2z\xfc\xd9\xee\x0f\xa8\x18\xda\xe1\x92\xb7\xc5OB\x80h\xe8\xc8'\xc0+\xee`(\x9c\xaf\x9b\xcaW\x0b\x18\x7f*\x07h\x85\xba\xdaV\xbe\xe5\xb9\xae\x88\xd6\xe9X\x87h\xe4h\xe3\x1e\xf1\xb0}l]\x1akO\xb0\xc3\xa0\x19\x99\xd9B\xfc\xd4\xfc\x1b\x0eCe\x9e\xde\xd9\xad\xae\xa0\xa9\xb9Z\x82\x99\xf7\x80\xde\xf7!\xbe\x06\x83\xdaj\xeb\xbd\x06\x0el\x14U\xdc\xd2V\x9b\xaa\x83U\xf0\x83S\xbbf\xb9\xf1\x80=x\xa4\xdb\xe4\x1b\x8d3\xd1\x85Ln3Jv\xccm4\x82\x05$\x97\xe7\xa2\xaa&&\x05\x03\n\xa3\xe7\xccTJa\x87\x92\xf3\xb4\xf2\x93\xcf\xf7.q\xd1\x00H-\xa5|6\xdd\xc1\x0dO=\xb5\xd7[\xb6]+\xe4\xce\x98\xe3\x8d\xfe\xa7\x18\xc38$\x106\xaa\xb9\xde\xae\xbb\x80l\n\x84\xe2\xa0\xb2Yd\xfb,Y\x12\xb6BNM\xcc\x15\xa2\xd4~\xd8\xf6s\xe7g\x8f\xd2H\xccl	\xf5\x0d\x84\xdcRez\xa3A\xfa\xc8\xf5$5\x8f~\x0bW\xa7\xa1q\xd2\xc30bm-\xb0Y\xf4\xce\xe6\xe0&\x84\xd2p\x8a\x80\x9f\x91a\x97\x9c\x960\x85J\xb3\xd6\x05\xcc\x13\x92\xd0\x8a^\x8f\x993\xdc\x94\x86v\xc0\xd6\x1d\x96=\xccT,\xa7\xfdf\x96nc\xe96\x96nc\xe96\x96nc\xe96\x96nc\xe96\x96nc\xe96\x96nc\xe96\x96nc\xe96\x96nc\xe96\x96nc\xe9\xb6\xcf%\xdd\x16\xbe\x01=}r\xf6\x07\x001\xffQ\xbe\xf7xA\xf9]Eg\x91dIXz\x8b\xa5\xb7>\xab\xf4\x16\xaa\x92\xa0>\xda\xc7\xd3\xac\xac7\x95	\x88\xd6\x9e{\x04D\x1d|\xb1\xd0	\x0b\x9d\xb0\xd0	\x0b\x9d\xb0\xd0\xc9\x97-t2\x06\xb9\"v/\x85\xaa\xfa\xfd\x0e\xdc\x08\xeec\xd8\xc5N\x87\x9d\x0e;\x1dv:\xect\xd8\xe9\xc4N\xc7\xd6\x91\x8eC\xec\x8d\xe0Fp\x1f\xc3.v:\xect\xd8\xe9\xb0\xd3a\xa7\xc3N'v:\x00\xdc\xea\xf7:x'8\x90a\x17\xbb\x1dv;\xecv\xd8\xed\xb0\xdba\xb7\x13\xbb\x9d\x8f\xaa,M\xb9g\xbf\xe3n\x0672\xec\xea8\x9f3\x96\xb1g\x19{\x96\xb1g\x19{\x96\xb1\xff\x92d\xecG\xf9\xba\xb3\xf9\xb0\x9c\xea\xe24[\xa9\xec\xc3\xf4\xf6,\xf2<\xbbw\x82\x03\x19v\x89K\x83o\x91'\xf2\\\x96@\xc1D\xa1B\x94rI\xd7\x88\xacA\xe8\xd0\xa5\x11\xb2\xeb\xa2\x9e\x00\x08\xe7+y\xb9\xb5Uzk\xe4\x95Vk\x05\x8a\x82\x7fZ6\x7f\xf9KA\x7f\x99\x16\xaa\xfa\xb3\xf8l\xd9\xbcF\xca\xc3\"!\x0f\x8b\x14\xe2lz\xa0\xff:8\x9d\x97\x14O\xa7C=X\xb7\x1a\xc2\xe1\xf8\x1fY\x0d\xa7\x881R>/\xc9\xb9\xb08\x17\xd6\xfe\\X\xa2q\x08\xf2W\x93\xd7d\xa4\\X\xb1q\x1frE\x0e\x01(\xe5\x85]\x95\xeaV\x01\x8e\xfc47\xcb\xb3\xaf\xa7\xb7O\xbb\x0eb\xef\x93\xc1\xf4\x0f\xbb\"'\x80\xf2\xd9\xe7yZ\xc8\x1b|\x91\x10\x87\"/\x0f\xcex\xfa\x18\xcc\xe5c\xf0\x96c\x80-\xfd\x86hp\x92\xd3\xd12\x9c\x8e\x91\xde4\x88\x8a\xdbns\xc6\x9d\xf6u\xba\xce{g\xb9\xbb\x11\xa6\xe7\xb0\x8b\x10\xcc\xf7\\\xac\x0d\xca\xda\xa0\xac\x0d\xca\xda\xa0\xac\x0d\xca\xda\xa0\xac\x0d\xca\xda\xa0\xac\x0d\xca\xda\xa0\xac\x0d\xca\xda\xa0\xac\x0d\xca\xda\xa0\xac\x0d\xca\xda\xa0\xac\x0d\xca\xda\xa0\xac\x0d\xca\xda\xa0\xac\x0d\xca\xda\xa0\xac\x0d\xca\xda\xa0\xac\x0d\xca\xda\xa0\xac\x0d\xca\xda\xa0\xac\x0d\xca\xda\xa0\xac\x0d\xca\xda\xa0\xac\x0d\xca\xda\xa0\xac\x0d\xca\xda\xa0\xac\x0d\xca\xda\xa0\xac\x0d:\xbe6\xe8\xf9\x9b7/\xaf.^\xfd\xf39\x0c!\xf4\x07X\xd4\xba\x07\x81\n\xf7\xb0*w>\xcf\x9c\x94\xf2\xdd\x03\x7f\x90P\x8a\xd56\xf9\x8c\n\x99\x19\x9d\xabr\x93C\xd8\xe9\x08\x00~'\xbf\xd0*\x9f[\xa9\x8a,7\xb0rg\xb5\x9c\xc1WJUY9\xf9\xe9g\x97\xc2\xb1Tx2A\x10\xfa\xdaM&=\x87@\x7fQ\x83\xa7\x89v\xd2Syta\x8a\xff\xf3\xb9D\xa9\x10\xa8\x9c+\xfc\x7f\x8e%\xee\xd6\xf1`\xc3\xae\xcc6\x9fC\x88\xef\xebA\xbb\x83\xc8mS!h\xa9\x8aJ\xda\xba\xa8\xd2\x8f>I\"\xa2q\xe13OZM\xe5{%\xd3\xdc\x1a\xf8\xb6\x87OS\x0e%\x07M\xc1g!b\xc9Sk1b\xc5\x95\xdf\x84\x99\x1b\xb7\xeb\xa2\xb3\x00\xa8\x155\x03\x13\xc3\x86\xefP\x16\xd8n\x93M	\xdb\x9d\xb9\x04\x1b<\x81\xd0\xb6\x0d\xed#\xf0\x0bTS\xa5V\xfb\\\x9d\xd4s.\xc7\xa5?\x9e	\x87\x1ci\x99\xad\xf4\xad\x035\x05x\xf3Ou]\xd7?\xcb\x9f\x1c\xd9n\xe7K\xfc\xcf\xac5\xcbZ\xb3\xbf\x9c\xd6,\xd0\xb2\xa6\x951y\xccC\x8e\xfeHH\xe6\xc1W\x1b\xd7\xff\x8d\x8c\xdf\xe8\xd0<_\x18\xc0\x9f\x11\xfe\xbfv\x84\xff\x7f\x06\x00PK\x07\x08\xd9\x1d\xbf\xa5[z\x00\x00\x0f\xbc\x03\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x0c\x00	\x00openapi.jsonUT\x05\x00\x01\x80Cm8\xecZM\x8f\xdb8\xd2\xbe\xf7\xaf(\xf0}\x81\xec\x02\x86\xbb;\xc9e\xfb\x96\xcd\x07\xd0\xc0\xce\xa2'\x13\xec\x1e\x06s\xa0\xa5\xb2\xc4i\x89TH\xcaN#\xf0\x7f_\x14?$J\x96m\xd9\xe9\x04\xb3\x8b\xf1\xa5[R\x91|X\xf5T\xb1X\xe4\xd7+\x00\xa6\x1a\x94\xbc\x11\xec\x0e\xd8\xab\xe5\xcd\xf2\x15[\xd0[!\xd7\x8a\xdd\x01I\x000+l\x85$aJ\xbe}\x847\x0f\xf7N\n\x80\xe5h2-\x1a+\x94\xa4\xef\x9fJ\x84\x8f\xef\x7f\xf9D\"\xa0\xd6`K\x84\x1c\x1b\x949\xca\xec	\n\xcd\x9b\x124fJ\xe7\x98\x83\x90N\xe0\xedO\xef\xfe\x0e\xab'\xf0\x9d7Z\xadp	\xd4\x93\x155\x1a\xea\xc6\x08\x99!p\x99C+\xad\xa8\x80k\x04\x8d\x15\xb7b\x83\x90\xb7\x9a\xd3\xf8\x06\xac\x02\xa9\xb6`\xda\xac\x04n\xe0\xc5\xcb\xf2\xc5\x02\x94\x86\x8f\x1f\xde\xc2\xabW\xaf\xfe\xe6;\xb4\xbcn\xcc2N`\x83\xda\x04\xf0\xb7\xcb\x9b\xe5\x0d\xbb\x02\xd8\xd17fP\xd3Gv\x07\xbf:-|e\xad\xaeH\xee\x9a7\xe2zs\xcbvW\x00\xbf9\xd1\x86\xdb\xd2\xf4\xea\xba\xee\xa6,\xb0\x7f\x0d\xc0\n\xb4\xc9\xa3W\xbe\x07\x7f\x9fS\xcf\x83v\x8b\xd0\n\x80\x99\xb6\xae\xb9~\x8a\x1a^Wj\xebfK\xda\x0b0\xa3\xb6y\x9ek4f\xe1\xb4\xe5\xdf	\x1d\x85\xe2\xac\xe9\xc7\x1a\xaey\x8d6\x9d\xa2\xff}e\xff\xafqM\x83\xfd\xdfu\xa6\xeaFI\x94\xd6\\\xf7\xf2\xd74\x08\xdb\xf5}\xcdi\x93cc\xcbs\x1b9\xc3\x9f\xdb\xc8q\xc4Y\xc7\xff~\xeb\x9b3\x8d\xa6Q\xd2\x0c\xccB?\xf6\xf2\xe6\x86\xdd\x1d\x9az\xd7\xea\xfaC\xa5\xb6\xff\x10\xc6\x0e1\xb1\xd7\xf3Z\xbf\xd7Z\xe9\x04Y\xfc\xcf\xff\xdd-F\xf4\xb1\x17\x91\xc7\x9e\xa6\xceZ\xab\xda\xb1%\xab\x0458N\x9e \xf4'y\xfe+\xc8\xe3\x82\xc39\xbc\xf1\x0d\xa6\xa3\xcd\x1bhx\x81D\x0f^U\x8e\"N\x9a\xa2j\x8e\x1as\n\xdb\x9e$\xf7\xeff\x13D\xf2\xda-&|mQ\xb3\x050\xe1\xd6\x8e\xcf-\xea'\xb6\x98\\S\xb2V\x1b\xa5#M	\xd3\x02\xb6\xa5\xc8J\x10\x06$~\xb1\xdd'\x8d\x1b\xa1Z\xe3d\x96\xd4\x9b\xc9J\xac9i\x80\xd9\xa7\xc6\x0d,\xa4\xc5\xc2\x0f]\x0b)\xea\xb6fwp\xe3F^\xf3\xb6\"7\xbb\xd9\xed\x16\x93\xa0+Q\x0b;\x0ft\xcd\xbfP\xdf \xdbz\x85\x1dx\xa7\xc0\xf8p6\xca[z\xf2\xfd\xb2;\xb8\xbd\xb9\xb9\x19\xe2\xa67c\xe8\xa7\x9c\xeb\x87\x07\xd9d\xa0\xe9\x14\"\x92\xae\xd3WJ-\xfa\xb1LI\x8b\x92,\xf5\x95\xf1\xa6\xa9D\xe6\x18}\xfd\xbbq\xbd|M\x15:9\x7f\xff\xdd\x07\xf4\x07^ \xdb\xed\xfa\xc9\x84\x14\xe0;y\xa8\xe5\xd5\xa3\xf7\x8b\xd9>\x1a\x9b\x1c\xce	|\x90\xa6\xec)Cc\xd0PrE\x9aE\x99\x0bYx\x7f\x8d\xac\xcb\x94\x94\x98\x91\xbef\xfb\xec\xe4\xcc{\xf9k\xab\x9as\x19\xf4\xc7\xa3]\xd0\xf2\xf7#\xdb'7\x80\xcf\x1f~\x18\xdd0/\xce\xcbB}\x83\xc3\xe9\xa7\xfb\x0e+\xb4[D\xd9\xe5\xa0\"C\x13\x832\xe5\xe7\xf4\xbegc`\x9e\xe15\x02\xc5\x7f\xb3\xf8\x93\xa0\xe7\x13\xd4i\xfe\xfb\xd1\xf3\x17o\xc6\xf7y\x81?\x98\xa3\xc3\x0d\xd4\x0c\x8e\xfa\x06\x87\xb7H\xa6T\xda\xa2\xb1\xe0\x04#\xaf\x92\xec\x97\xcb\xb8U\xa2\x8d\x14\x97\xca\x96\xa8\xe7\xee\x8fb\x06C=M\xe4\x02\x1a?\xb7B#\x01\xb5\xba\xc5\xe9\x94\xe6\xfea\xf3\xba\x83\x10\xf0\xf9 ~(m1V\x0bY\xd0\xc7\xb5\xd25\xa7\xc5\x8f\x89f\xf3\x9a\x1d\xcaU\xaczNp~\x0f\xf9l\xe0j\xfe\xe5\x9d\xdb\x12\xceJ\x00c.U\xa1,l\x19!9\xe3\x9e\x95\xe5\x0d\xf3\xa7\xd7\x83\xdc\xe9\xf5\xffB\xe2d\xcb\xef\x18 \x1e\xb8-\x7fPd\xe8\xca0\xbd\xea\xbb\xf80,]t1\x83\x9c\x89\xdd%\xee\xe9^<\x87\x07X\x05[^=:\xce\xf9\x1a\x16y\xfe7\xb8\x02Uzl9\x00\x9b\xcfw\x86\xe1\x86\xa2TM\x87\xf0\xd9|\xe1\xb6\xf7\x05\xe6i\x9eb\xf5ofa\xa5\x92\x1b\xa1[\x0b\x99\xf7\xf9<\xb4M\xce-\xe6\xbe\xb2wB\x91	\x14Z\xc5\xef\x9es7\xa6\xd1\xb4\x95\xfd\x96\x18r;\xde\x82\xbdL6`\xcc\xd7\xa2R\xc8\xfe\xcds\xe8\xce\xf5tb\xb1\x08Yf\x004\x19`XW\xd3\xea\xdfMo\xcb\xf6\xb6b\xcf\xbb\x0d\x1bE\x96^\x89>T\x9cBGnM+\xbe\xa0\xbd\xcf\x86W\"\xff>PC\x11\xaf\xcb\xdf\x87\x1a\x0e\xa12A\xcb\xfe\xa9rL\x9e\x01:#\xa9\xd5\xef\x98\xd9\x14\xe5\x88\xb8o$\xa0\xcc\x1b%\xa4\xabppg\x82\xc1\xb4\x92\\\xe3\xd7.\xe05J;\xaf\x08\xf9\xb7\xfb\xb7\x109\xfd\xad\xf8\n+\xc3\xd2\x9ah\xa3\xa9\x92h\x87\xb5\xeaaD=\x9d\x82\xf4\xfdQ\x8f4\xfe\x81\x8a\x8bl\xab\x8a\xaf*<\x18}I\x00\x84O\x88\xa4\xca\x91\x0c\xcaCjD\x1b\x0ce\x10h\x00\xe3\xb6\x19\xbc(4\x16\xe4\x0f\xcb1\x880\xf9	\xfc#AR\xcd\x14\xda\xa1X\xd0\\*\x18\xed\xe74%(\xda\xf0\xeaa\xa0\xcd\xb1\xe6\xfa\xd0@?\xb6\xd1\xeb\x03\xfa\x9d\x88`\xff\xfa\xf8!\x86\xad\x907&E0U\x0bK\x01u\xadt8uq%!j\xb4\x9cZe#\x0c\xe7\xfd\x97\xb2\xd3\xd11\xe4\xd3\xc1B\x14\xeby\x9f+\x86Q\xc6D\xf5\xb2\xa48/I\xff%U\x89\x99\xf4\x0c\xbd\x9c\xf4X\xe7\x80C\xc5\x87a/i\x9a\xe2\x9c\xaa*\x0e\xa5\xfb\x85~\xa2\xfa8a\xe4\x89\xb5\xbd\xab\xd6\x1f\xb6z8I\xa3\xba\xd9p\xb33v\x8a\x03L\x9f&\xdc\xfd\xbb\xb4\xaf\xf4\xb8.%\x9e\xac\x9e\xe6\x8f\xff\xb9\xc5\x16O\xab\xfdg'6\x84\x9e)Y\xa0	\xdc;a\xb6\xb7\xbd\xec\xb0\x13\xaa\xf4\x9dl\xfd\xa6\x18\x9d91\xd3\xae\xba\xba\xfaLC\xc6&Q\x85?=|z\xfb\x90\x96\xdeR\xdd\xee\x15N\xd2]k\xda&S\xad$7\xe7\xa4\xf8\xb4P\x02\xc8\xb3r	\xf7v*\x184\x15\x17\x12F\xe3\x8fM\xc3\xa5T\x96w\xfa\xdd\x0f\xf9SlU\xb6+\xd4j\xdc\x08\xdc\xc6'*U\x8c\xcf\x99\xe2\x91%E\xef(\xe7\x1dq\xd1\x1f\xda\xe2\x97\x063\x8b\xf9\x1d\xacx\xf6\xd86`5_\xafE\xf6b<\xb9\xb0D\xb8\x81\xe8\x14@Y\x08S\xc0\xfcx\xc8#\xfb^\x16\xf1h\xce\xa9\x8f\xa5\xa6\xe9\xd6g\xf2\x06\x83\x99\x92\xb9	'\xd7\x84\x93\x17T\x9f]\x0bm,\x18\xbe\xa5wu\xeaF\xc1d\xad\xac\xd0\x98\xa4\x85Z\x03\n*N\x80\x119\x926\xb2G\xf7\xbd>\x9c\x07\xd4B\x92K\xf3\x8d[Jj\xfeef@\xad\xc5\xd0\xf6\xc7CUR\xa4\x7fR\xads\xb8D!{\xf4\xda\x14\xe7\xf4\xbdA\xcd\x8b\xc1\x18G\xa8K3<\xa3\xef\x1e\xb7\xaa\xf2=\xd4\x9d\xf1\xe3\x7f=q\x92\xb0r9\x7f\xfa8F\xffZ\xad*\xe0U\xa1\xb4\xb0e\xdd\x05\x05\xa3\xb2G\xb4	\xa9.\xa0\xcaV\x0b\x8b\xa7\xa8\xb2\xb7\x12\xcfcJ\xb2\xf4\xce\n\x14\xdd\x0c\xe3\x04c>\x17\xfd>kW\"\xa3\xcb\x1a\xab\x95\xa6\xe3E\xc0\xba\xb1O\xe4\xe4\xad|\x94j+\x97\x87W\xf0\xcb\x10\x8c#\xcf\x1c\x04\x9d\n\xf6\xb9\xf1s\\\xd9.H\xa4\x08\x9f\xb78\xb8\x05\xb2\xa7\x81\xc3x6\x0b\x92\xa6\xb3H\xa01\xdbx\xfc.#\x93y\xf7\xc0\xb3\x0c\x1b\xdb=RH\xae\x94\x8b*\x86[\xbax\x83\xf9L\xc6\xf4c\xcc\xf7\xd4\xd5\x13a\xd7\x98\xa1\xd8\xd0aski\xc5\x01\x8d<\x9e<wV\xb4\xc9\x81\xeb\xe9\x80\xd1\xcf\xf1\\0\x86T\x1c\x81\xf0\x8c\xa8Y\xd1\x12\xd4\x01\x8a\xc4>\x0fP\xaa\xe7\xf9\x90\x92\x90\x08[.,\x9d\xf4\xd1\x9a\xef{\xfb\xcb\xcb\xbf\x82\xf2\x89a%\x8cE\x89z<l\xb4\xe7\xfc!\xdd\xf9w\x9c\x90\x1f\xc7s6\xbe;4T\xcf\x97t\xb0\x95R\x15r9\xe1\xb3\xff.\xd1\xadz{\xe3\xd0Z\x8f\xdc\x7f\x08\xf8\x8fG\xed\xee\x88\xf72\xe7\xec\xef@$Y\xed\xa1\x1dx\xb8L\x01\x8c.%\xcc\xf4\x8c\xfd\xcc\x92k\xcd]\x1dKX\xac\xcd\xe9\x84\x95f8\xdeZ:\x00\x97\xee\xc1\xf7\xef\\Pw\xe1\xe2\x85\xd2\xe0\xf6\xe8\xdc:\x8dT\xdc\x1d\xb3\x14x\xda\n\xfbU\xa6\xf1N\xfa\xa8Z\x7f\xb0:\xbbN\xf7'C\x85\xf0o\xa0S\x7f\x82q\x82N\xfe\xb8\x83\x98p\x8e\x06B\xab)\xe3wSx^\xe6u\x9dN\xab\xeab\xbb\x93\xaa\xe6V\x03\xba3\xc4\x8b'CP\x8fO\xc6\x1f\xe0_l\xf9P\"	\x95)\xd8\n[:\x17\xb2\xca\xf2j\x10\xcd\xd5\x1a\x845\xa7\xe8\xd1\xd5\xfb\xfa:_\xd2\xc9\xb9\xb4\xb9\xb8\xda7\xb7\xd0\x96b\x9b\xdaf\x9c\"\xe79\x95\x93p\xe35\xfaY\xc0\xb8d\x7f\xb8\xfa[r'd&\xad\x06\x1e\x12o\x06\xcd\xf3\x91(\xfd-.\x1f|\xe0\xa8\x9f$7	\xe6\xcej\xc2\xa2\xc9q\xfd\xdem\x12\xee\xae\x91LU\x14\x86b\xfe`\xdf	\x1f\x0e\xb3{\x9b\xa0E\xa8a\x8f\xca\x92\xf4\xe8DM_\xba\x9c\xeb]\x87wL\x07+\x93G\xe5\x0e\xd6\xd8\xbf\xc5\xe7\xe2\xec.\xf5\xbaP\xa1L\n$\xf1\xee\xf0\xd4$\xa7\x11]4N\xe8\xf0x\"8\xbe\xdf2\x93\x99\x03\x7f\xa3\\\x7f\xae\xc9\xbb\x9bW\x17/\xaf\xa9\x1f\x1du\xb8\x89#\xb2\x99Kl\x8d\xc6PUt\xde\x94\xa2\xf4\x14=;\xb9\x0e\xdeU|\xda]\xed\xae\xfe3\x00PK\x07\x08\xdf\x8f1r\x1e	\x00\x00n2\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x10\x00	\x00schema/flows.sqlUT\x05\x00\x01\x80Cm8\xec[_o\xe38\x92\x7f\xcf\xa7(4\x1ap\x82S\x82\x9e\xbd\x9b\xbbC\x07\xbd\x80\xc7V\xba}\x93\xd89\xc7\x99\x99\xbd\xc5B\xa0\xa5\xb2\xcd\x8dDjH\xdan\xdfb\xbe\xfb\xa1\xf8G\x7f\x1c\xd9q\xd23\xf7\xb4~\xe9H*\x16\x8bU\xbf*V\x15\xd9\x83i\xdc\x9f\xc50\xeb\xffp\x1b\xc3\xe8\x06\xc6\x93\x19\xc4\xbf\x8c\x1ef\x0fP*\x99\xa2\xd6\xa8\xe1\xfc\x0c\x00\xc2s\xc23\x98\xf3\xa5F\xc5Yn\xe9\xc7\x8f\xb7\xb7p?\x1d\xdd\xf5\xa7\x7f\x81\x1f\xe3\xbfD\x96\x9c\x97\x9b\x7f\xb3\xff\n4\x15\x99\xfbT.y\xe6>\x19\\\xa2\xaa\x99\x0c\xbe\xc4\x83\x1f\xe1\xdc~\xff\xf3'\xf8p\x01\xc3\xf8\xa6\xffx;\x83\x0f\x11\\^\xda\x81\x9f>@\x81LhX0\x9e\xaf\x15\x82\x91\x90\xb2\xd2\xd0\x9f^D\xe0b!U\xc1\x0c\x97\xc2M(X\x81\x00\xb0a*]1u\xfe\xfd\x87\x8bz\xd20E\xafg\xe7\x98M\x86\x93\x8f\xf0/i\x91\xe5\\\xa0\x1d\x9e\xb39\xe6\x1a\xe0\xefZ\x8ay\xc7\xc0\x7f\xfc\xe6\x86n\xa5z\xca%\xcb\x80g(\x0c7\x1c50\x91\x814+TP\xa0a\x193\x0c\x981,]a\x06\xf3\x1d\xa0P<]\xa1\xd2v\xa2T!3\x98\x81\xe1\x05j\xc3\x8a\xf2\xf9d\x83\xc7\xe94\x1e\xcf\x92\xd9\xe8.~\x98\xf5\xef\xee\x9dJ\xd7e\xf6\x86\x91v\xe8\xe3x\xf4\xdf\x8f1\x9c\x93\xc1\"\xab\xe2\xc8)\xec\xe2\xec\xe2\xfa\xac\x7f;\x8b\xa7\x1e\x1f^\xbd\xa8\xa1?\x1c\xc2`r\xfbx7\xde\x03\x8dW\xd5\x11M]\x9f\x9d]^B*\x85\xc0\xd4\x80\xe6\x19\x9e\x1d\x01!K\x0d\xdf`\"dV\xe1\x90\xfe>\x15\x84^bO\xceE\x8dD\x98\xc67\xf14\x1e\x0f\xe2\x16\xd2\xeb\x01\x170\x19\xc30\xbe\x8dg1\x0c\xfa\x0f\x83\xfe0\xdeSX\x83\x964E\xcb\xca\xb96(^\\U\xc9\xb4\xfe\xa6eIe\x8e:\x10}\xff3|\xb8\xf0\xfeV	\xfa\x87j!\x02\x9a\xd7\xe9\xe2\x88I\x17\xb9\xdc\x86E\xd3\xdf$\xd6\xfe\xef\x94\x00\xa3\xe5Z\xa5\x0e\x1a-\x16G\x96\xd8F\x93\x1f\xd9ihb\x94\xa16\\\xd8(\xd2\x9a\xe5\x98\x0e\xdb\x86}i\x06\xef\x05\\\n\x1d\xa4\x87cvm\xd2\xd7\xe6\x0dQc\xff\xf7\xf6(\xf2m\x9cZN\xd2\xb6R\xd4\xa5T\x8b\x18\x0f\x98\xd1x\x18\xff\xb2\x17S\x08$:\xe9\x18\x98xy\x93'\xdc\x91\x11-!<>\x8c\xc6\x9fan\x14\"\x9cw\x8c\x8aB\xb0<e\xd6\xb6\xf8o\x14\xe2\x04\x1d\xbcJ\xa8.)\xf6\x04}\xa5F\xda\xa3}0\x13\xd2\xf0\xc5\x0e\xcc\n\xfd\xa22\xcc\x91\xe0!\x05\xe8\x15\xdb>%\xf6u\x04L[\xa2\xad\xe2\x065\xc8\x85\xfbJ\x01e\x8eM.\x02\xb7\x8eSX\xe2d\n\xd3\xf8\xfe\xb6?\x88\xe1\xe6q<\x98\x8d&cOoY'\n\x0b\xb9\xc1\xec\xfc\x02\xa6\xf1\xecq:~\x00\xa3\xf8\x92R\x86\xfe\x03\xbc\x7f\x7f\xf6C\xfcy4\xb6p\xbb\x8f\xa77\x93\xe9\x1d\x94\xcb\xc418\xef5D\xecEvGJ\xe6k\x9eg\x89\x9c\xff\x1dS\xe36\x13\xfa\xf5\xcc\xae\xc4^\x04=?]/\xaa?\xf1\xac\x17\xc1\xe4vx\xe5#U\xe3S\xc3\x19=M\xe3M\x83\xce\xa0`\xc2x\x12\xf7\xd0\xe4\x92s\xb4_ky\xe8\xf7\x10\xdf\xc6\x83Y\x87\xd4=\x96e\xaa\x17\xc1Jj\x13\xe24\xea+\xda\xbc/\"\xe8\xf97\xbd\xa8\xde\xd4\xae\xfcf\xded\x7f3\x9d\xdc\xb5\xa2a\xeb\xebh<\x8e\xa7\xf0_\x93\xd1\xb8\xe6\x02\x93\xc6\xc3\x95\xff\x8b\xe2\xee\xa7\x16\x9f\xc6\x97\x16\xcb\x9f\xbf\xc4\xd3\xb8M\xe9\xc1\x07\x9f\xac~\xdb\x08\xac\xc6\xfa\x1d\x8c~=\x8dj\x83\xea\xf7S\x95T\xa4\xf7V\xc8\xbe\xa2\x97\xafTc\x8b\xc1\xb7\xe8qO\x92c\x8al\x93\xb65\xd9\xe1\xe0\x95T\x17\xf6\xaf\x8b\x8f\x1f\x0d~5\x17\xd7\xf6\xc9\xb9\x16\x0d\xbd>\x8b\xc7\xc3\xeb\xb3\xf7\xef\xe1\xb6?\xfe\xfc\xd8\xff\x1cC\x99\x97K\xfdk~}6\x9cN\xeea6\x1d}\xfe\x1cO)(\xb5\x02\x92\xf7Y\xef?U\x14\xac\xe2k\x18\xd7I\xdd\xbf\xa1\x0c\xd3o\xc0a\xa8\x95\xecf2\x85\xb8?\xf8\x02\xd3\xc9\xcf\x10\xff\x12\x0f\x1eg1\xdcO'\x83x\xf88\x8d\xbb#\x85\x0b]\x14\x8e\xb8\xc8\xf0\xab\x8bG\xf4\xf8\xeb\x1a\xd5\x8e\"S\x89:\x82\xed\x8a\xa7+p1\x02\xb29\xe0\xd72g\\\xf4@\xaf(\xd2\xd1\x802\xa7*C.\x02?o\x10\xd4\x94\xb7\x13\x01\xe1!\x02\xbdNW!\x02\x12By\x8a\xfa\xd8nV\xb1I\xac_\x86H]so\xed_\x96\xe6\xe2:\xc8@\x13\xa0\xaa$ \xb4\xda\n\x83\xc4\xa1\xc0\x80\xbaZ\x9b^\x97\xa84R\x1e\xd2BKB\x83h\xd6\xa3B>\x1f\xe1\x05ll.-\xa2=\xb1\xad\x1b\xd5C.<\x80\xaa\xb9<|:\xe6y\xc2]\xb5\\\x0b\x85\xb03\x02\x17\xc0l*\x02\x8a\x89eC\xf5\xde\x8c\xf8\x95\xa6\x85\xcbK\xcdE\x8a\xbdc\xeb\xb3\x8cO\xd8\xb9=E\xc3\x00THzk\xaf\x05\xffu\x8d\x94\xc4i\xa3\x18\xa5\x84rqb\xc2\xd3\xa9\x0c'T{|\xe7^oU\x14$r;\x8a&d+.\x96\xf6\xdd\xe0n\xf8C\xc0\x81\xdc\n\xfb\xce\x1b\xc3\x97\xa4\xb5vi\xd76(\x88\x9d\xc35W\xc0\x96(\x8c\xbe\x82Y\xb5b\x87-2\x81\xd9\xcajN\xae\xedc\xc5\xfa\xea\xb5\xe5\xa2cT\xd5\xe5\xff\xfe\xaf\x9duy\xa5}2\xd4\x1c\x17\x92\xaa\xfe\x15\xc2O\xd3\x9b\n\xee\\\xd7\x88\xa7\xca\x9a\x86Tr%\xb4=&n\xb2d\xa3\x16	U\xb9\x0d\xff\x9bc.\xb7W02\xc4\x86\xb6[\x03R\xe4;\xd2`n\xa7\"n4\x1b0\x85\xc0\xe6\x1a\x85\x89\xc0\xc2lO\xb7\x14l\xb6\xd2\xd1\x16\xcc\xc6\x1b\x85\xc0\xcd\xd5\xd9p\xd2\xceX\xda\x80<?\xdb\xdb\xce\xbes{t\xa3\x93\x91\xe8t\x85\x05\xbbJe\xbe.\x84\xf6[\xaaa\xf3\x1c\x13\x8a\x12\xf0\xa9\xda\xb7P\xf7\xa0?\x1e\x82#\xad\xben\xd4\xa2g\xe7\xb9\x80\xd9\x97x\\M\xe9\x1d\xc5\xa7\xec]\xfe\xd2\xad\xc9=-\x1e\x8eb\xa4\xfe\xc8\x1b\xbb\xddbp[P<\x1e\xc2\xe8\xc6n?g\xef\xdf\x1fj:X\xa7\x19L\xc6\x0f\xb3i\x7f4\x9e5\xc3H[\xbc\xb6\\mv\xd6\xc9\xbe\x1d\x90/\xc6\x16\x0f\xb6\x97CL\xd0J+\xd4dJ\x96\x16Y\x1b\x8e[\x0dl\xb9T\xb8\xa4\xcfM\xf8{/\x0c\x1e@@S\x18\x8aA\xe7\x01>2\xba\x97\x97\x16@\x08\x97\x97\x96k\xcf\xf97\xed\x8b\xd4\xa8R\xc8\x9aa\x81zl\x16\xfe\x9d\xc852Q\xb8Ls\xa6\xf5y\xcf\xc821,\x7fB\xa5{\x170z\xa85F\x18<\x01\xe4\xe52a\xc6(>_\x1b\x0c\x99\xa21\ns\x9b^\x1e\x99\x8b\xf83c\x02\xbe}\xa6\xdd\x05q\x8b\x9d\xbb\xfe,\x9e\x8e\xfa\xb7\xa3\xff\x89\x87\xf0\xd3(\xfe\x19\x1a\xa2_\xbfD[\xc3\xcdo\xf3	fKl\x8es)\x8c]\x12i8Q\xb8P\xa8W\xa8\x9f\xa1\x9c\x92\xac:Si\xd4\x0d`\x14\x13:\xf7&\xb4\xf6\x18\xf7g@\xb6\xdf\xb2]ekz\xef\x824\x15cf\x85\x05Y\x9bB9\xc2B\xc9\xc2\xb24\x8a\xa5O\xce\xc8\xce\xa6f\xa5\xe4z\xb9\xb2\xf4\x16.>\xf6\x87\x0d\xcdR\x11\xa39\x9a-\xa2\xdb7\\\x81R\xef\x1a\x0d\x01}6ruv\xa4\x8b,\x98I\xc2\x10\xdb\xe7p\x91\xce\xfbY\xf5{a\x07\xf0\xed\x12+J\x12:\xcb]\xade\xa9\xa89\xc3\xf2@t\x8c\xc4w\xd1\x0e5[*V{\xdd\xb4Z\x01n\x92\x8e)\x1a$v\xf0\xa1)\xf6\xe9\xeaI|\x16pl\xa9M\xd4\xbc\xbei\xe4\xc3\xcd\x1b\x9bE\x96E\xa3\xdbZ\x87\xb1\x86\x8d\xa2\xb65\x1a\x8f\xa4\x94h_\x91\xad\x17\x8e\xa2\xa1\x85\x97\x9aD\xfb8;\x10\x80\x9f\xc1\xb1\x95\xb6>\x8f\xc5\xc1E3,\xcd*\x94\x12Z\xa6Oh(r\xae\xb1J\xa0BN\x1e\xdc\xd2\x93:\x17\xf1~K\xdc\x98\x8f\xde,M\xb1\xf4L*\xe7\x9a\xb3\xf4)\x97\xcb0\x91k'\xdb\xf1\x81\xa2`_y\xc1\x1ae\xc9|GM\x17+\x8bM\x91k\x01+!\x9c_\xb7R\xb3f\xde}x7t2&\x96\xf9s\x10\x87\x04\xed\xc3\xf5[x\x87\xa5\x9e\xca\xf6\x85\x8d[a\xbay\xa5\xa0/p\xd4(\xb2\x1386\x82\xf8\x92*o)\xc83\x8d\x929\xb0|)\x157\xab\xa2m\x89\xbapI\xd7s\x9e\x82T0\x9f\xab(D\xf0\x83X\xda\x8f\xc4\x1e[\xa0P\x97h;\xdb\xf9\xee5\x0b\xf4\xae\x9aJ\xb1\xac\xe2\xef\x9f\xbe\xff\xfe@\n\xfe\n\xc6N\xb0S\x19\x07\x05\xbaS2\x9f\xca\xc1R\xc9u\xd9\xaej\xf6P]%}\x95>\x89\x93\xdei\x83E\xe6ZM\xcc\xf8\x8a\xa8\xa8\x9a\x94L)\xb6#s\xfc\xe3\x1d\xa5\x0d\xef>\xba\xb3\xae\x08\xdeQ\xd6H\x8fK\x9e\xfd\xd6Zm5\xd1\xe1\x15\xcb-y\xe9\xc1c\xaf\xbf\xfe\xcd\x1f{\xf9}\xbb\xc2C3\x86\xfbWN\xb7\\\x80\xc6T\x8a\xac\xda\xf2}\xdc .\x05\x17\xbcX\x17\x11=\x03\xdb\xa0b\xcb:\x84\xd8\x00\xb1.:\xc3\x12\xcd\x81\xdc\xea\x99\x8e\xa8,\xe4\xa4r\x90\xe6\x8b\x9aNC&E\xcfP@N\x9f\xe8u\xf1\x1a\xf3\xb3%&\x05\x17\xc1\xb3_\xe5\xc64\x96m\x96o\x1e[\xb0\xaf\xf5\xd8\x80,[\x8dy\xf56P\xb3]I]\x95\xb6T\x17+\x0cT\xa1\xde\xd5%K+kUE\xa0K\xb9l\x0b6|{\xb1d\xb62\xb8z\xb9U\x9a\xbe\xbad\xde\xa8E\xe5T\xdf\x1dr\xd6\xb7Vt\x1d\xb5\xf1\xa9U\xddF-\xf6K\xbb\xee\x96O\xe7\xc4\xfb%\xdb\xa95P\xb3\x05\xf0\xcf\x02\xe8P\x81\xff\xaa\xea\xe7`\x15\xe3K\xb3\xaa\xdajH\xf2B\x81\xa3\xd7s\xa7T\xef,w\xf7\xb3\xc1\xfd\xc1\xe0\xb7\x17\xf1\xa0df\xd5l\xc46\xc7\xa5r-\xa8VbT\x085+)@\x96\xae\xa2CQ\x90\xf0\xd5\x08\x84\xa1\xf5\xf3\xbf\xa8$,\xa4\n\xfd_.`O\xd0\xab\xd7\x04\xb3j\xd9>\"\x1d\xcd\x1f\x14\xa6RemMt\x8aO+\xb5\xab\x83E\xbe\xd6\xab\xa0-\x83yN\xbcVr\x0b\xd6`\x8d\xdd\xc4\xf3t\x01\x8b\xdc\x84\xb2I\"\\!\xcb\xcd*\xb4\xfdR\x99\xe7\xc1 L\xe1\x15\xb1#\x0f)Qq\x99\x11\x17\xe6\xc5\x04m\x982\x1a\xb6+_%\xe6LS\xcf\xac\n\xa04\x15,\xb8\xe0z\x85\xd9\xd1\x02Q\xa7\xac\xaa\n\xe9\xef\xe6Y\xfe)\xd7\x00\xdeTIZ\xf1\x1a\xbf\xce,\xc5\xf1\xb7\x81=\xa1D\x8bPu\"\x7fJnQ\xd4\x0by!`\xdb\xdbC\x02M\xce\xc5SD\xba5h\xfbz\xb49\xe3\xbc\\XA\xac\xca\x8fVln\xee\xa0\xf5\x17\xe9,4\xea\xdfa\x90:\xbe\xa8\x94T\xfa$z\xf0\x9eJ\xf6\xac;\x07\xfe|\x98\xeem\xd5\xa5\x8a\xc3V=A\xc5\x1e\x80\xce\xa8\x9es\xef\xf5\x02w+P\x00\x9ce\xfeB\x8dh\xc5	\x85!A 	\xba\n;\x9d\xa5\xe8\xae	\x89>\xaa\x94\xdb8g\xaa\x13\x88\x02\xd5\xd2\xae\xccH\xfb\xc5f\xb4\xf6\xb0\xc6\xedB\xf6{\x9d\xfd\x13I\xa9p\xc3\xe5\xda\xa6\xae5#\xbf&\x9a\x92\xd2\xfb\xcbR\xc9\x0d'\xfc\xa1=\xab\x07\xb3b\xc6\x0e\xcf\xa5|\xa24\xd9\x0d(`!\xf3\\nC\xd8\xb5\xe3\x8f\xf9\x1e\x95h	\xcb9\xabo\xf9\x05w:	\xe6vhw\x97%t.:\xba#\xa1E\xf9\x1c\x98\xd5\x04\x87\xee\xaau\xb6\x1c\xac\x14\xd5\x15,R\x8b\x90\xfe\"\x02=\x90\x86\xb1\x0e\x82\xb6iW\x1b\xe1\x1d~\xa5\xe2	\xb3\x8f\xd6i\xd7%%\xbd\x8b\x05O\xdf\xf9\xe4\x98\xc6\x18\xde\xac<\x8a\x88\x0e\"\xea\x06\x9d\xab\xa3\xa2FMVA\x9e\xda\x18\xa0m\xc6\x17l\xb6\x03\xb969\xdf`#>\xe3\xd7\x92+\xda\xc5DV\x85|\xb6d\\\x1c3\x1e\xf9o\xc2\x84\x90\xa6\xb3\xb3v\x92\x01\x9d\xe8\x9d\x06\xf4+\xe9\xfa\xf4\xca\xebgd\x8dg\xfe\xdc\xeeB\xfd^P\x08\xa6p\xc27.\xa4uT\x02\xa1|\xf6\xcefq\x01^\x9f\x98\x1d+\x0f\x1a\xce\xda\xae\x10,\xff\xc6\xb1\x19\xf1\xec(\x00\xec\xfb\x8eT\xa2e\xcd?\xba\x00\xd8\x87O\x88\x8bT\x02\xf8\xce\x80\xaf\xe3\xc3\xb1l8\xc0h\x89\xd9\x19+m-\xd0i\x8b\xe7\x05]\x8b\xdb\xe1#\x9eg\xe2\x96\xadS\xd0R\xc9%\x05\xcf\xfa\xf6\x91\xde\x89\xd4\xf5\xc2\xabPl\xcfE\xab\xf4\x92\x8a\xb1\x15\x025 \xb5\xf1H$\xcbzS\xd2\x84\xdar\xc1\xcc\xf1\xb19\x97kI\xb6\xa3\xb0\xa0\x8d\x8a(CZd\xc9\xb99\xe6\xbdD\x1e\\\xd6\xf1<\x90\x854\x81\xee\x9c\xc6\xcb\xd4\xe14ow\xaaf\xf4\x0c\x9d\xb9\x10\xc4\n,\xa4\xda\x81l5\x08\xb8\xe8\xcaZ\xed\xfe\xd92\xb1}s\x18\xcc\xaf\xed\x02\xbe\xc0\xce\x8b\xba\x7fC\xb3\xa3]\xe72_\x92\xdb\x9a\xd5\xae\x8avjn\xb4Kf}\xa2\xeb\xd1`\xe7\x0d\xc8q\xbb9\xed\xbd\x84\x17\xaa\x1b5\x18	\x8b\x9c-\x1b\xd0\xf1#\xd7\"$\xd9\xbe\xb5R\x9f\xb2\x87\xfd\xfe\x18N\xfc\xa0o85yM\xae\x9be\xcd\x0c\xcf\xc6\xfe\xbf\xfe\xed9|\xec\x8d\xf7\xff\xbf\xec\xd8_\xc2\xd7&\xf1F\xe9>\xb0h\xd0\xe9uJ\x1d\x8b\x16]\x95\x95\x12\x855(%\x01)bF\xd1\xbe\xd1\x06\x13R\xe0\x1f\x98\xf1\xda\xe9\xdf\x90\xf6\xd6\xba\xa3\x13\x82\xf0;A&\x17\xc9\xb6\x8c\xbbn\xa8\x849\x86,\xc3\xb2\xf4NS\xfd\x0ezO\xc5\xd1\x9d9x\x80\xfb\xe1U\xa8\x9d<\x1c>\x1d\",\xb625\x8d\x86\xa4\xaa\x02\x88\x87{\xc1\x04\xa3T\xbaJ\x9d\xed\xfb\xcbT\x8a\x05_\x067\xac\xe9\x1ba\x9bf\xb0\xd6dy\xde\x8aW\x0b\xfb\x89\xa2>\x16\xa5\xd9E\xc0\xca2\xdf\x81\xa4$\xc7\xf9#\x8a\x0dWR\x14(\x8eFn+JR	\xde\xf6\xcc\x93\xf0\x1f<\xb2\xb3\xea|F\xed\xff\xefJE\xfd\xdd\x9f\xfe\xa3\xa6v\xa0\xd8\xb0|\x8d\xbfg\x86u\xd4\x80\x11T\xff;\xe4\xf2\xb2\xa9c{g\xa6\xbaG\x104\xd44W\x86\x0b\xb6\xce\x0d\x94\x8aox\x8eK\x0c}\x1dk\xe5\x84P\x89\xb6\xa4;\xd0\xb7\xdbr\xb3\xbaT2\xa7\x0b.[\xb9\xce3\xc8\xd1f\xd5\xae\xdf\x8d]w\x15B\xef\xcd_\xac	\x0d7\xcb\xc5\xb7\xb4\x94\xccCW\xab)Ho\xef\x12\xc14\xfei\xf2#\x95\x96\x0f\xf1t\x16\xc1\xe3\xfd\xb0?\x8b\xa3\xc6\xd5\xc5=h\xd8\xa9\x9a\x0c\x9f\xb5\xc9Z\xd7\x00B\x14\xb5~E\xf7\x99h\xd5,\xa8\x8fkHW\x98>\xd5uG\xa5u\x97v\x1c\xc3\xacc\x95\xd0m\"\x0f\xd80\xd9\xb3\xf8\xf1,\xdd ?\xe1oH'\x9c\x9a`4\x9eM\xda\xf3\xfb\xa9/\xe0\xa7\xfe\xedc\xfc\x00\xe7\xdf\xfd\xa7\xfd?\x18\x83\xc9\xf8\xe6v4\x985(\x86\x13\x18Of_F\xe3\xcf\xd7g\xff7\x00PK\x07\x08\x179\x05VR\x0e\x00\x00\xab6\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x10\x00	\x00schema/roles.sqlUT\x05\x00\x01\x80Cm8\xacT\xd1n\xe2:\x14|&_1\x0fHi\xa5\xd0\x0f(\xeaCJ\x0e4\xbai\xd2\x9b\x98\xde\xbe!\x17\x0c\x89\x1alj\x9b\xcb\"\xf1\xf1\xab\x18\n\xb4t\xd1v\xbbO\x96\x8133g\x06O\xa7\x03V\n\xa8\x85\xad\x94\xe45\xb4\xaa\x85\x81\x9a\xc2\x96\x02\xbd\xfb\xe86\xc0X\x0bn\xc5\x04\xcfk\xf8\xa6\xe4\xab\x97\xdd'\x1d3.\xc5\\\xa0\xd3YU\xb6\xec\xb8I\xff\xca\xdb\"\xae1\xe6\xd2\xb7\xa8\xd5\x0c\x95\x0c\xf0\xbc\xb4\xe0Z`\xa6\xb9l\xc0\xacr\x0cK#\xf4\x9eN-\x84\xe6Vis\xdd\x808\xaa\x91\x16|\"4\x9a\xc3\xb8	\xcb\x9f\x1b\x85\\N\xdc\xf5\xffJ\xac\x0c\xa6J\xbb\xdb\xebR\xe8J\x98\xa0\xf9\xfa\x80\xb1\xd2\x95\x15\x1a\xee\xd8\x82Lk\xb52\xe0\xdb\x0b\x9f	iM3\xf2\xb6\x9fY\xcb\xb1\x8f\x89\xda\xcaV\xb2^7`\x07\x0dFX[\xc9\xd9^\xf8\x16!\xc0\xaa\xac\xc6%\xf8bQ\xaf\xdf\x16\\\x95\xaa\x16\x98\xd6B\xd8+/\xca\xd0n{\x11\xf5\x920'\xaf\xe5\xfc\xe3#\xc9\xe7\x02V\xfc\xb0\xb8\xbe\xc1\xebRY1\xaa&B\xda\x8b\xf1Rk!\xedh\xfb\xbb\x8b\xcb\xcb\xaewK\x838\xf5Zq\x1fi\xc6@Oq\xc1\n\\\x14\x94P\x8f\xa1\x9fg\xf7X\xccF.	\xfcwG95y:\xfc\x1b\xf8\xc7\x86\xfa\x97`w\x94z\xadV/\xa7\x90\x11\xf2,\xa1\xf7\x96\xa7Y\x92\x0d\xe2\xb4\xeb\xb5(\x8d\x10\xf7\xbb\xdf\xa2u\xe6\x9f\xa5\xdd\xa5tJ\xeb\xb5\xe8\x89zCF\xf0\x07y\x982\x0c\x8bp@\xc8R\x14\xbd;\xba\x0f\xe1c\xb3\xc1\xb1\x99\x9b\x0d|\xb0\xec\xdd>\x01\xde	\xe9\x9e\xa0\xee\xb6\xc9R\x84I\x02\x16\xde&T \xfe\x1a\xc9/a\x03\xc4iA9\x0b0|\x88BF\x01\"J\x88\xd1\x9f\xd2\xed\xec<\xa5s\xde\x04\x1f\x96)\xe8\xdf!\xa5\xbd\xaf\xec\xb3'\xf0Z\xbb\x8a\xf8\xf4\xe1\x1duC\xf30j\xdeD8\xaff\x9a7}b\xae\x8e\xf4\x85	\xa3\x1c\x11\xf5\xc3a\xc2\xf0\x90\xc7\x8fqB\x83\xdf\xd0\xf41\x9e\x9dW{\xa9\x9fX\xffW\xb8\xcee\xf6Q\xc3'y|S\xc3I\x90\x87\x10\xcf\x844\xd6\xa2\xa9\x8e\x8a\xd7\xae\x9c\xb8\xdcv\x13\xe6Kc!\x95\x85\x16n\xc8\xa5e*\xf9r(_[\n\xdd\x04\x96\xd3c\xf6\x0f\x9d\xd9\xdd!\x8e\xf6\x1d\xe8\x9e\xff\xf1\x9f\xa6\xebQ\x1ay\xedv\xd7\xfb9\x00PK\x07\x08\x85\xf8G\xed\\\x02\x00\x00]\x06\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x10\x00	\x00schema/views.sqlUT\x05\x00\x01\x80Cm8\xc4V\xcfs\xea6\x10\xbe\xf3W\xec\x0d\xe8\x00\x97vzx\x99\x1e\xfc@\xa4j\xc1Nm9}\xe9\xc5\xa3\xd8\x0b\xd6\xc4X\xae\xa4\xc0\xbc\xff\xbe#\xf9\x07vL&9t\xe6q\x00k\xb5\xfb\xed\xa7\xd5~k\x96K`9\x82\xac\x8c\x90%/@\xc9\xa2x\xad4\xc8\x03\x98\x1c\xe1P\xc8\x8b\x86\x83Tn\xc5\x8fG\x85Gn\x10\xfe}E%P/ U\xc8\x0df\xf0\xfc}\xb2\\\xc2T\xe7\xfc\xf2\xd2\x18\x97:\xcd\xf1\x84\xb0\\\x9e\x05^\xf4\x14x\x99\x81\xc2\x83B\x9d\xbb\x88\xd6]\xa3:[\xb7f\xafq_M,\xa2M\x9b\xca\xb2\xc4\xd4\x12t\xbc\x90\xa79\xa4\x85\xc0\xd2@\xa5d\x8a\xfaj6X\xf2\xd2,\xe0\x92K\x8d\xc0\xb3L\xa1\xd6h\xf7[\xb0\xc7p\xab\x81+\x04^qe\xe0\xa0\xe4\xc9\xd95?!\xc8\x12\xbb\xa3K\x93\xa3\xd2\x93uH<F`\xef1\x12RoG\xff!\x1bx\xa4\xe4o\xa0[\xf0\x03\x06\xe4\x1b\x8dX\x04FV\x89\xe1\xc5\x0b*\x0d^4\x01\x00\x88\xc8\x8e\xac\x99{\xb4\x9f\x86*\xeaUC\xf2\xc6\x8e\xa8\xce\xbf\xdc\xb2\x9f\xd5\xe1\x96\xb9*\xf9	\xaf\x1bQ\xbc\x9f\xb9\x0b[\xf5*6\xff\xf2\xe5Y\x1cEi\xc0\x8b\xfa\x95\xbc\x86\xad\x83\xd8g\xb3\x9f\x06\x8e\x0e\xc6yl\xc3`\xdf[R\xdf'!\xfc\x11P\x1fxj\xc4\x19\x93Rf\xa8!\x18\xaeW\xf6;\x11\x19\xfcV\xc7\xae\xb4|Ui\xed\x9c\x88\xec-TW\x1b\x08z\x8bU\xf3T\xe3\x0c\xe0\xaf;\x0e\xea>\x0c\xe2\x07\xf8\xfa4.r\xcf\xe2\x8a\xdb[\xdb\xa2\xf6\x96\xae\x98w\xed}\xc7>\xfd+&@\xfd\x0d\xf9\xf6\xfeU'\xf5U&\x16:9\xabC\xe2@\x92\x17\xfcn\x0f\xd2\xef\x898\xa2\xfe=<\x1b\x85\x08\xb3\x96\x9b\x0d[@\xcd\xc3\x06\xce\xbb\xf4\x1f\xe5\xed]\xe4\x87\xd9z\xbe\xb0!\xd1z~\xd7)\x0b\xb3#jxFsA,k\x19\xa0:\x8b\xd4*\xfb\x92\x8b4wJ\xb1\xf6\xaeN\xad<\x9c^,\xe9\x85\x05\x1b\xea\xef\xb3\xa2i\x92%5\x8d\xdb\xb2\xa9\xdb\xa7\xa9X\xd7\xb3M3\xbc\xb9=\xd7\xe2n0\\=+\xae\xf5;\xae6=\xaa\xb1\xab\xed]\xbd\xaa\xa42\xff\x93\xb664b\xd4_\xb31m\xdb\x01C\x85:\xfa\xef#\x8c\x8f3\x82\xa8\x8f\xf5C\xd4\xebE\xa3#\xf6\xb0;\xdb\xe7\x85\xdd\x9f\x11\xcd\xc9\xbby30\x8c\x06N\x86\xda\x88\x92\xdb\xb6\xff\x14\xef\x16\xad\xa16L\xd1\x19\x87\xcc\x87\x0c\xde\x9bI\x83\x0e\x1e\xd5\xa7\x19\xe2\xb7R\x0d7\xae]\xf9\x99!5\x90V;\xa6\xea\xe6J\xec\x1e\xaa\xc4B\xb5\xc3c\xa8\xc4\x9b\xc3\xaa\x11V\xab\x1a\xb0\xe1\xbdQRpm\xda\xb7{7\x0e\xec\x9b\xbc\x9d$\x06\x8bB;\xd7\xe6\xcf\x03\\r\xb4\xefX\x10\x06\x84\x06\x17\xd9\x9e\x8cy_w\xe4\xcd\xfc\xb3`I\x93\x015\xcc\\\x83\xdb\x1a\xd9_\x803Wi\xce\xd5\xec\xd7\x9f\xe7.\xca\x8fw;x\x08\xe9\xde\x0b\x9f\xe0O\xf2TK\xaa\x8d\xcf\xc0\x88\x13j\xc3O\xd5\xd5{C\xb6^\xbcc\xb0\x8e\xc3\x90\xf8,atO\"\xe6\xed\x1f&\xf3\xbb	\xf5#\x122\xa0>\x0bFT,\x8b9<z\xbb\x98D0\x9b\xf6F\xf1t\xbe\x80\xd9tP\xde\xe9\xdcjb\x1d\xf8\xdb\x1d]\xb36x\x13\x80\x1f\xb0\xdf\xa9\x7f\x7f7\xf9o\x00PK\x07\x08+\xb2\xe7\x11D\x03\x00\x00\x95	\x00\x00PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\x00!(\xd9\x1d\xbf\xa5[z\x00\x00\x0f\xbc\x03\x00\x07\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xb4\x81\x00\x00\x00\x00CREDITSUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\x00!(\xdf\x8f1r\x1e	\x00\x00n2\x00\x00\x0c\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x99z\x00\x00openapi.jsonUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\x00!(\x179\x05VR\x0e\x00\x00\xab6\x00\x00\x10\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xfa\x83\x00\x00schema/flows.sqlUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\x00!(\x85\xf8G\xed\\\x02\x00\x00]\x06\x00\x00\x10\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x93\x92\x00\x00schema/roles.sqlUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\x00!(+\xb2\xe7\x11D\x03\x00\x00\x95	\x00\x00\x10\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x816\x95\x00\x00schema/views.sqlUT\x05\x00\x01\x80Cm8PK\x05\x06\x00\x00\x00\x00\x05\x00\x05\x00V\x01\x00\x00\xc1\x98\x00\x00\x00\x00"
 	fs.Register(data)
 }
@@ -0,0 +1,175 @@
+// Package statsd sends the connections of the flows and the summaries of the
+// scans of the agent to statsd or DogStatsD over UDP.
+package statsd
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/xerrors"
+
+	"github.com/yuuki/shawk/probe"
+)
+
+// maxPacketSize is the maximum size of a datagram of the metrics, which fits
+// in the MTU of Ethernet with the headers of IP and UDP.
+const maxPacketSize = 1432
+
+// unsafeChars are the characters not in the components of the names of the
+// plain statsd.
+var unsafeChars = regexp.MustCompile(`[^A-Za-z0-9_-]`)
+
+// Client sends the metrics to statsd. The labels of the metrics are the tags
+// of DogStatsD, or the components of the names of the plain statsd, which
+// has no tags.
+type Client struct {
+	prefix    string
+	dogstatsd bool
+	// tags are the tags added to all the metrics of DogStatsD.
+	tags []string
+
+	mu   sync.Mutex
+	conn net.Conn
+	buf  bytes.Buffer
+}
+
+// NewClient returns the Client sending the metrics named with the prefix
+// such as 'shawk.' to the server of addr such as '127.0.0.1:8125'. tags are
+// the tags such as 'env:prod' added to the metrics of DogStatsD.
+func NewClient(addr, prefix string, dogstatsd bool, tags []string) (*Client, error) {
+	conn, err := net.Dial("udp", addr)
+	if err != nil {
+		return nil, xerrors.Errorf("could not connect to statsd %s: %w", addr, err)
+	}
+	return &Client{prefix: prefix, dogstatsd: dogstatsd, tags: tags, conn: conn}, nil
+}
+
+// tag is a label of a metric.
+type tag struct{ key, value string }
+
+// add appends the metric of the value of the type such as 'g' or 'ms' to the
+// buffer, sending the buffer before if the metric doesn't fit in the packet.
+func (c *Client) add(name string, value int64, typ string, tags ...tag) error {
+	var line strings.Builder
+	line.WriteString(c.prefix)
+	line.WriteString(name)
+	if !c.dogstatsd {
+		for _, t := range tags {
+			line.WriteByte('.')
+			line.WriteString(nameComponent(t.value))
+		}
+	}
+	fmt.Fprintf(&line, ":%d|%s", value, typ)
+	if c.dogstatsd && len(tags)+len(c.tags) > 0 {
+		line.WriteString("|#")
+		for i, t := range tags {
+			if i > 0 {
+				line.WriteByte(',')
+			}
+			line.WriteString(t.key + ":" + tagValue(t.value))
+		}
+		for i, t := range c.tags {
+			if i > 0 || len(tags) > 0 {
+				line.WriteByte(',')
+			}
+			line.WriteString(t)
+		}
+	}
+	if c.buf.Len() > 0 && c.buf.Len()+1+line.Len() > maxPacketSize {
+		if err := c.flush(); err != nil {
+			return err
+		}
+	}
+	if c.buf.Len() > 0 {
+		c.buf.WriteByte('\n')
+	}
+	c.buf.WriteString(line.String())
+	return nil
+}
+
+// flush sends the buffer as a packet.
+func (c *Client) flush() error {
+	if c.buf.Len() == 0 {
+		return nil
+	}
+	defer c.buf.Reset()
+	if _, err := c.conn.Write(c.buf.Bytes()); err != nil {
+		return xerrors.Errorf("could not send the metrics to statsd %s: %w", c.conn.RemoteAddr(), err)
+	}
+	return nil
+}
+
+// SendScan sends the connections of each flow of a scan as the gauge
+// flow.connections, and the numbers of the flows and the connections of the
+// scan as the gauges scan.flows and scan.connections. elapsed is sent as the
+// timing scan.duration unless it is zero.
+func (c *Client) SendScan(flows []*probe.HostFlow, elapsed time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var conns int64
+	for _, f := range flows {
+		conns += f.Connections
+		process := ""
+		if f.Process != nil {
+			process = f.Process.Name
+		}
+		err := c.add("flow.connections", f.Connections, "g",
+			tag{"direction", f.Direction.String()},
+			tag{"local", net.JoinHostPort(f.Local.Addr, f.Local.PortString())},
+			tag{"peer", net.JoinHostPort(f.Peer.Addr, f.Peer.PortString())},
+			tag{"process", process},
+		)
+		if err != nil {
+			return err
+		}
+	}
+	if err := c.add("scan.flows", int64(len(flows)), "g"); err != nil {
+		return err
+	}
+	if err := c.add("scan.connections", conns, "g"); err != nil {
+		return err
+	}
+	if elapsed > 0 {
+		if err := c.add("scan.duration", elapsed.Milliseconds(), "ms"); err != nil {
+			return err
+		}
+	}
+	return c.flush()
+}
+
+// SendScanError counts a failed scan as the counter scan.errors.
+func (c *Client) SendScanError() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.add("scan.errors", 1, "c"); err != nil {
+		return err
+	}
+	return c.flush()
+}
+
+// Close closes the connection to statsd.
+func (c *Client) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.conn.Close()
+}
+
+// nameComponent returns the value as a component of the name of the plain
+// statsd, such as '10_0_0_1_5432' of '10.0.0.1:5432', or 'none' if empty.
+func nameComponent(value string) string {
+	if value == "" {
+		return "none"
+	}
+	return unsafeChars.ReplaceAllString(value, "_")
+}
+
+// tagValue returns the value as the value of a tag of DogStatsD, which
+// must not have the separators of the tags and the fields.
+func tagValue(value string) string {
+	return strings.NewReplacer(",", "_", "|", "_", "#", "_", "\n", "_").Replace(value)
+}
@@ -0,0 +1,122 @@
+package statsd
+
+import (
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/yuuki/shawk/probe"
+)
+
+var testFlows = []*probe.HostFlow{
+	{
+		Direction:   probe.FlowActive,
+		Local:       &probe.AddrPort{Addr: "10.0.0.1", Aggregated: true},
+		Peer:        &probe.AddrPort{Addr: "10.0.0.3", Port: 5432},
+		Connections: 10,
+		Process:     &probe.Process{Name: "app", Pgid: 200},
+	},
+	{
+		Direction:   probe.FlowPassive,
+		Local:       &probe.AddrPort{Addr: "10.0.0.1", Port: 80},
+		Peer:        &probe.AddrPort{Addr: "10.0.0.20", Aggregated: true},
+		Connections: 2,
+	},
+}
+
+// listen returns the server of statsd and a function receiving a packet.
+func listen(t *testing.T) (string, func() string) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn.LocalAddr().String(), func() string {
+		buf := make([]byte, 65536)
+		conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+		n, _, err := conn.ReadFrom(buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(buf[:n])
+	}
+}
+
+func TestClient_SendScan(t *testing.T) {
+	tests := []struct {
+		desc      string
+		dogstatsd bool
+		tags      []string
+		want      []string
+	}{
+		{
+			desc: "statsd",
+			want: []string{
+				"shawk.flow.connections.active.10_0_0_1_many.10_0_0_3_5432.app:10|g",
+				"shawk.flow.connections.passive.10_0_0_1_80.10_0_0_20_many.none:2|g",
+				"shawk.scan.flows:2|g",
+				"shawk.scan.connections:12|g",
+				"shawk.scan.duration:150|ms",
+			},
+		},
+		{
+			desc:      "dogstatsd",
+			dogstatsd: true,
+			tags:      []string{"env:prod"},
+			want: []string{
+				"shawk.flow.connections:10|g|#direction:active,local:10.0.0.1:many,peer:10.0.0.3:5432,process:app,env:prod",
+				"shawk.flow.connections:2|g|#direction:passive,local:10.0.0.1:80,peer:10.0.0.20:many,process:,env:prod",
+				"shawk.scan.flows:2|g|#env:prod",
+				"shawk.scan.connections:12|g|#env:prod",
+				"shawk.scan.duration:150|ms|#env:prod",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			addr, receive := listen(t)
+			c, err := NewClient(addr, "shawk.", tt.dogstatsd, tt.tags)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer c.Close()
+			if err := c.SendScan(testFlows, 150*time.Millisecond); err != nil {
+				t.Fatal(err)
+			}
+			got := strings.Split(receive(), "\n")
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("SendScan() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestClient_SendScan_packets(t *testing.T) {
+	addr, receive := listen(t)
+	c, err := NewClient(addr, "shawk.", false, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+	var flows []*probe.HostFlow
+	for i := 0; i < 100; i++ {
+		flows = append(flows, testFlows[0])
+	}
+	if err := c.SendScan(flows, 0); err != nil {
+		t.Fatal(err)
+	}
+	var lines int
+	for lines < len(flows)+2 {
+		packet := receive()
+		if len(packet) > maxPacketSize {
+			t.Errorf("the packet should be at most %d bytes, but %d", maxPacketSize, len(packet))
+		}
+		lines += len(strings.Split(packet, "\n"))
+	}
+	if lines != len(flows)+2 {
+		t.Errorf("the packets should have %d metrics, but %d", len(flows)+2, lines)
+	}
+}
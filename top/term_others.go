@@ -0,0 +1,19 @@
+// +build !linux
+
+package top
+
+import (
+	"os"
+
+	"golang.org/x/xerrors"
+)
+
+var resizeSignals []os.Signal
+
+func makeCbreak(fd int) (func(), error) {
+	return nil, xerrors.New("not supported on this platform")
+}
+
+func windowSize(fd int) (int, int, error) {
+	return 0, 0, xerrors.New("not supported on this platform")
+}
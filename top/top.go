@@ -0,0 +1,129 @@
+// Package top provides a top-like terminal view of the flows of the host.
+package top
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"os"
+	"os/signal"
+	"time"
+
+	"golang.org/x/xerrors"
+
+	"github.com/yuuki/shawk/probe"
+)
+
+// Option is the option of Run.
+type Option struct {
+	// Interval is the interval to probe the flows.
+	Interval time.Duration
+	// Probe returns the flows of the host.
+	Probe func() (probe.HostFlows, error)
+}
+
+type probeResult struct {
+	flows probe.HostFlows
+	err   error
+}
+
+// Run shows the flows on the terminal of in and out until ctx is done or
+// 'q' is pressed.
+func Run(ctx context.Context, in *os.File, out io.Writer, opt *Option) error {
+	fd := int(in.Fd())
+	restore, err := makeCbreak(fd)
+	if err != nil {
+		return xerrors.Errorf("shawk top requires a terminal: %w", err)
+	}
+	defer restore()
+
+	w := bufio.NewWriter(out)
+	// Switch to the alternate screen and hide the cursor.
+	io.WriteString(w, "\x1b[?1049h\x1b[?25l")
+	w.Flush()
+	defer func() {
+		io.WriteString(w, "\x1b[?25h\x1b[?1049l")
+		w.Flush()
+	}()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	keys := make(chan []Key)
+	go func() {
+		buf := make([]byte, 64)
+		for {
+			n, err := in.Read(buf)
+			if err != nil {
+				return
+			}
+			select {
+			case keys <- parseKeys(buf[:n]):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	refresh := make(chan struct{}, 1)
+	results := make(chan probeResult)
+	go func() {
+		ticker := time.NewTicker(opt.Interval)
+		defer ticker.Stop()
+		for {
+			flows, err := opt.Probe()
+			select {
+			case results <- probeResult{flows, err}:
+			case <-ctx.Done():
+				return
+			}
+			select {
+			case <-ticker.C:
+			case <-refresh:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	resize := make(chan os.Signal, 1)
+	signal.Notify(resize, resizeSignals...)
+	defer signal.Stop(resize)
+
+	var view View
+	draw := func() {
+		width, height, err := windowSize(fd)
+		if err != nil || width == 0 || height == 0 {
+			width, height = 80, 24
+		}
+		view.Render(w, width, height)
+		w.Flush()
+	}
+	draw()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case r := <-results:
+			if r.err != nil {
+				view.SetError(r.err)
+			} else {
+				view.SetFlows(r.flows, time.Now())
+			}
+		case ks := <-keys:
+			for _, k := range ks {
+				switch view.HandleKey(k) {
+				case ActionQuit:
+					return nil
+				case ActionRefresh:
+					select {
+					case refresh <- struct{}{}:
+					default:
+					}
+				}
+			}
+		case <-resize:
+		}
+		draw()
+	}
+}
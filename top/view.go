@@ -0,0 +1,300 @@
+package top
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/yuuki/shawk/probe"
+)
+
+// Key is a key pressed on the terminal. The special keys are negative.
+type Key rune
+
+const (
+	// KeyUp is the up arrow.
+	KeyUp Key = -1 - iota
+	// KeyDown is the down arrow.
+	KeyDown
+
+	// KeyEnter is the return key.
+	KeyEnter Key = '\n'
+	// KeyEsc is the escape key.
+	KeyEsc Key = 0x1b
+	// KeyBackspace is the backspace key.
+	KeyBackspace Key = 0x7f
+)
+
+// parseKeys parses the bytes read from the terminal in cbreak mode.
+func parseKeys(b []byte) []Key {
+	var keys []Key
+	for len(b) > 0 {
+		if b[0] == 0x1b && len(b) >= 3 && b[1] == '[' {
+			switch b[2] {
+			case 'A':
+				keys = append(keys, KeyUp)
+			case 'B':
+				keys = append(keys, KeyDown)
+			}
+			// Ignore the other escape sequences.
+			b = b[3:]
+			continue
+		}
+		r, n := utf8.DecodeRune(b)
+		b = b[n:]
+		switch r {
+		case '\r':
+			r = rune(KeyEnter)
+		case '\b':
+			r = rune(KeyBackspace)
+		}
+		keys = append(keys, Key(r))
+	}
+	return keys
+}
+
+// Action is what the caller does after a key.
+type Action int
+
+const (
+	// ActionNone only redraws the view.
+	ActionNone Action = iota
+	// ActionRefresh probes the flows again.
+	ActionRefresh
+	// ActionQuit exits.
+	ActionQuit
+)
+
+const helpLine = "q:quit  /:filter  d:direction  enter:drill into peer  esc:back  r:refresh"
+
+// View is the state of the flow view, which is independent of the terminal.
+type View struct {
+	flows   []*probe.HostFlow
+	updated time.Time
+	err     error
+
+	// direction filters the flows by the direction if not zero.
+	direction probe.FlowDirection
+	// query filters the flows including it.
+	query string
+	// input is the query being typed, or nil.
+	input []rune
+	// peer is the address of the peer drilled into, or empty.
+	peer   string
+	cursor int
+}
+
+// SetFlows replaces the flows, sorted by the connections.
+func (v *View) SetFlows(flows probe.HostFlows, now time.Time) {
+	v.flows = make([]*probe.HostFlow, 0, len(flows))
+	for _, f := range flows {
+		v.flows = append(v.flows, f)
+	}
+	sort.Slice(v.flows, func(i, j int) bool {
+		a, b := v.flows[i], v.flows[j]
+		if a.Connections != b.Connections {
+			return a.Connections > b.Connections
+		}
+		return a.String() < b.String()
+	})
+	v.updated, v.err = now, nil
+	v.clampCursor()
+}
+
+// SetError shows the error of the last probe, keeping the flows.
+func (v *View) SetError(err error) {
+	v.err = err
+}
+
+// visible returns the flows passing the filters.
+func (v *View) visible() []*probe.HostFlow {
+	flows := make([]*probe.HostFlow, 0, len(v.flows))
+	for _, f := range v.flows {
+		if v.direction != 0 && f.Direction != v.direction {
+			continue
+		}
+		if v.peer != "" && f.Peer.Addr != v.peer {
+			continue
+		}
+		if v.query != "" && !strings.Contains(strings.ToLower(describe(f)), strings.ToLower(v.query)) {
+			continue
+		}
+		flows = append(flows, f)
+	}
+	return flows
+}
+
+func (v *View) clampCursor() {
+	n := len(v.visible())
+	if v.cursor >= n {
+		v.cursor = n - 1
+	}
+	if v.cursor < 0 {
+		v.cursor = 0
+	}
+}
+
+// HandleKey updates the view by the key.
+func (v *View) HandleKey(k Key) Action {
+	if v.input != nil {
+		switch k {
+		case KeyEnter:
+			v.query, v.input = string(v.input), nil
+			v.cursor = 0
+		case KeyEsc:
+			v.input = nil
+		case KeyBackspace:
+			if len(v.input) > 0 {
+				v.input = v.input[:len(v.input)-1]
+			}
+		default:
+			if k >= ' ' {
+				v.input = append(v.input, rune(k))
+			}
+		}
+		return ActionNone
+	}
+
+	switch k {
+	case 'q', 'Q':
+		return ActionQuit
+	case 'r':
+		return ActionRefresh
+	case '/':
+		v.input = []rune(v.query)
+	case 'd':
+		switch v.direction {
+		case 0:
+			v.direction = probe.FlowActive
+		case probe.FlowActive:
+			v.direction = probe.FlowPassive
+		default:
+			v.direction = 0
+		}
+		v.cursor = 0
+	case KeyUp, 'k':
+		v.cursor--
+	case KeyDown, 'j':
+		v.cursor++
+	case KeyEnter:
+		if flows := v.visible(); len(flows) > 0 && v.peer == "" {
+			v.peer = flows[v.cursor].Peer.Addr
+			v.cursor = 0
+		}
+	case KeyEsc, KeyBackspace:
+		switch {
+		case v.peer != "":
+			v.peer = ""
+		case v.query != "":
+			v.query = ""
+		}
+		v.cursor = 0
+	}
+	v.clampCursor()
+	return ActionNone
+}
+
+// describe returns the row of the flow without the connections.
+func describe(f *probe.HostFlow) string {
+	dir := "-->"
+	if f.Direction == probe.FlowPassive {
+		dir = "<--"
+	}
+	var proc string
+	if f.Process != nil {
+		proc = fmt.Sprintf("%s (pgid=%d)", f.Process.Name, f.Process.Pgid)
+	}
+	return fmt.Sprintf("%-7s %-3s %-28s %-28s %s", f.Direction, dir, f.Local, f.Peer, proc)
+}
+
+// Render draws the view in width x height characters.
+func (v *View) Render(w io.Writer, width, height int) {
+	lines := make([]string, 0, height)
+
+	status := "shawk top - probing"
+	if !v.updated.IsZero() {
+		status = fmt.Sprintf("shawk top - %s - %d flows", v.updated.Format("15:04:05"), len(v.flows))
+	}
+	if v.direction != 0 {
+		status += " - direction: " + v.direction.String()
+	}
+	if v.query != "" {
+		status += fmt.Sprintf(" - filter: %q", v.query)
+	}
+	lines = append(lines, status)
+	switch {
+	case v.input != nil:
+		lines = append(lines, "/"+string(v.input))
+	case v.err != nil:
+		lines = append(lines, "error: "+v.err.Error())
+	default:
+		lines = append(lines, helpLine)
+	}
+	if v.peer != "" {
+		lines = append(lines, "peer: "+v.peerDetail())
+	}
+	lines = append(lines, "")
+	lines = append(lines, fmt.Sprintf("%6s %-7s %-3s %-28s %-28s %s", "CONNS", "DIR", "", "LOCAL", "PEER", "PROCESS"))
+
+	flows := v.visible()
+	// Scroll so that the cursor stays on the screen.
+	rows := height - len(lines)
+	offset := 0
+	if rows > 0 && v.cursor >= rows {
+		offset = v.cursor - rows + 1
+	}
+	selected := -1
+	for i := offset; i < len(flows) && len(lines) < height; i++ {
+		row := fmt.Sprintf("%6d %s", flows[i].Connections, describe(flows[i]))
+		if i == v.cursor {
+			// Highlight the selected row in reverse video.
+			selected = len(lines)
+			row = "\x1b[7m" + truncate(row, width) + "\x1b[0m"
+		}
+		lines = append(lines, row)
+	}
+
+	io.WriteString(w, "\x1b[H\x1b[2J")
+	for i, line := range lines {
+		if i != selected {
+			line = truncate(line, width)
+		}
+		io.WriteString(w, line)
+		if i < len(lines)-1 {
+			io.WriteString(w, "\r\n")
+		}
+	}
+}
+
+// peerDetail returns the name and the labels of the drilled peer.
+func (v *View) peerDetail() string {
+	for _, f := range v.flows {
+		if f.Peer.Addr != v.peer {
+			continue
+		}
+		detail := v.peer
+		if f.Peer.Name != "" && f.Peer.Name != v.peer {
+			detail += " (" + f.Peer.Name + ")"
+		}
+		keys := make([]string, 0, len(f.Peer.Labels))
+		for k := range f.Peer.Labels {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			detail += fmt.Sprintf(" %s=%s", k, f.Peer.Labels[k])
+		}
+		return detail
+	}
+	return v.peer
+}
+
+func truncate(s string, width int) string {
+	if width <= 0 || utf8.RuneCountInString(s) <= width {
+		return s
+	}
+	return string([]rune(s)[:width])
+}
@@ -0,0 +1,146 @@
+package top
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/yuuki/shawk/probe"
+)
+
+func TestParseKeys(t *testing.T) {
+	got := parseKeys([]byte("q\x1b[A\x1b[B\x1b[C\r\x1b\x7fé"))
+	want := []Key{'q', KeyUp, KeyDown, KeyEnter, KeyEsc, KeyBackspace, 'é'}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("parseKeys() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func newTestView() *View {
+	flows := probe.HostFlows{}
+	for _, f := range []*probe.HostFlow{
+		{
+			Direction:   probe.FlowPassive,
+			Local:       &probe.AddrPort{Addr: "10.0.0.1", Port: 80},
+			Peer:        &probe.AddrPort{Addr: "10.0.0.2", Aggregated: true},
+			Connections: 2,
+			Process:     &probe.Process{Name: "nginx", Pgid: 100},
+		},
+		{
+			Direction:   probe.FlowActive,
+			Local:       &probe.AddrPort{Addr: "10.0.0.1", Aggregated: true},
+			Peer:        &probe.AddrPort{Addr: "10.0.0.3", Port: 5432, Labels: map[string]string{"k8s.pod": "db-0"}},
+			Connections: 10,
+			Process:     &probe.Process{Name: "app", Pgid: 200},
+		},
+		{
+			Direction:   probe.FlowActive,
+			Local:       &probe.AddrPort{Addr: "10.0.0.1", Aggregated: true},
+			Peer:        &probe.AddrPort{Addr: "10.0.0.3", Port: 6379},
+			Connections: 5,
+			Process:     &probe.Process{Name: "app", Pgid: 200},
+		},
+	} {
+		flows.Merge(f)
+	}
+	v := &View{}
+	v.SetFlows(flows, time.Date(2020, 12, 20, 12, 0, 0, 0, time.UTC))
+	return v
+}
+
+// visiblePeers returns the peers of the visible flows in order.
+func visiblePeers(v *View) []string {
+	var peers []string
+	for _, f := range v.visible() {
+		peers = append(peers, f.Peer.String())
+	}
+	return peers
+}
+
+func TestView_HandleKey(t *testing.T) {
+	tests := []struct {
+		desc       string
+		keys       string
+		want       []string
+		wantCursor int
+	}{
+		{desc: "sorted by connections", want: []string{"10.0.0.3:5432", "10.0.0.3:6379", "10.0.0.2:many"}},
+		{desc: "direction", keys: "dd", want: []string{"10.0.0.2:many"}},
+		{desc: "all directions again", keys: "ddd", want: []string{"10.0.0.3:5432", "10.0.0.3:6379", "10.0.0.2:many"}},
+		{desc: "filter", keys: "/NGINX\n", want: []string{"10.0.0.2:many"}},
+		{desc: "cancel filter", keys: "/nginx\x1b", want: []string{"10.0.0.3:5432", "10.0.0.3:6379", "10.0.0.2:many"}},
+		{desc: "clear filter", keys: "/nginx\n\x1b", want: []string{"10.0.0.3:5432", "10.0.0.3:6379", "10.0.0.2:many"}},
+		{desc: "drill into peer", keys: "\n", want: []string{"10.0.0.3:5432", "10.0.0.3:6379"}},
+		{desc: "drill into selected peer", keys: "jj\n", want: []string{"10.0.0.2:many"}},
+		{desc: "leave peer", keys: "jj\n\x1b", want: []string{"10.0.0.3:5432", "10.0.0.3:6379", "10.0.0.2:many"}},
+		{desc: "cursor stays in flows", keys: "jjjjjk", want: []string{"10.0.0.3:5432", "10.0.0.3:6379", "10.0.0.2:many"}, wantCursor: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			v := newTestView()
+			for _, k := range parseKeys([]byte(tt.keys)) {
+				if a := v.HandleKey(k); a != ActionNone {
+					t.Fatalf("HandleKey(%q) = %v, want ActionNone", k, a)
+				}
+			}
+			if diff := cmp.Diff(tt.want, visiblePeers(v)); diff != "" {
+				t.Errorf("visible flows mismatch (-want +got):\n%s", diff)
+			}
+			if v.cursor != tt.wantCursor {
+				t.Errorf("cursor = %d, want %d", v.cursor, tt.wantCursor)
+			}
+		})
+	}
+}
+
+func TestView_HandleKey_actions(t *testing.T) {
+	v := newTestView()
+	if a := v.HandleKey('r'); a != ActionRefresh {
+		t.Errorf("HandleKey('r') = %v, want ActionRefresh", a)
+	}
+	if a := v.HandleKey('q'); a != ActionQuit {
+		t.Errorf("HandleKey('q') = %v, want ActionQuit", a)
+	}
+	// 'q' is a character of the query while typing it.
+	v.HandleKey('/')
+	if a := v.HandleKey('q'); a != ActionNone {
+		t.Errorf("HandleKey('q') while typing = %v, want ActionNone", a)
+	}
+}
+
+func TestView_Render(t *testing.T) {
+	v := newTestView()
+	v.HandleKey('\n')
+
+	var b bytes.Buffer
+	v.Render(&b, 120, 8)
+	lines := strings.Split(strings.TrimPrefix(b.String(), "\x1b[H\x1b[2J"), "\r\n")
+
+	if len(lines) != 7 {
+		t.Fatalf("Render() should draw 7 lines, but %d: %q", len(lines), lines)
+	}
+	if want := "peer: 10.0.0.3 k8s.pod=db-0"; lines[2] != want {
+		t.Errorf("peer line = %q, want %q", lines[2], want)
+	}
+	if !strings.HasPrefix(lines[5], "\x1b[7m    10 active") {
+		t.Errorf("the selected row should be highlighted: %q", lines[5])
+	}
+	if !strings.Contains(lines[6], "10.0.0.3:6379") || !strings.Contains(lines[6], "app (pgid=200)") {
+		t.Errorf("unexpected row: %q", lines[6])
+	}
+
+	// The rows beyond the height are not drawn.
+	b.Reset()
+	v.Render(&b, 40, 6)
+	if n := strings.Count(b.String(), "\r\n") + 1; n != 6 {
+		t.Errorf("Render() should draw 6 lines, but %d", n)
+	}
+	for _, line := range strings.Split(b.String(), "\r\n")[1:] {
+		if len([]rune(strings.TrimSuffix(strings.TrimPrefix(line, "\x1b[7m"), "\x1b[0m"))) > 40 {
+			t.Errorf("the line should be truncated to the width: %q", line)
+		}
+	}
+}
@@ -0,0 +1,179 @@
+package version
+
+import (
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/xerrors"
+)
+
+// latestTimeout is the timeout of fetching the latest version.
+const latestTimeout = 5 * time.Second
+
+// maxLatestBytes is the maximum size of the response of the latest version.
+const maxLatestBytes = 1 << 20
+
+// Compare compares the versions such as '0.7.1' and 'v0.8.0-rc1' as the
+// semantic versions, and returns -1, 0 or +1 as a is older than, equal to or
+// newer than b. The versions not semantic are older than the semantic ones,
+// and are compared as strings.
+func Compare(a, b string) int {
+	va, oka := parseSemver(a)
+	vb, okb := parseSemver(b)
+	switch {
+	case oka && !okb:
+		return 1
+	case !oka && okb:
+		return -1
+	case !oka && !okb:
+		return strings.Compare(a, b)
+	}
+	for i := range va.nums {
+		switch {
+		case va.nums[i] < vb.nums[i]:
+			return -1
+		case va.nums[i] > vb.nums[i]:
+			return 1
+		}
+	}
+	// A pre-release precedes its release.
+	switch {
+	case va.pre == vb.pre:
+		return 0
+	case va.pre == "":
+		return 1
+	case vb.pre == "":
+		return -1
+	}
+	return strings.Compare(va.pre, vb.pre)
+}
+
+// Valid returns whether the version is a semantic version such as '0.8.0'.
+func Valid(v string) bool {
+	_, ok := parseSemver(v)
+	return ok
+}
+
+type semver struct {
+	nums [3]int
+	pre  string
+}
+
+func parseSemver(v string) (*semver, bool) {
+	v = strings.TrimPrefix(strings.TrimSpace(v), "v")
+	if i := strings.IndexByte(v, '+'); i >= 0 {
+		v = v[:i] // build metadata
+	}
+	var s semver
+	if i := strings.IndexByte(v, '-'); i >= 0 {
+		v, s.pre = v[:i], v[i+1:]
+	}
+	parts := strings.Split(v, ".")
+	if len(parts) > 3 {
+		return nil, false
+	}
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 0 {
+			return nil, false
+		}
+		s.nums[i] = n
+	}
+	return &s, true
+}
+
+// FetchLatest returns the latest version published at the URL, such as
+// 'https://api.github.com/repos/yuuki/shawk/releases/latest'. The response
+// is the JSON of a release having tag_name or version, or the text of the
+// version.
+func FetchLatest(url string) (string, error) {
+	resp, err := (&http.Client{Timeout: latestTimeout}).Get(url)
+	if err != nil {
+		return "", xerrors.Errorf("GET %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxLatestBytes))
+	if err != nil {
+		return "", xerrors.Errorf("GET %s: %w", url, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", xerrors.Errorf("GET %s: unexpected status %s: %s", url, resp.Status, body)
+	}
+	var release struct {
+		TagName string `json:"tag_name"`
+		Version string `json:"version"`
+	}
+	latest := strings.TrimSpace(string(body))
+	if strings.HasPrefix(latest, "{") {
+		if err := json.Unmarshal(body, &release); err != nil {
+			return "", xerrors.Errorf("GET %s: could not decode response: %w", url, err)
+		}
+		latest = release.TagName
+		if latest == "" {
+			latest = release.Version
+		}
+	}
+	if !Valid(latest) {
+		return "", xerrors.Errorf("GET %s: the latest version must be a semantic version, but %q", url, latest)
+	}
+	return strings.TrimPrefix(latest, "v"), nil
+}
+
+// Rollout is the versions of the agents of the fleet, which tells the
+// progress of the rollout of the latest version.
+type Rollout struct {
+	// Latest is the version published as the latest, or the newest
+	// version of the agents if it is unknown.
+	Latest    string `json:"latest"`
+	Published bool   `json:"published"`
+	Agents    int    `json:"agents"`
+	// Outdated is the number of the agents older than Latest.
+	Outdated int `json:"outdated"`
+	// Versions are the versions of the agents from the newest.
+	Versions []*RolloutVersion `json:"versions"`
+}
+
+// RolloutVersion is a version of the agents.
+type RolloutVersion struct {
+	Version  string   `json:"version"`
+	Hosts    []string `json:"hosts"`
+	Outdated bool     `json:"outdated"`
+}
+
+// NewRollout returns the rollout of the versions of the agents by the
+// hosts. latest is the version published as the latest, or empty to take
+// the newest version of the agents as the latest.
+func NewRollout(agents map[string]string, latest string) *Rollout {
+	hosts := make(map[string][]string)
+	for host, v := range agents {
+		hosts[v] = append(hosts[v], host)
+	}
+	r := &Rollout{Latest: latest, Published: latest != "", Agents: len(agents), Versions: []*RolloutVersion{}}
+	for v, hs := range hosts {
+		sort.Strings(hs)
+		r.Versions = append(r.Versions, &RolloutVersion{Version: v, Hosts: hs})
+	}
+	sort.Slice(r.Versions, func(i, j int) bool {
+		a, b := r.Versions[i].Version, r.Versions[j].Version
+		if c := Compare(a, b); c != 0 {
+			return c > 0
+		}
+		return a < b
+	})
+	if r.Latest == "" && len(r.Versions) > 0 {
+		r.Latest = r.Versions[0].Version
+	}
+	for _, v := range r.Versions {
+		if Compare(v.Version, r.Latest) < 0 {
+			v.Outdated = true
+			r.Outdated += len(v.Hosts)
+		}
+	}
+	return r
+}
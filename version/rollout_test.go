@@ -0,0 +1,92 @@
+package version
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"0.7.1", "0.7.1", 0},
+		{"0.7.1", "v0.7.1", 0},
+		{"0.7.1", "0.8.0", -1},
+		{"0.10.0", "0.9.3", 1},
+		{"1.0", "0.99.99", 1},
+		{"0.8.0-rc1", "0.8.0", -1},
+		{"0.8.0-rc2", "0.8.0-rc1", 1},
+		{"0.8.0+build.5", "0.8.0", 0},
+		{"", "0.1.0", -1},
+		{"devel", "0.1.0", -1},
+	}
+	for _, tt := range tests {
+		if got := Compare(tt.a, tt.b); got != tt.want {
+			t.Errorf("Compare(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestNewRollout(t *testing.T) {
+	agents := map[string]string{
+		"web-1": "0.8.0",
+		"web-2": "0.8.0",
+		"db-1":  "0.7.1",
+		"old-1": "",
+	}
+	want := &Rollout{
+		Latest:   "0.8.0",
+		Agents:   4,
+		Outdated: 2,
+		Versions: []*RolloutVersion{
+			{Version: "0.8.0", Hosts: []string{"web-1", "web-2"}},
+			{Version: "0.7.1", Hosts: []string{"db-1"}, Outdated: true},
+			{Version: "", Hosts: []string{"old-1"}, Outdated: true},
+		},
+	}
+	if diff := cmp.Diff(want, NewRollout(agents, "")); diff != "" {
+		t.Errorf("NewRollout() mismatch (-want +got):\n%s", diff)
+	}
+
+	r := NewRollout(agents, "0.9.0")
+	if !r.Published || r.Latest != "0.9.0" || r.Outdated != 4 {
+		t.Errorf("all the agents should be outdated by the published 0.9.0, but %+v", r)
+	}
+}
+
+func TestFetchLatest(t *testing.T) {
+	bodies := map[string]string{
+		"/github": `{"tag_name":"v0.8.0","name":"v0.8.0"}`,
+		"/json":   `{"version":"0.8.1"}`,
+		"/text":   "0.8.2\n",
+		"/bad":    "<html>not found</html>",
+	}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, ok := bodies[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(body))
+	}))
+	defer ts.Close()
+
+	for path, want := range map[string]string{"/github": "0.8.0", "/json": "0.8.1", "/text": "0.8.2"} {
+		got, err := FetchLatest(ts.URL + path)
+		if err != nil {
+			t.Fatalf("FetchLatest(%s) should not return an error: %+v", path, err)
+		}
+		if got != want {
+			t.Errorf("FetchLatest(%s) = %q, want %q", path, got, want)
+		}
+	}
+	for _, path := range []string{"/bad", "/missing"} {
+		if _, err := FetchLatest(ts.URL + path); err == nil {
+			t.Errorf("FetchLatest(%s) should return an error", path)
+		}
+	}
+}
@@ -1,15 +1,22 @@
 package version
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
+	"path/filepath"
+	"runtime"
+	"runtime/debug"
+	"strings"
+	"time"
 )
 
 // name is application name.
 const name = "shawk"
 
-// version is application version.
-const version = "0.7.1"
+// version is application version, which is set by the ldflags of the
+// release build.
+var version = "0.7.1"
 
 // commit describes latest git commit hash.
 // This is automatically extracted by git describe --always.
@@ -18,9 +25,101 @@ var commit string
 // date describes build date.
 var date string
 
-// PrintVersion prints version.
-func PrintVersion(w io.Writer) {
-	fmt.Fprintf(w, "%s version %s, build %s, date %s \n", name, version, commit, date)
+// Info is the build metadata of the binary.
+type Info struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	Commit  string `json:"commit,omitempty"`
+	Date    string `json:"date,omitempty"`
+	// Module and ModuleVersion are the main module of the binary, whose
+	// version is '(devel)' unless it is built by 'go install' of a version.
+	Module        string `json:"module,omitempty"`
+	ModuleVersion string `json:"module_version,omitempty"`
+	GoVersion     string `json:"go_version"`
+	Platform      string `json:"platform"`
+}
+
+// Get returns the build metadata of the binary of the path, whose base name
+// is reported as the name of the application. The commit and the date are
+// derived from the version of the main module if the ldflags don't set
+// them.
+func Get(path string) *Info {
+	bi, ok := debug.ReadBuildInfo()
+	return get(path, bi, ok)
+}
+
+func get(path string, bi *debug.BuildInfo, ok bool) *Info {
+	info := &Info{
+		Name:      filepath.Base(path),
+		Version:   version,
+		Commit:    commit,
+		Date:      date,
+		GoVersion: runtime.Version(),
+		Platform:  runtime.GOOS + "/" + runtime.GOARCH,
+	}
+	if info.Name == "" || info.Name == "." || info.Name == string(filepath.Separator) {
+		info.Name = name
+	}
+	if !ok {
+		return info
+	}
+	info.Module, info.ModuleVersion = bi.Main.Path, bi.Main.Version
+
+	if pv, ok := parsePseudoVersion(bi.Main.Version); ok {
+		if info.Commit == "" {
+			info.Commit = pv.revision
+		}
+		if info.Date == "" {
+			info.Date = pv.time.Format(time.RFC3339)
+		}
+	}
+	return info
+}
+
+type pseudoVersion struct {
+	time     time.Time
+	revision string
+}
+
+// parsePseudoVersion parses the pseudo-version of a module such as
+// 'v0.7.2-0.20201220120000-abcdef123456', which 'go install' records for
+// an untagged commit.
+func parsePseudoVersion(v string) (*pseudoVersion, bool) {
+	v = strings.TrimSuffix(v, "+incompatible")
+	i := strings.LastIndex(v, "-")
+	if i < 0 || len(v)-i-1 != 12 {
+		return nil, false
+	}
+	revision := v[i+1:]
+	rest := v[:i]
+	j := strings.LastIndexAny(rest, "-.")
+	if j < 0 {
+		return nil, false
+	}
+	t, err := time.Parse("20060102150405", rest[j+1:])
+	if err != nil {
+		return nil, false
+	}
+	return &pseudoVersion{time: t, revision: revision}, true
+}
+
+// String returns the version line of the binary.
+func (i *Info) String() string {
+	return fmt.Sprintf("%s version %s, build %s, date %s, %s %s",
+		i.Name, i.Version, i.Commit, i.Date, i.GoVersion, i.Platform)
+}
+
+// PrintVersion prints version of the binary of the path.
+func PrintVersion(w io.Writer, path string) {
+	fmt.Fprintln(w, Get(path))
+}
+
+// PrintVersionJSON prints the build metadata of the binary of the path as
+// JSON.
+func PrintVersionJSON(w io.Writer, path string) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(Get(path))
 }
 
 // GetVersion returns version.
@@ -0,0 +1,80 @@
+package version
+
+import (
+	"runtime"
+	"runtime/debug"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestGet(t *testing.T) {
+	defer func(c, d string) { commit, date = c, d }(commit, date)
+	commit, date = "", ""
+
+	platform := runtime.GOOS + "/" + runtime.GOARCH
+	tests := []struct {
+		name string
+		path string
+		bi   *debug.BuildInfo
+		want *Info
+	}{
+		{
+			name: "no build info",
+			path: "/usr/bin/shawk-agent",
+			want: &Info{Name: "shawk-agent", Version: version, GoVersion: runtime.Version(), Platform: platform},
+		},
+		{
+			name: "devel",
+			path: "shawk",
+			bi:   &debug.BuildInfo{Main: debug.Module{Path: "github.com/yuuki/shawk", Version: "(devel)"}},
+			want: &Info{
+				Name: "shawk", Version: version,
+				Module: "github.com/yuuki/shawk", ModuleVersion: "(devel)",
+				GoVersion: runtime.Version(), Platform: platform,
+			},
+		},
+		{
+			name: "pseudo-version",
+			path: "",
+			bi:   &debug.BuildInfo{Main: debug.Module{Path: "github.com/yuuki/shawk", Version: "v0.7.2-0.20201220123456-abcdef123456"}},
+			want: &Info{
+				Name: "shawk", Version: version, Commit: "abcdef123456", Date: "2020-12-20T12:34:56Z",
+				Module: "github.com/yuuki/shawk", ModuleVersion: "v0.7.2-0.20201220123456-abcdef123456",
+				GoVersion: runtime.Version(), Platform: platform,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := get(tt.path, tt.bi, tt.bi != nil)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("get() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestParsePseudoVersion(t *testing.T) {
+	tests := []struct {
+		v    string
+		want string
+		ok   bool
+	}{
+		{"v0.0.0-20201220123456-abcdef123456", "abcdef123456", true},
+		{"v0.7.2-0.20201220123456-abcdef123456", "abcdef123456", true},
+		{"v0.8.0-rc.1.0.20201220123456-abcdef123456", "abcdef123456", true},
+		{"v0.7.1", "", false},
+		{"(devel)", "", false},
+	}
+	for _, tt := range tests {
+		pv, ok := parsePseudoVersion(tt.v)
+		if ok != tt.ok {
+			t.Errorf("parsePseudoVersion(%q) should be %v", tt.v, tt.ok)
+			continue
+		}
+		if ok && pv.revision != tt.want {
+			t.Errorf("the revision of %q should be %q, but %q", tt.v, tt.want, pv.revision)
+		}
+	}
+}
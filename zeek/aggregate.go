@@ -0,0 +1,141 @@
+package zeek
+
+import (
+	"sort"
+
+	"github.com/yuuki/shawk/probe"
+)
+
+// LabelService is the label of the servers of the protocol detected by
+// Zeek, such as http.
+const LabelService = "zeek.service"
+
+type flowKey struct {
+	orig string
+	resp string
+	port uint16
+}
+
+// Aggregator aggregates the established connections into the active flows
+// from the originators to the ports of the responders, as the agents on
+// the originators would aggregate their ephemeral ports. The flows have no
+// process, since a network tap doesn't see the processes.
+//
+// The midstream connections, whose originators are unknown to be the
+// clients, are aggregated at Flush by the servers inferred from all the
+// connections added, which are labeled with probe.LabelDirectionConfidence.
+type Aggregator struct {
+	flows     map[flowKey]*probe.HostFlow
+	infer     *probe.DirectionInferrer
+	midstream []*Conn
+}
+
+// NewAggregator returns an Aggregator.
+func NewAggregator() *Aggregator {
+	return &Aggregator{
+		flows: make(map[flowKey]*probe.HostFlow),
+		infer: probe.NewDirectionInferrer(),
+	}
+}
+
+// Add adds the connection, and returns false if it is neither established
+// nor midstream.
+func (a *Aggregator) Add(c *Conn) bool {
+	switch {
+	case c.Established():
+		a.infer.Observe(origOf(c), respOf(c))
+		a.aggregate(c.OrigHost, c.RespHost, c.RespPort, c.Service)
+	case c.Midstream():
+		a.infer.Observe(origOf(c), respOf(c))
+		a.midstream = append(a.midstream, c)
+	default:
+		return false
+	}
+	return true
+}
+
+func origOf(c *Conn) probe.Endpoint {
+	return probe.Endpoint{Addr: c.OrigHost, Port: c.OrigPort}
+}
+
+func respOf(c *Conn) probe.Endpoint {
+	return probe.Endpoint{Addr: c.RespHost, Port: c.RespPort}
+}
+
+func (a *Aggregator) aggregate(client, server string, port uint16, service string) *probe.HostFlow {
+	key := flowKey{orig: client, resp: server, port: port}
+	f, ok := a.flows[key]
+	if !ok {
+		f = &probe.HostFlow{
+			Direction: probe.FlowActive,
+			Local:     &probe.AddrPort{Addr: client, Aggregated: true},
+			Peer:      &probe.AddrPort{Addr: server, Port: port},
+		}
+		a.flows[key] = f
+	}
+	f.Connections++
+	if service != "" {
+		f.Peer.SetLabel(LabelService, service)
+	}
+	return f
+}
+
+// confidenceRanks are the ranks of the confidences, higher as it is.
+var confidenceRanks = map[string]int{
+	probe.ConfidenceLow:    1,
+	probe.ConfidenceMedium: 2,
+	probe.ConfidenceHigh:   3,
+}
+
+// aggregateMidstream aggregates the midstream connections by the servers
+// inferred. The flow is labeled with the lowest confidence of its
+// connections, or with none if it has the established ones.
+func (a *Aggregator) aggregateMidstream() {
+	for _, c := range a.midstream {
+		client, server := origOf(c), respOf(c)
+		origIsServer, confidence := a.infer.Infer(client, server)
+		if origIsServer {
+			client, server = server, client
+		}
+		f, ok := a.flows[flowKey{orig: client.Addr, resp: server.Addr, port: server.Port}]
+		established := ok && f.Peer.Labels[probe.LabelDirectionConfidence] == ""
+		f = a.aggregate(client.Addr, server.Addr, server.Port, c.Service)
+		if established {
+			continue
+		}
+		if prev, ok := f.Peer.Labels[probe.LabelDirectionConfidence]; ok && confidenceRanks[prev] < confidenceRanks[confidence] {
+			continue
+		}
+		f.Peer.SetLabel(probe.LabelDirectionConfidence, confidence)
+	}
+}
+
+// Len returns the number of the flows, except the midstream connections
+// aggregated at Flush.
+func (a *Aggregator) Len() int {
+	return len(a.flows)
+}
+
+// Flush returns the flows sorted by the originators, and starts aggregating
+// the next ones.
+func (a *Aggregator) Flush() []*probe.HostFlow {
+	a.aggregateMidstream()
+	flows := make([]*probe.HostFlow, 0, len(a.flows))
+	for _, f := range a.flows {
+		flows = append(flows, f)
+	}
+	sort.Slice(flows, func(i, j int) bool {
+		x, y := flows[i], flows[j]
+		if x.Local.Addr != y.Local.Addr {
+			return x.Local.Addr < y.Local.Addr
+		}
+		if x.Peer.Addr != y.Peer.Addr {
+			return x.Peer.Addr < y.Peer.Addr
+		}
+		return x.Peer.Port < y.Peer.Port
+	})
+	a.flows = make(map[flowKey]*probe.HostFlow)
+	a.infer = probe.NewDirectionInferrer()
+	a.midstream = nil
+	return flows
+}
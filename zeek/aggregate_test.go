@@ -0,0 +1,95 @@
+package zeek
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/yuuki/shawk/probe"
+)
+
+func TestAggregator(t *testing.T) {
+	a := NewAggregator()
+	conns := []*Conn{
+		{OrigHost: "10.0.0.1", RespHost: "10.0.0.3", RespPort: 5432, State: "S1"},
+		{OrigHost: "10.0.0.1", RespHost: "10.0.0.2", RespPort: 80, Service: "http", State: "SF"},
+		{OrigHost: "10.0.0.1", RespHost: "10.0.0.2", RespPort: 80, State: "RSTO"},
+		{OrigHost: "10.0.0.9", RespHost: "10.0.0.2", RespPort: 22, State: "S0"},
+	}
+	added := 0
+	for _, c := range conns {
+		if a.Add(c) {
+			added++
+		}
+	}
+	if added != 3 {
+		t.Errorf("Add() should add the 3 established connections, but %d", added)
+	}
+	if a.Len() != 2 {
+		t.Errorf("Len() = %d, want 2", a.Len())
+	}
+
+	want := []*probe.HostFlow{
+		{
+			Direction:   probe.FlowActive,
+			Local:       &probe.AddrPort{Addr: "10.0.0.1", Aggregated: true},
+			Peer:        &probe.AddrPort{Addr: "10.0.0.2", Port: 80, Labels: map[string]string{LabelService: "http"}},
+			Connections: 2,
+		},
+		{
+			Direction:   probe.FlowActive,
+			Local:       &probe.AddrPort{Addr: "10.0.0.1", Aggregated: true},
+			Peer:        &probe.AddrPort{Addr: "10.0.0.3", Port: 5432},
+			Connections: 1,
+		},
+	}
+	if diff := cmp.Diff(want, a.Flush()); diff != "" {
+		t.Errorf("Flush() mismatch (-want +got):\n%s", diff)
+	}
+	if a.Len() != 0 {
+		t.Errorf("Flush() should start the next flows, but %d", a.Len())
+	}
+}
+
+func TestAggregator_midstream(t *testing.T) {
+	a := NewAggregator()
+	conns := []*Conn{
+		// 10.0.0.2:5432 is connected from 2 ports, and seen first from it.
+		{OrigHost: "10.0.0.2", OrigPort: 5432, RespHost: "10.0.0.1", RespPort: 40001, State: "OTH"},
+		{OrigHost: "10.0.0.1", OrigPort: 40002, RespHost: "10.0.0.2", RespPort: 5432, State: "OTH"},
+		// The other connection to it completed the handshake.
+		{OrigHost: "10.0.0.3", OrigPort: 40003, RespHost: "10.0.0.4", RespPort: 80, State: "SF"},
+		{OrigHost: "10.0.0.4", OrigPort: 80, RespHost: "10.0.0.3", RespPort: 40004, State: "OTH"},
+		// Both the ports are ephemeral, and the lower one is the server.
+		{OrigHost: "10.0.0.5", OrigPort: 50001, RespHost: "10.0.0.6", RespPort: 40005, State: "OTH"},
+	}
+	for _, c := range conns {
+		if !a.Add(c) {
+			t.Errorf("Add() should add the midstream connection %+v", c)
+		}
+	}
+
+	want := []*probe.HostFlow{
+		{
+			Direction:   probe.FlowActive,
+			Local:       &probe.AddrPort{Addr: "10.0.0.1", Aggregated: true},
+			Peer:        &probe.AddrPort{Addr: "10.0.0.2", Port: 5432, Labels: map[string]string{probe.LabelDirectionConfidence: probe.ConfidenceHigh}},
+			Connections: 2,
+		},
+		{
+			Direction:   probe.FlowActive,
+			Local:       &probe.AddrPort{Addr: "10.0.0.3", Aggregated: true},
+			Peer:        &probe.AddrPort{Addr: "10.0.0.4", Port: 80},
+			Connections: 2,
+		},
+		{
+			Direction:   probe.FlowActive,
+			Local:       &probe.AddrPort{Addr: "10.0.0.5", Aggregated: true},
+			Peer:        &probe.AddrPort{Addr: "10.0.0.6", Port: 40005, Labels: map[string]string{probe.LabelDirectionConfidence: probe.ConfidenceLow}},
+			Connections: 1,
+		},
+	}
+	if diff := cmp.Diff(want, a.Flush()); diff != "" {
+		t.Errorf("Flush() mismatch (-want +got):\n%s", diff)
+	}
+}
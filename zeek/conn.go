@@ -0,0 +1,225 @@
+// Package zeek reads conn.log of Zeek and aggregates its TCP connections
+// into the flows of the clients, so that the connections seen on a network
+// tap supplement the agents with the hosts running no agent.
+package zeek
+
+import (
+	"encoding/json"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/xerrors"
+)
+
+// Conn is a TCP connection of conn.log.
+type Conn struct {
+	Time     time.Time
+	OrigHost string
+	// OrigPort is the port of the originator, or 0 if conn.log lacks it.
+	OrigPort uint16
+	RespHost string
+	RespPort uint16
+	// Service is the protocol detected by Zeek such as http, or empty.
+	Service string
+	// State is conn_state such as SF.
+	State string
+}
+
+// establishedStates are the states of the connections whose handshakes
+// completed. The others, such as S0 and REJ, are the attempts of the scans
+// and of the servers down, which are not the dependencies.
+var establishedStates = map[string]bool{
+	"S1": true, "S2": true, "S3": true, "SF": true, "RSTO": true, "RSTR": true,
+}
+
+// Established returns whether the handshake of the connection completed.
+func (c *Conn) Established() bool {
+	return establishedStates[c.State]
+}
+
+// Midstream returns whether the connection was seen without its handshake,
+// such as the one established before Zeek started, whose originator is not
+// necessarily the client.
+func (c *Conn) Midstream() bool {
+	return c.State == "OTH"
+}
+
+// The fields of conn.log read by the Parser.
+const (
+	fieldTS       = "ts"
+	fieldOrigHost = "id.orig_h"
+	fieldOrigPort = "id.orig_p"
+	fieldRespHost = "id.resp_h"
+	fieldRespPort = "id.resp_p"
+	fieldProto    = "proto"
+	fieldService  = "service"
+	fieldState    = "conn_state"
+)
+
+var requiredFields = []string{fieldTS, fieldOrigHost, fieldRespHost, fieldRespPort, fieldProto}
+
+// Parser parses the lines of conn.log in the TSV format with the headers,
+// or in the JSON format of LogAscii::use_json. The headers of the TSV
+// format may change in the middle, such as at the rotation of the log.
+type Parser struct {
+	separator string
+	unset     string
+	empty     string
+	fields    map[string]int
+}
+
+// NewParser returns a Parser.
+func NewParser() *Parser {
+	return &Parser{separator: "\t", unset: "-", empty: "(empty)"}
+}
+
+// Parse returns the TCP connection of the line, or nil for the headers,
+// the empty lines and the connections of the other protocols.
+func (p *Parser) Parse(line string) (*Conn, error) {
+	line = strings.TrimRight(line, "\r\n")
+	switch {
+	case line == "":
+		return nil, nil
+	case strings.HasPrefix(line, "#"):
+		return nil, p.parseHeader(line)
+	case strings.HasPrefix(line, "{"):
+		return parseJSON(line)
+	}
+	if p.fields == nil {
+		return nil, xerrors.New("the line precedes the #fields header")
+	}
+	values := strings.Split(line, p.separator)
+	if len(values) != len(p.fields) {
+		return nil, xerrors.Errorf("the line has %d fields, but the header %d", len(values), len(p.fields))
+	}
+	get := func(field string) string {
+		i, ok := p.fields[field]
+		if !ok {
+			return ""
+		}
+		v := values[i]
+		if v == p.unset || v == p.empty {
+			return ""
+		}
+		return v
+	}
+	if get(fieldProto) != "tcp" {
+		return nil, nil
+	}
+	ts, err := strconv.ParseFloat(get(fieldTS), 64)
+	if err != nil {
+		return nil, xerrors.Errorf("invalid ts %q: %w", get(fieldTS), err)
+	}
+	port, err := strconv.ParseUint(get(fieldRespPort), 10, 16)
+	if err != nil {
+		return nil, xerrors.Errorf("invalid id.resp_p %q: %w", get(fieldRespPort), err)
+	}
+	var origPort uint64
+	if v := get(fieldOrigPort); v != "" {
+		origPort, err = strconv.ParseUint(v, 10, 16)
+		if err != nil {
+			return nil, xerrors.Errorf("invalid id.orig_p %q: %w", v, err)
+		}
+	}
+	return &Conn{
+		Time:     epochTime(ts),
+		OrigHost: get(fieldOrigHost),
+		OrigPort: uint16(origPort),
+		RespHost: get(fieldRespHost),
+		RespPort: uint16(port),
+		Service:  get(fieldService),
+		State:    get(fieldState),
+	}, nil
+}
+
+// parseHeader parses the header line such as #fields. The other headers
+// such as #types and #close are ignored.
+func (p *Parser) parseHeader(line string) error {
+	if strings.HasPrefix(line, "#separator ") {
+		sep := strings.TrimPrefix(line, "#separator ")
+		if strings.HasPrefix(sep, `\x`) {
+			b, err := strconv.ParseUint(sep[2:], 16, 8)
+			if err != nil {
+				return xerrors.Errorf("invalid #separator %q: %w", sep, err)
+			}
+			sep = string([]byte{byte(b)})
+		}
+		p.separator = sep
+		return nil
+	}
+	name, value, ok := cut(line[1:], p.separator)
+	if !ok {
+		return nil
+	}
+	switch name {
+	case "unset_field":
+		p.unset = value
+	case "empty_field":
+		p.empty = value
+	case "fields":
+		fields := make(map[string]int)
+		for i, f := range strings.Split(value, p.separator) {
+			fields[f] = i
+		}
+		for _, f := range requiredFields {
+			if _, ok := fields[f]; !ok {
+				return xerrors.Errorf("the #fields header lacks %s: it must be of conn.log", f)
+			}
+		}
+		p.fields = fields
+	}
+	return nil
+}
+
+func cut(s, sep string) (before, after string, found bool) {
+	if i := strings.Index(s, sep); i >= 0 {
+		return s[:i], s[i+len(sep):], true
+	}
+	return s, "", false
+}
+
+// jsonConn is a connection of conn.log in the JSON format. ts is the epoch
+// time, or the time in ISO 8601 with LogAscii::json_timestamps.
+type jsonConn struct {
+	TS       json.RawMessage `json:"ts"`
+	OrigHost string          `json:"id.orig_h"`
+	OrigPort uint16          `json:"id.orig_p"`
+	RespHost string          `json:"id.resp_h"`
+	RespPort uint16          `json:"id.resp_p"`
+	Proto    string          `json:"proto"`
+	Service  string          `json:"service"`
+	State    string          `json:"conn_state"`
+}
+
+func parseJSON(line string) (*Conn, error) {
+	var jc jsonConn
+	if err := json.Unmarshal([]byte(line), &jc); err != nil {
+		return nil, xerrors.Errorf("invalid line of JSON: %w", err)
+	}
+	if jc.Proto != "tcp" {
+		return nil, nil
+	}
+	var t time.Time
+	var epoch float64
+	if err := json.Unmarshal(jc.TS, &epoch); err == nil {
+		t = epochTime(epoch)
+	} else if err := json.Unmarshal(jc.TS, &t); err != nil {
+		return nil, xerrors.Errorf("invalid ts %s: %w", jc.TS, err)
+	}
+	return &Conn{
+		Time:     t,
+		OrigHost: jc.OrigHost,
+		OrigPort: jc.OrigPort,
+		RespHost: jc.RespHost,
+		RespPort: jc.RespPort,
+		Service:  jc.Service,
+		State:    jc.State,
+	}, nil
+}
+
+func epochTime(ts float64) time.Time {
+	sec, frac := math.Modf(ts)
+	return time.Unix(int64(sec), int64(math.Round(frac*1e6))*int64(time.Microsecond))
+}
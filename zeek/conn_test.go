@@ -0,0 +1,91 @@
+package zeek
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParser_Parse(t *testing.T) {
+	var conns []*Conn
+	p := NewParser()
+	err := ReadFile("testdata/conn.log", func(line string) error {
+		c, err := p.Parse(line)
+		if c != nil {
+			conns = append(conns, c)
+		}
+		return err
+	})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	want := []*Conn{
+		{Time: time.Unix(1608465600, 123456000), OrigHost: "10.0.0.1", OrigPort: 50001, RespHost: "10.0.0.2", RespPort: 80, Service: "http", State: "SF"},
+		{Time: time.Unix(1608465601, 0), OrigHost: "10.0.0.1", OrigPort: 50002, RespHost: "10.0.0.2", RespPort: 80, State: "SF"},
+		{Time: time.Unix(1608465602, 0), OrigHost: "10.0.0.1", OrigPort: 50003, RespHost: "10.0.0.3", RespPort: 5432, State: "S1"},
+		{Time: time.Unix(1608465603, 0), OrigHost: "10.0.0.9", OrigPort: 50004, RespHost: "10.0.0.2", RespPort: 22, State: "S0"},
+	}
+	if diff := cmp.Diff(want, conns); diff != "" {
+		t.Errorf("Parse() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestParser_Parse_json(t *testing.T) {
+	tests := []struct {
+		line string
+		want *Conn
+	}{
+		{
+			line: `{"ts":1608465600.5,"uid":"Ck1","id.orig_h":"10.0.0.1","id.orig_p":50001,"id.resp_h":"10.0.0.2","id.resp_p":443,"proto":"tcp","service":"ssl","conn_state":"SF"}`,
+			want: &Conn{Time: time.Unix(1608465600, 500000000), OrigHost: "10.0.0.1", OrigPort: 50001, RespHost: "10.0.0.2", RespPort: 443, Service: "ssl", State: "SF"},
+		},
+		{
+			line: `{"ts":"2020-12-20T12:00:00.000000Z","id.orig_h":"10.0.0.1","id.resp_h":"10.0.0.2","id.resp_p":80,"proto":"tcp","conn_state":"RSTO"}`,
+			want: &Conn{Time: time.Date(2020, 12, 20, 12, 0, 0, 0, time.UTC), OrigHost: "10.0.0.1", RespHost: "10.0.0.2", RespPort: 80, State: "RSTO"},
+		},
+		{
+			line: `{"ts":1608465600.5,"id.orig_h":"10.0.0.1","id.resp_h":"10.0.0.53","id.resp_p":53,"proto":"udp","conn_state":"SF"}`,
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		got, err := NewParser().Parse(tt.line)
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		if diff := cmp.Diff(tt.want, got, cmp.Comparer(func(x, y time.Time) bool { return x.Equal(y) })); diff != "" {
+			t.Errorf("Parse(%s) mismatch (-want +got):\n%s", tt.line, diff)
+		}
+	}
+}
+
+func TestParser_Parse_errors(t *testing.T) {
+	tests := []struct {
+		desc    string
+		headers []string
+		line    string
+	}{
+		{desc: "no header", line: "1608465600.0\t10.0.0.1\t10.0.0.2\t80\ttcp"},
+		{desc: "not conn.log", headers: []string{"#fields\tts\tuid\tquery"}},
+		{desc: "short line", headers: []string{"#fields\tts\tid.orig_h\tid.resp_h\tid.resp_p\tproto"}, line: "1608465600.0\t10.0.0.1"},
+		{desc: "invalid port", headers: []string{"#fields\tts\tid.orig_h\tid.resp_h\tid.resp_p\tproto"}, line: "1608465600.0\t10.0.0.1\t10.0.0.2\thttp\ttcp"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			p := NewParser()
+			var err error
+			for _, h := range tt.headers {
+				if _, err = p.Parse(h); err != nil {
+					break
+				}
+			}
+			if err == nil {
+				_, err = p.Parse(tt.line)
+			}
+			if err == nil {
+				t.Error("Parse() should return an error")
+			}
+		})
+	}
+}
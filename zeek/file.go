@@ -0,0 +1,123 @@
+package zeek
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"golang.org/x/xerrors"
+)
+
+// ReadFile calls fn with each line of the file, which is decompressed if
+// it ends with .gz as the archived logs of Zeek.
+func ReadFile(path string, fn func(line string) error) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return xerrors.Errorf("could not open %s: %w", path, err)
+	}
+	defer f.Close()
+
+	var r io.Reader = f
+	if strings.HasSuffix(path, ".gz") {
+		gz, err := gzip.NewReader(f)
+		if err != nil {
+			return xerrors.Errorf("could not decompress %s: %w", path, err)
+		}
+		defer gz.Close()
+		r = gz
+	}
+	br := bufio.NewReader(r)
+	for {
+		line, err := br.ReadString('\n')
+		if line != "" {
+			if ferr := fn(line); ferr != nil {
+				return ferr
+			}
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return xerrors.Errorf("could not read %s: %w", path, err)
+		}
+	}
+}
+
+// Tail calls fn with each line of the file from its beginning, and with
+// each line appended to it until ctx is done, polling it every poll. The
+// file replaced by the rotation of Zeek is read from the beginning, as
+// `tail -F` does, after the rest of the old one.
+func Tail(ctx context.Context, path string, poll time.Duration, fn func(line string) error) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return xerrors.Errorf("could not open %s: %w", path, err)
+	}
+	defer func() { f.Close() }()
+
+	r := bufio.NewReader(f)
+	var partial string
+	for {
+		line, err := r.ReadString('\n')
+		if err == nil {
+			if err := fn(partial + line); err != nil {
+				return err
+			}
+			partial = ""
+			continue
+		}
+		if err != io.EOF {
+			return xerrors.Errorf("could not read %s: %w", path, err)
+		}
+		// A line without the newline is being written.
+		partial += line
+
+		reopen, err := replaced(f, path)
+		if err != nil {
+			return err
+		}
+		if reopen {
+			nf, err := os.Open(path)
+			if err == nil {
+				f.Close()
+				f = nf
+				r.Reset(f)
+				partial = ""
+				continue
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(poll):
+		}
+	}
+}
+
+// replaced returns whether the path is replaced by another file, or is
+// truncated, than the file f read to its end.
+func replaced(f *os.File, path string) (bool, error) {
+	st, err := os.Stat(path)
+	if os.IsNotExist(err) {
+		// The new file is not created yet.
+		return false, nil
+	}
+	if err != nil {
+		return false, xerrors.Errorf("could not stat %s: %w", path, err)
+	}
+	cur, err := f.Stat()
+	if err != nil {
+		return false, xerrors.Errorf("could not stat %s: %w", path, err)
+	}
+	if !os.SameFile(st, cur) {
+		return true, nil
+	}
+	offset, err := f.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return false, xerrors.Errorf("could not seek %s: %w", path, err)
+	}
+	return st.Size() < offset, nil
+}
@@ -0,0 +1,105 @@
+package zeek
+
+import (
+	"compress/gzip"
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestReadFile_gzip(t *testing.T) {
+	dir, err := ioutil.TempDir("", "zeek")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "conn.00:00:00-01:00:00.log.gz")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gz := gzip.NewWriter(f)
+	gz.Write([]byte("#fields\tts\nfirst\nlast"))
+	gz.Close()
+	f.Close()
+
+	var lines []string
+	if err := ReadFile(path, func(line string) error {
+		lines = append(lines, line)
+		return nil
+	}); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if diff := cmp.Diff([]string{"#fields\tts\n", "first\n", "last"}, lines); diff != "" {
+		t.Errorf("ReadFile() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestTail(t *testing.T) {
+	dir, err := ioutil.TempDir("", "zeek")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "conn.log")
+	if err := ioutil.WriteFile(path, []byte("a\nb"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	lines := make(chan string, 10)
+	done := make(chan error, 1)
+	go func() {
+		done <- Tail(ctx, path, 10*time.Millisecond, func(line string) error {
+			lines <- line
+			return nil
+		})
+	}()
+	next := func() string {
+		select {
+		case l := <-lines:
+			return l
+		case <-ctx.Done():
+			t.Fatal("Tail() should call fn with the next line")
+			return ""
+		}
+	}
+
+	if got := next(); got != "a\n" {
+		t.Errorf("the first line = %q, want %q", got, "a\n")
+	}
+	// The rest of the line being written.
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.WriteString("c\n")
+	f.Close()
+	if got := next(); got != "bc\n" {
+		t.Errorf("the appended line = %q, want %q", got, "bc\n")
+	}
+
+	// Zeek rotates the log by renaming it and creating a new one.
+	if err := os.Rename(path, path+".1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(path, []byte("d\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if got := next(); got != "d\n" {
+		t.Errorf("the line of the rotated log = %q, want %q", got, "d\n")
+	}
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Errorf("Tail() should return nil when ctx is done, but %+v", err)
+	}
+}